@@ -0,0 +1,29 @@
+BEGIN;
+
+DROP INDEX IF EXISTS "common_auditlog_organisation_id_timestamp_idx";
+
+ALTER TABLE
+    IF EXISTS "common_auditlog"
+    DROP CONSTRAINT IF EXISTS "common_auditlog_program_id_fkey";
+
+ALTER TABLE
+    IF EXISTS "common_auditlog"
+    DROP CONSTRAINT IF EXISTS "common_auditlog_actor_id_fkey";
+
+ALTER TABLE
+    IF EXISTS "common_auditlog"
+    DROP COLUMN IF EXISTS "program_id";
+
+ALTER TABLE
+    IF EXISTS "common_auditlog"
+    DROP COLUMN IF EXISTS "target_type";
+
+ALTER TABLE
+    IF EXISTS "common_auditlog"
+    DROP COLUMN IF EXISTS "target_id";
+
+ALTER TABLE
+    IF EXISTS "common_auditlog"
+    DROP COLUMN IF EXISTS "actor_id";
+
+COMMIT;
@@ -0,0 +1,31 @@
+BEGIN;
+
+ALTER TABLE
+    IF EXISTS "common_auditlog"
+    ADD COLUMN IF NOT EXISTS "actor_id" uuid;
+
+ALTER TABLE
+    IF EXISTS "common_auditlog"
+    ADD COLUMN IF NOT EXISTS "target_id" uuid;
+
+ALTER TABLE
+    IF EXISTS "common_auditlog"
+    ADD COLUMN IF NOT EXISTS "target_type" text;
+
+ALTER TABLE
+    IF EXISTS "common_auditlog"
+    ADD COLUMN IF NOT EXISTS "program_id" uuid;
+
+ALTER TABLE
+    IF EXISTS "common_auditlog"
+    ADD
+        CONSTRAINT "common_auditlog_actor_id_fkey" FOREIGN KEY ("actor_id") REFERENCES "users_user" ("id");
+
+ALTER TABLE
+    IF EXISTS "common_auditlog"
+    ADD
+        CONSTRAINT "common_auditlog_program_id_fkey" FOREIGN KEY ("program_id") REFERENCES "common_program" ("id");
+
+CREATE INDEX IF NOT EXISTS "common_auditlog_organisation_id_timestamp_idx" ON "common_auditlog" ("organisation_id", "timestamp" DESC);
+
+COMMIT;
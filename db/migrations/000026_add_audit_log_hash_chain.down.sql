@@ -0,0 +1,17 @@
+BEGIN;
+
+DROP INDEX IF EXISTS "common_auditlog_organisation_id_sequence_key";
+
+ALTER TABLE
+    IF EXISTS "common_auditlog"
+    DROP COLUMN IF EXISTS "hash";
+
+ALTER TABLE
+    IF EXISTS "common_auditlog"
+    DROP COLUMN IF EXISTS "previous_hash";
+
+ALTER TABLE
+    IF EXISTS "common_auditlog"
+    DROP COLUMN IF EXISTS "sequence";
+
+COMMIT;
@@ -0,0 +1,17 @@
+BEGIN;
+
+ALTER TABLE
+    IF EXISTS "common_auditlog"
+    ADD COLUMN IF NOT EXISTS "sequence" bigint;
+
+ALTER TABLE
+    IF EXISTS "common_auditlog"
+    ADD COLUMN IF NOT EXISTS "previous_hash" text;
+
+ALTER TABLE
+    IF EXISTS "common_auditlog"
+    ADD COLUMN IF NOT EXISTS "hash" text;
+
+CREATE UNIQUE INDEX IF NOT EXISTS "common_auditlog_organisation_id_sequence_key" ON "common_auditlog" ("organisation_id", "sequence");
+
+COMMIT;
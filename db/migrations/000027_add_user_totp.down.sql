@@ -0,0 +1,35 @@
+BEGIN;
+
+ALTER TABLE
+    IF EXISTS "common_organisation"
+    DROP COLUMN IF EXISTS "enforce_staff_totp";
+
+ALTER TABLE
+    IF EXISTS "users_userrecoverycode"
+    DROP CONSTRAINT IF EXISTS "users_userrecoverycode_created_by_fkey";
+
+ALTER TABLE
+    IF EXISTS "users_userrecoverycode"
+    DROP CONSTRAINT IF EXISTS "users_userrecoverycode_updated_by_fkey";
+
+ALTER TABLE
+    IF EXISTS "users_userrecoverycode"
+    DROP CONSTRAINT IF EXISTS "users_userrecoverycode_user_id_fkey";
+
+ALTER TABLE
+    IF EXISTS "users_usertotp"
+    DROP CONSTRAINT IF EXISTS "users_usertotp_created_by_fkey";
+
+ALTER TABLE
+    IF EXISTS "users_usertotp"
+    DROP CONSTRAINT IF EXISTS "users_usertotp_updated_by_fkey";
+
+ALTER TABLE
+    IF EXISTS "users_usertotp"
+    DROP CONSTRAINT IF EXISTS "users_usertotp_user_id_fkey";
+
+DROP TABLE IF EXISTS "users_userrecoverycode";
+
+DROP TABLE IF EXISTS "users_usertotp";
+
+COMMIT;
@@ -0,0 +1,67 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS "users_usertotp" (
+  "id" uuid PRIMARY KEY NOT NULL,
+  "active" boolean NOT NULL,
+  "created" timestamp NOT NULL,
+  "created_by" uuid,
+  "updated" timestamp NOT NULL,
+  "updated_by" uuid,
+  "deleted_at" timestamp,
+  "user_id" uuid NOT NULL UNIQUE,
+  "secret" text NOT NULL,
+  "confirmed" boolean NOT NULL DEFAULT false,
+  "confirmed_at" timestamp,
+  "failed_attempts" integer NOT NULL DEFAULT 0,
+  "last_used_step" bigint
+);
+
+CREATE TABLE IF NOT EXISTS "users_userrecoverycode" (
+  "id" uuid PRIMARY KEY NOT NULL,
+  "active" boolean NOT NULL,
+  "created" timestamp NOT NULL,
+  "created_by" uuid,
+  "updated" timestamp NOT NULL,
+  "updated_by" uuid,
+  "deleted_at" timestamp,
+  "user_id" uuid NOT NULL,
+  "hashed_code" text NOT NULL,
+  "salt" text NOT NULL,
+  "used_at" timestamp
+);
+
+ALTER TABLE
+    IF EXISTS "users_usertotp"
+    ADD
+        CONSTRAINT "users_usertotp_created_by_fkey" FOREIGN KEY ("created_by") REFERENCES "users_user" ("id");
+
+ALTER TABLE
+    IF EXISTS "users_usertotp"
+    ADD
+        CONSTRAINT "users_usertotp_updated_by_fkey" FOREIGN KEY ("updated_by") REFERENCES "users_user" ("id");
+
+ALTER TABLE
+    IF EXISTS "users_usertotp"
+    ADD
+        CONSTRAINT "users_usertotp_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "users_user" ("id");
+
+ALTER TABLE
+    IF EXISTS "users_userrecoverycode"
+    ADD
+        CONSTRAINT "users_userrecoverycode_created_by_fkey" FOREIGN KEY ("created_by") REFERENCES "users_user" ("id");
+
+ALTER TABLE
+    IF EXISTS "users_userrecoverycode"
+    ADD
+        CONSTRAINT "users_userrecoverycode_updated_by_fkey" FOREIGN KEY ("updated_by") REFERENCES "users_user" ("id");
+
+ALTER TABLE
+    IF EXISTS "users_userrecoverycode"
+    ADD
+        CONSTRAINT "users_userrecoverycode_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "users_user" ("id");
+
+ALTER TABLE
+    IF EXISTS "common_organisation"
+    ADD COLUMN IF NOT EXISTS "enforce_staff_totp" boolean NOT NULL DEFAULT false;
+
+COMMIT;
@@ -0,0 +1,30 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS "common_ratelimitevent" (
+  "id" uuid PRIMARY KEY NOT NULL,
+  "active" boolean NOT NULL,
+  "created" timestamp NOT NULL,
+  "created_by" uuid,
+  "updated" timestamp NOT NULL,
+  "updated_by" uuid,
+  "deleted_at" timestamp,
+  "action" text NOT NULL,
+  "key_type" text NOT NULL,
+  "key_value" text NOT NULL,
+  "allowed" boolean NOT NULL,
+  "timestamp" timestamp NOT NULL
+);
+
+ALTER TABLE
+    IF EXISTS "common_ratelimitevent"
+    ADD
+        CONSTRAINT "common_ratelimitevent_created_by_fkey" FOREIGN KEY ("created_by") REFERENCES "users_user" ("id");
+
+ALTER TABLE
+    IF EXISTS "common_ratelimitevent"
+    ADD
+        CONSTRAINT "common_ratelimitevent_updated_by_fkey" FOREIGN KEY ("updated_by") REFERENCES "users_user" ("id");
+
+CREATE INDEX IF NOT EXISTS "common_ratelimitevent_action_key_timestamp_idx" ON "common_ratelimitevent" ("action", "key_type", "key_value", "timestamp" DESC);
+
+COMMIT;
@@ -0,0 +1,36 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS "users_userotpdelivery" (
+  "id" uuid PRIMARY KEY NOT NULL,
+  "created" timestamp NOT NULL,
+  "created_by" uuid,
+  "updated" timestamp NOT NULL,
+  "updated_by" uuid,
+  "deleted_at" timestamp,
+  "otp_id" integer NOT NULL,
+  "provider" varchar(32) NOT NULL,
+  "provider_message_id" text,
+  "status" varchar(32) NOT NULL,
+  "error" text,
+  "attempted_at" timestamp NOT NULL,
+  "status_updated_at" timestamp
+);
+
+ALTER TABLE
+    IF EXISTS "users_userotpdelivery"
+    ADD
+        CONSTRAINT "users_userotpdelivery_otp_id_fkey" FOREIGN KEY ("otp_id") REFERENCES "users_userotp" ("id") ON DELETE CASCADE;
+
+ALTER TABLE
+    IF EXISTS "users_userotpdelivery"
+    ADD
+        CONSTRAINT "users_userotpdelivery_created_by_fkey" FOREIGN KEY ("created_by") REFERENCES "users_user" ("id");
+
+ALTER TABLE
+    IF EXISTS "users_userotpdelivery"
+    ADD
+        CONSTRAINT "users_userotpdelivery_updated_by_fkey" FOREIGN KEY ("updated_by") REFERENCES "users_user" ("id");
+
+CREATE INDEX IF NOT EXISTS "users_userotpdelivery_provider_message_idx" ON "users_userotpdelivery" ("provider", "provider_message_id");
+
+COMMIT;
@@ -0,0 +1,33 @@
+BEGIN;
+
+DROP INDEX IF EXISTS "oauth_session_user_id_idx";
+
+ALTER TABLE
+    IF EXISTS "oauth_session"
+    DROP COLUMN IF EXISTS "revoked_at";
+
+ALTER TABLE
+    IF EXISTS "oauth_session"
+    DROP COLUMN IF EXISTS "last_seen_at";
+
+ALTER TABLE
+    IF EXISTS "oauth_session"
+    DROP COLUMN IF EXISTS "push_token";
+
+ALTER TABLE
+    IF EXISTS "oauth_session"
+    DROP COLUMN IF EXISTS "ip_address";
+
+ALTER TABLE
+    IF EXISTS "oauth_session"
+    DROP COLUMN IF EXISTS "user_agent";
+
+ALTER TABLE
+    IF EXISTS "oauth_session"
+    DROP COLUMN IF EXISTS "device_name";
+
+ALTER TABLE
+    IF EXISTS "oauth_session"
+    DROP COLUMN IF EXISTS "device_id";
+
+COMMIT;
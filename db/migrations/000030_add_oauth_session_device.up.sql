@@ -0,0 +1,33 @@
+BEGIN;
+
+ALTER TABLE
+    IF EXISTS "oauth_session"
+    ADD COLUMN IF NOT EXISTS "device_id" text;
+
+ALTER TABLE
+    IF EXISTS "oauth_session"
+    ADD COLUMN IF NOT EXISTS "device_name" text;
+
+ALTER TABLE
+    IF EXISTS "oauth_session"
+    ADD COLUMN IF NOT EXISTS "user_agent" text;
+
+ALTER TABLE
+    IF EXISTS "oauth_session"
+    ADD COLUMN IF NOT EXISTS "ip_address" text;
+
+ALTER TABLE
+    IF EXISTS "oauth_session"
+    ADD COLUMN IF NOT EXISTS "push_token" text;
+
+ALTER TABLE
+    IF EXISTS "oauth_session"
+    ADD COLUMN IF NOT EXISTS "last_seen_at" timestamp;
+
+ALTER TABLE
+    IF EXISTS "oauth_session"
+    ADD COLUMN IF NOT EXISTS "revoked_at" timestamp;
+
+CREATE INDEX IF NOT EXISTS "oauth_session_user_id_idx" ON "oauth_session" ("user_id");
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+
+DROP INDEX IF EXISTS "users_userpin_user_id_valid_from_idx";
+
+ALTER TABLE
+    IF EXISTS "common_organisation"
+    DROP COLUMN IF EXISTS "pin_min_length",
+    DROP COLUMN IF EXISTS "pin_disallow_simple",
+    DROP COLUMN IF EXISTS "pin_history_count",
+    DROP COLUMN IF EXISTS "pin_max_age_days";
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+
+ALTER TABLE
+    IF EXISTS "common_organisation"
+    ADD COLUMN IF NOT EXISTS "pin_min_length" integer NOT NULL DEFAULT 4,
+    ADD COLUMN IF NOT EXISTS "pin_disallow_simple" boolean NOT NULL DEFAULT true,
+    ADD COLUMN IF NOT EXISTS "pin_history_count" integer NOT NULL DEFAULT 3,
+    ADD COLUMN IF NOT EXISTS "pin_max_age_days" integer NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS "users_userpin_user_id_valid_from_idx" ON "users_userpin" ("user_id", "valid_from" DESC);
+
+COMMIT;
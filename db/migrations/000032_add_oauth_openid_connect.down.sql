@@ -0,0 +1,17 @@
+BEGIN;
+
+ALTER TABLE
+    IF EXISTS "oauth_openid_connect_session"
+    DROP CONSTRAINT IF EXISTS "oauth_openid_connect_session_session_id_fkey";
+
+ALTER TABLE
+    IF EXISTS "oauth_openid_connect_session"
+    DROP CONSTRAINT IF EXISTS "oauth_openid_connect_session_client_id_fkey";
+
+DROP TABLE IF EXISTS "oauth_openid_connect_session";
+
+DROP INDEX IF EXISTS "oauth_signing_key_expires_at_idx";
+
+DROP TABLE IF EXISTS "oauth_signing_key";
+
+COMMIT;
@@ -0,0 +1,50 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS "oauth_signing_key" (
+    "id" uuid PRIMARY KEY NOT NULL,
+    "created_by" uuid,
+    "created" timestamp NOT NULL,
+    "updated_by" uuid,
+    "updated" timestamp NOT NULL,
+    "deleted_at" timestamp,
+    "active" boolean NOT NULL,
+    "key_id" varchar(64) NOT NULL UNIQUE,
+    "algorithm" varchar(16) NOT NULL,
+    "private_key" text NOT NULL,
+    "public_key" text NOT NULL,
+    "rotate_at" timestamp NOT NULL,
+    "expires_at" timestamp NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS "oauth_signing_key_expires_at_idx" ON "oauth_signing_key" ("expires_at");
+
+CREATE TABLE IF NOT EXISTS "oauth_openid_connect_session" (
+    "id" uuid PRIMARY KEY NOT NULL,
+    "created_by" uuid,
+    "created" timestamp NOT NULL,
+    "updated_by" uuid,
+    "updated" timestamp NOT NULL,
+    "deleted_at" timestamp,
+    "active" boolean NOT NULL,
+    "code" varchar(256) UNIQUE,
+    "requested_at" timestamp NOT NULL,
+    "requested_scopes" varchar(256) [],
+    "granted_scopes" varchar(256) [],
+    "form" jsonb,
+    "requested_audience" varchar(256) [],
+    "granted_audience" varchar(256) [],
+    "session_id" uuid,
+    "client_id" uuid
+);
+
+ALTER TABLE
+    IF EXISTS "oauth_openid_connect_session"
+ADD
+    CONSTRAINT "oauth_openid_connect_session_session_id_fkey" FOREIGN KEY ("session_id") REFERENCES "oauth_session" ("id");
+
+ALTER TABLE
+    IF EXISTS "oauth_openid_connect_session"
+ADD
+    CONSTRAINT "oauth_openid_connect_session_client_id_fkey" FOREIGN KEY ("client_id") REFERENCES "oauth_client" ("id");
+
+COMMIT;
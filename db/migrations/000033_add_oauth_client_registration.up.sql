@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE
+    IF EXISTS "oauth_client"
+ADD
+    COLUMN IF NOT EXISTS "registration_access_token" varchar(64);
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE
+    IF EXISTS "oauth_client"
+DROP
+    COLUMN IF EXISTS "mfl_code";
+
+COMMIT;
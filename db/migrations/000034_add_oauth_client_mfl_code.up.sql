@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE
+    IF EXISTS "oauth_client"
+ADD
+    COLUMN IF NOT EXISTS "mfl_code" varchar(64) UNIQUE;
+
+COMMIT;
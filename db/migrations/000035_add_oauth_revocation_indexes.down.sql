@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP INDEX IF EXISTS "oauth_access_token_revoked_idx";
+
+DROP INDEX IF EXISTS "oauth_session_revoked_at_idx";
+
+COMMIT;
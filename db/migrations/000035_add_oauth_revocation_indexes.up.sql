@@ -0,0 +1,11 @@
+BEGIN;
+
+CREATE INDEX IF NOT EXISTS "oauth_session_revoked_at_idx" ON "oauth_session" ("revoked_at")
+WHERE
+    "revoked_at" IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS "oauth_access_token_revoked_idx" ON "oauth_access_token" ("updated")
+WHERE
+    "active" = false;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE
+    IF EXISTS "common_organisation"
+    DROP COLUMN IF EXISTS "security_question_max_attempts";
+
+COMMIT;
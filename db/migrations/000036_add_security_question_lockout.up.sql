@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE
+    IF EXISTS "common_organisation"
+    ADD COLUMN IF NOT EXISTS "security_question_max_attempts" integer NOT NULL DEFAULT 3;
+
+COMMIT;
@@ -0,0 +1,11 @@
+BEGIN;
+
+ALTER TABLE
+    IF EXISTS "common_organisation"
+    DROP COLUMN IF EXISTS "staff_login_step_up";
+
+DROP TABLE IF EXISTS "users_loginevent";
+
+DROP TABLE IF EXISTS "users_userdevice";
+
+COMMIT;
@@ -0,0 +1,78 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS "users_userdevice" (
+  "id" uuid PRIMARY KEY NOT NULL,
+  "active" boolean NOT NULL,
+  "created" timestamp NOT NULL,
+  "created_by" uuid,
+  "updated" timestamp NOT NULL,
+  "updated_by" uuid,
+  "deleted_at" timestamp,
+  "user_id" uuid NOT NULL,
+  "fingerprint" text NOT NULL,
+  "device_id" text,
+  "device_name" text,
+  "user_agent" text,
+  "last_ip_address" text,
+  "last_country" text,
+  "last_login_at" timestamp NOT NULL
+);
+
+ALTER TABLE
+    IF EXISTS "users_userdevice"
+    ADD
+        CONSTRAINT "users_userdevice_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "users_user" ("id");
+
+ALTER TABLE
+    IF EXISTS "users_userdevice"
+    ADD
+        CONSTRAINT "users_userdevice_created_by_fkey" FOREIGN KEY ("created_by") REFERENCES "users_user" ("id");
+
+ALTER TABLE
+    IF EXISTS "users_userdevice"
+    ADD
+        CONSTRAINT "users_userdevice_updated_by_fkey" FOREIGN KEY ("updated_by") REFERENCES "users_user" ("id");
+
+CREATE UNIQUE INDEX IF NOT EXISTS "users_userdevice_user_id_fingerprint_idx" ON "users_userdevice" ("user_id", "fingerprint");
+
+CREATE TABLE IF NOT EXISTS "users_loginevent" (
+  "id" uuid PRIMARY KEY NOT NULL,
+  "active" boolean NOT NULL,
+  "created" timestamp NOT NULL,
+  "created_by" uuid,
+  "updated" timestamp NOT NULL,
+  "updated_by" uuid,
+  "deleted_at" timestamp,
+  "user_id" uuid NOT NULL,
+  "fingerprint" text NOT NULL,
+  "ip_address" text,
+  "country" text,
+  "risk_flags" text[],
+  "stepped_up" boolean NOT NULL DEFAULT false,
+  "timestamp" timestamp NOT NULL
+);
+
+ALTER TABLE
+    IF EXISTS "users_loginevent"
+    ADD
+        CONSTRAINT "users_loginevent_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "users_user" ("id");
+
+ALTER TABLE
+    IF EXISTS "users_loginevent"
+    ADD
+        CONSTRAINT "users_loginevent_created_by_fkey" FOREIGN KEY ("created_by") REFERENCES "users_user" ("id");
+
+ALTER TABLE
+    IF EXISTS "users_loginevent"
+    ADD
+        CONSTRAINT "users_loginevent_updated_by_fkey" FOREIGN KEY ("updated_by") REFERENCES "users_user" ("id");
+
+CREATE INDEX IF NOT EXISTS "users_loginevent_user_id_country_idx" ON "users_loginevent" ("user_id", "country");
+
+CREATE INDEX IF NOT EXISTS "users_loginevent_ip_address_timestamp_idx" ON "users_loginevent" ("ip_address", "timestamp" DESC);
+
+ALTER TABLE
+    IF EXISTS "common_organisation"
+    ADD COLUMN IF NOT EXISTS "staff_login_step_up" boolean NOT NULL DEFAULT false;
+
+COMMIT;
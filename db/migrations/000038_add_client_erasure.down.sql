@@ -0,0 +1,14 @@
+BEGIN;
+
+DROP INDEX IF EXISTS "clients_client_erasure_requested_at_idx";
+
+ALTER TABLE
+    IF EXISTS "clients_client"
+    DROP COLUMN IF EXISTS "erasure_requested_at",
+    DROP COLUMN IF EXISTS "anonymized_at";
+
+ALTER TABLE
+    IF EXISTS "common_organisation"
+    DROP COLUMN IF EXISTS "data_retention_days";
+
+COMMIT;
@@ -0,0 +1,16 @@
+BEGIN;
+
+ALTER TABLE
+    IF EXISTS "common_organisation"
+    ADD COLUMN IF NOT EXISTS "data_retention_days" integer NOT NULL DEFAULT 30;
+
+ALTER TABLE
+    IF EXISTS "clients_client"
+    ADD COLUMN IF NOT EXISTS "erasure_requested_at" timestamp,
+    ADD COLUMN IF NOT EXISTS "anonymized_at" timestamp;
+
+CREATE INDEX IF NOT EXISTS "clients_client_erasure_requested_at_idx"
+    ON "clients_client" ("erasure_requested_at")
+    WHERE "erasure_requested_at" IS NOT NULL AND "anonymized_at" IS NULL;
+
+COMMIT;
@@ -0,0 +1,14 @@
+BEGIN;
+
+DROP INDEX IF EXISTS "caregivers_caregiver_client_ends_at_idx";
+
+ALTER TABLE
+    IF EXISTS "caregivers_caregiver_client"
+    DROP COLUMN IF EXISTS "starts_at",
+    DROP COLUMN IF EXISTS "ends_at",
+    DROP COLUMN IF EXISTS "abilities",
+    DROP COLUMN IF EXISTS "revoked_at",
+    DROP COLUMN IF EXISTS "revoked_by",
+    DROP COLUMN IF EXISTS "expired_at";
+
+COMMIT;
@@ -0,0 +1,23 @@
+BEGIN;
+
+ALTER TABLE
+    IF EXISTS "caregivers_caregiver_client"
+    ADD COLUMN IF NOT EXISTS "starts_at" timestamp,
+    ADD COLUMN IF NOT EXISTS "ends_at" timestamp,
+    ADD COLUMN IF NOT EXISTS "abilities" text[],
+    ADD COLUMN IF NOT EXISTS "revoked_at" timestamp,
+    ADD COLUMN IF NOT EXISTS "revoked_by" uuid,
+    ADD COLUMN IF NOT EXISTS "expired_at" timestamp;
+
+-- caregivers assigned before delegation could be scoped keep all the abilities they had
+UPDATE "caregivers_caregiver_client"
+SET
+    "starts_at" = "created",
+    "abilities" = ARRAY['VIEW_APPOINTMENTS', 'RESCHEDULE_APPOINTMENTS', 'RECORD_HEALTH_DIARY', 'VIEW_SCREENING_RESULTS']
+WHERE "abilities" IS NULL;
+
+CREATE INDEX IF NOT EXISTS "caregivers_caregiver_client_ends_at_idx"
+    ON "caregivers_caregiver_client" ("ends_at")
+    WHERE "ends_at" IS NOT NULL AND "revoked_at" IS NULL AND "expired_at" IS NULL;
+
+COMMIT;
@@ -0,0 +1,44 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS "users_phonechangerequest" (
+  "id" uuid PRIMARY KEY NOT NULL,
+  "active" boolean NOT NULL,
+  "created" timestamp NOT NULL,
+  "created_by" uuid,
+  "updated" timestamp NOT NULL,
+  "updated_by" uuid,
+  "deleted_at" timestamp,
+  "user_id" uuid NOT NULL,
+  "flavour" varchar(32) NOT NULL,
+  "old_phone_number" text NOT NULL,
+  "new_phone_number" text NOT NULL,
+  "requested_by" uuid NOT NULL,
+  "assisted" boolean NOT NULL DEFAULT false,
+  "reason" text,
+  "expires_at" timestamp NOT NULL,
+  "confirmed_at" timestamp
+);
+
+ALTER TABLE
+    IF EXISTS "users_phonechangerequest"
+    ADD
+        CONSTRAINT "users_phonechangerequest_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "users_user" ("id");
+
+ALTER TABLE
+    IF EXISTS "users_phonechangerequest"
+    ADD
+        CONSTRAINT "users_phonechangerequest_requested_by_fkey" FOREIGN KEY ("requested_by") REFERENCES "users_user" ("id");
+
+ALTER TABLE
+    IF EXISTS "users_phonechangerequest"
+    ADD
+        CONSTRAINT "users_phonechangerequest_created_by_fkey" FOREIGN KEY ("created_by") REFERENCES "users_user" ("id");
+
+ALTER TABLE
+    IF EXISTS "users_phonechangerequest"
+    ADD
+        CONSTRAINT "users_phonechangerequest_updated_by_fkey" FOREIGN KEY ("updated_by") REFERENCES "users_user" ("id");
+
+CREATE INDEX IF NOT EXISTS "users_phonechangerequest_user_id_idx" ON "users_phonechangerequest" ("user_id", "active");
+
+COMMIT;
@@ -0,0 +1,88 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS "clients_clienttransfer" (
+  "id" uuid PRIMARY KEY NOT NULL,
+  "active" boolean NOT NULL,
+  "created" timestamp NOT NULL,
+  "created_by" uuid,
+  "updated" timestamp NOT NULL,
+  "updated_by" uuid,
+  "deleted_at" timestamp,
+  "user_id" uuid NOT NULL,
+  "client_id" uuid NOT NULL,
+  "new_client_id" uuid NOT NULL,
+  "from_facility_id" uuid NOT NULL,
+  "to_facility_id" uuid NOT NULL,
+  "from_program_id" uuid NOT NULL,
+  "to_program_id" uuid NOT NULL,
+  "from_organisation_id" uuid NOT NULL,
+  "to_organisation_id" uuid NOT NULL,
+  "reason" text NOT NULL,
+  "carried_history" text[],
+  "transferred_by" uuid NOT NULL,
+  "transferred_at" timestamp NOT NULL
+);
+
+ALTER TABLE
+    IF EXISTS "clients_clienttransfer"
+    ADD
+        CONSTRAINT "clients_clienttransfer_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "users_user" ("id");
+
+ALTER TABLE
+    IF EXISTS "clients_clienttransfer"
+    ADD
+        CONSTRAINT "clients_clienttransfer_client_id_fkey" FOREIGN KEY ("client_id") REFERENCES "clients_client" ("id");
+
+ALTER TABLE
+    IF EXISTS "clients_clienttransfer"
+    ADD
+        CONSTRAINT "clients_clienttransfer_new_client_id_fkey" FOREIGN KEY ("new_client_id") REFERENCES "clients_client" ("id");
+
+ALTER TABLE
+    IF EXISTS "clients_clienttransfer"
+    ADD
+        CONSTRAINT "clients_clienttransfer_from_facility_id_fkey" FOREIGN KEY ("from_facility_id") REFERENCES "common_facility" ("id");
+
+ALTER TABLE
+    IF EXISTS "clients_clienttransfer"
+    ADD
+        CONSTRAINT "clients_clienttransfer_to_facility_id_fkey" FOREIGN KEY ("to_facility_id") REFERENCES "common_facility" ("id");
+
+ALTER TABLE
+    IF EXISTS "clients_clienttransfer"
+    ADD
+        CONSTRAINT "clients_clienttransfer_from_program_id_fkey" FOREIGN KEY ("from_program_id") REFERENCES "common_program" ("id");
+
+ALTER TABLE
+    IF EXISTS "clients_clienttransfer"
+    ADD
+        CONSTRAINT "clients_clienttransfer_to_program_id_fkey" FOREIGN KEY ("to_program_id") REFERENCES "common_program" ("id");
+
+ALTER TABLE
+    IF EXISTS "clients_clienttransfer"
+    ADD
+        CONSTRAINT "clients_clienttransfer_from_organisation_id_fkey" FOREIGN KEY ("from_organisation_id") REFERENCES "common_organisation" ("id");
+
+ALTER TABLE
+    IF EXISTS "clients_clienttransfer"
+    ADD
+        CONSTRAINT "clients_clienttransfer_to_organisation_id_fkey" FOREIGN KEY ("to_organisation_id") REFERENCES "common_organisation" ("id");
+
+ALTER TABLE
+    IF EXISTS "clients_clienttransfer"
+    ADD
+        CONSTRAINT "clients_clienttransfer_transferred_by_fkey" FOREIGN KEY ("transferred_by") REFERENCES "users_user" ("id");
+
+ALTER TABLE
+    IF EXISTS "clients_clienttransfer"
+    ADD
+        CONSTRAINT "clients_clienttransfer_created_by_fkey" FOREIGN KEY ("created_by") REFERENCES "users_user" ("id");
+
+ALTER TABLE
+    IF EXISTS "clients_clienttransfer"
+    ADD
+        CONSTRAINT "clients_clienttransfer_updated_by_fkey" FOREIGN KEY ("updated_by") REFERENCES "users_user" ("id");
+
+CREATE INDEX IF NOT EXISTS "clients_clienttransfer_user_id_idx" ON "clients_clienttransfer" ("user_id", "transferred_at");
+
+COMMIT;
@@ -0,0 +1,19 @@
+BEGIN;
+
+DROP INDEX IF EXISTS "clients_servicerequest_pending_escalation_idx";
+
+DROP TABLE IF EXISTS "clients_servicerequestescalation";
+
+ALTER TABLE
+    IF EXISTS "clients_servicerequest"
+    DROP COLUMN IF EXISTS "sla_breached_at",
+    DROP COLUMN IF EXISTS "escalation_level",
+    DROP COLUMN IF EXISTS "patient_safety_incident";
+
+ALTER TABLE
+    IF EXISTS "common_program"
+    DROP COLUMN IF EXISTS "sla_tracking_started_at";
+
+DROP TABLE IF EXISTS "common_servicerequestsla";
+
+COMMIT;
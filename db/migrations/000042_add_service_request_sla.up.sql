@@ -0,0 +1,97 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS "common_servicerequestsla" (
+  "id" uuid PRIMARY KEY NOT NULL,
+  "active" boolean NOT NULL,
+  "created" timestamp NOT NULL,
+  "created_by" uuid,
+  "updated" timestamp NOT NULL,
+  "updated_by" uuid,
+  "deleted_at" timestamp,
+  "program_id" uuid NOT NULL,
+  "organisation_id" uuid NOT NULL,
+  "request_type" varchar(64) NOT NULL,
+  "resolution_hours" integer NOT NULL,
+  "escalation_hours" integer NOT NULL
+);
+
+ALTER TABLE
+    IF EXISTS "common_servicerequestsla"
+    ADD
+        CONSTRAINT "common_servicerequestsla_program_id_fkey" FOREIGN KEY ("program_id") REFERENCES "common_program" ("id");
+
+ALTER TABLE
+    IF EXISTS "common_servicerequestsla"
+    ADD
+        CONSTRAINT "common_servicerequestsla_organisation_id_fkey" FOREIGN KEY ("organisation_id") REFERENCES "common_organisation" ("id");
+
+ALTER TABLE
+    IF EXISTS "common_servicerequestsla"
+    ADD
+        CONSTRAINT "common_servicerequestsla_created_by_fkey" FOREIGN KEY ("created_by") REFERENCES "users_user" ("id");
+
+ALTER TABLE
+    IF EXISTS "common_servicerequestsla"
+    ADD
+        CONSTRAINT "common_servicerequestsla_updated_by_fkey" FOREIGN KEY ("updated_by") REFERENCES "users_user" ("id");
+
+CREATE UNIQUE INDEX IF NOT EXISTS "common_servicerequestsla_program_id_request_type_idx" ON "common_servicerequestsla" ("program_id", "request_type");
+
+ALTER TABLE
+    IF EXISTS "clients_servicerequest"
+    ADD COLUMN IF NOT EXISTS "sla_breached_at" timestamp,
+    ADD COLUMN IF NOT EXISTS "escalation_level" varchar(32),
+    ADD COLUMN IF NOT EXISTS "patient_safety_incident" boolean NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS "clients_servicerequestescalation" (
+  "id" uuid PRIMARY KEY NOT NULL,
+  "active" boolean NOT NULL,
+  "created" timestamp NOT NULL,
+  "created_by" uuid,
+  "updated" timestamp NOT NULL,
+  "updated_by" uuid,
+  "deleted_at" timestamp,
+  "service_request_id" uuid NOT NULL,
+  "level" varchar(32) NOT NULL,
+  "recipients" text[],
+  "patient_safety_incident" boolean NOT NULL DEFAULT false,
+  "escalated_at" timestamp NOT NULL,
+  "program_id" uuid NOT NULL,
+  "organisation_id" uuid NOT NULL
+);
+
+ALTER TABLE
+    IF EXISTS "clients_servicerequestescalation"
+    ADD
+        CONSTRAINT "clients_servicerequestescalation_service_request_id_fkey" FOREIGN KEY ("service_request_id") REFERENCES "clients_servicerequest" ("id");
+
+ALTER TABLE
+    IF EXISTS "clients_servicerequestescalation"
+    ADD
+        CONSTRAINT "clients_servicerequestescalation_program_id_fkey" FOREIGN KEY ("program_id") REFERENCES "common_program" ("id");
+
+ALTER TABLE
+    IF EXISTS "clients_servicerequestescalation"
+    ADD
+        CONSTRAINT "clients_servicerequestescalation_organisation_id_fkey" FOREIGN KEY ("organisation_id") REFERENCES "common_organisation" ("id");
+
+ALTER TABLE
+    IF EXISTS "clients_servicerequestescalation"
+    ADD
+        CONSTRAINT "clients_servicerequestescalation_created_by_fkey" FOREIGN KEY ("created_by") REFERENCES "users_user" ("id");
+
+ALTER TABLE
+    IF EXISTS "clients_servicerequestescalation"
+    ADD
+        CONSTRAINT "clients_servicerequestescalation_updated_by_fkey" FOREIGN KEY ("updated_by") REFERENCES "users_user" ("id");
+
+CREATE INDEX IF NOT EXISTS "clients_servicerequestescalation_service_request_id_idx" ON "clients_servicerequestescalation" ("service_request_id", "escalated_at");
+
+-- service requests created before SLA tracking started in a program are not escalated
+ALTER TABLE
+    IF EXISTS "common_program"
+    ADD COLUMN IF NOT EXISTS "sla_tracking_started_at" timestamp NOT NULL DEFAULT now();
+
+CREATE INDEX IF NOT EXISTS "clients_servicerequest_pending_escalation_idx" ON "clients_servicerequest" ("status", "request_type") WHERE "active" = true;
+
+COMMIT;
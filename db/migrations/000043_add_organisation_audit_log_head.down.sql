@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE
+    IF EXISTS "common_organisation"
+    DROP COLUMN IF EXISTS "audit_log_sequence",
+    DROP COLUMN IF EXISTS "audit_log_hash";
+
+COMMIT;
@@ -0,0 +1,20 @@
+BEGIN;
+
+ALTER TABLE
+    IF EXISTS "common_organisation"
+    ADD COLUMN IF NOT EXISTS "audit_log_sequence" bigint NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS "audit_log_hash" text NOT NULL DEFAULT '';
+
+UPDATE "common_organisation"
+SET
+    "audit_log_sequence" = "last_audit_log"."sequence",
+    "audit_log_hash" = COALESCE("last_audit_log"."hash", '')
+FROM (
+    SELECT DISTINCT ON ("organisation_id") "organisation_id", "sequence", "hash"
+    FROM "common_auditlog"
+    WHERE "sequence" IS NOT NULL
+    ORDER BY "organisation_id", "sequence" DESC
+) AS "last_audit_log"
+WHERE "common_organisation"."id" = "last_audit_log"."organisation_id";
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE
+    IF EXISTS "clients_clienttransfer"
+    DROP COLUMN IF EXISTS "client_consented";
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE
+    IF EXISTS "clients_clienttransfer"
+    ADD COLUMN IF NOT EXISTS "client_consented" boolean NOT NULL DEFAULT false;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+UPDATE "authority_authoritypermission"
+SET "active" = true
+WHERE "scope" IN ('organisation.create', 'organisation.delete', 'program.create');
+
+COMMIT;
@@ -0,0 +1,13 @@
+BEGIN;
+
+DELETE FROM "authority_authorityrole_permissions"
+WHERE "authoritypermission_id" IN (
+    SELECT "id" FROM "authority_authoritypermission"
+    WHERE "scope" IN ('organisation.create', 'organisation.delete', 'program.create')
+);
+
+UPDATE "authority_authoritypermission"
+SET "active" = false
+WHERE "scope" IN ('organisation.create', 'organisation.delete', 'program.create');
+
+COMMIT;
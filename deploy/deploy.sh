@@ -44,6 +44,7 @@ helm upgrade \
     --set app.container.env.proInviteLink="${PRO_INVITE_LINK}"\
     --set app.container.env.consumerInviteLink="${CONSUMER_INVITE_LINK}"\
     --set app.container.env.sensitiveContentSecretKey="${SENSITIVE_CONTENT_SECRET_KEY}"\
+    --set app.container.env.auditLogHashKey="${AUDIT_LOG_HASH_KEY}"\
     --set app.container.env.mailgunAPIKey="${MAILGUN_API_KEY}"\
     --set app.container.env.mailgunDomain="${MAILGUN_DOMAIN}"\
     --set app.container.env.mailgunFrom="${MAILGUN_FROM}"\
@@ -74,6 +75,8 @@ helm upgrade \
     --set app.container.env.mchMatrixPassword="${MCH_MATRIX_PASSWORD}"\
     --set app.container.env.matrixDomain="${MATRIX_DOMAIN}"\
     --set app.container.env.fositeSecret="${FOSITE_SECRET}"\
+    --set app.container.env.oauthInitialAccessToken="${OAUTH_INITIAL_ACCESS_TOKEN}"\
+    --set app.container.env.oauthAccessTokenStrategy="${OAUTH_ACCESS_TOKEN_STRATEGY}"\
     --set app.container.env.mycarehubClientID="${MYCAREHUB_CLIENT_ID}"\
     --set app.container.env.mycarehubClientSecret="${MYCAREHUB_CLIENT_SECRET}"\
     --set app.container.env.mycarehubIntrospectURL="${MYCAREHUB_INTROSPECT_URL}"\
@@ -1,5 +1,7 @@
 package common
 
+import "time"
+
 const (
 	// OrganizationID is the default organization ID that's added to all models on the django side
 	OrganizationID = "DEFAULT_ORG_ID"
@@ -66,4 +68,7 @@ const (
 
 	// AddFHIRIDToProgram is the topic where details to update a program's fhir ID will be published to
 	AddFHIRIDToProgram = "program.fhirid.update"
+
+	// RefreshTokenLifespan is how long a refresh token can be used. A session ends when its latest refresh token expires
+	RefreshTokenLifespan = 24 * time.Hour
 )
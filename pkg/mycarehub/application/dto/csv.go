@@ -3,9 +3,14 @@ package dto
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/savannahghi/converterandformatter"
+	"github.com/savannahghi/enumutils"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/scalarutils"
 	validator "gopkg.in/go-playground/validator.v9"
 )
 
@@ -87,3 +92,138 @@ func (f *FacilityCSVOutput) ParseValues(labels []string, values []string) (*Faci
 
 	return f, nil
 }
+
+// clientCSVDateFormat is the format of the dates in the client registration csv
+const clientCSVDateFormat = "2006-01-02"
+
+// ClientCSVLabels are the labels of the columns of the client registration csv. The username column is optional
+// and a username is made from the client's name and CCC number when it is not provided.
+// Client types are separated by a semicolon e.g `PMTCT;OTZ`
+var ClientCSVLabels = []string{"cccNumber", "clientName", "gender", "dateOfBirth", "phoneNumber", "clientTypes", "facility", "enrollmentDate", "counselled"}
+
+// ClientCSVRow is a row of the client registration csv. The row number counts the label row so that it matches the line in the file
+type ClientCSVRow struct {
+	Row   int
+	Input *ClientRegistrationInput
+	Err   error
+}
+
+// ValidateClientCSVLabels ensures that the client registration csv has all the required labels and no unknown label
+func ValidateClientCSVLabels(labels []string) error {
+	known := map[string]bool{"username": true}
+	for _, label := range ClientCSVLabels {
+		known[label] = true
+	}
+
+	present := map[string]bool{}
+	for _, label := range labels {
+		label = strings.TrimSpace(label)
+		if !known[label] {
+			return fmt.Errorf("invalid client csv: invalid label: %v", label)
+		}
+		present[label] = true
+	}
+
+	for _, label := range ClientCSVLabels {
+		if !present[label] {
+			return fmt.Errorf("invalid client csv: missing label: %v", label)
+		}
+	}
+
+	return nil
+}
+
+// ParseClientCSVValues maps a row of the client registration csv to a client registration input.
+// The input is not validated so that the caller can report all the problems with the row
+func ParseClientCSVValues(labels []string, values []string) (*ClientRegistrationInput, error) {
+	if len(values) != len(labels) {
+		return nil, fmt.Errorf("invalid values length: expected %v, got %v", len(labels), len(values))
+	}
+
+	row := map[string]string{}
+	for i, label := range labels {
+		row[strings.TrimSpace(label)] = strings.TrimSpace(values[i])
+	}
+
+	gender := enumutils.Gender(strings.ToLower(row["gender"]))
+	if !gender.IsValid() {
+		return nil, fmt.Errorf("invalid gender: %v", row["gender"])
+	}
+
+	dateOfBirth, err := parseClientCSVDate(row["dateOfBirth"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid date of birth: %w", err)
+	}
+
+	enrollmentDate, err := parseClientCSVDate(row["enrollmentDate"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid enrollment date: %w", err)
+	}
+
+	clientTypes := []enums.ClientType{}
+	for _, value := range strings.Split(row["clientTypes"], ";") {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		clientType := enums.ClientType(strings.ToUpper(strings.TrimSpace(value)))
+		if !clientType.IsValid() {
+			return nil, fmt.Errorf("invalid client type: %v", value)
+		}
+		clientTypes = append(clientTypes, clientType)
+	}
+
+	counselled := false
+	if row["counselled"] != "" {
+		counselled, err = strconv.ParseBool(row["counselled"])
+		if err != nil {
+			return nil, fmt.Errorf("invalid counselled value: %v", row["counselled"])
+		}
+	}
+
+	username := row["username"]
+	if username == "" {
+		username = clientCSVUsername(row["clientName"], row["cccNumber"])
+	}
+
+	return &ClientRegistrationInput{
+		Username:       username,
+		Facility:       row["facility"],
+		ClientTypes:    clientTypes,
+		ClientName:     row["clientName"],
+		Gender:         gender,
+		DateOfBirth:    dateOfBirth,
+		PhoneNumber:    row["phoneNumber"],
+		EnrollmentDate: enrollmentDate,
+		CCCNumber:      row["cccNumber"],
+		Counselled:     counselled,
+	}, nil
+}
+
+// parseClientCSVDate parses a date in the client registration csv. An empty value is left for the validation to report
+func parseClientCSVDate(value string) (scalarutils.Date, error) {
+	if value == "" {
+		return scalarutils.Date{}, nil
+	}
+
+	date, err := time.Parse(clientCSVDateFormat, value)
+	if err != nil {
+		return scalarutils.Date{}, fmt.Errorf("%v is not in the %s format", value, clientCSVDateFormat)
+	}
+
+	return scalarutils.Date{Year: date.Year(), Month: int(date.Month()), Day: date.Day()}, nil
+}
+
+// clientCSVUsername makes a username from the client's first name and the last digits of their CCC number
+func clientCSVUsername(clientName string, cccNumber string) string {
+	names := strings.Fields(clientName)
+	if len(names) == 0 || cccNumber == "" {
+		return ""
+	}
+
+	suffix := cccNumber
+	if len(suffix) > 4 {
+		suffix = suffix[len(suffix)-4:]
+	}
+
+	return strings.ToLower(names[0]) + suffix
+}
@@ -4,7 +4,9 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/savannahghi/enumutils"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/scalarutils"
 )
 
 func TestFacilityCSVOutput_ValidateLabels(t *testing.T) {
@@ -294,3 +296,132 @@ func TestFacilityCSVOutput_ParseValues(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateClientCSVLabels(t *testing.T) {
+	tests := []struct {
+		name    string
+		labels  []string
+		wantErr bool
+	}{
+		{
+			name:    "Happy Case: valid labels",
+			labels:  ClientCSVLabels,
+			wantErr: false,
+		},
+		{
+			name:    "Happy Case: valid labels with username",
+			labels:  append([]string{"username"}, ClientCSVLabels...),
+			wantErr: false,
+		},
+		{
+			name:    "Sad Case: missing label",
+			labels:  ClientCSVLabels[1:],
+			wantErr: true,
+		},
+		{
+			name:    "Sad Case: unknown label",
+			labels:  append([]string{"nationalID"}, ClientCSVLabels...),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateClientCSVLabels(tt.labels); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateClientCSVLabels() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseClientCSVValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		labels  []string
+		values  []string
+		want    *ClientRegistrationInput
+		wantErr bool
+	}{
+		{
+			name:   "Happy Case: parse values",
+			labels: ClientCSVLabels,
+			values: []string{"1234567890", "Jane Doe", "Female", "1990-01-31", "+254711223344", "pmtct;OTZ", "12345", "2023-01-01", "true"},
+			want: &ClientRegistrationInput{
+				Username:       "jane7890",
+				Facility:       "12345",
+				ClientTypes:    []enums.ClientType{enums.ClientTypePmtct, enums.ClientTypeOtz},
+				ClientName:     "Jane Doe",
+				Gender:         enumutils.GenderFemale,
+				DateOfBirth:    scalarutils.Date{Year: 1990, Month: 1, Day: 31},
+				PhoneNumber:    "+254711223344",
+				EnrollmentDate: scalarutils.Date{Year: 2023, Month: 1, Day: 1},
+				CCCNumber:      "1234567890",
+				Counselled:     true,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "Happy Case: parse values with a username",
+			labels: append(ClientCSVLabels, "username"),
+			values: []string{"1234567890", "Jane Doe", "female", "1990-01-31", "+254711223344", "PMTCT", "12345", "", "", "janed"},
+			want: &ClientRegistrationInput{
+				Username:    "janed",
+				Facility:    "12345",
+				ClientTypes: []enums.ClientType{enums.ClientTypePmtct},
+				ClientName:  "Jane Doe",
+				Gender:      enumutils.GenderFemale,
+				DateOfBirth: scalarutils.Date{Year: 1990, Month: 1, Day: 31},
+				PhoneNumber: "+254711223344",
+				CCCNumber:   "1234567890",
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Sad Case: invalid values length",
+			labels:  ClientCSVLabels,
+			values:  []string{"1234567890", "Jane Doe"},
+			wantErr: true,
+		},
+		{
+			name:    "Sad Case: invalid gender",
+			labels:  ClientCSVLabels,
+			values:  []string{"1234567890", "Jane Doe", "F", "1990-01-31", "+254711223344", "PMTCT", "12345", "2023-01-01", "true"},
+			wantErr: true,
+		},
+		{
+			name:    "Sad Case: invalid date of birth",
+			labels:  ClientCSVLabels,
+			values:  []string{"1234567890", "Jane Doe", "female", "31/01/1990", "+254711223344", "PMTCT", "12345", "2023-01-01", "true"},
+			wantErr: true,
+		},
+		{
+			name:    "Sad Case: invalid enrollment date",
+			labels:  ClientCSVLabels,
+			values:  []string{"1234567890", "Jane Doe", "female", "1990-01-31", "+254711223344", "PMTCT", "12345", "01/01/2023", "true"},
+			wantErr: true,
+		},
+		{
+			name:    "Sad Case: invalid client type",
+			labels:  ClientCSVLabels,
+			values:  []string{"1234567890", "Jane Doe", "female", "1990-01-31", "+254711223344", "PMTCT;ART", "12345", "2023-01-01", "true"},
+			wantErr: true,
+		},
+		{
+			name:    "Sad Case: invalid counselled value",
+			labels:  ClientCSVLabels,
+			values:  []string{"1234567890", "Jane Doe", "female", "1990-01-31", "+254711223344", "PMTCT", "12345", "2023-01-01", "maybe"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseClientCSVValues(tt.labels, tt.values)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseClientCSVValues() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseClientCSVValues() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
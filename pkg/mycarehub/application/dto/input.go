@@ -96,6 +96,29 @@ type LoginInput struct {
 	Username string          `json:"username" validate:"required"`
 	PIN      string          `json:"pin" validate:"required"`
 	Flavour  feedlib.Flavour `json:"flavour" validate:"required"`
+
+	// TOTPCode is a code from the user's authenticator app or one of their recovery codes.
+	// It is only required when the user has enrolled an authenticator app
+	TOTPCode string `json:"totpCode"`
+
+	// DeviceID and DeviceName identify the device that the user is logging in from so that
+	// they can recognise the session when listing or ending their sessions
+	DeviceID   string `json:"deviceID"`
+	DeviceName string `json:"deviceName"`
+
+	// OTP is the code sent to a staff's phone to confirm a login from a new device or location
+	OTP string `json:"otp"`
+
+	// UserAgent is set from the login request and is used with the device ID to recognise the user's devices
+	UserAgent string `json:"-"`
+}
+
+// SessionDeviceInput is the device that a session is started on
+type SessionDeviceInput struct {
+	DeviceID   string
+	DeviceName string
+	UserAgent  string
+	IPAddress  string
 }
 
 // Validate helps with validation of LoginInput fields
@@ -159,6 +182,32 @@ type SendRetryOTPPayload struct {
 	Flavour  feedlib.Flavour `json:"flavour" validate:"required"`
 }
 
+// OTPDeliveryReceiptInput is the delivery status of an OTP message reported by a delivery provider
+type OTPDeliveryReceiptInput struct {
+	Provider          enums.OTPDeliveryProvider `json:"provider" validate:"required"`
+	ProviderMessageID string                    `json:"providerMessageID" validate:"required"`
+	Status            enums.OTPDeliveryStatus   `json:"status" validate:"required"`
+}
+
+// Validate helps with validation of OTPDeliveryReceiptInput fields
+func (f *OTPDeliveryReceiptInput) Validate() error {
+	v := validator.New()
+	err := v.Struct(f)
+	if err != nil {
+		return err
+	}
+
+	if !f.Provider.IsValid() {
+		return fmt.Errorf("invalid OTP delivery provider: %s", f.Provider)
+	}
+
+	if !f.Status.IsValid() {
+		return fmt.Errorf("invalid OTP delivery status: %s", f.Status)
+	}
+
+	return nil
+}
+
 // Validate helps with validation of PINInput fields
 func (f *PINInput) Validate() error {
 	v := validator.New()
@@ -337,6 +386,108 @@ func (f *ClientRegistrationInput) Validate() error {
 	return err
 }
 
+// BulkClientRegistrationInput is the payload used to register the clients in a csv.
+// The program is only set by the command line, clients registered through the API are added to the staff's current program
+type BulkClientRegistrationInput struct {
+	CSV           string `json:"csv" validate:"required"`
+	DryRun        bool   `json:"dryRun"`
+	InviteClients bool   `json:"inviteClients"`
+	ProgramID     string `json:"-"`
+}
+
+// Validate helps with validation of BulkClientRegistrationInput fields
+func (b *BulkClientRegistrationInput) Validate() error {
+	v := validator.New()
+	err := v.Struct(b)
+	return err
+}
+
+// ClientDataExportInput is the payload used to export all the data held about a client, for example when they request it under the Data Protection Act.
+// The person who requested the export is only set by the command line, exports requested through the API are made by the logged in staff
+type ClientDataExportInput struct {
+	ClientID    string `json:"clientID" validate:"required"`
+	Reason      string `json:"reason" validate:"required"`
+	RequestedBy string `json:"-"`
+}
+
+// Validate helps with validation of ClientDataExportInput fields
+func (c *ClientDataExportInput) Validate() error {
+	v := validator.New()
+	err := v.Struct(c)
+	return err
+}
+
+// StaffOffboardingInput is the payload used to offboard a staff who has left an organisation. Their in progress service
+// requests are released back to the facility's queue unless there is a staff to reassign them to. A dry run only reports what would change
+type StaffOffboardingInput struct {
+	StaffID    string  `json:"staffID" validate:"required"`
+	ReassignTo *string `json:"reassignTo"`
+	Reason     string  `json:"reason" validate:"required"`
+	DryRun     bool    `json:"dryRun"`
+}
+
+// Validate helps with validation of StaffOffboardingInput fields
+func (s *StaffOffboardingInput) Validate() error {
+	v := validator.New()
+	err := v.Struct(s)
+	return err
+}
+
+// ClientTransferInput is the payload used to transfer a client to another facility. The client moves to another program
+// of their organisation when the program is provided. Their history is only carried to the new program with their consent
+type ClientTransferInput struct {
+	ClientID        string                        `json:"clientID" validate:"required"`
+	FacilityID      string                        `json:"facilityID" validate:"required"`
+	ProgramID       *string                       `json:"programID"`
+	Reason          string                        `json:"reason" validate:"required"`
+	CarryHistory    []enums.ClientTransferHistory `json:"carryHistory"`
+	ClientConsented bool                          `json:"clientConsented"`
+}
+
+// Validate helps with validation of ClientTransferInput fields
+func (c *ClientTransferInput) Validate() error {
+	v := validator.New()
+	err := v.Struct(c)
+	if err != nil {
+		return err
+	}
+
+	for _, history := range c.CarryHistory {
+		if !history.IsValid() {
+			return fmt.Errorf("invalid client transfer history: %s", history)
+		}
+	}
+
+	if len(c.CarryHistory) > 0 && !c.ClientConsented {
+		return fmt.Errorf("the client has not consented to carrying their history to the new program")
+	}
+
+	return nil
+}
+
+// ServiceRequestSLAInput is the payload used to set how long a type of service request may stay pending in a program
+// before it breaches its SLA, and how long it then stays with the facility staff before it is escalated to the admins
+type ServiceRequestSLAInput struct {
+	RequestType     enums.ServiceRequestType `json:"requestType" validate:"required"`
+	ResolutionHours int                      `json:"resolutionHours" validate:"required,min=1"`
+	EscalationHours int                      `json:"escalationHours" validate:"min=0"`
+}
+
+// Validate helps with validation of ServiceRequestSLAInput fields
+func (s *ServiceRequestSLAInput) Validate() error {
+	v := validator.New()
+	err := v.Struct(s)
+	if err != nil {
+		return err
+	}
+
+	if !s.RequestType.IsValid() {
+		return fmt.Errorf("invalid service request type: %s", s.RequestType)
+	}
+
+	return nil
+}
+
 // ExistingUserClientInput defines the fields passed as a payload to create a client profile of an already existing user
 type ExistingUserClientInput struct {
 	UserID         string             `json:"userID" validate:"required"`
@@ -790,11 +941,15 @@ type ClientFacilityInput struct {
 }
 
 // ClientCaregiverInput is the input for used to assign a caregiver to a client
+// The caregiver is delegated all the abilities from the day they are assigned when none are specified
 type ClientCaregiverInput struct {
-	ClientID      string              `json:"clientID"`
-	CaregiverID   string              `json:"caregiverID"`
-	CaregiverType enums.CaregiverType `json:"caregiverType"`
-	Consent       enums.ConsentState  `json:"consentState"`
+	ClientID      string                   `json:"clientID"`
+	CaregiverID   string                   `json:"caregiverID"`
+	CaregiverType enums.CaregiverType      `json:"caregiverType"`
+	Consent       enums.ConsentState       `json:"consentState"`
+	StartsAt      *scalarutils.Date        `json:"startsAt"`
+	EndsAt        *scalarutils.Date        `json:"endsAt"`
+	Abilities     []enums.CaregiverAbility `json:"abilities"`
 }
 
 // OrganisationInput is the input for creating an organisation
@@ -828,9 +983,27 @@ func (s ProgramInput) Validate() error {
 type OauthClientInput struct {
 	Name          string   `json:"name"`
 	Secret        string   `json:"secret"`
+	Public        bool     `json:"public"`
 	RedirectURIs  []string `json:"redirectURIs"`
+	Scopes        []string `json:"scopes"`
 	ResponseTypes []string `json:"responseTypes"`
 	Grants        []string `json:"grants"`
+	// MFLCode binds an integration client e.g KenyaEMR to the facility that it acts for
+	MFLCode string `json:"mflCode"`
+}
+
+// OauthClientRegistrationInput is the metadata that a client registers itself with
+// using the dynamic client registration protocol (RFC 7591)
+type OauthClientRegistrationInput struct {
+	// ClientID is only sent when a client updates its registration (RFC 7592)
+	ClientID                string   `json:"client_id,omitempty"`
+	ClientName              string   `json:"client_name"`
+	RedirectURIs            []string `json:"redirect_uris"`
+	GrantTypes              []string `json:"grant_types"`
+	ResponseTypes           []string `json:"response_types"`
+	TokenEndpointAuthMethod string   `json:"token_endpoint_auth_method"`
+	// Scope is a space separated list of the scopes that the client can request
+	Scope string `json:"scope"`
 }
 
 // MatrixNotifyInput is the input for receiving Matrix's notification event data
@@ -926,3 +1099,73 @@ type ClientSelfSignUp struct {
 	DateOfBirth scalarutils.Date `json:"dateOfBirth" validate:"required"`
 	PhoneNumber string           `json:"phoneNumber" validate:"required"`
 }
+
+// AuthorityRoleInput is used to create a custom role in a program
+type AuthorityRoleInput struct {
+	Name          string          `json:"name" validate:"required"`
+	Description   string          `json:"description" validate:"required"`
+	ProgramID     string          `json:"programID" validate:"required"`
+	UserType      enums.UsersType `json:"userType" validate:"required"`
+	PermissionIDs []string        `json:"permissionIDs"`
+}
+
+// Validate helps with validation of AuthorityRoleInput input
+func (a *AuthorityRoleInput) Validate() error {
+	v := validator.New()
+
+	err := v.Struct(a)
+
+	return err
+}
+
+// RoleAssignmentInput is used to assign or revoke roles from a staff, client or caregiver profile
+type RoleAssignmentInput struct {
+	UserType  enums.UsersType `json:"userType" validate:"required"`
+	ProfileID string          `json:"profileID" validate:"required"`
+	RoleIDs   []string        `json:"roleIDs" validate:"required,min=1"`
+}
+
+// Validate helps with validation of RoleAssignmentInput input
+func (r *RoleAssignmentInput) Validate() error {
+	v := validator.New()
+
+	err := v.Struct(r)
+
+	return err
+}
+
+// AuditLogFilterInput is used to filter the records in an organisation's audit trail
+type AuditLogFilterInput struct {
+	RecordType *enums.AuditLogRecordType `json:"recordType"`
+	ActorID    *string                   `json:"actorID"`
+	TargetID   *string                   `json:"targetID"`
+	ProgramID  *string                   `json:"programID"`
+	From       *time.Time                `json:"from"`
+	To         *time.Time                `json:"to"`
+}
+
+// Validate helps with validation of AuditLogFilterInput input
+func (a *AuditLogFilterInput) Validate() error {
+	if a.From != nil && a.To != nil && a.From.After(*a.To) {
+		return fmt.Errorf("the from date cannot be after the to date")
+	}
+
+	return nil
+}
+
+// PINPolicyInput is the PIN policy that an organisation admin sets for the organisation's users
+type PINPolicyInput struct {
+	MinLength         int  `json:"minLength" validate:"min=4,max=8"`
+	DisallowSimplePIN bool `json:"disallowSimplePIN"`
+	HistoryCount      int  `json:"historyCount" validate:"min=0,max=24"`
+	MaxAgeDays        int  `json:"maxAgeDays" validate:"min=0,max=365"`
+	// SecurityQuestionMaxAttempts is optional. The organisation's current value is kept when it is not provided
+	SecurityQuestionMaxAttempts int `json:"securityQuestionMaxAttempts" validate:"omitempty,min=1,max=10"`
+}
+
+// Validate helps with validation of PINPolicyInput fields
+func (p *PINPolicyInput) Validate() error {
+	v := validator.New()
+	err := v.Struct(p)
+	return err
+}
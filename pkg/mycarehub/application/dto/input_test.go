@@ -2,6 +2,7 @@ package dto
 
 import (
 	"testing"
+	"time"
 
 	"github.com/brianvoe/gofakeit"
 	"github.com/savannahghi/enumutils"
@@ -1103,3 +1104,358 @@ func TestVerifyOTPInput_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthorityRoleInput_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   AuthorityRoleInput
+		wantErr bool
+	}{
+		{
+			name: "valid: all params passed",
+			input: AuthorityRoleInput{
+				Name:          gofakeit.JobTitle(),
+				Description:   gofakeit.Sentence(5),
+				ProgramID:     ksuid.New().String(),
+				UserType:      enums.StaffUser,
+				PermissionIDs: []string{ksuid.New().String()},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid: missing program id",
+			input: AuthorityRoleInput{
+				Name:        gofakeit.JobTitle(),
+				Description: gofakeit.Sentence(5),
+				UserType:    enums.StaffUser,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.input.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("AuthorityRoleInput.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRoleAssignmentInput_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   RoleAssignmentInput
+		wantErr bool
+	}{
+		{
+			name: "valid: all params passed",
+			input: RoleAssignmentInput{
+				UserType:  enums.ClientUser,
+				ProfileID: ksuid.New().String(),
+				RoleIDs:   []string{ksuid.New().String()},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid: no roles passed",
+			input: RoleAssignmentInput{
+				UserType:  enums.ClientUser,
+				ProfileID: ksuid.New().String(),
+				RoleIDs:   []string{},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.input.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("RoleAssignmentInput.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuditLogFilterInput_Validate(t *testing.T) {
+	now := time.Now()
+	yesterday := now.Add(-24 * time.Hour)
+
+	tests := []struct {
+		name    string
+		input   AuditLogFilterInput
+		wantErr bool
+	}{
+		{
+			name: "valid: date range passed",
+			input: AuditLogFilterInput{
+				From: &yesterday,
+				To:   &now,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "valid: no filters passed",
+			input:   AuditLogFilterInput{},
+			wantErr: false,
+		},
+		{
+			name: "invalid: from date is after the to date",
+			input: AuditLogFilterInput{
+				From: &now,
+				To:   &yesterday,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.input.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("AuditLogFilterInput.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOTPDeliveryReceiptInput_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   OTPDeliveryReceiptInput
+		wantErr bool
+	}{
+		{
+			name: "valid: delivered message",
+			input: OTPDeliveryReceiptInput{
+				Provider:          enums.OTPDeliveryProviderSILComms,
+				ProviderMessageID: gofakeit.UUID(),
+				Status:            enums.OTPDeliveryStatusDelivered,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid: missing message ID",
+			input: OTPDeliveryReceiptInput{
+				Provider: enums.OTPDeliveryProviderSILComms,
+				Status:   enums.OTPDeliveryStatusDelivered,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid: unknown provider",
+			input: OTPDeliveryReceiptInput{
+				Provider:          enums.OTPDeliveryProvider("invalid"),
+				ProviderMessageID: gofakeit.UUID(),
+				Status:            enums.OTPDeliveryStatusDelivered,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid: unknown status",
+			input: OTPDeliveryReceiptInput{
+				Provider:          enums.OTPDeliveryProviderSILComms,
+				ProviderMessageID: gofakeit.UUID(),
+				Status:            enums.OTPDeliveryStatus("invalid"),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.input.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("OTPDeliveryReceiptInput.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPINPolicyInput_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   PINPolicyInput
+		wantErr bool
+	}{
+		{
+			name: "valid: pin policy",
+			input: PINPolicyInput{
+				MinLength:         6,
+				DisallowSimplePIN: true,
+				HistoryCount:      5,
+				MaxAgeDays:        90,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid: minimum length shorter than four digits",
+			input: PINPolicyInput{
+				MinLength: 3,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid: minimum length longer than eight digits",
+			input: PINPolicyInput{
+				MinLength: 9,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid: negative history count",
+			input: PINPolicyInput{
+				MinLength:    4,
+				HistoryCount: -1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid: negative maximum age",
+			input: PINPolicyInput{
+				MinLength:  4,
+				MaxAgeDays: -1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid: security question attempts",
+			input: PINPolicyInput{
+				MinLength:                   4,
+				SecurityQuestionMaxAttempts: 5,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid: too many security question attempts",
+			input: PINPolicyInput{
+				MinLength:                   4,
+				SecurityQuestionMaxAttempts: 11,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.input.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("PINPolicyInput.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClientTransferInput_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   ClientTransferInput
+		wantErr bool
+	}{
+		{
+			name: "valid: transfer with consented history",
+			input: ClientTransferInput{
+				ClientID:        gofakeit.UUID(),
+				FacilityID:      gofakeit.UUID(),
+				Reason:          "relocated",
+				CarryHistory:    []enums.ClientTransferHistory{enums.ClientTransferHistoryHealthDiary},
+				ClientConsented: true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid: transfer without history",
+			input: ClientTransferInput{
+				ClientID:   gofakeit.UUID(),
+				FacilityID: gofakeit.UUID(),
+				Reason:     "relocated",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid: missing reason",
+			input: ClientTransferInput{
+				ClientID:   gofakeit.UUID(),
+				FacilityID: gofakeit.UUID(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid: unknown history",
+			input: ClientTransferInput{
+				ClientID:        gofakeit.UUID(),
+				FacilityID:      gofakeit.UUID(),
+				Reason:          "relocated",
+				CarryHistory:    []enums.ClientTransferHistory{"invalid"},
+				ClientConsented: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid: history without consent",
+			input: ClientTransferInput{
+				ClientID:     gofakeit.UUID(),
+				FacilityID:   gofakeit.UUID(),
+				Reason:       "relocated",
+				CarryHistory: []enums.ClientTransferHistory{enums.ClientTransferHistoryAppointments},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.input.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("ClientTransferInput.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestServiceRequestSLAInput_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   ServiceRequestSLAInput
+		wantErr bool
+	}{
+		{
+			name: "valid: service request sla",
+			input: ServiceRequestSLAInput{
+				RequestType:     enums.ServiceRequestTypeSurveyRedFlag,
+				ResolutionHours: 48,
+				EscalationHours: 24,
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid: escalate to the admins immediately",
+			input: ServiceRequestSLAInput{
+				RequestType:     enums.ServiceRequestTypeRedFlag,
+				ResolutionHours: 12,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid: missing resolution hours",
+			input: ServiceRequestSLAInput{
+				RequestType: enums.ServiceRequestTypeSurveyRedFlag,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid: negative escalation hours",
+			input: ServiceRequestSLAInput{
+				RequestType:     enums.ServiceRequestTypeSurveyRedFlag,
+				ResolutionHours: 48,
+				EscalationHours: -1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid: unknown request type",
+			input: ServiceRequestSLAInput{
+				RequestType:     "invalid",
+				ResolutionHours: 48,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.input.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("ServiceRequestSLAInput.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
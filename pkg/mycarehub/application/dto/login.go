@@ -21,9 +21,10 @@ type User struct {
 	Username string `json:"username"`
 	Active   bool   `json:"active"`
 
-	NextAllowedLogin time.Time `json:"-"`
-	FailedLoginCount int       `json:"-"`
-	CurrentProgramID string    `json:"-"`
+	NextAllowedLogin      time.Time `json:"-"`
+	FailedLoginCount      int       `json:"-"`
+	CurrentProgramID      string    `json:"-"`
+	CurrentOrganizationID string    `json:"-"`
 
 	PinChangeRequired      bool `json:"pinChangeRequired"`
 	HasSetPin              bool `json:"hasSetPin"`
@@ -34,6 +35,10 @@ type User struct {
 	FailedSecurityCount    int  `json:"-"`
 	PinUpdateRequired      bool `json:"pinUpdateRequired"`
 	HasSetNickname         bool `json:"hasSetNickname"`
+	TOTPEnrollmentRequired bool `json:"totpEnrollmentRequired"`
+
+	// TOTPVerified is true when the user has logged in with a code from their authenticator app
+	TOTPVerified bool `json:"-"`
 }
 
 // Response models the response that will be returned after a user logs in
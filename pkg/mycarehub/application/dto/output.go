@@ -35,6 +35,28 @@ type ClientRegistrationOutput struct {
 	Caregiver         string             `json:"caregiver"`
 }
 
+// BulkClientRegistrationOutput is the report of a bulk client registration. In a dry run no client is registered
+type BulkClientRegistrationOutput struct {
+	DryRun     bool                           `json:"dryRun"`
+	TotalRows  int                            `json:"totalRows"`
+	ValidRows  int                            `json:"validRows"`
+	Registered int                            `json:"registered"`
+	Errors     []*BulkClientRegistrationError `json:"errors"`
+}
+
+// BulkClientRegistrationError lists the problems with a row of a bulk client registration csv
+type BulkClientRegistrationError struct {
+	Row       int      `json:"row"`
+	CCCNumber string   `json:"cccNumber"`
+	Errors    []string `json:"errors"`
+}
+
+// ClientDataExport is a zip archive of all the data held about a client. The content is base64 encoded
+type ClientDataExport struct {
+	FileName string `json:"fileName"`
+	Content  string `json:"content"`
+}
+
 // FacilityAppointmentsResponse is the response sent after creating/updating an appointment
 type FacilityAppointmentsResponse struct {
 	MFLCode      string                `json:"MFLCODE"`
@@ -258,6 +280,7 @@ type PubsubCreateCMSClientPayload struct {
 	DateOfBirth    scalarutils.Date `json:"date_of_birth"`
 	OrganisationID string           `json:"organisation_id"`
 	ProgramID      string           `json:"program_id"`
+	PhoneNumber    string           `json:"phone_number"`
 }
 
 // PubsubCreateCMSStaffPayload is the payload passed when creating a staff user on the CMS service
@@ -509,3 +532,21 @@ type SignUpPayload struct {
 	Facility    *domain.Facility
 	Matrix      *domain.MatrixAuth
 }
+
+// OauthClientRegistrationOutput is the information about a dynamically registered client (RFC 7591 and RFC 7592)
+type OauthClientRegistrationOutput struct {
+	ClientID string `json:"client_id"`
+	// ClientSecret is only returned when the client is registered since only its hash is stored
+	ClientSecret          string `json:"client_secret,omitempty"`
+	ClientSecretExpiresAt int64  `json:"client_secret_expires_at"`
+
+	RegistrationAccessToken string `json:"registration_access_token"`
+	RegistrationClientURI   string `json:"registration_client_uri"`
+
+	ClientName              string   `json:"client_name"`
+	RedirectURIs            []string `json:"redirect_uris"`
+	GrantTypes              []string `json:"grant_types"`
+	ResponseTypes           []string `json:"response_types"`
+	TokenEndpointAuthMethod string   `json:"token_endpoint_auth_method"`
+	Scope                   string   `json:"scope"`
+}
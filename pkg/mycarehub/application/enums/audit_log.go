@@ -0,0 +1,230 @@
+package enums
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// AuditLogRecordType is the type of event recorded in the audit log
+type AuditLogRecordType string
+
+const (
+	// AuditLogFacilityAccessDenied records an attempt by a staff to read data belonging to a facility they are not assigned to
+	AuditLogFacilityAccessDenied AuditLogRecordType = "FACILITY_ACCESS_DENIED"
+
+	// AuditLogPINReset records a user resetting their PIN
+	AuditLogPINReset AuditLogRecordType = "PIN_RESET"
+
+	// AuditLogPINResetVerification records a staff approving or rejecting a PIN reset service request
+	AuditLogPINResetVerification AuditLogRecordType = "PIN_RESET_VERIFICATION"
+
+	// AuditLogClientProfileDeletion records the deletion of a client's profile
+	AuditLogClientProfileDeletion AuditLogRecordType = "CLIENT_PROFILE_DELETION"
+
+	// AuditLogClientFacilityTransfer records a client being transferred to a different facility
+	AuditLogClientFacilityTransfer AuditLogRecordType = "CLIENT_FACILITY_TRANSFER"
+
+	// AuditLogCaregiverConsentChange records a change in the consent between a client and their caregiver
+	AuditLogCaregiverConsentChange AuditLogRecordType = "CAREGIVER_CONSENT_CHANGE"
+
+	// AuditLogRoleChange records a change in a role's permissions or the roles assigned to a user
+	AuditLogRoleChange AuditLogRecordType = "ROLE_CHANGE"
+
+	// AuditLogOrganisationAdminChange records a staff being granted or denied organisation admin rights
+	AuditLogOrganisationAdminChange AuditLogRecordType = "ORGANISATION_ADMIN_CHANGE"
+
+	// AuditLogTOTPChange records a user enrolling or removing their authenticator app or regenerating their recovery codes
+	AuditLogTOTPChange AuditLogRecordType = "TOTP_CHANGE"
+
+	// AuditLogOrganisationSecurityPolicyChange records a change in the security requirements an organisation sets for its users
+	AuditLogOrganisationSecurityPolicyChange AuditLogRecordType = "ORGANISATION_SECURITY_POLICY_CHANGE"
+
+	// AuditLogSessionRevocation records a staff logging a user out of their sessions
+	AuditLogSessionRevocation AuditLogRecordType = "SESSION_REVOCATION"
+
+	// AuditLogSecurityQuestionsReset records a staff clearing a user's security question responses so that they set new ones
+	AuditLogSecurityQuestionsReset AuditLogRecordType = "SECURITY_QUESTIONS_RESET"
+
+	// AuditLogClientMerge records a duplicate client's records being merged into another client
+	AuditLogClientMerge AuditLogRecordType = "CLIENT_MERGE"
+
+	// AuditLogClientDataExport records all the data held about a client being exported, for example at the client's request
+	AuditLogClientDataExport AuditLogRecordType = "CLIENT_DATA_EXPORT"
+
+	// AuditLogClientErasure records a client's personal information being anonymized after they asked for their data to be erased
+	AuditLogClientErasure AuditLogRecordType = "CLIENT_ERASURE"
+
+	// AuditLogCaregiverDelegationChange records a caregiver's delegation being revoked, ended or expiring
+	AuditLogCaregiverDelegationChange AuditLogRecordType = "CAREGIVER_DELEGATION_CHANGE"
+
+	// AuditLogPhoneNumberChange records a user's phone number being changed, either by the user or with the help of a staff
+	AuditLogPhoneNumberChange AuditLogRecordType = "PHONE_NUMBER_CHANGE"
+
+	// AuditLogStaffOffboarding records a staff being removed from an organisation when they leave
+	AuditLogStaffOffboarding AuditLogRecordType = "STAFF_OFFBOARDING"
+
+	// AuditLogClientTransfer records a client being transferred to another facility, program or organisation
+	AuditLogClientTransfer AuditLogRecordType = "CLIENT_TRANSFER"
+
+	// AuditLogServiceRequestSLAChange records a change to how long a program's service requests can wait before they are escalated
+	AuditLogServiceRequestSLAChange AuditLogRecordType = "SERVICE_REQUEST_SLA_CHANGE"
+
+	// AuditLogPatientSafetyIncident records a suicide-risk red flag that was not resolved within 24 hours
+	AuditLogPatientSafetyIncident AuditLogRecordType = "PATIENT_SAFETY_INCIDENT"
+)
+
+// IsValid returns true if an audit log record type is valid
+func (a AuditLogRecordType) IsValid() bool {
+	switch a {
+	case AuditLogFacilityAccessDenied, AuditLogPINReset, AuditLogPINResetVerification, AuditLogClientProfileDeletion,
+		AuditLogClientFacilityTransfer, AuditLogCaregiverConsentChange, AuditLogRoleChange, AuditLogOrganisationAdminChange,
+		AuditLogTOTPChange, AuditLogOrganisationSecurityPolicyChange, AuditLogSessionRevocation, AuditLogSecurityQuestionsReset,
+		AuditLogClientMerge, AuditLogClientDataExport, AuditLogClientErasure, AuditLogCaregiverDelegationChange,
+		AuditLogPhoneNumberChange, AuditLogStaffOffboarding, AuditLogClientTransfer, AuditLogServiceRequestSLAChange,
+		AuditLogPatientSafetyIncident:
+		return true
+	}
+	return false
+}
+
+func (a AuditLogRecordType) String() string {
+	return string(a)
+}
+
+// UnmarshalGQL converts the supplied value to an audit log record type.
+func (a *AuditLogRecordType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*a = AuditLogRecordType(str)
+	if !a.IsValid() {
+		return fmt.Errorf("%s is not a valid AuditLogRecordType", str)
+	}
+	return nil
+}
+
+// MarshalGQL writes the audit log record type to the supplied writer
+func (a AuditLogRecordType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(a.String()))
+}
+
+// AuditLogTargetType is the type of record affected by an action recorded in the audit log
+type AuditLogTargetType string
+
+const (
+	// AuditLogTargetUser is a user's account
+	AuditLogTargetUser AuditLogTargetType = "USER"
+
+	// AuditLogTargetClient is a client's profile
+	AuditLogTargetClient AuditLogTargetType = "CLIENT"
+
+	// AuditLogTargetStaff is a staff's profile
+	AuditLogTargetStaff AuditLogTargetType = "STAFF"
+
+	// AuditLogTargetCaregiver is a caregiver's profile
+	AuditLogTargetCaregiver AuditLogTargetType = "CAREGIVER"
+
+	// AuditLogTargetFacility is a facility
+	AuditLogTargetFacility AuditLogTargetType = "FACILITY"
+
+	// AuditLogTargetRole is an authority role
+	AuditLogTargetRole AuditLogTargetType = "ROLE"
+
+	// AuditLogTargetServiceRequest is a service request
+	AuditLogTargetServiceRequest AuditLogTargetType = "SERVICE_REQUEST"
+
+	// AuditLogTargetOrganisation is an organisation
+	AuditLogTargetOrganisation AuditLogTargetType = "ORGANISATION"
+
+	// AuditLogTargetProgram is a program
+	AuditLogTargetProgram AuditLogTargetType = "PROGRAM"
+)
+
+// IsValid returns true if an audit log target type is valid
+func (a AuditLogTargetType) IsValid() bool {
+	switch a {
+	case AuditLogTargetUser, AuditLogTargetClient, AuditLogTargetStaff, AuditLogTargetCaregiver,
+		AuditLogTargetFacility, AuditLogTargetRole, AuditLogTargetServiceRequest, AuditLogTargetOrganisation,
+		AuditLogTargetProgram:
+		return true
+	}
+	return false
+}
+
+func (a AuditLogTargetType) String() string {
+	return string(a)
+}
+
+// UnmarshalGQL converts the supplied value to an audit log target type.
+func (a *AuditLogTargetType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*a = AuditLogTargetType(str)
+	if !a.IsValid() {
+		return fmt.Errorf("%s is not a valid AuditLogTargetType", str)
+	}
+	return nil
+}
+
+// MarshalGQL writes the audit log target type to the supplied writer
+func (a AuditLogTargetType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(a.String()))
+}
+
+// AuditTrailIssueType is the type of problem found when verifying an organisation's audit log chain
+type AuditTrailIssueType string
+
+const (
+	// AuditTrailIssueMissingEntry is raised when an entry is missing from the chain
+	AuditTrailIssueMissingEntry AuditTrailIssueType = "MISSING_ENTRY"
+
+	// AuditTrailIssueModifiedEntry is raised when an entry's content no longer matches its hash
+	AuditTrailIssueModifiedEntry AuditTrailIssueType = "MODIFIED_ENTRY"
+
+	// AuditTrailIssueBrokenLink is raised when an entry does not link to the hash of the entry before it
+	AuditTrailIssueBrokenLink AuditTrailIssueType = "BROKEN_LINK"
+
+	// AuditTrailIssueUnchainedEntry is raised when an entry was recorded without being linked to the chain
+	AuditTrailIssueUnchainedEntry AuditTrailIssueType = "UNCHAINED_ENTRY"
+
+	// AuditTrailIssueTruncatedChain is raised when the chain does not end at the last entry recorded for the organisation
+	AuditTrailIssueTruncatedChain AuditTrailIssueType = "TRUNCATED_CHAIN"
+)
+
+// IsValid returns true if an audit trail issue type is valid
+func (a AuditTrailIssueType) IsValid() bool {
+	switch a {
+	case AuditTrailIssueMissingEntry, AuditTrailIssueModifiedEntry, AuditTrailIssueBrokenLink, AuditTrailIssueUnchainedEntry, AuditTrailIssueTruncatedChain:
+		return true
+	}
+	return false
+}
+
+func (a AuditTrailIssueType) String() string {
+	return string(a)
+}
+
+// UnmarshalGQL converts the supplied value to an audit trail issue type.
+func (a *AuditTrailIssueType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*a = AuditTrailIssueType(str)
+	if !a.IsValid() {
+		return fmt.Errorf("%s is not a valid AuditTrailIssueType", str)
+	}
+	return nil
+}
+
+// MarshalGQL writes the audit trail issue type to the supplied writer
+func (a AuditTrailIssueType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(a.String()))
+}
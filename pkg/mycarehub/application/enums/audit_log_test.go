@@ -0,0 +1,456 @@
+package enums
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+)
+
+func TestAuditLogRecordType_String(t *testing.T) {
+	tests := []struct {
+		name string
+		e    AuditLogRecordType
+		want string
+	}{
+		{
+			name: "FACILITY_ACCESS_DENIED",
+			e:    AuditLogFacilityAccessDenied,
+			want: "FACILITY_ACCESS_DENIED",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.e.String(); got != tt.want {
+				t.Errorf("AuditLogRecordType.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuditLogRecordType_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		e    AuditLogRecordType
+		want bool
+	}{
+		{
+			name: "valid type",
+			e:    AuditLogFacilityAccessDenied,
+			want: true,
+		},
+		{
+			name: "valid role change type",
+			e:    AuditLogRoleChange,
+			want: true,
+		},
+		{
+			name: "valid TOTP change type",
+			e:    AuditLogTOTPChange,
+			want: true,
+		},
+		{
+			name: "valid organisation security policy change type",
+			e:    AuditLogOrganisationSecurityPolicyChange,
+			want: true,
+		},
+		{
+			name: "valid session revocation type",
+			e:    AuditLogSessionRevocation,
+			want: true,
+		},
+		{
+			name: "valid security questions reset type",
+			e:    AuditLogSecurityQuestionsReset,
+			want: true,
+		},
+		{
+			name: "valid client merge type",
+			e:    AuditLogClientMerge,
+			want: true,
+		},
+		{
+			name: "valid client data export type",
+			e:    AuditLogClientDataExport,
+			want: true,
+		},
+		{
+			name: "valid client erasure type",
+			e:    AuditLogClientErasure,
+			want: true,
+		},
+		{
+			name: "valid caregiver delegation change type",
+			e:    AuditLogCaregiverDelegationChange,
+			want: true,
+		},
+		{
+			name: "valid phone number change type",
+			e:    AuditLogPhoneNumberChange,
+			want: true,
+		},
+		{
+			name: "valid staff offboarding type",
+			e:    AuditLogStaffOffboarding,
+			want: true,
+		},
+		{
+			name: "valid client transfer type",
+			e:    AuditLogClientTransfer,
+			want: true,
+		},
+		{
+			name: "valid service request sla change type",
+			e:    AuditLogServiceRequestSLAChange,
+			want: true,
+		},
+		{
+			name: "valid patient safety incident type",
+			e:    AuditLogPatientSafetyIncident,
+			want: true,
+		},
+		{
+			name: "invalid type",
+			e:    AuditLogRecordType("invalid"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.e.IsValid(); got != tt.want {
+				t.Errorf("AuditLogRecordType.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuditLogRecordType_UnmarshalGQL(t *testing.T) {
+	value := AuditLogFacilityAccessDenied
+	invalid := AuditLogRecordType("invalid")
+	type args struct {
+		v interface{}
+	}
+	tests := []struct {
+		name    string
+		e       *AuditLogRecordType
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "valid type",
+			e:    &value,
+			args: args{
+				v: "FACILITY_ACCESS_DENIED",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid type",
+			e:    &invalid,
+			args: args{
+				v: "this is not a valid type",
+			},
+			wantErr: true,
+		},
+		{
+			name: "non string type",
+			e:    &invalid,
+			args: args{
+				v: 1,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.e.UnmarshalGQL(tt.args.v); (err != nil) != tt.wantErr {
+				t.Errorf("AuditLogRecordType.UnmarshalGQL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuditLogRecordType_MarshalGQL(t *testing.T) {
+	w := &bytes.Buffer{}
+	tests := []struct {
+		name  string
+		e     AuditLogRecordType
+		b     *bytes.Buffer
+		wantW string
+	}{
+		{
+			name:  "valid type enums",
+			e:     AuditLogFacilityAccessDenied,
+			b:     w,
+			wantW: strconv.Quote("FACILITY_ACCESS_DENIED"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.e.MarshalGQL(tt.b)
+			if gotW := w.String(); gotW != tt.wantW {
+				t.Errorf("AuditLogRecordType.MarshalGQL() = %v, want %v", gotW, tt.wantW)
+			}
+		})
+	}
+}
+
+func TestAuditLogTargetType_String(t *testing.T) {
+	tests := []struct {
+		name string
+		e    AuditLogTargetType
+		want string
+	}{
+		{
+			name: "FACILITY",
+			e:    AuditLogTargetFacility,
+			want: "FACILITY",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.e.String(); got != tt.want {
+				t.Errorf("AuditLogTargetType.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuditLogTargetType_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		e    AuditLogTargetType
+		want bool
+	}{
+		{
+			name: "valid type",
+			e:    AuditLogTargetFacility,
+			want: true,
+		},
+		{
+			name: "valid role type",
+			e:    AuditLogTargetRole,
+			want: true,
+		},
+		{
+			name: "valid organisation type",
+			e:    AuditLogTargetOrganisation,
+			want: true,
+		},
+		{
+			name: "valid program type",
+			e:    AuditLogTargetProgram,
+			want: true,
+		},
+		{
+			name: "invalid type",
+			e:    AuditLogTargetType("invalid"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.e.IsValid(); got != tt.want {
+				t.Errorf("AuditLogTargetType.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuditLogTargetType_UnmarshalGQL(t *testing.T) {
+	value := AuditLogTargetFacility
+	invalid := AuditLogTargetType("invalid")
+	type args struct {
+		v interface{}
+	}
+	tests := []struct {
+		name    string
+		e       *AuditLogTargetType
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "valid type",
+			e:    &value,
+			args: args{
+				v: "FACILITY",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid type",
+			e:    &invalid,
+			args: args{
+				v: "this is not a valid type",
+			},
+			wantErr: true,
+		},
+		{
+			name: "non string type",
+			e:    &invalid,
+			args: args{
+				v: 1,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.e.UnmarshalGQL(tt.args.v); (err != nil) != tt.wantErr {
+				t.Errorf("AuditLogTargetType.UnmarshalGQL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuditLogTargetType_MarshalGQL(t *testing.T) {
+	w := &bytes.Buffer{}
+	tests := []struct {
+		name  string
+		e     AuditLogTargetType
+		b     *bytes.Buffer
+		wantW string
+	}{
+		{
+			name:  "valid type enums",
+			e:     AuditLogTargetFacility,
+			b:     w,
+			wantW: strconv.Quote("FACILITY"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.e.MarshalGQL(tt.b)
+			if gotW := w.String(); gotW != tt.wantW {
+				t.Errorf("AuditLogTargetType.MarshalGQL() = %v, want %v", gotW, tt.wantW)
+			}
+		})
+	}
+}
+
+func TestAuditTrailIssueType_String(t *testing.T) {
+	tests := []struct {
+		name string
+		e    AuditTrailIssueType
+		want string
+	}{
+		{
+			name: "MODIFIED_ENTRY",
+			e:    AuditTrailIssueModifiedEntry,
+			want: "MODIFIED_ENTRY",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.e.String(); got != tt.want {
+				t.Errorf("AuditTrailIssueType.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuditTrailIssueType_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		e    AuditTrailIssueType
+		want bool
+	}{
+		{
+			name: "valid type",
+			e:    AuditTrailIssueModifiedEntry,
+			want: true,
+		},
+		{
+			name: "valid broken link type",
+			e:    AuditTrailIssueBrokenLink,
+			want: true,
+		},
+		{
+			name: "valid truncated chain type",
+			e:    AuditTrailIssueTruncatedChain,
+			want: true,
+		},
+		{
+			name: "invalid type",
+			e:    AuditTrailIssueType("invalid"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.e.IsValid(); got != tt.want {
+				t.Errorf("AuditTrailIssueType.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuditTrailIssueType_UnmarshalGQL(t *testing.T) {
+	value := AuditTrailIssueModifiedEntry
+	invalid := AuditTrailIssueType("invalid")
+	type args struct {
+		v interface{}
+	}
+	tests := []struct {
+		name    string
+		e       *AuditTrailIssueType
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "valid type",
+			e:    &value,
+			args: args{
+				v: "MODIFIED_ENTRY",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid type",
+			e:    &invalid,
+			args: args{
+				v: "this is not a valid type",
+			},
+			wantErr: true,
+		},
+		{
+			name: "non string type",
+			e:    &invalid,
+			args: args{
+				v: 1,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.e.UnmarshalGQL(tt.args.v); (err != nil) != tt.wantErr {
+				t.Errorf("AuditTrailIssueType.UnmarshalGQL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuditTrailIssueType_MarshalGQL(t *testing.T) {
+	w := &bytes.Buffer{}
+	tests := []struct {
+		name  string
+		e     AuditTrailIssueType
+		b     *bytes.Buffer
+		wantW string
+	}{
+		{
+			name:  "valid type enums",
+			e:     AuditTrailIssueModifiedEntry,
+			b:     w,
+			wantW: strconv.Quote("MODIFIED_ENTRY"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.e.MarshalGQL(tt.b)
+			if gotW := w.String(); gotW != tt.wantW {
+				t.Errorf("AuditTrailIssueType.MarshalGQL() = %v, want %v", gotW, tt.wantW)
+			}
+		})
+	}
+}
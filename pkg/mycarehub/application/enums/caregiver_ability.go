@@ -0,0 +1,68 @@
+package enums
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// CaregiverAbility is something a client allows a caregiver to do on their behalf
+type CaregiverAbility string
+
+const (
+	// CaregiverAbilityViewAppointments allows a caregiver to view the client's appointments
+	CaregiverAbilityViewAppointments CaregiverAbility = "VIEW_APPOINTMENTS"
+
+	// CaregiverAbilityRescheduleAppointments allows a caregiver to ask for the client's appointments to be rescheduled
+	CaregiverAbilityRescheduleAppointments CaregiverAbility = "RESCHEDULE_APPOINTMENTS"
+
+	// CaregiverAbilityRecordHealthDiary allows a caregiver to record health diary entries for the client
+	CaregiverAbilityRecordHealthDiary CaregiverAbility = "RECORD_HEALTH_DIARY"
+
+	// CaregiverAbilityViewScreeningResults allows a caregiver to view the client's screening tool responses
+	CaregiverAbilityViewScreeningResults CaregiverAbility = "VIEW_SCREENING_RESULTS"
+)
+
+// AllCaregiverAbilities holds all the abilities that can be delegated to a caregiver
+var AllCaregiverAbilities = []CaregiverAbility{
+	CaregiverAbilityViewAppointments,
+	CaregiverAbilityRescheduleAppointments,
+	CaregiverAbilityRecordHealthDiary,
+	CaregiverAbilityViewScreeningResults,
+}
+
+// IsValid returns true if a caregiver ability is valid
+func (c CaregiverAbility) IsValid() bool {
+	switch c {
+	case CaregiverAbilityViewAppointments,
+		CaregiverAbilityRescheduleAppointments,
+		CaregiverAbilityRecordHealthDiary,
+		CaregiverAbilityViewScreeningResults:
+		return true
+	}
+	return false
+}
+
+// String converts the caregiver ability enum to a string
+func (c CaregiverAbility) String() string {
+	return string(c)
+}
+
+// UnmarshalGQL converts the supplied value to a caregiver ability.
+func (c *CaregiverAbility) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*c = CaregiverAbility(str)
+	if !c.IsValid() {
+		return fmt.Errorf("%s is not a valid CaregiverAbility", str)
+	}
+	return nil
+}
+
+// MarshalGQL writes the caregiver ability to the supplied writer
+func (c CaregiverAbility) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(c.String()))
+}
@@ -0,0 +1,78 @@
+package enums
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+)
+
+func TestCaregiverAbility_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		c    CaregiverAbility
+		want bool
+	}{
+		{
+			name: "Happy Case - Valid ability",
+			c:    CaregiverAbilityRecordHealthDiary,
+			want: true,
+		},
+		{
+			name: "Sad Case - Invalid ability",
+			c:    CaregiverAbility("invalid"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.IsValid(); got != tt.want {
+				t.Errorf("CaregiverAbility.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCaregiverAbility_UnmarshalGQL(t *testing.T) {
+	value := CaregiverAbilityViewAppointments
+	invalid := CaregiverAbility("invalid")
+	tests := []struct {
+		name    string
+		c       *CaregiverAbility
+		v       interface{}
+		wantErr bool
+	}{
+		{
+			name:    "Happy Case - Valid ability",
+			c:       &value,
+			v:       "VIEW_APPOINTMENTS",
+			wantErr: false,
+		},
+		{
+			name:    "Sad Case - Invalid ability",
+			c:       &invalid,
+			v:       "invalid",
+			wantErr: true,
+		},
+		{
+			name:    "Sad Case - Non string value",
+			c:       &invalid,
+			v:       1,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.c.UnmarshalGQL(tt.v); (err != nil) != tt.wantErr {
+				t.Errorf("CaregiverAbility.UnmarshalGQL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCaregiverAbility_MarshalGQL(t *testing.T) {
+	w := &bytes.Buffer{}
+	CaregiverAbilityViewScreeningResults.MarshalGQL(w)
+	if got := w.String(); got != strconv.Quote("VIEW_SCREENING_RESULTS") {
+		t.Errorf("CaregiverAbility.MarshalGQL() = %v, want %v", got, strconv.Quote("VIEW_SCREENING_RESULTS"))
+	}
+}
@@ -0,0 +1,63 @@
+package enums
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// ClientTransferHistory is a part of a client's history that can be carried to the program they are transferred to
+type ClientTransferHistory string
+
+const (
+	// ClientTransferHistoryHealthDiary carries the client's health diary entries
+	ClientTransferHistoryHealthDiary ClientTransferHistory = "HEALTH_DIARY"
+
+	// ClientTransferHistoryAppointments carries the client's appointments
+	ClientTransferHistoryAppointments ClientTransferHistory = "APPOINTMENTS"
+
+	// ClientTransferHistoryScreeningToolResponses carries the client's screening tool responses
+	ClientTransferHistoryScreeningToolResponses ClientTransferHistory = "SCREENING_TOOL_RESPONSES"
+)
+
+// AllClientTransferHistory holds all the parts of a client's history that can be carried in a transfer
+var AllClientTransferHistory = []ClientTransferHistory{
+	ClientTransferHistoryHealthDiary,
+	ClientTransferHistoryAppointments,
+	ClientTransferHistoryScreeningToolResponses,
+}
+
+// IsValid returns true if a client transfer history is valid
+func (c ClientTransferHistory) IsValid() bool {
+	switch c {
+	case ClientTransferHistoryHealthDiary,
+		ClientTransferHistoryAppointments,
+		ClientTransferHistoryScreeningToolResponses:
+		return true
+	}
+	return false
+}
+
+// String converts the client transfer history enum to a string
+func (c ClientTransferHistory) String() string {
+	return string(c)
+}
+
+// UnmarshalGQL converts the supplied value to a client transfer history.
+func (c *ClientTransferHistory) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*c = ClientTransferHistory(str)
+	if !c.IsValid() {
+		return fmt.Errorf("%s is not a valid ClientTransferHistory", str)
+	}
+	return nil
+}
+
+// MarshalGQL writes the client transfer history to the supplied writer
+func (c ClientTransferHistory) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(c.String()))
+}
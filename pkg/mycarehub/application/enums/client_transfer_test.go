@@ -0,0 +1,78 @@
+package enums
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+)
+
+func TestClientTransferHistory_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		c    ClientTransferHistory
+		want bool
+	}{
+		{
+			name: "Happy Case - Valid history",
+			c:    ClientTransferHistoryHealthDiary,
+			want: true,
+		},
+		{
+			name: "Sad Case - Invalid history",
+			c:    ClientTransferHistory("invalid"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.IsValid(); got != tt.want {
+				t.Errorf("ClientTransferHistory.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientTransferHistory_UnmarshalGQL(t *testing.T) {
+	value := ClientTransferHistoryAppointments
+	invalid := ClientTransferHistory("invalid")
+	tests := []struct {
+		name    string
+		c       *ClientTransferHistory
+		v       interface{}
+		wantErr bool
+	}{
+		{
+			name:    "Happy Case - Valid history",
+			c:       &value,
+			v:       "APPOINTMENTS",
+			wantErr: false,
+		},
+		{
+			name:    "Sad Case - Invalid history",
+			c:       &invalid,
+			v:       "invalid",
+			wantErr: true,
+		},
+		{
+			name:    "Sad Case - Non string value",
+			c:       &invalid,
+			v:       1,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.c.UnmarshalGQL(tt.v); (err != nil) != tt.wantErr {
+				t.Errorf("ClientTransferHistory.UnmarshalGQL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClientTransferHistory_MarshalGQL(t *testing.T) {
+	w := &bytes.Buffer{}
+	ClientTransferHistoryScreeningToolResponses.MarshalGQL(w)
+	if got := w.String(); got != strconv.Quote("SCREENING_TOOL_RESPONSES") {
+		t.Errorf("ClientTransferHistory.MarshalGQL() = %v, want %v", got, strconv.Quote("SCREENING_TOOL_RESPONSES"))
+	}
+}
@@ -0,0 +1,57 @@
+package enums
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// DuplicateClientMatch is a detail that a client shares with another client that may be a duplicate of them
+type DuplicateClientMatch string
+
+const (
+	// DuplicateClientMatchCCCNumber is a shared CCC number
+	DuplicateClientMatchCCCNumber DuplicateClientMatch = "CCC_NUMBER"
+
+	// DuplicateClientMatchPhoneNumber is a shared phone number
+	DuplicateClientMatchPhoneNumber DuplicateClientMatch = "PHONE_NUMBER"
+
+	// DuplicateClientMatchName is the same name once the case and spacing are ignored
+	DuplicateClientMatchName DuplicateClientMatch = "NAME"
+
+	// DuplicateClientMatchDateOfBirth is the same date of birth
+	DuplicateClientMatchDateOfBirth DuplicateClientMatch = "DATE_OF_BIRTH"
+)
+
+// IsValid returns true if a duplicate client match is valid
+func (d DuplicateClientMatch) IsValid() bool {
+	switch d {
+	case DuplicateClientMatchCCCNumber, DuplicateClientMatchPhoneNumber, DuplicateClientMatchName, DuplicateClientMatchDateOfBirth:
+		return true
+	}
+	return false
+}
+
+// String converts the duplicate client match enum to a string
+func (d DuplicateClientMatch) String() string {
+	return string(d)
+}
+
+// UnmarshalGQL converts the supplied value to a duplicate client match.
+func (d *DuplicateClientMatch) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*d = DuplicateClientMatch(str)
+	if !d.IsValid() {
+		return fmt.Errorf("%s is not a valid DuplicateClientMatch", str)
+	}
+	return nil
+}
+
+// MarshalGQL writes the duplicate client match to the supplied writer
+func (d DuplicateClientMatch) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(d.String()))
+}
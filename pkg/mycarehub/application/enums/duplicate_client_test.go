@@ -0,0 +1,125 @@
+package enums
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+)
+
+func TestDuplicateClientMatch_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		d    DuplicateClientMatch
+		want bool
+	}{
+		{
+			name: "Happy Case - Valid match",
+			d:    DuplicateClientMatchCCCNumber,
+			want: true,
+		},
+		{
+			name: "Sad Case - Invalid match",
+			d:    DuplicateClientMatch("Not a match"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.d.IsValid(); got != tt.want {
+				t.Errorf("DuplicateClientMatch.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuplicateClientMatch_String(t *testing.T) {
+	tests := []struct {
+		name string
+		d    DuplicateClientMatch
+		want string
+	}{
+		{
+			name: "Happy Case",
+			d:    DuplicateClientMatchDateOfBirth,
+			want: "DATE_OF_BIRTH",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.d.String(); got != tt.want {
+				t.Errorf("DuplicateClientMatch.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuplicateClientMatch_UnmarshalGQL(t *testing.T) {
+	value := DuplicateClientMatchName
+	invalid := DuplicateClientMatch("invalid")
+	type args struct {
+		v interface{}
+	}
+	tests := []struct {
+		name    string
+		d       *DuplicateClientMatch
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "valid type",
+			d:    &value,
+			args: args{
+				v: "NAME",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid type",
+			d:    &invalid,
+			args: args{
+				v: "this is not a valid type",
+			},
+			wantErr: true,
+		},
+		{
+			name: "non string type",
+			d:    &invalid,
+			args: args{
+				v: 1,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.d.UnmarshalGQL(tt.args.v); (err != nil) != tt.wantErr {
+				t.Errorf("DuplicateClientMatch.UnmarshalGQL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDuplicateClientMatch_MarshalGQL(t *testing.T) {
+	w := &bytes.Buffer{}
+	tests := []struct {
+		name  string
+		d     DuplicateClientMatch
+		b     *bytes.Buffer
+		wantW string
+	}{
+		{
+			name:  "valid type enums",
+			d:     DuplicateClientMatchPhoneNumber,
+			b:     w,
+			wantW: strconv.Quote("PHONE_NUMBER"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.d.MarshalGQL(tt.b)
+			if gotW := w.String(); gotW != tt.wantW {
+				t.Errorf("DuplicateClientMatch.MarshalGQL() = %v, want %v", gotW, tt.wantW)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package enums
+
+// LoginRiskFlag is an anomaly detected when a user logs in
+type LoginRiskFlag string
+
+const (
+	// LoginRiskNewDevice flags a login from a device that the user has not logged in from before
+	LoginRiskNewDevice LoginRiskFlag = "NEW_DEVICE"
+
+	// LoginRiskUnusualCountry flags a login from a country that the user has not logged in from before
+	LoginRiskUnusualCountry LoginRiskFlag = "UNUSUAL_COUNTRY"
+
+	// LoginRiskSharedIPAddress flags a login from an IP address that many other accounts have recently logged in from
+	// e.g a shared phone or credential stuffing
+	LoginRiskSharedIPAddress LoginRiskFlag = "SHARED_IP_ADDRESS"
+)
+
+// IsValid returns true if a login risk flag is valid
+func (l LoginRiskFlag) IsValid() bool {
+	switch l {
+	case LoginRiskNewDevice, LoginRiskUnusualCountry, LoginRiskSharedIPAddress:
+		return true
+	}
+	return false
+}
+
+// String converts the login risk flag enum to a string
+func (l LoginRiskFlag) String() string {
+	return string(l)
+}
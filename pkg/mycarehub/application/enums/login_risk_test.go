@@ -0,0 +1,50 @@
+package enums
+
+import "testing"
+
+func TestLoginRiskFlag_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		l    LoginRiskFlag
+		want bool
+	}{
+		{
+			name: "Happy Case - Valid flag",
+			l:    LoginRiskNewDevice,
+			want: true,
+		},
+		{
+			name: "Sad Case - Invalid flag",
+			l:    LoginRiskFlag("Not a flag"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.l.IsValid(); got != tt.want {
+				t.Errorf("LoginRiskFlag.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoginRiskFlag_String(t *testing.T) {
+	tests := []struct {
+		name string
+		l    LoginRiskFlag
+		want string
+	}{
+		{
+			name: "Happy Case",
+			l:    LoginRiskSharedIPAddress,
+			want: "SHARED_IP_ADDRESS",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.l.String(); got != tt.want {
+				t.Errorf("LoginRiskFlag.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -36,6 +36,25 @@ const (
 
 	// NotificationTypeBooking represents a booking notification
 	NotificationTypeBooking NotificationType = "BOOKING"
+
+	// NotificationTypeSecurityQuestionsReset represents a notification sent when a staff resets a user's security questions
+	NotificationTypeSecurityQuestionsReset NotificationType = "SECURITY_QUESTIONS_RESET"
+
+	// NotificationTypeSuspiciousLogin represents a notification sent when a user signs in from a new device or an unusual location
+	NotificationTypeSuspiciousLogin NotificationType = "SUSPICIOUS_LOGIN"
+
+	// NotificationTypeCaregiverAccessEnded represents a notification sent to a client and their caregiver when the client revokes
+	// the caregiver's access or it reaches its end date
+	NotificationTypeCaregiverAccessEnded NotificationType = "CAREGIVER_ACCESS_ENDED"
+
+	// NotificationTypeStaffOffboarded represents a notification sent to the organisation admins with a summary of a staff's offboarding
+	NotificationTypeStaffOffboarded NotificationType = "STAFF_OFFBOARDED"
+
+	// NotificationTypeClientTransferred represents a notification sent to the staff of the facility a client has been transferred to
+	NotificationTypeClientTransferred NotificationType = "CLIENT_TRANSFERRED"
+
+	// NotificationTypeServiceRequestEscalated represents a notification sent to the staff of a facility when a service request has waited longer than its SLA
+	NotificationTypeServiceRequestEscalated NotificationType = "SERVICE_REQUEST_ESCALATED"
 )
 
 // AllNotificationTypes holds all types of notification
@@ -49,6 +68,12 @@ var AllNotificationTypes = []NotificationType{
 	NotificationTypeDemoteModerator,
 	NotificationTypePromoteToModerator,
 	NotificationTypeBooking,
+	NotificationTypeSecurityQuestionsReset,
+	NotificationTypeSuspiciousLogin,
+	NotificationTypeCaregiverAccessEnded,
+	NotificationTypeStaffOffboarded,
+	NotificationTypeClientTransferred,
+	NotificationTypeServiceRequestEscalated,
 }
 
 // IsValid returns true if a notification type is valid
@@ -63,7 +88,13 @@ func (n NotificationType) IsValid() bool {
 		NotificationTypeSurveys,
 		NotificationTypeDemoteModerator,
 		NotificationTypePromoteToModerator,
-		NotificationTypeBooking:
+		NotificationTypeBooking,
+		NotificationTypeSecurityQuestionsReset,
+		NotificationTypeSuspiciousLogin,
+		NotificationTypeCaregiverAccessEnded,
+		NotificationTypeStaffOffboarded,
+		NotificationTypeClientTransferred,
+		NotificationTypeServiceRequestEscalated:
 		return true
 	}
 	return false
@@ -114,6 +145,18 @@ func (n NotificationType) Name() string {
 		return "Moderator Promotion"
 	case NotificationTypeBooking:
 		return "Booking"
+	case NotificationTypeSecurityQuestionsReset:
+		return "Security Questions Reset"
+	case NotificationTypeSuspiciousLogin:
+		return "Suspicious Login"
+	case NotificationTypeCaregiverAccessEnded:
+		return "Caregiver Access Ended"
+	case NotificationTypeStaffOffboarded:
+		return "Staff Offboarding"
+	case NotificationTypeClientTransferred:
+		return "Client Transfer"
+	case NotificationTypeServiceRequestEscalated:
+		return "Service Request Escalation"
 	}
 	return "UNKNOWN"
 }
@@ -17,6 +17,36 @@ func TestNotificationType_IsValid(t *testing.T) {
 			m:    NotificationTypeAppointment,
 			want: true,
 		},
+		{
+			name: "valid security questions reset type",
+			m:    NotificationTypeSecurityQuestionsReset,
+			want: true,
+		},
+		{
+			name: "valid suspicious login type",
+			m:    NotificationTypeSuspiciousLogin,
+			want: true,
+		},
+		{
+			name: "valid caregiver access ended type",
+			m:    NotificationTypeCaregiverAccessEnded,
+			want: true,
+		},
+		{
+			name: "valid staff offboarded type",
+			m:    NotificationTypeStaffOffboarded,
+			want: true,
+		},
+		{
+			name: "valid client transferred type",
+			m:    NotificationTypeClientTransferred,
+			want: true,
+		},
+		{
+			name: "valid service request escalated type",
+			m:    NotificationTypeServiceRequestEscalated,
+			want: true,
+		},
 		{
 			name: "invalid type",
 			m:    NotificationType("invalid"),
@@ -0,0 +1,69 @@
+package enums
+
+// OTPDeliveryProvider is a provider that an OTP can be sent through
+type OTPDeliveryProvider string
+
+const (
+	// OTPDeliveryProviderSILComms sends OTPs as SMS through SILComms
+	OTPDeliveryProviderSILComms OTPDeliveryProvider = "SILCOMMS"
+
+	// OTPDeliveryProviderTwilio sends OTPs as SMS through Twilio
+	OTPDeliveryProviderTwilio OTPDeliveryProvider = "TWILIO"
+
+	// OTPDeliveryProviderWhatsApp sends OTPs as WhatsApp messages
+	OTPDeliveryProviderWhatsApp OTPDeliveryProvider = "WHATSAPP"
+)
+
+// IsValid returns true if an OTP delivery provider is valid
+func (o OTPDeliveryProvider) IsValid() bool {
+	switch o {
+	case OTPDeliveryProviderSILComms, OTPDeliveryProviderTwilio, OTPDeliveryProviderWhatsApp:
+		return true
+	}
+	return false
+}
+
+// String converts the OTP delivery provider enum to a string
+func (o OTPDeliveryProvider) String() string {
+	return string(o)
+}
+
+// OTPDeliveryStatus is the status of an attempt to send an OTP
+type OTPDeliveryStatus string
+
+const (
+	// OTPDeliveryStatusSent means the provider accepted the message
+	OTPDeliveryStatusSent OTPDeliveryStatus = "SENT"
+
+	// OTPDeliveryStatusFailed means the provider could not send the message
+	OTPDeliveryStatusFailed OTPDeliveryStatus = "FAILED"
+
+	// OTPDeliveryStatusDelivered means the provider confirmed that the message reached the recipient
+	OTPDeliveryStatusDelivered OTPDeliveryStatus = "DELIVERED"
+
+	// OTPDeliveryStatusUndelivered means the provider sent the message but it did not reach the recipient
+	OTPDeliveryStatusUndelivered OTPDeliveryStatus = "UNDELIVERED"
+)
+
+// IsValid returns true if an OTP delivery status is valid
+func (o OTPDeliveryStatus) IsValid() bool {
+	switch o {
+	case OTPDeliveryStatusSent, OTPDeliveryStatusFailed, OTPDeliveryStatusDelivered, OTPDeliveryStatusUndelivered:
+		return true
+	}
+	return false
+}
+
+// IsFinal returns true if the status will not be changed by a later delivery receipt
+func (o OTPDeliveryStatus) IsFinal() bool {
+	switch o {
+	case OTPDeliveryStatusFailed, OTPDeliveryStatusDelivered, OTPDeliveryStatusUndelivered:
+		return true
+	}
+	return false
+}
+
+// String converts the OTP delivery status enum to a string
+func (o OTPDeliveryStatus) String() string {
+	return string(o)
+}
@@ -0,0 +1,81 @@
+package enums
+
+import "testing"
+
+func TestOTPDeliveryProvider_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		o    OTPDeliveryProvider
+		want bool
+	}{
+		{
+			name: "Happy Case - Valid provider",
+			o:    OTPDeliveryProviderTwilio,
+			want: true,
+		},
+		{
+			name: "Sad Case - Invalid provider",
+			o:    OTPDeliveryProvider("Not a provider"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.o.IsValid(); got != tt.want {
+				t.Errorf("OTPDeliveryProvider.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOTPDeliveryStatus_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		o    OTPDeliveryStatus
+		want bool
+	}{
+		{
+			name: "Happy Case - Valid status",
+			o:    OTPDeliveryStatusDelivered,
+			want: true,
+		},
+		{
+			name: "Sad Case - Invalid status",
+			o:    OTPDeliveryStatus("Not a status"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.o.IsValid(); got != tt.want {
+				t.Errorf("OTPDeliveryStatus.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOTPDeliveryStatus_IsFinal(t *testing.T) {
+	tests := []struct {
+		name string
+		o    OTPDeliveryStatus
+		want bool
+	}{
+		{
+			name: "Happy Case - Delivered is final",
+			o:    OTPDeliveryStatusDelivered,
+			want: true,
+		},
+		{
+			name: "Happy Case - Sent is not final",
+			o:    OTPDeliveryStatusSent,
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.o.IsFinal(); got != tt.want {
+				t.Errorf("OTPDeliveryStatus.IsFinal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package enums
+
+// RateLimitKeyType is the attribute of a request that a rate limit is applied to
+type RateLimitKeyType string
+
+const (
+	// RateLimitKeyPhone limits the requests made for the same phone number
+	RateLimitKeyPhone RateLimitKeyType = "PHONE"
+
+	// RateLimitKeyUsername limits the requests made for the same username
+	RateLimitKeyUsername RateLimitKeyType = "USERNAME"
+
+	// RateLimitKeyIPAddress limits the requests made from the same client IP address
+	RateLimitKeyIPAddress RateLimitKeyType = "IP_ADDRESS"
+)
+
+// IsValid returns true if a rate limit key type is valid
+func (r RateLimitKeyType) IsValid() bool {
+	switch r {
+	case RateLimitKeyPhone, RateLimitKeyUsername, RateLimitKeyIPAddress:
+		return true
+	}
+	return false
+}
+
+// String converts the rate limit key type enum to a string
+func (r RateLimitKeyType) String() string {
+	return string(r)
+}
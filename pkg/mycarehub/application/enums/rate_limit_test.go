@@ -0,0 +1,50 @@
+package enums
+
+import "testing"
+
+func TestRateLimitKeyType_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		r    RateLimitKeyType
+		want bool
+	}{
+		{
+			name: "Happy Case - Valid type",
+			r:    RateLimitKeyPhone,
+			want: true,
+		},
+		{
+			name: "Sad Case - Invalid type",
+			r:    RateLimitKeyType("Not a key type"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.IsValid(); got != tt.want {
+				t.Errorf("RateLimitKeyType.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimitKeyType_String(t *testing.T) {
+	tests := []struct {
+		name string
+		r    RateLimitKeyType
+		want string
+	}{
+		{
+			name: "Happy Case",
+			r:    RateLimitKeyIPAddress,
+			want: "IP_ADDRESS",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.String(); got != tt.want {
+				t.Errorf("RateLimitKeyType.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
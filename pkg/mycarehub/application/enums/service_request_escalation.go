@@ -0,0 +1,58 @@
+package enums
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// ServiceRequestEscalationLevel is who a service request has been escalated to after waiting longer than its SLA
+type ServiceRequestEscalationLevel string
+
+const (
+	// ServiceRequestEscalationLevelFacilityStaff is when the staff of the request's facility are reminded about it
+	ServiceRequestEscalationLevelFacilityStaff ServiceRequestEscalationLevel = "FACILITY_STAFF"
+
+	// ServiceRequestEscalationLevelAdmins is when the admins of the request's organisation are alerted by SMS and email
+	ServiceRequestEscalationLevelAdmins ServiceRequestEscalationLevel = "ADMINS"
+)
+
+// AllServiceRequestEscalationLevel holds all the levels a service request can be escalated to
+var AllServiceRequestEscalationLevel = []ServiceRequestEscalationLevel{
+	ServiceRequestEscalationLevelFacilityStaff,
+	ServiceRequestEscalationLevelAdmins,
+}
+
+// IsValid returns true if a service request escalation level is valid
+func (s ServiceRequestEscalationLevel) IsValid() bool {
+	switch s {
+	case ServiceRequestEscalationLevelFacilityStaff,
+		ServiceRequestEscalationLevelAdmins:
+		return true
+	}
+	return false
+}
+
+// String converts the service request escalation level enum to a string
+func (s ServiceRequestEscalationLevel) String() string {
+	return string(s)
+}
+
+// UnmarshalGQL converts the supplied value to a service request escalation level.
+func (s *ServiceRequestEscalationLevel) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*s = ServiceRequestEscalationLevel(str)
+	if !s.IsValid() {
+		return fmt.Errorf("%s is not a valid ServiceRequestEscalationLevel", str)
+	}
+	return nil
+}
+
+// MarshalGQL writes the service request escalation level to the supplied writer
+func (s ServiceRequestEscalationLevel) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(s.String()))
+}
@@ -0,0 +1,78 @@
+package enums
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+)
+
+func TestServiceRequestEscalationLevel_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		c    ServiceRequestEscalationLevel
+		want bool
+	}{
+		{
+			name: "Happy Case - Valid level",
+			c:    ServiceRequestEscalationLevelFacilityStaff,
+			want: true,
+		},
+		{
+			name: "Sad Case - Invalid level",
+			c:    ServiceRequestEscalationLevel("invalid"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.IsValid(); got != tt.want {
+				t.Errorf("ServiceRequestEscalationLevel.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServiceRequestEscalationLevel_UnmarshalGQL(t *testing.T) {
+	value := ServiceRequestEscalationLevelAdmins
+	invalid := ServiceRequestEscalationLevel("invalid")
+	tests := []struct {
+		name    string
+		c       *ServiceRequestEscalationLevel
+		v       interface{}
+		wantErr bool
+	}{
+		{
+			name:    "Happy Case - Valid level",
+			c:       &value,
+			v:       "ADMINS",
+			wantErr: false,
+		},
+		{
+			name:    "Sad Case - Invalid level",
+			c:       &invalid,
+			v:       "invalid",
+			wantErr: true,
+		},
+		{
+			name:    "Sad Case - Non string value",
+			c:       &invalid,
+			v:       1,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.c.UnmarshalGQL(tt.v); (err != nil) != tt.wantErr {
+				t.Errorf("ServiceRequestEscalationLevel.UnmarshalGQL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestServiceRequestEscalationLevel_MarshalGQL(t *testing.T) {
+	w := &bytes.Buffer{}
+	ServiceRequestEscalationLevelFacilityStaff.MarshalGQL(w)
+	if got := w.String(); got != strconv.Quote("FACILITY_STAFF") {
+		t.Errorf("ServiceRequestEscalationLevel.MarshalGQL() = %v, want %v", got, strconv.Quote("FACILITY_STAFF"))
+	}
+}
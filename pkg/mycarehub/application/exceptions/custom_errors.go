@@ -2,6 +2,8 @@ package exceptions
 
 import (
 	"fmt"
+	"math"
+	"time"
 
 	"github.com/savannahghi/errorcodeutil"
 )
@@ -533,3 +535,154 @@ func CreateOrganisationErr(err error, message string, code int) error {
 		Code:    code,
 	}
 }
+
+// TOTPRequiredErr returns an error message when a user with a TOTP authenticator does not provide an authentication code
+func TOTPRequiredErr() error {
+	return &CustomError{
+		Err:     nil,
+		Message: TOTPRequiredErrorMsg,
+		Code:    int(TOTPRequiredError),
+	}
+}
+
+// TOTPMismatchErr returns an error message when the provided TOTP or recovery code is not valid
+func TOTPMismatchErr() error {
+	return &CustomError{
+		Err:     nil,
+		Message: TOTPMismatchErrorMsg,
+		Code:    int(TOTPMismatchError),
+	}
+}
+
+// TOTPAlreadyEnrolledErr returns an error message when a user already has a confirmed TOTP authenticator
+func TOTPAlreadyEnrolledErr(err error) error {
+	return &CustomError{
+		Err:     err,
+		Message: TOTPAlreadyEnrolledErrorMsg,
+		Code:    int(TOTPAlreadyEnrolledError),
+	}
+}
+
+// TOTPNotEnrolledErr returns an error message when a user does not have a TOTP authenticator
+func TOTPNotEnrolledErr(err error) error {
+	return &CustomError{
+		Err:     err,
+		Message: TOTPNotEnrolledErrorMsg,
+		Code:    int(TOTPNotEnrolledError),
+	}
+}
+
+// TOTPEnforcedErr returns an error message when a user tries to disable a TOTP authenticator required by their organisation
+func TOTPEnforcedErr(err error) error {
+	return &CustomError{
+		Err:     err,
+		Message: TOTPEnforcedErrorMsg,
+		Code:    int(TOTPEnforcedError),
+	}
+}
+
+// TOTPEnrollmentRequiredErr returns an error message when a staff has to enroll an authenticator app before they can use the platform
+func TOTPEnrollmentRequiredErr(err error) error {
+	return &CustomError{
+		Err:     err,
+		Message: TOTPEnrollmentRequiredErrorMsg,
+		Code:    int(TOTPEnrollmentRequiredError),
+	}
+}
+
+// OTPRateLimitedErr returns an error message when too many OTPs have been requested within the rate limit window
+func OTPRateLimitedErr(err error, retryAfter time.Duration) error {
+	return &CustomError{
+		Err:     err,
+		Message: OTPRateLimitedErrorMsg,
+		Code:    int(OTPRateLimitedError),
+		Detail:  retryAfterDetail(retryAfter),
+	}
+}
+
+// OTPCooldownErr returns an error message when an OTP is requested before the cool-down after the previous one elapsed
+func OTPCooldownErr(err error, retryAfter time.Duration) error {
+	return &CustomError{
+		Err:     err,
+		Message: OTPCooldownErrorMsg,
+		Code:    int(OTPCooldownError),
+		Detail:  retryAfterDetail(retryAfter),
+	}
+}
+
+// SessionNotFoundErr returns an error message when a session does not exist or does not belong to the user
+func SessionNotFoundErr(err error) error {
+	return &CustomError{
+		Err:     err,
+		Message: SessionNotFoundErrorMsg,
+		Code:    int(SessionNotFoundError),
+	}
+}
+
+// WeakPINErr returns an error message when a PIN does not meet the organisation's PIN policy
+func WeakPINErr(err error) error {
+	return &CustomError{
+		Err:     err,
+		Message: WeakPINErrorMsg,
+		Code:    int(WeakPINError),
+	}
+}
+
+// PINReusedErr returns an error message when a PIN is one of the user's recent PINs
+func PINReusedErr(err error) error {
+	return &CustomError{
+		Err:     err,
+		Message: PINReusedErrorMsg,
+		Code:    int(PINReusedError),
+	}
+}
+
+// LoginOTPRequiredErr returns an error message when a suspicious staff login has to be confirmed with an OTP
+func LoginOTPRequiredErr() error {
+	return &CustomError{
+		Err:     nil,
+		Message: LoginOTPRequiredErrorMsg,
+		Code:    int(LoginOTPRequiredError),
+	}
+}
+
+// LoginOTPMismatchErr returns an error message when the OTP provided to confirm a login is not valid
+func LoginOTPMismatchErr() error {
+	return &CustomError{
+		Err:     nil,
+		Message: LoginOTPMismatchErrorMsg,
+		Code:    int(LoginOTPMismatchError),
+	}
+}
+
+// PhoneNumberInUseErr returns an error message when the new phone number of a phone number change belongs to another user
+func PhoneNumberInUseErr(err error) error {
+	return &CustomError{
+		Err:     err,
+		Message: PhoneNumberInUseErrorMsg,
+		Code:    int(PhoneNumberInUseError),
+	}
+}
+
+// PhoneChangeNotFoundErr returns an error message when there is no pending phone number change to confirm
+func PhoneChangeNotFoundErr(err error) error {
+	return &CustomError{
+		Err:     err,
+		Message: PhoneChangeNotFoundErrorMsg,
+		Code:    int(PhoneChangeNotFoundError),
+	}
+}
+
+// PhoneChangeOTPMismatchErr returns an error message when the OTP provided to confirm a phone number change is not valid
+func PhoneChangeOTPMismatchErr() error {
+	return &CustomError{
+		Err:     nil,
+		Message: PhoneChangeOTPMismatchErrorMsg,
+		Code:    int(PhoneChangeOTPMismatchError),
+	}
+}
+
+// retryAfterDetail tells the user how long to wait before retrying a rate limited request
+func retryAfterDetail(retryAfter time.Duration) string {
+	return fmt.Sprintf("please try again after %v seconds", math.Ceil(retryAfter.Seconds()))
+}
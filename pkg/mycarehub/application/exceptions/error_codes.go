@@ -437,4 +437,72 @@ const (
 
 	// DuplicateOrganisationEmailAddress is the error code to be used when organisation is registered with an existing organisation email address
 	DuplicateOrganisationEmailAddress
+
+	// TOTPRequiredError means that the user has a TOTP authenticator enrolled and did not provide a code when logging in
+	// it is error code 91
+	TOTPRequiredError
+
+	// TOTPMismatchError means that the provided TOTP or recovery code is not valid
+	// it is error code 92
+	TOTPMismatchError
+
+	// TOTPAlreadyEnrolledError means that the user already has a confirmed TOTP authenticator
+	// it is error code 93
+	TOTPAlreadyEnrolledError
+
+	// TOTPNotEnrolledError means that the user does not have a TOTP authenticator
+	// it is error code 94
+	TOTPNotEnrolledError
+
+	// TOTPEnforcedError means that the user's organisation requires staff to use a TOTP authenticator
+	// hence it cannot be disabled
+	// it is error code 95
+	TOTPEnforcedError
+
+	// OTPRateLimitedError means that too many OTPs have been requested for a phone number, username or IP address
+	// within the rate limit window
+	// it is error code 96
+	OTPRateLimitedError
+
+	// OTPCooldownError means that an OTP was requested before the cool-down after the previous OTP elapsed
+	// it is error code 97
+	OTPCooldownError
+
+	// SessionNotFoundError means that the session does not exist or does not belong to the user
+	// it is error code 98
+	SessionNotFoundError
+
+	// WeakPINError means that the PIN does not meet the length or complexity rules of the organisation's PIN policy
+	// it is error code 99
+	WeakPINError
+
+	// PINReusedError means that the PIN is one of the user's recent PINs
+	// it is error code 100
+	PINReusedError
+
+	// LoginOTPRequiredError means that a suspicious staff login has to be confirmed with an OTP sent to the staff's phone
+	// it is error code 101
+	LoginOTPRequiredError
+
+	// LoginOTPMismatchError means that the OTP provided to confirm a suspicious login is not valid
+	// it is error code 102
+	LoginOTPMismatchError
+
+	// PhoneNumberInUseError means that the new phone number provided for a phone number change belongs to another user
+	// it is error code 103
+	PhoneNumberInUseError
+
+	// PhoneChangeNotFoundError means that the user does not have a pending phone number change for the phone number
+	// or the change has expired
+	// it is error code 104
+	PhoneChangeNotFoundError
+
+	// PhoneChangeOTPMismatchError means that the OTP provided to confirm a phone number change is not valid
+	// it is error code 105
+	PhoneChangeOTPMismatchError
+
+	// TOTPEnrollmentRequiredError means that the staff has to enroll an authenticator app required by their organisation
+	// before they can use the platform
+	// it is error code 106
+	TOTPEnrollmentRequiredError
 )
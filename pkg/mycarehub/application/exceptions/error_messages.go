@@ -192,4 +192,54 @@ const (
 
 	// DuplicateOrganisationEmailAddressErrorMessage is the error message to be displayed when an existing email is used to create a new organisation
 	DuplicateOrganisationEmailAddressErrorMessage = "the provided email is associated with another organisation"
+
+	// TOTPRequiredErrorMsg is the error message displayed when a user with a TOTP authenticator logs in without an authentication code
+	TOTPRequiredErrorMsg = "an authentication code from your authenticator app is required"
+
+	// TOTPMismatchErrorMsg is the error message displayed when the provided TOTP or recovery code is not valid
+	TOTPMismatchErrorMsg = "the provided authentication code is not valid"
+
+	// TOTPAlreadyEnrolledErrorMsg is the error message displayed when a user with a confirmed authenticator tries to enroll another one
+	TOTPAlreadyEnrolledErrorMsg = "an authenticator app is already enrolled"
+
+	// TOTPNotEnrolledErrorMsg is the error message displayed when a user does not have an authenticator app enrolled
+	TOTPNotEnrolledErrorMsg = "no authenticator app is enrolled"
+
+	// TOTPEnforcedErrorMsg is the error message displayed when a user tries to disable an authenticator app that their organisation requires
+	TOTPEnforcedErrorMsg = "your organisation requires staff to use an authenticator app"
+
+	// OTPRateLimitedErrorMsg is the error message displayed when too many verification codes have been requested
+	OTPRateLimitedErrorMsg = "too many verification codes have been requested"
+
+	// OTPCooldownErrorMsg is the error message displayed when a verification code is requested too soon after the previous one
+	OTPCooldownErrorMsg = "a verification code has just been sent"
+
+	// SessionNotFoundErrorMsg is the error message displayed when a user tries to end a session that is not theirs or does not exist
+	SessionNotFoundErrorMsg = "the session could not be found"
+
+	// WeakPINErrorMsg is the error message displayed when a PIN is too short or easy to guess e.g 1234
+	WeakPINErrorMsg = "the PIN is too weak, please choose a different PIN"
+
+	// PINReusedErrorMsg is the error message displayed when a user sets a PIN that they have used recently
+	PINReusedErrorMsg = "the PIN has been used recently, please choose a different PIN"
+
+	// LoginOTPRequiredErrorMsg is the error message displayed when a staff logs in from a new device or location
+	// and has to confirm the login with an OTP
+	LoginOTPRequiredErrorMsg = "we have sent a verification code to your phone to confirm that it is you signing in"
+
+	// LoginOTPMismatchErrorMsg is the error message displayed when the OTP provided to confirm a login is not valid
+	LoginOTPMismatchErrorMsg = "the provided verification code is not valid"
+
+	// PhoneNumberInUseErrorMsg is the error message displayed when a user tries to change their phone number to one used by another user
+	PhoneNumberInUseErrorMsg = "the provided phone number is already in use"
+
+	// PhoneChangeNotFoundErrorMsg is the error message displayed when a phone number change is confirmed without a pending request
+	PhoneChangeNotFoundErrorMsg = "the phone number change could not be found or has expired, please request a new verification code"
+
+	// PhoneChangeOTPMismatchErrorMsg is the error message displayed when the OTP sent to a new phone number is not valid
+	PhoneChangeOTPMismatchErrorMsg = "the provided verification code is not valid"
+
+	// TOTPEnrollmentRequiredErrorMsg is the error message displayed when a staff who has not enrolled an authenticator app
+	// required by their organisation tries to use the platform
+	TOTPEnrollmentRequiredErrorMsg = "your organisation requires you to enroll an authenticator app before you can continue"
 )
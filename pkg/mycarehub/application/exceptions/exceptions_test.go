@@ -3,6 +3,7 @@ package exceptions_test
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
 
@@ -132,4 +133,54 @@ func TestCustomErrors(t *testing.T) {
 
 	err = exceptions.CreateOrganisationErr(fmt.Errorf("error"), err.Error(), 88)
 	assert.NotNil(t, err)
+
+	err = exceptions.TOTPRequiredErr()
+	assert.NotNil(t, err)
+
+	err = exceptions.TOTPMismatchErr()
+	assert.NotNil(t, err)
+
+	err = exceptions.TOTPAlreadyEnrolledErr(fmt.Errorf("error"))
+	assert.NotNil(t, err)
+
+	err = exceptions.TOTPNotEnrolledErr(fmt.Errorf("error"))
+	assert.NotNil(t, err)
+
+	err = exceptions.TOTPEnforcedErr(fmt.Errorf("error"))
+	assert.NotNil(t, err)
+
+	err = exceptions.TOTPEnrollmentRequiredErr(fmt.Errorf("error"))
+	assert.NotNil(t, err)
+
+	err = exceptions.OTPRateLimitedErr(fmt.Errorf("error"), time.Minute)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "please try again after 60 seconds")
+
+	err = exceptions.OTPCooldownErr(fmt.Errorf("error"), time.Millisecond*1500)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "please try again after 2 seconds")
+
+	err = exceptions.SessionNotFoundErr(fmt.Errorf("error"))
+	assert.NotNil(t, err)
+
+	err = exceptions.WeakPINErr(fmt.Errorf("error"))
+	assert.NotNil(t, err)
+
+	err = exceptions.PINReusedErr(fmt.Errorf("error"))
+	assert.NotNil(t, err)
+
+	err = exceptions.LoginOTPRequiredErr()
+	assert.NotNil(t, err)
+
+	err = exceptions.LoginOTPMismatchErr()
+	assert.NotNil(t, err)
+
+	err = exceptions.PhoneNumberInUseErr(fmt.Errorf("error"))
+	assert.NotNil(t, err)
+
+	err = exceptions.PhoneChangeNotFoundErr(fmt.Errorf("error"))
+	assert.NotNil(t, err)
+
+	err = exceptions.PhoneChangeOTPMismatchErr()
+	assert.NotNil(t, err)
 }
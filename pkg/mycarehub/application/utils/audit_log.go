@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+)
+
+// AuditLogHashKeyEnvVarName is the environment variable holding the secret key that audit log entries are signed with.
+// The key is kept out of the database so that someone who can modify the database cannot re-sign an entry they changed
+const AuditLogHashKeyEnvVarName = "AUDIT_LOG_HASH_KEY"
+
+// auditLogHashContent is the content of an audit log entry that is covered by its hash
+type auditLogHashContent struct {
+	Sequence       int64       `json:"sequence"`
+	PreviousHash   string      `json:"previousHash"`
+	Active         bool        `json:"active"`
+	Timestamp      string      `json:"timestamp"`
+	RecordType     string      `json:"recordType"`
+	Notes          string      `json:"notes"`
+	ActorID        string      `json:"actorID"`
+	TargetID       string      `json:"targetID"`
+	TargetType     string      `json:"targetType"`
+	ProgramID      string      `json:"programID"`
+	OrganisationID string      `json:"organisationID"`
+	Before         interface{} `json:"before"`
+	After          interface{} `json:"after"`
+}
+
+// HashAuditLog computes the HMAC-SHA256 of an audit log entry with the provided key. The hash links the entry to the chain.
+//
+// The hash covers the entry's sequence, the previous entry's hash and the entry's content, including whether it is active. The timestamp is
+// truncated to the microsecond precision stored by the database and the payload is normalised the same way it
+// is when read back from the database so that the hash can be recomputed during verification.
+func HashAuditLog(key string, auditLog *domain.AuditLog) (string, error) {
+	if key == "" {
+		return "", fmt.Errorf("an audit log hash key is required")
+	}
+
+	before, err := normaliseAuditLogPayload(auditLog.Before)
+	if err != nil {
+		return "", err
+	}
+
+	after, err := normaliseAuditLogPayload(auditLog.After)
+	if err != nil {
+		return "", err
+	}
+
+	content, err := json.Marshal(auditLogHashContent{
+		Sequence:       auditLog.Sequence,
+		PreviousHash:   auditLog.PreviousHash,
+		Active:         auditLog.Active,
+		Timestamp:      auditLog.Timestamp.UTC().Truncate(time.Microsecond).Format(time.RFC3339Nano),
+		RecordType:     auditLog.RecordType.String(),
+		Notes:          auditLog.Notes,
+		ActorID:        auditLog.ActorID,
+		TargetID:       auditLog.TargetID,
+		TargetType:     auditLog.TargetType.String(),
+		ProgramID:      auditLog.ProgramID,
+		OrganisationID: auditLog.OrganisationID,
+		Before:         before,
+		After:          after,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal audit log content: %w", err)
+	}
+
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write(content)
+
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// normaliseAuditLogPayload round trips a payload through JSON so that values such as numbers and slices
+// take the same form they have when decoded from the database
+func normaliseAuditLogPayload(payload map[string]interface{}) (interface{}, error) {
+	if payload == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audit log payload: %w", err)
+	}
+
+	var normalised interface{}
+	if err := json.Unmarshal(data, &normalised); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal audit log payload: %w", err)
+	}
+
+	return normalised, nil
+}
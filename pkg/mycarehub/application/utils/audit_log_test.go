@@ -0,0 +1,129 @@
+package utils
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+)
+
+func TestHashAuditLog(t *testing.T) {
+	timestamp := time.Now()
+	auditLog := domain.AuditLog{
+		Active:         true,
+		Timestamp:      timestamp,
+		RecordType:     enums.AuditLogRoleChange,
+		Notes:          "roles assigned",
+		ActorID:        uuid.NewString(),
+		TargetID:       uuid.NewString(),
+		TargetType:     enums.AuditLogTargetStaff,
+		ProgramID:      uuid.NewString(),
+		OrganisationID: uuid.NewString(),
+		After:          map[string]interface{}{"assigned_role_ids": []string{"1"}, "count": 1},
+		Sequence:       2,
+		PreviousHash:   "previous",
+	}
+
+	key := "audit log hash key"
+
+	want, err := HashAuditLog(key, &auditLog)
+	if err != nil {
+		t.Fatalf("HashAuditLog() error = %v", err)
+	}
+
+	// the same entry as it is read back from the database
+	stored := auditLog
+	stored.Timestamp = timestamp.Truncate(time.Microsecond).In(time.FixedZone("EAT", 3*60*60))
+	stored.After = map[string]interface{}{"assigned_role_ids": []interface{}{"1"}, "count": float64(1)}
+
+	modifiedNotes := auditLog
+	modifiedNotes.Notes = "roles revoked"
+
+	modifiedPayload := auditLog
+	modifiedPayload.After = map[string]interface{}{"assigned_role_ids": []string{"2"}, "count": 1}
+
+	modifiedLink := auditLog
+	modifiedLink.PreviousHash = "another"
+
+	modifiedSequence := auditLog
+	modifiedSequence.Sequence = 3
+
+	deactivated := auditLog
+	deactivated.Active = false
+
+	tests := []struct {
+		name      string
+		key       string
+		auditLog  domain.AuditLog
+		wantEqual bool
+		wantErr   bool
+	}{
+		{
+			name:      "Happy case: stored entry hashes the same",
+			key:       key,
+			auditLog:  stored,
+			wantEqual: true,
+		},
+		{
+			name:     "Happy case: another key changes the hash",
+			key:      "another audit log hash key",
+			auditLog: auditLog,
+		},
+		{
+			name:     "Happy case: modified notes change the hash",
+			key:      key,
+			auditLog: modifiedNotes,
+		},
+		{
+			name:     "Happy case: modified payload changes the hash",
+			key:      key,
+			auditLog: modifiedPayload,
+		},
+		{
+			name:     "Happy case: modified previous hash changes the hash",
+			key:      key,
+			auditLog: modifiedLink,
+		},
+		{
+			name:     "Happy case: modified sequence changes the hash",
+			key:      key,
+			auditLog: modifiedSequence,
+		},
+		{
+			name:     "Happy case: deactivating the entry changes the hash",
+			key:      key,
+			auditLog: deactivated,
+		},
+		{
+			name:     "Sad case: no key",
+			auditLog: auditLog,
+			wantErr:  true,
+		},
+		{
+			name: "Sad case: payload cannot be marshalled",
+			key:  key,
+			auditLog: domain.AuditLog{
+				Before: map[string]interface{}{"value": math.Inf(1)},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := HashAuditLog(tt.key, &tt.auditLog)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("HashAuditLog() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if (got == want) != tt.wantEqual {
+				t.Errorf("HashAuditLog() = %v, want equal to %v: %v", got, want, tt.wantEqual)
+			}
+		})
+	}
+}
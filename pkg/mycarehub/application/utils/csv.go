@@ -86,3 +86,45 @@ func ParseFacilitiesFromCSV(path string) ([]*dto.FacilityInput, error) {
 	}
 	return facilities, nil
 }
+
+// ParseClientsFromCSV maps the rows of a client registration csv to client registration inputs.
+// A row that cannot be parsed is returned with its error so that every invalid row can be reported
+func ParseClientsFromCSV(reader io.Reader) ([]*dto.ClientCSVRow, error) {
+	csvReader := csv.NewReader(reader)
+	// rows with a different number of values are reported with the other errors of the row
+	csvReader.FieldsPerRecord = -1
+
+	labels, err := csvReader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("invalid client csv: the file is empty")
+	} else if err != nil {
+		return nil, err
+	}
+
+	err = dto.ValidateClientCSVLabels(labels)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := []*dto.ClientCSVRow{}
+	count := 1
+
+	for {
+		values, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		count++
+
+		input, err := dto.ParseClientCSVValues(labels, values)
+		rows = append(rows, &dto.ClientCSVRow{
+			Row:   count,
+			Input: input,
+			Err:   err,
+		})
+	}
+
+	return rows, nil
+}
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -79,3 +80,67 @@ func TestParseFacilitiesFromCSV(t *testing.T) {
 		})
 	}
 }
+
+func TestParseClientsFromCSV(t *testing.T) {
+	tests := []struct {
+		name          string
+		csv           string
+		wantRows      int
+		wantRowErrors int
+		wantErr       bool
+	}{
+		{
+			name: "Happy Case: parse clients from csv",
+			csv: "cccNumber,clientName,gender,dateOfBirth,phoneNumber,clientTypes,facility,enrollmentDate,counselled\n" +
+				"1234567890,Jane Doe,female,1990-01-01,+254711223344,PMTCT,12345,2023-01-01,true\n" +
+				"1234567891,John Doe,male,1990-01-01,+254711223355,PMTCT,12345,2023-01-01,true\n",
+			wantRows: 2,
+		},
+		{
+			name: "Happy Case: rows that cannot be parsed are returned with their error",
+			csv: "cccNumber,clientName,gender,dateOfBirth,phoneNumber,clientTypes,facility,enrollmentDate,counselled\n" +
+				"1234567890,Jane Doe,female,01/01/1990,+254711223344,PMTCT,12345,2023-01-01,true\n" +
+				"1234567891,John Doe\n",
+			wantRows:      2,
+			wantRowErrors: 2,
+		},
+		{
+			name:    "Sad Case: empty csv",
+			csv:     "",
+			wantErr: true,
+		},
+		{
+			name:    "Sad Case: invalid labels",
+			csv:     "cccNumber,name\n1234567890,Jane Doe\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseClientsFromCSV(strings.NewReader(tt.csv))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseClientsFromCSV() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != tt.wantRows {
+				t.Errorf("ParseClientsFromCSV() got %v rows, want %v", len(got), tt.wantRows)
+				return
+			}
+			rowErrors := 0
+			for i, row := range got {
+				if row.Row != i+2 {
+					t.Errorf("ParseClientsFromCSV() got row number %v, want %v", row.Row, i+2)
+				}
+				if row.Err != nil {
+					rowErrors++
+				}
+			}
+			if rowErrors != tt.wantRowErrors {
+				t.Errorf("ParseClientsFromCSV() got %v row errors, want %v", rowErrors, tt.wantRowErrors)
+			}
+		})
+	}
+}
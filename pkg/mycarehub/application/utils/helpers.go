@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"net"
+	"net/http"
 	"os"
 	"strconv"
 	"strings"
@@ -34,6 +36,22 @@ const (
 
 	// ProgramContextKey is used to add/retrieve the program ID that is passed around in the context
 	ProgramContextKey = firebasetools.ContextKey("ProgramID")
+
+	// ClientIPContextKey is used to add/retrieve the IP address of the client that made a request
+	ClientIPContextKey = firebasetools.ContextKey("ClientIP")
+
+	// ClientCountryContextKey is used to add/retrieve the country that a request was made from
+	ClientCountryContextKey = firebasetools.ContextKey("ClientCountry")
+
+	// SessionIDContextKey is used to add/retrieve the ID of the session that the logged in user made a request with
+	SessionIDContextKey = firebasetools.ContextKey("SessionID")
+
+	// TOTPEnrollmentContextKey is used to add/retrieve whether the logged in user made a request with a session that can
+	// only be used to enroll an authenticator app
+	TOTPEnrollmentContextKey = firebasetools.ContextKey("TOTPEnrollment")
+
+	// FacilityMFLCodeContextKey is used to add/retrieve the MFL code of the facility that an integration client is bound to
+	FacilityMFLCodeContextKey = firebasetools.ContextKey("FacilityMFLCode")
 )
 
 // CalculateNextAllowedLoginTime will be used to calculate the next allowed login time in cases where
@@ -201,6 +219,21 @@ func GetValueFromContext(ctx context.Context, key firebasetools.ContextKey) (str
 	return token, nil
 }
 
+// CheckFacilityAccess checks that an integration client that is bound to a facility e.g KenyaEMR only accesses the records
+// of its own facility. Requests that were not made by a facility client are not restricted
+func CheckFacilityAccess(ctx context.Context, mflCode string) error {
+	boundMFLCode, err := GetValueFromContext(ctx, FacilityMFLCodeContextKey)
+	if err != nil {
+		return nil
+	}
+
+	if boundMFLCode != strings.TrimSpace(mflCode) {
+		return fmt.Errorf("client is not allowed to access the records of facility with MFL code %s", mflCode)
+	}
+
+	return nil
+}
+
 // CalculateDistance is used to calculate the distance between two points on the earth give the starting point coordinates and destination point coordinates
 func CalculateDistance(startPoint geodist.Coord, destination geodist.Coord) (float64, error) {
 	_, km, err := geodist.VincentyDistance(startPoint, destination)
@@ -231,3 +264,74 @@ func ShuffleSecurityQuestionResponses(slice []*domain.SecurityQuestionResponse)
 
 	return shuffled, nil
 }
+
+// trustedProxyHops is the number of addresses that the proxies in front of the service append to the `X-Forwarded-For` header.
+// It defaults to the Google Cloud load balancer which appends the address of the client followed by its own address
+var trustedProxyHops = IntFromEnv("TRUSTED_PROXY_HOPS", 2)
+
+// GetClientIP returns the IP address of the client that made a request.
+// The address is taken from the `X-Forwarded-For` header at the configured number of trusted proxy hops from the right
+// since the preceding addresses can be set by the client. The remote address is used when the request did not go through the proxies
+func GetClientIP(r *http.Request) string {
+	if forwardedFor := r.Header.Get("X-Forwarded-For"); forwardedFor != "" && trustedProxyHops > 0 {
+		addresses := strings.Split(forwardedFor, ",")
+		if len(addresses) >= trustedProxyHops {
+			if address := strings.TrimSpace(addresses[len(addresses)-trustedProxyHops]); address != "" {
+				return address
+			}
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+// clientCountryHeaderEnvVarName is the name of the request header that the load balancer sets to the country that
+// a request was made from e.g `X-Client-Region`. The country is not known when it is not set
+const clientCountryHeaderEnvVarName = "CLIENT_COUNTRY_HEADER"
+
+// GetClientCountry returns the two letter code of the country that a request was made from as reported by the load balancer,
+// or an empty string if it is not known
+func GetClientCountry(r *http.Request) string {
+	header := strings.TrimSpace(os.Getenv(clientCountryHeaderEnvVarName))
+	if header == "" {
+		return ""
+	}
+
+	return strings.ToUpper(strings.TrimSpace(r.Header.Get(header)))
+}
+
+// IntFromEnv reads a non-negative integer from the environment. It panics when the value is invalid
+// so that a misconfigured limit is caught when the service starts
+func IntFromEnv(key string, defaultValue int) int {
+	value := strings.TrimSpace(os.Getenv(key))
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < 0 {
+		panic(fmt.Sprintf("invalid value %q for environment variable %s: expected a non-negative integer", value, key))
+	}
+
+	return parsed
+}
+
+// DurationFromEnv reads a non-negative duration e.g `30s` or `1h` from the environment. It panics when the value is invalid
+func DurationFromEnv(key string, defaultValue time.Duration) time.Duration {
+	value := strings.TrimSpace(os.Getenv(key))
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := time.ParseDuration(value)
+	if err != nil || parsed < 0 {
+		panic(fmt.Sprintf("invalid value %q for environment variable %s: expected a non-negative duration", value, key))
+	}
+
+	return parsed
+}
@@ -1,6 +1,9 @@
 package utils
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
 	"time"
@@ -474,3 +477,170 @@ func TestShuffleSecurityQuestionResponses(t *testing.T) {
 		})
 	}
 }
+
+func TestGetClientIP(t *testing.T) {
+	tests := []struct {
+		name             string
+		trustedProxyHops int
+		remoteAddr       string
+		forwardedFor     string
+		wantIPAddress    string
+	}{
+		{
+			name:             "Happy case: request without a proxy",
+			trustedProxyHops: 2,
+			remoteAddr:       "192.168.0.10:54321",
+			wantIPAddress:    "192.168.0.10",
+		},
+		{
+			name:             "Happy case: request through the load balancer",
+			trustedProxyHops: 2,
+			remoteAddr:       "10.0.0.1:54321",
+			forwardedFor:     "203.0.113.5, 34.120.0.1",
+			wantIPAddress:    "203.0.113.5",
+		},
+		{
+			name:             "Happy case: client sets its own forwarded for header",
+			trustedProxyHops: 2,
+			remoteAddr:       "10.0.0.1:54321",
+			forwardedFor:     "198.51.100.1, 203.0.113.5, 34.120.0.1",
+			wantIPAddress:    "203.0.113.5",
+		},
+		{
+			name:             "Happy case: request through a single proxy",
+			trustedProxyHops: 1,
+			remoteAddr:       "10.0.0.1:54321",
+			forwardedFor:     "198.51.100.1, 203.0.113.5",
+			wantIPAddress:    "203.0.113.5",
+		},
+		{
+			name:             "Happy case: forwarded for header with fewer addresses than the trusted proxies",
+			trustedProxyHops: 2,
+			remoteAddr:       "10.0.0.1:54321",
+			forwardedFor:     "198.51.100.1",
+			wantIPAddress:    "10.0.0.1",
+		},
+		{
+			name:             "Happy case: forwarded for header is ignored without trusted proxies",
+			trustedProxyHops: 0,
+			remoteAddr:       "192.168.0.10:54321",
+			forwardedFor:     "198.51.100.1",
+			wantIPAddress:    "192.168.0.10",
+		},
+		{
+			name:             "Happy case: remote address without a port",
+			trustedProxyHops: 2,
+			remoteAddr:       "192.168.0.10",
+			wantIPAddress:    "192.168.0.10",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hops := trustedProxyHops
+			trustedProxyHops = tt.trustedProxyHops
+			defer func() { trustedProxyHops = hops }()
+
+			r := httptest.NewRequest(http.MethodPost, "/send_otp", nil)
+			r.RemoteAddr = tt.remoteAddr
+			if tt.forwardedFor != "" {
+				r.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			}
+
+			if got := GetClientIP(r); got != tt.wantIPAddress {
+				t.Errorf("GetClientIP() = %v, want %v", got, tt.wantIPAddress)
+			}
+		})
+	}
+}
+
+func TestGetClientCountry(t *testing.T) {
+	tests := []struct {
+		name        string
+		header      string
+		country     string
+		wantCountry string
+	}{
+		{
+			name:        "Happy case: country set by the load balancer",
+			header:      "X-Client-Region",
+			country:     "ke",
+			wantCountry: "KE",
+		},
+		{
+			name:        "Happy case: country header is not configured",
+			country:     "KE",
+			wantCountry: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("CLIENT_COUNTRY_HEADER", tt.header)
+
+			r := httptest.NewRequest(http.MethodPost, "/login_by_phone", nil)
+			r.Header.Set("X-Client-Region", tt.country)
+
+			if got := GetClientCountry(r); got != tt.wantCountry {
+				t.Errorf("GetClientCountry() = %v, want %v", got, tt.wantCountry)
+			}
+		})
+	}
+}
+
+func TestIntFromEnv(t *testing.T) {
+	t.Setenv("TEST_INT_FROM_ENV", "")
+	assert.Equal(t, 5, IntFromEnv("TEST_INT_FROM_ENV", 5))
+
+	t.Setenv("TEST_INT_FROM_ENV", "10")
+	assert.Equal(t, 10, IntFromEnv("TEST_INT_FROM_ENV", 5))
+
+	t.Setenv("TEST_INT_FROM_ENV", "-1")
+	assert.Panics(t, func() { IntFromEnv("TEST_INT_FROM_ENV", 5) })
+}
+
+func TestDurationFromEnv(t *testing.T) {
+	t.Setenv("TEST_DURATION_FROM_ENV", "")
+	assert.Equal(t, time.Hour, DurationFromEnv("TEST_DURATION_FROM_ENV", time.Hour))
+
+	t.Setenv("TEST_DURATION_FROM_ENV", "30m")
+	assert.Equal(t, 30*time.Minute, DurationFromEnv("TEST_DURATION_FROM_ENV", time.Hour))
+
+	t.Setenv("TEST_DURATION_FROM_ENV", "an hour")
+	assert.Panics(t, func() { DurationFromEnv("TEST_DURATION_FROM_ENV", time.Hour) })
+}
+
+func TestCheckFacilityAccess(t *testing.T) {
+	facilityCtx := context.WithValue(context.Background(), FacilityMFLCodeContextKey, "1234")
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		mflCode string
+		wantErr bool
+	}{
+		{
+			name:    "Happy case: client accesses its own facility",
+			ctx:     facilityCtx,
+			mflCode: "1234",
+			wantErr: false,
+		},
+		{
+			name:    "Happy case: request was not made by a facility client",
+			ctx:     context.Background(),
+			mflCode: "1234",
+			wantErr: false,
+		},
+		{
+			name:    "Sad case: client accesses another facility",
+			ctx:     facilityCtx,
+			mflCode: "5678",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CheckFacilityAccess(tt.ctx, tt.mflCode); (err != nil) != tt.wantErr {
+				t.Errorf("CheckFacilityAccess() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"strconv"
 
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	"github.com/xdg-go/pbkdf2"
 )
 
@@ -29,6 +30,8 @@ const (
 	minPINLength = 4
 	// Default max length of the date
 	maxPINLength = 4
+	// the longest PIN an organisation's PIN policy can require
+	maxPolicyPINLength = 8
 )
 
 // DefaultHashFunction ...
@@ -132,3 +135,44 @@ func ValidatePINDigits(pin string) error {
 	}
 	return nil
 }
+
+// ValidatePINPolicy checks that a PIN meets the length and complexity rules of an organisation's PIN policy.
+// Reuse of previous PINs is checked separately since it requires the user's PIN history
+func ValidatePINPolicy(pin string, policy domain.PINPolicy) error {
+	if err := ValidatePINDigits(pin); err != nil {
+		return err
+	}
+
+	minLength := policy.MinLength
+	if minLength < minPINLength {
+		minLength = minPINLength
+	}
+
+	if len(pin) < minLength || len(pin) > maxPolicyPINLength {
+		return fmt.Errorf("PIN should be between %d and %d digits", minLength, maxPolicyPINLength)
+	}
+
+	if policy.DisallowSimplePIN && IsSimplePIN(pin) {
+		return fmt.Errorf("PIN should not be a repeated digit or a sequence of digits")
+	}
+
+	return nil
+}
+
+// IsSimplePIN checks whether a PIN is easy to guess i.e all its digits are the same e.g 1111
+// or each digit is one more or one less than the previous one e.g 1234 or 9876
+func IsSimplePIN(pin string) bool {
+	if len(pin) < 2 {
+		return true
+	}
+
+	repeated, ascending, descending := true, true, true
+	for i := 1; i < len(pin); i++ {
+		diff := int(pin[i]) - int(pin[i-1])
+		repeated = repeated && diff == 0
+		ascending = ascending && diff == 1
+		descending = descending && diff == -1
+	}
+
+	return repeated || ascending || descending
+}
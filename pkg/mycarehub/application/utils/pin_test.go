@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/utils"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	"github.com/tj/assert"
 )
 
@@ -144,3 +145,111 @@ func TestComparePIN(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatePINPolicy(t *testing.T) {
+	type args struct {
+		pin    string
+		policy domain.PINPolicy
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success: pin meets the default policy",
+			args: args{
+				pin:    "4826",
+				policy: domain.DefaultPINPolicy,
+			},
+			wantErr: false,
+		},
+		{
+			name: "success: longer pin meets the policy minimum length",
+			args: args{
+				pin:    "482619",
+				policy: domain.PINPolicy{MinLength: 6, DisallowSimplePIN: true},
+			},
+			wantErr: false,
+		},
+		{
+			name: "success: simple pin is allowed when the policy allows it",
+			args: args{
+				pin:    "1234",
+				policy: domain.PINPolicy{MinLength: 4},
+			},
+			wantErr: false,
+		},
+		{
+			name: "success: minimum length below four digits is raised to four",
+			args: args{
+				pin:    "4826",
+				policy: domain.PINPolicy{MinLength: 2},
+			},
+			wantErr: false,
+		},
+		{
+			name: "failure: pin shorter than the policy minimum length",
+			args: args{
+				pin:    "4826",
+				policy: domain.PINPolicy{MinLength: 6},
+			},
+			wantErr: true,
+		},
+		{
+			name: "failure: pin shorter than four digits",
+			args: args{
+				pin:    "482",
+				policy: domain.PINPolicy{MinLength: 2},
+			},
+			wantErr: true,
+		},
+		{
+			name: "failure: pin longer than eight digits",
+			args: args{
+				pin:    "482619375",
+				policy: domain.DefaultPINPolicy,
+			},
+			wantErr: true,
+		},
+		{
+			name: "failure: pin is not a number",
+			args: args{
+				pin:    "abcd",
+				policy: domain.DefaultPINPolicy,
+			},
+			wantErr: true,
+		},
+		{
+			name: "failure: ascending sequence",
+			args: args{
+				pin:    "1234",
+				policy: domain.DefaultPINPolicy,
+			},
+			wantErr: true,
+		},
+		{
+			name: "failure: descending sequence",
+			args: args{
+				pin:    "9876",
+				policy: domain.DefaultPINPolicy,
+			},
+			wantErr: true,
+		},
+		{
+			name: "failure: repeated digit",
+			args: args{
+				pin:    "0000",
+				policy: domain.DefaultPINPolicy,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := utils.ValidatePINPolicy(tt.args.pin, tt.args.policy); (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePINPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
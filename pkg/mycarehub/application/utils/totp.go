@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+)
+
+const (
+	// TOTPIssuer is the issuer name displayed by authenticator apps next to a user's TOTP account
+	TOTPIssuer = "myCareHub"
+
+	// TOTPPeriod is the number of seconds a TOTP code is valid for as recommended by RFC 6238
+	TOTPPeriod = 30
+
+	// totpSkew is the number of periods before and after the current one whose codes are accepted
+	// to allow for clock drift between the server and the user's device
+	totpSkew = 1
+
+	// RecoveryCodesCount is the number of recovery codes issued to a user when they enroll a TOTP authenticator
+	RecoveryCodesCount = 10
+
+	// recoveryCodeLength is the number of characters in a recovery code excluding the separator
+	recoveryCodeLength = 10
+
+	// recoveryCodeCharset excludes characters that are easily confused with each other e.g `0` and `o`
+	recoveryCodeCharset = "abcdefghjkmnpqrstuvwxyz23456789"
+)
+
+// GenerateTOTPKey generates a new TOTP secret for the provided account.
+// The key uses the RFC 6238 defaults (SHA1, 6 digits and a 30 second period) supported by most authenticator apps
+func GenerateTOTPKey(accountName string) (*otp.Key, error) {
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      TOTPIssuer,
+		AccountName: accountName,
+		Period:      TOTPPeriod,
+		Digits:      otp.DigitsSix,
+		Algorithm:   otp.AlgorithmSHA1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate TOTP key: %w", err)
+	}
+
+	return key, nil
+}
+
+// ValidateTOTPCode checks the provided code against the codes generated from the secret within the allowed clock skew.
+// It returns the time step the code was generated for so that the caller can reject a code that has already been used.
+func ValidateTOTPCode(secret, code string, at time.Time) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != otp.DigitsSix.Length() {
+		return 0, false
+	}
+
+	currentStep := at.Unix() / TOTPPeriod
+	for skew := -totpSkew; skew <= totpSkew; skew++ {
+		step := currentStep + int64(skew)
+
+		expected, err := totp.GenerateCodeCustom(secret, time.Unix(step*TOTPPeriod, 0).UTC(), totp.ValidateOpts{
+			Period:    TOTPPeriod,
+			Digits:    otp.DigitsSix,
+			Algorithm: otp.AlgorithmSHA1,
+		})
+		if err != nil {
+			return 0, false
+		}
+
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+// GenerateRecoveryCodes generates single use codes that a user can log in with when they cannot access their authenticator app.
+// The codes are formatted as two groups of five characters e.g `abcde-23456`
+func GenerateRecoveryCodes(count int) ([]string, error) {
+	codes := []string{}
+	for i := 0; i < count; i++ {
+		var code strings.Builder
+		for j := 0; j < recoveryCodeLength; j++ {
+			if j == recoveryCodeLength/2 {
+				code.WriteString("-")
+			}
+
+			index, err := rand.Int(rand.Reader, big.NewInt(int64(len(recoveryCodeCharset))))
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate recovery code: %w", err)
+			}
+			code.WriteByte(recoveryCodeCharset[index.Int64()])
+		}
+		codes = append(codes, code.String())
+	}
+
+	return codes, nil
+}
+
+// NormalizeRecoveryCode removes the formatting a user may add or remove when typing a recovery code
+// so that it can be compared with the hashed recovery codes
+func NormalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	code = strings.ReplaceAll(code, "-", "")
+	return strings.ReplaceAll(code, " ", "")
+}
@@ -0,0 +1,143 @@
+package utils
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/pquerna/otp/totp"
+)
+
+func TestGenerateTOTPKey(t *testing.T) {
+	key, err := GenerateTOTPKey("johndoe")
+	if err != nil {
+		t.Fatalf("GenerateTOTPKey() error = %v", err)
+	}
+
+	if key.Issuer() != TOTPIssuer {
+		t.Errorf("GenerateTOTPKey() issuer = %v, want %v", key.Issuer(), TOTPIssuer)
+	}
+	if key.AccountName() != "johndoe" {
+		t.Errorf("GenerateTOTPKey() account name = %v, want johndoe", key.AccountName())
+	}
+	if key.Secret() == "" {
+		t.Errorf("GenerateTOTPKey() expected a secret")
+	}
+}
+
+func TestValidateTOTPCode(t *testing.T) {
+	key, err := GenerateTOTPKey("johndoe")
+	if err != nil {
+		t.Fatalf("GenerateTOTPKey() error = %v", err)
+	}
+
+	now := time.Now()
+	currentStep := now.Unix() / TOTPPeriod
+
+	codeAt := func(at time.Time) string {
+		code, err := totp.GenerateCode(key.Secret(), at)
+		if err != nil {
+			t.Fatalf("failed to generate code: %v", err)
+		}
+		return code
+	}
+
+	tests := []struct {
+		name      string
+		secret    string
+		code      string
+		wantStep  int64
+		wantValid bool
+	}{
+		{
+			name:      "Happy case: current code",
+			secret:    key.Secret(),
+			code:      codeAt(now),
+			wantStep:  currentStep,
+			wantValid: true,
+		},
+		{
+			name:      "Happy case: previous period code within skew",
+			secret:    key.Secret(),
+			code:      codeAt(now.Add(-TOTPPeriod * time.Second)),
+			wantStep:  currentStep - 1,
+			wantValid: true,
+		},
+		{
+			name:   "Sad case: code outside the allowed skew",
+			secret: key.Secret(),
+			code:   codeAt(now.Add(-3 * TOTPPeriod * time.Second)),
+		},
+		{
+			name:   "Sad case: code of an invalid length",
+			secret: key.Secret(),
+			code:   "1234",
+		},
+		{
+			name:   "Sad case: invalid secret",
+			secret: "invalid secret!",
+			code:   "123456",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, valid := ValidateTOTPCode(tt.secret, tt.code, now)
+			if valid != tt.wantValid {
+				t.Errorf("ValidateTOTPCode() valid = %v, want %v", valid, tt.wantValid)
+				return
+			}
+			if step != tt.wantStep {
+				t.Errorf("ValidateTOTPCode() step = %v, want %v", step, tt.wantStep)
+			}
+		})
+	}
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes(RecoveryCodesCount)
+	if err != nil {
+		t.Fatalf("GenerateRecoveryCodes() error = %v", err)
+	}
+
+	if len(codes) != RecoveryCodesCount {
+		t.Fatalf("GenerateRecoveryCodes() returned %v codes, want %v", len(codes), RecoveryCodesCount)
+	}
+
+	format := regexp.MustCompile(`^[a-z2-9]{5}-[a-z2-9]{5}$`)
+	seen := map[string]bool{}
+	for _, code := range codes {
+		if !format.MatchString(code) {
+			t.Errorf("GenerateRecoveryCodes() code %v has an unexpected format", code)
+		}
+		if seen[code] {
+			t.Errorf("GenerateRecoveryCodes() code %v is duplicated", code)
+		}
+		seen[code] = true
+	}
+}
+
+func TestNormalizeRecoveryCode(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want string
+	}{
+		{
+			name: "Happy case: formatted code",
+			code: "abcde-23456",
+			want: "abcde23456",
+		},
+		{
+			name: "Happy case: code with spaces and upper case characters",
+			code: " ABCDE 23456 ",
+			want: "abcde23456",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeRecoveryCode(tt.code); got != tt.want {
+				t.Errorf("NormalizeRecoveryCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"strconv"
 
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 )
 
@@ -101,6 +102,24 @@ var (
 		Category:    PermissionCategoryAuthorization.String(),
 		Scope:       "role.read",
 	}
+	canCreateRole = domain.AuthorityPermission{
+		Name:        "Create roles",
+		Description: "Can create roles",
+		Category:    PermissionCategoryAuthorization.String(),
+		Scope:       "role.create",
+	}
+	canUpdateRole = domain.AuthorityPermission{
+		Name:        "Update roles",
+		Description: "Can add and remove permissions from roles",
+		Category:    PermissionCategoryAuthorization.String(),
+		Scope:       "role.update",
+	}
+	canAssignRole = domain.AuthorityPermission{
+		Name:        "Assign roles",
+		Description: "Can assign and revoke roles from users",
+		Category:    PermissionCategoryAuthorization.String(),
+		Scope:       "role.assign",
+	}
 )
 
 // Content Permissions
@@ -184,6 +203,7 @@ var (
 )
 
 // Organisation Permissions
+// Creating and deleting organisations and creating programs are not scoped to a program so only superusers can do them
 var (
 	canReadOrganisation = domain.AuthorityPermission{
 		Name:        "Read organisation",
@@ -191,18 +211,6 @@ var (
 		Category:    PermissionCategoryOrganisation.String(),
 		Scope:       "organisation.read",
 	}
-	canCreateOrganisation = domain.AuthorityPermission{
-		Name:        "Create organisation",
-		Description: "Can create organisation",
-		Category:    PermissionCategoryOrganisation.String(),
-		Scope:       "organisation.create",
-	}
-	canDeleteOrganisation = domain.AuthorityPermission{
-		Name:        "Delete organisation",
-		Description: "Can delete organisation",
-		Category:    PermissionCategoryOrganisation.String(),
-		Scope:       "organisation.delete",
-	}
 )
 
 // OTP Permissions
@@ -223,12 +231,6 @@ var (
 		Category:    PermissionCategoryProgram.String(),
 		Scope:       "program.read",
 	}
-	canCreateProgram = domain.AuthorityPermission{
-		Name:        "Create Program",
-		Description: "Can create program",
-		Category:    PermissionCategoryProgram.String(),
-		Scope:       "program.create",
-	}
 	canUpdateProgram = domain.AuthorityPermission{
 		Name:        "Update Program",
 		Description: "Can update program",
@@ -319,6 +321,12 @@ var (
 		Category:    PermissionCategoryServiceRequest.String(),
 		Scope:       "staff.servicerequest.update",
 	}
+	canUpdateServiceRequestSLA = domain.AuthorityPermission{
+		Name:        "Update service request SLA",
+		Description: "Can set how long a program's service requests can wait before they are escalated",
+		Category:    PermissionCategoryServiceRequest.String(),
+		Scope:       "servicerequest.sla.update",
+	}
 )
 
 // Survey Permissions
@@ -429,24 +437,84 @@ var (
 		Category:    PermissionCategoryUser.String(),
 		Scope:       "client.create",
 	}
+	canBulkCreateClients = domain.AuthorityPermission{
+		Name:        "Bulk create clients",
+		Description: "Can register the clients in a csv file",
+		Category:    PermissionCategoryUser.String(),
+		Scope:       "client.bulk_create",
+	}
+	canMergeClients = domain.AuthorityPermission{
+		Name:        "Merge clients",
+		Description: "Can find clients that were registered more than once and merge their records",
+		Category:    PermissionCategoryUser.String(),
+		Scope:       "client.merge",
+	}
+	canExportClientData = domain.AuthorityPermission{
+		Name:        "Export client data",
+		Description: "Can export all the data held about a client when they request it",
+		Category:    PermissionCategoryUser.String(),
+		Scope:       "client.export",
+	}
+	canTransferClient = domain.AuthorityPermission{
+		Name:        "Transfer client",
+		Description: "Can transfer a client to another facility, program or organisation",
+		Category:    PermissionCategoryUser.String(),
+		Scope:       "client.transfer",
+	}
 	canCreateStaff = domain.AuthorityPermission{
 		Name:        "Create staff",
 		Description: "Can create staff",
 		Category:    PermissionCategoryUser.String(),
 		Scope:       "staff.create",
 	}
+	canUpdateStaff = domain.AuthorityPermission{
+		Name:        "Update staff",
+		Description: "Can update staff",
+		Category:    PermissionCategoryUser.String(),
+		Scope:       "staff.update",
+	}
 	canCreateCaregiver = domain.AuthorityPermission{
 		Name:        "Create caregiver",
 		Description: "Can create caregiver",
 		Category:    PermissionCategoryUser.String(),
 		Scope:       "caregiver.create",
 	}
+	canUpdateCaregiverDelegation = domain.AuthorityPermission{
+		Name:        "Update caregiver delegation",
+		Description: "Can set the date that a caregiver's access to a client ends",
+		Category:    PermissionCategoryUser.String(),
+		Scope:       "caregiver.delegation.update",
+	}
 	canDeleteUser = domain.AuthorityPermission{
 		Name:        "Delete user",
 		Description: "Can delete user",
 		Category:    PermissionCategoryUser.String(),
 		Scope:       "user.delete",
 	}
+	canRevokeUserSessions = domain.AuthorityPermission{
+		Name:        "Revoke user sessions",
+		Description: "Can log a user out of all their sessions",
+		Category:    PermissionCategoryUser.String(),
+		Scope:       "user.session.revoke",
+	}
+	canResetSecurityQuestions = domain.AuthorityPermission{
+		Name:        "Reset security questions",
+		Description: "Can clear a user's security question answers so that they set new ones",
+		Category:    PermissionCategoryUser.String(),
+		Scope:       "user.security_questions.reset",
+	}
+	canChangeUserPhone = domain.AuthorityPermission{
+		Name:        "Change user phone number",
+		Description: "Can help a user change their phone number by confirming the verification code sent to the new phone number",
+		Category:    PermissionCategoryUser.String(),
+		Scope:       "user.phone.update",
+	}
+	canOffboardStaff = domain.AuthorityPermission{
+		Name:        "Offboard staff",
+		Description: "Can remove a staff who has left the organisation and hand over their service requests",
+		Category:    PermissionCategoryUser.String(),
+		Scope:       "staff.offboard",
+	}
 	//canCreateUserInvite = domain.AuthorityPermission{
 	//	Name:        "Create user invite",
 	//	Description: "Can create user invite",
@@ -464,6 +532,9 @@ func AllPermissions(ctx context.Context) []domain.AuthorityPermission {
 
 		// Authorization Permissions
 		canReadSystemRole,
+		canCreateRole,
+		canUpdateRole,
+		canAssignRole,
 
 		// Content Permissions
 		canReadContent,
@@ -487,15 +558,12 @@ func AllPermissions(ctx context.Context) []domain.AuthorityPermission {
 
 		// Organisation Permissions
 		canReadOrganisation,
-		canCreateOrganisation,
-		canDeleteOrganisation,
 
 		// OTP Permissions
 		canCreateOTP,
 
 		// Program Permissions
 		canReadProgram,
-		canCreateProgram,
 		canUpdateProgram,
 
 		// ScreeningTool Permissions
@@ -515,6 +583,7 @@ func AllPermissions(ctx context.Context) []domain.AuthorityPermission {
 		canUpdateServiceRequest,
 		canUpdateClientServiceRequest,
 		canUpdateStaffServiceRequest,
+		canUpdateServiceRequestSLA,
 
 		// Survey Permissions
 		canReadSurvey,
@@ -536,9 +605,96 @@ func AllPermissions(ctx context.Context) []domain.AuthorityPermission {
 		canReadClientIdentifier,
 		canUpdateUser,
 		canCreateClient,
+		canBulkCreateClients,
+		canMergeClients,
+		canExportClientData,
+		canTransferClient,
 		canCreateStaff,
+		canUpdateStaff,
 		canCreateCaregiver,
+		canUpdateCaregiverDelegation,
 		canDeleteUser,
+		canRevokeUserSessions,
+		canResetSecurityQuestions,
+		canChangeUserPhone,
+		canOffboardStaff,
+	}
+}
+
+// DefaultStaffPermissions return default staff permissions
+func DefaultStaffPermissions(ctx context.Context) []domain.AuthorityPermission {
+	return []domain.AuthorityPermission{
+		// Appointment Permissions
+		canReadClientAppointment,
+		canUpdateClientAppointment,
+
+		// Authorization Permissions
+		canReadSystemRole,
+
+		// Content Permissions
+		canReadContent,
+
+		// Facility Permissions
+		canReadFacility,
+
+		// HealthDiary Permissions
+		canReadHealthDiary,
+		canReadClientHealthDiary,
+
+		// Notification Permissions
+		canReadNotification,
+
+		// Organisation Permissions
+		canReadOrganisation,
+
+		// OTP Permissions
+		canCreateOTP,
+
+		// Program Permissions
+		canReadProgram,
+
+		// ScreeningTool Permissions
+		canReadScreeningTool,
+		canReadScreeningToolResponse,
+		canReadScreeningToolRespondent,
+
+		// SecurityQuestion Permissions
+		canReadSecurityQuestion,
+		canCreateSecurityQuestion,
+
+		// ServiceRequest Permissions
+		canReadServiceRequest,
+		canCreateServiceRequest,
+		canUpdateServiceRequest,
+		canUpdateClientServiceRequest,
+		canUpdateStaffServiceRequest,
+
+		// Survey Permissions
+		canReadSurvey,
+		canReadSurveyRespondent,
+		canReadClientWithServiceRequest,
+		canReadSurveyResponse,
+		canCreateSurveyLink,
+
+		// User Permissions
+		canReadTerms,
+		canReadPin,
+		canReadClient,
+		canReadStaff,
+		canReadCaregiver,
+		canReadClientOfCaregiver,
+		canReadCaregiverOfClient,
+		canReadStaffFacility,
+		canReadClientFacility,
+		canReadClientIdentifier,
+		canUpdateUser,
+		canCreateClient,
+		canBulkCreateClients,
+		canTransferClient,
+		canCreateCaregiver,
+		canUpdateCaregiverDelegation,
+		canResetSecurityQuestions,
+		canChangeUserPhone,
 	}
 }
 
@@ -642,3 +798,14 @@ func DefaultCaregiverPermissions(ctx context.Context) []domain.AuthorityPermissi
 		canUpdateUser,
 	}
 }
+
+// ValidateCaregiverAbilities checks that abilities delegated to a caregiver are ones that a client can delegate
+func ValidateCaregiverAbilities(ctx context.Context, abilities []enums.CaregiverAbility) error {
+	for _, ability := range abilities {
+		if !ability.IsValid() {
+			return fmt.Errorf("%s is not a valid caregiver ability", ability)
+		}
+	}
+
+	return nil
+}
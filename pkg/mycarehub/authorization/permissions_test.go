@@ -2,8 +2,11 @@ package authorization
 
 import (
 	"bytes"
+	"context"
 	"strconv"
 	"testing"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 )
 
 func TestPermissionCategory_IsValid(t *testing.T) {
@@ -121,3 +124,34 @@ func TestPermissionCategory_MarshalGQL(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateCaregiverAbilities(t *testing.T) {
+	tests := []struct {
+		name      string
+		abilities []enums.CaregiverAbility
+		wantErr   bool
+	}{
+		{
+			name:      "Happy Case - All abilities can be delegated",
+			abilities: enums.AllCaregiverAbilities,
+			wantErr:   false,
+		},
+		{
+			name:      "Happy Case - No abilities",
+			abilities: []enums.CaregiverAbility{},
+			wantErr:   false,
+		},
+		{
+			name:      "Sad Case - Invalid ability",
+			abilities: []enums.CaregiverAbility{enums.CaregiverAbilityViewAppointments, "MANAGE_EVERYTHING"},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateCaregiverAbilities(context.Background(), tt.abilities); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCaregiverAbilities() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"strconv"
 
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 )
 
@@ -14,6 +15,7 @@ type DefaultRole string
 
 const (
 	DefaultRoleAdmin     DefaultRole = "Default Admin"
+	DefaultRoleStaff     DefaultRole = "Default Staff"
 	DefaultRoleClient    DefaultRole = "Default Client"
 	DefaultRoleCaregiver DefaultRole = "Default Caregiver"
 )
@@ -22,6 +24,7 @@ const (
 func (p DefaultRole) IsValid() bool {
 	switch p {
 	case DefaultRoleAdmin,
+		DefaultRoleStaff,
 		DefaultRoleClient,
 		DefaultRoleCaregiver:
 		return true
@@ -56,18 +59,50 @@ func (p DefaultRole) MarshalGQL(w io.Writer) {
 var (
 	// DefaultAdminRole defines the default role assigned to an admin
 	DefaultAdminRole = domain.AuthorityRole{
-		Name:        DefaultRoleAdmin.String(),
-		Permissions: AllPermissions(context.Background()),
+		Name:         DefaultRoleAdmin.String(),
+		Description:  "Default role granted all the permissions in a program",
+		Active:       true,
+		IsSystemRole: true,
+		UserType:     enums.StaffUser,
+		Permissions:  AllPermissions(context.Background()),
+	}
+
+	// DefaultStaffRole defines the default role assigned to a staff
+	DefaultStaffRole = domain.AuthorityRole{
+		Name:         DefaultRoleStaff.String(),
+		Description:  "Default role granted the permissions that a staff needs in a program",
+		Active:       true,
+		IsSystemRole: true,
+		UserType:     enums.StaffUser,
+		Permissions:  DefaultStaffPermissions(context.Background()),
 	}
 
 	// DefaultClientRole defines the default role assigned to a client
 	DefaultClientRole = domain.AuthorityRole{
-		Name:        DefaultRoleClient.String(),
-		Permissions: DefaultClientPermissions(context.Background()),
+		Name:         DefaultRoleClient.String(),
+		Description:  "Default role granted the permissions that a client needs in a program",
+		Active:       true,
+		IsSystemRole: true,
+		UserType:     enums.ClientUser,
+		Permissions:  DefaultClientPermissions(context.Background()),
 	}
 	// DefaultCaregiverRole defines the default role assigned to a caregiver
 	DefaultCaregiverRole = domain.AuthorityRole{
-		Name:        DefaultRoleCaregiver.String(),
-		Permissions: DefaultCaregiverPermissions(context.Background()),
+		Name:         DefaultRoleCaregiver.String(),
+		Description:  "Default role granted the permissions that a caregiver needs in a program",
+		Active:       true,
+		IsSystemRole: true,
+		UserType:     enums.CaregiverUser,
+		Permissions:  DefaultCaregiverPermissions(context.Background()),
 	}
 )
+
+// DefaultRoles returns the roles that every program is seeded with
+func DefaultRoles() []domain.AuthorityRole {
+	return []domain.AuthorityRole{
+		DefaultAdminRole,
+		DefaultStaffRole,
+		DefaultClientRole,
+		DefaultCaregiverRole,
+	}
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+)
+
+// AuditLog is a record of a sensitive action performed in the system
+type AuditLog struct {
+	ID             string                   `json:"id"`
+	Active         bool                     `json:"active"`
+	Timestamp      time.Time                `json:"timestamp"`
+	RecordType     enums.AuditLogRecordType `json:"recordType"`
+	Notes          string                   `json:"notes"`
+	ActorID        string                   `json:"actorID"`
+	TargetID       string                   `json:"targetID"`
+	TargetType     enums.AuditLogTargetType `json:"targetType"`
+	ProgramID      string                   `json:"programID"`
+	OrganisationID string                   `json:"organisationID"`
+	Before         map[string]interface{}   `json:"before"`
+	After          map[string]interface{}   `json:"after"`
+
+	// Sequence, PreviousHash and Hash chain the entry to the one recorded before it in the same organisation
+	Sequence     int64  `json:"sequence"`
+	PreviousHash string `json:"previousHash"`
+	Hash         string `json:"hash"`
+}
+
+// AuditLogPage is a paginated list of audit logs
+type AuditLogPage struct {
+	AuditLogs  []*AuditLog `json:"auditLogs"`
+	Pagination Pagination  `json:"pagination"`
+}
+
+// AuditTrailVerification is the outcome of checking an organisation's audit log chain for gaps or modifications
+type AuditTrailVerification struct {
+	OrganisationID  string             `json:"organisationID"`
+	EntriesVerified int                `json:"entriesVerified"`
+	Issues          []*AuditTrailIssue `json:"issues"`
+}
+
+// AuditTrailIssue is a problem found when verifying an organisation's audit log chain
+type AuditTrailIssue struct {
+	Sequence    int64                     `json:"sequence"`
+	AuditLogID  string                    `json:"auditLogID"`
+	IssueType   enums.AuditTrailIssueType `json:"issueType"`
+	Description string                    `json:"description"`
+}
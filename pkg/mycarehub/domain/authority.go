@@ -6,7 +6,10 @@ import "github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 type AuthorityRole struct {
 	AuthorityRoleID string                `json:"authorityRoleID"`
 	Name            string                `json:"name"`
+	Description     string                `json:"description"`
 	Active          bool                  `json:"active"`
+	IsSystemRole    bool                  `json:"isSystemRole"`
+	UserType        enums.UsersType       `json:"userType"`
 	OrganisationID  string                `json:"organisationID"`
 	ProgramID       string                `json:"programID"`
 	Permissions     []AuthorityPermission `json:"permissions"`
@@ -14,10 +17,10 @@ type AuthorityRole struct {
 
 // AuthorityPermission defines user permissions
 type AuthorityPermission struct {
-	PermissionID string               `json:"permissionID"`
-	Active       bool                 `json:"active"`
-	Name         enums.PermissionType `json:"name"`
-	Description  string               `json:"description"`
-	Category     string               `json:"category"`
-	Scope        string               `json:"scope"`
+	PermissionID string `json:"permissionID"`
+	Active       bool   `json:"active"`
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	Category     string `json:"category"`
+	Scope        string `json:"scope"`
 }
@@ -26,6 +26,7 @@ type CaregiverProfile struct {
 	Consent         ConsentStatus `json:"consent"`
 	CurrentClient   *string       `json:"currentClient"`
 	CurrentFacility *string       `json:"currentFacility"`
+	OrganisationID  string        `json:"organisationID"`
 }
 
 // ConsentStatus is used to indicate the consent status of a caregiver
@@ -46,6 +47,44 @@ type CaregiverClient struct {
 	OrganisationID     string              `json:"organisationID"`
 	AssignedBy         string              `json:"assignedBy"`
 	ProgramID          string              `json:"programID"`
+
+	// The period the caregiver can act on behalf of the client and what they can do
+	StartsAt  *time.Time               `json:"startsAt"`
+	EndsAt    *time.Time               `json:"endsAt"`
+	Abilities []enums.CaregiverAbility `json:"abilities"`
+
+	// RevokedAt is set when the client revokes the delegation and ExpiredAt once its end date has passed
+	RevokedAt *time.Time `json:"revokedAt"`
+	RevokedBy *string    `json:"revokedBy"`
+	ExpiredAt *time.Time `json:"expiredAt"`
+}
+
+// InEffect returns true if the caregiver can act on behalf of the client at the given time
+func (c *CaregiverClient) InEffect(at time.Time) bool {
+	if c.RevokedAt != nil || c.ExpiredAt != nil {
+		return false
+	}
+
+	if c.StartsAt != nil && at.Before(*c.StartsAt) {
+		return false
+	}
+
+	return c.EndsAt == nil || at.Before(*c.EndsAt)
+}
+
+// Allows returns true if the client has consented to the caregiver and delegated the ability to them at the given time
+func (c *CaregiverClient) Allows(ability enums.CaregiverAbility, at time.Time) bool {
+	if c.ClientConsent != enums.ConsentStateAccepted || !c.InEffect(at) {
+		return false
+	}
+
+	for _, delegated := range c.Abilities {
+		if delegated == ability {
+			return true
+		}
+	}
+
+	return false
 }
 
 // ManagedClient represents a client who is managed by a caregiver
@@ -54,6 +93,10 @@ type ManagedClient struct {
 	CaregiverConsent   enums.ConsentState `json:"caregiverConsent"`
 	ClientConsent      enums.ConsentState `json:"clientConsent"`
 	WorkStationDetails WorkStationDetails `json:"workStationDetails"`
+
+	Abilities []enums.CaregiverAbility `json:"abilities"`
+	StartsAt  *time.Time               `json:"startsAt"`
+	EndsAt    *time.Time               `json:"endsAt"`
 }
 
 // ClientCaregivers is the model that holds the client's caregivers
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+)
+
+func TestCaregiverClient_Allows(t *testing.T) {
+	now := time.Now()
+	yesterday := now.AddDate(0, 0, -1)
+	tomorrow := now.AddDate(0, 0, 1)
+
+	tests := []struct {
+		name            string
+		caregiverClient CaregiverClient
+		ability         enums.CaregiverAbility
+		want            bool
+	}{
+		{
+			name: "Happy Case - Ability delegated for an open ended period",
+			caregiverClient: CaregiverClient{
+				ClientConsent: enums.ConsentStateAccepted,
+				StartsAt:      &yesterday,
+				Abilities:     []enums.CaregiverAbility{enums.CaregiverAbilityViewAppointments},
+			},
+			ability: enums.CaregiverAbilityViewAppointments,
+			want:    true,
+		},
+		{
+			name: "Happy Case - Ability delegated until tomorrow",
+			caregiverClient: CaregiverClient{
+				ClientConsent: enums.ConsentStateAccepted,
+				EndsAt:        &tomorrow,
+				Abilities:     []enums.CaregiverAbility{enums.CaregiverAbilityRecordHealthDiary},
+			},
+			ability: enums.CaregiverAbilityRecordHealthDiary,
+			want:    true,
+		},
+		{
+			name: "Sad Case - Ability not delegated",
+			caregiverClient: CaregiverClient{
+				ClientConsent: enums.ConsentStateAccepted,
+				Abilities:     []enums.CaregiverAbility{enums.CaregiverAbilityViewAppointments},
+			},
+			ability: enums.CaregiverAbilityRescheduleAppointments,
+			want:    false,
+		},
+		{
+			name: "Sad Case - Client has not consented",
+			caregiverClient: CaregiverClient{
+				ClientConsent: enums.ConsentStatePending,
+				Abilities:     enums.AllCaregiverAbilities,
+			},
+			ability: enums.CaregiverAbilityViewAppointments,
+			want:    false,
+		},
+		{
+			name: "Sad Case - Delegation has not started",
+			caregiverClient: CaregiverClient{
+				ClientConsent: enums.ConsentStateAccepted,
+				StartsAt:      &tomorrow,
+				Abilities:     enums.AllCaregiverAbilities,
+			},
+			ability: enums.CaregiverAbilityViewAppointments,
+			want:    false,
+		},
+		{
+			name: "Sad Case - Delegation has ended",
+			caregiverClient: CaregiverClient{
+				ClientConsent: enums.ConsentStateAccepted,
+				EndsAt:        &yesterday,
+				Abilities:     enums.AllCaregiverAbilities,
+			},
+			ability: enums.CaregiverAbilityViewAppointments,
+			want:    false,
+		},
+		{
+			name: "Sad Case - Delegation was revoked",
+			caregiverClient: CaregiverClient{
+				ClientConsent: enums.ConsentStateAccepted,
+				RevokedAt:     &yesterday,
+				Abilities:     enums.AllCaregiverAbilities,
+			},
+			ability: enums.CaregiverAbilityViewAppointments,
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.caregiverClient.Allows(tt.ability, now); got != tt.want {
+				t.Errorf("CaregiverClient.Allows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+)
+
+// ClientTransfer records a client moving to another facility, program or organisation.
+// A client who moves to another program gets a new client profile there and their old profile is deactivated
+type ClientTransfer struct {
+	ID     string `json:"id"`
+	UserID string `json:"userID"`
+
+	// ClientID is the profile the client was transferred from and NewClientID the one they were transferred to.
+	// They are the same when the client moves to another facility within their program
+	ClientID    string `json:"clientID"`
+	NewClientID string `json:"newClientID"`
+
+	FromFacilityID     string `json:"fromFacilityID"`
+	ToFacilityID       string `json:"toFacilityID"`
+	FromProgramID      string `json:"fromProgramID"`
+	ToProgramID        string `json:"toProgramID"`
+	FromOrganisationID string `json:"fromOrganisationID"`
+	ToOrganisationID   string `json:"toOrganisationID"`
+
+	Reason string `json:"reason"`
+
+	// CarriedHistory is the history the client consented to carry to their new program
+	CarriedHistory []enums.ClientTransferHistory `json:"carriedHistory"`
+
+	// ClientConsented records whether the client consented to carrying their history to the new program
+	ClientConsented bool `json:"clientConsented"`
+
+	// TransferredBy is the user ID of the staff who transferred the client
+	TransferredBy string    `json:"transferredBy"`
+	TransferredAt time.Time `json:"transferredAt"`
+}
+
+// IsProgramTransfer returns true if the client moved to another program
+func (c *ClientTransfer) IsProgramTransfer() bool {
+	return c.FromProgramID != c.ToProgramID
+}
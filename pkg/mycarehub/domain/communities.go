@@ -33,11 +33,19 @@ type MatrixAuth struct {
 	Password string `json:"password"`
 }
 
-// MatrixUserRegistration defines the structure of the input to be used when registering a Matrix user
+// MatrixUserRegistration defines the structure of the input to be used when registering a Matrix user.
+// It is also used to update an existing user, in which case the password is left out so that the user is not logged out of their devices
 type MatrixUserRegistration struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
-	Admin    bool   `json:"admin"`
+	Username  string            `json:"username"`
+	Password  string            `json:"password,omitempty"`
+	Admin     bool              `json:"admin"`
+	Threepids []*MatrixThreepid `json:"threepids,omitempty"`
+}
+
+// MatrixThreepid is a third party identifier e.g a phone number that is linked to a Matrix user
+type MatrixThreepid struct {
+	Medium  string `json:"medium"`
+	Address string `json:"address"`
 }
 
 // MatrixUserSearchResult defines the structure of the users search output
@@ -0,0 +1,24 @@
+package domain
+
+import "github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+
+// DuplicateClient is a client that may be the same person as another client. The score is higher the more
+// identifying details the two clients share
+type DuplicateClient struct {
+	Client  *ClientProfile               `json:"client"`
+	Score   int                          `json:"score"`
+	Matches []enums.DuplicateClientMatch `json:"matches"`
+}
+
+// ClientMerge is the number of records and links that were moved from a duplicate client to the surviving client when they were merged
+type ClientMerge struct {
+	SurvivingClientID      string `json:"survivingClientID"`
+	DuplicateClientID      string `json:"duplicateClientID"`
+	HealthDiaryEntries     int    `json:"healthDiaryEntries"`
+	ServiceRequests        int    `json:"serviceRequests"`
+	Appointments           int    `json:"appointments"`
+	ScreeningToolResponses int    `json:"screeningToolResponses"`
+	ServiceBookings        int    `json:"serviceBookings"`
+	CaregiverLinks         int    `json:"caregiverLinks"`
+	Facilities             int    `json:"facilities"`
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+)
+
+// UserDevice is a device that a user has logged in from. It is identified by a fingerprint of the
+// device ID and user agent that the device reports when logging in
+type UserDevice struct {
+	ID            string    `json:"id"`
+	UserID        string    `json:"userID"`
+	Fingerprint   string    `json:"fingerprint"`
+	DeviceID      string    `json:"deviceID"`
+	DeviceName    string    `json:"deviceName"`
+	UserAgent     string    `json:"userAgent"`
+	LastIPAddress string    `json:"lastIPAddress"`
+	LastCountry   string    `json:"lastCountry"`
+	LastLoginAt   time.Time `json:"lastLoginAt"`
+}
+
+// LoginEvent is a successful login and the anomalies that were detected when evaluating its risk
+type LoginEvent struct {
+	ID          string                `json:"id"`
+	UserID      string                `json:"userID"`
+	Fingerprint string                `json:"fingerprint"`
+	IPAddress   string                `json:"ipAddress"`
+	Country     string                `json:"country"`
+	RiskFlags   []enums.LoginRiskFlag `json:"riskFlags"`
+
+	// SteppedUp is true when the user confirmed the login with an OTP because it was suspicious
+	SteppedUp bool      `json:"steppedUp"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// IsSuspicious returns true if any anomaly was detected for the login
+func (l *LoginEvent) IsSuspicious() bool {
+	return len(l.RiskFlags) > 0
+}
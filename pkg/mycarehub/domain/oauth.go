@@ -43,6 +43,42 @@ type AuthorizationCode struct {
 	Client    OauthClient
 }
 
+// OpenIDConnectSession is the authorization request that an ID token is issued for when its authorization code is exchanged
+type OpenIDConnectSession struct {
+	ID     string
+	Active bool
+	Code   string
+
+	RequestedAt       time.Time
+	RequestedScopes   []string
+	GrantedScopes     []string
+	Form              url.Values
+	RequestedAudience []string
+	GrantedAudience   []string
+
+	SessionID string
+	Session   Session
+	ClientID  string
+	Client    OauthClient
+}
+
+// OauthSigningKey is an RSA key that tokens are signed with.
+// A new key is used to sign tokens after the rotation time while the old key is still published until it expires
+// so that tokens signed with it can still be verified
+type OauthSigningKey struct {
+	ID        string
+	KeyID     string
+	Algorithm string
+
+	// PrivateKey is the PEM encoded private key. It is encrypted at rest
+	PrivateKey string
+	PublicKey  string
+
+	CreatedAt time.Time
+	RotateAt  time.Time
+	ExpiresAt time.Time
+}
+
 // OauthClientJWT
 type OauthClientJWT struct {
 	ID        string
@@ -51,7 +87,8 @@ type OauthClientJWT struct {
 	ExpiresAt time.Time
 }
 
-// PKCE
+// PKCE is the authorization request of an authorization code that holds the code challenge sent by the client.
+// The code verifier sent when the code is exchanged for tokens is checked against it
 type PKCE struct {
 	ID        string
 	Active    bool
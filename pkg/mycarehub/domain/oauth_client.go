@@ -32,6 +32,12 @@ type OauthClient struct {
 	// the authentication method that the client uses to authenticate with the auth server when requesting tokens.
 	// e.g  "client_secret_basic"
 	TokenEndpointAuthMethod string
+	// the SHA-256 hash of the token that a dynamically registered client uses to read, update and delete its registration.
+	// It is empty for clients that were not registered dynamically
+	RegistrationAccessToken string
+	// the MFL code of the facility that an integration client e.g KenyaEMR acts for.
+	// A client that is bound to a facility can only access that facility's records
+	MFLCode string
 }
 
 // GetID returns the client ID.
@@ -10,6 +10,42 @@ import (
 	"github.com/ory/fosite/token/jwt"
 )
 
+// SessionIDClaim is the extra claim that holds the ID of the session that a token was issued for
+const SessionIDClaim = "session_id"
+
+// TokenUseClaim is the claim that tells JWT access tokens apart from ID tokens, which are signed with the same keys
+const TokenUseClaim = "token_use"
+
+// AccessTokenUse is the value of the token use claim of JWT access tokens
+const AccessTokenUse = "access"
+
+// TOTPEnrollmentClaim is the extra claim of the sessions of staff who have to enroll an authenticator app before they
+// can use the platform. The tokens issued for these sessions can only be used to enroll the app
+const TOTPEnrollmentClaim = "totp_enrollment"
+
+// AccessTokenExtraClaims are the claims of a session that are added to its JWT access tokens so that requests can be
+// authorized without looking up the token
+var AccessTokenExtraClaims = []string{
+	"user_id",
+	"organisation_id",
+	"program_id",
+	"facility_id",
+	TOTPEnrollmentClaim,
+}
+
+// IDTokenExtraClaims are the claims of a session that are added to its ID tokens and returned by the userinfo endpoint.
+// They describe the user and the program and facility that they logged in to
+var IDTokenExtraClaims = []string{
+	"email",
+	"gender",
+	"organisation_id",
+	"program_id",
+	"program_name",
+	"facility_id",
+	"facility_name",
+	"staff_id",
+}
+
 type Session struct {
 	ID       string
 	ClientID string
@@ -23,6 +59,40 @@ type Session struct {
 
 	UserID string
 	User   User
+
+	// The device that the session was started on
+	DeviceID   string
+	DeviceName string
+	UserAgent  string
+	IPAddress  string
+	PushToken  string
+
+	CreatedAt  time.Time
+	LastSeenAt *time.Time
+	RevokedAt  *time.Time
+
+	// The claims and headers of the ID tokens issued for the session. They are built from the session when first used
+	IDClaims  *jwt.IDTokenClaims
+	IDHeaders *jwt.Headers
+}
+
+// IsRevoked returns true if the session has been ended by the user or a staff
+func (s *Session) IsRevoked() bool {
+	return s.RevokedAt != nil
+}
+
+// UserSession is a session that a user is logged in with, as listed to the user
+type UserSession struct {
+	ID         string     `json:"id"`
+	DeviceID   string     `json:"deviceID"`
+	DeviceName string     `json:"deviceName"`
+	UserAgent  string     `json:"userAgent"`
+	IPAddress  string     `json:"ipAddress"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastSeenAt *time.Time `json:"lastSeenAt"`
+
+	// Current is true for the session that the user made the request with
+	Current bool `json:"current"`
 }
 
 func NewSession(
@@ -34,8 +104,16 @@ func NewSession(
 	extra map[string]interface{},
 ) *Session {
 
+	sessionID := uuid.New().String()
+
+	// the session ID is returned when the access token is introspected so that requests can be tied to the session
+	if extra == nil {
+		extra = map[string]interface{}{}
+	}
+	extra[SessionIDClaim] = sessionID
+
 	session := &Session{
-		ID:       uuid.New().String(),
+		ID:       sessionID,
 		UserID:   userID,
 		ClientID: clientID,
 		Username: username,
@@ -110,11 +188,66 @@ func (s *Session) Clone() fosite.Session {
 // IDTokenClaims returns a pointer to claims which will be modified in-place by handlers.
 // Session should store this pointer and return always the same pointer.
 func (s *Session) IDTokenClaims() *jwt.IDTokenClaims {
-	return &jwt.IDTokenClaims{}
+	if s.IDClaims == nil {
+		s.IDClaims = &jwt.IDTokenClaims{
+			Subject:     s.UserID,
+			AuthTime:    s.CreatedAt,
+			RequestedAt: s.CreatedAt,
+			Extra:       s.UserInfoClaims(),
+		}
+	}
+
+	return s.IDClaims
 }
 
 // IDTokenHeaders returns a pointer to header values which will be modified in-place by handlers.
 // Session should store this pointer and return always the same pointer.
 func (s *Session) IDTokenHeaders() *jwt.Headers {
+	if s.IDHeaders == nil {
+		s.IDHeaders = &jwt.Headers{}
+	}
+
+	return s.IDHeaders
+}
+
+// UserInfoClaims returns the OpenID Connect claims about the session's user other than the subject
+func (s *Session) UserInfoClaims() map[string]interface{} {
+	claims := map[string]interface{}{
+		"name":               s.Subject,
+		"preferred_username": s.Username,
+	}
+
+	for _, claim := range IDTokenExtraClaims {
+		if value, ok := s.Extra[claim]; ok && value != nil {
+			claims[claim] = value
+		}
+	}
+
+	return claims
+}
+
+// GetJWTClaims returns the claims of the JWT access tokens issued for the session.
+// A new container is returned each time since the token strategy sets the expiry and scopes on it
+func (s *Session) GetJWTClaims() jwt.JWTClaimsContainer {
+	extra := map[string]interface{}{
+		TokenUseClaim:  AccessTokenUse,
+		SessionIDClaim: s.ID,
+		"client_id":    s.ClientID,
+	}
+
+	for _, claim := range AccessTokenExtraClaims {
+		if value, ok := s.Extra[claim]; ok && value != nil && value != "" {
+			extra[claim] = value
+		}
+	}
+
+	return &jwt.JWTClaims{
+		Subject: s.UserID,
+		Extra:   extra,
+	}
+}
+
+// GetJWTHeader returns the header of the JWT access tokens issued for the session
+func (s *Session) GetJWTHeader() *jwt.Headers {
 	return &jwt.Headers{}
 }
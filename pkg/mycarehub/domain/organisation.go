@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 // Organisation represents the DAO of an organisation
 type Organisation struct {
 	ID              string     `json:"id"`
@@ -13,4 +15,47 @@ type Organisation struct {
 	PhysicalAddress string     `json:"physicalAddress"`
 	DefaultCountry  string     `json:"defaultCountry"`
 	Programs        []*Program `json:"programs"`
+
+	EnforceStaffTOTP bool `json:"enforceStaffTOTP"`
+	// StaffLoginStepUp requires staff to confirm logins from a new device or location with an OTP sent to their phone
+	StaffLoginStepUp bool      `json:"staffLoginStepUp"`
+	PINPolicy        PINPolicy `json:"pinPolicy"`
+	// DataRetentionDays is the number of days a client's data is kept after they ask for it to be erased
+	DataRetentionDays int `json:"dataRetentionDays"`
+	// AuditLogSequence and AuditLogHash are the sequence and hash of the last audit log recorded in the organisation
+	AuditLogSequence int64  `json:"auditLogSequence"`
+	AuditLogHash     string `json:"auditLogHash"`
+}
+
+// DefaultPINPolicy is the PIN policy applied to users who do not belong to an organisation
+var DefaultPINPolicy = PINPolicy{
+	MinLength:         4,
+	DisallowSimplePIN: true,
+	HistoryCount:      3,
+
+	SecurityQuestionMaxAttempts: 3,
+}
+
+// PINPolicy is the set of rules an organisation's users' PINs have to meet
+type PINPolicy struct {
+	// MinLength is the minimum number of digits in a PIN
+	MinLength int `json:"minLength"`
+	// DisallowSimplePIN rejects PINs made of a single repeated digit e.g 1111 or a sequence e.g 1234 or 9876
+	DisallowSimplePIN bool `json:"disallowSimplePIN"`
+	// HistoryCount is the number of the user's previous PINs that cannot be reused. Zero allows any PIN to be reused
+	HistoryCount int `json:"historyCount"`
+	// MaxAgeDays is the number of days after which the user is asked to change their PIN. Zero means that PINs do not age
+	MaxAgeDays int `json:"maxAgeDays"`
+	// SecurityQuestionMaxAttempts is the number of wrong security question answers after which the user can no longer
+	// reset their PIN by themselves and has to have their identity verified by a staff
+	SecurityQuestionMaxAttempts int `json:"securityQuestionMaxAttempts"`
+}
+
+// IsPINTooOld checks whether a PIN set at `validFrom` has exceeded the policy's maximum age
+func (p PINPolicy) IsPINTooOld(validFrom time.Time, now time.Time) bool {
+	if p.MaxAgeDays <= 0 {
+		return false
+	}
+
+	return now.After(validFrom.AddDate(0, 0, p.MaxAgeDays))
 }
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPINPolicy_IsPINTooOld(t *testing.T) {
+	now := time.Now()
+
+	type args struct {
+		validFrom time.Time
+		now       time.Time
+	}
+	tests := []struct {
+		name   string
+		policy PINPolicy
+		args   args
+		want   bool
+	}{
+		{
+			name:   "valid: pin older than the maximum age",
+			policy: PINPolicy{MaxAgeDays: 90},
+			args: args{
+				validFrom: now.AddDate(0, 0, -91),
+				now:       now,
+			},
+			want: true,
+		},
+		{
+			name:   "valid: pin within the maximum age",
+			policy: PINPolicy{MaxAgeDays: 90},
+			args: args{
+				validFrom: now.AddDate(0, 0, -89),
+				now:       now,
+			},
+			want: false,
+		},
+		{
+			name:   "valid: pins do not age without a maximum age",
+			policy: PINPolicy{MaxAgeDays: 0},
+			args: args{
+				validFrom: now.AddDate(-5, 0, 0),
+				now:       now,
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.IsPINTooOld(tt.args.validFrom, tt.args.now); got != tt.want {
+				t.Errorf("PINPolicy.IsPINTooOld() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
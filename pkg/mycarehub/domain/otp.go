@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/savannahghi/feedlib"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 )
 
 // OTP model the OTP details of OTP data
@@ -16,6 +17,19 @@ type OTP struct {
 	Flavour     feedlib.Flavour `json:"flavour"`
 	PhoneNumber string          `json:"phoneNumber"`
 	OTP         string          `json:"otp"`
+
+	DeliveryAttempts []*OTPDeliveryAttempt `json:"deliveryAttempts,omitempty"`
+}
+
+// OTPDeliveryAttempt is a single attempt to send an OTP through one of the delivery providers
+type OTPDeliveryAttempt struct {
+	ID                string                    `json:"id"`
+	Provider          enums.OTPDeliveryProvider `json:"provider"`
+	ProviderMessageID string                    `json:"providerMessageID"`
+	Status            enums.OTPDeliveryStatus   `json:"status"`
+	Error             string                    `json:"error"`
+	AttemptedAt       time.Time                 `json:"attemptedAt"`
+	StatusUpdatedAt   *time.Time                `json:"statusUpdatedAt"`
 }
 
 // OTPResponse models the object to be returned to the client when an OTP is generated
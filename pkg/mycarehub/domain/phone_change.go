@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/savannahghi/feedlib"
+)
+
+// PhoneChangeRequest is a pending change of a user's phone number. The user's current phone number stays in use
+// until the change is confirmed with the OTP that is sent to the new phone number
+type PhoneChangeRequest struct {
+	ID             string          `json:"id"`
+	UserID         string          `json:"userID"`
+	Flavour        feedlib.Flavour `json:"flavour"`
+	OldPhoneNumber string          `json:"oldPhoneNumber"`
+	NewPhoneNumber string          `json:"newPhoneNumber"`
+
+	// RequestedBy is the user who requested the change. It is a staff member when the change is assisted
+	RequestedBy string `json:"requestedBy"`
+	Assisted    bool   `json:"assisted"`
+	Reason      string `json:"reason"`
+
+	ExpiresAt   time.Time  `json:"expiresAt"`
+	ConfirmedAt *time.Time `json:"confirmedAt"`
+}
+
+// IsPending returns true if the change has not been confirmed and has not expired
+func (p *PhoneChangeRequest) IsPending(at time.Time) bool {
+	return p.ConfirmedAt == nil && at.Before(p.ExpiresAt)
+}
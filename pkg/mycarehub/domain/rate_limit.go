@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+)
+
+// RateLimitRule is a sliding window limit applied to the requests made with the same key e.g the same phone number
+type RateLimitRule struct {
+	KeyType enums.RateLimitKeyType `json:"keyType"`
+	Key     string                 `json:"key"`
+
+	// Limit is the maximum number of requests allowed within the window
+	Limit  int           `json:"limit"`
+	Window time.Duration `json:"window"`
+
+	// Cooldown is the minimum time allowed between two consecutive requests
+	Cooldown time.Duration `json:"cooldown"`
+}
+
+// RateLimitDecision is the outcome of checking a request against its rate limit rules
+type RateLimitDecision struct {
+	Allowed bool `json:"allowed"`
+
+	// Rule is the rule that rejected the request
+	Rule *RateLimitRule `json:"rule"`
+
+	// CooledDown is true when the request was rejected because it was made before the rule's cool-down had elapsed
+	CooledDown bool      `json:"cooledDown"`
+	RetryAfter time.Time `json:"retryAfter"`
+
+	// Rejections is the number of requests rejected for the rule's key within its window, including this one
+	Rejections int `json:"rejections"`
+}
@@ -37,6 +37,11 @@ type ServiceRequest struct {
 	CaregiverName      *string                `json:"caregiverName"`
 	CaregiverContact   *string                `json:"caregiverContact"`
 
+	// SLABreachedAt is when the request passed its SLA without being resolved and EscalationLevel who it has been escalated to since
+	SLABreachedAt         *time.Time                           `json:"slaBreachedAt"`
+	EscalationLevel       *enums.ServiceRequestEscalationLevel `json:"escalationLevel"`
+	PatientSafetyIncident bool                                 `json:"patientSafetyIncident"`
+
 	// Facility-Registry Specific
 	Services []FacilityService `json:"services"`
 }
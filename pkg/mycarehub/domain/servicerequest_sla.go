@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+)
+
+// SuicideRiskResolutionHours is the most a suicide-risk red flag can wait to be resolved.
+// Missing it is a patient-safety incident whatever the SLA of the client's program
+const SuicideRiskResolutionHours = 24
+
+// ServiceRequestSLA is how long a program's service requests of a type can wait before they are escalated.
+// A request that is still pending ResolutionHours after it was raised breaches the SLA and the staff of its facility are reminded.
+// If it is still pending EscalationHours after the breach the admins of the organisation are alerted
+type ServiceRequestSLA struct {
+	ID              string                   `json:"id"`
+	ProgramID       string                   `json:"programID"`
+	OrganisationID  string                   `json:"organisationID"`
+	RequestType     enums.ServiceRequestType `json:"requestType"`
+	ResolutionHours int                      `json:"resolutionHours"`
+	EscalationHours int                      `json:"escalationHours"`
+}
+
+// DefaultServiceRequestSLAs are used for the red flags of programs that have not set an SLA for them
+var DefaultServiceRequestSLAs = map[enums.ServiceRequestType]*ServiceRequestSLA{
+	enums.ServiceRequestTypeRedFlag: {
+		RequestType:     enums.ServiceRequestTypeRedFlag,
+		ResolutionHours: SuicideRiskResolutionHours,
+		EscalationHours: 0,
+	},
+	enums.ServiceRequestTypeScreeningToolsRedFlag: {
+		RequestType:     enums.ServiceRequestTypeScreeningToolsRedFlag,
+		ResolutionHours: 48,
+		EscalationHours: 24,
+	},
+	enums.ServiceRequestTypeSurveyRedFlag: {
+		RequestType:     enums.ServiceRequestTypeSurveyRedFlag,
+		ResolutionHours: 48,
+		EscalationHours: 24,
+	},
+}
+
+// ServiceRequestEscalation records a service request that breached its SLA being escalated
+type ServiceRequestEscalation struct {
+	ID               string                              `json:"id"`
+	ServiceRequestID string                              `json:"serviceRequestID"`
+	Level            enums.ServiceRequestEscalationLevel `json:"level"`
+
+	// Recipients are the user IDs of the admins who were alerted. They are empty when the staff of the facility were reminded
+	Recipients []string `json:"recipients"`
+
+	PatientSafetyIncident bool      `json:"patientSafetyIncident"`
+	EscalatedAt           time.Time `json:"escalatedAt"`
+	ProgramID             string    `json:"programID"`
+	OrganisationID        string    `json:"organisationID"`
+}
+
+// IsSuicideRiskRedFlag returns true if a service request was raised because a client reported feeling very sad in their health diary
+func (s *ServiceRequest) IsSuicideRiskRedFlag() bool {
+	return s.RequestType == enums.ServiceRequestTypeRedFlag.String()
+}
@@ -0,0 +1,33 @@
+package domain
+
+// StaffOffboarding describes what is changed when a staff who has left is removed from an organisation.
+// A dry run returns it without making any of the changes
+type StaffOffboarding struct {
+	StaffID        string `json:"staffID"`
+	UserID         string `json:"userID"`
+	Name           string `json:"name"`
+	OrganisationID string `json:"organisationID"`
+	Reason         string `json:"reason"`
+	DryRun         bool   `json:"dryRun"`
+
+	// StaffProfiles are the staff's profiles in the organisation's programs that are deactivated
+	StaffProfiles []string `json:"staffProfiles"`
+
+	// The user keeps their account when they are also a client or a caregiver, or a staff in another organisation
+	UserDeactivated          bool `json:"userDeactivated"`
+	MatrixAccountDeactivated bool `json:"matrixAccountDeactivated"`
+	RevokedSessions          int  `json:"revokedSessions"`
+	OrganisationAdminRemoved bool `json:"organisationAdminRemoved"`
+
+	ServiceRequests []*StaffOffboardingServiceRequest `json:"serviceRequests"`
+}
+
+// StaffOffboardingServiceRequest is an in progress service request of a staff who is being offboarded.
+// It is reassigned to another staff or released back to the facility's queue when ReassignedTo is not set
+type StaffOffboardingServiceRequest struct {
+	ID           string  `json:"id"`
+	RequestType  string  `json:"requestType"`
+	FacilityID   string  `json:"facilityID"`
+	ProgramID    string  `json:"programID"`
+	ReassignedTo *string `json:"reassignedTo"`
+}
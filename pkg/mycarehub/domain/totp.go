@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+// UserTOTP represents a user's enrollment of a time-based one-time password (TOTP) authenticator app
+type UserTOTP struct {
+	ID             string     `json:"id"`
+	UserID         string     `json:"userID"`
+	Secret         string     `json:"-"`
+	Confirmed      bool       `json:"confirmed"`
+	ConfirmedAt    *time.Time `json:"confirmedAt"`
+	FailedAttempts int        `json:"failedAttempts"`
+	LastUsedStep   *int64     `json:"-"`
+}
+
+// UserRecoveryCode represents a hashed single use code that a user can log in with when they cannot access their authenticator app
+type UserRecoveryCode struct {
+	ID         string     `json:"id"`
+	UserID     string     `json:"userID"`
+	HashedCode string     `json:"-"`
+	Salt       string     `json:"-"`
+	UsedAt     *time.Time `json:"usedAt"`
+}
+
+// TOTPEnrollment contains the details a user adds to their authenticator app when enrolling it
+type TOTPEnrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+// TOTPStatus represents whether a user has an authenticator app enrolled and whether they are required to have one
+type TOTPStatus struct {
+	Enabled                bool `json:"enabled"`
+	Enforced               bool `json:"enforced"`
+	RemainingRecoveryCodes int  `json:"remainingRecoveryCodes"`
+}
@@ -87,6 +87,9 @@ type ClientProfile struct {
 
 	ClientCounselled bool `json:"counselled"`
 
+	// ErasureRequestedAt is when the client asked for their data to be erased. The data is anonymized once their organisation's retention period has passed
+	ErasureRequestedAt *time.Time `json:"erasureRequestedAt"`
+
 	OrganisationID string `json:"organisationID"`
 
 	DefaultFacility *Facility `json:"defaultFacility"`
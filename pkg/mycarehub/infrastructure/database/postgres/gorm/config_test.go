@@ -301,6 +301,13 @@ var (
 
 	oauthAuthorizationCode = "e455b001-faa4-42ec-835f-16dec96d68d9"
 
+	oauthOpenIDConnectSession = "0a6a8f70-4f7e-4f57-9d41-6a4c9f0e2b11"
+
+	oauthPKCEOne = "6e2d9b41-8c3f-4a17-b5e0-d4f1a9c27e36"
+	oauthPKCETwo = "f08a3c6d-1b52-4e9f-a7d3-92c5e6b1f480"
+
+	oauthSigningKeyOne = "c1f0b1e4-3d6a-4b4e-8f65-2a7d6c1f9e03"
+
 	oauthAccessTokenOne = "7fc4bb0f-a405-4746-861e-eb65040f0f92"
 	oauthAccessTokenTwo = "da017ecb-0aee-4f88-ab09-62aa489ed87b"
 
@@ -620,6 +627,13 @@ func TestMain(m *testing.M) {
 
 			"test_oauth_auth_code_one": oauthAuthorizationCode,
 
+			"test_oauth_openid_connect_session_one": oauthOpenIDConnectSession,
+
+			"test_oauth_pkce_one": oauthPKCEOne,
+			"test_oauth_pkce_two": oauthPKCETwo,
+
+			"test_oauth_signing_key_one": oauthSigningKeyOne,
+
 			"test_oauth_access_token_one": oauthAccessTokenOne,
 			"test_oauth_access_token_two": oauthAccessTokenTwo,
 
@@ -694,6 +708,9 @@ func TestMain(m *testing.M) {
 			"../../../../../../fixtures/oauth_client.yml",
 			"../../../../../../fixtures/oauth_session.yml",
 			"../../../../../../fixtures/oauth_authorization_code.yml",
+			"../../../../../../fixtures/oauth_openid_connect_session.yml",
+			"../../../../../../fixtures/oauth_pkce.yml",
+			"../../../../../../fixtures/oauth_signing_key.yml",
 			"../../../../../../fixtures/oauth_access_token.yml",
 			"../../../../../../fixtures/oauth_refresh_token.yml",
 			"../../../../../../fixtures/service_booking.yml",
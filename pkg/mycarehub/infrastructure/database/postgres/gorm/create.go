@@ -3,6 +3,12 @@ package gorm
 import (
 	"context"
 	"fmt"
+	"sort"
+	"time"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/authorization"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 
 	"gorm.io/gorm/clause"
 
@@ -52,9 +58,25 @@ type Create interface {
 	CreateOauthClient(ctx context.Context, client *OauthClient) error
 	CreateOrUpdateSession(ctx context.Context, session *Session) error
 	CreateAuthorizationCode(ctx context.Context, code *AuthorizationCode) error
+	CreateOpenIDConnectSession(ctx context.Context, session *OpenIDConnectSession) error
+	CreatePKCE(ctx context.Context, pkce *PKCE) error
+	CreateOauthSigningKey(ctx context.Context, key *OauthSigningKey) error
 	CreateAccessToken(ctx context.Context, token *AccessToken) error
 	CreateRefreshToken(ctx context.Context, token *RefreshToken) error
 	CreateBooking(ctx context.Context, booking *Booking) (*Booking, error)
+	CreateAuthorityRole(ctx context.Context, role *AuthorityRole, permissionIDs []string) error
+	AddPermissionsToRole(ctx context.Context, roleID string, permissionIDs []string) error
+	AssignRoles(ctx context.Context, userType enums.UsersType, profileID string, roleIDs []string) error
+	SeedAuthorityRoles(ctx context.Context, permissions []*AuthorityPermission, roles []*AuthorityRole, roleScopes map[string][]string) error
+	AssignDefaultRoles(ctx context.Context) error
+	CreateAuditLog(ctx context.Context, auditLog *AuditLog, sign func(auditLog *AuditLog) error) error
+	SaveUserTOTP(ctx context.Context, userTOTP *UserTOTP) error
+	SaveUserRecoveryCodes(ctx context.Context, userID string, recoveryCodes []*UserRecoveryCode) error
+	ConsumeRateLimit(ctx context.Context, action string, rules []*domain.RateLimitRule, at time.Time) (*domain.RateLimitDecision, error)
+	RecordLoginEvent(ctx context.Context, event *LoginEvent, device *UserDevice) error
+	CreatePhoneChangeRequest(ctx context.Context, request *PhoneChangeRequest) error
+	TransferClient(ctx context.Context, transfer *ClientTransfer, identifier *Identifier, client *Client) error
+	SetServiceRequestSLA(ctx context.Context, sla *ServiceRequestSLA) error
 }
 
 // SaveTemporaryUserPin is used to save a temporary user pin
@@ -90,17 +112,45 @@ func (db *PGInstance) SaveFeedback(ctx context.Context, feedback *Feedback) erro
 
 // SaveOTP saves the generated otp to the database
 func (db *PGInstance) SaveOTP(ctx context.Context, otpInput *UserOTP) error {
-	err := db.DB.WithContext(ctx).Model(&UserOTP{}).Where(&UserOTP{PhoneNumber: otpInput.PhoneNumber, Flavour: otpInput.Flavour}).
+	tx := db.DB.WithContext(ctx).Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+	if err := tx.Error; err != nil {
+		return fmt.Errorf("failed initialize database transaction %w", err)
+	}
+
+	err := tx.Model(&UserOTP{}).Where(&UserOTP{PhoneNumber: otpInput.PhoneNumber, Flavour: otpInput.Flavour}).
 		Updates(map[string]interface{}{"is_valid": false}).Error
 	if err != nil {
+		tx.Rollback()
 		return fmt.Errorf("failed to update OTP data: %w", err)
 	}
 
 	//Save the OTP by setting valid to true
-	err = db.DB.WithContext(ctx).Create(otpInput).Error
+	err = tx.Create(otpInput).Error
 	if err != nil {
+		tx.Rollback()
 		return fmt.Errorf("failed to save otp data: %w", err)
 	}
+
+	for _, attempt := range otpInput.DeliveryAttempts {
+		attempt.OTPID = otpInput.OTPID
+
+		err = tx.Create(attempt).Error
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to save otp delivery attempt: %w", err)
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return nil
 }
 
@@ -805,9 +855,23 @@ func (db *PGInstance) CreateScreeningToolResponse(ctx context.Context, screening
 }
 
 // AddCaregiverToClient adds a caregiver to a client
+// A caregiver whose delegation was revoked or has expired can be added again, which replaces their previous delegation
 func (db *PGInstance) AddCaregiverToClient(ctx context.Context, clientCaregiver *CaregiverClient) error {
-	if err := db.DB.WithContext(ctx).Create(&clientCaregiver).Error; err != nil {
-		return fmt.Errorf("failed to create client caregiver: %w", err)
+	result := db.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "caregiver_id"}, {Name: "client_id"}},
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Expr{SQL: "caregivers_caregiver_client.revoked_at IS NOT NULL OR caregivers_caregiver_client.expired_at IS NOT NULL"},
+		}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"active", "relationship_type", "caregiver_consent", "caregiver_consent_at", "client_consent", "client_consent_at",
+			"assigned_by", "updated", "starts_at", "ends_at", "abilities", "revoked_at", "revoked_by", "expired_at",
+		}),
+	}).Create(&clientCaregiver)
+	if result.Error != nil {
+		return fmt.Errorf("failed to create client caregiver: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("caregiver %s is already assigned to client %s", clientCaregiver.CaregiverID, clientCaregiver.ClientID)
 	}
 
 	return nil
@@ -894,12 +958,14 @@ func (db *PGInstance) CreateOauthClient(ctx context.Context, client *OauthClient
 
 // CreateOrUpdateSession creates a new session or updates an existing session
 func (db *PGInstance) CreateOrUpdateSession(ctx context.Context, session *Session) error {
+	// the device details, sign in time and revocation of an existing session are not overwritten
+	// when its tokens are refreshed
 	if err := db.DB.Clauses(
 		clause.OnConflict{
 			Columns: []clause.Column{
 				{Name: "id"},
 			},
-			UpdateAll: true,
+			DoUpdates: clause.AssignmentColumns([]string{"updated", "username", "subject", "expires_at", "extra", "last_seen_at"}),
 		},
 	).Create(&session).Error; err != nil {
 		return fmt.Errorf("error creating/ updating session: %w", err)
@@ -917,6 +983,33 @@ func (db *PGInstance) CreateAuthorizationCode(ctx context.Context, code *Authori
 	return nil
 }
 
+// CreateOpenIDConnectSession creates the OpenID Connect session of an authorization code
+func (db *PGInstance) CreateOpenIDConnectSession(ctx context.Context, session *OpenIDConnectSession) error {
+	if err := db.DB.WithContext(ctx).Create(&session).Error; err != nil {
+		return fmt.Errorf("error creating openid connect session: %w", err)
+	}
+
+	return nil
+}
+
+// CreatePKCE creates the PKCE session of an authorization code
+func (db *PGInstance) CreatePKCE(ctx context.Context, pkce *PKCE) error {
+	if err := db.DB.WithContext(ctx).Create(&pkce).Error; err != nil {
+		return fmt.Errorf("error creating pkce session: %w", err)
+	}
+
+	return nil
+}
+
+// CreateOauthSigningKey creates a new token signing key
+func (db *PGInstance) CreateOauthSigningKey(ctx context.Context, key *OauthSigningKey) error {
+	if err := db.DB.WithContext(ctx).Create(&key).Error; err != nil {
+		return fmt.Errorf("error creating oauth signing key: %w", err)
+	}
+
+	return nil
+}
+
 // CreateAccessToken creates a new access token.
 func (db *PGInstance) CreateAccessToken(ctx context.Context, token *AccessToken) error {
 	if err := db.DB.Clauses(
@@ -958,3 +1051,744 @@ func (db *PGInstance) CreateBooking(ctx context.Context, booking *Booking) (*Boo
 
 	return result, nil
 }
+
+// CreateAuthorityRole creates a role in a program together with the permissions that have been granted to it
+func (db *PGInstance) CreateAuthorityRole(ctx context.Context, role *AuthorityRole, permissionIDs []string) error {
+	tx := db.DB.WithContext(ctx).Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err := tx.Create(role).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to create authority role: %w", err)
+	}
+
+	for _, permissionID := range permissionIDs {
+		permissionID := permissionID
+		rolePermission := &AuthorityRolePermission{
+			RoleID:       role.AuthorityRoleID,
+			PermissionID: &permissionID,
+		}
+
+		if err := tx.Create(rolePermission).Error; err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to add permission to role: %w", err)
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed transaction commit to create authority role: %w", err)
+	}
+
+	return nil
+}
+
+// AddPermissionsToRole grants the provided permissions to a role. Permissions that have already been granted are ignored
+func (db *PGInstance) AddPermissionsToRole(ctx context.Context, roleID string, permissionIDs []string) error {
+	rolePermissions := []*AuthorityRolePermission{}
+	for _, permissionID := range permissionIDs {
+		permissionID := permissionID
+		rolePermissions = append(rolePermissions, &AuthorityRolePermission{
+			RoleID:       &roleID,
+			PermissionID: &permissionID,
+		})
+	}
+
+	if err := db.DB.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&rolePermissions).Error; err != nil {
+		return fmt.Errorf("failed to add permissions to role: %w", err)
+	}
+
+	return nil
+}
+
+// AssignRoles assigns roles to a staff, client or caregiver profile. Roles that have already been assigned are ignored
+func (db *PGInstance) AssignRoles(ctx context.Context, userType enums.UsersType, profileID string, roleIDs []string) error {
+	var records interface{}
+
+	switch userType {
+	case enums.StaffUser:
+		staffRoles := []*AuthorityRoleStaff{}
+		for _, roleID := range roleIDs {
+			roleID := roleID
+			staffRoles = append(staffRoles, &AuthorityRoleStaff{StaffID: &profileID, RoleID: &roleID})
+		}
+		records = &staffRoles
+
+	case enums.ClientUser:
+		clientRoles := []*AuthorityRoleClient{}
+		for _, roleID := range roleIDs {
+			roleID := roleID
+			clientRoles = append(clientRoles, &AuthorityRoleClient{ClientID: &profileID, RoleID: &roleID})
+		}
+		records = &clientRoles
+
+	case enums.CaregiverUser:
+		caregiverRoles := []*AuthorityRoleCaregiver{}
+		for _, roleID := range roleIDs {
+			roleID := roleID
+			caregiverRoles = append(caregiverRoles, &AuthorityRoleCaregiver{CaregiverID: &profileID, RoleID: &roleID})
+		}
+		records = &caregiverRoles
+
+	default:
+		return fmt.Errorf("roles cannot be assigned to user type: %s", userType)
+	}
+
+	if err := db.DB.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(records).Error; err != nil {
+		return fmt.Errorf("failed to assign roles: %w", err)
+	}
+
+	return nil
+}
+
+// SeedAuthorityRoles creates or updates the provided permissions and makes sure that every program has the provided roles,
+// granted the permissions whose scopes are listed against the role's name. Permissions are matched by scope and roles by
+// program and name so that seeding can be repeated
+func (db *PGInstance) SeedAuthorityRoles(ctx context.Context, permissions []*AuthorityPermission, roles []*AuthorityRole, roleScopes map[string][]string) error {
+	tx := db.DB.WithContext(ctx).Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if len(permissions) > 0 {
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "scope"}},
+			DoUpdates: clause.AssignmentColumns([]string{"name", "description", "category", "active", "updated"}),
+		}).Create(&permissions).Error
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to seed authority permissions: %w", err)
+		}
+	}
+
+	// the upsert does not return the IDs of the permissions that already existed
+	var savedPermissions []*AuthorityPermission
+	if err := tx.Find(&savedPermissions).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to get authority permissions: %w", err)
+	}
+
+	permissionIDs := map[string]string{}
+	for _, permission := range savedPermissions {
+		permissionIDs[permission.Scope] = *permission.AuthorityPermissionID
+	}
+
+	var programs []*Program
+	if err := tx.Select("id", "organisation_id").Find(&programs).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to get programs: %w", err)
+	}
+
+	for _, program := range programs {
+		for _, role := range roles {
+			var programRole AuthorityRole
+			err := tx.Where(&AuthorityRole{ProgramID: program.ID, Name: role.Name}).
+				Attrs(AuthorityRole{
+					Description:    role.Description,
+					Active:         role.Active,
+					IsSystemRole:   role.IsSystemRole,
+					UserType:       role.UserType,
+					OrganisationID: program.OrganisationID,
+				}).
+				FirstOrCreate(&programRole).Error
+			if err != nil {
+				tx.Rollback()
+				return fmt.Errorf("failed to seed authority role %s: %w", role.Name, err)
+			}
+
+			rolePermissions := []*AuthorityRolePermission{}
+			for _, scope := range roleScopes[role.Name] {
+				permissionID, ok := permissionIDs[scope]
+				if !ok {
+					tx.Rollback()
+					return fmt.Errorf("permission with scope %s does not exist", scope)
+				}
+
+				rolePermissions = append(rolePermissions, &AuthorityRolePermission{
+					RoleID:       programRole.AuthorityRoleID,
+					PermissionID: &permissionID,
+				})
+			}
+
+			if len(rolePermissions) == 0 {
+				continue
+			}
+
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rolePermissions).Error; err != nil {
+				tx.Rollback()
+				return fmt.Errorf("failed to add permissions to role %s: %w", role.Name, err)
+			}
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed transaction commit to seed authority roles: %w", err)
+	}
+
+	return nil
+}
+
+// AssignDefaultRoles assigns the default roles to the active staff, client and caregiver profiles that have not been assigned
+// any role in their program. Organisation admins are assigned the default admin role and caregivers are assigned the default
+// caregiver role of each program they care for a client in
+func (db *PGInstance) AssignDefaultRoles(ctx context.Context) error {
+	tx := db.DB.WithContext(ctx).Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	err := tx.Exec(`
+		INSERT INTO authority_authorityrole_staff (staff_id, authorityrole_id)
+		SELECT staff_staff.id, authority_authorityrole.id FROM staff_staff
+		JOIN authority_authorityrole ON authority_authorityrole.program_id = staff_staff.program_id
+			AND authority_authorityrole.name = CASE WHEN COALESCE(staff_staff.is_organisation_admin, false) THEN ? ELSE ? END
+		WHERE staff_staff.active = true AND NOT EXISTS (
+			SELECT 1 FROM authority_authorityrole_staff WHERE authority_authorityrole_staff.staff_id = staff_staff.id
+		)
+		ON CONFLICT DO NOTHING
+	`, authorization.DefaultRoleAdmin.String(), authorization.DefaultRoleStaff.String()).Error
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to assign default staff roles: %w", err)
+	}
+
+	err = tx.Exec(`
+		INSERT INTO authority_authorityrole_clients (client_id, authorityrole_id)
+		SELECT clients_client.id, authority_authorityrole.id FROM clients_client
+		JOIN authority_authorityrole ON authority_authorityrole.program_id = clients_client.program_id
+			AND authority_authorityrole.name = ?
+		WHERE clients_client.active = true AND NOT EXISTS (
+			SELECT 1 FROM authority_authorityrole_clients WHERE authority_authorityrole_clients.client_id = clients_client.id
+		)
+		ON CONFLICT DO NOTHING
+	`, authorization.DefaultRoleClient.String()).Error
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to assign default client roles: %w", err)
+	}
+
+	err = tx.Exec(`
+		INSERT INTO authority_authorityrole_caregivers (caregiver_id, authorityrole_id)
+		SELECT DISTINCT caregivers_caregiver.id, authority_authorityrole.id FROM caregivers_caregiver
+		JOIN caregivers_caregiver_client ON caregivers_caregiver_client.caregiver_id = caregivers_caregiver.id
+		JOIN authority_authorityrole ON authority_authorityrole.program_id = caregivers_caregiver_client.program_id
+			AND authority_authorityrole.name = ?
+		WHERE caregivers_caregiver.active = true AND NOT EXISTS (
+			SELECT 1 FROM authority_authorityrole_caregivers
+			JOIN authority_authorityrole AS assigned_role ON assigned_role.id = authority_authorityrole_caregivers.authorityrole_id
+			WHERE authority_authorityrole_caregivers.caregiver_id = caregivers_caregiver.id
+			AND assigned_role.program_id = caregivers_caregiver_client.program_id
+		)
+		ON CONFLICT DO NOTHING
+	`, authorization.DefaultRoleCaregiver.String()).Error
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to assign default caregiver roles: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed transaction commit to assign default roles: %w", err)
+	}
+
+	return nil
+}
+
+// CreateAuditLog records an event in the audit log and links it to the last entry recorded in the same organisation.
+// The organisation keeps the sequence and hash of its last entry and its row is locked for the duration of the transaction
+// so that concurrent writers cannot fork the chain. The sign function is called once the entry's sequence and previous hash
+// are known and should set its hash.
+func (db *PGInstance) CreateAuditLog(ctx context.Context, auditLog *AuditLog, sign func(auditLog *AuditLog) error) error {
+	tx := db.DB.WithContext(ctx).Begin()
+
+	var organisation Organisation
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", auditLog.OrganisationID).First(&organisation).Error
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to lock the organisation's audit log chain: %w", err)
+	}
+
+	sequence := organisation.AuditLogSequence + 1
+	previousHash := organisation.AuditLogHash
+	auditLog.Sequence = &sequence
+	auditLog.PreviousHash = &previousHash
+
+	if err := sign(auditLog); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to sign audit log: %w", err)
+	}
+
+	if err := tx.Create(auditLog).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to create audit log: %w", err)
+	}
+
+	err = tx.Model(&Organisation{}).Where("id = ?", auditLog.OrganisationID).UpdateColumns(map[string]interface{}{
+		"audit_log_sequence": sequence,
+		"audit_log_hash":     *auditLog.Hash,
+	}).Error
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to update the organisation's last audit log: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// SaveUserTOTP saves a user's TOTP enrollment. An existing enrollment for the user is replaced
+func (db *PGInstance) SaveUserTOTP(ctx context.Context, userTOTP *UserTOTP) error {
+	err := db.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"active", "secret", "confirmed", "confirmed_at", "failed_attempts", "last_used_step", "updated"}),
+	}).Create(userTOTP).Error
+	if err != nil {
+		return fmt.Errorf("failed to save user TOTP: %w", err)
+	}
+
+	return nil
+}
+
+// SaveUserRecoveryCodes replaces a user's recovery codes with the provided ones
+func (db *PGInstance) SaveUserRecoveryCodes(ctx context.Context, userID string, recoveryCodes []*UserRecoveryCode) error {
+	tx := db.DB.WithContext(ctx).Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err := tx.Where(&UserRecoveryCode{UserID: userID}).Delete(&UserRecoveryCode{}).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to delete existing recovery codes: %w", err)
+	}
+
+	if len(recoveryCodes) > 0 {
+		if err := tx.Create(recoveryCodes).Error; err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to save recovery codes: %w", err)
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ConsumeRateLimit checks a request against the provided sliding window rules and records it.
+// The keys are locked for the duration of the transaction so that concurrent requests cannot exceed a limit.
+// Only allowed requests count towards a limit so that rejected requests do not extend the time a key is blocked for.
+func (db *PGInstance) ConsumeRateLimit(ctx context.Context, action string, rules []*domain.RateLimitRule, at time.Time) (*domain.RateLimitDecision, error) {
+	tx := db.DB.WithContext(ctx).Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// the keys are locked in the same order by every request to avoid deadlocks
+	lockKeys := []string{}
+	for _, rule := range rules {
+		lockKeys = append(lockKeys, fmt.Sprintf("%s:%s:%s", action, rule.KeyType, rule.Key))
+	}
+	sort.Strings(lockKeys)
+
+	for _, lockKey := range lockKeys {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", lockKey).Error; err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to lock rate limit key: %w", err)
+		}
+	}
+
+	decision := &domain.RateLimitDecision{Allowed: true}
+	for _, rule := range rules {
+		// events older than both the window and the cool-down are no longer needed to make a decision
+		retention := rule.Window
+		if rule.Cooldown > retention {
+			retention = rule.Cooldown
+		}
+		err := tx.Where("action = ? AND key_type = ? AND key_value = ? AND timestamp < ?", action, rule.KeyType.String(), rule.Key, at.Add(-retention)).
+			Delete(&RateLimitEvent{}).Error
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to delete expired rate limit events: %w", err)
+		}
+
+		var events []*RateLimitEvent
+		err = tx.Model(&RateLimitEvent{}).
+			Where("action = ? AND key_type = ? AND key_value = ?", action, rule.KeyType.String(), rule.Key).
+			Where("allowed = ? AND timestamp >= ?", true, at.Add(-retention)).
+			Order("timestamp ASC").
+			Find(&events).Error
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to list rate limit events: %w", err)
+		}
+
+		if rule.Cooldown > 0 && len(events) > 0 {
+			cooldownEnds := events[len(events)-1].Timestamp.Add(rule.Cooldown)
+			if at.Before(cooldownEnds) {
+				decision = &domain.RateLimitDecision{Rule: rule, CooledDown: true, RetryAfter: cooldownEnds}
+				break
+			}
+		}
+
+		if rule.Limit > 0 {
+			windowEvents := []*RateLimitEvent{}
+			for _, event := range events {
+				if !event.Timestamp.Before(at.Add(-rule.Window)) {
+					windowEvents = append(windowEvents, event)
+				}
+			}
+
+			if len(windowEvents) >= rule.Limit {
+				// a request is allowed once enough of the earlier requests have left the window
+				retryAfter := windowEvents[len(windowEvents)-rule.Limit].Timestamp.Add(rule.Window)
+				decision = &domain.RateLimitDecision{Rule: rule, RetryAfter: retryAfter}
+				break
+			}
+		}
+	}
+
+	events := []*RateLimitEvent{}
+	for _, rule := range rules {
+		events = append(events, &RateLimitEvent{
+			Active:    true,
+			Action:    action,
+			KeyType:   rule.KeyType.String(),
+			KeyValue:  rule.Key,
+			Allowed:   decision.Allowed,
+			Timestamp: at,
+		})
+	}
+
+	if len(events) > 0 {
+		if err := tx.Create(events).Error; err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to record rate limit events: %w", err)
+		}
+	}
+
+	if !decision.Allowed {
+		var rejections int64
+		err := tx.Model(&RateLimitEvent{}).
+			Where("action = ? AND key_type = ? AND key_value = ?", action, decision.Rule.KeyType.String(), decision.Rule.Key).
+			Where("allowed = ? AND timestamp >= ?", false, at.Add(-decision.Rule.Window)).
+			Count(&rejections).Error
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to count rejected requests: %w", err)
+		}
+		decision.Rejections = int(rejections)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return decision, nil
+}
+
+// RecordLoginEvent records a successful login and the device that it was made from.
+// A device that the user has logged in from before is updated with the details of the login
+func (db *PGInstance) RecordLoginEvent(ctx context.Context, event *LoginEvent, device *UserDevice) error {
+	tx := db.DB.WithContext(ctx).Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	err := tx.Clauses(
+		clause.OnConflict{
+			Columns: []clause.Column{
+				{Name: "user_id"},
+				{Name: "fingerprint"},
+			},
+			DoUpdates: clause.AssignmentColumns([]string{"updated", "device_id", "device_name", "user_agent", "last_ip_address", "last_country", "last_login_at"}),
+		},
+	).Create(device).Error
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to save user device: %w", err)
+	}
+
+	if err := tx.Create(event).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to record login event: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// CreatePhoneChangeRequest saves a request to change a user's phone number.
+// Any earlier request of the user that has not been confirmed is deactivated so that only the latest one can be confirmed
+func (db *PGInstance) CreatePhoneChangeRequest(ctx context.Context, request *PhoneChangeRequest) error {
+	tx := db.DB.WithContext(ctx).Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	err := tx.Model(&PhoneChangeRequest{}).
+		Where("user_id = ? AND active = ? AND confirmed_at IS NULL", request.UserID, true).
+		Update("active", false).Error
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to deactivate pending phone change requests: %w", err)
+	}
+
+	if err := tx.Create(request).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to create phone change request: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// clientTransferHistoryTables are the tables that hold each part of a client's history that can be carried to another program
+var clientTransferHistoryTables = map[string]string{
+	enums.ClientTransferHistoryHealthDiary.String():            "clients_healthdiaryentry",
+	enums.ClientTransferHistoryAppointments.String():           "appointments_appointment",
+	enums.ClientTransferHistoryScreeningToolResponses.String(): "questionnaires_screeningtoolresponse",
+}
+
+// TransferClient moves a client to another facility and records the transfer.
+// Within a program the client profile is moved to the new facility together with the client's pending service requests.
+// When the client is provided the client is moving to another program: the new client profile is created and linked to the identifier,
+// the old profile is deactivated and the history the client consented to carry is moved to the new profile
+func (db *PGInstance) TransferClient(ctx context.Context, transfer *ClientTransfer, identifier *Identifier, client *Client) error {
+	tx := db.DB.WithContext(ctx).Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if client == nil {
+		result := tx.Model(&Client{}).
+			Where("id = ? AND active = ?", transfer.ClientID, true).
+			Update("current_facility_id", transfer.ToFacilityID)
+		if result.Error != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to move client to facility: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			tx.Rollback()
+			return fmt.Errorf("client %s does not exist or is inactive", transfer.ClientID)
+		}
+
+		clientFacility := ClientFacilities{ClientID: &transfer.ClientID, FacilityID: &transfer.ToFacilityID}
+		if err := tx.Where(clientFacility).FirstOrCreate(&clientFacility).Error; err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to link client to facility: %w", err)
+		}
+
+		err := tx.Model(&ClientServiceRequest{}).
+			Where("client_id = ? AND facility_id = ? AND status = ?", transfer.ClientID, transfer.FromFacilityID, enums.ServiceRequestStatusPending.String()).
+			Update("facility_id", transfer.ToFacilityID).Error
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to move pending service requests to facility: %w", err)
+		}
+
+		transfer.NewClientID = transfer.ClientID
+	} else {
+		if err := tx.Where(identifier).FirstOrCreate(identifier).Error; err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to get or create identifier: %w", err)
+		}
+
+		if err := tx.Create(client).Error; err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+
+		clientIdentifier := ClientIdentifiers{ClientID: client.ID, IdentifierID: &identifier.ID}
+		if err := tx.Create(&clientIdentifier).Error; err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to link client identifier: %w", err)
+		}
+
+		clientFacility := ClientFacilities{ClientID: client.ID, FacilityID: &client.FacilityID}
+		if err := tx.Create(&clientFacility).Error; err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to link client to facility: %w", err)
+		}
+
+		result := tx.Model(&Client{}).Where("id = ? AND active = ?", transfer.ClientID, true).Update("active", false)
+		if result.Error != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to deactivate client: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			tx.Rollback()
+			return fmt.Errorf("client %s does not exist or is inactive", transfer.ClientID)
+		}
+
+		carried := map[string]interface{}{
+			"client_id":       *client.ID,
+			"program_id":      transfer.ToProgramID,
+			"organisation_id": transfer.ToOrganisationID,
+		}
+
+		for _, history := range transfer.CarriedHistory {
+			table, ok := clientTransferHistoryTables[history]
+			if !ok {
+				tx.Rollback()
+				return fmt.Errorf("%s history cannot be carried", history)
+			}
+
+			if history == enums.ClientTransferHistoryScreeningToolResponses.String() {
+				if err := carryScreeningToolResponses(tx, transfer, *client.ID); err != nil {
+					tx.Rollback()
+					return err
+				}
+				continue
+			}
+
+			if err := tx.Table(table).Where("client_id = ?", transfer.ClientID).Updates(carried).Error; err != nil {
+				tx.Rollback()
+				return fmt.Errorf("failed to carry %s records: %w", table, err)
+			}
+		}
+
+		err := tx.Model(&User{}).
+			Where("id = ? AND current_program_id = ?", transfer.UserID, transfer.FromProgramID).
+			Updates(map[string]interface{}{
+				"current_program_id":      transfer.ToProgramID,
+				"current_organisation_id": transfer.ToOrganisationID,
+			}).Error
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to update user's current program: %w", err)
+		}
+
+		transfer.NewClientID = *client.ID
+	}
+
+	if err := tx.Create(transfer).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to record client transfer: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to commit transfer client transaction: %w", err)
+	}
+
+	return nil
+}
+
+// carryScreeningToolResponses moves a client's screening tool responses to the screening tools of the program they are transferred to.
+// Screening tools and their questions belong to a program, so a response is matched to the screening tool whose questionnaire has the
+// same name in the new program and each answer to the question in the same sequence. The responses to a screening tool that the new
+// program does not have stay with the program that recorded them
+func carryScreeningToolResponses(tx *gorm.DB, transfer *ClientTransfer, newClientID string) error {
+	type match struct {
+		FromID string
+		ToID   string
+	}
+
+	var screeningTools []match
+	err := tx.Table("questionnaires_screeningtool AS from_tool").
+		Select("from_tool.id AS from_id, to_tool.id AS to_id").
+		Joins("JOIN questionnaires_questionnaire AS from_questionnaire ON from_questionnaire.id = from_tool.questionnaire_id").
+		Joins("JOIN questionnaires_questionnaire AS to_questionnaire ON to_questionnaire.name = from_questionnaire.name AND to_questionnaire.program_id = ?", transfer.ToProgramID).
+		Joins("JOIN questionnaires_screeningtool AS to_tool ON to_tool.questionnaire_id = to_questionnaire.id AND to_tool.program_id = ? AND to_tool.active = ?", transfer.ToProgramID, true).
+		Where("from_tool.id IN (?)", tx.Model(&ScreeningToolResponse{}).Select("screeningtool_id").Where("client_id = ?", transfer.ClientID)).
+		Scan(&screeningTools).Error
+	if err != nil {
+		return fmt.Errorf("failed to match screening tools in program %s: %w", transfer.ToProgramID, err)
+	}
+
+	for _, screeningTool := range screeningTools {
+		var questions []match
+		err := tx.Table("questionnaires_question AS from_question").
+			Select("from_question.id AS from_id, to_question.id AS to_id").
+			Joins("JOIN questionnaires_screeningtool AS from_tool ON from_tool.questionnaire_id = from_question.questionnaire_id").
+			Joins("JOIN questionnaires_screeningtool AS to_tool ON to_tool.id = ?", screeningTool.ToID).
+			Joins("JOIN questionnaires_question AS to_question ON to_question.questionnaire_id = to_tool.questionnaire_id AND to_question.sequence = from_question.sequence").
+			Where("from_tool.id = ?", screeningTool.FromID).
+			Scan(&questions).Error
+		if err != nil {
+			return fmt.Errorf("failed to match screening tool questions in program %s: %w", transfer.ToProgramID, err)
+		}
+
+		responses := tx.Model(&ScreeningToolResponse{}).Select("id").
+			Where("client_id = ? AND screeningtool_id = ?", transfer.ClientID, screeningTool.FromID)
+
+		for _, question := range questions {
+			err := tx.Model(&ScreeningToolQuestionResponse{}).
+				Where("question_id = ? AND screeningtoolresponse_id IN (?)", question.FromID, responses).
+				Updates(map[string]interface{}{
+					"question_id":     question.ToID,
+					"program_id":      transfer.ToProgramID,
+					"organisation_id": transfer.ToOrganisationID,
+				}).Error
+			if err != nil {
+				return fmt.Errorf("failed to carry screening tool question responses: %w", err)
+			}
+		}
+
+		err = tx.Model(&ScreeningToolResponse{}).
+			Where("client_id = ? AND screeningtool_id = ?", transfer.ClientID, screeningTool.FromID).
+			Updates(map[string]interface{}{
+				"client_id":        newClientID,
+				"screeningtool_id": screeningTool.ToID,
+				"program_id":       transfer.ToProgramID,
+				"organisation_id":  transfer.ToOrganisationID,
+			}).Error
+		if err != nil {
+			return fmt.Errorf("failed to carry screening tool responses: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// SetServiceRequestSLA creates a program's SLA for a type of service request or replaces the one it has
+func (db *PGInstance) SetServiceRequestSLA(ctx context.Context, sla *ServiceRequestSLA) error {
+	err := db.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "program_id"}, {Name: "request_type"}},
+		DoUpdates: clause.AssignmentColumns([]string{"active", "resolution_hours", "escalation_hours", "updated", "updated_by"}),
+	}).Create(sla).Error
+	if err != nil {
+		return fmt.Errorf("failed to set service request sla: %w", err)
+	}
+
+	err = db.DB.WithContext(ctx).Where("program_id = ? AND request_type = ?", sla.ProgramID, sla.RequestType).First(sla).Error
+	if err != nil {
+		return fmt.Errorf("failed to get service request sla: %w", err)
+	}
+
+	return nil
+}
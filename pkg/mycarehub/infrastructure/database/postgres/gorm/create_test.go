@@ -2,6 +2,7 @@ package gorm_test
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 	"testing"
 	"time"
@@ -15,6 +16,7 @@ import (
 	"github.com/savannahghi/interserviceclient"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/utils"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/gorm"
 	"github.com/segmentio/ksuid"
 )
@@ -311,6 +313,20 @@ func TestPGInstance_SaveOTP(t *testing.T) {
 		PhoneNumber: otpInput.PhoneNumber,
 		OTP:         newOTP,
 		Flavour:     otpInput.Flavour,
+		DeliveryAttempts: []*gorm.UserOTPDeliveryAttempt{
+			{
+				Provider:    enums.OTPDeliveryProviderSILComms.String(),
+				Status:      enums.OTPDeliveryStatusFailed.String(),
+				Error:       "an error occurred",
+				AttemptedAt: time.Now(),
+			},
+			{
+				Provider:          enums.OTPDeliveryProviderTwilio.String(),
+				ProviderMessageID: gofakeit.UUID(),
+				Status:            enums.OTPDeliveryStatusSent.String(),
+				AttemptedAt:       time.Now(),
+			},
+		},
 	}
 
 	invalidgormOTPInput1 := &gorm.UserOTP{
@@ -1887,6 +1903,26 @@ func TestPGInstance_AddCaregiverToClient(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Sad case: caregiver is already assigned to client",
+			args: args{
+				ctx: addRequiredContext(context.Background(), t),
+				clientCaregiver: &gorm.CaregiverClient{
+					CaregiverID:        testCaregiverID,
+					ClientID:           clientID2,
+					Active:             true,
+					RelationshipType:   enums.CaregiverTypeFather,
+					CaregiverConsent:   enums.ConsentStateAccepted,
+					CaregiverConsentAt: &now,
+					ClientConsent:      enums.ConsentStateAccepted,
+					ClientConsentAt:    &now,
+					OrganisationID:     orgID,
+					AssignedBy:         staffID,
+					ProgramID:          programID,
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "Sad case: unable to add new caregiver to client",
 			args: args{
@@ -2746,6 +2782,158 @@ func TestPGInstance_CreateAuthorizationCode(t *testing.T) {
 	}
 }
 
+func TestPGInstance_CreateOpenIDConnectSession(t *testing.T) {
+	type args struct {
+		ctx     context.Context
+		session *gorm.OpenIDConnectSession
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "happy case: create an openid connect session",
+			args: args{
+				ctx: context.Background(),
+				session: &gorm.OpenIDConnectSession{
+					Active:          true,
+					Code:            gofakeit.UUID(),
+					RequestedAt:     time.Now(),
+					RequestedScopes: []string{"openid", "profile"},
+					GrantedScopes:   []string{"openid", "profile"},
+					SessionID:       oauthSessionTwoID,
+					ClientID:        oauthClientOneID,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "sad case: invalid client",
+			args: args{
+				ctx: context.Background(),
+				session: &gorm.OpenIDConnectSession{
+					Active:      true,
+					Code:        gofakeit.UUID(),
+					RequestedAt: time.Now(),
+					SessionID:   oauthSessionTwoID,
+					ClientID:    gofakeit.UUID(),
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testingDB.CreateOpenIDConnectSession(tt.args.ctx, tt.args.session); (err != nil) != tt.wantErr {
+				t.Errorf("CreateOpenIDConnectSession() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPGInstance_CreatePKCE(t *testing.T) {
+	type args struct {
+		ctx  context.Context
+		pkce *gorm.PKCE
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "happy case: create a pkce session",
+			args: args{
+				ctx: context.Background(),
+				pkce: &gorm.PKCE{
+					Active:          true,
+					Signature:       gofakeit.UUID(),
+					RequestedAt:     time.Now(),
+					RequestedScopes: []string{"openid", "profile"},
+					GrantedScopes:   []string{"openid", "profile"},
+					SessionID:       oauthSessionTwoID,
+					ClientID:        oauthClientOneID,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "sad case: invalid client",
+			args: args{
+				ctx: context.Background(),
+				pkce: &gorm.PKCE{
+					Active:      true,
+					Signature:   gofakeit.UUID(),
+					RequestedAt: time.Now(),
+					SessionID:   oauthSessionTwoID,
+					ClientID:    gofakeit.UUID(),
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testingDB.CreatePKCE(tt.args.ctx, tt.args.pkce); (err != nil) != tt.wantErr {
+				t.Errorf("CreatePKCE() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPGInstance_CreateOauthSigningKey(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		key *gorm.OauthSigningKey
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "happy case: create a signing key",
+			args: args{
+				ctx: context.Background(),
+				key: &gorm.OauthSigningKey{
+					Active:     true,
+					KeyID:      gofakeit.UUID(),
+					Algorithm:  "RS256",
+					PrivateKey: gofakeit.HipsterSentence(10),
+					PublicKey:  gofakeit.HipsterSentence(10),
+					RotateAt:   time.Now().Add(time.Hour),
+					ExpiresAt:  time.Now().Add(2 * time.Hour),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "sad case: duplicate key id",
+			args: args{
+				ctx: context.Background(),
+				key: &gorm.OauthSigningKey{
+					Active:     true,
+					KeyID:      "5d1f0c8e-6c2b-4f0a-9a53-7b8f2d6e4c11",
+					Algorithm:  "RS256",
+					PrivateKey: gofakeit.HipsterSentence(10),
+					PublicKey:  gofakeit.HipsterSentence(10),
+					RotateAt:   time.Now().Add(time.Hour),
+					ExpiresAt:  time.Now().Add(2 * time.Hour),
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testingDB.CreateOauthSigningKey(tt.args.ctx, tt.args.key); (err != nil) != tt.wantErr {
+				t.Errorf("CreateOauthSigningKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestPGInstance_CreateAccessToken(t *testing.T) {
 
 	type args struct {
@@ -2881,3 +3069,939 @@ func TestPGInstance_CreateBooking(t *testing.T) {
 		})
 	}
 }
+
+func TestPGInstance_CreateAuthorityRole(t *testing.T) {
+	invalidProgramID := "programID"
+
+	type args struct {
+		ctx           context.Context
+		role          *gorm.AuthorityRole
+		permissionIDs []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: create authority role",
+			args: args{
+				ctx: context.Background(),
+				role: &gorm.AuthorityRole{
+					Name:           gofakeit.BS(),
+					Description:    gofakeit.Sentence(5),
+					Active:         true,
+					UserType:       enums.StaffUser.String(),
+					OrganisationID: orgID,
+					ProgramID:      programID,
+				},
+				permissionIDs: []string{canInviteUserPermissionID},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: invalid program id",
+			args: args{
+				ctx: context.Background(),
+				role: &gorm.AuthorityRole{
+					Name:           gofakeit.BS(),
+					Active:         true,
+					UserType:       enums.StaffUser.String(),
+					OrganisationID: orgID,
+					ProgramID:      invalidProgramID,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: invalid permission id",
+			args: args{
+				ctx: context.Background(),
+				role: &gorm.AuthorityRole{
+					Name:           gofakeit.BS(),
+					Active:         true,
+					UserType:       enums.StaffUser.String(),
+					OrganisationID: orgID,
+					ProgramID:      programID,
+				},
+				permissionIDs: []string{"permissionID"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testingDB.CreateAuthorityRole(tt.args.ctx, tt.args.role, tt.args.permissionIDs); (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.CreateAuthorityRole() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPGInstance_AddPermissionsToRole(t *testing.T) {
+	type args struct {
+		ctx           context.Context
+		roleID        string
+		permissionIDs []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: add existing permission to role",
+			args: args{
+				ctx:           context.Background(),
+				roleID:        systemAdminRoleID,
+				permissionIDs: []string{canInviteUserPermissionID},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: invalid role id",
+			args: args{
+				ctx:           context.Background(),
+				roleID:        "roleID",
+				permissionIDs: []string{canInviteUserPermissionID},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testingDB.AddPermissionsToRole(tt.args.ctx, tt.args.roleID, tt.args.permissionIDs); (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.AddPermissionsToRole() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPGInstance_AssignRoles(t *testing.T) {
+	role := &gorm.AuthorityRole{
+		Name:           gofakeit.BS(),
+		Active:         true,
+		UserType:       enums.StaffUser.String(),
+		OrganisationID: orgID,
+		ProgramID:      programID,
+	}
+	err := testingDB.CreateAuthorityRole(context.Background(), role, nil)
+	if err != nil {
+		t.Errorf("failed to create role: %v", err)
+		return
+	}
+
+	type args struct {
+		ctx       context.Context
+		userType  enums.UsersType
+		profileID string
+		roleIDs   []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: assign role to staff",
+			args: args{
+				ctx:       context.Background(),
+				userType:  enums.StaffUser,
+				profileID: staffID,
+				roleIDs:   []string{*role.AuthorityRoleID},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unsupported user type",
+			args: args{
+				ctx:       context.Background(),
+				userType:  enums.HealthcareWorkerUser,
+				profileID: staffID,
+				roleIDs:   []string{*role.AuthorityRoleID},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: invalid role id",
+			args: args{
+				ctx:       context.Background(),
+				userType:  enums.StaffUser,
+				profileID: staffID,
+				roleIDs:   []string{"roleID"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testingDB.AssignRoles(tt.args.ctx, tt.args.userType, tt.args.profileID, tt.args.roleIDs); (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.AssignRoles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPGInstance_SeedAuthorityRoles(t *testing.T) {
+	roleName := gofakeit.BS()
+	newScope := fmt.Sprintf("seed.%s", gofakeit.UUID())
+
+	permissions := func() []*gorm.AuthorityPermission {
+		return []*gorm.AuthorityPermission{
+			{Active: true, Name: "invite user", Description: "a user with this permission can create an invite link to be sent to a user to join the platform", Category: "user", Scope: "user.invite.create"},
+			{Active: true, Name: "seeded permission", Description: "a seeded permission", Category: "user", Scope: newScope},
+		}
+	}
+	roles := []*gorm.AuthorityRole{
+		{Name: roleName, Active: true, IsSystemRole: true, UserType: enums.StaffUser.String()},
+	}
+
+	type args struct {
+		ctx         context.Context
+		permissions []*gorm.AuthorityPermission
+		roles       []*gorm.AuthorityRole
+		roleScopes  map[string][]string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: seed authority roles",
+			args: args{
+				ctx:         context.Background(),
+				permissions: permissions(),
+				roles:       roles,
+				roleScopes:  map[string][]string{roleName: {"user.invite.create", newScope}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case: seeding is repeatable",
+			args: args{
+				ctx:         context.Background(),
+				permissions: permissions(),
+				roles:       roles,
+				roleScopes:  map[string][]string{roleName: {"user.invite.create", newScope}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: role granted a permission that does not exist",
+			args: args{
+				ctx:         context.Background(),
+				permissions: permissions(),
+				roles:       roles,
+				roleScopes:  map[string][]string{roleName: {"scope.does.not.exist"}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testingDB.SeedAuthorityRoles(tt.args.ctx, tt.args.permissions, tt.args.roles, tt.args.roleScopes); (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.SeedAuthorityRoles() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			var seededRoles []*gorm.AuthorityRole
+			if err := testingDB.DB.Where("name = ? AND program_id = ?", roleName, programID).Find(&seededRoles).Error; err != nil {
+				t.Errorf("failed to get seeded role: %v", err)
+				return
+			}
+			if len(seededRoles) != 1 || !seededRoles[0].IsSystemRole {
+				t.Errorf("expected the program to have one seeded system role, got %d", len(seededRoles))
+				return
+			}
+
+			var grantedPermissions int64
+			if err := testingDB.DB.Model(&gorm.AuthorityRolePermission{}).Where("authorityrole_id = ?", *seededRoles[0].AuthorityRoleID).Count(&grantedPermissions).Error; err != nil {
+				t.Errorf("failed to count role permissions: %v", err)
+				return
+			}
+			if grantedPermissions != 2 {
+				t.Errorf("expected the seeded role to be granted 2 permissions, got %d", grantedPermissions)
+			}
+		})
+	}
+
+	// teardown
+	var seededRoleIDs []string
+	if err := testingDB.DB.Model(&gorm.AuthorityRole{}).Where("name = ?", roleName).Pluck("id", &seededRoleIDs).Error; err != nil {
+		t.Errorf("failed to get seeded roles: %v", err)
+		return
+	}
+	if err := testingDB.DB.Where("authorityrole_id IN ?", seededRoleIDs).Delete(&gorm.AuthorityRolePermission{}).Error; err != nil {
+		t.Errorf("failed to delete seeded role permissions: %v", err)
+		return
+	}
+	if err := testingDB.DB.Where("id IN ?", seededRoleIDs).Delete(&gorm.AuthorityRole{}).Error; err != nil {
+		t.Errorf("failed to delete seeded roles: %v", err)
+		return
+	}
+	if err := testingDB.DB.Where("scope = ?", newScope).Delete(&gorm.AuthorityPermission{}).Error; err != nil {
+		t.Errorf("failed to delete seeded permission: %v", err)
+	}
+}
+
+func TestPGInstance_AssignDefaultRoles(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{
+			name:    "Happy case: assign default roles",
+			wantErr: false,
+		},
+		{
+			name:    "Happy case: assigning default roles is repeatable",
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testingDB.AssignDefaultRoles(context.Background()); (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.AssignDefaultRoles() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			var clientRoles []*gorm.AuthorityRoleClient
+			if err := testingDB.DB.Where("client_id = ?", clientID).Find(&clientRoles).Error; err != nil {
+				t.Errorf("failed to get client roles: %v", err)
+				return
+			}
+			if len(clientRoles) != 1 || *clientRoles[0].RoleID != defaultClientRoleID {
+				t.Errorf("expected the client to be assigned the default client role once, got %d roles", len(clientRoles))
+			}
+		})
+	}
+}
+
+func TestPGInstance_CreateAuditLog(t *testing.T) {
+	payload := pgtype.JSONB{}
+	err := payload.Set(map[string]interface{}{"facilityID": facilityID})
+	if err != nil {
+		t.Errorf("failed to set payload: %v", err)
+		return
+	}
+
+	type args struct {
+		ctx      context.Context
+		auditLog *gorm.AuditLog
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: create audit log",
+			args: args{
+				ctx: context.Background(),
+				auditLog: &gorm.AuditLog{
+					Active:         true,
+					Timestamp:      time.Now(),
+					RecordType:     enums.AuditLogFacilityAccessDenied.String(),
+					Notes:          gofakeit.Sentence(5),
+					Payload:        payload,
+					OrganisationID: orgID,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case: create audit log linked to the previous entry",
+			args: args{
+				ctx: context.Background(),
+				auditLog: &gorm.AuditLog{
+					Active:         true,
+					Timestamp:      time.Now(),
+					RecordType:     enums.AuditLogFacilityAccessDenied.String(),
+					Payload:        payload,
+					OrganisationID: orgID,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to sign audit log",
+			args: args{
+				ctx: context.Background(),
+				auditLog: &gorm.AuditLog{
+					Active:         true,
+					Timestamp:      time.Now(),
+					RecordType:     enums.AuditLogFacilityAccessDenied.String(),
+					Payload:        payload,
+					OrganisationID: orgID,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: invalid organisation id",
+			args: args{
+				ctx: context.Background(),
+				auditLog: &gorm.AuditLog{
+					Active:         true,
+					Timestamp:      time.Now(),
+					RecordType:     enums.AuditLogFacilityAccessDenied.String(),
+					Payload:        payload,
+					OrganisationID: "organisationID",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	var previous *gorm.AuditLog
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sign := func(auditLog *gorm.AuditLog) error {
+				hash := uuid.NewString()
+				auditLog.Hash = &hash
+				return nil
+			}
+			if tt.name == "Sad case: unable to sign audit log" {
+				sign = func(auditLog *gorm.AuditLog) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+
+			if err := testingDB.CreateAuditLog(tt.args.ctx, tt.args.auditLog, sign); (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.CreateAuditLog() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.name == "Happy case: create audit log linked to the previous entry" {
+				if *tt.args.auditLog.Sequence != *previous.Sequence+1 {
+					t.Errorf("expected sequence %v, got %v", *previous.Sequence+1, *tt.args.auditLog.Sequence)
+				}
+				if *tt.args.auditLog.PreviousHash != *previous.Hash {
+					t.Errorf("expected previous hash %v, got %v", *previous.Hash, *tt.args.auditLog.PreviousHash)
+				}
+			}
+			if !tt.wantErr {
+				previous = tt.args.auditLog
+			}
+		})
+	}
+
+	var organisation gorm.Organisation
+	if err := testingDB.DB.Where("id = ?", orgID).First(&organisation).Error; err != nil {
+		t.Errorf("failed to get organisation: %v", err)
+		return
+	}
+	if organisation.AuditLogSequence != *previous.Sequence || organisation.AuditLogHash != *previous.Hash {
+		t.Errorf("expected the organisation's last audit log to be %v, got %v", *previous.Sequence, organisation.AuditLogSequence)
+	}
+}
+
+func TestPGInstance_SaveUserTOTP(t *testing.T) {
+	type args struct {
+		ctx      context.Context
+		userTOTP *gorm.UserTOTP
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: save user TOTP",
+			args: args{
+				ctx: context.Background(),
+				userTOTP: &gorm.UserTOTP{
+					Active: true,
+					UserID: userID,
+					Secret: gofakeit.HipsterSentence(5),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case: replace an existing user TOTP",
+			args: args{
+				ctx: context.Background(),
+				userTOTP: &gorm.UserTOTP{
+					Active: true,
+					UserID: userID,
+					Secret: gofakeit.HipsterSentence(5),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: invalid user id",
+			args: args{
+				ctx: context.Background(),
+				userTOTP: &gorm.UserTOTP{
+					Active: true,
+					UserID: "userID",
+					Secret: gofakeit.HipsterSentence(5),
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testingDB.SaveUserTOTP(tt.args.ctx, tt.args.userTOTP); (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.SaveUserTOTP() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if err := testingDB.DB.Where("user_id", userID).Unscoped().Delete(&gorm.UserTOTP{}).Error; err != nil {
+		t.Errorf("failed to delete user TOTP: %v", err)
+	}
+}
+
+func TestPGInstance_SaveUserRecoveryCodes(t *testing.T) {
+	type args struct {
+		ctx           context.Context
+		userID        string
+		recoveryCodes []*gorm.UserRecoveryCode
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: save user recovery codes",
+			args: args{
+				ctx:    context.Background(),
+				userID: userID,
+				recoveryCodes: []*gorm.UserRecoveryCode{
+					{
+						Active:     true,
+						UserID:     userID,
+						HashedCode: gofakeit.HipsterSentence(5),
+						Salt:       gofakeit.HipsterSentence(5),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: invalid user id",
+			args: args{
+				ctx:    context.Background(),
+				userID: userID,
+				recoveryCodes: []*gorm.UserRecoveryCode{
+					{
+						Active:     true,
+						UserID:     "userID",
+						HashedCode: gofakeit.HipsterSentence(5),
+						Salt:       gofakeit.HipsterSentence(5),
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testingDB.SaveUserRecoveryCodes(tt.args.ctx, tt.args.userID, tt.args.recoveryCodes); (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.SaveUserRecoveryCodes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if err := testingDB.DB.Where("user_id", userID).Unscoped().Delete(&gorm.UserRecoveryCode{}).Error; err != nil {
+		t.Errorf("failed to delete user recovery codes: %v", err)
+	}
+}
+
+func TestPGInstance_ConsumeRateLimit(t *testing.T) {
+	phoneNumber := gofakeit.Phone()
+	now := time.Now()
+
+	rules := []*domain.RateLimitRule{
+		{
+			KeyType:  enums.RateLimitKeyPhone,
+			Key:      phoneNumber,
+			Limit:    2,
+			Window:   time.Hour,
+			Cooldown: time.Minute,
+		},
+	}
+
+	type args struct {
+		ctx    context.Context
+		action string
+		rules  []*domain.RateLimitRule
+		at     time.Time
+	}
+	tests := []struct {
+		name           string
+		args           args
+		wantAllowed    bool
+		wantCooledDown bool
+		wantErr        bool
+	}{
+		{
+			name: "Happy case: first request is allowed",
+			args: args{
+				ctx:    context.Background(),
+				action: "OTP",
+				rules:  rules,
+				at:     now,
+			},
+			wantAllowed: true,
+			wantErr:     false,
+		},
+		{
+			name: "Happy case: request within the cool-down is rejected",
+			args: args{
+				ctx:    context.Background(),
+				action: "OTP",
+				rules:  rules,
+				at:     now.Add(time.Second * 10),
+			},
+			wantAllowed:    false,
+			wantCooledDown: true,
+			wantErr:        false,
+		},
+		{
+			name: "Happy case: request after the cool-down is allowed",
+			args: args{
+				ctx:    context.Background(),
+				action: "OTP",
+				rules:  rules,
+				at:     now.Add(time.Minute * 2),
+			},
+			wantAllowed: true,
+			wantErr:     false,
+		},
+		{
+			name: "Happy case: request over the limit is rejected",
+			args: args{
+				ctx:    context.Background(),
+				action: "OTP",
+				rules:  rules,
+				at:     now.Add(time.Minute * 4),
+			},
+			wantAllowed: false,
+			wantErr:     false,
+		},
+		{
+			name: "Happy case: request is allowed once the earlier requests leave the window",
+			args: args{
+				ctx:    context.Background(),
+				action: "OTP",
+				rules:  rules,
+				at:     now.Add(time.Hour + time.Minute*3),
+			},
+			wantAllowed: true,
+			wantErr:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.ConsumeRateLimit(tt.args.ctx, tt.args.action, tt.args.rules, tt.args.at)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.ConsumeRateLimit() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Allowed != tt.wantAllowed {
+				t.Errorf("PGInstance.ConsumeRateLimit() allowed = %v, want %v", got.Allowed, tt.wantAllowed)
+			}
+			if got.CooledDown != tt.wantCooledDown {
+				t.Errorf("PGInstance.ConsumeRateLimit() cooled down = %v, want %v", got.CooledDown, tt.wantCooledDown)
+			}
+		})
+	}
+
+	if err := testingDB.DB.Where("key_value", phoneNumber).Unscoped().Delete(&gorm.RateLimitEvent{}).Error; err != nil {
+		t.Errorf("failed to delete rate limit events: %v", err)
+	}
+}
+
+func TestPGInstance_RecordLoginEvent(t *testing.T) {
+	fingerprint := gofakeit.UUID()
+
+	type args struct {
+		ctx    context.Context
+		event  *gorm.LoginEvent
+		device *gorm.UserDevice
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: record a login from a new device",
+			args: args{
+				ctx: context.Background(),
+				event: &gorm.LoginEvent{
+					Active:      true,
+					UserID:      userID,
+					Fingerprint: fingerprint,
+					IPAddress:   gofakeit.IPv4Address(),
+					Country:     "KE",
+					RiskFlags:   pq.StringArray{"NEW_DEVICE"},
+					Timestamp:   time.Now(),
+				},
+				device: &gorm.UserDevice{
+					Active:      true,
+					UserID:      userID,
+					Fingerprint: fingerprint,
+					DeviceName:  gofakeit.Name(),
+					LastCountry: "KE",
+					LastLoginAt: time.Now(),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case: record a login from a known device",
+			args: args{
+				ctx: context.Background(),
+				event: &gorm.LoginEvent{
+					Active:      true,
+					UserID:      userID,
+					Fingerprint: fingerprint,
+					IPAddress:   gofakeit.IPv4Address(),
+					Country:     "KE",
+					Timestamp:   time.Now(),
+				},
+				device: &gorm.UserDevice{
+					Active:      true,
+					UserID:      userID,
+					Fingerprint: fingerprint,
+					DeviceName:  gofakeit.Name(),
+					LastCountry: "KE",
+					LastLoginAt: time.Now(),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: invalid user id",
+			args: args{
+				ctx: context.Background(),
+				event: &gorm.LoginEvent{
+					Active:      true,
+					UserID:      "userID",
+					Fingerprint: fingerprint,
+					Timestamp:   time.Now(),
+				},
+				device: &gorm.UserDevice{
+					Active:      true,
+					UserID:      "userID",
+					Fingerprint: fingerprint,
+					LastLoginAt: time.Now(),
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testingDB.RecordLoginEvent(tt.args.ctx, tt.args.event, tt.args.device); (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.RecordLoginEvent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if err := testingDB.DB.Where("user_id", userID).Unscoped().Delete(&gorm.LoginEvent{}).Error; err != nil {
+		t.Errorf("failed to delete login events: %v", err)
+	}
+	if err := testingDB.DB.Where("user_id", userID).Unscoped().Delete(&gorm.UserDevice{}).Error; err != nil {
+		t.Errorf("failed to delete user devices: %v", err)
+	}
+}
+
+func TestPGInstance_CreatePhoneChangeRequest(t *testing.T) {
+	type args struct {
+		ctx     context.Context
+		request *gorm.PhoneChangeRequest
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: create a phone change request",
+			args: args{
+				ctx: context.Background(),
+				request: &gorm.PhoneChangeRequest{
+					Active:         true,
+					UserID:         userID,
+					Flavour:        feedlib.FlavourConsumer,
+					OldPhoneNumber: testPhone,
+					NewPhoneNumber: gofakeit.Phone(),
+					RequestedBy:    userID,
+					ExpiresAt:      time.Now().Add(time.Minute * 10),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: invalid user",
+			args: args{
+				ctx: context.Background(),
+				request: &gorm.PhoneChangeRequest{
+					Active:         true,
+					UserID:         "invalid",
+					Flavour:        feedlib.FlavourConsumer,
+					OldPhoneNumber: testPhone,
+					NewPhoneNumber: gofakeit.Phone(),
+					RequestedBy:    userID,
+					ExpiresAt:      time.Now().Add(time.Minute * 10),
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testingDB.CreatePhoneChangeRequest(tt.args.ctx, tt.args.request); (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.CreatePhoneChangeRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPGInstance_TransferClient(t *testing.T) {
+	type args struct {
+		ctx      context.Context
+		transfer *gorm.ClientTransfer
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: transfer client within a program",
+			args: args{
+				ctx: addRequiredContext(context.Background(), t),
+				transfer: &gorm.ClientTransfer{
+					Active:             true,
+					UserID:             userIDtoAssignClient,
+					ClientID:           clientID,
+					FromFacilityID:     facilityID,
+					ToFacilityID:       facilityID,
+					FromProgramID:      programID,
+					ToProgramID:        programID,
+					FromOrganisationID: orgID,
+					ToOrganisationID:   orgID,
+					Reason:             "Relocated",
+					CarriedHistory:     []string{},
+					TransferredBy:      userID,
+					TransferredAt:      time.Now(),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: client does not exist",
+			args: args{
+				ctx: addRequiredContext(context.Background(), t),
+				transfer: &gorm.ClientTransfer{
+					Active:             true,
+					UserID:             userIDtoAssignClient,
+					ClientID:           uuid.New().String(),
+					FromFacilityID:     facilityID,
+					ToFacilityID:       facilityID,
+					FromProgramID:      programID,
+					ToProgramID:        programID,
+					FromOrganisationID: orgID,
+					ToOrganisationID:   orgID,
+					Reason:             "Relocated",
+					TransferredBy:      userID,
+					TransferredAt:      time.Now(),
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testingDB.TransferClient(tt.args.ctx, tt.args.transfer, nil, nil); (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.TransferClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPGInstance_SetServiceRequestSLA(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		sla *gorm.ServiceRequestSLA
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: set service request sla",
+			args: args{
+				ctx: context.Background(),
+				sla: &gorm.ServiceRequestSLA{
+					Active:          true,
+					ProgramID:       programID,
+					OrganisationID:  orgID,
+					RequestType:     enums.ServiceRequestTypeScreeningToolsRedFlag.String(),
+					ResolutionHours: 24,
+					EscalationHours: 12,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case: replace service request sla",
+			args: args{
+				ctx: context.Background(),
+				sla: &gorm.ServiceRequestSLA{
+					Active:          true,
+					ProgramID:       programID,
+					OrganisationID:  orgID,
+					RequestType:     enums.ServiceRequestTypeScreeningToolsRedFlag.String(),
+					ResolutionHours: 12,
+					EscalationHours: 6,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: invalid program id",
+			args: args{
+				ctx: context.Background(),
+				sla: &gorm.ServiceRequestSLA{
+					Active:          true,
+					ProgramID:       "invalid",
+					OrganisationID:  orgID,
+					RequestType:     enums.ServiceRequestTypeScreeningToolsRedFlag.String(),
+					ResolutionHours: 12,
+					EscalationHours: 6,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := testingDB.SetServiceRequestSLA(tt.args.ctx, tt.args.sla)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.SetServiceRequestSLA() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.name == "Happy case: replace service request sla" && tt.args.sla.ResolutionHours != 12 {
+				t.Errorf("expected the service request sla to be replaced, got %v", tt.args.sla)
+			}
+		})
+	}
+}
@@ -5,14 +5,15 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+
 	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
 )
 
 // Delete represents all `delete` ops to the database
 type Delete interface {
 	DeleteFacility(ctx context.Context, identifier *FacilityIdentifier) (bool, error)
-	DeleteClientProfile(ctx context.Context, clientID string, userID *string) error
+	AnonymizeClientProfile(ctx context.Context, clientID string, userID *string) error
 	DeleteStaffProfile(ctx context.Context, staffID string) error
 	DeleteCommunity(ctx context.Context, communityID string) error
 	RemoveFacilitiesFromClientProfile(ctx context.Context, clientID string, facilities []string) error
@@ -20,6 +21,10 @@ type Delete interface {
 	DeleteOrganisation(ctx context.Context, organisation *Organisation) error
 	DeleteAccessToken(ctx context.Context, signature string) error
 	DeleteRefreshToken(ctx context.Context, signature string) error
+	DeletePKCE(ctx context.Context, signature string) error
+	RemovePermissionsFromRole(ctx context.Context, roleID string, permissionIDs []string) error
+	RevokeRoles(ctx context.Context, userType enums.UsersType, profileID string, roleIDs []string) error
+	DeleteUserTOTP(ctx context.Context, userID string) error
 }
 
 // DeleteFacility will do the actual deletion of a facility from the database
@@ -192,34 +197,238 @@ func (db *PGInstance) DeleteRefreshToken(ctx context.Context, signature string)
 	return nil
 }
 
-// DeleteClientProfile will permanently delete a client and their information from the database
-func (db *PGInstance) DeleteClientProfile(ctx context.Context, clientID string, userID *string) error {
-	tx := db.DB.Begin()
+// DeletePKCE deletes the PKCE session of an authorization code using its signature
+func (db *PGInstance) DeletePKCE(ctx context.Context, signature string) error {
+	if err := db.DB.WithContext(ctx).Where(PKCE{Signature: signature}).Delete(&PKCE{}).Error; err != nil {
+		return fmt.Errorf("error deleting pkce session: %w", err)
+	}
+
+	return nil
+}
+
+// AnonymizeClientProfile removes the personally identifiable information held about a client while keeping their de-identified clinical records
+// e.g the moods of their health diary entries and the types and statuses of their service requests.
+// The client's identifiers, health diary notes, service request details and the feedback they gave in the program are cleared and their
+// related persons and addresses are removed. When the user is provided, they have no other profile and their name, username, email, contacts,
+// phone change requests and the phone numbers and IP addresses used to sign in are removed as well. Their date of birth is reduced to the
+// year of birth and their gender is kept
+func (db *PGInstance) AnonymizeClientProfile(ctx context.Context, clientID string, userID *string) error {
+	tx := db.DB.WithContext(ctx).Begin()
 	defer func() {
 		if r := recover(); r != nil {
 			tx.Rollback()
 		}
 	}()
 	if err := tx.Error; err != nil {
-		return fmt.Errorf("failed to initialize user deletion transaction")
+		return fmt.Errorf("failed to initialize client anonymization transaction")
+	}
+
+	anonymizedAt := time.Now()
+
+	result := tx.Model(&Client{}).Where("id = ?", clientID).Updates(map[string]interface{}{
+		"active":               false,
+		"fhir_patient_id":      nil,
+		"emr_health_record_id": nil,
+		"anonymized_at":        anonymizedAt,
+	})
+	if result.Error != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to anonymize client profile: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return fmt.Errorf("client %s does not exist", clientID)
+	}
+
+	var client Client
+	if err := tx.Where("id = ?", clientID).First(&client).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to get client profile: %w", err)
+	}
+
+	// identifier values are unique per type so they are replaced with the identifier's own ID
+	err := tx.Exec(`
+		UPDATE common_identifiers SET identifier_value = id::text, description = '', active = false
+		WHERE id IN (SELECT identifier_id FROM clients_client_identifiers WHERE client_id = ?)
+	`, clientID).Error
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to anonymize client identifiers: %w", err)
+	}
+
+	err = tx.Model(&ClientHealthDiaryEntry{}).Where("client_id = ?", clientID).Update("note", "").Error
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to anonymize health diary entries: %w", err)
 	}
 
-	err := tx.Unscoped().Preload(clause.Associations).Delete(&Client{ID: &clientID}).Error
+	err = tx.Model(&ClientServiceRequest{}).Where("client_id = ?", clientID).Updates(map[string]interface{}{
+		"request": "",
+		"meta":    nil,
+	}).Error
 	if err != nil {
 		tx.Rollback()
-		return fmt.Errorf("failed to delete client profile: %w", err)
+		return fmt.Errorf("failed to anonymize service requests: %w", err)
+	}
+
+	err = tx.Model(&Feedback{}).Where("user_id = ? AND program_id = ?", client.UserID, client.ProgramID).Updates(map[string]interface{}{
+		"feedback":     "",
+		"phone_number": "",
+	}).Error
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to anonymize feedback: %w", err)
+	}
+
+	var relatedPersonIDs, contactIDs, addressIDs, clientAddressIDs []string
+	if err := tx.Model(&ClientRelatedPerson{}).Where("client_id = ?", clientID).Pluck("relatedperson_id", &relatedPersonIDs).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to get client's related persons: %w", err)
+	}
+	if err := tx.Model(&RelatedPersonContacts{}).Where("relatedperson_id IN ?", relatedPersonIDs).Pluck("contact_id", &contactIDs).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to get related persons' contacts: %w", err)
+	}
+	if err := tx.Model(&RelatedPersonAddresses{}).Where("relatedperson_id IN ?", relatedPersonIDs).Pluck("address_id", &addressIDs).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to get related persons' addresses: %w", err)
+	}
+
+	// the client addresses through table is not part of the migrations and only exists in older databases
+	hasClientAddresses := tx.Migrator().HasTable(&ClientAddress{})
+	if hasClientAddresses {
+		if err := tx.Model(&ClientAddress{}).Where("client_id = ?", clientID).Pluck("address_id", &clientAddressIDs).Error; err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to get client's addresses: %w", err)
+		}
+		addressIDs = append(addressIDs, clientAddressIDs...)
+	}
+
+	type personalRecord struct {
+		model     interface{}
+		condition string
+		value     interface{}
+	}
+
+	// the links are removed before the related persons, contacts and addresses that they reference
+	personalRecords := []personalRecord{
+		{model: &RelatedPersonContacts{}, condition: "relatedperson_id IN ?", value: relatedPersonIDs},
+		{model: &RelatedPersonAddresses{}, condition: "relatedperson_id IN ?", value: relatedPersonIDs},
+		{model: &ClientRelatedPerson{}, condition: "client_id = ?", value: clientID},
+	}
+	if hasClientAddresses {
+		personalRecords = append(personalRecords, personalRecord{model: &ClientAddress{}, condition: "client_id = ?", value: clientID})
+	}
+	personalRecords = append(
+		personalRecords,
+		personalRecord{model: &Contact{}, condition: "id IN ?", value: contactIDs},
+		personalRecord{model: &Address{}, condition: "id IN ?", value: addressIDs},
+		personalRecord{model: &RelatedPerson{}, condition: "id IN ?", value: relatedPersonIDs},
+	)
+
+	for _, record := range personalRecords {
+		if err := tx.Where(record.condition, record.value).Delete(record.model).Error; err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to delete client's related persons and addresses: %w", err)
+		}
 	}
 
 	if userID != nil {
-		err := tx.Unscoped().Preload(clause.Associations).Delete(&User{UserID: userID}).Error
+		err := tx.Exec(`
+			UPDATE users_user SET
+				name = '', username = id::text, email = NULL, avatar = '', push_tokens = NULL, active = false,
+				date_of_birth = date_trunc('year', date_of_birth)::date
+			WHERE id = ?
+		`, *userID).Error
 		if err != nil {
-			return fmt.Errorf("an error occurred while deleting user profile: %w", err)
+			tx.Rollback()
+			return fmt.Errorf("failed to anonymize user profile: %w", err)
+		}
+
+		for _, model := range []interface{}{&Contact{}, &SecurityQuestionResponse{}, &UserDevice{}, &PhoneChangeRequest{}} {
+			if err := tx.Where("user_id = ?", *userID).Delete(model).Error; err != nil {
+				tx.Rollback()
+				return fmt.Errorf("failed to delete user's personal information: %w", err)
+			}
+		}
+
+		if err := tx.Model(&UserOTP{}).Where("user_id = ?", *userID).Update("phonenumber", "").Error; err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to anonymize user's OTPs: %w", err)
+		}
+
+		if err := tx.Model(&LoginEvent{}).Where("user_id = ?", *userID).Update("ip_address", "").Error; err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to anonymize user's login events: %w", err)
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("transaction commit to anonymize client profile failed: %w", err)
+	}
+
+	return nil
+}
+
+// RemovePermissionsFromRole revokes the provided permissions from a role
+func (db *PGInstance) RemovePermissionsFromRole(ctx context.Context, roleID string, permissionIDs []string) error {
+	err := db.DB.WithContext(ctx).Where("authorityrole_id = ? AND authoritypermission_id IN ?", roleID, permissionIDs).
+		Delete(&AuthorityRolePermission{}).Error
+	if err != nil {
+		return fmt.Errorf("failed to remove permissions from role: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeRoles removes roles from a staff, client or caregiver profile
+func (db *PGInstance) RevokeRoles(ctx context.Context, userType enums.UsersType, profileID string, roleIDs []string) error {
+	tx := db.DB.WithContext(ctx).Where("authorityrole_id IN ?", roleIDs)
+
+	switch userType {
+	case enums.StaffUser:
+		tx = tx.Where("staff_id = ?", profileID).Delete(&AuthorityRoleStaff{})
+
+	case enums.ClientUser:
+		tx = tx.Where("client_id = ?", profileID).Delete(&AuthorityRoleClient{})
+
+	case enums.CaregiverUser:
+		tx = tx.Where("caregiver_id = ?", profileID).Delete(&AuthorityRoleCaregiver{})
+
+	default:
+		return fmt.Errorf("roles cannot be revoked from user type: %s", userType)
+	}
+
+	if err := tx.Error; err != nil {
+		return fmt.Errorf("failed to revoke roles: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteUserTOTP removes a user's TOTP enrollment together with their recovery codes
+func (db *PGInstance) DeleteUserTOTP(ctx context.Context, userID string) error {
+	tx := db.DB.WithContext(ctx).Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
 		}
+	}()
+
+	if err := tx.Where(&UserRecoveryCode{UserID: userID}).Delete(&UserRecoveryCode{}).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to delete user recovery codes: %w", err)
+	}
+
+	if err := tx.Where(&UserTOTP{UserID: userID}).Delete(&UserTOTP{}).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to delete user TOTP: %w", err)
 	}
 
 	if err := tx.Commit().Error; err != nil {
 		tx.Rollback()
-		return fmt.Errorf("transaction commit to delete user profile failed: %w", err)
+		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
 	return nil
@@ -3,8 +3,12 @@ package gorm_test
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/brianvoe/gofakeit"
 	"github.com/google/uuid"
+	"github.com/savannahghi/feedlib"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/gorm"
 )
 
@@ -268,7 +272,107 @@ func TestPGInstance_DeleteRefreshToken(t *testing.T) {
 	}
 }
 
-func TestPGInstance_DeleteClientProfile(t *testing.T) {
+func TestPGInstance_DeletePKCE(t *testing.T) {
+
+	type args struct {
+		ctx       context.Context
+		signature string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "happy case: delete pkce session",
+			args: args{
+				ctx:       context.Background(),
+				signature: "0b9e8d7c6a5f4e3d2c1b0a9f8e7d6c5b4a3f2e1d0c9b8a7f",
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testingDB.DeletePKCE(tt.args.ctx, tt.args.signature); (err != nil) != tt.wantErr {
+				t.Errorf("DeletePKCE() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPGInstance_AnonymizeClientProfile(t *testing.T) {
+	ctx := context.Background()
+
+	contact := &gorm.Contact{
+		Type:           "PHONE",
+		Value:          gofakeit.Phone(),
+		Active:         true,
+		OptedIn:        true,
+		OrganisationID: orgID,
+	}
+	if err := testingDB.DB.Create(contact).Error; err != nil {
+		t.Errorf("failed to create related person's contact: %v", err)
+		return
+	}
+
+	relatedPerson := &gorm.RelatedPerson{
+		Active:           true,
+		FirstName:        gofakeit.FirstName(),
+		LastName:         gofakeit.LastName(),
+		Gender:           "MALE",
+		RelationshipType: "Next of Kin",
+		ProgramID:        programID,
+		OrganisationID:   orgID,
+	}
+	if err := testingDB.GetOrCreateNextOfKin(ctx, relatedPerson, testOPtOutClient, contact.ID); err != nil {
+		t.Errorf("failed to create related person: %v", err)
+		return
+	}
+
+	address := &gorm.Address{
+		Active:         true,
+		AddressType:    "POSTAL",
+		Text:           gofakeit.Street(),
+		PostalCode:     gofakeit.Zip(),
+		Country:        "KE",
+		OrganisationID: orgID,
+	}
+	if err := testingDB.DB.Create(address).Error; err != nil {
+		t.Errorf("failed to create related person's address: %v", err)
+		return
+	}
+	if err := testingDB.DB.Create(&gorm.RelatedPersonAddresses{RelatedPersonID: &relatedPerson.ID, AddressID: address.ID}).Error; err != nil {
+		t.Errorf("failed to link related person's address: %v", err)
+		return
+	}
+
+	phoneChangeRequest := &gorm.PhoneChangeRequest{
+		Active:         true,
+		UserID:         testOPtOutClient,
+		Flavour:        feedlib.FlavourConsumer,
+		OldPhoneNumber: gofakeit.Phone(),
+		NewPhoneNumber: gofakeit.Phone(),
+		RequestedBy:    testOPtOutClient,
+		ExpiresAt:      time.Now().Add(time.Hour),
+	}
+	if err := testingDB.CreatePhoneChangeRequest(ctx, phoneChangeRequest); err != nil {
+		t.Errorf("failed to create phone change request: %v", err)
+		return
+	}
+
+	loginEvent := &gorm.LoginEvent{
+		Active:      true,
+		UserID:      testOPtOutClient,
+		Fingerprint: gofakeit.UUID(),
+		IPAddress:   gofakeit.IPv4Address(),
+		Country:     "KE",
+		Timestamp:   time.Now(),
+	}
+	if err := testingDB.DB.Create(loginEvent).Error; err != nil {
+		t.Errorf("failed to create login event: %v", err)
+		return
+	}
 
 	type args struct {
 		ctx      context.Context
@@ -281,7 +385,7 @@ func TestPGInstance_DeleteClientProfile(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "happy case: delete client with one user profile",
+			name: "happy case: anonymize client with one user profile",
 			args: args{
 				ctx:      context.Background(),
 				clientID: testOPtOutClient,
@@ -290,7 +394,7 @@ func TestPGInstance_DeleteClientProfile(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "happy case: delete client who is a caregiver",
+			name: "happy case: anonymize client who is a caregiver",
 			args: args{
 				ctx:      context.Background(),
 				clientID: testOPtOutClientCaregiver,
@@ -298,7 +402,7 @@ func TestPGInstance_DeleteClientProfile(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "happy case: delete client who has a staff profile",
+			name: "happy case: anonymize client who has a staff profile",
 			args: args{
 				ctx:      context.Background(),
 				clientID: testOPtOutClientStaff,
@@ -306,7 +410,7 @@ func TestPGInstance_DeleteClientProfile(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "happy case: delete client who has a staff profile 2",
+			name: "happy case: anonymize client who has a staff profile 2",
 			args: args{
 				ctx:      context.Background(),
 				clientID: testOptOutStaffClient,
@@ -321,11 +425,238 @@ func TestPGInstance_DeleteClientProfile(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Sad case: client does not exist",
+			args: args{
+				ctx:      context.Background(),
+				clientID: gofakeit.UUID(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testingDB.AnonymizeClientProfile(tt.args.ctx, tt.args.clientID, tt.args.userID); (err != nil) != tt.wantErr {
+				t.Errorf("AnonymizeClientProfile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	var relatedPersons, relatedPersonLinks, contacts, addresses, phoneChangeRequests int64
+	counts := []struct {
+		model interface{}
+		query string
+		value interface{}
+		count *int64
+	}{
+		{model: &gorm.RelatedPerson{}, query: "id = ?", value: relatedPerson.ID, count: &relatedPersons},
+		{model: &gorm.ClientRelatedPerson{}, query: "client_id = ?", value: testOPtOutClient, count: &relatedPersonLinks},
+		{model: &gorm.Contact{}, query: "id = ?", value: contact.ID, count: &contacts},
+		{model: &gorm.Address{}, query: "id = ?", value: *address.ID, count: &addresses},
+		{model: &gorm.PhoneChangeRequest{}, query: "user_id = ?", value: testOPtOutClient, count: &phoneChangeRequests},
+	}
+	for _, c := range counts {
+		if err := testingDB.DB.Model(c.model).Where(c.query, c.value).Count(c.count).Error; err != nil {
+			t.Errorf("failed to count records: %v", err)
+			return
+		}
+		if *c.count != 0 {
+			t.Errorf("expected the client's %T records to be deleted, found %d", c.model, *c.count)
+		}
+	}
+
+	var feedback gorm.Feedback
+	if err := testingDB.DB.Where("id = ?", testOPtOutClient).First(&feedback).Error; err != nil {
+		t.Errorf("failed to get feedback: %v", err)
+		return
+	}
+	if feedback.Feedback != "" || feedback.PhoneNumber != "" {
+		t.Errorf("expected the client's feedback and phone number to be cleared")
+	}
+
+	var otps []gorm.UserOTP
+	if err := testingDB.DB.Where("user_id = ?", testOPtOutClient).Find(&otps).Error; err != nil {
+		t.Errorf("failed to get OTPs: %v", err)
+		return
+	}
+	for _, otp := range otps {
+		if otp.PhoneNumber != "" {
+			t.Errorf("expected the phone number of the user's OTPs to be cleared")
+		}
+	}
+
+	var event gorm.LoginEvent
+	if err := testingDB.DB.Where("id = ?", loginEvent.ID).First(&event).Error; err != nil {
+		t.Errorf("failed to get login event: %v", err)
+		return
+	}
+	if event.IPAddress != "" {
+		t.Errorf("expected the IP address of the user's login events to be cleared")
+	}
+
+	if err := testingDB.DB.Where("id = ?", loginEvent.ID).Delete(&gorm.LoginEvent{}).Error; err != nil {
+		t.Errorf("failed to delete login event: %v", err)
+	}
+}
+
+func TestPGInstance_RemovePermissionsFromRole(t *testing.T) {
+	role := &gorm.AuthorityRole{
+		Name:           gofakeit.BS(),
+		Active:         true,
+		UserType:       enums.StaffUser.String(),
+		OrganisationID: orgID,
+		ProgramID:      programID,
+	}
+	err := testingDB.CreateAuthorityRole(context.Background(), role, []string{canInviteUserPermissionID})
+	if err != nil {
+		t.Errorf("failed to create role: %v", err)
+		return
+	}
+
+	type args struct {
+		ctx           context.Context
+		roleID        string
+		permissionIDs []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: remove permission from role",
+			args: args{
+				ctx:           context.Background(),
+				roleID:        *role.AuthorityRoleID,
+				permissionIDs: []string{canInviteUserPermissionID},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: invalid role id",
+			args: args{
+				ctx:           context.Background(),
+				roleID:        "roleID",
+				permissionIDs: []string{canInviteUserPermissionID},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testingDB.RemovePermissionsFromRole(tt.args.ctx, tt.args.roleID, tt.args.permissionIDs); (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.RemovePermissionsFromRole() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPGInstance_RevokeRoles(t *testing.T) {
+	role := &gorm.AuthorityRole{
+		Name:           gofakeit.BS(),
+		Active:         true,
+		UserType:       enums.CaregiverUser.String(),
+		OrganisationID: orgID,
+		ProgramID:      programID,
+	}
+	err := testingDB.CreateAuthorityRole(context.Background(), role, nil)
+	if err != nil {
+		t.Errorf("failed to create role: %v", err)
+		return
+	}
+	err = testingDB.AssignRoles(context.Background(), enums.CaregiverUser, testCaregiverID, []string{*role.AuthorityRoleID})
+	if err != nil {
+		t.Errorf("failed to assign role: %v", err)
+		return
+	}
+
+	type args struct {
+		ctx       context.Context
+		userType  enums.UsersType
+		profileID string
+		roleIDs   []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: revoke role from caregiver",
+			args: args{
+				ctx:       context.Background(),
+				userType:  enums.CaregiverUser,
+				profileID: testCaregiverID,
+				roleIDs:   []string{*role.AuthorityRoleID},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unsupported user type",
+			args: args{
+				ctx:       context.Background(),
+				userType:  enums.HealthcareWorkerUser,
+				profileID: testCaregiverID,
+				roleIDs:   []string{*role.AuthorityRoleID},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: invalid profile id",
+			args: args{
+				ctx:       context.Background(),
+				userType:  enums.CaregiverUser,
+				profileID: "profileID",
+				roleIDs:   []string{*role.AuthorityRoleID},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testingDB.RevokeRoles(tt.args.ctx, tt.args.userType, tt.args.profileID, tt.args.roleIDs); (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.RevokeRoles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPGInstance_DeleteUserTOTP(t *testing.T) {
+	if err := testingDB.SaveUserTOTP(context.Background(), &gorm.UserTOTP{Active: true, UserID: userID, Secret: gofakeit.HipsterSentence(5)}); err != nil {
+		t.Errorf("failed to save user TOTP: %v", err)
+		return
+	}
+
+	type args struct {
+		ctx    context.Context
+		userID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: delete user TOTP",
+			args: args{
+				ctx:    context.Background(),
+				userID: userID,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: invalid user id",
+			args: args{
+				ctx:    context.Background(),
+				userID: "userID",
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := testingDB.DeleteClientProfile(tt.args.ctx, tt.args.clientID, tt.args.userID); (err != nil) != tt.wantErr {
-				t.Errorf("DeleteClientProfile() error = %v, wantErr %v", err, tt.wantErr)
+			if err := testingDB.DeleteUserTOTP(tt.args.ctx, tt.args.userID); (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.DeleteUserTOTP() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/brianvoe/gofakeit"
 	"github.com/google/uuid"
+	"github.com/jackc/pgtype"
 	"github.com/lib/pq"
 	"github.com/savannahghi/enumutils"
 	"github.com/savannahghi/feedlib"
@@ -125,7 +126,7 @@ type GormMock struct {
 	MockGetAppointmentFn                                      func(ctx context.Context, params *gorm.Appointment) (*gorm.Appointment, error)
 	MockCheckIfStaffHasUnresolvedServiceRequestsFn            func(ctx context.Context, staffID string, serviceRequestType string) (bool, error)
 	MockGetFacilityStaffsFn                                   func(ctx context.Context, facilityID string) ([]*gorm.StaffProfile, error)
-	MockDeleteClientProfileFn                                 func(ctx context.Context, clientID string, userID *string) error
+	MockAnonymizeClientProfileFn                              func(ctx context.Context, clientID string, userID *string) error
 	MockDeleteStaffProfileFn                                  func(ctx context.Context, staffID string) error
 	MockSaveFeedbackFn                                        func(ctx context.Context, feedback *gorm.Feedback) error
 	MockUpdateNotificationFn                                  func(ctx context.Context, notification *gorm.Notification, updateData map[string]interface{}) error
@@ -234,6 +235,80 @@ type GormMock struct {
 	MockCreateBookingFn                                       func(ctx context.Context, booking *gorm.Booking) (*gorm.Booking, error)
 	MockUpdateBookingFn                                       func(ctx context.Context, booking *gorm.Booking, updateData map[string]interface{}) error
 	MockListBookingsFn                                        func(ctx context.Context, clientID string, bookingState enums.BookingState, pagination *domain.Pagination) ([]*gorm.Booking, *domain.Pagination, error)
+	MockGetUserPermissionsFn                                  func(ctx context.Context, userID string, programID string) ([]*gorm.AuthorityPermission, error)
+	MockCreateAuthorityRoleFn                                 func(ctx context.Context, role *gorm.AuthorityRole, permissionIDs []string) error
+	MockAddPermissionsToRoleFn                                func(ctx context.Context, roleID string, permissionIDs []string) error
+	MockAssignRolesFn                                         func(ctx context.Context, userType enums.UsersType, profileID string, roleIDs []string) error
+	MockSeedAuthorityRolesFn                                  func(ctx context.Context, permissions []*gorm.AuthorityPermission, roles []*gorm.AuthorityRole, roleScopes map[string][]string) error
+	MockAssignDefaultRolesFn                                  func(ctx context.Context) error
+	MockRemovePermissionsFromRoleFn                           func(ctx context.Context, roleID string, permissionIDs []string) error
+	MockRevokeRolesFn                                         func(ctx context.Context, userType enums.UsersType, profileID string, roleIDs []string) error
+	MockGetAuthorityRoleByIDFn                                func(ctx context.Context, roleID string) (*gorm.AuthorityRole, error)
+	MockGetAuthorityRoleByNameFn                              func(ctx context.Context, programID string, name string) (*gorm.AuthorityRole, error)
+	MockListAuthorityRolesFn                                  func(ctx context.Context, programID string) ([]*gorm.AuthorityRole, error)
+	MockListAuthorityPermissionsFn                            func(ctx context.Context) ([]*gorm.AuthorityPermission, error)
+	MockGetRolePermissionsFn                                  func(ctx context.Context, roleID string) ([]*gorm.AuthorityPermission, error)
+	MockGetProfileRolesFn                                     func(ctx context.Context, userType enums.UsersType, profileID string) ([]*gorm.AuthorityRole, error)
+	MockCreateAuditLogFn                                      func(ctx context.Context, auditLog *gorm.AuditLog, sign func(auditLog *gorm.AuditLog) error) error
+	MockCheckIfStaffHasFacilityAccessFn                       func(ctx context.Context, userID, programID, facilityID string) (bool, error)
+	MockListAuditLogsFn                                       func(ctx context.Context, organisationID string, filter *dto.AuditLogFilterInput, pagination *domain.Pagination) ([]*gorm.AuditLog, *domain.Pagination, error)
+	MockListAuditLogChainFn                                   func(ctx context.Context, organisationID string, afterSequence int64, limit int) ([]*gorm.AuditLog, error)
+	MockCountUnchainedAuditLogsFn                             func(ctx context.Context, organisationID string, since time.Time) (int64, error)
+	MockSaveUserTOTPFn                                        func(ctx context.Context, userTOTP *gorm.UserTOTP) error
+	MockSaveUserRecoveryCodesFn                               func(ctx context.Context, userID string, recoveryCodes []*gorm.UserRecoveryCode) error
+	MockGetUserTOTPFn                                         func(ctx context.Context, userID string) (*gorm.UserTOTP, error)
+	MockCheckIfUserHasTOTPFn                                  func(ctx context.Context, userID string) (bool, error)
+	MockListUserRecoveryCodesFn                               func(ctx context.Context, userID string) ([]*gorm.UserRecoveryCode, error)
+	MockCheckIfStaffTOTPIsEnforcedFn                          func(ctx context.Context, userID string) (bool, error)
+	MockUpdateUserTOTPFn                                      func(ctx context.Context, userTOTP *gorm.UserTOTP, updateData map[string]interface{}) error
+	MockUseUserRecoveryCodeFn                                 func(ctx context.Context, recoveryCode *gorm.UserRecoveryCode) error
+	MockUpdateOrganisationFn                                  func(ctx context.Context, organisation *gorm.Organisation, updateData map[string]interface{}) error
+	MockDeleteUserTOTPFn                                      func(ctx context.Context, userID string) error
+	MockConsumeRateLimitFn                                    func(ctx context.Context, action string, rules []*domain.RateLimitRule, at time.Time) (*domain.RateLimitDecision, error)
+	MockUpdateOTPDeliveryStatusFn                             func(ctx context.Context, provider string, providerMessageID string, status string, at time.Time) (bool, error)
+	MockListUserSessionsFn                                    func(ctx context.Context, userID string, activeSince time.Time) ([]*gorm.Session, error)
+	MockUpdateSessionFn                                       func(ctx context.Context, session *gorm.Session, updateData map[string]interface{}) error
+	MockRevokeSessionsFn                                      func(ctx context.Context, sessionIDs []string, revokedAt time.Time) error
+	MockGetUserPINHistoryFn                                   func(ctx context.Context, userID string, limit int) ([]*gorm.PINData, error)
+	MockCreateOpenIDConnectSessionFn                          func(ctx context.Context, session *gorm.OpenIDConnectSession) error
+	MockGetOpenIDConnectSessionFn                             func(ctx context.Context, code string) (*gorm.OpenIDConnectSession, error)
+	MockCreateOauthSigningKeyFn                               func(ctx context.Context, key *gorm.OauthSigningKey) error
+	MockListOauthSigningKeysFn                                func(ctx context.Context, activeAt time.Time) ([]*gorm.OauthSigningKey, error)
+	MockCreatePKCEFn                                          func(ctx context.Context, pkce *gorm.PKCE) error
+	MockGetPKCEFn                                             func(ctx context.Context, signature string) (*gorm.PKCE, error)
+	MockDeletePKCEFn                                          func(ctx context.Context, signature string) error
+	MockUpdateOauthClientFn                                   func(ctx context.Context, client *gorm.OauthClient, updateData map[string]interface{}) error
+	MockListRevokedSessionIDsFn                               func(ctx context.Context, since time.Time) ([]string, error)
+	MockListRevokedAccessTokenSignaturesFn                    func(ctx context.Context, since time.Time) ([]string, error)
+	MockResetSecurityQuestionResponsesFn                      func(ctx context.Context, userID string) error
+	MockRecordLoginEventFn                                    func(ctx context.Context, event *gorm.LoginEvent, device *gorm.UserDevice) error
+	MockListUserDevicesFn                                     func(ctx context.Context, userID string) ([]*gorm.UserDevice, error)
+	MockListUserLoginCountriesFn                              func(ctx context.Context, userID string) ([]string, error)
+	MockCountUsersLoggedInFromIPAddressFn                     func(ctx context.Context, ipAddress string, since time.Time) (int, error)
+	MockListDuplicateClientCandidatesFn                       func(ctx context.Context, clientID string) ([]*gorm.DuplicateClientCandidate, error)
+	MockMergeClientsFn                                        func(ctx context.Context, survivingClientID string, duplicateClientID string) (map[string]int64, error)
+	MockListUserContactsFn                                    func(ctx context.Context, userID string) ([]*gorm.Contact, error)
+	MockListClientScreeningToolResponsesFn                    func(ctx context.Context, clientID string) ([]*gorm.ScreeningToolResponse, error)
+	MockListUserMetricsFn                                     func(ctx context.Context, userID string) ([]*gorm.Metric, error)
+	MockListUserFeedbackFn                                    func(ctx context.Context, userID string) ([]*gorm.Feedback, error)
+	MockMarkClientForErasureFn                                func(ctx context.Context, clientID string, userID *string, requestedAt time.Time) error
+	MockListClientsDueForErasureFn                            func(ctx context.Context, now time.Time) ([]*gorm.Client, error)
+	MockListExpiredCaregiverDelegationsFn                     func(ctx context.Context, now time.Time) ([]*gorm.CaregiverClient, error)
+	MockEndClientCaregiverDelegationsFn                       func(ctx context.Context, clientID string, endsAt time.Time) (int64, error)
+	MockCreatePhoneChangeRequestFn                            func(ctx context.Context, request *gorm.PhoneChangeRequest) error
+	MockGetPendingPhoneChangeRequestFn                        func(ctx context.Context, userID string, flavour feedlib.Flavour) (*gorm.PhoneChangeRequest, error)
+	MockConfirmPhoneChangeFn                                  func(ctx context.Context, request *gorm.PhoneChangeRequest, confirmedAt time.Time) error
+	MockGetStaffInProgressServiceRequestsFn                   func(ctx context.Context, staffIDs []string) ([]*gorm.ClientServiceRequest, error)
+	MockListOrganisationAdminsFn                              func(ctx context.Context, organisationID string) ([]*gorm.StaffProfile, error)
+	MockListFacilityAdminsFn                                  func(ctx context.Context, facilityID, programID string) ([]*gorm.StaffProfile, error)
+	MockOffboardStaffFn                                       func(ctx context.Context, userID string, staffIDs []string, serviceRequests map[string]*string, deactivateUser bool, offboardedAt time.Time) error
+	MockTransferClientFn                                      func(ctx context.Context, transfer *gorm.ClientTransfer, identifier *gorm.Identifier, client *gorm.Client) error
+	MockListClientTransfersFn                                 func(ctx context.Context, userID string) ([]*gorm.ClientTransfer, error)
+	MockSetServiceRequestSLAFn                                func(ctx context.Context, sla *gorm.ServiceRequestSLA) error
+	MockListServiceRequestSLAsFn                              func(ctx context.Context, programID *string) ([]*gorm.ServiceRequestSLA, error)
+	MockGetServiceRequestsDueForEscalationFn                  func(ctx context.Context, requestTypes []string) ([]*gorm.ClientServiceRequest, error)
+	MockListServiceRequestEscalationsFn                       func(ctx context.Context, serviceRequestID string) ([]*gorm.ServiceRequestEscalation, error)
+	MockEscalateServiceRequestFn                              func(ctx context.Context, escalation *gorm.ServiceRequestEscalation) error
 }
 
 // NewGormMock initializes a new instance of `GormMock` then mocking the case of success.
@@ -1336,7 +1411,7 @@ func NewGormMock() *GormMock {
 		MockDeleteStaffProfileFn: func(ctx context.Context, staffID string) error {
 			return nil
 		},
-		MockDeleteClientProfileFn: func(ctx context.Context, clientID string, userID *string) error {
+		MockAnonymizeClientProfileFn: func(ctx context.Context, clientID string, userID *string) error {
 			return nil
 		},
 		MockGetClientsByFilterParamsFn: func(ctx context.Context, facilityID string, filterParams *dto.ClientFilterParamsInput) ([]*gorm.Client, error) {
@@ -1822,6 +1897,541 @@ func NewGormMock() *GormMock {
 					CurrentPage: 1,
 				}, nil
 		},
+		MockGetUserPermissionsFn: func(ctx context.Context, userID string, programID string) ([]*gorm.AuthorityPermission, error) {
+			return []*gorm.AuthorityPermission{
+				{
+					AuthorityPermissionID: &UUID,
+					Active:                true,
+					Name:                  "delete facility",
+					Description:           "a user with this permission can delete a facility",
+					Category:              "facility",
+					Scope:                 "facility.delete",
+				},
+			}, nil
+		},
+		MockCreateAuthorityRoleFn: func(ctx context.Context, role *gorm.AuthorityRole, permissionIDs []string) error {
+			role.AuthorityRoleID = &UUID
+			return nil
+		},
+		MockAddPermissionsToRoleFn: func(ctx context.Context, roleID string, permissionIDs []string) error {
+			return nil
+		},
+		MockAssignRolesFn: func(ctx context.Context, userType enums.UsersType, profileID string, roleIDs []string) error {
+			return nil
+		},
+		MockSeedAuthorityRolesFn: func(ctx context.Context, permissions []*gorm.AuthorityPermission, roles []*gorm.AuthorityRole, roleScopes map[string][]string) error {
+			return nil
+		},
+		MockAssignDefaultRolesFn: func(ctx context.Context) error {
+			return nil
+		},
+		MockRemovePermissionsFromRoleFn: func(ctx context.Context, roleID string, permissionIDs []string) error {
+			return nil
+		},
+		MockRevokeRolesFn: func(ctx context.Context, userType enums.UsersType, profileID string, roleIDs []string) error {
+			return nil
+		},
+		MockGetAuthorityRoleByIDFn: func(ctx context.Context, roleID string) (*gorm.AuthorityRole, error) {
+			return &gorm.AuthorityRole{
+				AuthorityRoleID: &roleID,
+				Name:            name,
+				Description:     description,
+				Active:          true,
+				UserType:        enums.StaffUser.String(),
+				OrganisationID:  UUID,
+				ProgramID:       UUID,
+			}, nil
+		},
+		MockGetAuthorityRoleByNameFn: func(ctx context.Context, programID string, name string) (*gorm.AuthorityRole, error) {
+			return &gorm.AuthorityRole{
+				AuthorityRoleID: &UUID,
+				Name:            name,
+				Description:     description,
+				Active:          true,
+				IsSystemRole:    true,
+				UserType:        enums.StaffUser.String(),
+				OrganisationID:  UUID,
+				ProgramID:       programID,
+			}, nil
+		},
+		MockListAuthorityRolesFn: func(ctx context.Context, programID string) ([]*gorm.AuthorityRole, error) {
+			return []*gorm.AuthorityRole{
+				{
+					AuthorityRoleID: &UUID,
+					Name:            name,
+					Description:     description,
+					Active:          true,
+					UserType:        enums.StaffUser.String(),
+					OrganisationID:  UUID,
+					ProgramID:       programID,
+				},
+			}, nil
+		},
+		MockListAuthorityPermissionsFn: func(ctx context.Context) ([]*gorm.AuthorityPermission, error) {
+			return []*gorm.AuthorityPermission{
+				{
+					AuthorityPermissionID: &UUID,
+					Active:                true,
+					Name:                  "delete facility",
+					Description:           "a user with this permission can delete a facility",
+					Category:              "facility",
+					Scope:                 "facility.delete",
+				},
+			}, nil
+		},
+		MockGetRolePermissionsFn: func(ctx context.Context, roleID string) ([]*gorm.AuthorityPermission, error) {
+			return []*gorm.AuthorityPermission{
+				{
+					AuthorityPermissionID: &UUID,
+					Active:                true,
+					Name:                  "delete facility",
+					Description:           "a user with this permission can delete a facility",
+					Category:              "facility",
+					Scope:                 "facility.delete",
+				},
+			}, nil
+		},
+		MockGetProfileRolesFn: func(ctx context.Context, userType enums.UsersType, profileID string) ([]*gorm.AuthorityRole, error) {
+			return []*gorm.AuthorityRole{
+				{
+					AuthorityRoleID: &UUID,
+					Name:            name,
+					Description:     description,
+					Active:          true,
+					UserType:        userType.String(),
+					OrganisationID:  UUID,
+					ProgramID:       UUID,
+				},
+			}, nil
+		},
+		MockCreateAuditLogFn: func(ctx context.Context, auditLog *gorm.AuditLog, sign func(auditLog *gorm.AuditLog) error) error {
+			sequence := int64(1)
+			previousHash := ""
+			auditLog.Sequence = &sequence
+			auditLog.PreviousHash = &previousHash
+			if err := sign(auditLog); err != nil {
+				return err
+			}
+			auditLog.ID = &UUID
+			return nil
+		},
+		MockCheckIfStaffHasFacilityAccessFn: func(ctx context.Context, userID, programID, facilityID string) (bool, error) {
+			return true, nil
+		},
+		MockListAuditLogsFn: func(ctx context.Context, organisationID string, filter *dto.AuditLogFilterInput, pagination *domain.Pagination) ([]*gorm.AuditLog, *domain.Pagination, error) {
+			payload := pgtype.JSONB{}
+			_ = payload.Set(map[string]interface{}{
+				"before": map[string]interface{}{"is_organisation_admin": false},
+				"after":  map[string]interface{}{"is_organisation_admin": true},
+			})
+			return []*gorm.AuditLog{
+				{
+					ID:             &UUID,
+					Active:         true,
+					Timestamp:      time.Now(),
+					RecordType:     enums.AuditLogOrganisationAdminChange.String(),
+					Notes:          description,
+					Payload:        payload,
+					ActorID:        &UUID,
+					TargetID:       &UUID,
+					TargetType:     enums.AuditLogTargetStaff.String(),
+					ProgramID:      &UUID,
+					OrganisationID: UUID,
+				},
+			}, pagination, nil
+		},
+		MockListAuditLogChainFn: func(ctx context.Context, organisationID string, afterSequence int64, limit int) ([]*gorm.AuditLog, error) {
+			return []*gorm.AuditLog{}, nil
+		},
+		MockCountUnchainedAuditLogsFn: func(ctx context.Context, organisationID string, since time.Time) (int64, error) {
+			return 0, nil
+		},
+		MockSaveUserTOTPFn: func(ctx context.Context, userTOTP *gorm.UserTOTP) error {
+			return nil
+		},
+		MockSaveUserRecoveryCodesFn: func(ctx context.Context, userID string, recoveryCodes []*gorm.UserRecoveryCode) error {
+			return nil
+		},
+		MockGetUserTOTPFn: func(ctx context.Context, userID string) (*gorm.UserTOTP, error) {
+			return &gorm.UserTOTP{
+				ID:          UUID,
+				Active:      true,
+				UserID:      userID,
+				Secret:      description,
+				Confirmed:   true,
+				ConfirmedAt: &currentTime,
+			}, nil
+		},
+		MockCheckIfUserHasTOTPFn: func(ctx context.Context, userID string) (bool, error) {
+			return false, nil
+		},
+		MockListUserRecoveryCodesFn: func(ctx context.Context, userID string) ([]*gorm.UserRecoveryCode, error) {
+			return []*gorm.UserRecoveryCode{
+				{
+					ID:         UUID,
+					Active:     true,
+					UserID:     userID,
+					HashedCode: description,
+					Salt:       name,
+				},
+			}, nil
+		},
+		MockCheckIfStaffTOTPIsEnforcedFn: func(ctx context.Context, userID string) (bool, error) {
+			return false, nil
+		},
+		MockUpdateUserTOTPFn: func(ctx context.Context, userTOTP *gorm.UserTOTP, updateData map[string]interface{}) error {
+			return nil
+		},
+		MockUseUserRecoveryCodeFn: func(ctx context.Context, recoveryCode *gorm.UserRecoveryCode) error {
+			return nil
+		},
+		MockUpdateOrganisationFn: func(ctx context.Context, organisation *gorm.Organisation, updateData map[string]interface{}) error {
+			return nil
+		},
+		MockDeleteUserTOTPFn: func(ctx context.Context, userID string) error {
+			return nil
+		},
+		MockConsumeRateLimitFn: func(ctx context.Context, action string, rules []*domain.RateLimitRule, at time.Time) (*domain.RateLimitDecision, error) {
+			return &domain.RateLimitDecision{Allowed: true}, nil
+		},
+		MockUpdateOTPDeliveryStatusFn: func(ctx context.Context, provider string, providerMessageID string, status string, at time.Time) (bool, error) {
+			return true, nil
+		},
+		MockListUserSessionsFn: func(ctx context.Context, userID string, activeSince time.Time) ([]*gorm.Session, error) {
+			now := time.Now()
+			return []*gorm.Session{
+				{
+					ID:         UUID,
+					ClientID:   UUID,
+					UserID:     userID,
+					DeviceID:   UUID,
+					DeviceName: "Pixel 7",
+					UserAgent:  "okhttp/4.9.2",
+					IPAddress:  "127.0.0.1",
+					LastSeenAt: &now,
+				},
+			}, nil
+		},
+		MockUpdateSessionFn: func(ctx context.Context, session *gorm.Session, updateData map[string]interface{}) error {
+			return nil
+		},
+		MockRevokeSessionsFn: func(ctx context.Context, sessionIDs []string, revokedAt time.Time) error {
+			return nil
+		},
+		MockGetUserPINHistoryFn: func(ctx context.Context, userID string, limit int) ([]*gorm.PINData, error) {
+			return []*gorm.PINData{pinData}, nil
+		},
+		MockCreateOpenIDConnectSessionFn: func(ctx context.Context, session *gorm.OpenIDConnectSession) error {
+			return nil
+		},
+		MockGetOpenIDConnectSessionFn: func(ctx context.Context, code string) (*gorm.OpenIDConnectSession, error) {
+			return &gorm.OpenIDConnectSession{ID: gofakeit.UUID()}, nil
+		},
+		MockCreateOauthSigningKeyFn: func(ctx context.Context, key *gorm.OauthSigningKey) error {
+			return nil
+		},
+		MockListOauthSigningKeysFn: func(ctx context.Context, activeAt time.Time) ([]*gorm.OauthSigningKey, error) {
+			return []*gorm.OauthSigningKey{
+				{
+					ID:         UUID,
+					Active:     true,
+					KeyID:      UUID,
+					Algorithm:  "RS256",
+					PrivateKey: gofakeit.HipsterSentence(10),
+					PublicKey:  gofakeit.HipsterSentence(10),
+					RotateAt:   time.Now().Add(time.Hour),
+					ExpiresAt:  time.Now().Add(2 * time.Hour),
+				},
+			}, nil
+		},
+		MockCreatePKCEFn: func(ctx context.Context, pkce *gorm.PKCE) error {
+			return nil
+		},
+		MockGetPKCEFn: func(ctx context.Context, signature string) (*gorm.PKCE, error) {
+			return &gorm.PKCE{ID: UUID}, nil
+		},
+		MockDeletePKCEFn: func(ctx context.Context, signature string) error {
+			return nil
+		},
+		MockUpdateOauthClientFn: func(ctx context.Context, client *gorm.OauthClient, updateData map[string]interface{}) error {
+			return nil
+		},
+		MockListRevokedSessionIDsFn: func(ctx context.Context, since time.Time) ([]string, error) {
+			return []string{UUID}, nil
+		},
+		MockListRevokedAccessTokenSignaturesFn: func(ctx context.Context, since time.Time) ([]string, error) {
+			return []string{gofakeit.UUID()}, nil
+		},
+		MockResetSecurityQuestionResponsesFn: func(ctx context.Context, userID string) error {
+			return nil
+		},
+		MockRecordLoginEventFn: func(ctx context.Context, event *gorm.LoginEvent, device *gorm.UserDevice) error {
+			return nil
+		},
+		MockListUserDevicesFn: func(ctx context.Context, userID string) ([]*gorm.UserDevice, error) {
+			return []*gorm.UserDevice{
+				{
+					ID:          UUID,
+					Active:      true,
+					UserID:      userID,
+					Fingerprint: gofakeit.UUID(),
+					DeviceName:  gofakeit.Name(),
+					LastCountry: "KE",
+					LastLoginAt: time.Now(),
+				},
+			}, nil
+		},
+		MockListUserLoginCountriesFn: func(ctx context.Context, userID string) ([]string, error) {
+			return []string{"KE"}, nil
+		},
+		MockCountUsersLoggedInFromIPAddressFn: func(ctx context.Context, ipAddress string, since time.Time) (int, error) {
+			return 1, nil
+		},
+		MockListDuplicateClientCandidatesFn: func(ctx context.Context, clientID string) ([]*gorm.DuplicateClientCandidate, error) {
+			return []*gorm.DuplicateClientCandidate{
+				{
+					ClientID:           UUID,
+					MatchesCCCNumber:   true,
+					MatchesPhone:       true,
+					MatchesName:        true,
+					MatchesDateOfBirth: true,
+				},
+			}, nil
+		},
+		MockMergeClientsFn: func(ctx context.Context, survivingClientID string, duplicateClientID string) (map[string]int64, error) {
+			return map[string]int64{
+				"clients_healthdiaryentry":             1,
+				"clients_servicerequest":               1,
+				"appointments_appointment":             1,
+				"questionnaires_screeningtoolresponse": 1,
+				"service_booking":                      1,
+				"caregivers_caregiver_client":          1,
+				"clients_client_facilities":            1,
+			}, nil
+		},
+		MockListUserContactsFn: func(ctx context.Context, userID string) ([]*gorm.Contact, error) {
+			return []*gorm.Contact{
+				{
+					ID:      UUID,
+					Type:    "PHONE",
+					Value:   gofakeit.Phone(),
+					Active:  true,
+					OptedIn: true,
+					UserID:  &userID,
+				},
+			}, nil
+		},
+		MockListClientScreeningToolResponsesFn: func(ctx context.Context, clientID string) ([]*gorm.ScreeningToolResponse, error) {
+			return []*gorm.ScreeningToolResponse{
+				{
+					ID:              UUID,
+					Active:          true,
+					ScreeningToolID: UUID,
+					FacilityID:      UUID,
+					ClientID:        clientID,
+					AggregateScore:  2,
+					ProgramID:       UUID,
+				},
+			}, nil
+		},
+		MockListUserMetricsFn: func(ctx context.Context, userID string) ([]*gorm.Metric, error) {
+			return []*gorm.Metric{
+				{
+					ID:        1,
+					Active:    true,
+					Type:      enums.MetricTypeEngagement,
+					Payload:   `{"event": "content_interaction"}`,
+					Timestamp: time.Now(),
+					UserID:    &userID,
+				},
+			}, nil
+		},
+		MockListUserFeedbackFn: func(ctx context.Context, userID string) ([]*gorm.Feedback, error) {
+			return []*gorm.Feedback{
+				{
+					ID:                UUID,
+					Active:            true,
+					FeedbackType:      enums.GeneralFeedbackType.String(),
+					SatisfactionLevel: 4,
+					Feedback:          gofakeit.Sentence(5),
+					UserID:            userID,
+				},
+			}, nil
+		},
+		MockMarkClientForErasureFn: func(ctx context.Context, clientID string, userID *string, requestedAt time.Time) error {
+			return nil
+		},
+		MockListClientsDueForErasureFn: func(ctx context.Context, now time.Time) ([]*gorm.Client, error) {
+			erasureRequestedAt := now.AddDate(0, 0, -30)
+			return []*gorm.Client{
+				{
+					ID:                 &UUID,
+					UserID:             &UUID,
+					OrganisationID:     UUID,
+					ProgramID:          UUID,
+					ErasureRequestedAt: &erasureRequestedAt,
+				},
+			}, nil
+		},
+		MockListExpiredCaregiverDelegationsFn: func(ctx context.Context, now time.Time) ([]*gorm.CaregiverClient, error) {
+			endsAt := now.AddDate(0, 0, -1)
+			return []*gorm.CaregiverClient{
+				{
+					CaregiverID:      uuid.NewString(),
+					ClientID:         uuid.NewString(),
+					RelationshipType: enums.CaregiverTypeMother,
+					CaregiverConsent: enums.ConsentStateAccepted,
+					ClientConsent:    enums.ConsentStateAccepted,
+					AssignedBy:       uuid.NewString(),
+					EndsAt:           &endsAt,
+					Abilities:        []string{enums.CaregiverAbilityViewAppointments.String()},
+				},
+			}, nil
+		},
+		MockEndClientCaregiverDelegationsFn: func(ctx context.Context, clientID string, endsAt time.Time) (int64, error) {
+			return 1, nil
+		},
+		MockCreatePhoneChangeRequestFn: func(ctx context.Context, request *gorm.PhoneChangeRequest) error {
+			return nil
+		},
+		MockGetPendingPhoneChangeRequestFn: func(ctx context.Context, userID string, flavour feedlib.Flavour) (*gorm.PhoneChangeRequest, error) {
+			return &gorm.PhoneChangeRequest{
+				ID:             UUID,
+				Active:         true,
+				UserID:         userID,
+				Flavour:        flavour,
+				OldPhoneNumber: phoneContact,
+				NewPhoneNumber: gofakeit.Phone(),
+				RequestedBy:    userID,
+				ExpiresAt:      time.Now().Add(time.Minute * 10),
+			}, nil
+		},
+		MockConfirmPhoneChangeFn: func(ctx context.Context, request *gorm.PhoneChangeRequest, confirmedAt time.Time) error {
+			return nil
+		},
+		MockGetStaffInProgressServiceRequestsFn: func(ctx context.Context, staffIDs []string) ([]*gorm.ClientServiceRequest, error) {
+			inProgressAt := time.Now()
+			return []*gorm.ClientServiceRequest{
+				{
+					ID:             &UUID,
+					Active:         true,
+					RequestType:    enums.ServiceRequestTypeRedFlag.String(),
+					Status:         enums.ServiceRequestStatusInProgress.String(),
+					InProgressAt:   &inProgressAt,
+					InProgressByID: &staffIDs[0],
+					ProgramID:      UUID,
+					OrganisationID: UUID,
+					FacilityID:     UUID,
+					ClientID:       UUID,
+				},
+			}, nil
+		},
+		MockListFacilityAdminsFn: func(ctx context.Context, facilityID, programID string) ([]*gorm.StaffProfile, error) {
+			return []*gorm.StaffProfile{
+				{
+					ID:                &UUID,
+					Active:            true,
+					UserID:            UUID,
+					ProgramID:         programID,
+					OrganisationID:    UUID,
+					DefaultFacilityID: facilityID,
+				},
+			}, nil
+		},
+		MockListOrganisationAdminsFn: func(ctx context.Context, organisationID string) ([]*gorm.StaffProfile, error) {
+			return []*gorm.StaffProfile{
+				{
+					ID:                  &UUID,
+					Active:              true,
+					UserID:              UUID,
+					ProgramID:           UUID,
+					OrganisationID:      organisationID,
+					IsOrganisationAdmin: true,
+				},
+			}, nil
+		},
+		MockOffboardStaffFn: func(ctx context.Context, userID string, staffIDs []string, serviceRequests map[string]*string, deactivateUser bool, offboardedAt time.Time) error {
+			return nil
+		},
+		MockTransferClientFn: func(ctx context.Context, transfer *gorm.ClientTransfer, identifier *gorm.Identifier, client *gorm.Client) error {
+			transfer.ID = UUID
+			transfer.NewClientID = transfer.ClientID
+			if client != nil {
+				transfer.NewClientID = UUID
+			}
+			return nil
+		},
+		MockListClientTransfersFn: func(ctx context.Context, userID string) ([]*gorm.ClientTransfer, error) {
+			return []*gorm.ClientTransfer{
+				{
+					ID:                 UUID,
+					Active:             true,
+					UserID:             userID,
+					ClientID:           UUID,
+					NewClientID:        UUID,
+					FromFacilityID:     UUID,
+					ToFacilityID:       UUID,
+					FromProgramID:      UUID,
+					ToProgramID:        UUID,
+					FromOrganisationID: UUID,
+					ToOrganisationID:   UUID,
+					Reason:             "Relocated",
+					CarriedHistory:     []string{enums.ClientTransferHistoryHealthDiary.String()},
+					TransferredBy:      UUID,
+					TransferredAt:      time.Now(),
+				},
+			}, nil
+		},
+		MockSetServiceRequestSLAFn: func(ctx context.Context, sla *gorm.ServiceRequestSLA) error {
+			sla.ID = UUID
+			return nil
+		},
+		MockListServiceRequestSLAsFn: func(ctx context.Context, programID *string) ([]*gorm.ServiceRequestSLA, error) {
+			return []*gorm.ServiceRequestSLA{
+				{
+					ID:              UUID,
+					Active:          true,
+					ProgramID:       UUID,
+					OrganisationID:  UUID,
+					RequestType:     enums.ServiceRequestTypeScreeningToolsRedFlag.String(),
+					ResolutionHours: 24,
+					EscalationHours: 12,
+				},
+			}, nil
+		},
+		MockGetServiceRequestsDueForEscalationFn: func(ctx context.Context, requestTypes []string) ([]*gorm.ClientServiceRequest, error) {
+			return []*gorm.ClientServiceRequest{
+				{
+					ID:             &UUID,
+					Active:         true,
+					RequestType:    enums.ServiceRequestTypeRedFlag.String(),
+					Request:        "Client is feeling very sad",
+					Status:         enums.ServiceRequestStatusPending.String(),
+					Meta:           "{}",
+					ProgramID:      UUID,
+					OrganisationID: UUID,
+					FacilityID:     UUID,
+					ClientID:       UUID,
+				},
+			}, nil
+		},
+		MockListServiceRequestEscalationsFn: func(ctx context.Context, serviceRequestID string) ([]*gorm.ServiceRequestEscalation, error) {
+			return []*gorm.ServiceRequestEscalation{
+				{
+					ID:               UUID,
+					Active:           true,
+					ServiceRequestID: serviceRequestID,
+					Level:            enums.ServiceRequestEscalationLevelFacilityStaff.String(),
+					Recipients:       []string{},
+					EscalatedAt:      time.Now(),
+					ProgramID:        UUID,
+					OrganisationID:   UUID,
+				},
+			}, nil
+		},
+		MockEscalateServiceRequestFn: func(ctx context.Context, escalation *gorm.ServiceRequestEscalation) error {
+			return nil
+		},
 	}
 }
 
@@ -1835,9 +2445,9 @@ func (gm *GormMock) GetOrganisation(ctx context.Context, id string) (*gorm.Organ
 	return gm.MockGetOrganisationFn(ctx, id)
 }
 
-// DeleteClientProfile mocks the implementation of deleting a client user
-func (gm *GormMock) DeleteClientProfile(ctx context.Context, clientID string, userID *string) error {
-	return gm.MockDeleteClientProfileFn(ctx, clientID, userID)
+// AnonymizeClientProfile mocks the implementation of anonymizing a client
+func (gm *GormMock) AnonymizeClientProfile(ctx context.Context, clientID string, userID *string) error {
+	return gm.MockAnonymizeClientProfileFn(ctx, clientID, userID)
 }
 
 // RetrieveFacility mocks the implementation of `gorm's` RetrieveFacility method.
@@ -2880,3 +3490,373 @@ func (gm GormMock) UpdateBooking(ctx context.Context, booking *gorm.Booking, upd
 func (gm GormMock) ListBookings(ctx context.Context, clientID string, bookingState enums.BookingState, pagination *domain.Pagination) ([]*gorm.Booking, *domain.Pagination, error) {
 	return gm.MockListBookingsFn(ctx, clientID, bookingState, pagination)
 }
+
+// GetUserPermissions mocks the implementation of retrieving a user's permissions
+func (gm *GormMock) GetUserPermissions(ctx context.Context, userID string, programID string) ([]*gorm.AuthorityPermission, error) {
+	return gm.MockGetUserPermissionsFn(ctx, userID, programID)
+}
+
+// CreateAuthorityRole mocks the implementation of creating a role
+func (gm *GormMock) CreateAuthorityRole(ctx context.Context, role *gorm.AuthorityRole, permissionIDs []string) error {
+	return gm.MockCreateAuthorityRoleFn(ctx, role, permissionIDs)
+}
+
+// AddPermissionsToRole mocks the implementation of granting permissions to a role
+func (gm *GormMock) AddPermissionsToRole(ctx context.Context, roleID string, permissionIDs []string) error {
+	return gm.MockAddPermissionsToRoleFn(ctx, roleID, permissionIDs)
+}
+
+// AssignRoles mocks the implementation of assigning roles to a profile
+func (gm *GormMock) AssignRoles(ctx context.Context, userType enums.UsersType, profileID string, roleIDs []string) error {
+	return gm.MockAssignRolesFn(ctx, userType, profileID, roleIDs)
+}
+
+// SeedAuthorityRoles mocks the implementation of seeding permissions and roles
+func (gm *GormMock) SeedAuthorityRoles(ctx context.Context, permissions []*gorm.AuthorityPermission, roles []*gorm.AuthorityRole, roleScopes map[string][]string) error {
+	return gm.MockSeedAuthorityRolesFn(ctx, permissions, roles, roleScopes)
+}
+
+// AssignDefaultRoles mocks the implementation of assigning the default roles to profiles without a role
+func (gm *GormMock) AssignDefaultRoles(ctx context.Context) error {
+	return gm.MockAssignDefaultRolesFn(ctx)
+}
+
+// RemovePermissionsFromRole mocks the implementation of revoking permissions from a role
+func (gm *GormMock) RemovePermissionsFromRole(ctx context.Context, roleID string, permissionIDs []string) error {
+	return gm.MockRemovePermissionsFromRoleFn(ctx, roleID, permissionIDs)
+}
+
+// RevokeRoles mocks the implementation of revoking roles from a profile
+func (gm *GormMock) RevokeRoles(ctx context.Context, userType enums.UsersType, profileID string, roleIDs []string) error {
+	return gm.MockRevokeRolesFn(ctx, userType, profileID, roleIDs)
+}
+
+// GetAuthorityRoleByID mocks the implementation of retrieving a role
+func (gm *GormMock) GetAuthorityRoleByID(ctx context.Context, roleID string) (*gorm.AuthorityRole, error) {
+	return gm.MockGetAuthorityRoleByIDFn(ctx, roleID)
+}
+
+// GetAuthorityRoleByName mocks the implementation of retrieving a program's role using its name
+func (gm *GormMock) GetAuthorityRoleByName(ctx context.Context, programID string, name string) (*gorm.AuthorityRole, error) {
+	return gm.MockGetAuthorityRoleByNameFn(ctx, programID, name)
+}
+
+// ListAuthorityRoles mocks the implementation of listing the roles in a program
+func (gm *GormMock) ListAuthorityRoles(ctx context.Context, programID string) ([]*gorm.AuthorityRole, error) {
+	return gm.MockListAuthorityRolesFn(ctx, programID)
+}
+
+// ListAuthorityPermissions mocks the implementation of listing permissions
+func (gm *GormMock) ListAuthorityPermissions(ctx context.Context) ([]*gorm.AuthorityPermission, error) {
+	return gm.MockListAuthorityPermissionsFn(ctx)
+}
+
+// GetRolePermissions mocks the implementation of retrieving a role's permissions
+func (gm *GormMock) GetRolePermissions(ctx context.Context, roleID string) ([]*gorm.AuthorityPermission, error) {
+	return gm.MockGetRolePermissionsFn(ctx, roleID)
+}
+
+// GetProfileRoles mocks the implementation of retrieving a profile's roles
+func (gm *GormMock) GetProfileRoles(ctx context.Context, userType enums.UsersType, profileID string) ([]*gorm.AuthorityRole, error) {
+	return gm.MockGetProfileRolesFn(ctx, userType, profileID)
+}
+
+// CreateAuditLog mocks the implementation of creating an audit log
+func (gm *GormMock) CreateAuditLog(ctx context.Context, auditLog *gorm.AuditLog, sign func(auditLog *gorm.AuditLog) error) error {
+	return gm.MockCreateAuditLogFn(ctx, auditLog, sign)
+}
+
+// CheckIfStaffHasFacilityAccess mocks the implementation of checking whether a staff is assigned to a facility
+func (gm *GormMock) CheckIfStaffHasFacilityAccess(ctx context.Context, userID, programID, facilityID string) (bool, error) {
+	return gm.MockCheckIfStaffHasFacilityAccessFn(ctx, userID, programID, facilityID)
+}
+
+// ListAuditLogs mocks the implementation of listing audit logs
+func (gm *GormMock) ListAuditLogs(ctx context.Context, organisationID string, filter *dto.AuditLogFilterInput, pagination *domain.Pagination) ([]*gorm.AuditLog, *domain.Pagination, error) {
+	return gm.MockListAuditLogsFn(ctx, organisationID, filter, pagination)
+}
+
+// ListAuditLogChain mocks the implementation of listing an organisation's chained audit logs
+func (gm *GormMock) ListAuditLogChain(ctx context.Context, organisationID string, afterSequence int64, limit int) ([]*gorm.AuditLog, error) {
+	return gm.MockListAuditLogChainFn(ctx, organisationID, afterSequence, limit)
+}
+
+// CountUnchainedAuditLogs mocks the implementation of counting audit logs that are not linked to the chain
+func (gm *GormMock) CountUnchainedAuditLogs(ctx context.Context, organisationID string, since time.Time) (int64, error) {
+	return gm.MockCountUnchainedAuditLogsFn(ctx, organisationID, since)
+}
+
+// SaveUserTOTP mocks the implementation of saving a user's TOTP enrollment
+func (gm *GormMock) SaveUserTOTP(ctx context.Context, userTOTP *gorm.UserTOTP) error {
+	return gm.MockSaveUserTOTPFn(ctx, userTOTP)
+}
+
+// SaveUserRecoveryCodes mocks the implementation of replacing a user's recovery codes
+func (gm *GormMock) SaveUserRecoveryCodes(ctx context.Context, userID string, recoveryCodes []*gorm.UserRecoveryCode) error {
+	return gm.MockSaveUserRecoveryCodesFn(ctx, userID, recoveryCodes)
+}
+
+// GetUserTOTP mocks the implementation of retrieving a user's TOTP enrollment
+func (gm *GormMock) GetUserTOTP(ctx context.Context, userID string) (*gorm.UserTOTP, error) {
+	return gm.MockGetUserTOTPFn(ctx, userID)
+}
+
+// CheckIfUserHasTOTP mocks the implementation of checking whether a user has a confirmed TOTP enrollment
+func (gm *GormMock) CheckIfUserHasTOTP(ctx context.Context, userID string) (bool, error) {
+	return gm.MockCheckIfUserHasTOTPFn(ctx, userID)
+}
+
+// ListUserRecoveryCodes mocks the implementation of listing a user's unused recovery codes
+func (gm *GormMock) ListUserRecoveryCodes(ctx context.Context, userID string) ([]*gorm.UserRecoveryCode, error) {
+	return gm.MockListUserRecoveryCodesFn(ctx, userID)
+}
+
+// CheckIfStaffTOTPIsEnforced mocks the implementation of checking whether a staff's organisation requires a TOTP authenticator
+func (gm *GormMock) CheckIfStaffTOTPIsEnforced(ctx context.Context, userID string) (bool, error) {
+	return gm.MockCheckIfStaffTOTPIsEnforcedFn(ctx, userID)
+}
+
+// UpdateUserTOTP mocks the implementation of updating a user's TOTP enrollment
+func (gm *GormMock) UpdateUserTOTP(ctx context.Context, userTOTP *gorm.UserTOTP, updateData map[string]interface{}) error {
+	return gm.MockUpdateUserTOTPFn(ctx, userTOTP, updateData)
+}
+
+// UseUserRecoveryCode mocks the implementation of marking a recovery code as used
+func (gm *GormMock) UseUserRecoveryCode(ctx context.Context, recoveryCode *gorm.UserRecoveryCode) error {
+	return gm.MockUseUserRecoveryCodeFn(ctx, recoveryCode)
+}
+
+// UpdateOrganisation mocks the implementation of updating an organisation
+func (gm *GormMock) UpdateOrganisation(ctx context.Context, organisation *gorm.Organisation, updateData map[string]interface{}) error {
+	return gm.MockUpdateOrganisationFn(ctx, organisation, updateData)
+}
+
+// DeleteUserTOTP mocks the implementation of removing a user's TOTP enrollment
+func (gm *GormMock) DeleteUserTOTP(ctx context.Context, userID string) error {
+	return gm.MockDeleteUserTOTPFn(ctx, userID)
+}
+
+// ConsumeRateLimit mocks the implementation of checking a request against rate limit rules
+func (gm *GormMock) ConsumeRateLimit(ctx context.Context, action string, rules []*domain.RateLimitRule, at time.Time) (*domain.RateLimitDecision, error) {
+	return gm.MockConsumeRateLimitFn(ctx, action, rules, at)
+}
+
+// UpdateOTPDeliveryStatus mocks the implementation of updating an OTP delivery attempt's status
+func (gm *GormMock) UpdateOTPDeliveryStatus(ctx context.Context, provider string, providerMessageID string, status string, at time.Time) (bool, error) {
+	return gm.MockUpdateOTPDeliveryStatusFn(ctx, provider, providerMessageID, status, at)
+}
+
+// ListUserSessions mocks the implementation of ListUserSessions method
+func (gm *GormMock) ListUserSessions(ctx context.Context, userID string, activeSince time.Time) ([]*gorm.Session, error) {
+	return gm.MockListUserSessionsFn(ctx, userID, activeSince)
+}
+
+// UpdateSession mocks the implementation of UpdateSession method
+func (gm *GormMock) UpdateSession(ctx context.Context, session *gorm.Session, updateData map[string]interface{}) error {
+	return gm.MockUpdateSessionFn(ctx, session, updateData)
+}
+
+// RevokeSessions mocks the implementation of RevokeSessions method
+func (gm *GormMock) RevokeSessions(ctx context.Context, sessionIDs []string, revokedAt time.Time) error {
+	return gm.MockRevokeSessionsFn(ctx, sessionIDs, revokedAt)
+}
+
+// GetUserPINHistory mocks the implementation of getting a user's most recent PINs
+func (gm *GormMock) GetUserPINHistory(ctx context.Context, userID string, limit int) ([]*gorm.PINData, error) {
+	return gm.MockGetUserPINHistoryFn(ctx, userID, limit)
+}
+
+// CreateOpenIDConnectSession mocks the implementation of creating the OpenID Connect session of an authorization code
+func (gm *GormMock) CreateOpenIDConnectSession(ctx context.Context, session *gorm.OpenIDConnectSession) error {
+	return gm.MockCreateOpenIDConnectSessionFn(ctx, session)
+}
+
+// GetOpenIDConnectSession mocks the implementation of retrieving the OpenID Connect session of an authorization code
+func (gm *GormMock) GetOpenIDConnectSession(ctx context.Context, code string) (*gorm.OpenIDConnectSession, error) {
+	return gm.MockGetOpenIDConnectSessionFn(ctx, code)
+}
+
+// CreateOauthSigningKey mocks the implementation of creating a token signing key
+func (gm *GormMock) CreateOauthSigningKey(ctx context.Context, key *gorm.OauthSigningKey) error {
+	return gm.MockCreateOauthSigningKeyFn(ctx, key)
+}
+
+// ListOauthSigningKeys mocks the implementation of listing the token signing keys that have not expired
+func (gm *GormMock) ListOauthSigningKeys(ctx context.Context, activeAt time.Time) ([]*gorm.OauthSigningKey, error) {
+	return gm.MockListOauthSigningKeysFn(ctx, activeAt)
+}
+
+// CreatePKCE mocks the implementation of creating the PKCE session of an authorization code
+func (gm *GormMock) CreatePKCE(ctx context.Context, pkce *gorm.PKCE) error {
+	return gm.MockCreatePKCEFn(ctx, pkce)
+}
+
+// GetPKCE mocks the implementation of retrieving the PKCE session of an authorization code
+func (gm *GormMock) GetPKCE(ctx context.Context, signature string) (*gorm.PKCE, error) {
+	return gm.MockGetPKCEFn(ctx, signature)
+}
+
+// DeletePKCE mocks the implementation of deleting the PKCE session of an authorization code
+func (gm *GormMock) DeletePKCE(ctx context.Context, signature string) error {
+	return gm.MockDeletePKCEFn(ctx, signature)
+}
+
+// UpdateOauthClient mocks the implementation of updating an oauth client
+func (gm *GormMock) UpdateOauthClient(ctx context.Context, client *gorm.OauthClient, updateData map[string]interface{}) error {
+	return gm.MockUpdateOauthClientFn(ctx, client, updateData)
+}
+
+// ListRevokedSessionIDs mocks the implementation of listing the sessions that were revoked since the given time
+func (gm *GormMock) ListRevokedSessionIDs(ctx context.Context, since time.Time) ([]string, error) {
+	return gm.MockListRevokedSessionIDsFn(ctx, since)
+}
+
+// ListRevokedAccessTokenSignatures mocks the implementation of listing the access tokens that were revoked since the given time
+func (gm *GormMock) ListRevokedAccessTokenSignatures(ctx context.Context, since time.Time) ([]string, error) {
+	return gm.MockListRevokedAccessTokenSignaturesFn(ctx, since)
+}
+
+// ResetSecurityQuestionResponses mocks the implementation of clearing a user's security question responses
+func (gm *GormMock) ResetSecurityQuestionResponses(ctx context.Context, userID string) error {
+	return gm.MockResetSecurityQuestionResponsesFn(ctx, userID)
+}
+
+// RecordLoginEvent mocks the implementation of recording a login and the device that it was made from
+func (gm *GormMock) RecordLoginEvent(ctx context.Context, event *gorm.LoginEvent, device *gorm.UserDevice) error {
+	return gm.MockRecordLoginEventFn(ctx, event, device)
+}
+
+// ListUserDevices mocks the implementation of listing the devices that a user has logged in from
+func (gm *GormMock) ListUserDevices(ctx context.Context, userID string) ([]*gorm.UserDevice, error) {
+	return gm.MockListUserDevicesFn(ctx, userID)
+}
+
+// ListUserLoginCountries mocks the implementation of listing the countries that a user has logged in from
+func (gm *GormMock) ListUserLoginCountries(ctx context.Context, userID string) ([]string, error) {
+	return gm.MockListUserLoginCountriesFn(ctx, userID)
+}
+
+// CountUsersLoggedInFromIPAddress mocks the implementation of counting the users that have logged in from an IP address
+func (gm *GormMock) CountUsersLoggedInFromIPAddress(ctx context.Context, ipAddress string, since time.Time) (int, error) {
+	return gm.MockCountUsersLoggedInFromIPAddressFn(ctx, ipAddress, since)
+}
+
+// ListDuplicateClientCandidates mocks the implementation of listing the clients that may be duplicates of a client
+func (gm *GormMock) ListDuplicateClientCandidates(ctx context.Context, clientID string) ([]*gorm.DuplicateClientCandidate, error) {
+	return gm.MockListDuplicateClientCandidatesFn(ctx, clientID)
+}
+
+// MergeClients mocks the implementation of merging a duplicate client into a surviving client
+func (gm *GormMock) MergeClients(ctx context.Context, survivingClientID string, duplicateClientID string) (map[string]int64, error) {
+	return gm.MockMergeClientsFn(ctx, survivingClientID, duplicateClientID)
+}
+
+// ListUserContacts mocks the implementation of listing a user's contacts
+func (gm *GormMock) ListUserContacts(ctx context.Context, userID string) ([]*gorm.Contact, error) {
+	return gm.MockListUserContactsFn(ctx, userID)
+}
+
+// ListClientScreeningToolResponses mocks the implementation of listing a client's screening tool responses
+func (gm *GormMock) ListClientScreeningToolResponses(ctx context.Context, clientID string) ([]*gorm.ScreeningToolResponse, error) {
+	return gm.MockListClientScreeningToolResponsesFn(ctx, clientID)
+}
+
+// ListUserMetrics mocks the implementation of listing the metrics recorded for a user
+func (gm *GormMock) ListUserMetrics(ctx context.Context, userID string) ([]*gorm.Metric, error) {
+	return gm.MockListUserMetricsFn(ctx, userID)
+}
+
+// ListUserFeedback mocks the implementation of listing the feedback a user has sent
+func (gm *GormMock) ListUserFeedback(ctx context.Context, userID string) ([]*gorm.Feedback, error) {
+	return gm.MockListUserFeedbackFn(ctx, userID)
+}
+
+// MarkClientForErasure mocks the implementation of marking a client for erasure
+func (gm *GormMock) MarkClientForErasure(ctx context.Context, clientID string, userID *string, requestedAt time.Time) error {
+	return gm.MockMarkClientForErasureFn(ctx, clientID, userID, requestedAt)
+}
+
+// ListClientsDueForErasure mocks the implementation of listing the clients whose data is due for erasure
+func (gm *GormMock) ListClientsDueForErasure(ctx context.Context, now time.Time) ([]*gorm.Client, error) {
+	return gm.MockListClientsDueForErasureFn(ctx, now)
+}
+
+// ListExpiredCaregiverDelegations mocks the implementation of listing the caregiver delegations whose end date has passed
+func (gm *GormMock) ListExpiredCaregiverDelegations(ctx context.Context, now time.Time) ([]*gorm.CaregiverClient, error) {
+	return gm.MockListExpiredCaregiverDelegationsFn(ctx, now)
+}
+
+// EndClientCaregiverDelegations mocks the implementation of setting the date that a client's caregiver delegations end
+func (gm *GormMock) EndClientCaregiverDelegations(ctx context.Context, clientID string, endsAt time.Time) (int64, error) {
+	return gm.MockEndClientCaregiverDelegationsFn(ctx, clientID, endsAt)
+}
+
+// CreatePhoneChangeRequest mocks the implementation of saving a request to change a user's phone number
+func (gm *GormMock) CreatePhoneChangeRequest(ctx context.Context, request *gorm.PhoneChangeRequest) error {
+	return gm.MockCreatePhoneChangeRequestFn(ctx, request)
+}
+
+// GetPendingPhoneChangeRequest mocks the implementation of getting a user's phone change request that has not been confirmed
+func (gm *GormMock) GetPendingPhoneChangeRequest(ctx context.Context, userID string, flavour feedlib.Flavour) (*gorm.PhoneChangeRequest, error) {
+	return gm.MockGetPendingPhoneChangeRequestFn(ctx, userID, flavour)
+}
+
+// ConfirmPhoneChange mocks the implementation of replacing a user's phone number with the one in a phone change request
+func (gm *GormMock) ConfirmPhoneChange(ctx context.Context, request *gorm.PhoneChangeRequest, confirmedAt time.Time) error {
+	return gm.MockConfirmPhoneChangeFn(ctx, request, confirmedAt)
+}
+
+// GetStaffInProgressServiceRequests mocks the implementation of getting the service requests that staff are working on
+func (gm *GormMock) GetStaffInProgressServiceRequests(ctx context.Context, staffIDs []string) ([]*gorm.ClientServiceRequest, error) {
+	return gm.MockGetStaffInProgressServiceRequestsFn(ctx, staffIDs)
+}
+
+// ListOrganisationAdmins mocks the implementation of listing the organisation admins of an organisation
+func (gm *GormMock) ListOrganisationAdmins(ctx context.Context, organisationID string) ([]*gorm.StaffProfile, error) {
+	return gm.MockListOrganisationAdminsFn(ctx, organisationID)
+}
+
+// ListFacilityAdmins mocks the implementation of listing the admins of a facility in a program
+func (gm *GormMock) ListFacilityAdmins(ctx context.Context, facilityID, programID string) ([]*gorm.StaffProfile, error) {
+	return gm.MockListFacilityAdminsFn(ctx, facilityID, programID)
+}
+
+// OffboardStaff mocks the implementation of removing a staff who has left an organisation
+func (gm *GormMock) OffboardStaff(ctx context.Context, userID string, staffIDs []string, serviceRequests map[string]*string, deactivateUser bool, offboardedAt time.Time) error {
+	return gm.MockOffboardStaffFn(ctx, userID, staffIDs, serviceRequests, deactivateUser, offboardedAt)
+}
+
+// TransferClient mocks the implementation of moving a client to another facility or program
+func (gm *GormMock) TransferClient(ctx context.Context, transfer *gorm.ClientTransfer, identifier *gorm.Identifier, client *gorm.Client) error {
+	return gm.MockTransferClientFn(ctx, transfer, identifier, client)
+}
+
+// ListClientTransfers mocks the implementation of listing a client's transfers
+func (gm *GormMock) ListClientTransfers(ctx context.Context, userID string) ([]*gorm.ClientTransfer, error) {
+	return gm.MockListClientTransfersFn(ctx, userID)
+}
+
+// SetServiceRequestSLA mocks the implementation of setting a program's SLA for a type of service request
+func (gm *GormMock) SetServiceRequestSLA(ctx context.Context, sla *gorm.ServiceRequestSLA) error {
+	return gm.MockSetServiceRequestSLAFn(ctx, sla)
+}
+
+// ListServiceRequestSLAs mocks the implementation of listing service request SLAs
+func (gm *GormMock) ListServiceRequestSLAs(ctx context.Context, programID *string) ([]*gorm.ServiceRequestSLA, error) {
+	return gm.MockListServiceRequestSLAsFn(ctx, programID)
+}
+
+// GetServiceRequestsDueForEscalation mocks the implementation of getting the pending service requests that can be escalated
+func (gm *GormMock) GetServiceRequestsDueForEscalation(ctx context.Context, requestTypes []string) ([]*gorm.ClientServiceRequest, error) {
+	return gm.MockGetServiceRequestsDueForEscalationFn(ctx, requestTypes)
+}
+
+// ListServiceRequestEscalations mocks the implementation of listing a service request's escalations
+func (gm *GormMock) ListServiceRequestEscalations(ctx context.Context, serviceRequestID string) ([]*gorm.ServiceRequestEscalation, error) {
+	return gm.MockListServiceRequestEscalationsFn(ctx, serviceRequestID)
+}
+
+// EscalateServiceRequest mocks the implementation of recording a service request being escalated
+func (gm *GormMock) EscalateServiceRequest(ctx context.Context, escalation *gorm.ServiceRequestEscalation) error {
+	return gm.MockEscalateServiceRequestFn(ctx, escalation)
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/authorization"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	"github.com/savannahghi/serverutils"
 )
@@ -41,6 +42,7 @@ type Query interface {
 	GetUserProfileByUsername(ctx context.Context, username string) (*User, error)
 	GetUserProfileByPhoneNumber(ctx context.Context, phoneNumber string) (*User, error)
 	GetUserPINByUserID(ctx context.Context, userID string) (*PINData, error)
+	GetUserPINHistory(ctx context.Context, userID string, limit int) ([]*PINData, error)
 	GetUserProfileByUserID(ctx context.Context, userID *string) (*User, error)
 	GetCurrentTerms(ctx context.Context) (*TermsOfService, error)
 	GetSecurityQuestions(ctx context.Context, flavour feedlib.Flavour) ([]*SecurityQuestion, error)
@@ -141,8 +143,14 @@ type Query interface {
 	GetOauthClient(ctx context.Context, id string) (*OauthClient, error)
 	GetValidClientJWT(ctx context.Context, jti string) (*OauthClientJWT, error)
 	GetAuthorizationCode(ctx context.Context, code string) (*AuthorizationCode, error)
+	GetOpenIDConnectSession(ctx context.Context, code string) (*OpenIDConnectSession, error)
+	GetPKCE(ctx context.Context, signature string) (*PKCE, error)
+	ListOauthSigningKeys(ctx context.Context, activeAt time.Time) ([]*OauthSigningKey, error)
+	ListRevokedSessionIDs(ctx context.Context, since time.Time) ([]string, error)
+	ListRevokedAccessTokenSignatures(ctx context.Context, since time.Time) ([]string, error)
 	GetAccessToken(ctx context.Context, token AccessToken) (*AccessToken, error)
 	GetRefreshToken(ctx context.Context, token RefreshToken) (*RefreshToken, error)
+	ListUserSessions(ctx context.Context, userID string, activeSince time.Time) ([]*Session, error)
 	CheckIfClientHasPendingSurveyServiceRequest(ctx context.Context, clientID string, projectID int, FormID string) (bool, error)
 	GetUserProfileByPushToken(ctx context.Context, pushToken string) (*User, error)
 	CheckStaffExistsInProgram(ctx context.Context, userID, programID string) (bool, error)
@@ -152,6 +160,39 @@ type Query interface {
 	GetUserClientProfiles(ctx context.Context, userID string) ([]*Client, error)
 	GetUserStaffProfiles(ctx context.Context, userID string) ([]*StaffProfile, error)
 	ListBookings(ctx context.Context, clientID string, bookingState enums.BookingState, pagination *domain.Pagination) ([]*Booking, *domain.Pagination, error)
+	GetUserPermissions(ctx context.Context, userID string, programID string) ([]*AuthorityPermission, error)
+	GetAuthorityRoleByID(ctx context.Context, roleID string) (*AuthorityRole, error)
+	GetAuthorityRoleByName(ctx context.Context, programID string, name string) (*AuthorityRole, error)
+	ListAuthorityRoles(ctx context.Context, programID string) ([]*AuthorityRole, error)
+	ListAuthorityPermissions(ctx context.Context) ([]*AuthorityPermission, error)
+	GetRolePermissions(ctx context.Context, roleID string) ([]*AuthorityPermission, error)
+	GetProfileRoles(ctx context.Context, userType enums.UsersType, profileID string) ([]*AuthorityRole, error)
+	CheckIfStaffHasFacilityAccess(ctx context.Context, userID, programID, facilityID string) (bool, error)
+	ListAuditLogs(ctx context.Context, organisationID string, filter *dto.AuditLogFilterInput, pagination *domain.Pagination) ([]*AuditLog, *domain.Pagination, error)
+	ListAuditLogChain(ctx context.Context, organisationID string, afterSequence int64, limit int) ([]*AuditLog, error)
+	CountUnchainedAuditLogs(ctx context.Context, organisationID string, since time.Time) (int64, error)
+	GetUserTOTP(ctx context.Context, userID string) (*UserTOTP, error)
+	CheckIfUserHasTOTP(ctx context.Context, userID string) (bool, error)
+	ListUserRecoveryCodes(ctx context.Context, userID string) ([]*UserRecoveryCode, error)
+	CheckIfStaffTOTPIsEnforced(ctx context.Context, userID string) (bool, error)
+	ListUserDevices(ctx context.Context, userID string) ([]*UserDevice, error)
+	ListUserLoginCountries(ctx context.Context, userID string) ([]string, error)
+	CountUsersLoggedInFromIPAddress(ctx context.Context, ipAddress string, since time.Time) (int, error)
+	ListDuplicateClientCandidates(ctx context.Context, clientID string) ([]*DuplicateClientCandidate, error)
+	ListUserContacts(ctx context.Context, userID string) ([]*Contact, error)
+	ListClientScreeningToolResponses(ctx context.Context, clientID string) ([]*ScreeningToolResponse, error)
+	ListUserMetrics(ctx context.Context, userID string) ([]*Metric, error)
+	ListUserFeedback(ctx context.Context, userID string) ([]*Feedback, error)
+	ListClientsDueForErasure(ctx context.Context, now time.Time) ([]*Client, error)
+	ListExpiredCaregiverDelegations(ctx context.Context, now time.Time) ([]*CaregiverClient, error)
+	GetPendingPhoneChangeRequest(ctx context.Context, userID string, flavour feedlib.Flavour) (*PhoneChangeRequest, error)
+	GetStaffInProgressServiceRequests(ctx context.Context, staffIDs []string) ([]*ClientServiceRequest, error)
+	ListOrganisationAdmins(ctx context.Context, organisationID string) ([]*StaffProfile, error)
+	ListFacilityAdmins(ctx context.Context, facilityID, programID string) ([]*StaffProfile, error)
+	ListClientTransfers(ctx context.Context, userID string) ([]*ClientTransfer, error)
+	ListServiceRequestSLAs(ctx context.Context, programID *string) ([]*ServiceRequestSLA, error)
+	GetServiceRequestsDueForEscalation(ctx context.Context, requestTypes []string) ([]*ClientServiceRequest, error)
+	ListServiceRequestEscalations(ctx context.Context, serviceRequestID string) ([]*ServiceRequestEscalation, error)
 }
 
 // GetFacilityStaffs returns a list of staff at a particular facility
@@ -548,6 +589,17 @@ func (db *PGInstance) GetUserPINByUserID(ctx context.Context, userID string) (*P
 	return &pin, nil
 }
 
+// GetUserPINHistory fetches a user's most recent PINs, including the current one, starting with the newest
+func (db *PGInstance) GetUserPINHistory(ctx context.Context, userID string, limit int) ([]*PINData, error) {
+	var pins []*PINData
+	err := db.DB.WithContext(ctx).Where(&PINData{UserID: userID}).Order("valid_from DESC").Limit(limit).Find(&pins).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user pin history: %w", err)
+	}
+
+	return pins, nil
+}
+
 // GetCurrentTerms fetches the most recent terms of service depending on the flavour
 func (db *PGInstance) GetCurrentTerms(ctx context.Context) (*TermsOfService, error) {
 	var termsOfService TermsOfService
@@ -1228,7 +1280,7 @@ func (db *PGInstance) CheckIfClientHasUnresolvedServiceRequests(ctx context.Cont
 func (db *PGInstance) SearchClientProfile(ctx context.Context, searchParameter string) ([]*Client, error) {
 	var clients []*Client
 
-	err := db.DB.Scopes(ProgramScope(ctx, "clients_client")).Joins("JOIN users_user on users_user.id = clients_client.user_id").
+	err := db.DB.Scopes(ProgramScope(ctx, "clients_client"), StaffClientScope(ctx, "clients_client")).Joins("JOIN users_user on users_user.id = clients_client.user_id").
 		Joins("JOIN clients_client_identifiers on clients_client.id = clients_client_identifiers.client_id").
 		Joins("JOIN common_identifiers on common_identifiers.id = clients_client_identifiers.identifier_id").
 		Joins("JOIN common_contact on users_user.id = common_contact.user_id").
@@ -1840,11 +1892,14 @@ func (db *PGInstance) GetCaregiverProfileByCaregiverID(ctx context.Context, care
 }
 
 // ListClientsCaregivers retrieves a list of clients caregivers
+// Delegations that have ended or were revoked are left out
 func (db *PGInstance) ListClientsCaregivers(ctx context.Context, clientID string, pagination *domain.Pagination) ([]*CaregiverClient, *domain.Pagination, error) {
 	var caregiverClients []*CaregiverClient
 	var count int64
 
-	tx := db.DB.Model(&CaregiverClient{}).Where(&CaregiverClient{ClientID: clientID})
+	tx := db.DB.Model(&CaregiverClient{}).Where(&CaregiverClient{ClientID: clientID}).
+		Where("revoked_at IS NULL AND expired_at IS NULL").
+		Where("ends_at IS NULL OR ends_at > ?", time.Now())
 
 	if pagination != nil {
 		if err := tx.Count(&count).Error; err != nil {
@@ -1941,16 +1996,21 @@ func (db *PGInstance) GetCaregiversClient(ctx context.Context, caregiverClient C
 
 // GetCaregiverManagedClients lists clients who are managed by the caregivers
 // The clients should have given their consent to be managed by the caregivers
+// Delegations that have not started, have ended or were revoked are left out
 func (db *PGInstance) GetCaregiverManagedClients(ctx context.Context, userID string, pagination *domain.Pagination) ([]*CaregiverClient, *domain.Pagination, error) {
 
 	var caregiversClients []*CaregiverClient
 	var count int64
+	now := time.Now()
 
 	tx := db.DB.Model(&caregiversClients)
 
 	tx = tx.Joins("JOIN clients_client ON clients_client.id = caregivers_caregiver_client.client_id").
 		Joins("JOIN caregivers_caregiver ON caregivers_caregiver.id = caregivers_caregiver_client.caregiver_id").
-		Where("caregivers_caregiver.user_id = ?", userID)
+		Where("caregivers_caregiver.user_id = ?", userID).
+		Where("caregivers_caregiver_client.revoked_at IS NULL AND caregivers_caregiver_client.expired_at IS NULL").
+		Where("caregivers_caregiver_client.starts_at IS NULL OR caregivers_caregiver_client.starts_at <= ?", now).
+		Where("caregivers_caregiver_client.ends_at IS NULL OR caregivers_caregiver_client.ends_at > ?", now)
 
 	if pagination != nil {
 		if err := tx.Count(&count).Error; err != nil {
@@ -2249,6 +2309,61 @@ func (db *PGInstance) GetAuthorizationCode(ctx context.Context, code string) (*A
 	return &result, nil
 }
 
+// GetOpenIDConnectSession retrieves the OpenID Connect session of an authorization code
+func (db *PGInstance) GetOpenIDConnectSession(ctx context.Context, code string) (*OpenIDConnectSession, error) {
+	var result OpenIDConnectSession
+
+	if err := db.DB.WithContext(ctx).Preload("Session.User").Preload(clause.Associations).Where(OpenIDConnectSession{Code: code}).First(&result).Error; err != nil {
+		return nil, fmt.Errorf("error fetching openid connect session: %w", err)
+	}
+
+	return &result, nil
+}
+
+// GetPKCE retrieves the PKCE session of an authorization code using its signature
+func (db *PGInstance) GetPKCE(ctx context.Context, signature string) (*PKCE, error) {
+	var result PKCE
+
+	if err := db.DB.WithContext(ctx).Preload("Session.User").Preload(clause.Associations).Where(PKCE{Signature: signature}).First(&result).Error; err != nil {
+		return nil, fmt.Errorf("error fetching pkce session: %w", err)
+	}
+
+	return &result, nil
+}
+
+// ListOauthSigningKeys returns the token signing keys that have not expired at the given time, newest first
+func (db *PGInstance) ListOauthSigningKeys(ctx context.Context, activeAt time.Time) ([]*OauthSigningKey, error) {
+	var keys []*OauthSigningKey
+
+	if err := db.DB.WithContext(ctx).Where("active = ? AND expires_at > ?", true, activeAt).Order("created DESC").Find(&keys).Error; err != nil {
+		return nil, fmt.Errorf("error listing oauth signing keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// ListRevokedSessionIDs returns the IDs of the sessions that were revoked since the given time
+func (db *PGInstance) ListRevokedSessionIDs(ctx context.Context, since time.Time) ([]string, error) {
+	var sessionIDs []string
+
+	if err := db.DB.WithContext(ctx).Model(&Session{}).Where("revoked_at >= ?", since).Pluck("id", &sessionIDs).Error; err != nil {
+		return nil, fmt.Errorf("error listing revoked sessions: %w", err)
+	}
+
+	return sessionIDs, nil
+}
+
+// ListRevokedAccessTokenSignatures returns the signatures of the access tokens that were revoked since the given time
+func (db *PGInstance) ListRevokedAccessTokenSignatures(ctx context.Context, since time.Time) ([]string, error) {
+	var signatures []string
+
+	if err := db.DB.WithContext(ctx).Model(&AccessToken{}).Where("active = ? AND updated >= ?", false, since).Pluck("signature", &signatures).Error; err != nil {
+		return nil, fmt.Errorf("error listing revoked access tokens: %w", err)
+	}
+
+	return signatures, nil
+}
+
 // GetAccessToken retrieves an access token using the signature
 func (db *PGInstance) GetAccessToken(ctx context.Context, token AccessToken) (*AccessToken, error) {
 	var result AccessToken
@@ -2271,6 +2386,22 @@ func (db *PGInstance) GetRefreshToken(ctx context.Context, token RefreshToken) (
 	return &result, nil
 }
 
+// ListUserSessions retrieves the sessions that a user has not been logged out of.
+// A session is listed while it has an active refresh token that was issued after `activeSince`
+func (db *PGInstance) ListUserSessions(ctx context.Context, userID string, activeSince time.Time) ([]*Session, error) {
+	var sessions []*Session
+
+	err := db.DB.WithContext(ctx).Where("user_id = ? AND revoked_at IS NULL", userID).
+		Where("EXISTS (SELECT 1 FROM oauth_refresh_token WHERE oauth_refresh_token.session_id = oauth_session.id AND oauth_refresh_token.active = true AND oauth_refresh_token.requested_at > ?)", activeSince).
+		Order("COALESCE(last_seen_at, created) DESC").
+		Find(&sessions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
 // CheckIfClientHasPendingSurveyServiceRequest returns true if client has a pending survey service request
 func (db *PGInstance) CheckIfClientHasPendingSurveyServiceRequest(ctx context.Context, clientID string, projectID int, formID string) (bool, error) {
 	var clientServiceRequests []ClientServiceRequest
@@ -2439,3 +2570,555 @@ func (db *PGInstance) ListBookings(ctx context.Context, clientID string, booking
 
 	return bookings, pagination, nil
 }
+
+// GetUserPermissions retrieves the permissions granted to a user in a program through the roles assigned to their staff,
+// client or caregiver profiles
+func (db *PGInstance) GetUserPermissions(ctx context.Context, userID string, programID string) ([]*AuthorityPermission, error) {
+	var permissions []*AuthorityPermission
+
+	staffRoles := db.DB.Model(&AuthorityRoleStaff{}).Select("authority_authorityrole_staff.authorityrole_id").
+		Joins("JOIN staff_staff ON staff_staff.id = authority_authorityrole_staff.staff_id").
+		Where("staff_staff.user_id = ? AND staff_staff.program_id = ? AND staff_staff.active = ?", userID, programID, true)
+
+	clientRoles := db.DB.Model(&AuthorityRoleClient{}).Select("authority_authorityrole_clients.authorityrole_id").
+		Joins("JOIN clients_client ON clients_client.id = authority_authorityrole_clients.client_id").
+		Where("clients_client.user_id = ? AND clients_client.program_id = ? AND clients_client.active = ?", userID, programID, true)
+
+	caregiverRoles := db.DB.Model(&AuthorityRoleCaregiver{}).Select("authority_authorityrole_caregivers.authorityrole_id").
+		Joins("JOIN caregivers_caregiver ON caregivers_caregiver.id = authority_authorityrole_caregivers.caregiver_id").
+		Where("caregivers_caregiver.user_id = ? AND caregivers_caregiver.active = ?", userID, true)
+
+	err := db.DB.WithContext(ctx).Distinct("authority_authoritypermission.*").
+		Joins("JOIN authority_authorityrole_permissions ON authority_authorityrole_permissions.authoritypermission_id = authority_authoritypermission.id").
+		Joins("JOIN authority_authorityrole ON authority_authorityrole.id = authority_authorityrole_permissions.authorityrole_id").
+		Where("authority_authoritypermission.active = ? AND authority_authorityrole.active = ?", true, true).
+		Where("authority_authorityrole.program_id = ?", programID).
+		Where(
+			db.DB.Where("authority_authorityrole.id IN (?)", staffRoles).
+				Or("authority_authorityrole.id IN (?)", clientRoles).
+				Or("authority_authorityrole.id IN (?)", caregiverRoles),
+		).
+		Find(&permissions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user permissions: %w", err)
+	}
+
+	return permissions, nil
+}
+
+// GetAuthorityRoleByID retrieves a role using its ID
+func (db *PGInstance) GetAuthorityRoleByID(ctx context.Context, roleID string) (*AuthorityRole, error) {
+	var role AuthorityRole
+	if err := db.DB.WithContext(ctx).Where(&AuthorityRole{AuthorityRoleID: &roleID}).First(&role).Error; err != nil {
+		return nil, fmt.Errorf("failed to get authority role: %w", err)
+	}
+
+	return &role, nil
+}
+
+// GetAuthorityRoleByName retrieves a program's role using its name
+func (db *PGInstance) GetAuthorityRoleByName(ctx context.Context, programID string, name string) (*AuthorityRole, error) {
+	var role AuthorityRole
+	if err := db.DB.WithContext(ctx).Where(&AuthorityRole{ProgramID: programID, Name: name}).First(&role).Error; err != nil {
+		return nil, fmt.Errorf("failed to get authority role: %w", err)
+	}
+
+	return &role, nil
+}
+
+// ListAuthorityRoles lists the roles that have been defined in a program
+func (db *PGInstance) ListAuthorityRoles(ctx context.Context, programID string) ([]*AuthorityRole, error) {
+	var roles []*AuthorityRole
+	if err := db.DB.WithContext(ctx).Where(&AuthorityRole{ProgramID: programID}).Order("name").Find(&roles).Error; err != nil {
+		return nil, fmt.Errorf("failed to list authority roles: %w", err)
+	}
+
+	return roles, nil
+}
+
+// ListAuthorityPermissions lists all the permissions that can be granted to a role
+func (db *PGInstance) ListAuthorityPermissions(ctx context.Context) ([]*AuthorityPermission, error) {
+	var permissions []*AuthorityPermission
+	if err := db.DB.WithContext(ctx).Where(&AuthorityPermission{Active: true}).Order("category").Order("scope").Find(&permissions).Error; err != nil {
+		return nil, fmt.Errorf("failed to list authority permissions: %w", err)
+	}
+
+	return permissions, nil
+}
+
+// GetRolePermissions retrieves the permissions that have been granted to a role
+func (db *PGInstance) GetRolePermissions(ctx context.Context, roleID string) ([]*AuthorityPermission, error) {
+	var permissions []*AuthorityPermission
+	err := db.DB.WithContext(ctx).
+		Joins("JOIN authority_authorityrole_permissions ON authority_authorityrole_permissions.authoritypermission_id = authority_authoritypermission.id").
+		Where("authority_authorityrole_permissions.authorityrole_id = ?", roleID).
+		Find(&permissions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get role permissions: %w", err)
+	}
+
+	return permissions, nil
+}
+
+// GetProfileRoles retrieves the roles that have been assigned to a staff, client or caregiver profile
+func (db *PGInstance) GetProfileRoles(ctx context.Context, userType enums.UsersType, profileID string) ([]*AuthorityRole, error) {
+	var roles []*AuthorityRole
+
+	tx := db.DB.WithContext(ctx)
+
+	switch userType {
+	case enums.StaffUser:
+		tx = tx.Joins("JOIN authority_authorityrole_staff ON authority_authorityrole_staff.authorityrole_id = authority_authorityrole.id").
+			Where("authority_authorityrole_staff.staff_id = ?", profileID)
+
+	case enums.ClientUser:
+		tx = tx.Joins("JOIN authority_authorityrole_clients ON authority_authorityrole_clients.authorityrole_id = authority_authorityrole.id").
+			Where("authority_authorityrole_clients.client_id = ?", profileID)
+
+	case enums.CaregiverUser:
+		tx = tx.Joins("JOIN authority_authorityrole_caregivers ON authority_authorityrole_caregivers.authorityrole_id = authority_authorityrole.id").
+			Where("authority_authorityrole_caregivers.caregiver_id = ?", profileID)
+
+	default:
+		return nil, fmt.Errorf("roles cannot be retrieved for user type: %s", userType)
+	}
+
+	if err := tx.Find(&roles).Error; err != nil {
+		return nil, fmt.Errorf("failed to get profile roles: %w", err)
+	}
+
+	return roles, nil
+}
+
+// CheckIfStaffHasFacilityAccess checks whether a user is assigned to a facility as a staff in the provided program
+func (db *PGInstance) CheckIfStaffHasFacilityAccess(ctx context.Context, userID, programID, facilityID string) (bool, error) {
+	var count int64
+
+	err := staffFacilities(db.DB.WithContext(ctx), userID, programID).
+		Where("staff_staff_facilities.facility_id = ?", facilityID).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check staff facility access: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// ListAuditLogs lists an organisation's audit logs, starting with the most recent, using the provided filters
+func (db *PGInstance) ListAuditLogs(ctx context.Context, organisationID string, filter *dto.AuditLogFilterInput, pagination *domain.Pagination) ([]*AuditLog, *domain.Pagination, error) {
+	var auditLogs []*AuditLog
+	var count int64
+
+	tx := db.DB.WithContext(ctx).Model(&AuditLog{}).Where(&AuditLog{OrganisationID: organisationID, Active: true})
+
+	if filter != nil {
+		if filter.RecordType != nil {
+			tx = tx.Where("record_type = ?", filter.RecordType.String())
+		}
+		if filter.ActorID != nil {
+			tx = tx.Where("actor_id = ?", *filter.ActorID)
+		}
+		if filter.TargetID != nil {
+			tx = tx.Where("target_id = ?", *filter.TargetID)
+		}
+		if filter.ProgramID != nil {
+			tx = tx.Where("program_id = ?", *filter.ProgramID)
+		}
+		if filter.From != nil {
+			tx = tx.Where("timestamp >= ?", *filter.From)
+		}
+		if filter.To != nil {
+			tx = tx.Where("timestamp <= ?", *filter.To)
+		}
+	}
+
+	if pagination != nil {
+		if err := tx.Count(&count).Error; err != nil {
+			return nil, nil, fmt.Errorf("failed to count audit logs: %w", err)
+		}
+
+		pagination.Count = count
+		paginateQuery(tx, pagination)
+	}
+
+	if err := tx.Order(clause.OrderByColumn{Column: clause.Column{Name: "timestamp"}, Desc: true}).Find(&auditLogs).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to list audit logs: %w", err)
+	}
+
+	return auditLogs, pagination, nil
+}
+
+// ListAuditLogChain retrieves an organisation's chained audit logs, including inactive ones, in the order they were recorded.
+// Only entries whose sequence is after the provided sequence are returned.
+func (db *PGInstance) ListAuditLogChain(ctx context.Context, organisationID string, afterSequence int64, limit int) ([]*AuditLog, error) {
+	var auditLogs []*AuditLog
+
+	err := db.DB.WithContext(ctx).
+		Where("organisation_id = ? AND sequence > ?", organisationID, afterSequence).
+		Order(clause.OrderByColumn{Column: clause.Column{Name: "sequence"}}).
+		Limit(limit).
+		Find(&auditLogs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit log chain: %w", err)
+	}
+
+	return auditLogs, nil
+}
+
+// CountUnchainedAuditLogs counts an organisation's audit logs recorded since the provided time without being linked to the chain
+func (db *PGInstance) CountUnchainedAuditLogs(ctx context.Context, organisationID string, since time.Time) (int64, error) {
+	var count int64
+
+	err := db.DB.WithContext(ctx).Model(&AuditLog{}).
+		Where("organisation_id = ? AND timestamp >= ? AND (sequence IS NULL OR hash IS NULL)", organisationID, since).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unchained audit logs: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetUserTOTP retrieves a user's TOTP enrollment
+func (db *PGInstance) GetUserTOTP(ctx context.Context, userID string) (*UserTOTP, error) {
+	var userTOTP UserTOTP
+	if err := db.DB.WithContext(ctx).Where(&UserTOTP{UserID: userID, Active: true}).First(&userTOTP).Error; err != nil {
+		return nil, fmt.Errorf("failed to get user TOTP: %w", err)
+	}
+
+	return &userTOTP, nil
+}
+
+// CheckIfUserHasTOTP checks whether a user has a confirmed TOTP enrollment
+func (db *PGInstance) CheckIfUserHasTOTP(ctx context.Context, userID string) (bool, error) {
+	var count int64
+	err := db.DB.WithContext(ctx).Model(&UserTOTP{}).Where(&UserTOTP{UserID: userID, Active: true, Confirmed: true}).Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check if user has TOTP: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// ListUserRecoveryCodes retrieves a user's recovery codes that have not been used
+func (db *PGInstance) ListUserRecoveryCodes(ctx context.Context, userID string) ([]*UserRecoveryCode, error) {
+	var recoveryCodes []*UserRecoveryCode
+	err := db.DB.WithContext(ctx).Where(&UserRecoveryCode{UserID: userID, Active: true}).Where("used_at IS NULL").Find(&recoveryCodes).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user recovery codes: %w", err)
+	}
+
+	return recoveryCodes, nil
+}
+
+// CheckIfStaffTOTPIsEnforced checks whether any of the organisations the user is an active staff in requires staff to use a TOTP authenticator
+func (db *PGInstance) CheckIfStaffTOTPIsEnforced(ctx context.Context, userID string) (bool, error) {
+	var count int64
+	err := db.DB.WithContext(ctx).Model(&StaffProfile{}).
+		Joins("JOIN common_organisation ON common_organisation.id = staff_staff.organisation_id").
+		Where("staff_staff.user_id = ? AND staff_staff.active = ? AND staff_staff.deleted_at IS NULL", userID, true).
+		Where("common_organisation.enforce_staff_totp = ? AND common_organisation.active = ?", true, true).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check if staff TOTP is enforced: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// ListUserDevices retrieves the devices that a user has logged in from, starting with the most recently used
+func (db *PGInstance) ListUserDevices(ctx context.Context, userID string) ([]*UserDevice, error) {
+	var devices []*UserDevice
+	err := db.DB.WithContext(ctx).Where(&UserDevice{UserID: userID, Active: true}).Order("last_login_at DESC").Find(&devices).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user devices: %w", err)
+	}
+
+	return devices, nil
+}
+
+// ListUserLoginCountries retrieves the countries that a user has logged in from
+func (db *PGInstance) ListUserLoginCountries(ctx context.Context, userID string) ([]string, error) {
+	var countries []string
+	err := db.DB.WithContext(ctx).Model(&LoginEvent{}).
+		Where("user_id = ? AND country IS NOT NULL AND country != ''", userID).
+		Distinct().Pluck("country", &countries).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user login countries: %w", err)
+	}
+
+	return countries, nil
+}
+
+// CountUsersLoggedInFromIPAddress counts the different users that have logged in from an IP address since the given time
+func (db *PGInstance) CountUsersLoggedInFromIPAddress(ctx context.Context, ipAddress string, since time.Time) (int, error) {
+	var count int64
+	err := db.DB.WithContext(ctx).Model(&LoginEvent{}).
+		Where("ip_address = ? AND timestamp >= ?", ipAddress, since).
+		Distinct("user_id").Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count users logged in from IP address: %w", err)
+	}
+
+	return int(count), nil
+}
+
+// ListDuplicateClientCandidates returns the active clients of other users in the same program as the provided client that share their CCC number,
+// a phone number or their name. The name is compared ignoring case and extra spaces and the date of birth match is returned for scoring
+func (db *PGInstance) ListDuplicateClientCandidates(ctx context.Context, clientID string) ([]*DuplicateClientCandidate, error) {
+	var candidates []*DuplicateClientCandidate
+
+	err := db.DB.WithContext(ctx).Raw(`
+	SELECT * FROM (
+		SELECT
+			c.id AS client_id,
+			EXISTS (
+				SELECT 1 FROM clients_client_identifiers ci
+				JOIN common_identifiers i ON i.id = ci.identifier_id
+				JOIN common_identifiers ti ON ti.identifier_value = i.identifier_value
+				JOIN clients_client_identifiers tci ON tci.identifier_id = ti.id
+				WHERE ci.client_id = c.id AND tci.client_id = t.id
+				AND i.identifier_type = 'CCC' AND ti.identifier_type = 'CCC'
+				AND i.deleted_at IS NULL AND ti.deleted_at IS NULL
+			) AS matches_ccc_number,
+			EXISTS (
+				SELECT 1 FROM common_contact cc
+				JOIN common_contact tc ON tc.contact_value = cc.contact_value
+				WHERE cc.user_id = c.user_id AND tc.user_id = t.user_id
+				AND cc.contact_type = 'PHONE' AND tc.contact_type = 'PHONE'
+				AND cc.deleted_at IS NULL AND tc.deleted_at IS NULL
+			) AS matches_phone,
+			COALESCE(
+				lower(regexp_replace(trim(u.name), '\s+', ' ', 'g')) = lower(regexp_replace(trim(tu.name), '\s+', ' ', 'g')), false
+			) AS matches_name,
+			COALESCE(u.date_of_birth::date = tu.date_of_birth::date, false) AS matches_date_of_birth
+		FROM clients_client c
+		JOIN users_user u ON u.id = c.user_id
+		JOIN clients_client t ON t.id = ?
+		JOIN users_user tu ON tu.id = t.user_id
+		WHERE c.id <> t.id AND c.user_id <> t.user_id AND c.program_id = t.program_id
+		AND c.active = true AND c.deleted_at IS NULL
+	) candidates
+	WHERE matches_ccc_number OR matches_phone OR matches_name
+	`, clientID).Scan(&candidates).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list duplicate client candidates: %w", err)
+	}
+
+	return candidates, nil
+}
+
+// ListUserContacts retrieves all the contacts of a user
+func (db *PGInstance) ListUserContacts(ctx context.Context, userID string) ([]*Contact, error) {
+	var contacts []*Contact
+
+	if err := db.DB.WithContext(ctx).Where(&Contact{UserID: &userID}).Order("created asc").Find(&contacts).Error; err != nil {
+		return nil, fmt.Errorf("failed to list user contacts: %w", err)
+	}
+
+	return contacts, nil
+}
+
+// ListClientScreeningToolResponses retrieves all the screening tool responses of a client, the most recent first
+func (db *PGInstance) ListClientScreeningToolResponses(ctx context.Context, clientID string) ([]*ScreeningToolResponse, error) {
+	var screeningToolResponses []*ScreeningToolResponse
+
+	if err := db.DB.WithContext(ctx).Where(&ScreeningToolResponse{ClientID: clientID}).Order("created desc").Find(&screeningToolResponses).Error; err != nil {
+		return nil, fmt.Errorf("failed to list client screening tool responses: %w", err)
+	}
+
+	return screeningToolResponses, nil
+}
+
+// ListUserMetrics retrieves all the metrics recorded for a user, the most recent first
+func (db *PGInstance) ListUserMetrics(ctx context.Context, userID string) ([]*Metric, error) {
+	var metrics []*Metric
+
+	if err := db.DB.WithContext(ctx).Where(&Metric{UserID: &userID}).Order("timestamp desc").Find(&metrics).Error; err != nil {
+		return nil, fmt.Errorf("failed to list user metrics: %w", err)
+	}
+
+	return metrics, nil
+}
+
+// ListUserFeedback retrieves all the feedback that a user has sent, the most recent first
+func (db *PGInstance) ListUserFeedback(ctx context.Context, userID string) ([]*Feedback, error) {
+	var feedback []*Feedback
+
+	if err := db.DB.WithContext(ctx).Where(&Feedback{UserID: userID}).Order("created desc").Find(&feedback).Error; err != nil {
+		return nil, fmt.Errorf("failed to list user feedback: %w", err)
+	}
+
+	return feedback, nil
+}
+
+// ListClientsDueForErasure returns the clients who asked for their data to be erased and whose organisation's data retention period
+// has passed since they asked. Clients who have already been anonymized are left out
+func (db *PGInstance) ListClientsDueForErasure(ctx context.Context, now time.Time) ([]*Client, error) {
+	var clients []*Client
+
+	err := db.DB.WithContext(ctx).
+		Joins("JOIN common_organisation ON common_organisation.id = clients_client.organisation_id").
+		Where("clients_client.erasure_requested_at IS NOT NULL AND clients_client.anonymized_at IS NULL").
+		Where("clients_client.erasure_requested_at + make_interval(days => common_organisation.data_retention_days) <= ?", now).
+		Order("clients_client.erasure_requested_at").
+		Find(&clients).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list clients due for erasure: %w", err)
+	}
+
+	return clients, nil
+}
+
+// ListExpiredCaregiverDelegations returns the caregiver delegations whose end date has passed but have not been marked as expired.
+// Delegations that were revoked by the client are left out
+func (db *PGInstance) ListExpiredCaregiverDelegations(ctx context.Context, now time.Time) ([]*CaregiverClient, error) {
+	var caregiverClients []*CaregiverClient
+
+	err := db.DB.WithContext(ctx).
+		Where("ends_at <= ? AND revoked_at IS NULL AND expired_at IS NULL", now).
+		Order("ends_at").
+		Find(&caregiverClients).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expired caregiver delegations: %w", err)
+	}
+
+	return caregiverClients, nil
+}
+
+// GetPendingPhoneChangeRequest returns the latest phone change request of a user that has not been confirmed
+func (db *PGInstance) GetPendingPhoneChangeRequest(ctx context.Context, userID string, flavour feedlib.Flavour) (*PhoneChangeRequest, error) {
+	var request PhoneChangeRequest
+
+	err := db.DB.WithContext(ctx).
+		Where("user_id = ? AND flavour = ? AND active = ? AND confirmed_at IS NULL", userID, flavour, true).
+		Order("created DESC").
+		First(&request).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending phone change request: %w", err)
+	}
+
+	return &request, nil
+}
+
+// GetStaffInProgressServiceRequests returns the client service requests that the staff have marked as in progress and not resolved
+func (db *PGInstance) GetStaffInProgressServiceRequests(ctx context.Context, staffIDs []string) ([]*ClientServiceRequest, error) {
+	var serviceRequests []*ClientServiceRequest
+
+	if len(staffIDs) == 0 {
+		return serviceRequests, nil
+	}
+
+	err := db.DB.WithContext(ctx).
+		Where("in_progress_by_id IN ? AND status = ? AND active = ?", staffIDs, enums.ServiceRequestStatusInProgress.String(), true).
+		Order("in_progress_at ASC").
+		Find(&serviceRequests).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get staff in progress service requests: %w", err)
+	}
+
+	return serviceRequests, nil
+}
+
+// ListOrganisationAdmins returns the active staff profiles of an organisation that have organisation admin rights
+func (db *PGInstance) ListOrganisationAdmins(ctx context.Context, organisationID string) ([]*StaffProfile, error) {
+	var staffProfiles []*StaffProfile
+
+	err := db.DB.WithContext(ctx).
+		Where("organisation_id = ? AND is_organisation_admin = ? AND active = ?", organisationID, true, true).
+		Find(&staffProfiles).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organisation admins: %w", err)
+	}
+
+	return staffProfiles, nil
+}
+
+// ListFacilityAdmins returns the active staff profiles of a program that are assigned to a facility and hold the program's admin role
+func (db *PGInstance) ListFacilityAdmins(ctx context.Context, facilityID, programID string) ([]*StaffProfile, error) {
+	var staffProfiles []*StaffProfile
+
+	err := db.DB.WithContext(ctx).
+		Distinct("staff_staff.*").
+		Joins("JOIN staff_staff_facilities ON staff_staff_facilities.staff_id = staff_staff.id").
+		Joins("JOIN authority_authorityrole_users ON authority_authorityrole_users.user_id = staff_staff.user_id").
+		Joins("JOIN authority_authorityrole ON authority_authorityrole.id = authority_authorityrole_users.authorityrole_id").
+		Where("staff_staff_facilities.facility_id = ? AND staff_staff.program_id = ? AND staff_staff.active = ?", facilityID, programID, true).
+		Where("authority_authorityrole.name = ? AND authority_authorityrole.program_id = ? AND authority_authorityrole.active = ?", authorization.DefaultRoleAdmin.String(), programID, true).
+		Find(&staffProfiles).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list facility admins: %w", err)
+	}
+
+	return staffProfiles, nil
+}
+
+// ListClientTransfers returns the transfers of a client's user across all their client profiles, the most recent first
+func (db *PGInstance) ListClientTransfers(ctx context.Context, userID string) ([]*ClientTransfer, error) {
+	var transfers []*ClientTransfer
+
+	err := db.DB.WithContext(ctx).
+		Where("user_id = ? AND active = ?", userID, true).
+		Order("transferred_at DESC").
+		Find(&transfers).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list client transfers: %w", err)
+	}
+
+	return transfers, nil
+}
+
+// ListServiceRequestSLAs returns the service request SLAs of a program or of all the programs when it is not provided
+func (db *PGInstance) ListServiceRequestSLAs(ctx context.Context, programID *string) ([]*ServiceRequestSLA, error) {
+	var slas []*ServiceRequestSLA
+
+	tx := db.DB.WithContext(ctx).Where("active = ?", true)
+	if programID != nil {
+		tx = tx.Where("program_id = ?", *programID)
+	}
+
+	if err := tx.Order("request_type ASC").Find(&slas).Error; err != nil {
+		return nil, fmt.Errorf("failed to list service request slas: %w", err)
+	}
+
+	return slas, nil
+}
+
+// GetServiceRequestsDueForEscalation returns the pending client service requests of the given types that have not been escalated to the admins yet.
+// Requests created before SLA tracking started in their program are left out
+func (db *PGInstance) GetServiceRequestsDueForEscalation(ctx context.Context, requestTypes []string) ([]*ClientServiceRequest, error) {
+	var serviceRequests []*ClientServiceRequest
+
+	if len(requestTypes) == 0 {
+		return serviceRequests, nil
+	}
+
+	err := db.DB.WithContext(ctx).
+		Select("clients_servicerequest.*").
+		Joins("JOIN common_program ON common_program.id = clients_servicerequest.program_id").
+		Where("clients_servicerequest.request_type IN ? AND clients_servicerequest.status = ? AND clients_servicerequest.active = ?", requestTypes, enums.ServiceRequestStatusPending.String(), true).
+		Where("clients_servicerequest.escalation_level IS NULL OR clients_servicerequest.escalation_level <> ?", enums.ServiceRequestEscalationLevelAdmins.String()).
+		Where("clients_servicerequest.created >= common_program.sla_tracking_started_at").
+		Order("clients_servicerequest.created ASC").
+		Find(&serviceRequests).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service requests due for escalation: %w", err)
+	}
+
+	return serviceRequests, nil
+}
+
+// ListServiceRequestEscalations returns the escalations of a service request, the earliest first
+func (db *PGInstance) ListServiceRequestEscalations(ctx context.Context, serviceRequestID string) ([]*ServiceRequestEscalation, error) {
+	var escalations []*ServiceRequestEscalation
+
+	err := db.DB.WithContext(ctx).
+		Where("service_request_id = ? AND active = ?", serviceRequestID, true).
+		Order("escalated_at ASC").
+		Find(&escalations).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service request escalations: %w", err)
+	}
+
+	return escalations, nil
+}
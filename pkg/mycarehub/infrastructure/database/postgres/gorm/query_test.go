@@ -1176,6 +1176,59 @@ func TestPGInstance_GetUserPINByUserID(t *testing.T) {
 	}
 }
 
+func TestPGInstance_GetUserPINHistory(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		userID string
+		limit  int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "happy case: get user pin history",
+			args: args{
+				ctx:    context.Background(),
+				userID: userID,
+				limit:  3,
+			},
+			wantErr: false,
+		},
+		{
+			name: "happy case: user without pins",
+			args: args{
+				ctx:    context.Background(),
+				userID: uuid.New().String(),
+				limit:  3,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.GetUserPINHistory(tt.args.ctx, tt.args.userID, tt.args.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.GetUserPINHistory() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) > tt.args.limit {
+				t.Errorf("expected at most %v pins but got %v", tt.args.limit, len(got))
+				return
+			}
+			if tt.name == "happy case: get user pin history" && len(got) == 0 {
+				t.Errorf("expected the user's pins but got none")
+				return
+			}
+			if tt.name == "happy case: user without pins" && len(got) != 0 {
+				t.Errorf("expected no pins but got %v", len(got))
+				return
+			}
+		})
+	}
+}
+
 func TestPGInstance_GetSecurityQuestionResponse(t *testing.T) {
 	type args struct {
 		ctx        context.Context
@@ -5791,6 +5844,218 @@ func TestPGInstance_GetAuthorizationCode(t *testing.T) {
 	}
 }
 
+func TestPGInstance_GetOpenIDConnectSession(t *testing.T) {
+	type args struct {
+		ctx  context.Context
+		code string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "happy case: get an openid connect session",
+			args: args{
+				ctx:  context.Background(),
+				code: "9f2c1d0e8b7a6f5e4d3c2b1a09f8e7d6c5b4a3928170f6e5d4c3b2a1908f7e6d",
+			},
+			wantErr: false,
+		},
+		{
+			name: "sad case: invalid code",
+			args: args{
+				ctx:  context.Background(),
+				code: gofakeit.Username(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.GetOpenIDConnectSession(tt.args.ctx, tt.args.code)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.GetOpenIDConnectSession() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got == nil {
+				t.Errorf("PGInstance.GetOpenIDConnectSession() got = %v, wantErr %v", got, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPGInstance_GetPKCE(t *testing.T) {
+	type args struct {
+		ctx       context.Context
+		signature string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "happy case: get a pkce session",
+			args: args{
+				ctx:       context.Background(),
+				signature: "3c8f1a2b7d6e4f509a1b2c3d4e5f60718293a4b5c6d7e8f9",
+			},
+			wantErr: false,
+		},
+		{
+			name: "sad case: invalid signature",
+			args: args{
+				ctx:       context.Background(),
+				signature: gofakeit.Username(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.GetPKCE(tt.args.ctx, tt.args.signature)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.GetPKCE() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got == nil {
+				t.Errorf("PGInstance.GetPKCE() got = %v, wantErr %v", got, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPGInstance_ListOauthSigningKeys(t *testing.T) {
+	type args struct {
+		ctx      context.Context
+		activeAt time.Time
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantCount bool
+		wantErr   bool
+	}{
+		{
+			name: "happy case: list signing keys",
+			args: args{
+				ctx:      context.Background(),
+				activeAt: time.Now(),
+			},
+			wantCount: true,
+			wantErr:   false,
+		},
+		{
+			name: "happy case: no keys are active after they expire",
+			args: args{
+				ctx:      context.Background(),
+				activeAt: time.Now().AddDate(1, 0, 0),
+			},
+			wantCount: false,
+			wantErr:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.ListOauthSigningKeys(tt.args.ctx, tt.args.activeAt)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.ListOauthSigningKeys() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if (len(got) > 0) != tt.wantCount {
+				t.Errorf("PGInstance.ListOauthSigningKeys() got %d keys, want keys %v", len(got), tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestPGInstance_ListRevokedSessionIDs(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		since time.Time
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantCount bool
+		wantErr   bool
+	}{
+		{
+			name: "happy case: list revoked sessions",
+			args: args{
+				ctx:   context.Background(),
+				since: time.Now().Add(-1 * time.Hour),
+			},
+			wantErr: false,
+		},
+		{
+			name: "happy case: nothing is revoked in the future",
+			args: args{
+				ctx:   context.Background(),
+				since: time.Now().AddDate(1, 0, 0),
+			},
+			wantCount: false,
+			wantErr:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.ListRevokedSessionIDs(tt.args.ctx, tt.args.since)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.ListRevokedSessionIDs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.name == "happy case: nothing is revoked in the future" && (len(got) > 0) != tt.wantCount {
+				t.Errorf("PGInstance.ListRevokedSessionIDs() got %d results, want results %v", len(got), tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestPGInstance_ListRevokedAccessTokenSignatures(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		since time.Time
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantCount bool
+		wantErr   bool
+	}{
+		{
+			name: "happy case: list revoked access tokens",
+			args: args{
+				ctx:   context.Background(),
+				since: time.Now().Add(-1 * time.Hour),
+			},
+			wantErr: false,
+		},
+		{
+			name: "happy case: nothing is revoked in the future",
+			args: args{
+				ctx:   context.Background(),
+				since: time.Now().AddDate(1, 0, 0),
+			},
+			wantCount: false,
+			wantErr:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.ListRevokedAccessTokenSignatures(tt.args.ctx, tt.args.since)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.ListRevokedAccessTokenSignatures() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.name == "happy case: nothing is revoked in the future" && (len(got) > 0) != tt.wantCount {
+				t.Errorf("PGInstance.ListRevokedAccessTokenSignatures() got %d results, want results %v", len(got), tt.wantCount)
+			}
+		})
+	}
+}
+
 func TestPGInstance_GetAccessToken(t *testing.T) {
 	type args struct {
 		ctx   context.Context
@@ -5881,6 +6146,47 @@ func TestPGInstance_GetRefreshToken(t *testing.T) {
 	}
 }
 
+func TestPGInstance_ListUserSessions(t *testing.T) {
+	type args struct {
+		ctx         context.Context
+		userID      string
+		activeSince time.Time
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "happy case: list user sessions",
+			args: args{
+				ctx:         context.Background(),
+				userID:      userIDOauthUser,
+				activeSince: time.Now().Add(-24 * time.Hour),
+			},
+			wantErr: false,
+		},
+		{
+			name: "sad case: invalid user id",
+			args: args{
+				ctx:         context.Background(),
+				userID:      "invalid",
+				activeSince: time.Now().Add(-24 * time.Hour),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := testingDB.ListUserSessions(tt.args.ctx, tt.args.userID, tt.args.activeSince)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ListUserSessions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+		})
+	}
+}
+
 func TestPGInstance_GetClientProfilesByIdentifier(t *testing.T) {
 	type args struct {
 		ctx            context.Context
@@ -6386,3 +6692,1428 @@ func TestPGInstance_ListBookings(t *testing.T) {
 		})
 	}
 }
+
+func TestPGInstance_GetUserPermissions(t *testing.T) {
+	type args struct {
+		ctx       context.Context
+		userID    string
+		programID string
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantCount int
+		wantErr   bool
+	}{
+		{
+			name: "happy case: get staff user permissions",
+			args: args{
+				ctx:       context.Background(),
+				userID:    userWithRolesID,
+				programID: programID,
+			},
+			wantCount: 4,
+			wantErr:   false,
+		},
+		{
+			name: "happy case: user without roles in program",
+			args: args{
+				ctx:       context.Background(),
+				userID:    userWithRolesID,
+				programID: uuid.NewString(),
+			},
+			wantCount: 0,
+			wantErr:   false,
+		},
+		{
+			name: "sad case: invalid program id",
+			args: args{
+				ctx:       context.Background(),
+				userID:    userWithRolesID,
+				programID: "programID",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.GetUserPermissions(tt.args.ctx, tt.args.userID, tt.args.programID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.GetUserPermissions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got) != tt.wantCount {
+				t.Errorf("PGInstance.GetUserPermissions() got %v permissions, want %v", len(got), tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestPGInstance_GetAuthorityRoleByID(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		roleID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: get authority role",
+			args: args{
+				ctx:    context.Background(),
+				roleID: systemAdminRoleID,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: role not found",
+			args: args{
+				ctx:    context.Background(),
+				roleID: uuid.NewString(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.GetAuthorityRoleByID(tt.args.ctx, tt.args.roleID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.GetAuthorityRoleByID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got == nil {
+				t.Errorf("PGInstance.GetAuthorityRoleByID() expected a role to be returned")
+			}
+		})
+	}
+}
+
+func TestPGInstance_GetAuthorityRoleByName(t *testing.T) {
+	type args struct {
+		ctx       context.Context
+		programID string
+		name      string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: get authority role by name",
+			args: args{
+				ctx:       context.Background(),
+				programID: programID,
+				name:      systemAdminRole,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: role not found in program",
+			args: args{
+				ctx:       context.Background(),
+				programID: uuid.NewString(),
+				name:      systemAdminRole,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.GetAuthorityRoleByName(tt.args.ctx, tt.args.programID, tt.args.name)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.GetAuthorityRoleByName() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && *got.AuthorityRoleID != systemAdminRoleID {
+				t.Errorf("PGInstance.GetAuthorityRoleByName() expected role %s, got %s", systemAdminRoleID, *got.AuthorityRoleID)
+			}
+		})
+	}
+}
+
+func TestPGInstance_ListAuthorityRoles(t *testing.T) {
+	type args struct {
+		ctx       context.Context
+		programID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: list program roles",
+			args: args{
+				ctx:       context.Background(),
+				programID: programID,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: invalid program id",
+			args: args{
+				ctx:       context.Background(),
+				programID: "programID",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.ListAuthorityRoles(tt.args.ctx, tt.args.programID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.ListAuthorityRoles() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got) == 0 {
+				t.Errorf("PGInstance.ListAuthorityRoles() expected roles to be returned")
+			}
+		})
+	}
+}
+
+func TestPGInstance_ListAuthorityPermissions(t *testing.T) {
+	got, err := testingDB.ListAuthorityPermissions(context.Background())
+	if err != nil {
+		t.Errorf("PGInstance.ListAuthorityPermissions() error = %v", err)
+		return
+	}
+	if len(got) == 0 {
+		t.Errorf("PGInstance.ListAuthorityPermissions() expected permissions to be returned")
+	}
+}
+
+func TestPGInstance_GetRolePermissions(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		roleID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: get role permissions",
+			args: args{
+				ctx:    context.Background(),
+				roleID: systemAdminRoleID,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: invalid role id",
+			args: args{
+				ctx:    context.Background(),
+				roleID: "roleID",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := testingDB.GetRolePermissions(tt.args.ctx, tt.args.roleID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.GetRolePermissions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPGInstance_GetProfileRoles(t *testing.T) {
+	type args struct {
+		ctx       context.Context
+		userType  enums.UsersType
+		profileID string
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantCount int
+		wantErr   bool
+	}{
+		{
+			name: "Happy case: get staff roles",
+			args: args{
+				ctx:       context.Background(),
+				userType:  enums.StaffUser,
+				profileID: staffWithRolesID,
+			},
+			wantCount: 1,
+			wantErr:   false,
+		},
+		{
+			name: "Happy case: get caregiver roles",
+			args: args{
+				ctx:       context.Background(),
+				userType:  enums.CaregiverUser,
+				profileID: caregiverWithRolesID,
+			},
+			wantCount: 1,
+			wantErr:   false,
+		},
+		{
+			name: "Sad case: unsupported user type",
+			args: args{
+				ctx:       context.Background(),
+				userType:  enums.HealthcareWorkerUser,
+				profileID: staffWithRolesID,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.GetProfileRoles(tt.args.ctx, tt.args.userType, tt.args.profileID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.GetProfileRoles() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got) != tt.wantCount {
+				t.Errorf("PGInstance.GetProfileRoles() got %v roles, want %v", len(got), tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestPGInstance_CheckIfStaffHasFacilityAccess(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		userID     string
+		programID  string
+		facilityID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case: staff is assigned to facility",
+			args: args{
+				ctx:        context.Background(),
+				userID:     userIDtoAssignStaff,
+				programID:  programID,
+				facilityID: facilityID,
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Happy case: staff is not assigned to facility",
+			args: args{
+				ctx:        context.Background(),
+				userID:     userIDtoAssignStaff,
+				programID:  programID,
+				facilityID: uuid.NewString(),
+			},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name: "Happy case: staff is not assigned to facility in program",
+			args: args{
+				ctx:        context.Background(),
+				userID:     userIDtoAssignStaff,
+				programID:  uuid.NewString(),
+				facilityID: facilityID,
+			},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name: "Sad case: invalid facility id",
+			args: args{
+				ctx:        context.Background(),
+				userID:     userIDtoAssignStaff,
+				programID:  programID,
+				facilityID: "facilityID",
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.CheckIfStaffHasFacilityAccess(tt.args.ctx, tt.args.userID, tt.args.programID, tt.args.facilityID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.CheckIfStaffHasFacilityAccess() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("PGInstance.CheckIfStaffHasFacilityAccess() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPGInstance_ListAuditLogs(t *testing.T) {
+	recordType := enums.AuditLogFacilityAccessDenied
+	from := time.Now().Add(-time.Hour * 24)
+	to := time.Now().Add(time.Hour)
+	invalidProgramID := "programID"
+
+	type args struct {
+		ctx            context.Context
+		organisationID string
+		filter         *dto.AuditLogFilterInput
+		pagination     *domain.Pagination
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: list audit logs",
+			args: args{
+				ctx:            context.Background(),
+				organisationID: orgID,
+				pagination:     &domain.Pagination{Limit: 10, CurrentPage: 1},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case: list audit logs with filters",
+			args: args{
+				ctx:            context.Background(),
+				organisationID: orgID,
+				filter: &dto.AuditLogFilterInput{
+					RecordType: &recordType,
+					ProgramID:  &programID,
+					From:       &from,
+					To:         &to,
+				},
+				pagination: &domain.Pagination{Limit: 10, CurrentPage: 1},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: invalid program id filter",
+			args: args{
+				ctx:            context.Background(),
+				organisationID: orgID,
+				filter: &dto.AuditLogFilterInput{
+					ProgramID: &invalidProgramID,
+				},
+				pagination: &domain.Pagination{Limit: 10, CurrentPage: 1},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := testingDB.ListAuditLogs(tt.args.ctx, tt.args.organisationID, tt.args.filter, tt.args.pagination)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.ListAuditLogs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPGInstance_ListAuditLogChain(t *testing.T) {
+	type args struct {
+		ctx            context.Context
+		organisationID string
+		afterSequence  int64
+		limit          int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: list audit log chain",
+			args: args{
+				ctx:            context.Background(),
+				organisationID: orgID,
+				afterSequence:  0,
+				limit:          100,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: invalid organisation id",
+			args: args{
+				ctx:            context.Background(),
+				organisationID: "organisationID",
+				afterSequence:  0,
+				limit:          100,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.ListAuditLogChain(tt.args.ctx, tt.args.organisationID, tt.args.afterSequence, tt.args.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.ListAuditLogChain() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			for i := 1; i < len(got); i++ {
+				if *got[i].Sequence <= *got[i-1].Sequence {
+					t.Errorf("expected the audit log chain to be ordered by sequence")
+				}
+			}
+		})
+	}
+}
+
+func TestPGInstance_CountUnchainedAuditLogs(t *testing.T) {
+	type args struct {
+		ctx            context.Context
+		organisationID string
+		since          time.Time
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: count unchained audit logs",
+			args: args{
+				ctx:            context.Background(),
+				organisationID: orgID,
+				since:          time.Now().Add(-time.Hour),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: invalid organisation id",
+			args: args{
+				ctx:            context.Background(),
+				organisationID: "organisationID",
+				since:          time.Now().Add(-time.Hour),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := testingDB.CountUnchainedAuditLogs(tt.args.ctx, tt.args.organisationID, tt.args.since)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.CountUnchainedAuditLogs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPGInstance_GetUserTOTP(t *testing.T) {
+	if err := testingDB.SaveUserTOTP(context.Background(), &gorm.UserTOTP{Active: true, UserID: userID, Secret: gofakeit.HipsterSentence(5)}); err != nil {
+		t.Errorf("failed to save user TOTP: %v", err)
+		return
+	}
+
+	type args struct {
+		ctx    context.Context
+		userID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: get user TOTP",
+			args: args{
+				ctx:    context.Background(),
+				userID: userID,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: user has no TOTP",
+			args: args{
+				ctx:    context.Background(),
+				userID: uuid.NewString(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.GetUserTOTP(tt.args.ctx, tt.args.userID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.GetUserTOTP() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.UserID != tt.args.userID {
+				t.Errorf("PGInstance.GetUserTOTP() got TOTP for user %v, want %v", got.UserID, tt.args.userID)
+			}
+		})
+	}
+
+	if err := testingDB.DB.Where("user_id", userID).Unscoped().Delete(&gorm.UserTOTP{}).Error; err != nil {
+		t.Errorf("failed to delete user TOTP: %v", err)
+	}
+}
+
+func TestPGInstance_CheckIfUserHasTOTP(t *testing.T) {
+	if err := testingDB.SaveUserTOTP(context.Background(), &gorm.UserTOTP{Active: true, UserID: userID, Secret: gofakeit.HipsterSentence(5), Confirmed: true}); err != nil {
+		t.Errorf("failed to save user TOTP: %v", err)
+		return
+	}
+
+	type args struct {
+		ctx    context.Context
+		userID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case: user has TOTP",
+			args: args{
+				ctx:    context.Background(),
+				userID: userID,
+			},
+			want: true,
+		},
+		{
+			name: "Happy case: user has no TOTP",
+			args: args{
+				ctx:    context.Background(),
+				userID: uuid.NewString(),
+			},
+			want: false,
+		},
+		{
+			name: "Sad case: invalid user id",
+			args: args{
+				ctx:    context.Background(),
+				userID: "userID",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.CheckIfUserHasTOTP(tt.args.ctx, tt.args.userID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.CheckIfUserHasTOTP() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("PGInstance.CheckIfUserHasTOTP() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if err := testingDB.DB.Where("user_id", userID).Unscoped().Delete(&gorm.UserTOTP{}).Error; err != nil {
+		t.Errorf("failed to delete user TOTP: %v", err)
+	}
+}
+
+func TestPGInstance_ListUserRecoveryCodes(t *testing.T) {
+	recoveryCodes := []*gorm.UserRecoveryCode{
+		{
+			Active:     true,
+			UserID:     userID,
+			HashedCode: gofakeit.HipsterSentence(5),
+			Salt:       gofakeit.HipsterSentence(5),
+		},
+	}
+	if err := testingDB.SaveUserRecoveryCodes(context.Background(), userID, recoveryCodes); err != nil {
+		t.Errorf("failed to save user recovery codes: %v", err)
+		return
+	}
+
+	type args struct {
+		ctx    context.Context
+		userID string
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantCount int
+		wantErr   bool
+	}{
+		{
+			name: "Happy case: list user recovery codes",
+			args: args{
+				ctx:    context.Background(),
+				userID: userID,
+			},
+			wantCount: 1,
+		},
+		{
+			name: "Sad case: invalid user id",
+			args: args{
+				ctx:    context.Background(),
+				userID: "userID",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.ListUserRecoveryCodes(tt.args.ctx, tt.args.userID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.ListUserRecoveryCodes() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != tt.wantCount {
+				t.Errorf("PGInstance.ListUserRecoveryCodes() returned %v codes, want %v", len(got), tt.wantCount)
+			}
+		})
+	}
+
+	if err := testingDB.DB.Where("user_id", userID).Unscoped().Delete(&gorm.UserRecoveryCode{}).Error; err != nil {
+		t.Errorf("failed to delete user recovery codes: %v", err)
+	}
+}
+
+func TestPGInstance_CheckIfStaffTOTPIsEnforced(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		userID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case: organisation does not enforce staff TOTP",
+			args: args{
+				ctx:    context.Background(),
+				userID: userIDtoAssignStaff,
+			},
+			want: false,
+		},
+		{
+			name: "Happy case: organisation enforces staff TOTP",
+			args: args{
+				ctx:    context.Background(),
+				userID: userIDtoAssignStaff,
+			},
+			want: true,
+		},
+		{
+			name: "Sad case: invalid user id",
+			args: args{
+				ctx:    context.Background(),
+				userID: "userID",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.name == "Happy case: organisation enforces staff TOTP" {
+				if err := testingDB.DB.Model(&gorm.Organisation{}).Where("id", orgID).Update("enforce_staff_totp", true).Error; err != nil {
+					t.Errorf("failed to update organisation: %v", err)
+					return
+				}
+			}
+
+			got, err := testingDB.CheckIfStaffTOTPIsEnforced(tt.args.ctx, tt.args.userID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.CheckIfStaffTOTPIsEnforced() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("PGInstance.CheckIfStaffTOTPIsEnforced() = %v, want %v", got, tt.want)
+			}
+
+			if tt.name == "Happy case: organisation enforces staff TOTP" {
+				if err := testingDB.DB.Model(&gorm.Organisation{}).Where("id", orgID).Update("enforce_staff_totp", false).Error; err != nil {
+					t.Errorf("failed to update organisation: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestPGInstance_LoginRisk(t *testing.T) {
+	ipAddress := gofakeit.IPv4Address()
+	fingerprint := gofakeit.UUID()
+
+	event := &gorm.LoginEvent{
+		Active:      true,
+		UserID:      userID,
+		Fingerprint: fingerprint,
+		IPAddress:   ipAddress,
+		Country:     "KE",
+		Timestamp:   time.Now(),
+	}
+	device := &gorm.UserDevice{
+		Active:      true,
+		UserID:      userID,
+		Fingerprint: fingerprint,
+		LastLoginAt: time.Now(),
+	}
+	if err := testingDB.RecordLoginEvent(context.Background(), event, device); err != nil {
+		t.Errorf("failed to record login event: %v", err)
+		return
+	}
+
+	t.Run("Happy case: list user devices", func(t *testing.T) {
+		devices, err := testingDB.ListUserDevices(context.Background(), userID)
+		if err != nil {
+			t.Errorf("PGInstance.ListUserDevices() error = %v", err)
+			return
+		}
+		if len(devices) != 1 || devices[0].Fingerprint != fingerprint {
+			t.Errorf("PGInstance.ListUserDevices() = %v, expected the recorded device", devices)
+		}
+	})
+	t.Run("Sad case: list devices of an invalid user id", func(t *testing.T) {
+		if _, err := testingDB.ListUserDevices(context.Background(), "userID"); err == nil {
+			t.Errorf("PGInstance.ListUserDevices() expected an error")
+		}
+	})
+	t.Run("Happy case: list user login countries", func(t *testing.T) {
+		countries, err := testingDB.ListUserLoginCountries(context.Background(), userID)
+		if err != nil {
+			t.Errorf("PGInstance.ListUserLoginCountries() error = %v", err)
+			return
+		}
+		if len(countries) != 1 || countries[0] != "KE" {
+			t.Errorf("PGInstance.ListUserLoginCountries() = %v, want [KE]", countries)
+		}
+	})
+	t.Run("Sad case: list login countries of an invalid user id", func(t *testing.T) {
+		if _, err := testingDB.ListUserLoginCountries(context.Background(), "userID"); err == nil {
+			t.Errorf("PGInstance.ListUserLoginCountries() expected an error")
+		}
+	})
+	t.Run("Happy case: count users logged in from IP address", func(t *testing.T) {
+		count, err := testingDB.CountUsersLoggedInFromIPAddress(context.Background(), ipAddress, time.Now().Add(-time.Hour))
+		if err != nil {
+			t.Errorf("PGInstance.CountUsersLoggedInFromIPAddress() error = %v", err)
+			return
+		}
+		if count != 1 {
+			t.Errorf("PGInstance.CountUsersLoggedInFromIPAddress() = %v, want 1", count)
+		}
+	})
+
+	if err := testingDB.DB.Where("user_id", userID).Unscoped().Delete(&gorm.LoginEvent{}).Error; err != nil {
+		t.Errorf("failed to delete login events: %v", err)
+	}
+	if err := testingDB.DB.Where("user_id", userID).Unscoped().Delete(&gorm.UserDevice{}).Error; err != nil {
+		t.Errorf("failed to delete user devices: %v", err)
+	}
+}
+
+func TestPGInstance_ListDuplicateClientCandidates(t *testing.T) {
+	type args struct {
+		ctx      context.Context
+		clientID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: list duplicate client candidates",
+			args: args{
+				ctx:      context.Background(),
+				clientID: clientID,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case: client not found",
+			args: args{
+				ctx:      context.Background(),
+				clientID: gofakeit.UUID(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case: clients with the same CCC number in separate identifiers",
+			args: args{
+				ctx:      context.Background(),
+				clientID: clientDifferentUserSameProgramID,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.ListDuplicateClientCandidates(tt.args.ctx, tt.args.clientID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.ListDuplicateClientCandidates() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			for _, candidate := range got {
+				if candidate.ClientID == tt.args.clientID {
+					t.Errorf("PGInstance.ListDuplicateClientCandidates() expected the client not to be a candidate")
+				}
+			}
+
+			if tt.name == "Happy case: clients with the same CCC number in separate identifiers" {
+				candidates := map[string]*gorm.DuplicateClientCandidate{}
+				for _, candidate := range got {
+					candidates[candidate.ClientID] = candidate
+				}
+				if candidate, ok := candidates[testClientWithoutCaregiver]; !ok || !candidate.MatchesCCCNumber {
+					t.Errorf("PGInstance.ListDuplicateClientCandidates() expected client %s to match the CCC number", testClientWithoutCaregiver)
+				}
+				if _, ok := candidates[testClientToUpdateConsent]; ok {
+					t.Errorf("PGInstance.ListDuplicateClientCandidates() expected client %s in another program not to be a candidate", testClientToUpdateConsent)
+				}
+			}
+		})
+	}
+}
+
+func TestPGInstance_ListUserContacts(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		userID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: list user contacts",
+			args: args{
+				ctx:    context.Background(),
+				userID: userID,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := testingDB.ListUserContacts(tt.args.ctx, tt.args.userID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.ListUserContacts() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+		})
+	}
+}
+
+func TestPGInstance_ListClientScreeningToolResponses(t *testing.T) {
+	type args struct {
+		ctx      context.Context
+		clientID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: list client screening tool responses",
+			args: args{
+				ctx:      context.Background(),
+				clientID: clientID,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := testingDB.ListClientScreeningToolResponses(tt.args.ctx, tt.args.clientID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.ListClientScreeningToolResponses() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+		})
+	}
+}
+
+func TestPGInstance_ListUserMetrics(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		userID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: list user metrics",
+			args: args{
+				ctx:    context.Background(),
+				userID: userID,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := testingDB.ListUserMetrics(tt.args.ctx, tt.args.userID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.ListUserMetrics() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+		})
+	}
+}
+
+func TestPGInstance_ListUserFeedback(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		userID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: list user feedback",
+			args: args{
+				ctx:    context.Background(),
+				userID: userID,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := testingDB.ListUserFeedback(tt.args.ctx, tt.args.userID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.ListUserFeedback() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+		})
+	}
+}
+
+func TestPGInstance_ListClientsDueForErasure(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		now time.Time
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: list clients due for erasure",
+			args: args{
+				ctx: context.Background(),
+				now: time.Now(),
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := testingDB.ListClientsDueForErasure(tt.args.ctx, tt.args.now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.ListClientsDueForErasure() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPGInstance_ListExpiredCaregiverDelegations(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		now time.Time
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: list expired caregiver delegations",
+			args: args{
+				ctx: context.Background(),
+				now: time.Now(),
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := testingDB.ListExpiredCaregiverDelegations(tt.args.ctx, tt.args.now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.ListExpiredCaregiverDelegations() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPGInstance_GetPendingPhoneChangeRequest(t *testing.T) {
+	err := testingDB.CreatePhoneChangeRequest(context.Background(), &gorm.PhoneChangeRequest{
+		Active:         true,
+		UserID:         userID,
+		Flavour:        feedlib.FlavourConsumer,
+		OldPhoneNumber: testPhone,
+		NewPhoneNumber: gofakeit.Phone(),
+		RequestedBy:    userID,
+		ExpiresAt:      time.Now().Add(time.Minute * 10),
+	})
+	if err != nil {
+		t.Errorf("failed to create phone change request: %v", err)
+		return
+	}
+
+	type args struct {
+		ctx     context.Context
+		userID  string
+		flavour feedlib.Flavour
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: get pending phone change request",
+			args: args{
+				ctx:     context.Background(),
+				userID:  userID,
+				flavour: feedlib.FlavourConsumer,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: user has no pending phone change request",
+			args: args{
+				ctx:     context.Background(),
+				userID:  userID,
+				flavour: feedlib.FlavourPro,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.GetPendingPhoneChangeRequest(tt.args.ctx, tt.args.userID, tt.args.flavour)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.GetPendingPhoneChangeRequest() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got == nil {
+				t.Errorf("expected a phone change request")
+			}
+		})
+	}
+}
+
+func TestPGInstance_GetStaffInProgressServiceRequests(t *testing.T) {
+	type args struct {
+		ctx      context.Context
+		staffIDs []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: get staff in progress service requests",
+			args: args{
+				ctx:      context.Background(),
+				staffIDs: []string{staffID},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case: no staff",
+			args: args{
+				ctx:      context.Background(),
+				staffIDs: []string{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: invalid staff id",
+			args: args{
+				ctx:      context.Background(),
+				staffIDs: []string{"invalid"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.GetStaffInProgressServiceRequests(tt.args.ctx, tt.args.staffIDs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.GetStaffInProgressServiceRequests() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got == nil {
+				t.Errorf("expected service requests not to be nil for %v", tt.name)
+				return
+			}
+		})
+	}
+}
+
+func TestPGInstance_ListOrganisationAdmins(t *testing.T) {
+	type args struct {
+		ctx            context.Context
+		organisationID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: list organisation admins",
+			args: args{
+				ctx:            context.Background(),
+				organisationID: orgID,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: invalid organisation id",
+			args: args{
+				ctx:            context.Background(),
+				organisationID: "invalid",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := testingDB.ListOrganisationAdmins(tt.args.ctx, tt.args.organisationID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.ListOrganisationAdmins() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+		})
+	}
+}
+
+func TestPGInstance_ListFacilityAdmins(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		facilityID string
+		programID  string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: list facility admins",
+			args: args{
+				ctx:        context.Background(),
+				facilityID: facilityID,
+				programID:  programID,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: invalid facility id",
+			args: args{
+				ctx:        context.Background(),
+				facilityID: "invalid",
+				programID:  programID,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := testingDB.ListFacilityAdmins(tt.args.ctx, tt.args.facilityID, tt.args.programID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.ListFacilityAdmins() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+		})
+	}
+}
+
+func TestPGInstance_ListClientTransfers(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		userID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: list client transfers",
+			args: args{
+				ctx:    context.Background(),
+				userID: userIDtoAssignClient,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: invalid user id",
+			args: args{
+				ctx:    context.Background(),
+				userID: "invalid",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := testingDB.ListClientTransfers(tt.args.ctx, tt.args.userID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.ListClientTransfers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPGInstance_ListServiceRequestSLAs(t *testing.T) {
+	invalidProgramID := "invalid"
+
+	type args struct {
+		ctx       context.Context
+		programID *string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: list the service request slas of all programs",
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case: list the service request slas of a program",
+			args: args{
+				ctx:       context.Background(),
+				programID: &programID,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: invalid program id",
+			args: args{
+				ctx:       context.Background(),
+				programID: &invalidProgramID,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := testingDB.ListServiceRequestSLAs(tt.args.ctx, tt.args.programID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.ListServiceRequestSLAs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPGInstance_GetServiceRequestsDueForEscalation(t *testing.T) {
+	// the pending booking request in the second program was created before SLA tracking started in the program
+	requestBeforeSLATrackingID := "26b20a42-cbb8-4553-aedb-c539602d04fc"
+
+	type args struct {
+		ctx          context.Context
+		requestTypes []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: requests created before SLA tracking started are not escalated",
+			args: args{
+				ctx:          context.Background(),
+				requestTypes: []string{enums.ServiceRequestBooking.String(), enums.ServiceRequestTypePinReset.String()},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case: get service requests due for escalation",
+			args: args{
+				ctx:          context.Background(),
+				requestTypes: []string{enums.ServiceRequestTypeRedFlag.String(), enums.ServiceRequestTypePinReset.String()},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case: no request types",
+			args: args{
+				ctx:          context.Background(),
+				requestTypes: []string{},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.GetServiceRequestsDueForEscalation(tt.args.ctx, tt.args.requestTypes)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.GetServiceRequestsDueForEscalation() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.name == "Happy case: requests created before SLA tracking started are not escalated" {
+				found := map[string]bool{}
+				for _, serviceRequest := range got {
+					found[*serviceRequest.ID] = true
+				}
+				if found[requestBeforeSLATrackingID] {
+					t.Errorf("PGInstance.GetServiceRequestsDueForEscalation() returned request %s created before SLA tracking started", requestBeforeSLATrackingID)
+				}
+				if !found[pendingServiceRequestID] {
+					t.Errorf("PGInstance.GetServiceRequestsDueForEscalation() expected request %s to be due for escalation", pendingServiceRequestID)
+				}
+			}
+		})
+	}
+}
+
+func TestPGInstance_ListServiceRequestEscalations(t *testing.T) {
+	type args struct {
+		ctx              context.Context
+		serviceRequestID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: list service request escalations",
+			args: args{
+				ctx:              context.Background(),
+				serviceRequestID: pendingServiceRequestID,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: invalid service request id",
+			args: args{
+				ctx:              context.Background(),
+				serviceRequestID: "invalid",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := testingDB.ListServiceRequestEscalations(tt.args.ctx, tt.args.serviceRequestID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.ListServiceRequestEscalations() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -40,3 +40,37 @@ func ProgramScope(ctx context.Context, tableName string) func(db *gorm.DB) *gorm
 		return db.Where(columnReference, id)
 	}
 }
+
+// StaffClientScope generates a GORM scope that restricts client records to the clients enrolled in the
+// facilities that the logged in staff is assigned to within the program set in the context.
+//
+// The 'tableName' parameter should reference the clients table used in the query e.g `clients_client`.
+// The scope does not filter any records when either the logged in user or the program is missing from the context
+func StaffClientScope(ctx context.Context, tableName string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		userID := utils.GetLoggedInUserID(ctx)
+		if userID == nil {
+			return db
+		}
+		programID, err := utils.GetValueFromContext(ctx, utils.ProgramContextKey)
+		if err != nil {
+			return db
+		}
+
+		staffClients := db.Session(&gorm.Session{NewDB: true}).Model(&ClientFacility{}).
+			Select("clients_clientfacility.client_id").
+			Where("clients_clientfacility.active = ?", true).
+			Where("clients_clientfacility.facility_id IN (?)", staffFacilities(db, *userID, programID))
+
+		columnReference := fmt.Sprintf("%s.id IN (?)", tableName)
+		return db.Where(columnReference, staffClients)
+	}
+}
+
+// staffFacilities is a sub query that selects the facilities a user is assigned to as a staff in a program
+func staffFacilities(db *gorm.DB, userID, programID string) *gorm.DB {
+	return db.Session(&gorm.Session{NewDB: true}).Model(&StaffFacilities{}).
+		Select("staff_staff_facilities.facility_id").
+		Joins("JOIN staff_staff ON staff_staff.id = staff_staff_facilities.staff_id").
+		Where("staff_staff.user_id = ? AND staff_staff.program_id = ? AND staff_staff.active = ?", userID, programID, true)
+}
@@ -125,8 +125,17 @@ type AuditLog struct {
 	RecordType string       `gorm:"column:record_type;not null"`
 	Notes      string       `gorm:"column:notes"`
 	Payload    pgtype.JSONB `gorm:"column:payload"`
+	ActorID    *string      `gorm:"column:actor_id"`
+	TargetID   *string      `gorm:"column:target_id"`
+	TargetType string       `gorm:"column:target_type"`
 
-	OrganisationID string `gorm:"column:organisation_id;not null"`
+	ProgramID      *string `gorm:"column:program_id"`
+	OrganisationID string  `gorm:"column:organisation_id;not null"`
+
+	// Sequence, PreviousHash and Hash chain each entry to the one recorded before it in the same organisation
+	Sequence     *int64  `gorm:"column:sequence"`
+	PreviousHash *string `gorm:"column:previous_hash"`
+	Hash         *string `gorm:"column:hash"`
 }
 
 // BeforeCreate is a hook run before creating a new facility
@@ -473,6 +482,21 @@ type Organisation struct {
 	PostalAddress   string  `gorm:"column:postal_address;not null"`
 	PhysicalAddress string  `gorm:"column:physical_address;not null"`
 	DefaultCountry  string  `gorm:"column:default_country;not null"`
+
+	EnforceStaffTOTP     bool `gorm:"column:enforce_staff_totp;not null"`
+	StaffLoginStepUp     bool `gorm:"column:staff_login_step_up;not null;default:false"`
+	PINMinLength         int  `gorm:"column:pin_min_length;not null;default:4"`
+	PINDisallowSimplePIN bool `gorm:"column:pin_disallow_simple;not null;default:true"`
+	PINHistoryCount      int  `gorm:"column:pin_history_count;not null;default:3"`
+	PINMaxAgeDays        int  `gorm:"column:pin_max_age_days;not null;default:0"`
+
+	SecurityQuestionMaxAttempts int `gorm:"column:security_question_max_attempts;not null;default:3"`
+
+	DataRetentionDays int `gorm:"column:data_retention_days;not null;default:30"`
+
+	// AuditLogSequence and AuditLogHash are the sequence and hash of the last audit log recorded in the organisation
+	AuditLogSequence int64  `gorm:"column:audit_log_sequence;not null;default:0"`
+	AuditLogHash     string `gorm:"column:audit_log_hash;not null;default:''"`
 }
 
 // BeforeCreate is a hook run before creating a new organisation
@@ -556,6 +580,8 @@ type UserOTP struct {
 	OTP         string          `gorm:"column:otp"`
 
 	UserID string `gorm:"column:user_id"`
+
+	DeliveryAttempts []*UserOTPDeliveryAttempt `gorm:"-"`
 }
 
 // BeforeCreate is a hook called before updating UserOTP.
@@ -581,6 +607,372 @@ func (UserOTP) TableName() string {
 	return "users_userotp"
 }
 
+// UserOTPDeliveryAttempt maps the schema for the table that stores the attempts made to send an OTP
+type UserOTPDeliveryAttempt struct {
+	Base
+
+	ID                string     `gorm:"primaryKey;unique;column:id"`
+	OTPID             int        `gorm:"column:otp_id;not null"`
+	Provider          string     `gorm:"column:provider;not null"`
+	ProviderMessageID string     `gorm:"column:provider_message_id"`
+	Status            string     `gorm:"column:status;not null"`
+	Error             string     `gorm:"column:error"`
+	AttemptedAt       time.Time  `gorm:"column:attempted_at;not null"`
+	StatusUpdatedAt   *time.Time `gorm:"column:status_updated_at"`
+}
+
+// BeforeCreate is a hook run before creating an OTP delivery attempt
+func (u *UserOTPDeliveryAttempt) BeforeCreate(tx *gorm.DB) (err error) {
+	ctx := tx.Statement.Context
+	if userID := utils.GetLoggedInUserID(ctx); userID != nil {
+		u.CreatedBy = userID
+	}
+
+	if u.ID == "" {
+		u.ID = uuid.New().String()
+	}
+
+	return
+}
+
+// BeforeUpdate is a hook called before updating an OTP delivery attempt
+func (u *UserOTPDeliveryAttempt) BeforeUpdate(tx *gorm.DB) (err error) {
+	ctx := tx.Statement.Context
+	if userID := utils.GetLoggedInUserID(ctx); userID != nil {
+		u.UpdatedBy = userID
+	}
+	return
+}
+
+// TableName customizes how the table name is generated
+func (UserOTPDeliveryAttempt) TableName() string {
+	return "users_userotpdelivery"
+}
+
+// UserTOTP maps the schema for the table that stores a user's time-based one-time password (TOTP) enrollment
+type UserTOTP struct {
+	Base
+
+	ID             string     `gorm:"primaryKey;unique;column:id"`
+	Active         bool       `gorm:"column:active;not null"`
+	UserID         string     `gorm:"column:user_id;unique;not null"`
+	Secret         string     `gorm:"column:secret;not null"`
+	Confirmed      bool       `gorm:"column:confirmed;not null"`
+	ConfirmedAt    *time.Time `gorm:"column:confirmed_at"`
+	FailedAttempts int        `gorm:"column:failed_attempts;not null"`
+	LastUsedStep   *int64     `gorm:"column:last_used_step"`
+}
+
+// BeforeCreate is a hook run before creating a user TOTP enrollment
+func (u *UserTOTP) BeforeCreate(tx *gorm.DB) (err error) {
+	ctx := tx.Statement.Context
+	if userID := utils.GetLoggedInUserID(ctx); userID != nil {
+		u.CreatedBy = userID
+	}
+
+	if u.ID == "" {
+		u.ID = uuid.New().String()
+	}
+
+	return
+}
+
+// BeforeUpdate is a hook called before updating a user TOTP enrollment
+func (u *UserTOTP) BeforeUpdate(tx *gorm.DB) (err error) {
+	ctx := tx.Statement.Context
+	if userID := utils.GetLoggedInUserID(ctx); userID != nil {
+		u.UpdatedBy = userID
+	}
+	return
+}
+
+// TableName customizes how the table name is generated
+func (UserTOTP) TableName() string {
+	return "users_usertotp"
+}
+
+// UserRecoveryCode maps the schema for the table that stores the hashed single use codes
+// a user can log in with when they cannot access their authenticator app
+type UserRecoveryCode struct {
+	Base
+
+	ID         string     `gorm:"primaryKey;unique;column:id"`
+	Active     bool       `gorm:"column:active;not null"`
+	UserID     string     `gorm:"column:user_id;not null"`
+	HashedCode string     `gorm:"column:hashed_code;not null"`
+	Salt       string     `gorm:"column:salt;not null"`
+	UsedAt     *time.Time `gorm:"column:used_at"`
+}
+
+// BeforeCreate is a hook run before creating a user recovery code
+func (u *UserRecoveryCode) BeforeCreate(tx *gorm.DB) (err error) {
+	ctx := tx.Statement.Context
+	if userID := utils.GetLoggedInUserID(ctx); userID != nil {
+		u.CreatedBy = userID
+	}
+
+	if u.ID == "" {
+		u.ID = uuid.New().String()
+	}
+
+	return
+}
+
+// BeforeUpdate is a hook called before updating a user recovery code
+func (u *UserRecoveryCode) BeforeUpdate(tx *gorm.DB) (err error) {
+	ctx := tx.Statement.Context
+	if userID := utils.GetLoggedInUserID(ctx); userID != nil {
+		u.UpdatedBy = userID
+	}
+	return
+}
+
+// TableName customizes how the table name is generated
+func (UserRecoveryCode) TableName() string {
+	return "users_userrecoverycode"
+}
+
+// UserDevice maps the schema for the table that stores the devices that a user has logged in from
+type UserDevice struct {
+	Base
+
+	ID            string    `gorm:"primaryKey;unique;column:id"`
+	Active        bool      `gorm:"column:active;not null"`
+	UserID        string    `gorm:"column:user_id;not null"`
+	Fingerprint   string    `gorm:"column:fingerprint;not null"`
+	DeviceID      string    `gorm:"column:device_id"`
+	DeviceName    string    `gorm:"column:device_name"`
+	UserAgent     string    `gorm:"column:user_agent"`
+	LastIPAddress string    `gorm:"column:last_ip_address"`
+	LastCountry   string    `gorm:"column:last_country"`
+	LastLoginAt   time.Time `gorm:"column:last_login_at;not null"`
+}
+
+// BeforeCreate is a hook run before creating a user device
+func (u *UserDevice) BeforeCreate(tx *gorm.DB) (err error) {
+	ctx := tx.Statement.Context
+	if userID := utils.GetLoggedInUserID(ctx); userID != nil {
+		u.CreatedBy = userID
+	}
+
+	if u.ID == "" {
+		u.ID = uuid.New().String()
+	}
+
+	return
+}
+
+// TableName customizes how the table name is generated
+func (UserDevice) TableName() string {
+	return "users_userdevice"
+}
+
+// LoginEvent maps the schema for the table that stores the successful logins and the anomalies detected for them
+type LoginEvent struct {
+	Base
+
+	ID          string         `gorm:"primaryKey;unique;column:id"`
+	Active      bool           `gorm:"column:active;not null"`
+	UserID      string         `gorm:"column:user_id;not null"`
+	Fingerprint string         `gorm:"column:fingerprint;not null"`
+	IPAddress   string         `gorm:"column:ip_address"`
+	Country     string         `gorm:"column:country"`
+	RiskFlags   pq.StringArray `gorm:"type:text[];column:risk_flags"`
+	SteppedUp   bool           `gorm:"column:stepped_up;not null"`
+	Timestamp   time.Time      `gorm:"column:timestamp;not null"`
+}
+
+// BeforeCreate is a hook run before recording a login event
+func (l *LoginEvent) BeforeCreate(tx *gorm.DB) (err error) {
+	ctx := tx.Statement.Context
+	if userID := utils.GetLoggedInUserID(ctx); userID != nil {
+		l.CreatedBy = userID
+	}
+
+	if l.ID == "" {
+		l.ID = uuid.New().String()
+	}
+
+	return
+}
+
+// TableName customizes how the table name is generated
+func (LoginEvent) TableName() string {
+	return "users_loginevent"
+}
+
+// PhoneChangeRequest maps the schema for the table that stores the requests to change a user's phone number
+type PhoneChangeRequest struct {
+	Base
+
+	ID             string          `gorm:"primaryKey;unique;column:id"`
+	Active         bool            `gorm:"column:active;not null"`
+	UserID         string          `gorm:"column:user_id;not null"`
+	Flavour        feedlib.Flavour `gorm:"column:flavour;not null"`
+	OldPhoneNumber string          `gorm:"column:old_phone_number;not null"`
+	NewPhoneNumber string          `gorm:"column:new_phone_number;not null"`
+	RequestedBy    string          `gorm:"column:requested_by;not null"`
+	Assisted       bool            `gorm:"column:assisted;not null"`
+	Reason         string          `gorm:"column:reason"`
+	ExpiresAt      time.Time       `gorm:"column:expires_at;not null"`
+	ConfirmedAt    *time.Time      `gorm:"column:confirmed_at"`
+}
+
+// BeforeCreate is a hook run before creating a phone change request
+func (p *PhoneChangeRequest) BeforeCreate(tx *gorm.DB) (err error) {
+	ctx := tx.Statement.Context
+	if userID := utils.GetLoggedInUserID(ctx); userID != nil {
+		p.CreatedBy = userID
+	}
+
+	if p.ID == "" {
+		p.ID = uuid.New().String()
+	}
+
+	return
+}
+
+// TableName customizes how the table name is generated
+func (PhoneChangeRequest) TableName() string {
+	return "users_phonechangerequest"
+}
+
+// ClientTransfer maps the schema for the table that stores the history of client transfers
+type ClientTransfer struct {
+	Base
+
+	ID                 string         `gorm:"primaryKey;unique;column:id"`
+	Active             bool           `gorm:"column:active;not null"`
+	UserID             string         `gorm:"column:user_id;not null"`
+	ClientID           string         `gorm:"column:client_id;not null"`
+	NewClientID        string         `gorm:"column:new_client_id;not null"`
+	FromFacilityID     string         `gorm:"column:from_facility_id;not null"`
+	ToFacilityID       string         `gorm:"column:to_facility_id;not null"`
+	FromProgramID      string         `gorm:"column:from_program_id;not null"`
+	ToProgramID        string         `gorm:"column:to_program_id;not null"`
+	FromOrganisationID string         `gorm:"column:from_organisation_id;not null"`
+	ToOrganisationID   string         `gorm:"column:to_organisation_id;not null"`
+	Reason             string         `gorm:"column:reason;not null"`
+	CarriedHistory     pq.StringArray `gorm:"type:text[];column:carried_history"`
+	ClientConsented    bool           `gorm:"column:client_consented;not null"`
+	TransferredBy      string         `gorm:"column:transferred_by;not null"`
+	TransferredAt      time.Time      `gorm:"column:transferred_at;not null"`
+}
+
+// BeforeCreate is a hook run before creating a client transfer
+func (c *ClientTransfer) BeforeCreate(tx *gorm.DB) (err error) {
+	ctx := tx.Statement.Context
+	if userID := utils.GetLoggedInUserID(ctx); userID != nil {
+		c.CreatedBy = userID
+	}
+
+	if c.ID == "" {
+		c.ID = uuid.New().String()
+	}
+
+	return
+}
+
+// TableName customizes how the table name is generated
+func (ClientTransfer) TableName() string {
+	return "clients_clienttransfer"
+}
+
+// ServiceRequestSLA is how long a program's service requests of a type can wait before they are escalated
+type ServiceRequestSLA struct {
+	Base
+
+	ID              string `gorm:"primaryKey;unique;column:id"`
+	Active          bool   `gorm:"column:active;not null"`
+	ProgramID       string `gorm:"column:program_id;not null"`
+	OrganisationID  string `gorm:"column:organisation_id;not null"`
+	RequestType     string `gorm:"column:request_type;not null"`
+	ResolutionHours int    `gorm:"column:resolution_hours;not null"`
+	EscalationHours int    `gorm:"column:escalation_hours;not null"`
+}
+
+// BeforeCreate is a hook run before creating a service request SLA
+func (s *ServiceRequestSLA) BeforeCreate(tx *gorm.DB) (err error) {
+	ctx := tx.Statement.Context
+	if userID := utils.GetLoggedInUserID(ctx); userID != nil {
+		s.CreatedBy = userID
+		s.UpdatedBy = userID
+	}
+
+	if s.ID == "" {
+		s.ID = uuid.New().String()
+	}
+
+	return
+}
+
+// TableName customizes how the table name is generated
+func (ServiceRequestSLA) TableName() string {
+	return "common_servicerequestsla"
+}
+
+// ServiceRequestEscalation records a service request that breached its SLA being escalated
+type ServiceRequestEscalation struct {
+	Base
+
+	ID                    string         `gorm:"primaryKey;unique;column:id"`
+	Active                bool           `gorm:"column:active;not null"`
+	ServiceRequestID      string         `gorm:"column:service_request_id;not null"`
+	Level                 string         `gorm:"column:level;not null"`
+	Recipients            pq.StringArray `gorm:"type:text[];column:recipients"`
+	PatientSafetyIncident bool           `gorm:"column:patient_safety_incident;not null"`
+	EscalatedAt           time.Time      `gorm:"column:escalated_at;not null"`
+	ProgramID             string         `gorm:"column:program_id;not null"`
+	OrganisationID        string         `gorm:"column:organisation_id;not null"`
+}
+
+// BeforeCreate is a hook run before creating a service request escalation
+func (s *ServiceRequestEscalation) BeforeCreate(tx *gorm.DB) (err error) {
+	if s.ID == "" {
+		s.ID = uuid.New().String()
+	}
+
+	return
+}
+
+// TableName customizes how the table name is generated
+func (ServiceRequestEscalation) TableName() string {
+	return "clients_servicerequestescalation"
+}
+
+// RateLimitEvent records a request checked against a rate limit rule.
+// The events within a rule's window are counted to decide whether the next request with the same key is allowed
+type RateLimitEvent struct {
+	Base
+
+	ID        string    `gorm:"primaryKey;unique;column:id"`
+	Active    bool      `gorm:"column:active;not null"`
+	Action    string    `gorm:"column:action;not null"`
+	KeyType   string    `gorm:"column:key_type;not null"`
+	KeyValue  string    `gorm:"column:key_value;not null"`
+	Allowed   bool      `gorm:"column:allowed;not null"`
+	Timestamp time.Time `gorm:"column:timestamp;not null"`
+}
+
+// BeforeCreate is a hook run before recording a rate limit event
+func (r *RateLimitEvent) BeforeCreate(tx *gorm.DB) (err error) {
+	ctx := tx.Statement.Context
+	if userID := utils.GetLoggedInUserID(ctx); userID != nil {
+		r.CreatedBy = userID
+	}
+
+	if r.ID == "" {
+		r.ID = uuid.New().String()
+	}
+
+	return
+}
+
+// TableName customizes how the table name is generated
+func (RateLimitEvent) TableName() string {
+	return "common_ratelimitevent"
+}
+
 // SecurityQuestionResponse maps the schema for the table that stores the security question
 // responses
 type SecurityQuestionResponse struct {
@@ -643,6 +1035,8 @@ type Client struct {
 	FHIRPatientID           *string        `gorm:"column:fhir_patient_id"`
 	HealthRecordID          *string        `gorm:"column:emr_health_record_id"`
 	ClientCounselled        bool           `gorm:"column:counselled"`
+	ErasureRequestedAt      *time.Time     `gorm:"column:erasure_requested_at"`
+	AnonymizedAt            *time.Time     `gorm:"column:anonymized_at"`
 
 	UserID         *string `gorm:"column:user_id;not null"`
 	User           User    `gorm:"ForeignKey:user_id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;not null"`
@@ -717,6 +1111,16 @@ func (Client) TableName() string {
 	return "clients_client"
 }
 
+// DuplicateClientCandidate is a client that shares identifying details with another client in the same organisation.
+// It is the result of a query rather than a table
+type DuplicateClientCandidate struct {
+	ClientID           string `gorm:"column:client_id"`
+	MatchesCCCNumber   bool   `gorm:"column:matches_ccc_number"`
+	MatchesPhone       bool   `gorm:"column:matches_phone"`
+	MatchesName        bool   `gorm:"column:matches_name"`
+	MatchesDateOfBirth bool   `gorm:"column:matches_date_of_birth"`
+}
+
 // ClientFacility represents the client facility table
 type ClientFacility struct {
 	Base
@@ -882,6 +1286,10 @@ type ClientServiceRequest struct {
 	FacilityID     string     `gorm:"column:facility_id"`
 	ClientID       string     `gorm:"column:client_id"`
 	CaregiverID    *string    `gorm:"column:caregiver_id"`
+
+	SLABreachedAt         *time.Time `gorm:"column:sla_breached_at"`
+	EscalationLevel       *string    `gorm:"column:escalation_level"`
+	PatientSafetyIncident bool       `gorm:"column:patient_safety_incident"`
 }
 
 // BeforeCreate is a hook called before creating a service request.
@@ -1008,7 +1416,10 @@ type AuthorityRole struct {
 	Base
 	AuthorityRoleID *string `gorm:"column:id"`
 	Name            string  `gorm:"column:name"`
+	Description     string  `gorm:"column:description"`
 	Active          bool    `gorm:"column:active"`
+	IsSystemRole    bool    `gorm:"column:is_system_role"`
+	UserType        string  `gorm:"column:user_type"`
 
 	OrganisationID string `gorm:"column:organisation_id"`
 	ProgramID      string `gorm:"column:program_id"`
@@ -1045,6 +1456,7 @@ func (AuthorityRole) TableName() string {
 type AuthorityPermission struct {
 	Base
 	AuthorityPermissionID *string `gorm:"column:id"`
+	Active                bool    `gorm:"column:active"`
 	Name                  string  `gorm:"column:name"`
 	Description           string  `gorm:"column:description"`
 	Category              string  `gorm:"column:category"`
@@ -1101,12 +1513,35 @@ func (AuthorityRoleClient) TableName() string {
 	return "authority_authorityrole_clients"
 }
 
+// AuthorityRoleStaff is the gorms authority role staff model
+type AuthorityRoleStaff struct {
+	ID      int     `gorm:"primaryKey;column:id;autoincrement"`
+	StaffID *string `gorm:"column:staff_id"`
+	RoleID  *string `gorm:"column:authorityrole_id"`
+}
+
+// TableName references the table that we map data from
+func (AuthorityRoleStaff) TableName() string {
+	return "authority_authorityrole_staff"
+}
+
+// AuthorityRoleCaregiver is the gorms authority role caregiver model
+type AuthorityRoleCaregiver struct {
+	ID          int     `gorm:"primaryKey;column:id;autoincrement"`
+	CaregiverID *string `gorm:"column:caregiver_id"`
+	RoleID      *string `gorm:"column:authorityrole_id"`
+}
+
+// TableName references the table that we map data from
+func (AuthorityRoleCaregiver) TableName() string {
+	return "authority_authorityrole_caregivers"
+}
+
 // AuthorityRolePermission is the gorms authority role permission model
 type AuthorityRolePermission struct {
 	ID           int     `gorm:"primaryKey;column:id;autoincrement"`
 	PermissionID *string `gorm:"column:authoritypermission_id"`
 	RoleID       *string `gorm:"column:authorityrole_id"`
-	Active       bool    `gorm:"column:active"`
 }
 
 // TableName references the table that we map data from
@@ -1861,6 +2296,13 @@ type CaregiverClient struct {
 	OrganisationID string `gorm:"column:organisation_id;not null"`
 	AssignedBy     string `gorm:"column:assigned_by;not null"`
 	ProgramID      string `gorm:"column:program_id"`
+
+	StartsAt  *time.Time     `gorm:"column:starts_at"`
+	EndsAt    *time.Time     `gorm:"column:ends_at"`
+	Abilities pq.StringArray `gorm:"type:text[];column:abilities"`
+	RevokedAt *time.Time     `gorm:"column:revoked_at"`
+	RevokedBy *string        `gorm:"column:revoked_by"`
+	ExpiredAt *time.Time     `gorm:"column:expired_at"`
 }
 
 // BeforeCreate is a hook run before creating a caregiver client
@@ -1993,6 +2435,69 @@ func (a *AuthorizationCode) BeforeCreate(tx *gorm.DB) (err error) {
 	return nil
 }
 
+// OpenIDConnectSession is the authorization request that an ID token is issued for when an authorization code is exchanged
+type OpenIDConnectSession struct {
+	Base
+
+	ID     string `gorm:"primarykey"`
+	Active bool   `gorm:"column:active"`
+	Code   string `gorm:"column:code"`
+
+	RequestedAt       time.Time      `gorm:"column:requested_at"`
+	RequestedScopes   pq.StringArray `gorm:"type:varchar(256)[];column:requested_scopes"`
+	GrantedScopes     pq.StringArray `gorm:"type:varchar(256)[];column:granted_scopes"`
+	Form              pgtype.JSONB   `gorm:"type:jsonb;column:form;default:'{}'"`
+	RequestedAudience pq.StringArray `gorm:"type:varchar(256)[];column:requested_audience"`
+	GrantedAudience   pq.StringArray `gorm:"type:varchar(256)[];column:granted_audience"`
+
+	SessionID string `gorm:"column:session_id"`
+	Session   Session
+	ClientID  string `gorm:"column:client_id"`
+	Client    OauthClient
+}
+
+// TableName references the table name in the database
+func (OpenIDConnectSession) TableName() string {
+	return "oauth_openid_connect_session"
+}
+
+// BeforeCreate is a hook run before creating
+func (o *OpenIDConnectSession) BeforeCreate(tx *gorm.DB) (err error) {
+	if o.ID == "" {
+		o.ID = uuid.New().String()
+	}
+
+	return nil
+}
+
+// OauthSigningKey is an RSA key that ID tokens are signed with. The private key is encrypted
+type OauthSigningKey struct {
+	Base
+
+	ID         string    `gorm:"primarykey"`
+	Active     bool      `gorm:"column:active"`
+	KeyID      string    `gorm:"column:key_id;unique"`
+	Algorithm  string    `gorm:"column:algorithm"`
+	PrivateKey string    `gorm:"column:private_key"`
+	PublicKey  string    `gorm:"column:public_key"`
+	RotateAt   time.Time `gorm:"column:rotate_at"`
+	ExpiresAt  time.Time `gorm:"column:expires_at"`
+}
+
+// TableName references the table name in the database
+func (OauthSigningKey) TableName() string {
+	return "oauth_signing_key"
+}
+
+// BeforeCreate is a hook run before creating
+func (o *OauthSigningKey) BeforeCreate(tx *gorm.DB) (err error) {
+	if o.ID == "" {
+		o.ID = uuid.New().String()
+	}
+
+	return nil
+}
+
 type OauthClient struct {
 	Base
 
@@ -2008,6 +2513,8 @@ type OauthClient struct {
 	Grants                  pq.StringArray `gorm:"type:varchar(256)[];column:grants"`
 	ResponseTypes           pq.StringArray `gorm:"type:varchar(256)[];column:response_types"`
 	TokenEndpointAuthMethod string         `gorm:"column:token_endpoint_auth_method"`
+	RegistrationAccessToken string         `gorm:"column:registration_access_token"`
+	MFLCode                 *string        `gorm:"column:mfl_code"`
 }
 
 // TableName references the table name in the database
@@ -2130,6 +2637,14 @@ type Session struct {
 
 	UserID string `gorm:"column:user_id;default:null"`
 	User   User
+
+	DeviceID   string     `gorm:"column:device_id"`
+	DeviceName string     `gorm:"column:device_name"`
+	UserAgent  string     `gorm:"column:user_agent"`
+	IPAddress  string     `gorm:"column:ip_address"`
+	PushToken  string     `gorm:"column:push_token"`
+	LastSeenAt *time.Time `gorm:"column:last_seen_at"`
+	RevokedAt  *time.Time `gorm:"column:revoked_at"`
 }
 
 // TableName references the table name in the database
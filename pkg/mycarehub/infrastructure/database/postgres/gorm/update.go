@@ -9,6 +9,7 @@ import (
 	"github.com/savannahghi/feedlib"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // Update represents all `update` operations to the database
@@ -44,7 +45,21 @@ type Update interface {
 	UpdateAuthorizationCode(ctx context.Context, code *AuthorizationCode, updateData map[string]interface{}) error
 	UpdateAccessToken(ctx context.Context, code *AccessToken, updateData map[string]interface{}) error
 	UpdateRefreshToken(ctx context.Context, code *RefreshToken, updateData map[string]interface{}) error
+	UpdateOauthClient(ctx context.Context, client *OauthClient, updateData map[string]interface{}) error
 	UpdateBooking(ctx context.Context, booking *Booking, updateData map[string]interface{}) error
+	UpdateUserTOTP(ctx context.Context, userTOTP *UserTOTP, updateData map[string]interface{}) error
+	UseUserRecoveryCode(ctx context.Context, recoveryCode *UserRecoveryCode) error
+	UpdateOrganisation(ctx context.Context, organisation *Organisation, updateData map[string]interface{}) error
+	UpdateOTPDeliveryStatus(ctx context.Context, provider string, providerMessageID string, status string, at time.Time) (bool, error)
+	UpdateSession(ctx context.Context, session *Session, updateData map[string]interface{}) error
+	RevokeSessions(ctx context.Context, sessionIDs []string, revokedAt time.Time) error
+	ResetSecurityQuestionResponses(ctx context.Context, userID string) error
+	MergeClients(ctx context.Context, survivingClientID string, duplicateClientID string) (map[string]int64, error)
+	MarkClientForErasure(ctx context.Context, clientID string, userID *string, requestedAt time.Time) error
+	EndClientCaregiverDelegations(ctx context.Context, clientID string, endsAt time.Time) (int64, error)
+	ConfirmPhoneChange(ctx context.Context, request *PhoneChangeRequest, confirmedAt time.Time) error
+	OffboardStaff(ctx context.Context, userID string, staffIDs []string, serviceRequests map[string]*string, deactivateUser bool, offboardedAt time.Time) error
+	EscalateServiceRequest(ctx context.Context, escalation *ServiceRequestEscalation) error
 }
 
 // ReactivateFacility performs the actual re-activation of the facility in the database
@@ -626,6 +641,16 @@ func (db *PGInstance) UpdateRefreshToken(ctx context.Context, code *RefreshToken
 	return nil
 }
 
+// UpdateOauthClient updates the details of a given oauth client
+func (db *PGInstance) UpdateOauthClient(ctx context.Context, client *OauthClient, updateData map[string]interface{}) error {
+	err := db.DB.WithContext(ctx).Model(client).Updates(updateData).Error
+	if err != nil {
+		return fmt.Errorf("failed to update oauth client: %v", err)
+	}
+
+	return nil
+}
+
 // UpdateBooking is used to update booking data given the model and data to used to update the record
 func (db *PGInstance) UpdateBooking(ctx context.Context, booking *Booking, updateData map[string]interface{}) error {
 	if err := db.DB.WithContext(ctx).Model(booking).Updates(updateData).Error; err != nil {
@@ -634,3 +659,478 @@ func (db *PGInstance) UpdateBooking(ctx context.Context, booking *Booking, updat
 
 	return nil
 }
+
+// UpdateUserTOTP updates a user's TOTP enrollment
+func (db *PGInstance) UpdateUserTOTP(ctx context.Context, userTOTP *UserTOTP, updateData map[string]interface{}) error {
+	err := db.DB.WithContext(ctx).Model(&UserTOTP{}).Where(&UserTOTP{UserID: userTOTP.UserID}).Updates(updateData).Error
+	if err != nil {
+		return fmt.Errorf("failed to update user TOTP: %w", err)
+	}
+
+	return nil
+}
+
+// UseUserRecoveryCode marks a recovery code as used.
+// It fails if the code has already been used so that the same code cannot be used by concurrent logins
+func (db *PGInstance) UseUserRecoveryCode(ctx context.Context, recoveryCode *UserRecoveryCode) error {
+	tx := db.DB.WithContext(ctx).Model(&UserRecoveryCode{}).
+		Where("id = ? AND used_at IS NULL", recoveryCode.ID).
+		Updates(map[string]interface{}{"used_at": time.Now()})
+	if err := tx.Error; err != nil {
+		return fmt.Errorf("failed to use recovery code: %w", err)
+	}
+
+	if tx.RowsAffected == 0 {
+		return fmt.Errorf("recovery code has already been used")
+	}
+
+	return nil
+}
+
+// UpdateOrganisation updates an organisation's details
+func (db *PGInstance) UpdateOrganisation(ctx context.Context, organisation *Organisation, updateData map[string]interface{}) error {
+	err := db.DB.WithContext(ctx).Model(&Organisation{}).Where(&Organisation{ID: organisation.ID}).Updates(updateData).Error
+	if err != nil {
+		return fmt.Errorf("failed to update organisation: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateOTPDeliveryStatus updates the status of an OTP delivery attempt from a provider's delivery receipt.
+// Attempts that already have a final status are not updated since receipts may be received out of order
+func (db *PGInstance) UpdateOTPDeliveryStatus(ctx context.Context, provider string, providerMessageID string, status string, at time.Time) (bool, error) {
+	finalStatuses := []string{
+		enums.OTPDeliveryStatusFailed.String(),
+		enums.OTPDeliveryStatusDelivered.String(),
+		enums.OTPDeliveryStatusUndelivered.String(),
+	}
+
+	tx := db.DB.WithContext(ctx).Model(&UserOTPDeliveryAttempt{}).
+		Where("provider = ? AND provider_message_id = ? AND status NOT IN ?", provider, providerMessageID, finalStatuses).
+		Updates(map[string]interface{}{"status": status, "status_updated_at": at})
+	if err := tx.Error; err != nil {
+		return false, fmt.Errorf("failed to update OTP delivery status: %w", err)
+	}
+
+	return tx.RowsAffected > 0, nil
+}
+
+// UpdateSession updates the details of a given session
+func (db *PGInstance) UpdateSession(ctx context.Context, session *Session, updateData map[string]interface{}) error {
+	err := db.DB.WithContext(ctx).Model(&Session{}).Where(&Session{ID: session.ID}).Updates(updateData).Error
+	if err != nil {
+		return fmt.Errorf("failed to update session: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeSessions ends the sessions and deactivates all the access and refresh tokens issued for them
+// so that they cannot be used or refreshed
+func (db *PGInstance) RevokeSessions(ctx context.Context, sessionIDs []string, revokedAt time.Time) error {
+	if len(sessionIDs) == 0 {
+		return nil
+	}
+
+	tx := db.DB.WithContext(ctx).Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err := revokeSessions(tx, sessionIDs, revokedAt); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return fmt.Errorf("failed to commit revoke sessions transaction: %w", err)
+	}
+
+	return nil
+}
+
+// revokeSessions ends the sessions and deactivates their access and refresh tokens within the provided transaction
+func revokeSessions(tx *gorm.DB, sessionIDs []string, revokedAt time.Time) error {
+	if len(sessionIDs) == 0 {
+		return nil
+	}
+
+	err := tx.Model(&Session{}).Where("id IN ? AND revoked_at IS NULL", sessionIDs).
+		Updates(map[string]interface{}{"revoked_at": revokedAt}).Error
+	if err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	err = tx.Model(&AccessToken{}).Where("session_id IN ? AND active = ?", sessionIDs, true).
+		Updates(map[string]interface{}{"active": false}).Error
+	if err != nil {
+		return fmt.Errorf("failed to deactivate session access tokens: %w", err)
+	}
+
+	err = tx.Model(&RefreshToken{}).Where("session_id IN ? AND active = ?", sessionIDs, true).
+		Updates(map[string]interface{}{"active": false}).Error
+	if err != nil {
+		return fmt.Errorf("failed to deactivate session refresh tokens: %w", err)
+	}
+
+	return nil
+}
+
+// ResetSecurityQuestionResponses deletes the user's security question responses and clears their failed attempts
+// so that they are asked to set new security questions the next time they log in
+func (db *PGInstance) ResetSecurityQuestionResponses(ctx context.Context, userID string) error {
+	tx := db.DB.WithContext(ctx).Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	err := tx.Unscoped().Where(&SecurityQuestionResponse{UserID: userID}).Delete(&SecurityQuestionResponse{}).Error
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to delete security question responses: %w", err)
+	}
+
+	result := tx.Model(&User{}).Where(&User{UserID: &userID}).Updates(map[string]interface{}{
+		"has_set_security_questions": false,
+		"failed_security_count":      0,
+	})
+	if result.Error != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to update user security questions status: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return fmt.Errorf("user %s does not exist", userID)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return fmt.Errorf("failed to commit reset security question responses transaction: %w", err)
+	}
+
+	return nil
+}
+
+// clientRecordTables are the tables of the records that belong to a client and are moved when the client is merged into another client
+var clientRecordTables = []string{
+	"clients_healthdiaryentry",
+	"clients_servicerequest",
+	"appointments_appointment",
+	"questionnaires_screeningtoolresponse",
+	"service_booking",
+}
+
+// clientLinkTables are the tables that link a client to another record at most once, with the column of the linked record.
+// Links that the surviving client already has are removed from the duplicate client instead of being moved
+var clientLinkTables = map[string]string{
+	"caregivers_caregiver_client": "caregiver_id",
+	"clients_client_facilities":   "facility_id",
+}
+
+// MergeClients moves the records and links of the duplicate client to the surviving client and deactivates the duplicate client.
+// It returns the number of rows moved from each table
+func (db *PGInstance) MergeClients(ctx context.Context, survivingClientID string, duplicateClientID string) (map[string]int64, error) {
+	tx := db.DB.WithContext(ctx).Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// the clients are locked so that neither of them can change while their records are moved
+	var survivingClient, duplicateClient Client
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", survivingClientID).First(&survivingClient).Error; err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to get surviving client %s: %w", survivingClientID, err)
+	}
+
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", duplicateClientID).First(&duplicateClient).Error; err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to get duplicate client %s: %w", duplicateClientID, err)
+	}
+
+	if survivingClient.ProgramID != duplicateClient.ProgramID {
+		tx.Rollback()
+		return nil, fmt.Errorf("clients %s and %s belong to different programs", survivingClientID, duplicateClientID)
+	}
+
+	if !survivingClient.Active || survivingClient.DeletedAt != nil || survivingClient.ErasureRequestedAt != nil || survivingClient.AnonymizedAt != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("surviving client %s is inactive or has been deleted", survivingClientID)
+	}
+
+	if !duplicateClient.Active {
+		tx.Rollback()
+		return nil, fmt.Errorf("duplicate client %s is inactive and may have already been merged", duplicateClientID)
+	}
+
+	moved := map[string]int64{}
+
+	for _, table := range clientRecordTables {
+		result := tx.Table(table).Where("client_id = ?", duplicateClientID).Update("client_id", survivingClientID)
+		if result.Error != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to move %s records: %w", table, result.Error)
+		}
+		moved[table] = result.RowsAffected
+	}
+
+	for table, column := range clientLinkTables {
+		err := tx.Exec(
+			fmt.Sprintf("DELETE FROM %s WHERE client_id = ? AND %s IN (SELECT %s FROM %s WHERE client_id = ?)", table, column, column, table),
+			duplicateClientID, survivingClientID,
+		).Error
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to remove %s links that are already on the surviving client: %w", table, err)
+		}
+
+		result := tx.Table(table).Where("client_id = ?", duplicateClientID).Update("client_id", survivingClientID)
+		if result.Error != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to move %s links: %w", table, result.Error)
+		}
+		moved[table] = result.RowsAffected
+	}
+
+	err := tx.Model(&Client{}).Where("id = ?", duplicateClientID).Updates(map[string]interface{}{"active": false}).Error
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to deactivate duplicate client: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit merge clients transaction: %w", err)
+	}
+
+	return moved, nil
+}
+
+// MarkClientForErasure deactivates a client and records when they asked for their data to be erased.
+// When the user is provided, they have no other profile and they are deactivated as well so that they can no longer log in
+func (db *PGInstance) MarkClientForErasure(ctx context.Context, clientID string, userID *string, requestedAt time.Time) error {
+	tx := db.DB.WithContext(ctx).Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	result := tx.Model(&Client{}).Where("id = ? AND erasure_requested_at IS NULL", clientID).Updates(map[string]interface{}{
+		"active":               false,
+		"erasure_requested_at": requestedAt,
+	})
+	if result.Error != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to mark client for erasure: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return fmt.Errorf("client %s does not exist or is already marked for erasure", clientID)
+	}
+
+	if userID != nil {
+		if err := tx.Model(&User{}).Where("id = ?", *userID).Update("active", false).Error; err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to deactivate user: %w", err)
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return fmt.Errorf("failed to commit mark client for erasure transaction: %w", err)
+	}
+
+	return nil
+}
+
+// EndClientCaregiverDelegations sets the date that the client's caregivers stop being able to act on their behalf.
+// Delegations that were revoked, have expired or already end earlier are left unchanged. It returns the number of delegations updated
+func (db *PGInstance) EndClientCaregiverDelegations(ctx context.Context, clientID string, endsAt time.Time) (int64, error) {
+	result := db.DB.WithContext(ctx).Model(&CaregiverClient{}).
+		Where("client_id = ? AND revoked_at IS NULL AND expired_at IS NULL", clientID).
+		Where("ends_at IS NULL OR ends_at > ?", endsAt).
+		Update("ends_at", endsAt)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to end client caregiver delegations: %w", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
+
+// ConfirmPhoneChange replaces the user's phone contact with the new phone number of the request and marks the request as confirmed.
+// The phone number is marked as verified since the change is only confirmed with an OTP sent to the new phone number
+// and the OTP is invalidated
+func (db *PGInstance) ConfirmPhoneChange(ctx context.Context, request *PhoneChangeRequest, confirmedAt time.Time) error {
+	tx := db.DB.WithContext(ctx).Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	result := tx.Model(&Contact{}).
+		Where("user_id = ? AND contact_type = ? AND contact_value = ?", request.UserID, "PHONE", request.OldPhoneNumber).
+		Update("contact_value", request.NewPhoneNumber)
+	if result.Error != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to update user phone contact: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return fmt.Errorf("the user's phone number has changed since the phone change was requested")
+	}
+
+	err := tx.Model(&User{}).Where("id = ?", request.UserID).Update("is_phone_verified", true).Error
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to mark user phone as verified: %w", err)
+	}
+
+	// the OTP that confirmed the change should not be usable again e.g to verify a login
+	err = tx.Model(&UserOTP{}).Where(&UserOTP{PhoneNumber: request.NewPhoneNumber, Flavour: request.Flavour}).
+		Updates(map[string]interface{}{"is_valid": false}).Error
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to invalidate phone change OTP: %w", err)
+	}
+
+	err = tx.Model(&PhoneChangeRequest{}).Where("id = ?", request.ID).Updates(map[string]interface{}{
+		"active":       false,
+		"confirmed_at": confirmedAt,
+	}).Error
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to confirm phone change request: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// OffboardStaff deactivates a staff's profiles and removes their organisation admin rights. Their in progress service requests
+// are reassigned to the staff they are mapped to or released back to the facility's queue when they are mapped to nil.
+// The user is logged out of all their sessions and is deactivated when deactivateUser is set
+func (db *PGInstance) OffboardStaff(ctx context.Context, userID string, staffIDs []string, serviceRequests map[string]*string, deactivateUser bool, offboardedAt time.Time) error {
+	tx := db.DB.WithContext(ctx).Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if len(staffIDs) > 0 {
+		err := tx.Model(&StaffProfile{}).Where("id IN ?", staffIDs).Updates(map[string]interface{}{
+			"active":                false,
+			"is_organisation_admin": false,
+		}).Error
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to deactivate staff profiles: %w", err)
+		}
+	}
+
+	for requestID, reassignTo := range serviceRequests {
+		updates := map[string]interface{}{
+			"status":            enums.ServiceRequestStatusPending.String(),
+			"in_progress_by_id": nil,
+			"in_progress_at":    nil,
+		}
+		if reassignTo != nil {
+			updates = map[string]interface{}{
+				"in_progress_by_id": *reassignTo,
+				"in_progress_at":    offboardedAt,
+			}
+		}
+
+		// the request is left alone if it was resolved or picked up by someone else in the meantime
+		err := tx.Model(&ClientServiceRequest{}).
+			Where("id = ? AND status = ? AND in_progress_by_id IN ?", requestID, enums.ServiceRequestStatusInProgress.String(), staffIDs).
+			Updates(updates).Error
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to hand over service request %s: %w", requestID, err)
+		}
+	}
+
+	if deactivateUser {
+		if err := tx.Model(&User{}).Where("id = ?", userID).Update("active", false).Error; err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to deactivate user: %w", err)
+		}
+	}
+
+	var sessionIDs []string
+	if err := tx.Model(&Session{}).Where("user_id = ?", userID).Pluck("id", &sessionIDs).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to list user sessions: %w", err)
+	}
+
+	if err := revokeSessions(tx, sessionIDs, offboardedAt); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return fmt.Errorf("failed to commit offboard staff transaction: %w", err)
+	}
+
+	return nil
+}
+
+// EscalateServiceRequest records a service request that breached its SLA being escalated. The request is marked as breached
+// the first time it is escalated and is left alone if it was picked up, resolved or escalated further in the meantime
+func (db *PGInstance) EscalateServiceRequest(ctx context.Context, escalation *ServiceRequestEscalation) error {
+	tx := db.DB.WithContext(ctx).Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// a request is only escalated to the admins after the staff of its facility have been reminded about it
+	previousLevel, previousLevelArgs := "escalation_level IS NULL", []interface{}{}
+	if escalation.Level == enums.ServiceRequestEscalationLevelAdmins.String() {
+		previousLevel, previousLevelArgs = "escalation_level = ?", []interface{}{enums.ServiceRequestEscalationLevelFacilityStaff.String()}
+	}
+
+	updates := map[string]interface{}{
+		"escalation_level": escalation.Level,
+		"sla_breached_at":  gorm.Expr("COALESCE(sla_breached_at, ?)", escalation.EscalatedAt),
+	}
+	if escalation.PatientSafetyIncident {
+		updates["patient_safety_incident"] = true
+	}
+
+	result := tx.Model(&ClientServiceRequest{}).
+		Where("id = ? AND status = ? AND active = ?", escalation.ServiceRequestID, enums.ServiceRequestStatusPending.String(), true).
+		Where(previousLevel, previousLevelArgs...).
+		Updates(updates)
+	if result.Error != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to mark service request as escalated: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return fmt.Errorf("service request %s is no longer due for escalation to %s", escalation.ServiceRequestID, escalation.Level)
+	}
+
+	if err := tx.Create(escalation).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to record service request escalation: %w", err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return fmt.Errorf("failed to commit escalate service request transaction: %w", err)
+	}
+
+	return nil
+}
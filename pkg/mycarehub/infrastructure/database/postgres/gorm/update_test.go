@@ -7,6 +7,7 @@ import (
 
 	"github.com/brianvoe/gofakeit"
 	"github.com/google/uuid"
+	"github.com/savannahghi/enumutils"
 	"github.com/savannahghi/feedlib"
 	"github.com/savannahghi/interserviceclient"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
@@ -1674,6 +1675,164 @@ func TestPGInstance_UpdateRefreshToken(t *testing.T) {
 	}
 }
 
+func TestPGInstance_UpdateOauthClient(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		client     *gorm.OauthClient
+		updateData map[string]interface{}
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "happy case: update client",
+			args: args{
+				ctx: context.Background(),
+				client: &gorm.OauthClient{
+					ID: oauthClientOneID,
+				},
+				updateData: map[string]interface{}{
+					"name": "client-one",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "sad case: invalid id",
+			args: args{
+				ctx: context.Background(),
+				client: &gorm.OauthClient{
+					ID: "invalid",
+				},
+				updateData: map[string]interface{}{
+					"name": "client-one",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testingDB.UpdateOauthClient(tt.args.ctx, tt.args.client, tt.args.updateData); (err != nil) != tt.wantErr {
+				t.Errorf("UpdateOauthClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPGInstance_UpdateSession(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		session    *gorm.Session
+		updateData map[string]interface{}
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "happy case: update session last seen",
+			args: args{
+				ctx: context.Background(),
+				session: &gorm.Session{
+					ID: oauthSessionOneID,
+				},
+				updateData: map[string]interface{}{
+					"last_seen_at": time.Now(),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "sad case: invalid id",
+			args: args{
+				ctx: context.Background(),
+				session: &gorm.Session{
+					ID: "invalid",
+				},
+				updateData: map[string]interface{}{
+					"last_seen_at": time.Now(),
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testingDB.UpdateSession(tt.args.ctx, tt.args.session, tt.args.updateData); (err != nil) != tt.wantErr {
+				t.Errorf("UpdateSession() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPGInstance_RevokeSessions(t *testing.T) {
+	session := &gorm.Session{
+		ID:       uuid.New().String(),
+		ClientID: oauthClientOneID,
+		Username: gofakeit.Username(),
+		Subject:  gofakeit.Name(),
+		UserID:   userIDOauthUser,
+	}
+	if err := testingDB.CreateOrUpdateSession(context.Background(), session); err != nil {
+		t.Errorf("failed to create session: %v", err)
+		return
+	}
+
+	type args struct {
+		ctx        context.Context
+		sessionIDs []string
+		revokedAt  time.Time
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "happy case: revoke sessions",
+			args: args{
+				ctx:        context.Background(),
+				sessionIDs: []string{session.ID},
+				revokedAt:  time.Now(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "happy case: no sessions to revoke",
+			args: args{
+				ctx:        context.Background(),
+				sessionIDs: []string{},
+				revokedAt:  time.Now(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "sad case: invalid session id",
+			args: args{
+				ctx:        context.Background(),
+				sessionIDs: []string{"invalid"},
+				revokedAt:  time.Now(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testingDB.RevokeSessions(tt.args.ctx, tt.args.sessionIDs, tt.args.revokedAt); (err != nil) != tt.wantErr {
+				t.Errorf("RevokeSessions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if err := testingDB.DB.Where("id", session.ID).Unscoped().Delete(&gorm.Session{}).Error; err != nil {
+		t.Errorf("failed to delete session: %v", err)
+	}
+}
+
 func TestPGInstance_UpdateBooking(t *testing.T) {
 	type args struct {
 		ctx        context.Context
@@ -1720,3 +1879,695 @@ func TestPGInstance_UpdateBooking(t *testing.T) {
 		})
 	}
 }
+
+func TestPGInstance_UpdateUserTOTP(t *testing.T) {
+	if err := testingDB.SaveUserTOTP(context.Background(), &gorm.UserTOTP{Active: true, UserID: userID, Secret: gofakeit.HipsterSentence(5)}); err != nil {
+		t.Errorf("failed to save user TOTP: %v", err)
+		return
+	}
+
+	type args struct {
+		ctx        context.Context
+		userTOTP   *gorm.UserTOTP
+		updateData map[string]interface{}
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: confirm user TOTP",
+			args: args{
+				ctx:      context.Background(),
+				userTOTP: &gorm.UserTOTP{UserID: userID},
+				updateData: map[string]interface{}{
+					"confirmed":    true,
+					"confirmed_at": time.Now(),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: invalid column",
+			args: args{
+				ctx:      context.Background(),
+				userTOTP: &gorm.UserTOTP{UserID: userID},
+				updateData: map[string]interface{}{
+					"invalid": true,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testingDB.UpdateUserTOTP(tt.args.ctx, tt.args.userTOTP, tt.args.updateData); (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.UpdateUserTOTP() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if err := testingDB.DB.Where("user_id", userID).Unscoped().Delete(&gorm.UserTOTP{}).Error; err != nil {
+		t.Errorf("failed to delete user TOTP: %v", err)
+	}
+}
+
+func TestPGInstance_UseUserRecoveryCode(t *testing.T) {
+	recoveryCode := &gorm.UserRecoveryCode{
+		Active:     true,
+		UserID:     userID,
+		HashedCode: gofakeit.HipsterSentence(5),
+		Salt:       gofakeit.HipsterSentence(5),
+	}
+	if err := testingDB.SaveUserRecoveryCodes(context.Background(), userID, []*gorm.UserRecoveryCode{recoveryCode}); err != nil {
+		t.Errorf("failed to save user recovery codes: %v", err)
+		return
+	}
+
+	type args struct {
+		ctx          context.Context
+		recoveryCode *gorm.UserRecoveryCode
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: use recovery code",
+			args: args{
+				ctx:          context.Background(),
+				recoveryCode: recoveryCode,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: recovery code has already been used",
+			args: args{
+				ctx:          context.Background(),
+				recoveryCode: recoveryCode,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testingDB.UseUserRecoveryCode(tt.args.ctx, tt.args.recoveryCode); (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.UseUserRecoveryCode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if err := testingDB.DB.Where("user_id", userID).Unscoped().Delete(&gorm.UserRecoveryCode{}).Error; err != nil {
+		t.Errorf("failed to delete user recovery codes: %v", err)
+	}
+}
+
+func TestPGInstance_UpdateOrganisation(t *testing.T) {
+	type args struct {
+		ctx          context.Context
+		organisation *gorm.Organisation
+		updateData   map[string]interface{}
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: update organisation",
+			args: args{
+				ctx:          context.Background(),
+				organisation: &gorm.Organisation{ID: &orgID},
+				updateData: map[string]interface{}{
+					"enforce_staff_totp": false,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: invalid column",
+			args: args{
+				ctx:          context.Background(),
+				organisation: &gorm.Organisation{ID: &orgID},
+				updateData: map[string]interface{}{
+					"invalid": true,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testingDB.UpdateOrganisation(tt.args.ctx, tt.args.organisation, tt.args.updateData); (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.UpdateOrganisation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPGInstance_UpdateOTPDeliveryStatus(t *testing.T) {
+	providerMessageID := gofakeit.UUID()
+	otpInput := &gorm.UserOTP{
+		UserID:      userID,
+		Valid:       true,
+		GeneratedAt: time.Now(),
+		ValidUntil:  time.Now().Add(time.Hour),
+		Channel:     "SMS",
+		PhoneNumber: gofakeit.Phone(),
+		OTP:         "123456",
+		Flavour:     feedlib.FlavourConsumer,
+		DeliveryAttempts: []*gorm.UserOTPDeliveryAttempt{
+			{
+				Provider:          enums.OTPDeliveryProviderTwilio.String(),
+				ProviderMessageID: providerMessageID,
+				Status:            enums.OTPDeliveryStatusSent.String(),
+				AttemptedAt:       time.Now(),
+			},
+		},
+	}
+	if err := testingDB.SaveOTP(context.Background(), otpInput); err != nil {
+		t.Errorf("failed to save OTP: %v", err)
+		return
+	}
+
+	type args struct {
+		ctx               context.Context
+		provider          string
+		providerMessageID string
+		status            string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case: message delivered",
+			args: args{
+				ctx:               context.Background(),
+				provider:          enums.OTPDeliveryProviderTwilio.String(),
+				providerMessageID: providerMessageID,
+				status:            enums.OTPDeliveryStatusDelivered.String(),
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Happy case: final status is not overwritten",
+			args: args{
+				ctx:               context.Background(),
+				provider:          enums.OTPDeliveryProviderTwilio.String(),
+				providerMessageID: providerMessageID,
+				status:            enums.OTPDeliveryStatusSent.String(),
+			},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name: "Happy case: unknown message",
+			args: args{
+				ctx:               context.Background(),
+				provider:          enums.OTPDeliveryProviderSILComms.String(),
+				providerMessageID: providerMessageID,
+				status:            enums.OTPDeliveryStatusDelivered.String(),
+			},
+			want:    false,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.UpdateOTPDeliveryStatus(tt.args.ctx, tt.args.provider, tt.args.providerMessageID, tt.args.status, time.Now())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.UpdateOTPDeliveryStatus() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("PGInstance.UpdateOTPDeliveryStatus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if err := testingDB.DB.Where("otp_id", otpInput.OTPID).Unscoped().Delete(&gorm.UserOTPDeliveryAttempt{}).Error; err != nil {
+		t.Errorf("failed to delete OTP delivery attempts: %v", err)
+	}
+	if err := testingDB.DB.Where("id", otpInput.OTPID).Unscoped().Delete(&gorm.UserOTP{}).Error; err != nil {
+		t.Errorf("failed to delete OTP: %v", err)
+	}
+}
+
+func TestPGInstance_ResetSecurityQuestionResponses(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		userID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: reset security question responses",
+			args: args{
+				ctx:    addRequiredContext(context.Background(), t),
+				userID: userFailedSecurityCountID,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: user not found",
+			args: args{
+				ctx:    addRequiredContext(context.Background(), t),
+				userID: gofakeit.UUID(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testingDB.ResetSecurityQuestionResponses(tt.args.ctx, tt.args.userID); (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.ResetSecurityQuestionResponses() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPGInstance_MergeClients(t *testing.T) {
+	type args struct {
+		ctx               context.Context
+		survivingClientID string
+		duplicateClientID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Sad case: clients belong to different programs",
+			args: args{
+				ctx:               addRequiredContext(context.Background(), t),
+				survivingClientID: clientID2,
+				duplicateClientID: clientSameUserDifferentProgramID,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Happy case: merge clients",
+			args: args{
+				ctx:               addRequiredContext(context.Background(), t),
+				survivingClientID: clientID2,
+				duplicateClientID: clientUnresolvedRequestID,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: surviving client is inactive",
+			args: args{
+				ctx:               addRequiredContext(context.Background(), t),
+				survivingClientID: clientUnresolvedRequestID,
+				duplicateClientID: clientID2,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: duplicate client has already been merged",
+			args: args{
+				ctx:               addRequiredContext(context.Background(), t),
+				survivingClientID: clientID2,
+				duplicateClientID: clientUnresolvedRequestID,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: surviving client not found",
+			args: args{
+				ctx:               addRequiredContext(context.Background(), t),
+				survivingClientID: gofakeit.UUID(),
+				duplicateClientID: clientID2,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: duplicate client not found",
+			args: args{
+				ctx:               addRequiredContext(context.Background(), t),
+				survivingClientID: clientID2,
+				duplicateClientID: gofakeit.UUID(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testingDB.MergeClients(tt.args.ctx, tt.args.survivingClientID, tt.args.duplicateClientID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.MergeClients() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got == nil {
+				t.Errorf("PGInstance.MergeClients() expected moved records to be returned")
+			}
+		})
+	}
+}
+
+func TestPGInstance_MarkClientForErasure(t *testing.T) {
+	type args struct {
+		ctx         context.Context
+		clientID    string
+		userID      *string
+		requestedAt time.Time
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: mark client for erasure",
+			args: args{
+				ctx:         context.Background(),
+				clientID:    clientUnresolvedRequestID,
+				requestedAt: time.Now(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: client already marked for erasure",
+			args: args{
+				ctx:         context.Background(),
+				clientID:    clientUnresolvedRequestID,
+				requestedAt: time.Now(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: client does not exist",
+			args: args{
+				ctx:         context.Background(),
+				clientID:    gofakeit.UUID(),
+				requestedAt: time.Now(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testingDB.MarkClientForErasure(tt.args.ctx, tt.args.clientID, tt.args.userID, tt.args.requestedAt); (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.MarkClientForErasure() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPGInstance_EndClientCaregiverDelegations(t *testing.T) {
+	type args struct {
+		ctx      context.Context
+		clientID string
+		endsAt   time.Time
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: end client caregiver delegations",
+			args: args{
+				ctx:      context.Background(),
+				clientID: testClientWithCaregiver,
+				endsAt:   time.Now().AddDate(0, 1, 0),
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := testingDB.EndClientCaregiverDelegations(tt.args.ctx, tt.args.clientID, tt.args.endsAt)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.EndClientCaregiverDelegations() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPGInstance_ConfirmPhoneChange(t *testing.T) {
+	oldPhone := gofakeit.Phone()
+	userID := userIDtoAssignClient
+	_, err := testingDB.GetOrCreateContact(context.Background(), &gorm.Contact{
+		Active:         true,
+		Type:           "PHONE",
+		Value:          oldPhone,
+		UserID:         &userID,
+		OrganisationID: orgID,
+	})
+	if err != nil {
+		t.Errorf("failed to create contact: %v", err)
+		return
+	}
+
+	request := &gorm.PhoneChangeRequest{
+		Active:         true,
+		UserID:         userID,
+		Flavour:        feedlib.FlavourConsumer,
+		OldPhoneNumber: oldPhone,
+		NewPhoneNumber: gofakeit.Phone(),
+		RequestedBy:    userID,
+		ExpiresAt:      time.Now().Add(time.Minute * 10),
+	}
+	err = testingDB.CreatePhoneChangeRequest(context.Background(), request)
+	if err != nil {
+		t.Errorf("failed to create phone change request: %v", err)
+		return
+	}
+
+	type args struct {
+		ctx     context.Context
+		request *gorm.PhoneChangeRequest
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: confirm phone change",
+			args: args{
+				ctx:     context.Background(),
+				request: request,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: phone number changed since the request",
+			args: args{
+				ctx:     context.Background(),
+				request: request,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testingDB.ConfirmPhoneChange(tt.args.ctx, tt.args.request, time.Now()); (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.ConfirmPhoneChange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPGInstance_OffboardStaff(t *testing.T) {
+	ctx := addRequiredContext(context.Background(), t)
+	currentTime := time.Now()
+
+	user := &gorm.User{
+		Username:              gofakeit.Username(),
+		Name:                  gofakeit.Name(),
+		Gender:                enumutils.GenderMale,
+		Active:                true,
+		DateOfBirth:           &currentTime,
+		CurrentProgramID:      programID,
+		CurrentOrganisationID: orgID,
+	}
+	if err := testingDB.CreateUser(ctx, user); err != nil {
+		t.Errorf("failed to create user: %v", err)
+		return
+	}
+
+	identifier := &gorm.Identifier{
+		Active:              true,
+		Type:                "NATIONAL_ID",
+		Value:               gofakeit.SSN(),
+		Use:                 "OFFICIAL",
+		Description:         "A national ID number",
+		ValidFrom:           currentTime,
+		ValidTo:             currentTime,
+		IsPrimaryIdentifier: true,
+		OrganisationID:      orgID,
+		ProgramID:           programID,
+	}
+	staff, err := testingDB.RegisterExistingUserAsStaff(ctx, identifier, &gorm.StaffProfile{
+		UserID:              *user.UserID,
+		Active:              true,
+		StaffNumber:         gofakeit.SSN(),
+		DefaultFacilityID:   facilityID,
+		OrganisationID:      orgID,
+		ProgramID:           programID,
+		IsOrganisationAdmin: true,
+	})
+	if err != nil {
+		t.Errorf("failed to register staff: %v", err)
+		return
+	}
+
+	serviceRequest := &gorm.ClientServiceRequest{
+		Active:         true,
+		RequestType:    enums.ServiceRequestTypeRedFlag.String(),
+		Request:        gofakeit.Sentence(5),
+		Status:         enums.ServiceRequestStatusInProgress.String(),
+		InProgressAt:   &currentTime,
+		InProgressByID: staff.ID,
+		ClientID:       clientID,
+		OrganisationID: orgID,
+		FacilityID:     facilityID,
+		Meta:           `{}`,
+		ProgramID:      programID,
+	}
+	if err := testingDB.CreateServiceRequest(ctx, serviceRequest); err != nil {
+		t.Errorf("failed to create service request: %v", err)
+		return
+	}
+
+	type args struct {
+		ctx             context.Context
+		userID          string
+		staffIDs        []string
+		serviceRequests map[string]*string
+		deactivateUser  bool
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: offboard staff and release their service requests",
+			args: args{
+				ctx:             ctx,
+				userID:          *user.UserID,
+				staffIDs:        []string{*staff.ID},
+				serviceRequests: map[string]*string{*serviceRequest.ID: nil},
+				deactivateUser:  true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: invalid staff id",
+			args: args{
+				ctx:             ctx,
+				userID:          *user.UserID,
+				staffIDs:        []string{"invalid"},
+				serviceRequests: map[string]*string{},
+				deactivateUser:  true,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testingDB.OffboardStaff(tt.args.ctx, tt.args.userID, tt.args.staffIDs, tt.args.serviceRequests, tt.args.deactivateUser, time.Now()); (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.OffboardStaff() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPGInstance_EscalateServiceRequest(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		escalation *gorm.ServiceRequestEscalation
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: escalate service request to facility staff",
+			args: args{
+				ctx: context.Background(),
+				escalation: &gorm.ServiceRequestEscalation{
+					Active:           true,
+					ServiceRequestID: pendingServiceRequestID,
+					Level:            enums.ServiceRequestEscalationLevelFacilityStaff.String(),
+					Recipients:       []string{},
+					EscalatedAt:      time.Now(),
+					ProgramID:        programID,
+					OrganisationID:   orgID,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: service request already escalated to facility staff",
+			args: args{
+				ctx: context.Background(),
+				escalation: &gorm.ServiceRequestEscalation{
+					Active:           true,
+					ServiceRequestID: pendingServiceRequestID,
+					Level:            enums.ServiceRequestEscalationLevelFacilityStaff.String(),
+					Recipients:       []string{},
+					EscalatedAt:      time.Now(),
+					ProgramID:        programID,
+					OrganisationID:   orgID,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Happy case: escalate service request to admins",
+			args: args{
+				ctx: context.Background(),
+				escalation: &gorm.ServiceRequestEscalation{
+					Active:                true,
+					ServiceRequestID:      pendingServiceRequestID,
+					Level:                 enums.ServiceRequestEscalationLevelAdmins.String(),
+					Recipients:            []string{userID},
+					PatientSafetyIncident: true,
+					EscalatedAt:           time.Now(),
+					ProgramID:             programID,
+					OrganisationID:        orgID,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: escalate a service request that is not pending",
+			args: args{
+				ctx: context.Background(),
+				escalation: &gorm.ServiceRequestEscalation{
+					Active:           true,
+					ServiceRequestID: resolvedServiceRequestID,
+					Level:            enums.ServiceRequestEscalationLevelFacilityStaff.String(),
+					Recipients:       []string{},
+					EscalatedAt:      time.Now(),
+					ProgramID:        programID,
+					OrganisationID:   orgID,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := testingDB.EscalateServiceRequest(tt.args.ctx, tt.args.escalation)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PGInstance.EscalateServiceRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
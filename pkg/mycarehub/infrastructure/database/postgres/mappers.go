@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"github.com/lib/pq"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/gorm"
@@ -92,3 +93,266 @@ func (d *MyCareHubDb) mapProfileObjectToDomain(profileObject *gorm.User) *domain
 	}
 	return createMapUser(profileObject)
 }
+
+// mapAuthorityPermissionToDomain maps the db authority permission to a domain model
+func mapAuthorityPermissionToDomain(permission *gorm.AuthorityPermission) *domain.AuthorityPermission {
+	return &domain.AuthorityPermission{
+		PermissionID: *permission.AuthorityPermissionID,
+		Active:       permission.Active,
+		Name:         permission.Name,
+		Description:  permission.Description,
+		Category:     permission.Category,
+		Scope:        permission.Scope,
+	}
+}
+
+// mapAuthorityRoleToDomain maps the db authority role and the permissions granted to it to a domain model
+func mapAuthorityRoleToDomain(role *gorm.AuthorityRole, permissions []*gorm.AuthorityPermission) *domain.AuthorityRole {
+	rolePermissions := []domain.AuthorityPermission{}
+	for _, permission := range permissions {
+		rolePermissions = append(rolePermissions, *mapAuthorityPermissionToDomain(permission))
+	}
+
+	return &domain.AuthorityRole{
+		AuthorityRoleID: *role.AuthorityRoleID,
+		Name:            role.Name,
+		Description:     role.Description,
+		Active:          role.Active,
+		IsSystemRole:    role.IsSystemRole,
+		UserType:        enums.UsersType(role.UserType),
+		OrganisationID:  role.OrganisationID,
+		ProgramID:       role.ProgramID,
+		Permissions:     rolePermissions,
+	}
+}
+
+// mapAuditLogToDomain maps the db audit log to a domain model
+func mapAuditLogToDomain(auditLog *gorm.AuditLog) *domain.AuditLog {
+	payload := struct {
+		Before map[string]interface{} `json:"before"`
+		After  map[string]interface{} `json:"after"`
+	}{}
+	_ = auditLog.Payload.AssignTo(&payload)
+
+	record := &domain.AuditLog{
+		Active:         auditLog.Active,
+		Timestamp:      auditLog.Timestamp,
+		RecordType:     enums.AuditLogRecordType(auditLog.RecordType),
+		Notes:          auditLog.Notes,
+		TargetType:     enums.AuditLogTargetType(auditLog.TargetType),
+		OrganisationID: auditLog.OrganisationID,
+		Before:         payload.Before,
+		After:          payload.After,
+	}
+	if auditLog.ID != nil {
+		record.ID = *auditLog.ID
+	}
+	if auditLog.ActorID != nil {
+		record.ActorID = *auditLog.ActorID
+	}
+	if auditLog.TargetID != nil {
+		record.TargetID = *auditLog.TargetID
+	}
+	if auditLog.ProgramID != nil {
+		record.ProgramID = *auditLog.ProgramID
+	}
+	if auditLog.Sequence != nil {
+		record.Sequence = *auditLog.Sequence
+	}
+	if auditLog.PreviousHash != nil {
+		record.PreviousHash = *auditLog.PreviousHash
+	}
+	if auditLog.Hash != nil {
+		record.Hash = *auditLog.Hash
+	}
+
+	return record
+}
+
+// mapUserTOTPToDomain maps a user's TOTP enrollment from the database to the domain model
+func mapUserTOTPToDomain(userTOTP *gorm.UserTOTP) *domain.UserTOTP {
+	return &domain.UserTOTP{
+		ID:             userTOTP.ID,
+		UserID:         userTOTP.UserID,
+		Secret:         userTOTP.Secret,
+		Confirmed:      userTOTP.Confirmed,
+		ConfirmedAt:    userTOTP.ConfirmedAt,
+		FailedAttempts: userTOTP.FailedAttempts,
+		LastUsedStep:   userTOTP.LastUsedStep,
+	}
+}
+
+// mapPINPolicy maps an organisation's PIN policy columns to the domain model
+func mapPINPolicy(organisation *gorm.Organisation) domain.PINPolicy {
+	return domain.PINPolicy{
+		MinLength:         organisation.PINMinLength,
+		DisallowSimplePIN: organisation.PINDisallowSimplePIN,
+		HistoryCount:      organisation.PINHistoryCount,
+		MaxAgeDays:        organisation.PINMaxAgeDays,
+
+		SecurityQuestionMaxAttempts: organisation.SecurityQuestionMaxAttempts,
+	}
+}
+
+// mapCaregiverClientToDomain maps a caregiver's delegation to act on behalf of a client from the database to the domain model
+func mapCaregiverClientToDomain(caregiverClient *gorm.CaregiverClient) *domain.CaregiverClient {
+	abilities := []enums.CaregiverAbility{}
+	for _, ability := range caregiverClient.Abilities {
+		abilities = append(abilities, enums.CaregiverAbility(ability))
+	}
+
+	return &domain.CaregiverClient{
+		CaregiverID:        caregiverClient.CaregiverID,
+		ClientID:           caregiverClient.ClientID,
+		Active:             caregiverClient.Active,
+		RelationshipType:   caregiverClient.RelationshipType,
+		CaregiverConsent:   caregiverClient.CaregiverConsent,
+		CaregiverConsentAt: caregiverClient.CaregiverConsentAt,
+		ClientConsent:      caregiverClient.ClientConsent,
+		ClientConsentAt:    caregiverClient.ClientConsentAt,
+		OrganisationID:     caregiverClient.OrganisationID,
+		AssignedBy:         caregiverClient.AssignedBy,
+		ProgramID:          caregiverClient.ProgramID,
+		StartsAt:           caregiverClient.StartsAt,
+		EndsAt:             caregiverClient.EndsAt,
+		Abilities:          abilities,
+		RevokedAt:          caregiverClient.RevokedAt,
+		RevokedBy:          caregiverClient.RevokedBy,
+		ExpiredAt:          caregiverClient.ExpiredAt,
+	}
+}
+
+// mapCaregiverAbilities maps the abilities delegated to a caregiver to the column that stores them
+func mapCaregiverAbilities(abilities []enums.CaregiverAbility) pq.StringArray {
+	values := pq.StringArray{}
+	for _, ability := range abilities {
+		values = append(values, ability.String())
+	}
+
+	return values
+}
+
+// mapPhoneChangeRequestToDomain maps a request to change a user's phone number from the database to the domain model
+func mapPhoneChangeRequestToDomain(request *gorm.PhoneChangeRequest) *domain.PhoneChangeRequest {
+	return &domain.PhoneChangeRequest{
+		ID:             request.ID,
+		UserID:         request.UserID,
+		Flavour:        request.Flavour,
+		OldPhoneNumber: request.OldPhoneNumber,
+		NewPhoneNumber: request.NewPhoneNumber,
+		RequestedBy:    request.RequestedBy,
+		Assisted:       request.Assisted,
+		Reason:         request.Reason,
+		ExpiresAt:      request.ExpiresAt,
+		ConfirmedAt:    request.ConfirmedAt,
+	}
+}
+
+// mapPhoneChangeRequestToTable maps a request to change a user's phone number from the domain model to the database
+func mapPhoneChangeRequestToTable(request *domain.PhoneChangeRequest) *gorm.PhoneChangeRequest {
+	return &gorm.PhoneChangeRequest{
+		ID:             request.ID,
+		Active:         request.ConfirmedAt == nil,
+		UserID:         request.UserID,
+		Flavour:        request.Flavour,
+		OldPhoneNumber: request.OldPhoneNumber,
+		NewPhoneNumber: request.NewPhoneNumber,
+		RequestedBy:    request.RequestedBy,
+		Assisted:       request.Assisted,
+		Reason:         request.Reason,
+		ExpiresAt:      request.ExpiresAt,
+		ConfirmedAt:    request.ConfirmedAt,
+	}
+}
+
+// mapClientTransferToDomain maps a client transfer from the database to the domain model
+func mapClientTransferToDomain(transfer *gorm.ClientTransfer) *domain.ClientTransfer {
+	carriedHistory := []enums.ClientTransferHistory{}
+	for _, history := range transfer.CarriedHistory {
+		carriedHistory = append(carriedHistory, enums.ClientTransferHistory(history))
+	}
+
+	return &domain.ClientTransfer{
+		ID:                 transfer.ID,
+		UserID:             transfer.UserID,
+		ClientID:           transfer.ClientID,
+		NewClientID:        transfer.NewClientID,
+		FromFacilityID:     transfer.FromFacilityID,
+		ToFacilityID:       transfer.ToFacilityID,
+		FromProgramID:      transfer.FromProgramID,
+		ToProgramID:        transfer.ToProgramID,
+		FromOrganisationID: transfer.FromOrganisationID,
+		ToOrganisationID:   transfer.ToOrganisationID,
+		Reason:             transfer.Reason,
+		CarriedHistory:     carriedHistory,
+		ClientConsented:    transfer.ClientConsented,
+		TransferredBy:      transfer.TransferredBy,
+		TransferredAt:      transfer.TransferredAt,
+	}
+}
+
+// mapClientTransferToTable maps a client transfer from the domain model to the database
+func mapClientTransferToTable(transfer *domain.ClientTransfer) *gorm.ClientTransfer {
+	carriedHistory := pq.StringArray{}
+	for _, history := range transfer.CarriedHistory {
+		carriedHistory = append(carriedHistory, history.String())
+	}
+
+	return &gorm.ClientTransfer{
+		ID:                 transfer.ID,
+		Active:             true,
+		UserID:             transfer.UserID,
+		ClientID:           transfer.ClientID,
+		NewClientID:        transfer.NewClientID,
+		FromFacilityID:     transfer.FromFacilityID,
+		ToFacilityID:       transfer.ToFacilityID,
+		FromProgramID:      transfer.FromProgramID,
+		ToProgramID:        transfer.ToProgramID,
+		FromOrganisationID: transfer.FromOrganisationID,
+		ToOrganisationID:   transfer.ToOrganisationID,
+		Reason:             transfer.Reason,
+		CarriedHistory:     carriedHistory,
+		ClientConsented:    transfer.ClientConsented,
+		TransferredBy:      transfer.TransferredBy,
+		TransferredAt:      transfer.TransferredAt,
+	}
+}
+
+// mapServiceRequestSLAToDomain maps a service request SLA from the database to the domain model
+func mapServiceRequestSLAToDomain(sla *gorm.ServiceRequestSLA) *domain.ServiceRequestSLA {
+	return &domain.ServiceRequestSLA{
+		ID:              sla.ID,
+		ProgramID:       sla.ProgramID,
+		OrganisationID:  sla.OrganisationID,
+		RequestType:     enums.ServiceRequestType(sla.RequestType),
+		ResolutionHours: sla.ResolutionHours,
+		EscalationHours: sla.EscalationHours,
+	}
+}
+
+// mapServiceRequestEscalationToDomain maps a service request escalation from the database to the domain model
+func mapServiceRequestEscalationToDomain(escalation *gorm.ServiceRequestEscalation) *domain.ServiceRequestEscalation {
+	recipients := []string{}
+	recipients = append(recipients, escalation.Recipients...)
+
+	return &domain.ServiceRequestEscalation{
+		ID:                    escalation.ID,
+		ServiceRequestID:      escalation.ServiceRequestID,
+		Level:                 enums.ServiceRequestEscalationLevel(escalation.Level),
+		Recipients:            recipients,
+		PatientSafetyIncident: escalation.PatientSafetyIncident,
+		EscalatedAt:           escalation.EscalatedAt,
+		ProgramID:             escalation.ProgramID,
+		OrganisationID:        escalation.OrganisationID,
+	}
+}
+
+// mapServiceRequestEscalationLevel maps the level a service request has been escalated to from the database to the domain model
+func mapServiceRequestEscalationLevel(level *string) *enums.ServiceRequestEscalationLevel {
+	if level == nil || *level == "" {
+		return nil
+	}
+
+	escalationLevel := enums.ServiceRequestEscalationLevel(*level)
+	return &escalationLevel
+}
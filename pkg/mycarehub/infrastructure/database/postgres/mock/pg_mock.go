@@ -125,7 +125,7 @@ type PostgresMock struct {
 	MockGetAppointmentFn                                      func(ctx context.Context, params domain.Appointment) (*domain.Appointment, error)
 	MockGetFacilityStaffsFn                                   func(ctx context.Context, facilityID string) ([]*domain.StaffProfile, error)
 	MockCheckIfStaffHasUnresolvedServiceRequestsFn            func(ctx context.Context, staffID string, serviceRequestType string) (bool, error)
-	MockDeleteClientProfileFn                                 func(ctx context.Context, clientID string, userID *string) error
+	MockAnonymizeClientProfileFn                              func(ctx context.Context, clientID string, userID *string) error
 	MockDeleteStaffProfileFn                                  func(ctx context.Context, staffID string) error
 	MockUpdateNotificationFn                                  func(ctx context.Context, notification *domain.Notification, updateData map[string]interface{}) error
 	MockGetNotificationFn                                     func(ctx context.Context, notificationID string) (*domain.Notification, error)
@@ -226,6 +226,78 @@ type PostgresMock struct {
 	MockUpdateBookingFn                                       func(ctx context.Context, booking *domain.Booking, updateData map[string]interface{}) error
 	MockListBookingsFn                                        func(ctx context.Context, clientID string, bookingState enums.BookingState, pagination *domain.Pagination) ([]*domain.Booking, *domain.Pagination, error)
 	MockGetAllScreeningToolsFn                                func(ctx context.Context, pagination *domain.Pagination) ([]*domain.ScreeningTool, *domain.Pagination, error)
+	MockGetUserPermissionsFn                                  func(ctx context.Context, userID string, programID string) ([]*domain.AuthorityPermission, error)
+	MockCreateAuthorityRoleFn                                 func(ctx context.Context, role *domain.AuthorityRole, permissionIDs []string) (*domain.AuthorityRole, error)
+	MockAddPermissionsToRoleFn                                func(ctx context.Context, roleID string, permissionIDs []string) error
+	MockAssignRolesFn                                         func(ctx context.Context, userType enums.UsersType, profileID string, roleIDs []string) error
+	MockSeedAuthorityRolesFn                                  func(ctx context.Context, roles []domain.AuthorityRole) error
+	MockAssignDefaultRolesFn                                  func(ctx context.Context) error
+	MockRemovePermissionsFromRoleFn                           func(ctx context.Context, roleID string, permissionIDs []string) error
+	MockRevokeRolesFn                                         func(ctx context.Context, userType enums.UsersType, profileID string, roleIDs []string) error
+	MockGetAuthorityRoleByIDFn                                func(ctx context.Context, roleID string) (*domain.AuthorityRole, error)
+	MockGetAuthorityRoleByNameFn                              func(ctx context.Context, programID string, name string) (*domain.AuthorityRole, error)
+	MockListAuthorityRolesFn                                  func(ctx context.Context, programID string) ([]*domain.AuthorityRole, error)
+	MockListAuthorityPermissionsFn                            func(ctx context.Context) ([]*domain.AuthorityPermission, error)
+	MockGetProfileRolesFn                                     func(ctx context.Context, userType enums.UsersType, profileID string) ([]*domain.AuthorityRole, error)
+	MockCreateAuditLogFn                                      func(ctx context.Context, auditLog *domain.AuditLog) error
+	MockListAuditLogsFn                                       func(ctx context.Context, organisationID string, filter *dto.AuditLogFilterInput, pagination *domain.Pagination) ([]*domain.AuditLog, *domain.Pagination, error)
+	MockListAuditLogChainFn                                   func(ctx context.Context, organisationID string, afterSequence int64, limit int) ([]*domain.AuditLog, error)
+	MockCountUnchainedAuditLogsFn                             func(ctx context.Context, organisationID string, since time.Time) (int64, error)
+	MockSaveUserTOTPFn                                        func(ctx context.Context, userTOTP *domain.UserTOTP) error
+	MockSaveUserRecoveryCodesFn                               func(ctx context.Context, userID string, recoveryCodes []*domain.UserRecoveryCode) error
+	MockGetUserTOTPFn                                         func(ctx context.Context, userID string) (*domain.UserTOTP, error)
+	MockCheckIfUserHasTOTPFn                                  func(ctx context.Context, userID string) (bool, error)
+	MockListUserRecoveryCodesFn                               func(ctx context.Context, userID string) ([]*domain.UserRecoveryCode, error)
+	MockCheckIfStaffTOTPIsEnforcedFn                          func(ctx context.Context, userID string) (bool, error)
+	MockUpdateUserTOTPFn                                      func(ctx context.Context, userTOTP *domain.UserTOTP, updateData map[string]interface{}) error
+	MockUseUserRecoveryCodeFn                                 func(ctx context.Context, recoveryCode *domain.UserRecoveryCode) error
+	MockUpdateOrganisationFn                                  func(ctx context.Context, organisation *domain.Organisation, updateData map[string]interface{}) error
+	MockDeleteUserTOTPFn                                      func(ctx context.Context, userID string) error
+	MockConsumeRateLimitFn                                    func(ctx context.Context, action string, rules []*domain.RateLimitRule, at time.Time) (*domain.RateLimitDecision, error)
+	MockUpdateOTPDeliveryStatusFn                             func(ctx context.Context, provider enums.OTPDeliveryProvider, providerMessageID string, status enums.OTPDeliveryStatus, at time.Time) (bool, error)
+	MockListUserSessionsFn                                    func(ctx context.Context, userID string, activeSince time.Time) ([]*domain.Session, error)
+	MockUpdateSessionFn                                       func(ctx context.Context, session *domain.Session, updateData map[string]interface{}) error
+	MockRevokeSessionsFn                                      func(ctx context.Context, sessionIDs []string, revokedAt time.Time) error
+	MockGetUserPINHistoryFn                                   func(ctx context.Context, userID string, limit int) ([]*domain.UserPIN, error)
+	MockCreateOpenIDConnectSessionFn                          func(ctx context.Context, session *domain.OpenIDConnectSession) error
+	MockGetOpenIDConnectSessionFn                             func(ctx context.Context, code string) (*domain.OpenIDConnectSession, error)
+	MockCreateOauthSigningKeyFn                               func(ctx context.Context, key *domain.OauthSigningKey) error
+	MockListOauthSigningKeysFn                                func(ctx context.Context, activeAt time.Time) ([]*domain.OauthSigningKey, error)
+	MockCreatePKCEFn                                          func(ctx context.Context, pkce *domain.PKCE) error
+	MockGetPKCEFn                                             func(ctx context.Context, signature string) (*domain.PKCE, error)
+	MockDeletePKCEFn                                          func(ctx context.Context, signature string) error
+	MockUpdateOauthClientFn                                   func(ctx context.Context, client *domain.OauthClient, updateData map[string]interface{}) error
+	MockListRevokedSessionIDsFn                               func(ctx context.Context, since time.Time) ([]string, error)
+	MockListRevokedAccessTokenSignaturesFn                    func(ctx context.Context, since time.Time) ([]string, error)
+	MockResetSecurityQuestionResponsesFn                      func(ctx context.Context, userID string) error
+	MockRecordLoginEventFn                                    func(ctx context.Context, event *domain.LoginEvent, device *domain.UserDevice) error
+	MockListUserDevicesFn                                     func(ctx context.Context, userID string) ([]*domain.UserDevice, error)
+	MockListUserLoginCountriesFn                              func(ctx context.Context, userID string) ([]string, error)
+	MockCountUsersLoggedInFromIPAddressFn                     func(ctx context.Context, ipAddress string, since time.Time) (int, error)
+	MockListDuplicateClientCandidatesFn                       func(ctx context.Context, clientID string) ([]*domain.DuplicateClient, error)
+	MockMergeClientsFn                                        func(ctx context.Context, survivingClientID string, duplicateClientID string) (*domain.ClientMerge, error)
+	MockListUserContactsFn                                    func(ctx context.Context, userID string) ([]*domain.Contact, error)
+	MockListClientScreeningToolResponsesFn                    func(ctx context.Context, clientID string) ([]*domain.QuestionnaireScreeningToolResponse, error)
+	MockListUserMetricsFn                                     func(ctx context.Context, userID string) ([]*domain.Metric, error)
+	MockListUserFeedbackFn                                    func(ctx context.Context, userID string) ([]*domain.FeedbackResponse, error)
+	MockMarkClientForErasureFn                                func(ctx context.Context, clientID string, userID *string, requestedAt time.Time) error
+	MockListClientsDueForErasureFn                            func(ctx context.Context, now time.Time) ([]*domain.ClientProfile, error)
+	MockListExpiredCaregiverDelegationsFn                     func(ctx context.Context, now time.Time) ([]*domain.CaregiverClient, error)
+	MockEndClientCaregiverDelegationsFn                       func(ctx context.Context, clientID string, endsAt time.Time) (int, error)
+	MockCreatePhoneChangeRequestFn                            func(ctx context.Context, request *domain.PhoneChangeRequest) (*domain.PhoneChangeRequest, error)
+	MockGetPendingPhoneChangeRequestFn                        func(ctx context.Context, userID string, flavour feedlib.Flavour) (*domain.PhoneChangeRequest, error)
+	MockConfirmPhoneChangeFn                                  func(ctx context.Context, request *domain.PhoneChangeRequest, confirmedAt time.Time) error
+	MockGetStaffInProgressServiceRequestsFn                   func(ctx context.Context, staffIDs []string) ([]*domain.ServiceRequest, error)
+	MockListOrganisationAdminsFn                              func(ctx context.Context, organisationID string) ([]*domain.StaffProfile, error)
+	MockListFacilityAdminsFn                                  func(ctx context.Context, facilityID, programID string) ([]*domain.StaffProfile, error)
+	MockOffboardStaffFn                                       func(ctx context.Context, offboarding *domain.StaffOffboarding, offboardedAt time.Time) error
+	MockTransferClientFn                                      func(ctx context.Context, transfer *domain.ClientTransfer, payload *domain.ClientRegistrationPayload) (*domain.ClientTransfer, error)
+	MockListClientTransfersFn                                 func(ctx context.Context, userID string) ([]*domain.ClientTransfer, error)
+	MockSetServiceRequestSLAFn                                func(ctx context.Context, sla *domain.ServiceRequestSLA) (*domain.ServiceRequestSLA, error)
+	MockListServiceRequestSLAsFn                              func(ctx context.Context, programID *string) ([]*domain.ServiceRequestSLA, error)
+	MockGetServiceRequestsDueForEscalationFn                  func(ctx context.Context, requestTypes []string) ([]*domain.ServiceRequest, error)
+	MockListServiceRequestEscalationsFn                       func(ctx context.Context, serviceRequestID string) ([]*domain.ServiceRequestEscalation, error)
+	MockEscalateServiceRequestFn                              func(ctx context.Context, escalation *domain.ServiceRequestEscalation) error
 }
 
 // NewPostgresMock initializes a new instance of `GormMock` then mocking the case of success.
@@ -441,6 +513,7 @@ func NewPostgresMock() *PostgresMock {
 		OrganisationID:     ID,
 		AssignedBy:         ID,
 		ProgramID:          ID,
+		Abilities:          enums.AllCaregiverAbilities,
 	}
 
 	organisationPayload := domain.Organisation{
@@ -521,6 +594,7 @@ func NewPostgresMock() *PostgresMock {
 				EmailAddress:   gofakeit.Email(),
 				PhoneNumber:    phone,
 				DefaultCountry: gofakeit.Country(),
+				PINPolicy:      domain.DefaultPINPolicy,
 			}, nil
 		},
 		MockCheckIdentifierExists: func(ctx context.Context, identifierType enums.UserIdentifierType, identifierValue string) (bool, error) {
@@ -1300,7 +1374,7 @@ func NewPostgresMock() *PostgresMock {
 		MockCreateUserFn: func(ctx context.Context, user domain.User) (*domain.User, error) {
 			return userProfile, nil
 		},
-		MockDeleteClientProfileFn: func(ctx context.Context, clientID string, userID *string) error {
+		MockAnonymizeClientProfileFn: func(ctx context.Context, clientID string, userID *string) error {
 			return nil
 		},
 		MockGetClientsByFilterParamsFn: func(ctx context.Context, facilityID *string, filterParams *dto.ClientFilterParamsInput) ([]*domain.ClientProfile, error) {
@@ -1916,6 +1990,523 @@ func NewPostgresMock() *PostgresMock {
 				},
 			}, pagination, nil
 		},
+		MockGetUserPermissionsFn: func(ctx context.Context, userID string, programID string) ([]*domain.AuthorityPermission, error) {
+			return []*domain.AuthorityPermission{
+				{
+					PermissionID: ID,
+					Active:       true,
+					Name:         "delete facility",
+					Description:  "a user with this permission can delete a facility",
+					Category:     "facility",
+					Scope:        "facility.delete",
+				},
+			}, nil
+		},
+		MockCreateAuthorityRoleFn: func(ctx context.Context, role *domain.AuthorityRole, permissionIDs []string) (*domain.AuthorityRole, error) {
+			role.AuthorityRoleID = ID
+			role.Active = true
+			return role, nil
+		},
+		MockAddPermissionsToRoleFn: func(ctx context.Context, roleID string, permissionIDs []string) error {
+			return nil
+		},
+		MockAssignRolesFn: func(ctx context.Context, userType enums.UsersType, profileID string, roleIDs []string) error {
+			return nil
+		},
+		MockSeedAuthorityRolesFn: func(ctx context.Context, roles []domain.AuthorityRole) error {
+			return nil
+		},
+		MockAssignDefaultRolesFn: func(ctx context.Context) error {
+			return nil
+		},
+		MockRemovePermissionsFromRoleFn: func(ctx context.Context, roleID string, permissionIDs []string) error {
+			return nil
+		},
+		MockRevokeRolesFn: func(ctx context.Context, userType enums.UsersType, profileID string, roleIDs []string) error {
+			return nil
+		},
+		MockGetAuthorityRoleByIDFn: func(ctx context.Context, roleID string) (*domain.AuthorityRole, error) {
+			return &domain.AuthorityRole{
+				AuthorityRoleID: roleID,
+				Name:            name,
+				Description:     description,
+				Active:          true,
+				UserType:        enums.StaffUser,
+				OrganisationID:  ID,
+				ProgramID:       ID,
+			}, nil
+		},
+		MockGetAuthorityRoleByNameFn: func(ctx context.Context, programID string, name string) (*domain.AuthorityRole, error) {
+			return &domain.AuthorityRole{
+				AuthorityRoleID: ID,
+				Name:            name,
+				Description:     description,
+				Active:          true,
+				IsSystemRole:    true,
+				UserType:        enums.StaffUser,
+				OrganisationID:  ID,
+				ProgramID:       programID,
+			}, nil
+		},
+		MockListAuthorityRolesFn: func(ctx context.Context, programID string) ([]*domain.AuthorityRole, error) {
+			return []*domain.AuthorityRole{
+				{
+					AuthorityRoleID: ID,
+					Name:            name,
+					Description:     description,
+					Active:          true,
+					UserType:        enums.StaffUser,
+					OrganisationID:  ID,
+					ProgramID:       programID,
+				},
+			}, nil
+		},
+		MockListAuthorityPermissionsFn: func(ctx context.Context) ([]*domain.AuthorityPermission, error) {
+			return []*domain.AuthorityPermission{
+				{
+					PermissionID: ID,
+					Active:       true,
+					Name:         "delete facility",
+					Description:  "a user with this permission can delete a facility",
+					Category:     "facility",
+					Scope:        "facility.delete",
+				},
+			}, nil
+		},
+		MockGetProfileRolesFn: func(ctx context.Context, userType enums.UsersType, profileID string) ([]*domain.AuthorityRole, error) {
+			return []*domain.AuthorityRole{
+				{
+					AuthorityRoleID: ID,
+					Name:            name,
+					Description:     description,
+					Active:          true,
+					UserType:        userType,
+					OrganisationID:  ID,
+					ProgramID:       ID,
+				},
+			}, nil
+		},
+		MockCreateAuditLogFn: func(ctx context.Context, auditLog *domain.AuditLog) error {
+			return nil
+		},
+		MockListAuditLogsFn: func(ctx context.Context, organisationID string, filter *dto.AuditLogFilterInput, pagination *domain.Pagination) ([]*domain.AuditLog, *domain.Pagination, error) {
+			return []*domain.AuditLog{
+				{
+					ID:             ID,
+					Timestamp:      time.Now(),
+					RecordType:     enums.AuditLogOrganisationAdminChange,
+					Notes:          description,
+					ActorID:        ID,
+					TargetID:       ID,
+					TargetType:     enums.AuditLogTargetStaff,
+					ProgramID:      ID,
+					OrganisationID: ID,
+					Before:         map[string]interface{}{"is_organisation_admin": false},
+					After:          map[string]interface{}{"is_organisation_admin": true},
+				},
+			}, pagination, nil
+		},
+		MockListAuditLogChainFn: func(ctx context.Context, organisationID string, afterSequence int64, limit int) ([]*domain.AuditLog, error) {
+			return []*domain.AuditLog{}, nil
+		},
+		MockCountUnchainedAuditLogsFn: func(ctx context.Context, organisationID string, since time.Time) (int64, error) {
+			return 0, nil
+		},
+		MockSaveUserTOTPFn: func(ctx context.Context, userTOTP *domain.UserTOTP) error {
+			userTOTP.ID = ID
+			return nil
+		},
+		MockSaveUserRecoveryCodesFn: func(ctx context.Context, userID string, recoveryCodes []*domain.UserRecoveryCode) error {
+			return nil
+		},
+		MockGetUserTOTPFn: func(ctx context.Context, userID string) (*domain.UserTOTP, error) {
+			return &domain.UserTOTP{
+				ID:          ID,
+				UserID:      userID,
+				Secret:      description,
+				Confirmed:   true,
+				ConfirmedAt: &currentTime,
+			}, nil
+		},
+		MockCheckIfUserHasTOTPFn: func(ctx context.Context, userID string) (bool, error) {
+			return false, nil
+		},
+		MockListUserRecoveryCodesFn: func(ctx context.Context, userID string) ([]*domain.UserRecoveryCode, error) {
+			return []*domain.UserRecoveryCode{
+				{
+					ID:         ID,
+					UserID:     userID,
+					HashedCode: description,
+					Salt:       name,
+				},
+			}, nil
+		},
+		MockCheckIfStaffTOTPIsEnforcedFn: func(ctx context.Context, userID string) (bool, error) {
+			return false, nil
+		},
+		MockUpdateUserTOTPFn: func(ctx context.Context, userTOTP *domain.UserTOTP, updateData map[string]interface{}) error {
+			return nil
+		},
+		MockUseUserRecoveryCodeFn: func(ctx context.Context, recoveryCode *domain.UserRecoveryCode) error {
+			return nil
+		},
+		MockUpdateOrganisationFn: func(ctx context.Context, organisation *domain.Organisation, updateData map[string]interface{}) error {
+			return nil
+		},
+		MockDeleteUserTOTPFn: func(ctx context.Context, userID string) error {
+			return nil
+		},
+		MockConsumeRateLimitFn: func(ctx context.Context, action string, rules []*domain.RateLimitRule, at time.Time) (*domain.RateLimitDecision, error) {
+			return &domain.RateLimitDecision{Allowed: true}, nil
+		},
+		MockUpdateOTPDeliveryStatusFn: func(ctx context.Context, provider enums.OTPDeliveryProvider, providerMessageID string, status enums.OTPDeliveryStatus, at time.Time) (bool, error) {
+			return true, nil
+		},
+		MockListUserSessionsFn: func(ctx context.Context, userID string, activeSince time.Time) ([]*domain.Session, error) {
+			now := time.Now()
+			return []*domain.Session{
+				{
+					ID:         ID,
+					ClientID:   ID,
+					UserID:     userID,
+					DeviceID:   ID,
+					DeviceName: "Pixel 7",
+					UserAgent:  "okhttp/4.9.2",
+					IPAddress:  "127.0.0.1",
+					PushToken:  "push-token",
+					CreatedAt:  now,
+					LastSeenAt: &now,
+				},
+			}, nil
+		},
+		MockUpdateSessionFn: func(ctx context.Context, session *domain.Session, updateData map[string]interface{}) error {
+			return nil
+		},
+		MockRevokeSessionsFn: func(ctx context.Context, sessionIDs []string, revokedAt time.Time) error {
+			return nil
+		},
+		MockGetUserPINHistoryFn: func(ctx context.Context, userID string, limit int) ([]*domain.UserPIN, error) {
+			salt, hashedPIN := utils.EncryptPIN("5829", nil)
+			return []*domain.UserPIN{
+				{
+					UserID:    userID,
+					HashedPIN: hashedPIN,
+					Salt:      salt,
+					ValidFrom: time.Now().AddDate(0, 0, -30),
+					ValidTo:   time.Now().AddDate(0, 0, 30),
+					IsValid:   true,
+				},
+			}, nil
+		},
+		MockCreateOpenIDConnectSessionFn: func(ctx context.Context, session *domain.OpenIDConnectSession) error {
+			return nil
+		},
+		MockGetOpenIDConnectSessionFn: func(ctx context.Context, code string) (*domain.OpenIDConnectSession, error) {
+			return &domain.OpenIDConnectSession{
+				ID:            ID,
+				Active:        true,
+				Code:          code,
+				RequestedAt:   time.Now(),
+				GrantedScopes: []string{"openid"},
+				SessionID:     ID,
+				Session:       domain.Session{ID: ID, UserID: ID, Subject: gofakeit.Name()},
+				ClientID:      ID,
+			}, nil
+		},
+		MockCreateOauthSigningKeyFn: func(ctx context.Context, key *domain.OauthSigningKey) error {
+			return nil
+		},
+		MockListOauthSigningKeysFn: func(ctx context.Context, activeAt time.Time) ([]*domain.OauthSigningKey, error) {
+			return []*domain.OauthSigningKey{}, nil
+		},
+		MockCreatePKCEFn: func(ctx context.Context, pkce *domain.PKCE) error {
+			return nil
+		},
+		MockGetPKCEFn: func(ctx context.Context, signature string) (*domain.PKCE, error) {
+			return &domain.PKCE{
+				ID:            ID,
+				Active:        true,
+				Signature:     signature,
+				RequestedAt:   time.Now(),
+				GrantedScopes: []string{"openid"},
+				SessionID:     ID,
+				Session:       domain.Session{ID: ID, UserID: ID, Subject: gofakeit.Name()},
+				ClientID:      ID,
+			}, nil
+		},
+		MockDeletePKCEFn: func(ctx context.Context, signature string) error {
+			return nil
+		},
+		MockUpdateOauthClientFn: func(ctx context.Context, client *domain.OauthClient, updateData map[string]interface{}) error {
+			return nil
+		},
+		MockListRevokedSessionIDsFn: func(ctx context.Context, since time.Time) ([]string, error) {
+			return []string{}, nil
+		},
+		MockListRevokedAccessTokenSignaturesFn: func(ctx context.Context, since time.Time) ([]string, error) {
+			return []string{}, nil
+		},
+		MockResetSecurityQuestionResponsesFn: func(ctx context.Context, userID string) error {
+			return nil
+		},
+		MockRecordLoginEventFn: func(ctx context.Context, event *domain.LoginEvent, device *domain.UserDevice) error {
+			return nil
+		},
+		MockListUserDevicesFn: func(ctx context.Context, userID string) ([]*domain.UserDevice, error) {
+			return []*domain.UserDevice{
+				{
+					ID:          ID,
+					UserID:      userID,
+					Fingerprint: gofakeit.UUID(),
+					DeviceName:  gofakeit.Name(),
+					LastCountry: "KE",
+					LastLoginAt: time.Now(),
+				},
+			}, nil
+		},
+		MockListUserLoginCountriesFn: func(ctx context.Context, userID string) ([]string, error) {
+			return []string{"KE"}, nil
+		},
+		MockCountUsersLoggedInFromIPAddressFn: func(ctx context.Context, ipAddress string, since time.Time) (int, error) {
+			return 1, nil
+		},
+		MockListDuplicateClientCandidatesFn: func(ctx context.Context, clientID string) ([]*domain.DuplicateClient, error) {
+			return []*domain.DuplicateClient{
+				{
+					Client: &domain.ClientProfile{ID: &ID},
+					Matches: []enums.DuplicateClientMatch{
+						enums.DuplicateClientMatchCCCNumber,
+						enums.DuplicateClientMatchPhoneNumber,
+						enums.DuplicateClientMatchName,
+						enums.DuplicateClientMatchDateOfBirth,
+					},
+				},
+			}, nil
+		},
+		MockMergeClientsFn: func(ctx context.Context, survivingClientID string, duplicateClientID string) (*domain.ClientMerge, error) {
+			return &domain.ClientMerge{
+				SurvivingClientID:      survivingClientID,
+				DuplicateClientID:      duplicateClientID,
+				HealthDiaryEntries:     1,
+				ServiceRequests:        1,
+				Appointments:           1,
+				ScreeningToolResponses: 1,
+				ServiceBookings:        1,
+				CaregiverLinks:         1,
+				Facilities:             1,
+			}, nil
+		},
+		MockListUserContactsFn: func(ctx context.Context, userID string) ([]*domain.Contact, error) {
+			return []*domain.Contact{contactData}, nil
+		},
+		MockListClientScreeningToolResponsesFn: func(ctx context.Context, clientID string) ([]*domain.QuestionnaireScreeningToolResponse, error) {
+			return []*domain.QuestionnaireScreeningToolResponse{
+				{
+					ID:              ID,
+					Active:          true,
+					ScreeningToolID: ID,
+					FacilityID:      ID,
+					ClientID:        clientID,
+					DateOfResponse:  currentTime,
+					AggregateScore:  2,
+					ProgramID:       ID,
+				},
+			}, nil
+		},
+		MockListUserMetricsFn: func(ctx context.Context, userID string) ([]*domain.Metric, error) {
+			return []*domain.Metric{
+				{
+					ID:        1,
+					UserID:    &userID,
+					Type:      enums.MetricTypeEngagement,
+					Event:     map[string]interface{}{"event": "content_interaction"},
+					Timestamp: currentTime,
+				},
+			}, nil
+		},
+		MockListUserFeedbackFn: func(ctx context.Context, userID string) ([]*domain.FeedbackResponse, error) {
+			return []*domain.FeedbackResponse{
+				{
+					UserID:            userID,
+					FeedbackType:      enums.GeneralFeedbackType,
+					SatisfactionLevel: 4,
+					Feedback:          description,
+					ProgramID:         ID,
+				},
+			}, nil
+		},
+		MockMarkClientForErasureFn: func(ctx context.Context, clientID string, userID *string, requestedAt time.Time) error {
+			return nil
+		},
+		MockListClientsDueForErasureFn: func(ctx context.Context, now time.Time) ([]*domain.ClientProfile, error) {
+			erasureRequestedAt := now.AddDate(0, 0, -30)
+			return []*domain.ClientProfile{
+				{
+					ID:                 &ID,
+					UserID:             ID,
+					OrganisationID:     ID,
+					ProgramID:          ID,
+					ErasureRequestedAt: &erasureRequestedAt,
+				},
+			}, nil
+		},
+		MockListExpiredCaregiverDelegationsFn: func(ctx context.Context, now time.Time) ([]*domain.CaregiverClient, error) {
+			endsAt := now.AddDate(0, 0, -1)
+			return []*domain.CaregiverClient{
+				{
+					CaregiverID:      ID,
+					ClientID:         ID,
+					RelationshipType: enums.CaregiverTypeMother,
+					CaregiverConsent: enums.ConsentStateAccepted,
+					ClientConsent:    enums.ConsentStateAccepted,
+					OrganisationID:   ID,
+					ProgramID:        ID,
+					EndsAt:           &endsAt,
+					Abilities:        enums.AllCaregiverAbilities,
+				},
+			}, nil
+		},
+		MockEndClientCaregiverDelegationsFn: func(ctx context.Context, clientID string, endsAt time.Time) (int, error) {
+			return 1, nil
+		},
+		MockCreatePhoneChangeRequestFn: func(ctx context.Context, request *domain.PhoneChangeRequest) (*domain.PhoneChangeRequest, error) {
+			request.ID = ID
+			return request, nil
+		},
+		MockGetPendingPhoneChangeRequestFn: func(ctx context.Context, userID string, flavour feedlib.Flavour) (*domain.PhoneChangeRequest, error) {
+			return &domain.PhoneChangeRequest{
+				ID:             ID,
+				UserID:         userID,
+				Flavour:        flavour,
+				OldPhoneNumber: "+254700000000",
+				NewPhoneNumber: phone,
+				RequestedBy:    userID,
+				ExpiresAt:      time.Now().Add(time.Minute * 10),
+			}, nil
+		},
+		MockConfirmPhoneChangeFn: func(ctx context.Context, request *domain.PhoneChangeRequest, confirmedAt time.Time) error {
+			return nil
+		},
+		MockGetStaffInProgressServiceRequestsFn: func(ctx context.Context, staffIDs []string) ([]*domain.ServiceRequest, error) {
+			inProgressAt := time.Now()
+			return []*domain.ServiceRequest{
+				{
+					ID:             ID,
+					RequestType:    enums.ServiceRequestTypeRedFlag.String(),
+					Status:         enums.ServiceRequestStatusInProgress.String(),
+					Active:         true,
+					ClientID:       ID,
+					InProgressAt:   &inProgressAt,
+					InProgressBy:   &staffIDs[0],
+					FacilityID:     ID,
+					ProgramID:      ID,
+					OrganisationID: ID,
+				},
+			}, nil
+		},
+		MockListFacilityAdminsFn: func(ctx context.Context, facilityID, programID string) ([]*domain.StaffProfile, error) {
+			return []*domain.StaffProfile{
+				{
+					ID:             &ID,
+					UserID:         ID,
+					Active:         true,
+					ProgramID:      programID,
+					OrganisationID: ID,
+				},
+			}, nil
+		},
+		MockListOrganisationAdminsFn: func(ctx context.Context, organisationID string) ([]*domain.StaffProfile, error) {
+			return []*domain.StaffProfile{
+				{
+					ID:                  &ID,
+					UserID:              ID,
+					Active:              true,
+					ProgramID:           ID,
+					OrganisationID:      organisationID,
+					IsOrganisationAdmin: true,
+				},
+			}, nil
+		},
+		MockOffboardStaffFn: func(ctx context.Context, offboarding *domain.StaffOffboarding, offboardedAt time.Time) error {
+			return nil
+		},
+		MockTransferClientFn: func(ctx context.Context, transfer *domain.ClientTransfer, payload *domain.ClientRegistrationPayload) (*domain.ClientTransfer, error) {
+			transfer.ID = ID
+			transfer.NewClientID = transfer.ClientID
+			if payload != nil {
+				transfer.NewClientID = ID
+			}
+			return transfer, nil
+		},
+		MockListClientTransfersFn: func(ctx context.Context, userID string) ([]*domain.ClientTransfer, error) {
+			return []*domain.ClientTransfer{
+				{
+					ID:                 ID,
+					UserID:             userID,
+					ClientID:           ID,
+					NewClientID:        ID,
+					FromFacilityID:     ID,
+					ToFacilityID:       ID,
+					FromProgramID:      ID,
+					ToProgramID:        ID,
+					FromOrganisationID: ID,
+					ToOrganisationID:   ID,
+					Reason:             "Relocated",
+					CarriedHistory:     []enums.ClientTransferHistory{enums.ClientTransferHistoryHealthDiary},
+					TransferredBy:      ID,
+					TransferredAt:      time.Now(),
+				},
+			}, nil
+		},
+		MockSetServiceRequestSLAFn: func(ctx context.Context, sla *domain.ServiceRequestSLA) (*domain.ServiceRequestSLA, error) {
+			sla.ID = ID
+			return sla, nil
+		},
+		MockListServiceRequestSLAsFn: func(ctx context.Context, programID *string) ([]*domain.ServiceRequestSLA, error) {
+			return []*domain.ServiceRequestSLA{
+				{
+					ID:              ID,
+					ProgramID:       ID,
+					OrganisationID:  ID,
+					RequestType:     enums.ServiceRequestTypeScreeningToolsRedFlag,
+					ResolutionHours: 24,
+					EscalationHours: 12,
+				},
+			}, nil
+		},
+		MockGetServiceRequestsDueForEscalationFn: func(ctx context.Context, requestTypes []string) ([]*domain.ServiceRequest, error) {
+			return []*domain.ServiceRequest{
+				{
+					ID:             ID,
+					RequestType:    enums.ServiceRequestTypeScreeningToolsRedFlag.String(),
+					Request:        "Client requires your attention",
+					Status:         enums.ServiceRequestStatusPending.String(),
+					Active:         true,
+					ClientID:       ID,
+					ClientName:     &name,
+					CreatedAt:      time.Now(),
+					FacilityID:     ID,
+					ProgramID:      ID,
+					OrganisationID: ID,
+				},
+			}, nil
+		},
+		MockListServiceRequestEscalationsFn: func(ctx context.Context, serviceRequestID string) ([]*domain.ServiceRequestEscalation, error) {
+			return []*domain.ServiceRequestEscalation{
+				{
+					ID:               ID,
+					ServiceRequestID: serviceRequestID,
+					Level:            enums.ServiceRequestEscalationLevelFacilityStaff,
+					Recipients:       []string{},
+					EscalatedAt:      time.Now(),
+					ProgramID:        ID,
+					OrganisationID:   ID,
+				},
+			}, nil
+		},
+		MockEscalateServiceRequestFn: func(ctx context.Context, escalation *domain.ServiceRequestEscalation) error {
+			return nil
+		},
 	}
 }
 
@@ -1924,9 +2515,9 @@ func (gm *PostgresMock) DeleteStaffProfile(ctx context.Context, staffID string)
 	return gm.MockDeleteStaffProfileFn(ctx, staffID)
 }
 
-// DeleteClientProfile mocks the implementation of deleting a client user
-func (gm *PostgresMock) DeleteClientProfile(ctx context.Context, clientID string, userID *string) error {
-	return gm.MockDeleteClientProfileFn(ctx, clientID, userID)
+// AnonymizeClientProfile mocks the implementation of anonymizing a client
+func (gm *PostgresMock) AnonymizeClientProfile(ctx context.Context, clientID string, userID *string) error {
+	return gm.MockAnonymizeClientProfileFn(ctx, clientID, userID)
 }
 
 // CheckStaffExists checks if there is a staff profile that exists for a user
@@ -2940,3 +3531,363 @@ func (gm *PostgresMock) UpdateBooking(ctx context.Context, booking *domain.Booki
 func (gm *PostgresMock) ListBookings(ctx context.Context, clientID string, bookingState enums.BookingState, pagination *domain.Pagination) ([]*domain.Booking, *domain.Pagination, error) {
 	return gm.MockListBookingsFn(ctx, clientID, bookingState, pagination)
 }
+
+// GetUserPermissions mocks the implementation of retrieving a user's permissions
+func (gm *PostgresMock) GetUserPermissions(ctx context.Context, userID string, programID string) ([]*domain.AuthorityPermission, error) {
+	return gm.MockGetUserPermissionsFn(ctx, userID, programID)
+}
+
+// CreateAuthorityRole mocks the implementation of creating a role
+func (gm *PostgresMock) CreateAuthorityRole(ctx context.Context, role *domain.AuthorityRole, permissionIDs []string) (*domain.AuthorityRole, error) {
+	return gm.MockCreateAuthorityRoleFn(ctx, role, permissionIDs)
+}
+
+// AddPermissionsToRole mocks the implementation of granting permissions to a role
+func (gm *PostgresMock) AddPermissionsToRole(ctx context.Context, roleID string, permissionIDs []string) error {
+	return gm.MockAddPermissionsToRoleFn(ctx, roleID, permissionIDs)
+}
+
+// AssignRoles mocks the implementation of assigning roles to a profile
+func (gm *PostgresMock) AssignRoles(ctx context.Context, userType enums.UsersType, profileID string, roleIDs []string) error {
+	return gm.MockAssignRolesFn(ctx, userType, profileID, roleIDs)
+}
+
+// SeedAuthorityRoles mocks the implementation of seeding roles in every program
+func (gm *PostgresMock) SeedAuthorityRoles(ctx context.Context, roles []domain.AuthorityRole) error {
+	return gm.MockSeedAuthorityRolesFn(ctx, roles)
+}
+
+// AssignDefaultRoles mocks the implementation of assigning the default roles to profiles without a role
+func (gm *PostgresMock) AssignDefaultRoles(ctx context.Context) error {
+	return gm.MockAssignDefaultRolesFn(ctx)
+}
+
+// RemovePermissionsFromRole mocks the implementation of revoking permissions from a role
+func (gm *PostgresMock) RemovePermissionsFromRole(ctx context.Context, roleID string, permissionIDs []string) error {
+	return gm.MockRemovePermissionsFromRoleFn(ctx, roleID, permissionIDs)
+}
+
+// RevokeRoles mocks the implementation of revoking roles from a profile
+func (gm *PostgresMock) RevokeRoles(ctx context.Context, userType enums.UsersType, profileID string, roleIDs []string) error {
+	return gm.MockRevokeRolesFn(ctx, userType, profileID, roleIDs)
+}
+
+// GetAuthorityRoleByID mocks the implementation of retrieving a role
+func (gm *PostgresMock) GetAuthorityRoleByID(ctx context.Context, roleID string) (*domain.AuthorityRole, error) {
+	return gm.MockGetAuthorityRoleByIDFn(ctx, roleID)
+}
+
+// GetAuthorityRoleByName mocks the implementation of retrieving a program's role using its name
+func (gm *PostgresMock) GetAuthorityRoleByName(ctx context.Context, programID string, name string) (*domain.AuthorityRole, error) {
+	return gm.MockGetAuthorityRoleByNameFn(ctx, programID, name)
+}
+
+// ListAuthorityRoles mocks the implementation of listing the roles in a program
+func (gm *PostgresMock) ListAuthorityRoles(ctx context.Context, programID string) ([]*domain.AuthorityRole, error) {
+	return gm.MockListAuthorityRolesFn(ctx, programID)
+}
+
+// ListAuthorityPermissions mocks the implementation of listing permissions
+func (gm *PostgresMock) ListAuthorityPermissions(ctx context.Context) ([]*domain.AuthorityPermission, error) {
+	return gm.MockListAuthorityPermissionsFn(ctx)
+}
+
+// GetProfileRoles mocks the implementation of retrieving a profile's roles
+func (gm *PostgresMock) GetProfileRoles(ctx context.Context, userType enums.UsersType, profileID string) ([]*domain.AuthorityRole, error) {
+	return gm.MockGetProfileRolesFn(ctx, userType, profileID)
+}
+
+// CreateAuditLog mocks the implementation of recording an audit log
+func (gm *PostgresMock) CreateAuditLog(ctx context.Context, auditLog *domain.AuditLog) error {
+	return gm.MockCreateAuditLogFn(ctx, auditLog)
+}
+
+// ListAuditLogs mocks the implementation of listing audit logs
+func (gm *PostgresMock) ListAuditLogs(ctx context.Context, organisationID string, filter *dto.AuditLogFilterInput, pagination *domain.Pagination) ([]*domain.AuditLog, *domain.Pagination, error) {
+	return gm.MockListAuditLogsFn(ctx, organisationID, filter, pagination)
+}
+
+// ListAuditLogChain mocks the implementation of listing an organisation's chained audit logs
+func (gm *PostgresMock) ListAuditLogChain(ctx context.Context, organisationID string, afterSequence int64, limit int) ([]*domain.AuditLog, error) {
+	return gm.MockListAuditLogChainFn(ctx, organisationID, afterSequence, limit)
+}
+
+// CountUnchainedAuditLogs mocks the implementation of counting audit logs that are not linked to the chain
+func (gm *PostgresMock) CountUnchainedAuditLogs(ctx context.Context, organisationID string, since time.Time) (int64, error) {
+	return gm.MockCountUnchainedAuditLogsFn(ctx, organisationID, since)
+}
+
+// SaveUserTOTP mocks the implementation of saving a user's TOTP enrollment
+func (gm *PostgresMock) SaveUserTOTP(ctx context.Context, userTOTP *domain.UserTOTP) error {
+	return gm.MockSaveUserTOTPFn(ctx, userTOTP)
+}
+
+// SaveUserRecoveryCodes mocks the implementation of replacing a user's recovery codes
+func (gm *PostgresMock) SaveUserRecoveryCodes(ctx context.Context, userID string, recoveryCodes []*domain.UserRecoveryCode) error {
+	return gm.MockSaveUserRecoveryCodesFn(ctx, userID, recoveryCodes)
+}
+
+// GetUserTOTP mocks the implementation of retrieving a user's TOTP enrollment
+func (gm *PostgresMock) GetUserTOTP(ctx context.Context, userID string) (*domain.UserTOTP, error) {
+	return gm.MockGetUserTOTPFn(ctx, userID)
+}
+
+// CheckIfUserHasTOTP mocks the implementation of checking whether a user has a confirmed TOTP enrollment
+func (gm *PostgresMock) CheckIfUserHasTOTP(ctx context.Context, userID string) (bool, error) {
+	return gm.MockCheckIfUserHasTOTPFn(ctx, userID)
+}
+
+// ListUserRecoveryCodes mocks the implementation of listing a user's unused recovery codes
+func (gm *PostgresMock) ListUserRecoveryCodes(ctx context.Context, userID string) ([]*domain.UserRecoveryCode, error) {
+	return gm.MockListUserRecoveryCodesFn(ctx, userID)
+}
+
+// CheckIfStaffTOTPIsEnforced mocks the implementation of checking whether a staff's organisation requires a TOTP authenticator
+func (gm *PostgresMock) CheckIfStaffTOTPIsEnforced(ctx context.Context, userID string) (bool, error) {
+	return gm.MockCheckIfStaffTOTPIsEnforcedFn(ctx, userID)
+}
+
+// UpdateUserTOTP mocks the implementation of updating a user's TOTP enrollment
+func (gm *PostgresMock) UpdateUserTOTP(ctx context.Context, userTOTP *domain.UserTOTP, updateData map[string]interface{}) error {
+	return gm.MockUpdateUserTOTPFn(ctx, userTOTP, updateData)
+}
+
+// UseUserRecoveryCode mocks the implementation of marking a recovery code as used
+func (gm *PostgresMock) UseUserRecoveryCode(ctx context.Context, recoveryCode *domain.UserRecoveryCode) error {
+	return gm.MockUseUserRecoveryCodeFn(ctx, recoveryCode)
+}
+
+// UpdateOrganisation mocks the implementation of updating an organisation
+func (gm *PostgresMock) UpdateOrganisation(ctx context.Context, organisation *domain.Organisation, updateData map[string]interface{}) error {
+	return gm.MockUpdateOrganisationFn(ctx, organisation, updateData)
+}
+
+// DeleteUserTOTP mocks the implementation of removing a user's TOTP enrollment
+func (gm *PostgresMock) DeleteUserTOTP(ctx context.Context, userID string) error {
+	return gm.MockDeleteUserTOTPFn(ctx, userID)
+}
+
+// ConsumeRateLimit mocks the implementation of checking a request against rate limit rules
+func (gm *PostgresMock) ConsumeRateLimit(ctx context.Context, action string, rules []*domain.RateLimitRule, at time.Time) (*domain.RateLimitDecision, error) {
+	return gm.MockConsumeRateLimitFn(ctx, action, rules, at)
+}
+
+// UpdateOTPDeliveryStatus mocks the implementation of updating an OTP delivery attempt's status
+func (gm *PostgresMock) UpdateOTPDeliveryStatus(ctx context.Context, provider enums.OTPDeliveryProvider, providerMessageID string, status enums.OTPDeliveryStatus, at time.Time) (bool, error) {
+	return gm.MockUpdateOTPDeliveryStatusFn(ctx, provider, providerMessageID, status, at)
+}
+
+// ListUserSessions mocks the implementation of ListUserSessions method
+func (gm *PostgresMock) ListUserSessions(ctx context.Context, userID string, activeSince time.Time) ([]*domain.Session, error) {
+	return gm.MockListUserSessionsFn(ctx, userID, activeSince)
+}
+
+// UpdateSession mocks the implementation of UpdateSession method
+func (gm *PostgresMock) UpdateSession(ctx context.Context, session *domain.Session, updateData map[string]interface{}) error {
+	return gm.MockUpdateSessionFn(ctx, session, updateData)
+}
+
+// RevokeSessions mocks the implementation of RevokeSessions method
+func (gm *PostgresMock) RevokeSessions(ctx context.Context, sessionIDs []string, revokedAt time.Time) error {
+	return gm.MockRevokeSessionsFn(ctx, sessionIDs, revokedAt)
+}
+
+// GetUserPINHistory mocks the implementation of getting a user's most recent PINs
+func (gm *PostgresMock) GetUserPINHistory(ctx context.Context, userID string, limit int) ([]*domain.UserPIN, error) {
+	return gm.MockGetUserPINHistoryFn(ctx, userID, limit)
+}
+
+// CreateOpenIDConnectSession mocks the implementation of creating the OpenID Connect session of an authorization code
+func (gm *PostgresMock) CreateOpenIDConnectSession(ctx context.Context, session *domain.OpenIDConnectSession) error {
+	return gm.MockCreateOpenIDConnectSessionFn(ctx, session)
+}
+
+// GetOpenIDConnectSession mocks the implementation of retrieving the OpenID Connect session of an authorization code
+func (gm *PostgresMock) GetOpenIDConnectSession(ctx context.Context, code string) (*domain.OpenIDConnectSession, error) {
+	return gm.MockGetOpenIDConnectSessionFn(ctx, code)
+}
+
+// CreateOauthSigningKey mocks the implementation of creating a token signing key
+func (gm *PostgresMock) CreateOauthSigningKey(ctx context.Context, key *domain.OauthSigningKey) error {
+	return gm.MockCreateOauthSigningKeyFn(ctx, key)
+}
+
+// ListOauthSigningKeys mocks the implementation of listing the token signing keys that have not expired
+func (gm *PostgresMock) ListOauthSigningKeys(ctx context.Context, activeAt time.Time) ([]*domain.OauthSigningKey, error) {
+	return gm.MockListOauthSigningKeysFn(ctx, activeAt)
+}
+
+// CreatePKCE mocks the implementation of creating the PKCE session of an authorization code
+func (gm *PostgresMock) CreatePKCE(ctx context.Context, pkce *domain.PKCE) error {
+	return gm.MockCreatePKCEFn(ctx, pkce)
+}
+
+// GetPKCE mocks the implementation of retrieving the PKCE session of an authorization code
+func (gm *PostgresMock) GetPKCE(ctx context.Context, signature string) (*domain.PKCE, error) {
+	return gm.MockGetPKCEFn(ctx, signature)
+}
+
+// DeletePKCE mocks the implementation of deleting the PKCE session of an authorization code
+func (gm *PostgresMock) DeletePKCE(ctx context.Context, signature string) error {
+	return gm.MockDeletePKCEFn(ctx, signature)
+}
+
+// UpdateOauthClient mocks the implementation of updating an oauth client
+func (gm *PostgresMock) UpdateOauthClient(ctx context.Context, client *domain.OauthClient, updateData map[string]interface{}) error {
+	return gm.MockUpdateOauthClientFn(ctx, client, updateData)
+}
+
+// ListRevokedSessionIDs mocks the implementation of listing the sessions that were revoked since the given time
+func (gm *PostgresMock) ListRevokedSessionIDs(ctx context.Context, since time.Time) ([]string, error) {
+	return gm.MockListRevokedSessionIDsFn(ctx, since)
+}
+
+// ListRevokedAccessTokenSignatures mocks the implementation of listing the access tokens that were revoked since the given time
+func (gm *PostgresMock) ListRevokedAccessTokenSignatures(ctx context.Context, since time.Time) ([]string, error) {
+	return gm.MockListRevokedAccessTokenSignaturesFn(ctx, since)
+}
+
+// ResetSecurityQuestionResponses mocks the implementation of clearing a user's security question responses
+func (gm *PostgresMock) ResetSecurityQuestionResponses(ctx context.Context, userID string) error {
+	return gm.MockResetSecurityQuestionResponsesFn(ctx, userID)
+}
+
+// RecordLoginEvent mocks the implementation of recording a login and the device that it was made from
+func (gm *PostgresMock) RecordLoginEvent(ctx context.Context, event *domain.LoginEvent, device *domain.UserDevice) error {
+	return gm.MockRecordLoginEventFn(ctx, event, device)
+}
+
+// ListUserDevices mocks the implementation of listing the devices that a user has logged in from
+func (gm *PostgresMock) ListUserDevices(ctx context.Context, userID string) ([]*domain.UserDevice, error) {
+	return gm.MockListUserDevicesFn(ctx, userID)
+}
+
+// ListUserLoginCountries mocks the implementation of listing the countries that a user has logged in from
+func (gm *PostgresMock) ListUserLoginCountries(ctx context.Context, userID string) ([]string, error) {
+	return gm.MockListUserLoginCountriesFn(ctx, userID)
+}
+
+// CountUsersLoggedInFromIPAddress mocks the implementation of counting the users that have logged in from an IP address
+func (gm *PostgresMock) CountUsersLoggedInFromIPAddress(ctx context.Context, ipAddress string, since time.Time) (int, error) {
+	return gm.MockCountUsersLoggedInFromIPAddressFn(ctx, ipAddress, since)
+}
+
+// ListDuplicateClientCandidates mocks the implementation of listing the clients that may be duplicates of a client
+func (gm *PostgresMock) ListDuplicateClientCandidates(ctx context.Context, clientID string) ([]*domain.DuplicateClient, error) {
+	return gm.MockListDuplicateClientCandidatesFn(ctx, clientID)
+}
+
+// MergeClients mocks the implementation of merging a duplicate client into a surviving client
+func (gm *PostgresMock) MergeClients(ctx context.Context, survivingClientID string, duplicateClientID string) (*domain.ClientMerge, error) {
+	return gm.MockMergeClientsFn(ctx, survivingClientID, duplicateClientID)
+}
+
+// ListUserContacts mocks the implementation of listing a user's contacts
+func (gm *PostgresMock) ListUserContacts(ctx context.Context, userID string) ([]*domain.Contact, error) {
+	return gm.MockListUserContactsFn(ctx, userID)
+}
+
+// ListClientScreeningToolResponses mocks the implementation of listing a client's screening tool responses
+func (gm *PostgresMock) ListClientScreeningToolResponses(ctx context.Context, clientID string) ([]*domain.QuestionnaireScreeningToolResponse, error) {
+	return gm.MockListClientScreeningToolResponsesFn(ctx, clientID)
+}
+
+// ListUserMetrics mocks the implementation of listing the metrics recorded for a user
+func (gm *PostgresMock) ListUserMetrics(ctx context.Context, userID string) ([]*domain.Metric, error) {
+	return gm.MockListUserMetricsFn(ctx, userID)
+}
+
+// ListUserFeedback mocks the implementation of listing the feedback a user has sent
+func (gm *PostgresMock) ListUserFeedback(ctx context.Context, userID string) ([]*domain.FeedbackResponse, error) {
+	return gm.MockListUserFeedbackFn(ctx, userID)
+}
+
+// MarkClientForErasure mocks the implementation of marking a client for erasure
+func (gm *PostgresMock) MarkClientForErasure(ctx context.Context, clientID string, userID *string, requestedAt time.Time) error {
+	return gm.MockMarkClientForErasureFn(ctx, clientID, userID, requestedAt)
+}
+
+// ListClientsDueForErasure mocks the implementation of listing the clients whose data is due for erasure
+func (gm *PostgresMock) ListClientsDueForErasure(ctx context.Context, now time.Time) ([]*domain.ClientProfile, error) {
+	return gm.MockListClientsDueForErasureFn(ctx, now)
+}
+
+// ListExpiredCaregiverDelegations mocks the implementation of listing the caregiver delegations whose end date has passed
+func (gm *PostgresMock) ListExpiredCaregiverDelegations(ctx context.Context, now time.Time) ([]*domain.CaregiverClient, error) {
+	return gm.MockListExpiredCaregiverDelegationsFn(ctx, now)
+}
+
+// EndClientCaregiverDelegations mocks the implementation of setting the date that a client's caregiver delegations end
+func (gm *PostgresMock) EndClientCaregiverDelegations(ctx context.Context, clientID string, endsAt time.Time) (int, error) {
+	return gm.MockEndClientCaregiverDelegationsFn(ctx, clientID, endsAt)
+}
+
+// CreatePhoneChangeRequest mocks the implementation of saving a request to change a user's phone number
+func (gm *PostgresMock) CreatePhoneChangeRequest(ctx context.Context, request *domain.PhoneChangeRequest) (*domain.PhoneChangeRequest, error) {
+	return gm.MockCreatePhoneChangeRequestFn(ctx, request)
+}
+
+// GetPendingPhoneChangeRequest mocks the implementation of getting a user's phone change request that has not been confirmed
+func (gm *PostgresMock) GetPendingPhoneChangeRequest(ctx context.Context, userID string, flavour feedlib.Flavour) (*domain.PhoneChangeRequest, error) {
+	return gm.MockGetPendingPhoneChangeRequestFn(ctx, userID, flavour)
+}
+
+// ConfirmPhoneChange mocks the implementation of replacing a user's phone number with the one in a phone change request
+func (gm *PostgresMock) ConfirmPhoneChange(ctx context.Context, request *domain.PhoneChangeRequest, confirmedAt time.Time) error {
+	return gm.MockConfirmPhoneChangeFn(ctx, request, confirmedAt)
+}
+
+// GetStaffInProgressServiceRequests mocks the implementation of getting the service requests that staff are working on
+func (gm *PostgresMock) GetStaffInProgressServiceRequests(ctx context.Context, staffIDs []string) ([]*domain.ServiceRequest, error) {
+	return gm.MockGetStaffInProgressServiceRequestsFn(ctx, staffIDs)
+}
+
+// ListOrganisationAdmins mocks the implementation of listing the organisation admins of an organisation
+func (gm *PostgresMock) ListOrganisationAdmins(ctx context.Context, organisationID string) ([]*domain.StaffProfile, error) {
+	return gm.MockListOrganisationAdminsFn(ctx, organisationID)
+}
+
+// ListFacilityAdmins mocks the implementation of listing the admins of a facility in a program
+func (gm *PostgresMock) ListFacilityAdmins(ctx context.Context, facilityID, programID string) ([]*domain.StaffProfile, error) {
+	return gm.MockListFacilityAdminsFn(ctx, facilityID, programID)
+}
+
+// OffboardStaff mocks the implementation of removing a staff who has left an organisation
+func (gm *PostgresMock) OffboardStaff(ctx context.Context, offboarding *domain.StaffOffboarding, offboardedAt time.Time) error {
+	return gm.MockOffboardStaffFn(ctx, offboarding, offboardedAt)
+}
+
+// TransferClient mocks the implementation of moving a client to another facility or program
+func (gm *PostgresMock) TransferClient(ctx context.Context, transfer *domain.ClientTransfer, payload *domain.ClientRegistrationPayload) (*domain.ClientTransfer, error) {
+	return gm.MockTransferClientFn(ctx, transfer, payload)
+}
+
+// ListClientTransfers mocks the implementation of listing a client's transfers
+func (gm *PostgresMock) ListClientTransfers(ctx context.Context, userID string) ([]*domain.ClientTransfer, error) {
+	return gm.MockListClientTransfersFn(ctx, userID)
+}
+
+// SetServiceRequestSLA mocks the implementation of setting a program's SLA for a type of service request
+func (gm *PostgresMock) SetServiceRequestSLA(ctx context.Context, sla *domain.ServiceRequestSLA) (*domain.ServiceRequestSLA, error) {
+	return gm.MockSetServiceRequestSLAFn(ctx, sla)
+}
+
+// ListServiceRequestSLAs mocks the implementation of listing service request SLAs
+func (gm *PostgresMock) ListServiceRequestSLAs(ctx context.Context, programID *string) ([]*domain.ServiceRequestSLA, error) {
+	return gm.MockListServiceRequestSLAsFn(ctx, programID)
+}
+
+// GetServiceRequestsDueForEscalation mocks the implementation of getting the pending service requests that can be escalated
+func (gm *PostgresMock) GetServiceRequestsDueForEscalation(ctx context.Context, requestTypes []string) ([]*domain.ServiceRequest, error) {
+	return gm.MockGetServiceRequestsDueForEscalationFn(ctx, requestTypes)
+}
+
+// ListServiceRequestEscalations mocks the implementation of listing a service request's escalations
+func (gm *PostgresMock) ListServiceRequestEscalations(ctx context.Context, serviceRequestID string) ([]*domain.ServiceRequestEscalation, error) {
+	return gm.MockListServiceRequestEscalationsFn(ctx, serviceRequestID)
+}
+
+// EscalateServiceRequest mocks the implementation of recording a service request being escalated
+func (gm *PostgresMock) EscalateServiceRequest(ctx context.Context, escalation *domain.ServiceRequestEscalation) error {
+	return gm.MockEscalateServiceRequestFn(ctx, escalation)
+}
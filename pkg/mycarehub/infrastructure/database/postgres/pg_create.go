@@ -5,16 +5,22 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/jackc/pgtype"
 	"github.com/lib/pq"
 	"github.com/savannahghi/enumutils"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/utils"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/gorm"
+	"github.com/savannahghi/serverutils"
 )
 
+// auditLogHashKey is the secret key that audit log entries are signed with
+var auditLogHashKey = serverutils.MustGetEnvVar(utils.AuditLogHashKeyEnvVarName)
+
 // SaveTemporaryUserPin does the actual saving of the users PIN in the database
 func (d *MyCareHubDb) SaveTemporaryUserPin(ctx context.Context, pinData *domain.UserPIN) (bool, error) {
 	pinObj := &gorm.PINData{
@@ -76,6 +82,17 @@ func (d *MyCareHubDb) SaveOTP(ctx context.Context, otpInput *domain.OTP) error {
 		Flavour:     otpInput.Flavour,
 	}
 
+	for _, attempt := range otpInput.DeliveryAttempts {
+		otpObject.DeliveryAttempts = append(otpObject.DeliveryAttempts, &gorm.UserOTPDeliveryAttempt{
+			Provider:          attempt.Provider.String(),
+			ProviderMessageID: attempt.ProviderMessageID,
+			Status:            attempt.Status.String(),
+			Error:             attempt.Error,
+			AttemptedAt:       attempt.AttemptedAt,
+			StatusUpdatedAt:   attempt.StatusUpdatedAt,
+		})
+	}
+
 	err := d.create.SaveOTP(ctx, otpObject)
 	if err != nil {
 		return fmt.Errorf("failed to save OTP: %w", err)
@@ -933,6 +950,9 @@ func (d *MyCareHubDb) AddCaregiverToClient(ctx context.Context, clientCaregiver
 		AssignedBy:         clientCaregiver.AssignedBy,
 		ProgramID:          clientCaregiver.ProgramID,
 		OrganisationID:     clientCaregiver.OrganisationID,
+		StartsAt:           clientCaregiver.StartsAt,
+		EndsAt:             clientCaregiver.EndsAt,
+		Abilities:          mapCaregiverAbilities(clientCaregiver.Abilities),
 	}
 
 	return d.create.AddCaregiverToClient(ctx, caregiverClient)
@@ -1190,6 +1210,12 @@ func (d *MyCareHubDb) CreateOauthClient(ctx context.Context, client *domain.Oaut
 		Grants:                  client.Grants,
 		ResponseTypes:           client.ResponseTypes,
 		TokenEndpointAuthMethod: client.TokenEndpointAuthMethod,
+		RegistrationAccessToken: client.RegistrationAccessToken,
+	}
+
+	// clients that are not bound to a facility have no MFL code so that the code is unique among the facility clients
+	if client.MFLCode != "" {
+		oauthClient.MFLCode = &client.MFLCode
 	}
 
 	err := d.create.CreateOauthClient(ctx, oauthClient)
@@ -1217,13 +1243,19 @@ func (d *MyCareHubDb) CreateOrUpdateSession(ctx context.Context, session *domain
 	}
 
 	sesh := &gorm.Session{
-		ID:        session.ID,
-		ClientID:  session.ClientID,
-		Username:  session.Username,
-		Subject:   session.Subject,
-		ExpiresAt: expiresAt,
-		Extra:     extra,
-		UserID:    session.UserID,
+		ID:         session.ID,
+		ClientID:   session.ClientID,
+		Username:   session.Username,
+		Subject:    session.Subject,
+		ExpiresAt:  expiresAt,
+		Extra:      extra,
+		UserID:     session.UserID,
+		DeviceID:   session.DeviceID,
+		DeviceName: session.DeviceName,
+		UserAgent:  session.UserAgent,
+		IPAddress:  session.IPAddress,
+		PushToken:  session.PushToken,
+		LastSeenAt: session.LastSeenAt,
 	}
 
 	err = d.create.CreateOrUpdateSession(ctx, sesh)
@@ -1270,6 +1302,97 @@ func (d *MyCareHubDb) CreateAuthorizationCode(ctx context.Context, code *domain.
 	return nil
 }
 
+// CreateOpenIDConnectSession creates the OpenID Connect session of an authorization code
+func (d *MyCareHubDb) CreateOpenIDConnectSession(ctx context.Context, session *domain.OpenIDConnectSession) error {
+	form := pgtype.JSONB{}
+	err := form.Set(session.Form)
+	if err != nil {
+		return err
+	}
+
+	openIDConnectSession := gorm.OpenIDConnectSession{
+		ID:                session.ID,
+		Active:            session.Active,
+		Code:              session.Code,
+		RequestedAt:       session.RequestedAt,
+		RequestedScopes:   session.RequestedScopes,
+		GrantedScopes:     session.GrantedScopes,
+		Form:              form,
+		RequestedAudience: session.RequestedAudience,
+		GrantedAudience:   session.GrantedAudience,
+		SessionID:         session.SessionID,
+		Session:           gorm.Session{},
+		ClientID:          session.ClientID,
+		Client:            gorm.OauthClient{},
+	}
+
+	err = d.create.CreateOpenIDConnectSession(ctx, &openIDConnectSession)
+	if err != nil {
+		return err
+	}
+
+	session.ID = openIDConnectSession.ID
+
+	return nil
+}
+
+// CreatePKCE creates the PKCE session of an authorization code
+func (d *MyCareHubDb) CreatePKCE(ctx context.Context, pkce *domain.PKCE) error {
+	form := pgtype.JSONB{}
+	err := form.Set(pkce.Form)
+	if err != nil {
+		return err
+	}
+
+	pkceSession := gorm.PKCE{
+		ID:                pkce.ID,
+		Active:            pkce.Active,
+		Signature:         pkce.Signature,
+		RequestedAt:       pkce.RequestedAt,
+		RequestedScopes:   pkce.RequestedScopes,
+		GrantedScopes:     pkce.GrantedScopes,
+		Form:              form,
+		RequestedAudience: pkce.RequestedAudience,
+		GrantedAudience:   pkce.GrantedAudience,
+		SessionID:         pkce.SessionID,
+		Session:           gorm.Session{},
+		ClientID:          pkce.ClientID,
+		Client:            gorm.OauthClient{},
+	}
+
+	err = d.create.CreatePKCE(ctx, &pkceSession)
+	if err != nil {
+		return err
+	}
+
+	pkce.ID = pkceSession.ID
+
+	return nil
+}
+
+// CreateOauthSigningKey creates a new token signing key
+func (d *MyCareHubDb) CreateOauthSigningKey(ctx context.Context, key *domain.OauthSigningKey) error {
+	signingKey := &gorm.OauthSigningKey{
+		ID:         key.ID,
+		Active:     true,
+		KeyID:      key.KeyID,
+		Algorithm:  key.Algorithm,
+		PrivateKey: key.PrivateKey,
+		PublicKey:  key.PublicKey,
+		RotateAt:   key.RotateAt,
+		ExpiresAt:  key.ExpiresAt,
+	}
+
+	if err := d.create.CreateOauthSigningKey(ctx, signingKey); err != nil {
+		return err
+	}
+
+	key.ID = signingKey.ID
+	key.CreatedAt = signingKey.CreatedAt
+
+	return nil
+}
+
 // CreateAccessToken creates a new access token.
 func (d *MyCareHubDb) CreateAccessToken(ctx context.Context, token *domain.AccessToken) error {
 	form := pgtype.JSONB{}
@@ -1392,3 +1515,294 @@ func (d *MyCareHubDb) CreateBooking(ctx context.Context, booking *domain.Booking
 		ProgramID:      result.ProgramID,
 	}, nil
 }
+
+// CreateAuthorityRole creates a role in a program and grants it the provided permissions
+func (d *MyCareHubDb) CreateAuthorityRole(ctx context.Context, role *domain.AuthorityRole, permissionIDs []string) (*domain.AuthorityRole, error) {
+	payload := &gorm.AuthorityRole{
+		Name:           role.Name,
+		Description:    role.Description,
+		Active:         true,
+		IsSystemRole:   role.IsSystemRole,
+		UserType:       role.UserType.String(),
+		OrganisationID: role.OrganisationID,
+		ProgramID:      role.ProgramID,
+	}
+
+	err := d.create.CreateAuthorityRole(ctx, payload, permissionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.GetAuthorityRoleByID(ctx, *payload.AuthorityRoleID)
+}
+
+// AddPermissionsToRole grants the provided permissions to a role
+func (d *MyCareHubDb) AddPermissionsToRole(ctx context.Context, roleID string, permissionIDs []string) error {
+	return d.create.AddPermissionsToRole(ctx, roleID, permissionIDs)
+}
+
+// AssignRoles assigns roles to a staff, client or caregiver profile
+func (d *MyCareHubDb) AssignRoles(ctx context.Context, userType enums.UsersType, profileID string, roleIDs []string) error {
+	return d.create.AssignRoles(ctx, userType, profileID, roleIDs)
+}
+
+// SeedAuthorityRoles makes sure that every program has the provided roles together with their permissions
+func (d *MyCareHubDb) SeedAuthorityRoles(ctx context.Context, roles []domain.AuthorityRole) error {
+	permissions := []*gorm.AuthorityPermission{}
+	seenScopes := map[string]bool{}
+	roleScopes := map[string][]string{}
+	seededRoles := []*gorm.AuthorityRole{}
+
+	for _, role := range roles {
+		for _, permission := range role.Permissions {
+			roleScopes[role.Name] = append(roleScopes[role.Name], permission.Scope)
+
+			if seenScopes[permission.Scope] {
+				continue
+			}
+			seenScopes[permission.Scope] = true
+
+			permissions = append(permissions, &gorm.AuthorityPermission{
+				Active:      true,
+				Name:        permission.Name,
+				Description: permission.Description,
+				Category:    permission.Category,
+				Scope:       permission.Scope,
+			})
+		}
+
+		seededRoles = append(seededRoles, &gorm.AuthorityRole{
+			Name:         role.Name,
+			Description:  role.Description,
+			Active:       role.Active,
+			IsSystemRole: role.IsSystemRole,
+			UserType:     role.UserType.String(),
+		})
+	}
+
+	return d.create.SeedAuthorityRoles(ctx, permissions, seededRoles, roleScopes)
+}
+
+// AssignDefaultRoles assigns the default roles to the profiles that have not been assigned any role in their program
+func (d *MyCareHubDb) AssignDefaultRoles(ctx context.Context) error {
+	return d.create.AssignDefaultRoles(ctx)
+}
+
+// CreateAuditLog records a sensitive action in the audit log and links it to the organisation's audit log chain.
+// The actor, organisation and program default to the values of the logged in user's context when they are not provided
+func (d *MyCareHubDb) CreateAuditLog(ctx context.Context, auditLog *domain.AuditLog) error {
+	if auditLog.ActorID == "" {
+		if userID := utils.GetLoggedInUserID(ctx); userID != nil {
+			auditLog.ActorID = *userID
+		}
+	}
+	if auditLog.OrganisationID == "" {
+		auditLog.OrganisationID, _ = utils.GetValueFromContext(ctx, utils.OrganisationContextKey)
+	}
+	if auditLog.ProgramID == "" {
+		auditLog.ProgramID, _ = utils.GetValueFromContext(ctx, utils.ProgramContextKey)
+	}
+	if auditLog.Timestamp.IsZero() {
+		auditLog.Timestamp = time.Now()
+	}
+	// the database stores timestamps to the microsecond so the hashed timestamp must match the stored one
+	auditLog.Timestamp = auditLog.Timestamp.Truncate(time.Microsecond)
+
+	payload := pgtype.JSONB{}
+	err := payload.Set(map[string]interface{}{
+		"before": auditLog.Before,
+		"after":  auditLog.After,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set audit log payload: %w", err)
+	}
+
+	record := &gorm.AuditLog{
+		Active:         true,
+		Timestamp:      auditLog.Timestamp,
+		RecordType:     auditLog.RecordType.String(),
+		Notes:          auditLog.Notes,
+		Payload:        payload,
+		TargetType:     auditLog.TargetType.String(),
+		OrganisationID: auditLog.OrganisationID,
+	}
+	if auditLog.ActorID != "" {
+		record.ActorID = &auditLog.ActorID
+	}
+	if auditLog.TargetID != "" {
+		record.TargetID = &auditLog.TargetID
+	}
+	if auditLog.ProgramID != "" {
+		record.ProgramID = &auditLog.ProgramID
+	}
+
+	sign := func(record *gorm.AuditLog) error {
+		hash, err := utils.HashAuditLog(auditLogHashKey, mapAuditLogToDomain(record))
+		if err != nil {
+			return err
+		}
+		record.Hash = &hash
+		return nil
+	}
+
+	if err := d.create.CreateAuditLog(ctx, record, sign); err != nil {
+		return err
+	}
+
+	auditLog.ID = *record.ID
+	auditLog.Sequence = *record.Sequence
+	auditLog.PreviousHash = *record.PreviousHash
+	auditLog.Hash = *record.Hash
+
+	return nil
+}
+
+// SaveUserTOTP saves a user's TOTP enrollment replacing any existing enrollment
+func (d *MyCareHubDb) SaveUserTOTP(ctx context.Context, userTOTP *domain.UserTOTP) error {
+	record := &gorm.UserTOTP{
+		Active:         true,
+		UserID:         userTOTP.UserID,
+		Secret:         userTOTP.Secret,
+		Confirmed:      userTOTP.Confirmed,
+		ConfirmedAt:    userTOTP.ConfirmedAt,
+		FailedAttempts: userTOTP.FailedAttempts,
+		LastUsedStep:   userTOTP.LastUsedStep,
+	}
+
+	if err := d.create.SaveUserTOTP(ctx, record); err != nil {
+		return err
+	}
+
+	userTOTP.ID = record.ID
+
+	return nil
+}
+
+// SaveUserRecoveryCodes replaces a user's recovery codes with the provided ones
+func (d *MyCareHubDb) SaveUserRecoveryCodes(ctx context.Context, userID string, recoveryCodes []*domain.UserRecoveryCode) error {
+	records := []*gorm.UserRecoveryCode{}
+	for _, recoveryCode := range recoveryCodes {
+		records = append(records, &gorm.UserRecoveryCode{
+			Active:     true,
+			UserID:     userID,
+			HashedCode: recoveryCode.HashedCode,
+			Salt:       recoveryCode.Salt,
+		})
+	}
+
+	return d.create.SaveUserRecoveryCodes(ctx, userID, records)
+}
+
+// ConsumeRateLimit checks a request against the provided sliding window rules and records it
+func (d *MyCareHubDb) ConsumeRateLimit(ctx context.Context, action string, rules []*domain.RateLimitRule, at time.Time) (*domain.RateLimitDecision, error) {
+	return d.create.ConsumeRateLimit(ctx, action, rules, at)
+}
+
+// RecordLoginEvent records a successful login and the device that it was made from
+func (d *MyCareHubDb) RecordLoginEvent(ctx context.Context, event *domain.LoginEvent, device *domain.UserDevice) error {
+	riskFlags := pq.StringArray{}
+	for _, flag := range event.RiskFlags {
+		riskFlags = append(riskFlags, flag.String())
+	}
+
+	eventRecord := &gorm.LoginEvent{
+		Active:      true,
+		UserID:      event.UserID,
+		Fingerprint: event.Fingerprint,
+		IPAddress:   event.IPAddress,
+		Country:     event.Country,
+		RiskFlags:   riskFlags,
+		SteppedUp:   event.SteppedUp,
+		Timestamp:   event.Timestamp,
+	}
+
+	deviceRecord := &gorm.UserDevice{
+		Active:        true,
+		UserID:        device.UserID,
+		Fingerprint:   device.Fingerprint,
+		DeviceID:      device.DeviceID,
+		DeviceName:    device.DeviceName,
+		UserAgent:     device.UserAgent,
+		LastIPAddress: device.LastIPAddress,
+		LastCountry:   device.LastCountry,
+		LastLoginAt:   device.LastLoginAt,
+	}
+
+	return d.create.RecordLoginEvent(ctx, eventRecord, deviceRecord)
+}
+
+// CreatePhoneChangeRequest saves a request to change a user's phone number
+func (d *MyCareHubDb) CreatePhoneChangeRequest(ctx context.Context, request *domain.PhoneChangeRequest) (*domain.PhoneChangeRequest, error) {
+	record := mapPhoneChangeRequestToTable(request)
+
+	err := d.create.CreatePhoneChangeRequest(ctx, record)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapPhoneChangeRequestToDomain(record), nil
+}
+
+// TransferClient moves a client to another facility and records the transfer.
+// The payload holds the client's new profile and identifier when the client moves to another program
+func (d *MyCareHubDb) TransferClient(ctx context.Context, transfer *domain.ClientTransfer, payload *domain.ClientRegistrationPayload) (*domain.ClientTransfer, error) {
+	record := mapClientTransferToTable(transfer)
+
+	var identifier *gorm.Identifier
+	var client *gorm.Client
+	if payload != nil {
+		identifier = &gorm.Identifier{
+			Active:              payload.ClientIdentifier.Active,
+			Type:                payload.ClientIdentifier.Type.String(),
+			Value:               payload.ClientIdentifier.Value,
+			Use:                 payload.ClientIdentifier.Use,
+			Description:         payload.ClientIdentifier.Description,
+			IsPrimaryIdentifier: payload.ClientIdentifier.IsPrimaryIdentifier,
+			OrganisationID:      payload.ClientIdentifier.OrganisationID,
+			ProgramID:           payload.ClientIdentifier.ProgramID,
+		}
+
+		var clientTypes pq.StringArray
+		for _, clientType := range payload.Client.ClientTypes {
+			clientTypes = append(clientTypes, clientType.String())
+		}
+
+		client = &gorm.Client{
+			UserID:                  &payload.Client.UserID,
+			ClientTypes:             clientTypes,
+			TreatmentEnrollmentDate: payload.Client.TreatmentEnrollmentDate,
+			FHIRPatientID:           payload.Client.FHIRPatientID,
+			HealthRecordID:          payload.Client.HealthRecordID,
+			FacilityID:              *payload.Client.DefaultFacility.ID,
+			ClientCounselled:        payload.Client.ClientCounselled,
+			Active:                  payload.Client.Active,
+			ProgramID:               payload.Client.ProgramID,
+			OrganisationID:          payload.Client.OrganisationID,
+		}
+	}
+
+	err := d.create.TransferClient(ctx, record, identifier, client)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapClientTransferToDomain(record), nil
+}
+
+// SetServiceRequestSLA creates a program's SLA for a type of service request or replaces the one it has
+func (d *MyCareHubDb) SetServiceRequestSLA(ctx context.Context, sla *domain.ServiceRequestSLA) (*domain.ServiceRequestSLA, error) {
+	record := &gorm.ServiceRequestSLA{
+		Active:          true,
+		ProgramID:       sla.ProgramID,
+		OrganisationID:  sla.OrganisationID,
+		RequestType:     sla.RequestType.String(),
+		ResolutionHours: sla.ResolutionHours,
+		EscalationHours: sla.EscalationHours,
+	}
+
+	if err := d.create.SetServiceRequestSLA(ctx, record); err != nil {
+		return nil, err
+	}
+
+	return mapServiceRequestSLAToDomain(record), nil
+}
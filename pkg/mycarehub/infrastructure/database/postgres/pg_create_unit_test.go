@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"testing"
 	"time"
 
@@ -198,6 +199,14 @@ func TestMyCareHubDb_SaveOTP(t *testing.T) {
 					PhoneNumber: gofakeit.Phone(),
 					Channel:     "SMS",
 					Flavour:     feedlib.FlavourPro,
+					DeliveryAttempts: []*domain.OTPDeliveryAttempt{
+						{
+							Provider:          enums.OTPDeliveryProviderSILComms,
+							ProviderMessageID: uuid.New().String(),
+							Status:            enums.OTPDeliveryStatusSent,
+							AttemptedAt:       time.Now(),
+						},
+					},
 				},
 			},
 			wantErr: false,
@@ -2592,3 +2601,973 @@ func TestMyCareHubDb_CreateBooking(t *testing.T) {
 		})
 	}
 }
+
+func TestMyCareHubDb_CreateAuthorityRole(t *testing.T) {
+	type args struct {
+		ctx           context.Context
+		role          *domain.AuthorityRole
+		permissionIDs []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: create authority role",
+			args: args{
+				ctx:           context.Background(),
+				role:          &domain.AuthorityRole{Name: gofakeit.JobTitle(), UserType: enums.StaffUser, ProgramID: gofakeit.UUID()},
+				permissionIDs: []string{gofakeit.UUID()},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to create authority role",
+			args: args{
+				ctx:           context.Background(),
+				role:          &domain.AuthorityRole{Name: gofakeit.JobTitle(), UserType: enums.StaffUser, ProgramID: gofakeit.UUID()},
+				permissionIDs: []string{gofakeit.UUID()},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to create authority role" {
+				fakeGorm.MockCreateAuthorityRoleFn = func(ctx context.Context, role *gorm.AuthorityRole, permissionIDs []string) error {
+					return fmt.Errorf("error")
+				}
+			}
+
+			_, err := d.CreateAuthorityRole(tt.args.ctx, tt.args.role, tt.args.permissionIDs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.CreateAuthorityRole() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_AddPermissionsToRole(t *testing.T) {
+	type args struct {
+		ctx           context.Context
+		roleID        string
+		permissionIDs []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: add permissions to role",
+			args: args{
+				ctx:           context.Background(),
+				roleID:        gofakeit.UUID(),
+				permissionIDs: []string{gofakeit.UUID()},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to add permissions to role",
+			args: args{
+				ctx:           context.Background(),
+				roleID:        gofakeit.UUID(),
+				permissionIDs: []string{gofakeit.UUID()},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to add permissions to role" {
+				fakeGorm.MockAddPermissionsToRoleFn = func(ctx context.Context, roleID string, permissionIDs []string) error {
+					return fmt.Errorf("error")
+				}
+			}
+
+			err := d.AddPermissionsToRole(tt.args.ctx, tt.args.roleID, tt.args.permissionIDs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.AddPermissionsToRole() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_AssignRoles(t *testing.T) {
+	type args struct {
+		ctx       context.Context
+		userType  enums.UsersType
+		profileID string
+		roleIDs   []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: assign roles",
+			args: args{
+				ctx:       context.Background(),
+				userType:  enums.StaffUser,
+				profileID: gofakeit.UUID(),
+				roleIDs:   []string{gofakeit.UUID()},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to assign roles",
+			args: args{
+				ctx:       context.Background(),
+				userType:  enums.StaffUser,
+				profileID: gofakeit.UUID(),
+				roleIDs:   []string{gofakeit.UUID()},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to assign roles" {
+				fakeGorm.MockAssignRolesFn = func(ctx context.Context, userType enums.UsersType, profileID string, roleIDs []string) error {
+					return fmt.Errorf("error")
+				}
+			}
+
+			err := d.AssignRoles(tt.args.ctx, tt.args.userType, tt.args.profileID, tt.args.roleIDs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.AssignRoles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_SeedAuthorityRoles(t *testing.T) {
+	sharedPermission := domain.AuthorityPermission{Name: "Read content", Category: "Content", Scope: "content.read"}
+	adminPermission := domain.AuthorityPermission{Name: "Create role", Category: "Authorization", Scope: "role.create"}
+
+	type args struct {
+		ctx   context.Context
+		roles []domain.AuthorityRole
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: seed authority roles",
+			args: args{
+				ctx: context.Background(),
+				roles: []domain.AuthorityRole{
+					{
+						Name:         "Default Admin",
+						Active:       true,
+						IsSystemRole: true,
+						UserType:     enums.StaffUser,
+						Permissions:  []domain.AuthorityPermission{sharedPermission, adminPermission},
+					},
+					{
+						Name:         "Default Client",
+						Active:       true,
+						IsSystemRole: true,
+						UserType:     enums.ClientUser,
+						Permissions:  []domain.AuthorityPermission{sharedPermission},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to seed authority roles",
+			args: args{
+				ctx:   context.Background(),
+				roles: []domain.AuthorityRole{{Name: "Default Admin"}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			var seededPermissions []*gorm.AuthorityPermission
+			var seededRoleScopes map[string][]string
+			fakeGorm.MockSeedAuthorityRolesFn = func(ctx context.Context, permissions []*gorm.AuthorityPermission, roles []*gorm.AuthorityRole, roleScopes map[string][]string) error {
+				seededPermissions = permissions
+				seededRoleScopes = roleScopes
+				return nil
+			}
+
+			if tt.name == "Sad case: unable to seed authority roles" {
+				fakeGorm.MockSeedAuthorityRolesFn = func(ctx context.Context, permissions []*gorm.AuthorityPermission, roles []*gorm.AuthorityRole, roleScopes map[string][]string) error {
+					return fmt.Errorf("error")
+				}
+			}
+
+			err := d.SeedAuthorityRoles(tt.args.ctx, tt.args.roles)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.SeedAuthorityRoles() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.name == "Happy case: seed authority roles" {
+				if len(seededPermissions) != 2 {
+					t.Errorf("expected each permission to be seeded once, got %d permissions", len(seededPermissions))
+				}
+				if len(seededRoleScopes["Default Admin"]) != 2 || len(seededRoleScopes["Default Client"]) != 1 {
+					t.Errorf("expected the roles to be granted their permissions, got %v", seededRoleScopes)
+				}
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_AssignDefaultRoles(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{
+			name:    "Happy case: assign default roles",
+			wantErr: false,
+		},
+		{
+			name:    "Sad case: unable to assign default roles",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to assign default roles" {
+				fakeGorm.MockAssignDefaultRolesFn = func(ctx context.Context) error {
+					return fmt.Errorf("error")
+				}
+			}
+
+			if err := d.AssignDefaultRoles(context.Background()); (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.AssignDefaultRoles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_CreateAuditLog(t *testing.T) {
+	ctx := context.WithValue(context.Background(), utils.OrganisationContextKey, uuid.New().String())
+	ctx = context.WithValue(ctx, utils.ProgramContextKey, uuid.New().String())
+
+	type args struct {
+		ctx      context.Context
+		auditLog *domain.AuditLog
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: create audit log",
+			args: args{
+				ctx: ctx,
+				auditLog: &domain.AuditLog{
+					RecordType: enums.AuditLogOrganisationAdminChange,
+					Notes:      gofakeit.Sentence(5),
+					ActorID:    uuid.New().String(),
+					TargetID:   uuid.New().String(),
+					TargetType: enums.AuditLogTargetStaff,
+					Before:     map[string]interface{}{"is_organisation_admin": false},
+					After:      map[string]interface{}{"is_organisation_admin": true},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case: create audit log without actor and target",
+			args: args{
+				ctx: context.Background(),
+				auditLog: &domain.AuditLog{
+					RecordType:     enums.AuditLogPINReset,
+					OrganisationID: uuid.New().String(),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to create audit log",
+			args: args{
+				ctx: ctx,
+				auditLog: &domain.AuditLog{
+					RecordType: enums.AuditLogRoleChange,
+					TargetID:   uuid.New().String(),
+					TargetType: enums.AuditLogTargetRole,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to create audit log" {
+				fakeGorm.MockCreateAuditLogFn = func(ctx context.Context, auditLog *gorm.AuditLog, sign func(auditLog *gorm.AuditLog) error) error {
+					return fmt.Errorf("error")
+				}
+			}
+
+			err := d.CreateAuditLog(tt.args.ctx, tt.args.auditLog)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.CreateAuditLog() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && tt.args.auditLog.OrganisationID == "" {
+				t.Errorf("expected the audit log organisation to be set")
+			}
+			if !tt.wantErr && (tt.args.auditLog.Sequence == 0 || tt.args.auditLog.Hash == "") {
+				t.Errorf("expected the audit log to be linked to the chain")
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_SaveUserTOTP(t *testing.T) {
+	type args struct {
+		ctx      context.Context
+		userTOTP *domain.UserTOTP
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: save user TOTP",
+			args: args{
+				ctx:      context.Background(),
+				userTOTP: &domain.UserTOTP{UserID: uuid.New().String(), Secret: gofakeit.HipsterSentence(5)},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to save user TOTP",
+			args: args{
+				ctx:      context.Background(),
+				userTOTP: &domain.UserTOTP{UserID: uuid.New().String(), Secret: gofakeit.HipsterSentence(5)},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to save user TOTP" {
+				fakeGorm.MockSaveUserTOTPFn = func(ctx context.Context, userTOTP *gorm.UserTOTP) error {
+					return fmt.Errorf("error")
+				}
+			}
+
+			err := d.SaveUserTOTP(tt.args.ctx, tt.args.userTOTP)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.SaveUserTOTP() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_SaveUserRecoveryCodes(t *testing.T) {
+	type args struct {
+		ctx           context.Context
+		userID        string
+		recoveryCodes []*domain.UserRecoveryCode
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: save user recovery codes",
+			args: args{
+				ctx:           context.Background(),
+				userID:        uuid.New().String(),
+				recoveryCodes: []*domain.UserRecoveryCode{{HashedCode: gofakeit.HipsterSentence(5), Salt: gofakeit.HipsterSentence(5)}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to save user recovery codes",
+			args: args{
+				ctx:           context.Background(),
+				userID:        uuid.New().String(),
+				recoveryCodes: []*domain.UserRecoveryCode{{HashedCode: gofakeit.HipsterSentence(5), Salt: gofakeit.HipsterSentence(5)}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to save user recovery codes" {
+				fakeGorm.MockSaveUserRecoveryCodesFn = func(ctx context.Context, userID string, recoveryCodes []*gorm.UserRecoveryCode) error {
+					return fmt.Errorf("error")
+				}
+			}
+
+			err := d.SaveUserRecoveryCodes(tt.args.ctx, tt.args.userID, tt.args.recoveryCodes)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.SaveUserRecoveryCodes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_ConsumeRateLimit(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		action string
+		rules  []*domain.RateLimitRule
+		at     time.Time
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: consume rate limit",
+			args: args{
+				ctx:    context.Background(),
+				action: "OTP",
+				rules: []*domain.RateLimitRule{
+					{
+						KeyType: enums.RateLimitKeyPhone,
+						Key:     gofakeit.Phone(),
+						Limit:   5,
+						Window:  time.Hour,
+					},
+				},
+				at: time.Now(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to consume rate limit",
+			args: args{
+				ctx:    context.Background(),
+				action: "OTP",
+				rules: []*domain.RateLimitRule{
+					{
+						KeyType: enums.RateLimitKeyPhone,
+						Key:     gofakeit.Phone(),
+						Limit:   5,
+						Window:  time.Hour,
+					},
+				},
+				at: time.Now(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to consume rate limit" {
+				fakeGorm.MockConsumeRateLimitFn = func(ctx context.Context, action string, rules []*domain.RateLimitRule, at time.Time) (*domain.RateLimitDecision, error) {
+					return nil, fmt.Errorf("error")
+				}
+			}
+
+			got, err := d.ConsumeRateLimit(tt.args.ctx, tt.args.action, tt.args.rules, tt.args.at)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.ConsumeRateLimit() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got == nil {
+				t.Errorf("MyCareHubDb.ConsumeRateLimit() expected a decision")
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_CreateOpenIDConnectSession(t *testing.T) {
+	type args struct {
+		ctx     context.Context
+		session *domain.OpenIDConnectSession
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: create openid connect session",
+			args: args{
+				ctx: context.Background(),
+				session: &domain.OpenIDConnectSession{
+					Active:          true,
+					Code:            gofakeit.UUID(),
+					RequestedAt:     time.Now(),
+					RequestedScopes: []string{"openid"},
+					GrantedScopes:   []string{"openid"},
+					Form:            url.Values{"nonce": []string{gofakeit.UUID()}},
+					SessionID:       gofakeit.UUID(),
+					ClientID:        gofakeit.UUID(),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: failed to create openid connect session",
+			args: args{
+				ctx: context.Background(),
+				session: &domain.OpenIDConnectSession{
+					Active:    true,
+					Code:      gofakeit.UUID(),
+					SessionID: gofakeit.UUID(),
+					ClientID:  gofakeit.UUID(),
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: failed to create openid connect session" {
+				fakeGorm.MockCreateOpenIDConnectSessionFn = func(ctx context.Context, session *gorm.OpenIDConnectSession) error {
+					return fmt.Errorf("failed to create openid connect session")
+				}
+			}
+
+			if err := d.CreateOpenIDConnectSession(tt.args.ctx, tt.args.session); (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.CreateOpenIDConnectSession() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_CreatePKCE(t *testing.T) {
+	type args struct {
+		ctx  context.Context
+		pkce *domain.PKCE
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: create pkce session",
+			args: args{
+				ctx: context.Background(),
+				pkce: &domain.PKCE{
+					Active:          true,
+					Signature:       gofakeit.UUID(),
+					RequestedAt:     time.Now(),
+					RequestedScopes: []string{"openid"},
+					GrantedScopes:   []string{"openid"},
+					Form:            url.Values{"code_challenge": []string{gofakeit.UUID()}, "code_challenge_method": []string{"S256"}},
+					SessionID:       gofakeit.UUID(),
+					ClientID:        gofakeit.UUID(),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: failed to create pkce session",
+			args: args{
+				ctx: context.Background(),
+				pkce: &domain.PKCE{
+					Active:    true,
+					Signature: gofakeit.UUID(),
+					SessionID: gofakeit.UUID(),
+					ClientID:  gofakeit.UUID(),
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: failed to create pkce session" {
+				fakeGorm.MockCreatePKCEFn = func(ctx context.Context, pkce *gorm.PKCE) error {
+					return fmt.Errorf("failed to create pkce session")
+				}
+			}
+
+			if err := d.CreatePKCE(tt.args.ctx, tt.args.pkce); (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.CreatePKCE() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_CreateOauthSigningKey(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		key *domain.OauthSigningKey
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: create signing key",
+			args: args{
+				ctx: context.Background(),
+				key: &domain.OauthSigningKey{
+					KeyID:      gofakeit.UUID(),
+					Algorithm:  "RS256",
+					PrivateKey: gofakeit.HipsterSentence(10),
+					PublicKey:  gofakeit.HipsterSentence(10),
+					RotateAt:   time.Now().Add(time.Hour),
+					ExpiresAt:  time.Now().Add(2 * time.Hour),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: failed to create signing key",
+			args: args{
+				ctx: context.Background(),
+				key: &domain.OauthSigningKey{
+					KeyID: gofakeit.UUID(),
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: failed to create signing key" {
+				fakeGorm.MockCreateOauthSigningKeyFn = func(ctx context.Context, key *gorm.OauthSigningKey) error {
+					return fmt.Errorf("failed to create signing key")
+				}
+			}
+
+			if err := d.CreateOauthSigningKey(tt.args.ctx, tt.args.key); (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.CreateOauthSigningKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_RecordLoginEvent(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		event  *domain.LoginEvent
+		device *domain.UserDevice
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: record login event",
+			args: args{
+				ctx: context.Background(),
+				event: &domain.LoginEvent{
+					UserID:      uuid.New().String(),
+					Fingerprint: gofakeit.UUID(),
+					RiskFlags:   []enums.LoginRiskFlag{enums.LoginRiskNewDevice},
+					Timestamp:   time.Now(),
+				},
+				device: &domain.UserDevice{
+					UserID:      uuid.New().String(),
+					Fingerprint: gofakeit.UUID(),
+					LastLoginAt: time.Now(),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to record login event",
+			args: args{
+				ctx: context.Background(),
+				event: &domain.LoginEvent{
+					UserID:      uuid.New().String(),
+					Fingerprint: gofakeit.UUID(),
+					RiskFlags:   []enums.LoginRiskFlag{enums.LoginRiskNewDevice},
+					Timestamp:   time.Now(),
+				},
+				device: &domain.UserDevice{
+					UserID:      uuid.New().String(),
+					Fingerprint: gofakeit.UUID(),
+					LastLoginAt: time.Now(),
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to record login event" {
+				fakeGorm.MockRecordLoginEventFn = func(ctx context.Context, event *gorm.LoginEvent, device *gorm.UserDevice) error {
+					return fmt.Errorf("error")
+				}
+			}
+
+			err := d.RecordLoginEvent(tt.args.ctx, tt.args.event, tt.args.device)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.RecordLoginEvent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_CreatePhoneChangeRequest(t *testing.T) {
+	type args struct {
+		ctx     context.Context
+		request *domain.PhoneChangeRequest
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: create phone change request",
+			args: args{
+				ctx: context.Background(),
+				request: &domain.PhoneChangeRequest{
+					UserID:         uuid.NewString(),
+					Flavour:        feedlib.FlavourConsumer,
+					OldPhoneNumber: gofakeit.Phone(),
+					NewPhoneNumber: gofakeit.Phone(),
+					RequestedBy:    uuid.NewString(),
+					ExpiresAt:      time.Now().Add(time.Minute * 10),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to create phone change request",
+			args: args{
+				ctx: context.Background(),
+				request: &domain.PhoneChangeRequest{
+					UserID:         uuid.NewString(),
+					Flavour:        feedlib.FlavourConsumer,
+					OldPhoneNumber: gofakeit.Phone(),
+					NewPhoneNumber: gofakeit.Phone(),
+					RequestedBy:    uuid.NewString(),
+					ExpiresAt:      time.Now().Add(time.Minute * 10),
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to create phone change request" {
+				fakeGorm.MockCreatePhoneChangeRequestFn = func(ctx context.Context, request *gorm.PhoneChangeRequest) error {
+					return fmt.Errorf("error")
+				}
+			}
+
+			got, err := d.CreatePhoneChangeRequest(tt.args.ctx, tt.args.request)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.CreatePhoneChangeRequest() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got == nil {
+				t.Errorf("expected a phone change request")
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_TransferClient(t *testing.T) {
+	facilityID := uuid.NewString()
+
+	transfer := func() *domain.ClientTransfer {
+		return &domain.ClientTransfer{
+			UserID:             uuid.NewString(),
+			ClientID:           uuid.NewString(),
+			FromFacilityID:     uuid.NewString(),
+			ToFacilityID:       facilityID,
+			FromProgramID:      uuid.NewString(),
+			ToProgramID:        uuid.NewString(),
+			FromOrganisationID: uuid.NewString(),
+			ToOrganisationID:   uuid.NewString(),
+			Reason:             "Relocated",
+			CarriedHistory:     []enums.ClientTransferHistory{enums.ClientTransferHistoryHealthDiary},
+			TransferredBy:      uuid.NewString(),
+			TransferredAt:      time.Now(),
+		}
+	}
+
+	type args struct {
+		ctx      context.Context
+		transfer *domain.ClientTransfer
+		payload  *domain.ClientRegistrationPayload
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: transfer client within a program",
+			args: args{
+				ctx:      context.Background(),
+				transfer: transfer(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case: transfer client to another program",
+			args: args{
+				ctx:      context.Background(),
+				transfer: transfer(),
+				payload: &domain.ClientRegistrationPayload{
+					ClientIdentifier: domain.Identifier{
+						Type:  enums.UserIdentifierTypeCCC,
+						Value: "123456",
+					},
+					Client: domain.ClientProfile{
+						UserID:          uuid.NewString(),
+						ClientTypes:     []enums.ClientType{enums.ClientTypePmtct},
+						Active:          true,
+						DefaultFacility: &domain.Facility{ID: &facilityID},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to transfer client",
+			args: args{
+				ctx:      context.Background(),
+				transfer: transfer(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to transfer client" {
+				fakeGorm.MockTransferClientFn = func(ctx context.Context, transfer *gorm.ClientTransfer, identifier *gorm.Identifier, client *gorm.Client) error {
+					return fmt.Errorf("error")
+				}
+			}
+
+			got, err := d.TransferClient(tt.args.ctx, tt.args.transfer, tt.args.payload)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.TransferClient() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if got.NewClientID == "" {
+				t.Errorf("expected the client's new profile to be recorded")
+			}
+			if tt.args.payload == nil && got.NewClientID != tt.args.transfer.ClientID {
+				t.Errorf("expected a transfer within a program to keep the client profile")
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_SetServiceRequestSLA(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		sla *domain.ServiceRequestSLA
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: set service request sla",
+			args: args{
+				ctx: context.Background(),
+				sla: &domain.ServiceRequestSLA{
+					ProgramID:       uuid.NewString(),
+					OrganisationID:  uuid.NewString(),
+					RequestType:     enums.ServiceRequestTypeSurveyRedFlag,
+					ResolutionHours: 24,
+					EscalationHours: 12,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to set service request sla",
+			args: args{
+				ctx: context.Background(),
+				sla: &domain.ServiceRequestSLA{
+					ProgramID:       uuid.NewString(),
+					OrganisationID:  uuid.NewString(),
+					RequestType:     enums.ServiceRequestTypeSurveyRedFlag,
+					ResolutionHours: 24,
+					EscalationHours: 12,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to set service request sla" {
+				fakeGorm.MockSetServiceRequestSLAFn = func(ctx context.Context, sla *gorm.ServiceRequestSLA) error {
+					return fmt.Errorf("error")
+				}
+			}
+
+			got, err := d.SetServiceRequestSLA(tt.args.ctx, tt.args.sla)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.SetServiceRequestSLA() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && (got.ID == "" || got.RequestType != tt.args.sla.RequestType) {
+				t.Errorf("expected the service request sla to be set, got %v", got)
+			}
+		})
+	}
+}
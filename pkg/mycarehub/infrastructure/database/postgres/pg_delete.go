@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/gorm"
 )
@@ -54,7 +55,28 @@ func (d *MyCareHubDb) DeleteRefreshToken(ctx context.Context, signature string)
 	return d.delete.DeleteRefreshToken(ctx, signature)
 }
 
-// DeleteClientProfile method is used to delete a client user from the system
-func (d *MyCareHubDb) DeleteClientProfile(ctx context.Context, clientID string, userID *string) error {
-	return d.delete.DeleteClientProfile(ctx, clientID, userID)
+// DeletePKCE deletes the PKCE session of an authorization code using its signature
+func (d *MyCareHubDb) DeletePKCE(ctx context.Context, signature string) error {
+	return d.delete.DeletePKCE(ctx, signature)
+}
+
+// AnonymizeClientProfile removes the personally identifiable information held about a client while keeping their de-identified clinical records.
+// The user's personal information is removed as well when the user is provided
+func (d *MyCareHubDb) AnonymizeClientProfile(ctx context.Context, clientID string, userID *string) error {
+	return d.delete.AnonymizeClientProfile(ctx, clientID, userID)
+}
+
+// RemovePermissionsFromRole revokes the provided permissions from a role
+func (d *MyCareHubDb) RemovePermissionsFromRole(ctx context.Context, roleID string, permissionIDs []string) error {
+	return d.delete.RemovePermissionsFromRole(ctx, roleID, permissionIDs)
+}
+
+// RevokeRoles removes roles from a staff, client or caregiver profile
+func (d *MyCareHubDb) RevokeRoles(ctx context.Context, userType enums.UsersType, profileID string, roleIDs []string) error {
+	return d.delete.RevokeRoles(ctx, userType, profileID, roleIDs)
+}
+
+// DeleteUserTOTP removes a user's TOTP enrollment together with their recovery codes
+func (d *MyCareHubDb) DeleteUserTOTP(ctx context.Context, userID string) error {
+	return d.delete.DeleteUserTOTP(ctx, userID)
 }
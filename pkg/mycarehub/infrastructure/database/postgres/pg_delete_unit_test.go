@@ -7,6 +7,7 @@ import (
 
 	"github.com/brianvoe/gofakeit"
 	"github.com/google/uuid"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/gorm"
 	gormMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/gorm/mock"
@@ -206,7 +207,7 @@ func TestMyCareHubDb_DeleteOrganisation(t *testing.T) {
 	}
 }
 
-func TestMyCareHubDb_DeleteClientProfile(t *testing.T) {
+func TestMyCareHubDb_AnonymizeClientProfile(t *testing.T) {
 	ctx := context.Background()
 
 	var fakeGorm = gormMock.NewGormMock()
@@ -225,7 +226,7 @@ func TestMyCareHubDb_DeleteClientProfile(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "Happy case: delete client profile",
+			name: "Happy case: anonymize client profile",
 			args: args{
 				ctx:      ctx,
 				clientID: gofakeit.UUID(),
@@ -235,7 +236,7 @@ func TestMyCareHubDb_DeleteClientProfile(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "Sad case: failed to delete client profile",
+			name: "Sad case: failed to anonymize client profile",
 			args: args{
 				ctx:      ctx,
 				clientID: gofakeit.UUID(),
@@ -247,16 +248,163 @@ func TestMyCareHubDb_DeleteClientProfile(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if tt.name == "Sad case: failed to delete client profile" {
-				fakeGorm.MockDeleteClientProfileFn = func(ctx context.Context, clientID string, userID *string) error {
-					return fmt.Errorf("an error occurred while deleting")
+			if tt.name == "Sad case: failed to anonymize client profile" {
+				fakeGorm.MockAnonymizeClientProfileFn = func(ctx context.Context, clientID string, userID *string) error {
+					return fmt.Errorf("an error occurred while anonymizing")
 				}
 			}
-			err := d.DeleteClientProfile(tt.args.ctx, tt.args.clientID, tt.args.userID)
+			err := d.AnonymizeClientProfile(tt.args.ctx, tt.args.clientID, tt.args.userID)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("MyCareHubDb.DeleteClientProfile() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("MyCareHubDb.AnonymizeClientProfile() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 		})
 	}
 }
+
+func TestMyCareHubDb_RemovePermissionsFromRole(t *testing.T) {
+	type args struct {
+		ctx           context.Context
+		roleID        string
+		permissionIDs []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: remove permissions from role",
+			args: args{
+				ctx:           context.Background(),
+				roleID:        gofakeit.UUID(),
+				permissionIDs: []string{gofakeit.UUID()},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to remove permissions from role",
+			args: args{
+				ctx:           context.Background(),
+				roleID:        gofakeit.UUID(),
+				permissionIDs: []string{gofakeit.UUID()},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to remove permissions from role" {
+				fakeGorm.MockRemovePermissionsFromRoleFn = func(ctx context.Context, roleID string, permissionIDs []string) error {
+					return fmt.Errorf("error")
+				}
+			}
+
+			err := d.RemovePermissionsFromRole(tt.args.ctx, tt.args.roleID, tt.args.permissionIDs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.RemovePermissionsFromRole() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_RevokeRoles(t *testing.T) {
+	type args struct {
+		ctx       context.Context
+		userType  enums.UsersType
+		profileID string
+		roleIDs   []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: revoke roles",
+			args: args{
+				ctx:       context.Background(),
+				userType:  enums.CaregiverUser,
+				profileID: gofakeit.UUID(),
+				roleIDs:   []string{gofakeit.UUID()},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to revoke roles",
+			args: args{
+				ctx:       context.Background(),
+				userType:  enums.CaregiverUser,
+				profileID: gofakeit.UUID(),
+				roleIDs:   []string{gofakeit.UUID()},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to revoke roles" {
+				fakeGorm.MockRevokeRolesFn = func(ctx context.Context, userType enums.UsersType, profileID string, roleIDs []string) error {
+					return fmt.Errorf("error")
+				}
+			}
+
+			err := d.RevokeRoles(tt.args.ctx, tt.args.userType, tt.args.profileID, tt.args.roleIDs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.RevokeRoles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_DeleteUserTOTP(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		userID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: delete user TOTP",
+			args: args{
+				ctx:    context.Background(),
+				userID: uuid.New().String(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to delete user TOTP",
+			args: args{
+				ctx:    context.Background(),
+				userID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to delete user TOTP" {
+				fakeGorm.MockDeleteUserTOTPFn = func(ctx context.Context, userID string) error {
+					return fmt.Errorf("error")
+				}
+			}
+
+			err := d.DeleteUserTOTP(tt.args.ctx, tt.args.userID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.DeleteUserTOTP() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"strconv"
@@ -14,6 +15,7 @@ import (
 	"github.com/savannahghi/scalarutils"
 	"go.opentelemetry.io/otel"
 
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
@@ -107,6 +109,13 @@ func (d *MyCareHubDb) GetOrganisation(ctx context.Context, id string) (*domain.O
 		PhysicalAddress: record.PhysicalAddress,
 		DefaultCountry:  record.DefaultCountry,
 		Programs:        mappedPrograms,
+
+		EnforceStaffTOTP:  record.EnforceStaffTOTP,
+		StaffLoginStepUp:  record.StaffLoginStepUp,
+		PINPolicy:         mapPINPolicy(record),
+		DataRetentionDays: record.DataRetentionDays,
+		AuditLogSequence:  record.AuditLogSequence,
+		AuditLogHash:      record.AuditLogHash,
 	}, nil
 }
 
@@ -202,6 +211,32 @@ func (d *MyCareHubDb) GetUserPINByUserID(ctx context.Context, userID string) (*d
 	}, nil
 }
 
+// GetUserPINHistory fetches a user's most recent PINs, including the current one, starting with the newest
+func (d *MyCareHubDb) GetUserPINHistory(ctx context.Context, userID string, limit int) ([]*domain.UserPIN, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user id cannot be empty")
+	}
+
+	pins, err := d.query.GetUserPINHistory(ctx, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user PIN history: %w", err)
+	}
+
+	history := []*domain.UserPIN{}
+	for _, pinData := range pins {
+		history = append(history, &domain.UserPIN{
+			UserID:    pinData.UserID,
+			HashedPIN: pinData.HashedPIN,
+			ValidFrom: pinData.ValidFrom,
+			ValidTo:   pinData.ValidTo,
+			IsValid:   pinData.IsValid,
+			Salt:      pinData.Salt,
+		})
+	}
+
+	return history, nil
+}
+
 // GetCurrentTerms fetches the current terms service
 func (d *MyCareHubDb) GetCurrentTerms(ctx context.Context) (*domain.TermsOfService, error) {
 	terms, err := d.query.GetCurrentTerms(ctx)
@@ -744,6 +779,11 @@ func (d *MyCareHubDb) GetPendingServiceRequestsCount(ctx context.Context, facili
 	if facilityID == "" {
 		return nil, fmt.Errorf("facility ID cannot be empty")
 	}
+
+	if err := d.checkFacilityAccess(ctx, "GetPendingServiceRequestsCount", facilityID); err != nil {
+		return nil, err
+	}
+
 	clientsPendingServiceRequestsCount, err := d.query.GetClientsPendingServiceRequestsCount(ctx, facilityID, &programID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch clients pending service requests count: %v", err)
@@ -850,6 +890,7 @@ func (d *MyCareHubDb) GetClientProfileByClientID(ctx context.Context, clientID s
 		FHIRPatientID:           response.FHIRPatientID,
 		HealthRecordID:          response.HealthRecordID,
 		ClientCounselled:        response.ClientCounselled,
+		ErasureRequestedAt:      response.ErasureRequestedAt,
 		OrganisationID:          response.OrganisationID,
 		ProgramID:               response.ProgramID,
 		DefaultFacility:         facility,
@@ -863,6 +904,10 @@ func (d *MyCareHubDb) GetClientProfileByClientID(ctx context.Context, clientID s
 
 // GetServiceRequests retrieves the service requests by the type passed in the parameters
 func (d *MyCareHubDb) GetServiceRequests(ctx context.Context, requestType, requestStatus *string, facilityID string, programID string, flavour feedlib.Flavour, pagination *domain.Pagination) ([]*domain.ServiceRequest, *domain.Pagination, error) {
+	if err := d.checkFacilityAccess(ctx, "GetServiceRequests", facilityID); err != nil {
+		return nil, nil, err
+	}
+
 	switch flavour {
 	case feedlib.FlavourConsumer:
 		clientServiceRequests, page, err := d.query.GetServiceRequests(ctx, requestType, requestStatus, facilityID, programID, pagination)
@@ -952,6 +997,12 @@ func (d *MyCareHubDb) ReturnClientsServiceRequests(ctx context.Context, clientSe
 			CaregiverID:      caregiverID,
 			CaregiverName:    &caregiverName,
 			CaregiverContact: &caregiverContact,
+			ProgramID:        serviceRequest.ProgramID,
+			OrganisationID:   serviceRequest.OrganisationID,
+
+			SLABreachedAt:         serviceRequest.SLABreachedAt,
+			EscalationLevel:       mapServiceRequestEscalationLevel(serviceRequest.EscalationLevel),
+			PatientSafetyIncident: serviceRequest.PatientSafetyIncident,
 		}
 		if serviceRequest.CaregiverID != nil {
 			clientServiceRequest.CaregiverID = *serviceRequest.CaregiverID
@@ -1075,6 +1126,10 @@ func (d *MyCareHubDb) CheckFacilityExistsByIdentifier(ctx context.Context, ident
 
 // GetClientsInAFacility fetches all the clients that belong to a specific facility
 func (d *MyCareHubDb) GetClientsInAFacility(ctx context.Context, facilityID string) ([]*domain.ClientProfile, error) {
+	if err := d.checkStaffFacilityAccess(ctx, "GetClientsInAFacility", facilityID); err != nil {
+		return nil, err
+	}
+
 	clientProfiles, err := d.query.GetClientsInAFacility(ctx, facilityID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch clients that belong to a facility: %v", err)
@@ -1409,6 +1464,10 @@ func (d *MyCareHubDb) ListNotifications(ctx context.Context, params *domain.Noti
 
 // ListSurveyRespondents lists survey respondents based on the provided parameters
 func (d *MyCareHubDb) ListSurveyRespondents(ctx context.Context, params *domain.UserSurvey, facilityID string, pagination *domain.Pagination) ([]*domain.SurveyRespondent, *domain.Pagination, error) {
+	if err := d.checkFacilityAccess(ctx, "ListSurveyRespondents", facilityID); err != nil {
+		return nil, nil, err
+	}
+
 	userSurveyParams := &gorm.UserSurvey{
 		HasSubmitted: params.HasSubmitted,
 		FormID:       params.FormID,
@@ -1647,18 +1706,24 @@ func (d *MyCareHubDb) GetClientServiceRequestByID(ctx context.Context, serviceRe
 	}
 
 	return &domain.ServiceRequest{
-		ID:           *serviceRequest.ID,
-		RequestType:  serviceRequest.RequestType,
-		Request:      serviceRequest.Request,
-		Status:       serviceRequest.Status,
-		ClientID:     serviceRequest.ClientID,
-		CreatedAt:    serviceRequest.CreatedAt,
-		InProgressAt: serviceRequest.InProgressAt,
-		InProgressBy: serviceRequest.InProgressByID,
-		ResolvedAt:   serviceRequest.ResolvedAt,
-		ResolvedBy:   serviceRequest.ResolvedByID,
-		FacilityID:   serviceRequest.FacilityID,
-		Meta:         metadata,
+		ID:             *serviceRequest.ID,
+		RequestType:    serviceRequest.RequestType,
+		Request:        serviceRequest.Request,
+		Status:         serviceRequest.Status,
+		ClientID:       serviceRequest.ClientID,
+		CreatedAt:      serviceRequest.CreatedAt,
+		InProgressAt:   serviceRequest.InProgressAt,
+		InProgressBy:   serviceRequest.InProgressByID,
+		ResolvedAt:     serviceRequest.ResolvedAt,
+		ResolvedBy:     serviceRequest.ResolvedByID,
+		FacilityID:     serviceRequest.FacilityID,
+		Meta:           metadata,
+		ProgramID:      serviceRequest.ProgramID,
+		OrganisationID: serviceRequest.OrganisationID,
+
+		SLABreachedAt:         serviceRequest.SLABreachedAt,
+		EscalationLevel:       mapServiceRequestEscalationLevel(serviceRequest.EscalationLevel),
+		PatientSafetyIncident: serviceRequest.PatientSafetyIncident,
 	}, nil
 }
 
@@ -1683,6 +1748,7 @@ func (d *MyCareHubDb) GetStaffProfileByStaffID(ctx context.Context, staffID stri
 		StaffNumber:         staffProfile.StaffNumber,
 		DefaultFacility:     facility,
 		ProgramID:           staffProfile.ProgramID,
+		OrganisationID:      staffProfile.OrganisationID,
 		IsOrganisationAdmin: staffProfile.IsOrganisationAdmin,
 	}, nil
 }
@@ -1852,6 +1918,10 @@ func (d *MyCareHubDb) GetAppointment(ctx context.Context, params domain.Appointm
 
 // GetClientServiceRequests fetches all client service requests generated by the system given the status
 func (d *MyCareHubDb) GetClientServiceRequests(ctx context.Context, requestType, status, clientID, facilityID string) ([]*domain.ServiceRequest, error) {
+	if err := d.checkStaffFacilityAccess(ctx, "GetClientServiceRequests", facilityID); err != nil {
+		return nil, err
+	}
+
 	serviceRequests, err := d.query.GetClientServiceRequests(ctx, requestType, status, clientID, facilityID)
 	if err != nil {
 		return nil, err
@@ -1918,6 +1988,10 @@ func (d *MyCareHubDb) GetUserSurveyForms(ctx context.Context, params map[string]
 
 // GetSharedHealthDiaryEntries fetches the most recent shared health diary entry
 func (d *MyCareHubDb) GetSharedHealthDiaryEntries(ctx context.Context, clientID string, facilityID string) ([]*domain.ClientHealthDiaryEntry, error) {
+	if err := d.checkFacilityAccess(ctx, "GetSharedHealthDiaryEntries", facilityID); err != nil {
+		return nil, err
+	}
+
 	clientProfile, err := d.query.GetClientProfileByClientID(ctx, clientID)
 	if err != nil {
 		return nil, err
@@ -1999,6 +2073,10 @@ func (d *MyCareHubDb) GetNotification(ctx context.Context, notificationID string
 
 // GetClientsByFilterParams fetches clients by filter params
 func (d *MyCareHubDb) GetClientsByFilterParams(ctx context.Context, facilityID *string, filterParams *dto.ClientFilterParamsInput) ([]*domain.ClientProfile, error) {
+	if err := d.checkFacilityAccess(ctx, "GetClientsByFilterParams", *facilityID); err != nil {
+		return nil, err
+	}
+
 	clients, err := d.query.GetClientsByFilterParams(ctx, *facilityID, filterParams)
 	if err != nil {
 		return nil, err
@@ -2039,6 +2117,10 @@ func (d *MyCareHubDb) GetClientsByFilterParams(ctx context.Context, facilityID *
 
 // SearchClientServiceRequests is used to query(search) for client service requests depending on the search parameter
 func (d *MyCareHubDb) SearchClientServiceRequests(ctx context.Context, searchParameter string, requestType string, facilityID string) ([]*domain.ServiceRequest, error) {
+	if err := d.checkFacilityAccess(ctx, "SearchClientServiceRequests", facilityID); err != nil {
+		return nil, err
+	}
+
 	serviceRequests, err := d.query.SearchClientServiceRequests(ctx, searchParameter, requestType, facilityID)
 	if err != nil {
 		return nil, err
@@ -2049,6 +2131,10 @@ func (d *MyCareHubDb) SearchClientServiceRequests(ctx context.Context, searchPar
 
 // SearchStaffServiceRequests is used to query(search) for staff's service requests depending on the search parameter
 func (d *MyCareHubDb) SearchStaffServiceRequests(ctx context.Context, searchParameter string, requestType string, facilityID string) ([]*domain.ServiceRequest, error) {
+	if err := d.checkFacilityAccess(ctx, "SearchStaffServiceRequests", facilityID); err != nil {
+		return nil, err
+	}
+
 	serviceRequests, err := d.query.SearchStaffServiceRequests(ctx, searchParameter, requestType, facilityID)
 	if err != nil {
 		return nil, err
@@ -2297,6 +2383,10 @@ func (d *MyCareHubDb) GetScreeningToolResponsesWithPendingServiceRequests(ctx co
 
 // GetFacilityRespondedScreeningTools fetches responded screening tools for a given facility
 func (d *MyCareHubDb) GetFacilityRespondedScreeningTools(ctx context.Context, facilityID, programID string, pagination *domain.Pagination) ([]*domain.ScreeningTool, *domain.Pagination, error) {
+	if err := d.checkFacilityAccess(ctx, "GetFacilityRespondedScreeningTools", facilityID); err != nil {
+		return nil, nil, err
+	}
+
 	screeningTools, pageInfo, err := d.query.GetFacilityRespondedScreeningTools(ctx, facilityID, programID, pagination)
 	if err != nil {
 		return nil, nil, err
@@ -2327,7 +2417,9 @@ func (d *MyCareHubDb) GetFacilityRespondedScreeningTools(ctx context.Context, fa
 
 // GetScreeningToolRespondents fetches the respondents for a screening tool
 func (d *MyCareHubDb) GetScreeningToolRespondents(ctx context.Context, facilityID, programID string, screeningToolID string, searchTerm string, paginationInput *dto.PaginationsInput) ([]*domain.ScreeningToolRespondent, *domain.Pagination, error) {
-
+	if err := d.checkFacilityAccess(ctx, "GetScreeningToolRespondents", facilityID); err != nil {
+		return nil, nil, err
+	}
 	page := &domain.Pagination{
 		Limit:       paginationInput.Limit,
 		CurrentPage: paginationInput.CurrentPage,
@@ -2416,6 +2508,10 @@ func (d *MyCareHubDb) GetScreeningToolResponseByID(ctx context.Context, id strin
 
 // GetSurveysWithServiceRequests fetches all the surveys with a service request for a given facility
 func (d *MyCareHubDb) GetSurveysWithServiceRequests(ctx context.Context, facilityID, programID string) ([]*dto.SurveysWithServiceRequest, error) {
+	if err := d.checkFacilityAccess(ctx, "GetSurveysWithServiceRequests", facilityID); err != nil {
+		return nil, err
+	}
+
 	surveys, err := d.query.GetSurveysWithServiceRequests(ctx, facilityID, programID)
 	if err != nil {
 		return nil, err
@@ -2447,6 +2543,9 @@ func (d *MyCareHubDb) GetSurveysWithServiceRequests(ctx context.Context, facilit
 
 // GetSurveyServiceRequestUser returns a list of users who have a survey service request
 func (d *MyCareHubDb) GetSurveyServiceRequestUser(ctx context.Context, facilityID string, projectID int, formID string, pagination *domain.Pagination) ([]*domain.SurveyServiceRequestUser, *domain.Pagination, error) {
+	if err := d.checkFacilityAccess(ctx, "GetSurveyServiceRequestUser", facilityID); err != nil {
+		return nil, nil, err
+	}
 
 	serviceReq, pageInfo, err := d.query.GetClientsSurveyServiceRequest(ctx, facilityID, projectID, formID, pagination)
 	if err != nil {
@@ -2808,6 +2907,7 @@ func (d *MyCareHubDb) GetCaregiverManagedClients(ctx context.Context, userID str
 		if err != nil {
 			return nil, nil, err
 		}
+		delegation := mapCaregiverClientToDomain(caregiverClient)
 		managedClient := &domain.ManagedClient{
 			ClientProfile: &domain.ClientProfile{
 				ID:              clientProfile.ID,
@@ -2821,6 +2921,9 @@ func (d *MyCareHubDb) GetCaregiverManagedClients(ctx context.Context, userID str
 				Notifications: notificationCount,
 				Surveys:       surveyCount,
 			},
+			Abilities: delegation.Abilities,
+			StartsAt:  delegation.StartsAt,
+			EndsAt:    delegation.EndsAt,
 		}
 		managedClients = append(managedClients, managedClient)
 
@@ -2903,6 +3006,11 @@ func (d *MyCareHubDb) ListOrganisations(ctx context.Context, pagination *domain.
 			PhysicalAddress: organisation.PhysicalAddress,
 			DefaultCountry:  organisation.DefaultCountry,
 			Programs:        programs,
+
+			EnforceStaffTOTP:  organisation.EnforceStaffTOTP,
+			StaffLoginStepUp:  organisation.StaffLoginStepUp,
+			PINPolicy:         mapPINPolicy(organisation),
+			DataRetentionDays: organisation.DataRetentionDays,
 		})
 	}
 
@@ -3055,19 +3163,7 @@ func (d *MyCareHubDb) GetCaregiversClient(ctx context.Context, caregiverClient d
 	caregiverClients := []*domain.CaregiverClient{}
 
 	for _, client := range caregiverClientProfile {
-		caregiverClients = append(caregiverClients, &domain.CaregiverClient{
-			CaregiverID:        client.CaregiverID,
-			ClientID:           client.ClientID,
-			Active:             client.Active,
-			RelationshipType:   client.RelationshipType,
-			CaregiverConsent:   client.ClientConsent,
-			CaregiverConsentAt: client.CaregiverConsentAt,
-			ClientConsent:      client.ClientConsent,
-			ClientConsentAt:    client.ClientConsentAt,
-			OrganisationID:     client.OrganisationID,
-			AssignedBy:         client.AssignedBy,
-			ProgramID:          client.ProgramID,
-		})
+		caregiverClients = append(caregiverClients, mapCaregiverClientToDomain(client))
 	}
 
 	return caregiverClients, nil
@@ -3095,6 +3191,7 @@ func (d *MyCareHubDb) GetCaregiverProfileByCaregiverID(ctx context.Context, care
 		IsClient:        isClient,
 		CurrentClient:   caregiver.CurrentClient,
 		CurrentFacility: caregiver.CurrentFacility,
+		OrganisationID:  caregiver.OrganisationID,
 	}, nil
 }
 
@@ -3123,6 +3220,11 @@ func (d *MyCareHubDb) SearchOrganisation(ctx context.Context, searchParameter st
 			PhysicalAddress: org.PhysicalAddress,
 			DefaultCountry:  org.DefaultCountry,
 			Programs:        programs,
+
+			EnforceStaffTOTP:  org.EnforceStaffTOTP,
+			StaffLoginStepUp:  org.StaffLoginStepUp,
+			PINPolicy:         mapPINPolicy(org),
+			DataRetentionDays: org.DataRetentionDays,
 		})
 	}
 
@@ -3260,6 +3362,11 @@ func (d *MyCareHubDb) GetOauthClient(ctx context.Context, id string) (*domain.Oa
 		Grants:                  result.Grants,
 		ResponseTypes:           result.ResponseTypes,
 		TokenEndpointAuthMethod: result.TokenEndpointAuthMethod,
+		RegistrationAccessToken: result.RegistrationAccessToken,
+	}
+
+	if result.MFLCode != nil {
+		client.MFLCode = *result.MFLCode
 	}
 
 	return client, nil
@@ -3308,13 +3415,21 @@ func (d *MyCareHubDb) GetAuthorizationCode(ctx context.Context, code string) (*d
 	}
 
 	session := domain.Session{
-		ID:        result.Session.ID,
-		ClientID:  result.Session.ClientID,
-		Username:  result.Session.Username,
-		Subject:   result.Session.Subject,
-		ExpiresAt: sessionExpiresAt,
-		Extra:     sessionExtra,
-		UserID:    result.Session.UserID,
+		ID:         result.Session.ID,
+		ClientID:   result.Session.ClientID,
+		Username:   result.Session.Username,
+		Subject:    result.Session.Subject,
+		ExpiresAt:  sessionExpiresAt,
+		Extra:      sessionExtra,
+		UserID:     result.Session.UserID,
+		DeviceID:   result.Session.DeviceID,
+		DeviceName: result.Session.DeviceName,
+		UserAgent:  result.Session.UserAgent,
+		IPAddress:  result.Session.IPAddress,
+		PushToken:  result.Session.PushToken,
+		CreatedAt:  result.Session.CreatedAt,
+		LastSeenAt: result.Session.LastSeenAt,
+		RevokedAt:  result.Session.RevokedAt,
 	}
 
 	client := result.Client
@@ -3351,6 +3466,190 @@ func (d *MyCareHubDb) GetAuthorizationCode(ctx context.Context, code string) (*d
 	return authCode, nil
 }
 
+// GetOpenIDConnectSession retrieves the OpenID Connect session of an authorization code
+func (d *MyCareHubDb) GetOpenIDConnectSession(ctx context.Context, code string) (*domain.OpenIDConnectSession, error) {
+	result, err := d.query.GetOpenIDConnectSession(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	var form map[string][]string
+	err = result.Form.AssignTo(&form)
+	if err != nil {
+		return nil, err
+	}
+
+	var sessionExtra map[string]interface{}
+	err = result.Session.Extra.AssignTo(&sessionExtra)
+	if err != nil {
+		return nil, err
+	}
+
+	var sessionExpiresAt map[fosite.TokenType]time.Time
+	err = result.Session.ExpiresAt.AssignTo(&sessionExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	session := domain.Session{
+		ID:         result.Session.ID,
+		ClientID:   result.Session.ClientID,
+		Username:   result.Session.Username,
+		Subject:    result.Session.Subject,
+		ExpiresAt:  sessionExpiresAt,
+		Extra:      sessionExtra,
+		UserID:     result.Session.UserID,
+		DeviceID:   result.Session.DeviceID,
+		DeviceName: result.Session.DeviceName,
+		UserAgent:  result.Session.UserAgent,
+		IPAddress:  result.Session.IPAddress,
+		PushToken:  result.Session.PushToken,
+		CreatedAt:  result.Session.CreatedAt,
+		LastSeenAt: result.Session.LastSeenAt,
+		RevokedAt:  result.Session.RevokedAt,
+	}
+
+	client := result.Client
+
+	return &domain.OpenIDConnectSession{
+		ID:                result.ID,
+		Active:            result.Active,
+		Code:              result.Code,
+		RequestedAt:       result.RequestedAt,
+		RequestedScopes:   result.RequestedScopes,
+		GrantedScopes:     result.GrantedScopes,
+		Form:              form,
+		RequestedAudience: result.RequestedAudience,
+		GrantedAudience:   result.GrantedAudience,
+		SessionID:         result.SessionID,
+		Session:           session,
+		ClientID:          result.ClientID,
+		Client: domain.OauthClient{
+			ID:                      client.ID,
+			Name:                    client.Name,
+			Active:                  client.Active,
+			Secret:                  client.Secret,
+			RotatedSecrets:          client.RotatedSecrets,
+			Public:                  client.Public,
+			RedirectURIs:            client.RedirectURIs,
+			Scopes:                  client.Scopes,
+			Audience:                client.Audience,
+			Grants:                  client.Grants,
+			ResponseTypes:           client.ResponseTypes,
+			TokenEndpointAuthMethod: client.TokenEndpointAuthMethod,
+		},
+	}, nil
+}
+
+// GetPKCE retrieves the PKCE session of an authorization code using its signature
+func (d *MyCareHubDb) GetPKCE(ctx context.Context, signature string) (*domain.PKCE, error) {
+	result, err := d.query.GetPKCE(ctx, signature)
+	if err != nil {
+		return nil, err
+	}
+
+	var form map[string][]string
+	err = result.Form.AssignTo(&form)
+	if err != nil {
+		return nil, err
+	}
+
+	var sessionExtra map[string]interface{}
+	err = result.Session.Extra.AssignTo(&sessionExtra)
+	if err != nil {
+		return nil, err
+	}
+
+	var sessionExpiresAt map[fosite.TokenType]time.Time
+	err = result.Session.ExpiresAt.AssignTo(&sessionExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	session := domain.Session{
+		ID:         result.Session.ID,
+		ClientID:   result.Session.ClientID,
+		Username:   result.Session.Username,
+		Subject:    result.Session.Subject,
+		ExpiresAt:  sessionExpiresAt,
+		Extra:      sessionExtra,
+		UserID:     result.Session.UserID,
+		DeviceID:   result.Session.DeviceID,
+		DeviceName: result.Session.DeviceName,
+		UserAgent:  result.Session.UserAgent,
+		IPAddress:  result.Session.IPAddress,
+		PushToken:  result.Session.PushToken,
+		CreatedAt:  result.Session.CreatedAt,
+		LastSeenAt: result.Session.LastSeenAt,
+		RevokedAt:  result.Session.RevokedAt,
+	}
+
+	client := result.Client
+
+	return &domain.PKCE{
+		ID:                result.ID,
+		Active:            result.Active,
+		Signature:         result.Signature,
+		RequestedAt:       result.RequestedAt,
+		RequestedScopes:   result.RequestedScopes,
+		GrantedScopes:     result.GrantedScopes,
+		Form:              form,
+		RequestedAudience: result.RequestedAudience,
+		GrantedAudience:   result.GrantedAudience,
+		SessionID:         result.SessionID,
+		Session:           session,
+		ClientID:          result.ClientID,
+		Client: domain.OauthClient{
+			ID:                      client.ID,
+			Name:                    client.Name,
+			Active:                  client.Active,
+			Secret:                  client.Secret,
+			RotatedSecrets:          client.RotatedSecrets,
+			Public:                  client.Public,
+			RedirectURIs:            client.RedirectURIs,
+			Scopes:                  client.Scopes,
+			Audience:                client.Audience,
+			Grants:                  client.Grants,
+			ResponseTypes:           client.ResponseTypes,
+			TokenEndpointAuthMethod: client.TokenEndpointAuthMethod,
+		},
+	}, nil
+}
+
+// ListOauthSigningKeys returns the token signing keys that have not expired at the given time, newest first
+func (d *MyCareHubDb) ListOauthSigningKeys(ctx context.Context, activeAt time.Time) ([]*domain.OauthSigningKey, error) {
+	results, err := d.query.ListOauthSigningKeys(ctx, activeAt)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []*domain.OauthSigningKey{}
+	for _, result := range results {
+		keys = append(keys, &domain.OauthSigningKey{
+			ID:         result.ID,
+			KeyID:      result.KeyID,
+			Algorithm:  result.Algorithm,
+			PrivateKey: result.PrivateKey,
+			PublicKey:  result.PublicKey,
+			CreatedAt:  result.CreatedAt,
+			RotateAt:   result.RotateAt,
+			ExpiresAt:  result.ExpiresAt,
+		})
+	}
+
+	return keys, nil
+}
+
+// ListRevokedSessionIDs returns the IDs of the sessions that were revoked since the given time
+func (d *MyCareHubDb) ListRevokedSessionIDs(ctx context.Context, since time.Time) ([]string, error) {
+	return d.query.ListRevokedSessionIDs(ctx, since)
+}
+
+// ListRevokedAccessTokenSignatures returns the signatures of the access tokens that were revoked since the given time
+func (d *MyCareHubDb) ListRevokedAccessTokenSignatures(ctx context.Context, since time.Time) ([]string, error) {
+	return d.query.ListRevokedAccessTokenSignatures(ctx, since)
+}
+
 // GetAccessToken retrieves an access token using the signature
 func (d *MyCareHubDb) GetAccessToken(ctx context.Context, token domain.AccessToken) (*domain.AccessToken, error) {
 	params := gorm.AccessToken{
@@ -3382,13 +3681,21 @@ func (d *MyCareHubDb) GetAccessToken(ctx context.Context, token domain.AccessTok
 	}
 
 	session := domain.Session{
-		ID:        result.Session.ID,
-		ClientID:  result.Session.ClientID,
-		Username:  result.Session.Username,
-		Subject:   result.Session.Subject,
-		ExpiresAt: sessionExpiresAt,
-		Extra:     sessionExtra,
-		UserID:    result.Session.UserID,
+		ID:         result.Session.ID,
+		ClientID:   result.Session.ClientID,
+		Username:   result.Session.Username,
+		Subject:    result.Session.Subject,
+		ExpiresAt:  sessionExpiresAt,
+		Extra:      sessionExtra,
+		UserID:     result.Session.UserID,
+		DeviceID:   result.Session.DeviceID,
+		DeviceName: result.Session.DeviceName,
+		UserAgent:  result.Session.UserAgent,
+		IPAddress:  result.Session.IPAddress,
+		PushToken:  result.Session.PushToken,
+		CreatedAt:  result.Session.CreatedAt,
+		LastSeenAt: result.Session.LastSeenAt,
+		RevokedAt:  result.Session.RevokedAt,
 	}
 
 	client := result.Client
@@ -3456,13 +3763,21 @@ func (d *MyCareHubDb) GetRefreshToken(ctx context.Context, token domain.RefreshT
 	}
 
 	session := domain.Session{
-		ID:        result.Session.ID,
-		ClientID:  result.Session.ClientID,
-		Username:  result.Session.Username,
-		Subject:   result.Session.Subject,
-		ExpiresAt: sessionExpiresAt,
-		Extra:     sessionExtra,
-		UserID:    result.Session.UserID,
+		ID:         result.Session.ID,
+		ClientID:   result.Session.ClientID,
+		Username:   result.Session.Username,
+		Subject:    result.Session.Subject,
+		ExpiresAt:  sessionExpiresAt,
+		Extra:      sessionExtra,
+		UserID:     result.Session.UserID,
+		DeviceID:   result.Session.DeviceID,
+		DeviceName: result.Session.DeviceName,
+		UserAgent:  result.Session.UserAgent,
+		IPAddress:  result.Session.IPAddress,
+		PushToken:  result.Session.PushToken,
+		CreatedAt:  result.Session.CreatedAt,
+		LastSeenAt: result.Session.LastSeenAt,
+		RevokedAt:  result.Session.RevokedAt,
 	}
 
 	client := result.Client
@@ -3642,6 +3957,7 @@ func (d *MyCareHubDb) GetUserStaffProfiles(ctx context.Context, userID string) (
 			StaffNumber:         staffProfile.StaffNumber,
 			Facilities:          facilities,
 			ProgramID:           staffProfile.ProgramID,
+			OrganisationID:      staffProfile.OrganisationID,
 			DefaultFacility:     facility,
 			IsOrganisationAdmin: staffProfile.IsOrganisationAdmin,
 			Identifiers:         identifiers,
@@ -3687,3 +4003,627 @@ func (d *MyCareHubDb) ListBookings(ctx context.Context, clientID string, booking
 
 	return bookings, paginationInfo, nil
 }
+
+// GetUserPermissions retrieves the permissions a user has been granted in a program
+func (d *MyCareHubDb) GetUserPermissions(ctx context.Context, userID string, programID string) ([]*domain.AuthorityPermission, error) {
+	records, err := d.query.GetUserPermissions(ctx, userID, programID)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions := []*domain.AuthorityPermission{}
+	for _, record := range records {
+		permissions = append(permissions, mapAuthorityPermissionToDomain(record))
+	}
+
+	return permissions, nil
+}
+
+// GetAuthorityRoleByID retrieves a role together with the permissions that have been granted to it
+func (d *MyCareHubDb) GetAuthorityRoleByID(ctx context.Context, roleID string) (*domain.AuthorityRole, error) {
+	role, err := d.query.GetAuthorityRoleByID(ctx, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions, err := d.query.GetRolePermissions(ctx, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapAuthorityRoleToDomain(role, permissions), nil
+}
+
+// GetAuthorityRoleByName retrieves a program's role using its name together with the permissions that have been granted to it
+func (d *MyCareHubDb) GetAuthorityRoleByName(ctx context.Context, programID string, name string) (*domain.AuthorityRole, error) {
+	role, err := d.query.GetAuthorityRoleByName(ctx, programID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions, err := d.query.GetRolePermissions(ctx, *role.AuthorityRoleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapAuthorityRoleToDomain(role, permissions), nil
+}
+
+// ListAuthorityRoles lists the roles defined in a program together with their permissions
+func (d *MyCareHubDb) ListAuthorityRoles(ctx context.Context, programID string) ([]*domain.AuthorityRole, error) {
+	records, err := d.query.ListAuthorityRoles(ctx, programID)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := []*domain.AuthorityRole{}
+	for _, record := range records {
+		permissions, err := d.query.GetRolePermissions(ctx, *record.AuthorityRoleID)
+		if err != nil {
+			return nil, err
+		}
+
+		roles = append(roles, mapAuthorityRoleToDomain(record, permissions))
+	}
+
+	return roles, nil
+}
+
+// ListAuthorityPermissions lists all the permissions that can be granted to a role
+func (d *MyCareHubDb) ListAuthorityPermissions(ctx context.Context) ([]*domain.AuthorityPermission, error) {
+	records, err := d.query.ListAuthorityPermissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions := []*domain.AuthorityPermission{}
+	for _, record := range records {
+		permissions = append(permissions, mapAuthorityPermissionToDomain(record))
+	}
+
+	return permissions, nil
+}
+
+// GetProfileRoles retrieves the roles that have been assigned to a staff, client or caregiver profile
+func (d *MyCareHubDb) GetProfileRoles(ctx context.Context, userType enums.UsersType, profileID string) ([]*domain.AuthorityRole, error) {
+	records, err := d.query.GetProfileRoles(ctx, userType, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := []*domain.AuthorityRole{}
+	for _, record := range records {
+		permissions, err := d.query.GetRolePermissions(ctx, *record.AuthorityRoleID)
+		if err != nil {
+			return nil, err
+		}
+
+		roles = append(roles, mapAuthorityRoleToDomain(record, permissions))
+	}
+
+	return roles, nil
+}
+
+// checkFacilityAccess ensures that the logged in staff is assigned to a facility in their current program
+// before they read the facility's records. Denied attempts are recorded in the audit trail.
+func (d *MyCareHubDb) checkFacilityAccess(ctx context.Context, operation string, facilityID string) error {
+	userID := utils.GetLoggedInUserID(ctx)
+	if userID == nil {
+		err := fmt.Errorf("unable to get logged in user")
+		helpers.ReportErrorToSentry(err)
+		return exceptions.GetLoggedInUserUIDErr(err)
+	}
+
+	user, err := d.query.GetUserProfileByUserID(ctx, userID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return exceptions.UserNotFoundError(fmt.Errorf("failed to get user profile: %w", err))
+	}
+
+	hasAccess, err := d.query.CheckIfStaffHasFacilityAccess(ctx, *userID, user.CurrentProgramID, facilityID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return exceptions.InternalErr(err)
+	}
+
+	if hasAccess {
+		return nil
+	}
+
+	auditLog := &domain.AuditLog{
+		RecordType:     enums.AuditLogFacilityAccessDenied,
+		Notes:          fmt.Sprintf("%s was denied access to facility %s", operation, facilityID),
+		ActorID:        *userID,
+		TargetID:       facilityID,
+		TargetType:     enums.AuditLogTargetFacility,
+		ProgramID:      user.CurrentProgramID,
+		OrganisationID: user.CurrentOrganisationID,
+	}
+
+	if err := d.CreateAuditLog(ctx, auditLog); err != nil {
+		helpers.ReportErrorToSentry(fmt.Errorf("failed to record denied facility access: %w", err))
+	}
+
+	err = fmt.Errorf("user %s is not assigned to facility %s in program %s", *userID, facilityID, user.CurrentProgramID)
+	helpers.ReportErrorToSentry(err)
+	return exceptions.UserNotAuthorizedErr(err)
+}
+
+// checkStaffFacilityAccess applies the facility access check when the logged in user is a staff in their current program.
+// It guards readers that are shared with client and system flows, such as login or the KenyaEMR sync, which are scoped
+// by the client they act on rather than by a staff's facilities.
+func (d *MyCareHubDb) checkStaffFacilityAccess(ctx context.Context, operation string, facilityID string) error {
+	userID := utils.GetLoggedInUserID(ctx)
+	if userID == nil || facilityID == "" {
+		return nil
+	}
+
+	user, err := d.query.GetUserProfileByUserID(ctx, userID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return exceptions.UserNotFoundError(fmt.Errorf("failed to get user profile: %w", err))
+	}
+
+	isStaff, err := d.query.CheckStaffExistsInProgram(ctx, *userID, user.CurrentProgramID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return exceptions.InternalErr(err)
+	}
+
+	if !isStaff {
+		return nil
+	}
+
+	return d.checkFacilityAccess(ctx, operation, facilityID)
+}
+
+// ListAuditLogs lists an organisation's audit logs using the provided filters
+func (d *MyCareHubDb) ListAuditLogs(ctx context.Context, organisationID string, filter *dto.AuditLogFilterInput, pagination *domain.Pagination) ([]*domain.AuditLog, *domain.Pagination, error) {
+	auditLogs, pageInfo, err := d.query.ListAuditLogs(ctx, organisationID, filter, pagination)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	records := []*domain.AuditLog{}
+	for _, auditLog := range auditLogs {
+		records = append(records, mapAuditLogToDomain(auditLog))
+	}
+
+	return records, pageInfo, nil
+}
+
+// ListAuditLogChain retrieves an organisation's chained audit logs recorded after the provided sequence, in the order they were recorded
+func (d *MyCareHubDb) ListAuditLogChain(ctx context.Context, organisationID string, afterSequence int64, limit int) ([]*domain.AuditLog, error) {
+	auditLogs, err := d.query.ListAuditLogChain(ctx, organisationID, afterSequence, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	records := []*domain.AuditLog{}
+	for _, auditLog := range auditLogs {
+		records = append(records, mapAuditLogToDomain(auditLog))
+	}
+
+	return records, nil
+}
+
+// CountUnchainedAuditLogs counts an organisation's audit logs recorded since the provided time without being linked to the chain
+func (d *MyCareHubDb) CountUnchainedAuditLogs(ctx context.Context, organisationID string, since time.Time) (int64, error) {
+	return d.query.CountUnchainedAuditLogs(ctx, organisationID, since)
+}
+
+// GetUserTOTP retrieves a user's TOTP enrollment
+func (d *MyCareHubDb) GetUserTOTP(ctx context.Context, userID string) (*domain.UserTOTP, error) {
+	record, err := d.query.GetUserTOTP(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapUserTOTPToDomain(record), nil
+}
+
+// CheckIfUserHasTOTP checks whether a user has a confirmed TOTP enrollment
+func (d *MyCareHubDb) CheckIfUserHasTOTP(ctx context.Context, userID string) (bool, error) {
+	return d.query.CheckIfUserHasTOTP(ctx, userID)
+}
+
+// ListUserRecoveryCodes retrieves a user's recovery codes that have not been used
+func (d *MyCareHubDb) ListUserRecoveryCodes(ctx context.Context, userID string) ([]*domain.UserRecoveryCode, error) {
+	records, err := d.query.ListUserRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryCodes := []*domain.UserRecoveryCode{}
+	for _, record := range records {
+		recoveryCodes = append(recoveryCodes, &domain.UserRecoveryCode{
+			ID:         record.ID,
+			UserID:     record.UserID,
+			HashedCode: record.HashedCode,
+			Salt:       record.Salt,
+			UsedAt:     record.UsedAt,
+		})
+	}
+
+	return recoveryCodes, nil
+}
+
+// CheckIfStaffTOTPIsEnforced checks whether any of the organisations the user is a staff in requires staff to use a TOTP authenticator
+func (d *MyCareHubDb) CheckIfStaffTOTPIsEnforced(ctx context.Context, userID string) (bool, error) {
+	return d.query.CheckIfStaffTOTPIsEnforced(ctx, userID)
+}
+
+// ListUserSessions retrieves the sessions that a user has not been logged out of
+func (d *MyCareHubDb) ListUserSessions(ctx context.Context, userID string, activeSince time.Time) ([]*domain.Session, error) {
+	sessions, err := d.query.ListUserSessions(ctx, userID, activeSince)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []*domain.Session{}
+	for _, session := range sessions {
+		results = append(results, &domain.Session{
+			ID:         session.ID,
+			ClientID:   session.ClientID,
+			Username:   session.Username,
+			Subject:    session.Subject,
+			UserID:     session.UserID,
+			DeviceID:   session.DeviceID,
+			DeviceName: session.DeviceName,
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			PushToken:  session.PushToken,
+			CreatedAt:  session.CreatedAt,
+			LastSeenAt: session.LastSeenAt,
+			RevokedAt:  session.RevokedAt,
+		})
+	}
+
+	return results, nil
+}
+
+// ListUserDevices retrieves the devices that a user has logged in from
+func (d *MyCareHubDb) ListUserDevices(ctx context.Context, userID string) ([]*domain.UserDevice, error) {
+	records, err := d.query.ListUserDevices(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	devices := []*domain.UserDevice{}
+	for _, record := range records {
+		devices = append(devices, &domain.UserDevice{
+			ID:            record.ID,
+			UserID:        record.UserID,
+			Fingerprint:   record.Fingerprint,
+			DeviceID:      record.DeviceID,
+			DeviceName:    record.DeviceName,
+			UserAgent:     record.UserAgent,
+			LastIPAddress: record.LastIPAddress,
+			LastCountry:   record.LastCountry,
+			LastLoginAt:   record.LastLoginAt,
+		})
+	}
+
+	return devices, nil
+}
+
+// ListUserLoginCountries retrieves the countries that a user has logged in from
+func (d *MyCareHubDb) ListUserLoginCountries(ctx context.Context, userID string) ([]string, error) {
+	return d.query.ListUserLoginCountries(ctx, userID)
+}
+
+// CountUsersLoggedInFromIPAddress counts the different users that have logged in from an IP address since the given time
+func (d *MyCareHubDb) CountUsersLoggedInFromIPAddress(ctx context.Context, ipAddress string, since time.Time) (int, error) {
+	return d.query.CountUsersLoggedInFromIPAddress(ctx, ipAddress, since)
+}
+
+// ListDuplicateClientCandidates retrieves the clients in the same program that share a CCC number, phone number or name with a client.
+// Only the IDs of the candidates and the details they share with the client are returned
+func (d *MyCareHubDb) ListDuplicateClientCandidates(ctx context.Context, clientID string) ([]*domain.DuplicateClient, error) {
+	candidates, err := d.query.ListDuplicateClientCandidates(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	duplicates := []*domain.DuplicateClient{}
+	for _, candidate := range candidates {
+		candidateID := candidate.ClientID
+
+		matches := []enums.DuplicateClientMatch{}
+		if candidate.MatchesCCCNumber {
+			matches = append(matches, enums.DuplicateClientMatchCCCNumber)
+		}
+		if candidate.MatchesPhone {
+			matches = append(matches, enums.DuplicateClientMatchPhoneNumber)
+		}
+		if candidate.MatchesName {
+			matches = append(matches, enums.DuplicateClientMatchName)
+		}
+		if candidate.MatchesDateOfBirth {
+			matches = append(matches, enums.DuplicateClientMatchDateOfBirth)
+		}
+
+		duplicates = append(duplicates, &domain.DuplicateClient{
+			Client:  &domain.ClientProfile{ID: &candidateID},
+			Matches: matches,
+		})
+	}
+
+	return duplicates, nil
+}
+
+// ListUserContacts retrieves all the contacts of a user
+func (d *MyCareHubDb) ListUserContacts(ctx context.Context, userID string) ([]*domain.Contact, error) {
+	records, err := d.query.ListUserContacts(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	contacts := []*domain.Contact{}
+	for _, record := range records {
+		contacts = append(contacts, &domain.Contact{
+			ID:             &record.ID,
+			ContactType:    record.Type,
+			ContactValue:   record.Value,
+			Active:         record.Active,
+			OptedIn:        record.OptedIn,
+			UserID:         record.UserID,
+			OrganisationID: record.OrganisationID,
+		})
+	}
+
+	return contacts, nil
+}
+
+// ListClientScreeningToolResponses retrieves all the screening tool responses of a client without the responses to the questions
+func (d *MyCareHubDb) ListClientScreeningToolResponses(ctx context.Context, clientID string) ([]*domain.QuestionnaireScreeningToolResponse, error) {
+	records, err := d.query.ListClientScreeningToolResponses(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	screeningToolResponses := []*domain.QuestionnaireScreeningToolResponse{}
+	for _, record := range records {
+		screeningToolResponses = append(screeningToolResponses, &domain.QuestionnaireScreeningToolResponse{
+			ID:              record.ID,
+			Active:          record.Active,
+			ScreeningToolID: record.ScreeningToolID,
+			FacilityID:      record.FacilityID,
+			ClientID:        record.ClientID,
+			DateOfResponse:  record.CreatedAt,
+			AggregateScore:  record.AggregateScore,
+			ProgramID:       record.ProgramID,
+			OrganisationID:  record.OrganisationID,
+			CaregiverID:     record.CaregiverID,
+		})
+	}
+
+	return screeningToolResponses, nil
+}
+
+// ListUserMetrics retrieves all the metrics recorded for a user
+func (d *MyCareHubDb) ListUserMetrics(ctx context.Context, userID string) ([]*domain.Metric, error) {
+	records, err := d.query.ListUserMetrics(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := []*domain.Metric{}
+	for _, record := range records {
+		event := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(record.Payload), &event); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal metric payload: %w", err)
+		}
+
+		metrics = append(metrics, &domain.Metric{
+			ID:        record.ID,
+			UserID:    record.UserID,
+			Type:      record.Type,
+			Event:     event,
+			Timestamp: record.Timestamp,
+		})
+	}
+
+	return metrics, nil
+}
+
+// ListUserFeedback retrieves all the feedback that a user has sent
+func (d *MyCareHubDb) ListUserFeedback(ctx context.Context, userID string) ([]*domain.FeedbackResponse, error) {
+	records, err := d.query.ListUserFeedback(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	feedback := []*domain.FeedbackResponse{}
+	for _, record := range records {
+		feedback = append(feedback, &domain.FeedbackResponse{
+			UserID:            record.UserID,
+			FeedbackType:      enums.FeedbackType(record.FeedbackType),
+			SatisfactionLevel: record.SatisfactionLevel,
+			ServiceName:       record.ServiceName,
+			Feedback:          record.Feedback,
+			RequiresFollowUp:  record.RequiresFollowUp,
+			PhoneNumber:       record.PhoneNumber,
+			ProgramID:         record.ProgramID,
+			OrganisationID:    record.OrganisationID,
+		})
+	}
+
+	return feedback, nil
+}
+
+// ListClientsDueForErasure returns the clients who asked for their data to be erased and whose organisation's data retention period has passed
+func (d *MyCareHubDb) ListClientsDueForErasure(ctx context.Context, now time.Time) ([]*domain.ClientProfile, error) {
+	records, err := d.query.ListClientsDueForErasure(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+
+	clients := []*domain.ClientProfile{}
+	for _, record := range records {
+		clients = append(clients, &domain.ClientProfile{
+			ID:                 record.ID,
+			Active:             record.Active,
+			UserID:             *record.UserID,
+			ErasureRequestedAt: record.ErasureRequestedAt,
+			OrganisationID:     record.OrganisationID,
+			ProgramID:          record.ProgramID,
+		})
+	}
+
+	return clients, nil
+}
+
+// ListExpiredCaregiverDelegations returns the caregiver delegations whose end date has passed but have not been marked as expired
+func (d *MyCareHubDb) ListExpiredCaregiverDelegations(ctx context.Context, now time.Time) ([]*domain.CaregiverClient, error) {
+	records, err := d.query.ListExpiredCaregiverDelegations(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+
+	delegations := []*domain.CaregiverClient{}
+	for _, record := range records {
+		delegations = append(delegations, mapCaregiverClientToDomain(record))
+	}
+
+	return delegations, nil
+}
+
+// GetPendingPhoneChangeRequest returns the latest phone change request of a user that has not been confirmed
+func (d *MyCareHubDb) GetPendingPhoneChangeRequest(ctx context.Context, userID string, flavour feedlib.Flavour) (*domain.PhoneChangeRequest, error) {
+	record, err := d.query.GetPendingPhoneChangeRequest(ctx, userID, flavour)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapPhoneChangeRequestToDomain(record), nil
+}
+
+// GetStaffInProgressServiceRequests returns the client service requests that the staff have marked as in progress and not resolved
+func (d *MyCareHubDb) GetStaffInProgressServiceRequests(ctx context.Context, staffIDs []string) ([]*domain.ServiceRequest, error) {
+	records, err := d.query.GetStaffInProgressServiceRequests(ctx, staffIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceRequests := []*domain.ServiceRequest{}
+	for _, record := range records {
+		serviceRequests = append(serviceRequests, &domain.ServiceRequest{
+			ID:             *record.ID,
+			RequestType:    record.RequestType,
+			Request:        record.Request,
+			Status:         record.Status,
+			Active:         record.Active,
+			ClientID:       record.ClientID,
+			CreatedAt:      record.CreatedAt,
+			InProgressAt:   record.InProgressAt,
+			InProgressBy:   record.InProgressByID,
+			FacilityID:     record.FacilityID,
+			ProgramID:      record.ProgramID,
+			OrganisationID: record.OrganisationID,
+		})
+	}
+
+	return serviceRequests, nil
+}
+
+// ListOrganisationAdmins returns the active staff profiles of an organisation that have organisation admin rights
+func (d *MyCareHubDb) ListOrganisationAdmins(ctx context.Context, organisationID string) ([]*domain.StaffProfile, error) {
+	records, err := d.query.ListOrganisationAdmins(ctx, organisationID)
+	if err != nil {
+		return nil, err
+	}
+
+	staffProfiles := []*domain.StaffProfile{}
+	for _, record := range records {
+		staffProfiles = append(staffProfiles, &domain.StaffProfile{
+			ID:                  record.ID,
+			UserID:              record.UserID,
+			Active:              record.Active,
+			StaffNumber:         record.StaffNumber,
+			ProgramID:           record.ProgramID,
+			OrganisationID:      record.OrganisationID,
+			IsOrganisationAdmin: record.IsOrganisationAdmin,
+		})
+	}
+
+	return staffProfiles, nil
+}
+
+// ListFacilityAdmins returns the active staff of a program that are assigned to a facility and hold the program's admin role
+func (d *MyCareHubDb) ListFacilityAdmins(ctx context.Context, facilityID, programID string) ([]*domain.StaffProfile, error) {
+	records, err := d.query.ListFacilityAdmins(ctx, facilityID, programID)
+	if err != nil {
+		return nil, err
+	}
+
+	staffProfiles := []*domain.StaffProfile{}
+	for _, record := range records {
+		staffProfiles = append(staffProfiles, &domain.StaffProfile{
+			ID:                  record.ID,
+			UserID:              record.UserID,
+			Active:              record.Active,
+			StaffNumber:         record.StaffNumber,
+			ProgramID:           record.ProgramID,
+			OrganisationID:      record.OrganisationID,
+			IsOrganisationAdmin: record.IsOrganisationAdmin,
+		})
+	}
+
+	return staffProfiles, nil
+}
+
+// ListClientTransfers returns the transfers of a client's user across all their client profiles, the most recent first
+func (d *MyCareHubDb) ListClientTransfers(ctx context.Context, userID string) ([]*domain.ClientTransfer, error) {
+	records, err := d.query.ListClientTransfers(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	transfers := []*domain.ClientTransfer{}
+	for _, record := range records {
+		transfers = append(transfers, mapClientTransferToDomain(record))
+	}
+
+	return transfers, nil
+}
+
+// ListServiceRequestSLAs returns the service request SLAs of a program or of all the programs when it is not provided
+func (d *MyCareHubDb) ListServiceRequestSLAs(ctx context.Context, programID *string) ([]*domain.ServiceRequestSLA, error) {
+	records, err := d.query.ListServiceRequestSLAs(ctx, programID)
+	if err != nil {
+		return nil, err
+	}
+
+	slas := []*domain.ServiceRequestSLA{}
+	for _, record := range records {
+		slas = append(slas, mapServiceRequestSLAToDomain(record))
+	}
+
+	return slas, nil
+}
+
+// GetServiceRequestsDueForEscalation returns the pending client service requests of the given types that have not been escalated to the admins yet
+func (d *MyCareHubDb) GetServiceRequestsDueForEscalation(ctx context.Context, requestTypes []string) ([]*domain.ServiceRequest, error) {
+	records, err := d.query.GetServiceRequestsDueForEscalation(ctx, requestTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.ReturnClientsServiceRequests(ctx, records)
+}
+
+// ListServiceRequestEscalations returns the escalations of a service request, the earliest first
+func (d *MyCareHubDb) ListServiceRequestEscalations(ctx context.Context, serviceRequestID string) ([]*domain.ServiceRequestEscalation, error) {
+	records, err := d.query.ListServiceRequestEscalations(ctx, serviceRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	escalations := []*domain.ServiceRequestEscalation{}
+	for _, record := range records {
+		escalations = append(escalations, mapServiceRequestEscalationToDomain(record))
+	}
+
+	return escalations, nil
+}
@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"firebase.google.com/go/auth"
 	"github.com/brianvoe/gofakeit"
 	"github.com/google/uuid"
 	"github.com/savannahghi/enumutils"
@@ -346,6 +347,70 @@ func TestOnboardingDb_GetUserPINByUserID(t *testing.T) {
 	}
 }
 
+func TestMyCareHubDb_GetUserPINHistory(t *testing.T) {
+	ctx := context.Background()
+	type args struct {
+		ctx    context.Context
+		userID string
+		limit  int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy Case - Successfully get user pin history",
+			args: args{
+				ctx:    ctx,
+				userID: uuid.New().String(),
+				limit:  3,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad Case - Fail to get user pin history",
+			args: args{
+				ctx:    ctx,
+				userID: uuid.New().String(),
+				limit:  3,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad Case - empty user id",
+			args: args{
+				ctx:    ctx,
+				userID: "",
+				limit:  3,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad Case - Fail to get user pin history" {
+				fakeGorm.MockGetUserPINHistoryFn = func(ctx context.Context, userID string, limit int) ([]*gorm.PINData, error) {
+					return nil, fmt.Errorf("failed to get user pin history")
+				}
+			}
+
+			got, err := d.GetUserPINHistory(tt.args.ctx, tt.args.userID, tt.args.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.GetUserPINHistory() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got) == 0 {
+				t.Errorf("expected to get the user's pins but got: %v", got)
+				return
+			}
+		})
+	}
+}
+
 func TestMyCareHubDb_GetCurrentTerms(t *testing.T) {
 	ctx := context.Background()
 
@@ -1944,7 +2009,7 @@ func TestMyCareHubDb_GetClientHealthDiaryEntries(t *testing.T) {
 }
 
 func TestMyCareHubDb_GetPendingServiceRequestsCount(t *testing.T) {
-	ctx := context.Background()
+	ctx := context.WithValue(context.Background(), firebasetools.AuthTokenContextKey, &auth.Token{UID: uuid.New().String()})
 
 	facilityID := uuid.New().String()
 
@@ -1992,12 +2057,27 @@ func TestMyCareHubDb_GetPendingServiceRequestsCount(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Sad case: staff not assigned to facility",
+			args: args{
+				ctx:        ctx,
+				facilityID: facilityID,
+				programID:  gofakeit.UUID(),
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			var fakeGorm = gormMock.NewGormMock()
 			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
 
+			if tt.name == "Sad case: staff not assigned to facility" {
+				fakeGorm.MockCheckIfStaffHasFacilityAccessFn = func(ctx context.Context, userID, programID, facilityID string) (bool, error) {
+					return false, nil
+				}
+			}
+
 			if tt.name == "Sad case: unable to get pending service request count" {
 				fakeGorm.MockGetClientPendingServiceRequestsCountFn = func(ctx context.Context, facilityID string, programID *string) (*domain.ServiceRequestsCount, error) {
 					return nil, fmt.Errorf("an error occurred")
@@ -2138,6 +2218,7 @@ func TestMyCareHubDb_GetClientByClientID(t *testing.T) {
 func TestMyCareHubDb_GetServiceRequests(t *testing.T) {
 	var requesttype = enums.ServiceRequestTypeRedFlag.String()
 	facilityID := uuid.New().String()
+	ctx := context.WithValue(context.Background(), firebasetools.AuthTokenContextKey, &auth.Token{UID: uuid.New().String()})
 
 	type args struct {
 		ctx           context.Context
@@ -2158,7 +2239,7 @@ func TestMyCareHubDb_GetServiceRequests(t *testing.T) {
 		{
 			name: "Happy Case - Successfully get service requests - Consumer",
 			args: args{
-				ctx:           context.Background(),
+				ctx:           ctx,
 				requestType:   &requesttype,
 				requestStatus: new(string),
 				facilityID:    facilityID,
@@ -2174,7 +2255,7 @@ func TestMyCareHubDb_GetServiceRequests(t *testing.T) {
 		{
 			name: "Happy Case - Successfully get service requests - Pro",
 			args: args{
-				ctx:           context.Background(),
+				ctx:           ctx,
 				requestType:   &requesttype,
 				requestStatus: new(string),
 				facilityID:    facilityID,
@@ -2190,7 +2271,7 @@ func TestMyCareHubDb_GetServiceRequests(t *testing.T) {
 		{
 			name: "Sad Case - Invalid Flavour",
 			args: args{
-				ctx:           context.Background(),
+				ctx:           ctx,
 				requestType:   &requesttype,
 				requestStatus: new(string),
 				facilityID:    facilityID,
@@ -2206,7 +2287,7 @@ func TestMyCareHubDb_GetServiceRequests(t *testing.T) {
 		{
 			name: "Sad Case - Fail to get service requests - Consumer",
 			args: args{
-				ctx:           context.Background(),
+				ctx:           ctx,
 				requestType:   &requesttype,
 				requestStatus: new(string),
 				facilityID:    facilityID,
@@ -2222,7 +2303,7 @@ func TestMyCareHubDb_GetServiceRequests(t *testing.T) {
 		{
 			name: "Sad Case - Fail to get client profile by client ID",
 			args: args{
-				ctx:           context.Background(),
+				ctx:           ctx,
 				requestType:   &requesttype,
 				requestStatus: new(string),
 				facilityID:    facilityID,
@@ -2238,7 +2319,7 @@ func TestMyCareHubDb_GetServiceRequests(t *testing.T) {
 		{
 			name: "Sad Case - Fail to get user profile by staff ID",
 			args: args{
-				ctx:           context.Background(),
+				ctx:           ctx,
 				requestType:   &requesttype,
 				requestStatus: new(string),
 				facilityID:    facilityID,
@@ -2254,7 +2335,7 @@ func TestMyCareHubDb_GetServiceRequests(t *testing.T) {
 		{
 			name: "Sad Case - Fail to get staff service requests",
 			args: args{
-				ctx:           context.Background(),
+				ctx:           ctx,
 				requestType:   &requesttype,
 				requestStatus: new(string),
 				facilityID:    facilityID,
@@ -2270,7 +2351,7 @@ func TestMyCareHubDb_GetServiceRequests(t *testing.T) {
 		{
 			name: "Sad Case - Fail to get staff profile",
 			args: args{
-				ctx:           context.Background(),
+				ctx:           ctx,
 				requestType:   &requesttype,
 				requestStatus: new(string),
 				facilityID:    facilityID,
@@ -2286,7 +2367,7 @@ func TestMyCareHubDb_GetServiceRequests(t *testing.T) {
 		{
 			name: "Sad Case - Fail to get user profile by staff ID",
 			args: args{
-				ctx:           context.Background(),
+				ctx:           ctx,
 				requestType:   &requesttype,
 				requestStatus: new(string),
 				facilityID:    facilityID,
@@ -2299,12 +2380,56 @@ func TestMyCareHubDb_GetServiceRequests(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Sad Case - No logged in user",
+			args: args{
+				ctx:         context.Background(),
+				requestType: &requesttype,
+				facilityID:  facilityID,
+				programID:   uuid.New().String(),
+				flavour:     feedlib.FlavourConsumer,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad Case - Staff not assigned to facility",
+			args: args{
+				ctx:         ctx,
+				requestType: &requesttype,
+				facilityID:  facilityID,
+				programID:   uuid.New().String(),
+				flavour:     feedlib.FlavourConsumer,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad Case - Fail to check staff facility access",
+			args: args{
+				ctx:         ctx,
+				requestType: &requesttype,
+				facilityID:  facilityID,
+				programID:   uuid.New().String(),
+				flavour:     feedlib.FlavourConsumer,
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			var fakeGorm = gormMock.NewGormMock()
 			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
 
+			if tt.name == "Sad Case - Staff not assigned to facility" {
+				fakeGorm.MockCheckIfStaffHasFacilityAccessFn = func(ctx context.Context, userID, programID, facilityID string) (bool, error) {
+					return false, nil
+				}
+			}
+			if tt.name == "Sad Case - Fail to check staff facility access" {
+				fakeGorm.MockCheckIfStaffHasFacilityAccessFn = func(ctx context.Context, userID, programID, facilityID string) (bool, error) {
+					return false, fmt.Errorf("failed to check staff facility access")
+				}
+			}
+
 			if tt.name == "Sad Case - Fail to get service requests - Consumer" {
 				fakeGorm.MockGetServiceRequestsFn = func(ctx context.Context, requestType, requestStatus *string, facilityID string, programID string, pagination *domain.Pagination) ([]*gorm.ClientServiceRequest, *domain.Pagination, error) {
 					return nil, nil, fmt.Errorf("failed to get service requests by type")
@@ -2487,7 +2612,7 @@ func TestMyCareHubDb_GetCommunityByID(t *testing.T) {
 }
 
 func TestMyCareHubDb_GetClientsInAFacility(t *testing.T) {
-	ctx := context.Background()
+	ctx := context.WithValue(context.Background(), firebasetools.AuthTokenContextKey, &auth.Token{UID: uuid.New().String()})
 	type args struct {
 		ctx        context.Context
 		facilityID string
@@ -2513,12 +2638,29 @@ func TestMyCareHubDb_GetClientsInAFacility(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Sad case: staff not assigned to facility",
+			args: args{
+				ctx:        ctx,
+				facilityID: "1223445",
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			var fakeGorm = gormMock.NewGormMock()
 			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
 
+			if tt.name == "Sad case: staff not assigned to facility" {
+				fakeGorm.MockCheckStaffExistsInProgramFn = func(ctx context.Context, userID, programID string) (bool, error) {
+					return true, nil
+				}
+				fakeGorm.MockCheckIfStaffHasFacilityAccessFn = func(ctx context.Context, userID, programID, facilityID string) (bool, error) {
+					return false, nil
+				}
+			}
+
 			if tt.name == "Sad Case - Fail to get clients in a facility" {
 				fakeGorm.MockGetClientsInAFacilityFn = func(ctx context.Context, facilityID string) ([]*gorm.Client, error) {
 					return nil, fmt.Errorf("failed to get clients in a facility")
@@ -4088,6 +4230,8 @@ func TestMyCareHubDb_GetFacilitiesWithoutFHIRID(t *testing.T) {
 }
 
 func TestMyCareHubDb_GetClientServiceRequests(t *testing.T) {
+	ctx := context.WithValue(context.Background(), firebasetools.AuthTokenContextKey, &auth.Token{UID: uuid.New().String()})
+
 	var fakeGorm = gormMock.NewGormMock()
 	d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
 	type args struct {
@@ -4105,16 +4249,35 @@ func TestMyCareHubDb_GetClientServiceRequests(t *testing.T) {
 		{
 			name: "Happy case:  get system generated client service requests",
 			args: args{
-				ctx:        context.Background(),
+				ctx:        ctx,
+				toolType:   "SCREENING_TOOLS_RED_FLAG",
+				status:     enums.ServiceRequestStatusPending.String(),
+				clientID:   uuid.New().String(),
+				facilityID: uuid.New().String(),
+			},
+		},
+		{
+			name: "Sad case: staff not assigned to facility",
+			args: args{
+				ctx:        ctx,
 				toolType:   "SCREENING_TOOLS_RED_FLAG",
 				status:     enums.ServiceRequestStatusPending.String(),
 				clientID:   uuid.New().String(),
 				facilityID: uuid.New().String(),
 			},
+			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			if tt.name == "Sad case: staff not assigned to facility" {
+				fakeGorm.MockCheckStaffExistsInProgramFn = func(ctx context.Context, userID, programID string) (bool, error) {
+					return true, nil
+				}
+				fakeGorm.MockCheckIfStaffHasFacilityAccessFn = func(ctx context.Context, userID, programID, facilityID string) (bool, error) {
+					return false, nil
+				}
+			}
 
 			got, err := d.GetClientServiceRequests(tt.args.ctx, tt.args.toolType, tt.args.status, tt.args.clientID, tt.args.facilityID)
 			if (err != nil) != tt.wantErr {
@@ -4189,7 +4352,7 @@ func TestMyCareHubDb_GetAppointment(t *testing.T) {
 }
 
 func TestMyCareHubDb_GetSharedHealthDiaryEntry(t *testing.T) {
-	ctx := context.Background()
+	ctx := context.WithValue(context.Background(), firebasetools.AuthTokenContextKey, &auth.Token{UID: uuid.New().String()})
 
 	var fakeGorm = gormMock.NewGormMock()
 	d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
@@ -4241,9 +4404,54 @@ func TestMyCareHubDb_GetSharedHealthDiaryEntry(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Sad case - staff not assigned to facility",
+			args: args{
+				ctx:        ctx,
+				clientID:   uuid.New().String(),
+				facilityID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - staff not assigned to facility and unable to record audit log",
+			args: args{
+				ctx:        ctx,
+				clientID:   uuid.New().String(),
+				facilityID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case - unable to get logged in user profile",
+			args: args{
+				ctx:        ctx,
+				clientID:   uuid.New().String(),
+				facilityID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			if tt.name == "Sad case - staff not assigned to facility" {
+				fakeGorm.MockCheckIfStaffHasFacilityAccessFn = func(ctx context.Context, userID, programID, facilityID string) (bool, error) {
+					return false, nil
+				}
+			}
+			if tt.name == "Sad case - staff not assigned to facility and unable to record audit log" {
+				fakeGorm.MockCheckIfStaffHasFacilityAccessFn = func(ctx context.Context, userID, programID, facilityID string) (bool, error) {
+					return false, nil
+				}
+				fakeGorm.MockCreateAuditLogFn = func(ctx context.Context, auditLog *gorm.AuditLog, sign func(auditLog *gorm.AuditLog) error) error {
+					return fmt.Errorf("failed to create audit log")
+				}
+			}
+			if tt.name == "Sad case - unable to get logged in user profile" {
+				fakeGorm.MockGetUserProfileByUserIDFn = func(ctx context.Context, userID *string) (*gorm.User, error) {
+					return nil, fmt.Errorf("failed to get user profile")
+				}
+			}
 			if tt.name == "Sad case - invalid facility" {
 				fakeGorm.MockGetSharedHealthDiaryEntriesFn = func(ctx context.Context, clientID string, facilityID string) ([]*gorm.ClientHealthDiaryEntry, error) {
 					return nil, fmt.Errorf("failed to get shared health diary entries")
@@ -4527,6 +4735,8 @@ func TestMyCareHubDb_GetNotification(t *testing.T) {
 }
 
 func TestMyCareHubDb_GetClientsByFilterParams(t *testing.T) {
+	ctx := context.WithValue(context.Background(), firebasetools.AuthTokenContextKey, &auth.Token{UID: uuid.New().String()})
+
 	facilityID := gofakeit.UUID()
 	type args struct {
 		ctx          context.Context
@@ -4542,7 +4752,7 @@ func TestMyCareHubDb_GetClientsByFilterParams(t *testing.T) {
 		{
 			name: "Happy case: retrieve clients",
 			args: args{
-				ctx:          context.Background(),
+				ctx:          ctx,
 				facilityID:   &facilityID,
 				filterParams: &dto.ClientFilterParamsInput{},
 			},
@@ -4551,7 +4761,7 @@ func TestMyCareHubDb_GetClientsByFilterParams(t *testing.T) {
 		{
 			name: "Happy case: retrieve clients with filters",
 			args: args{
-				ctx:        context.Background(),
+				ctx:        ctx,
 				facilityID: &facilityID,
 				filterParams: &dto.ClientFilterParamsInput{
 					ClientTypes: []enums.ClientType{enums.ClientTypePmtct},
@@ -4567,7 +4777,7 @@ func TestMyCareHubDb_GetClientsByFilterParams(t *testing.T) {
 		{
 			name: "Sad case: failed to filter clients",
 			args: args{
-				ctx:        context.Background(),
+				ctx:        ctx,
 				facilityID: &facilityID,
 				filterParams: &dto.ClientFilterParamsInput{
 					ClientTypes: []enums.ClientType{enums.ClientTypePmtct},
@@ -4583,7 +4793,7 @@ func TestMyCareHubDb_GetClientsByFilterParams(t *testing.T) {
 		{
 			name: "Sad case: failed to get user profile by user id",
 			args: args{
-				ctx:        context.Background(),
+				ctx:        ctx,
 				facilityID: &facilityID,
 				filterParams: &dto.ClientFilterParamsInput{
 					ClientTypes: []enums.ClientType{enums.ClientTypePmtct},
@@ -4596,12 +4806,27 @@ func TestMyCareHubDb_GetClientsByFilterParams(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Sad case: staff not assigned to facility",
+			args: args{
+				ctx:          ctx,
+				facilityID:   &facilityID,
+				filterParams: &dto.ClientFilterParamsInput{},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			var fakeGorm = gormMock.NewGormMock()
 			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
 
+			if tt.name == "Sad case: staff not assigned to facility" {
+				fakeGorm.MockCheckIfStaffHasFacilityAccessFn = func(ctx context.Context, userID, programID, facilityID string) (bool, error) {
+					return false, nil
+				}
+			}
+
 			if tt.name == "Sad case: failed to filter clients" {
 				fakeGorm.MockGetClientsByFilterParamsFn = func(ctx context.Context, facilityID string, filterParams *dto.ClientFilterParamsInput) ([]*gorm.Client, error) {
 					return nil, fmt.Errorf("cannot filter clients")
@@ -4627,7 +4852,7 @@ func TestMyCareHubDb_GetClientsByFilterParams(t *testing.T) {
 }
 
 func TestMyCareHubDb_SearchStaffServiceRequests(t *testing.T) {
-	ctx := context.Background()
+	ctx := context.WithValue(context.Background(), firebasetools.AuthTokenContextKey, &auth.Token{UID: uuid.New().String()})
 	var fakeGorm = gormMock.NewGormMock()
 	d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
 
@@ -4669,9 +4894,25 @@ func TestMyCareHubDb_SearchStaffServiceRequests(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Sad case: staff not assigned to facility",
+			args: args{
+				ctx:             ctx,
+				searchParameter: "PENDING",
+				requestType:     "PIN_RESET",
+				facilityID:      uuid.New().String(),
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			if tt.name == "Sad case: staff not assigned to facility" {
+				fakeGorm.MockCheckIfStaffHasFacilityAccessFn = func(ctx context.Context, userID, programID, facilityID string) (bool, error) {
+					return false, nil
+				}
+			}
+
 			if tt.name == "Sad case: unable to search staff service requests" {
 				fakeGorm.MockSearchStaffServiceRequestsFn = func(ctx context.Context, searchParameter string, requestType string, facilityID string) ([]*gorm.StaffServiceRequest, error) {
 					return nil, fmt.Errorf("an error occurred")
@@ -4710,7 +4951,7 @@ func TestMyCareHubDb_SearchStaffServiceRequests(t *testing.T) {
 }
 
 func TestMyCareHubDb_SearchClientServiceRequests(t *testing.T) {
-	ctx := context.Background()
+	ctx := context.WithValue(context.Background(), firebasetools.AuthTokenContextKey, &auth.Token{UID: uuid.New().String()})
 	var fakeGorm = gormMock.NewGormMock()
 	d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
 
@@ -4754,9 +4995,25 @@ func TestMyCareHubDb_SearchClientServiceRequests(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Sad case: staff not assigned to facility",
+			args: args{
+				ctx:             ctx,
+				searchParameter: "PENDING",
+				requestType:     "RED_FLAG",
+				facilityID:      uuid.New().String(),
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			if tt.name == "Sad case: staff not assigned to facility" {
+				fakeGorm.MockCheckIfStaffHasFacilityAccessFn = func(ctx context.Context, userID, programID, facilityID string) (bool, error) {
+					return false, nil
+				}
+			}
+
 			if tt.name == "Sad case: unable to search client service requests" {
 				fakeGorm.MockSearchClientServiceRequestsFn = func(ctx context.Context, searchParameter string, requestType string, facilityID string) ([]*gorm.ClientServiceRequest, error) {
 					return nil, fmt.Errorf("an error occurred")
@@ -5090,7 +5347,7 @@ func TestMyCareHubDb_GetScreeningToolByID(t *testing.T) {
 }
 
 func TestMyCareHubDb_GetFacilityRespondedScreeningTools(t *testing.T) {
-	ctx := context.Background()
+	ctx := context.WithValue(context.Background(), firebasetools.AuthTokenContextKey, &auth.Token{UID: uuid.New().String()})
 
 	var fakeGorm = gormMock.NewGormMock()
 	d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
@@ -5133,9 +5390,27 @@ func TestMyCareHubDb_GetFacilityRespondedScreeningTools(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Sad case: staff not assigned to facility",
+			args: args{
+				ctx:        ctx,
+				facilityID: uuid.New().String(),
+				pagination: &domain.Pagination{
+					Limit:       10,
+					CurrentPage: 1,
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			if tt.name == "Sad case: staff not assigned to facility" {
+				fakeGorm.MockCheckIfStaffHasFacilityAccessFn = func(ctx context.Context, userID, programID, facilityID string) (bool, error) {
+					return false, nil
+				}
+			}
+
 			if tt.name == "Sad case: unable to get facility responded screening tools" {
 				fakeGorm.MockGetFacilityRespondedScreeningToolsFn = func(ctx context.Context, facilityID, programID string, pagination *domain.Pagination) ([]*gorm.ScreeningTool, *domain.Pagination, error) {
 					return nil, nil, fmt.Errorf("an error occurred")
@@ -5160,7 +5435,7 @@ func TestMyCareHubDb_GetFacilityRespondedScreeningTools(t *testing.T) {
 }
 
 func TestMyCareHubDb_ListSurveyRespondents(t *testing.T) {
-	ctx := context.Background()
+	ctx := context.WithValue(context.Background(), firebasetools.AuthTokenContextKey, &auth.Token{UID: uuid.New().String()})
 
 	var fakeGorm = gormMock.NewGormMock()
 	d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
@@ -5232,9 +5507,33 @@ func TestMyCareHubDb_ListSurveyRespondents(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Sad case: staff not assigned to facility",
+			args: args{
+				ctx: ctx,
+				params: &domain.UserSurvey{
+					HasSubmitted: true,
+					FormID:       gofakeit.UUID(),
+					ProjectID:    1,
+					ProgramID:    gofakeit.UUID(),
+				},
+				pagination: &domain.Pagination{
+					Limit:       10,
+					CurrentPage: 1,
+				},
+				facilityID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			if tt.name == "Sad case: staff not assigned to facility" {
+				fakeGorm.MockCheckIfStaffHasFacilityAccessFn = func(ctx context.Context, userID, programID, facilityID string) (bool, error) {
+					return false, nil
+				}
+			}
+
 			if tt.name == "Sad case: unable to get survey respondents" {
 				fakeGorm.MockListSurveyRespondentsFn = func(ctx context.Context, params *gorm.UserSurvey, facilityID string, pagination *domain.Pagination) ([]*gorm.UserSurvey, *domain.Pagination, error) {
 					return nil, nil, fmt.Errorf("an error occurred")
@@ -5255,7 +5554,7 @@ func TestMyCareHubDb_ListSurveyRespondents(t *testing.T) {
 }
 
 func TestMyCareHubDb_GetScreeningToolRespondents(t *testing.T) {
-	ctx := context.Background()
+	ctx := context.WithValue(context.Background(), firebasetools.AuthTokenContextKey, &auth.Token{UID: uuid.New().String()})
 
 	var fakeGorm = gormMock.NewGormMock()
 	d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
@@ -5311,6 +5610,19 @@ func TestMyCareHubDb_GetScreeningToolRespondents(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Sad case: staff not assigned to facility",
+			args: args{
+				ctx:             ctx,
+				facilityID:      uuid.New().String(),
+				screeningToolID: uuid.New().String(),
+				paginationInput: &dto.PaginationsInput{
+					Limit:       1,
+					CurrentPage: 1,
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -5324,6 +5636,11 @@ func TestMyCareHubDb_GetScreeningToolRespondents(t *testing.T) {
 					return nil, fmt.Errorf("an error occurred")
 				}
 			}
+			if tt.name == "Sad case: staff not assigned to facility" {
+				fakeGorm.MockCheckIfStaffHasFacilityAccessFn = func(ctx context.Context, userID, programID, facilityID string) (bool, error) {
+					return false, nil
+				}
+			}
 			got, _, err := d.GetScreeningToolRespondents(tt.args.ctx, tt.args.facilityID, tt.args.programID, tt.args.screeningToolID, tt.args.searchTerm, tt.args.paginationInput)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("MyCareHubDb.GetScreeningToolRespondents() error = %v, wantErr %v", err, tt.wantErr)
@@ -5416,7 +5733,7 @@ func TestMyCareHubDb_GetScreeningToolResponseByID(t *testing.T) {
 }
 
 func TestMyCareHubDb_GetSurveysWithServiceRequests(t *testing.T) {
-	ctx := context.Background()
+	ctx := context.WithValue(context.Background(), firebasetools.AuthTokenContextKey, &auth.Token{UID: uuid.New().String()})
 
 	var fakeGorm = gormMock.NewGormMock()
 	d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
@@ -5450,12 +5767,27 @@ func TestMyCareHubDb_GetSurveysWithServiceRequests(t *testing.T) {
 			},
 			wantErr: true,
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if tt.name == "Sad case: unable return surveys with service requests" {
-				fakeGorm.MockGetSurveysWithServiceRequestsFn = func(ctx context.Context, facilityID, programID string) ([]*gorm.UserSurvey, error) {
-					return nil, fmt.Errorf("an error occurred")
+		{
+			name: "Sad case: staff not assigned to facility",
+			args: args{
+				ctx:        ctx,
+				facilityID: uuid.New().String(),
+				programID:  uuid.New().String(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.name == "Sad case: staff not assigned to facility" {
+				fakeGorm.MockCheckIfStaffHasFacilityAccessFn = func(ctx context.Context, userID, programID, facilityID string) (bool, error) {
+					return false, nil
+				}
+			}
+
+			if tt.name == "Sad case: unable return surveys with service requests" {
+				fakeGorm.MockGetSurveysWithServiceRequestsFn = func(ctx context.Context, facilityID, programID string) ([]*gorm.UserSurvey, error) {
+					return nil, fmt.Errorf("an error occurred")
 				}
 			}
 
@@ -5475,7 +5807,7 @@ func TestMyCareHubDb_GetSurveysWithServiceRequests(t *testing.T) {
 }
 
 func TestMyCareHubDb_GetSurveyServiceRequestUser(t *testing.T) {
-	ctx := context.Background()
+	ctx := context.WithValue(context.Background(), firebasetools.AuthTokenContextKey, &auth.Token{UID: uuid.New().String()})
 
 	var fakeGorm = gormMock.NewGormMock()
 	d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
@@ -5548,9 +5880,29 @@ func TestMyCareHubDb_GetSurveyServiceRequestUser(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Sad case: staff not assigned to facility",
+			args: args{
+				ctx:        ctx,
+				facilityID: uuid.New().String(),
+				projectID:  1,
+				formID:     "test",
+				pagination: &domain.Pagination{
+					Limit:       5,
+					CurrentPage: 1,
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			if tt.name == "Sad case: staff not assigned to facility" {
+				fakeGorm.MockCheckIfStaffHasFacilityAccessFn = func(ctx context.Context, userID, programID, facilityID string) (bool, error) {
+					return false, nil
+				}
+			}
+
 			if tt.name == "Sad case: unable to get clients survey service requests" {
 				fakeGorm.MockGetClientsSurveyServiceRequestFn = func(ctx context.Context, facilityID string, projectID int, formID string, pagination *domain.Pagination) ([]*gorm.ClientServiceRequest, *domain.Pagination, error) {
 					return nil, nil, fmt.Errorf("an error occurred")
@@ -8036,3 +8388,1895 @@ func TestMyCareHubDb_ListBookings(t *testing.T) {
 		})
 	}
 }
+
+func TestMyCareHubDb_GetUserPermissions(t *testing.T) {
+	type args struct {
+		ctx       context.Context
+		userID    string
+		programID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: get user permissions",
+			args: args{
+				ctx:       context.Background(),
+				userID:    gofakeit.UUID(),
+				programID: gofakeit.UUID(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to get user permissions",
+			args: args{
+				ctx:       context.Background(),
+				userID:    gofakeit.UUID(),
+				programID: gofakeit.UUID(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to get user permissions" {
+				fakeGorm.MockGetUserPermissionsFn = func(ctx context.Context, userID string, programID string) ([]*gorm.AuthorityPermission, error) {
+					return nil, fmt.Errorf("error")
+				}
+			}
+
+			got, err := d.GetUserPermissions(tt.args.ctx, tt.args.userID, tt.args.programID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.GetUserPermissions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got) == 0 {
+				t.Errorf("MyCareHubDb.GetUserPermissions() expected permissions to be returned")
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_GetAuthorityRoleByID(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		roleID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: get authority role",
+			args: args{
+				ctx:    context.Background(),
+				roleID: gofakeit.UUID(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to get authority role",
+			args: args{
+				ctx:    context.Background(),
+				roleID: gofakeit.UUID(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to get authority role" {
+				fakeGorm.MockGetAuthorityRoleByIDFn = func(ctx context.Context, roleID string) (*gorm.AuthorityRole, error) {
+					return nil, fmt.Errorf("error")
+				}
+			}
+
+			_, err := d.GetAuthorityRoleByID(tt.args.ctx, tt.args.roleID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.GetAuthorityRoleByID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_GetAuthorityRoleByName(t *testing.T) {
+	type args struct {
+		ctx       context.Context
+		programID string
+		name      string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: get authority role by name",
+			args: args{
+				ctx:       context.Background(),
+				programID: gofakeit.UUID(),
+				name:      "Default Staff",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to get authority role",
+			args: args{
+				ctx:       context.Background(),
+				programID: gofakeit.UUID(),
+				name:      "Default Staff",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: unable to get role permissions",
+			args: args{
+				ctx:       context.Background(),
+				programID: gofakeit.UUID(),
+				name:      "Default Staff",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to get authority role" {
+				fakeGorm.MockGetAuthorityRoleByNameFn = func(ctx context.Context, programID string, name string) (*gorm.AuthorityRole, error) {
+					return nil, fmt.Errorf("error")
+				}
+			}
+			if tt.name == "Sad case: unable to get role permissions" {
+				fakeGorm.MockGetRolePermissionsFn = func(ctx context.Context, roleID string) ([]*gorm.AuthorityPermission, error) {
+					return nil, fmt.Errorf("error")
+				}
+			}
+
+			_, err := d.GetAuthorityRoleByName(tt.args.ctx, tt.args.programID, tt.args.name)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.GetAuthorityRoleByName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_ListAuthorityRoles(t *testing.T) {
+	type args struct {
+		ctx       context.Context
+		programID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: list authority roles",
+			args: args{
+				ctx:       context.Background(),
+				programID: gofakeit.UUID(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to list authority roles",
+			args: args{
+				ctx:       context.Background(),
+				programID: gofakeit.UUID(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to list authority roles" {
+				fakeGorm.MockListAuthorityRolesFn = func(ctx context.Context, programID string) ([]*gorm.AuthorityRole, error) {
+					return nil, fmt.Errorf("error")
+				}
+			}
+
+			_, err := d.ListAuthorityRoles(tt.args.ctx, tt.args.programID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.ListAuthorityRoles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_ListAuthorityPermissions(t *testing.T) {
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: list authority permissions",
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to list authority permissions",
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to list authority permissions" {
+				fakeGorm.MockListAuthorityPermissionsFn = func(ctx context.Context) ([]*gorm.AuthorityPermission, error) {
+					return nil, fmt.Errorf("error")
+				}
+			}
+
+			_, err := d.ListAuthorityPermissions(tt.args.ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.ListAuthorityPermissions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_GetProfileRoles(t *testing.T) {
+	type args struct {
+		ctx       context.Context
+		userType  enums.UsersType
+		profileID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: get profile roles",
+			args: args{
+				ctx:       context.Background(),
+				userType:  enums.ClientUser,
+				profileID: gofakeit.UUID(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to get profile roles",
+			args: args{
+				ctx:       context.Background(),
+				userType:  enums.ClientUser,
+				profileID: gofakeit.UUID(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to get profile roles" {
+				fakeGorm.MockGetProfileRolesFn = func(ctx context.Context, userType enums.UsersType, profileID string) ([]*gorm.AuthorityRole, error) {
+					return nil, fmt.Errorf("error")
+				}
+			}
+
+			_, err := d.GetProfileRoles(tt.args.ctx, tt.args.userType, tt.args.profileID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.GetProfileRoles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_ListAuditLogs(t *testing.T) {
+	recordType := enums.AuditLogOrganisationAdminChange
+
+	type args struct {
+		ctx            context.Context
+		organisationID string
+		filter         *dto.AuditLogFilterInput
+		pagination     *domain.Pagination
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: list audit logs",
+			args: args{
+				ctx:            context.Background(),
+				organisationID: uuid.New().String(),
+				filter:         &dto.AuditLogFilterInput{RecordType: &recordType},
+				pagination:     &domain.Pagination{Limit: 10, CurrentPage: 1},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to list audit logs",
+			args: args{
+				ctx:            context.Background(),
+				organisationID: uuid.New().String(),
+				pagination:     &domain.Pagination{Limit: 10, CurrentPage: 1},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to list audit logs" {
+				fakeGorm.MockListAuditLogsFn = func(ctx context.Context, organisationID string, filter *dto.AuditLogFilterInput, pagination *domain.Pagination) ([]*gorm.AuditLog, *domain.Pagination, error) {
+					return nil, nil, fmt.Errorf("error")
+				}
+			}
+
+			got, _, err := d.ListAuditLogs(tt.args.ctx, tt.args.organisationID, tt.args.filter, tt.args.pagination)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.ListAuditLogs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				if len(got) == 0 {
+					t.Errorf("expected audit logs to be returned")
+					return
+				}
+				if got[0].Before["is_organisation_admin"] != false || got[0].After["is_organisation_admin"] != true {
+					t.Errorf("expected the audit log payload to be mapped, got before %v after %v", got[0].Before, got[0].After)
+				}
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_ListAuditLogChain(t *testing.T) {
+	type args struct {
+		ctx            context.Context
+		organisationID string
+		afterSequence  int64
+		limit          int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: list audit log chain",
+			args: args{
+				ctx:            context.Background(),
+				organisationID: uuid.New().String(),
+				afterSequence:  0,
+				limit:          100,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to list audit log chain",
+			args: args{
+				ctx:            context.Background(),
+				organisationID: uuid.New().String(),
+				afterSequence:  0,
+				limit:          100,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			sequence := int64(1)
+			hash := gofakeit.UUID()
+			fakeGorm.MockListAuditLogChainFn = func(ctx context.Context, organisationID string, afterSequence int64, limit int) ([]*gorm.AuditLog, error) {
+				return []*gorm.AuditLog{
+					{
+						ID:             &hash,
+						Timestamp:      time.Now(),
+						RecordType:     enums.AuditLogRoleChange.String(),
+						OrganisationID: organisationID,
+						Sequence:       &sequence,
+						Hash:           &hash,
+					},
+				}, nil
+			}
+
+			if tt.name == "Sad case: unable to list audit log chain" {
+				fakeGorm.MockListAuditLogChainFn = func(ctx context.Context, organisationID string, afterSequence int64, limit int) ([]*gorm.AuditLog, error) {
+					return nil, fmt.Errorf("error")
+				}
+			}
+
+			got, err := d.ListAuditLogChain(tt.args.ctx, tt.args.organisationID, tt.args.afterSequence, tt.args.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.ListAuditLogChain() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && (len(got) != 1 || got[0].Sequence != sequence || got[0].Hash != hash) {
+				t.Errorf("expected the chained audit log to be mapped, got %v", got)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_CountUnchainedAuditLogs(t *testing.T) {
+	type args struct {
+		ctx            context.Context
+		organisationID string
+		since          time.Time
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: count unchained audit logs",
+			args: args{
+				ctx:            context.Background(),
+				organisationID: uuid.New().String(),
+				since:          time.Now(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to count unchained audit logs",
+			args: args{
+				ctx:            context.Background(),
+				organisationID: uuid.New().String(),
+				since:          time.Now(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to count unchained audit logs" {
+				fakeGorm.MockCountUnchainedAuditLogsFn = func(ctx context.Context, organisationID string, since time.Time) (int64, error) {
+					return 0, fmt.Errorf("error")
+				}
+			}
+
+			_, err := d.CountUnchainedAuditLogs(tt.args.ctx, tt.args.organisationID, tt.args.since)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.CountUnchainedAuditLogs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_GetUserTOTP(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		userID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: get user TOTP",
+			args: args{
+				ctx:    context.Background(),
+				userID: uuid.New().String(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to get user TOTP",
+			args: args{
+				ctx:    context.Background(),
+				userID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to get user TOTP" {
+				fakeGorm.MockGetUserTOTPFn = func(ctx context.Context, userID string) (*gorm.UserTOTP, error) {
+					return nil, fmt.Errorf("error")
+				}
+			}
+
+			_, err := d.GetUserTOTP(tt.args.ctx, tt.args.userID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.GetUserTOTP() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_CheckIfUserHasTOTP(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		userID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: check if user has TOTP",
+			args: args{
+				ctx:    context.Background(),
+				userID: uuid.New().String(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to check if user has TOTP",
+			args: args{
+				ctx:    context.Background(),
+				userID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to check if user has TOTP" {
+				fakeGorm.MockCheckIfUserHasTOTPFn = func(ctx context.Context, userID string) (bool, error) {
+					return false, fmt.Errorf("error")
+				}
+			}
+
+			_, err := d.CheckIfUserHasTOTP(tt.args.ctx, tt.args.userID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.CheckIfUserHasTOTP() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_ListUserRecoveryCodes(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		userID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: list user recovery codes",
+			args: args{
+				ctx:    context.Background(),
+				userID: uuid.New().String(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to list user recovery codes",
+			args: args{
+				ctx:    context.Background(),
+				userID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to list user recovery codes" {
+				fakeGorm.MockListUserRecoveryCodesFn = func(ctx context.Context, userID string) ([]*gorm.UserRecoveryCode, error) {
+					return nil, fmt.Errorf("error")
+				}
+			}
+
+			_, err := d.ListUserRecoveryCodes(tt.args.ctx, tt.args.userID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.ListUserRecoveryCodes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_CheckIfStaffTOTPIsEnforced(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		userID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: check if staff TOTP is enforced",
+			args: args{
+				ctx:    context.Background(),
+				userID: uuid.New().String(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to check if staff TOTP is enforced",
+			args: args{
+				ctx:    context.Background(),
+				userID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to check if staff TOTP is enforced" {
+				fakeGorm.MockCheckIfStaffTOTPIsEnforcedFn = func(ctx context.Context, userID string) (bool, error) {
+					return false, fmt.Errorf("error")
+				}
+			}
+
+			_, err := d.CheckIfStaffTOTPIsEnforced(tt.args.ctx, tt.args.userID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.CheckIfStaffTOTPIsEnforced() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_ListUserSessions(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		userID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: list user sessions",
+			args: args{
+				ctx:    context.Background(),
+				userID: uuid.New().String(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to list user sessions",
+			args: args{
+				ctx:    context.Background(),
+				userID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to list user sessions" {
+				fakeGorm.MockListUserSessionsFn = func(ctx context.Context, userID string, activeSince time.Time) ([]*gorm.Session, error) {
+					return nil, fmt.Errorf("error")
+				}
+			}
+
+			got, err := d.ListUserSessions(tt.args.ctx, tt.args.userID, time.Now().Add(-time.Hour))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.ListUserSessions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got) == 0 {
+				t.Errorf("MyCareHubDb.ListUserSessions() expected sessions")
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_GetOpenIDConnectSession(t *testing.T) {
+	type args struct {
+		ctx  context.Context
+		code string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: get openid connect session",
+			args: args{
+				ctx:  context.Background(),
+				code: gofakeit.UUID(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: failed to get openid connect session",
+			args: args{
+				ctx:  context.Background(),
+				code: gofakeit.UUID(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: failed to get openid connect session" {
+				fakeGorm.MockGetOpenIDConnectSessionFn = func(ctx context.Context, code string) (*gorm.OpenIDConnectSession, error) {
+					return nil, fmt.Errorf("failed to get openid connect session")
+				}
+			}
+
+			got, err := d.GetOpenIDConnectSession(tt.args.ctx, tt.args.code)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.GetOpenIDConnectSession() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got == nil {
+				t.Errorf("expected an openid connect session")
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_GetPKCE(t *testing.T) {
+	type args struct {
+		ctx       context.Context
+		signature string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: get pkce session",
+			args: args{
+				ctx:       context.Background(),
+				signature: gofakeit.UUID(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: failed to get pkce session",
+			args: args{
+				ctx:       context.Background(),
+				signature: gofakeit.UUID(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: failed to get pkce session" {
+				fakeGorm.MockGetPKCEFn = func(ctx context.Context, signature string) (*gorm.PKCE, error) {
+					return nil, fmt.Errorf("failed to get pkce session")
+				}
+			}
+
+			got, err := d.GetPKCE(tt.args.ctx, tt.args.signature)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.GetPKCE() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got == nil {
+				t.Errorf("expected a pkce session")
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_ListOauthSigningKeys(t *testing.T) {
+	type args struct {
+		ctx      context.Context
+		activeAt time.Time
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: list signing keys",
+			args: args{
+				ctx:      context.Background(),
+				activeAt: time.Now(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: failed to list signing keys",
+			args: args{
+				ctx:      context.Background(),
+				activeAt: time.Now(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: failed to list signing keys" {
+				fakeGorm.MockListOauthSigningKeysFn = func(ctx context.Context, activeAt time.Time) ([]*gorm.OauthSigningKey, error) {
+					return nil, fmt.Errorf("failed to list signing keys")
+				}
+			}
+
+			got, err := d.ListOauthSigningKeys(tt.args.ctx, tt.args.activeAt)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.ListOauthSigningKeys() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got) == 0 {
+				t.Errorf("expected signing keys")
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_ListRevokedSessionIDs(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		since time.Time
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: list revoked sessions",
+			args: args{
+				ctx:   context.Background(),
+				since: time.Now().Add(-1 * time.Hour),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: failed to list revoked sessions",
+			args: args{
+				ctx:   context.Background(),
+				since: time.Now().Add(-1 * time.Hour),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: failed to list revoked sessions" {
+				fakeGorm.MockListRevokedSessionIDsFn = func(ctx context.Context, since time.Time) ([]string, error) {
+					return nil, fmt.Errorf("failed to list revoked sessions")
+				}
+			}
+
+			got, err := d.ListRevokedSessionIDs(tt.args.ctx, tt.args.since)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.ListRevokedSessionIDs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got) == 0 {
+				t.Errorf("expected revoked sessions")
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_ListRevokedAccessTokenSignatures(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		since time.Time
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: list revoked access tokens",
+			args: args{
+				ctx:   context.Background(),
+				since: time.Now().Add(-1 * time.Hour),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: failed to list revoked access tokens",
+			args: args{
+				ctx:   context.Background(),
+				since: time.Now().Add(-1 * time.Hour),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fakeGorm = gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: failed to list revoked access tokens" {
+				fakeGorm.MockListRevokedAccessTokenSignaturesFn = func(ctx context.Context, since time.Time) ([]string, error) {
+					return nil, fmt.Errorf("failed to list revoked access tokens")
+				}
+			}
+
+			got, err := d.ListRevokedAccessTokenSignatures(tt.args.ctx, tt.args.since)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.ListRevokedAccessTokenSignatures() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got) == 0 {
+				t.Errorf("expected revoked access tokens")
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_ListUserDevices(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		userID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: list user devices",
+			args: args{
+				ctx:    context.Background(),
+				userID: uuid.New().String(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to list user devices",
+			args: args{
+				ctx:    context.Background(),
+				userID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to list user devices" {
+				fakeGorm.MockListUserDevicesFn = func(ctx context.Context, userID string) ([]*gorm.UserDevice, error) {
+					return nil, fmt.Errorf("error")
+				}
+			}
+
+			_, err := d.ListUserDevices(tt.args.ctx, tt.args.userID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.ListUserDevices() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_ListUserLoginCountries(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		userID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: list user login countries",
+			args: args{
+				ctx:    context.Background(),
+				userID: uuid.New().String(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to list user login countries",
+			args: args{
+				ctx:    context.Background(),
+				userID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to list user login countries" {
+				fakeGorm.MockListUserLoginCountriesFn = func(ctx context.Context, userID string) ([]string, error) {
+					return nil, fmt.Errorf("error")
+				}
+			}
+
+			_, err := d.ListUserLoginCountries(tt.args.ctx, tt.args.userID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.ListUserLoginCountries() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_CountUsersLoggedInFromIPAddress(t *testing.T) {
+	type args struct {
+		ctx       context.Context
+		ipAddress string
+		since     time.Time
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: count users logged in from IP address",
+			args: args{
+				ctx:       context.Background(),
+				ipAddress: gofakeit.IPv4Address(),
+				since:     time.Now().Add(-time.Hour),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to count users logged in from IP address",
+			args: args{
+				ctx:       context.Background(),
+				ipAddress: gofakeit.IPv4Address(),
+				since:     time.Now().Add(-time.Hour),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to count users logged in from IP address" {
+				fakeGorm.MockCountUsersLoggedInFromIPAddressFn = func(ctx context.Context, ipAddress string, since time.Time) (int, error) {
+					return 0, fmt.Errorf("error")
+				}
+			}
+
+			_, err := d.CountUsersLoggedInFromIPAddress(tt.args.ctx, tt.args.ipAddress, tt.args.since)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.CountUsersLoggedInFromIPAddress() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_ListDuplicateClientCandidates(t *testing.T) {
+	type args struct {
+		ctx      context.Context
+		clientID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: list duplicate client candidates",
+			args: args{
+				ctx:      context.Background(),
+				clientID: uuid.New().String(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to list duplicate client candidates",
+			args: args{
+				ctx:      context.Background(),
+				clientID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to list duplicate client candidates" {
+				fakeGorm.MockListDuplicateClientCandidatesFn = func(ctx context.Context, clientID string) ([]*gorm.DuplicateClientCandidate, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.ListDuplicateClientCandidates(tt.args.ctx, tt.args.clientID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.ListDuplicateClientCandidates() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got[0].Matches) != 4 {
+				t.Errorf("MyCareHubDb.ListDuplicateClientCandidates() expected all the matched details, got %v", got[0].Matches)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_ListUserContacts(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		userID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: list user contacts",
+			args: args{
+				ctx:    context.Background(),
+				userID: uuid.New().String(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to list user contacts",
+			args: args{
+				ctx:    context.Background(),
+				userID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to list user contacts" {
+				fakeGorm.MockListUserContactsFn = func(ctx context.Context, userID string) ([]*gorm.Contact, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.ListUserContacts(tt.args.ctx, tt.args.userID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.ListUserContacts() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got) != 1 {
+				t.Errorf("MyCareHubDb.ListUserContacts() expected 1 record, got %d", len(got))
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_ListClientScreeningToolResponses(t *testing.T) {
+	type args struct {
+		ctx      context.Context
+		clientID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: list client screening tool responses",
+			args: args{
+				ctx:      context.Background(),
+				clientID: uuid.New().String(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to list client screening tool responses",
+			args: args{
+				ctx:      context.Background(),
+				clientID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to list client screening tool responses" {
+				fakeGorm.MockListClientScreeningToolResponsesFn = func(ctx context.Context, clientID string) ([]*gorm.ScreeningToolResponse, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.ListClientScreeningToolResponses(tt.args.ctx, tt.args.clientID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.ListClientScreeningToolResponses() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got) != 1 {
+				t.Errorf("MyCareHubDb.ListClientScreeningToolResponses() expected 1 record, got %d", len(got))
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_ListUserMetrics(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		userID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: list user metrics",
+			args: args{
+				ctx:    context.Background(),
+				userID: uuid.New().String(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to list user metrics",
+			args: args{
+				ctx:    context.Background(),
+				userID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: invalid metric payload",
+			args: args{
+				ctx:    context.Background(),
+				userID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to list user metrics" {
+				fakeGorm.MockListUserMetricsFn = func(ctx context.Context, userID string) ([]*gorm.Metric, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: invalid metric payload" {
+				fakeGorm.MockListUserMetricsFn = func(ctx context.Context, userID string) ([]*gorm.Metric, error) {
+					return []*gorm.Metric{{ID: 1, Payload: "not json", UserID: &userID}}, nil
+				}
+			}
+
+			got, err := d.ListUserMetrics(tt.args.ctx, tt.args.userID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.ListUserMetrics() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got) != 1 {
+				t.Errorf("MyCareHubDb.ListUserMetrics() expected 1 record, got %d", len(got))
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_ListUserFeedback(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		userID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: list user feedback",
+			args: args{
+				ctx:    context.Background(),
+				userID: uuid.New().String(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to list user feedback",
+			args: args{
+				ctx:    context.Background(),
+				userID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to list user feedback" {
+				fakeGorm.MockListUserFeedbackFn = func(ctx context.Context, userID string) ([]*gorm.Feedback, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := d.ListUserFeedback(tt.args.ctx, tt.args.userID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.ListUserFeedback() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got) != 1 {
+				t.Errorf("MyCareHubDb.ListUserFeedback() expected 1 record, got %d", len(got))
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_ListClientsDueForErasure(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		now time.Time
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: list clients due for erasure",
+			args: args{
+				ctx: context.Background(),
+				now: time.Now(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to list clients due for erasure",
+			args: args{
+				ctx: context.Background(),
+				now: time.Now(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to list clients due for erasure" {
+				fakeGorm.MockListClientsDueForErasureFn = func(ctx context.Context, now time.Time) ([]*gorm.Client, error) {
+					return nil, fmt.Errorf("error")
+				}
+			}
+
+			got, err := d.ListClientsDueForErasure(tt.args.ctx, tt.args.now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.ListClientsDueForErasure() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && (len(got) != 1 || got[0].ErasureRequestedAt == nil) {
+				t.Errorf("MyCareHubDb.ListClientsDueForErasure() expected the client due for erasure to be returned, got %v", got)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_ListExpiredCaregiverDelegations(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		now time.Time
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: list expired caregiver delegations",
+			args: args{
+				ctx: context.Background(),
+				now: time.Now(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to list expired caregiver delegations",
+			args: args{
+				ctx: context.Background(),
+				now: time.Now(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to list expired caregiver delegations" {
+				fakeGorm.MockListExpiredCaregiverDelegationsFn = func(ctx context.Context, now time.Time) ([]*gorm.CaregiverClient, error) {
+					return nil, fmt.Errorf("error")
+				}
+			}
+
+			got, err := d.ListExpiredCaregiverDelegations(tt.args.ctx, tt.args.now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.ListExpiredCaregiverDelegations() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && (len(got) != 1 || got[0].EndsAt == nil || len(got[0].Abilities) != 1) {
+				t.Errorf("MyCareHubDb.ListExpiredCaregiverDelegations() expected the expired delegation to be returned, got %v", got)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_GetPendingPhoneChangeRequest(t *testing.T) {
+	type args struct {
+		ctx     context.Context
+		userID  string
+		flavour feedlib.Flavour
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: get pending phone change request",
+			args: args{
+				ctx:     context.Background(),
+				userID:  uuid.NewString(),
+				flavour: feedlib.FlavourConsumer,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to get pending phone change request",
+			args: args{
+				ctx:     context.Background(),
+				userID:  uuid.NewString(),
+				flavour: feedlib.FlavourConsumer,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to get pending phone change request" {
+				fakeGorm.MockGetPendingPhoneChangeRequestFn = func(ctx context.Context, userID string, flavour feedlib.Flavour) (*gorm.PhoneChangeRequest, error) {
+					return nil, fmt.Errorf("error")
+				}
+			}
+
+			got, err := d.GetPendingPhoneChangeRequest(tt.args.ctx, tt.args.userID, tt.args.flavour)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.GetPendingPhoneChangeRequest() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got == nil {
+				t.Errorf("expected a phone change request")
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_GetStaffInProgressServiceRequests(t *testing.T) {
+	type args struct {
+		ctx      context.Context
+		staffIDs []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: get staff in progress service requests",
+			args: args{
+				ctx:      context.Background(),
+				staffIDs: []string{uuid.NewString()},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to get staff in progress service requests",
+			args: args{
+				ctx:      context.Background(),
+				staffIDs: []string{uuid.NewString()},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to get staff in progress service requests" {
+				fakeGorm.MockGetStaffInProgressServiceRequestsFn = func(ctx context.Context, staffIDs []string) ([]*gorm.ClientServiceRequest, error) {
+					return nil, fmt.Errorf("error")
+				}
+			}
+
+			got, err := d.GetStaffInProgressServiceRequests(tt.args.ctx, tt.args.staffIDs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.GetStaffInProgressServiceRequests() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && (len(got) != 1 || *got[0].InProgressBy != tt.args.staffIDs[0]) {
+				t.Errorf("expected the service request in progress by the staff, got %v", got)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_ListOrganisationAdmins(t *testing.T) {
+	type args struct {
+		ctx            context.Context
+		organisationID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: list organisation admins",
+			args: args{
+				ctx:            context.Background(),
+				organisationID: uuid.NewString(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to list organisation admins",
+			args: args{
+				ctx:            context.Background(),
+				organisationID: uuid.NewString(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to list organisation admins" {
+				fakeGorm.MockListOrganisationAdminsFn = func(ctx context.Context, organisationID string) ([]*gorm.StaffProfile, error) {
+					return nil, fmt.Errorf("error")
+				}
+			}
+
+			got, err := d.ListOrganisationAdmins(tt.args.ctx, tt.args.organisationID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.ListOrganisationAdmins() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && (len(got) != 1 || !got[0].IsOrganisationAdmin) {
+				t.Errorf("expected an organisation admin, got %v", got)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_ListFacilityAdmins(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		facilityID string
+		programID  string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: list facility admins",
+			args: args{
+				ctx:        context.Background(),
+				facilityID: uuid.NewString(),
+				programID:  uuid.NewString(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to list facility admins",
+			args: args{
+				ctx:        context.Background(),
+				facilityID: uuid.NewString(),
+				programID:  uuid.NewString(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to list facility admins" {
+				fakeGorm.MockListFacilityAdminsFn = func(ctx context.Context, facilityID, programID string) ([]*gorm.StaffProfile, error) {
+					return nil, fmt.Errorf("error")
+				}
+			}
+
+			got, err := d.ListFacilityAdmins(tt.args.ctx, tt.args.facilityID, tt.args.programID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.ListFacilityAdmins() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && (len(got) != 1 || got[0].ProgramID != tt.args.programID) {
+				t.Errorf("expected a facility admin in program %s, got %v", tt.args.programID, got)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_ListClientTransfers(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		userID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: list client transfers",
+			args: args{
+				ctx:    context.Background(),
+				userID: uuid.NewString(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to list client transfers",
+			args: args{
+				ctx:    context.Background(),
+				userID: uuid.NewString(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to list client transfers" {
+				fakeGorm.MockListClientTransfersFn = func(ctx context.Context, userID string) ([]*gorm.ClientTransfer, error) {
+					return nil, fmt.Errorf("error")
+				}
+			}
+
+			got, err := d.ListClientTransfers(tt.args.ctx, tt.args.userID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.ListClientTransfers() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && (len(got) != 1 || len(got[0].CarriedHistory) != 1) {
+				t.Errorf("expected a client transfer with its carried history, got %v", got)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_ListServiceRequestSLAs(t *testing.T) {
+	programID := uuid.NewString()
+
+	type args struct {
+		ctx       context.Context
+		programID *string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: list service request slas",
+			args: args{
+				ctx:       context.Background(),
+				programID: &programID,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to list service request slas",
+			args: args{
+				ctx:       context.Background(),
+				programID: &programID,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to list service request slas" {
+				fakeGorm.MockListServiceRequestSLAsFn = func(ctx context.Context, programID *string) ([]*gorm.ServiceRequestSLA, error) {
+					return nil, fmt.Errorf("error")
+				}
+			}
+
+			got, err := d.ListServiceRequestSLAs(tt.args.ctx, tt.args.programID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.ListServiceRequestSLAs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got) != 1 {
+				t.Errorf("expected a service request sla, got %v", got)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_GetServiceRequestsDueForEscalation(t *testing.T) {
+	type args struct {
+		ctx          context.Context
+		requestTypes []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: get service requests due for escalation",
+			args: args{
+				ctx:          context.Background(),
+				requestTypes: []string{enums.ServiceRequestTypeRedFlag.String()},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to get service requests due for escalation",
+			args: args{
+				ctx:          context.Background(),
+				requestTypes: []string{enums.ServiceRequestTypeRedFlag.String()},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: unable to get client profile",
+			args: args{
+				ctx:          context.Background(),
+				requestTypes: []string{enums.ServiceRequestTypeRedFlag.String()},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to get service requests due for escalation" {
+				fakeGorm.MockGetServiceRequestsDueForEscalationFn = func(ctx context.Context, requestTypes []string) ([]*gorm.ClientServiceRequest, error) {
+					return nil, fmt.Errorf("error")
+				}
+			}
+			if tt.name == "Sad case: unable to get client profile" {
+				fakeGorm.MockGetClientProfileByClientIDFn = func(ctx context.Context, clientID string) (*gorm.Client, error) {
+					return nil, fmt.Errorf("error")
+				}
+			}
+
+			got, err := d.GetServiceRequestsDueForEscalation(tt.args.ctx, tt.args.requestTypes)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.GetServiceRequestsDueForEscalation() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && (len(got) != 1 || got[0].ProgramID == "" || got[0].EscalationLevel != nil) {
+				t.Errorf("expected a service request that has not been escalated, got %v", got)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_ListServiceRequestEscalations(t *testing.T) {
+	type args struct {
+		ctx              context.Context
+		serviceRequestID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: list service request escalations",
+			args: args{
+				ctx:              context.Background(),
+				serviceRequestID: uuid.NewString(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to list service request escalations",
+			args: args{
+				ctx:              context.Background(),
+				serviceRequestID: uuid.NewString(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to list service request escalations" {
+				fakeGorm.MockListServiceRequestEscalationsFn = func(ctx context.Context, serviceRequestID string) ([]*gorm.ServiceRequestEscalation, error) {
+					return nil, fmt.Errorf("error")
+				}
+			}
+
+			got, err := d.ListServiceRequestEscalations(tt.args.ctx, tt.args.serviceRequestID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.ListServiceRequestEscalations() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && (len(got) != 1 || got[0].Level != enums.ServiceRequestEscalationLevelFacilityStaff) {
+				t.Errorf("expected a service request escalation, got %v", got)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/savannahghi/feedlib"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
@@ -366,6 +367,22 @@ func (d *MyCareHubDb) UpdateRefreshToken(ctx context.Context, token *domain.Refr
 	return d.update.UpdateRefreshToken(ctx, authCode, updateData)
 }
 
+// UpdateOauthClient updates the details of a given oauth client
+func (d *MyCareHubDb) UpdateOauthClient(ctx context.Context, client *domain.OauthClient, updateData map[string]interface{}) error {
+	oauthClient := &gorm.OauthClient{
+		ID: client.ID,
+	}
+
+	// the redirect URIs, scopes, grants and response types are stored as postgres arrays
+	for column, value := range updateData {
+		if values, ok := value.([]string); ok {
+			updateData[column] = pq.StringArray(values)
+		}
+	}
+
+	return d.update.UpdateOauthClient(ctx, oauthClient, updateData)
+}
+
 // UpdateBooking updates the booking model given the models data and the update data
 func (d *MyCareHubDb) UpdateBooking(ctx context.Context, booking *domain.Booking, updateData map[string]interface{}) error {
 	updatePayload := &gorm.Booking{
@@ -376,3 +393,106 @@ func (d *MyCareHubDb) UpdateBooking(ctx context.Context, booking *domain.Booking
 
 	return d.update.UpdateBooking(ctx, updatePayload, updateData)
 }
+
+// UpdateUserTOTP updates a user's TOTP enrollment
+func (d *MyCareHubDb) UpdateUserTOTP(ctx context.Context, userTOTP *domain.UserTOTP, updateData map[string]interface{}) error {
+	return d.update.UpdateUserTOTP(ctx, &gorm.UserTOTP{UserID: userTOTP.UserID}, updateData)
+}
+
+// UseUserRecoveryCode marks a recovery code as used
+func (d *MyCareHubDb) UseUserRecoveryCode(ctx context.Context, recoveryCode *domain.UserRecoveryCode) error {
+	return d.update.UseUserRecoveryCode(ctx, &gorm.UserRecoveryCode{ID: recoveryCode.ID})
+}
+
+// UpdateOrganisation updates an organisation's details
+func (d *MyCareHubDb) UpdateOrganisation(ctx context.Context, organisation *domain.Organisation, updateData map[string]interface{}) error {
+	return d.update.UpdateOrganisation(ctx, &gorm.Organisation{ID: &organisation.ID}, updateData)
+}
+
+// UpdateOTPDeliveryStatus updates the status of an OTP delivery attempt from a provider's delivery receipt.
+// It returns false when there is no pending attempt with the provider's message ID
+func (d *MyCareHubDb) UpdateOTPDeliveryStatus(ctx context.Context, provider enums.OTPDeliveryProvider, providerMessageID string, status enums.OTPDeliveryStatus, at time.Time) (bool, error) {
+	return d.update.UpdateOTPDeliveryStatus(ctx, provider.String(), providerMessageID, status.String(), at)
+}
+
+// UpdateSession updates the details of a given session
+func (d *MyCareHubDb) UpdateSession(ctx context.Context, session *domain.Session, updateData map[string]interface{}) error {
+	return d.update.UpdateSession(ctx, &gorm.Session{ID: session.ID}, updateData)
+}
+
+// RevokeSessions ends the sessions and deactivates the tokens issued for them
+func (d *MyCareHubDb) RevokeSessions(ctx context.Context, sessionIDs []string, revokedAt time.Time) error {
+	return d.update.RevokeSessions(ctx, sessionIDs, revokedAt)
+}
+
+// ResetSecurityQuestionResponses clears the user's security question responses so that they set new ones
+func (d *MyCareHubDb) ResetSecurityQuestionResponses(ctx context.Context, userID string) error {
+	return d.update.ResetSecurityQuestionResponses(ctx, userID)
+}
+
+// MergeClients moves the health diary entries, service requests, appointments, screening tool responses, bookings, caregivers and facilities
+// of the duplicate client to the surviving client and deactivates the duplicate client
+func (d *MyCareHubDb) MergeClients(ctx context.Context, survivingClientID string, duplicateClientID string) (*domain.ClientMerge, error) {
+	moved, err := d.update.MergeClients(ctx, survivingClientID, duplicateClientID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ClientMerge{
+		SurvivingClientID:      survivingClientID,
+		DuplicateClientID:      duplicateClientID,
+		HealthDiaryEntries:     int(moved["clients_healthdiaryentry"]),
+		ServiceRequests:        int(moved["clients_servicerequest"]),
+		Appointments:           int(moved["appointments_appointment"]),
+		ScreeningToolResponses: int(moved["questionnaires_screeningtoolresponse"]),
+		ServiceBookings:        int(moved["service_booking"]),
+		CaregiverLinks:         int(moved["caregivers_caregiver_client"]),
+		Facilities:             int(moved["clients_client_facilities"]),
+	}, nil
+}
+
+// MarkClientForErasure deactivates a client and records when they asked for their data to be erased
+func (d *MyCareHubDb) MarkClientForErasure(ctx context.Context, clientID string, userID *string, requestedAt time.Time) error {
+	return d.update.MarkClientForErasure(ctx, clientID, userID, requestedAt)
+}
+
+// EndClientCaregiverDelegations sets the date that the client's caregivers stop being able to act on their behalf
+// It returns the number of delegations that will now end on that date
+func (d *MyCareHubDb) EndClientCaregiverDelegations(ctx context.Context, clientID string, endsAt time.Time) (int, error) {
+	count, err := d.update.EndClientCaregiverDelegations(ctx, clientID, endsAt)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
+// ConfirmPhoneChange replaces the user's phone number with the new phone number of the request and marks the request as confirmed
+func (d *MyCareHubDb) ConfirmPhoneChange(ctx context.Context, request *domain.PhoneChangeRequest, confirmedAt time.Time) error {
+	return d.update.ConfirmPhoneChange(ctx, mapPhoneChangeRequestToTable(request), confirmedAt)
+}
+
+// OffboardStaff deactivates the staff profiles of a staff who has left an organisation, hands over their in progress service requests
+// and logs them out of all their sessions. The user is deactivated when the offboarding says so
+func (d *MyCareHubDb) OffboardStaff(ctx context.Context, offboarding *domain.StaffOffboarding, offboardedAt time.Time) error {
+	serviceRequests := map[string]*string{}
+	for _, serviceRequest := range offboarding.ServiceRequests {
+		serviceRequests[serviceRequest.ID] = serviceRequest.ReassignedTo
+	}
+
+	return d.update.OffboardStaff(ctx, offboarding.UserID, offboarding.StaffProfiles, serviceRequests, offboarding.UserDeactivated, offboardedAt)
+}
+
+// EscalateServiceRequest records a service request that breached its SLA being escalated
+func (d *MyCareHubDb) EscalateServiceRequest(ctx context.Context, escalation *domain.ServiceRequestEscalation) error {
+	return d.update.EscalateServiceRequest(ctx, &gorm.ServiceRequestEscalation{
+		Active:                true,
+		ServiceRequestID:      escalation.ServiceRequestID,
+		Level:                 escalation.Level.String(),
+		Recipients:            escalation.Recipients,
+		PatientSafetyIncident: escalation.PatientSafetyIncident,
+		EscalatedAt:           escalation.EscalatedAt,
+		ProgramID:             escalation.ProgramID,
+		OrganisationID:        escalation.OrganisationID,
+	})
+}
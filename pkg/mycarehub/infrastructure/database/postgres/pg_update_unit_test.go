@@ -9,6 +9,7 @@ import (
 
 	"github.com/brianvoe/gofakeit"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/savannahghi/feedlib"
 	"github.com/savannahghi/interserviceclient"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
@@ -2057,3 +2058,754 @@ func TestMyCareHubDb_UpdateBooking(t *testing.T) {
 		})
 	}
 }
+
+func TestMyCareHubDb_UpdateUserTOTP(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		userTOTP   *domain.UserTOTP
+		updateData map[string]interface{}
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: update user TOTP",
+			args: args{
+				ctx:        context.Background(),
+				userTOTP:   &domain.UserTOTP{UserID: uuid.New().String()},
+				updateData: map[string]interface{}{"confirmed": true},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to update user TOTP",
+			args: args{
+				ctx:        context.Background(),
+				userTOTP:   &domain.UserTOTP{UserID: uuid.New().String()},
+				updateData: map[string]interface{}{"confirmed": true},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to update user TOTP" {
+				fakeGorm.MockUpdateUserTOTPFn = func(ctx context.Context, userTOTP *gorm.UserTOTP, updateData map[string]interface{}) error {
+					return fmt.Errorf("error")
+				}
+			}
+
+			err := d.UpdateUserTOTP(tt.args.ctx, tt.args.userTOTP, tt.args.updateData)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.UpdateUserTOTP() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_UseUserRecoveryCode(t *testing.T) {
+	type args struct {
+		ctx          context.Context
+		recoveryCode *domain.UserRecoveryCode
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: use recovery code",
+			args: args{
+				ctx:          context.Background(),
+				recoveryCode: &domain.UserRecoveryCode{ID: uuid.New().String()},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to use recovery code",
+			args: args{
+				ctx:          context.Background(),
+				recoveryCode: &domain.UserRecoveryCode{ID: uuid.New().String()},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to use recovery code" {
+				fakeGorm.MockUseUserRecoveryCodeFn = func(ctx context.Context, recoveryCode *gorm.UserRecoveryCode) error {
+					return fmt.Errorf("error")
+				}
+			}
+
+			err := d.UseUserRecoveryCode(tt.args.ctx, tt.args.recoveryCode)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.UseUserRecoveryCode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_UpdateOrganisation(t *testing.T) {
+	type args struct {
+		ctx          context.Context
+		organisation *domain.Organisation
+		updateData   map[string]interface{}
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: update organisation",
+			args: args{
+				ctx:          context.Background(),
+				organisation: &domain.Organisation{ID: uuid.New().String()},
+				updateData:   map[string]interface{}{"enforce_staff_totp": true},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to update organisation",
+			args: args{
+				ctx:          context.Background(),
+				organisation: &domain.Organisation{ID: uuid.New().String()},
+				updateData:   map[string]interface{}{"enforce_staff_totp": true},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to update organisation" {
+				fakeGorm.MockUpdateOrganisationFn = func(ctx context.Context, organisation *gorm.Organisation, updateData map[string]interface{}) error {
+					return fmt.Errorf("error")
+				}
+			}
+
+			err := d.UpdateOrganisation(tt.args.ctx, tt.args.organisation, tt.args.updateData)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.UpdateOrganisation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_UpdateOauthClient(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		client     *domain.OauthClient
+		updateData map[string]interface{}
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: update oauth client",
+			args: args{
+				ctx:        context.Background(),
+				client:     &domain.OauthClient{ID: uuid.New().String()},
+				updateData: map[string]interface{}{"name": "client", "redirect_uris": []string{"https://example.com/callback"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to update oauth client",
+			args: args{
+				ctx:        context.Background(),
+				client:     &domain.OauthClient{ID: uuid.New().String()},
+				updateData: map[string]interface{}{"name": "client"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Happy case: update oauth client" {
+				fakeGorm.MockUpdateOauthClientFn = func(ctx context.Context, client *gorm.OauthClient, updateData map[string]interface{}) error {
+					if _, ok := updateData["redirect_uris"].(pq.StringArray); !ok {
+						return fmt.Errorf("expected the redirect URIs to be a postgres array")
+					}
+					return nil
+				}
+			}
+
+			if tt.name == "Sad case: unable to update oauth client" {
+				fakeGorm.MockUpdateOauthClientFn = func(ctx context.Context, client *gorm.OauthClient, updateData map[string]interface{}) error {
+					return fmt.Errorf("error")
+				}
+			}
+
+			err := d.UpdateOauthClient(tt.args.ctx, tt.args.client, tt.args.updateData)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.UpdateOauthClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_UpdateOTPDeliveryStatus(t *testing.T) {
+	type args struct {
+		ctx               context.Context
+		provider          enums.OTPDeliveryProvider
+		providerMessageID string
+		status            enums.OTPDeliveryStatus
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case: update OTP delivery status",
+			args: args{
+				ctx:               context.Background(),
+				provider:          enums.OTPDeliveryProviderTwilio,
+				providerMessageID: uuid.New().String(),
+				status:            enums.OTPDeliveryStatusDelivered,
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to update OTP delivery status",
+			args: args{
+				ctx:               context.Background(),
+				provider:          enums.OTPDeliveryProviderTwilio,
+				providerMessageID: uuid.New().String(),
+				status:            enums.OTPDeliveryStatusDelivered,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to update OTP delivery status" {
+				fakeGorm.MockUpdateOTPDeliveryStatusFn = func(ctx context.Context, provider string, providerMessageID string, status string, at time.Time) (bool, error) {
+					return false, fmt.Errorf("error")
+				}
+			}
+
+			got, err := d.UpdateOTPDeliveryStatus(tt.args.ctx, tt.args.provider, tt.args.providerMessageID, tt.args.status, time.Now())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.UpdateOTPDeliveryStatus() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("MyCareHubDb.UpdateOTPDeliveryStatus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_UpdateSession(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		session    *domain.Session
+		updateData map[string]interface{}
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: update session",
+			args: args{
+				ctx:        context.Background(),
+				session:    &domain.Session{ID: uuid.New().String()},
+				updateData: map[string]interface{}{"last_seen_at": time.Now()},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to update session",
+			args: args{
+				ctx:        context.Background(),
+				session:    &domain.Session{ID: uuid.New().String()},
+				updateData: map[string]interface{}{"last_seen_at": time.Now()},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to update session" {
+				fakeGorm.MockUpdateSessionFn = func(ctx context.Context, session *gorm.Session, updateData map[string]interface{}) error {
+					return fmt.Errorf("error")
+				}
+			}
+
+			if err := d.UpdateSession(tt.args.ctx, tt.args.session, tt.args.updateData); (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.UpdateSession() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_RevokeSessions(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		sessionIDs []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: revoke sessions",
+			args: args{
+				ctx:        context.Background(),
+				sessionIDs: []string{uuid.New().String()},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to revoke sessions",
+			args: args{
+				ctx:        context.Background(),
+				sessionIDs: []string{uuid.New().String()},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to revoke sessions" {
+				fakeGorm.MockRevokeSessionsFn = func(ctx context.Context, sessionIDs []string, revokedAt time.Time) error {
+					return fmt.Errorf("error")
+				}
+			}
+
+			if err := d.RevokeSessions(tt.args.ctx, tt.args.sessionIDs, time.Now()); (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.RevokeSessions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_ResetSecurityQuestionResponses(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		userID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: reset security question responses",
+			args: args{
+				ctx:    context.Background(),
+				userID: uuid.New().String(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to reset security question responses",
+			args: args{
+				ctx:    context.Background(),
+				userID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to reset security question responses" {
+				fakeGorm.MockResetSecurityQuestionResponsesFn = func(ctx context.Context, userID string) error {
+					return fmt.Errorf("error")
+				}
+			}
+
+			if err := d.ResetSecurityQuestionResponses(tt.args.ctx, tt.args.userID); (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.ResetSecurityQuestionResponses() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_MergeClients(t *testing.T) {
+	type args struct {
+		ctx               context.Context
+		survivingClientID string
+		duplicateClientID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: merge clients",
+			args: args{
+				ctx:               context.Background(),
+				survivingClientID: uuid.New().String(),
+				duplicateClientID: uuid.New().String(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to merge clients",
+			args: args{
+				ctx:               context.Background(),
+				survivingClientID: uuid.New().String(),
+				duplicateClientID: uuid.New().String(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to merge clients" {
+				fakeGorm.MockMergeClientsFn = func(ctx context.Context, survivingClientID string, duplicateClientID string) (map[string]int64, error) {
+					return nil, fmt.Errorf("error")
+				}
+			}
+
+			got, err := d.MergeClients(tt.args.ctx, tt.args.survivingClientID, tt.args.duplicateClientID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.MergeClients() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.HealthDiaryEntries != 1 {
+				t.Errorf("MyCareHubDb.MergeClients() expected the moved health diary entries to be counted")
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_MarkClientForErasure(t *testing.T) {
+	userID := uuid.New().String()
+
+	type args struct {
+		ctx         context.Context
+		clientID    string
+		userID      *string
+		requestedAt time.Time
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: mark client for erasure",
+			args: args{
+				ctx:         context.Background(),
+				clientID:    uuid.New().String(),
+				userID:      &userID,
+				requestedAt: time.Now(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to mark client for erasure",
+			args: args{
+				ctx:         context.Background(),
+				clientID:    uuid.New().String(),
+				requestedAt: time.Now(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to mark client for erasure" {
+				fakeGorm.MockMarkClientForErasureFn = func(ctx context.Context, clientID string, userID *string, requestedAt time.Time) error {
+					return fmt.Errorf("error")
+				}
+			}
+
+			if err := d.MarkClientForErasure(tt.args.ctx, tt.args.clientID, tt.args.userID, tt.args.requestedAt); (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.MarkClientForErasure() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_EndClientCaregiverDelegations(t *testing.T) {
+	type args struct {
+		ctx      context.Context
+		clientID string
+		endsAt   time.Time
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "Happy case: end client caregiver delegations",
+			args: args{
+				ctx:      context.Background(),
+				clientID: uuid.NewString(),
+				endsAt:   time.Now().AddDate(0, 1, 0),
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to end client caregiver delegations",
+			args: args{
+				ctx:      context.Background(),
+				clientID: uuid.NewString(),
+				endsAt:   time.Now().AddDate(0, 1, 0),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to end client caregiver delegations" {
+				fakeGorm.MockEndClientCaregiverDelegationsFn = func(ctx context.Context, clientID string, endsAt time.Time) (int64, error) {
+					return 0, fmt.Errorf("error")
+				}
+			}
+
+			got, err := d.EndClientCaregiverDelegations(tt.args.ctx, tt.args.clientID, tt.args.endsAt)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.EndClientCaregiverDelegations() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("MyCareHubDb.EndClientCaregiverDelegations() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_ConfirmPhoneChange(t *testing.T) {
+	type args struct {
+		ctx         context.Context
+		request     *domain.PhoneChangeRequest
+		confirmedAt time.Time
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: confirm phone change",
+			args: args{
+				ctx: context.Background(),
+				request: &domain.PhoneChangeRequest{
+					ID:             uuid.NewString(),
+					UserID:         uuid.NewString(),
+					OldPhoneNumber: gofakeit.Phone(),
+					NewPhoneNumber: gofakeit.Phone(),
+				},
+				confirmedAt: time.Now(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to confirm phone change",
+			args: args{
+				ctx: context.Background(),
+				request: &domain.PhoneChangeRequest{
+					ID:             uuid.NewString(),
+					UserID:         uuid.NewString(),
+					OldPhoneNumber: gofakeit.Phone(),
+					NewPhoneNumber: gofakeit.Phone(),
+				},
+				confirmedAt: time.Now(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to confirm phone change" {
+				fakeGorm.MockConfirmPhoneChangeFn = func(ctx context.Context, request *gorm.PhoneChangeRequest, confirmedAt time.Time) error {
+					return fmt.Errorf("error")
+				}
+			}
+
+			if err := d.ConfirmPhoneChange(tt.args.ctx, tt.args.request, tt.args.confirmedAt); (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.ConfirmPhoneChange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_OffboardStaff(t *testing.T) {
+	staffID := uuid.NewString()
+	reassignTo := uuid.NewString()
+
+	type args struct {
+		ctx          context.Context
+		offboarding  *domain.StaffOffboarding
+		offboardedAt time.Time
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: offboard staff",
+			args: args{
+				ctx: context.Background(),
+				offboarding: &domain.StaffOffboarding{
+					StaffID:         staffID,
+					UserID:          uuid.NewString(),
+					StaffProfiles:   []string{staffID},
+					UserDeactivated: true,
+					ServiceRequests: []*domain.StaffOffboardingServiceRequest{
+						{ID: "reassigned", ReassignedTo: &reassignTo},
+						{ID: "released"},
+					},
+				},
+				offboardedAt: time.Now(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to offboard staff",
+			args: args{
+				ctx: context.Background(),
+				offboarding: &domain.StaffOffboarding{
+					StaffID:       staffID,
+					UserID:        uuid.NewString(),
+					StaffProfiles: []string{staffID},
+				},
+				offboardedAt: time.Now(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			var handedOver map[string]*string
+			fakeGorm.MockOffboardStaffFn = func(ctx context.Context, userID string, staffIDs []string, serviceRequests map[string]*string, deactivateUser bool, offboardedAt time.Time) error {
+				handedOver = serviceRequests
+				return nil
+			}
+
+			if tt.name == "Sad case: unable to offboard staff" {
+				fakeGorm.MockOffboardStaffFn = func(ctx context.Context, userID string, staffIDs []string, serviceRequests map[string]*string, deactivateUser bool, offboardedAt time.Time) error {
+					return fmt.Errorf("error")
+				}
+			}
+
+			if err := d.OffboardStaff(tt.args.ctx, tt.args.offboarding, tt.args.offboardedAt); (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.OffboardStaff() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.name == "Happy case: offboard staff" {
+				if handedOver["reassigned"] == nil || *handedOver["reassigned"] != reassignTo || handedOver["released"] != nil {
+					t.Errorf("expected one service request to be reassigned and the other released, got %v", handedOver)
+				}
+			}
+		})
+	}
+}
+
+func TestMyCareHubDb_EscalateServiceRequest(t *testing.T) {
+	type args struct {
+		ctx        context.Context
+		escalation *domain.ServiceRequestEscalation
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: escalate service request",
+			args: args{
+				ctx: context.Background(),
+				escalation: &domain.ServiceRequestEscalation{
+					ServiceRequestID:      uuid.NewString(),
+					Level:                 enums.ServiceRequestEscalationLevelAdmins,
+					Recipients:            []string{uuid.NewString()},
+					PatientSafetyIncident: true,
+					EscalatedAt:           time.Now(),
+					ProgramID:             uuid.NewString(),
+					OrganisationID:        uuid.NewString(),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to escalate service request",
+			args: args{
+				ctx: context.Background(),
+				escalation: &domain.ServiceRequestEscalation{
+					ServiceRequestID: uuid.NewString(),
+					Level:            enums.ServiceRequestEscalationLevelFacilityStaff,
+					EscalatedAt:      time.Now(),
+					ProgramID:        uuid.NewString(),
+					OrganisationID:   uuid.NewString(),
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGorm := gormMock.NewGormMock()
+			d := NewMyCareHubDb(fakeGorm, fakeGorm, fakeGorm, fakeGorm)
+
+			if tt.name == "Sad case: unable to escalate service request" {
+				fakeGorm.MockEscalateServiceRequestFn = func(ctx context.Context, escalation *gorm.ServiceRequestEscalation) error {
+					return fmt.Errorf("error")
+				}
+			}
+
+			if err := d.EscalateServiceRequest(tt.args.ctx, tt.args.escalation); (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubDb.EscalateServiceRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -54,9 +54,25 @@ type Create interface {
 	CreateOauthClient(ctx context.Context, client *domain.OauthClient) error
 	CreateOrUpdateSession(ctx context.Context, session *domain.Session) error
 	CreateAuthorizationCode(ctx context.Context, code *domain.AuthorizationCode) error
+	CreateOpenIDConnectSession(ctx context.Context, session *domain.OpenIDConnectSession) error
+	CreatePKCE(ctx context.Context, pkce *domain.PKCE) error
+	CreateOauthSigningKey(ctx context.Context, key *domain.OauthSigningKey) error
 	CreateAccessToken(ctx context.Context, token *domain.AccessToken) error
 	CreateRefreshToken(ctx context.Context, token *domain.RefreshToken) error
 	CreateBooking(ctx context.Context, booking *domain.Booking) (*domain.Booking, error)
+	CreateAuthorityRole(ctx context.Context, role *domain.AuthorityRole, permissionIDs []string) (*domain.AuthorityRole, error)
+	AddPermissionsToRole(ctx context.Context, roleID string, permissionIDs []string) error
+	AssignRoles(ctx context.Context, userType enums.UsersType, profileID string, roleIDs []string) error
+	SeedAuthorityRoles(ctx context.Context, roles []domain.AuthorityRole) error
+	AssignDefaultRoles(ctx context.Context) error
+	CreateAuditLog(ctx context.Context, auditLog *domain.AuditLog) error
+	SaveUserTOTP(ctx context.Context, userTOTP *domain.UserTOTP) error
+	SaveUserRecoveryCodes(ctx context.Context, userID string, recoveryCodes []*domain.UserRecoveryCode) error
+	ConsumeRateLimit(ctx context.Context, action string, rules []*domain.RateLimitRule, at time.Time) (*domain.RateLimitDecision, error)
+	RecordLoginEvent(ctx context.Context, event *domain.LoginEvent, device *domain.UserDevice) error
+	CreatePhoneChangeRequest(ctx context.Context, request *domain.PhoneChangeRequest) (*domain.PhoneChangeRequest, error)
+	TransferClient(ctx context.Context, transfer *domain.ClientTransfer, payload *domain.ClientRegistrationPayload) (*domain.ClientTransfer, error)
+	SetServiceRequestSLA(ctx context.Context, sla *domain.ServiceRequestSLA) (*domain.ServiceRequestSLA, error)
 }
 
 // Delete represents all the deletion action interfaces
@@ -69,7 +85,11 @@ type Delete interface {
 	DeleteOrganisation(ctx context.Context, organisation *domain.Organisation) error
 	DeleteAccessToken(ctx context.Context, signature string) error
 	DeleteRefreshToken(ctx context.Context, signature string) error
-	DeleteClientProfile(ctx context.Context, clientID string, userID *string) error
+	DeletePKCE(ctx context.Context, signature string) error
+	AnonymizeClientProfile(ctx context.Context, clientID string, userID *string) error
+	RemovePermissionsFromRole(ctx context.Context, roleID string, permissionIDs []string) error
+	RevokeRoles(ctx context.Context, userType enums.UsersType, profileID string, roleIDs []string) error
+	DeleteUserTOTP(ctx context.Context, userID string) error
 }
 
 // Query contains all query methods
@@ -83,6 +103,7 @@ type Query interface {
 	GetUserProfileByUsername(ctx context.Context, username string) (*domain.User, error)
 	GetUserProfileByPhoneNumber(ctx context.Context, phoneNumber string) (*domain.User, error)
 	GetUserPINByUserID(ctx context.Context, userID string) (*domain.UserPIN, error)
+	GetUserPINHistory(ctx context.Context, userID string, limit int) ([]*domain.UserPIN, error)
 	GetUserProfileByUserID(ctx context.Context, userID string) (*domain.User, error)
 	GetCurrentTerms(ctx context.Context) (*domain.TermsOfService, error)
 	GetSecurityQuestions(ctx context.Context, flavour feedlib.Flavour) ([]*domain.SecurityQuestion, error)
@@ -178,8 +199,14 @@ type Query interface {
 	GetOauthClient(ctx context.Context, id string) (*domain.OauthClient, error)
 	GetValidClientJWT(ctx context.Context, jti string) (*domain.OauthClientJWT, error)
 	GetAuthorizationCode(ctx context.Context, code string) (*domain.AuthorizationCode, error)
+	GetOpenIDConnectSession(ctx context.Context, code string) (*domain.OpenIDConnectSession, error)
+	GetPKCE(ctx context.Context, signature string) (*domain.PKCE, error)
+	ListOauthSigningKeys(ctx context.Context, activeAt time.Time) ([]*domain.OauthSigningKey, error)
+	ListRevokedSessionIDs(ctx context.Context, since time.Time) ([]string, error)
+	ListRevokedAccessTokenSignatures(ctx context.Context, since time.Time) ([]string, error)
 	GetAccessToken(ctx context.Context, token domain.AccessToken) (*domain.AccessToken, error)
 	GetRefreshToken(ctx context.Context, token domain.RefreshToken) (*domain.RefreshToken, error)
+	ListUserSessions(ctx context.Context, userID string, activeSince time.Time) ([]*domain.Session, error)
 	CheckIfClientHasPendingSurveyServiceRequest(ctx context.Context, clientID string, projectID int, formID string) (bool, error)
 	GetUserProfileByPushToken(ctx context.Context, pushToken string) (*domain.User, error)
 	CheckStaffExistsInProgram(ctx context.Context, userID, programID string) (bool, error)
@@ -188,6 +215,37 @@ type Query interface {
 	GetUserClientProfiles(ctx context.Context, userID string) ([]*domain.ClientProfile, error)
 	GetUserStaffProfiles(ctx context.Context, userID string) ([]*domain.StaffProfile, error)
 	ListBookings(ctx context.Context, clientID string, bookingState enums.BookingState, pagination *domain.Pagination) ([]*domain.Booking, *domain.Pagination, error)
+	GetUserPermissions(ctx context.Context, userID string, programID string) ([]*domain.AuthorityPermission, error)
+	GetAuthorityRoleByID(ctx context.Context, roleID string) (*domain.AuthorityRole, error)
+	GetAuthorityRoleByName(ctx context.Context, programID string, name string) (*domain.AuthorityRole, error)
+	ListAuthorityRoles(ctx context.Context, programID string) ([]*domain.AuthorityRole, error)
+	ListAuthorityPermissions(ctx context.Context) ([]*domain.AuthorityPermission, error)
+	GetProfileRoles(ctx context.Context, userType enums.UsersType, profileID string) ([]*domain.AuthorityRole, error)
+	ListAuditLogs(ctx context.Context, organisationID string, filter *dto.AuditLogFilterInput, pagination *domain.Pagination) ([]*domain.AuditLog, *domain.Pagination, error)
+	ListAuditLogChain(ctx context.Context, organisationID string, afterSequence int64, limit int) ([]*domain.AuditLog, error)
+	CountUnchainedAuditLogs(ctx context.Context, organisationID string, since time.Time) (int64, error)
+	GetUserTOTP(ctx context.Context, userID string) (*domain.UserTOTP, error)
+	CheckIfUserHasTOTP(ctx context.Context, userID string) (bool, error)
+	ListUserRecoveryCodes(ctx context.Context, userID string) ([]*domain.UserRecoveryCode, error)
+	CheckIfStaffTOTPIsEnforced(ctx context.Context, userID string) (bool, error)
+	ListUserDevices(ctx context.Context, userID string) ([]*domain.UserDevice, error)
+	ListUserLoginCountries(ctx context.Context, userID string) ([]string, error)
+	CountUsersLoggedInFromIPAddress(ctx context.Context, ipAddress string, since time.Time) (int, error)
+	ListDuplicateClientCandidates(ctx context.Context, clientID string) ([]*domain.DuplicateClient, error)
+	ListUserContacts(ctx context.Context, userID string) ([]*domain.Contact, error)
+	ListClientScreeningToolResponses(ctx context.Context, clientID string) ([]*domain.QuestionnaireScreeningToolResponse, error)
+	ListUserMetrics(ctx context.Context, userID string) ([]*domain.Metric, error)
+	ListUserFeedback(ctx context.Context, userID string) ([]*domain.FeedbackResponse, error)
+	ListClientsDueForErasure(ctx context.Context, now time.Time) ([]*domain.ClientProfile, error)
+	ListExpiredCaregiverDelegations(ctx context.Context, now time.Time) ([]*domain.CaregiverClient, error)
+	GetPendingPhoneChangeRequest(ctx context.Context, userID string, flavour feedlib.Flavour) (*domain.PhoneChangeRequest, error)
+	GetStaffInProgressServiceRequests(ctx context.Context, staffIDs []string) ([]*domain.ServiceRequest, error)
+	ListOrganisationAdmins(ctx context.Context, organisationID string) ([]*domain.StaffProfile, error)
+	ListFacilityAdmins(ctx context.Context, facilityID, programID string) ([]*domain.StaffProfile, error)
+	ListClientTransfers(ctx context.Context, userID string) ([]*domain.ClientTransfer, error)
+	ListServiceRequestSLAs(ctx context.Context, programID *string) ([]*domain.ServiceRequestSLA, error)
+	GetServiceRequestsDueForEscalation(ctx context.Context, requestTypes []string) ([]*domain.ServiceRequest, error)
+	ListServiceRequestEscalations(ctx context.Context, serviceRequestID string) ([]*domain.ServiceRequestEscalation, error)
 }
 
 // Update represents all the update action interfaces
@@ -225,5 +283,19 @@ type Update interface {
 	UpdateAuthorizationCode(ctx context.Context, code *domain.AuthorizationCode, updateData map[string]interface{}) error
 	UpdateAccessToken(ctx context.Context, token *domain.AccessToken, updateData map[string]interface{}) error
 	UpdateRefreshToken(ctx context.Context, token *domain.RefreshToken, updateData map[string]interface{}) error
+	UpdateOauthClient(ctx context.Context, client *domain.OauthClient, updateData map[string]interface{}) error
+	UpdateSession(ctx context.Context, session *domain.Session, updateData map[string]interface{}) error
+	RevokeSessions(ctx context.Context, sessionIDs []string, revokedAt time.Time) error
+	ResetSecurityQuestionResponses(ctx context.Context, userID string) error
+	MergeClients(ctx context.Context, survivingClientID string, duplicateClientID string) (*domain.ClientMerge, error)
+	MarkClientForErasure(ctx context.Context, clientID string, userID *string, requestedAt time.Time) error
+	EndClientCaregiverDelegations(ctx context.Context, clientID string, endsAt time.Time) (int, error)
+	ConfirmPhoneChange(ctx context.Context, request *domain.PhoneChangeRequest, confirmedAt time.Time) error
+	OffboardStaff(ctx context.Context, offboarding *domain.StaffOffboarding, offboardedAt time.Time) error
+	EscalateServiceRequest(ctx context.Context, escalation *domain.ServiceRequestEscalation) error
 	UpdateBooking(ctx context.Context, booking *domain.Booking, updateData map[string]interface{}) error
+	UpdateUserTOTP(ctx context.Context, userTOTP *domain.UserTOTP, updateData map[string]interface{}) error
+	UseUserRecoveryCode(ctx context.Context, recoveryCode *domain.UserRecoveryCode) error
+	UpdateOrganisation(ctx context.Context, organisation *domain.Organisation, updateData map[string]interface{}) error
+	UpdateOTPDeliveryStatus(ctx context.Context, provider enums.OTPDeliveryProvider, providerMessageID string, status enums.OTPDeliveryStatus, at time.Time) (bool, error)
 }
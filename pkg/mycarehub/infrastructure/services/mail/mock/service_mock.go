@@ -7,6 +7,7 @@ import (
 // MailServiceMock mocks the mailgun's service library implementations
 type MailServiceMock struct {
 	MockSendFeedbackFn func(ctx context.Context, subject, feedbackMessage string) (bool, error)
+	MockSendEmailFn    func(ctx context.Context, subject, text string, to ...string) (bool, error)
 }
 
 // NewMailServiceMock initializes the mock service
@@ -15,6 +16,9 @@ func NewMailServiceMock() *MailServiceMock {
 		MockSendFeedbackFn: func(ctx context.Context, subject, feedbackMessage string) (bool, error) {
 			return true, nil
 		},
+		MockSendEmailFn: func(ctx context.Context, subject, text string, to ...string) (bool, error) {
+			return true, nil
+		},
 	}
 }
 
@@ -22,3 +26,8 @@ func NewMailServiceMock() *MailServiceMock {
 func (m *MailServiceMock) SendFeedback(ctx context.Context, subject, feedbackMessage string) (bool, error) {
 	return m.MockSendFeedbackFn(ctx, subject, feedbackMessage)
 }
+
+// SendEmail mocks the implementation of sending an email
+func (m *MailServiceMock) SendEmail(ctx context.Context, subject, text string, to ...string) (bool, error) {
+	return m.MockSendEmailFn(ctx, subject, text, to...)
+}
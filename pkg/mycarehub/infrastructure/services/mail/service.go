@@ -15,6 +15,7 @@ var (
 // IServiceMail holds the methods to interact with the MailGuns service
 type IServiceMail interface {
 	SendFeedback(ctx context.Context, subject, feedbackMessage string) (bool, error)
+	SendEmail(ctx context.Context, subject, text string, to ...string) (bool, error)
 }
 
 // IMailgunClient defines the methods used to communicate with the Mailgun service
@@ -47,3 +48,15 @@ func (mg *MailgunServiceImpl) SendFeedback(ctx context.Context, subject, feedbac
 
 	return true, nil
 }
+
+// SendEmail sends an email to the provided email addresses
+func (mg *MailgunServiceImpl) SendEmail(ctx context.Context, subject, text string, to ...string) (bool, error) {
+	m := mg.client.NewMessage(mailGunFrom, subject, text, to...)
+
+	_, _, err := mg.client.Send(ctx, m)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -66,3 +66,63 @@ func TestMailgunServiceImpl_SendFeedback(t *testing.T) {
 		})
 	}
 }
+
+func TestMailgunServiceImpl_SendEmail(t *testing.T) {
+	type args struct {
+		ctx     context.Context
+		subject string
+		text    string
+		to      []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case: send email",
+			args: args{
+				ctx:     context.Background(),
+				subject: "Service request escalation",
+				text:    "Hello World",
+				to:      []string{"admin@example.com"},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to send email",
+			args: args{
+				ctx:     context.Background(),
+				subject: "Service request escalation",
+				text:    "Hello World",
+				to:      []string{"admin@example.com"},
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeMail := mailMock.NewMailGunClientMock()
+			mg := mail.NewServiceMail(fakeMail)
+
+			if tt.name == "Sad case: unable to send email" {
+				fakeMail.MockSendFn = func(ctx context.Context, m *mailgun.Message) (string, string, error) {
+					return "", "", errors.New("error")
+				}
+			}
+
+			got, err := mg.SendEmail(tt.args.ctx, tt.args.subject, tt.args.text, tt.args.to...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MailgunServiceImpl.SendEmail() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("MailgunServiceImpl.SendEmail() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -145,9 +145,10 @@ func (m *ServiceImpl) MakeRequest(ctx context.Context, auth *domain.MatrixAuth,
 // RegisterUser registers a user in our Matrix homeserver
 func (m *ServiceImpl) RegisterUser(ctx context.Context, auth *domain.MatrixAuth, registrationPayload *domain.MatrixUserRegistration) (*dto.MatrixUserRegistrationOutput, error) {
 	matrixUser := &domain.MatrixUserRegistration{
-		Username: registrationPayload.Username,
-		Password: registrationPayload.Password,
-		Admin:    registrationPayload.Admin,
+		Username:  registrationPayload.Username,
+		Password:  registrationPayload.Password,
+		Admin:     registrationPayload.Admin,
+		Threepids: registrationPayload.Threepids,
 	}
 
 	matrixUserRegistrationURL := fmt.Sprintf("%s/_synapse/admin/v2/users/@%s:%s", m.BaseURL, matrixUser.Username, matrixLocalPart)
@@ -167,7 +168,8 @@ func (m *ServiceImpl) RegisterUser(ctx context.Context, auth *domain.MatrixAuth,
 		return nil, err
 	}
 
-	if resp.StatusCode != http.StatusCreated {
+	// an existing user is updated with the details in the payload
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
 		var errResponse map[string]string
 
 		err = json.Unmarshal(respBytes, &errResponse)
@@ -272,7 +274,16 @@ func (m *ServiceImpl) CheckIfUserIsAdmin(ctx context.Context, auth *domain.Matri
 		return false, fmt.Errorf("%v", errResponse["error"])
 	}
 
-	return true, nil
+	var data struct {
+		Admin bool `json:"admin"`
+	}
+
+	err = json.Unmarshal(respBytes, &data)
+	if err != nil {
+		return false, err
+	}
+
+	return data.Admin, nil
 }
 
 // SearchUsers searches for users from Matrix server
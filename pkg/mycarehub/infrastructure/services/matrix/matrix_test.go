@@ -42,6 +42,23 @@ func TestServiceImpl_RegisterUser(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "happy case: Successfully update an existing user's phone number",
+			args: args{
+				ctx: context.Background(),
+				auth: &domain.MatrixAuth{
+					Username: "test",
+					Password: "test",
+				},
+				registrationPayload: &domain.MatrixUserRegistration{
+					Username: "test",
+					Threepids: []*domain.MatrixThreepid{
+						{Medium: "msisdn", Address: "254711223344"},
+					},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "sad case: unable to register user",
 			args: args{
@@ -107,6 +124,29 @@ func TestServiceImpl_RegisterUser(t *testing.T) {
 					},
 				)
 			}
+			if tt.name == "happy case: Successfully update an existing user's phone number" {
+				httpmock.RegisterResponder(http.MethodPost, "/_matrix/client/v3/login",
+					func(req *http.Request) (*http.Response, error) {
+						resp, err := httpmock.NewJsonResponse(200, map[string]interface{}{
+							"auth": map[string]interface{}{
+								"type": "m.login.dummy",
+							},
+							"username": gofakeit.BeerMalt(),
+							"password": gofakeit.BeerName(),
+						})
+						return resp, err
+					},
+				)
+				url := fmt.Sprintf("/_synapse/admin/v2/users/@%s:prohealth360.org", "test")
+				httpmock.RegisterResponder(http.MethodPut, url,
+					func(req *http.Request) (*http.Response, error) {
+						resp, err := httpmock.NewJsonResponse(200, map[string]interface{}{
+							"name": "@test:prohealth360.org",
+						})
+						return resp, err
+					},
+				)
+			}
 			if tt.name == "sad case: unable to register user" {
 				url := fmt.Sprintf("/_synapse/admin/v2/users/@%s:prohealth360.org", "test")
 				httpmock.RegisterResponder(http.MethodPut, url,
@@ -321,6 +361,7 @@ func TestServiceImpl_CheckIfUserIsAdmin(t *testing.T) {
 	tests := []struct {
 		name    string
 		args    args
+		want    bool
 		wantErr bool
 	}{
 		{
@@ -333,6 +374,7 @@ func TestServiceImpl_CheckIfUserIsAdmin(t *testing.T) {
 				},
 				userID: "test",
 			},
+			want:    true,
 			wantErr: false,
 		},
 		{
@@ -375,7 +417,9 @@ func TestServiceImpl_CheckIfUserIsAdmin(t *testing.T) {
 
 				httpmock.RegisterResponder(http.MethodGet, "/_synapse/admin/v1/users/@test:prohealth360.org/admin",
 					func(req *http.Request) (*http.Response, error) {
-						resp, err := httpmock.NewJsonResponse(200, nil)
+						resp, err := httpmock.NewJsonResponse(200, map[string]interface{}{
+							"admin": true,
+						})
 
 						return resp, err
 					},
@@ -406,11 +450,14 @@ func TestServiceImpl_CheckIfUserIsAdmin(t *testing.T) {
 				)
 			}
 
-			_, err := m.CheckIfUserIsAdmin(tt.args.ctx, tt.args.auth, tt.args.userID)
+			got, err := m.CheckIfUserIsAdmin(tt.args.ctx, tt.args.auth, tt.args.userID)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("ServiceImpl.CheckIfUserIsAdmin() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if got != tt.want {
+				t.Errorf("ServiceImpl.CheckIfUserIsAdmin() = %v, want %v", got, tt.want)
+			}
 		})
 	}
 }
@@ -67,6 +67,7 @@ func (ps ServicePubSubMessaging) ReceivePubSubPushMessages(
 			DateOfBirth:    data.DateOfBirth,
 			OrganisationID: data.OrganisationID,
 			ProgramID:      data.ProgramID,
+			PhoneNumber:    data.PhoneNumber,
 		}
 
 		registerClientAPIEndpoint := fmt.Sprintf("%s/%s", cmsServiceBaseURL, clientsPath)
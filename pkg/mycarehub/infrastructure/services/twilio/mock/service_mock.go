@@ -2,11 +2,17 @@ package mock
 
 import (
 	"context"
+
+	"github.com/google/uuid"
+	twilioClient "github.com/kevinburke/twilio-go"
 )
 
 // TwilioServiceMock mocks the twilio's client service library implementations
 type TwilioServiceMock struct {
-	MockSendSMSViaTwilioFn func(ctx context.Context, phonenumber, message string) error
+	MockSendSMSViaTwilioFn        func(ctx context.Context, phonenumber, message string) error
+	MockSendTrackedSMSViaTwilioFn func(ctx context.Context, phonenumber, message string) (*twilioClient.Message, error)
+	MockSendWhatsAppViaTwilioFn   func(ctx context.Context, phonenumber, message string) (*twilioClient.Message, error)
+	MockWhatsAppEnabledFn         func() bool
 }
 
 // NewTwilioServiceMock initializes the mock client
@@ -15,6 +21,21 @@ func NewTwilioServiceMock() *TwilioServiceMock {
 		MockSendSMSViaTwilioFn: func(ctx context.Context, phonenumber, message string) error {
 			return nil
 		},
+		MockSendTrackedSMSViaTwilioFn: func(ctx context.Context, phonenumber, message string) (*twilioClient.Message, error) {
+			return &twilioClient.Message{
+				Sid:    uuid.New().String(),
+				Status: twilioClient.StatusQueued,
+			}, nil
+		},
+		MockSendWhatsAppViaTwilioFn: func(ctx context.Context, phonenumber, message string) (*twilioClient.Message, error) {
+			return &twilioClient.Message{
+				Sid:    uuid.New().String(),
+				Status: twilioClient.StatusQueued,
+			}, nil
+		},
+		MockWhatsAppEnabledFn: func() bool {
+			return true
+		},
 	}
 }
 
@@ -22,3 +43,18 @@ func NewTwilioServiceMock() *TwilioServiceMock {
 func (m *TwilioServiceMock) SendSMSViaTwilio(ctx context.Context, phonenumber, message string) error {
 	return m.MockSendSMSViaTwilioFn(ctx, phonenumber, message)
 }
+
+// SendTrackedSMSViaTwilio mocks the implementation of sending an SMS whose delivery status is tracked
+func (m *TwilioServiceMock) SendTrackedSMSViaTwilio(ctx context.Context, phonenumber, message string) (*twilioClient.Message, error) {
+	return m.MockSendTrackedSMSViaTwilioFn(ctx, phonenumber, message)
+}
+
+// SendWhatsAppViaTwilio mocks the implementation of sending a WhatsApp message via twilio
+func (m *TwilioServiceMock) SendWhatsAppViaTwilio(ctx context.Context, phonenumber, message string) (*twilioClient.Message, error) {
+	return m.MockSendWhatsAppViaTwilioFn(ctx, phonenumber, message)
+}
+
+// WhatsAppEnabled mocks the implementation of checking whether WhatsApp messages can be sent
+func (m *TwilioServiceMock) WhatsAppEnabled() bool {
+	return m.MockWhatsAppEnabledFn()
+}
@@ -1,6 +1,7 @@
 package mock
 
 import (
+	"context"
 	"net/url"
 
 	twilioClient "github.com/kevinburke/twilio-go"
@@ -9,6 +10,7 @@ import (
 // TwilioClientMock mocks the twilio's client service library implementations
 type TwilioClientMock struct {
 	MockSendMessageFn func(from string, to string, body string, mediaURLs []*url.URL) (*twilioClient.Message, error)
+	MockCreateFn      func(ctx context.Context, data url.Values) (*twilioClient.Message, error)
 }
 
 // NewTwilioClientMock initializes the mock client
@@ -19,6 +21,14 @@ func NewTwilioClientMock() *TwilioClientMock {
 				From: "+254700000000",
 			}, nil
 		},
+		MockCreateFn: func(ctx context.Context, data url.Values) (*twilioClient.Message, error) {
+			return &twilioClient.Message{
+				Sid:    "SM00000000000000000000000000000000",
+				From:   twilioClient.PhoneNumber(data.Get("From")),
+				To:     twilioClient.PhoneNumber(data.Get("To")),
+				Status: twilioClient.StatusQueued,
+			}, nil
+		},
 	}
 }
 
@@ -26,3 +36,8 @@ func NewTwilioClientMock() *TwilioClientMock {
 func (m *TwilioClientMock) SendMessage(from string, to string, body string, mediaURLs []*url.URL) (*twilioClient.Message, error) {
 	return m.MockSendMessageFn(from, to, body, mediaURLs)
 }
+
+// Create mocks the twilio's client service library implementations
+func (m *TwilioClientMock) Create(ctx context.Context, data url.Values) (*twilioClient.Message, error) {
+	return m.MockCreateFn(ctx, data)
+}
@@ -2,24 +2,40 @@ package twilio
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"net/url"
+	"os"
 
 	twilioClient "github.com/kevinburke/twilio-go"
 	"github.com/savannahghi/serverutils"
 )
 
+const (
+	// whatsAppAddressPrefix is added to the sender and recipient numbers of messages sent through WhatsApp
+	whatsAppAddressPrefix = "whatsapp:"
+
+	// DeliveryReceiptPath is the path that Twilio sends the status of the messages sent through it to
+	DeliveryReceiptPath = "/otp_delivery_receipt/twilio"
+)
+
 var (
 	smsNumber = serverutils.MustGetEnvVar("TWILIO_SMS_NUMBER")
+	authToken = serverutils.MustGetEnvVar("TWILIO_ACCOUNT_AUTH_TOKEN")
 )
 
 // ITwilioService is aN interface that holds methods for sending SMS messages via Twilio
 type ITwilioService interface {
 	SendSMSViaTwilio(ctx context.Context, phonenumber, message string) error
+	SendTrackedSMSViaTwilio(ctx context.Context, phonenumber, message string) (*twilioClient.Message, error)
+	SendWhatsAppViaTwilio(ctx context.Context, phonenumber, message string) (*twilioClient.Message, error)
+	WhatsAppEnabled() bool
 }
 
 // ITwilioClient is an interface that holds methods for sending SMS messages via Twilio client
 type ITwilioClient interface {
 	SendMessage(from string, to string, body string, mediaURLs []*url.URL) (*twilioClient.Message, error)
+	Create(ctx context.Context, data url.Values) (*twilioClient.Message, error)
 }
 
 // ServiceImpl defines the implementation of the Twilio service
@@ -43,3 +59,58 @@ func (t *ServiceImpl) SendSMSViaTwilio(ctx context.Context, phonenumber, message
 
 	return nil
 }
+
+// SendTrackedSMSViaTwilio sends an outbound SMS message via Twilio and asks Twilio to send the message's
+// delivery status to the delivery receipt callback
+func (t *ServiceImpl) SendTrackedSMSViaTwilio(ctx context.Context, phonenumber, message string) (*twilioClient.Message, error) {
+	return t.sendMessage(ctx, smsNumber, phonenumber, message)
+}
+
+// SendWhatsAppViaTwilio sends an outbound WhatsApp message via Twilio
+func (t *ServiceImpl) SendWhatsAppViaTwilio(ctx context.Context, phonenumber, message string) (*twilioClient.Message, error) {
+	sender := whatsAppNumber()
+	if sender == "" {
+		return nil, fmt.Errorf("twilio whatsapp number has not been configured")
+	}
+
+	return t.sendMessage(ctx, whatsAppAddressPrefix+sender, whatsAppAddressPrefix+phonenumber, message)
+}
+
+// WhatsAppEnabled returns true if a WhatsApp sender number has been configured
+func (t *ServiceImpl) WhatsAppEnabled() bool {
+	return whatsAppNumber() != ""
+}
+
+func (t *ServiceImpl) sendMessage(ctx context.Context, from, to, message string) (*twilioClient.Message, error) {
+	data := url.Values{}
+	data.Set("Body", message)
+	data.Set("From", from)
+	data.Set("To", to)
+
+	if host := serviceHost(); host != "" {
+		data.Set("StatusCallback", host+DeliveryReceiptPath)
+	}
+
+	msg, err := t.client.Create(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// ValidateDeliveryReceipt checks that a delivery receipt was sent by Twilio using the signature in the request's headers
+func ValidateDeliveryReceipt(r *http.Request) error {
+	return twilioClient.ValidateIncomingRequest(serviceHost(), authToken, r)
+}
+
+// whatsAppNumber is the sender number of WhatsApp messages. It is optional and messages are not sent
+// through WhatsApp when it is not set
+func whatsAppNumber() string {
+	return os.Getenv("TWILIO_WHATSAPP_NUMBER")
+}
+
+// serviceHost is the public URL of the service that Twilio sends the delivery receipts to
+func serviceHost() string {
+	return os.Getenv("SERVICE_HOST")
+}
@@ -3,7 +3,11 @@ package twilio_test
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"os"
+	"strings"
 	"testing"
 
 	"github.com/kevinburke/twilio-go"
@@ -58,3 +62,161 @@ func TestTwilioServiceImpl_SendSMSViaTwilio(t *testing.T) {
 		})
 	}
 }
+
+func TestTwilioServiceImpl_SendTrackedSMSViaTwilio(t *testing.T) {
+	type args struct {
+		ctx         context.Context
+		phonenumber string
+		message     string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: send tracked sms via twilio",
+			args: args{
+				ctx:         context.Background(),
+				phonenumber: "+254700000000",
+				message:     "Hello World",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to send tracked sms via twilio",
+			args: args{
+				ctx:         context.Background(),
+				phonenumber: "+254700000000",
+				message:     "Hello World",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("SERVICE_HOST", "https://mycarehub.test")
+
+			fakeTwilio := twilioClientMock.NewTwilioClientMock()
+			tw := serviceTwilio.NewServiceTwilio(fakeTwilio)
+
+			fakeTwilio.MockCreateFn = func(ctx context.Context, data url.Values) (*twilio.Message, error) {
+				if tt.name == "Sad case: unable to send tracked sms via twilio" {
+					return nil, fmt.Errorf("an error occurred")
+				}
+
+				if data.Get("StatusCallback") != "https://mycarehub.test"+serviceTwilio.DeliveryReceiptPath {
+					t.Errorf("unexpected status callback %v", data.Get("StatusCallback"))
+				}
+				return &twilio.Message{Sid: "SM123", Status: twilio.StatusQueued}, nil
+			}
+
+			got, err := tw.SendTrackedSMSViaTwilio(tt.args.ctx, tt.args.phonenumber, tt.args.message)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ServiceImpl.SendTrackedSMSViaTwilio() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.Sid != "SM123" {
+				t.Errorf("ServiceImpl.SendTrackedSMSViaTwilio() sid = %v, want SM123", got.Sid)
+			}
+		})
+	}
+}
+
+func TestTwilioServiceImpl_SendWhatsAppViaTwilio(t *testing.T) {
+	type args struct {
+		ctx         context.Context
+		phonenumber string
+		message     string
+	}
+	tests := []struct {
+		name           string
+		args           args
+		whatsAppNumber string
+		wantErr        bool
+	}{
+		{
+			name: "Happy case: send whatsapp message via twilio",
+			args: args{
+				ctx:         context.Background(),
+				phonenumber: "+254700000000",
+				message:     "Hello World",
+			},
+			whatsAppNumber: "+14155238886",
+			wantErr:        false,
+		},
+		{
+			name: "Sad case: whatsapp number not configured",
+			args: args{
+				ctx:         context.Background(),
+				phonenumber: "+254700000000",
+				message:     "Hello World",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TWILIO_WHATSAPP_NUMBER", tt.whatsAppNumber)
+
+			fakeTwilio := twilioClientMock.NewTwilioClientMock()
+			tw := serviceTwilio.NewServiceTwilio(fakeTwilio)
+
+			fakeTwilio.MockCreateFn = func(ctx context.Context, data url.Values) (*twilio.Message, error) {
+				if data.Get("From") != "whatsapp:"+tt.whatsAppNumber || data.Get("To") != "whatsapp:"+tt.args.phonenumber {
+					t.Errorf("unexpected whatsapp addresses from %v to %v", data.Get("From"), data.Get("To"))
+				}
+				return &twilio.Message{Sid: "SM123", Status: twilio.StatusQueued}, nil
+			}
+
+			if got := tw.WhatsAppEnabled(); got != (tt.whatsAppNumber != "") {
+				t.Errorf("ServiceImpl.WhatsAppEnabled() = %v", got)
+			}
+
+			_, err := tw.SendWhatsAppViaTwilio(tt.args.ctx, tt.args.phonenumber, tt.args.message)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ServiceImpl.SendWhatsAppViaTwilio() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateDeliveryReceipt(t *testing.T) {
+	host := "https://mycarehub.test"
+	form := url.Values{}
+	form.Set("MessageSid", "SM123")
+	form.Set("MessageStatus", "delivered")
+
+	newRequest := func(signature string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, serviceTwilio.DeliveryReceiptPath, strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		r.Header.Set("X-Twilio-Signature", signature)
+		return r
+	}
+
+	tests := []struct {
+		name    string
+		request *http.Request
+		wantErr bool
+	}{
+		{
+			name:    "Happy case: valid signature",
+			request: newRequest(twilio.GetExpectedTwilioSignature(host, os.Getenv("TWILIO_ACCOUNT_AUTH_TOKEN"), serviceTwilio.DeliveryReceiptPath, form)),
+			wantErr: false,
+		},
+		{
+			name:    "Sad case: invalid signature",
+			request: newRequest("invalid"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("SERVICE_HOST", host)
+
+			if err := serviceTwilio.ValidateDeliveryReceipt(tt.request); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateDeliveryReceipt() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -101,6 +101,111 @@ func mycarehubCommands() []*cobra.Command {
 		},
 	}
 
+	var organisationID string
+	var verifyAuditCmd = &cobra.Command{
+		Use:   "verifyaudit",
+		Short: "Verifies that the audit trail has not been altered",
+		Long: `Walks each organisation's hash-chained audit log and reports any entry that is missing,
+			has been modified or does not link to the entry recorded before it`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := mycarehubService.VerifyAuditTrail(cmd.Context(), organisationID, os.Stdout); err != nil {
+				log.Fatal(err)
+			}
+			os.Exit(0)
+		},
+	}
+	verifyAuditCmd.Flags().StringVar(&organisationID, "organisation", "", "ID of the organisation whose audit trail is verified. All organisations are verified when it is not provided")
+
+	var (
+		programID     string
+		dryRun        bool
+		inviteClients bool
+	)
+	var loadClientsCmd = &cobra.Command{
+		Use:   "loadclients <csv>",
+		Short: "Registers the clients in a csv file",
+		Long: `Registers the clients in a csv file, for example the patients exported from KenyaEMR when onboarding a facility.
+			The csv has the cccNumber, clientName, gender, dateOfBirth, phoneNumber, clientTypes, facility, enrollmentDate
+			and counselled columns and an optional username column. Every row is validated and the rows with errors are reported`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := mycarehubService.LoadClients(cmd.Context(), args[0], programID, dryRun, inviteClients, os.Stdout); err != nil {
+				log.Fatal(err)
+			}
+			os.Exit(0)
+		},
+	}
+	loadClientsCmd.Flags().StringVar(&programID, "program", "", "ID of the program that the clients are registered in")
+	loadClientsCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Validate the rows without registering the clients")
+	loadClientsCmd.Flags().BoolVar(&inviteClients, "invite", false, "Send the registered clients an invite SMS")
+	_ = loadClientsCmd.MarkFlagRequired("program")
+
+	var (
+		exportReason      string
+		exportRequestedBy string
+		exportOutputPath  string
+	)
+	var exportClientDataCmd = &cobra.Command{
+		Use:   "exportclientdata <clientID>",
+		Short: "Exports all the data held about a client",
+		Long: `Writes all the data held about a client to a zip archive of json and csv files, for example when a client
+			requests their data under the Data Protection Act. The export is recorded in the audit log`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := mycarehubService.ExportClientData(cmd.Context(), args[0], exportReason, exportRequestedBy, exportOutputPath, os.Stdout); err != nil {
+				log.Fatal(err)
+			}
+			os.Exit(0)
+		},
+	}
+	exportClientDataCmd.Flags().StringVar(&exportReason, "reason", "", "Why the data is exported e.g the reference of the client's request")
+	exportClientDataCmd.Flags().StringVar(&exportRequestedBy, "requested-by", "", "Name of the person who requested the export")
+	exportClientDataCmd.Flags().StringVarP(&exportOutputPath, "output", "o", "", "Path the zip archive is written to")
+	_ = exportClientDataCmd.MarkFlagRequired("reason")
+	_ = exportClientDataCmd.MarkFlagRequired("requested-by")
+
+	var purgeClientsCmd = &cobra.Command{
+		Use:   "purgeclients",
+		Short: "Erases the clients whose data retention period has passed",
+		Long: `Anonymizes the personal information of the clients who asked for their data to be erased once their organisation's
+			data retention period has passed and removes them from the CMS, Matrix and the clinical service.
+			It is run daily by a cron job of the helm chart. Clients that could not be erased are retried the next time it runs`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := mycarehubService.PurgeClients(cmd.Context(), os.Stdout); err != nil {
+				log.Fatal(err)
+			}
+			os.Exit(0)
+		},
+	}
+
+	var expireCaregiversCmd = &cobra.Command{
+		Use:   "expirecaregivers",
+		Short: "Ends the access of caregivers whose delegation end date has passed",
+		Long: `Marks the caregiver delegations whose end date has passed as expired and notifies the clients and their caregivers.
+			It is run daily by a cron job of the helm chart. Caregivers cannot act on behalf of a client once
+			the end date passes even before it runs`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := mycarehubService.ExpireCaregivers(cmd.Context(), os.Stdout); err != nil {
+				log.Fatal(err)
+			}
+			os.Exit(0)
+		},
+	}
+
+	var escalateServiceRequestsCmd = &cobra.Command{
+		Use:   "escalateservicerequests",
+		Short: "Escalates the pending service requests that have breached their SLA",
+		Long: `Escalates the pending service requests that have breached the SLA of their program to the staff of their facility and,
+			once the escalation period passes, to the facility or organisation admins by SMS and email. It is run every fifteen
+			minutes by a cron job of the helm chart. A suicide-risk red flag that breaches its SLA is escalated to the admins at once`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := mycarehubService.EscalateServiceRequests(cmd.Context(), os.Stdout); err != nil {
+				log.Fatal(err)
+			}
+			os.Exit(0)
+		},
+	}
+
 	return []*cobra.Command{
 		loadOrganisationCmd,
 		loadProgramCmd,
@@ -109,6 +214,12 @@ func mycarehubCommands() []*cobra.Command {
 		loadTermsOfServiceCmd,
 		loadSecurityQuestionsCmd,
 		createsuperuserCmd,
+		verifyAuditCmd,
+		loadClientsCmd,
+		exportClientDataCmd,
+		purgeClientsCmd,
+		expireCaregiversCmd,
+		escalateServiceRequestsCmd,
 	}
 
 }
@@ -3,9 +3,11 @@ package service
 import (
 	"bufio"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
+	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -24,6 +26,12 @@ type MyCareHubCmdInterfaces interface {
 	LinkFacilityToProgram(ctx context.Context, stdin io.Reader) error
 	LoadSecurityQuestions(ctx context.Context, absoluteFilePath string) error
 	LoadTermsOfService(ctx context.Context, stdin io.Reader) error
+	VerifyAuditTrail(ctx context.Context, organisationID string, stdout io.Writer) error
+	LoadClients(ctx context.Context, path string, programID string, dryRun bool, inviteClients bool, stdout io.Writer) error
+	ExportClientData(ctx context.Context, clientID string, reason string, requestedBy string, outputPath string, stdout io.Writer) error
+	PurgeClients(ctx context.Context, stdout io.Writer) error
+	ExpireCaregivers(ctx context.Context, stdout io.Writer) error
+	EscalateServiceRequests(ctx context.Context, stdout io.Writer) error
 }
 
 // MyCareHubCmdInterfacesImpl represents the usecase implementation object
@@ -476,3 +484,162 @@ func (m *MyCareHubCmdInterfacesImpl) LoadTermsOfService(ctx context.Context, std
 
 	return nil
 }
+
+// VerifyAuditTrail walks the audit log chain of the provided organisation, or of every organisation when none is provided,
+// and reports any entry that is missing or has been modified. An error is returned when an issue is found.
+func (m *MyCareHubCmdInterfacesImpl) VerifyAuditTrail(ctx context.Context, organisationID string, stdout io.Writer) error {
+	organisations := []*domain.Organisation{}
+
+	if organisationID != "" {
+		organisation, err := m.usecase.Organisation.GetOrganisationByID(ctx, organisationID)
+		if err != nil {
+			return err
+		}
+		organisations = append(organisations, organisation)
+	} else {
+		organisationsPage, err := m.usecase.Organisation.ListOrganisations(ctx, nil)
+		if err != nil {
+			return err
+		}
+		organisations = append(organisations, organisationsPage.Organisations...)
+	}
+
+	issues := 0
+	for _, organisation := range organisations {
+		fmt.Fprintf(stdout, "Verifying audit trail for %s (%s)...\n", organisation.Name, organisation.ID)
+
+		verification, err := m.usecase.Organisation.VerifyAuditTrail(ctx, organisation.ID)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(stdout, "\t%d entries verified\n", verification.EntriesVerified)
+		for _, issue := range verification.Issues {
+			fmt.Fprintf(stdout, "\t%s: %s\n", issue.IssueType, issue.Description)
+		}
+		issues += len(verification.Issues)
+	}
+
+	if issues > 0 {
+		return fmt.Errorf("audit trail verification found %d issue(s)", issues)
+	}
+
+	fmt.Fprintln(stdout, "Successfully verified audit trail")
+
+	return nil
+}
+
+// LoadClients registers the clients in a csv file in the provided program. In a dry run the rows are only validated.
+// A report of the rows that could not be registered is printed and an error is returned when there is any
+func (m *MyCareHubCmdInterfacesImpl) LoadClients(ctx context.Context, path string, programID string, dryRun bool, inviteClients bool, stdout io.Writer) error {
+	if programID == "" {
+		return fmt.Errorf("the program that the clients are registered in is required")
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		fmt.Fprintln(stdout, "Validating clients...")
+	} else {
+		fmt.Fprintln(stdout, "Loading clients...")
+	}
+
+	report, err := m.usecase.User.BulkRegisterClients(ctx, &dto.BulkClientRegistrationInput{
+		CSV:           string(content),
+		DryRun:        dryRun,
+		InviteClients: inviteClients,
+		ProgramID:     programID,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, rowError := range report.Errors {
+		fmt.Fprintf(stdout, "\trow %d (%s): %s\n", rowError.Row, rowError.CCCNumber, strings.Join(rowError.Errors, "; "))
+	}
+	fmt.Fprintf(stdout, "%d rows, %d valid, %d registered\n", report.TotalRows, report.ValidRows, report.Registered)
+
+	if len(report.Errors) > 0 {
+		return fmt.Errorf("%d row(s) could not be registered", len(report.Errors))
+	}
+
+	if dryRun {
+		fmt.Fprintln(stdout, "Successfully validated clients")
+	} else {
+		fmt.Fprintln(stdout, "Successfully loaded clients")
+	}
+
+	return nil
+}
+
+// ExportClientData writes all the data held about a client to a zip archive, for example when a client requests their data.
+// The archive is written to the output path or to the current directory when no path is provided
+func (m *MyCareHubCmdInterfacesImpl) ExportClientData(ctx context.Context, clientID string, reason string, requestedBy string, outputPath string, stdout io.Writer) error {
+	if requestedBy == "" {
+		return fmt.Errorf("the name of the person who requested the export is required")
+	}
+
+	fmt.Fprintln(stdout, "Exporting client data...")
+
+	export, err := m.usecase.User.ExportClientData(ctx, &dto.ClientDataExportInput{
+		ClientID:    clientID,
+		Reason:      reason,
+		RequestedBy: requestedBy,
+	})
+	if err != nil {
+		return err
+	}
+
+	content, err := base64.StdEncoding.DecodeString(export.Content)
+	if err != nil {
+		return err
+	}
+
+	if outputPath == "" {
+		outputPath = export.FileName
+	}
+
+	if err := os.WriteFile(outputPath, content, 0600); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "Successfully exported client data to %s\n", outputPath)
+
+	return nil
+}
+
+// PurgeClients anonymizes the clients who asked for their data to be erased once their organisation's data retention period has passed.
+// It is meant to be run on a schedule e.g by a daily cron job
+func (m *MyCareHubCmdInterfacesImpl) PurgeClients(ctx context.Context, stdout io.Writer) error {
+	fmt.Fprintln(stdout, "Purging clients due for erasure...")
+
+	erased, err := m.usecase.User.PurgeClientsDueForErasure(ctx)
+	fmt.Fprintf(stdout, "Erased %d clients\n", erased)
+
+	return err
+}
+
+// ExpireCaregivers ends the access of the caregivers whose delegation end date has passed.
+// It is meant to be run on a schedule e.g by a daily cron job
+func (m *MyCareHubCmdInterfacesImpl) ExpireCaregivers(ctx context.Context, stdout io.Writer) error {
+	fmt.Fprintln(stdout, "Expiring caregiver delegations...")
+
+	expired, err := m.usecase.User.ExpireCaregiverDelegations(ctx)
+	fmt.Fprintf(stdout, "Expired %d caregiver delegations\n", expired)
+
+	return err
+}
+
+// EscalateServiceRequests escalates the pending service requests that have breached their SLA.
+// It is meant to be run on a schedule e.g by an hourly cron job
+func (m *MyCareHubCmdInterfacesImpl) EscalateServiceRequests(ctx context.Context, stdout io.Writer) error {
+	fmt.Fprintln(stdout, "Escalating service requests...")
+
+	escalated, err := m.usecase.ServiceRequest.EscalateServiceRequests(ctx)
+	fmt.Fprintf(stdout, "Made %d service request escalations\n", escalated)
+
+	return err
+}
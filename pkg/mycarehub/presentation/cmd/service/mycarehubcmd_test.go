@@ -4,11 +4,14 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/brianvoe/gofakeit"
 	"github.com/savannahghi/enumutils"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/presentation/cmd/service"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases"
@@ -1479,3 +1482,544 @@ func TestMyCareHubCmdInterfacesImpl_LoadTermsOfService(t *testing.T) {
 		})
 	}
 }
+
+func TestMyCareHubCmdInterfacesImpl_VerifyAuditTrail(t *testing.T) {
+	type args struct {
+		ctx            context.Context
+		organisationID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy Case: verify the audit trail of all organisations",
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy Case: verify the audit trail of an organisation",
+			args: args{
+				ctx:            context.Background(),
+				organisationID: gofakeit.UUID(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad Case: audit trail has issues",
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad Case: failed to get organisation",
+			args: args{
+				ctx:            context.Background(),
+				organisationID: gofakeit.UUID(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad Case: failed to list organisations",
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad Case: failed to verify audit trail",
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			facilityUseCase := facilityMock.NewFacilityUsecaseMock()
+			notificationUseCase := notificationMock.NewServiceNotificationMock()
+			authorityUseCase := authorityMock.NewAuthorityUseCaseMock()
+			userUsecase := userMock.NewUserUseCaseMock()
+			termsUsecase := termsMock.NewTermsUseCaseMock()
+			securityQuestionsUsecase := securityquestionsMock.NewSecurityQuestionsUseCaseMock()
+			contentUseCase := contentMock.NewContentUsecaseMock()
+			feedbackUsecase := feedbackMock.NewFeedbackUsecaseMock()
+			serviceRequestUseCase := servicerequestMock.NewServiceRequestUseCaseMock()
+			appointmentUsecase := appointmentMock.NewAppointmentsUseCaseMock()
+			healthDiaryUseCase := healthdiaryMock.NewHealthDiaryUseCaseMock()
+			surveysUsecase := surveysMock.NewSurveysMock()
+			metricsUsecase := metricsMock.NewMetricsUseCaseMock()
+			questionnaireUsecase := questionnairesMock.NewServiceRequestUseCaseMock()
+			programsUsecase := programsMock.NewProgramsUseCaseMock()
+			organisationUsecase := organisationMock.NewOrganisationUseCaseMock()
+			otpUseCase := otpMock.NewOTPUseCaseMock()
+			pubSubUseCase := pubsubMock.NewServicePubSubMock()
+			communitiesUsecase := communitiesMock.NewCommunityUsecaseMock()
+			oauthUsecase := oauthMock.NewOauthUseCaseMock()
+			usecases := usecases.NewMyCareHubUseCase(
+				userUsecase, termsUsecase, facilityUseCase,
+				securityQuestionsUsecase, otpUseCase, contentUseCase, feedbackUsecase, healthDiaryUseCase,
+				serviceRequestUseCase, authorityUseCase,
+				appointmentUsecase, notificationUseCase, surveysUsecase, metricsUsecase, questionnaireUsecase,
+				programsUsecase, organisationUsecase, pubSubUseCase, communitiesUsecase, oauthUsecase,
+			)
+			m := service.NewMyCareHubCmdInterfaces(*usecases)
+
+			if tt.name == "Sad Case: audit trail has issues" {
+				organisationUsecase.MockVerifyAuditTrailFn = func(ctx context.Context, organisationID string) (*domain.AuditTrailVerification, error) {
+					return &domain.AuditTrailVerification{
+						OrganisationID:  organisationID,
+						EntriesVerified: 3,
+						Issues: []*domain.AuditTrailIssue{
+							{
+								Sequence:    2,
+								AuditLogID:  gofakeit.UUID(),
+								IssueType:   enums.AuditTrailIssueModifiedEntry,
+								Description: "entry 2 has been modified since it was recorded",
+							},
+						},
+					}, nil
+				}
+			}
+			if tt.name == "Sad Case: failed to get organisation" {
+				organisationUsecase.MOckGetOrganisationByIDFn = func(ctx context.Context, organisationID string) (*domain.Organisation, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad Case: failed to list organisations" {
+				organisationUsecase.MockListOrganisationsFn = func(ctx context.Context, paginationInput *dto.PaginationsInput) (*dto.OrganisationOutputPage, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad Case: failed to verify audit trail" {
+				organisationUsecase.MockVerifyAuditTrailFn = func(ctx context.Context, organisationID string) (*domain.AuditTrailVerification, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			stdout := &bytes.Buffer{}
+			if err := m.VerifyAuditTrail(tt.args.ctx, tt.args.organisationID, stdout); (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubCmdInterfacesImpl.VerifyAuditTrail() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMyCareHubCmdInterfacesImpl_LoadClients(t *testing.T) {
+	type args struct {
+		ctx       context.Context
+		path      string
+		programID string
+		dryRun    bool
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy Case: load clients",
+			args: args{
+				ctx:       context.Background(),
+				path:      "testData/client/valid.csv",
+				programID: gofakeit.UUID(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy Case: validate clients",
+			args: args{
+				ctx:       context.Background(),
+				path:      "testData/client/valid.csv",
+				programID: gofakeit.UUID(),
+				dryRun:    true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad Case: rows could not be registered",
+			args: args{
+				ctx:       context.Background(),
+				path:      "testData/client/valid.csv",
+				programID: gofakeit.UUID(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad Case: missing program",
+			args: args{
+				ctx:  context.Background(),
+				path: "testData/client/valid.csv",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad Case: invalid path",
+			args: args{
+				ctx:       context.Background(),
+				path:      "testData/client/missing.csv",
+				programID: gofakeit.UUID(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad Case: failed to register clients",
+			args: args{
+				ctx:       context.Background(),
+				path:      "testData/client/valid.csv",
+				programID: gofakeit.UUID(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			facilityUseCase := facilityMock.NewFacilityUsecaseMock()
+			notificationUseCase := notificationMock.NewServiceNotificationMock()
+			authorityUseCase := authorityMock.NewAuthorityUseCaseMock()
+			userUsecase := userMock.NewUserUseCaseMock()
+			termsUsecase := termsMock.NewTermsUseCaseMock()
+			securityQuestionsUsecase := securityquestionsMock.NewSecurityQuestionsUseCaseMock()
+			contentUseCase := contentMock.NewContentUsecaseMock()
+			feedbackUsecase := feedbackMock.NewFeedbackUsecaseMock()
+			serviceRequestUseCase := servicerequestMock.NewServiceRequestUseCaseMock()
+			appointmentUsecase := appointmentMock.NewAppointmentsUseCaseMock()
+			healthDiaryUseCase := healthdiaryMock.NewHealthDiaryUseCaseMock()
+			surveysUsecase := surveysMock.NewSurveysMock()
+			metricsUsecase := metricsMock.NewMetricsUseCaseMock()
+			questionnaireUsecase := questionnairesMock.NewServiceRequestUseCaseMock()
+			programsUsecase := programsMock.NewProgramsUseCaseMock()
+			organisationUsecase := organisationMock.NewOrganisationUseCaseMock()
+			otpUseCase := otpMock.NewOTPUseCaseMock()
+			pubSubUseCase := pubsubMock.NewServicePubSubMock()
+			communitiesUsecase := communitiesMock.NewCommunityUsecaseMock()
+			oauthUsecase := oauthMock.NewOauthUseCaseMock()
+			usecases := usecases.NewMyCareHubUseCase(
+				userUsecase, termsUsecase, facilityUseCase,
+				securityQuestionsUsecase, otpUseCase, contentUseCase, feedbackUsecase, healthDiaryUseCase,
+				serviceRequestUseCase, authorityUseCase,
+				appointmentUsecase, notificationUseCase, surveysUsecase, metricsUsecase, questionnaireUsecase,
+				programsUsecase, organisationUsecase, pubSubUseCase, communitiesUsecase, oauthUsecase,
+			)
+			m := service.NewMyCareHubCmdInterfaces(*usecases)
+
+			if tt.name == "Sad Case: rows could not be registered" {
+				userUsecase.MockBulkRegisterClientsFn = func(ctx context.Context, input *dto.BulkClientRegistrationInput) (*dto.BulkClientRegistrationOutput, error) {
+					return &dto.BulkClientRegistrationOutput{
+						TotalRows:  2,
+						ValidRows:  1,
+						Registered: 1,
+						Errors: []*dto.BulkClientRegistrationError{
+							{
+								Row:       3,
+								CCCNumber: "1234567891",
+								Errors:    []string{"an identifier with this CCC number 1234567891 already exists"},
+							},
+						},
+					}, nil
+				}
+			}
+			if tt.name == "Sad Case: failed to register clients" {
+				userUsecase.MockBulkRegisterClientsFn = func(ctx context.Context, input *dto.BulkClientRegistrationInput) (*dto.BulkClientRegistrationOutput, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			stdout := &bytes.Buffer{}
+			if err := m.LoadClients(tt.args.ctx, tt.args.path, tt.args.programID, tt.args.dryRun, false, stdout); (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubCmdInterfacesImpl.LoadClients() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMyCareHubCmdInterfacesImpl_ExportClientData(t *testing.T) {
+	type args struct {
+		ctx         context.Context
+		clientID    string
+		reason      string
+		requestedBy string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy Case: export client data",
+			args: args{
+				ctx:         context.Background(),
+				clientID:    gofakeit.UUID(),
+				reason:      "Data subject access request",
+				requestedBy: gofakeit.Name(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad Case: missing requested by",
+			args: args{
+				ctx:      context.Background(),
+				clientID: gofakeit.UUID(),
+				reason:   "Data subject access request",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad Case: failed to export client data",
+			args: args{
+				ctx:         context.Background(),
+				clientID:    gofakeit.UUID(),
+				reason:      "Data subject access request",
+				requestedBy: gofakeit.Name(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad Case: invalid export content",
+			args: args{
+				ctx:         context.Background(),
+				clientID:    gofakeit.UUID(),
+				reason:      "Data subject access request",
+				requestedBy: gofakeit.Name(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			facilityUseCase := facilityMock.NewFacilityUsecaseMock()
+			notificationUseCase := notificationMock.NewServiceNotificationMock()
+			authorityUseCase := authorityMock.NewAuthorityUseCaseMock()
+			userUsecase := userMock.NewUserUseCaseMock()
+			termsUsecase := termsMock.NewTermsUseCaseMock()
+			securityQuestionsUsecase := securityquestionsMock.NewSecurityQuestionsUseCaseMock()
+			contentUseCase := contentMock.NewContentUsecaseMock()
+			feedbackUsecase := feedbackMock.NewFeedbackUsecaseMock()
+			serviceRequestUseCase := servicerequestMock.NewServiceRequestUseCaseMock()
+			appointmentUsecase := appointmentMock.NewAppointmentsUseCaseMock()
+			healthDiaryUseCase := healthdiaryMock.NewHealthDiaryUseCaseMock()
+			surveysUsecase := surveysMock.NewSurveysMock()
+			metricsUsecase := metricsMock.NewMetricsUseCaseMock()
+			questionnaireUsecase := questionnairesMock.NewServiceRequestUseCaseMock()
+			programsUsecase := programsMock.NewProgramsUseCaseMock()
+			organisationUsecase := organisationMock.NewOrganisationUseCaseMock()
+			otpUseCase := otpMock.NewOTPUseCaseMock()
+			pubSubUseCase := pubsubMock.NewServicePubSubMock()
+			communitiesUsecase := communitiesMock.NewCommunityUsecaseMock()
+			oauthUsecase := oauthMock.NewOauthUseCaseMock()
+			usecases := usecases.NewMyCareHubUseCase(
+				userUsecase, termsUsecase, facilityUseCase,
+				securityQuestionsUsecase, otpUseCase, contentUseCase, feedbackUsecase, healthDiaryUseCase,
+				serviceRequestUseCase, authorityUseCase,
+				appointmentUsecase, notificationUseCase, surveysUsecase, metricsUsecase, questionnaireUsecase,
+				programsUsecase, organisationUsecase, pubSubUseCase, communitiesUsecase, oauthUsecase,
+			)
+			m := service.NewMyCareHubCmdInterfaces(*usecases)
+
+			if tt.name == "Sad Case: failed to export client data" {
+				userUsecase.MockExportClientDataFn = func(ctx context.Context, input *dto.ClientDataExportInput) (*dto.ClientDataExport, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad Case: invalid export content" {
+				userUsecase.MockExportClientDataFn = func(ctx context.Context, input *dto.ClientDataExportInput) (*dto.ClientDataExport, error) {
+					return &dto.ClientDataExport{FileName: "client.zip", Content: "not base64!"}, nil
+				}
+			}
+
+			outputPath := filepath.Join(t.TempDir(), "export.zip")
+			stdout := &bytes.Buffer{}
+			if err := m.ExportClientData(tt.args.ctx, tt.args.clientID, tt.args.reason, tt.args.requestedBy, outputPath, stdout); (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubCmdInterfacesImpl.ExportClientData() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr {
+				if _, err := os.Stat(outputPath); err != nil {
+					t.Errorf("expected the export to be written to %s: %v", outputPath, err)
+				}
+			}
+		})
+	}
+}
+
+func TestMyCareHubCmdInterfacesImpl_PurgeClients(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{
+			name:    "Happy Case: purge clients",
+			wantErr: false,
+		},
+		{
+			name:    "Sad Case: failed to purge clients",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			facilityUseCase := facilityMock.NewFacilityUsecaseMock()
+			notificationUseCase := notificationMock.NewServiceNotificationMock()
+			authorityUseCase := authorityMock.NewAuthorityUseCaseMock()
+			userUsecase := userMock.NewUserUseCaseMock()
+			termsUsecase := termsMock.NewTermsUseCaseMock()
+			securityQuestionsUsecase := securityquestionsMock.NewSecurityQuestionsUseCaseMock()
+			contentUseCase := contentMock.NewContentUsecaseMock()
+			feedbackUsecase := feedbackMock.NewFeedbackUsecaseMock()
+			serviceRequestUseCase := servicerequestMock.NewServiceRequestUseCaseMock()
+			appointmentUsecase := appointmentMock.NewAppointmentsUseCaseMock()
+			healthDiaryUseCase := healthdiaryMock.NewHealthDiaryUseCaseMock()
+			surveysUsecase := surveysMock.NewSurveysMock()
+			metricsUsecase := metricsMock.NewMetricsUseCaseMock()
+			questionnaireUsecase := questionnairesMock.NewServiceRequestUseCaseMock()
+			programsUsecase := programsMock.NewProgramsUseCaseMock()
+			organisationUsecase := organisationMock.NewOrganisationUseCaseMock()
+			otpUseCase := otpMock.NewOTPUseCaseMock()
+			pubSubUseCase := pubsubMock.NewServicePubSubMock()
+			communitiesUsecase := communitiesMock.NewCommunityUsecaseMock()
+			oauthUsecase := oauthMock.NewOauthUseCaseMock()
+			usecases := usecases.NewMyCareHubUseCase(
+				userUsecase, termsUsecase, facilityUseCase,
+				securityQuestionsUsecase, otpUseCase, contentUseCase, feedbackUsecase, healthDiaryUseCase,
+				serviceRequestUseCase, authorityUseCase,
+				appointmentUsecase, notificationUseCase, surveysUsecase, metricsUsecase, questionnaireUsecase,
+				programsUsecase, organisationUsecase, pubSubUseCase, communitiesUsecase, oauthUsecase,
+			)
+			m := service.NewMyCareHubCmdInterfaces(*usecases)
+
+			if tt.name == "Sad Case: failed to purge clients" {
+				userUsecase.MockPurgeClientsDueForErasureFn = func(ctx context.Context) (int, error) {
+					return 0, fmt.Errorf("an error occurred")
+				}
+			}
+
+			stdout := &bytes.Buffer{}
+			if err := m.PurgeClients(context.Background(), stdout); (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubCmdInterfacesImpl.PurgeClients() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMyCareHubCmdInterfacesImpl_ExpireCaregivers(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{
+			name:    "Happy Case: expire caregivers",
+			wantErr: false,
+		},
+		{
+			name:    "Sad Case: failed to expire caregivers",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			facilityUseCase := facilityMock.NewFacilityUsecaseMock()
+			notificationUseCase := notificationMock.NewServiceNotificationMock()
+			authorityUseCase := authorityMock.NewAuthorityUseCaseMock()
+			userUsecase := userMock.NewUserUseCaseMock()
+			termsUsecase := termsMock.NewTermsUseCaseMock()
+			securityQuestionsUsecase := securityquestionsMock.NewSecurityQuestionsUseCaseMock()
+			contentUseCase := contentMock.NewContentUsecaseMock()
+			feedbackUsecase := feedbackMock.NewFeedbackUsecaseMock()
+			serviceRequestUseCase := servicerequestMock.NewServiceRequestUseCaseMock()
+			appointmentUsecase := appointmentMock.NewAppointmentsUseCaseMock()
+			healthDiaryUseCase := healthdiaryMock.NewHealthDiaryUseCaseMock()
+			surveysUsecase := surveysMock.NewSurveysMock()
+			metricsUsecase := metricsMock.NewMetricsUseCaseMock()
+			questionnaireUsecase := questionnairesMock.NewServiceRequestUseCaseMock()
+			programsUsecase := programsMock.NewProgramsUseCaseMock()
+			organisationUsecase := organisationMock.NewOrganisationUseCaseMock()
+			otpUseCase := otpMock.NewOTPUseCaseMock()
+			pubSubUseCase := pubsubMock.NewServicePubSubMock()
+			communitiesUsecase := communitiesMock.NewCommunityUsecaseMock()
+			oauthUsecase := oauthMock.NewOauthUseCaseMock()
+			usecases := usecases.NewMyCareHubUseCase(
+				userUsecase, termsUsecase, facilityUseCase,
+				securityQuestionsUsecase, otpUseCase, contentUseCase, feedbackUsecase, healthDiaryUseCase,
+				serviceRequestUseCase, authorityUseCase,
+				appointmentUsecase, notificationUseCase, surveysUsecase, metricsUsecase, questionnaireUsecase,
+				programsUsecase, organisationUsecase, pubSubUseCase, communitiesUsecase, oauthUsecase,
+			)
+			m := service.NewMyCareHubCmdInterfaces(*usecases)
+
+			if tt.name == "Sad Case: failed to expire caregivers" {
+				userUsecase.MockExpireCaregiverDelegationsFn = func(ctx context.Context) (int, error) {
+					return 0, fmt.Errorf("an error occurred")
+				}
+			}
+
+			stdout := &bytes.Buffer{}
+			if err := m.ExpireCaregivers(context.Background(), stdout); (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubCmdInterfacesImpl.ExpireCaregivers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMyCareHubCmdInterfacesImpl_EscalateServiceRequests(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{
+			name:    "Happy Case: escalate service requests",
+			wantErr: false,
+		},
+		{
+			name:    "Sad Case: failed to escalate service requests",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			facilityUseCase := facilityMock.NewFacilityUsecaseMock()
+			notificationUseCase := notificationMock.NewServiceNotificationMock()
+			authorityUseCase := authorityMock.NewAuthorityUseCaseMock()
+			userUsecase := userMock.NewUserUseCaseMock()
+			termsUsecase := termsMock.NewTermsUseCaseMock()
+			securityQuestionsUsecase := securityquestionsMock.NewSecurityQuestionsUseCaseMock()
+			contentUseCase := contentMock.NewContentUsecaseMock()
+			feedbackUsecase := feedbackMock.NewFeedbackUsecaseMock()
+			serviceRequestUseCase := servicerequestMock.NewServiceRequestUseCaseMock()
+			appointmentUsecase := appointmentMock.NewAppointmentsUseCaseMock()
+			healthDiaryUseCase := healthdiaryMock.NewHealthDiaryUseCaseMock()
+			surveysUsecase := surveysMock.NewSurveysMock()
+			metricsUsecase := metricsMock.NewMetricsUseCaseMock()
+			questionnaireUsecase := questionnairesMock.NewServiceRequestUseCaseMock()
+			programsUsecase := programsMock.NewProgramsUseCaseMock()
+			organisationUsecase := organisationMock.NewOrganisationUseCaseMock()
+			otpUseCase := otpMock.NewOTPUseCaseMock()
+			pubSubUseCase := pubsubMock.NewServicePubSubMock()
+			communitiesUsecase := communitiesMock.NewCommunityUsecaseMock()
+			oauthUsecase := oauthMock.NewOauthUseCaseMock()
+			usecases := usecases.NewMyCareHubUseCase(
+				userUsecase, termsUsecase, facilityUseCase,
+				securityQuestionsUsecase, otpUseCase, contentUseCase, feedbackUsecase, healthDiaryUseCase,
+				serviceRequestUseCase, authorityUseCase,
+				appointmentUsecase, notificationUseCase, surveysUsecase, metricsUsecase, questionnaireUsecase,
+				programsUsecase, organisationUsecase, pubSubUseCase, communitiesUsecase, oauthUsecase,
+			)
+			m := service.NewMyCareHubCmdInterfaces(*usecases)
+
+			if tt.name == "Sad Case: failed to escalate service requests" {
+				serviceRequestUseCase.MockEscalateServiceRequestsFn = func(ctx context.Context) (int, error) {
+					return 0, fmt.Errorf("an error occurred")
+				}
+			}
+
+			stdout := &bytes.Buffer{}
+			if err := m.EscalateServiceRequests(context.Background(), stdout); (err != nil) != tt.wantErr {
+				t.Errorf("MyCareHubCmdInterfacesImpl.EscalateServiceRequests() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -15,13 +15,14 @@ import (
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
 	"github.com/savannahghi/firebasetools"
-	"github.com/savannahghi/interserviceclient"
 	externalExtension "github.com/savannahghi/mycarehub/pkg/mycarehub/application/extension"
 	loginservice "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/login"
+	serviceTwilio "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/twilio"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/presentation/graph"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/presentation/graph/generated"
 	internalRest "github.com/savannahghi/mycarehub/pkg/mycarehub/presentation/rest"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/oauth"
 	"github.com/savannahghi/serverutils"
 	log "github.com/sirupsen/logrus"
 
@@ -61,6 +62,12 @@ func Router(ctx context.Context) (*mux.Router, error) {
 		return nil, err
 	}
 
+	// the permissions guarding the API are granted through the default roles so every program must have them
+	err = useCases.Authority.SeedDefaultRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	sessionManager := scs.New()
 	sessionManager.Lifetime = 24 * time.Hour
 
@@ -79,6 +86,9 @@ func Router(ctx context.Context) (*mux.Router, error) {
 	// Add Middleware that records the metrics for HTTP routes
 	r.Use(serverutils.CustomHTTPRequestMetricsMiddleware())
 
+	// Add Middleware that records the IP address of the client for rate limiting
+	r.Use(ClientIPMiddleware())
+
 	oauth2Routes := r.PathPrefix("/oauth").Subrouter()
 
 	oauth2Routes.Path("/authorize").Methods(
@@ -102,6 +112,43 @@ func Router(ctx context.Context) (*mux.Router, error) {
 		http.MethodPost,
 	).HandlerFunc(internalHandlers.IntrospectionHandler())
 
+	oauth2Routes.Path("/userinfo").Methods(
+		http.MethodOptions,
+		http.MethodGet,
+		http.MethodPost,
+	).HandlerFunc(internalHandlers.UserInfoHandler())
+
+	// dynamic client registration (RFC 7591) and management (RFC 7592)
+	oauth2Routes.Path("/register").Methods(
+		http.MethodOptions,
+		http.MethodPost,
+	).HandlerFunc(internalHandlers.RegisterOauthClientHandler())
+
+	oauth2Routes.Path("/register/{clientID}").Methods(
+		http.MethodOptions,
+		http.MethodGet,
+	).HandlerFunc(internalHandlers.GetOauthClientRegistrationHandler())
+
+	oauth2Routes.Path("/register/{clientID}").Methods(
+		http.MethodPut,
+	).HandlerFunc(internalHandlers.UpdateOauthClientRegistrationHandler())
+
+	oauth2Routes.Path("/register/{clientID}").Methods(
+		http.MethodDelete,
+	).HandlerFunc(internalHandlers.DeleteOauthClientRegistrationHandler())
+
+	wellKnownRoutes := r.PathPrefix("/.well-known").Subrouter()
+
+	wellKnownRoutes.Path("/openid-configuration").Methods(
+		http.MethodOptions,
+		http.MethodGet,
+	).HandlerFunc(internalHandlers.OpenIDConfigurationHandler())
+
+	wellKnownRoutes.Path("/jwks.json").Methods(
+		http.MethodOptions,
+		http.MethodGet,
+	).HandlerFunc(internalHandlers.JSONWebKeySetHandler())
+
 	// Shared unauthenticated routes
 	// openSourcePresentation.SharedUnauthenticatedRoutes(h, r)
 	r.Path("/ide").HandlerFunc(playground.Handler("GraphQL IDE", "/graphql"))
@@ -194,6 +241,13 @@ func Router(ctx context.Context) (*mux.Router, error) {
 
 	r.Path("/pubsub").Methods(http.MethodPost).HandlerFunc(useCases.Pubsub.ReceivePubSubPushMessages)
 
+	// OTP delivery receipts
+	twilioReceipts := r.Path(serviceTwilio.DeliveryReceiptPath).Subrouter()
+	twilioReceipts.Use(TwilioSignatureMiddleware())
+	twilioReceipts.Methods(
+		http.MethodPost,
+	).HandlerFunc(internalHandlers.TwilioOTPDeliveryReceipt())
+
 	// Matrix routes
 	r.Path("/_matrix/push/v1/notify").Methods(
 		http.MethodOptions,
@@ -219,49 +273,64 @@ func Router(ctx context.Context) (*mux.Router, error) {
 		http.MethodPost,
 	).HandlerFunc(internalHandlers.ClientSignUp())
 
-	// KenyaEMR routes. These endpoints are authenticated and are used for integrations
-	// between myCareHub and KenyaEMR
+	// KenyaEMR routes. These endpoints are used for integrations between myCareHub and KenyaEMR.
+	// Each facility's KenyaEMR is an oauth client that is bound to the facility's MFL code and gets
+	// scoped access tokens using the client credentials grant. Firebase tokens are still accepted until
+	// every facility's KenyaEMR has moved to client credentials
 	kenyaEMR := r.PathPrefix("/kenya-emr").Subrouter()
-	kenyaEMR.Use(firebasetools.AuthenticationMiddleware(firebaseApp))
+	kenyaEMRClient := func(scope string, handler http.Handler) http.Handler {
+		return FacilityClientOrFirebaseAuthenticationMiddleware(useCases.Oauth, firebaseApp, scope)(handler)
+	}
 
 	kenyaEMR.Path("/health_diary").Methods(
 		http.MethodGet,
 		http.MethodOptions,
-	).HandlerFunc(internalHandlers.GetClientHealthDiaryEntries())
+	).Handler(kenyaEMRClient(oauth.ScopeKenyaEMRHealthDiaryRead, internalHandlers.GetClientHealthDiaryEntries()))
 
 	kenyaEMR.Path("/service_request").Methods(
 		http.MethodOptions,
 		http.MethodGet,
+	).Handler(kenyaEMRClient(oauth.ScopeKenyaEMRServiceRequestsRead, internalHandlers.ServiceRequests()))
+
+	kenyaEMR.Path("/service_request").Methods(
 		http.MethodPost,
-	).HandlerFunc(internalHandlers.ServiceRequests())
+	).Handler(kenyaEMRClient(oauth.ScopeKenyaEMRServiceRequestsWrite, internalHandlers.ServiceRequests()))
 
 	kenyaEMR.Path("/patients").Methods(
 		http.MethodOptions,
 		http.MethodGet,
-	).HandlerFunc(internalHandlers.RegisteredFacilityPatients())
+	).Handler(kenyaEMRClient(oauth.ScopeKenyaEMRPatientsRead, internalHandlers.RegisteredFacilityPatients()))
 
 	kenyaEMR.Path("/appointments").Methods(
 		http.MethodOptions,
 		http.MethodPost,
-	).HandlerFunc(internalHandlers.CreateOrUpdateKenyaEMRAppointments())
+	).Handler(kenyaEMRClient(oauth.ScopeKenyaEMRAppointmentsWrite, internalHandlers.CreateOrUpdateKenyaEMRAppointments()))
 
 	kenyaEMR.Path("/observations").Methods(
 		http.MethodOptions,
 		http.MethodPost,
-	).HandlerFunc(internalHandlers.AddPatientsRecords())
+	).Handler(kenyaEMRClient(oauth.ScopeKenyaEMRObservationsWrite, internalHandlers.AddPatientsRecords()))
+
 	kenyaEMR.Path("/appointment-service-request").Methods(
 		http.MethodOptions,
 		http.MethodGet,
+	).Handler(kenyaEMRClient(oauth.ScopeKenyaEMRServiceRequestsRead, internalHandlers.AppointmentsServiceRequests()))
+
+	kenyaEMR.Path("/appointment-service-request").Methods(
 		http.MethodPost,
-	).HandlerFunc(internalHandlers.AppointmentsServiceRequests())
+	).Handler(kenyaEMRClient(oauth.ScopeKenyaEMRServiceRequestsWrite, internalHandlers.AppointmentsServiceRequests()))
 
-	// ISC routes. These are inter-service route
+	// ISC routes. These are inter-service routes that also accept the scoped access tokens of oauth clients
 	isc := r.PathPrefix("/internal").Subrouter()
-	isc.Use(interserviceclient.InterServiceAuthenticationMiddleware())
+
+	isc.Path("/otp_delivery_receipt").Methods(
+		http.MethodOptions,
+		http.MethodPost,
+	).Handler(InterServiceOrClientAuthenticationMiddleware(useCases.Oauth, oauth.ScopeInternalOTPDeliveryReceiptsWrite)(internalHandlers.OTPDeliveryReceipt()))
 
 	// Graphql route
 	authR := r.Path("/graphql").Subrouter()
-	authR.Use(AuthenticationMiddleware(Introspector))
+	authR.Use(AuthenticationMiddleware(LocalIntrospector(useCases.Oauth, Introspector)))
 	authR.Use(UserContextInjectionMiddleware(useCases.User))
 	authR.Methods(
 		http.MethodPost,
@@ -320,9 +389,13 @@ func GQLHandler(ctx context.Context,
 		generated.NewExecutableSchema(
 			generated.Config{
 				Resolvers: resolver,
+				Directives: generated.DirectiveRoot{
+					HasPermission: resolver.HasPermission,
+				},
 			},
 		),
 	)
+	server.AroundRootFields(resolver.RestrictTOTPEnrollmentSessions)
 	return func(w http.ResponseWriter, r *http.Request) {
 		server.ServeHTTP(w, r)
 	}
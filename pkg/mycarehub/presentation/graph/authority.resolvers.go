@@ -1 +1,69 @@
 package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.40
+
+import (
+	"context"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+)
+
+// CreateAuthorityRole is the resolver for the createAuthorityRole field.
+func (r *mutationResolver) CreateAuthorityRole(ctx context.Context, input dto.AuthorityRoleInput) (*domain.AuthorityRole, error) {
+	r.checkPreconditions()
+
+	return r.mycarehub.Authority.CreateAuthorityRole(ctx, input)
+}
+
+// AddPermissionsToRole is the resolver for the addPermissionsToRole field.
+func (r *mutationResolver) AddPermissionsToRole(ctx context.Context, roleID string, permissionIDs []string) (*domain.AuthorityRole, error) {
+	r.checkPreconditions()
+
+	return r.mycarehub.Authority.AddPermissionsToRole(ctx, roleID, permissionIDs)
+}
+
+// RemovePermissionsFromRole is the resolver for the removePermissionsFromRole field.
+func (r *mutationResolver) RemovePermissionsFromRole(ctx context.Context, roleID string, permissionIDs []string) (*domain.AuthorityRole, error) {
+	r.checkPreconditions()
+
+	return r.mycarehub.Authority.RemovePermissionsFromRole(ctx, roleID, permissionIDs)
+}
+
+// AssignRoles is the resolver for the assignRoles field.
+func (r *mutationResolver) AssignRoles(ctx context.Context, input dto.RoleAssignmentInput) (bool, error) {
+	r.checkPreconditions()
+
+	return r.mycarehub.Authority.AssignRoles(ctx, input)
+}
+
+// RevokeRoles is the resolver for the revokeRoles field.
+func (r *mutationResolver) RevokeRoles(ctx context.Context, input dto.RoleAssignmentInput) (bool, error) {
+	r.checkPreconditions()
+
+	return r.mycarehub.Authority.RevokeRoles(ctx, input)
+}
+
+// ListAuthorityRoles is the resolver for the listAuthorityRoles field.
+func (r *queryResolver) ListAuthorityRoles(ctx context.Context, programID string) ([]*domain.AuthorityRole, error) {
+	r.checkPreconditions()
+
+	return r.mycarehub.Authority.ListAuthorityRoles(ctx, programID)
+}
+
+// ListAuthorityPermissions is the resolver for the listAuthorityPermissions field.
+func (r *queryResolver) ListAuthorityPermissions(ctx context.Context) ([]*domain.AuthorityPermission, error) {
+	r.checkPreconditions()
+
+	return r.mycarehub.Authority.ListAuthorityPermissions(ctx)
+}
+
+// GetProfileRoles is the resolver for the getProfileRoles field.
+func (r *queryResolver) GetProfileRoles(ctx context.Context, userType enums.UsersType, profileID string) ([]*domain.AuthorityRole, error) {
+	r.checkPreconditions()
+
+	return r.mycarehub.Authority.GetProfileRoles(ctx, userType, profileID)
+}
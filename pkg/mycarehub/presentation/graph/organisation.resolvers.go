@@ -21,6 +21,26 @@ func (r *mutationResolver) DeleteOrganisation(ctx context.Context, organisationI
 	return r.mycarehub.Organisation.DeleteOrganisation(ctx, organisationID)
 }
 
+// SetStaffTOTPEnforcement is the resolver for the setStaffTOTPEnforcement field.
+func (r *mutationResolver) SetStaffTOTPEnforcement(ctx context.Context, enforce bool) (bool, error) {
+	return r.mycarehub.Organisation.SetStaffTOTPEnforcement(ctx, enforce)
+}
+
+// SetStaffLoginStepUp is the resolver for the setStaffLoginStepUp field.
+func (r *mutationResolver) SetStaffLoginStepUp(ctx context.Context, enabled bool) (bool, error) {
+	return r.mycarehub.Organisation.SetStaffLoginStepUp(ctx, enabled)
+}
+
+// SetPINPolicy is the resolver for the setPINPolicy field.
+func (r *mutationResolver) SetPINPolicy(ctx context.Context, input dto.PINPolicyInput) (bool, error) {
+	return r.mycarehub.Organisation.SetPINPolicy(ctx, input)
+}
+
+// SetDataRetentionPeriod is the resolver for the setDataRetentionPeriod field.
+func (r *mutationResolver) SetDataRetentionPeriod(ctx context.Context, days int) (bool, error) {
+	return r.mycarehub.Organisation.SetDataRetentionPeriod(ctx, days)
+}
+
 // ListOrganisations is the resolver for the listOrganisations field.
 func (r *queryResolver) ListOrganisations(ctx context.Context, paginationInput dto.PaginationsInput) (*dto.OrganisationOutputPage, error) {
 	r.checkPreconditions()
@@ -41,3 +61,10 @@ func (r *queryResolver) GetOrganisationByID(ctx context.Context, organisationID
 
 	return r.mycarehub.Organisation.GetOrganisationByID(ctx, organisationID)
 }
+
+// AuditTrail is the resolver for the auditTrail field.
+func (r *queryResolver) AuditTrail(ctx context.Context, filter *dto.AuditLogFilterInput, paginationInput dto.PaginationsInput) (*domain.AuditLogPage, error) {
+	r.checkPreconditions()
+
+	return r.mycarehub.Organisation.AuditTrail(ctx, filter, paginationInput)
+}
@@ -2,9 +2,14 @@ package graph
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"strings"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/savannahghi/firebasetools"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/utils"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases"
 
 	"firebase.google.com/go/auth"
@@ -16,6 +21,13 @@ import (
 //
 // It serves as dependency injection for your app, add any dependencies you require here.
 
+// totpEnrollmentFields are the only fields that can be resolved with a session that can only be used to enroll an authenticator app
+var totpEnrollmentFields = map[string]bool{
+	"getTOTPStatus":         true,
+	"enrollTOTP":            true,
+	"confirmTOTPEnrollment": true,
+}
+
 // Resolver sets up a GraphQL resolver with all necessary dependencies
 type Resolver struct {
 	mycarehub *usecases.MyCareHub
@@ -45,3 +57,39 @@ func (r *Resolver) CheckUserTokenInContext(ctx context.Context) *auth.Token {
 	}
 	return token
 }
+
+// HasPermission implements the `@hasPermission` directive. It only resolves the field when the logged in user
+// has been granted a permission with the provided scope.
+func (r *Resolver) HasPermission(ctx context.Context, obj interface{}, next graphql.Resolver, scope string) (interface{}, error) {
+	r.checkPreconditions()
+
+	ok, err := r.mycarehub.Authority.CheckUserPermission(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, exceptions.UserNotAuthorizedErr(fmt.Errorf("user does not have the required permission: %s", scope))
+	}
+
+	return next(ctx)
+}
+
+// RestrictTOTPEnrollmentSessions only resolves the authenticator app enrollment fields for staff who logged in
+// without the authenticator app that their organisation requires. They have to log in again once it is enrolled.
+func (r *Resolver) RestrictTOTPEnrollmentSessions(ctx context.Context, next graphql.RootResolver) graphql.Marshaler {
+	enrollmentOnly, _ := ctx.Value(utils.TOTPEnrollmentContextKey).(bool)
+	if !enrollmentOnly {
+		return next(ctx)
+	}
+
+	field := graphql.GetRootFieldContext(ctx)
+	if field == nil || totpEnrollmentFields[field.Field.Name] || strings.HasPrefix(field.Field.Name, "__") {
+		return next(ctx)
+	}
+
+	err := fmt.Errorf("%s cannot be resolved before an authenticator app is enrolled", field.Field.Name)
+	graphql.AddError(ctx, exceptions.TOTPEnrollmentRequiredErr(err))
+
+	return graphql.Null
+}
@@ -18,6 +18,12 @@ func (r *mutationResolver) RecordSecurityQuestionResponses(ctx context.Context,
 	return r.mycarehub.SecurityQuestions.RecordSecurityQuestionResponses(ctx, input)
 }
 
+// ResetSecurityQuestionResponses is the resolver for the resetSecurityQuestionResponses field.
+func (r *mutationResolver) ResetSecurityQuestionResponses(ctx context.Context, userID string) (bool, error) {
+	r.checkPreconditions()
+	return r.mycarehub.SecurityQuestions.ResetSecurityQuestionResponses(ctx, userID)
+}
+
 // GetSecurityQuestions is the resolver for the getSecurityQuestions field.
 func (r *queryResolver) GetSecurityQuestions(ctx context.Context, flavour feedlib.Flavour) ([]*domain.SecurityQuestion, error) {
 	r.checkPreconditions()
@@ -45,6 +45,13 @@ func (r *mutationResolver) CompleteVisit(ctx context.Context, staffID string, se
 	return r.mycarehub.ServiceRequest.CompleteVisit(ctx, staffID, serviceRequestID, bookingID, *notes)
 }
 
+// SetServiceRequestSLA is the resolver for the setServiceRequestSLA field.
+func (r *mutationResolver) SetServiceRequestSLA(ctx context.Context, input dto.ServiceRequestSLAInput) (*domain.ServiceRequestSLA, error) {
+	r.checkPreconditions()
+
+	return r.mycarehub.ServiceRequest.SetServiceRequestSLA(ctx, &input)
+}
+
 // GetServiceRequests is the resolver for the getServiceRequests field.
 func (r *queryResolver) GetServiceRequests(ctx context.Context, requestType *string, requestStatus *string, facilityID string, flavour feedlib.Flavour, pagination dto.PaginationsInput) (*domain.ServiceRequestPage, error) {
 	return r.mycarehub.ServiceRequest.GetServiceRequests(ctx, *requestType, requestStatus, facilityID, flavour, &pagination)
@@ -59,3 +66,17 @@ func (r *queryResolver) GetPendingServiceRequestsCount(ctx context.Context) (*do
 func (r *queryResolver) SearchServiceRequests(ctx context.Context, searchTerm string, flavour feedlib.Flavour, requestType string, facilityID string) ([]*domain.ServiceRequest, error) {
 	return r.mycarehub.ServiceRequest.SearchServiceRequests(ctx, searchTerm, flavour, requestType, facilityID)
 }
+
+// ListServiceRequestSLAs is the resolver for the listServiceRequestSLAs field.
+func (r *queryResolver) ListServiceRequestSLAs(ctx context.Context) ([]*domain.ServiceRequestSLA, error) {
+	r.checkPreconditions()
+
+	return r.mycarehub.ServiceRequest.ListServiceRequestSLAs(ctx)
+}
+
+// ListServiceRequestEscalations is the resolver for the listServiceRequestEscalations field.
+func (r *queryResolver) ListServiceRequestEscalations(ctx context.Context, serviceRequestID string) ([]*domain.ServiceRequestEscalation, error) {
+	r.checkPreconditions()
+
+	return r.mycarehub.ServiceRequest.ListServiceRequestEscalations(ctx, serviceRequestID)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	"github.com/savannahghi/scalarutils"
 )
 
 // AcceptTerms is the resolver for the acceptTerms field.
@@ -36,6 +37,11 @@ func (r *mutationResolver) RegisterClient(ctx context.Context, input *dto.Client
 	return r.mycarehub.User.RegisterClient(ctx, input)
 }
 
+// BulkRegisterClients is the resolver for the bulkRegisterClients field.
+func (r *mutationResolver) BulkRegisterClients(ctx context.Context, input dto.BulkClientRegistrationInput) (*dto.BulkClientRegistrationOutput, error) {
+	return r.mycarehub.User.BulkRegisterClients(ctx, &input)
+}
+
 // RegisterStaff is the resolver for the registerStaff field.
 func (r *mutationResolver) RegisterStaff(ctx context.Context, input dto.StaffRegistrationInput) (*dto.StaffRegistrationOutput, error) {
 	return r.mycarehub.User.RegisterStaff(ctx, input)
@@ -56,6 +62,16 @@ func (r *mutationResolver) RegisterClientAsCaregiver(ctx context.Context, client
 	return r.mycarehub.User.RegisterClientAsCaregiver(ctx, clientID, caregiverNumber)
 }
 
+// MergeClients is the resolver for the mergeClients field.
+func (r *mutationResolver) MergeClients(ctx context.Context, survivingClientID string, duplicateClientID string) (*domain.ClientMerge, error) {
+	return r.mycarehub.User.MergeClients(ctx, survivingClientID, duplicateClientID)
+}
+
+// ExportClientData is the resolver for the exportClientData field.
+func (r *mutationResolver) ExportClientData(ctx context.Context, input dto.ClientDataExportInput) (*dto.ClientDataExport, error) {
+	return r.mycarehub.User.ExportClientData(ctx, &input)
+}
+
 // DeleteClientProfile is the resolver for the DeleteClientProfile field.
 func (r *mutationResolver) DeleteClientProfile(ctx context.Context, clientID string) (bool, error) {
 	return r.mycarehub.User.DeleteClientProfile(ctx, clientID)
@@ -81,6 +97,13 @@ func (r *mutationResolver) TransferClientToFacility(ctx context.Context, clientI
 	return r.mycarehub.User.TransferClientToFacility(ctx, &clientID, &facilityID)
 }
 
+// TransferClient is the resolver for the transferClient field.
+func (r *mutationResolver) TransferClient(ctx context.Context, input dto.ClientTransferInput) (*domain.ClientTransfer, error) {
+	r.checkPreconditions()
+
+	return r.mycarehub.User.TransferClient(ctx, &input)
+}
+
 // SetStaffDefaultFacility is the resolver for the setStaffDefaultFacility field.
 func (r *mutationResolver) SetStaffDefaultFacility(ctx context.Context, staffID string, facilityID string) (*domain.Facility, error) {
 	return r.mycarehub.User.SetStaffDefaultFacility(ctx, staffID, facilityID)
@@ -133,6 +156,16 @@ func (r *mutationResolver) ConsentToManagingClient(ctx context.Context, caregive
 	return r.mycarehub.User.ConsentToManagingClient(ctx, caregiverID, clientID, consent)
 }
 
+// RevokeCaregiverDelegation is the resolver for the revokeCaregiverDelegation field.
+func (r *mutationResolver) RevokeCaregiverDelegation(ctx context.Context, clientID string, caregiverID string) (bool, error) {
+	return r.mycarehub.User.RevokeCaregiverDelegation(ctx, clientID, caregiverID)
+}
+
+// EndClientCaregiverAccess is the resolver for the endClientCaregiverAccess field.
+func (r *mutationResolver) EndClientCaregiverAccess(ctx context.Context, clientID string, endDate scalarutils.Date) (int, error) {
+	return r.mycarehub.User.EndClientCaregiverAccess(ctx, clientID, endDate)
+}
+
 // RegisterExistingUserAsClient is the resolver for the registerExistingUserAsClient field.
 func (r *mutationResolver) RegisterExistingUserAsClient(ctx context.Context, input dto.ExistingUserClientInput) (*dto.ClientRegistrationOutput, error) {
 	r.checkPreconditions()
@@ -169,6 +202,90 @@ func (r *mutationResolver) UpdateOrganisationAdminPermission(ctx context.Context
 	return r.mycarehub.User.UpdateOrganisationAdminPermission(ctx, staffID, isOrganisationAdmin)
 }
 
+// OffboardStaff is the resolver for the offboardStaff field.
+func (r *mutationResolver) OffboardStaff(ctx context.Context, input dto.StaffOffboardingInput) (*domain.StaffOffboarding, error) {
+	r.checkPreconditions()
+
+	return r.mycarehub.User.OffboardStaff(ctx, &input)
+}
+
+// EnrollTotp is the resolver for the enrollTOTP field.
+func (r *mutationResolver) EnrollTotp(ctx context.Context) (*domain.TOTPEnrollment, error) {
+	r.checkPreconditions()
+
+	return r.mycarehub.User.EnrollTOTP(ctx)
+}
+
+// ConfirmTOTPEnrollment is the resolver for the confirmTOTPEnrollment field.
+func (r *mutationResolver) ConfirmTOTPEnrollment(ctx context.Context, code string) ([]string, error) {
+	r.checkPreconditions()
+
+	return r.mycarehub.User.ConfirmTOTPEnrollment(ctx, code)
+}
+
+// RegenerateTOTPRecoveryCodes is the resolver for the regenerateTOTPRecoveryCodes field.
+func (r *mutationResolver) RegenerateTOTPRecoveryCodes(ctx context.Context, code string) ([]string, error) {
+	r.checkPreconditions()
+
+	return r.mycarehub.User.RegenerateTOTPRecoveryCodes(ctx, code)
+}
+
+// DisableTotp is the resolver for the disableTOTP field.
+func (r *mutationResolver) DisableTotp(ctx context.Context, code string) (bool, error) {
+	r.checkPreconditions()
+
+	return r.mycarehub.User.DisableTOTP(ctx, code)
+}
+
+// RevokeSession is the resolver for the revokeSession field.
+func (r *mutationResolver) RevokeSession(ctx context.Context, sessionID string) (bool, error) {
+	r.checkPreconditions()
+
+	return r.mycarehub.User.RevokeSession(ctx, sessionID)
+}
+
+// RevokeAllOtherSessions is the resolver for the revokeAllOtherSessions field.
+func (r *mutationResolver) RevokeAllOtherSessions(ctx context.Context) (bool, error) {
+	r.checkPreconditions()
+
+	return r.mycarehub.User.RevokeAllOtherSessions(ctx)
+}
+
+// ForceLogoutUser is the resolver for the forceLogoutUser field.
+func (r *mutationResolver) ForceLogoutUser(ctx context.Context, userID string) (bool, error) {
+	r.checkPreconditions()
+
+	return r.mycarehub.User.ForceLogoutUser(ctx, userID)
+}
+
+// RequestPhoneChange is the resolver for the requestPhoneChange field.
+func (r *mutationResolver) RequestPhoneChange(ctx context.Context, phoneNumber string, flavour feedlib.Flavour) (bool, error) {
+	r.checkPreconditions()
+
+	return r.mycarehub.User.RequestPhoneChange(ctx, phoneNumber, flavour)
+}
+
+// ConfirmPhoneChange is the resolver for the confirmPhoneChange field.
+func (r *mutationResolver) ConfirmPhoneChange(ctx context.Context, phoneNumber string, otp string, flavour feedlib.Flavour) (bool, error) {
+	r.checkPreconditions()
+
+	return r.mycarehub.User.ConfirmPhoneChange(ctx, phoneNumber, otp, flavour)
+}
+
+// RequestAssistedPhoneChange is the resolver for the requestAssistedPhoneChange field.
+func (r *mutationResolver) RequestAssistedPhoneChange(ctx context.Context, userID string, phoneNumber string, flavour feedlib.Flavour, reason string) (bool, error) {
+	r.checkPreconditions()
+
+	return r.mycarehub.User.RequestAssistedPhoneChange(ctx, userID, phoneNumber, flavour, reason)
+}
+
+// ConfirmAssistedPhoneChange is the resolver for the confirmAssistedPhoneChange field.
+func (r *mutationResolver) ConfirmAssistedPhoneChange(ctx context.Context, userID string, phoneNumber string, otp string, flavour feedlib.Flavour) (bool, error) {
+	r.checkPreconditions()
+
+	return r.mycarehub.User.ConfirmAssistedPhoneChange(ctx, userID, phoneNumber, otp, flavour)
+}
+
 // GetCurrentTerms is the resolver for the getCurrentTerms field.
 func (r *queryResolver) GetCurrentTerms(ctx context.Context) (*domain.TermsOfService, error) {
 	r.checkPreconditions()
@@ -235,3 +352,31 @@ func (r *queryResolver) CheckIdentifierExists(ctx context.Context, identifierTyp
 func (r *queryResolver) CheckIfPhoneExists(ctx context.Context, phoneNumber string) (bool, error) {
 	return r.mycarehub.User.CheckIfPhoneExists(ctx, phoneNumber)
 }
+
+// GetTOTPStatus is the resolver for the getTOTPStatus field.
+func (r *queryResolver) GetTOTPStatus(ctx context.Context) (*domain.TOTPStatus, error) {
+	r.checkPreconditions()
+
+	return r.mycarehub.User.GetTOTPStatus(ctx)
+}
+
+// ListMySessions is the resolver for the listMySessions field.
+func (r *queryResolver) ListMySessions(ctx context.Context) ([]*domain.UserSession, error) {
+	r.checkPreconditions()
+
+	return r.mycarehub.User.ListMySessions(ctx)
+}
+
+// FindDuplicateClients is the resolver for the findDuplicateClients field.
+func (r *queryResolver) FindDuplicateClients(ctx context.Context, clientID string) ([]*domain.DuplicateClient, error) {
+	r.checkPreconditions()
+
+	return r.mycarehub.User.FindDuplicateClients(ctx, clientID)
+}
+
+// ListClientTransfers is the resolver for the listClientTransfers field.
+func (r *queryResolver) ListClientTransfers(ctx context.Context, clientID string) ([]*domain.ClientTransfer, error) {
+	r.checkPreconditions()
+
+	return r.mycarehub.User.ListClientTransfers(ctx, clientID)
+}
@@ -3,6 +3,7 @@ package presentation
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -10,10 +11,14 @@ import (
 	"strings"
 
 	"firebase.google.com/go/auth"
+	"github.com/ory/fosite"
 	"github.com/savannahghi/firebasetools"
+	"github.com/savannahghi/interserviceclient"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/utils"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	serviceTwilio "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/twilio"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/oauth"
 	"github.com/savannahghi/serverutils"
 )
 
@@ -25,8 +30,10 @@ var (
 )
 
 type IntrospectResponse struct {
-	Active bool   `json:"active"`
-	UserID string `json:"user_id"`
+	Active         bool   `json:"active"`
+	UserID         string `json:"user_id"`
+	SessionID      string `json:"session_id"`
+	TOTPEnrollment bool   `json:"totp_enrollment"`
 }
 
 type IntrospectFunc func(ctx context.Context, token string) (*IntrospectResponse, error)
@@ -83,6 +90,33 @@ func Introspector(ctx context.Context, token string) (*IntrospectResponse, error
 	return &introspection, nil
 }
 
+// IAccessTokenVerifier verifies JWT access tokens without looking them up
+type IAccessTokenVerifier interface {
+	VerifyAccessToken(ctx context.Context, accessToken string) (*oauth.AccessTokenClaims, error)
+}
+
+// LocalIntrospector verifies JWT access tokens locally using the signing keys and the revocation list.
+// Opaque access tokens, e.g those issued before JWT access tokens were enabled, are introspected using the fallback
+func LocalIntrospector(verifier IAccessTokenVerifier, fallback IntrospectFunc) IntrospectFunc {
+	return func(ctx context.Context, token string) (*IntrospectResponse, error) {
+		if strings.Count(token, ".") != 2 {
+			return fallback(ctx, token)
+		}
+
+		claims, err := verifier.VerifyAccessToken(ctx, token)
+		if err != nil {
+			return &IntrospectResponse{Active: false}, nil
+		}
+
+		return &IntrospectResponse{
+			Active:         true,
+			UserID:         claims.UserID,
+			SessionID:      claims.SessionID,
+			TOTPEnrollment: claims.TOTPEnrollment,
+		}, nil
+	}
+}
+
 // AuthenticationMiddleware
 func AuthenticationMiddleware(checkFunc IntrospectFunc) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -114,6 +148,16 @@ func AuthenticationMiddleware(checkFunc IntrospectFunc) func(http.Handler) http.
 
 				ctx := context.WithValue(r.Context(), firebasetools.AuthTokenContextKey, &auth.Token{UID: tokenInfo.UserID})
 
+				// tokens issued before sessions were tracked do not have a session ID
+				if tokenInfo.SessionID != "" {
+					ctx = context.WithValue(ctx, utils.SessionIDContextKey, tokenInfo.SessionID)
+				}
+
+				// staff who have not enrolled an authenticator app that their organisation requires can only enroll it
+				if tokenInfo.TOTPEnrollment {
+					ctx = context.WithValue(ctx, utils.TOTPEnrollmentContextKey, true)
+				}
+
 				r = r.WithContext(ctx)
 
 				next.ServeHTTP(w, r)
@@ -157,3 +201,164 @@ func UserContextInjectionMiddleware(us IUserProfile) func(http.Handler) http.Han
 		)
 	}
 }
+
+// ClientIPMiddleware sets the IP address and country of the client that made a request into the context
+// so that requests from the same client can be rate limited and unusual logins detected
+func ClientIPMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				ctx := context.WithValue(r.Context(), utils.ClientIPContextKey, utils.GetClientIP(r))
+				ctx = context.WithValue(ctx, utils.ClientCountryContextKey, utils.GetClientCountry(r))
+
+				r = r.WithContext(ctx)
+
+				next.ServeHTTP(w, r)
+			},
+		)
+	}
+}
+
+// TwilioSignatureMiddleware rejects the requests to the Twilio callbacks that were not signed by Twilio
+func TwilioSignatureMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if err := serviceTwilio.ValidateDeliveryReceipt(r); err != nil {
+					serverutils.WriteJSONResponse(w, serverutils.ErrorMap(err), http.StatusUnauthorized)
+					return
+				}
+
+				next.ServeHTTP(w, r)
+			},
+		)
+	}
+}
+
+// IOauthClientAuthenticator authenticates the integration clients that get access tokens for themselves using the
+// client credentials grant
+type IOauthClientAuthenticator interface {
+	AuthenticateClient(ctx context.Context, accessToken string, scope string) (*domain.OauthClient, error)
+}
+
+// clientAuthenticationStatus returns the status code of a request whose client could not be authenticated.
+// A client whose token is valid but was not granted the scope of the route is forbidden from using the route
+func clientAuthenticationStatus(err error) int {
+	if errors.Is(err, fosite.ErrInvalidScope) {
+		return http.StatusForbidden
+	}
+
+	return http.StatusUnauthorized
+}
+
+// FacilityClientAuthenticationMiddleware authenticates an integration client e.g KenyaEMR that is bound to a facility.
+// The client should have been granted the scope of the route. The MFL code of the client's facility is set into the context
+// so that the client can only access the records of its own facility
+func FacilityClientAuthenticationMiddleware(authenticator IOauthClientAuthenticator, scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				bearerToken, err := firebasetools.ExtractBearerToken(r)
+				if err != nil {
+					serverutils.WriteJSONResponse(w, serverutils.ErrorMap(err), http.StatusUnauthorized)
+					return
+				}
+
+				client, err := authenticator.AuthenticateClient(r.Context(), bearerToken, scope)
+				if err != nil {
+					serverutils.WriteJSONResponse(w, serverutils.ErrorMap(err), clientAuthenticationStatus(err))
+					return
+				}
+
+				if client.MFLCode == "" {
+					err := fmt.Errorf("client is not bound to a facility")
+					serverutils.WriteJSONResponse(w, serverutils.ErrorMap(err), http.StatusForbidden)
+					return
+				}
+
+				ctx := context.WithValue(r.Context(), utils.FacilityMFLCodeContextKey, client.MFLCode)
+
+				r = r.WithContext(ctx)
+
+				next.ServeHTTP(w, r)
+			},
+		)
+	}
+}
+
+// FacilityClientOrFirebaseAuthenticationMiddleware authenticates either a facility-bound integration client as FacilityClientAuthenticationMiddleware does
+// or a KenyaEMR that still uses a Firebase token. Tokens that were not issued by myCareHub are checked as Firebase tokens.
+// The Firebase fallback should be removed once every facility's KenyaEMR has been registered as an oauth client
+func FacilityClientOrFirebaseAuthenticationMiddleware(authenticator IOauthClientAuthenticator, firebaseApp firebasetools.IFirebaseApp, scope string) func(http.Handler) http.Handler {
+	firebaseAuthentication := firebasetools.AuthenticationMiddleware(firebaseApp)
+
+	return func(next http.Handler) http.Handler {
+		firebaseHandler := firebaseAuthentication(next)
+
+		return http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				bearerToken, err := firebasetools.ExtractBearerToken(r)
+				if err != nil {
+					serverutils.WriteJSONResponse(w, serverutils.ErrorMap(err), http.StatusUnauthorized)
+					return
+				}
+
+				client, err := authenticator.AuthenticateClient(r.Context(), bearerToken, scope)
+				if errors.Is(err, fosite.ErrInvalidScope) {
+					serverutils.WriteJSONResponse(w, serverutils.ErrorMap(err), http.StatusForbidden)
+					return
+				}
+
+				if err != nil {
+					firebaseHandler.ServeHTTP(w, r)
+					return
+				}
+
+				if client.MFLCode == "" {
+					err := fmt.Errorf("client is not bound to a facility")
+					serverutils.WriteJSONResponse(w, serverutils.ErrorMap(err), http.StatusForbidden)
+					return
+				}
+
+				ctx := context.WithValue(r.Context(), utils.FacilityMFLCodeContextKey, client.MFLCode)
+
+				r = r.WithContext(ctx)
+
+				next.ServeHTTP(w, r)
+			},
+		)
+	}
+}
+
+// InterServiceOrClientAuthenticationMiddleware authenticates either an integration client that was granted the scope of the route
+// or another service using an inter-service token. Tokens that were not issued by myCareHub are checked as inter-service tokens
+func InterServiceOrClientAuthenticationMiddleware(authenticator IOauthClientAuthenticator, scope string) func(http.Handler) http.Handler {
+	interServiceAuthentication := interserviceclient.InterServiceAuthenticationMiddleware()
+
+	return func(next http.Handler) http.Handler {
+		interServiceHandler := interServiceAuthentication(next)
+
+		return http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				bearerToken, err := firebasetools.ExtractBearerToken(r)
+				if err != nil {
+					interServiceHandler.ServeHTTP(w, r)
+					return
+				}
+
+				_, err = authenticator.AuthenticateClient(r.Context(), bearerToken, scope)
+				if errors.Is(err, fosite.ErrInvalidScope) {
+					serverutils.WriteJSONResponse(w, serverutils.ErrorMap(err), http.StatusForbidden)
+					return
+				}
+
+				if err != nil {
+					interServiceHandler.ServeHTTP(w, r)
+					return
+				}
+
+				next.ServeHTTP(w, r)
+			},
+		)
+	}
+}
@@ -5,13 +5,16 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ory/fosite"
 	"github.com/savannahghi/errorcodeutil"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/utils"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases"
 	"github.com/savannahghi/serverutils"
 	"go.opentelemetry.io/otel"
@@ -49,9 +52,18 @@ type MyCareHubHandlersInterfaces interface {
 	TokenHandler() http.HandlerFunc
 	RevokeHandler() http.HandlerFunc
 	IntrospectionHandler() http.HandlerFunc
+	OpenIDConfigurationHandler() http.HandlerFunc
+	JSONWebKeySetHandler() http.HandlerFunc
+	UserInfoHandler() http.HandlerFunc
+	RegisterOauthClientHandler() http.HandlerFunc
+	GetOauthClientRegistrationHandler() http.HandlerFunc
+	UpdateOauthClientRegistrationHandler() http.HandlerFunc
+	DeleteOauthClientRegistrationHandler() http.HandlerFunc
 	NotifyHandler() http.HandlerFunc
 	ContentHandler() http.HandlerFunc
 	ClientSignUp() http.HandlerFunc
+	OTPDeliveryReceipt() http.HandlerFunc
+	TwilioOTPDeliveryReceipt() http.HandlerFunc
 }
 
 type okResp struct {
@@ -136,6 +148,8 @@ func (h *MyCareHubHandlersInterfacesImpl) LoginByPhone() http.HandlerFunc {
 			return
 		}
 
+		payload.UserAgent = r.UserAgent()
+
 		response, successful := h.usecase.User.Login(ctx, payload)
 		if !successful {
 			serverutils.WriteJSONResponse(w, response, http.StatusBadRequest)
@@ -144,7 +158,20 @@ func (h *MyCareHubHandlersInterfacesImpl) LoginByPhone() http.HandlerFunc {
 
 		user := response.GetUserProfile()
 
-		tokens, err := h.usecase.Oauth.GenerateUserAuthTokens(ctx, user.ID)
+		device := dto.SessionDeviceInput{
+			DeviceID:   payload.DeviceID,
+			DeviceName: payload.DeviceName,
+			UserAgent:  r.UserAgent(),
+			IPAddress:  utils.GetClientIP(r),
+		}
+
+		generateTokens := h.usecase.Oauth.GenerateUserAuthTokens
+		// staff who have not enrolled the authenticator app that their organisation requires can only enroll it
+		if user.TOTPEnrollmentRequired {
+			generateTokens = h.usecase.Oauth.GenerateTOTPEnrollmentAuthTokens
+		}
+
+		tokens, err := generateTokens(ctx, user.ID, device)
 		if err != nil {
 			helpers.ReportErrorToSentry(err)
 
@@ -299,7 +326,7 @@ func (h *MyCareHubHandlersInterfacesImpl) VerifyPhone() http.HandlerFunc {
 		otpResponse, err := h.usecase.OTP.VerifyPhoneNumber(ctx, payload.Username, payload.Flavour)
 		if err != nil {
 			helpers.ReportErrorToSentry(err)
-			serverutils.WriteJSONResponse(w, serverutils.ErrorMap(err), http.StatusBadRequest)
+			serverutils.WriteJSONResponse(w, serverutils.ErrorMap(err), otpErrorStatusCode(err))
 			return
 		}
 
@@ -381,8 +408,10 @@ func (h *MyCareHubHandlersInterfacesImpl) SendOTP() http.HandlerFunc {
 		if err != nil {
 			helpers.ReportErrorToSentry(err)
 			serverutils.WriteJSONResponse(w, errorcodeutil.CustomError{
+				Err:     err,
 				Message: err.Error(),
-			}, http.StatusBadRequest)
+				Code:    exceptions.GetErrorCode(err),
+			}, otpErrorStatusCode(err))
 			return
 		}
 
@@ -423,8 +452,10 @@ func (h *MyCareHubHandlersInterfacesImpl) RequestPINReset() http.HandlerFunc {
 		if err != nil {
 			helpers.ReportErrorToSentry(err)
 			serverutils.WriteJSONResponse(w, errorcodeutil.CustomError{
+				Err:     err,
 				Message: err.Error(),
-			}, http.StatusBadRequest)
+				Code:    exceptions.GetErrorCode(err),
+			}, otpErrorStatusCode(err))
 			return
 		}
 
@@ -456,7 +487,7 @@ func (h *MyCareHubHandlersInterfacesImpl) SendRetryOTP() http.HandlerFunc {
 		resp, err := h.usecase.OTP.GenerateRetryOTP(ctx, retryPayload)
 		if err != nil {
 			helpers.ReportErrorToSentry(err)
-			serverutils.WriteJSONResponse(w, serverutils.ErrorMap(err), http.StatusBadRequest)
+			serverutils.WriteJSONResponse(w, serverutils.ErrorMap(err), otpErrorStatusCode(err))
 			return
 		}
 
@@ -604,6 +635,12 @@ func (h *MyCareHubHandlersInterfacesImpl) GetClientHealthDiaryEntries() http.Han
 			return
 		}
 
+		if err := utils.CheckFacilityAccess(ctx, strconv.Itoa(payload.MFLCode)); err != nil {
+			helpers.ReportErrorToSentry(err)
+			serverutils.WriteJSONResponse(w, serverutils.ErrorMap(err), http.StatusForbidden)
+			return
+		}
+
 		response, err := h.usecase.HealthDiary.GetFacilityHealthDiaryEntries(ctx, *payload)
 		if err != nil {
 			helpers.ReportErrorToSentry(err)
@@ -651,6 +688,12 @@ func (h *MyCareHubHandlersInterfacesImpl) RegisteredFacilityPatients() http.Hand
 			return
 		}
 
+		if err := utils.CheckFacilityAccess(ctx, strconv.Itoa(payload.MFLCode)); err != nil {
+			helpers.ReportErrorToSentry(err)
+			serverutils.WriteJSONResponse(w, serverutils.ErrorMap(err), http.StatusForbidden)
+			return
+		}
+
 		response, err := h.usecase.User.RegisteredFacilityPatients(ctx, *payload)
 		if err != nil {
 			helpers.ReportErrorToSentry(err)
@@ -756,6 +799,12 @@ func (h *MyCareHubHandlersInterfacesImpl) GetServiceRequestsForKenyaEMR(ctx cont
 		return
 	}
 
+	if err := utils.CheckFacilityAccess(ctx, strconv.Itoa(payload.MFLCode)); err != nil {
+		helpers.ReportErrorToSentry(err)
+		serverutils.WriteJSONResponse(w, serverutils.ErrorMap(err), http.StatusForbidden)
+		return
+	}
+
 	serviceRequests, err := h.usecase.ServiceRequest.GetServiceRequestsForKenyaEMR(ctx, payload)
 	if err != nil {
 		helpers.ReportErrorToSentry(err)
@@ -813,6 +862,12 @@ func (h *MyCareHubHandlersInterfacesImpl) CreateOrUpdateKenyaEMRAppointments() h
 			return
 		}
 
+		if err := utils.CheckFacilityAccess(ctx, payload.MFLCode); err != nil {
+			helpers.ReportErrorToSentry(err)
+			serverutils.WriteJSONResponse(w, serverutils.ErrorMap(err), http.StatusForbidden)
+			return
+		}
+
 		response, err := h.usecase.Appointment.CreateOrUpdateKenyaEMRAppointments(ctx, *payload)
 		if err != nil {
 			helpers.ReportErrorToSentry(err)
@@ -884,6 +939,12 @@ func (h *MyCareHubHandlersInterfacesImpl) AddPatientsRecords() http.HandlerFunc
 			return
 		}
 
+		if err := utils.CheckFacilityAccess(r.Context(), payload.MFLCode); err != nil {
+			helpers.ReportErrorToSentry(err)
+			serverutils.WriteJSONResponse(w, serverutils.ErrorMap(err), http.StatusForbidden)
+			return
+		}
+
 		err := h.usecase.Appointment.AddPatientsRecords(r.Context(), *payload)
 		if err != nil {
 			helpers.ReportErrorToSentry(err)
@@ -953,6 +1014,12 @@ func (h *MyCareHubHandlersInterfacesImpl) GetAppointmentServiceRequests(ctx cont
 		return
 	}
 
+	if err := utils.CheckFacilityAccess(ctx, strconv.Itoa(payload.MFLCode)); err != nil {
+		helpers.ReportErrorToSentry(err)
+		serverutils.WriteJSONResponse(w, serverutils.ErrorMap(err), http.StatusForbidden)
+		return
+	}
+
 	response, err := h.usecase.Appointment.GetAppointmentServiceRequests(ctx, *payload)
 	if err != nil {
 		helpers.ReportErrorToSentry(err)
@@ -1133,3 +1200,70 @@ func (h *MyCareHubHandlersInterfacesImpl) ClientSignUp() http.HandlerFunc {
 		serverutils.WriteJSONResponse(w, output, http.StatusOK)
 	}
 }
+
+// OTPDeliveryReceipt is used by the OTP delivery providers e.g SILComms to report whether an OTP message was delivered
+func (h *MyCareHubHandlersInterfacesImpl) OTPDeliveryReceipt() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		payload := &dto.OTPDeliveryReceiptInput{}
+		serverutils.DecodeJSONToTargetStruct(w, r, payload)
+
+		updated, err := h.usecase.OTP.RecordOTPDeliveryReceipt(ctx, payload)
+		if err != nil {
+			serverutils.WriteJSONResponse(w, errorcodeutil.CustomError{
+				Err:     err,
+				Message: err.Error(),
+			}, http.StatusBadRequest)
+			return
+		}
+
+		serverutils.WriteJSONResponse(w, okResp{Status: updated}, http.StatusOK)
+	}
+}
+
+// TwilioOTPDeliveryReceipt receives the status callbacks that Twilio sends for the OTP messages sent through it.
+// Only the final statuses are recorded since Twilio also reports when a message is queued and sent.
+func (h *MyCareHubHandlersInterfacesImpl) TwilioOTPDeliveryReceipt() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		err := r.ParseForm()
+		if err != nil {
+			helpers.ReportErrorToSentry(err)
+			serverutils.WriteJSONResponse(w, errorcodeutil.CustomError{
+				Err:     err,
+				Message: err.Error(),
+			}, http.StatusBadRequest)
+			return
+		}
+
+		status, ok := twilioDeliveryStatus(r.PostForm.Get("MessageStatus"))
+		if !ok {
+			serverutils.WriteJSONResponse(w, okResp{Status: false}, http.StatusOK)
+			return
+		}
+
+		provider := enums.OTPDeliveryProviderTwilio
+		if strings.HasPrefix(r.PostForm.Get("To"), twilioWhatsAppAddressPrefix) {
+			provider = enums.OTPDeliveryProviderWhatsApp
+		}
+
+		payload := &dto.OTPDeliveryReceiptInput{
+			Provider:          provider,
+			ProviderMessageID: r.PostForm.Get("MessageSid"),
+			Status:            status,
+		}
+
+		updated, err := h.usecase.OTP.RecordOTPDeliveryReceipt(ctx, payload)
+		if err != nil {
+			serverutils.WriteJSONResponse(w, errorcodeutil.CustomError{
+				Err:     err,
+				Message: err.Error(),
+			}, http.StatusBadRequest)
+			return
+		}
+
+		serverutils.WriteJSONResponse(w, okResp{Status: updated}, http.StatusOK)
+	}
+}
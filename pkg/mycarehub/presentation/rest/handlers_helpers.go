@@ -1 +1,41 @@
 package rest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
+)
+
+// twilioWhatsAppAddressPrefix is the prefix of the phone numbers in the callbacks of messages sent through WhatsApp
+const twilioWhatsAppAddressPrefix = "whatsapp:"
+
+// otpErrorStatusCode returns the status code for an error returned when sending an OTP.
+// Rate limited requests are rejected with `429 Too Many Requests` so that clients can back off before retrying
+func otpErrorStatusCode(err error) int {
+	customErr := &exceptions.CustomError{}
+	if errors.As(err, &customErr) {
+		switch exceptions.ErrorCode(customErr.Code) {
+		case exceptions.OTPRateLimitedError, exceptions.OTPCooldownError:
+			return http.StatusTooManyRequests
+		}
+	}
+
+	return http.StatusBadRequest
+}
+
+// twilioDeliveryStatus maps the final statuses of a Twilio message to an OTP delivery status.
+// It returns false for the statuses reported while the message is still being sent
+func twilioDeliveryStatus(status string) (enums.OTPDeliveryStatus, bool) {
+	switch status {
+	case "delivered", "read":
+		return enums.OTPDeliveryStatusDelivered, true
+	case "undelivered":
+		return enums.OTPDeliveryStatusUndelivered, true
+	case "failed":
+		return enums.OTPDeliveryStatusFailed, true
+	}
+
+	return "", false
+}
@@ -8,10 +8,14 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/savannahghi/feedlib"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	restMock "github.com/savannahghi/mycarehub/pkg/mycarehub/presentation/rest/mock"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases"
@@ -24,6 +28,7 @@ import (
 	healthdiaryMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/healthdiary/mock"
 	metricsMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/metrics/mock"
 	notificationMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/notification/mock"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/oauth"
 	oauthMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/oauth/mock"
 	organisationMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/organisation/mock"
 	otpMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/otp/mock"
@@ -201,3 +206,433 @@ func TestUnit_GetUserRespondedSecurityQuestions(t *testing.T) {
 	}
 
 }
+
+func TestUnit_LoginByPhone(t *testing.T) {
+	tests := []struct {
+		name               string
+		body               map[string]interface{}
+		wantAccessToken    string
+		expectedStatusCode int
+	}{
+		{
+			name: "Happy case: staff with an authenticator app gets a normal session",
+			body: map[string]interface{}{
+				"username": "test",
+				"pin":      "1234",
+				"flavour":  feedlib.FlavourPro,
+			},
+			wantAccessToken:    "access",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Happy case: staff without a required authenticator app only gets an enrollment session",
+			body: map[string]interface{}{
+				"username": "test",
+				"pin":      "1234",
+				"flavour":  feedlib.FlavourPro,
+			},
+			wantAccessToken:    "enrollment access",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Sad case: failed to generate the enrollment session",
+			body: map[string]interface{}{
+				"username": "test",
+				"pin":      "1234",
+				"flavour":  feedlib.FlavourPro,
+			},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userUsecase := userMock.NewUserUseCaseMock()
+			oauthUsecases := oauthMock.NewOauthUseCaseMock()
+			fakeUsecases := usecases.NewMyCareHubUseCase(
+				userUsecase, termsMock.NewTermsUseCaseMock(), facilityMock.NewFacilityUsecaseMock(),
+				securityquestionsMock.NewSecurityQuestionsUseCaseMock(), otpMock.NewOTPUseCaseMock(), contentMock.NewContentUsecaseMock(),
+				feedbackMock.NewFeedbackUsecaseMock(), healthdiaryMock.NewHealthDiaryUseCaseMock(),
+				servicerequestMock.NewServiceRequestUseCaseMock(), authorityMock.NewAuthorityUseCaseMock(),
+				appointmentMock.NewAppointmentsUseCaseMock(), notificationMock.NewServiceNotificationMock(), surveysMock.NewSurveysMock(),
+				metricsMock.NewMetricsUseCaseMock(), questionnairesMock.NewServiceRequestUseCaseMock(),
+				programsMock.NewProgramsUseCaseMock(),
+				organisationMock.NewOrganisationUseCaseMock(), pubsubMock.NewServicePubSubMock(), communitiesMock.NewCommunityUsecaseMock(), oauthUsecases,
+			)
+
+			if tt.name != "Happy case: staff with an authenticator app gets a normal session" {
+				userUsecase.MockLoginFn = func(ctx context.Context, input *dto.LoginInput) (*dto.LoginResponse, bool) {
+					response := dto.NewLoginResponse()
+					response.SetUserProfile(&dto.User{ID: "123", TOTPEnrollmentRequired: true})
+					return response, true
+				}
+				oauthUsecases.MockGenerateUserAuthTokensFn = func(ctx context.Context, userID string, device dto.SessionDeviceInput) (*oauth.AuthTokens, error) {
+					t.Errorf("expected staff without a required authenticator app not to get a normal session")
+					return nil, fmt.Errorf("unexpected session")
+				}
+			}
+			if tt.name == "Sad case: failed to generate the enrollment session" {
+				oauthUsecases.MockGenerateTOTPEnrollmentAuthTokensFn = func(ctx context.Context, userID string, device dto.SessionDeviceInput) (*oauth.AuthTokens, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			h := &MyCareHubHandlersInterfacesImpl{
+				provider:       restMock.NewFositeOAuth2Mock(),
+				usecase:        *fakeUsecases,
+				sessionManager: restMock.NewSCSSessionManagerMock(),
+			}
+
+			ts := httptest.NewServer(h.LoginByPhone())
+			defer ts.Close()
+
+			body, err := mapToJSONReader(tt.body)
+			if err != nil {
+				t.Errorf("invalid request body: %v", err)
+			}
+
+			resp, err := http.Post(ts.URL+"/login_by_phone", "application/json", body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, but got %d", tt.expectedStatusCode, resp.StatusCode)
+				return
+			}
+
+			if tt.expectedStatusCode != http.StatusOK {
+				return
+			}
+
+			data := dto.LoginResponse{}
+			if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+				t.Errorf("bad data returned: %v", err)
+				return
+			}
+
+			if data.Response.AuthCredentials.IDToken != tt.wantAccessToken {
+				t.Errorf("Expected access token %q, but got %q", tt.wantAccessToken, data.Response.AuthCredentials.IDToken)
+			}
+		})
+	}
+}
+
+func TestUnit_SendOTP(t *testing.T) {
+	tests := []struct {
+		name               string
+		body               map[string]interface{}
+		expectedStatusCode int
+		expectedCode       int
+	}{
+		{
+			name: "Happy case: send OTP",
+			body: map[string]interface{}{
+				"username": "test",
+				"flavour":  feedlib.FlavourConsumer,
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Sad case: rate limited request",
+			body: map[string]interface{}{
+				"username": "test",
+				"flavour":  feedlib.FlavourConsumer,
+			},
+			expectedStatusCode: http.StatusTooManyRequests,
+			expectedCode:       int(exceptions.OTPRateLimitedError),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			otpUsecase := otpMock.NewOTPUseCaseMock()
+			fakeUsecases := usecases.NewMyCareHubUseCase(
+				userMock.NewUserUseCaseMock(), termsMock.NewTermsUseCaseMock(), facilityMock.NewFacilityUsecaseMock(),
+				securityquestionsMock.NewSecurityQuestionsUseCaseMock(), otpUsecase, contentMock.NewContentUsecaseMock(),
+				feedbackMock.NewFeedbackUsecaseMock(), healthdiaryMock.NewHealthDiaryUseCaseMock(),
+				servicerequestMock.NewServiceRequestUseCaseMock(), authorityMock.NewAuthorityUseCaseMock(),
+				appointmentMock.NewAppointmentsUseCaseMock(), notificationMock.NewServiceNotificationMock(), surveysMock.NewSurveysMock(),
+				metricsMock.NewMetricsUseCaseMock(), questionnairesMock.NewServiceRequestUseCaseMock(),
+				programsMock.NewProgramsUseCaseMock(),
+				organisationMock.NewOrganisationUseCaseMock(), pubsubMock.NewServicePubSubMock(), communitiesMock.NewCommunityUsecaseMock(), oauthMock.NewOauthUseCaseMock(),
+			)
+
+			if tt.name == "Sad case: rate limited request" {
+				otpUsecase.MockGenerateAndSendOTPFn = func(ctx context.Context, phoneNumber string, flavour feedlib.Flavour) (*domain.OTPResponse, error) {
+					return nil, exceptions.OTPRateLimitedErr(fmt.Errorf("an error occurred"), time.Minute)
+				}
+			}
+
+			h := &MyCareHubHandlersInterfacesImpl{
+				usecase: *fakeUsecases,
+			}
+
+			ts := httptest.NewServer(h.SendOTP())
+			defer ts.Close()
+
+			body, err := mapToJSONReader(tt.body)
+			if err != nil {
+				t.Errorf("invalid request body: %v", err)
+			}
+
+			resp, err := http.Post(ts.URL+"/send_otp", "application/json", body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, but got %d", tt.expectedStatusCode, resp.StatusCode)
+				return
+			}
+
+			if tt.expectedStatusCode == http.StatusOK {
+				return
+			}
+
+			data := map[string]interface{}{}
+			if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+				t.Errorf("bad data returned: %v", err)
+				return
+			}
+
+			if code, _ := data["code"].(float64); int(code) != tt.expectedCode {
+				t.Errorf("Expected error code %d, but got %v", tt.expectedCode, data["code"])
+			}
+		})
+	}
+}
+
+func TestUnit_RequestPINReset(t *testing.T) {
+	tests := []struct {
+		name               string
+		body               map[string]interface{}
+		expectedStatusCode int
+		expectedCode       int
+	}{
+		{
+			name: "Happy case: request PIN reset",
+			body: map[string]interface{}{
+				"username": "test",
+				"flavour":  feedlib.FlavourConsumer,
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Sad case: request in cooldown",
+			body: map[string]interface{}{
+				"username": "test",
+				"flavour":  feedlib.FlavourConsumer,
+			},
+			expectedStatusCode: http.StatusTooManyRequests,
+			expectedCode:       int(exceptions.OTPCooldownError),
+		},
+		{
+			name: "Sad case: user not found",
+			body: map[string]interface{}{
+				"username": "test",
+				"flavour":  feedlib.FlavourConsumer,
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedCode:       int(exceptions.UserNotFound),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userUsecase := userMock.NewUserUseCaseMock()
+			fakeUsecases := usecases.NewMyCareHubUseCase(
+				userUsecase, termsMock.NewTermsUseCaseMock(), facilityMock.NewFacilityUsecaseMock(),
+				securityquestionsMock.NewSecurityQuestionsUseCaseMock(), otpMock.NewOTPUseCaseMock(), contentMock.NewContentUsecaseMock(),
+				feedbackMock.NewFeedbackUsecaseMock(), healthdiaryMock.NewHealthDiaryUseCaseMock(),
+				servicerequestMock.NewServiceRequestUseCaseMock(), authorityMock.NewAuthorityUseCaseMock(),
+				appointmentMock.NewAppointmentsUseCaseMock(), notificationMock.NewServiceNotificationMock(), surveysMock.NewSurveysMock(),
+				metricsMock.NewMetricsUseCaseMock(), questionnairesMock.NewServiceRequestUseCaseMock(),
+				programsMock.NewProgramsUseCaseMock(),
+				organisationMock.NewOrganisationUseCaseMock(), pubsubMock.NewServicePubSubMock(), communitiesMock.NewCommunityUsecaseMock(), oauthMock.NewOauthUseCaseMock(),
+			)
+
+			if tt.name == "Sad case: request in cooldown" {
+				userUsecase.MockRequestPINResetFn = func(ctx context.Context, phoneNumber string, flavour feedlib.Flavour) (string, error) {
+					return "", exceptions.OTPCooldownErr(fmt.Errorf("an error occurred"), time.Second)
+				}
+			}
+			if tt.name == "Sad case: user not found" {
+				userUsecase.MockRequestPINResetFn = func(ctx context.Context, phoneNumber string, flavour feedlib.Flavour) (string, error) {
+					return "", exceptions.UserNotFoundError(fmt.Errorf("an error occurred"))
+				}
+			}
+
+			h := &MyCareHubHandlersInterfacesImpl{
+				usecase: *fakeUsecases,
+			}
+
+			ts := httptest.NewServer(h.RequestPINReset())
+			defer ts.Close()
+
+			body, err := mapToJSONReader(tt.body)
+			if err != nil {
+				t.Errorf("invalid request body: %v", err)
+			}
+
+			resp, err := http.Post(ts.URL+"/request_pin_reset", "application/json", body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, but got %d", tt.expectedStatusCode, resp.StatusCode)
+				return
+			}
+
+			if tt.expectedStatusCode == http.StatusOK {
+				return
+			}
+
+			data := map[string]interface{}{}
+			if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+				t.Errorf("bad data returned: %v", err)
+				return
+			}
+
+			if code, _ := data["code"].(float64); int(code) != tt.expectedCode {
+				t.Errorf("Expected error code %d, but got %v", tt.expectedCode, data["code"])
+			}
+		})
+	}
+}
+
+func TestUnit_otpErrorStatusCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{
+			name: "Happy case: rate limited request",
+			err:  exceptions.OTPRateLimitedErr(fmt.Errorf("an error occurred"), time.Minute),
+			want: http.StatusTooManyRequests,
+		},
+		{
+			name: "Happy case: wrapped cooldown error",
+			err:  fmt.Errorf("failed to generate and send OTP: %w", exceptions.OTPCooldownErr(fmt.Errorf("an error occurred"), time.Second)),
+			want: http.StatusTooManyRequests,
+		},
+		{
+			name: "Happy case: other custom error",
+			err:  exceptions.UserNotFoundError(fmt.Errorf("an error occurred")),
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "Happy case: plain error",
+			err:  fmt.Errorf("an error occurred"),
+			want: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := otpErrorStatusCode(tt.err); got != tt.want {
+				t.Errorf("otpErrorStatusCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnit_TwilioOTPDeliveryReceipt(t *testing.T) {
+	tests := []struct {
+		name               string
+		form               url.Values
+		wantProvider       enums.OTPDeliveryProvider
+		wantStatus         enums.OTPDeliveryStatus
+		expectedStatusCode int
+	}{
+		{
+			name: "Happy case: delivered sms",
+			form: url.Values{
+				"MessageSid":    []string{"SM123"},
+				"MessageStatus": []string{"delivered"},
+				"To":            []string{"+254700000000"},
+			},
+			wantProvider:       enums.OTPDeliveryProviderTwilio,
+			wantStatus:         enums.OTPDeliveryStatusDelivered,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Happy case: undelivered whatsapp message",
+			form: url.Values{
+				"MessageSid":    []string{"SM123"},
+				"MessageStatus": []string{"undelivered"},
+				"To":            []string{"whatsapp:+254700000000"},
+			},
+			wantProvider:       enums.OTPDeliveryProviderWhatsApp,
+			wantStatus:         enums.OTPDeliveryStatusUndelivered,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Happy case: intermediate status is ignored",
+			form: url.Values{
+				"MessageSid":    []string{"SM123"},
+				"MessageStatus": []string{"queued"},
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Sad case: failed to record delivery receipt",
+			form: url.Values{
+				"MessageSid":    []string{"SM123"},
+				"MessageStatus": []string{"failed"},
+			},
+			wantProvider:       enums.OTPDeliveryProviderTwilio,
+			wantStatus:         enums.OTPDeliveryStatusFailed,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			otpUseCase := otpMock.NewOTPUseCaseMock()
+			fakeUsecases := usecases.NewMyCareHubUseCase(
+				userMock.NewUserUseCaseMock(), termsMock.NewTermsUseCaseMock(), facilityMock.NewFacilityUsecaseMock(),
+				securityquestionsMock.NewSecurityQuestionsUseCaseMock(), otpUseCase, contentMock.NewContentUsecaseMock(), feedbackMock.NewFeedbackUsecaseMock(), healthdiaryMock.NewHealthDiaryUseCaseMock(),
+				servicerequestMock.NewServiceRequestUseCaseMock(), authorityMock.NewAuthorityUseCaseMock(),
+				appointmentMock.NewAppointmentsUseCaseMock(), notificationMock.NewServiceNotificationMock(), surveysMock.NewSurveysMock(), metricsMock.NewMetricsUseCaseMock(), questionnairesMock.NewServiceRequestUseCaseMock(),
+				programsMock.NewProgramsUseCaseMock(),
+				organisationMock.NewOrganisationUseCaseMock(), pubsubMock.NewServicePubSubMock(), communitiesMock.NewCommunityUsecaseMock(), oauthMock.NewOauthUseCaseMock(),
+			)
+
+			called := false
+			otpUseCase.MockRecordOTPDeliveryReceiptFn = func(ctx context.Context, input *dto.OTPDeliveryReceiptInput) (bool, error) {
+				called = true
+				if input.Provider != tt.wantProvider || input.Status != tt.wantStatus || input.ProviderMessageID != "SM123" {
+					t.Errorf("unexpected delivery receipt %+v", input)
+				}
+				if tt.name == "Sad case: failed to record delivery receipt" {
+					return false, fmt.Errorf("an error occurred")
+				}
+				return true, nil
+			}
+
+			h := &MyCareHubHandlersInterfacesImpl{
+				provider:       restMock.NewFositeOAuth2Mock(),
+				usecase:        *fakeUsecases,
+				sessionManager: restMock.NewSCSSessionManagerMock(),
+			}
+
+			ts := httptest.NewServer(h.TwilioOTPDeliveryReceipt())
+			defer ts.Close()
+
+			resp, err := http.PostForm(ts.URL, tt.form)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.expectedStatusCode {
+				t.Errorf("expected status %d, got %d", tt.expectedStatusCode, resp.StatusCode)
+			}
+
+			if called != (tt.wantStatus != "") {
+				t.Errorf("expected the delivery receipt to be recorded: %v, got %v", tt.wantStatus != "", called)
+			}
+		})
+	}
+}
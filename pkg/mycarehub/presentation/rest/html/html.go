@@ -20,6 +20,9 @@ type LoginParams struct {
 	Title        string
 	HasError     bool
 	ErrorMessage string
+	Username     string
+	RequireTOTP  bool
+	RequireOTP   bool
 }
 
 func ServeLoginPage(w io.Writer, p LoginParams) error {
@@ -3,18 +3,27 @@ package rest
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"firebase.google.com/go/auth"
+	"github.com/gorilla/mux"
 	"github.com/hashicorp/go-multierror"
 	"github.com/ory/fosite"
+	"github.com/savannahghi/errorcodeutil"
 	"github.com/savannahghi/feedlib"
 	"github.com/savannahghi/firebasetools"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/utils"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/presentation/rest/html"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/oauth"
+	"github.com/savannahghi/serverutils"
 )
 
 // AuthorizationSession is an object that represents a session that helps in decision making when logging in with OAuth
@@ -25,6 +34,9 @@ type AuthorizationSession struct {
 	Facility       domain.Facility
 	QueryParams    url.Values
 	LoggedInUserID string
+
+	// TOTPEnrollmentRequired is set when the staff has to enroll the authenticator app that their organisation requires
+	TOTPEnrollmentRequired bool
 }
 
 // putSession is a helper function that saves data in a session
@@ -51,9 +63,12 @@ func (h *MyCareHubHandlersInterfacesImpl) handleLoginPage(w http.ResponseWriter,
 	ctx := r.Context()
 
 	loginInput := &dto.LoginInput{
-		Username: r.FormValue("username"),
-		PIN:      r.FormValue("pin"),
-		Flavour:  feedlib.FlavourPro,
+		Username:  r.FormValue("username"),
+		PIN:       r.FormValue("pin"),
+		TOTPCode:  r.FormValue("totp_code"),
+		OTP:       r.FormValue("otp"),
+		Flavour:   feedlib.FlavourPro,
+		UserAgent: r.UserAgent(),
 	}
 	if loginInput.PIN == "" || loginInput.Username == "" {
 		w.Header().Set("Content-Type", "text/html; charset=utf-8")
@@ -70,7 +85,7 @@ func (h *MyCareHubHandlersInterfacesImpl) handleLoginPage(w http.ResponseWriter,
 		return
 	}
 
-	loginResponse, successful := h.usecase.User.Login(context.Background(), loginInput)
+	loginResponse, successful := h.usecase.User.Login(ctx, loginInput)
 	if !successful {
 		w.Header().Set("Content-Type", "text/html; charset=utf-8")
 
@@ -91,6 +106,18 @@ func (h *MyCareHubHandlersInterfacesImpl) handleLoginPage(w http.ResponseWriter,
 			HasError:     true,
 			ErrorMessage: errorMessage,
 		}
+
+		// staff with an authenticator app are asked for a code from the app once their PIN is verified
+		switch loginResponse.Code {
+		case int(exceptions.TOTPRequiredError), int(exceptions.TOTPMismatchError):
+			params.RequireTOTP = true
+			params.Username = loginInput.Username
+		// staff logging in from a new device or location are asked for the OTP sent to their phone
+		case int(exceptions.LoginOTPRequiredError), int(exceptions.LoginOTPMismatchError):
+			params.RequireOTP = true
+			params.Username = loginInput.Username
+		}
+
 		err := html.ServeLoginPage(w, params)
 		if err != nil {
 			h.handleWriteAuthorizeError(w, r, ar, err)
@@ -111,6 +138,7 @@ func (h *MyCareHubHandlersInterfacesImpl) handleLoginPage(w http.ResponseWriter,
 	authorizationSession.User = *user
 
 	authorizationSession.LoggedInUserID = loginResponse.Response.User.ID
+	authorizationSession.TOTPEnrollmentRequired = loginResponse.Response.User.TOTPEnrollmentRequired
 
 	authorizationSession.Page = "chooseProgram"
 
@@ -363,10 +391,12 @@ func (h *MyCareHubHandlersInterfacesImpl) AuthorizeHandler() http.HandlerFunc {
 			"user_id":               user.ID,
 			"organisation_id":       program.Organisation.ID,
 			"program_id":            program.ID,
+			"program_name":          program.Name,
 			"gender":                user.Gender,
 			"is_superuser":          user.IsSuperuser,
 			"staff_id":              *staffProfile.ID,
 			"facility_id":           *facility.ID,
+			"facility_name":         facility.Name,
 			"is_organisation_admin": false,
 			"is_program_admin":      false,
 		}
@@ -375,6 +405,11 @@ func (h *MyCareHubHandlersInterfacesImpl) AuthorizeHandler() http.HandlerFunc {
 			extraDetails["email"] = *user.Email
 		}
 
+		// the session can only be used to enroll the authenticator app that the staff's organisation requires
+		if authorizationSession.TOTPEnrollmentRequired {
+			extraDetails[domain.TOTPEnrollmentClaim] = true
+		}
+
 		session := domain.NewSession(
 			ctx,
 			client.GetID(),
@@ -383,6 +418,15 @@ func (h *MyCareHubHandlersInterfacesImpl) AuthorizeHandler() http.HandlerFunc {
 			user.Name,
 			extraDetails,
 		)
+		session.UserAgent = r.UserAgent()
+		session.IPAddress = utils.GetClientIP(r)
+		// the user has just logged in so this is the authentication time of the ID tokens issued for the session
+		session.CreatedAt = time.Now()
+
+		// the requested scopes have been checked against the client's scopes when creating the authorize request
+		for _, scope := range ar.GetRequestedScopes() {
+			ar.GrantScope(scope)
+		}
 
 		response, err := h.provider.NewAuthorizeResponse(ctx, ar, session)
 		if err != nil {
@@ -420,6 +464,11 @@ func (h *MyCareHubHandlersInterfacesImpl) TokenHandler() http.HandlerFunc {
 
 			session.ClientID = client.GetID()
 			ar.SetSession(session)
+
+			// the requested scopes have already been checked against the scopes that the client is allowed to request
+			for _, scope := range ar.GetRequestedScopes() {
+				ar.GrantScope(scope)
+			}
 		}
 
 		response, err := h.provider.NewAccessResponse(ctx, ar)
@@ -462,3 +511,149 @@ func (h *MyCareHubHandlersInterfacesImpl) IntrospectionHandler() http.HandlerFun
 		h.provider.WriteIntrospectionResponse(ctx, w, ir)
 	}
 }
+
+// OpenIDConfigurationHandler returns the OpenID Connect discovery document
+func (h *MyCareHubHandlersInterfacesImpl) OpenIDConfigurationHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		serverutils.WriteJSONResponse(w, h.usecase.Oauth.OpenIDConfiguration(ctx), http.StatusOK)
+	}
+}
+
+// JSONWebKeySetHandler returns the public keys that ID tokens can be verified with
+func (h *MyCareHubHandlersInterfacesImpl) JSONWebKeySetHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		keySet, err := h.usecase.Oauth.JSONWebKeySet(ctx)
+		if err != nil {
+			helpers.ReportErrorToSentry(err)
+			serverutils.WriteJSONResponse(w, errorcodeutil.CustomError{
+				Err:     err,
+				Message: err.Error(),
+			}, http.StatusInternalServerError)
+			return
+		}
+
+		serverutils.WriteJSONResponse(w, keySet, http.StatusOK)
+	}
+}
+
+// UserInfoHandler returns the claims about the user that the bearer access token was issued for
+func (h *MyCareHubHandlersInterfacesImpl) UserInfoHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		claims, err := h.usecase.Oauth.UserInfo(ctx, fosite.AccessTokenFromRequest(r))
+		if err != nil {
+			rfcErr := fosite.ErrorToRFC6749Error(err)
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer error="invalid_token",error_description=%q`, rfcErr.GetDescription()))
+			serverutils.WriteJSONResponse(w, rfcErr, http.StatusUnauthorized)
+			return
+		}
+
+		serverutils.WriteJSONResponse(w, claims, http.StatusOK)
+	}
+}
+
+// writeClientRegistrationError writes an error of the dynamic client registration endpoints in the format of RFC 7591
+func writeClientRegistrationError(w http.ResponseWriter, err error) {
+	rfcErr := fosite.ErrorToRFC6749Error(err)
+
+	switch rfcErr.CodeField {
+	case http.StatusUnauthorized:
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer error="invalid_token",error_description=%q`, rfcErr.GetDescription()))
+	case http.StatusInternalServerError:
+		helpers.ReportErrorToSentry(err)
+	}
+
+	serverutils.WriteJSONResponse(w, rfcErr, rfcErr.CodeField)
+}
+
+// decodeClientRegistration reads the client metadata sent to the dynamic client registration endpoints
+func decodeClientRegistration(r *http.Request) (dto.OauthClientRegistrationInput, error) {
+	var input dto.OauthClientRegistrationInput
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		return input, oauth.ErrInvalidClientMetadata.WithHint("The client metadata should be a JSON object.")
+	}
+
+	return input, nil
+}
+
+// RegisterOauthClientHandler registers a client using the dynamic client registration protocol (RFC 7591).
+// The request is authorized with the initial access token that is given to integrators
+func (h *MyCareHubHandlersInterfacesImpl) RegisterOauthClientHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		w.Header().Set("Cache-Control", "no-store")
+
+		input, err := decodeClientRegistration(r)
+		if err != nil {
+			writeClientRegistrationError(w, err)
+			return
+		}
+
+		registration, err := h.usecase.Oauth.RegisterOauthClient(ctx, fosite.AccessTokenFromRequest(r), input)
+		if err != nil {
+			writeClientRegistrationError(w, err)
+			return
+		}
+
+		serverutils.WriteJSONResponse(w, registration, http.StatusCreated)
+	}
+}
+
+// GetOauthClientRegistrationHandler returns the registration of a dynamically registered client (RFC 7592)
+func (h *MyCareHubHandlersInterfacesImpl) GetOauthClientRegistrationHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		w.Header().Set("Cache-Control", "no-store")
+
+		registration, err := h.usecase.Oauth.GetOauthClientRegistration(ctx, mux.Vars(r)["clientID"], fosite.AccessTokenFromRequest(r))
+		if err != nil {
+			writeClientRegistrationError(w, err)
+			return
+		}
+
+		serverutils.WriteJSONResponse(w, registration, http.StatusOK)
+	}
+}
+
+// UpdateOauthClientRegistrationHandler replaces the metadata of a dynamically registered client (RFC 7592)
+func (h *MyCareHubHandlersInterfacesImpl) UpdateOauthClientRegistrationHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		w.Header().Set("Cache-Control", "no-store")
+
+		input, err := decodeClientRegistration(r)
+		if err != nil {
+			writeClientRegistrationError(w, err)
+			return
+		}
+
+		registration, err := h.usecase.Oauth.UpdateOauthClientRegistration(ctx, mux.Vars(r)["clientID"], fosite.AccessTokenFromRequest(r), input)
+		if err != nil {
+			writeClientRegistrationError(w, err)
+			return
+		}
+
+		serverutils.WriteJSONResponse(w, registration, http.StatusOK)
+	}
+}
+
+// DeleteOauthClientRegistrationHandler deactivates a dynamically registered client (RFC 7592)
+func (h *MyCareHubHandlersInterfacesImpl) DeleteOauthClientRegistrationHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		err := h.usecase.Oauth.DeleteOauthClientRegistration(ctx, mux.Vars(r)["clientID"], fosite.AccessTokenFromRequest(r))
+		if err != nil {
+			writeClientRegistrationError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -13,9 +13,11 @@ import (
 	"testing"
 
 	"github.com/brianvoe/gofakeit"
+	"github.com/gorilla/mux"
 	"github.com/ory/fosite"
 	"github.com/savannahghi/feedlib"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	restMock "github.com/savannahghi/mycarehub/pkg/mycarehub/presentation/rest/mock"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases"
@@ -28,6 +30,7 @@ import (
 	healthdiaryMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/healthdiary/mock"
 	metricsMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/metrics/mock"
 	notificationMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/notification/mock"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/oauth"
 	oauthMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/oauth/mock"
 	organisationMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/organisation/mock"
 	otpMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/otp/mock"
@@ -39,6 +42,7 @@ import (
 	surveysMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/surveys/mock"
 	termsMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/terms/mock"
 	userMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/user/mock"
+	jose "gopkg.in/square/go-jose.v2"
 )
 
 func TestMyCareHubHandlersInterfacesImpl_handleLoginPage(t *testing.T) {
@@ -83,6 +87,46 @@ func TestMyCareHubHandlersInterfacesImpl_handleLoginPage(t *testing.T) {
 			expectedPageTitle:        "Login",
 			expectedAvailableProgram: nil,
 		},
+		{
+			name:                     "Sad case: authenticator code required",
+			ctx:                      context.Background(),
+			method:                   "POST",
+			url:                      "/login",
+			formValues:               url.Values{"username": {"test"}, "pin": {"1234"}},
+			expectedStatusCode:       http.StatusOK,
+			expectedPageTitle:        "Authenticator Code",
+			expectedAvailableProgram: nil,
+		},
+		{
+			name:                     "Sad case: invalid authenticator code",
+			ctx:                      context.Background(),
+			method:                   "POST",
+			url:                      "/login",
+			formValues:               url.Values{"username": {"test"}, "pin": {"1234"}, "totp_code": {"000000"}},
+			expectedStatusCode:       http.StatusOK,
+			expectedPageTitle:        "Authenticator Code",
+			expectedAvailableProgram: nil,
+		},
+		{
+			name:                     "Sad case: verification code required",
+			ctx:                      context.Background(),
+			method:                   "POST",
+			url:                      "/login",
+			formValues:               url.Values{"username": {"test"}, "pin": {"1234"}},
+			expectedStatusCode:       http.StatusOK,
+			expectedPageTitle:        "Verification Code",
+			expectedAvailableProgram: nil,
+		},
+		{
+			name:                     "Sad case: invalid verification code",
+			ctx:                      context.Background(),
+			method:                   "POST",
+			url:                      "/login",
+			formValues:               url.Values{"username": {"test"}, "pin": {"1234"}, "otp": {"000000"}},
+			expectedStatusCode:       http.StatusOK,
+			expectedPageTitle:        "Verification Code",
+			expectedAvailableProgram: nil,
+		},
 
 		{
 			name:                     "Sad case: failed to get user profile",
@@ -158,6 +202,38 @@ func TestMyCareHubHandlersInterfacesImpl_handleLoginPage(t *testing.T) {
 					}
 				}
 
+				if tt.name == "Sad case: authenticator code required" {
+					userUsecase.MockLoginFn = func(ctx context.Context, input *dto.LoginInput) (*dto.LoginResponse, bool) {
+						response := dto.NewLoginResponse()
+						response.SetResponseCode(int(exceptions.TOTPRequiredError), exceptions.TOTPRequiredErr().Error())
+						return response, false
+					}
+				}
+
+				if tt.name == "Sad case: invalid authenticator code" {
+					userUsecase.MockLoginFn = func(ctx context.Context, input *dto.LoginInput) (*dto.LoginResponse, bool) {
+						response := dto.NewLoginResponse()
+						response.SetResponseCode(int(exceptions.TOTPMismatchError), exceptions.TOTPMismatchErr().Error())
+						return response, false
+					}
+				}
+
+				if tt.name == "Sad case: verification code required" {
+					userUsecase.MockLoginFn = func(ctx context.Context, input *dto.LoginInput) (*dto.LoginResponse, bool) {
+						response := dto.NewLoginResponse()
+						response.SetResponseCode(int(exceptions.LoginOTPRequiredError), exceptions.LoginOTPRequiredErr().Error())
+						return response, false
+					}
+				}
+
+				if tt.name == "Sad case: invalid verification code" {
+					userUsecase.MockLoginFn = func(ctx context.Context, input *dto.LoginInput) (*dto.LoginResponse, bool) {
+						response := dto.NewLoginResponse()
+						response.SetResponseCode(int(exceptions.LoginOTPMismatchError), exceptions.LoginOTPMismatchErr().Error())
+						return response, false
+					}
+				}
+
 				if tt.name == "Sad case: failed to get user profile" {
 					userUsecase.MockGetUserProfileFn = func(ctx context.Context, userID string) (*domain.User, error) {
 						return nil, fmt.Errorf("an error occurred")
@@ -1049,3 +1125,418 @@ func TestMyCareHubHandlersInterfacesImpl_IntrospectionHandler(t *testing.T) {
 	}
 
 }
+
+func TestMyCareHubHandlersInterfacesImpl_OpenIDConfigurationHandler(t *testing.T) {
+	tests := []struct {
+		name               string
+		method             string
+		url                string
+		expectedStatusCode int
+	}{
+		{
+			name:               "Happy case: get openid configuration",
+			method:             "GET",
+			url:                "/.well-known/openid-configuration",
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			facilityUseCase := facilityMock.NewFacilityUsecaseMock()
+			notificationUseCase := notificationMock.NewServiceNotificationMock()
+			authorityUseCase := authorityMock.NewAuthorityUseCaseMock()
+			userUsecase := userMock.NewUserUseCaseMock()
+			termsUsecase := termsMock.NewTermsUseCaseMock()
+			securityQuestionsUsecase := securityquestionsMock.NewSecurityQuestionsUseCaseMock()
+			contentUseCase := contentMock.NewContentUsecaseMock()
+			feedbackUsecase := feedbackMock.NewFeedbackUsecaseMock()
+			serviceRequestUseCase := servicerequestMock.NewServiceRequestUseCaseMock()
+			appointmentUsecase := appointmentMock.NewAppointmentsUseCaseMock()
+			healthDiaryUseCase := healthdiaryMock.NewHealthDiaryUseCaseMock()
+			surveysUsecase := surveysMock.NewSurveysMock()
+			metricsUsecase := metricsMock.NewMetricsUseCaseMock()
+			questionnaireUsecase := questionnairesMock.NewServiceRequestUseCaseMock()
+			programsUsecase := programsMock.NewProgramsUseCaseMock()
+			organisationUsecase := organisationMock.NewOrganisationUseCaseMock()
+			otpUseCase := otpMock.NewOTPUseCaseMock()
+			pubSubUseCase := pubsubMock.NewServicePubSubMock()
+			communityUsecase := communitiesMock.NewCommunityUsecaseMock()
+			oauthUsecases := oauthMock.NewOauthUseCaseMock()
+			fakeUsecases := usecases.NewMyCareHubUseCase(
+				userUsecase, termsUsecase, facilityUseCase,
+				securityQuestionsUsecase, otpUseCase, contentUseCase, feedbackUsecase, healthDiaryUseCase,
+				serviceRequestUseCase, authorityUseCase,
+				appointmentUsecase, notificationUseCase, surveysUsecase, metricsUsecase, questionnaireUsecase,
+				programsUsecase,
+				organisationUsecase, pubSubUseCase, communityUsecase, oauthUsecases,
+			)
+			sessionManager := restMock.NewSCSSessionManagerMock()
+			provider := restMock.NewFositeOAuth2Mock()
+
+			// create a test server
+			h := &MyCareHubHandlersInterfacesImpl{
+				provider:       provider,
+				usecase:        *fakeUsecases,
+				sessionManager: sessionManager,
+			}
+
+			ts := httptest.NewServer(h.OpenIDConfigurationHandler())
+			defer ts.Close()
+
+			// create the request
+			req, err := http.NewRequest(tt.method, ts.URL+tt.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// make the request
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			// check the response
+			if resp.StatusCode != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, but got %d", tt.expectedStatusCode, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestMyCareHubHandlersInterfacesImpl_JSONWebKeySetHandler(t *testing.T) {
+	tests := []struct {
+		name               string
+		method             string
+		url                string
+		expectedStatusCode int
+	}{
+		{
+			name:               "Happy case: get json web key set",
+			method:             "GET",
+			url:                "/.well-known/jwks.json",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Sad case: failed to get json web key set",
+			method:             "GET",
+			url:                "/.well-known/jwks.json",
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			facilityUseCase := facilityMock.NewFacilityUsecaseMock()
+			notificationUseCase := notificationMock.NewServiceNotificationMock()
+			authorityUseCase := authorityMock.NewAuthorityUseCaseMock()
+			userUsecase := userMock.NewUserUseCaseMock()
+			termsUsecase := termsMock.NewTermsUseCaseMock()
+			securityQuestionsUsecase := securityquestionsMock.NewSecurityQuestionsUseCaseMock()
+			contentUseCase := contentMock.NewContentUsecaseMock()
+			feedbackUsecase := feedbackMock.NewFeedbackUsecaseMock()
+			serviceRequestUseCase := servicerequestMock.NewServiceRequestUseCaseMock()
+			appointmentUsecase := appointmentMock.NewAppointmentsUseCaseMock()
+			healthDiaryUseCase := healthdiaryMock.NewHealthDiaryUseCaseMock()
+			surveysUsecase := surveysMock.NewSurveysMock()
+			metricsUsecase := metricsMock.NewMetricsUseCaseMock()
+			questionnaireUsecase := questionnairesMock.NewServiceRequestUseCaseMock()
+			programsUsecase := programsMock.NewProgramsUseCaseMock()
+			organisationUsecase := organisationMock.NewOrganisationUseCaseMock()
+			otpUseCase := otpMock.NewOTPUseCaseMock()
+			pubSubUseCase := pubsubMock.NewServicePubSubMock()
+			communityUsecase := communitiesMock.NewCommunityUsecaseMock()
+			oauthUsecases := oauthMock.NewOauthUseCaseMock()
+			fakeUsecases := usecases.NewMyCareHubUseCase(
+				userUsecase, termsUsecase, facilityUseCase,
+				securityQuestionsUsecase, otpUseCase, contentUseCase, feedbackUsecase, healthDiaryUseCase,
+				serviceRequestUseCase, authorityUseCase,
+				appointmentUsecase, notificationUseCase, surveysUsecase, metricsUsecase, questionnaireUsecase,
+				programsUsecase,
+				organisationUsecase, pubSubUseCase, communityUsecase, oauthUsecases,
+			)
+			sessionManager := restMock.NewSCSSessionManagerMock()
+			provider := restMock.NewFositeOAuth2Mock()
+
+			if tt.name == "Sad case: failed to get json web key set" {
+				oauthUsecases.MockJSONWebKeySetFn = func(ctx context.Context) (*jose.JSONWebKeySet, error) {
+					return nil, errors.New("an error occurred")
+				}
+			}
+
+			// create a test server
+			h := &MyCareHubHandlersInterfacesImpl{
+				provider:       provider,
+				usecase:        *fakeUsecases,
+				sessionManager: sessionManager,
+			}
+
+			ts := httptest.NewServer(h.JSONWebKeySetHandler())
+			defer ts.Close()
+
+			// create the request
+			req, err := http.NewRequest(tt.method, ts.URL+tt.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// make the request
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			// check the response
+			if resp.StatusCode != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, but got %d", tt.expectedStatusCode, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestMyCareHubHandlersInterfacesImpl_UserInfoHandler(t *testing.T) {
+	tests := []struct {
+		name               string
+		method             string
+		url                string
+		expectedStatusCode int
+	}{
+		{
+			name:               "Happy case: get user info",
+			method:             "GET",
+			url:                "/oauth/userinfo",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Sad case: invalid access token",
+			method:             "GET",
+			url:                "/oauth/userinfo",
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			facilityUseCase := facilityMock.NewFacilityUsecaseMock()
+			notificationUseCase := notificationMock.NewServiceNotificationMock()
+			authorityUseCase := authorityMock.NewAuthorityUseCaseMock()
+			userUsecase := userMock.NewUserUseCaseMock()
+			termsUsecase := termsMock.NewTermsUseCaseMock()
+			securityQuestionsUsecase := securityquestionsMock.NewSecurityQuestionsUseCaseMock()
+			contentUseCase := contentMock.NewContentUsecaseMock()
+			feedbackUsecase := feedbackMock.NewFeedbackUsecaseMock()
+			serviceRequestUseCase := servicerequestMock.NewServiceRequestUseCaseMock()
+			appointmentUsecase := appointmentMock.NewAppointmentsUseCaseMock()
+			healthDiaryUseCase := healthdiaryMock.NewHealthDiaryUseCaseMock()
+			surveysUsecase := surveysMock.NewSurveysMock()
+			metricsUsecase := metricsMock.NewMetricsUseCaseMock()
+			questionnaireUsecase := questionnairesMock.NewServiceRequestUseCaseMock()
+			programsUsecase := programsMock.NewProgramsUseCaseMock()
+			organisationUsecase := organisationMock.NewOrganisationUseCaseMock()
+			otpUseCase := otpMock.NewOTPUseCaseMock()
+			pubSubUseCase := pubsubMock.NewServicePubSubMock()
+			communityUsecase := communitiesMock.NewCommunityUsecaseMock()
+			oauthUsecases := oauthMock.NewOauthUseCaseMock()
+			fakeUsecases := usecases.NewMyCareHubUseCase(
+				userUsecase, termsUsecase, facilityUseCase,
+				securityQuestionsUsecase, otpUseCase, contentUseCase, feedbackUsecase, healthDiaryUseCase,
+				serviceRequestUseCase, authorityUseCase,
+				appointmentUsecase, notificationUseCase, surveysUsecase, metricsUsecase, questionnaireUsecase,
+				programsUsecase,
+				organisationUsecase, pubSubUseCase, communityUsecase, oauthUsecases,
+			)
+			sessionManager := restMock.NewSCSSessionManagerMock()
+			provider := restMock.NewFositeOAuth2Mock()
+
+			if tt.name == "Sad case: invalid access token" {
+				oauthUsecases.MockUserInfoFn = func(ctx context.Context, accessToken string) (map[string]interface{}, error) {
+					return nil, fosite.ErrInactiveToken
+				}
+			}
+
+			// create a test server
+			h := &MyCareHubHandlersInterfacesImpl{
+				provider:       provider,
+				usecase:        *fakeUsecases,
+				sessionManager: sessionManager,
+			}
+
+			ts := httptest.NewServer(h.UserInfoHandler())
+			defer ts.Close()
+
+			// create the request
+			req, err := http.NewRequest(tt.method, ts.URL+tt.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Authorization", "Bearer token")
+
+			// make the request
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			// check the response
+			if resp.StatusCode != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, but got %d", tt.expectedStatusCode, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestMyCareHubHandlersInterfacesImpl_ClientRegistration(t *testing.T) {
+	clientID := gofakeit.UUID()
+	registration := `{"client_name": "Mobile App", "redirect_uris": ["com.example.app:/callback"], "token_endpoint_auth_method": "none", "scope": "openid"}`
+
+	tests := []struct {
+		name               string
+		method             string
+		url                string
+		body               string
+		expectedStatusCode int
+	}{
+		{
+			name:               "Happy case: register client",
+			method:             http.MethodPost,
+			url:                "/oauth/register",
+			body:               registration,
+			expectedStatusCode: http.StatusCreated,
+		},
+		{
+			name:               "Sad case: register client with invalid metadata",
+			method:             http.MethodPost,
+			url:                "/oauth/register",
+			body:               `["not", "an", "object"]`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Sad case: register client with invalid initial access token",
+			method:             http.MethodPost,
+			url:                "/oauth/register",
+			body:               registration,
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "Happy case: get client registration",
+			method:             http.MethodGet,
+			url:                "/oauth/register/" + clientID,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Happy case: update client registration",
+			method:             http.MethodPut,
+			url:                "/oauth/register/" + clientID,
+			body:               registration,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "Sad case: update client registration with an invalid redirect URI",
+			method:             http.MethodPut,
+			url:                "/oauth/register/" + clientID,
+			body:               registration,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Happy case: delete client registration",
+			method:             http.MethodDelete,
+			url:                "/oauth/register/" + clientID,
+			expectedStatusCode: http.StatusNoContent,
+		},
+		{
+			name:               "Sad case: failed to delete client registration",
+			method:             http.MethodDelete,
+			url:                "/oauth/register/" + clientID,
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			facilityUseCase := facilityMock.NewFacilityUsecaseMock()
+			notificationUseCase := notificationMock.NewServiceNotificationMock()
+			authorityUseCase := authorityMock.NewAuthorityUseCaseMock()
+			userUsecase := userMock.NewUserUseCaseMock()
+			termsUsecase := termsMock.NewTermsUseCaseMock()
+			securityQuestionsUsecase := securityquestionsMock.NewSecurityQuestionsUseCaseMock()
+			contentUseCase := contentMock.NewContentUsecaseMock()
+			feedbackUsecase := feedbackMock.NewFeedbackUsecaseMock()
+			serviceRequestUseCase := servicerequestMock.NewServiceRequestUseCaseMock()
+			appointmentUsecase := appointmentMock.NewAppointmentsUseCaseMock()
+			healthDiaryUseCase := healthdiaryMock.NewHealthDiaryUseCaseMock()
+			surveysUsecase := surveysMock.NewSurveysMock()
+			metricsUsecase := metricsMock.NewMetricsUseCaseMock()
+			questionnaireUsecase := questionnairesMock.NewServiceRequestUseCaseMock()
+			programsUsecase := programsMock.NewProgramsUseCaseMock()
+			organisationUsecase := organisationMock.NewOrganisationUseCaseMock()
+			otpUseCase := otpMock.NewOTPUseCaseMock()
+			pubSubUseCase := pubsubMock.NewServicePubSubMock()
+			communityUsecase := communitiesMock.NewCommunityUsecaseMock()
+			oauthUsecases := oauthMock.NewOauthUseCaseMock()
+			fakeUsecases := usecases.NewMyCareHubUseCase(
+				userUsecase, termsUsecase, facilityUseCase,
+				securityQuestionsUsecase, otpUseCase, contentUseCase, feedbackUsecase, healthDiaryUseCase,
+				serviceRequestUseCase, authorityUseCase,
+				appointmentUsecase, notificationUseCase, surveysUsecase, metricsUsecase, questionnaireUsecase,
+				programsUsecase,
+				organisationUsecase, pubSubUseCase, communityUsecase, oauthUsecases,
+			)
+			sessionManager := restMock.NewSCSSessionManagerMock()
+			provider := restMock.NewFositeOAuth2Mock()
+
+			if tt.name == "Sad case: register client with invalid initial access token" {
+				oauthUsecases.MockRegisterOauthClientFn = func(ctx context.Context, accessToken string, input dto.OauthClientRegistrationInput) (*dto.OauthClientRegistrationOutput, error) {
+					return nil, oauth.ErrInvalidRegistrationToken
+				}
+			}
+
+			if tt.name == "Sad case: update client registration with an invalid redirect URI" {
+				oauthUsecases.MockUpdateOauthClientRegistrationFn = func(ctx context.Context, clientID string, registrationAccessToken string, input dto.OauthClientRegistrationInput) (*dto.OauthClientRegistrationOutput, error) {
+					return nil, oauth.ErrInvalidRedirectURI
+				}
+			}
+
+			if tt.name == "Sad case: failed to delete client registration" {
+				oauthUsecases.MockDeleteOauthClientRegistrationFn = func(ctx context.Context, clientID string, registrationAccessToken string) error {
+					return fmt.Errorf("failed to delete client registration")
+				}
+			}
+
+			h := &MyCareHubHandlersInterfacesImpl{
+				provider:       provider,
+				usecase:        *fakeUsecases,
+				sessionManager: sessionManager,
+			}
+
+			// the client ID is read from the path so the handlers are served with their routes
+			router := mux.NewRouter()
+			router.Path("/oauth/register").Methods(http.MethodPost).HandlerFunc(h.RegisterOauthClientHandler())
+			router.Path("/oauth/register/{clientID}").Methods(http.MethodGet).HandlerFunc(h.GetOauthClientRegistrationHandler())
+			router.Path("/oauth/register/{clientID}").Methods(http.MethodPut).HandlerFunc(h.UpdateOauthClientRegistrationHandler())
+			router.Path("/oauth/register/{clientID}").Methods(http.MethodDelete).HandlerFunc(h.DeleteOauthClientRegistrationHandler())
+
+			ts := httptest.NewServer(router)
+			defer ts.Close()
+
+			req, err := http.NewRequest(tt.method, ts.URL+tt.url, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Authorization", "Bearer token")
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.expectedStatusCode {
+				t.Errorf("Expected status code %d, but got %d", tt.expectedStatusCode, resp.StatusCode)
+			}
+
+			if resp.StatusCode == http.StatusUnauthorized && resp.Header.Get("WWW-Authenticate") == "" {
+				t.Errorf("Expected a WWW-Authenticate header")
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure"
 	pubsubmessaging "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/pubsub"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/common"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/notification"
 	"github.com/savannahghi/scalarutils"
 	"gorm.io/gorm"
@@ -244,6 +245,9 @@ func (a *UseCasesAppointmentsImpl) UpdateKenyaEMRAppointments(ctx context.Contex
 
 // FetchClientAppointments fetches appointments for a client
 func (a *UseCasesAppointmentsImpl) FetchClientAppointments(ctx context.Context, clientID string, paginationInput dto.PaginationsInput, filters []*firebasetools.FilterParam) (*domain.AppointmentsPage, error) {
+	if err := common.CheckCaregiverAbility(ctx, a.Query, clientID, enums.CaregiverAbilityViewAppointments); err != nil {
+		return nil, err
+	}
 
 	// if user did not provide current page, throw an error
 	if err := paginationInput.Validate(); err != nil {
@@ -513,6 +517,21 @@ func (a *UseCasesAppointmentsImpl) RescheduleClientAppointment(ctx context.Conte
 		return false, fmt.Errorf("error getting client profile")
 	}
 
+	if err := common.CheckCaregiverAbility(ctx, a.Query, appointment.ClientID, enums.CaregiverAbilityRescheduleAppointments); err != nil {
+		return false, err
+	}
+
+	if caregiverID != nil {
+		delegations, err := a.Query.GetCaregiversClient(ctx, domain.CaregiverClient{ClientID: appointment.ClientID, CaregiverID: *caregiverID})
+		if err != nil {
+			return false, fmt.Errorf("error getting caregiver's delegation: %w", err)
+		}
+
+		if len(delegations) != 1 || !delegations[0].Allows(enums.CaregiverAbilityRescheduleAppointments, time.Now()) {
+			return false, fmt.Errorf("caregiver is not allowed to reschedule the client's appointments")
+		}
+	}
+
 	// update appointment has rescheduled field to true
 	_, err = a.Update.UpdateAppointment(ctx, &domain.Appointment{ID: appointment.ID}, map[string]interface{}{"has_rescheduled_appointment": true, "caregiver_id": caregiverID})
 	if err != nil {
@@ -549,6 +568,10 @@ func (a *UseCasesAppointmentsImpl) RescheduleClientAppointment(ctx context.Conte
 // NextRefill indicates the next time a user is supposed to visit the pharmacy to refill drugs
 // It is stored as an appointment with reason "Pharmacy Visit" as obtained from Kenya EMR
 func (a *UseCasesAppointmentsImpl) NextRefill(ctx context.Context, clientID string) (*scalarutils.Date, error) {
+	if err := common.CheckCaregiverAbility(ctx, a.Query, clientID, enums.CaregiverAbilityViewAppointments); err != nil {
+		return nil, err
+	}
+
 	_, err := a.Query.GetClientProfileByClientID(ctx, clientID)
 	if err != nil {
 		helpers.ReportErrorToSentry(err)
@@ -6,10 +6,12 @@ import (
 	"testing"
 	"time"
 
+	"firebase.google.com/go/auth"
 	"github.com/brianvoe/gofakeit"
 	"github.com/google/uuid"
 	"github.com/savannahghi/firebasetools"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	extensionMock "github.com/savannahghi/mycarehub/pkg/mycarehub/application/extension/mock"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	pgMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/mock"
@@ -698,6 +700,7 @@ func TestUseCasesAppointmentsImpl_UpdateKenyaEMRAppointments(t *testing.T) {
 }
 
 func TestUseCasesAppointmentsImpl_FetchClientAppointments(t *testing.T) {
+	ctx := context.WithValue(context.Background(), firebasetools.AuthTokenContextKey, &auth.Token{UID: uuid.New().String()})
 	type args struct {
 		ctx             context.Context
 		clientID        string
@@ -713,7 +716,7 @@ func TestUseCasesAppointmentsImpl_FetchClientAppointments(t *testing.T) {
 		{
 			name: "sad case: current page not provided",
 			args: args{
-				ctx:             context.Background(),
+				ctx:             ctx,
 				clientID:        "client-id",
 				paginationInput: dto.PaginationsInput{},
 				filters:         []*firebasetools.FilterParam{},
@@ -723,7 +726,7 @@ func TestUseCasesAppointmentsImpl_FetchClientAppointments(t *testing.T) {
 		{
 			name: "sad case: error listing appointments",
 			args: args{
-				ctx:      context.Background(),
+				ctx:      ctx,
 				clientID: "client-id",
 				paginationInput: dto.PaginationsInput{
 					CurrentPage: 1,
@@ -736,7 +739,7 @@ func TestUseCasesAppointmentsImpl_FetchClientAppointments(t *testing.T) {
 		{
 			name: "happy case: success listing appointments",
 			args: args{
-				ctx:      context.Background(),
+				ctx:      ctx,
 				clientID: "client-id",
 				paginationInput: dto.PaginationsInput{
 					CurrentPage: 1,
@@ -746,6 +749,32 @@ func TestUseCasesAppointmentsImpl_FetchClientAppointments(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "sad case: failed to get logged in user",
+			args: args{
+				ctx:      context.Background(),
+				clientID: "client-id",
+				paginationInput: dto.PaginationsInput{
+					CurrentPage: 1,
+					Limit:       5,
+				},
+				filters: []*firebasetools.FilterParam{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: caregiver not delegated to view appointments",
+			args: args{
+				ctx:      ctx,
+				clientID: "client-id",
+				paginationInput: dto.PaginationsInput{
+					CurrentPage: 1,
+					Limit:       5,
+				},
+				filters: []*firebasetools.FilterParam{},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -757,6 +786,16 @@ func TestUseCasesAppointmentsImpl_FetchClientAppointments(t *testing.T) {
 
 			a := NewUseCaseAppointmentsImpl(fakeExtension, fakeDB, fakeDB, fakeDB, fakePubsub, fakeNotification)
 
+			if tt.name == "sad case: caregiver not delegated to view appointments" {
+				fakeDB.MockGetCaregiversClientFn = func(ctx context.Context, caregiverClient domain.CaregiverClient) ([]*domain.CaregiverClient, error) {
+					return []*domain.CaregiverClient{
+						{
+							ClientConsent: enums.ConsentStateAccepted,
+							Abilities:     []enums.CaregiverAbility{enums.CaregiverAbilityRecordHealthDiary},
+						},
+					}, nil
+				}
+			}
 			if tt.name == "sad case: error listing appointments" {
 				fakeDB.MockListAppointments = func(ctx context.Context, params *domain.Appointment, filters []*firebasetools.FilterParam, pagination *domain.Pagination) ([]*domain.Appointment, *domain.Pagination, error) {
 					return nil, nil, fmt.Errorf("error listing appointments")
@@ -1360,6 +1399,7 @@ func TestUseCasesAppointmentsImpl_GetAppointmentServiceRequests(t *testing.T) {
 }
 
 func TestUseCasesAppointmentsImpl_RescheduleClientAppointment(t *testing.T) {
+	ctx := context.WithValue(context.Background(), firebasetools.AuthTokenContextKey, &auth.Token{UID: uuid.New().String()})
 	futureTime := time.Now().Add(24 * time.Hour)
 	futureDate, err := scalarutils.NewDate(futureTime.Day(), int(futureTime.Month()), futureTime.Year())
 	id := uuid.NewString()
@@ -1383,7 +1423,7 @@ func TestUseCasesAppointmentsImpl_RescheduleClientAppointment(t *testing.T) {
 		{
 			name: "happy case: reschedule appointment",
 			args: args{
-				ctx:           context.Background(),
+				ctx:           ctx,
 				appointmentID: uuid.New().String(),
 				date:          *futureDate,
 			},
@@ -1393,7 +1433,7 @@ func TestUseCasesAppointmentsImpl_RescheduleClientAppointment(t *testing.T) {
 		{
 			name: "happy case: reschedule appointment as a caregiver",
 			args: args{
-				ctx:           context.Background(),
+				ctx:           ctx,
 				appointmentID: uuid.New().String(),
 				date:          *futureDate,
 				caregiverID:   &id,
@@ -1404,7 +1444,7 @@ func TestUseCasesAppointmentsImpl_RescheduleClientAppointment(t *testing.T) {
 		{
 			name: "sad case: empty appointment id",
 			args: args{
-				ctx:           context.Background(),
+				ctx:           ctx,
 				appointmentID: "",
 				date:          *futureDate,
 			},
@@ -1414,7 +1454,7 @@ func TestUseCasesAppointmentsImpl_RescheduleClientAppointment(t *testing.T) {
 		{
 			name: "sad case: failed to get client by id",
 			args: args{
-				ctx:           context.Background(),
+				ctx:           ctx,
 				appointmentID: uuid.New().String(),
 				date:          *futureDate,
 			},
@@ -1424,7 +1464,7 @@ func TestUseCasesAppointmentsImpl_RescheduleClientAppointment(t *testing.T) {
 		{
 			name: "sad case: failed to get appointment by id",
 			args: args{
-				ctx:           context.Background(),
+				ctx:           ctx,
 				appointmentID: uuid.New().String(),
 				date:          *futureDate,
 			},
@@ -1434,7 +1474,7 @@ func TestUseCasesAppointmentsImpl_RescheduleClientAppointment(t *testing.T) {
 		{
 			name: "sad case: failed to create service request",
 			args: args{
-				ctx:           context.Background(),
+				ctx:           ctx,
 				appointmentID: uuid.New().String(),
 				date:          *futureDate,
 			},
@@ -1443,6 +1483,49 @@ func TestUseCasesAppointmentsImpl_RescheduleClientAppointment(t *testing.T) {
 		},
 		{
 			name: "sad case: failed to update appointment",
+			args: args{
+				ctx:           ctx,
+				appointmentID: uuid.New().String(),
+				date:          *futureDate,
+			},
+			wantErr: true,
+			want:    false,
+		},
+		{
+			name: "sad case: failed to get caregiver's delegation",
+			args: args{
+				ctx:           ctx,
+				appointmentID: uuid.New().String(),
+				date:          *futureDate,
+				caregiverID:   &id,
+			},
+			wantErr: true,
+			want:    false,
+		},
+		{
+			name: "sad case: caregiver not delegated to reschedule appointments",
+			args: args{
+				ctx:           ctx,
+				appointmentID: uuid.New().String(),
+				date:          *futureDate,
+				caregiverID:   &id,
+			},
+			wantErr: true,
+			want:    false,
+		},
+		{
+			name: "sad case: caregiver's delegation has ended",
+			args: args{
+				ctx:           ctx,
+				appointmentID: uuid.New().String(),
+				date:          *futureDate,
+				caregiverID:   &id,
+			},
+			wantErr: true,
+			want:    false,
+		},
+		{
+			name: "sad case: failed to get logged in user",
 			args: args{
 				ctx:           context.Background(),
 				appointmentID: uuid.New().String(),
@@ -1451,6 +1534,16 @@ func TestUseCasesAppointmentsImpl_RescheduleClientAppointment(t *testing.T) {
 			wantErr: true,
 			want:    false,
 		},
+		{
+			name: "sad case: logged in caregiver not delegated to reschedule appointments",
+			args: args{
+				ctx:           ctx,
+				appointmentID: uuid.New().String(),
+				date:          *futureDate,
+			},
+			wantErr: true,
+			want:    false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1461,6 +1554,36 @@ func TestUseCasesAppointmentsImpl_RescheduleClientAppointment(t *testing.T) {
 
 			a := NewUseCaseAppointmentsImpl(fakeExtension, fakeDB, fakeDB, fakeDB, fakePubsub, fakeNotification)
 
+			if tt.name == "sad case: failed to get caregiver's delegation" {
+				fakeDB.MockGetCaregiversClientFn = func(ctx context.Context, caregiverClient domain.CaregiverClient) ([]*domain.CaregiverClient, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "sad case: caregiver not delegated to reschedule appointments" || tt.name == "sad case: logged in caregiver not delegated to reschedule appointments" {
+				fakeDB.MockGetCaregiversClientFn = func(ctx context.Context, caregiverClient domain.CaregiverClient) ([]*domain.CaregiverClient, error) {
+					return []*domain.CaregiverClient{
+						{
+							CaregiverID:   id,
+							ClientConsent: enums.ConsentStateAccepted,
+							Abilities:     []enums.CaregiverAbility{enums.CaregiverAbilityViewAppointments},
+						},
+					}, nil
+				}
+			}
+			if tt.name == "sad case: caregiver's delegation has ended" {
+				fakeDB.MockGetCaregiversClientFn = func(ctx context.Context, caregiverClient domain.CaregiverClient) ([]*domain.CaregiverClient, error) {
+					endsAt := time.Now().Add(-time.Hour)
+					return []*domain.CaregiverClient{
+						{
+							CaregiverID:   id,
+							ClientConsent: enums.ConsentStateAccepted,
+							EndsAt:        &endsAt,
+							Abilities:     enums.AllCaregiverAbilities,
+						},
+					}, nil
+				}
+			}
+
 			if tt.name == "sad case: failed to get client by id" {
 				fakeDB.MockGetClientProfileByClientIDFn = func(ctx context.Context, clientID string) (*domain.ClientProfile, error) {
 					return nil, fmt.Errorf("error retrieving client by id")
@@ -1496,6 +1619,7 @@ func TestUseCasesAppointmentsImpl_RescheduleClientAppointment(t *testing.T) {
 }
 
 func TestUseCasesAppointmentsImpl_NextRefill(t *testing.T) {
+	ctx := context.WithValue(context.Background(), firebasetools.AuthTokenContextKey, &auth.Token{UID: uuid.New().String()})
 
 	type args struct {
 		ctx      context.Context
@@ -1511,7 +1635,7 @@ func TestUseCasesAppointmentsImpl_NextRefill(t *testing.T) {
 		{
 			name: "Happy case: has next refill date",
 			args: args{
-				ctx:      context.Background(),
+				ctx:      ctx,
 				clientID: gofakeit.UUID(),
 			},
 			wantErr: false,
@@ -1520,7 +1644,7 @@ func TestUseCasesAppointmentsImpl_NextRefill(t *testing.T) {
 		{
 			name: "Happy case: has no refill date",
 			args: args{
-				ctx:      context.Background(),
+				ctx:      ctx,
 				clientID: gofakeit.UUID(),
 			},
 			wantErr: false,
@@ -1529,7 +1653,7 @@ func TestUseCasesAppointmentsImpl_NextRefill(t *testing.T) {
 		{
 			name: "Sad case: invalid client id",
 			args: args{
-				ctx:      context.Background(),
+				ctx:      ctx,
 				clientID: gofakeit.UUID(),
 			},
 			wantErr: true,
@@ -1538,7 +1662,16 @@ func TestUseCasesAppointmentsImpl_NextRefill(t *testing.T) {
 		{
 			name: "Sad case: error fetching appointment",
 			args: args{
-				ctx:      context.Background(),
+				ctx:      ctx,
+				clientID: gofakeit.UUID(),
+			},
+			wantErr: true,
+			wantNil: true,
+		},
+		{
+			name: "Sad case: caregiver not delegated to view appointments",
+			args: args{
+				ctx:      ctx,
 				clientID: gofakeit.UUID(),
 			},
 			wantErr: true,
@@ -1557,6 +1690,17 @@ func TestUseCasesAppointmentsImpl_NextRefill(t *testing.T) {
 				}
 			}
 
+			if tt.name == "Sad case: caregiver not delegated to view appointments" {
+				fakeDB.MockGetCaregiversClientFn = func(ctx context.Context, caregiverClient domain.CaregiverClient) ([]*domain.CaregiverClient, error) {
+					return []*domain.CaregiverClient{
+						{
+							ClientConsent: enums.ConsentStateAccepted,
+							Abilities:     []enums.CaregiverAbility{enums.CaregiverAbilityViewScreeningResults},
+						},
+					}, nil
+				}
+			}
+
 			if tt.name == "Sad case: invalid client id" {
 				fakeDB.MockGetClientProfileByClientIDFn = func(ctx context.Context, clientID string) (*domain.ClientProfile, error) {
 					return nil, fmt.Errorf("client does not exist")
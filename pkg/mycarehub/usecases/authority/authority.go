@@ -1,34 +1,632 @@
 package authority
 
 import (
+	"context"
+	"fmt"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/extension"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/authorization"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/common"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/notification"
+	"go.opentelemetry.io/otel"
 )
 
+var tracer = otel.Tracer("github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/authority")
+
+// ICheckPermission checks whether a user has been granted a permission
+type ICheckPermission interface {
+	CheckUserPermission(ctx context.Context, scope string) (bool, error)
+}
+
+// IManageRoles contains the methods used to create roles and manage the permissions granted to them
+type IManageRoles interface {
+	CreateAuthorityRole(ctx context.Context, input dto.AuthorityRoleInput) (*domain.AuthorityRole, error)
+	ListAuthorityRoles(ctx context.Context, programID string) ([]*domain.AuthorityRole, error)
+	ListAuthorityPermissions(ctx context.Context) ([]*domain.AuthorityPermission, error)
+	AddPermissionsToRole(ctx context.Context, roleID string, permissionIDs []string) (*domain.AuthorityRole, error)
+	RemovePermissionsFromRole(ctx context.Context, roleID string, permissionIDs []string) (*domain.AuthorityRole, error)
+	SeedDefaultRoles(ctx context.Context) error
+}
+
+// IAssignRoles contains the methods used to assign and revoke roles from staff, clients and caregivers
+type IAssignRoles interface {
+	AssignRoles(ctx context.Context, input dto.RoleAssignmentInput) (bool, error)
+	RevokeRoles(ctx context.Context, input dto.RoleAssignmentInput) (bool, error)
+	GetProfileRoles(ctx context.Context, userType enums.UsersType, profileID string) ([]*domain.AuthorityRole, error)
+}
+
 // UsecaseAuthority groups al the interfaces for the Authority usecase
 type UsecaseAuthority interface {
+	ICheckPermission
+	IManageRoles
+	IAssignRoles
 }
 
 // UsecaseAuthorityImpl represents the Authority implementation
 type UsecaseAuthorityImpl struct {
+	Create       infrastructure.Create
 	Query        infrastructure.Query
 	Update       infrastructure.Update
+	Delete       infrastructure.Delete
 	ExternalExt  extension.ExternalMethodsExtension
 	Notification notification.UseCaseNotification
 }
 
 // NewUsecaseAuthority is the controller function for the Authority usecase
 func NewUsecaseAuthority(
+	create infrastructure.Create,
 	query infrastructure.Query,
 	update infrastructure.Update,
+	delete infrastructure.Delete,
 	externalExt extension.ExternalMethodsExtension,
 	notification notification.UseCaseNotification,
 ) *UsecaseAuthorityImpl {
 	return &UsecaseAuthorityImpl{
+		Create:       create,
 		Query:        query,
 		Update:       update,
+		Delete:       delete,
 		ExternalExt:  externalExt,
 		Notification: notification,
 	}
 }
+
+// CheckUserPermission checks whether the logged in user has been granted a permission with the provided scope
+// in their current program. Superusers are allowed to perform every action.
+func (u *UsecaseAuthorityImpl) CheckUserPermission(ctx context.Context, scope string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "CheckUserPermission")
+	defer span.End()
+
+	uid, err := u.ExternalExt.GetLoggedInUserUID(ctx)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.GetLoggedInUserUIDErr(err)
+	}
+
+	user, err := u.Query.GetUserProfileByUserID(ctx, uid)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.UserNotFoundError(err)
+	}
+
+	if user.IsSuperuser {
+		return true, nil
+	}
+
+	if user.CurrentProgramID == "" {
+		return false, nil
+	}
+
+	permissions, err := u.Query.GetUserPermissions(ctx, uid, user.CurrentProgramID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.CheckUserPermissionErr(fmt.Errorf("failed to get user permissions: %w", err))
+	}
+
+	for _, permission := range permissions {
+		if permission.Active && permission.Scope == scope {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// CreateAuthorityRole creates a custom role in a program and grants it the provided permissions
+func (u *UsecaseAuthorityImpl) CreateAuthorityRole(ctx context.Context, input dto.AuthorityRoleInput) (*domain.AuthorityRole, error) {
+	ctx, span := tracer.Start(ctx, "CreateAuthorityRole")
+	defer span.End()
+
+	if err := input.Validate(); err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InputValidationErr(err)
+	}
+
+	if err := u.checkRoleProgram(ctx, input.ProgramID); err != nil {
+		return nil, err
+	}
+
+	if err := u.checkGrantablePermissions(ctx, input.ProgramID, input.PermissionIDs); err != nil {
+		return nil, err
+	}
+
+	program, err := u.Query.GetProgramByID(ctx, input.ProgramID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.ItemNotFoundErr(fmt.Errorf("failed to get program: %w", err))
+	}
+
+	role := &domain.AuthorityRole{
+		Name:           input.Name,
+		Description:    input.Description,
+		IsSystemRole:   false,
+		UserType:       input.UserType,
+		OrganisationID: program.Organisation.ID,
+		ProgramID:      program.ID,
+	}
+
+	result, err := u.Create.CreateAuthorityRole(ctx, role, input.PermissionIDs)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.FailedToSaveItemErr(fmt.Errorf("failed to create role: %w", err))
+	}
+
+	return result, nil
+}
+
+// ListAuthorityRoles lists the roles that have been defined in a program
+func (u *UsecaseAuthorityImpl) ListAuthorityRoles(ctx context.Context, programID string) ([]*domain.AuthorityRole, error) {
+	ctx, span := tracer.Start(ctx, "ListAuthorityRoles")
+	defer span.End()
+
+	if err := u.checkRoleProgram(ctx, programID); err != nil {
+		return nil, err
+	}
+
+	roles, err := u.Query.ListAuthorityRoles(ctx, programID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.GetAllRolesErr(err)
+	}
+
+	return roles, nil
+}
+
+// ListAuthorityPermissions lists all the permissions that can be granted to a role
+func (u *UsecaseAuthorityImpl) ListAuthorityPermissions(ctx context.Context) ([]*domain.AuthorityPermission, error) {
+	ctx, span := tracer.Start(ctx, "ListAuthorityPermissions")
+	defer span.End()
+
+	permissions, err := u.Query.ListAuthorityPermissions(ctx)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.GetUserPermissionsErr(err)
+	}
+
+	return permissions, nil
+}
+
+// AddPermissionsToRole grants the provided permissions to a role
+func (u *UsecaseAuthorityImpl) AddPermissionsToRole(ctx context.Context, roleID string, permissionIDs []string) (*domain.AuthorityRole, error) {
+	ctx, span := tracer.Start(ctx, "AddPermissionsToRole")
+	defer span.End()
+
+	if len(permissionIDs) == 0 {
+		return nil, exceptions.EmptyInputErr(fmt.Errorf("at least one permission must be provided"))
+	}
+
+	role, err := u.Query.GetAuthorityRoleByID(ctx, roleID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.ItemNotFoundErr(fmt.Errorf("failed to get role: %w", err))
+	}
+
+	if err := u.checkEditableRole(ctx, role); err != nil {
+		return nil, err
+	}
+
+	if err := u.checkGrantablePermissions(ctx, role.ProgramID, permissionIDs); err != nil {
+		return nil, err
+	}
+
+	err = u.Create.AddPermissionsToRole(ctx, roleID, permissionIDs)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.FailedToUpdateItemErr(err)
+	}
+
+	common.RecordAuditLog(ctx, u.Create, &domain.AuditLog{
+		RecordType:     enums.AuditLogRoleChange,
+		Notes:          "permissions added to role",
+		TargetID:       roleID,
+		TargetType:     enums.AuditLogTargetRole,
+		ProgramID:      role.ProgramID,
+		OrganisationID: role.OrganisationID,
+		After:          map[string]interface{}{"added_permission_ids": permissionIDs},
+	})
+
+	return u.Query.GetAuthorityRoleByID(ctx, roleID)
+}
+
+// RemovePermissionsFromRole revokes the provided permissions from a role
+func (u *UsecaseAuthorityImpl) RemovePermissionsFromRole(ctx context.Context, roleID string, permissionIDs []string) (*domain.AuthorityRole, error) {
+	ctx, span := tracer.Start(ctx, "RemovePermissionsFromRole")
+	defer span.End()
+
+	if len(permissionIDs) == 0 {
+		return nil, exceptions.EmptyInputErr(fmt.Errorf("at least one permission must be provided"))
+	}
+
+	role, err := u.Query.GetAuthorityRoleByID(ctx, roleID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.ItemNotFoundErr(fmt.Errorf("failed to get role: %w", err))
+	}
+
+	if err := u.checkEditableRole(ctx, role); err != nil {
+		return nil, err
+	}
+
+	err = u.Delete.RemovePermissionsFromRole(ctx, roleID, permissionIDs)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.FailedToUpdateItemErr(err)
+	}
+
+	common.RecordAuditLog(ctx, u.Create, &domain.AuditLog{
+		RecordType:     enums.AuditLogRoleChange,
+		Notes:          "permissions removed from role",
+		TargetID:       roleID,
+		TargetType:     enums.AuditLogTargetRole,
+		ProgramID:      role.ProgramID,
+		OrganisationID: role.OrganisationID,
+		After:          map[string]interface{}{"removed_permission_ids": permissionIDs},
+	})
+
+	return u.Query.GetAuthorityRoleByID(ctx, roleID)
+}
+
+// SeedDefaultRoles makes sure that every program has the default admin, staff, client and caregiver roles, that the roles
+// are granted the permissions defined for them and that every profile without a role is assigned its default role
+func (u *UsecaseAuthorityImpl) SeedDefaultRoles(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "SeedDefaultRoles")
+	defer span.End()
+
+	err := u.Create.SeedAuthorityRoles(ctx, authorization.DefaultRoles())
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return exceptions.InternalErr(fmt.Errorf("failed to seed default roles: %w", err))
+	}
+
+	err = u.Create.AssignDefaultRoles(ctx)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return exceptions.InternalErr(fmt.Errorf("failed to assign default roles: %w", err))
+	}
+
+	return nil
+}
+
+// AssignRoles assigns roles to a staff, client or caregiver profile and notifies the user of each assigned role
+func (u *UsecaseAuthorityImpl) AssignRoles(ctx context.Context, input dto.RoleAssignmentInput) (bool, error) {
+	ctx, span := tracer.Start(ctx, "AssignRoles")
+	defer span.End()
+
+	if err := input.Validate(); err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.InputValidationErr(err)
+	}
+
+	programID, err := u.loggedInStaffProgramID(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	user, roles, err := u.getAssignableRoles(ctx, programID, input)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.AssignRolesErr(err)
+	}
+
+	uid, err := u.ExternalExt.GetLoggedInUserUID(ctx)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.GetLoggedInUserUIDErr(err)
+	}
+
+	if user != nil && user.ID != nil && *user.ID == uid {
+		err := fmt.Errorf("roles cannot be assigned to the logged in user's own profile")
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.UserNotAuthorizedErr(err)
+	}
+
+	permissionIDs := []string{}
+	for _, role := range roles {
+		for _, permission := range role.Permissions {
+			permissionIDs = append(permissionIDs, permission.PermissionID)
+		}
+	}
+
+	if err := u.checkGrantablePermissions(ctx, programID, permissionIDs); err != nil {
+		return false, err
+	}
+
+	err = u.Create.AssignRoles(ctx, input.UserType, input.ProfileID, input.RoleIDs)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.AssignRolesErr(err)
+	}
+
+	u.notifyRoleChange(ctx, enums.NotificationTypeRoleAssignment, input.UserType, user, roles)
+
+	common.RecordAuditLog(ctx, u.Create, &domain.AuditLog{
+		RecordType: enums.AuditLogRoleChange,
+		Notes:      "roles assigned",
+		TargetID:   input.ProfileID,
+		TargetType: enums.AuditLogTargetType(input.UserType),
+		After:      map[string]interface{}{"assigned_role_ids": input.RoleIDs},
+	})
+
+	return true, nil
+}
+
+// RevokeRoles removes roles from a staff, client or caregiver profile and notifies the user of each revoked role
+func (u *UsecaseAuthorityImpl) RevokeRoles(ctx context.Context, input dto.RoleAssignmentInput) (bool, error) {
+	ctx, span := tracer.Start(ctx, "RevokeRoles")
+	defer span.End()
+
+	if err := input.Validate(); err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.InputValidationErr(err)
+	}
+
+	programID, err := u.loggedInStaffProgramID(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	user, roles, err := u.getAssignableRoles(ctx, programID, input)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.RevokeRolesErr(err)
+	}
+
+	err = u.Delete.RevokeRoles(ctx, input.UserType, input.ProfileID, input.RoleIDs)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.RevokeRolesErr(err)
+	}
+
+	u.notifyRoleChange(ctx, enums.NotificationTypeRoleRevocation, input.UserType, user, roles)
+
+	common.RecordAuditLog(ctx, u.Create, &domain.AuditLog{
+		RecordType: enums.AuditLogRoleChange,
+		Notes:      "roles revoked",
+		TargetID:   input.ProfileID,
+		TargetType: enums.AuditLogTargetType(input.UserType),
+		After:      map[string]interface{}{"revoked_role_ids": input.RoleIDs},
+	})
+
+	return true, nil
+}
+
+// GetProfileRoles retrieves the roles that a staff, client or caregiver profile has been assigned in the logged in staff's program.
+// Staff and client profiles must belong to the logged in staff's program and caregiver profiles to their organisation
+func (u *UsecaseAuthorityImpl) GetProfileRoles(ctx context.Context, userType enums.UsersType, profileID string) ([]*domain.AuthorityRole, error) {
+	ctx, span := tracer.Start(ctx, "GetProfileRoles")
+	defer span.End()
+
+	staff, err := u.loggedInStaff(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	_, programID, organisationID, err := u.getProfileOwner(ctx, userType, profileID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.GetUserRolesErr(err)
+	}
+
+	if (programID != "" && programID != staff.ProgramID) || organisationID != staff.OrganisationID {
+		err := fmt.Errorf("the roles of profile %s cannot be read from program %s", profileID, staff.ProgramID)
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.UserNotAuthorizedErr(err)
+	}
+
+	records, err := u.Query.GetProfileRoles(ctx, userType, profileID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.GetUserRolesErr(err)
+	}
+
+	roles := []*domain.AuthorityRole{}
+	for _, role := range records {
+		if role.ProgramID == staff.ProgramID {
+			roles = append(roles, role)
+		}
+	}
+
+	return roles, nil
+}
+
+// getProfileOwner retrieves the user that owns a staff, client or caregiver profile together with the profile's program and organisation.
+// Caregiver profiles do not belong to a program so their program is empty
+func (u *UsecaseAuthorityImpl) getProfileOwner(ctx context.Context, userType enums.UsersType, profileID string) (*domain.User, string, string, error) {
+	switch userType {
+	case enums.StaffUser:
+		staff, err := u.Query.GetStaffProfileByStaffID(ctx, profileID)
+		if err != nil {
+			return nil, "", "", fmt.Errorf("failed to get staff profile: %w", err)
+		}
+		return staff.User, staff.ProgramID, staff.OrganisationID, nil
+
+	case enums.ClientUser:
+		client, err := u.Query.GetClientProfileByClientID(ctx, profileID)
+		if err != nil {
+			return nil, "", "", fmt.Errorf("failed to get client profile: %w", err)
+		}
+		return client.User, client.ProgramID, client.OrganisationID, nil
+
+	case enums.CaregiverUser:
+		caregiver, err := u.Query.GetCaregiverProfileByCaregiverID(ctx, profileID)
+		if err != nil {
+			return nil, "", "", fmt.Errorf("failed to get caregiver profile: %w", err)
+		}
+		return &caregiver.User, "", caregiver.OrganisationID, nil
+
+	default:
+		return nil, "", "", fmt.Errorf("roles cannot be assigned to user type: %s", userType)
+	}
+}
+
+// getAssignableRoles retrieves the user that owns a profile and the roles being assigned or revoked.
+// A role can only be assigned to a profile of the same user type, every role must belong to the logged in staff's program
+// and staff and client roles must also belong to the profile's program
+func (u *UsecaseAuthorityImpl) getAssignableRoles(ctx context.Context, staffProgramID string, input dto.RoleAssignmentInput) (*domain.User, []*domain.AuthorityRole, error) {
+	user, programID, _, err := u.getProfileOwner(ctx, input.UserType, input.ProfileID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	roles := []*domain.AuthorityRole{}
+	for _, roleID := range input.RoleIDs {
+		role, err := u.Query.GetAuthorityRoleByID(ctx, roleID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get role: %w", err)
+		}
+
+		if role.UserType != input.UserType {
+			return nil, nil, fmt.Errorf("role %s can only be assigned to %s users", role.Name, role.UserType)
+		}
+
+		if role.ProgramID != staffProgramID {
+			return nil, nil, fmt.Errorf("role %s does not belong to the logged in staff's program", role.Name)
+		}
+
+		if programID != "" && role.ProgramID != programID {
+			return nil, nil, fmt.Errorf("role %s does not belong to the user's program", role.Name)
+		}
+
+		roles = append(roles, role)
+	}
+
+	return user, roles, nil
+}
+
+// loggedInStaffProgramID returns the program of the logged in staff's current profile.
+// Roles can only be managed within this program.
+func (u *UsecaseAuthorityImpl) loggedInStaffProgramID(ctx context.Context) (string, error) {
+	staff, err := u.loggedInStaff(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return staff.ProgramID, nil
+}
+
+// loggedInStaff returns the logged in staff's profile in their current program
+func (u *UsecaseAuthorityImpl) loggedInStaff(ctx context.Context) (*domain.StaffProfile, error) {
+	uid, err := u.ExternalExt.GetLoggedInUserUID(ctx)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.GetLoggedInUserUIDErr(err)
+	}
+
+	user, err := u.Query.GetUserProfileByUserID(ctx, uid)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.UserNotFoundError(err)
+	}
+
+	staff, err := u.Query.GetStaffProfile(ctx, uid, user.CurrentProgramID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.StaffProfileNotFoundErr(err)
+	}
+
+	return staff, nil
+}
+
+// checkGrantablePermissions ensures that the logged in user holds every permission they are granting in a program
+// so that a role cannot be used to escalate their own permissions. Superusers can grant every permission
+func (u *UsecaseAuthorityImpl) checkGrantablePermissions(ctx context.Context, programID string, permissionIDs []string) error {
+	uid, err := u.ExternalExt.GetLoggedInUserUID(ctx)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return exceptions.GetLoggedInUserUIDErr(err)
+	}
+
+	user, err := u.Query.GetUserProfileByUserID(ctx, uid)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return exceptions.UserNotFoundError(err)
+	}
+
+	if user.IsSuperuser {
+		return nil
+	}
+
+	permissions, err := u.Query.GetUserPermissions(ctx, uid, programID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return exceptions.GetUserPermissionsErr(err)
+	}
+
+	held := map[string]bool{}
+	for _, permission := range permissions {
+		if permission.Active {
+			held[permission.PermissionID] = true
+		}
+	}
+
+	for _, permissionID := range permissionIDs {
+		if !held[permissionID] {
+			err := fmt.Errorf("permission %s has not been granted to the logged in user", permissionID)
+			helpers.ReportErrorToSentry(err)
+			return exceptions.UserNotAuthorizedErr(err)
+		}
+	}
+
+	return nil
+}
+
+// checkRoleProgram ensures that the logged in staff is managing the roles of their own program
+func (u *UsecaseAuthorityImpl) checkRoleProgram(ctx context.Context, programID string) error {
+	staffProgramID, err := u.loggedInStaffProgramID(ctx)
+	if err != nil {
+		return err
+	}
+
+	if staffProgramID != programID {
+		err := fmt.Errorf("roles in program %s cannot be managed from program %s", programID, staffProgramID)
+		helpers.ReportErrorToSentry(err)
+		return exceptions.UserNotAuthorizedErr(err)
+	}
+
+	return nil
+}
+
+// checkEditableRole ensures that the permissions of a role can be changed by the logged in staff.
+// System roles and the default roles are managed by the platform and their permissions cannot be edited.
+func (u *UsecaseAuthorityImpl) checkEditableRole(ctx context.Context, role *domain.AuthorityRole) error {
+	if role.IsSystemRole || authorization.DefaultRole(role.Name).IsValid() {
+		err := fmt.Errorf("the permissions of role %s cannot be edited", role.Name)
+		helpers.ReportErrorToSentry(err)
+		return exceptions.UserNotAuthorizedErr(err)
+	}
+
+	return u.checkRoleProgram(ctx, role.ProgramID)
+}
+
+// notifyRoleChange notifies a user of the roles that have been assigned to or revoked from them
+func (u *UsecaseAuthorityImpl) notifyRoleChange(ctx context.Context, notificationType enums.NotificationType, userType enums.UsersType, user *domain.User, roles []*domain.AuthorityRole) {
+	if user == nil {
+		return
+	}
+
+	for _, role := range roles {
+		var roleNotification *domain.Notification
+
+		if userType == enums.StaffUser {
+			roleNotification = notification.ComposeStaffNotification(notificationType, notification.StaffNotificationArgs{
+				Subject: user,
+				Role:    role,
+			})
+		} else {
+			roleNotification = notification.ComposeClientNotification(notificationType, notification.ClientNotificationInput{
+				Role: role,
+			})
+		}
+
+		err := u.Notification.NotifyUser(ctx, user, roleNotification)
+		if err != nil {
+			helpers.ReportErrorToSentry(err)
+		}
+	}
+}
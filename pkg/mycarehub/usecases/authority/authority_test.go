@@ -0,0 +1,1225 @@
+package authority_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/brianvoe/gofakeit"
+	"github.com/google/uuid"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	extensionMock "github.com/savannahghi/mycarehub/pkg/mycarehub/application/extension/mock"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/authorization"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	pgMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/mock"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/authority"
+	notificationMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/notification/mock"
+)
+
+func TestUsecaseAuthorityImpl_CheckUserPermission(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		scope string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case: user has permission",
+			args: args{
+				ctx:   context.Background(),
+				scope: "facility.delete",
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Happy case: superuser has all permissions",
+			args: args{
+				ctx:   context.Background(),
+				scope: "organisation.delete",
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case: user does not have permission",
+			args: args{
+				ctx:   context.Background(),
+				scope: "organisation.delete",
+			},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name: "Sad case: user has no current program",
+			args: args{
+				ctx:   context.Background(),
+				scope: "facility.delete",
+			},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name: "Sad case: failed to get logged in user",
+			args: args{
+				ctx:   context.Background(),
+				scope: "facility.delete",
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case: failed to get user profile",
+			args: args{
+				ctx:   context.Background(),
+				scope: "facility.delete",
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case: failed to get user permissions",
+			args: args{
+				ctx:   context.Background(),
+				scope: "facility.delete",
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			u := authority.NewUsecaseAuthority(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeNotification)
+
+			fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.User, error) {
+				return &domain.User{
+					ID:               &userID,
+					CurrentProgramID: uuid.NewString(),
+				}, nil
+			}
+
+			if tt.name == "Happy case: superuser has all permissions" {
+				fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.User, error) {
+					return &domain.User{
+						ID:          &userID,
+						IsSuperuser: true,
+					}, nil
+				}
+			}
+			if tt.name == "Sad case: user has no current program" {
+				fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.User, error) {
+					return &domain.User{
+						ID: &userID,
+					}, nil
+				}
+			}
+			if tt.name == "Sad case: failed to get logged in user" {
+				fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+					return "", fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: failed to get user profile" {
+				fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.User, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: failed to get user permissions" {
+				fakeDB.MockGetUserPermissionsFn = func(ctx context.Context, userID string, programID string) ([]*domain.AuthorityPermission, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := u.CheckUserPermission(tt.args.ctx, tt.args.scope)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UsecaseAuthorityImpl.CheckUserPermission() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UsecaseAuthorityImpl.CheckUserPermission() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUsecaseAuthorityImpl_CreateAuthorityRole(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		input dto.AuthorityRoleInput
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: create role",
+			args: args{
+				ctx: context.Background(),
+				input: dto.AuthorityRoleInput{
+					Name:          gofakeit.JobTitle(),
+					Description:   gofakeit.Sentence(5),
+					ProgramID:     uuid.NewString(),
+					UserType:      enums.StaffUser,
+					PermissionIDs: []string{uuid.NewString()},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: invalid input",
+			args: args{
+				ctx: context.Background(),
+				input: dto.AuthorityRoleInput{
+					Name: gofakeit.JobTitle(),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: permission is not held by the logged in staff",
+			args: args{
+				ctx: context.Background(),
+				input: dto.AuthorityRoleInput{
+					Name:          gofakeit.JobTitle(),
+					Description:   gofakeit.Sentence(5),
+					ProgramID:     uuid.NewString(),
+					UserType:      enums.StaffUser,
+					PermissionIDs: []string{uuid.NewString()},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: failed to get logged in staff permissions",
+			args: args{
+				ctx: context.Background(),
+				input: dto.AuthorityRoleInput{
+					Name:          gofakeit.JobTitle(),
+					Description:   gofakeit.Sentence(5),
+					ProgramID:     uuid.NewString(),
+					UserType:      enums.StaffUser,
+					PermissionIDs: []string{uuid.NewString()},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: program is not the logged in staff's program",
+			args: args{
+				ctx: context.Background(),
+				input: dto.AuthorityRoleInput{
+					Name:        gofakeit.JobTitle(),
+					Description: gofakeit.Sentence(5),
+					ProgramID:   uuid.NewString(),
+					UserType:    enums.StaffUser,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: failed to get logged in staff profile",
+			args: args{
+				ctx: context.Background(),
+				input: dto.AuthorityRoleInput{
+					Name:        gofakeit.JobTitle(),
+					Description: gofakeit.Sentence(5),
+					ProgramID:   uuid.NewString(),
+					UserType:    enums.StaffUser,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: failed to get program",
+			args: args{
+				ctx: context.Background(),
+				input: dto.AuthorityRoleInput{
+					Name:        gofakeit.JobTitle(),
+					Description: gofakeit.Sentence(5),
+					ProgramID:   uuid.NewString(),
+					UserType:    enums.StaffUser,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: failed to create role",
+			args: args{
+				ctx: context.Background(),
+				input: dto.AuthorityRoleInput{
+					Name:        gofakeit.JobTitle(),
+					Description: gofakeit.Sentence(5),
+					ProgramID:   uuid.NewString(),
+					UserType:    enums.StaffUser,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			u := authority.NewUsecaseAuthority(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeNotification)
+
+			fakeDB.MockGetStaffProfileFn = func(ctx context.Context, userID string, _ string) (*domain.StaffProfile, error) {
+				return &domain.StaffProfile{ID: &userID, ProgramID: tt.args.input.ProgramID}, nil
+			}
+			fakeDB.MockGetUserPermissionsFn = func(ctx context.Context, userID string, programID string) ([]*domain.AuthorityPermission, error) {
+				permissions := []*domain.AuthorityPermission{}
+				for _, permissionID := range tt.args.input.PermissionIDs {
+					permissions = append(permissions, &domain.AuthorityPermission{PermissionID: permissionID, Active: true})
+				}
+				return permissions, nil
+			}
+
+			if tt.name == "Sad case: permission is not held by the logged in staff" {
+				fakeDB.MockGetUserPermissionsFn = func(ctx context.Context, userID string, programID string) ([]*domain.AuthorityPermission, error) {
+					return []*domain.AuthorityPermission{{PermissionID: uuid.NewString(), Active: true}}, nil
+				}
+			}
+			if tt.name == "Sad case: failed to get logged in staff permissions" {
+				fakeDB.MockGetUserPermissionsFn = func(ctx context.Context, userID string, programID string) ([]*domain.AuthorityPermission, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			if tt.name == "Sad case: program is not the logged in staff's program" {
+				fakeDB.MockGetStaffProfileFn = func(ctx context.Context, userID string, _ string) (*domain.StaffProfile, error) {
+					return &domain.StaffProfile{ID: &userID, ProgramID: uuid.NewString()}, nil
+				}
+			}
+			if tt.name == "Sad case: failed to get logged in staff profile" {
+				fakeDB.MockGetStaffProfileFn = func(ctx context.Context, userID string, _ string) (*domain.StaffProfile, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			if tt.name == "Sad case: failed to get program" {
+				fakeDB.MockGetProgramByIDFn = func(ctx context.Context, programID string) (*domain.Program, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: failed to create role" {
+				fakeDB.MockCreateAuthorityRoleFn = func(ctx context.Context, role *domain.AuthorityRole, permissionIDs []string) (*domain.AuthorityRole, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := u.CreateAuthorityRole(tt.args.ctx, tt.args.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UsecaseAuthorityImpl.CreateAuthorityRole() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got == nil {
+				t.Errorf("UsecaseAuthorityImpl.CreateAuthorityRole() expected a role to be returned")
+			}
+		})
+	}
+}
+
+func TestUsecaseAuthorityImpl_ListAuthorityRoles(t *testing.T) {
+	programID := uuid.NewString()
+
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{
+			name:    "Happy case: list roles",
+			wantErr: false,
+		},
+		{
+			name:    "Sad case: program is not the logged in staff's program",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: failed to list roles",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			u := authority.NewUsecaseAuthority(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeNotification)
+
+			fakeDB.MockGetStaffProfileFn = func(ctx context.Context, userID string, _ string) (*domain.StaffProfile, error) {
+				return &domain.StaffProfile{ID: &userID, ProgramID: programID}, nil
+			}
+
+			if tt.name == "Sad case: program is not the logged in staff's program" {
+				fakeDB.MockGetStaffProfileFn = func(ctx context.Context, userID string, _ string) (*domain.StaffProfile, error) {
+					return &domain.StaffProfile{ID: &userID, ProgramID: uuid.NewString()}, nil
+				}
+			}
+
+			if tt.name == "Sad case: failed to list roles" {
+				fakeDB.MockListAuthorityRolesFn = func(ctx context.Context, programID string) ([]*domain.AuthorityRole, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			_, err := u.ListAuthorityRoles(context.Background(), programID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UsecaseAuthorityImpl.ListAuthorityRoles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUsecaseAuthorityImpl_ListAuthorityPermissions(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{
+			name:    "Happy case: list permissions",
+			wantErr: false,
+		},
+		{
+			name:    "Sad case: failed to list permissions",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			u := authority.NewUsecaseAuthority(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeNotification)
+
+			if tt.name == "Sad case: failed to list permissions" {
+				fakeDB.MockListAuthorityPermissionsFn = func(ctx context.Context) ([]*domain.AuthorityPermission, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			_, err := u.ListAuthorityPermissions(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UsecaseAuthorityImpl.ListAuthorityPermissions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUsecaseAuthorityImpl_AddPermissionsToRole(t *testing.T) {
+	programID := uuid.NewString()
+
+	type args struct {
+		ctx           context.Context
+		roleID        string
+		permissionIDs []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: add permissions to role",
+			args: args{
+				ctx:           context.Background(),
+				roleID:        uuid.NewString(),
+				permissionIDs: []string{uuid.NewString()},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: no permissions provided",
+			args: args{
+				ctx:    context.Background(),
+				roleID: uuid.NewString(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: role not found",
+			args: args{
+				ctx:           context.Background(),
+				roleID:        uuid.NewString(),
+				permissionIDs: []string{uuid.NewString()},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: role belongs to another program",
+			args: args{
+				ctx:           context.Background(),
+				roleID:        uuid.NewString(),
+				permissionIDs: []string{uuid.NewString()},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: system role",
+			args: args{
+				ctx:           context.Background(),
+				roleID:        uuid.NewString(),
+				permissionIDs: []string{uuid.NewString()},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: default role",
+			args: args{
+				ctx:           context.Background(),
+				roleID:        uuid.NewString(),
+				permissionIDs: []string{uuid.NewString()},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: permission is not held by the logged in staff",
+			args: args{
+				ctx:           context.Background(),
+				roleID:        uuid.NewString(),
+				permissionIDs: []string{uuid.NewString()},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Happy case: superuser grants a permission they do not hold",
+			args: args{
+				ctx:           context.Background(),
+				roleID:        uuid.NewString(),
+				permissionIDs: []string{uuid.NewString()},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: failed to add permissions",
+			args: args{
+				ctx:           context.Background(),
+				roleID:        uuid.NewString(),
+				permissionIDs: []string{uuid.NewString()},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			u := authority.NewUsecaseAuthority(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeNotification)
+
+			fakeDB.MockGetStaffProfileFn = func(ctx context.Context, userID string, _ string) (*domain.StaffProfile, error) {
+				return &domain.StaffProfile{ID: &userID, ProgramID: programID}, nil
+			}
+			fakeDB.MockGetAuthorityRoleByIDFn = func(ctx context.Context, roleID string) (*domain.AuthorityRole, error) {
+				return &domain.AuthorityRole{AuthorityRoleID: roleID, Name: gofakeit.JobTitle(), ProgramID: programID}, nil
+			}
+			fakeDB.MockGetUserPermissionsFn = func(ctx context.Context, userID string, programID string) ([]*domain.AuthorityPermission, error) {
+				permissions := []*domain.AuthorityPermission{}
+				for _, permissionID := range tt.args.permissionIDs {
+					permissions = append(permissions, &domain.AuthorityPermission{PermissionID: permissionID, Active: true})
+				}
+				return permissions, nil
+			}
+
+			if tt.name == "Sad case: permission is not held by the logged in staff" || tt.name == "Happy case: superuser grants a permission they do not hold" {
+				fakeDB.MockGetUserPermissionsFn = func(ctx context.Context, userID string, programID string) ([]*domain.AuthorityPermission, error) {
+					return []*domain.AuthorityPermission{}, nil
+				}
+			}
+			if tt.name == "Happy case: superuser grants a permission they do not hold" {
+				fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.User, error) {
+					return &domain.User{ID: &userID, IsSuperuser: true}, nil
+				}
+			}
+
+			if tt.name == "Sad case: role belongs to another program" {
+				fakeDB.MockGetAuthorityRoleByIDFn = func(ctx context.Context, roleID string) (*domain.AuthorityRole, error) {
+					return &domain.AuthorityRole{AuthorityRoleID: roleID, Name: gofakeit.JobTitle(), ProgramID: uuid.NewString()}, nil
+				}
+			}
+			if tt.name == "Sad case: system role" {
+				fakeDB.MockGetAuthorityRoleByIDFn = func(ctx context.Context, roleID string) (*domain.AuthorityRole, error) {
+					return &domain.AuthorityRole{AuthorityRoleID: roleID, Name: gofakeit.JobTitle(), ProgramID: programID, IsSystemRole: true}, nil
+				}
+			}
+			if tt.name == "Sad case: default role" {
+				fakeDB.MockGetAuthorityRoleByIDFn = func(ctx context.Context, roleID string) (*domain.AuthorityRole, error) {
+					return &domain.AuthorityRole{AuthorityRoleID: roleID, Name: string(authorization.DefaultRoleAdmin), ProgramID: programID}, nil
+				}
+			}
+
+			if tt.name == "Sad case: role not found" {
+				fakeDB.MockGetAuthorityRoleByIDFn = func(ctx context.Context, roleID string) (*domain.AuthorityRole, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: failed to add permissions" {
+				fakeDB.MockAddPermissionsToRoleFn = func(ctx context.Context, roleID string, permissionIDs []string) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+
+			_, err := u.AddPermissionsToRole(tt.args.ctx, tt.args.roleID, tt.args.permissionIDs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UsecaseAuthorityImpl.AddPermissionsToRole() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUsecaseAuthorityImpl_RemovePermissionsFromRole(t *testing.T) {
+	programID := uuid.NewString()
+
+	type args struct {
+		ctx           context.Context
+		roleID        string
+		permissionIDs []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: remove permissions from role",
+			args: args{
+				ctx:           context.Background(),
+				roleID:        uuid.NewString(),
+				permissionIDs: []string{uuid.NewString()},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: no permissions provided",
+			args: args{
+				ctx:    context.Background(),
+				roleID: uuid.NewString(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: role not found",
+			args: args{
+				ctx:           context.Background(),
+				roleID:        uuid.NewString(),
+				permissionIDs: []string{uuid.NewString()},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: role belongs to another program",
+			args: args{
+				ctx:           context.Background(),
+				roleID:        uuid.NewString(),
+				permissionIDs: []string{uuid.NewString()},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: system role",
+			args: args{
+				ctx:           context.Background(),
+				roleID:        uuid.NewString(),
+				permissionIDs: []string{uuid.NewString()},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: default role",
+			args: args{
+				ctx:           context.Background(),
+				roleID:        uuid.NewString(),
+				permissionIDs: []string{uuid.NewString()},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: failed to remove permissions",
+			args: args{
+				ctx:           context.Background(),
+				roleID:        uuid.NewString(),
+				permissionIDs: []string{uuid.NewString()},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			u := authority.NewUsecaseAuthority(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeNotification)
+
+			fakeDB.MockGetStaffProfileFn = func(ctx context.Context, userID string, _ string) (*domain.StaffProfile, error) {
+				return &domain.StaffProfile{ID: &userID, ProgramID: programID}, nil
+			}
+			fakeDB.MockGetAuthorityRoleByIDFn = func(ctx context.Context, roleID string) (*domain.AuthorityRole, error) {
+				return &domain.AuthorityRole{AuthorityRoleID: roleID, Name: gofakeit.JobTitle(), ProgramID: programID}, nil
+			}
+
+			if tt.name == "Sad case: role belongs to another program" {
+				fakeDB.MockGetAuthorityRoleByIDFn = func(ctx context.Context, roleID string) (*domain.AuthorityRole, error) {
+					return &domain.AuthorityRole{AuthorityRoleID: roleID, Name: gofakeit.JobTitle(), ProgramID: uuid.NewString()}, nil
+				}
+			}
+			if tt.name == "Sad case: system role" {
+				fakeDB.MockGetAuthorityRoleByIDFn = func(ctx context.Context, roleID string) (*domain.AuthorityRole, error) {
+					return &domain.AuthorityRole{AuthorityRoleID: roleID, Name: gofakeit.JobTitle(), ProgramID: programID, IsSystemRole: true}, nil
+				}
+			}
+			if tt.name == "Sad case: default role" {
+				fakeDB.MockGetAuthorityRoleByIDFn = func(ctx context.Context, roleID string) (*domain.AuthorityRole, error) {
+					return &domain.AuthorityRole{AuthorityRoleID: roleID, Name: string(authorization.DefaultRoleAdmin), ProgramID: programID}, nil
+				}
+			}
+
+			if tt.name == "Sad case: role not found" {
+				fakeDB.MockGetAuthorityRoleByIDFn = func(ctx context.Context, roleID string) (*domain.AuthorityRole, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: failed to remove permissions" {
+				fakeDB.MockRemovePermissionsFromRoleFn = func(ctx context.Context, roleID string, permissionIDs []string) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+
+			_, err := u.RemovePermissionsFromRole(tt.args.ctx, tt.args.roleID, tt.args.permissionIDs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UsecaseAuthorityImpl.RemovePermissionsFromRole() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUsecaseAuthorityImpl_SeedDefaultRoles(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{
+			name:    "Happy case: seed default roles",
+			wantErr: false,
+		},
+		{
+			name:    "Sad case: failed to seed default roles",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: failed to assign default roles",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			u := authority.NewUsecaseAuthority(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeNotification)
+
+			var seededRoles []domain.AuthorityRole
+			fakeDB.MockSeedAuthorityRolesFn = func(ctx context.Context, roles []domain.AuthorityRole) error {
+				seededRoles = roles
+				return nil
+			}
+
+			if tt.name == "Sad case: failed to seed default roles" {
+				fakeDB.MockSeedAuthorityRolesFn = func(ctx context.Context, roles []domain.AuthorityRole) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: failed to assign default roles" {
+				fakeDB.MockAssignDefaultRolesFn = func(ctx context.Context) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+
+			err := u.SeedDefaultRoles(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UsecaseAuthorityImpl.SeedDefaultRoles() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.name == "Happy case: seed default roles" {
+				for _, role := range seededRoles {
+					if !role.IsSystemRole || !authorization.DefaultRole(role.Name).IsValid() {
+						t.Errorf("expected %s to be seeded as a default system role", role.Name)
+					}
+				}
+				if len(seededRoles) != 4 {
+					t.Errorf("expected 4 default roles to be seeded, got %d", len(seededRoles))
+				}
+			}
+		})
+	}
+}
+
+func TestUsecaseAuthorityImpl_AssignRoles(t *testing.T) {
+	programID := uuid.NewString()
+
+	type args struct {
+		ctx   context.Context
+		input dto.RoleAssignmentInput
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case: assign roles to staff",
+			args: args{
+				ctx: context.Background(),
+				input: dto.RoleAssignmentInput{
+					UserType:  enums.StaffUser,
+					ProfileID: uuid.NewString(),
+					RoleIDs:   []string{uuid.NewString()},
+				},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Happy case: assign roles to client",
+			args: args{
+				ctx: context.Background(),
+				input: dto.RoleAssignmentInput{
+					UserType:  enums.ClientUser,
+					ProfileID: uuid.NewString(),
+					RoleIDs:   []string{uuid.NewString()},
+				},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Happy case: assign roles to caregiver",
+			args: args{
+				ctx: context.Background(),
+				input: dto.RoleAssignmentInput{
+					UserType:  enums.CaregiverUser,
+					ProfileID: uuid.NewString(),
+					RoleIDs:   []string{uuid.NewString()},
+				},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Happy case: failure to record audit log does not fail the assignment",
+			args: args{
+				ctx: context.Background(),
+				input: dto.RoleAssignmentInput{
+					UserType:  enums.StaffUser,
+					ProfileID: uuid.NewString(),
+					RoleIDs:   []string{uuid.NewString()},
+				},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case: invalid input",
+			args: args{
+				ctx: context.Background(),
+				input: dto.RoleAssignmentInput{
+					UserType: enums.StaffUser,
+				},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case: unsupported user type",
+			args: args{
+				ctx: context.Background(),
+				input: dto.RoleAssignmentInput{
+					UserType:  enums.HealthcareWorkerUser,
+					ProfileID: uuid.NewString(),
+					RoleIDs:   []string{uuid.NewString()},
+				},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case: failed to get staff profile",
+			args: args{
+				ctx: context.Background(),
+				input: dto.RoleAssignmentInput{
+					UserType:  enums.StaffUser,
+					ProfileID: uuid.NewString(),
+					RoleIDs:   []string{uuid.NewString()},
+				},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case: role belongs to a different user type",
+			args: args{
+				ctx: context.Background(),
+				input: dto.RoleAssignmentInput{
+					UserType:  enums.StaffUser,
+					ProfileID: uuid.NewString(),
+					RoleIDs:   []string{uuid.NewString()},
+				},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case: role belongs to a different program",
+			args: args{
+				ctx: context.Background(),
+				input: dto.RoleAssignmentInput{
+					UserType:  enums.StaffUser,
+					ProfileID: uuid.NewString(),
+					RoleIDs:   []string{uuid.NewString()},
+				},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case: role is not in the logged in staff's program",
+			args: args{
+				ctx: context.Background(),
+				input: dto.RoleAssignmentInput{
+					UserType:  enums.CaregiverUser,
+					ProfileID: uuid.NewString(),
+					RoleIDs:   []string{uuid.NewString()},
+				},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case: failed to get logged in staff profile",
+			args: args{
+				ctx: context.Background(),
+				input: dto.RoleAssignmentInput{
+					UserType:  enums.StaffUser,
+					ProfileID: uuid.NewString(),
+					RoleIDs:   []string{uuid.NewString()},
+				},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case: staff assigns roles to their own profile",
+			args: args{
+				ctx: context.Background(),
+				input: dto.RoleAssignmentInput{
+					UserType:  enums.StaffUser,
+					ProfileID: uuid.NewString(),
+					RoleIDs:   []string{uuid.NewString()},
+				},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case: role grants a permission the logged in staff does not hold",
+			args: args{
+				ctx: context.Background(),
+				input: dto.RoleAssignmentInput{
+					UserType:  enums.StaffUser,
+					ProfileID: uuid.NewString(),
+					RoleIDs:   []string{uuid.NewString()},
+				},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case: failed to assign roles",
+			args: args{
+				ctx: context.Background(),
+				input: dto.RoleAssignmentInput{
+					UserType:  enums.StaffUser,
+					ProfileID: uuid.NewString(),
+					RoleIDs:   []string{uuid.NewString()},
+				},
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			u := authority.NewUsecaseAuthority(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeNotification)
+
+			fakeDB.MockGetStaffProfileFn = func(ctx context.Context, userID string, _ string) (*domain.StaffProfile, error) {
+				return &domain.StaffProfile{ID: &userID, ProgramID: programID}, nil
+			}
+
+			fakeDB.MockGetStaffProfileByStaffIDFn = func(ctx context.Context, staffID string) (*domain.StaffProfile, error) {
+				return &domain.StaffProfile{ID: &staffID, User: &domain.User{Name: gofakeit.Name()}, ProgramID: programID}, nil
+			}
+			fakeDB.MockGetClientProfileByClientIDFn = func(ctx context.Context, clientID string) (*domain.ClientProfile, error) {
+				return &domain.ClientProfile{ID: &clientID, User: &domain.User{Name: gofakeit.Name()}, ProgramID: programID}, nil
+			}
+			fakeDB.MockGetAuthorityRoleByIDFn = func(ctx context.Context, roleID string) (*domain.AuthorityRole, error) {
+				return &domain.AuthorityRole{
+					AuthorityRoleID: roleID,
+					Name:            gofakeit.JobTitle(),
+					UserType:        tt.args.input.UserType,
+					ProgramID:       programID,
+				}, nil
+			}
+
+			if tt.name == "Happy case: failure to record audit log does not fail the assignment" {
+				fakeDB.MockCreateAuditLogFn = func(ctx context.Context, auditLog *domain.AuditLog) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: failed to get staff profile" {
+				fakeDB.MockGetStaffProfileByStaffIDFn = func(ctx context.Context, staffID string) (*domain.StaffProfile, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: role belongs to a different user type" {
+				fakeDB.MockGetAuthorityRoleByIDFn = func(ctx context.Context, roleID string) (*domain.AuthorityRole, error) {
+					return &domain.AuthorityRole{AuthorityRoleID: roleID, UserType: enums.ClientUser, ProgramID: programID}, nil
+				}
+			}
+			if tt.name == "Sad case: role belongs to a different program" {
+				fakeDB.MockGetAuthorityRoleByIDFn = func(ctx context.Context, roleID string) (*domain.AuthorityRole, error) {
+					return &domain.AuthorityRole{AuthorityRoleID: roleID, UserType: enums.StaffUser, ProgramID: uuid.NewString()}, nil
+				}
+			}
+			if tt.name == "Sad case: role is not in the logged in staff's program" {
+				fakeDB.MockGetStaffProfileFn = func(ctx context.Context, userID string, _ string) (*domain.StaffProfile, error) {
+					return &domain.StaffProfile{ID: &userID, ProgramID: uuid.NewString()}, nil
+				}
+			}
+			if tt.name == "Sad case: failed to get logged in staff profile" {
+				fakeDB.MockGetStaffProfileFn = func(ctx context.Context, userID string, _ string) (*domain.StaffProfile, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: staff assigns roles to their own profile" {
+				userID := uuid.NewString()
+				fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+					return userID, nil
+				}
+				fakeDB.MockGetStaffProfileByStaffIDFn = func(ctx context.Context, staffID string) (*domain.StaffProfile, error) {
+					return &domain.StaffProfile{ID: &staffID, User: &domain.User{ID: &userID, Name: gofakeit.Name()}, ProgramID: programID}, nil
+				}
+			}
+			if tt.name == "Sad case: role grants a permission the logged in staff does not hold" {
+				fakeDB.MockGetAuthorityRoleByIDFn = func(ctx context.Context, roleID string) (*domain.AuthorityRole, error) {
+					return &domain.AuthorityRole{
+						AuthorityRoleID: roleID,
+						UserType:        enums.StaffUser,
+						ProgramID:       programID,
+						Permissions:     []domain.AuthorityPermission{{PermissionID: uuid.NewString(), Active: true}},
+					}, nil
+				}
+				fakeDB.MockGetUserPermissionsFn = func(ctx context.Context, userID string, programID string) ([]*domain.AuthorityPermission, error) {
+					return []*domain.AuthorityPermission{}, nil
+				}
+			}
+			if tt.name == "Sad case: failed to assign roles" {
+				fakeDB.MockAssignRolesFn = func(ctx context.Context, userType enums.UsersType, profileID string, roleIDs []string) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := u.AssignRoles(tt.args.ctx, tt.args.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UsecaseAuthorityImpl.AssignRoles() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UsecaseAuthorityImpl.AssignRoles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUsecaseAuthorityImpl_RevokeRoles(t *testing.T) {
+	programID := uuid.NewString()
+
+	type args struct {
+		ctx   context.Context
+		input dto.RoleAssignmentInput
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case: revoke roles from caregiver",
+			args: args{
+				ctx: context.Background(),
+				input: dto.RoleAssignmentInput{
+					UserType:  enums.CaregiverUser,
+					ProfileID: uuid.NewString(),
+					RoleIDs:   []string{uuid.NewString()},
+				},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case: invalid input",
+			args: args{
+				ctx: context.Background(),
+				input: dto.RoleAssignmentInput{
+					UserType: enums.CaregiverUser,
+				},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case: failed to get caregiver profile",
+			args: args{
+				ctx: context.Background(),
+				input: dto.RoleAssignmentInput{
+					UserType:  enums.CaregiverUser,
+					ProfileID: uuid.NewString(),
+					RoleIDs:   []string{uuid.NewString()},
+				},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case: role is not in the logged in staff's program",
+			args: args{
+				ctx: context.Background(),
+				input: dto.RoleAssignmentInput{
+					UserType:  enums.CaregiverUser,
+					ProfileID: uuid.NewString(),
+					RoleIDs:   []string{uuid.NewString()},
+				},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad case: failed to revoke roles",
+			args: args{
+				ctx: context.Background(),
+				input: dto.RoleAssignmentInput{
+					UserType:  enums.CaregiverUser,
+					ProfileID: uuid.NewString(),
+					RoleIDs:   []string{uuid.NewString()},
+				},
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			u := authority.NewUsecaseAuthority(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeNotification)
+
+			fakeDB.MockGetStaffProfileFn = func(ctx context.Context, userID string, _ string) (*domain.StaffProfile, error) {
+				return &domain.StaffProfile{ID: &userID, ProgramID: programID}, nil
+			}
+
+			fakeDB.MockGetAuthorityRoleByIDFn = func(ctx context.Context, roleID string) (*domain.AuthorityRole, error) {
+				return &domain.AuthorityRole{AuthorityRoleID: roleID, Name: gofakeit.JobTitle(), UserType: enums.CaregiverUser, ProgramID: programID}, nil
+			}
+
+			if tt.name == "Sad case: failed to get caregiver profile" {
+				fakeDB.MockGetCaregiverProfileByCaregiverIDFn = func(ctx context.Context, caregiverID string) (*domain.CaregiverProfile, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: role is not in the logged in staff's program" {
+				fakeDB.MockGetStaffProfileFn = func(ctx context.Context, userID string, _ string) (*domain.StaffProfile, error) {
+					return &domain.StaffProfile{ID: &userID, ProgramID: uuid.NewString()}, nil
+				}
+			}
+			if tt.name == "Sad case: failed to revoke roles" {
+				fakeDB.MockRevokeRolesFn = func(ctx context.Context, userType enums.UsersType, profileID string, roleIDs []string) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := u.RevokeRoles(tt.args.ctx, tt.args.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UsecaseAuthorityImpl.RevokeRoles() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UsecaseAuthorityImpl.RevokeRoles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUsecaseAuthorityImpl_GetProfileRoles(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{
+			name:    "Happy case: get profile roles",
+			wantErr: false,
+		},
+		{
+			name:    "Sad case: failed to get profile roles",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: profile belongs to another program",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: failed to get staff profile",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			u := authority.NewUsecaseAuthority(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeNotification)
+
+			if tt.name == "Sad case: profile belongs to another program" {
+				fakeDB.MockGetStaffProfileByStaffIDFn = func(ctx context.Context, staffID string) (*domain.StaffProfile, error) {
+					return &domain.StaffProfile{ID: &staffID, User: &domain.User{Name: gofakeit.Name()}, ProgramID: uuid.NewString()}, nil
+				}
+			}
+			if tt.name == "Sad case: failed to get staff profile" {
+				fakeDB.MockGetStaffProfileByStaffIDFn = func(ctx context.Context, staffID string) (*domain.StaffProfile, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: failed to get profile roles" {
+				fakeDB.MockGetProfileRolesFn = func(ctx context.Context, userType enums.UsersType, profileID string) ([]*domain.AuthorityRole, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			_, err := u.GetProfileRoles(context.Background(), enums.StaffUser, uuid.NewString())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UsecaseAuthorityImpl.GetProfileRoles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,11 +1,133 @@
 package mock
 
+import (
+	"context"
+
+	"github.com/brianvoe/gofakeit"
+	"github.com/google/uuid"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+)
+
 // AuthorityUseCaseMock mocks the implementation of usecase methods.
 type AuthorityUseCaseMock struct {
+	MockCheckUserPermissionFn       func(ctx context.Context, scope string) (bool, error)
+	MockCreateAuthorityRoleFn       func(ctx context.Context, input dto.AuthorityRoleInput) (*domain.AuthorityRole, error)
+	MockListAuthorityRolesFn        func(ctx context.Context, programID string) ([]*domain.AuthorityRole, error)
+	MockListAuthorityPermissionsFn  func(ctx context.Context) ([]*domain.AuthorityPermission, error)
+	MockAddPermissionsToRoleFn      func(ctx context.Context, roleID string, permissionIDs []string) (*domain.AuthorityRole, error)
+	MockRemovePermissionsFromRoleFn func(ctx context.Context, roleID string, permissionIDs []string) (*domain.AuthorityRole, error)
+	MockSeedDefaultRolesFn          func(ctx context.Context) error
+	MockAssignRolesFn               func(ctx context.Context, input dto.RoleAssignmentInput) (bool, error)
+	MockRevokeRolesFn               func(ctx context.Context, input dto.RoleAssignmentInput) (bool, error)
+	MockGetProfileRolesFn           func(ctx context.Context, userType enums.UsersType, profileID string) ([]*domain.AuthorityRole, error)
 }
 
 // NewAuthorityUseCaseMock creates in initializes create type mocks
 func NewAuthorityUseCaseMock() *AuthorityUseCaseMock {
+	ID := uuid.New().String()
+
+	permission := domain.AuthorityPermission{
+		PermissionID: ID,
+		Active:       true,
+		Name:         "delete facility",
+		Description:  "a user with this permission can delete a facility",
+		Category:     "facility",
+		Scope:        "facility.delete",
+	}
+
+	role := &domain.AuthorityRole{
+		AuthorityRoleID: ID,
+		Name:            gofakeit.JobTitle(),
+		Description:     gofakeit.Sentence(5),
+		Active:          true,
+		UserType:        enums.StaffUser,
+		OrganisationID:  ID,
+		ProgramID:       ID,
+		Permissions:     []domain.AuthorityPermission{permission},
+	}
+
+	return &AuthorityUseCaseMock{
+		MockCheckUserPermissionFn: func(ctx context.Context, scope string) (bool, error) {
+			return true, nil
+		},
+		MockCreateAuthorityRoleFn: func(ctx context.Context, input dto.AuthorityRoleInput) (*domain.AuthorityRole, error) {
+			return role, nil
+		},
+		MockListAuthorityRolesFn: func(ctx context.Context, programID string) ([]*domain.AuthorityRole, error) {
+			return []*domain.AuthorityRole{role}, nil
+		},
+		MockListAuthorityPermissionsFn: func(ctx context.Context) ([]*domain.AuthorityPermission, error) {
+			return []*domain.AuthorityPermission{&permission}, nil
+		},
+		MockAddPermissionsToRoleFn: func(ctx context.Context, roleID string, permissionIDs []string) (*domain.AuthorityRole, error) {
+			return role, nil
+		},
+		MockRemovePermissionsFromRoleFn: func(ctx context.Context, roleID string, permissionIDs []string) (*domain.AuthorityRole, error) {
+			return role, nil
+		},
+		MockSeedDefaultRolesFn: func(ctx context.Context) error {
+			return nil
+		},
+		MockAssignRolesFn: func(ctx context.Context, input dto.RoleAssignmentInput) (bool, error) {
+			return true, nil
+		},
+		MockRevokeRolesFn: func(ctx context.Context, input dto.RoleAssignmentInput) (bool, error) {
+			return true, nil
+		},
+		MockGetProfileRolesFn: func(ctx context.Context, userType enums.UsersType, profileID string) ([]*domain.AuthorityRole, error) {
+			return []*domain.AuthorityRole{role}, nil
+		},
+	}
+}
+
+// CheckUserPermission mocks the implementation of checking whether a user has a permission
+func (a *AuthorityUseCaseMock) CheckUserPermission(ctx context.Context, scope string) (bool, error) {
+	return a.MockCheckUserPermissionFn(ctx, scope)
+}
+
+// CreateAuthorityRole mocks the implementation of creating a role
+func (a *AuthorityUseCaseMock) CreateAuthorityRole(ctx context.Context, input dto.AuthorityRoleInput) (*domain.AuthorityRole, error) {
+	return a.MockCreateAuthorityRoleFn(ctx, input)
+}
+
+// ListAuthorityRoles mocks the implementation of listing the roles in a program
+func (a *AuthorityUseCaseMock) ListAuthorityRoles(ctx context.Context, programID string) ([]*domain.AuthorityRole, error) {
+	return a.MockListAuthorityRolesFn(ctx, programID)
+}
+
+// ListAuthorityPermissions mocks the implementation of listing permissions
+func (a *AuthorityUseCaseMock) ListAuthorityPermissions(ctx context.Context) ([]*domain.AuthorityPermission, error) {
+	return a.MockListAuthorityPermissionsFn(ctx)
+}
+
+// AddPermissionsToRole mocks the implementation of granting permissions to a role
+func (a *AuthorityUseCaseMock) AddPermissionsToRole(ctx context.Context, roleID string, permissionIDs []string) (*domain.AuthorityRole, error) {
+	return a.MockAddPermissionsToRoleFn(ctx, roleID, permissionIDs)
+}
+
+// RemovePermissionsFromRole mocks the implementation of revoking permissions from a role
+func (a *AuthorityUseCaseMock) RemovePermissionsFromRole(ctx context.Context, roleID string, permissionIDs []string) (*domain.AuthorityRole, error) {
+	return a.MockRemovePermissionsFromRoleFn(ctx, roleID, permissionIDs)
+}
+
+// SeedDefaultRoles mocks the implementation of seeding the default roles in every program
+func (a *AuthorityUseCaseMock) SeedDefaultRoles(ctx context.Context) error {
+	return a.MockSeedDefaultRolesFn(ctx)
+}
+
+// AssignRoles mocks the implementation of assigning roles to a profile
+func (a *AuthorityUseCaseMock) AssignRoles(ctx context.Context, input dto.RoleAssignmentInput) (bool, error) {
+	return a.MockAssignRolesFn(ctx, input)
+}
+
+// RevokeRoles mocks the implementation of revoking roles from a profile
+func (a *AuthorityUseCaseMock) RevokeRoles(ctx context.Context, input dto.RoleAssignmentInput) (bool, error) {
+	return a.MockRevokeRolesFn(ctx, input)
+}
 
-	return &AuthorityUseCaseMock{}
+// GetProfileRoles mocks the implementation of retrieving a profile's roles
+func (a *AuthorityUseCaseMock) GetProfileRoles(ctx context.Context, userType enums.UsersType, profileID string) ([]*domain.AuthorityRole, error) {
+	return a.MockGetProfileRolesFn(ctx, userType, profileID)
 }
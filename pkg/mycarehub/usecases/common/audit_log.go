@@ -0,0 +1,18 @@
+package common
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure"
+)
+
+// RecordAuditLog persists an audit trail entry for a sensitive action.
+// A failure to record the entry is reported but does not fail the action itself.
+func RecordAuditLog(ctx context.Context, create infrastructure.Create, auditLog *domain.AuditLog) {
+	if err := create.CreateAuditLog(ctx, auditLog); err != nil {
+		helpers.ReportErrorToSentry(fmt.Errorf("failed to record %s audit log: %w", auditLog.RecordType, err))
+	}
+}
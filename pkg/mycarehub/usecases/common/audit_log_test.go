@@ -0,0 +1,54 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	pgMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/mock"
+)
+
+func TestRecordAuditLog(t *testing.T) {
+	tests := []struct {
+		name string
+	}{
+		{
+			name: "Happy case: record audit log",
+		},
+		{
+			name: "Sad case: failed to record audit log",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+
+			auditLog := &domain.AuditLog{
+				RecordType: enums.AuditLogPINReset,
+				TargetID:   uuid.New().String(),
+				TargetType: enums.AuditLogTargetUser,
+			}
+
+			var recorded *domain.AuditLog
+			fakeDB.MockCreateAuditLogFn = func(ctx context.Context, log *domain.AuditLog) error {
+				recorded = log
+				return nil
+			}
+
+			if tt.name == "Sad case: failed to record audit log" {
+				fakeDB.MockCreateAuditLogFn = func(ctx context.Context, log *domain.AuditLog) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+
+			RecordAuditLog(context.Background(), fakeDB, auditLog)
+
+			if tt.name == "Happy case: record audit log" && recorded != auditLog {
+				t.Errorf("RecordAuditLog() expected the audit log to be recorded")
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/savannahghi/firebasetools"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure"
+)
+
+// CheckCaregiverAbility ensures that the logged in user can act on behalf of a client.
+//
+// The client and the staff of the client's program are authorized by their own permissions. Any other user must be
+// one of the client's caregivers and must have been delegated the ability by the client.
+func CheckCaregiverAbility(ctx context.Context, query infrastructure.Query, clientID string, ability enums.CaregiverAbility) error {
+	userID, err := firebasetools.GetLoggedInUserUID(ctx)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return exceptions.GetLoggedInUserUIDErr(err)
+	}
+
+	client, err := query.GetClientProfileByClientID(ctx, clientID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return exceptions.ClientProfileNotFoundErr(err)
+	}
+
+	if client.UserID == userID {
+		return nil
+	}
+
+	isStaff, err := query.CheckStaffExistsInProgram(ctx, userID, client.ProgramID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return exceptions.InternalErr(fmt.Errorf("failed to check if the user is a staff in the client's program: %w", err))
+	}
+
+	if isStaff {
+		return nil
+	}
+
+	isCaregiver, err := query.CheckCaregiverExists(ctx, userID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return exceptions.InternalErr(fmt.Errorf("failed to check if the user is a caregiver: %w", err))
+	}
+
+	if !isCaregiver {
+		err := fmt.Errorf("user is not allowed to act on behalf of client %s", clientID)
+		helpers.ReportErrorToSentry(err)
+		return exceptions.UserNotAuthorizedErr(err)
+	}
+
+	caregiver, err := query.GetCaregiverByUserID(ctx, userID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return exceptions.InternalErr(fmt.Errorf("failed to get caregiver profile: %w", err))
+	}
+
+	delegations, err := query.GetCaregiversClient(ctx, domain.CaregiverClient{ClientID: clientID, CaregiverID: caregiver.ID})
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return exceptions.InternalErr(fmt.Errorf("failed to get caregiver's delegation: %w", err))
+	}
+
+	if len(delegations) == 0 {
+		err := fmt.Errorf("caregiver is not linked to client %s", clientID)
+		helpers.ReportErrorToSentry(err)
+		return exceptions.UserNotAuthorizedErr(err)
+	}
+
+	if !delegations[0].Allows(ability, time.Now()) {
+		err := fmt.Errorf("caregiver has not been delegated the %s ability by the client", ability)
+		helpers.ReportErrorToSentry(err)
+		return exceptions.UserNotAuthorizedErr(err)
+	}
+
+	return nil
+}
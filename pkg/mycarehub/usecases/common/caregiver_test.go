@@ -0,0 +1,230 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"firebase.google.com/go/auth"
+	"github.com/google/uuid"
+	"github.com/savannahghi/firebasetools"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	pgMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/mock"
+)
+
+func TestCheckCaregiverAbility(t *testing.T) {
+	userID := uuid.New().String()
+	ctx := context.WithValue(context.Background(), firebasetools.AuthTokenContextKey, &auth.Token{UID: userID})
+
+	type args struct {
+		ctx      context.Context
+		clientID string
+		ability  enums.CaregiverAbility
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: caregiver has been delegated the ability",
+			args: args{
+				ctx:      ctx,
+				clientID: uuid.New().String(),
+				ability:  enums.CaregiverAbilityViewAppointments,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case: logged in user is the client",
+			args: args{
+				ctx:      ctx,
+				clientID: uuid.New().String(),
+				ability:  enums.CaregiverAbilityViewAppointments,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case: logged in user is a staff in the client's program",
+			args: args{
+				ctx:      ctx,
+				clientID: uuid.New().String(),
+				ability:  enums.CaregiverAbilityViewAppointments,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: logged in user is not a caregiver",
+			args: args{
+				ctx:      ctx,
+				clientID: uuid.New().String(),
+				ability:  enums.CaregiverAbilityViewAppointments,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: caregiver is not linked to the client",
+			args: args{
+				ctx:      ctx,
+				clientID: uuid.New().String(),
+				ability:  enums.CaregiverAbilityViewAppointments,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: failed to get client profile",
+			args: args{
+				ctx:      ctx,
+				clientID: uuid.New().String(),
+				ability:  enums.CaregiverAbilityViewAppointments,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: failed to check if user is a staff",
+			args: args{
+				ctx:      ctx,
+				clientID: uuid.New().String(),
+				ability:  enums.CaregiverAbilityViewAppointments,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: failed to get logged in user",
+			args: args{
+				ctx:      context.Background(),
+				clientID: uuid.New().String(),
+				ability:  enums.CaregiverAbilityViewAppointments,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: failed to check if user is a caregiver",
+			args: args{
+				ctx:      ctx,
+				clientID: uuid.New().String(),
+				ability:  enums.CaregiverAbilityViewAppointments,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: failed to get caregiver profile",
+			args: args{
+				ctx:      ctx,
+				clientID: uuid.New().String(),
+				ability:  enums.CaregiverAbilityViewAppointments,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: failed to get caregiver's delegation",
+			args: args{
+				ctx:      ctx,
+				clientID: uuid.New().String(),
+				ability:  enums.CaregiverAbilityViewAppointments,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: caregiver has not been delegated the ability",
+			args: args{
+				ctx:      ctx,
+				clientID: uuid.New().String(),
+				ability:  enums.CaregiverAbilityViewScreeningResults,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: client revoked the delegation",
+			args: args{
+				ctx:      ctx,
+				clientID: uuid.New().String(),
+				ability:  enums.CaregiverAbilityViewAppointments,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+
+			fakeDB.MockGetClientProfileByClientIDFn = func(ctx context.Context, clientID string) (*domain.ClientProfile, error) {
+				return &domain.ClientProfile{ID: &clientID, UserID: uuid.New().String(), ProgramID: uuid.New().String()}, nil
+			}
+
+			if tt.name == "Happy case: logged in user is the client" {
+				fakeDB.MockGetClientProfileByClientIDFn = func(ctx context.Context, clientID string) (*domain.ClientProfile, error) {
+					return &domain.ClientProfile{ID: &clientID, UserID: userID, ProgramID: uuid.New().String()}, nil
+				}
+			}
+			if tt.name == "Happy case: logged in user is a staff in the client's program" {
+				fakeDB.MockCheckStaffExistsInProgramFn = func(ctx context.Context, userID, programID string) (bool, error) {
+					return true, nil
+				}
+			}
+			if tt.name == "Sad case: logged in user is not a caregiver" {
+				fakeDB.MockCheckCaregiverExistsFn = func(ctx context.Context, userID string) (bool, error) {
+					return false, nil
+				}
+			}
+			if tt.name == "Sad case: failed to get client profile" {
+				fakeDB.MockGetClientProfileByClientIDFn = func(ctx context.Context, clientID string) (*domain.ClientProfile, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: failed to check if user is a staff" {
+				fakeDB.MockCheckStaffExistsInProgramFn = func(ctx context.Context, userID, programID string) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: caregiver is not linked to the client" {
+				fakeDB.MockGetCaregiversClientFn = func(ctx context.Context, caregiverClient domain.CaregiverClient) ([]*domain.CaregiverClient, error) {
+					return []*domain.CaregiverClient{}, nil
+				}
+			}
+			if tt.name == "Sad case: failed to check if user is a caregiver" {
+				fakeDB.MockCheckCaregiverExistsFn = func(ctx context.Context, userID string) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: failed to get caregiver profile" {
+				fakeDB.MockGetCaregiverByUserIDFn = func(ctx context.Context, userID string) (*domain.Caregiver, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: failed to get caregiver's delegation" {
+				fakeDB.MockGetCaregiversClientFn = func(ctx context.Context, caregiverClient domain.CaregiverClient) ([]*domain.CaregiverClient, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: caregiver has not been delegated the ability" {
+				fakeDB.MockGetCaregiversClientFn = func(ctx context.Context, caregiverClient domain.CaregiverClient) ([]*domain.CaregiverClient, error) {
+					return []*domain.CaregiverClient{
+						{
+							ClientConsent: enums.ConsentStateAccepted,
+							Abilities:     []enums.CaregiverAbility{enums.CaregiverAbilityViewAppointments},
+						},
+					}, nil
+				}
+			}
+			if tt.name == "Sad case: client revoked the delegation" {
+				fakeDB.MockGetCaregiversClientFn = func(ctx context.Context, caregiverClient domain.CaregiverClient) ([]*domain.CaregiverClient, error) {
+					revokedAt := time.Now().Add(-time.Hour)
+					return []*domain.CaregiverClient{
+						{
+							ClientConsent: enums.ConsentStateAccepted,
+							Abilities:     enums.AllCaregiverAbilities,
+							RevokedAt:     &revokedAt,
+						},
+					}, nil
+				}
+			}
+
+			if err := CheckCaregiverAbility(tt.args.ctx, fakeDB, tt.args.clientID, tt.args.ability); (err != nil) != tt.wantErr {
+				t.Errorf("CheckCaregiverAbility() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
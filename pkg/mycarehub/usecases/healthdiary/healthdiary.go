@@ -13,6 +13,7 @@ import (
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/common"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/servicerequest"
 )
 
@@ -103,6 +104,22 @@ func (h UseCasesHealthDiaryImpl) CreateHealthDiaryEntry(
 		return false, fmt.Errorf("error querying client profile: %w", err)
 	}
 
+	if err := common.CheckCaregiverAbility(ctx, h.Query, clientID, enums.CaregiverAbilityRecordHealthDiary); err != nil {
+		return false, err
+	}
+
+	if caregiverID != nil {
+		delegations, err := h.Query.GetCaregiversClient(ctx, domain.CaregiverClient{ClientID: clientID, CaregiverID: *caregiverID})
+		if err != nil {
+			helpers.ReportErrorToSentry(err)
+			return false, fmt.Errorf("error querying caregiver's delegation: %w", err)
+		}
+
+		if len(delegations) != 1 || !delegations[0].Allows(enums.CaregiverAbilityRecordHealthDiary, currentTime) {
+			return false, fmt.Errorf("caregiver is not allowed to record health diary entries for the client")
+		}
+	}
+
 	switch mood {
 	case enums.MoodVerySad.String():
 		healthDiaryEntry := &domain.ClientHealthDiaryEntry{
@@ -7,8 +7,10 @@ import (
 	"testing"
 	"time"
 
+	"firebase.google.com/go/auth"
 	"github.com/brianvoe/gofakeit"
 	"github.com/google/uuid"
+	"github.com/savannahghi/firebasetools"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
@@ -19,7 +21,7 @@ import (
 )
 
 func TestUseCasesHealthDiaryImpl_CreateHealthDiaryEntry(t *testing.T) {
-	ctx := context.Background()
+	ctx := context.WithValue(context.Background(), firebasetools.AuthTokenContextKey, &auth.Token{UID: uuid.New().String()})
 	note := gofakeit.HipsterSentence(20)
 	id := uuid.NewString()
 	type args struct {
@@ -121,6 +123,56 @@ func TestUseCasesHealthDiaryImpl_CreateHealthDiaryEntry(t *testing.T) {
 			want:    false,
 			wantErr: true,
 		},
+		{
+			name: "Sad Case - Failed to get caregiver's delegation",
+			args: args{
+				ctx:           ctx,
+				clientID:      uuid.New().String(),
+				note:          &note,
+				mood:          enums.MoodSad.String(),
+				reportToStaff: false,
+				caregiverID:   &id,
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad Case - Caregiver not delegated to record health diary entries",
+			args: args{
+				ctx:           ctx,
+				clientID:      uuid.New().String(),
+				note:          &note,
+				mood:          enums.MoodSad.String(),
+				reportToStaff: false,
+				caregiverID:   &id,
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad Case - Failed to get logged in user",
+			args: args{
+				ctx:           context.Background(),
+				clientID:      uuid.New().String(),
+				note:          &note,
+				mood:          enums.MoodSad.String(),
+				reportToStaff: false,
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad Case - Logged in caregiver not delegated to record health diary entries",
+			args: args{
+				ctx:           ctx,
+				clientID:      uuid.New().String(),
+				note:          &note,
+				mood:          enums.MoodSad.String(),
+				reportToStaff: false,
+			},
+			want:    false,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -128,6 +180,24 @@ func TestUseCasesHealthDiaryImpl_CreateHealthDiaryEntry(t *testing.T) {
 			fakeServiceRequest := serviceRequestMock.NewServiceRequestUseCaseMock()
 			_ = mock.NewHealthDiaryUseCaseMock()
 
+			if tt.name == "Sad Case - Failed to get caregiver's delegation" {
+				fakeDB.MockGetCaregiversClientFn = func(ctx context.Context, caregiverClient domain.CaregiverClient) ([]*domain.CaregiverClient, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			if tt.name == "Sad Case - Caregiver not delegated to record health diary entries" || tt.name == "Sad Case - Logged in caregiver not delegated to record health diary entries" {
+				fakeDB.MockGetCaregiversClientFn = func(ctx context.Context, caregiverClient domain.CaregiverClient) ([]*domain.CaregiverClient, error) {
+					return []*domain.CaregiverClient{
+						{
+							CaregiverID:   id,
+							ClientConsent: enums.ConsentStateAccepted,
+							Abilities:     []enums.CaregiverAbility{enums.CaregiverAbilityViewAppointments},
+						},
+					}, nil
+				}
+			}
+
 			if tt.name == "Sad Case - Fail to create healthdiary entry for happy mood" {
 				fakeDB.MockCreateHealthDiaryEntryFn = func(ctx context.Context, healthDiaryInput *domain.ClientHealthDiaryEntry) (*domain.ClientHealthDiaryEntry, error) {
 					return nil, fmt.Errorf("failed to create health diary entry")
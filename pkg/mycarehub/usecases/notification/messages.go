@@ -17,6 +17,20 @@ type StaffNotificationArgs struct {
 
 	// Arguments for a service request notification
 	ServiceRequestType *enums.ServiceRequestType
+
+	// Arguments for a role assignment/revocation notification
+	Role *domain.AuthorityRole
+
+	// Arguments for a staff offboarding notification sent to the organisation admins
+	Offboarding *domain.StaffOffboarding
+
+	// Arguments for a client transfer notification sent to the staff of the receiving facility
+	Transfer        *domain.ClientTransfer
+	TransferredFrom *domain.Facility
+
+	// Arguments for a service request escalation notification sent when a service request breaches its SLA
+	ServiceRequest *domain.ServiceRequest
+	Escalation     *domain.ServiceRequestEscalation
 }
 
 // ComposeStaffNotification composes a staff notification which will be sent to the staff at a facility
@@ -53,6 +67,39 @@ func ComposeStaffNotification(notificationType enums.NotificationType, input Sta
 
 		return notification
 
+	case enums.NotificationTypeRoleAssignment:
+		notification.Title = "You have been assigned a new role"
+		notification.Body = fmt.Sprintf("You have been assigned the %s role.", input.Role.Name)
+
+		return notification
+
+	case enums.NotificationTypeRoleRevocation:
+		notification.Title = "A role has been revoked"
+		notification.Body = fmt.Sprintf("The %s role has been revoked from your profile.", input.Role.Name)
+
+		return notification
+
+	case enums.NotificationTypeStaffOffboarded:
+		notification.Title = "A staff has been offboarded"
+		notification.Body = StaffOffboardingMessage(input.Offboarding)
+
+		return notification
+
+	case enums.NotificationTypeClientTransferred:
+		notification.Title = "A client has been transferred to your facility"
+		notification.Body = ClientTransferMessage(input.Subject.Name, input.TransferredFrom, input.Transfer)
+
+		return notification
+
+	case enums.NotificationTypeServiceRequestEscalated:
+		notification.Title = "A service request has breached its SLA"
+		if input.Escalation.PatientSafetyIncident {
+			notification.Title = "Patient safety incident: a red flag has not been followed up"
+		}
+		notification.Body = ServiceRequestEscalationMessage(input.Subject.Name, input.ServiceRequest, input.Escalation)
+
+		return notification
+
 	default:
 		return nil
 	}
@@ -96,6 +143,19 @@ type ClientNotificationInput struct {
 
 	// Args to a survey notification
 	Survey *domain.UserSurvey
+
+	// Args to a role assignment/revocation notification
+	Role *domain.AuthorityRole
+
+	// Args to a suspicious login notification
+	LoginEvent *domain.LoginEvent
+	Device     *domain.UserDevice
+
+	// Args to a caregiver access ended notification. The notification is worded for the caregiver when ToCaregiver is set
+	CaregiverDelegation *domain.CaregiverClient
+	Caregiver           *domain.User
+	Client              *domain.User
+	ToCaregiver         bool
 }
 
 // ComposeClientNotification composes a client notification which will be sent to the client at a facility
@@ -162,7 +222,173 @@ func ComposeClientNotification(notificationType enums.NotificationType, input Cl
 
 		return notification
 
+	case enums.NotificationTypeRoleAssignment:
+		notification.Title = "You have been assigned a new role"
+		notification.Body = fmt.Sprintf("You have been assigned the %s role.", input.Role.Name)
+
+		return notification
+
+	case enums.NotificationTypeRoleRevocation:
+		notification.Title = "A role has been revoked"
+		notification.Body = fmt.Sprintf("The %s role has been revoked from your profile.", input.Role.Name)
+
+		return notification
+
+	case enums.NotificationTypeSecurityQuestionsReset:
+		notification.Title = "Your security questions have been reset"
+		notification.Body = "Your security question answers have been cleared by your health care worker. " +
+			"You will be asked to set new security questions the next time you sign in."
+
+		return notification
+
+	case enums.NotificationTypeSuspiciousLogin:
+		notification.Title = "New sign in to your account"
+		notification.Body = fmt.Sprintf(
+			"There was a sign in to your account %s. If this was not you, please change your PIN immediately.",
+			SuspiciousLoginMessage(input.LoginEvent, input.Device),
+		)
+
+		return notification
+
+	case enums.NotificationTypeCaregiverAccessEnded:
+		notification.Title = "Caregiver access has ended"
+		notification.Body = CaregiverAccessEndedMessage(input.CaregiverDelegation, input.Caregiver, input.Client, input.ToCaregiver)
+
+		return notification
+
 	default:
 		return nil
 	}
 }
+
+// SuspiciousLoginMessage describes why a login was flagged as suspicious e.g "from a new device (Nokia 3310)"
+func SuspiciousLoginMessage(event *domain.LoginEvent, device *domain.UserDevice) string {
+	reasons := []string{}
+	for _, flag := range event.RiskFlags {
+		switch flag {
+		case enums.LoginRiskNewDevice:
+			if device != nil && device.DeviceName != "" {
+				reasons = append(reasons, fmt.Sprintf("from a new device (%s)", device.DeviceName))
+			} else {
+				reasons = append(reasons, "from a new device")
+			}
+		case enums.LoginRiskUnusualCountry:
+			reasons = append(reasons, fmt.Sprintf("from a country you have not signed in from before (%s)", event.Country))
+		case enums.LoginRiskSharedIPAddress:
+			reasons = append(reasons, "from a network that many other accounts have signed in from")
+		}
+	}
+
+	return strings.Join(reasons, " and ")
+}
+
+// CaregiverAccessEndedMessage explains to the client or the caregiver why the caregiver can no longer act on the client's behalf
+func CaregiverAccessEndedMessage(delegation *domain.CaregiverClient, caregiver *domain.User, client *domain.User, toCaregiver bool) string {
+	if delegation.RevokedAt != nil {
+		if toCaregiver {
+			return fmt.Sprintf("%s has removed you as their caregiver. You can no longer act on their behalf.", client.Name)
+		}
+		return fmt.Sprintf("You have removed %s as your caregiver. They can no longer act on your behalf.", caregiver.Name)
+	}
+
+	endDate := delegation.EndsAt.Format("January 02, 2006")
+	if toCaregiver {
+		return fmt.Sprintf("Your access as %s's caregiver ended on %s. You can no longer act on their behalf.", client.Name, endDate)
+	}
+	return fmt.Sprintf("%s's access as your caregiver ended on %s. They can no longer act on your behalf.", caregiver.Name, endDate)
+}
+
+// StaffOffboardingMessage summarises what was changed when a staff was offboarded for the organisation admins
+func StaffOffboardingMessage(offboarding *domain.StaffOffboarding) string {
+	reassigned := 0
+	for _, serviceRequest := range offboarding.ServiceRequests {
+		if serviceRequest.ReassignedTo != nil {
+			reassigned++
+		}
+	}
+
+	summary := []string{
+		fmt.Sprintf("%s has been offboarded: %s.", offboarding.Name, offboarding.Reason),
+		fmt.Sprintf(
+			"%d in progress service requests were reassigned and %d were released back to the facility queue.",
+			reassigned,
+			len(offboarding.ServiceRequests)-reassigned,
+		),
+	}
+
+	if offboarding.OrganisationAdminRemoved {
+		summary = append(summary, "Their organisation admin rights have been removed.")
+	}
+
+	if offboarding.UserDeactivated {
+		summary = append(summary, "Their account has been deactivated.")
+	} else {
+		summary = append(summary, "They have been logged out and keep their account as they have other profiles.")
+	}
+
+	return strings.Join(summary, " ")
+}
+
+// ClientTransferMessage composes the message telling the staff of the receiving facility about a client's transfer
+// and, when the client moved from another program, which part of their history was carried
+func ClientTransferMessage(name string, from *domain.Facility, transfer *domain.ClientTransfer) string {
+	summary := []string{
+		fmt.Sprintf("%s has been transferred to your facility from %s: %s.", name, from.Name, transfer.Reason),
+	}
+
+	if !transfer.IsProgramTransfer() {
+		return summary[0]
+	}
+
+	carried := []string{}
+	for _, history := range transfer.CarriedHistory {
+		switch history {
+		case enums.ClientTransferHistoryHealthDiary:
+			carried = append(carried, "health diary entries")
+		case enums.ClientTransferHistoryAppointments:
+			carried = append(carried, "appointments")
+		case enums.ClientTransferHistoryScreeningToolResponses:
+			carried = append(carried, "screening tool responses")
+		}
+	}
+
+	switch len(carried) {
+	case 0:
+		summary = append(summary, "None of their history was carried from their previous program.")
+	case 1:
+		summary = append(summary, fmt.Sprintf("Their %s were carried from their previous program.", carried[0]))
+	default:
+		summary = append(summary, fmt.Sprintf(
+			"Their %s and %s were carried from their previous program.",
+			strings.Join(carried[:len(carried)-1], ", "),
+			carried[len(carried)-1],
+		))
+	}
+
+	return strings.Join(summary, " ")
+}
+
+// ServiceRequestEscalationMessage composes the message sent to the facility staff or the admins when a service request breaches its SLA
+func ServiceRequestEscalationMessage(name string, serviceRequest *domain.ServiceRequest, escalation *domain.ServiceRequestEscalation) string {
+	summary := []string{
+		fmt.Sprintf(
+			"%s from %s has been pending since %s and has breached its SLA.",
+			ServiceRequestMessage(enums.ServiceRequestType(serviceRequest.RequestType)),
+			name,
+			serviceRequest.CreatedAt.Format("January 02, 2006 15:04"),
+		),
+	}
+
+	if escalation.PatientSafetyIncident {
+		summary = append(summary, fmt.Sprintf(
+			"This is a patient safety incident as a possible suicide risk was not followed up within %d hours.",
+			domain.SuicideRiskResolutionHours,
+		))
+	} else if escalation.Level == enums.ServiceRequestEscalationLevelAdmins {
+		summary = append(summary, "It has not been resolved since it was escalated to the facility staff.")
+	}
+
+	summary = append(summary, "Please follow up and resolve it.")
+
+	return strings.Join(summary, " ")
+}
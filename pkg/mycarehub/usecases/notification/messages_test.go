@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/savannahghi/feedlib"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
@@ -56,6 +57,7 @@ func TestServiceRequestMessage(t *testing.T) {
 func TestComposeStaffNotification(t *testing.T) {
 	redFlag := enums.ServiceRequestTypeRedFlag
 	booking := enums.ServiceRequestBooking
+	reassignTo := "staff"
 	type args struct {
 		notificationType enums.NotificationType
 		args             StaffNotificationArgs
@@ -101,6 +103,204 @@ func TestComposeStaffNotification(t *testing.T) {
 				Flavour: feedlib.FlavourPro,
 			},
 		},
+		{
+			name: "role assignment notification",
+			args: args{
+				notificationType: enums.NotificationTypeRoleAssignment,
+				args: StaffNotificationArgs{
+					Subject: &domain.User{
+						Name: "John Doe",
+					},
+					Role: &domain.AuthorityRole{
+						Name: "Clinician",
+					},
+				},
+			},
+			want: &domain.Notification{
+				Title:   "You have been assigned a new role",
+				Body:    "You have been assigned the Clinician role.",
+				Type:    enums.NotificationTypeRoleAssignment,
+				Flavour: feedlib.FlavourPro,
+			},
+		},
+		{
+			name: "role revocation notification",
+			args: args{
+				notificationType: enums.NotificationTypeRoleRevocation,
+				args: StaffNotificationArgs{
+					Subject: &domain.User{
+						Name: "John Doe",
+					},
+					Role: &domain.AuthorityRole{
+						Name: "Clinician",
+					},
+				},
+			},
+			want: &domain.Notification{
+				Title:   "A role has been revoked",
+				Body:    "The Clinician role has been revoked from your profile.",
+				Type:    enums.NotificationTypeRoleRevocation,
+				Flavour: feedlib.FlavourPro,
+			},
+		},
+		{
+			name: "staff offboarded notification",
+			args: args{
+				notificationType: enums.NotificationTypeStaffOffboarded,
+				args: StaffNotificationArgs{
+					Subject: &domain.User{
+						Name: "John Doe",
+					},
+					Offboarding: &domain.StaffOffboarding{
+						Name:                     "John Doe",
+						Reason:                   "resigned",
+						UserDeactivated:          true,
+						OrganisationAdminRemoved: true,
+						ServiceRequests: []*domain.StaffOffboardingServiceRequest{
+							{ID: "1", ReassignedTo: &reassignTo},
+							{ID: "2"},
+							{ID: "3"},
+						},
+					},
+				},
+			},
+			want: &domain.Notification{
+				Title: "A staff has been offboarded",
+				Body: "John Doe has been offboarded: resigned. 1 in progress service requests were reassigned and 2 were released back to the facility queue. " +
+					"Their organisation admin rights have been removed. Their account has been deactivated.",
+				Type:    enums.NotificationTypeStaffOffboarded,
+				Flavour: feedlib.FlavourPro,
+			},
+		},
+		{
+			name: "staff offboarded notification, user keeps their account",
+			args: args{
+				notificationType: enums.NotificationTypeStaffOffboarded,
+				args: StaffNotificationArgs{
+					Subject: &domain.User{
+						Name: "John Doe",
+					},
+					Offboarding: &domain.StaffOffboarding{
+						Name:   "John Doe",
+						Reason: "transferred",
+					},
+				},
+			},
+			want: &domain.Notification{
+				Title: "A staff has been offboarded",
+				Body: "John Doe has been offboarded: transferred. 0 in progress service requests were reassigned and 0 were released back to the facility queue. " +
+					"They have been logged out and keep their account as they have other profiles.",
+				Type:    enums.NotificationTypeStaffOffboarded,
+				Flavour: feedlib.FlavourPro,
+			},
+		},
+		{
+			name: "client transferred notification",
+			args: args{
+				notificationType: enums.NotificationTypeClientTransferred,
+				args: StaffNotificationArgs{
+					Subject: &domain.User{
+						Name: "John Doe",
+					},
+					TransferredFrom: &domain.Facility{
+						Name: "Kenyatta National Hospital",
+					},
+					Transfer: &domain.ClientTransfer{
+						FromProgramID: "1",
+						ToProgramID:   "2",
+						Reason:        "relocated",
+						CarriedHistory: []enums.ClientTransferHistory{
+							enums.ClientTransferHistoryHealthDiary,
+							enums.ClientTransferHistoryAppointments,
+							enums.ClientTransferHistoryScreeningToolResponses,
+						},
+					},
+				},
+			},
+			want: &domain.Notification{
+				Title: "A client has been transferred to your facility",
+				Body: "John Doe has been transferred to your facility from Kenyatta National Hospital: relocated. " +
+					"Their health diary entries, appointments and screening tool responses were carried from their previous program.",
+				Type:    enums.NotificationTypeClientTransferred,
+				Flavour: feedlib.FlavourPro,
+			},
+		},
+		{
+			name: "client transferred notification, within a program",
+			args: args{
+				notificationType: enums.NotificationTypeClientTransferred,
+				args: StaffNotificationArgs{
+					Subject: &domain.User{
+						Name: "John Doe",
+					},
+					TransferredFrom: &domain.Facility{
+						Name: "Kenyatta National Hospital",
+					},
+					Transfer: &domain.ClientTransfer{
+						FromProgramID: "1",
+						ToProgramID:   "1",
+						Reason:        "closer to home",
+					},
+				},
+			},
+			want: &domain.Notification{
+				Title:   "A client has been transferred to your facility",
+				Body:    "John Doe has been transferred to your facility from Kenyatta National Hospital: closer to home.",
+				Type:    enums.NotificationTypeClientTransferred,
+				Flavour: feedlib.FlavourPro,
+			},
+		},
+		{
+			name: "service request escalated notification",
+			args: args{
+				notificationType: enums.NotificationTypeServiceRequestEscalated,
+				args: StaffNotificationArgs{
+					Subject: &domain.User{
+						Name: "John Doe",
+					},
+					ServiceRequest: &domain.ServiceRequest{
+						RequestType: enums.ServiceRequestTypeSurveyRedFlag.String(),
+						CreatedAt:   time.Date(2023, time.March, 1, 9, 30, 0, 0, time.UTC),
+					},
+					Escalation: &domain.ServiceRequestEscalation{
+						Level: enums.ServiceRequestEscalationLevelAdmins,
+					},
+				},
+			},
+			want: &domain.Notification{
+				Title: "A service request has breached its SLA",
+				Body: "A flagged survey response service request from John Doe has been pending since March 01, 2023 09:30 and has breached its SLA. " +
+					"It has not been resolved since it was escalated to the facility staff. Please follow up and resolve it.",
+				Type:    enums.NotificationTypeServiceRequestEscalated,
+				Flavour: feedlib.FlavourPro,
+			},
+		},
+		{
+			name: "service request escalated notification, patient safety incident",
+			args: args{
+				notificationType: enums.NotificationTypeServiceRequestEscalated,
+				args: StaffNotificationArgs{
+					Subject: &domain.User{
+						Name: "John Doe",
+					},
+					ServiceRequest: &domain.ServiceRequest{
+						RequestType: enums.ServiceRequestTypeRedFlag.String(),
+						CreatedAt:   time.Date(2023, time.March, 1, 9, 30, 0, 0, time.UTC),
+					},
+					Escalation: &domain.ServiceRequestEscalation{
+						Level:                 enums.ServiceRequestEscalationLevelFacilityStaff,
+						PatientSafetyIncident: true,
+					},
+				},
+			},
+			want: &domain.Notification{
+				Title: "Patient safety incident: a red flag has not been followed up",
+				Body: "A flagged health diary entry service request from John Doe has been pending since March 01, 2023 09:30 and has breached its SLA. " +
+					"This is a patient safety incident as a possible suicide risk was not followed up within 24 hours. Please follow up and resolve it.",
+				Type:    enums.NotificationTypeServiceRequestEscalated,
+				Flavour: feedlib.FlavourPro,
+			},
+		},
 		{
 			name: "unknown notification type",
 			args: args{
@@ -124,6 +324,9 @@ func TestComposeStaffNotification(t *testing.T) {
 }
 
 func TestComposeClientNotification(t *testing.T) {
+	revokedAt := time.Now()
+	endsAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
 	type args struct {
 		notificationType enums.NotificationType
 		args             ClientNotificationInput
@@ -153,6 +356,111 @@ func TestComposeClientNotification(t *testing.T) {
 				Flavour: feedlib.FlavourConsumer,
 			},
 		},
+		{
+			name: "role assignment notification",
+			args: args{
+				notificationType: enums.NotificationTypeRoleAssignment,
+				args: ClientNotificationInput{
+					Role: &domain.AuthorityRole{
+						Name: "Peer Educator",
+					},
+				},
+			},
+			want: &domain.Notification{
+				Title:   "You have been assigned a new role",
+				Body:    "You have been assigned the Peer Educator role.",
+				Type:    enums.NotificationTypeRoleAssignment,
+				Flavour: feedlib.FlavourConsumer,
+			},
+		},
+		{
+			name: "role revocation notification",
+			args: args{
+				notificationType: enums.NotificationTypeRoleRevocation,
+				args: ClientNotificationInput{
+					Role: &domain.AuthorityRole{
+						Name: "Peer Educator",
+					},
+				},
+			},
+			want: &domain.Notification{
+				Title:   "A role has been revoked",
+				Body:    "The Peer Educator role has been revoked from your profile.",
+				Type:    enums.NotificationTypeRoleRevocation,
+				Flavour: feedlib.FlavourConsumer,
+			},
+		},
+		{
+			name: "security questions reset notification",
+			args: args{
+				notificationType: enums.NotificationTypeSecurityQuestionsReset,
+				args:             ClientNotificationInput{},
+			},
+			want: &domain.Notification{
+				Title: "Your security questions have been reset",
+				Body: "Your security question answers have been cleared by your health care worker. " +
+					"You will be asked to set new security questions the next time you sign in.",
+				Type:    enums.NotificationTypeSecurityQuestionsReset,
+				Flavour: feedlib.FlavourConsumer,
+			},
+		},
+		{
+			name: "suspicious login notification",
+			args: args{
+				notificationType: enums.NotificationTypeSuspiciousLogin,
+				args: ClientNotificationInput{
+					LoginEvent: &domain.LoginEvent{
+						Country:   "UG",
+						RiskFlags: []enums.LoginRiskFlag{enums.LoginRiskNewDevice, enums.LoginRiskUnusualCountry},
+					},
+					Device: &domain.UserDevice{
+						DeviceName: "Nokia 3310",
+					},
+				},
+			},
+			want: &domain.Notification{
+				Title: "New sign in to your account",
+				Body: "There was a sign in to your account from a new device (Nokia 3310) and from a country you have not signed in from before (UG). " +
+					"If this was not you, please change your PIN immediately.",
+				Type:    enums.NotificationTypeSuspiciousLogin,
+				Flavour: feedlib.FlavourConsumer,
+			},
+		},
+		{
+			name: "caregiver access revoked notification to the caregiver",
+			args: args{
+				notificationType: enums.NotificationTypeCaregiverAccessEnded,
+				args: ClientNotificationInput{
+					CaregiverDelegation: &domain.CaregiverClient{RevokedAt: &revokedAt},
+					Caregiver:           &domain.User{Name: "John Doe"},
+					Client:              &domain.User{Name: "Jane Doe"},
+					ToCaregiver:         true,
+				},
+			},
+			want: &domain.Notification{
+				Title:   "Caregiver access has ended",
+				Body:    "Jane Doe has removed you as their caregiver. You can no longer act on their behalf.",
+				Type:    enums.NotificationTypeCaregiverAccessEnded,
+				Flavour: feedlib.FlavourConsumer,
+			},
+		},
+		{
+			name: "caregiver access expired notification to the client",
+			args: args{
+				notificationType: enums.NotificationTypeCaregiverAccessEnded,
+				args: ClientNotificationInput{
+					CaregiverDelegation: &domain.CaregiverClient{EndsAt: &endsAt},
+					Caregiver:           &domain.User{Name: "John Doe"},
+					Client:              &domain.User{Name: "Jane Doe"},
+				},
+			},
+			want: &domain.Notification{
+				Title:   "Caregiver access has ended",
+				Body:    "John Doe's access as your caregiver ended on January 01, 2024. They can no longer act on your behalf.",
+				Type:    enums.NotificationTypeCaregiverAccessEnded,
+				Flavour: feedlib.FlavourConsumer,
+			},
+		},
 		{
 			name: "new appointment notification",
 			args: args{
@@ -0,0 +1,289 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ory/fosite"
+	"github.com/ory/fosite/handler/oauth2"
+	"github.com/ory/fosite/token/jwt"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure"
+)
+
+const (
+	// accessTokenStrategyEnvVarName selects how access tokens are issued. When it is "jwt" the access tokens are RS256 signed JWTs
+	// that are verified without looking them up. Otherwise they are opaque tokens that are looked up in the database
+	accessTokenStrategyEnvVarName = "OAUTH_ACCESS_TOKEN_STRATEGY"
+
+	accessTokenStrategyJWT = "jwt"
+
+	// revocationListRefreshInterval is how often the revoked sessions and access tokens are reloaded.
+	// It is the longest time that a JWT access token can still be used after the user has logged out
+	revocationListRefreshInterval = 15 * time.Second
+
+	// revocationListRetryInterval is how long the first retry waits after the revocations fail to load.
+	// It doubles with each consecutive failure up to the refresh interval
+	revocationListRetryInterval = time.Second
+)
+
+// jwtAccessTokens returns true when access tokens are issued as JWTs. It is read when the provider is composed
+func jwtAccessTokens() bool {
+	return strings.EqualFold(os.Getenv(accessTokenStrategyEnvVarName), accessTokenStrategyJWT)
+}
+
+// newJWTStrategy returns a strategy that issues JWT access tokens signed with the rotating signing keys.
+// Refresh tokens and authorization codes are still opaque
+func newJWTStrategy(signer jwt.Signer, hmacStrategy *oauth2.HMACSHAStrategy, conf *fosite.Config) *oauth2.DefaultJWTStrategy {
+	return &oauth2.DefaultJWTStrategy{
+		Signer:          signer,
+		HMACSHAStrategy: hmacStrategy,
+		Config:          conf,
+	}
+}
+
+// AccessTokenClaims are the claims of a JWT access token that a request is authorized with
+type AccessTokenClaims struct {
+	UserID         string
+	SessionID      string
+	ClientID       string
+	ProgramID      string
+	OrganisationID string
+	FacilityID     string
+	Scopes         []string
+	ExpiresAt      time.Time
+
+	// TOTPEnrollment is set on the tokens of staff who can only enroll an authenticator app
+	TOTPEnrollment bool
+}
+
+// VerifyAccessToken verifies a JWT access token using the published signing keys and the revocation list
+// so that requests are authorized without looking up the token
+func (u UseCasesOauthImpl) VerifyAccessToken(ctx context.Context, accessToken string) (*AccessTokenClaims, error) {
+	token, err := u.signer.Decode(ctx, accessToken)
+	if err != nil {
+		return nil, fosite.ErrRequestUnauthorized.WithWrap(err).WithHint("The access token could not be verified.")
+	}
+
+	claims := token.Claims
+
+	// ID tokens are signed with the same keys but cannot be used to access resources
+	if claims[domain.TokenUseClaim] != domain.AccessTokenUse {
+		return nil, fosite.ErrRequestUnauthorized.WithHint("The token is not an access token.")
+	}
+
+	if !claims.VerifyIssuer(issuer, true) {
+		return nil, fosite.ErrTokenClaim.WithHint("The access token was not issued by this server.")
+	}
+
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return nil, fosite.ErrTokenExpired.WithHint("The access token has expired.")
+	}
+
+	verified := &AccessTokenClaims{
+		UserID:         claimString(claims, "sub"),
+		SessionID:      claimString(claims, domain.SessionIDClaim),
+		ClientID:       claimString(claims, "client_id"),
+		ProgramID:      claimString(claims, "program_id"),
+		OrganisationID: claimString(claims, "organisation_id"),
+		FacilityID:     claimString(claims, "facility_id"),
+		Scopes:         claimStrings(claims, "scp"),
+		ExpiresAt:      claimTime(claims, "exp"),
+		TOTPEnrollment: claimBool(claims, domain.TOTPEnrollmentClaim),
+	}
+
+	// the signature identifies the token in the database, in the same way as fosite's JWT strategy
+	parts := strings.Split(accessToken, ".")
+	if u.revocations.isRevoked(ctx, verified.SessionID, parts[len(parts)-1]) {
+		return nil, fosite.ErrInactiveToken.WithHint("The access token has been revoked.")
+	}
+
+	return verified, nil
+}
+
+// claimString returns a string claim or an empty string if the claim is missing
+func claimString(claims jwt.MapClaims, name string) string {
+	value, _ := claims[name].(string)
+	return value
+}
+
+// claimBool returns a boolean claim or false if the claim is missing
+func claimBool(claims jwt.MapClaims, name string) bool {
+	value, _ := claims[name].(bool)
+	return value
+}
+
+// claimTime returns a numeric date claim or the zero time if the claim is missing
+func claimTime(claims jwt.MapClaims, name string) time.Time {
+	switch value := claims[name].(type) {
+	case float64:
+		return time.Unix(int64(value), 0)
+	case int64:
+		return time.Unix(value, 0)
+	case json.Number:
+		seconds, _ := value.Int64()
+		return time.Unix(seconds, 0)
+	}
+
+	return time.Time{}
+}
+
+// claimStrings returns a list of strings claim
+func claimStrings(claims jwt.MapClaims, name string) []string {
+	values := []string{}
+
+	list, _ := claims[name].([]interface{})
+	for _, item := range list {
+		if value, ok := item.(string); ok {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+// revocationList keeps the sessions and access tokens that were revoked before their access tokens expire.
+// It is shared by all the requests and reloaded periodically so that revoking a session on one instance of
+// the service is seen by the other instances
+type revocationList struct {
+	query infrastructure.Query
+
+	// lifespan is the lifespan of the access tokens. Revocations older than it are not needed since the tokens have expired
+	lifespan time.Duration
+
+	// loading is held while the revocations are reloaded so that only one request reloads them at a time
+	loading sync.Mutex
+
+	mu       sync.RWMutex
+	sessions map[string]bool
+	tokens   map[string]bool
+	loadedAt time.Time
+
+	// failures is the number of consecutive reloads that failed and retryAt is when the revocations can be reloaded again after them
+	failures int
+	retryAt  time.Time
+}
+
+func newRevocationList(query infrastructure.Query, lifespan time.Duration) *revocationList {
+	return &revocationList{
+		query:    query,
+		lifespan: lifespan,
+	}
+}
+
+// isRevoked returns true if the session or the access token with the given signature has been revoked.
+// Tokens are treated as revoked if the revocation list has never been loaded
+func (l *revocationList) isRevoked(ctx context.Context, sessionID string, signature string) bool {
+	if l.due() {
+		l.reload(ctx)
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if l.sessions == nil {
+		return true
+	}
+
+	return l.sessions[sessionID] || l.tokens[signature]
+}
+
+// due returns true when the revocations are stale and are not being backed off from after a failed reload
+func (l *revocationList) due() bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	now := time.Now()
+	if now.Before(l.retryAt) {
+		return false
+	}
+
+	return l.sessions == nil || now.Sub(l.loadedAt) >= revocationListRefreshInterval
+}
+
+// reload reloads the revocations without holding up the requests that check them.
+// While another request is reloading them the last loaded list is used, unless none has been loaded yet
+func (l *revocationList) reload(ctx context.Context) {
+	if !l.loading.TryLock() {
+		l.mu.RLock()
+		loaded := l.sessions != nil
+		l.mu.RUnlock()
+
+		if loaded {
+			return
+		}
+		l.loading.Lock()
+	}
+	defer l.loading.Unlock()
+
+	// another request may have reloaded the revocations while this one waited
+	if !l.due() {
+		return
+	}
+
+	sessions, tokens, err := l.load(ctx)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err != nil {
+		// the last loaded list is used until the revocations can be loaded again
+		helpers.ReportErrorToSentry(err)
+
+		l.failures++
+		l.retryAt = time.Now().Add(revocationListRetryBackoff(l.failures))
+		return
+	}
+
+	l.sessions, l.tokens = sessions, tokens
+	l.loadedAt = time.Now()
+	l.failures = 0
+	l.retryAt = time.Time{}
+}
+
+// revocationListRetryBackoff returns how long to wait before reloading the revocations after the given number of consecutive failures
+func revocationListRetryBackoff(failures int) time.Duration {
+	backoff := revocationListRetryInterval
+	for i := 1; i < failures && backoff < revocationListRefreshInterval; i++ {
+		backoff *= 2
+	}
+
+	if backoff > revocationListRefreshInterval {
+		return revocationListRefreshInterval
+	}
+
+	return backoff
+}
+
+// load loads the revocations that happened within the lifespan of an access token
+func (l *revocationList) load(ctx context.Context) (map[string]bool, map[string]bool, error) {
+	since := time.Now().Add(-l.lifespan)
+
+	sessionIDs, err := l.query.ListRevokedSessionIDs(ctx, since)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list revoked sessions: %w", err)
+	}
+
+	signatures, err := l.query.ListRevokedAccessTokenSignatures(ctx, since)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list revoked access tokens: %w", err)
+	}
+
+	sessions := make(map[string]bool, len(sessionIDs))
+	for _, sessionID := range sessionIDs {
+		sessions[sessionID] = true
+	}
+
+	tokens := make(map[string]bool, len(signatures))
+	for _, signature := range signatures {
+		tokens[signature] = true
+	}
+
+	return sessions, tokens, nil
+}
@@ -0,0 +1,207 @@
+package oauth_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	pgMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/mock"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/oauth"
+)
+
+func TestUseCasesOauthImpl_VerifyAccessToken(t *testing.T) {
+	t.Setenv("OAUTH_ACCESS_TOKEN_STRATEGY", "jwt")
+
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "happy case: verify access token",
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "happy case: verify TOTP enrollment access token",
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "sad case: access token has been tampered with",
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: opaque access token",
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: session has been revoked",
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: access token has been revoked",
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: failed to load revoked sessions",
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: failed to load revoked access tokens",
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: failed load is not retried before the backoff passes",
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			u := oauth.NewUseCasesOauthImplementation(fakeDB, fakeDB, fakeDB, fakeDB)
+
+			userID := gofakeit.UUID()
+			programID := gofakeit.UUID()
+			fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.User, error) {
+				return &domain.User{
+					ID:                    &userID,
+					Username:              gofakeit.Username(),
+					Name:                  gofakeit.Name(),
+					CurrentOrganizationID: gofakeit.UUID(),
+					CurrentProgramID:      programID,
+				}, nil
+			}
+
+			generateTokens := u.GenerateUserAuthTokens
+			if tt.name == "happy case: verify TOTP enrollment access token" {
+				generateTokens = u.GenerateTOTPEnrollmentAuthTokens
+			}
+
+			tokens, err := generateTokens(context.Background(), userID, dto.SessionDeviceInput{})
+			if err != nil {
+				t.Fatalf("failed to generate tokens: %v", err)
+			}
+
+			accessToken := tokens.AccessToken
+			parts := strings.Split(accessToken, ".")
+			if len(parts) != 3 {
+				t.Fatalf("expected a JWT access token, got %s", accessToken)
+			}
+
+			if tt.name == "sad case: access token has been tampered with" {
+				payload, _ := json.Marshal(map[string]interface{}{"sub": gofakeit.UUID(), "token_use": "access"})
+				accessToken = strings.Join([]string{parts[0], base64.RawURLEncoding.EncodeToString(payload), parts[2]}, ".")
+			}
+			if tt.name == "sad case: opaque access token" {
+				accessToken = fmt.Sprintf("%s.%s", gofakeit.UUID(), gofakeit.UUID())
+			}
+			if tt.name == "sad case: session has been revoked" {
+				payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+				if err != nil {
+					t.Fatalf("failed to decode access token: %v", err)
+				}
+
+				claims := map[string]interface{}{}
+				if err := json.Unmarshal(payload, &claims); err != nil {
+					t.Fatalf("failed to decode access token claims: %v", err)
+				}
+
+				fakeDB.MockListRevokedSessionIDsFn = func(ctx context.Context, since time.Time) ([]string, error) {
+					return []string{claims[domain.SessionIDClaim].(string)}, nil
+				}
+			}
+			if tt.name == "sad case: access token has been revoked" {
+				fakeDB.MockListRevokedAccessTokenSignaturesFn = func(ctx context.Context, since time.Time) ([]string, error) {
+					return []string{parts[2]}, nil
+				}
+			}
+			if tt.name == "sad case: failed to load revoked sessions" {
+				fakeDB.MockListRevokedSessionIDsFn = func(ctx context.Context, since time.Time) ([]string, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "sad case: failed to load revoked access tokens" {
+				fakeDB.MockListRevokedAccessTokenSignaturesFn = func(ctx context.Context, since time.Time) ([]string, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			loads := 0
+			if tt.name == "sad case: failed load is not retried before the backoff passes" {
+				fakeDB.MockListRevokedSessionIDsFn = func(ctx context.Context, since time.Time) ([]string, error) {
+					loads++
+					return nil, fmt.Errorf("an error occurred")
+				}
+
+				if _, err := u.VerifyAccessToken(tt.args.ctx, accessToken); err == nil {
+					t.Errorf("expected the access token to be rejected while the revocations cannot be loaded")
+				}
+			}
+
+			got, err := u.VerifyAccessToken(tt.args.ctx, accessToken)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesOauthImpl.VerifyAccessToken() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.name == "sad case: failed load is not retried before the backoff passes" && loads != 1 {
+				t.Errorf("expected the revocations to be loaded once, got %d", loads)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if got.UserID != userID {
+				t.Errorf("UseCasesOauthImpl.VerifyAccessToken() UserID = %v, want %v", got.UserID, userID)
+			}
+			if got.ProgramID != programID {
+				t.Errorf("UseCasesOauthImpl.VerifyAccessToken() ProgramID = %v, want %v", got.ProgramID, programID)
+			}
+			if got.SessionID == "" {
+				t.Errorf("UseCasesOauthImpl.VerifyAccessToken() expected a session ID")
+			}
+			if got.ExpiresAt.Before(time.Now()) {
+				t.Errorf("UseCasesOauthImpl.VerifyAccessToken() expected the token to expire in the future")
+			}
+			if wantEnrollment := tt.name == "happy case: verify TOTP enrollment access token"; got.TOTPEnrollment != wantEnrollment {
+				t.Errorf("UseCasesOauthImpl.VerifyAccessToken() TOTPEnrollment = %v, want %v", got.TOTPEnrollment, wantEnrollment)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package oauth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ory/fosite"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+)
+
+// The scopes that integration clients e.g KenyaEMR request using the client credentials grant
+const (
+	ScopeKenyaEMRHealthDiaryRead          = "kenyaemr.health_diary.read"
+	ScopeKenyaEMRPatientsRead             = "kenyaemr.patients.read"
+	ScopeKenyaEMRServiceRequestsRead      = "kenyaemr.service_requests.read"
+	ScopeKenyaEMRServiceRequestsWrite     = "kenyaemr.service_requests.write"
+	ScopeKenyaEMRAppointmentsWrite        = "kenyaemr.appointments.write"
+	ScopeKenyaEMRObservationsWrite        = "kenyaemr.observations.write"
+	ScopeInternalOTPDeliveryReceiptsWrite = "internal.otp_delivery_receipts.write"
+)
+
+// integrationScopes are the scopes that a client created by staff can be allowed to request
+// in addition to the scopes supported for users
+var integrationScopes = []string{
+	ScopeKenyaEMRHealthDiaryRead,
+	ScopeKenyaEMRPatientsRead,
+	ScopeKenyaEMRServiceRequestsRead,
+	ScopeKenyaEMRServiceRequestsWrite,
+	ScopeKenyaEMRAppointmentsWrite,
+	ScopeKenyaEMRObservationsWrite,
+	ScopeInternalOTPDeliveryReceiptsWrite,
+}
+
+// validateClientScopes checks that a client is only allowed to request the scopes that myCareHub supports
+func validateClientScopes(client *domain.OauthClient) error {
+	scopes := append(append([]string{}, supportedScopes...), integrationScopes...)
+
+	if !fosite.Arguments(scopes).Has(client.Scopes...) {
+		return fmt.Errorf("the supported scopes are %s", strings.Join(scopes, ", "))
+	}
+
+	return nil
+}
+
+// validateFacilityClient checks that a client that is bound to a facility is a confidential client that gets tokens
+// for itself and that the facility exists
+func (u UseCasesOauthImpl) validateFacilityClient(ctx context.Context, client *domain.OauthClient) error {
+	if client.MFLCode == "" {
+		return nil
+	}
+
+	if client.Public || !fosite.Arguments(client.Grants).Has(grantTypeClientCredentials) {
+		return fmt.Errorf("a client that is bound to a facility should be a confidential client that uses the client_credentials grant")
+	}
+
+	_, err := u.query.RetrieveFacilityByIdentifier(ctx, &dto.FacilityIdentifierInput{
+		Type:  enums.FacilityIdentifierTypeMFLCode,
+		Value: client.MFLCode,
+	}, true)
+	if err != nil {
+		return fmt.Errorf("facility with MFL code %s does not exist: %w", client.MFLCode, err)
+	}
+
+	return nil
+}
+
+// AuthenticateClient checks that an access token was issued to a client using the client credentials grant
+// and that the client was granted the required scope. It returns the client that the token was issued to
+func (u UseCasesOauthImpl) AuthenticateClient(ctx context.Context, accessToken string, scope string) (*domain.OauthClient, error) {
+	_, requester, err := u.provider.IntrospectToken(ctx, accessToken, fosite.AccessToken, new(domain.Session), scope)
+	if err != nil {
+		return nil, err
+	}
+
+	// tokens that are issued to users act on behalf of the user and not the client
+	session, ok := requester.GetSession().(*domain.Session)
+	if !ok || session.UserID != "" {
+		return nil, fosite.ErrRequestUnauthorized.WithHint("The access token was not issued to a client.")
+	}
+
+	client, err := u.query.GetOauthClient(ctx, requester.GetClient().GetID())
+	if err != nil {
+		return nil, err
+	}
+
+	if !client.Active {
+		return nil, fosite.ErrRequestUnauthorized.WithHint("The client has been deactivated.")
+	}
+
+	return client, nil
+}
@@ -0,0 +1,127 @@
+package oauth_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	pgMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/mock"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/oauth"
+)
+
+func TestUseCasesOauthImpl_AuthenticateClient(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		scope string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "happy case: authenticate facility client",
+			args: args{
+				ctx:   context.Background(),
+				scope: oauth.ScopeKenyaEMRAppointmentsWrite,
+			},
+			wantErr: false,
+		},
+		{
+			name: "sad case: token was not granted the scope",
+			args: args{
+				ctx:   context.Background(),
+				scope: oauth.ScopeKenyaEMRPatientsRead,
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: token was issued to a user",
+			args: args{
+				ctx:   context.Background(),
+				scope: oauth.ScopeKenyaEMRAppointmentsWrite,
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: client has been deactivated",
+			args: args{
+				ctx:   context.Background(),
+				scope: oauth.ScopeKenyaEMRAppointmentsWrite,
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: failed to get client",
+			args: args{
+				ctx:   context.Background(),
+				scope: oauth.ScopeKenyaEMRAppointmentsWrite,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			u := oauth.NewUseCasesOauthImplementation(fakeDB, fakeDB, fakeDB, fakeDB)
+
+			// any token that was signed by myCareHub will do since the stored token is mocked
+			tokens, err := u.GenerateUserAuthTokens(context.Background(), gofakeit.UUID(), dto.SessionDeviceInput{})
+			if err != nil {
+				t.Fatalf("failed to generate tokens: %v", err)
+			}
+
+			client := &domain.OauthClient{
+				ID:      gofakeit.UUID(),
+				Name:    "KenyaEMR",
+				Active:  true,
+				Scopes:  []string{oauth.ScopeKenyaEMRAppointmentsWrite},
+				Grants:  []string{"client_credentials"},
+				MFLCode: "1234",
+			}
+
+			accessToken := &domain.AccessToken{
+				Active:        true,
+				RequestedAt:   time.Now(),
+				GrantedScopes: []string{oauth.ScopeKenyaEMRAppointmentsWrite},
+				Client:        *client,
+				Session: domain.Session{
+					ID:       gofakeit.UUID(),
+					ClientID: client.ID,
+				},
+			}
+
+			if tt.name == "sad case: token was issued to a user" {
+				accessToken.Session.UserID = gofakeit.UUID()
+			}
+
+			if tt.name == "sad case: client has been deactivated" {
+				client.Active = false
+			}
+
+			fakeDB.MockGetAccessTokenFn = func(ctx context.Context, token domain.AccessToken) (*domain.AccessToken, error) {
+				return accessToken, nil
+			}
+
+			fakeDB.MockGetOauthClient = func(ctx context.Context, id string) (*domain.OauthClient, error) {
+				if tt.name == "sad case: failed to get client" {
+					return nil, fmt.Errorf("failed to get client")
+				}
+				return client, nil
+			}
+
+			got, err := u.AuthenticateClient(tt.args.ctx, tokens.AccessToken, tt.args.scope)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesOauthImpl.AuthenticateClient() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.MFLCode != client.MFLCode {
+				t.Errorf("UseCasesOauthImpl.AuthenticateClient() got = %v, want client bound to %v", got, client.MFLCode)
+			}
+		})
+	}
+}
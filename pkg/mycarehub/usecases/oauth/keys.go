@@ -0,0 +1,338 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ory/fosite/token/jwt"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure"
+	"github.com/savannahghi/serverutils"
+	jose "gopkg.in/square/go-jose.v2"
+)
+
+const (
+	// signingKeyAlgorithm is the algorithm that tokens are signed with
+	signingKeyAlgorithm = "RS256"
+
+	// signingKeySize is the size in bits of the RSA signing keys
+	signingKeySize = 2048
+
+	// signingKeyRotationPeriod is how long a signing key is used to sign new tokens
+	signingKeyRotationPeriod = 30 * 24 * time.Hour
+
+	// signingKeyRetentionPeriod is how long a signing key is published after it has been rotated.
+	// It should be longer than the lifespan of any token signed with the key
+	signingKeyRetentionPeriod = 7 * 24 * time.Hour
+
+	// signingKeyCacheDuration is how long the signing keys are cached before they are reloaded from the database
+	// so that keys created by other instances of the service are published
+	signingKeyCacheDuration = 5 * time.Minute
+
+	// signingKeyReloadInterval is the minimum time between reloads of the signing keys when a token is signed with an unknown key
+	signingKeyReloadInterval = 1 * time.Minute
+)
+
+var signingKeyPassphrase = serverutils.MustGetEnvVar("SENSITIVE_CONTENT_SECRET_KEY")
+
+// signingKey is a decrypted signing key
+type signingKey struct {
+	keyID      string
+	algorithm  string
+	privateKey *rsa.PrivateKey
+	rotateAt   time.Time
+}
+
+// signingKeys keeps the RSA keys that tokens are signed with.
+// A new key is created when the current key is due for rotation and the old keys are still used to verify tokens until they expire
+type signingKeys struct {
+	create infrastructure.Create
+	query  infrastructure.Query
+
+	mu       sync.Mutex
+	keys     []*signingKey
+	loadedAt time.Time
+}
+
+func newSigningKeys(create infrastructure.Create, query infrastructure.Query) *signingKeys {
+	return &signingKeys{
+		create: create,
+		query:  query,
+	}
+}
+
+// current returns the key that new tokens should be signed with, creating one if there is none
+func (s *signingKeys) current(ctx context.Context) (*signingKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.load(ctx, signingKeyCacheDuration); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, key := range s.keys {
+		if key.rotateAt.After(now) {
+			return key, nil
+		}
+	}
+
+	key, err := s.generate(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+
+	s.keys = append([]*signingKey{key}, s.keys...)
+
+	return key, nil
+}
+
+// published returns the keys that tokens may have been signed with and are still valid
+func (s *signingKeys) published(ctx context.Context) ([]*signingKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.load(ctx, signingKeyCacheDuration); err != nil {
+		return nil, err
+	}
+
+	return s.keys, nil
+}
+
+// find returns the published key with the given ID.
+// The keys are reloaded if it is not found since it may have been created by another instance of the service
+func (s *signingKeys) find(ctx context.Context, keyID string) (*signingKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, maxAge := range []time.Duration{signingKeyCacheDuration, signingKeyReloadInterval} {
+		if err := s.load(ctx, maxAge); err != nil {
+			return nil, err
+		}
+
+		for _, key := range s.keys {
+			if key.keyID == keyID {
+				return key, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("unknown signing key %q", keyID)
+}
+
+// load reloads the keys from the database when the cached keys are older than maxAge. The caller should hold the lock
+func (s *signingKeys) load(ctx context.Context, maxAge time.Duration) error {
+	if s.keys != nil && time.Since(s.loadedAt) < maxAge {
+		return nil
+	}
+
+	records, err := s.query.ListOauthSigningKeys(ctx, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to list signing keys: %w", err)
+	}
+
+	keys := []*signingKey{}
+	for _, record := range records {
+		key, err := decodeSigningKey(record)
+		if err != nil {
+			// a key that cannot be decoded is skipped so that tokens can still be signed with a new key
+			helpers.ReportErrorToSentry(err)
+			continue
+		}
+
+		keys = append(keys, key)
+	}
+
+	s.keys = keys
+	s.loadedAt = time.Now()
+
+	return nil
+}
+
+// generate creates and stores a new signing key
+func (s *signingKeys) generate(ctx context.Context, now time.Time) (*signingKey, error) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, signingKeySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate signing key: %w", err)
+	}
+
+	privateKeyPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
+	})
+
+	encryptedPrivateKey, err := helpers.EncryptSensitiveData(string(privateKeyPEM), signingKeyPassphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt signing key: %w", err)
+	}
+
+	publicKeyPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PUBLIC KEY",
+		Bytes: x509.MarshalPKCS1PublicKey(&privateKey.PublicKey),
+	})
+
+	record := &domain.OauthSigningKey{
+		KeyID:      uuid.New().String(),
+		Algorithm:  signingKeyAlgorithm,
+		PrivateKey: encryptedPrivateKey,
+		PublicKey:  string(publicKeyPEM),
+		RotateAt:   now.Add(signingKeyRotationPeriod),
+		ExpiresAt:  now.Add(signingKeyRotationPeriod + signingKeyRetentionPeriod),
+	}
+
+	if err := s.create.CreateOauthSigningKey(ctx, record); err != nil {
+		return nil, fmt.Errorf("failed to save signing key: %w", err)
+	}
+
+	return &signingKey{
+		keyID:      record.KeyID,
+		algorithm:  record.Algorithm,
+		privateKey: privateKey,
+		rotateAt:   record.RotateAt,
+	}, nil
+}
+
+// decodeSigningKey decrypts a stored signing key
+func decodeSigningKey(record *domain.OauthSigningKey) (*signingKey, error) {
+	privateKeyPEM, err := helpers.DecryptSensitiveData(record.PrivateKey, signingKeyPassphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt signing key %s: %w", record.KeyID, err)
+	}
+
+	block, _ := pem.Decode([]byte(privateKeyPEM))
+	if block == nil {
+		return nil, fmt.Errorf("signing key %s is not PEM encoded", record.KeyID)
+	}
+
+	privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signing key %s: %w", record.KeyID, err)
+	}
+
+	return &signingKey{
+		keyID:      record.KeyID,
+		algorithm:  record.Algorithm,
+		privateKey: privateKey,
+		rotateAt:   record.RotateAt,
+	}, nil
+}
+
+// signer returns a fosite signer that uses the key
+func (k *signingKey) signer() *jwt.DefaultSigner {
+	return &jwt.DefaultSigner{
+		GetPrivateKey: func(ctx context.Context) (interface{}, error) {
+			return &jose.JSONWebKey{
+				Key:       k.privateKey,
+				KeyID:     k.keyID,
+				Algorithm: k.algorithm,
+				Use:       "sig",
+			}, nil
+		},
+	}
+}
+
+// publicJSONWebKey returns the public part of the key as published in the JWKS document
+func (k *signingKey) publicJSONWebKey() jose.JSONWebKey {
+	return jose.JSONWebKey{
+		Key:       &k.privateKey.PublicKey,
+		KeyID:     k.keyID,
+		Algorithm: k.algorithm,
+		Use:       "sig",
+	}
+}
+
+// keySigner signs tokens with the current signing key and verifies them with the key that they were signed with.
+// The ID of the key is added to the token header so that clients can pick the right key from the JWKS document
+type keySigner struct {
+	*jwt.DefaultSigner
+	keys *signingKeys
+}
+
+func newKeySigner(keys *signingKeys) *keySigner {
+	return &keySigner{
+		// the default signer is used for the methods that do not need a key e.g Hash and GetSignature
+		DefaultSigner: &jwt.DefaultSigner{},
+		keys:          keys,
+	}
+}
+
+// Generate signs the claims with the current signing key
+func (s *keySigner) Generate(ctx context.Context, claims jwt.MapClaims, header jwt.Mapper) (string, string, error) {
+	key, err := s.keys.current(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	headers := &jwt.Headers{}
+	if header != nil {
+		for name, value := range header.ToMap() {
+			headers.Add(name, value)
+		}
+	}
+	headers.Add("kid", key.keyID)
+
+	return key.signer().Generate(ctx, claims, headers)
+}
+
+// Validate checks the signature of a token and returns the signature
+func (s *keySigner) Validate(ctx context.Context, token string) (string, error) {
+	key, err := s.tokenKey(ctx, token)
+	if err != nil {
+		return "", err
+	}
+
+	return key.signer().Validate(ctx, token)
+}
+
+// Decode checks the signature of a token and returns the token
+func (s *keySigner) Decode(ctx context.Context, token string) (*jwt.Token, error) {
+	key, err := s.tokenKey(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return key.signer().Decode(ctx, token)
+}
+
+// tokenKey returns the published key that a token was signed with
+func (s *keySigner) tokenKey(ctx context.Context, token string) (*signingKey, error) {
+	signature, err := jose.ParseSigned(token)
+	if err != nil {
+		return nil, &jwt.ValidationError{Errors: jwt.ValidationErrorMalformed, Inner: err}
+	}
+
+	if len(signature.Signatures) != 1 {
+		return nil, &jwt.ValidationError{Errors: jwt.ValidationErrorMalformed, Inner: errors.New("token should have one signature")}
+	}
+
+	key, err := s.keys.find(ctx, signature.Signatures[0].Header.KeyID)
+	if err != nil {
+		return nil, &jwt.ValidationError{Errors: jwt.ValidationErrorUnverifiable, Inner: err}
+	}
+
+	return key, nil
+}
+
+// jsonWebKeySet returns the public keys that tokens are verified with
+func (s *keySigner) jsonWebKeySet(ctx context.Context) (*jose.JSONWebKeySet, error) {
+	keys, err := s.keys.published(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keySet := &jose.JSONWebKeySet{Keys: []jose.JSONWebKey{}}
+	for _, key := range keys {
+		keySet.Keys = append(keySet.Keys, key.publicJSONWebKey())
+	}
+
+	return keySet, nil
+}
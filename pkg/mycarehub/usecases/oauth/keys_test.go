@@ -0,0 +1,226 @@
+package oauth
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ory/fosite/token/jwt"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	pgMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/mock"
+)
+
+// newStoredSigningKey generates a signing key and returns it as it would be stored in the database
+func newStoredSigningKey(t *testing.T, rotateAt time.Time) *domain.OauthSigningKey {
+	fakeDB := pgMock.NewPostgresMock()
+
+	var stored *domain.OauthSigningKey
+	fakeDB.MockCreateOauthSigningKeyFn = func(ctx context.Context, key *domain.OauthSigningKey) error {
+		stored = key
+		return nil
+	}
+
+	if _, err := newSigningKeys(fakeDB, fakeDB).generate(context.Background(), time.Now()); err != nil {
+		t.Fatalf("failed to generate signing key: %v", err)
+	}
+
+	stored.RotateAt = rotateAt
+	stored.ExpiresAt = rotateAt.Add(signingKeyRetentionPeriod)
+
+	return stored
+}
+
+func Test_keySigner_Generate(t *testing.T) {
+	storedKey := newStoredSigningKey(t, time.Now().Add(time.Hour))
+	rotatedKey := newStoredSigningKey(t, time.Now().Add(-time.Hour))
+
+	tests := []struct {
+		name       string
+		keys       []*domain.OauthSigningKey
+		wantNewKey bool
+		wantErr    bool
+	}{
+		{
+			name:       "happy case: sign with the current key",
+			keys:       []*domain.OauthSigningKey{storedKey},
+			wantNewKey: false,
+			wantErr:    false,
+		},
+		{
+			name:       "happy case: create a key when there is none",
+			keys:       []*domain.OauthSigningKey{},
+			wantNewKey: true,
+			wantErr:    false,
+		},
+		{
+			name:       "happy case: create a key when the current key is due for rotation",
+			keys:       []*domain.OauthSigningKey{rotatedKey},
+			wantNewKey: true,
+			wantErr:    false,
+		},
+		{
+			name:       "happy case: skip a key that cannot be decrypted",
+			keys:       []*domain.OauthSigningKey{{KeyID: "broken", Algorithm: signingKeyAlgorithm, PrivateKey: "broken", RotateAt: time.Now().Add(time.Hour)}},
+			wantNewKey: true,
+			wantErr:    false,
+		},
+		{
+			name:    "sad case: failed to list signing keys",
+			wantErr: true,
+		},
+		{
+			name:    "sad case: failed to save signing key",
+			keys:    []*domain.OauthSigningKey{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+
+			fakeDB.MockListOauthSigningKeysFn = func(ctx context.Context, activeAt time.Time) ([]*domain.OauthSigningKey, error) {
+				return tt.keys, nil
+			}
+
+			createdKey := false
+			fakeDB.MockCreateOauthSigningKeyFn = func(ctx context.Context, key *domain.OauthSigningKey) error {
+				createdKey = true
+				return nil
+			}
+
+			if tt.name == "sad case: failed to list signing keys" {
+				fakeDB.MockListOauthSigningKeysFn = func(ctx context.Context, activeAt time.Time) ([]*domain.OauthSigningKey, error) {
+					return nil, fmt.Errorf("failed to list signing keys")
+				}
+			}
+
+			if tt.name == "sad case: failed to save signing key" {
+				fakeDB.MockCreateOauthSigningKeyFn = func(ctx context.Context, key *domain.OauthSigningKey) error {
+					return fmt.Errorf("failed to save signing key")
+				}
+			}
+
+			signer := newKeySigner(newSigningKeys(fakeDB, fakeDB))
+
+			token, _, err := signer.Generate(context.Background(), jwt.MapClaims{"sub": "user"}, &jwt.Headers{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("keySigner.Generate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if createdKey != tt.wantNewKey {
+				t.Errorf("keySigner.Generate() created key = %v, want %v", createdKey, tt.wantNewKey)
+			}
+
+			decoded, err := signer.Decode(context.Background(), token)
+			if err != nil {
+				t.Errorf("keySigner.Decode() error = %v", err)
+				return
+			}
+
+			keyID, _ := decoded.Header["kid"].(string)
+			if !tt.wantNewKey && keyID != storedKey.KeyID {
+				t.Errorf("expected the token to be signed with key %s, got %s", storedKey.KeyID, keyID)
+			}
+			if tt.wantNewKey && (keyID == "" || keyID == rotatedKey.KeyID) {
+				t.Errorf("expected the token to be signed with a new key, got %q", keyID)
+			}
+		})
+	}
+}
+
+func Test_keySigner_Validate(t *testing.T) {
+	currentKey := newStoredSigningKey(t, time.Now().Add(time.Hour))
+	rotatedKey := newStoredSigningKey(t, time.Now().Add(-time.Hour))
+	unknownKey := newStoredSigningKey(t, time.Now().Add(time.Hour))
+
+	// sign signs a token with the key as it was before it was rotated
+	sign := func(key *domain.OauthSigningKey) string {
+		unrotatedKey := *key
+		unrotatedKey.RotateAt = time.Now().Add(time.Hour)
+
+		fakeDB := pgMock.NewPostgresMock()
+		fakeDB.MockListOauthSigningKeysFn = func(ctx context.Context, activeAt time.Time) ([]*domain.OauthSigningKey, error) {
+			return []*domain.OauthSigningKey{&unrotatedKey}, nil
+		}
+
+		token, _, err := newKeySigner(newSigningKeys(fakeDB, fakeDB)).Generate(context.Background(), jwt.MapClaims{"sub": "user"}, &jwt.Headers{})
+		if err != nil {
+			t.Fatalf("failed to sign token: %v", err)
+		}
+
+		return token
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{
+			name:    "happy case: token signed with the current key",
+			token:   sign(currentKey),
+			wantErr: false,
+		},
+		{
+			name:    "happy case: token signed with a rotated key that has not expired",
+			token:   sign(rotatedKey),
+			wantErr: false,
+		},
+		{
+			name:    "sad case: token signed with an unknown key",
+			token:   sign(unknownKey),
+			wantErr: true,
+		},
+		{
+			name:    "sad case: malformed token",
+			token:   "not-a-token",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeDB.MockListOauthSigningKeysFn = func(ctx context.Context, activeAt time.Time) ([]*domain.OauthSigningKey, error) {
+				return []*domain.OauthSigningKey{currentKey, rotatedKey}, nil
+			}
+
+			signer := newKeySigner(newSigningKeys(fakeDB, fakeDB))
+
+			if _, err := signer.Validate(context.Background(), tt.token); (err != nil) != tt.wantErr {
+				t.Errorf("keySigner.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_signingKeys_find(t *testing.T) {
+	existingKey := newStoredSigningKey(t, time.Now().Add(time.Hour))
+	newKey := newStoredSigningKey(t, time.Now().Add(time.Hour))
+
+	fakeDB := pgMock.NewPostgresMock()
+	storedKeys := []*domain.OauthSigningKey{existingKey}
+	fakeDB.MockListOauthSigningKeysFn = func(ctx context.Context, activeAt time.Time) ([]*domain.OauthSigningKey, error) {
+		return storedKeys, nil
+	}
+
+	keys := newSigningKeys(fakeDB, fakeDB)
+	if _, err := keys.find(context.Background(), existingKey.KeyID); err != nil {
+		t.Fatalf("signingKeys.find() error = %v", err)
+	}
+
+	// a key created by another instance is not found until the keys can be reloaded
+	storedKeys = append(storedKeys, newKey)
+	if _, err := keys.find(context.Background(), newKey.KeyID); err == nil {
+		t.Errorf("expected the keys not to be reloaded within %v", signingKeyReloadInterval)
+	}
+
+	keys.loadedAt = time.Now().Add(-signingKeyReloadInterval)
+	if _, err := keys.find(context.Background(), newKey.KeyID); err != nil {
+		t.Errorf("signingKeys.find() error = %v", err)
+	}
+}
@@ -2,20 +2,32 @@ package mock
 
 import (
 	"context"
+	"time"
 
 	"github.com/brianvoe/gofakeit"
 	"github.com/ory/fosite"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/oauth"
+	jose "gopkg.in/square/go-jose.v2"
 )
 
 // OauthUseCaseMock mocks the implementation of oauth usecase
 type OauthUseCaseMock struct {
-	MockCreateOauthClientFn      func(ctx context.Context, input dto.OauthClientInput) (*domain.OauthClient, error)
-	MockFositeProviderFn         func() fosite.OAuth2Provider
-	MockGenerateUserAuthTokensFn func(ctx context.Context, userID string) (*oauth.AuthTokens, error)
-	MockRefreshAutTokenFn        func(ctx context.Context, refreshToken string) (*oauth.AuthTokens, error)
+	MockCreateOauthClientFn                func(ctx context.Context, input dto.OauthClientInput) (*domain.OauthClient, error)
+	MockFositeProviderFn                   func() fosite.OAuth2Provider
+	MockGenerateUserAuthTokensFn           func(ctx context.Context, userID string, device dto.SessionDeviceInput) (*oauth.AuthTokens, error)
+	MockGenerateTOTPEnrollmentAuthTokensFn func(ctx context.Context, userID string, device dto.SessionDeviceInput) (*oauth.AuthTokens, error)
+	MockRefreshAutTokenFn                  func(ctx context.Context, refreshToken string) (*oauth.AuthTokens, error)
+	MockOpenIDConfigurationFn              func(ctx context.Context) *oauth.OpenIDConfiguration
+	MockJSONWebKeySetFn                    func(ctx context.Context) (*jose.JSONWebKeySet, error)
+	MockUserInfoFn                         func(ctx context.Context, accessToken string) (map[string]interface{}, error)
+	MockRegisterOauthClientFn              func(ctx context.Context, accessToken string, input dto.OauthClientRegistrationInput) (*dto.OauthClientRegistrationOutput, error)
+	MockGetOauthClientRegistrationFn       func(ctx context.Context, clientID string, registrationAccessToken string) (*dto.OauthClientRegistrationOutput, error)
+	MockUpdateOauthClientRegistrationFn    func(ctx context.Context, clientID string, registrationAccessToken string, input dto.OauthClientRegistrationInput) (*dto.OauthClientRegistrationOutput, error)
+	MockDeleteOauthClientRegistrationFn    func(ctx context.Context, clientID string, registrationAccessToken string) error
+	MockAuthenticateClientFn               func(ctx context.Context, accessToken string, scope string) (*domain.OauthClient, error)
+	MockVerifyAccessTokenFn                func(ctx context.Context, accessToken string) (*oauth.AccessTokenClaims, error)
 }
 
 // NewOauthUseCaseMock initializes a new instance mock of the oauth usecase
@@ -30,13 +42,20 @@ func NewOauthUseCaseMock() *OauthUseCaseMock {
 		MockFositeProviderFn: func() fosite.OAuth2Provider {
 			return nil
 		},
-		MockGenerateUserAuthTokensFn: func(ctx context.Context, userID string) (*oauth.AuthTokens, error) {
+		MockGenerateUserAuthTokensFn: func(ctx context.Context, userID string, device dto.SessionDeviceInput) (*oauth.AuthTokens, error) {
 			return &oauth.AuthTokens{
 				AccessToken:  "access",
 				ExpiresIn:    3600,
 				RefreshToken: "refresh",
 			}, nil
 		},
+		MockGenerateTOTPEnrollmentAuthTokensFn: func(ctx context.Context, userID string, device dto.SessionDeviceInput) (*oauth.AuthTokens, error) {
+			return &oauth.AuthTokens{
+				AccessToken:  "enrollment access",
+				ExpiresIn:    3600,
+				RefreshToken: "enrollment refresh",
+			}, nil
+		},
 		MockRefreshAutTokenFn: func(ctx context.Context, refreshToken string) (*oauth.AuthTokens, error) {
 			return &oauth.AuthTokens{
 				AccessToken:  "access",
@@ -44,6 +63,67 @@ func NewOauthUseCaseMock() *OauthUseCaseMock {
 				RefreshToken: "refresh",
 			}, nil
 		},
+		MockOpenIDConfigurationFn: func(ctx context.Context) *oauth.OpenIDConfiguration {
+			return &oauth.OpenIDConfiguration{
+				Issuer:  "https://example.com",
+				JWKSURI: "https://example.com/.well-known/jwks.json",
+			}
+		},
+		MockJSONWebKeySetFn: func(ctx context.Context) (*jose.JSONWebKeySet, error) {
+			return &jose.JSONWebKeySet{Keys: []jose.JSONWebKey{}}, nil
+		},
+		MockUserInfoFn: func(ctx context.Context, accessToken string) (map[string]interface{}, error) {
+			return map[string]interface{}{
+				"sub":  gofakeit.UUID(),
+				"name": gofakeit.Name(),
+			}, nil
+		},
+		MockRegisterOauthClientFn: func(ctx context.Context, accessToken string, input dto.OauthClientRegistrationInput) (*dto.OauthClientRegistrationOutput, error) {
+			return &dto.OauthClientRegistrationOutput{
+				ClientID:                gofakeit.UUID(),
+				RegistrationAccessToken: gofakeit.UUID(),
+				ClientName:              input.ClientName,
+				RedirectURIs:            input.RedirectURIs,
+			}, nil
+		},
+		MockGetOauthClientRegistrationFn: func(ctx context.Context, clientID string, registrationAccessToken string) (*dto.OauthClientRegistrationOutput, error) {
+			return &dto.OauthClientRegistrationOutput{
+				ClientID:                clientID,
+				RegistrationAccessToken: registrationAccessToken,
+			}, nil
+		},
+		MockUpdateOauthClientRegistrationFn: func(ctx context.Context, clientID string, registrationAccessToken string, input dto.OauthClientRegistrationInput) (*dto.OauthClientRegistrationOutput, error) {
+			return &dto.OauthClientRegistrationOutput{
+				ClientID:                clientID,
+				RegistrationAccessToken: registrationAccessToken,
+				ClientName:              input.ClientName,
+				RedirectURIs:            input.RedirectURIs,
+			}, nil
+		},
+		MockDeleteOauthClientRegistrationFn: func(ctx context.Context, clientID string, registrationAccessToken string) error {
+			return nil
+		},
+		MockAuthenticateClientFn: func(ctx context.Context, accessToken string, scope string) (*domain.OauthClient, error) {
+			return &domain.OauthClient{
+				ID:      gofakeit.UUID(),
+				Name:    gofakeit.Name(),
+				Active:  true,
+				Scopes:  []string{scope},
+				Grants:  []string{"client_credentials"},
+				MFLCode: "1234",
+			}, nil
+		},
+		MockVerifyAccessTokenFn: func(ctx context.Context, accessToken string) (*oauth.AccessTokenClaims, error) {
+			return &oauth.AccessTokenClaims{
+				UserID:         gofakeit.UUID(),
+				SessionID:      gofakeit.UUID(),
+				ClientID:       gofakeit.UUID(),
+				ProgramID:      gofakeit.UUID(),
+				OrganisationID: gofakeit.UUID(),
+				Scopes:         []string{"openid"},
+				ExpiresAt:      time.Now().Add(time.Hour),
+			}, nil
+		},
 	}
 }
 
@@ -56,11 +136,61 @@ func (u *OauthUseCaseMock) FositeProvider() fosite.OAuth2Provider {
 	return u.MockFositeProviderFn()
 }
 
-func (u *OauthUseCaseMock) GenerateUserAuthTokens(ctx context.Context, userID string) (*oauth.AuthTokens, error) {
-	return u.MockGenerateUserAuthTokensFn(ctx, userID)
+func (u *OauthUseCaseMock) GenerateUserAuthTokens(ctx context.Context, userID string, device dto.SessionDeviceInput) (*oauth.AuthTokens, error) {
+	return u.MockGenerateUserAuthTokensFn(ctx, userID, device)
+}
+
+// GenerateTOTPEnrollmentAuthTokens mocks the implementation of GenerateTOTPEnrollmentAuthTokens method
+func (u *OauthUseCaseMock) GenerateTOTPEnrollmentAuthTokens(ctx context.Context, userID string, device dto.SessionDeviceInput) (*oauth.AuthTokens, error) {
+	return u.MockGenerateTOTPEnrollmentAuthTokensFn(ctx, userID, device)
 }
 
 // RefreshAuthToken mocks the implementation of RefreshAuthToken method
 func (u *OauthUseCaseMock) RefreshAuthToken(ctx context.Context, refreshToken string) (*oauth.AuthTokens, error) {
 	return u.MockRefreshAutTokenFn(ctx, refreshToken)
 }
+
+// OpenIDConfiguration mocks the implementation of OpenIDConfiguration method
+func (u *OauthUseCaseMock) OpenIDConfiguration(ctx context.Context) *oauth.OpenIDConfiguration {
+	return u.MockOpenIDConfigurationFn(ctx)
+}
+
+// JSONWebKeySet mocks the implementation of JSONWebKeySet method
+func (u *OauthUseCaseMock) JSONWebKeySet(ctx context.Context) (*jose.JSONWebKeySet, error) {
+	return u.MockJSONWebKeySetFn(ctx)
+}
+
+// UserInfo mocks the implementation of UserInfo method
+func (u *OauthUseCaseMock) UserInfo(ctx context.Context, accessToken string) (map[string]interface{}, error) {
+	return u.MockUserInfoFn(ctx, accessToken)
+}
+
+// RegisterOauthClient mocks the implementation of RegisterOauthClient method
+func (u *OauthUseCaseMock) RegisterOauthClient(ctx context.Context, accessToken string, input dto.OauthClientRegistrationInput) (*dto.OauthClientRegistrationOutput, error) {
+	return u.MockRegisterOauthClientFn(ctx, accessToken, input)
+}
+
+// GetOauthClientRegistration mocks the implementation of GetOauthClientRegistration method
+func (u *OauthUseCaseMock) GetOauthClientRegistration(ctx context.Context, clientID string, registrationAccessToken string) (*dto.OauthClientRegistrationOutput, error) {
+	return u.MockGetOauthClientRegistrationFn(ctx, clientID, registrationAccessToken)
+}
+
+// UpdateOauthClientRegistration mocks the implementation of UpdateOauthClientRegistration method
+func (u *OauthUseCaseMock) UpdateOauthClientRegistration(ctx context.Context, clientID string, registrationAccessToken string, input dto.OauthClientRegistrationInput) (*dto.OauthClientRegistrationOutput, error) {
+	return u.MockUpdateOauthClientRegistrationFn(ctx, clientID, registrationAccessToken, input)
+}
+
+// DeleteOauthClientRegistration mocks the implementation of DeleteOauthClientRegistration method
+func (u *OauthUseCaseMock) DeleteOauthClientRegistration(ctx context.Context, clientID string, registrationAccessToken string) error {
+	return u.MockDeleteOauthClientRegistrationFn(ctx, clientID, registrationAccessToken)
+}
+
+// AuthenticateClient mocks the implementation of authenticating a client using a client credentials access token
+func (u *OauthUseCaseMock) AuthenticateClient(ctx context.Context, accessToken string, scope string) (*domain.OauthClient, error) {
+	return u.MockAuthenticateClientFn(ctx, accessToken, scope)
+}
+
+// VerifyAccessToken mocks the implementation of verifying a JWT access token
+func (u *OauthUseCaseMock) VerifyAccessToken(ctx context.Context, accessToken string) (*oauth.AccessTokenClaims, error) {
+	return u.MockVerifyAccessTokenFn(ctx, accessToken)
+}
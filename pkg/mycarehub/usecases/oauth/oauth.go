@@ -14,12 +14,16 @@ import (
 
 	"github.com/ory/fosite"
 	"github.com/ory/fosite/compose"
+	"github.com/ory/fosite/handler/oauth2"
+	"github.com/ory/fosite/handler/openid"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/oauth/storage"
 	"github.com/savannahghi/serverutils"
 	"golang.org/x/crypto/bcrypt"
+	jose "gopkg.in/square/go-jose.v2"
 	"gorm.io/gorm"
 )
 
@@ -29,14 +33,31 @@ var (
 	clientID     = serverutils.MustGetEnvVar("MYCAREHUB_CLIENT_ID")
 	clientSecret = serverutils.MustGetEnvVar("MYCAREHUB_CLIENT_SECRET")
 	tokenURL     = serverutils.MustGetEnvVar("MYCAREHUB_TOKEN_URL")
+	issuer       = strings.TrimSuffix(serverutils.MustGetEnvVar("SERVICE_HOST"), "/")
 )
 
+// idTokenLifespan is how long an ID token is valid for
+const idTokenLifespan = 1 * time.Hour
+
+// accessTokenLifespan is how long an access token is valid for
+const accessTokenLifespan = 1 * time.Hour
+
 // UseCasesCommunities holds all interfaces required to implement the communities feature
 type UseCasesOauth interface {
 	CreateOauthClient(ctx context.Context, input dto.OauthClientInput) (*domain.OauthClient, error)
 	FositeProvider() fosite.OAuth2Provider
-	GenerateUserAuthTokens(ctx context.Context, userID string) (*AuthTokens, error)
+	GenerateUserAuthTokens(ctx context.Context, userID string, device dto.SessionDeviceInput) (*AuthTokens, error)
+	GenerateTOTPEnrollmentAuthTokens(ctx context.Context, userID string, device dto.SessionDeviceInput) (*AuthTokens, error)
 	RefreshAuthToken(ctx context.Context, refreshToken string) (*AuthTokens, error)
+	OpenIDConfiguration(ctx context.Context) *OpenIDConfiguration
+	JSONWebKeySet(ctx context.Context) (*jose.JSONWebKeySet, error)
+	UserInfo(ctx context.Context, accessToken string) (map[string]interface{}, error)
+	RegisterOauthClient(ctx context.Context, accessToken string, input dto.OauthClientRegistrationInput) (*dto.OauthClientRegistrationOutput, error)
+	GetOauthClientRegistration(ctx context.Context, clientID string, registrationAccessToken string) (*dto.OauthClientRegistrationOutput, error)
+	UpdateOauthClientRegistration(ctx context.Context, clientID string, registrationAccessToken string, input dto.OauthClientRegistrationInput) (*dto.OauthClientRegistrationOutput, error)
+	DeleteOauthClientRegistration(ctx context.Context, clientID string, registrationAccessToken string) error
+	AuthenticateClient(ctx context.Context, accessToken string, scope string) (*domain.OauthClient, error)
+	VerifyAccessToken(ctx context.Context, accessToken string) (*AccessTokenClaims, error)
 }
 
 // UseCasesOauthImpl represents oauth implementation
@@ -46,6 +67,9 @@ type UseCasesOauthImpl struct {
 	create   infrastructure.Create
 	delete   infrastructure.Delete
 	provider fosite.OAuth2Provider
+	signer   *keySigner
+
+	revocations *revocationList
 }
 
 // NewUseCasesOauthImplementation initializes an implementation of the fosite storage
@@ -59,29 +83,57 @@ func NewUseCasesOauthImplementation(create infrastructure.Create, update infrast
 	conf := &fosite.Config{
 		GlobalSecret: []byte(secret),
 
-		AccessTokenLifespan: 1 * time.Hour,
+		AccessTokenLifespan: accessTokenLifespan,
+		AccessTokenIssuer:   issuer,
 
-		RefreshTokenLifespan: 24 * time.Hour,
+		RefreshTokenLifespan: common.RefreshTokenLifespan,
 		RefreshTokenScopes:   []string{},
 
 		AuthorizeCodeLifespan: 5 * time.Minute,
 
+		IDTokenLifespan: idTokenLifespan,
+		IDTokenIssuer:   issuer,
+
+		// public clients e.g mobile apps and single page apps cannot keep a secret so they must prove that they started
+		// the authorization request when they exchange the authorization code
+		EnforcePKCEForPublicClients:    true,
+		EnablePKCEPlainChallengeMethod: false,
+
 		SendDebugMessagesToClients: debugEnv,
 	}
 
 	storage := storage.NewFositeStorage(create, update, query, delete)
 
+	signer := newKeySigner(newSigningKeys(create, query))
+
+	var coreStrategy oauth2.CoreStrategy = compose.NewOAuth2HMACStrategy(conf)
+	if jwtAccessTokens() {
+		coreStrategy = newJWTStrategy(signer, compose.NewOAuth2HMACStrategy(conf), conf)
+	}
+
+	strategy := compose.CommonStrategy{
+		CoreStrategy: coreStrategy,
+		OpenIDConnectTokenStrategy: &openid.DefaultStrategy{
+			Signer: signer,
+			Config: conf,
+		},
+		Signer: signer,
+	}
+
 	provider := compose.Compose(
 		conf,
 		storage,
-		compose.NewOAuth2HMACStrategy(conf),
+		strategy,
 		compose.OAuth2AuthorizeExplicitFactory,
-		compose.OAuth2AuthorizeImplicitFactory,
+		compose.OAuth2PKCEFactory,
 		compose.OAuth2ClientCredentialsGrantFactory,
 		compose.OAuth2RefreshTokenGrantFactory,
 		compose.OAuth2TokenIntrospectionFactory,
 		compose.OAuth2TokenRevocationFactory,
 		OAuth2InternalGrantFactory,
+
+		compose.OpenIDConnectExplicitFactory,
+		compose.OpenIDConnectRefreshFactory,
 	)
 
 	return UseCasesOauthImpl{
@@ -90,6 +142,9 @@ func NewUseCasesOauthImplementation(create infrastructure.Create, update infrast
 		create:   create,
 		delete:   delete,
 		provider: provider,
+		signer:   signer,
+
+		revocations: newRevocationList(query, accessTokenLifespan),
 	}
 }
 
@@ -103,7 +158,17 @@ type AuthTokens struct {
 	RefreshToken string `json:"refresh_token"`
 }
 
-func (u UseCasesOauthImpl) GenerateUserAuthTokens(ctx context.Context, userID string) (*AuthTokens, error) {
+func (u UseCasesOauthImpl) GenerateUserAuthTokens(ctx context.Context, userID string, device dto.SessionDeviceInput) (*AuthTokens, error) {
+	return u.generateUserAuthTokens(ctx, userID, device, false)
+}
+
+// GenerateTOTPEnrollmentAuthTokens generates the tokens of a staff who has to enroll an authenticator app before they
+// can use the platform. The tokens can only be used to enroll the app and the staff has to log in again once they have
+func (u UseCasesOauthImpl) GenerateTOTPEnrollmentAuthTokens(ctx context.Context, userID string, device dto.SessionDeviceInput) (*AuthTokens, error) {
+	return u.generateUserAuthTokens(ctx, userID, device, true)
+}
+
+func (u UseCasesOauthImpl) generateUserAuthTokens(ctx context.Context, userID string, device dto.SessionDeviceInput, totpEnrollment bool) (*AuthTokens, error) {
 	client, err := u.getOrCreateInternalCLient(ctx)
 	if err != nil {
 		return nil, err
@@ -115,10 +180,20 @@ func (u UseCasesOauthImpl) GenerateUserAuthTokens(ctx context.Context, userID st
 	}
 
 	extraDetails := map[string]interface{}{
-		"user_id": *user.ID,
+		"user_id":         *user.ID,
+		"organisation_id": user.CurrentOrganizationID,
+		"program_id":      user.CurrentProgramID,
+	}
+
+	if totpEnrollment {
+		extraDetails[domain.TOTPEnrollmentClaim] = true
 	}
 
 	session := domain.NewSession(ctx, client.ID, *user.ID, user.Username, user.Name, extraDetails)
+	session.DeviceID = device.DeviceID
+	session.DeviceName = device.DeviceName
+	session.UserAgent = device.UserAgent
+	session.IPAddress = device.IPAddress
 	request := fosite.NewAccessRequest(session)
 	request.GrantTypes = []string{"internal"}
 	request.Client = client
@@ -184,23 +259,47 @@ func (u UseCasesOauthImpl) getOrCreateInternalCLient(ctx context.Context) (*doma
 }
 
 // CreateOauthClient is the resolver for the createOauthClient field.
+// Public clients do not have a secret and have to use PKCE. Integration clients e.g KenyaEMR are bound to the facility they act for
 func (u UseCasesOauthImpl) CreateOauthClient(ctx context.Context, input dto.OauthClientInput) (*domain.OauthClient, error) {
-	secret, err := bcrypt.GenerateFromPassword([]byte(input.Secret), fosite.DefaultBCryptWorkFactor)
-	if err != nil {
-		return nil, err
-	}
-
 	client := &domain.OauthClient{
 		Name:                    input.Name,
-		Secret:                  string(secret),
 		RedirectURIs:            input.RedirectURIs,
 		Active:                  true,
+		Public:                  input.Public,
+		Scopes:                  input.Scopes,
 		Grants:                  input.Grants,
 		ResponseTypes:           input.ResponseTypes,
-		TokenEndpointAuthMethod: "client_secret_basic",
+		TokenEndpointAuthMethod: tokenEndpointAuthMethodBasic,
+		MFLCode:                 input.MFLCode,
 	}
 
-	err = u.create.CreateOauthClient(ctx, client)
+	if input.Public {
+		client.TokenEndpointAuthMethod = tokenEndpointAuthMethodNone
+	} else {
+		if input.Secret == "" {
+			return nil, fmt.Errorf("a secret is required for a confidential client")
+		}
+
+		secret, err := bcrypt.GenerateFromPassword([]byte(input.Secret), fosite.DefaultBCryptWorkFactor)
+		if err != nil {
+			return nil, err
+		}
+		client.Secret = string(secret)
+	}
+
+	if err := validateClientMetadata(ctx, client, clientGrantTypes); err != nil {
+		return nil, fmt.Errorf("invalid oauth client: %s", fosite.ErrorToRFC6749Error(err).GetDescription())
+	}
+
+	if err := validateClientScopes(client); err != nil {
+		return nil, fmt.Errorf("invalid oauth client: %w", err)
+	}
+
+	if err := u.validateFacilityClient(ctx, client); err != nil {
+		return nil, fmt.Errorf("invalid oauth client: %w", err)
+	}
+
+	err := u.create.CreateOauthClient(ctx, client)
 	if err != nil {
 		return nil, err
 	}
@@ -253,3 +352,72 @@ func (u UseCasesOauthImpl) RefreshAuthToken(ctx context.Context, refreshToken st
 
 	return &tokens, nil
 }
+
+// OpenIDConfiguration is the OpenID Connect discovery document that clients use to configure themselves
+type OpenIDConfiguration struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	UserInfoEndpoint                  string   `json:"userinfo_endpoint"`
+	JWKSURI                           string   `json:"jwks_uri"`
+	RevocationEndpoint                string   `json:"revocation_endpoint"`
+	IntrospectionEndpoint             string   `json:"introspection_endpoint"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	ClaimsSupported                   []string `json:"claims_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+	RegistrationEndpoint              string   `json:"registration_endpoint"`
+}
+
+// OpenIDConfiguration returns the OpenID Connect discovery document
+func (u UseCasesOauthImpl) OpenIDConfiguration(ctx context.Context) *OpenIDConfiguration {
+	claims := []string{"sub", "iss", "aud", "exp", "iat", "auth_time", "nonce", "at_hash", "name", "preferred_username"}
+	claims = append(claims, domain.IDTokenExtraClaims...)
+
+	return &OpenIDConfiguration{
+		Issuer:                            issuer,
+		AuthorizationEndpoint:             issuer + "/oauth/authorize",
+		TokenEndpoint:                     issuer + "/oauth/token",
+		UserInfoEndpoint:                  issuer + "/oauth/userinfo",
+		JWKSURI:                           issuer + "/.well-known/jwks.json",
+		RevocationEndpoint:                issuer + "/oauth/revoke",
+		IntrospectionEndpoint:             issuer + "/oauth/introspect",
+		ScopesSupported:                   supportedScopes,
+		ResponseTypesSupported:            supportedResponseTypes,
+		GrantTypesSupported:               clientGrantTypes,
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  []string{signingKeyAlgorithm},
+		TokenEndpointAuthMethodsSupported: supportedTokenEndpointAuthMethods,
+		ClaimsSupported:                   claims,
+		CodeChallengeMethodsSupported:     []string{"S256"},
+		RegistrationEndpoint:              issuer + "/oauth/register",
+	}
+}
+
+// JSONWebKeySet returns the public keys that ID tokens can be verified with
+func (u UseCasesOauthImpl) JSONWebKeySet(ctx context.Context) (*jose.JSONWebKeySet, error) {
+	return u.signer.jsonWebKeySet(ctx)
+}
+
+// UserInfo returns the claims about the user that an access token was issued for.
+// The token should have been granted the openid scope
+func (u UseCasesOauthImpl) UserInfo(ctx context.Context, accessToken string) (map[string]interface{}, error) {
+	_, requester, err := u.provider.IntrospectToken(ctx, accessToken, fosite.AccessToken, new(domain.Session), "openid")
+	if err != nil {
+		return nil, err
+	}
+
+	session, ok := requester.GetSession().(*domain.Session)
+	if !ok || session.UserID == "" {
+		return nil, fosite.ErrInvalidRequest.WithHint("The access token was not issued to a user.")
+	}
+
+	claims := session.UserInfoClaims()
+	claims["sub"] = session.UserID
+
+	return claims, nil
+}
@@ -2,14 +2,22 @@ package oauth_test
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/brianvoe/gofakeit"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	pgMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/mock"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/oauth"
+	josejwt "gopkg.in/square/go-jose.v2/jwt"
 	"gorm.io/gorm"
 )
 
@@ -57,12 +65,153 @@ func TestUseCasesOauthImpl_CreateOauthClient(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "happy case: create public oauth client",
+			args: args{
+				ctx: context.Background(),
+				input: dto.OauthClientInput{
+					Name:          "Mobile App",
+					Public:        true,
+					RedirectURIs:  []string{"com.example.app:/callback"},
+					Scopes:        []string{"openid", "offline"},
+					ResponseTypes: []string{"code"},
+					Grants:        []string{"authorization_code", "refresh_token"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "sad case: confidential client without a secret",
+			args: args{
+				ctx: context.Background(),
+				input: dto.OauthClientInput{
+					Name:   "Client One",
+					Grants: []string{"client_credentials"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: public client with the client credentials grant",
+			args: args{
+				ctx: context.Background(),
+				input: dto.OauthClientInput{
+					Name:   "Mobile App",
+					Public: true,
+					Grants: []string{"client_credentials"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: implicit grant",
+			args: args{
+				ctx: context.Background(),
+				input: dto.OauthClientInput{
+					Name:          "Single Page App",
+					Public:        true,
+					RedirectURIs:  []string{"https://example.com/callback"},
+					ResponseTypes: []string{"token"},
+					Grants:        []string{"implicit"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: authorization code grant without a redirect URI",
+			args: args{
+				ctx: context.Background(),
+				input: dto.OauthClientInput{
+					Name:          "Client One",
+					Secret:        gofakeit.Password(true, true, true, true, false, 10),
+					ResponseTypes: []string{"code"},
+					Grants:        []string{"authorization_code"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: insecure redirect URI",
+			args: args{
+				ctx: context.Background(),
+				input: dto.OauthClientInput{
+					Name:          "Client One",
+					Secret:        gofakeit.Password(true, true, true, true, false, 10),
+					RedirectURIs:  []string{"http://example.com/callback"},
+					ResponseTypes: []string{"code"},
+					Grants:        []string{"authorization_code"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "happy case: create facility client",
+			args: args{
+				ctx: context.Background(),
+				input: dto.OauthClientInput{
+					Name:    "KenyaEMR",
+					Secret:  gofakeit.Password(true, true, true, true, false, 10),
+					Scopes:  []string{oauth.ScopeKenyaEMRAppointmentsWrite, oauth.ScopeKenyaEMRServiceRequestsRead},
+					Grants:  []string{"client_credentials"},
+					MFLCode: "1234",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "sad case: unsupported scope",
+			args: args{
+				ctx: context.Background(),
+				input: dto.OauthClientInput{
+					Name:   "KenyaEMR",
+					Secret: gofakeit.Password(true, true, true, true, false, 10),
+					Scopes: []string{"admin"},
+					Grants: []string{"client_credentials"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: facility client without the client credentials grant",
+			args: args{
+				ctx: context.Background(),
+				input: dto.OauthClientInput{
+					Name:          "KenyaEMR",
+					Secret:        gofakeit.Password(true, true, true, true, false, 10),
+					RedirectURIs:  []string{"https://example.com/callback"},
+					ResponseTypes: []string{"code"},
+					Grants:        []string{"authorization_code"},
+					MFLCode:       "1234",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: facility does not exist",
+			args: args{
+				ctx: context.Background(),
+				input: dto.OauthClientInput{
+					Name:    "KenyaEMR",
+					Secret:  gofakeit.Password(true, true, true, true, false, 10),
+					Scopes:  []string{oauth.ScopeKenyaEMRAppointmentsWrite},
+					Grants:  []string{"client_credentials"},
+					MFLCode: "1234",
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			fakeDB := pgMock.NewPostgresMock()
 			u := oauth.NewUseCasesOauthImplementation(fakeDB, fakeDB, fakeDB, fakeDB)
 
+			if tt.name == "sad case: facility does not exist" {
+				fakeDB.MockRetrieveFacilityByIdentifierFn = func(ctx context.Context, identifier *dto.FacilityIdentifierInput, isActive bool) (*domain.Facility, error) {
+					return nil, fmt.Errorf("facility not found")
+				}
+			}
+
 			if tt.name == "sad case: error creating client" {
 				fakeDB.MockCreateOauthClient = func(ctx context.Context, client *domain.OauthClient) error {
 					return fmt.Errorf("failed to create client")
@@ -86,6 +235,7 @@ func TestUseCasesOauthImpl_GenerateUserAuthTokens(t *testing.T) {
 	type args struct {
 		ctx    context.Context
 		userID string
+		device dto.SessionDeviceInput
 	}
 	tests := []struct {
 		name    string
@@ -100,6 +250,20 @@ func TestUseCasesOauthImpl_GenerateUserAuthTokens(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "happy case: session records the device",
+			args: args{
+				ctx:    context.Background(),
+				userID: gofakeit.UUID(),
+				device: dto.SessionDeviceInput{
+					DeviceID:   gofakeit.UUID(),
+					DeviceName: "Pixel 7",
+					UserAgent:  "okhttp/4.9.2",
+					IPAddress:  "127.0.0.1",
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "happy case: generate user tokens new client",
 			args: args{
@@ -144,6 +308,21 @@ func TestUseCasesOauthImpl_GenerateUserAuthTokens(t *testing.T) {
 				}
 			}
 
+			if tt.name == "happy case: session records the device" {
+				fakeDB.MockCreateOrUpdateSessionFn = func(ctx context.Context, session *domain.Session) error {
+					if session.DeviceID != tt.args.device.DeviceID || session.UserAgent != tt.args.device.UserAgent {
+						t.Errorf("expected the session to record the device %v, got %v", tt.args.device, session)
+					}
+					if session.Extra[domain.SessionIDClaim] != session.ID {
+						t.Errorf("expected the session ID claim to be %s", session.ID)
+					}
+					if _, ok := session.Extra[domain.TOTPEnrollmentClaim]; ok {
+						t.Errorf("expected a session that is not limited to enrolling an authenticator app")
+					}
+					return nil
+				}
+			}
+
 			if tt.name == "sad case: fail to get oauth client" {
 				fakeDB.MockGetOauthClient = func(ctx context.Context, id string) (*domain.OauthClient, error) {
 					return nil, fmt.Errorf("database error")
@@ -162,7 +341,7 @@ func TestUseCasesOauthImpl_GenerateUserAuthTokens(t *testing.T) {
 				}
 			}
 
-			got, err := u.GenerateUserAuthTokens(tt.args.ctx, tt.args.userID)
+			got, err := u.GenerateUserAuthTokens(tt.args.ctx, tt.args.userID, tt.args.device)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("UseCasesOauthImpl.GenerateUserAuthTokens() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -174,6 +353,64 @@ func TestUseCasesOauthImpl_GenerateUserAuthTokens(t *testing.T) {
 	}
 }
 
+func TestUseCasesOauthImpl_GenerateTOTPEnrollmentAuthTokens(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		userID string
+		device dto.SessionDeviceInput
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "happy case: session can only be used to enroll an authenticator app",
+			args: args{
+				ctx:    context.Background(),
+				userID: gofakeit.UUID(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "sad case: fail to get user profile",
+			args: args{
+				ctx:    context.Background(),
+				userID: gofakeit.UUID(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			u := oauth.NewUseCasesOauthImplementation(fakeDB, fakeDB, fakeDB, fakeDB)
+
+			fakeDB.MockCreateOrUpdateSessionFn = func(ctx context.Context, session *domain.Session) error {
+				if session.Extra[domain.TOTPEnrollmentClaim] != true {
+					t.Errorf("expected a session that can only be used to enroll an authenticator app")
+				}
+				return nil
+			}
+
+			if tt.name == "sad case: fail to get user profile" {
+				fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.User, error) {
+					return nil, gorm.ErrRecordNotFound
+				}
+			}
+
+			got, err := u.GenerateTOTPEnrollmentAuthTokens(tt.args.ctx, tt.args.userID, tt.args.device)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesOauthImpl.GenerateTOTPEnrollmentAuthTokens() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got == nil {
+				t.Errorf("UseCasesOauthImpl.GenerateTOTPEnrollmentAuthTokens() got = %v, wantErr %v", got, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestUseCasesOauthImpl_RefreshAutToken(t *testing.T) {
 	type args struct {
 		ctx          context.Context
@@ -221,3 +458,440 @@ func TestUseCasesOauthImpl_RefreshAutToken(t *testing.T) {
 		})
 	}
 }
+
+func TestUseCasesOauthImpl_OpenIDConfiguration(t *testing.T) {
+	fakeDB := pgMock.NewPostgresMock()
+	u := oauth.NewUseCasesOauthImplementation(fakeDB, fakeDB, fakeDB, fakeDB)
+
+	got := u.OpenIDConfiguration(context.Background())
+
+	if got.Issuer == "" || !strings.HasPrefix(got.JWKSURI, got.Issuer) || !strings.HasPrefix(got.UserInfoEndpoint, got.Issuer) {
+		t.Errorf("UseCasesOauthImpl.OpenIDConfiguration() endpoints should be on the issuer, got %v", got)
+	}
+
+	if len(got.IDTokenSigningAlgValuesSupported) != 1 || got.IDTokenSigningAlgValuesSupported[0] != "RS256" {
+		t.Errorf("UseCasesOauthImpl.OpenIDConfiguration() signing algorithms = %v, want [RS256]", got.IDTokenSigningAlgValuesSupported)
+	}
+}
+
+func TestUseCasesOauthImpl_JSONWebKeySet(t *testing.T) {
+	tests := []struct {
+		name     string
+		wantKeys int
+		wantErr  bool
+	}{
+		{
+			name:     "happy case: publish the signing key",
+			wantKeys: 1,
+			wantErr:  false,
+		},
+		{
+			name:    "sad case: failed to list signing keys",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			u := oauth.NewUseCasesOauthImplementation(fakeDB, fakeDB, fakeDB, fakeDB)
+
+			storedKeys := []*domain.OauthSigningKey{}
+			fakeDB.MockCreateOauthSigningKeyFn = func(ctx context.Context, key *domain.OauthSigningKey) error {
+				storedKeys = append(storedKeys, key)
+				return nil
+			}
+			fakeDB.MockListOauthSigningKeysFn = func(ctx context.Context, activeAt time.Time) ([]*domain.OauthSigningKey, error) {
+				return storedKeys, nil
+			}
+
+			if tt.name == "sad case: failed to list signing keys" {
+				fakeDB.MockListOauthSigningKeysFn = func(ctx context.Context, activeAt time.Time) ([]*domain.OauthSigningKey, error) {
+					return nil, fmt.Errorf("failed to list signing keys")
+				}
+			}
+
+			if !tt.wantErr {
+				// a key is created when the first token is signed
+				issueIDToken(t, u, fakeDB)
+			}
+
+			got, err := u.JSONWebKeySet(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesOauthImpl.JSONWebKeySet() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if len(got.Keys) != tt.wantKeys {
+				t.Errorf("UseCasesOauthImpl.JSONWebKeySet() got %d keys, want %d", len(got.Keys), tt.wantKeys)
+				return
+			}
+
+			if !got.Keys[0].IsPublic() || got.Keys[0].KeyID != storedKeys[0].KeyID {
+				t.Errorf("UseCasesOauthImpl.JSONWebKeySet() should publish the public key %s", storedKeys[0].KeyID)
+			}
+		})
+	}
+}
+
+// newPublicClient returns a public client e.g a mobile app that logs users in with the authorization code flow
+func newPublicClient(fakeDB *pgMock.PostgresMock) *domain.OauthClient {
+	client := &domain.OauthClient{
+		ID:                      gofakeit.UUID(),
+		Active:                  true,
+		Public:                  true,
+		RedirectURIs:            []string{"http://localhost/callback"},
+		Scopes:                  []string{"openid"},
+		Grants:                  []string{"authorization_code"},
+		ResponseTypes:           []string{"code"},
+		TokenEndpointAuthMethod: "none",
+	}
+	fakeDB.MockGetOauthClient = func(ctx context.Context, id string) (*domain.OauthClient, error) {
+		return client, nil
+	}
+
+	// the authorization request is stored when the code is issued and loaded when the code is exchanged
+	var session *domain.Session
+	var authorizationCode *domain.AuthorizationCode
+	var pkce *domain.PKCE
+	var openIDConnectSession *domain.OpenIDConnectSession
+
+	fakeDB.MockCreateOrUpdateSessionFn = func(ctx context.Context, s *domain.Session) error {
+		session = s
+		return nil
+	}
+	fakeDB.MockCreateAuthorizationCodeFn = func(ctx context.Context, code *domain.AuthorizationCode) error {
+		authorizationCode = code
+		return nil
+	}
+	fakeDB.MockGetAuthorizationCodeFn = func(ctx context.Context, code string) (*domain.AuthorizationCode, error) {
+		if authorizationCode == nil || authorizationCode.Code != code {
+			return nil, fmt.Errorf("error fetching authorization code: %w", gorm.ErrRecordNotFound)
+		}
+		stored := *authorizationCode
+		stored.Client, stored.Session = *client, *session
+		return &stored, nil
+	}
+	fakeDB.MockCreatePKCEFn = func(ctx context.Context, p *domain.PKCE) error {
+		pkce = p
+		return nil
+	}
+	fakeDB.MockGetPKCEFn = func(ctx context.Context, signature string) (*domain.PKCE, error) {
+		if pkce == nil || pkce.Signature != signature {
+			return nil, fmt.Errorf("error fetching pkce session: %w", gorm.ErrRecordNotFound)
+		}
+		stored := *pkce
+		stored.Client, stored.Session = *client, *session
+		return &stored, nil
+	}
+	fakeDB.MockCreateOpenIDConnectSessionFn = func(ctx context.Context, s *domain.OpenIDConnectSession) error {
+		openIDConnectSession = s
+		return nil
+	}
+	fakeDB.MockGetOpenIDConnectSessionFn = func(ctx context.Context, code string) (*domain.OpenIDConnectSession, error) {
+		if openIDConnectSession == nil || openIDConnectSession.Code != code {
+			return nil, fmt.Errorf("error fetching openid connect session: %w", gorm.ErrRecordNotFound)
+		}
+		stored := *openIDConnectSession
+		stored.Client, stored.Session = *client, *session
+		return &stored, nil
+	}
+
+	return client
+}
+
+// codeChallenge returns the S256 code challenge of a PKCE code verifier
+func codeChallenge(verifier string) string {
+	hash := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+// authorize runs the authorization endpoint of the authorization code flow and returns the code
+func authorize(u oauth.UseCasesOauthImpl, client *domain.OauthClient, challenge string, challengeMethod string) (string, error) {
+	query := url.Values{
+		"client_id":     []string{client.ID},
+		"response_type": []string{"code"},
+		"redirect_uri":  []string{"http://localhost/callback"},
+		"scope":         []string{"openid"},
+		"state":         []string{"state-12345678"},
+		"nonce":         []string{"nonce-12345678"},
+	}
+	if challenge != "" {
+		query.Set("code_challenge", challenge)
+		query.Set("code_challenge_method", challengeMethod)
+	}
+	request := httptest.NewRequest(http.MethodGet, "/oauth/authorize?"+query.Encode(), nil)
+
+	ctx := context.Background()
+	provider := u.FositeProvider()
+
+	authorizeRequest, err := provider.NewAuthorizeRequest(ctx, request)
+	if err != nil {
+		return "", err
+	}
+	authorizeRequest.GrantScope("openid")
+
+	session := domain.NewSession(ctx, client.ID, gofakeit.UUID(), "jdoe", "John Doe", map[string]interface{}{
+		"program_id":    gofakeit.UUID(),
+		"program_name":  "Program",
+		"facility_id":   gofakeit.UUID(),
+		"facility_name": "Facility",
+	})
+	session.CreatedAt = time.Now()
+
+	response, err := provider.NewAuthorizeResponse(ctx, authorizeRequest, session)
+	if err != nil {
+		return "", err
+	}
+
+	return response.GetCode(), nil
+}
+
+// exchangeCode exchanges an authorization code for tokens at the token endpoint
+func exchangeCode(u oauth.UseCasesOauthImpl, client *domain.OauthClient, code string, verifier string) (map[string]interface{}, error) {
+	form := url.Values{
+		"grant_type":    []string{"authorization_code"},
+		"client_id":     []string{client.ID},
+		"code":          []string{code},
+		"redirect_uri":  []string{"http://localhost/callback"},
+		"code_verifier": []string{verifier},
+	}
+	request := httptest.NewRequest(http.MethodPost, "/oauth/token", strings.NewReader(form.Encode()))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	ctx := context.Background()
+	provider := u.FositeProvider()
+
+	accessRequest, err := provider.NewAccessRequest(ctx, request, new(domain.Session))
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := provider.NewAccessResponse(ctx, accessRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.ToMap(), nil
+}
+
+// issueIDToken runs the authorization code flow with PKCE for a public client and returns the ID token
+func issueIDToken(t *testing.T, u oauth.UseCasesOauthImpl, fakeDB *pgMock.PostgresMock) string {
+	client := newPublicClient(fakeDB)
+	verifier := gofakeit.Password(true, true, true, false, false, 64)
+
+	code, err := authorize(u, client, codeChallenge(verifier), "S256")
+	if err != nil {
+		t.Fatalf("failed to authorize: %v", err)
+	}
+
+	tokens, err := exchangeCode(u, client, code, verifier)
+	if err != nil {
+		t.Fatalf("failed to exchange the authorization code: %v", err)
+	}
+
+	idToken, _ := tokens["id_token"].(string)
+	if idToken == "" {
+		t.Fatalf("expected an ID token in the token response")
+	}
+
+	return idToken
+}
+
+func TestUseCasesOauthImpl_PKCE(t *testing.T) {
+	verifier := gofakeit.Password(true, true, true, false, false, 64)
+
+	tests := []struct {
+		name                string
+		challenge           string
+		challengeMethod     string
+		verifier            string
+		wantAuthorizeErr    bool
+		wantExchangeCodeErr bool
+	}{
+		{
+			name:            "happy case: exchange a code with the code verifier",
+			challenge:       codeChallenge(verifier),
+			challengeMethod: "S256",
+			verifier:        verifier,
+		},
+		{
+			name:             "sad case: public client without a code challenge",
+			wantAuthorizeErr: true,
+		},
+		{
+			name:             "sad case: plain code challenge",
+			challenge:        verifier,
+			challengeMethod:  "plain",
+			verifier:         verifier,
+			wantAuthorizeErr: true,
+		},
+		{
+			name:                "sad case: wrong code verifier",
+			challenge:           codeChallenge(verifier),
+			challengeMethod:     "S256",
+			verifier:            gofakeit.Password(true, true, true, false, false, 64),
+			wantExchangeCodeErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			u := oauth.NewUseCasesOauthImplementation(fakeDB, fakeDB, fakeDB, fakeDB)
+			client := newPublicClient(fakeDB)
+
+			code, err := authorize(u, client, tt.challenge, tt.challengeMethod)
+			if (err != nil) != tt.wantAuthorizeErr {
+				t.Errorf("authorize error = %v, wantErr %v", err, tt.wantAuthorizeErr)
+				return
+			}
+			if tt.wantAuthorizeErr {
+				return
+			}
+
+			tokens, err := exchangeCode(u, client, code, tt.verifier)
+			if (err != nil) != tt.wantExchangeCodeErr {
+				t.Errorf("exchange code error = %v, wantErr %v", err, tt.wantExchangeCodeErr)
+				return
+			}
+			if !tt.wantExchangeCodeErr && tokens["access_token"] == nil {
+				t.Errorf("expected an access token")
+			}
+		})
+	}
+}
+
+func TestUseCasesOauthImpl_IDToken(t *testing.T) {
+	fakeDB := pgMock.NewPostgresMock()
+	u := oauth.NewUseCasesOauthImplementation(fakeDB, fakeDB, fakeDB, fakeDB)
+
+	storedKeys := []*domain.OauthSigningKey{}
+	fakeDB.MockCreateOauthSigningKeyFn = func(ctx context.Context, key *domain.OauthSigningKey) error {
+		storedKeys = append(storedKeys, key)
+		return nil
+	}
+	fakeDB.MockListOauthSigningKeysFn = func(ctx context.Context, activeAt time.Time) ([]*domain.OauthSigningKey, error) {
+		return storedKeys, nil
+	}
+
+	idToken := issueIDToken(t, u, fakeDB)
+
+	keySet, err := u.JSONWebKeySet(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get the JSON web key set: %v", err)
+	}
+
+	token, err := josejwt.ParseSigned(idToken)
+	if err != nil {
+		t.Fatalf("failed to parse ID token: %v", err)
+	}
+
+	keys := keySet.Key(token.Headers[0].KeyID)
+	if len(keys) != 1 {
+		t.Fatalf("expected the ID token to be signed with a published key, got kid %q", token.Headers[0].KeyID)
+	}
+
+	claims := map[string]interface{}{}
+	if err := token.Claims(keys[0].Key, &claims); err != nil {
+		t.Fatalf("failed to verify ID token: %v", err)
+	}
+
+	for _, claim := range []string{"sub", "iss", "nonce", "name", "preferred_username", "program_id", "program_name", "facility_id", "facility_name"} {
+		if _, ok := claims[claim]; !ok {
+			t.Errorf("expected the ID token to have the %s claim, got %v", claim, claims)
+		}
+	}
+
+	if claims["nonce"] != "nonce-12345678" {
+		t.Errorf("expected the ID token nonce to be nonce-12345678, got %v", claims["nonce"])
+	}
+}
+
+func TestUseCasesOauthImpl_UserInfo(t *testing.T) {
+	userID := gofakeit.UUID()
+
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{
+			name:    "happy case: get user info",
+			wantErr: false,
+		},
+		{
+			name:    "sad case: token was not granted the openid scope",
+			wantErr: true,
+		},
+		{
+			name:    "sad case: token was not issued to a user",
+			wantErr: true,
+		},
+		{
+			name:    "sad case: revoked session",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			u := oauth.NewUseCasesOauthImplementation(fakeDB, fakeDB, fakeDB, fakeDB)
+
+			tokens, err := u.GenerateUserAuthTokens(context.Background(), userID, dto.SessionDeviceInput{})
+			if err != nil {
+				t.Fatalf("failed to generate tokens: %v", err)
+			}
+
+			accessToken := &domain.AccessToken{
+				Active:        true,
+				RequestedAt:   time.Now(),
+				GrantedScopes: []string{"openid"},
+				Session: domain.Session{
+					ID:       gofakeit.UUID(),
+					UserID:   userID,
+					Username: "jdoe",
+					Subject:  "John Doe",
+					Extra: map[string]interface{}{
+						"program_id":  gofakeit.UUID(),
+						"facility_id": gofakeit.UUID(),
+						"is_staff":    true,
+					},
+				},
+			}
+
+			if tt.name == "sad case: token was not granted the openid scope" {
+				accessToken.GrantedScopes = []string{}
+			}
+
+			if tt.name == "sad case: token was not issued to a user" {
+				accessToken.Session.UserID = ""
+			}
+
+			if tt.name == "sad case: revoked session" {
+				now := time.Now()
+				accessToken.Session.RevokedAt = &now
+			}
+
+			fakeDB.MockGetAccessTokenFn = func(ctx context.Context, token domain.AccessToken) (*domain.AccessToken, error) {
+				return accessToken, nil
+			}
+
+			got, err := u.UserInfo(context.Background(), tokens.AccessToken)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesOauthImpl.UserInfo() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if got["sub"] != userID || got["preferred_username"] != "jdoe" || got["program_id"] == nil {
+				t.Errorf("UseCasesOauthImpl.UserInfo() got = %v", got)
+			}
+			if _, ok := got["is_staff"]; ok {
+				t.Errorf("UseCasesOauthImpl.UserInfo() should only return the OpenID Connect claims, got %v", got)
+			}
+		})
+	}
+}
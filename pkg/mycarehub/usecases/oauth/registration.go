@@ -0,0 +1,360 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/ory/fosite"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	grantTypeAuthorizationCode = "authorization_code"
+	grantTypeRefreshToken      = "refresh_token"
+	grantTypeClientCredentials = "client_credentials"
+
+	responseTypeCode = "code"
+
+	tokenEndpointAuthMethodBasic = "client_secret_basic"
+	tokenEndpointAuthMethodPost  = "client_secret_post"
+	tokenEndpointAuthMethodNone  = "none"
+
+	// initialAccessTokenEnvVarName is the token that integrators register clients with.
+	// Dynamic client registration is disabled when it is not set
+	initialAccessTokenEnvVarName = "OAUTH_INITIAL_ACCESS_TOKEN"
+
+	// registrationTokenBytes is the number of random bytes in a registration access token or a client secret
+	registrationTokenBytes = 32
+)
+
+var (
+	// supportedScopes are the scopes that dynamically registered clients can request
+	supportedScopes = []string{"openid", "offline"}
+
+	// clientGrantTypes are the grant types that a client created by staff can use
+	clientGrantTypes = []string{grantTypeAuthorizationCode, grantTypeRefreshToken, grantTypeClientCredentials}
+
+	// registeredClientGrantTypes are the grant types that a dynamically registered client can use.
+	// They only act on behalf of users who have logged in
+	registeredClientGrantTypes = []string{grantTypeAuthorizationCode, grantTypeRefreshToken}
+
+	supportedResponseTypes            = []string{responseTypeCode}
+	supportedTokenEndpointAuthMethods = []string{tokenEndpointAuthMethodBasic, tokenEndpointAuthMethodPost, tokenEndpointAuthMethodNone}
+)
+
+var (
+	// ErrInvalidClientMetadata is returned when the metadata that a client is registered with is invalid or inconsistent
+	ErrInvalidClientMetadata = &fosite.RFC6749Error{
+		ErrorField:       "invalid_client_metadata",
+		DescriptionField: "The value of one of the client metadata fields is invalid.",
+		CodeField:        http.StatusBadRequest,
+	}
+
+	// ErrInvalidRedirectURI is returned when one of the redirect URIs that a client is registered with is invalid
+	ErrInvalidRedirectURI = &fosite.RFC6749Error{
+		ErrorField:       "invalid_redirect_uri",
+		DescriptionField: "The value of one or more redirection URIs is invalid.",
+		CodeField:        http.StatusBadRequest,
+	}
+
+	// ErrInvalidRegistrationToken is returned when the initial access token or registration access token is missing or invalid
+	ErrInvalidRegistrationToken = &fosite.RFC6749Error{
+		ErrorField:       "invalid_token",
+		DescriptionField: "The access token provided is expired, revoked, malformed, or invalid for other reasons.",
+		CodeField:        http.StatusUnauthorized,
+	}
+)
+
+// validateClientMetadata checks that a client only uses the supported grant types, response types and authentication methods
+// and that they are consistent with each other
+func validateClientMetadata(ctx context.Context, client *domain.OauthClient, grantTypes []string) error {
+	grants := fosite.Arguments(client.Grants)
+	responseTypes := fosite.Arguments(client.ResponseTypes)
+
+	if !fosite.Arguments(grantTypes).Has(client.Grants...) {
+		return ErrInvalidClientMetadata.WithHintf("The supported grant types are %s.", strings.Join(grantTypes, ", "))
+	}
+
+	if !fosite.Arguments(supportedResponseTypes).Has(client.ResponseTypes...) {
+		return ErrInvalidClientMetadata.WithHintf("The supported response types are %s.", strings.Join(supportedResponseTypes, ", "))
+	}
+
+	if !fosite.Arguments(supportedTokenEndpointAuthMethods).Has(client.TokenEndpointAuthMethod) {
+		return ErrInvalidClientMetadata.WithHintf("The supported token endpoint authentication methods are %s.", strings.Join(supportedTokenEndpointAuthMethods, ", "))
+	}
+
+	if client.Public != (client.TokenEndpointAuthMethod == tokenEndpointAuthMethodNone) {
+		return ErrInvalidClientMetadata.WithHint("Public clients, and only public clients, should use the 'none' token endpoint authentication method.")
+	}
+
+	// public clients cannot keep a secret so they can only get tokens on behalf of a user
+	if client.Public && grants.Has(grantTypeClientCredentials) {
+		return ErrInvalidClientMetadata.WithHint("Public clients cannot use the client_credentials grant type.")
+	}
+
+	if grants.Has(grantTypeAuthorizationCode) != responseTypes.Has(responseTypeCode) {
+		return ErrInvalidClientMetadata.WithHint("The authorization_code grant type and the code response type should be used together.")
+	}
+
+	if grants.Has(grantTypeAuthorizationCode) && len(client.RedirectURIs) == 0 {
+		return ErrInvalidRedirectURI.WithHint("At least one redirect URI is required for the authorization_code grant type.")
+	}
+
+	for _, redirectURI := range client.RedirectURIs {
+		uri, err := url.Parse(redirectURI)
+		if err != nil || !fosite.IsValidRedirectURI(uri) {
+			return ErrInvalidRedirectURI.WithHintf("The redirect URI '%s' should be an absolute URI without a fragment.", redirectURI)
+		}
+
+		if !fosite.IsRedirectURISecure(ctx, uri) {
+			return ErrInvalidRedirectURI.WithHintf("The redirect URI '%s' should use https unless it is on the loopback interface.", redirectURI)
+		}
+	}
+
+	return nil
+}
+
+// initialAccessToken returns the token that clients are registered with. It is read when needed so that it can be rotated
+func initialAccessToken() string {
+	return os.Getenv(initialAccessTokenEnvVarName)
+}
+
+// newRegistrationToken returns a random token that is used as a registration access token or a client secret
+func newRegistrationToken() (string, error) {
+	token := make([]byte, registrationTokenBytes)
+	if _, err := rand.Read(token); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+// hashRegistrationToken returns the hash of a registration access token as it is stored
+func hashRegistrationToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// clientFromRegistration returns the client described by the metadata that a client registered with.
+// The defaults are those of RFC 7591
+func clientFromRegistration(input dto.OauthClientRegistrationInput) (*domain.OauthClient, error) {
+	client := &domain.OauthClient{
+		Name:                    input.ClientName,
+		Active:                  true,
+		RedirectURIs:            input.RedirectURIs,
+		Grants:                  input.GrantTypes,
+		ResponseTypes:           input.ResponseTypes,
+		TokenEndpointAuthMethod: input.TokenEndpointAuthMethod,
+		Scopes:                  strings.Fields(input.Scope),
+	}
+
+	if len(client.Grants) == 0 {
+		client.Grants = []string{grantTypeAuthorizationCode}
+	}
+
+	if len(client.ResponseTypes) == 0 {
+		client.ResponseTypes = []string{responseTypeCode}
+	}
+
+	if client.TokenEndpointAuthMethod == "" {
+		client.TokenEndpointAuthMethod = tokenEndpointAuthMethodBasic
+	}
+
+	client.Public = client.TokenEndpointAuthMethod == tokenEndpointAuthMethodNone
+
+	if !fosite.Arguments(supportedScopes).Has(client.Scopes...) {
+		return nil, ErrInvalidClientMetadata.WithHintf("The supported scopes are %s.", strings.Join(supportedScopes, " "))
+	}
+
+	return client, nil
+}
+
+// setClientSecret generates a secret for a confidential client and stores its hash on the client.
+// Public clients do not have a secret
+func setClientSecret(client *domain.OauthClient) (string, error) {
+	if client.Public {
+		client.Secret = ""
+		return "", nil
+	}
+
+	secret, err := newRegistrationToken()
+	if err != nil {
+		return "", err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(secret), fosite.DefaultBCryptWorkFactor)
+	if err != nil {
+		return "", err
+	}
+
+	client.Secret = string(hash)
+
+	return secret, nil
+}
+
+// registrationOutput returns the information about a registered client that is sent back to the client
+func registrationOutput(client *domain.OauthClient, secret string, registrationAccessToken string) *dto.OauthClientRegistrationOutput {
+	return &dto.OauthClientRegistrationOutput{
+		ClientID:                client.ID,
+		ClientSecret:            secret,
+		ClientSecretExpiresAt:   0,
+		RegistrationAccessToken: registrationAccessToken,
+		RegistrationClientURI:   issuer + "/oauth/register/" + client.ID,
+		ClientName:              client.Name,
+		RedirectURIs:            client.RedirectURIs,
+		GrantTypes:              client.Grants,
+		ResponseTypes:           client.ResponseTypes,
+		TokenEndpointAuthMethod: client.TokenEndpointAuthMethod,
+		Scope:                   strings.Join(client.Scopes, " "),
+	}
+}
+
+// RegisterOauthClient registers a client using the dynamic client registration protocol (RFC 7591).
+// The request should be authorized with the initial access token that is given to integrators
+func (u UseCasesOauthImpl) RegisterOauthClient(ctx context.Context, accessToken string, input dto.OauthClientRegistrationInput) (*dto.OauthClientRegistrationOutput, error) {
+	expectedToken := initialAccessToken()
+	if expectedToken == "" || subtle.ConstantTimeCompare([]byte(accessToken), []byte(expectedToken)) != 1 {
+		return nil, ErrInvalidRegistrationToken
+	}
+
+	client, err := clientFromRegistration(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateClientMetadata(ctx, client, registeredClientGrantTypes); err != nil {
+		return nil, err
+	}
+
+	secret, err := setClientSecret(client)
+	if err != nil {
+		return nil, err
+	}
+
+	registrationAccessToken, err := newRegistrationToken()
+	if err != nil {
+		return nil, err
+	}
+	client.RegistrationAccessToken = hashRegistrationToken(registrationAccessToken)
+
+	if err := u.create.CreateOauthClient(ctx, client); err != nil {
+		return nil, fmt.Errorf("failed to register client: %w", err)
+	}
+
+	return registrationOutput(client, secret, registrationAccessToken), nil
+}
+
+// getRegisteredClient returns an active dynamically registered client after checking its registration access token.
+// An unknown client is reported as an invalid token so that client IDs cannot be probed
+func (u UseCasesOauthImpl) getRegisteredClient(ctx context.Context, clientID string, registrationAccessToken string) (*domain.OauthClient, error) {
+	client, err := u.query.GetOauthClient(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidRegistrationToken
+		}
+		return nil, err
+	}
+
+	if !client.Active || client.RegistrationAccessToken == "" || registrationAccessToken == "" {
+		return nil, ErrInvalidRegistrationToken
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashRegistrationToken(registrationAccessToken)), []byte(client.RegistrationAccessToken)) != 1 {
+		return nil, ErrInvalidRegistrationToken
+	}
+
+	return client, nil
+}
+
+// GetOauthClientRegistration returns the registration of a dynamically registered client (RFC 7592)
+func (u UseCasesOauthImpl) GetOauthClientRegistration(ctx context.Context, clientID string, registrationAccessToken string) (*dto.OauthClientRegistrationOutput, error) {
+	client, err := u.getRegisteredClient(ctx, clientID, registrationAccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return registrationOutput(client, "", registrationAccessToken), nil
+}
+
+// UpdateOauthClientRegistration replaces the metadata of a dynamically registered client (RFC 7592).
+// A new secret is issued when a public client becomes confidential
+func (u UseCasesOauthImpl) UpdateOauthClientRegistration(ctx context.Context, clientID string, registrationAccessToken string, input dto.OauthClientRegistrationInput) (*dto.OauthClientRegistrationOutput, error) {
+	existing, err := u.getRegisteredClient(ctx, clientID, registrationAccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.ClientID != existing.ID {
+		return nil, ErrInvalidClientMetadata.WithHint("The client_id should be the ID of the client that is being updated.")
+	}
+
+	client, err := clientFromRegistration(input)
+	if err != nil {
+		return nil, err
+	}
+	client.ID = existing.ID
+	client.Secret = existing.Secret
+	client.RegistrationAccessToken = existing.RegistrationAccessToken
+
+	if err := validateClientMetadata(ctx, client, registeredClientGrantTypes); err != nil {
+		return nil, err
+	}
+
+	var secret string
+	if client.Public || client.Secret == "" {
+		secret, err = setClientSecret(client)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	updateData := map[string]interface{}{
+		"name":                       client.Name,
+		"secret":                     client.Secret,
+		"public":                     client.Public,
+		"redirect_uris":              client.RedirectURIs,
+		"scopes":                     client.Scopes,
+		"grants":                     client.Grants,
+		"response_types":             client.ResponseTypes,
+		"token_endpoint_auth_method": client.TokenEndpointAuthMethod,
+	}
+
+	if err := u.update.UpdateOauthClient(ctx, client, updateData); err != nil {
+		return nil, fmt.Errorf("failed to update client registration: %w", err)
+	}
+
+	return registrationOutput(client, secret, registrationAccessToken), nil
+}
+
+// DeleteOauthClientRegistration deactivates a dynamically registered client (RFC 7592).
+// The client can no longer get tokens and its registration access token is invalidated
+func (u UseCasesOauthImpl) DeleteOauthClientRegistration(ctx context.Context, clientID string, registrationAccessToken string) error {
+	client, err := u.getRegisteredClient(ctx, clientID, registrationAccessToken)
+	if err != nil {
+		return err
+	}
+
+	updateData := map[string]interface{}{
+		"active":                    false,
+		"registration_access_token": "",
+	}
+
+	if err := u.update.UpdateOauthClient(ctx, client, updateData); err != nil {
+		return fmt.Errorf("failed to delete client registration: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,472 @@
+package oauth_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/brianvoe/gofakeit"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	pgMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/mock"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/oauth"
+	"gorm.io/gorm"
+)
+
+const initialAccessToken = "initial-access-token"
+
+// registerClient registers a client and makes it the client that is returned by the database
+func registerClient(t *testing.T, u oauth.UseCasesOauthImpl, fakeDB *pgMock.PostgresMock, input dto.OauthClientRegistrationInput) (*domain.OauthClient, *dto.OauthClientRegistrationOutput) {
+	t.Setenv("OAUTH_INITIAL_ACCESS_TOKEN", initialAccessToken)
+
+	var client *domain.OauthClient
+	fakeDB.MockCreateOauthClient = func(ctx context.Context, c *domain.OauthClient) error {
+		c.ID = gofakeit.UUID()
+		client = c
+		return nil
+	}
+	fakeDB.MockGetOauthClient = func(ctx context.Context, id string) (*domain.OauthClient, error) {
+		if client == nil || client.ID != id {
+			return nil, fmt.Errorf("error fetching oauth client: %w", gorm.ErrRecordNotFound)
+		}
+		return client, nil
+	}
+
+	registration, err := u.RegisterOauthClient(context.Background(), initialAccessToken, input)
+	if err != nil {
+		t.Fatalf("failed to register client: %v", err)
+	}
+
+	return client, registration
+}
+
+func mobileAppRegistration() dto.OauthClientRegistrationInput {
+	return dto.OauthClientRegistrationInput{
+		ClientName:              "Mobile App",
+		RedirectURIs:            []string{"com.example.app:/callback"},
+		GrantTypes:              []string{"authorization_code", "refresh_token"},
+		TokenEndpointAuthMethod: "none",
+		Scope:                   "openid offline",
+	}
+}
+
+func TestUseCasesOauthImpl_RegisterOauthClient(t *testing.T) {
+	type args struct {
+		ctx         context.Context
+		accessToken string
+		input       dto.OauthClientRegistrationInput
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantSecret bool
+		wantErr    bool
+		wantErrIs  error
+	}{
+		{
+			name: "happy case: register a public client",
+			args: args{
+				ctx:         context.Background(),
+				accessToken: initialAccessToken,
+				input:       mobileAppRegistration(),
+			},
+			wantSecret: false,
+			wantErr:    false,
+		},
+		{
+			name: "happy case: register a confidential client",
+			args: args{
+				ctx:         context.Background(),
+				accessToken: initialAccessToken,
+				input: dto.OauthClientRegistrationInput{
+					ClientName:   "Web App",
+					RedirectURIs: []string{"https://example.com/callback"},
+					Scope:        "openid",
+				},
+			},
+			wantSecret: true,
+			wantErr:    false,
+		},
+		{
+			name: "sad case: registration is disabled",
+			args: args{
+				ctx:         context.Background(),
+				accessToken: "",
+				input:       mobileAppRegistration(),
+			},
+			wantErr:   true,
+			wantErrIs: oauth.ErrInvalidRegistrationToken,
+		},
+		{
+			name: "sad case: invalid initial access token",
+			args: args{
+				ctx:         context.Background(),
+				accessToken: "invalid",
+				input:       mobileAppRegistration(),
+			},
+			wantErr:   true,
+			wantErrIs: oauth.ErrInvalidRegistrationToken,
+		},
+		{
+			name: "sad case: unsupported scope",
+			args: args{
+				ctx:         context.Background(),
+				accessToken: initialAccessToken,
+				input: dto.OauthClientRegistrationInput{
+					RedirectURIs:            []string{"com.example.app:/callback"},
+					TokenEndpointAuthMethod: "none",
+					Scope:                   "openid internal",
+				},
+			},
+			wantErr:   true,
+			wantErrIs: oauth.ErrInvalidClientMetadata,
+		},
+		{
+			name: "sad case: client credentials grant",
+			args: args{
+				ctx:         context.Background(),
+				accessToken: initialAccessToken,
+				input: dto.OauthClientRegistrationInput{
+					GrantTypes:    []string{"client_credentials"},
+					ResponseTypes: []string{},
+					Scope:         "openid",
+				},
+			},
+			wantErr:   true,
+			wantErrIs: oauth.ErrInvalidClientMetadata,
+		},
+		{
+			name: "sad case: implicit response type",
+			args: args{
+				ctx:         context.Background(),
+				accessToken: initialAccessToken,
+				input: dto.OauthClientRegistrationInput{
+					RedirectURIs:            []string{"https://example.com/callback"},
+					ResponseTypes:           []string{"token"},
+					TokenEndpointAuthMethod: "none",
+				},
+			},
+			wantErr:   true,
+			wantErrIs: oauth.ErrInvalidClientMetadata,
+		},
+		{
+			name: "sad case: redirect URI with a fragment",
+			args: args{
+				ctx:         context.Background(),
+				accessToken: initialAccessToken,
+				input: dto.OauthClientRegistrationInput{
+					RedirectURIs:            []string{"https://example.com/callback#fragment"},
+					TokenEndpointAuthMethod: "none",
+				},
+			},
+			wantErr:   true,
+			wantErrIs: oauth.ErrInvalidRedirectURI,
+		},
+		{
+			name: "sad case: failed to save client",
+			args: args{
+				ctx:         context.Background(),
+				accessToken: initialAccessToken,
+				input:       mobileAppRegistration(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			u := oauth.NewUseCasesOauthImplementation(fakeDB, fakeDB, fakeDB, fakeDB)
+
+			t.Setenv("OAUTH_INITIAL_ACCESS_TOKEN", initialAccessToken)
+
+			if tt.name == "sad case: registration is disabled" {
+				t.Setenv("OAUTH_INITIAL_ACCESS_TOKEN", "")
+			}
+
+			var saved *domain.OauthClient
+			fakeDB.MockCreateOauthClient = func(ctx context.Context, client *domain.OauthClient) error {
+				saved = client
+				return nil
+			}
+
+			if tt.name == "sad case: failed to save client" {
+				fakeDB.MockCreateOauthClient = func(ctx context.Context, client *domain.OauthClient) error {
+					return fmt.Errorf("failed to save client")
+				}
+			}
+
+			got, err := u.RegisterOauthClient(tt.args.ctx, tt.args.accessToken, tt.args.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesOauthImpl.RegisterOauthClient() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("UseCasesOauthImpl.RegisterOauthClient() error = %v, want %v", err, tt.wantErrIs)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if (got.ClientSecret != "") != tt.wantSecret {
+				t.Errorf("UseCasesOauthImpl.RegisterOauthClient() client secret = %q, wantSecret %v", got.ClientSecret, tt.wantSecret)
+			}
+			if got.RegistrationAccessToken == "" || saved.RegistrationAccessToken == got.RegistrationAccessToken {
+				t.Errorf("expected the registration access token to be returned and only its hash to be stored")
+			}
+			if saved.Secret != "" && saved.Secret == got.ClientSecret {
+				t.Errorf("expected only the hash of the client secret to be stored")
+			}
+		})
+	}
+}
+
+func TestUseCasesOauthImpl_GetOauthClientRegistration(t *testing.T) {
+	tests := []struct {
+		name      string
+		token     func(registration *dto.OauthClientRegistrationOutput) string
+		wantErr   bool
+		wantErrIs error
+	}{
+		{
+			name: "happy case: get client registration",
+			token: func(registration *dto.OauthClientRegistrationOutput) string {
+				return registration.RegistrationAccessToken
+			},
+			wantErr: false,
+		},
+		{
+			name:      "sad case: invalid registration access token",
+			token:     func(registration *dto.OauthClientRegistrationOutput) string { return "invalid" },
+			wantErr:   true,
+			wantErrIs: oauth.ErrInvalidRegistrationToken,
+		},
+		{
+			name: "sad case: unknown client",
+			token: func(registration *dto.OauthClientRegistrationOutput) string {
+				return registration.RegistrationAccessToken
+			},
+			wantErr:   true,
+			wantErrIs: oauth.ErrInvalidRegistrationToken,
+		},
+		{
+			name: "sad case: client registration has been deleted",
+			token: func(registration *dto.OauthClientRegistrationOutput) string {
+				return registration.RegistrationAccessToken
+			},
+			wantErr:   true,
+			wantErrIs: oauth.ErrInvalidRegistrationToken,
+		},
+		{
+			name: "sad case: failed to get client",
+			token: func(registration *dto.OauthClientRegistrationOutput) string {
+				return registration.RegistrationAccessToken
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			u := oauth.NewUseCasesOauthImplementation(fakeDB, fakeDB, fakeDB, fakeDB)
+
+			client, registration := registerClient(t, u, fakeDB, mobileAppRegistration())
+			clientID := client.ID
+
+			if tt.name == "sad case: unknown client" {
+				clientID = gofakeit.UUID()
+			}
+
+			if tt.name == "sad case: client registration has been deleted" {
+				client.Active = false
+			}
+
+			if tt.name == "sad case: failed to get client" {
+				fakeDB.MockGetOauthClient = func(ctx context.Context, id string) (*domain.OauthClient, error) {
+					return nil, fmt.Errorf("failed to get client")
+				}
+			}
+
+			got, err := u.GetOauthClientRegistration(context.Background(), clientID, tt.token(registration))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesOauthImpl.GetOauthClientRegistration() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("UseCasesOauthImpl.GetOauthClientRegistration() error = %v, want %v", err, tt.wantErrIs)
+				return
+			}
+			if !tt.wantErr && (got.ClientID != clientID || got.ClientSecret != "" || got.Scope != "openid offline") {
+				t.Errorf("UseCasesOauthImpl.GetOauthClientRegistration() got = %+v", got)
+			}
+		})
+	}
+}
+
+func TestUseCasesOauthImpl_UpdateOauthClientRegistration(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      func(clientID string) dto.OauthClientRegistrationInput
+		wantSecret bool
+		wantErr    bool
+		wantErrIs  error
+	}{
+		{
+			name: "happy case: update redirect URIs",
+			input: func(clientID string) dto.OauthClientRegistrationInput {
+				input := mobileAppRegistration()
+				input.ClientID = clientID
+				input.RedirectURIs = []string{"com.example.app:/oauth/callback"}
+				return input
+			},
+			wantSecret: false,
+			wantErr:    false,
+		},
+		{
+			name: "happy case: public client becomes confidential",
+			input: func(clientID string) dto.OauthClientRegistrationInput {
+				input := mobileAppRegistration()
+				input.ClientID = clientID
+				input.RedirectURIs = []string{"https://example.com/callback"}
+				input.TokenEndpointAuthMethod = "client_secret_basic"
+				return input
+			},
+			wantSecret: true,
+			wantErr:    false,
+		},
+		{
+			name: "sad case: different client ID",
+			input: func(clientID string) dto.OauthClientRegistrationInput {
+				input := mobileAppRegistration()
+				input.ClientID = gofakeit.UUID()
+				return input
+			},
+			wantErr:   true,
+			wantErrIs: oauth.ErrInvalidClientMetadata,
+		},
+		{
+			name: "sad case: invalid redirect URI",
+			input: func(clientID string) dto.OauthClientRegistrationInput {
+				input := mobileAppRegistration()
+				input.ClientID = clientID
+				input.RedirectURIs = []string{"/callback"}
+				return input
+			},
+			wantErr:   true,
+			wantErrIs: oauth.ErrInvalidRedirectURI,
+		},
+		{
+			name: "sad case: failed to update client",
+			input: func(clientID string) dto.OauthClientRegistrationInput {
+				input := mobileAppRegistration()
+				input.ClientID = clientID
+				return input
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			u := oauth.NewUseCasesOauthImplementation(fakeDB, fakeDB, fakeDB, fakeDB)
+
+			client, registration := registerClient(t, u, fakeDB, mobileAppRegistration())
+
+			var updateData map[string]interface{}
+			fakeDB.MockUpdateOauthClientFn = func(ctx context.Context, c *domain.OauthClient, data map[string]interface{}) error {
+				updateData = data
+				return nil
+			}
+
+			if tt.name == "sad case: failed to update client" {
+				fakeDB.MockUpdateOauthClientFn = func(ctx context.Context, c *domain.OauthClient, data map[string]interface{}) error {
+					return fmt.Errorf("failed to update client")
+				}
+			}
+
+			got, err := u.UpdateOauthClientRegistration(context.Background(), client.ID, registration.RegistrationAccessToken, tt.input(client.ID))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesOauthImpl.UpdateOauthClientRegistration() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("UseCasesOauthImpl.UpdateOauthClientRegistration() error = %v, want %v", err, tt.wantErrIs)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if (got.ClientSecret != "") != tt.wantSecret {
+				t.Errorf("UseCasesOauthImpl.UpdateOauthClientRegistration() client secret = %q, wantSecret %v", got.ClientSecret, tt.wantSecret)
+			}
+			if tt.wantSecret && (updateData["secret"] == "" || updateData["public"] != false) {
+				t.Errorf("expected the client to be saved as a confidential client with a secret, got %v", updateData)
+			}
+		})
+	}
+}
+
+func TestUseCasesOauthImpl_DeleteOauthClientRegistration(t *testing.T) {
+	tests := []struct {
+		name      string
+		token     func(registration *dto.OauthClientRegistrationOutput) string
+		wantErr   bool
+		wantErrIs error
+	}{
+		{
+			name: "happy case: delete client registration",
+			token: func(registration *dto.OauthClientRegistrationOutput) string {
+				return registration.RegistrationAccessToken
+			},
+			wantErr: false,
+		},
+		{
+			name:      "sad case: invalid registration access token",
+			token:     func(registration *dto.OauthClientRegistrationOutput) string { return "" },
+			wantErr:   true,
+			wantErrIs: oauth.ErrInvalidRegistrationToken,
+		},
+		{
+			name: "sad case: failed to deactivate client",
+			token: func(registration *dto.OauthClientRegistrationOutput) string {
+				return registration.RegistrationAccessToken
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			u := oauth.NewUseCasesOauthImplementation(fakeDB, fakeDB, fakeDB, fakeDB)
+
+			client, registration := registerClient(t, u, fakeDB, mobileAppRegistration())
+
+			var updateData map[string]interface{}
+			fakeDB.MockUpdateOauthClientFn = func(ctx context.Context, c *domain.OauthClient, data map[string]interface{}) error {
+				updateData = data
+				return nil
+			}
+
+			if tt.name == "sad case: failed to deactivate client" {
+				fakeDB.MockUpdateOauthClientFn = func(ctx context.Context, c *domain.OauthClient, data map[string]interface{}) error {
+					return fmt.Errorf("failed to update client")
+				}
+			}
+
+			err := u.DeleteOauthClientRegistration(context.Background(), client.ID, tt.token(registration))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesOauthImpl.DeleteOauthClientRegistration() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("UseCasesOauthImpl.DeleteOauthClientRegistration() error = %v, want %v", err, tt.wantErrIs)
+				return
+			}
+			if !tt.wantErr && (updateData["active"] != false || updateData["registration_access_token"] != "") {
+				t.Errorf("expected the client to be deactivated, got %v", updateData)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/ory/fosite"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
@@ -13,6 +14,9 @@ import (
 func (s Storage) CreateAccessTokenSession(ctx context.Context, signature string, request fosite.Requester) (err error) {
 	session := request.GetSession().(*domain.Session)
 
+	now := time.Now()
+	session.LastSeenAt = &now
+
 	err = s.Create.CreateOrUpdateSession(ctx, session)
 	if err != nil {
 		return err
@@ -51,6 +55,14 @@ func (s Storage) GetAccessTokenSession(ctx context.Context, signature string, se
 		return nil, err
 	}
 
+	// tokens of a session that the user has been logged out of are rejected immediately
+	// instead of when they expire
+	if !accessToken.Active || accessToken.Session.IsRevoked() {
+		return nil, fosite.ErrInactiveToken
+	}
+
+	s.updateSessionLastSeen(ctx, &accessToken.Session)
+
 	rq := &fosite.Request{
 		ID:                accessToken.ID,
 		RequestedAt:       accessToken.RequestedAt,
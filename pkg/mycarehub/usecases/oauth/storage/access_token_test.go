@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/brianvoe/gofakeit"
 	"github.com/ory/fosite"
@@ -117,6 +118,15 @@ func TestStorage_GetAccessTokenSession(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "happy case: get token when the session last seen fails to update",
+			args: args{
+				ctx:       context.Background(),
+				signature: "signed",
+				session:   nil,
+			},
+			wantErr: false,
+		},
 		{
 			name: "sad case: failed to get token",
 			args: args{
@@ -126,18 +136,55 @@ func TestStorage_GetAccessTokenSession(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "sad case: inactive token",
+			args: args{
+				ctx:       context.Background(),
+				signature: "signed",
+				session:   nil,
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: revoked session",
+			args: args{
+				ctx:       context.Background(),
+				signature: "signed",
+				session:   nil,
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			fakeDB := pgMock.NewPostgresMock()
 			s := storage.NewFositeStorage(fakeDB, fakeDB, fakeDB, fakeDB)
 
+			if tt.name == "happy case: get token when the session last seen fails to update" {
+				fakeDB.MockUpdateSessionFn = func(ctx context.Context, session *domain.Session, updateData map[string]interface{}) error {
+					return fmt.Errorf("failed to update session")
+				}
+			}
+
 			if tt.name == "sad case: failed to get token" {
 				fakeDB.MockGetAccessTokenFn = func(ctx context.Context, token domain.AccessToken) (*domain.AccessToken, error) {
 					return nil, fmt.Errorf("failed to get token")
 				}
 			}
 
+			if tt.name == "sad case: inactive token" {
+				fakeDB.MockGetAccessTokenFn = func(ctx context.Context, token domain.AccessToken) (*domain.AccessToken, error) {
+					return &domain.AccessToken{Active: false}, nil
+				}
+			}
+
+			if tt.name == "sad case: revoked session" {
+				fakeDB.MockGetAccessTokenFn = func(ctx context.Context, token domain.AccessToken) (*domain.AccessToken, error) {
+					revokedAt := time.Now()
+					return &domain.AccessToken{Active: true, Session: domain.Session{RevokedAt: &revokedAt}}, nil
+				}
+			}
+
 			got, err := s.GetAccessTokenSession(tt.args.ctx, tt.args.signature, tt.args.session)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Storage.GetAccessTokenSession() error = %v, wantErr %v", err, tt.wantErr)
@@ -2,11 +2,13 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 
 	"github.com/ory/fosite"
+	"gorm.io/gorm"
 )
 
 // ClientAssertionJWTValid returns an error if the JTI is known or the DB check failed
@@ -25,13 +27,21 @@ func (s Storage) ClientAssertionJWTValid(ctx context.Context, jti string) error
 }
 
 // GetClient loads the client by its ID or returns an error
-// if the client does not exist or another error occurred.
+// if the client does not exist, has been deactivated or another error occurred.
 func (s Storage) GetClient(ctx context.Context, id string) (fosite.Client, error) {
 	client, err := s.Query.GetOauthClient(ctx, id)
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fosite.ErrNotFound
+		}
 		return nil, err
 	}
 
+	// a client that has been deactivated e.g when its registration is deleted cannot be used to request tokens
+	if !client.Active {
+		return nil, fosite.ErrNotFound
+	}
+
 	return client, nil
 }
 
@@ -0,0 +1,100 @@
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/brianvoe/gofakeit"
+	"github.com/ory/fosite"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	pgMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/mock"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/oauth/storage"
+	"gorm.io/gorm"
+)
+
+func TestStorage_GetClient(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		id  string
+	}
+	tests := []struct {
+		name      string
+		args      args
+		wantErr   bool
+		wantErrIs error
+	}{
+		{
+			name: "happy case: get client",
+			args: args{
+				ctx: context.Background(),
+				id:  gofakeit.UUID(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "sad case: client does not exist",
+			args: args{
+				ctx: context.Background(),
+				id:  gofakeit.UUID(),
+			},
+			wantErr:   true,
+			wantErrIs: fosite.ErrNotFound,
+		},
+		{
+			name: "sad case: client has been deactivated",
+			args: args{
+				ctx: context.Background(),
+				id:  gofakeit.UUID(),
+			},
+			wantErr:   true,
+			wantErrIs: fosite.ErrNotFound,
+		},
+		{
+			name: "sad case: failed to get client",
+			args: args{
+				ctx: context.Background(),
+				id:  gofakeit.UUID(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			s := storage.NewFositeStorage(fakeDB, fakeDB, fakeDB, fakeDB)
+
+			if tt.name == "sad case: client does not exist" {
+				fakeDB.MockGetOauthClient = func(ctx context.Context, id string) (*domain.OauthClient, error) {
+					return nil, fmt.Errorf("error fetching oauth client: %w", gorm.ErrRecordNotFound)
+				}
+			}
+
+			if tt.name == "sad case: client has been deactivated" {
+				fakeDB.MockGetOauthClient = func(ctx context.Context, id string) (*domain.OauthClient, error) {
+					return &domain.OauthClient{ID: id, Active: false}, nil
+				}
+			}
+
+			if tt.name == "sad case: failed to get client" {
+				fakeDB.MockGetOauthClient = func(ctx context.Context, id string) (*domain.OauthClient, error) {
+					return nil, fmt.Errorf("failed to get client")
+				}
+			}
+
+			got, err := s.GetClient(tt.args.ctx, tt.args.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Storage.GetClient() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("Storage.GetClient() error = %v, want %v", err, tt.wantErrIs)
+				return
+			}
+			if !tt.wantErr && got == nil {
+				t.Errorf("expected a client")
+			}
+		})
+	}
+}
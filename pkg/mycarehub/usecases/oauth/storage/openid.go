@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+
+	"github.com/ory/fosite"
+	"github.com/ory/fosite/handler/openid"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	"gorm.io/gorm"
+)
+
+// openIDConnectSessionKey returns the key that an OpenID Connect session is stored under.
+// Fosite passes the full authorization code so only its hash is stored
+func openIDConnectSessionKey(authorizeCode string) string {
+	hash := sha256.Sum256([]byte(authorizeCode))
+	return hex.EncodeToString(hash[:])
+}
+
+// CreateOpenIDConnectSession stores the authorization request that an ID token will be issued for
+// when the authorization code is exchanged for tokens.
+func (s Storage) CreateOpenIDConnectSession(ctx context.Context, authorizeCode string, requester fosite.Requester) error {
+	session := requester.GetSession().(*domain.Session)
+
+	err := s.Create.CreateOrUpdateSession(ctx, session)
+	if err != nil {
+		return err
+	}
+
+	data := &domain.OpenIDConnectSession{
+		Active:            true,
+		Code:              openIDConnectSessionKey(authorizeCode),
+		RequestedAt:       requester.GetRequestedAt(),
+		ClientID:          requester.GetClient().GetID(),
+		RequestedScopes:   requester.GetRequestedScopes(),
+		GrantedScopes:     requester.GetGrantedScopes(),
+		Form:              requester.GetRequestForm(),
+		SessionID:         session.ID,
+		RequestedAudience: requester.GetRequestedAudience(),
+		GrantedAudience:   requester.GetGrantedAudience(),
+	}
+
+	return s.Create.CreateOpenIDConnectSession(ctx, data)
+}
+
+// GetOpenIDConnectSession returns the authorization request that was stored for an authorization code.
+// It returns openid.ErrNoSessionFound if the authorization request did not ask for an ID token.
+func (s Storage) GetOpenIDConnectSession(ctx context.Context, authorizeCode string, requester fosite.Requester) (fosite.Requester, error) {
+	openIDConnectSession, err := s.Query.GetOpenIDConnectSession(ctx, openIDConnectSessionKey(authorizeCode))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, openid.ErrNoSessionFound
+		}
+		return nil, err
+	}
+
+	return &fosite.Request{
+		ID:                openIDConnectSession.ID,
+		RequestedAt:       openIDConnectSession.RequestedAt,
+		Client:            openIDConnectSession.Client,
+		RequestedScope:    fosite.Arguments(openIDConnectSession.RequestedScopes),
+		GrantedScope:      fosite.Arguments(openIDConnectSession.GrantedScopes),
+		Form:              openIDConnectSession.Form,
+		Session:           &openIDConnectSession.Session,
+		RequestedAudience: fosite.Arguments(openIDConnectSession.RequestedAudience),
+		GrantedAudience:   fosite.Arguments(openIDConnectSession.GrantedAudience),
+	}, nil
+}
+
+// DeleteOpenIDConnectSession is deprecated in fosite and is never called.
+// OpenID Connect sessions can only be used once their authorization code has been exchanged and they expire with it
+func (s Storage) DeleteOpenIDConnectSession(ctx context.Context, authorizeCode string) error {
+	return nil
+}
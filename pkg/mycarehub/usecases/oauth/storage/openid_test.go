@@ -0,0 +1,186 @@
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/brianvoe/gofakeit"
+	"github.com/ory/fosite"
+	"github.com/ory/fosite/handler/openid"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	pgMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/mock"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/oauth/storage"
+	"gorm.io/gorm"
+)
+
+func TestStorage_CreateOpenIDConnectSession(t *testing.T) {
+	type args struct {
+		ctx       context.Context
+		code      string
+		requester fosite.Requester
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "happy case: create openid connect session",
+			args: args{
+				ctx:  context.Background(),
+				code: gofakeit.Username(),
+				requester: &fosite.Request{
+					Session: &domain.Session{
+						ID: gofakeit.UUID(),
+					},
+					Client: &domain.OauthClient{
+						ID: gofakeit.UUID(),
+					},
+					GrantedScope: fosite.Arguments{"openid"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "sad case: fail to create session",
+			args: args{
+				ctx:  context.Background(),
+				code: gofakeit.Username(),
+				requester: &fosite.Request{
+					Session: &domain.Session{
+						ID: gofakeit.UUID(),
+					},
+					Client: &domain.OauthClient{
+						ID: gofakeit.UUID(),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: fail to create openid connect session",
+			args: args{
+				ctx:  context.Background(),
+				code: gofakeit.Username(),
+				requester: &fosite.Request{
+					Session: &domain.Session{
+						ID: gofakeit.UUID(),
+					},
+					Client: &domain.OauthClient{
+						ID: gofakeit.UUID(),
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			s := storage.NewFositeStorage(fakeDB, fakeDB, fakeDB, fakeDB)
+
+			if tt.name == "happy case: create openid connect session" {
+				fakeDB.MockCreateOpenIDConnectSessionFn = func(ctx context.Context, session *domain.OpenIDConnectSession) error {
+					if session.Code == tt.args.code {
+						return fmt.Errorf("expected the authorization code to be hashed")
+					}
+					return nil
+				}
+			}
+
+			if tt.name == "sad case: fail to create session" {
+				fakeDB.MockCreateOrUpdateSessionFn = func(ctx context.Context, session *domain.Session) error {
+					return fmt.Errorf("failed to create session")
+				}
+			}
+
+			if tt.name == "sad case: fail to create openid connect session" {
+				fakeDB.MockCreateOpenIDConnectSessionFn = func(ctx context.Context, session *domain.OpenIDConnectSession) error {
+					return fmt.Errorf("failed to create openid connect session")
+				}
+			}
+
+			if err := s.CreateOpenIDConnectSession(tt.args.ctx, tt.args.code, tt.args.requester); (err != nil) != tt.wantErr {
+				t.Errorf("Storage.CreateOpenIDConnectSession() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStorage_GetOpenIDConnectSession(t *testing.T) {
+	type args struct {
+		ctx       context.Context
+		code      string
+		requester fosite.Requester
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantErr    bool
+		wantErrIs  error
+		wantScopes fosite.Arguments
+	}{
+		{
+			name: "happy case: get openid connect session",
+			args: args{
+				ctx:       context.Background(),
+				code:      gofakeit.Username(),
+				requester: &fosite.Request{},
+			},
+			wantErr:    false,
+			wantScopes: fosite.Arguments{"openid"},
+		},
+		{
+			name: "sad case: no openid connect session",
+			args: args{
+				ctx:       context.Background(),
+				code:      gofakeit.Username(),
+				requester: &fosite.Request{},
+			},
+			wantErr:   true,
+			wantErrIs: openid.ErrNoSessionFound,
+		},
+		{
+			name: "sad case: failed to get openid connect session",
+			args: args{
+				ctx:       context.Background(),
+				code:      gofakeit.Username(),
+				requester: &fosite.Request{},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			s := storage.NewFositeStorage(fakeDB, fakeDB, fakeDB, fakeDB)
+
+			if tt.name == "sad case: no openid connect session" {
+				fakeDB.MockGetOpenIDConnectSessionFn = func(ctx context.Context, code string) (*domain.OpenIDConnectSession, error) {
+					return nil, fmt.Errorf("error fetching openid connect session: %w", gorm.ErrRecordNotFound)
+				}
+			}
+
+			if tt.name == "sad case: failed to get openid connect session" {
+				fakeDB.MockGetOpenIDConnectSessionFn = func(ctx context.Context, code string) (*domain.OpenIDConnectSession, error) {
+					return nil, fmt.Errorf("failed to get openid connect session")
+				}
+			}
+
+			got, err := s.GetOpenIDConnectSession(tt.args.ctx, tt.args.code, tt.args.requester)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Storage.GetOpenIDConnectSession() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("Storage.GetOpenIDConnectSession() error = %v, want %v", err, tt.wantErrIs)
+				return
+			}
+			if !tt.wantErr && !got.GetGrantedScopes().Has(tt.wantScopes...) {
+				t.Errorf("Storage.GetOpenIDConnectSession() granted scopes = %v, want %v", got.GetGrantedScopes(), tt.wantScopes)
+			}
+		})
+	}
+}
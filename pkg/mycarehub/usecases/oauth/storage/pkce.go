@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ory/fosite"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	"gorm.io/gorm"
+)
+
+// CreatePKCERequestSession stores the code challenge of an authorization request.
+// It is checked against the code verifier when the authorization code is exchanged for tokens
+func (s Storage) CreatePKCERequestSession(ctx context.Context, signature string, requester fosite.Requester) error {
+	session := requester.GetSession().(*domain.Session)
+
+	err := s.Create.CreateOrUpdateSession(ctx, session)
+	if err != nil {
+		return err
+	}
+
+	data := &domain.PKCE{
+		Active:            true,
+		Signature:         signature,
+		RequestedAt:       requester.GetRequestedAt(),
+		ClientID:          requester.GetClient().GetID(),
+		RequestedScopes:   requester.GetRequestedScopes(),
+		GrantedScopes:     requester.GetGrantedScopes(),
+		Form:              requester.GetRequestForm(),
+		SessionID:         session.ID,
+		RequestedAudience: requester.GetRequestedAudience(),
+		GrantedAudience:   requester.GetGrantedAudience(),
+	}
+
+	return s.Create.CreatePKCE(ctx, data)
+}
+
+// GetPKCERequestSession returns the authorization request that holds the code challenge of an authorization code
+func (s Storage) GetPKCERequestSession(ctx context.Context, signature string, session fosite.Session) (fosite.Requester, error) {
+	pkce, err := s.Query.GetPKCE(ctx, signature)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fosite.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &fosite.Request{
+		ID:                pkce.ID,
+		RequestedAt:       pkce.RequestedAt,
+		Client:            pkce.Client,
+		RequestedScope:    fosite.Arguments(pkce.RequestedScopes),
+		GrantedScope:      fosite.Arguments(pkce.GrantedScopes),
+		Form:              pkce.Form,
+		Session:           &pkce.Session,
+		RequestedAudience: fosite.Arguments(pkce.RequestedAudience),
+		GrantedAudience:   fosite.Arguments(pkce.GrantedAudience),
+	}, nil
+}
+
+// DeletePKCERequestSession removes the code challenge once its authorization code has been exchanged so that it cannot be reused
+func (s Storage) DeletePKCERequestSession(ctx context.Context, signature string) error {
+	return s.Delete.DeletePKCE(ctx, signature)
+}
@@ -0,0 +1,221 @@
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/brianvoe/gofakeit"
+	"github.com/ory/fosite"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	pgMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/mock"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/oauth/storage"
+	"gorm.io/gorm"
+)
+
+func TestStorage_CreatePKCERequestSession(t *testing.T) {
+	type args struct {
+		ctx       context.Context
+		signature string
+		requester fosite.Requester
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "happy case: create pkce session",
+			args: args{
+				ctx:       context.Background(),
+				signature: gofakeit.Username(),
+				requester: &fosite.Request{
+					Session: &domain.Session{
+						ID: gofakeit.UUID(),
+					},
+					Client: &domain.OauthClient{
+						ID: gofakeit.UUID(),
+					},
+					GrantedScope: fosite.Arguments{"openid"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "sad case: fail to create session",
+			args: args{
+				ctx:       context.Background(),
+				signature: gofakeit.Username(),
+				requester: &fosite.Request{
+					Session: &domain.Session{
+						ID: gofakeit.UUID(),
+					},
+					Client: &domain.OauthClient{
+						ID: gofakeit.UUID(),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: fail to create pkce session",
+			args: args{
+				ctx:       context.Background(),
+				signature: gofakeit.Username(),
+				requester: &fosite.Request{
+					Session: &domain.Session{
+						ID: gofakeit.UUID(),
+					},
+					Client: &domain.OauthClient{
+						ID: gofakeit.UUID(),
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			s := storage.NewFositeStorage(fakeDB, fakeDB, fakeDB, fakeDB)
+
+			if tt.name == "sad case: fail to create session" {
+				fakeDB.MockCreateOrUpdateSessionFn = func(ctx context.Context, session *domain.Session) error {
+					return fmt.Errorf("failed to create session")
+				}
+			}
+
+			if tt.name == "sad case: fail to create pkce session" {
+				fakeDB.MockCreatePKCEFn = func(ctx context.Context, pkce *domain.PKCE) error {
+					return fmt.Errorf("failed to create pkce session")
+				}
+			}
+
+			if err := s.CreatePKCERequestSession(tt.args.ctx, tt.args.signature, tt.args.requester); (err != nil) != tt.wantErr {
+				t.Errorf("Storage.CreatePKCERequestSession() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStorage_GetPKCERequestSession(t *testing.T) {
+	type args struct {
+		ctx       context.Context
+		signature string
+		session   fosite.Session
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantErr    bool
+		wantErrIs  error
+		wantScopes fosite.Arguments
+	}{
+		{
+			name: "happy case: get pkce session",
+			args: args{
+				ctx:       context.Background(),
+				signature: gofakeit.Username(),
+				session:   &domain.Session{},
+			},
+			wantErr:    false,
+			wantScopes: fosite.Arguments{"openid"},
+		},
+		{
+			name: "sad case: no pkce session",
+			args: args{
+				ctx:       context.Background(),
+				signature: gofakeit.Username(),
+				session:   &domain.Session{},
+			},
+			wantErr:   true,
+			wantErrIs: fosite.ErrNotFound,
+		},
+		{
+			name: "sad case: failed to get pkce session",
+			args: args{
+				ctx:       context.Background(),
+				signature: gofakeit.Username(),
+				session:   &domain.Session{},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			s := storage.NewFositeStorage(fakeDB, fakeDB, fakeDB, fakeDB)
+
+			if tt.name == "sad case: no pkce session" {
+				fakeDB.MockGetPKCEFn = func(ctx context.Context, signature string) (*domain.PKCE, error) {
+					return nil, fmt.Errorf("error fetching pkce session: %w", gorm.ErrRecordNotFound)
+				}
+			}
+
+			if tt.name == "sad case: failed to get pkce session" {
+				fakeDB.MockGetPKCEFn = func(ctx context.Context, signature string) (*domain.PKCE, error) {
+					return nil, fmt.Errorf("failed to get pkce session")
+				}
+			}
+
+			got, err := s.GetPKCERequestSession(tt.args.ctx, tt.args.signature, tt.args.session)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Storage.GetPKCERequestSession() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("Storage.GetPKCERequestSession() error = %v, want %v", err, tt.wantErrIs)
+				return
+			}
+			if !tt.wantErr && !got.GetGrantedScopes().Has(tt.wantScopes...) {
+				t.Errorf("Storage.GetPKCERequestSession() granted scopes = %v, want %v", got.GetGrantedScopes(), tt.wantScopes)
+			}
+		})
+	}
+}
+
+func TestStorage_DeletePKCERequestSession(t *testing.T) {
+	type args struct {
+		ctx       context.Context
+		signature string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "happy case: delete pkce session",
+			args: args{
+				ctx:       context.Background(),
+				signature: gofakeit.Username(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "sad case: failed to delete pkce session",
+			args: args{
+				ctx:       context.Background(),
+				signature: gofakeit.Username(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			s := storage.NewFositeStorage(fakeDB, fakeDB, fakeDB, fakeDB)
+
+			if tt.name == "sad case: failed to delete pkce session" {
+				fakeDB.MockDeletePKCEFn = func(ctx context.Context, signature string) error {
+					return fmt.Errorf("failed to delete pkce session")
+				}
+			}
+
+			if err := s.DeletePKCERequestSession(tt.args.ctx, tt.args.signature); (err != nil) != tt.wantErr {
+				t.Errorf("Storage.DeletePKCERequestSession() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/ory/fosite"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
@@ -13,6 +14,9 @@ import (
 func (s Storage) CreateRefreshTokenSession(ctx context.Context, signature string, request fosite.Requester) (err error) {
 	session := request.GetSession().(*domain.Session)
 
+	now := time.Now()
+	session.LastSeenAt = &now
+
 	err = s.Create.CreateOrUpdateSession(ctx, session)
 	if err != nil {
 		return err
@@ -63,7 +67,7 @@ func (s Storage) GetRefreshTokenSession(ctx context.Context, signature string, s
 		GrantedAudience:   fosite.Arguments(refreshToken.GrantedAudience),
 	}
 
-	if !refreshToken.Active {
+	if !refreshToken.Active || refreshToken.Session.IsRevoked() {
 		return rq, fosite.ErrInactiveToken
 	}
 
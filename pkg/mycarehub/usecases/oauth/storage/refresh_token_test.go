@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/brianvoe/gofakeit"
 	"github.com/ory/fosite"
@@ -125,6 +126,15 @@ func TestStorage_GetRefreshTokenSession(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "sad case: revoked session",
+			args: args{
+				ctx:       context.Background(),
+				signature: "signed",
+				session:   nil,
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -137,6 +147,13 @@ func TestStorage_GetRefreshTokenSession(t *testing.T) {
 				}
 			}
 
+			if tt.name == "sad case: revoked session" {
+				fakeDB.MockGetRefreshTokenFn = func(ctx context.Context, token domain.RefreshToken) (*domain.RefreshToken, error) {
+					revokedAt := time.Now()
+					return &domain.RefreshToken{Active: true, Session: domain.Session{RevokedAt: &revokedAt}}, nil
+				}
+			}
+
 			got, err := s.GetRefreshTokenSession(tt.args.ctx, tt.args.signature, tt.args.session)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Storage.GetRefreshTokenSession() error = %v, wantErr %v", err, tt.wantErr)
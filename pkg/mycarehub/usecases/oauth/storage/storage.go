@@ -1,6 +1,18 @@
 package storage
 
-import "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure"
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure"
+)
+
+// sessionLastSeenInterval is how often the last seen time of a session is updated when its access token is used.
+// It avoids writing to the database on every request
+const sessionLastSeenInterval = 5 * time.Minute
 
 // Storage represents oauth implementation
 type Storage struct {
@@ -23,3 +35,18 @@ func NewFositeStorage(
 		Delete: delete,
 	}
 }
+
+// updateSessionLastSeen records that a session has been used. Failing to update it does not fail the request
+func (s Storage) updateSessionLastSeen(ctx context.Context, session *domain.Session) {
+	now := time.Now()
+	if session.LastSeenAt != nil && now.Sub(*session.LastSeenAt) < sessionLastSeenInterval {
+		return
+	}
+
+	if err := s.Update.UpdateSession(ctx, session, map[string]interface{}{"last_seen_at": now}); err != nil {
+		helpers.ReportErrorToSentry(fmt.Errorf("failed to update session last seen: %w", err))
+		return
+	}
+
+	session.LastSeenAt = &now
+}
@@ -2,21 +2,29 @@ package mock
 
 import (
 	"context"
+	"time"
 
 	"github.com/brianvoe/gofakeit"
 	"github.com/google/uuid"
 	"github.com/savannahghi/interserviceclient"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 )
 
 // OrganisationUseCaseMock mocks the implementation of organisation usecase
 type OrganisationUseCaseMock struct {
-	MockCreateOrganisationFn  func(ctx context.Context, input dto.OrganisationInput, programInput []*dto.ProgramInput) (*domain.Organisation, error)
-	MockDeleteOrganisationFn  func(ctx context.Context, organisationID string) (bool, error)
-	MockListOrganisationsFn   func(ctx context.Context, paginationInput *dto.PaginationsInput) (*dto.OrganisationOutputPage, error)
-	MockSearchOrganisationFn  func(ctx context.Context, searchParameter string) ([]*domain.Organisation, error)
-	MOckGetOrganisationByIDFn func(ctx context.Context, organisationID string) (*domain.Organisation, error)
+	MockCreateOrganisationFn      func(ctx context.Context, input dto.OrganisationInput, programInput []*dto.ProgramInput) (*domain.Organisation, error)
+	MockDeleteOrganisationFn      func(ctx context.Context, organisationID string) (bool, error)
+	MockListOrganisationsFn       func(ctx context.Context, paginationInput *dto.PaginationsInput) (*dto.OrganisationOutputPage, error)
+	MockSearchOrganisationFn      func(ctx context.Context, searchParameter string) ([]*domain.Organisation, error)
+	MOckGetOrganisationByIDFn     func(ctx context.Context, organisationID string) (*domain.Organisation, error)
+	MockAuditTrailFn              func(ctx context.Context, filter *dto.AuditLogFilterInput, paginationInput dto.PaginationsInput) (*domain.AuditLogPage, error)
+	MockVerifyAuditTrailFn        func(ctx context.Context, organisationID string) (*domain.AuditTrailVerification, error)
+	MockSetStaffTOTPEnforcementFn func(ctx context.Context, enforce bool) (bool, error)
+	MockSetStaffLoginStepUpFn     func(ctx context.Context, enabled bool) (bool, error)
+	MockSetPINPolicyFn            func(ctx context.Context, input dto.PINPolicyInput) (bool, error)
+	MockSetDataRetentionPeriodFn  func(ctx context.Context, days int) (bool, error)
 }
 
 // NewOrganisationUseCaseMock initializes a new instance mock of the organisation usecase
@@ -69,6 +77,45 @@ func NewOrganisationUseCaseMock() *OrganisationUseCaseMock {
 		MOckGetOrganisationByIDFn: func(ctx context.Context, organisationID string) (*domain.Organisation, error) {
 			return org, nil
 		},
+		MockAuditTrailFn: func(ctx context.Context, filter *dto.AuditLogFilterInput, paginationInput dto.PaginationsInput) (*domain.AuditLogPage, error) {
+			return &domain.AuditLogPage{
+				AuditLogs: []*domain.AuditLog{
+					{
+						ID:             uuid.New().String(),
+						Timestamp:      time.Now(),
+						RecordType:     enums.AuditLogRoleChange,
+						ActorID:        uuid.New().String(),
+						TargetID:       uuid.New().String(),
+						TargetType:     enums.AuditLogTargetRole,
+						OrganisationID: org.ID,
+						After:          map[string]interface{}{"added_permission_ids": []string{uuid.New().String()}},
+					},
+				},
+				Pagination: domain.Pagination{
+					Limit:       paginationInput.Limit,
+					CurrentPage: paginationInput.CurrentPage,
+				},
+			}, nil
+		},
+		MockVerifyAuditTrailFn: func(ctx context.Context, organisationID string) (*domain.AuditTrailVerification, error) {
+			return &domain.AuditTrailVerification{
+				OrganisationID:  organisationID,
+				EntriesVerified: 1,
+				Issues:          []*domain.AuditTrailIssue{},
+			}, nil
+		},
+		MockSetStaffTOTPEnforcementFn: func(ctx context.Context, enforce bool) (bool, error) {
+			return true, nil
+		},
+		MockSetStaffLoginStepUpFn: func(ctx context.Context, enabled bool) (bool, error) {
+			return true, nil
+		},
+		MockSetPINPolicyFn: func(ctx context.Context, input dto.PINPolicyInput) (bool, error) {
+			return true, nil
+		},
+		MockSetDataRetentionPeriodFn: func(ctx context.Context, days int) (bool, error) {
+			return true, nil
+		},
 	}
 }
 
@@ -96,3 +143,33 @@ func (m *OrganisationUseCaseMock) SearchOrganisation(ctx context.Context, search
 func (m *OrganisationUseCaseMock) GetOrganisationByID(ctx context.Context, organisationID string) (*domain.Organisation, error) {
 	return m.MOckGetOrganisationByIDFn(ctx, organisationID)
 }
+
+// AuditTrail mocks the audit trail method
+func (m *OrganisationUseCaseMock) AuditTrail(ctx context.Context, filter *dto.AuditLogFilterInput, paginationInput dto.PaginationsInput) (*domain.AuditLogPage, error) {
+	return m.MockAuditTrailFn(ctx, filter, paginationInput)
+}
+
+// VerifyAuditTrail mocks the verify audit trail method
+func (m *OrganisationUseCaseMock) VerifyAuditTrail(ctx context.Context, organisationID string) (*domain.AuditTrailVerification, error) {
+	return m.MockVerifyAuditTrailFn(ctx, organisationID)
+}
+
+// SetStaffTOTPEnforcement mocks the implementation of setting whether staff are required to use an authenticator app
+func (m *OrganisationUseCaseMock) SetStaffTOTPEnforcement(ctx context.Context, enforce bool) (bool, error) {
+	return m.MockSetStaffTOTPEnforcementFn(ctx, enforce)
+}
+
+// SetStaffLoginStepUp mocks the implementation of setting whether staff have to confirm suspicious logins with an OTP
+func (m *OrganisationUseCaseMock) SetStaffLoginStepUp(ctx context.Context, enabled bool) (bool, error) {
+	return m.MockSetStaffLoginStepUpFn(ctx, enabled)
+}
+
+// SetPINPolicy mocks the implementation of setting an organisation's PIN policy
+func (m *OrganisationUseCaseMock) SetPINPolicy(ctx context.Context, input dto.PINPolicyInput) (bool, error) {
+	return m.MockSetPINPolicyFn(ctx, input)
+}
+
+// SetDataRetentionPeriod mocks the implementation of setting how long an organisation keeps the data of clients who ask for it to be erased
+func (m *OrganisationUseCaseMock) SetDataRetentionPeriod(ctx context.Context, days int) (bool, error) {
+	return m.MockSetDataRetentionPeriodFn(ctx, days)
+}
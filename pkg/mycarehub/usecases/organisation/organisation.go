@@ -9,13 +9,23 @@ import (
 
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/extension"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/utils"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure"
 	pubsubmessaging "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/pubsub"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/common"
+	"github.com/savannahghi/serverutils"
 )
 
+// auditTrailVerificationBatchSize is the number of audit logs read at a time when verifying an audit trail
+const auditTrailVerificationBatchSize = 500
+
+// auditLogHashKey is the secret key that audit log entries are signed with
+var auditLogHashKey = serverutils.MustGetEnvVar(utils.AuditLogHashKeyEnvVarName)
+
 // CreateOrganisation interface holds the method for creating an organisation
 type CreateOrganisation interface {
 	CreateOrganisation(ctx context.Context, organisationInput dto.OrganisationInput, programInput []*dto.ProgramInput) (*domain.Organisation, error)
@@ -33,11 +43,32 @@ type ListOrganisation interface {
 	GetOrganisationByID(ctx context.Context, organisationID string) (*domain.Organisation, error)
 }
 
+// AuditOrganisation interface holds the method for reviewing an organisation's audit trail
+type AuditOrganisation interface {
+	AuditTrail(ctx context.Context, filter *dto.AuditLogFilterInput, paginationInput dto.PaginationsInput) (*domain.AuditLogPage, error)
+	VerifyAuditTrail(ctx context.Context, organisationID string) (*domain.AuditTrailVerification, error)
+}
+
+// OrganisationSecurityPolicy interface holds the methods for managing an organisation's security policies
+type OrganisationSecurityPolicy interface {
+	SetStaffTOTPEnforcement(ctx context.Context, enforce bool) (bool, error)
+	SetStaffLoginStepUp(ctx context.Context, enabled bool) (bool, error)
+	SetPINPolicy(ctx context.Context, input dto.PINPolicyInput) (bool, error)
+}
+
+// OrganisationDataRetention interface holds the method for managing how long an organisation keeps the data of clients who ask for it to be erased
+type OrganisationDataRetention interface {
+	SetDataRetentionPeriod(ctx context.Context, days int) (bool, error)
+}
+
 // UseCaseOrganisation is the interface for the organisation use case
 type UseCaseOrganisation interface {
 	CreateOrganisation
 	DeleteOrganisation
 	ListOrganisation
+	AuditOrganisation
+	OrganisationSecurityPolicy
+	OrganisationDataRetention
 }
 
 // UseCaseOrganisationImpl implements the CreateOrganisation interface
@@ -45,6 +76,7 @@ type UseCaseOrganisationImpl struct {
 	Create      infrastructure.Create
 	Delete      infrastructure.Delete
 	Query       infrastructure.Query
+	Update      infrastructure.Update
 	ExternalExt extension.ExternalMethodsExtension
 	Pubsub      pubsubmessaging.ServicePubsub
 }
@@ -54,6 +86,7 @@ func NewUseCaseOrganisationImpl(
 	create infrastructure.Create,
 	delete infrastructure.Delete,
 	query infrastructure.Query,
+	update infrastructure.Update,
 	ext extension.ExternalMethodsExtension,
 	pubsub pubsubmessaging.ServicePubsub,
 ) UseCaseOrganisation {
@@ -61,6 +94,7 @@ func NewUseCaseOrganisationImpl(
 		Create:      create,
 		Delete:      delete,
 		Query:       query,
+		Update:      update,
 		ExternalExt: ext,
 		Pubsub:      pubsub,
 	}
@@ -250,3 +284,356 @@ func (u *UseCaseOrganisationImpl) GetOrganisationByID(ctx context.Context, organ
 
 	return organisation, nil
 }
+
+// AuditTrail returns the audit trail of sensitive actions performed within the logged in staff's organisation.
+// Only organisation administrators are allowed to review the audit trail.
+func (u *UseCaseOrganisationImpl) AuditTrail(ctx context.Context, filter *dto.AuditLogFilterInput, paginationInput dto.PaginationsInput) (*domain.AuditLogPage, error) {
+	if err := paginationInput.Validate(); err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InputValidationErr(fmt.Errorf("pagination input validation failed: %w", err))
+	}
+
+	if filter != nil {
+		if err := filter.Validate(); err != nil {
+			helpers.ReportErrorToSentry(err)
+			return nil, exceptions.InputValidationErr(err)
+		}
+	}
+
+	loggedInUserID, err := u.ExternalExt.GetLoggedInUserUID(ctx)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.GetLoggedInUserUIDErr(err)
+	}
+
+	userProfile, err := u.Query.GetUserProfileByUserID(ctx, loggedInUserID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.UserNotFoundError(err)
+	}
+
+	staffProfile, err := u.Query.GetStaffProfile(ctx, loggedInUserID, userProfile.CurrentProgramID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.StaffProfileNotFoundErr(err)
+	}
+
+	if !staffProfile.IsOrganisationAdmin {
+		err := fmt.Errorf("staff is not an organisation admin")
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.UserNotAuthorizedErr(err)
+	}
+
+	page := &domain.Pagination{
+		Limit:       paginationInput.Limit,
+		CurrentPage: paginationInput.CurrentPage,
+	}
+
+	auditLogs, pageInfo, err := u.Query.ListAuditLogs(ctx, userProfile.CurrentOrganizationID, filter, page)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, err
+	}
+
+	return &domain.AuditLogPage{
+		AuditLogs:  auditLogs,
+		Pagination: *pageInfo,
+	}, nil
+}
+
+// VerifyAuditTrail walks an organisation's audit log chain and reports any entry that is missing, has been modified
+// or does not link to the entry recorded before it. Entries recorded without being linked to the chain and a chain that
+// does not end at the last entry recorded in the organisation are also reported. Entries are signed with a key kept out of the database
+// so an entry that is changed and signed again without the key is reported as modified.
+func (u *UseCaseOrganisationImpl) VerifyAuditTrail(ctx context.Context, organisationID string) (*domain.AuditTrailVerification, error) {
+	verification := &domain.AuditTrailVerification{
+		OrganisationID: organisationID,
+		Issues:         []*domain.AuditTrailIssue{},
+	}
+
+	var first, previous *domain.AuditLog
+	var afterSequence int64
+
+	for {
+		auditLogs, err := u.Query.ListAuditLogChain(ctx, organisationID, afterSequence, auditTrailVerificationBatchSize)
+		if err != nil {
+			helpers.ReportErrorToSentry(err)
+			return nil, err
+		}
+
+		for _, auditLog := range auditLogs {
+			expectedSequence := int64(1)
+			expectedPreviousHash := ""
+			if previous != nil {
+				expectedSequence = previous.Sequence + 1
+				expectedPreviousHash = previous.Hash
+			}
+
+			switch {
+			case auditLog.Sequence != expectedSequence:
+				verification.Issues = append(verification.Issues, &domain.AuditTrailIssue{
+					Sequence:    expectedSequence,
+					AuditLogID:  auditLog.ID,
+					IssueType:   enums.AuditTrailIssueMissingEntry,
+					Description: fmt.Sprintf("entries %d to %d are missing from the chain", expectedSequence, auditLog.Sequence-1),
+				})
+			case auditLog.PreviousHash != expectedPreviousHash:
+				verification.Issues = append(verification.Issues, &domain.AuditTrailIssue{
+					Sequence:    auditLog.Sequence,
+					AuditLogID:  auditLog.ID,
+					IssueType:   enums.AuditTrailIssueBrokenLink,
+					Description: fmt.Sprintf("entry %d does not link to the entry recorded before it", auditLog.Sequence),
+				})
+			}
+
+			hash, err := utils.HashAuditLog(auditLogHashKey, auditLog)
+			if err != nil {
+				helpers.ReportErrorToSentry(err)
+				return nil, err
+			}
+			if hash != auditLog.Hash {
+				verification.Issues = append(verification.Issues, &domain.AuditTrailIssue{
+					Sequence:    auditLog.Sequence,
+					AuditLogID:  auditLog.ID,
+					IssueType:   enums.AuditTrailIssueModifiedEntry,
+					Description: fmt.Sprintf("entry %d has been modified since it was recorded", auditLog.Sequence),
+				})
+			}
+
+			if first == nil {
+				first = auditLog
+			}
+			previous = auditLog
+			verification.EntriesVerified++
+		}
+
+		if len(auditLogs) < auditTrailVerificationBatchSize {
+			break
+		}
+		afterSequence = auditLogs[len(auditLogs)-1].Sequence
+	}
+
+	organisation, err := u.Query.GetOrganisation(ctx, organisationID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, err
+	}
+
+	// entries removed from the end of the chain leave no gap so the chain's end is compared with the organisation's last entry
+	var lastSequence int64
+	var lastHash string
+	if previous != nil {
+		lastSequence, lastHash = previous.Sequence, previous.Hash
+	}
+	if lastSequence != organisation.AuditLogSequence || lastHash != organisation.AuditLogHash {
+		verification.Issues = append(verification.Issues, &domain.AuditTrailIssue{
+			Sequence:    organisation.AuditLogSequence,
+			IssueType:   enums.AuditTrailIssueTruncatedChain,
+			Description: fmt.Sprintf("the chain ends at entry %d but the last entry recorded in the organisation is %d", lastSequence, organisation.AuditLogSequence),
+		})
+	}
+
+	if first == nil {
+		return verification, nil
+	}
+
+	unchained, err := u.Query.CountUnchainedAuditLogs(ctx, organisationID, first.Timestamp)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, err
+	}
+	if unchained > 0 {
+		verification.Issues = append(verification.Issues, &domain.AuditTrailIssue{
+			IssueType:   enums.AuditTrailIssueUnchainedEntry,
+			Description: fmt.Sprintf("%d entries were recorded without being linked to the chain", unchained),
+		})
+	}
+
+	return verification, nil
+}
+
+// SetStaffTOTPEnforcement sets whether the staff in the logged in staff's organisation are required to log in with an authenticator app.
+// Only organisation administrators are allowed to change the policy.
+func (u *UseCaseOrganisationImpl) SetStaffTOTPEnforcement(ctx context.Context, enforce bool) (bool, error) {
+	userProfile, organisation, err := u.loggedInOrganisationAdmin(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	err = u.Update.UpdateOrganisation(ctx, organisation, map[string]interface{}{
+		"enforce_staff_totp": enforce,
+	})
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.InternalErr(err)
+	}
+
+	u.recordSecurityPolicyChange(ctx, userProfile, organisation, &domain.AuditLog{
+		Notes:  "staff authenticator app requirement changed",
+		Before: map[string]interface{}{"enforceStaffTOTP": organisation.EnforceStaffTOTP},
+		After:  map[string]interface{}{"enforceStaffTOTP": enforce},
+	})
+
+	return true, nil
+}
+
+// SetStaffLoginStepUp sets whether the staff in the logged in staff's organisation have to confirm logins from a new device
+// or location with an OTP sent to their phone. Only organisation administrators are allowed to change the policy.
+func (u *UseCaseOrganisationImpl) SetStaffLoginStepUp(ctx context.Context, enabled bool) (bool, error) {
+	userProfile, organisation, err := u.loggedInOrganisationAdmin(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	err = u.Update.UpdateOrganisation(ctx, organisation, map[string]interface{}{
+		"staff_login_step_up": enabled,
+	})
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.InternalErr(err)
+	}
+
+	u.recordSecurityPolicyChange(ctx, userProfile, organisation, &domain.AuditLog{
+		Notes:  "staff suspicious login verification changed",
+		Before: map[string]interface{}{"staffLoginStepUp": organisation.StaffLoginStepUp},
+		After:  map[string]interface{}{"staffLoginStepUp": enabled},
+	})
+
+	return true, nil
+}
+
+// SetPINPolicy sets the length, complexity, reuse and maximum age rules for the PINs of the users in the logged in staff's organisation
+// and the number of wrong security question answers after which a user is locked out of resetting their PIN.
+// The new rules apply the next time a user sets their PIN or logs in. Only organisation administrators are allowed to change the policy.
+func (u *UseCaseOrganisationImpl) SetPINPolicy(ctx context.Context, input dto.PINPolicyInput) (bool, error) {
+	if err := input.Validate(); err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.InputValidationErr(err)
+	}
+
+	userProfile, organisation, err := u.loggedInOrganisationAdmin(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	securityQuestionMaxAttempts := input.SecurityQuestionMaxAttempts
+	if securityQuestionMaxAttempts == 0 {
+		securityQuestionMaxAttempts = organisation.PINPolicy.SecurityQuestionMaxAttempts
+	}
+
+	err = u.Update.UpdateOrganisation(ctx, organisation, map[string]interface{}{
+		"pin_min_length":                 input.MinLength,
+		"pin_disallow_simple":            input.DisallowSimplePIN,
+		"pin_history_count":              input.HistoryCount,
+		"pin_max_age_days":               input.MaxAgeDays,
+		"security_question_max_attempts": securityQuestionMaxAttempts,
+	})
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.InternalErr(err)
+	}
+
+	u.recordSecurityPolicyChange(ctx, userProfile, organisation, &domain.AuditLog{
+		Notes: "PIN policy changed",
+		Before: map[string]interface{}{
+			"minLength":         organisation.PINPolicy.MinLength,
+			"disallowSimplePIN": organisation.PINPolicy.DisallowSimplePIN,
+			"historyCount":      organisation.PINPolicy.HistoryCount,
+			"maxAgeDays":        organisation.PINPolicy.MaxAgeDays,
+
+			"securityQuestionMaxAttempts": organisation.PINPolicy.SecurityQuestionMaxAttempts,
+		},
+		After: map[string]interface{}{
+			"minLength":         input.MinLength,
+			"disallowSimplePIN": input.DisallowSimplePIN,
+			"historyCount":      input.HistoryCount,
+			"maxAgeDays":        input.MaxAgeDays,
+
+			"securityQuestionMaxAttempts": securityQuestionMaxAttempts,
+		},
+	})
+
+	return true, nil
+}
+
+// maxDataRetentionDays is the longest period that an organisation can keep a client's data after they ask for it to be erased
+const maxDataRetentionDays = 3650
+
+// SetDataRetentionPeriod sets the number of days that the logged in staff's organisation keeps a client's data after the client asks for it to be erased.
+// The client's personal information is anonymized once the period has passed. Only organisation administrators are allowed to change the period.
+func (u *UseCaseOrganisationImpl) SetDataRetentionPeriod(ctx context.Context, days int) (bool, error) {
+	if days < 0 || days > maxDataRetentionDays {
+		err := fmt.Errorf("the data retention period must be between 0 and %d days", maxDataRetentionDays)
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.InputValidationErr(err)
+	}
+
+	userProfile, organisation, err := u.loggedInOrganisationAdmin(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	err = u.Update.UpdateOrganisation(ctx, organisation, map[string]interface{}{
+		"data_retention_days": days,
+	})
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.InternalErr(err)
+	}
+
+	u.recordSecurityPolicyChange(ctx, userProfile, organisation, &domain.AuditLog{
+		Notes:  "data retention period changed",
+		Before: map[string]interface{}{"dataRetentionDays": organisation.DataRetentionDays},
+		After:  map[string]interface{}{"dataRetentionDays": days},
+	})
+
+	return true, nil
+}
+
+// loggedInOrganisationAdmin returns the logged in staff's user profile and organisation.
+// It fails when the staff is not an administrator of the organisation
+func (u *UseCaseOrganisationImpl) loggedInOrganisationAdmin(ctx context.Context) (*domain.User, *domain.Organisation, error) {
+	loggedInUserID, err := u.ExternalExt.GetLoggedInUserUID(ctx)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, nil, exceptions.GetLoggedInUserUIDErr(err)
+	}
+
+	userProfile, err := u.Query.GetUserProfileByUserID(ctx, loggedInUserID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, nil, exceptions.UserNotFoundError(err)
+	}
+
+	staffProfile, err := u.Query.GetStaffProfile(ctx, loggedInUserID, userProfile.CurrentProgramID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, nil, exceptions.StaffProfileNotFoundErr(err)
+	}
+
+	if !staffProfile.IsOrganisationAdmin {
+		err := fmt.Errorf("staff is not an organisation admin")
+		helpers.ReportErrorToSentry(err)
+		return nil, nil, exceptions.UserNotAuthorizedErr(err)
+	}
+
+	organisation, err := u.Query.GetOrganisation(ctx, userProfile.CurrentOrganizationID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, nil, err
+	}
+
+	return userProfile, organisation, nil
+}
+
+// recordSecurityPolicyChange records an audit log of a change to the organisation's security policy
+func (u *UseCaseOrganisationImpl) recordSecurityPolicyChange(ctx context.Context, userProfile *domain.User, organisation *domain.Organisation, auditLog *domain.AuditLog) {
+	auditLog.RecordType = enums.AuditLogOrganisationSecurityPolicyChange
+	auditLog.ActorID = *userProfile.ID
+	auditLog.TargetID = organisation.ID
+	auditLog.TargetType = enums.AuditLogTargetOrganisation
+	auditLog.ProgramID = userProfile.CurrentProgramID
+	auditLog.OrganisationID = organisation.ID
+
+	common.RecordAuditLog(ctx, u.Create, auditLog)
+}
@@ -3,13 +3,17 @@ package organisation_test
 import (
 	"context"
 	"fmt"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/brianvoe/gofakeit"
 	"github.com/google/uuid"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/extension"
 	extensionMock "github.com/savannahghi/mycarehub/pkg/mycarehub/application/extension/mock"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/utils"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure"
 	pgMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/mock"
@@ -237,7 +241,7 @@ func TestUseCaseOrganisationImpl_CreateOrganisation(t *testing.T) {
 			fakeExtension := extensionMock.NewFakeExtension()
 			fakePubsub := pubsubMock.NewPubsubServiceMock()
 
-			o := organisation.NewUseCaseOrganisationImpl(fakeDB, fakeDB, fakeDB, fakeExtension, fakePubsub)
+			o := organisation.NewUseCaseOrganisationImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakePubsub)
 
 			if tt.name == "sad case: unable to create organisation" {
 				fakeDB.MockCreateOrganisationFn = func(ctx context.Context, organisation *domain.Organisation, programs []*domain.Program) (*domain.Organisation, error) {
@@ -348,7 +352,7 @@ func TestUseCaseOrganisationImpl_DeleteOrganisation(t *testing.T) {
 			fakeDB := pgMock.NewPostgresMock()
 			fakeExtension := extensionMock.NewFakeExtension()
 			fakePubsub := pubsubMock.NewPubsubServiceMock()
-			o := organisation.NewUseCaseOrganisationImpl(fakeDB, fakeDB, fakeDB, fakeExtension, fakePubsub)
+			o := organisation.NewUseCaseOrganisationImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakePubsub)
 
 			if tt.name == "sad case: unable to delete organisation" {
 				fakeDB.MockDeleteOrganisationFn = func(ctx context.Context, organisation *domain.Organisation) error {
@@ -425,7 +429,7 @@ func TestUseCaseOrganisationImpl_ListOrganisations(t *testing.T) {
 			fakeDB := pgMock.NewPostgresMock()
 			fakeExtension := extensionMock.NewFakeExtension()
 			fakePubsub := pubsubMock.NewPubsubServiceMock()
-			o := organisation.NewUseCaseOrganisationImpl(fakeDB, fakeDB, fakeDB, fakeExtension, fakePubsub)
+			o := organisation.NewUseCaseOrganisationImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakePubsub)
 
 			if tt.name == "sad case: unable to list organisations" {
 				fakeDB.MockListOrganisationsFn = func(ctx context.Context, pagination *domain.Pagination) ([]*domain.Organisation, *domain.Pagination, error) {
@@ -481,7 +485,7 @@ func TestUseCaseOrganisationImpl_SearchOrganisation(t *testing.T) {
 			fakeDB := pgMock.NewPostgresMock()
 			fakeExtension := extensionMock.NewFakeExtension()
 			fakePubsub := pubsubMock.NewPubsubServiceMock()
-			o := organisation.NewUseCaseOrganisationImpl(fakeDB, fakeDB, fakeDB, fakeExtension, fakePubsub)
+			o := organisation.NewUseCaseOrganisationImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakePubsub)
 
 			if tt.name == "sad case: unable to search organisation" {
 				fakeDB.MockSearchOrganisationsFn = func(ctx context.Context, searchParameter string) ([]*domain.Organisation, error) {
@@ -529,7 +533,7 @@ func TestUseCaseOrganisationImpl_GetOrganisationByID(t *testing.T) {
 			fakeDB := pgMock.NewPostgresMock()
 			fakeExtension := extensionMock.NewFakeExtension()
 			fakePubsub := pubsubMock.NewPubsubServiceMock()
-			o := organisation.NewUseCaseOrganisationImpl(fakeDB, fakeDB, fakeDB, fakeExtension, fakePubsub)
+			o := organisation.NewUseCaseOrganisationImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakePubsub)
 
 			if tt.name == "sad case: unable to get organisation by id" {
 				fakeDB.MockGetOrganisationFn = func(ctx context.Context, id string) (*domain.Organisation, error) {
@@ -544,3 +548,935 @@ func TestUseCaseOrganisationImpl_GetOrganisationByID(t *testing.T) {
 		})
 	}
 }
+
+func TestUseCaseOrganisationImpl_AuditTrail(t *testing.T) {
+	recordType := enums.AuditLogRoleChange
+	from := time.Now().Add(-time.Hour)
+	to := time.Now()
+
+	type args struct {
+		ctx             context.Context
+		filter          *dto.AuditLogFilterInput
+		paginationInput dto.PaginationsInput
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "happy case: list audit trail",
+			args: args{
+				ctx: context.Background(),
+				filter: &dto.AuditLogFilterInput{
+					RecordType: &recordType,
+					From:       &from,
+					To:         &to,
+				},
+				paginationInput: dto.PaginationsInput{Limit: 10, CurrentPage: 1},
+			},
+			wantErr: false,
+		},
+		{
+			name: "sad case: invalid pagination input",
+			args: args{
+				ctx:             context.Background(),
+				paginationInput: dto.PaginationsInput{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: invalid filter",
+			args: args{
+				ctx: context.Background(),
+				filter: &dto.AuditLogFilterInput{
+					From: &to,
+					To:   &from,
+				},
+				paginationInput: dto.PaginationsInput{Limit: 10, CurrentPage: 1},
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: unable to get logged in user",
+			args: args{
+				ctx:             context.Background(),
+				paginationInput: dto.PaginationsInput{Limit: 10, CurrentPage: 1},
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: unable to get user profile",
+			args: args{
+				ctx:             context.Background(),
+				paginationInput: dto.PaginationsInput{Limit: 10, CurrentPage: 1},
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: unable to get staff profile",
+			args: args{
+				ctx:             context.Background(),
+				paginationInput: dto.PaginationsInput{Limit: 10, CurrentPage: 1},
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: staff is not an organisation admin",
+			args: args{
+				ctx:             context.Background(),
+				paginationInput: dto.PaginationsInput{Limit: 10, CurrentPage: 1},
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: unable to list audit logs",
+			args: args{
+				ctx:             context.Background(),
+				paginationInput: dto.PaginationsInput{Limit: 10, CurrentPage: 1},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakePubsub := pubsubMock.NewPubsubServiceMock()
+			o := organisation.NewUseCaseOrganisationImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakePubsub)
+
+			fakeDB.MockGetStaffProfileFn = func(ctx context.Context, userID string, programID string) (*domain.StaffProfile, error) {
+				return &domain.StaffProfile{
+					ID:                  &userID,
+					UserID:              userID,
+					ProgramID:           programID,
+					IsOrganisationAdmin: true,
+				}, nil
+			}
+
+			if tt.name == "sad case: unable to get logged in user" {
+				fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+					return "", fmt.Errorf("unable to get logged in user")
+				}
+			}
+			if tt.name == "sad case: unable to get user profile" {
+				fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.User, error) {
+					return nil, fmt.Errorf("unable to get user profile")
+				}
+			}
+			if tt.name == "sad case: unable to get staff profile" {
+				fakeDB.MockGetStaffProfileFn = func(ctx context.Context, userID string, programID string) (*domain.StaffProfile, error) {
+					return nil, fmt.Errorf("unable to get staff profile")
+				}
+			}
+			if tt.name == "sad case: staff is not an organisation admin" {
+				fakeDB.MockGetStaffProfileFn = func(ctx context.Context, userID string, programID string) (*domain.StaffProfile, error) {
+					return &domain.StaffProfile{
+						ID:                  &userID,
+						UserID:              userID,
+						IsOrganisationAdmin: false,
+					}, nil
+				}
+			}
+			if tt.name == "sad case: unable to list audit logs" {
+				fakeDB.MockListAuditLogsFn = func(ctx context.Context, organisationID string, filter *dto.AuditLogFilterInput, pagination *domain.Pagination) ([]*domain.AuditLog, *domain.Pagination, error) {
+					return nil, nil, fmt.Errorf("unable to list audit logs")
+				}
+			}
+
+			got, err := o.AuditTrail(tt.args.ctx, tt.args.filter, tt.args.paginationInput)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCaseOrganisationImpl.AuditTrail() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got == nil {
+				t.Errorf("expected audit log page to be returned")
+			}
+		})
+	}
+}
+
+// auditLogChain builds a valid audit log chain of the provided length
+func auditLogChain(t *testing.T, organisationID string, length int) []*domain.AuditLog {
+	chain := []*domain.AuditLog{}
+	previousHash := ""
+	for i := 1; i <= length; i++ {
+		auditLog := &domain.AuditLog{
+			ID:             uuid.New().String(),
+			Active:         true,
+			Timestamp:      time.Now(),
+			RecordType:     enums.AuditLogRoleChange,
+			Notes:          gofakeit.Sentence(3),
+			TargetID:       uuid.New().String(),
+			TargetType:     enums.AuditLogTargetRole,
+			OrganisationID: organisationID,
+			After:          map[string]interface{}{"assigned_role_ids": []interface{}{uuid.New().String()}},
+			Sequence:       int64(i),
+			PreviousHash:   previousHash,
+		}
+		hash, err := utils.HashAuditLog(os.Getenv(utils.AuditLogHashKeyEnvVarName), auditLog)
+		if err != nil {
+			t.Fatalf("failed to hash audit log: %v", err)
+		}
+		auditLog.Hash = hash
+		previousHash = hash
+
+		chain = append(chain, auditLog)
+	}
+	return chain
+}
+
+func TestUseCaseOrganisationImpl_VerifyAuditTrail(t *testing.T) {
+	organisationID := uuid.New().String()
+
+	tests := []struct {
+		name           string
+		chainLength    int
+		wantIssueTypes []enums.AuditTrailIssueType
+		wantErr        bool
+	}{
+		{
+			name:        "happy case: intact audit trail",
+			chainLength: 5,
+		},
+		{
+			name:        "happy case: intact audit trail spanning several batches",
+			chainLength: 1200,
+		},
+		{
+			name: "happy case: empty audit trail",
+		},
+		{
+			name:           "happy case: modified entry",
+			chainLength:    5,
+			wantIssueTypes: []enums.AuditTrailIssueType{enums.AuditTrailIssueModifiedEntry},
+		},
+		{
+			name:           "happy case: modified entries signed again without the key",
+			chainLength:    5,
+			wantIssueTypes: []enums.AuditTrailIssueType{enums.AuditTrailIssueModifiedEntry, enums.AuditTrailIssueModifiedEntry, enums.AuditTrailIssueModifiedEntry},
+		},
+		{
+			name:           "happy case: deactivated entry",
+			chainLength:    5,
+			wantIssueTypes: []enums.AuditTrailIssueType{enums.AuditTrailIssueModifiedEntry},
+		},
+		{
+			name:           "happy case: missing entry",
+			chainLength:    5,
+			wantIssueTypes: []enums.AuditTrailIssueType{enums.AuditTrailIssueMissingEntry},
+		},
+		{
+			name:           "happy case: broken link",
+			chainLength:    5,
+			wantIssueTypes: []enums.AuditTrailIssueType{enums.AuditTrailIssueBrokenLink, enums.AuditTrailIssueModifiedEntry},
+		},
+		{
+			name:           "happy case: unchained entries",
+			chainLength:    5,
+			wantIssueTypes: []enums.AuditTrailIssueType{enums.AuditTrailIssueUnchainedEntry},
+		},
+		{
+			name:           "happy case: entries removed from the end of the chain",
+			chainLength:    5,
+			wantIssueTypes: []enums.AuditTrailIssueType{enums.AuditTrailIssueTruncatedChain},
+		},
+		{
+			name:           "happy case: all entries removed",
+			chainLength:    5,
+			wantIssueTypes: []enums.AuditTrailIssueType{enums.AuditTrailIssueTruncatedChain},
+		},
+		{
+			name:        "sad case: unable to list audit log chain",
+			chainLength: 5,
+			wantErr:     true,
+		},
+		{
+			name:        "sad case: unable to get organisation",
+			chainLength: 5,
+			wantErr:     true,
+		},
+		{
+			name:        "sad case: unable to count unchained audit logs",
+			chainLength: 5,
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakePubsub := pubsubMock.NewPubsubServiceMock()
+			o := organisation.NewUseCaseOrganisationImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakePubsub)
+
+			chain := auditLogChain(t, organisationID, tt.chainLength)
+
+			head := &domain.Organisation{ID: organisationID}
+			if len(chain) > 0 {
+				head.AuditLogSequence = chain[len(chain)-1].Sequence
+				head.AuditLogHash = chain[len(chain)-1].Hash
+			}
+			fakeDB.MockGetOrganisationFn = func(ctx context.Context, id string) (*domain.Organisation, error) {
+				return head, nil
+			}
+
+			switch tt.name {
+			case "happy case: modified entry":
+				chain[2].Notes = "altered"
+			case "happy case: modified entries signed again without the key":
+				chain[2].Notes = "altered"
+				for _, auditLog := range chain[2:] {
+					auditLog.PreviousHash = chain[auditLog.Sequence-2].Hash
+					hash, err := utils.HashAuditLog("guessed key", auditLog)
+					if err != nil {
+						t.Fatalf("failed to hash audit log: %v", err)
+					}
+					auditLog.Hash = hash
+				}
+				head.AuditLogHash = chain[len(chain)-1].Hash
+			case "happy case: deactivated entry":
+				chain[2].Active = false
+			case "happy case: missing entry":
+				chain = append(chain[:2], chain[3:]...)
+			case "happy case: broken link":
+				chain[2].PreviousHash = "altered"
+			case "happy case: entries removed from the end of the chain":
+				chain = chain[:3]
+			case "happy case: all entries removed":
+				chain = nil
+			}
+
+			fakeDB.MockListAuditLogChainFn = func(ctx context.Context, organisationID string, afterSequence int64, limit int) ([]*domain.AuditLog, error) {
+				auditLogs := []*domain.AuditLog{}
+				for _, auditLog := range chain {
+					if auditLog.Sequence > afterSequence && len(auditLogs) < limit {
+						auditLogs = append(auditLogs, auditLog)
+					}
+				}
+				return auditLogs, nil
+			}
+
+			if tt.name == "happy case: unchained entries" {
+				fakeDB.MockCountUnchainedAuditLogsFn = func(ctx context.Context, organisationID string, since time.Time) (int64, error) {
+					return 2, nil
+				}
+			}
+			if tt.name == "sad case: unable to list audit log chain" {
+				fakeDB.MockListAuditLogChainFn = func(ctx context.Context, organisationID string, afterSequence int64, limit int) ([]*domain.AuditLog, error) {
+					return nil, fmt.Errorf("unable to list audit log chain")
+				}
+			}
+			if tt.name == "sad case: unable to get organisation" {
+				fakeDB.MockGetOrganisationFn = func(ctx context.Context, id string) (*domain.Organisation, error) {
+					return nil, fmt.Errorf("unable to get organisation")
+				}
+			}
+			if tt.name == "sad case: unable to count unchained audit logs" {
+				fakeDB.MockCountUnchainedAuditLogsFn = func(ctx context.Context, organisationID string, since time.Time) (int64, error) {
+					return 0, fmt.Errorf("unable to count unchained audit logs")
+				}
+			}
+
+			got, err := o.VerifyAuditTrail(context.Background(), organisationID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCaseOrganisationImpl.VerifyAuditTrail() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if got.EntriesVerified != len(chain) {
+				t.Errorf("expected %d entries to be verified, got %d", len(chain), got.EntriesVerified)
+			}
+			if len(got.Issues) != len(tt.wantIssueTypes) {
+				t.Errorf("expected issues %v, got %d issues", tt.wantIssueTypes, len(got.Issues))
+				return
+			}
+			for i, issue := range got.Issues {
+				if issue.IssueType != tt.wantIssueTypes[i] {
+					t.Errorf("expected issue %v, got %v", tt.wantIssueTypes[i], issue.IssueType)
+				}
+			}
+		})
+	}
+}
+
+func TestUseCaseOrganisationImpl_SetStaffTOTPEnforcement(t *testing.T) {
+	type args struct {
+		ctx     context.Context
+		enforce bool
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "happy case: enforce staff TOTP",
+			args: args{
+				ctx:     context.Background(),
+				enforce: true,
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "happy case: enforce staff TOTP even when the audit log is not recorded",
+			args: args{
+				ctx:     context.Background(),
+				enforce: true,
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "sad case: unable to get logged in user",
+			args: args{
+				ctx:     context.Background(),
+				enforce: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: unable to get user profile",
+			args: args{
+				ctx:     context.Background(),
+				enforce: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: unable to get staff profile",
+			args: args{
+				ctx:     context.Background(),
+				enforce: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: staff is not an organisation admin",
+			args: args{
+				ctx:     context.Background(),
+				enforce: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: unable to get organisation",
+			args: args{
+				ctx:     context.Background(),
+				enforce: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: unable to update organisation",
+			args: args{
+				ctx:     context.Background(),
+				enforce: true,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakePubsub := pubsubMock.NewPubsubServiceMock()
+			o := organisation.NewUseCaseOrganisationImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakePubsub)
+
+			fakeDB.MockGetStaffProfileFn = func(ctx context.Context, userID string, programID string) (*domain.StaffProfile, error) {
+				return &domain.StaffProfile{
+					ID:                  &userID,
+					UserID:              userID,
+					ProgramID:           programID,
+					IsOrganisationAdmin: true,
+				}, nil
+			}
+
+			var auditLog *domain.AuditLog
+			fakeDB.MockCreateAuditLogFn = func(ctx context.Context, log *domain.AuditLog) error {
+				auditLog = log
+				return nil
+			}
+
+			if tt.name == "happy case: enforce staff TOTP even when the audit log is not recorded" {
+				fakeDB.MockCreateAuditLogFn = func(ctx context.Context, log *domain.AuditLog) error {
+					return fmt.Errorf("unable to create audit log")
+				}
+			}
+			if tt.name == "sad case: unable to get logged in user" {
+				fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+					return "", fmt.Errorf("unable to get logged in user")
+				}
+			}
+			if tt.name == "sad case: unable to get user profile" {
+				fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.User, error) {
+					return nil, fmt.Errorf("unable to get user profile")
+				}
+			}
+			if tt.name == "sad case: unable to get staff profile" {
+				fakeDB.MockGetStaffProfileFn = func(ctx context.Context, userID string, programID string) (*domain.StaffProfile, error) {
+					return nil, fmt.Errorf("unable to get staff profile")
+				}
+			}
+			if tt.name == "sad case: staff is not an organisation admin" {
+				fakeDB.MockGetStaffProfileFn = func(ctx context.Context, userID string, programID string) (*domain.StaffProfile, error) {
+					return &domain.StaffProfile{
+						ID:                  &userID,
+						UserID:              userID,
+						IsOrganisationAdmin: false,
+					}, nil
+				}
+			}
+			if tt.name == "sad case: unable to get organisation" {
+				fakeDB.MockGetOrganisationFn = func(ctx context.Context, id string) (*domain.Organisation, error) {
+					return nil, fmt.Errorf("unable to get organisation")
+				}
+			}
+			if tt.name == "sad case: unable to update organisation" {
+				fakeDB.MockUpdateOrganisationFn = func(ctx context.Context, organisation *domain.Organisation, updateData map[string]interface{}) error {
+					return fmt.Errorf("unable to update organisation")
+				}
+			}
+
+			got, err := o.SetStaffTOTPEnforcement(tt.args.ctx, tt.args.enforce)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCaseOrganisationImpl.SetStaffTOTPEnforcement() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UseCaseOrganisationImpl.SetStaffTOTPEnforcement() = %v, want %v", got, tt.want)
+			}
+
+			if tt.name == "happy case: enforce staff TOTP" {
+				if auditLog == nil || auditLog.RecordType != enums.AuditLogOrganisationSecurityPolicyChange {
+					t.Errorf("UseCaseOrganisationImpl.SetStaffTOTPEnforcement() expected the change to be audited")
+					return
+				}
+				if auditLog.After["enforceStaffTOTP"] != tt.args.enforce {
+					t.Errorf("UseCaseOrganisationImpl.SetStaffTOTPEnforcement() audit log after = %v, want %v", auditLog.After, tt.args.enforce)
+				}
+			}
+		})
+	}
+}
+
+func TestUseCaseOrganisationImpl_SetStaffLoginStepUp(t *testing.T) {
+	type args struct {
+		ctx     context.Context
+		enabled bool
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "happy case: require staff login step up",
+			args: args{
+				ctx:     context.Background(),
+				enabled: true,
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "happy case: require staff login step up even when the audit log is not recorded",
+			args: args{
+				ctx:     context.Background(),
+				enabled: true,
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "sad case: unable to get logged in user",
+			args: args{
+				ctx:     context.Background(),
+				enabled: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: unable to get user profile",
+			args: args{
+				ctx:     context.Background(),
+				enabled: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: unable to get staff profile",
+			args: args{
+				ctx:     context.Background(),
+				enabled: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: staff is not an organisation admin",
+			args: args{
+				ctx:     context.Background(),
+				enabled: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: unable to get organisation",
+			args: args{
+				ctx:     context.Background(),
+				enabled: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: unable to update organisation",
+			args: args{
+				ctx:     context.Background(),
+				enabled: true,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakePubsub := pubsubMock.NewPubsubServiceMock()
+			o := organisation.NewUseCaseOrganisationImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakePubsub)
+
+			fakeDB.MockGetStaffProfileFn = func(ctx context.Context, userID string, programID string) (*domain.StaffProfile, error) {
+				return &domain.StaffProfile{
+					ID:                  &userID,
+					UserID:              userID,
+					ProgramID:           programID,
+					IsOrganisationAdmin: true,
+				}, nil
+			}
+
+			var auditLog *domain.AuditLog
+			fakeDB.MockCreateAuditLogFn = func(ctx context.Context, log *domain.AuditLog) error {
+				auditLog = log
+				return nil
+			}
+
+			if tt.name == "happy case: require staff login step up even when the audit log is not recorded" {
+				fakeDB.MockCreateAuditLogFn = func(ctx context.Context, log *domain.AuditLog) error {
+					return fmt.Errorf("unable to create audit log")
+				}
+			}
+			if tt.name == "sad case: unable to get logged in user" {
+				fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+					return "", fmt.Errorf("unable to get logged in user")
+				}
+			}
+			if tt.name == "sad case: unable to get user profile" {
+				fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.User, error) {
+					return nil, fmt.Errorf("unable to get user profile")
+				}
+			}
+			if tt.name == "sad case: unable to get staff profile" {
+				fakeDB.MockGetStaffProfileFn = func(ctx context.Context, userID string, programID string) (*domain.StaffProfile, error) {
+					return nil, fmt.Errorf("unable to get staff profile")
+				}
+			}
+			if tt.name == "sad case: staff is not an organisation admin" {
+				fakeDB.MockGetStaffProfileFn = func(ctx context.Context, userID string, programID string) (*domain.StaffProfile, error) {
+					return &domain.StaffProfile{
+						ID:                  &userID,
+						UserID:              userID,
+						IsOrganisationAdmin: false,
+					}, nil
+				}
+			}
+			if tt.name == "sad case: unable to get organisation" {
+				fakeDB.MockGetOrganisationFn = func(ctx context.Context, id string) (*domain.Organisation, error) {
+					return nil, fmt.Errorf("unable to get organisation")
+				}
+			}
+			if tt.name == "sad case: unable to update organisation" {
+				fakeDB.MockUpdateOrganisationFn = func(ctx context.Context, organisation *domain.Organisation, updateData map[string]interface{}) error {
+					return fmt.Errorf("unable to update organisation")
+				}
+			}
+
+			got, err := o.SetStaffLoginStepUp(tt.args.ctx, tt.args.enabled)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCaseOrganisationImpl.SetStaffLoginStepUp() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UseCaseOrganisationImpl.SetStaffLoginStepUp() = %v, want %v", got, tt.want)
+			}
+
+			if tt.name == "happy case: require staff login step up" {
+				if auditLog == nil || auditLog.RecordType != enums.AuditLogOrganisationSecurityPolicyChange {
+					t.Errorf("UseCaseOrganisationImpl.SetStaffLoginStepUp() expected the change to be audited")
+					return
+				}
+				if auditLog.After["staffLoginStepUp"] != tt.args.enabled {
+					t.Errorf("UseCaseOrganisationImpl.SetStaffLoginStepUp() audit log after = %v, want %v", auditLog.After, tt.args.enabled)
+				}
+			}
+		})
+	}
+}
+
+func TestUseCaseOrganisationImpl_SetPINPolicy(t *testing.T) {
+	validInput := dto.PINPolicyInput{
+		MinLength:         6,
+		DisallowSimplePIN: true,
+		HistoryCount:      5,
+		MaxAgeDays:        90,
+	}
+
+	type args struct {
+		ctx   context.Context
+		input dto.PINPolicyInput
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "happy case: set pin policy",
+			args: args{
+				ctx:   context.Background(),
+				input: validInput,
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "happy case: set pin policy with security question attempts",
+			args: args{
+				ctx: context.Background(),
+				input: dto.PINPolicyInput{
+					MinLength:                   4,
+					SecurityQuestionMaxAttempts: 5,
+				},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "sad case: invalid pin policy",
+			args: args{
+				ctx: context.Background(),
+				input: dto.PINPolicyInput{
+					MinLength: 2,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: staff is not an organisation admin",
+			args: args{
+				ctx:   context.Background(),
+				input: validInput,
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: unable to update organisation",
+			args: args{
+				ctx:   context.Background(),
+				input: validInput,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakePubsub := pubsubMock.NewPubsubServiceMock()
+			o := organisation.NewUseCaseOrganisationImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakePubsub)
+
+			fakeDB.MockGetStaffProfileFn = func(ctx context.Context, userID string, programID string) (*domain.StaffProfile, error) {
+				return &domain.StaffProfile{
+					ID:                  &userID,
+					UserID:              userID,
+					ProgramID:           programID,
+					IsOrganisationAdmin: true,
+				}, nil
+			}
+
+			var updates map[string]interface{}
+			fakeDB.MockUpdateOrganisationFn = func(ctx context.Context, organisation *domain.Organisation, updateData map[string]interface{}) error {
+				updates = updateData
+				return nil
+			}
+
+			var auditLog *domain.AuditLog
+			fakeDB.MockCreateAuditLogFn = func(ctx context.Context, log *domain.AuditLog) error {
+				auditLog = log
+				return nil
+			}
+
+			if tt.name == "sad case: staff is not an organisation admin" {
+				fakeDB.MockGetStaffProfileFn = func(ctx context.Context, userID string, programID string) (*domain.StaffProfile, error) {
+					return &domain.StaffProfile{
+						ID:                  &userID,
+						UserID:              userID,
+						IsOrganisationAdmin: false,
+					}, nil
+				}
+			}
+			if tt.name == "sad case: unable to update organisation" {
+				fakeDB.MockUpdateOrganisationFn = func(ctx context.Context, organisation *domain.Organisation, updateData map[string]interface{}) error {
+					return fmt.Errorf("unable to update organisation")
+				}
+			}
+
+			got, err := o.SetPINPolicy(tt.args.ctx, tt.args.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCaseOrganisationImpl.SetPINPolicy() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UseCaseOrganisationImpl.SetPINPolicy() = %v, want %v", got, tt.want)
+			}
+
+			if tt.name == "happy case: set pin policy" {
+				if updates["pin_min_length"] != validInput.MinLength || updates["pin_history_count"] != validInput.HistoryCount || updates["pin_max_age_days"] != validInput.MaxAgeDays {
+					t.Errorf("UseCaseOrganisationImpl.SetPINPolicy() updates = %v, want %v", updates, validInput)
+				}
+				if auditLog == nil || auditLog.RecordType != enums.AuditLogOrganisationSecurityPolicyChange {
+					t.Errorf("UseCaseOrganisationImpl.SetPINPolicy() expected the change to be audited")
+					return
+				}
+				if auditLog.After["historyCount"] != validInput.HistoryCount {
+					t.Errorf("UseCaseOrganisationImpl.SetPINPolicy() audit log after = %v, want %v", auditLog.After, validInput)
+				}
+			}
+		})
+	}
+}
+
+func TestUseCaseOrganisationImpl_SetDataRetentionPeriod(t *testing.T) {
+	type args struct {
+		ctx  context.Context
+		days int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "happy case: set data retention period",
+			args: args{
+				ctx:  context.Background(),
+				days: 90,
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "happy case: erase client data without a retention period",
+			args: args{
+				ctx:  context.Background(),
+				days: 0,
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "sad case: negative data retention period",
+			args: args{
+				ctx:  context.Background(),
+				days: -1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: data retention period is too long",
+			args: args{
+				ctx:  context.Background(),
+				days: 3651,
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: staff is not an organisation admin",
+			args: args{
+				ctx:  context.Background(),
+				days: 90,
+			},
+			wantErr: true,
+		},
+		{
+			name: "sad case: unable to update organisation",
+			args: args{
+				ctx:  context.Background(),
+				days: 90,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakePubsub := pubsubMock.NewPubsubServiceMock()
+			o := organisation.NewUseCaseOrganisationImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakePubsub)
+
+			fakeDB.MockGetStaffProfileFn = func(ctx context.Context, userID string, programID string) (*domain.StaffProfile, error) {
+				return &domain.StaffProfile{
+					ID:                  &userID,
+					UserID:              userID,
+					ProgramID:           programID,
+					IsOrganisationAdmin: true,
+				}, nil
+			}
+
+			var auditLog *domain.AuditLog
+			fakeDB.MockCreateAuditLogFn = func(ctx context.Context, log *domain.AuditLog) error {
+				auditLog = log
+				return nil
+			}
+
+			if tt.name == "sad case: staff is not an organisation admin" {
+				fakeDB.MockGetStaffProfileFn = func(ctx context.Context, userID string, programID string) (*domain.StaffProfile, error) {
+					return &domain.StaffProfile{
+						ID:                  &userID,
+						UserID:              userID,
+						IsOrganisationAdmin: false,
+					}, nil
+				}
+			}
+			if tt.name == "sad case: unable to update organisation" {
+				fakeDB.MockUpdateOrganisationFn = func(ctx context.Context, organisation *domain.Organisation, updateData map[string]interface{}) error {
+					return fmt.Errorf("unable to update organisation")
+				}
+			}
+
+			got, err := o.SetDataRetentionPeriod(tt.args.ctx, tt.args.days)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCaseOrganisationImpl.SetDataRetentionPeriod() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UseCaseOrganisationImpl.SetDataRetentionPeriod() = %v, want %v", got, tt.want)
+			}
+
+			if tt.name == "happy case: set data retention period" {
+				if auditLog == nil || auditLog.RecordType != enums.AuditLogOrganisationSecurityPolicyChange {
+					t.Errorf("UseCaseOrganisationImpl.SetDataRetentionPeriod() expected the change to be audited")
+					return
+				}
+				if auditLog.After["dataRetentionDays"] != tt.args.days {
+					t.Errorf("UseCaseOrganisationImpl.SetDataRetentionPeriod() audit log after = %v, want %v", auditLog.After, tt.args.days)
+				}
+			}
+		})
+	}
+}
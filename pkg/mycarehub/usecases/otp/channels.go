@@ -0,0 +1,157 @@
+package otp
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/savannahghi/interserviceclient"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	serviceSMS "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/sms"
+	serviceTwilio "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/twilio"
+)
+
+const (
+	// smsChannel is the channel recorded for OTPs sent as SMS
+	smsChannel = "SMS"
+
+	// whatsAppChannel is the channel recorded for OTPs sent as WhatsApp messages
+	whatsAppChannel = "WHATSAPP"
+)
+
+// DeliveryChannel is a provider that an OTP can be sent through.
+// Additional channels e.g voice calls can be added by appending them to `UseCaseOTPImpl.Channels`
+type DeliveryChannel interface {
+	// Provider identifies the channel in the recorded delivery attempts and delivery receipts
+	Provider() enums.OTPDeliveryProvider
+
+	// Channel is the medium that the OTP is sent through e.g `SMS`
+	Channel() string
+
+	// Supports returns true if the channel can send a message to the phone number
+	Supports(phoneNumber string) bool
+
+	// Send sends the message and returns the provider's ID for it
+	Send(ctx context.Context, phoneNumber string, message string) (string, error)
+}
+
+// silCommsChannel sends OTPs to Kenyan phone numbers as SMS through SILComms
+type silCommsChannel struct {
+	sms serviceSMS.IServiceSMS
+}
+
+func (c *silCommsChannel) Provider() enums.OTPDeliveryProvider {
+	return enums.OTPDeliveryProviderSILComms
+}
+
+func (c *silCommsChannel) Channel() string {
+	return smsChannel
+}
+
+func (c *silCommsChannel) Supports(phoneNumber string) bool {
+	return interserviceclient.IsKenyanNumber(phoneNumber)
+}
+
+func (c *silCommsChannel) Send(ctx context.Context, phoneNumber string, message string) (string, error) {
+	resp, err := c.sms.SendSMS(ctx, message, []string{phoneNumber})
+	if err != nil {
+		return "", err
+	}
+
+	return resp.GUID, nil
+}
+
+// twilioSMSChannel sends OTPs as SMS through Twilio
+type twilioSMSChannel struct {
+	twilio serviceTwilio.ITwilioService
+}
+
+func (c *twilioSMSChannel) Provider() enums.OTPDeliveryProvider {
+	return enums.OTPDeliveryProviderTwilio
+}
+
+func (c *twilioSMSChannel) Channel() string {
+	return smsChannel
+}
+
+func (c *twilioSMSChannel) Supports(phoneNumber string) bool {
+	return true
+}
+
+func (c *twilioSMSChannel) Send(ctx context.Context, phoneNumber string, message string) (string, error) {
+	msg, err := c.twilio.SendTrackedSMSViaTwilio(ctx, phoneNumber, message)
+	if err != nil {
+		return "", err
+	}
+
+	return msg.Sid, nil
+}
+
+// twilioWhatsAppChannel sends OTPs as WhatsApp messages through Twilio when a WhatsApp sender has been configured
+type twilioWhatsAppChannel struct {
+	twilio serviceTwilio.ITwilioService
+}
+
+func (c *twilioWhatsAppChannel) Provider() enums.OTPDeliveryProvider {
+	return enums.OTPDeliveryProviderWhatsApp
+}
+
+func (c *twilioWhatsAppChannel) Channel() string {
+	return whatsAppChannel
+}
+
+func (c *twilioWhatsAppChannel) Supports(phoneNumber string) bool {
+	return c.twilio.WhatsAppEnabled()
+}
+
+func (c *twilioWhatsAppChannel) Send(ctx context.Context, phoneNumber string, message string) (string, error) {
+	msg, err := c.twilio.SendWhatsAppViaTwilio(ctx, phoneNumber, message)
+	if err != nil {
+		return "", err
+	}
+
+	return msg.Sid, nil
+}
+
+// defaultDeliveryChannels are the channels OTPs are sent through in order of preference
+func defaultDeliveryChannels(sms serviceSMS.IServiceSMS, twilio serviceTwilio.ITwilioService) []DeliveryChannel {
+	return []DeliveryChannel{
+		&silCommsChannel{sms: sms},
+		&twilioSMSChannel{twilio: twilio},
+		&twilioWhatsAppChannel{twilio: twilio},
+	}
+}
+
+// deliverOTP sends the message through the first delivery channel that accepts it.
+// When a channel fails the next one is tried so that an outage of one provider does not lock users out.
+// It returns the channel that the message was sent through and every attempt made so that they can be saved with the OTP
+func (o *UseCaseOTPImpl) deliverOTP(ctx context.Context, phoneNumber string, message string) (string, []*domain.OTPDeliveryAttempt, error) {
+	attempts := []*domain.OTPDeliveryAttempt{}
+	for _, channel := range o.Channels {
+		if !channel.Supports(phoneNumber) {
+			continue
+		}
+
+		attempt := &domain.OTPDeliveryAttempt{
+			Provider:    channel.Provider(),
+			AttemptedAt: time.Now(),
+		}
+		attempts = append(attempts, attempt)
+
+		messageID, err := channel.Send(ctx, phoneNumber, message)
+		if err != nil {
+			helpers.ReportErrorToSentry(fmt.Errorf("failed to send OTP via %s: %w", channel.Provider(), err))
+			attempt.Status = enums.OTPDeliveryStatusFailed
+			attempt.Error = err.Error()
+			continue
+		}
+
+		attempt.ProviderMessageID = messageID
+		attempt.Status = enums.OTPDeliveryStatusSent
+		return channel.Channel(), attempts, nil
+	}
+
+	return "", attempts, fmt.Errorf("failed to send OTP verification code to recipient after %d attempts", len(attempts))
+}
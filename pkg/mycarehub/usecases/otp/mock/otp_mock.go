@@ -21,6 +21,9 @@ type OTPUseCaseMock struct {
 	MockGenerateRetryOTPFn  func(ctx context.Context, payload *dto.SendRetryOTPPayload) (string, error)
 	MockSendOTPFn           func(ctx context.Context, phoneNumber string, code string, message string) (string, error)
 	MockVerifyOTP           func(ctx context.Context, payload *dto.VerifyOTPInput) (bool, error)
+
+	MockRecordOTPDeliveryReceiptFn func(ctx context.Context, input *dto.OTPDeliveryReceiptInput) (bool, error)
+	MockSendPhoneChangeOTPFn       func(ctx context.Context, username string, phoneNumber string, flavour feedlib.Flavour) (*domain.OTPResponse, error)
 }
 
 // NewOTPUseCaseMock initializes a new instance mock of the OTP usecase
@@ -46,6 +49,15 @@ func NewOTPUseCaseMock() *OTPUseCaseMock {
 		MockVerifyOTP: func(ctx context.Context, payload *dto.VerifyOTPInput) (bool, error) {
 			return true, nil
 		},
+		MockRecordOTPDeliveryReceiptFn: func(ctx context.Context, input *dto.OTPDeliveryReceiptInput) (bool, error) {
+			return true, nil
+		},
+		MockSendPhoneChangeOTPFn: func(ctx context.Context, username string, phoneNumber string, flavour feedlib.Flavour) (*domain.OTPResponse, error) {
+			return &domain.OTPResponse{
+				OTP:         "111222",
+				PhoneNumber: phoneNumber,
+			}, nil
+		},
 	}
 }
 
@@ -77,3 +89,13 @@ func (o *OTPUseCaseMock) SendOTP(ctx context.Context, phoneNumber string, code s
 func (o *OTPUseCaseMock) VerifyOTP(ctx context.Context, payload *dto.VerifyOTPInput) (bool, error) {
 	return o.MockVerifyOTP(ctx, payload)
 }
+
+// RecordOTPDeliveryReceipt mocks the implementation of recording an OTP delivery receipt
+func (o *OTPUseCaseMock) RecordOTPDeliveryReceipt(ctx context.Context, input *dto.OTPDeliveryReceiptInput) (bool, error) {
+	return o.MockRecordOTPDeliveryReceiptFn(ctx, input)
+}
+
+// SendPhoneChangeOTP mocks the implementation of sending an OTP to the phone number a user wants to change to
+func (o *OTPUseCaseMock) SendPhoneChangeOTP(ctx context.Context, username string, phoneNumber string, flavour feedlib.Flavour) (*domain.OTPResponse, error) {
+	return o.MockSendPhoneChangeOTPFn(ctx, username, phoneNumber, flavour)
+}
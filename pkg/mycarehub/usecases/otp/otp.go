@@ -6,9 +6,9 @@ import (
 	"time"
 
 	"github.com/savannahghi/feedlib"
-	"github.com/savannahghi/interserviceclient"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/extension"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/utils"
@@ -63,11 +63,23 @@ type ISendOTP interface {
 	) (string, error)
 }
 
+// IOTPDeliveryReceipt specifies the method used to record the delivery status reported by the OTP delivery providers
+type IOTPDeliveryReceipt interface {
+	RecordOTPDeliveryReceipt(ctx context.Context, input *dto.OTPDeliveryReceiptInput) (bool, error)
+}
+
+// IPhoneChangeOTP specifies the method used to verify a new phone number before it replaces a user's phone number
+type IPhoneChangeOTP interface {
+	SendPhoneChangeOTP(ctx context.Context, username string, phoneNumber string, flavour feedlib.Flavour) (*domain.OTPResponse, error)
+}
+
 // UsecaseOTP defines otp service usecases interface
 type UsecaseOTP interface {
 	ISendOTP
 	IVerifyOTP
 	IverifyPhone
+	IOTPDeliveryReceipt
+	IPhoneChangeOTP
 }
 
 // IVerifyOTP specifies the method responsible for verifying the OTP
@@ -79,15 +91,25 @@ type IVerifyOTP interface {
 type UseCaseOTPImpl struct {
 	Create      infrastructure.Create
 	Query       infrastructure.Query
+	Update      infrastructure.Update
 	ExternalExt extension.ExternalMethodsExtension
 	SMS         serviceSMS.IServiceSMS
 	Twilio      serviceTwilio.ITwilioService
+
+	// Channels are the delivery channels OTPs are sent through in order of preference
+	Channels []DeliveryChannel
+
+	// RateLimits are the limits applied to each key of an OTP request
+	RateLimits map[enums.RateLimitKeyType]RateLimitConfig
+	// AbuseThreshold is the number of rejected requests after which a rate limited key is reported
+	AbuseThreshold int
 }
 
 // NewOTPUseCase initializes a new OTP service
 func NewOTPUseCase(
 	create infrastructure.Create,
 	query infrastructure.Query,
+	update infrastructure.Update,
 	externalExt extension.ExternalMethodsExtension,
 	sms serviceSMS.IServiceSMS,
 	twilio serviceTwilio.ITwilioService,
@@ -95,9 +117,14 @@ func NewOTPUseCase(
 	return &UseCaseOTPImpl{
 		Create:      create,
 		Query:       query,
+		Update:      update,
 		ExternalExt: externalExt,
 		SMS:         sms,
 		Twilio:      twilio,
+
+		Channels:       defaultDeliveryChannels(sms, twilio),
+		RateLimits:     otpRateLimits,
+		AbuseThreshold: otpAbuseThreshold,
 	}
 }
 
@@ -130,6 +157,11 @@ func (o *UseCaseOTPImpl) GenerateAndSendOTP(
 		return nil, exceptions.ContactNotFoundErr(err)
 	}
 
+	err = o.checkRateLimit(ctx, userProfile, phone.ContactValue)
+	if err != nil {
+		return nil, err
+	}
+
 	var message string
 	switch flavour {
 	case feedlib.FlavourConsumer:
@@ -138,7 +170,7 @@ func (o *UseCaseOTPImpl) GenerateAndSendOTP(
 		message = fmt.Sprintf(otpMessage, otp, proAppName, proAppIdentifier)
 	}
 
-	otp, err = o.SendOTP(ctx, phone.ContactValue, otp, message)
+	channel, attempts, err := o.deliverOTP(ctx, phone.ContactValue, message)
 	if err != nil {
 		helpers.ReportErrorToSentry(err)
 		return nil, err
@@ -149,10 +181,12 @@ func (o *UseCaseOTPImpl) GenerateAndSendOTP(
 		Valid:       true,
 		GeneratedAt: time.Now(),
 		ValidUntil:  time.Now().Add(time.Minute * 10),
-		Channel:     "SMS",
+		Channel:     channel,
 		Flavour:     flavour,
 		PhoneNumber: phone.ContactValue,
 		OTP:         otp,
+
+		DeliveryAttempts: attempts,
 	}
 
 	err = o.Create.SaveOTP(ctx, otpDataPayload)
@@ -210,6 +244,11 @@ func (o *UseCaseOTPImpl) VerifyPhoneNumber(ctx context.Context, username string,
 		return nil, exceptions.ContactNotFoundErr(err)
 	}
 
+	err = o.checkRateLimit(ctx, userProfile, phone.ContactValue)
+	if err != nil {
+		return nil, err
+	}
+
 	var message string
 	switch flavour {
 	case feedlib.FlavourConsumer:
@@ -218,7 +257,7 @@ func (o *UseCaseOTPImpl) VerifyPhoneNumber(ctx context.Context, username string,
 		message = fmt.Sprintf(otpMessage, otp, proAppName, proAppIdentifier)
 	}
 
-	otp, err = o.SendOTP(ctx, phone.ContactValue, otp, message)
+	channel, attempts, err := o.deliverOTP(ctx, phone.ContactValue, message)
 	if err != nil {
 		helpers.ReportErrorToSentry(err)
 		return nil, err
@@ -229,10 +268,12 @@ func (o *UseCaseOTPImpl) VerifyPhoneNumber(ctx context.Context, username string,
 		Valid:       true,
 		GeneratedAt: time.Now(),
 		ValidUntil:  time.Now().Add(time.Minute * 10),
-		Channel:     "SMS",
+		Channel:     channel,
 		Flavour:     flavour,
 		PhoneNumber: phone.ContactValue,
 		OTP:         otp,
+
+		DeliveryAttempts: attempts,
 	}
 
 	err = o.Create.SaveOTP(ctx, otpDataPayload)
@@ -246,6 +287,69 @@ func (o *UseCaseOTPImpl) VerifyPhoneNumber(ctx context.Context, username string,
 	}, nil
 }
 
+// SendPhoneChangeOTP sends an OTP to the phone number that a user wants to change to.
+// The OTP is saved against the new phone number so that it can only be used to confirm the change and not to log in
+func (o *UseCaseOTPImpl) SendPhoneChangeOTP(ctx context.Context, username string, phoneNumber string, flavour feedlib.Flavour) (*domain.OTPResponse, error) {
+	if !flavour.IsValid() {
+		return nil, exceptions.InvalidFlavourDefinedErr(fmt.Errorf("flavour is not valid"))
+	}
+
+	userProfile, err := o.Query.GetUserProfileByUsername(ctx, username)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.UserNotFoundError(err)
+	}
+
+	otp, err := utils.GenerateOTP()
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, fmt.Errorf("failed to generate an OTP")
+	}
+
+	err = o.checkRateLimit(ctx, userProfile, phoneNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	var message string
+	switch flavour {
+	case feedlib.FlavourConsumer:
+		message = fmt.Sprintf(otpMessage, otp, consumerAppName, consumerAppIdentifier)
+	case feedlib.FlavourPro:
+		message = fmt.Sprintf(otpMessage, otp, proAppName, proAppIdentifier)
+	}
+
+	channel, attempts, err := o.deliverOTP(ctx, phoneNumber, message)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, err
+	}
+
+	otpDataPayload := &domain.OTP{
+		UserID:      *userProfile.ID,
+		Valid:       true,
+		GeneratedAt: time.Now(),
+		ValidUntil:  time.Now().Add(time.Minute * 10),
+		Channel:     channel,
+		Flavour:     flavour,
+		PhoneNumber: phoneNumber,
+		OTP:         otp,
+
+		DeliveryAttempts: attempts,
+	}
+
+	err = o.Create.SaveOTP(ctx, otpDataPayload)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, fmt.Errorf("failed to save otp: %w", err)
+	}
+
+	return &domain.OTPResponse{
+		OTP:         otp,
+		PhoneNumber: phoneNumber,
+	}, nil
+}
+
 // GenerateRetryOTP generates fallback OTPs when Africa is talking sms fails
 func (o *UseCaseOTPImpl) GenerateRetryOTP(ctx context.Context, payload *dto.SendRetryOTPPayload) (string, error) {
 	retryResponseOTP, err := utils.GenerateOTP()
@@ -266,12 +370,17 @@ func (o *UseCaseOTPImpl) GenerateRetryOTP(ctx context.Context, payload *dto.Send
 		return "", exceptions.ContactNotFoundErr(err)
 	}
 
+	err = o.checkRateLimit(ctx, userProfile, phone.ContactValue)
+	if err != nil {
+		return "", err
+	}
+
 	otpMessage := fmt.Sprintf("%v is your verification code.", retryResponseOTP)
 	// send retry otp
-	_, err = o.SMS.SendSMS(ctx, otpMessage, []string{phone.ContactValue})
+	channel, attempts, err := o.deliverOTP(ctx, phone.ContactValue, otpMessage)
 	if err != nil {
 		helpers.ReportErrorToSentry(err)
-		return "", fmt.Errorf("failed to send OTP verification code to recipient %w", err)
+		return "", err
 	}
 
 	otpResponsePayload := &domain.OTP{
@@ -279,10 +388,12 @@ func (o *UseCaseOTPImpl) GenerateRetryOTP(ctx context.Context, payload *dto.Send
 		Valid:       true,
 		GeneratedAt: time.Now(),
 		ValidUntil:  time.Now().Add(time.Hour * 1),
-		Channel:     "SMS",
+		Channel:     channel,
 		Flavour:     payload.Flavour,
 		PhoneNumber: phone.ContactValue,
 		OTP:         retryResponseOTP,
+
+		DeliveryAttempts: attempts,
 	}
 
 	err = o.Create.SaveOTP(ctx, otpResponsePayload)
@@ -294,29 +405,36 @@ func (o *UseCaseOTPImpl) GenerateRetryOTP(ctx context.Context, payload *dto.Send
 	return retryResponseOTP, nil
 }
 
-// SendOTP sends an OTP message to the specified phonenumber. It checks to see whether the provided
-// phone number is Kenyan and if true, it uses AIT else for foreign numbers, it uses twilio to send
-// the otp
+// SendOTP sends an OTP message to the specified phonenumber through the first delivery channel that accepts it.
+// Kenyan numbers are sent through SILComms first while foreign numbers start with Twilio.
 func (o *UseCaseOTPImpl) SendOTP(
 	ctx context.Context,
 	phoneNumber string,
 	code string,
 	message string,
 ) (string, error) {
-	if interserviceclient.IsKenyanNumber(phoneNumber) {
-		_, err := o.SMS.SendSMS(ctx, message, []string{phoneNumber})
-		if err != nil {
-			helpers.ReportErrorToSentry(err)
-			return "", fmt.Errorf("failed to send OTP verification code to recipient: %w", err)
-		}
-
-	} else {
-		// Make the request to twilio
-		err := o.Twilio.SendSMSViaTwilio(ctx, phoneNumber, message)
-		if err != nil {
-			helpers.ReportErrorToSentry(err)
-			return "", fmt.Errorf("sms not sent via twilio: %w", err)
-		}
+	_, _, err := o.deliverOTP(ctx, phoneNumber, message)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return "", err
 	}
+
 	return code, nil
 }
+
+// RecordOTPDeliveryReceipt updates the status of an OTP delivery attempt from a provider's delivery receipt.
+// It returns false when the receipt does not match a pending delivery attempt e.g when it is for a message that is not an OTP
+func (o *UseCaseOTPImpl) RecordOTPDeliveryReceipt(ctx context.Context, input *dto.OTPDeliveryReceiptInput) (bool, error) {
+	if err := input.Validate(); err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.InputValidationErr(err)
+	}
+
+	updated, err := o.Update.UpdateOTPDeliveryStatus(ctx, input.Provider, input.ProviderMessageID, input.Status, time.Now())
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.InternalErr(fmt.Errorf("failed to record OTP delivery receipt: %w", err))
+	}
+
+	return updated, nil
+}
@@ -2,15 +2,22 @@ package otp_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/brianvoe/gofakeit"
 	"github.com/google/uuid"
+	"github.com/kevinburke/twilio-go"
 	"github.com/savannahghi/feedlib"
 	"github.com/savannahghi/interserviceclient"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
 	extensionMock "github.com/savannahghi/mycarehub/pkg/mycarehub/application/extension/mock"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/utils"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	pgMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/mock"
 	smsMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/sms/mock"
@@ -86,7 +93,7 @@ func TestUseCaseOTPImpl_GenerateAndSendOTP(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 
-			o := otp.NewOTPUseCase(fakeDB, fakeDB, fakeExtension, fakeSMS, fakeTwilio)
+			o := otp.NewOTPUseCase(fakeDB, fakeDB, fakeDB, fakeExtension, fakeSMS, fakeTwilio)
 
 			if tt.name == "Sad case - failed to get user profile" {
 				fakeDB.MockGetUserProfileByUsernameFn = func(ctx context.Context, username string) (*domain.User, error) {
@@ -183,6 +190,15 @@ func TestUseCaseOTPImpl_VerifyPhoneNumber(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Sad Case - rate limited",
+			args: args{
+				ctx:      ctx,
+				username: gofakeit.Word(),
+				flavour:  feedlib.FlavourPro,
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -192,7 +208,7 @@ func TestUseCaseOTPImpl_VerifyPhoneNumber(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 
-			o := otp.NewOTPUseCase(fakeDB, fakeDB, fakeExtension, fakeSMS, fakeTwilio)
+			o := otp.NewOTPUseCase(fakeDB, fakeDB, fakeDB, fakeExtension, fakeSMS, fakeTwilio)
 
 			if tt.name == "Sad case - failed to get user profile" {
 				fakeDB.MockGetUserProfileByUsernameFn = func(ctx context.Context, username string) (*domain.User, error) {
@@ -210,6 +226,12 @@ func TestUseCaseOTPImpl_VerifyPhoneNumber(t *testing.T) {
 				fakeSMS.MockSendSMSFn = func(ctx context.Context, message string, recipients []string) (*silcomms.BulkSMSResponse, error) {
 					return nil, fmt.Errorf("an error occurred")
 				}
+				fakeTwilio.MockSendTrackedSMSViaTwilioFn = func(ctx context.Context, phonenumber, message string) (*twilio.Message, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+				fakeTwilio.MockSendWhatsAppViaTwilioFn = func(ctx context.Context, phonenumber, message string) (*twilio.Message, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
 			}
 
 			if tt.name == "Sad Case - fail to get contact by user id" {
@@ -218,6 +240,12 @@ func TestUseCaseOTPImpl_VerifyPhoneNumber(t *testing.T) {
 				}
 			}
 
+			if tt.name == "Sad Case - rate limited" {
+				fakeDB.MockConsumeRateLimitFn = func(ctx context.Context, action string, rules []*domain.RateLimitRule, at time.Time) (*domain.RateLimitDecision, error) {
+					return &domain.RateLimitDecision{Allowed: false, Rule: rules[0], RetryAfter: at.Add(time.Minute)}, nil
+				}
+			}
+
 			_, err := o.VerifyPhoneNumber(tt.args.ctx, tt.args.username, tt.args.flavour)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("UseCaseOTPImpl.VerifyPhoneNumber() error = %v, wantErr %v", err, tt.wantErr)
@@ -303,7 +331,7 @@ func TestMyCareHubDb_VerifyOTP_Unittest(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 
-			o := otp.NewOTPUseCase(fakeDB, fakeDB, fakeExtension, fakeSMS, fakeTwilio)
+			o := otp.NewOTPUseCase(fakeDB, fakeDB, fakeDB, fakeExtension, fakeSMS, fakeTwilio)
 
 			if tt.name == "Sad case - failed to get user profile" {
 				fakeDB.MockGetUserProfileByUsernameFn = func(ctx context.Context, username string) (*domain.User, error) {
@@ -391,6 +419,14 @@ func TestUseCaseOTPImpl_GenerateRetryOTP(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Sad Case - rate limited",
+			args: args{
+				ctx:     ctx,
+				payload: validPayload,
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -400,7 +436,7 @@ func TestUseCaseOTPImpl_GenerateRetryOTP(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 
-			o := otp.NewOTPUseCase(fakeDB, fakeDB, fakeExtension, fakeSMS, fakeTwilio)
+			o := otp.NewOTPUseCase(fakeDB, fakeDB, fakeDB, fakeExtension, fakeSMS, fakeTwilio)
 
 			if tt.name == "Sad case - unable to get phone" {
 				fakeDB.MockGetContactByUserIDFn = func(ctx context.Context, userID *string, contactType string) (*domain.Contact, error) {
@@ -421,6 +457,18 @@ func TestUseCaseOTPImpl_GenerateRetryOTP(t *testing.T) {
 				fakeSMS.MockSendSMSFn = func(ctx context.Context, message string, recipients []string) (*silcomms.BulkSMSResponse, error) {
 					return nil, fmt.Errorf("an error occurred")
 				}
+				fakeTwilio.MockSendTrackedSMSViaTwilioFn = func(ctx context.Context, phonenumber, message string) (*twilio.Message, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+				fakeTwilio.MockSendWhatsAppViaTwilioFn = func(ctx context.Context, phonenumber, message string) (*twilio.Message, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			if tt.name == "Sad Case - rate limited" {
+				fakeDB.MockConsumeRateLimitFn = func(ctx context.Context, action string, rules []*domain.RateLimitRule, at time.Time) (*domain.RateLimitDecision, error) {
+					return &domain.RateLimitDecision{Allowed: false, Rule: rules[0], RetryAfter: at.Add(time.Minute)}, nil
+				}
 			}
 
 			_, err := o.GenerateRetryOTP(tt.args.ctx, tt.args.payload)
@@ -441,10 +489,11 @@ func TestUseCaseOTPImpl_SendOTP(t *testing.T) {
 		message     string
 	}
 	tests := []struct {
-		name    string
-		args    args
-		want    string
-		wantErr bool
+		name          string
+		args          args
+		want          string
+		wantProviders []enums.OTPDeliveryProvider
+		wantErr       bool
 	}{
 		{
 			name: "Happy Case - Successfully send an otp to kenyan number",
@@ -454,8 +503,33 @@ func TestUseCaseOTPImpl_SendOTP(t *testing.T) {
 				code:        "111222",
 				message:     gofakeit.HipsterSentence(5),
 			},
-			want:    "111222",
-			wantErr: false,
+			want:          "111222",
+			wantProviders: []enums.OTPDeliveryProvider{enums.OTPDeliveryProviderSILComms},
+			wantErr:       false,
+		},
+		{
+			name: "Happy Case - Fall back to twilio when SILComms fails",
+			args: args{
+				ctx:         ctx,
+				phoneNumber: interserviceclient.TestUserPhoneNumber,
+				code:        "111222",
+				message:     gofakeit.HipsterSentence(5),
+			},
+			want:          "111222",
+			wantProviders: []enums.OTPDeliveryProvider{enums.OTPDeliveryProviderSILComms, enums.OTPDeliveryProviderTwilio},
+			wantErr:       false,
+		},
+		{
+			name: "Happy Case - Fall back to whatsapp when SMS providers fail",
+			args: args{
+				ctx:         ctx,
+				phoneNumber: interserviceclient.TestUserPhoneNumber,
+				code:        "111222",
+				message:     gofakeit.HipsterSentence(5),
+			},
+			want:          "111222",
+			wantProviders: []enums.OTPDeliveryProvider{enums.OTPDeliveryProviderSILComms, enums.OTPDeliveryProviderTwilio, enums.OTPDeliveryProviderWhatsApp},
+			wantErr:       false,
 		},
 		{
 			name: "Sad Case - Fail to send an otp to kenyan number",
@@ -465,7 +539,8 @@ func TestUseCaseOTPImpl_SendOTP(t *testing.T) {
 				code:        "111222",
 				message:     gofakeit.HipsterSentence(5),
 			},
-			wantErr: true,
+			wantProviders: []enums.OTPDeliveryProvider{enums.OTPDeliveryProviderSILComms, enums.OTPDeliveryProviderTwilio, enums.OTPDeliveryProviderWhatsApp},
+			wantErr:       true,
 		},
 		{
 			name: "Happy Case - Successfully send an otp to foreign number",
@@ -475,8 +550,9 @@ func TestUseCaseOTPImpl_SendOTP(t *testing.T) {
 				code:        "111222",
 				message:     gofakeit.HipsterSentence(5),
 			},
-			want:    "111222",
-			wantErr: false,
+			want:          "111222",
+			wantProviders: []enums.OTPDeliveryProvider{enums.OTPDeliveryProviderTwilio},
+			wantErr:       false,
 		},
 		{
 			name: "Sad Case - Fail to send an otp to foreign number",
@@ -486,7 +562,8 @@ func TestUseCaseOTPImpl_SendOTP(t *testing.T) {
 				code:        "111222",
 				message:     gofakeit.HipsterSentence(5),
 			},
-			wantErr: true,
+			wantProviders: []enums.OTPDeliveryProvider{enums.OTPDeliveryProviderTwilio},
+			wantErr:       true,
 		},
 	}
 	for _, tt := range tests {
@@ -497,17 +574,34 @@ func TestUseCaseOTPImpl_SendOTP(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 
-			o := otp.NewOTPUseCase(fakeDB, fakeDB, fakeExtension, fakeSMS, fakeTwilio)
+			o := otp.NewOTPUseCase(fakeDB, fakeDB, fakeDB, fakeExtension, fakeSMS, fakeTwilio)
 
-			if tt.name == "Sad Case - Fail to send an otp to kenyan number" {
-				fakeSMS.MockSendSMSFn = func(ctx context.Context, message string, recipients []string) (*silcomms.BulkSMSResponse, error) {
-					return nil, fmt.Errorf("an error occurred")
+			gotProviders := []enums.OTPDeliveryProvider{}
+			fakeSMS.MockSendSMSFn = func(ctx context.Context, message string, recipients []string) (*silcomms.BulkSMSResponse, error) {
+				gotProviders = append(gotProviders, enums.OTPDeliveryProviderSILComms)
+				if tt.name == "Happy Case - Successfully send an otp to kenyan number" {
+					return &silcomms.BulkSMSResponse{GUID: uuid.New().String()}, nil
 				}
+				return nil, fmt.Errorf("an error occurred")
+			}
+			fakeTwilio.MockSendTrackedSMSViaTwilioFn = func(ctx context.Context, phonenumber, message string) (*twilio.Message, error) {
+				gotProviders = append(gotProviders, enums.OTPDeliveryProviderTwilio)
+				switch tt.name {
+				case "Happy Case - Fall back to twilio when SILComms fails", "Happy Case - Successfully send an otp to foreign number":
+					return &twilio.Message{Sid: uuid.New().String()}, nil
+				}
+				return nil, fmt.Errorf("an error occurred")
+			}
+			fakeTwilio.MockSendWhatsAppViaTwilioFn = func(ctx context.Context, phonenumber, message string) (*twilio.Message, error) {
+				gotProviders = append(gotProviders, enums.OTPDeliveryProviderWhatsApp)
+				if tt.name == "Happy Case - Fall back to whatsapp when SMS providers fail" {
+					return &twilio.Message{Sid: uuid.New().String()}, nil
+				}
+				return nil, fmt.Errorf("an error occurred")
 			}
-
 			if tt.name == "Sad Case - Fail to send an otp to foreign number" {
-				fakeTwilio.MockSendSMSViaTwilioFn = func(ctx context.Context, phonenumber, message string) error {
-					return fmt.Errorf("failed to send sms")
+				fakeTwilio.MockWhatsAppEnabledFn = func() bool {
+					return false
 				}
 			}
 
@@ -519,6 +613,406 @@ func TestUseCaseOTPImpl_SendOTP(t *testing.T) {
 			if got != tt.want {
 				t.Errorf("UseCaseOTPImpl.SendOTP() = %v, want %v", got, tt.want)
 			}
+			if !reflect.DeepEqual(gotProviders, tt.wantProviders) {
+				t.Errorf("UseCaseOTPImpl.SendOTP() tried providers %v, want %v", gotProviders, tt.wantProviders)
+			}
+		})
+	}
+}
+
+func TestUseCaseOTPImpl_GenerateAndSendOTP_RateLimit(t *testing.T) {
+	ctxWithIP := context.WithValue(context.Background(), utils.ClientIPContextKey, "10.0.0.1")
+
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantKeyTypes []enums.RateLimitKeyType
+		wantErrCode  exceptions.ErrorCode
+		wantMetric   bool
+		wantErr      bool
+		rateLimits   map[enums.RateLimitKeyType]otp.RateLimitConfig
+	}{
+		{
+			name: "Happy case: request is limited by the phone, username and IP address",
+			args: args{
+				ctx: ctxWithIP,
+			},
+			wantKeyTypes: []enums.RateLimitKeyType{enums.RateLimitKeyPhone, enums.RateLimitKeyUsername, enums.RateLimitKeyIPAddress},
+		},
+		{
+			name: "Happy case: IP address is skipped when it is not in the context",
+			args: args{
+				ctx: context.Background(),
+			},
+			wantKeyTypes: []enums.RateLimitKeyType{enums.RateLimitKeyPhone, enums.RateLimitKeyUsername},
+		},
+		{
+			name: "Happy case: rate limiting is skipped when no limits are configured",
+			args: args{
+				ctx: ctxWithIP,
+			},
+			rateLimits: map[enums.RateLimitKeyType]otp.RateLimitConfig{},
+		},
+		{
+			name: "Sad case: request within the cooldown",
+			args: args{
+				ctx: ctxWithIP,
+			},
+			wantKeyTypes: []enums.RateLimitKeyType{enums.RateLimitKeyPhone, enums.RateLimitKeyUsername, enums.RateLimitKeyIPAddress},
+			wantErrCode:  exceptions.OTPCooldownError,
+			wantErr:      true,
+		},
+		{
+			name: "Sad case: request exceeds the limit",
+			args: args{
+				ctx: ctxWithIP,
+			},
+			wantKeyTypes: []enums.RateLimitKeyType{enums.RateLimitKeyPhone, enums.RateLimitKeyUsername, enums.RateLimitKeyIPAddress},
+			wantErrCode:  exceptions.OTPRateLimitedError,
+			wantErr:      true,
+		},
+		{
+			name: "Sad case: repeated rejections are reported",
+			args: args{
+				ctx: ctxWithIP,
+			},
+			wantKeyTypes: []enums.RateLimitKeyType{enums.RateLimitKeyPhone, enums.RateLimitKeyUsername, enums.RateLimitKeyIPAddress},
+			wantErrCode:  exceptions.OTPRateLimitedError,
+			wantMetric:   true,
+			wantErr:      true,
+		},
+		{
+			name: "Sad case: failed to check the rate limit",
+			args: args{
+				ctx: ctxWithIP,
+			},
+			wantKeyTypes: []enums.RateLimitKeyType{enums.RateLimitKeyPhone, enums.RateLimitKeyUsername, enums.RateLimitKeyIPAddress},
+			wantErrCode:  exceptions.Internal,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeSMS := smsMock.NewSMSServiceMock()
+			fakeTwilio := twilioMock.NewTwilioServiceMock()
+
+			o := otp.NewOTPUseCase(fakeDB, fakeDB, fakeDB, fakeExtension, fakeSMS, fakeTwilio)
+			o.AbuseThreshold = 3
+			if tt.rateLimits != nil {
+				o.RateLimits = tt.rateLimits
+			}
+
+			gotKeyTypes := []enums.RateLimitKeyType{}
+			fakeDB.MockConsumeRateLimitFn = func(ctx context.Context, action string, rules []*domain.RateLimitRule, at time.Time) (*domain.RateLimitDecision, error) {
+				for _, rule := range rules {
+					gotKeyTypes = append(gotKeyTypes, rule.KeyType)
+				}
+
+				switch tt.name {
+				case "Sad case: request within the cooldown":
+					return &domain.RateLimitDecision{Rule: rules[0], CooledDown: true, RetryAfter: at.Add(30 * time.Second), Rejections: 1}, nil
+				case "Sad case: request exceeds the limit":
+					return &domain.RateLimitDecision{Rule: rules[1], RetryAfter: at.Add(time.Hour), Rejections: 1}, nil
+				case "Sad case: repeated rejections are reported":
+					return &domain.RateLimitDecision{Rule: rules[2], RetryAfter: at.Add(time.Hour), Rejections: 6}, nil
+				case "Sad case: failed to check the rate limit":
+					return nil, fmt.Errorf("an error occurred")
+				}
+				return &domain.RateLimitDecision{Allowed: true}, nil
+			}
+
+			gotMetric := false
+			fakeDB.MockCreateMetricFn = func(ctx context.Context, payload *domain.Metric) error {
+				gotMetric = true
+				if payload.Type != enums.MetricTypeSystem {
+					t.Errorf("expected a system metric, got %v", payload.Type)
+				}
+				return nil
+			}
+
+			_, err := o.GenerateAndSendOTP(tt.args.ctx, gofakeit.Word(), feedlib.FlavourConsumer)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCaseOTPImpl.GenerateAndSendOTP() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				customErr := &exceptions.CustomError{}
+				if !errors.As(err, &customErr) || customErr.Code != int(tt.wantErrCode) {
+					t.Errorf("UseCaseOTPImpl.GenerateAndSendOTP() error = %v, want code %v", err, tt.wantErrCode)
+				}
+			}
+
+			if len(gotKeyTypes) != len(tt.wantKeyTypes) {
+				t.Errorf("expected rate limit keys %v, got %v", tt.wantKeyTypes, gotKeyTypes)
+			}
+
+			if gotMetric != tt.wantMetric {
+				t.Errorf("expected abuse metric to be recorded: %v, got %v", tt.wantMetric, gotMetric)
+			}
+		})
+	}
+}
+
+func TestUseCaseOTPImpl_GenerateAndSendOTP_DeliveryAttempts(t *testing.T) {
+	fakeDB := pgMock.NewPostgresMock()
+	fakeExtension := extensionMock.NewFakeExtension()
+	fakeSMS := smsMock.NewSMSServiceMock()
+	fakeTwilio := twilioMock.NewTwilioServiceMock()
+
+	o := otp.NewOTPUseCase(fakeDB, fakeDB, fakeDB, fakeExtension, fakeSMS, fakeTwilio)
+
+	fakeDB.MockGetContactByUserIDFn = func(ctx context.Context, userID *string, contactType string) (*domain.Contact, error) {
+		return &domain.Contact{ContactValue: interserviceclient.TestUserPhoneNumber}, nil
+	}
+	fakeSMS.MockSendSMSFn = func(ctx context.Context, message string, recipients []string) (*silcomms.BulkSMSResponse, error) {
+		return nil, fmt.Errorf("an error occurred")
+	}
+	fakeTwilio.MockSendTrackedSMSViaTwilioFn = func(ctx context.Context, phonenumber, message string) (*twilio.Message, error) {
+		return &twilio.Message{Sid: "SM123"}, nil
+	}
+
+	var savedOTP *domain.OTP
+	fakeDB.MockSaveOTPFn = func(ctx context.Context, otpInput *domain.OTP) error {
+		savedOTP = otpInput
+		return nil
+	}
+
+	_, err := o.GenerateAndSendOTP(context.Background(), gofakeit.Word(), feedlib.FlavourConsumer)
+	if err != nil {
+		t.Errorf("UseCaseOTPImpl.GenerateAndSendOTP() error = %v", err)
+		return
+	}
+
+	if savedOTP == nil || len(savedOTP.DeliveryAttempts) != 2 {
+		t.Errorf("expected the OTP to be saved with two delivery attempts, got %v", savedOTP)
+		return
+	}
+
+	failed, sent := savedOTP.DeliveryAttempts[0], savedOTP.DeliveryAttempts[1]
+	if failed.Provider != enums.OTPDeliveryProviderSILComms || failed.Status != enums.OTPDeliveryStatusFailed || failed.Error == "" {
+		t.Errorf("unexpected failed delivery attempt %+v", failed)
+	}
+	if sent.Provider != enums.OTPDeliveryProviderTwilio || sent.Status != enums.OTPDeliveryStatusSent || sent.ProviderMessageID != "SM123" {
+		t.Errorf("unexpected sent delivery attempt %+v", sent)
+	}
+	if savedOTP.Channel != "SMS" {
+		t.Errorf("expected the OTP channel to be SMS, got %v", savedOTP.Channel)
+	}
+}
+
+func TestUseCaseOTPImpl_RecordOTPDeliveryReceipt(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		input *dto.OTPDeliveryReceiptInput
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Happy case: record delivery receipt",
+			args: args{
+				ctx: context.Background(),
+				input: &dto.OTPDeliveryReceiptInput{
+					Provider:          enums.OTPDeliveryProviderSILComms,
+					ProviderMessageID: uuid.New().String(),
+					Status:            enums.OTPDeliveryStatusDelivered,
+				},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Sad case: invalid input",
+			args: args{
+				ctx: context.Background(),
+				input: &dto.OTPDeliveryReceiptInput{
+					Provider: enums.OTPDeliveryProviderSILComms,
+					Status:   enums.OTPDeliveryStatusDelivered,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: unable to update delivery status",
+			args: args{
+				ctx: context.Background(),
+				input: &dto.OTPDeliveryReceiptInput{
+					Provider:          enums.OTPDeliveryProviderSILComms,
+					ProviderMessageID: uuid.New().String(),
+					Status:            enums.OTPDeliveryStatusDelivered,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeSMS := smsMock.NewSMSServiceMock()
+			fakeTwilio := twilioMock.NewTwilioServiceMock()
+
+			o := otp.NewOTPUseCase(fakeDB, fakeDB, fakeDB, fakeExtension, fakeSMS, fakeTwilio)
+
+			if tt.name == "Sad case: unable to update delivery status" {
+				fakeDB.MockUpdateOTPDeliveryStatusFn = func(ctx context.Context, provider enums.OTPDeliveryProvider, providerMessageID string, status enums.OTPDeliveryStatus, at time.Time) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := o.RecordOTPDeliveryReceipt(tt.args.ctx, tt.args.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCaseOTPImpl.RecordOTPDeliveryReceipt() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UseCaseOTPImpl.RecordOTPDeliveryReceipt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUseCaseOTPImpl_SendPhoneChangeOTP(t *testing.T) {
+	ctx := context.Background()
+	newPhone := interserviceclient.TestUserPhoneNumber
+
+	type args struct {
+		ctx         context.Context
+		username    string
+		phoneNumber string
+		flavour     feedlib.Flavour
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: send phone change otp",
+			args: args{
+				ctx:         ctx,
+				username:    gofakeit.Username(),
+				phoneNumber: newPhone,
+				flavour:     feedlib.FlavourConsumer,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: invalid flavour",
+			args: args{
+				ctx:         ctx,
+				username:    gofakeit.Username(),
+				phoneNumber: newPhone,
+				flavour:     "invalid",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: failed to get user profile",
+			args: args{
+				ctx:         ctx,
+				username:    gofakeit.Username(),
+				phoneNumber: newPhone,
+				flavour:     feedlib.FlavourConsumer,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: rate limited",
+			args: args{
+				ctx:         ctx,
+				username:    gofakeit.Username(),
+				phoneNumber: newPhone,
+				flavour:     feedlib.FlavourConsumer,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: failed to send otp",
+			args: args{
+				ctx:         ctx,
+				username:    gofakeit.Username(),
+				phoneNumber: newPhone,
+				flavour:     feedlib.FlavourPro,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: failed to save otp",
+			args: args{
+				ctx:         ctx,
+				username:    gofakeit.Username(),
+				phoneNumber: newPhone,
+				flavour:     feedlib.FlavourPro,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeSMS := smsMock.NewSMSServiceMock()
+			fakeTwilio := twilioMock.NewTwilioServiceMock()
+
+			o := otp.NewOTPUseCase(fakeDB, fakeDB, fakeDB, fakeExtension, fakeSMS, fakeTwilio)
+
+			var savedOTP *domain.OTP
+			fakeDB.MockSaveOTPFn = func(ctx context.Context, otpInput *domain.OTP) error {
+				savedOTP = otpInput
+				return nil
+			}
+
+			if tt.name == "Sad case: failed to get user profile" {
+				fakeDB.MockGetUserProfileByUsernameFn = func(ctx context.Context, username string) (*domain.User, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: rate limited" {
+				fakeDB.MockConsumeRateLimitFn = func(ctx context.Context, action string, rules []*domain.RateLimitRule, at time.Time) (*domain.RateLimitDecision, error) {
+					return &domain.RateLimitDecision{Allowed: false, Rule: rules[0], RetryAfter: at.Add(time.Minute)}, nil
+				}
+			}
+			if tt.name == "Sad case: failed to send otp" {
+				fakeSMS.MockSendSMSFn = func(ctx context.Context, message string, recipients []string) (*silcomms.BulkSMSResponse, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+				fakeTwilio.MockSendTrackedSMSViaTwilioFn = func(ctx context.Context, phonenumber, message string) (*twilio.Message, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+				fakeTwilio.MockSendWhatsAppViaTwilioFn = func(ctx context.Context, phonenumber, message string) (*twilio.Message, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: failed to save otp" {
+				fakeDB.MockSaveOTPFn = func(ctx context.Context, otpInput *domain.OTP) error {
+					return fmt.Errorf("failed to save otp")
+				}
+			}
+
+			got, err := o.SendPhoneChangeOTP(tt.args.ctx, tt.args.username, tt.args.phoneNumber, tt.args.flavour)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCaseOTPImpl.SendPhoneChangeOTP() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.PhoneNumber != tt.args.phoneNumber {
+				t.Errorf("expected the otp to be sent to %v, got %v", tt.args.phoneNumber, got.PhoneNumber)
+			}
+			if savedOTP == nil || savedOTP.PhoneNumber != tt.args.phoneNumber {
+				t.Errorf("expected the otp to be saved against the new phone number")
+			}
 		})
 	}
 }
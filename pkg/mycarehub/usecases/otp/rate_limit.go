@@ -0,0 +1,143 @@
+package otp
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/utils"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+)
+
+const (
+	// otpRateLimitAction identifies the OTP requests in the rate limit events.
+	// All the endpoints that send an OTP share it so that the limits cannot be bypassed by alternating between them
+	otpRateLimitAction = "OTP"
+
+	// otpAbuseMetricName is the name of the metric recorded when a key keeps making OTP requests after being rate limited
+	otpAbuseMetricName = "OTP_RATE_LIMIT_ABUSE"
+)
+
+// RateLimitConfig is the sliding window limit applied to the OTP requests made with the same key
+type RateLimitConfig struct {
+	// Limit is the number of OTPs that can be sent within the window. A limit of zero disables the window
+	Limit int
+	// Window is the duration over which the sent OTPs are counted
+	Window time.Duration
+	// Cooldown is the minimum duration between two OTPs. A cooldown of zero disables it
+	Cooldown time.Duration
+}
+
+var (
+	// otpRateLimits are the limits applied to each key of an OTP request.
+	// They can be overridden by setting e.g `OTP_RATE_LIMIT_PHONE_LIMIT`, `OTP_RATE_LIMIT_PHONE_WINDOW` and `OTP_RATE_LIMIT_PHONE_COOLDOWN`
+	otpRateLimits = map[enums.RateLimitKeyType]RateLimitConfig{
+		enums.RateLimitKeyPhone: rateLimitConfigFromEnv("OTP_RATE_LIMIT_PHONE", RateLimitConfig{
+			Limit:    5,
+			Window:   time.Hour,
+			Cooldown: 30 * time.Second,
+		}),
+		enums.RateLimitKeyUsername: rateLimitConfigFromEnv("OTP_RATE_LIMIT_USERNAME", RateLimitConfig{
+			Limit:    5,
+			Window:   time.Hour,
+			Cooldown: 30 * time.Second,
+		}),
+		enums.RateLimitKeyIPAddress: rateLimitConfigFromEnv("OTP_RATE_LIMIT_IP", RateLimitConfig{
+			Limit:  20,
+			Window: time.Hour,
+		}),
+	}
+
+	// otpAbuseThreshold is the number of rejected requests within the window after which the key is reported as abusive
+	otpAbuseThreshold = utils.IntFromEnv("OTP_RATE_LIMIT_ABUSE_THRESHOLD", 10)
+)
+
+// rateLimitConfigFromEnv overrides the default limit with the values set in the environment
+func rateLimitConfigFromEnv(prefix string, defaults RateLimitConfig) RateLimitConfig {
+	return RateLimitConfig{
+		Limit:    utils.IntFromEnv(prefix+"_LIMIT", defaults.Limit),
+		Window:   utils.DurationFromEnv(prefix+"_WINDOW", defaults.Window),
+		Cooldown: utils.DurationFromEnv(prefix+"_COOLDOWN", defaults.Cooldown),
+	}
+}
+
+// checkRateLimit records an OTP request made for the user's phone number, their username and the client's IP address.
+// The request is rejected when any of the keys has exceeded its limit or is within its cooldown
+func (o *UseCaseOTPImpl) checkRateLimit(ctx context.Context, userProfile *domain.User, phoneNumber string) error {
+	keys := map[enums.RateLimitKeyType]string{
+		enums.RateLimitKeyPhone:    phoneNumber,
+		enums.RateLimitKeyUsername: userProfile.Username,
+	}
+
+	// the client IP is only available for requests made through the HTTP server
+	clientIP, err := utils.GetValueFromContext(ctx, utils.ClientIPContextKey)
+	if err == nil {
+		keys[enums.RateLimitKeyIPAddress] = clientIP
+	}
+
+	rules := []*domain.RateLimitRule{}
+	for _, keyType := range []enums.RateLimitKeyType{enums.RateLimitKeyPhone, enums.RateLimitKeyUsername, enums.RateLimitKeyIPAddress} {
+		config, ok := o.RateLimits[keyType]
+		if !ok || keys[keyType] == "" {
+			continue
+		}
+
+		rules = append(rules, &domain.RateLimitRule{
+			KeyType:  keyType,
+			Key:      keys[keyType],
+			Limit:    config.Limit,
+			Window:   config.Window,
+			Cooldown: config.Cooldown,
+		})
+	}
+
+	if len(rules) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	decision, err := o.Create.ConsumeRateLimit(ctx, otpRateLimitAction, rules, now)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return exceptions.InternalErr(fmt.Errorf("failed to check OTP rate limit: %w", err))
+	}
+
+	if decision.Allowed {
+		return nil
+	}
+
+	if o.AbuseThreshold > 0 && decision.Rejections > 0 && decision.Rejections%o.AbuseThreshold == 0 {
+		o.reportAbuse(ctx, userProfile, decision, now)
+	}
+
+	err = fmt.Errorf("OTP request rejected by the %s rate limit", decision.Rule.KeyType)
+	retryAfter := decision.RetryAfter.Sub(now)
+	if decision.CooledDown {
+		return exceptions.OTPCooldownErr(err, retryAfter)
+	}
+
+	return exceptions.OTPRateLimitedErr(err, retryAfter)
+}
+
+// reportAbuse records a system metric for a key that keeps requesting OTPs after being rate limited
+func (o *UseCaseOTPImpl) reportAbuse(ctx context.Context, userProfile *domain.User, decision *domain.RateLimitDecision, now time.Time) {
+	metric := &domain.Metric{
+		UserID: userProfile.ID,
+		Type:   enums.MetricTypeSystem,
+		Event: map[string]interface{}{
+			"name":       otpAbuseMetricName,
+			"keyType":    decision.Rule.KeyType.String(),
+			"key":        decision.Rule.Key,
+			"rejections": decision.Rejections,
+			"window":     decision.Rule.Window.String(),
+		},
+		Timestamp: now,
+	}
+
+	if err := o.Create.CreateMetric(ctx, metric); err != nil {
+		helpers.ReportErrorToSentry(err)
+	}
+}
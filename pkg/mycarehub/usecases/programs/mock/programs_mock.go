@@ -108,7 +108,7 @@ func NewProgramsUseCaseMock() *ProgramsUseCaseMock {
 
 	permission := domain.AuthorityPermission{
 		PermissionID: UUID,
-		Name:         enums.PermissionTypeCanCreateGroup,
+		Name:         enums.PermissionTypeCanCreateGroup.String(),
 		Active:       false,
 	}
 	user := domain.User{
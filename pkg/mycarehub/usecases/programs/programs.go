@@ -11,6 +11,7 @@ import (
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/extension"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/utils"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/authorization"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/matrix"
@@ -118,6 +119,12 @@ func (u *UsecaseProgramsImpl) CreateProgram(ctx context.Context, input *dto.Prog
 		return nil, fmt.Errorf("failed to add facilities to program:%w", err)
 	}
 
+	err = u.Create.SeedAuthorityRoles(ctx, authorization.DefaultRoles())
+	if err != nil {
+		helpers.ReportErrorToSentry(fmt.Errorf("failed to seed program default roles:%w", err))
+		return nil, fmt.Errorf("failed to seed program default roles:%w", err)
+	}
+
 	cmsProgramPayload := &dto.CreateCMSProgramPayload{
 		ProgramID:      program.ID,
 		Name:           program.Name,
@@ -144,6 +144,19 @@ func TestUsecaseProgramsImpl_CreateProgram(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Sad case: failed to seed default roles",
+			args: args{
+				ctx: ctx,
+				input: &dto.ProgramInput{
+					Name:           gofakeit.BeerHop(),
+					Description:    gofakeit.BeerStyle(),
+					OrganisationID: uuid.NewString(),
+					Facilities:     []string{uuid.NewString()},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "Sad case: failed to create screening tools",
 			args: args{
@@ -216,6 +229,11 @@ func TestUsecaseProgramsImpl_CreateProgram(t *testing.T) {
 					return nil, fmt.Errorf("an error occurred")
 				}
 			}
+			if tt.name == "Sad case: failed to seed default roles" {
+				fakeDB.MockSeedAuthorityRolesFn = func(ctx context.Context, roles []domain.AuthorityRole) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
 
 			if tt.name == "Sad case: failed to create screening tools" {
 				fakeDB.MockCreateScreeningToolFn = func(ctx context.Context, input *domain.ScreeningTool) error {
@@ -13,6 +13,7 @@ import (
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/utils"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/common"
 )
 
 // ICreateScreeningTools contains methods related to the screening tools
@@ -422,6 +423,11 @@ func (q *UseCaseQuestionnaireImpl) GetScreeningToolResponse(ctx context.Context,
 		helpers.ReportErrorToSentry(err)
 		return nil, fmt.Errorf("failed to get screening tool response: %w", err)
 	}
+
+	if err := common.CheckCaregiverAbility(ctx, q.Query, response.ClientID, enums.CaregiverAbilityViewScreeningResults); err != nil {
+		return nil, err
+	}
+
 	return response, nil
 }
 
@@ -5,9 +5,11 @@ import (
 	"errors"
 	"testing"
 
+	"firebase.google.com/go/auth"
 	"github.com/brianvoe/gofakeit"
 	"github.com/google/uuid"
 	"github.com/savannahghi/enumutils"
+	"github.com/savannahghi/firebasetools"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	extensionMock "github.com/savannahghi/mycarehub/pkg/mycarehub/application/extension/mock"
@@ -1034,6 +1036,7 @@ func TestUseCaseQuestionnaireImpl_GetScreeningToolRespondents(t *testing.T) {
 
 func TestUseCaseQuestionnaireImpl_GetScreeningToolResponse(t *testing.T) {
 
+	ctx := context.WithValue(context.Background(), firebasetools.AuthTokenContextKey, &auth.Token{UID: uuid.New().String()})
 	UUID := uuid.New().String()
 	type args struct {
 		ctx context.Context
@@ -1048,19 +1051,35 @@ func TestUseCaseQuestionnaireImpl_GetScreeningToolResponse(t *testing.T) {
 		{
 			name: "Happy case: get screening tool response",
 			args: args{
-				ctx: context.Background(),
+				ctx: ctx,
 				id:  UUID,
 			},
 			wantErr: false,
 		},
 		{
 			name: "Sad case: unable to get screening tool response",
+			args: args{
+				ctx: ctx,
+				id:  UUID,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: failed to get logged in user",
 			args: args{
 				ctx: context.Background(),
 				id:  UUID,
 			},
 			wantErr: true,
 		},
+		{
+			name: "Sad case: caregiver not delegated to view screening results",
+			args: args{
+				ctx: ctx,
+				id:  UUID,
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1072,6 +1091,16 @@ func TestUseCaseQuestionnaireImpl_GetScreeningToolResponse(t *testing.T) {
 					return nil, errors.New("failed to get screening tool response")
 				}
 			}
+			if tt.name == "Sad case: caregiver not delegated to view screening results" {
+				fakeDB.MockGetCaregiversClientFn = func(ctx context.Context, caregiverClient domain.CaregiverClient) ([]*domain.CaregiverClient, error) {
+					return []*domain.CaregiverClient{
+						{
+							ClientConsent: enums.ConsentStateAccepted,
+							Abilities:     []enums.CaregiverAbility{enums.CaregiverAbilityViewAppointments},
+						},
+					}, nil
+				}
+			}
 			got, err := q.GetScreeningToolResponse(tt.args.ctx, tt.args.id)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("UseCaseQuestionnaireImpl.GetScreeningToolResponse() error = %v, wantErr %v", err, tt.wantErr)
@@ -0,0 +1,173 @@
+package securityquestions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/savannahghi/feedlib"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/common"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/notification"
+)
+
+// lockoutPINResetReason is recorded on the PIN reset service requests that are created when a user is locked out
+// so that the staff know why the user did not provide their CCC number
+const lockoutPINResetReason = "security questions answering attempts exceeded"
+
+// securityQuestionMaxAttempts returns the number of wrong security question answers that the user's organisation allows.
+// Users who do not belong to an organisation get the default PIN policy's attempts
+func (s *UseCaseSecurityQuestionsImpl) securityQuestionMaxAttempts(ctx context.Context, organisationID string) (int, error) {
+	if organisationID == "" {
+		return domain.DefaultPINPolicy.SecurityQuestionMaxAttempts, nil
+	}
+
+	organisation, err := s.Query.GetOrganisation(ctx, organisationID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return 0, exceptions.InternalErr(fmt.Errorf("failed to get organisation PIN policy: %w", err))
+	}
+
+	if organisation.PINPolicy.SecurityQuestionMaxAttempts <= 0 {
+		return domain.DefaultPINPolicy.SecurityQuestionMaxAttempts, nil
+	}
+
+	return organisation.PINPolicy.SecurityQuestionMaxAttempts, nil
+}
+
+// requestPINReset creates a PIN reset service request for a user who has been locked out of resetting their PIN using their security questions
+func (s *UseCaseSecurityQuestionsImpl) requestPINReset(ctx context.Context, userProfile *domain.User, flavour feedlib.Flavour) {
+	switch flavour {
+	case feedlib.FlavourConsumer:
+		clientProfile, err := s.Query.GetClientProfile(ctx, *userProfile.ID, userProfile.CurrentProgramID)
+		if err != nil {
+			helpers.ReportErrorToSentry(fmt.Errorf("failed to get client profile of locked out user %s: %w", *userProfile.ID, err))
+			return
+		}
+
+		// the client may have already asked for their PIN to be reset using their CCC number
+		pendingRequests, err := s.Query.GetClientServiceRequests(
+			ctx, enums.ServiceRequestTypePinReset.String(), enums.ServiceRequestStatusPending.String(), *clientProfile.ID, *clientProfile.DefaultFacility.ID,
+		)
+		if err != nil {
+			helpers.ReportErrorToSentry(fmt.Errorf("failed to get pending PIN reset service requests of client %s: %w", *clientProfile.ID, err))
+			return
+		}
+
+		if len(pendingRequests) > 0 {
+			return
+		}
+
+		_, err = s.ServiceRequest.CreateServiceRequest(ctx, &dto.ServiceRequestInput{
+			Active:      true,
+			RequestType: enums.ServiceRequestTypePinReset.String(),
+			Request:     "Change PIN Request",
+			ClientID:    *clientProfile.ID,
+			Flavour:     feedlib.FlavourConsumer,
+			Meta: map[string]interface{}{
+				"reason": lockoutPINResetReason,
+			},
+		})
+		if err != nil {
+			helpers.ReportErrorToSentry(fmt.Errorf("failed to create PIN reset service request for client %s: %w", *clientProfile.ID, err))
+		}
+
+	case feedlib.FlavourPro:
+		staffProfile, err := s.Query.GetStaffProfile(ctx, *userProfile.ID, userProfile.CurrentProgramID)
+		if err != nil {
+			helpers.ReportErrorToSentry(fmt.Errorf("failed to get staff profile of locked out user %s: %w", *userProfile.ID, err))
+			return
+		}
+
+		_, err = s.ServiceRequest.CreateServiceRequest(ctx, &dto.ServiceRequestInput{
+			Active:      true,
+			RequestType: enums.ServiceRequestTypeStaffPinReset.String(),
+			Request:     "Change PIN Request",
+			StaffID:     *staffProfile.ID,
+			Flavour:     feedlib.FlavourPro,
+		})
+		if err != nil {
+			helpers.ReportErrorToSentry(fmt.Errorf("failed to create PIN reset service request for staff %s: %w", *staffProfile.ID, err))
+		}
+	}
+}
+
+// ResetSecurityQuestionResponses is used by a staff to clear a user's security question responses e.g when a client
+// has forgotten their answers. The user is asked to set new security questions the next time they log in and is notified
+// of the reset. Only organisation admins can reset the security questions of other staff.
+func (s *UseCaseSecurityQuestionsImpl) ResetSecurityQuestionResponses(ctx context.Context, userID string) (bool, error) {
+	loggedInUserID, err := s.ExternalExt.GetLoggedInUserUID(ctx)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.GetLoggedInUserUIDErr(err)
+	}
+
+	loggedInUser, err := s.Query.GetUserProfileByUserID(ctx, loggedInUserID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.UserNotFoundError(err)
+	}
+
+	loggedInStaff, err := s.Query.GetStaffProfile(ctx, loggedInUserID, loggedInUser.CurrentProgramID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.StaffProfileNotFoundErr(err)
+	}
+
+	userProfile, err := s.Query.GetUserProfileByUserID(ctx, userID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.UserNotFoundError(err)
+	}
+
+	if userProfile.CurrentOrganizationID != loggedInUser.CurrentOrganizationID {
+		err := fmt.Errorf("user %s does not belong to the organisation of staff %s", userID, loggedInUserID)
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.UserNotAuthorizedErr(err)
+	}
+
+	isStaff, err := s.Query.CheckStaffExists(ctx, userID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.InternalErr(err)
+	}
+
+	if isStaff && !loggedInStaff.IsOrganisationAdmin {
+		err := fmt.Errorf("staff %s is not an organisation admin and cannot reset the security questions of staff %s", loggedInUserID, userID)
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.UserNotAuthorizedErr(err)
+	}
+
+	if err := s.Update.ResetSecurityQuestionResponses(ctx, userID); err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.InternalErr(fmt.Errorf("failed to reset security question responses: %w", err))
+	}
+
+	resetNotification := notification.ComposeClientNotification(enums.NotificationTypeSecurityQuestionsReset, notification.ClientNotificationInput{})
+	resetNotification.UserID = userProfile.ID
+	resetNotification.ProgramID = userProfile.CurrentProgramID
+	resetNotification.OrganisationID = userProfile.CurrentOrganizationID
+	if isStaff {
+		resetNotification.Flavour = feedlib.FlavourPro
+	}
+
+	if err := s.Notification.NotifyUser(ctx, userProfile, resetNotification); err != nil {
+		helpers.ReportErrorToSentry(err)
+	}
+
+	auditLog := &domain.AuditLog{
+		RecordType:     enums.AuditLogSecurityQuestionsReset,
+		Notes:          "security question responses cleared",
+		ActorID:        loggedInUserID,
+		TargetID:       userID,
+		TargetType:     enums.AuditLogTargetUser,
+		ProgramID:      loggedInUser.CurrentProgramID,
+		OrganisationID: loggedInUser.CurrentOrganizationID,
+	}
+	common.RecordAuditLog(ctx, s.Create, auditLog)
+
+	return true, nil
+}
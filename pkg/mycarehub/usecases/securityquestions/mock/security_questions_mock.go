@@ -19,6 +19,7 @@ type SecurityQuestionsUseCaseMock struct {
 	MockGetUserRespondedSecurityQuestionsFn func(ctx context.Context, input dto.GetUserRespondedSecurityQuestionsInput) ([]*domain.SecurityQuestion, error)
 	MockRecordSecurityQuestionResponsesFn   func(ctx context.Context, input []*dto.SecurityQuestionResponseInput) ([]*domain.RecordSecurityQuestionResponse, error)
 	MockCreateSecurityQuestionsFn           func(ctx context.Context, securityQuestions []*domain.SecurityQuestion) ([]*domain.SecurityQuestion, error)
+	MockResetSecurityQuestionResponsesFn    func(ctx context.Context, userID string) (bool, error)
 }
 
 // NewSecurityQuestionsUseCaseMock creates and itializes security question mocks
@@ -96,6 +97,9 @@ func NewSecurityQuestionsUseCaseMock() *SecurityQuestionsUseCaseMock {
 				},
 			}, nil
 		},
+		MockResetSecurityQuestionResponsesFn: func(ctx context.Context, userID string) (bool, error) {
+			return true, nil
+		},
 	}
 }
 
@@ -133,3 +137,8 @@ func (sq *SecurityQuestionsUseCaseMock) RecordSecurityQuestionResponses(ctx cont
 func (sq *SecurityQuestionsUseCaseMock) CreateSecurityQuestions(ctx context.Context, securityQuestions []*domain.SecurityQuestion) ([]*domain.SecurityQuestion, error) {
 	return sq.MockCreateSecurityQuestionsFn(ctx, securityQuestions)
 }
+
+// ResetSecurityQuestionResponses mocks the implementation of clearing a user's security question responses
+func (sq *SecurityQuestionsUseCaseMock) ResetSecurityQuestionResponses(ctx context.Context, userID string) (bool, error) {
+	return sq.MockResetSecurityQuestionResponsesFn(ctx, userID)
+}
@@ -12,8 +12,10 @@ import (
 	extensionMock "github.com/savannahghi/mycarehub/pkg/mycarehub/application/extension/mock"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	pgMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/mock"
+	notificationMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/notification/mock"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/securityquestions"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/securityquestions/mock"
+	serviceRequestMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/servicerequest/mock"
 	"github.com/segmentio/ksuid"
 )
 
@@ -68,7 +70,9 @@ func TestUseCaseSecurityQuestionsImpl_GetSecurityQuestions(t *testing.T) {
 			_ = mock.NewSecurityQuestionsUseCaseMock()
 
 			fakeExtension := extensionMock.NewFakeExtension()
-			s := securityquestions.NewSecurityQuestionsUsecase(fakeDB, fakeDB, fakeDB, fakeExtension)
+			fakeServiceRequest := serviceRequestMock.NewServiceRequestUseCaseMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			s := securityquestions.NewSecurityQuestionsUsecase(fakeDB, fakeDB, fakeDB, fakeExtension, fakeServiceRequest, fakeNotification)
 
 			if tt.name == "Sad case" {
 				fakeDB.MockGetSecurityQuestionsFn = func(ctx context.Context, flavour feedlib.Flavour) ([]*domain.SecurityQuestion, error) {
@@ -216,7 +220,9 @@ func TestUseCaseSecurityQuestionsImpl_RecordSecurityQuestionResponses(t *testing
 			_ = mock.NewSecurityQuestionsUseCaseMock()
 
 			fakeExtension := extensionMock.NewFakeExtension()
-			s := securityquestions.NewSecurityQuestionsUsecase(fakeDB, fakeDB, fakeDB, fakeExtension)
+			fakeServiceRequest := serviceRequestMock.NewServiceRequestUseCaseMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			s := securityquestions.NewSecurityQuestionsUsecase(fakeDB, fakeDB, fakeDB, fakeExtension, fakeServiceRequest, fakeNotification)
 
 			if tt.name == "Sad case: failed to get security question by id" {
 				fakeDB.MockGetSecurityQuestionByIDFn = func(ctx context.Context, securityQuestionID *string) (*domain.SecurityQuestion, error) {
@@ -374,6 +380,168 @@ func TestUseCaseSecurityQuestionsImpl_VerifySecurityQuestionResponses(t *testing
 			want:    false,
 			wantErr: true,
 		},
+		{
+			name: "Sad Case - client locked out after the maximum attempts",
+			args: args{
+				ctx: ctx,
+				responses: &dto.VerifySecurityQuestionsPayload{
+					SecurityQuestionsInput: []*dto.VerifySecurityQuestionInput{
+						{
+							QuestionID: "1234",
+							Flavour:    feedlib.FlavourConsumer,
+							Response:   "Nakuru",
+							Username:   gofakeit.Word(),
+						},
+					},
+				},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad Case - staff locked out after the maximum attempts",
+			args: args{
+				ctx: ctx,
+				responses: &dto.VerifySecurityQuestionsPayload{
+					SecurityQuestionsInput: []*dto.VerifySecurityQuestionInput{
+						{
+							QuestionID: "1234",
+							Flavour:    feedlib.FlavourPro,
+							Response:   "Nakuru",
+							Username:   gofakeit.Word(),
+						},
+					},
+				},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad Case - client with a pending pin reset request locked out",
+			args: args{
+				ctx: ctx,
+				responses: &dto.VerifySecurityQuestionsPayload{
+					SecurityQuestionsInput: []*dto.VerifySecurityQuestionInput{
+						{
+							QuestionID: "1234",
+							Flavour:    feedlib.FlavourConsumer,
+							Response:   "Nakuru",
+							Username:   gofakeit.Word(),
+						},
+					},
+				},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad Case - locked out and fail to create pin reset service request",
+			args: args{
+				ctx: ctx,
+				responses: &dto.VerifySecurityQuestionsPayload{
+					SecurityQuestionsInput: []*dto.VerifySecurityQuestionInput{
+						{
+							QuestionID: "1234",
+							Flavour:    feedlib.FlavourConsumer,
+							Response:   "Nakuru",
+							Username:   gofakeit.Word(),
+						},
+					},
+				},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad Case - locked out and fail to get client profile",
+			args: args{
+				ctx: ctx,
+				responses: &dto.VerifySecurityQuestionsPayload{
+					SecurityQuestionsInput: []*dto.VerifySecurityQuestionInput{
+						{
+							QuestionID: "1234",
+							Flavour:    feedlib.FlavourConsumer,
+							Response:   "Nakuru",
+							Username:   gofakeit.Word(),
+						},
+					},
+				},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad Case - locked out and fail to get staff profile",
+			args: args{
+				ctx: ctx,
+				responses: &dto.VerifySecurityQuestionsPayload{
+					SecurityQuestionsInput: []*dto.VerifySecurityQuestionInput{
+						{
+							QuestionID: "1234",
+							Flavour:    feedlib.FlavourPro,
+							Response:   "Nakuru",
+							Username:   gofakeit.Word(),
+						},
+					},
+				},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad Case - locked out and fail to get pending pin reset requests",
+			args: args{
+				ctx: ctx,
+				responses: &dto.VerifySecurityQuestionsPayload{
+					SecurityQuestionsInput: []*dto.VerifySecurityQuestionInput{
+						{
+							QuestionID: "1234",
+							Flavour:    feedlib.FlavourConsumer,
+							Response:   "Nakuru",
+							Username:   gofakeit.Word(),
+						},
+					},
+				},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad Case - user already locked out",
+			args: args{
+				ctx: ctx,
+				responses: &dto.VerifySecurityQuestionsPayload{
+					SecurityQuestionsInput: []*dto.VerifySecurityQuestionInput{
+						{
+							QuestionID: "1234",
+							Flavour:    feedlib.FlavourConsumer,
+							Response:   "Nakuru",
+							Username:   gofakeit.Word(),
+						},
+					},
+				},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad Case - fail to get organisation",
+			args: args{
+				ctx: ctx,
+				responses: &dto.VerifySecurityQuestionsPayload{
+					SecurityQuestionsInput: []*dto.VerifySecurityQuestionInput{
+						{
+							QuestionID: "1234",
+							Flavour:    feedlib.FlavourConsumer,
+							Response:   "Nakuru",
+							Username:   gofakeit.Word(),
+						},
+					},
+				},
+			},
+			want:    false,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -381,7 +549,9 @@ func TestUseCaseSecurityQuestionsImpl_VerifySecurityQuestionResponses(t *testing
 			fakeSecurity := mock.NewSecurityQuestionsUseCaseMock()
 
 			fakeExtension := extensionMock.NewFakeExtension()
-			s := securityquestions.NewSecurityQuestionsUsecase(fakeDB, fakeDB, fakeDB, fakeExtension)
+			fakeServiceRequest := serviceRequestMock.NewServiceRequestUseCaseMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			s := securityquestions.NewSecurityQuestionsUsecase(fakeDB, fakeDB, fakeDB, fakeExtension, fakeServiceRequest, fakeNotification)
 
 			if tt.name == "Sad Case - Fail to get security question by ID" {
 				fakeDB.MockGetSecurityQuestionResponseFn = func(ctx context.Context, questionID string, userID string) (*domain.SecurityQuestionResponse, error) {
@@ -412,11 +582,92 @@ func TestUseCaseSecurityQuestionsImpl_VerifySecurityQuestionResponses(t *testing
 				}
 			}
 
+			organisationID := gofakeit.UUID()
+			failedSecurityCount := 0
+			switch tt.name {
+			case "Sad Case - client locked out after the maximum attempts",
+				"Sad Case - staff locked out after the maximum attempts",
+				"Sad Case - client with a pending pin reset request locked out",
+				"Sad Case - locked out and fail to create pin reset service request",
+				"Sad Case - locked out and fail to get client profile",
+				"Sad Case - locked out and fail to get staff profile",
+				"Sad Case - locked out and fail to get pending pin reset requests":
+				failedSecurityCount = 4
+			case "Sad Case - user already locked out":
+				failedSecurityCount = 5
+			}
+			if failedSecurityCount > 0 || tt.name == "Sad Case - fail to get organisation" {
+				fakeDB.MockGetUserProfileByUsernameFn = func(ctx context.Context, username string) (*domain.User, error) {
+					userID := gofakeit.UUID()
+					return &domain.User{
+						ID:                    &userID,
+						Username:              username,
+						CurrentProgramID:      gofakeit.UUID(),
+						CurrentOrganizationID: organisationID,
+						FailedSecurityCount:   failedSecurityCount,
+					}, nil
+				}
+				fakeDB.MockGetOrganisationFn = func(ctx context.Context, id string) (*domain.Organisation, error) {
+					policy := domain.DefaultPINPolicy
+					policy.SecurityQuestionMaxAttempts = 5
+					return &domain.Organisation{ID: id, PINPolicy: policy}, nil
+				}
+			}
+
+			pinResetRequested := false
+			fakeServiceRequest.MockCreateServiceRequestFn = func(ctx context.Context, input *dto.ServiceRequestInput) (bool, error) {
+				pinResetRequested = true
+				return true, nil
+			}
+
+			if tt.name == "Sad Case - client locked out after the maximum attempts" {
+				fakeDB.MockGetClientServiceRequestsFn = func(ctx context.Context, requestType, status, clientID, facilityID string) ([]*domain.ServiceRequest, error) {
+					return []*domain.ServiceRequest{}, nil
+				}
+			}
+			if tt.name == "Sad Case - client with a pending pin reset request locked out" {
+				fakeDB.MockGetClientServiceRequestsFn = func(ctx context.Context, requestType, status, clientID, facilityID string) ([]*domain.ServiceRequest, error) {
+					return []*domain.ServiceRequest{{ID: gofakeit.UUID(), RequestType: requestType, Status: status}}, nil
+				}
+			}
+			if tt.name == "Sad Case - locked out and fail to create pin reset service request" {
+				fakeServiceRequest.MockCreateServiceRequestFn = func(ctx context.Context, input *dto.ServiceRequestInput) (bool, error) {
+					return false, fmt.Errorf("failed to create service request")
+				}
+			}
+			if tt.name == "Sad Case - locked out and fail to get client profile" {
+				fakeDB.MockGetClientProfileFn = func(ctx context.Context, userID string, programID string) (*domain.ClientProfile, error) {
+					return nil, fmt.Errorf("failed to get client profile")
+				}
+			}
+			if tt.name == "Sad Case - locked out and fail to get staff profile" {
+				fakeDB.MockGetStaffProfileFn = func(ctx context.Context, userID string, programID string) (*domain.StaffProfile, error) {
+					return nil, fmt.Errorf("failed to get staff profile")
+				}
+			}
+			if tt.name == "Sad Case - locked out and fail to get pending pin reset requests" {
+				fakeDB.MockGetClientServiceRequestsFn = func(ctx context.Context, requestType, status, clientID, facilityID string) ([]*domain.ServiceRequest, error) {
+					return nil, fmt.Errorf("failed to get service requests")
+				}
+			}
+			if tt.name == "Sad Case - fail to get organisation" {
+				fakeDB.MockGetOrganisationFn = func(ctx context.Context, id string) (*domain.Organisation, error) {
+					return nil, fmt.Errorf("failed to get organisation")
+				}
+			}
+
 			got, err := s.VerifySecurityQuestionResponses(tt.args.ctx, tt.args.responses)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("UseCaseSecurityQuestionsImpl.VerifySecurityQuestionResponses() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+
+			wantPINResetRequest := tt.name == "Sad Case - client locked out after the maximum attempts" ||
+				tt.name == "Sad Case - staff locked out after the maximum attempts"
+			if pinResetRequested != wantPINResetRequest {
+				t.Errorf("UseCaseSecurityQuestionsImpl.VerifySecurityQuestionResponses() pin reset requested = %v, want %v", pinResetRequested, wantPINResetRequest)
+				return
+			}
 			if got != tt.want {
 				t.Errorf("UseCaseSecurityQuestionsImpl.VerifySecurityQuestionResponses() = %v, want %v", got, tt.want)
 			}
@@ -565,7 +816,9 @@ func TestUseCaseSecurityQuestionsImpl_GetUserRespondedSecurityQuestions(t *testi
 			fakeDB := pgMock.NewPostgresMock()
 
 			fakeExtension := extensionMock.NewFakeExtension()
-			s := securityquestions.NewSecurityQuestionsUsecase(fakeDB, fakeDB, fakeDB, fakeExtension)
+			fakeServiceRequest := serviceRequestMock.NewServiceRequestUseCaseMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			s := securityquestions.NewSecurityQuestionsUsecase(fakeDB, fakeDB, fakeDB, fakeExtension, fakeServiceRequest, fakeNotification)
 
 			fakeSecurityQuestions := mock.NewSecurityQuestionsUseCaseMock()
 
@@ -680,7 +933,9 @@ func TestUseCaseSecurityQuestionsImpl_CreateSecurityQuestions(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			fakeDB := pgMock.NewPostgresMock()
 			fakeExtension := extensionMock.NewFakeExtension()
-			s := securityquestions.NewSecurityQuestionsUsecase(fakeDB, fakeDB, fakeDB, fakeExtension)
+			fakeServiceRequest := serviceRequestMock.NewServiceRequestUseCaseMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			s := securityquestions.NewSecurityQuestionsUsecase(fakeDB, fakeDB, fakeDB, fakeExtension, fakeServiceRequest, fakeNotification)
 
 			if tt.name == "Sad Case: failed to create screening tool" {
 				fakeDB.MockCreateSecurityQuestionsFn = func(ctx context.Context, securityQuestions []*domain.SecurityQuestion) ([]*domain.SecurityQuestion, error) {
@@ -696,3 +951,191 @@ func TestUseCaseSecurityQuestionsImpl_CreateSecurityQuestions(t *testing.T) {
 		})
 	}
 }
+
+func TestUseCaseSecurityQuestionsImpl_ResetSecurityQuestionResponses(t *testing.T) {
+	staffUserID := ksuid.New().String()
+	userID := ksuid.New().String()
+	organisationID := ksuid.New().String()
+
+	tests := []struct {
+		name    string
+		want    bool
+		wantErr bool
+	}{
+		{
+			name:    "Happy case: reset the security questions of a client",
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name:    "Happy case: organisation admin resets the security questions of a staff",
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name:    "Happy case: unable to notify the user",
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name:    "Happy case: unable to record the audit log",
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name:    "Sad case: unable to get logged in user",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to get logged in user profile",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to get staff profile",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to get user profile",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: user belongs to a different organisation",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to check if user is a staff",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: staff who is not an organisation admin resets the security questions of a staff",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to reset security question responses",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeServiceRequest := serviceRequestMock.NewServiceRequestUseCaseMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+
+			sq := securityquestions.NewSecurityQuestionsUsecase(fakeDB, fakeDB, fakeDB, fakeExtension, fakeServiceRequest, fakeNotification)
+
+			fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+				return staffUserID, nil
+			}
+			fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, id string) (*domain.User, error) {
+				return &domain.User{ID: &id, CurrentOrganizationID: organisationID}, nil
+			}
+			fakeDB.MockGetStaffProfileFn = func(ctx context.Context, userID string, programID string) (*domain.StaffProfile, error) {
+				return &domain.StaffProfile{UserID: userID, IsOrganisationAdmin: false}, nil
+			}
+			fakeDB.MockCheckStaffExistsFn = func(ctx context.Context, id string) (bool, error) {
+				return id == staffUserID, nil
+			}
+
+			var notified *domain.Notification
+			fakeNotification.MockNotifyUserFn = func(ctx context.Context, userProfile *domain.User, notificationPayload *domain.Notification) error {
+				notified = notificationPayload
+				return nil
+			}
+
+			var auditLog *domain.AuditLog
+			fakeDB.MockCreateAuditLogFn = func(ctx context.Context, log *domain.AuditLog) error {
+				auditLog = log
+				return nil
+			}
+
+			if tt.name == "Happy case: organisation admin resets the security questions of a staff" {
+				fakeDB.MockGetStaffProfileFn = func(ctx context.Context, userID string, programID string) (*domain.StaffProfile, error) {
+					return &domain.StaffProfile{UserID: userID, IsOrganisationAdmin: true}, nil
+				}
+				fakeDB.MockCheckStaffExistsFn = func(ctx context.Context, id string) (bool, error) {
+					return true, nil
+				}
+			}
+			if tt.name == "Happy case: unable to notify the user" {
+				fakeNotification.MockNotifyUserFn = func(ctx context.Context, userProfile *domain.User, notificationPayload *domain.Notification) error {
+					notified = notificationPayload
+					return fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Happy case: unable to record the audit log" {
+				fakeDB.MockCreateAuditLogFn = func(ctx context.Context, log *domain.AuditLog) error {
+					auditLog = log
+					return fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get logged in user" {
+				fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+					return "", fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get logged in user profile" {
+				fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, id string) (*domain.User, error) {
+					if id == staffUserID {
+						return nil, fmt.Errorf("an error occurred")
+					}
+					return &domain.User{ID: &id, CurrentOrganizationID: organisationID}, nil
+				}
+			}
+			if tt.name == "Sad case: unable to get staff profile" {
+				fakeDB.MockGetStaffProfileFn = func(ctx context.Context, userID string, programID string) (*domain.StaffProfile, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get user profile" {
+				fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, id string) (*domain.User, error) {
+					if id == userID {
+						return nil, fmt.Errorf("an error occurred")
+					}
+					return &domain.User{ID: &id, CurrentOrganizationID: organisationID}, nil
+				}
+			}
+			if tt.name == "Sad case: user belongs to a different organisation" {
+				fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, id string) (*domain.User, error) {
+					if id == userID {
+						return &domain.User{ID: &id, CurrentOrganizationID: ksuid.New().String()}, nil
+					}
+					return &domain.User{ID: &id, CurrentOrganizationID: organisationID}, nil
+				}
+			}
+			if tt.name == "Sad case: unable to check if user is a staff" {
+				fakeDB.MockCheckStaffExistsFn = func(ctx context.Context, id string) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: staff who is not an organisation admin resets the security questions of a staff" {
+				fakeDB.MockCheckStaffExistsFn = func(ctx context.Context, id string) (bool, error) {
+					return true, nil
+				}
+			}
+			if tt.name == "Sad case: unable to reset security question responses" {
+				fakeDB.MockResetSecurityQuestionResponsesFn = func(ctx context.Context, userID string) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := sq.ResetSecurityQuestionResponses(context.Background(), userID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCaseSecurityQuestionsImpl.ResetSecurityQuestionResponses() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UseCaseSecurityQuestionsImpl.ResetSecurityQuestionResponses() = %v, want %v", got, tt.want)
+			}
+
+			if !tt.wantErr {
+				if notified == nil || notified.Type != enums.NotificationTypeSecurityQuestionsReset {
+					t.Errorf("expected the user to be notified of the reset, got %v", notified)
+				}
+				if auditLog == nil || auditLog.RecordType != enums.AuditLogSecurityQuestionsReset || auditLog.TargetID != userID {
+					t.Errorf("expected the reset to be recorded in the audit log, got %v", auditLog)
+				}
+			}
+		})
+	}
+}
@@ -14,6 +14,8 @@ import (
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/utils"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/notification"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/servicerequest"
 	"github.com/savannahghi/serverutils"
 )
 
@@ -54,6 +56,11 @@ type IVerifySecurityQuestionResponses interface {
 	) (bool, error)
 }
 
+// IResetSecurityQuestionResponses clears a user's security question responses
+type IResetSecurityQuestionResponses interface {
+	ResetSecurityQuestionResponses(ctx context.Context, userID string) (bool, error)
+}
+
 // UseCaseSecurityQuestion groups all the security questions method interfaces
 type UseCaseSecurityQuestion interface {
 	IGetSecurityQuestions
@@ -61,14 +68,17 @@ type UseCaseSecurityQuestion interface {
 	IVerifySecurityQuestionResponses
 	IGetUserRespondedSecurityQuestions
 	ICreateSecurityQuestions
+	IResetSecurityQuestionResponses
 }
 
 // UseCaseSecurityQuestionsImpl represents security question implementation object
 type UseCaseSecurityQuestionsImpl struct {
-	Query       infrastructure.Query
-	Create      infrastructure.Create
-	Update      infrastructure.Update
-	ExternalExt extension.ExternalMethodsExtension
+	Query          infrastructure.Query
+	Create         infrastructure.Create
+	Update         infrastructure.Update
+	ExternalExt    extension.ExternalMethodsExtension
+	ServiceRequest servicerequest.UseCaseServiceRequest
+	Notification   notification.UseCaseNotification
 }
 
 // NewSecurityQuestionsUsecase returns a new security question instance
@@ -77,12 +87,16 @@ func NewSecurityQuestionsUsecase(
 	create infrastructure.Create,
 	update infrastructure.Update,
 	externalExt extension.ExternalMethodsExtension,
+	serviceRequest servicerequest.UseCaseServiceRequest,
+	notification notification.UseCaseNotification,
 ) *UseCaseSecurityQuestionsImpl {
 	return &UseCaseSecurityQuestionsImpl{
-		Query:       query,
-		Create:      create,
-		Update:      update,
-		ExternalExt: externalExt,
+		Query:          query,
+		Create:         create,
+		Update:         update,
+		ExternalExt:    externalExt,
+		ServiceRequest: serviceRequest,
+		Notification:   notification,
 	}
 }
 
@@ -147,6 +161,8 @@ func (s *UseCaseSecurityQuestionsImpl) RecordSecurityQuestionResponses(ctx conte
 }
 
 // VerifySecurityQuestionResponses verifies the security questions against the recorded responses.
+// Once the user's organisation's maximum number of wrong answers is reached, the user is locked out and a PIN reset
+// service request is created so that a staff verifies their identity in person
 func (s *UseCaseSecurityQuestionsImpl) VerifySecurityQuestionResponses(
 	ctx context.Context,
 	responses *dto.VerifySecurityQuestionsPayload,
@@ -161,9 +177,14 @@ func (s *UseCaseSecurityQuestionsImpl) VerifySecurityQuestionResponses(
 		return false, exceptions.ProfileNotFoundErr(err)
 	}
 
+	maxAttempts, err := s.securityQuestionMaxAttempts(ctx, userProfile.CurrentOrganizationID)
+	if err != nil {
+		return false, err
+	}
+
 	failCount := userProfile.FailedSecurityCount
-	if failCount >= 3 {
-		err := fmt.Errorf("failed: security questions answering attempts exceeded 3 attempts")
+	if failCount >= maxAttempts {
+		err := fmt.Errorf("failed: security questions answering attempts exceeded %d attempts", maxAttempts)
 		helpers.ReportErrorToSentry(err)
 		return false, exceptions.FailedSecurityCountExceededErr(err)
 	}
@@ -197,12 +218,20 @@ func (s *UseCaseSecurityQuestionsImpl) VerifySecurityQuestionResponses(
 				return false, exceptions.InternalErr(fmt.Errorf("failed to update security question response fail count %v", err))
 			}
 
+			if failCount >= maxAttempts {
+				s.requestPINReset(ctx, userProfile, securityQuestionResponse.Flavour)
+
+				err := fmt.Errorf("failed: security questions answering attempts exceeded %d attempts", maxAttempts)
+				helpers.ReportErrorToSentry(err)
+				return false, exceptions.FailedSecurityCountExceededErr(err)
+			}
+
 			helpers.ReportErrorToSentry(err)
-			return false, exceptions.SecurityQuestionResponseMismatchErr(fmt.Errorf("the security question response does not match: %d attempts left", 3-failCount))
+			return false, exceptions.SecurityQuestionResponseMismatchErr(fmt.Errorf("the security question response does not match: %d attempts left", maxAttempts-failCount))
 		}
 	}
 
-	if failCountInstance[responses.SecurityQuestionsInput[0].Username] <= 3 {
+	if failCountInstance[responses.SecurityQuestionsInput[0].Username] <= maxAttempts {
 		err := s.Update.UpdateFailedSecurityQuestionsAnsweringAttempts(ctx, *userProfile.ID, 0)
 		if err != nil {
 			helpers.ReportErrorToSentry(err)
@@ -0,0 +1,424 @@
+package servicerequest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/common"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/notification"
+)
+
+// SetServiceRequestSLA sets how long a type of service request may stay pending in the logged in staff's program.
+// A suicide-risk red flag can not be given more than the patient-safety window to be resolved
+func (u *UseCasesServiceRequestImpl) SetServiceRequestSLA(ctx context.Context, input *dto.ServiceRequestSLAInput) (*domain.ServiceRequestSLA, error) {
+	if err := input.Validate(); err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InputValidationErr(fmt.Errorf("failed to validate service request sla input: %w", err))
+	}
+
+	if input.RequestType == enums.ServiceRequestTypeRedFlag && input.ResolutionHours > domain.SuicideRiskResolutionHours {
+		return nil, exceptions.InputValidationErr(fmt.Errorf("a red flag must be resolved within %d hours", domain.SuicideRiskResolutionHours))
+	}
+
+	loggedInUserID, err := u.ExternalExt.GetLoggedInUserUID(ctx)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.GetLoggedInUserUIDErr(err)
+	}
+
+	userProfile, err := u.Query.GetUserProfileByUserID(ctx, loggedInUserID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.ProfileNotFoundErr(err)
+	}
+
+	slas, err := u.Query.ListServiceRequestSLAs(ctx, &userProfile.CurrentProgramID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InternalErr(fmt.Errorf("failed to list service request slas: %w", err))
+	}
+
+	previous := domain.DefaultServiceRequestSLAs[input.RequestType]
+	for _, sla := range slas {
+		if sla.RequestType == input.RequestType {
+			previous = sla
+		}
+	}
+
+	sla, err := u.Create.SetServiceRequestSLA(ctx, &domain.ServiceRequestSLA{
+		ProgramID:       userProfile.CurrentProgramID,
+		OrganisationID:  userProfile.CurrentOrganizationID,
+		RequestType:     input.RequestType,
+		ResolutionHours: input.ResolutionHours,
+		EscalationHours: input.EscalationHours,
+	})
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InternalErr(fmt.Errorf("failed to set service request sla: %w", err))
+	}
+
+	auditLog := &domain.AuditLog{
+		RecordType:     enums.AuditLogServiceRequestSLAChange,
+		Notes:          fmt.Sprintf("%s service request sla changed", sla.RequestType),
+		ActorID:        loggedInUserID,
+		TargetID:       sla.ProgramID,
+		TargetType:     enums.AuditLogTargetProgram,
+		ProgramID:      sla.ProgramID,
+		OrganisationID: sla.OrganisationID,
+		After: map[string]interface{}{
+			"request_type":     sla.RequestType,
+			"resolution_hours": sla.ResolutionHours,
+			"escalation_hours": sla.EscalationHours,
+		},
+	}
+	if previous != nil {
+		auditLog.Before = map[string]interface{}{
+			"request_type":     previous.RequestType,
+			"resolution_hours": previous.ResolutionHours,
+			"escalation_hours": previous.EscalationHours,
+		}
+	}
+	common.RecordAuditLog(ctx, u.Create, auditLog)
+
+	return sla, nil
+}
+
+// ListServiceRequestSLAs returns the SLAs that apply to the service requests of the logged in staff's program.
+// The red flags the program has not set an SLA for are returned with the default SLA, which has no ID
+func (u *UseCasesServiceRequestImpl) ListServiceRequestSLAs(ctx context.Context) ([]*domain.ServiceRequestSLA, error) {
+	loggedInUserID, err := u.ExternalExt.GetLoggedInUserUID(ctx)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.GetLoggedInUserUIDErr(err)
+	}
+
+	userProfile, err := u.Query.GetUserProfileByUserID(ctx, loggedInUserID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.ProfileNotFoundErr(err)
+	}
+
+	slas, err := u.Query.ListServiceRequestSLAs(ctx, &userProfile.CurrentProgramID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InternalErr(fmt.Errorf("failed to list service request slas: %w", err))
+	}
+
+	configured := map[enums.ServiceRequestType]bool{}
+	for _, sla := range slas {
+		configured[sla.RequestType] = true
+	}
+
+	for _, requestType := range defaultSLARequestTypes() {
+		if configured[requestType] {
+			continue
+		}
+
+		sla := *domain.DefaultServiceRequestSLAs[requestType]
+		sla.ProgramID = userProfile.CurrentProgramID
+		sla.OrganisationID = userProfile.CurrentOrganizationID
+		slas = append(slas, &sla)
+	}
+
+	return slas, nil
+}
+
+// ListServiceRequestEscalations returns the escalation history of a client's service request, the oldest first
+func (u *UseCasesServiceRequestImpl) ListServiceRequestEscalations(ctx context.Context, serviceRequestID string) ([]*domain.ServiceRequestEscalation, error) {
+	loggedInUserID, err := u.ExternalExt.GetLoggedInUserUID(ctx)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.GetLoggedInUserUIDErr(err)
+	}
+
+	userProfile, err := u.Query.GetUserProfileByUserID(ctx, loggedInUserID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.ProfileNotFoundErr(err)
+	}
+
+	serviceRequest, err := u.Query.GetClientServiceRequestByID(ctx, serviceRequestID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InternalErr(fmt.Errorf("failed to get service request %s: %w", serviceRequestID, err))
+	}
+
+	if serviceRequest.ProgramID != userProfile.CurrentProgramID {
+		err := fmt.Errorf("service request %s does not belong to the program of user %s", serviceRequestID, loggedInUserID)
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.UserNotAuthorizedErr(err)
+	}
+
+	escalations, err := u.Query.ListServiceRequestEscalations(ctx, serviceRequestID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InternalErr(fmt.Errorf("failed to list service request escalations: %w", err))
+	}
+
+	return escalations, nil
+}
+
+// EscalateServiceRequests escalates the pending service requests that have breached the SLA of their program.
+// It is meant to be run on a schedule and returns the number of escalations made.
+//
+// A request that breaches its SLA is escalated to the staff of its facility. If it is still pending once the SLA's escalation
+// period has passed, it is escalated to the admins of its facility by SMS and email, or to the organisation admins when none of the
+// facility admins can be reached. A suicide-risk red flag that breaches its SLA is a patient-safety incident and is escalated to the
+// facility staff and the admins at once. Requests created before SLA tracking started in their program are never escalated
+func (u *UseCasesServiceRequestImpl) EscalateServiceRequests(ctx context.Context) (int, error) {
+	slas, err := u.Query.ListServiceRequestSLAs(ctx, nil)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return 0, fmt.Errorf("failed to list service request slas: %w", err)
+	}
+
+	programSLAs := map[string]*domain.ServiceRequestSLA{}
+	requestTypes := map[enums.ServiceRequestType]bool{}
+	for _, requestType := range defaultSLARequestTypes() {
+		requestTypes[requestType] = true
+	}
+	for _, sla := range slas {
+		programSLAs[sla.ProgramID+sla.RequestType.String()] = sla
+		requestTypes[sla.RequestType] = true
+	}
+
+	types := []string{}
+	for requestType := range requestTypes {
+		types = append(types, requestType.String())
+	}
+	sort.Strings(types)
+
+	serviceRequests, err := u.Query.GetServiceRequestsDueForEscalation(ctx, types)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return 0, fmt.Errorf("failed to get service requests due for escalation: %w", err)
+	}
+
+	escalated := 0
+	now := time.Now()
+	for _, serviceRequest := range serviceRequests {
+		sla, ok := programSLAs[serviceRequest.ProgramID+serviceRequest.RequestType]
+		if !ok {
+			sla, ok = domain.DefaultServiceRequestSLAs[enums.ServiceRequestType(serviceRequest.RequestType)]
+			if !ok {
+				continue
+			}
+		}
+
+		for escalation := nextServiceRequestEscalation(serviceRequest, sla, now); escalation != nil; escalation = nextServiceRequestEscalation(serviceRequest, sla, now) {
+			if err := u.escalateServiceRequest(ctx, serviceRequest, escalation); err != nil {
+				helpers.ReportErrorToSentry(fmt.Errorf("failed to escalate service request %s: %w", serviceRequest.ID, err))
+				break
+			}
+
+			serviceRequest.EscalationLevel = &escalation.Level
+			if serviceRequest.SLABreachedAt == nil {
+				serviceRequest.SLABreachedAt = &escalation.EscalatedAt
+			}
+			escalated++
+		}
+	}
+
+	return escalated, nil
+}
+
+// nextServiceRequestEscalation returns the escalation a pending service request is due for, if any
+func nextServiceRequestEscalation(serviceRequest *domain.ServiceRequest, sla *domain.ServiceRequestSLA, now time.Time) *domain.ServiceRequestEscalation {
+	resolutionHours := sla.ResolutionHours
+	if serviceRequest.IsSuicideRiskRedFlag() && resolutionHours > domain.SuicideRiskResolutionHours {
+		resolutionHours = domain.SuicideRiskResolutionHours
+	}
+
+	if now.Before(serviceRequest.CreatedAt.Add(time.Duration(resolutionHours) * time.Hour)) {
+		return nil
+	}
+
+	escalation := &domain.ServiceRequestEscalation{
+		ServiceRequestID:      serviceRequest.ID,
+		PatientSafetyIncident: serviceRequest.IsSuicideRiskRedFlag(),
+		EscalatedAt:           now,
+		ProgramID:             serviceRequest.ProgramID,
+		OrganisationID:        serviceRequest.OrganisationID,
+	}
+
+	switch {
+	case serviceRequest.EscalationLevel == nil:
+		escalation.Level = enums.ServiceRequestEscalationLevelFacilityStaff
+
+	case *serviceRequest.EscalationLevel == enums.ServiceRequestEscalationLevelFacilityStaff:
+		breachedAt := now
+		if serviceRequest.SLABreachedAt != nil {
+			breachedAt = *serviceRequest.SLABreachedAt
+		}
+
+		if !escalation.PatientSafetyIncident && now.Before(breachedAt.Add(time.Duration(sla.EscalationHours)*time.Hour)) {
+			return nil
+		}
+		escalation.Level = enums.ServiceRequestEscalationLevelAdmins
+
+	default:
+		return nil
+	}
+
+	return escalation
+}
+
+// escalateServiceRequest alerts the recipients of an escalation and then records it on the service request.
+// The recipients are alerted first so that a request whose escalation could not be recorded is escalated again on the next run
+func (u *UseCasesServiceRequestImpl) escalateServiceRequest(ctx context.Context, serviceRequest *domain.ServiceRequest, escalation *domain.ServiceRequestEscalation) error {
+	clientName := ""
+	if serviceRequest.ClientName != nil {
+		clientName = *serviceRequest.ClientName
+	}
+
+	escalationNotification := notification.ComposeStaffNotification(enums.NotificationTypeServiceRequestEscalated, notification.StaffNotificationArgs{
+		Subject:        &domain.User{Name: clientName},
+		ServiceRequest: serviceRequest,
+		Escalation:     escalation,
+	})
+	escalationNotification.ProgramID = serviceRequest.ProgramID
+	escalationNotification.OrganisationID = serviceRequest.OrganisationID
+
+	switch escalation.Level {
+	case enums.ServiceRequestEscalationLevelFacilityStaff:
+		facility, err := u.Query.RetrieveFacility(ctx, &serviceRequest.FacilityID, true)
+		if err != nil {
+			return fmt.Errorf("failed to get facility %s: %w", serviceRequest.FacilityID, err)
+		}
+
+		staffs, err := u.Query.GetFacilityStaffs(ctx, serviceRequest.FacilityID)
+		if err != nil {
+			return fmt.Errorf("failed to get facility staffs: %w", err)
+		}
+
+		if err := u.Notification.NotifyFacilityStaffs(ctx, facility, escalationNotification); err != nil {
+			return fmt.Errorf("failed to notify facility staffs: %w", err)
+		}
+
+		escalation.Recipients = []string{}
+		for _, staff := range staffs {
+			escalation.Recipients = append(escalation.Recipients, staff.UserID)
+		}
+
+	case enums.ServiceRequestEscalationLevelAdmins:
+		recipients, err := u.alertServiceRequestAdmins(ctx, serviceRequest, escalationNotification)
+		if err != nil {
+			return err
+		}
+		escalation.Recipients = recipients
+	}
+
+	if err := u.Update.EscalateServiceRequest(ctx, escalation); err != nil {
+		return fmt.Errorf("failed to record service request escalation: %w", err)
+	}
+
+	if escalation.PatientSafetyIncident && escalation.Level == enums.ServiceRequestEscalationLevelFacilityStaff {
+		common.RecordAuditLog(ctx, u.Create, &domain.AuditLog{
+			RecordType:     enums.AuditLogPatientSafetyIncident,
+			Notes:          fmt.Sprintf("red flag not followed up within %d hours", domain.SuicideRiskResolutionHours),
+			TargetID:       serviceRequest.ID,
+			TargetType:     enums.AuditLogTargetServiceRequest,
+			ProgramID:      serviceRequest.ProgramID,
+			OrganisationID: serviceRequest.OrganisationID,
+			After: map[string]interface{}{
+				"client_id":   serviceRequest.ClientID,
+				"facility_id": serviceRequest.FacilityID,
+				"created":     serviceRequest.CreatedAt,
+			},
+		})
+	}
+
+	return nil
+}
+
+// alertServiceRequestAdmins sends an escalated service request to the admins of its facility by SMS and email.
+// The organisation admins are alerted instead when none of the facility admins could be reached.
+// It returns the user IDs of the admins who were alerted and fails if none of them could be reached
+func (u *UseCasesServiceRequestImpl) alertServiceRequestAdmins(ctx context.Context, serviceRequest *domain.ServiceRequest, escalationNotification *domain.Notification) ([]string, error) {
+	facilityAdmins, err := u.Query.ListFacilityAdmins(ctx, serviceRequest.FacilityID, serviceRequest.ProgramID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list facility admins: %w", err)
+	}
+
+	recipients := u.alertAdmins(ctx, facilityAdmins, escalationNotification)
+	if len(recipients) > 0 {
+		return recipients, nil
+	}
+
+	organisationAdmins, err := u.Query.ListOrganisationAdmins(ctx, serviceRequest.OrganisationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organisation admins: %w", err)
+	}
+
+	recipients = u.alertAdmins(ctx, organisationAdmins, escalationNotification)
+	if len(recipients) == 0 {
+		return nil, fmt.Errorf("none of the admins of facility %s or organisation %s could be alerted", serviceRequest.FacilityID, serviceRequest.OrganisationID)
+	}
+
+	return recipients, nil
+}
+
+// alertAdmins sends an escalated service request to admins by SMS to their phone contact and by email.
+// It returns the user IDs of the admins who were reached by either
+func (u *UseCasesServiceRequestImpl) alertAdmins(ctx context.Context, admins []*domain.StaffProfile, escalationNotification *domain.Notification) []string {
+	recipients := []string{}
+	alerted := map[string]bool{}
+	for _, admin := range admins {
+		if alerted[admin.UserID] {
+			continue
+		}
+		alerted[admin.UserID] = true
+
+		reached := false
+
+		phone, err := u.Query.GetContactByUserID(ctx, &admin.UserID, "PHONE")
+		if err != nil {
+			helpers.ReportErrorToSentry(fmt.Errorf("failed to get phone contact of admin %s: %w", admin.UserID, err))
+		} else if phone.ContactValue != "" {
+			_, err := u.SMS.SendSMS(ctx, escalationNotification.Body, []string{phone.ContactValue})
+			if err != nil {
+				helpers.ReportErrorToSentry(fmt.Errorf("failed to send service request escalation SMS to admin %s: %w", admin.UserID, err))
+			} else {
+				reached = true
+			}
+		}
+
+		adminProfile, err := u.Query.GetUserProfileByUserID(ctx, admin.UserID)
+		if err != nil {
+			helpers.ReportErrorToSentry(fmt.Errorf("failed to get profile of admin %s: %w", admin.UserID, err))
+		} else if adminProfile.Email != nil && *adminProfile.Email != "" {
+			_, err := u.Mail.SendEmail(ctx, escalationNotification.Title, escalationNotification.Body, *adminProfile.Email)
+			if err != nil {
+				helpers.ReportErrorToSentry(fmt.Errorf("failed to send service request escalation email to admin %s: %w", admin.UserID, err))
+			} else {
+				reached = true
+			}
+		}
+
+		if reached {
+			recipients = append(recipients, admin.UserID)
+		}
+	}
+
+	return recipients
+}
+
+// defaultSLARequestTypes returns the service request types that have a default SLA in a stable order
+func defaultSLARequestTypes() []enums.ServiceRequestType {
+	requestTypes := []enums.ServiceRequestType{}
+	for requestType := range domain.DefaultServiceRequestSLAs {
+		requestTypes = append(requestTypes, requestType)
+	}
+	sort.Slice(requestTypes, func(i, j int) bool {
+		return requestTypes[i] < requestTypes[j]
+	})
+
+	return requestTypes
+}
@@ -0,0 +1,773 @@
+package servicerequest_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit"
+	"github.com/google/uuid"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	extensionMock "github.com/savannahghi/mycarehub/pkg/mycarehub/application/extension/mock"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	pgMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/mock"
+	healthCRMMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/healthcrm/mock"
+	mailMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/mail/mock"
+	smsMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/sms/mock"
+	notificationMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/notification/mock"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/servicerequest"
+	userMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/user/mock"
+	"github.com/savannahghi/silcomms"
+)
+
+func TestUseCasesServiceRequestImpl_SetServiceRequestSLA(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		input *dto.ServiceRequestSLAInput
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: set service request sla",
+			args: args{
+				ctx: context.Background(),
+				input: &dto.ServiceRequestSLAInput{
+					RequestType:     enums.ServiceRequestTypeScreeningToolsRedFlag,
+					ResolutionHours: 12,
+					EscalationHours: 6,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: invalid input",
+			args: args{
+				ctx: context.Background(),
+				input: &dto.ServiceRequestSLAInput{
+					RequestType: enums.ServiceRequestTypeScreeningToolsRedFlag,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: red flag resolution over the patient safety window",
+			args: args{
+				ctx: context.Background(),
+				input: &dto.ServiceRequestSLAInput{
+					RequestType:     enums.ServiceRequestTypeRedFlag,
+					ResolutionHours: 48,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: unable to get logged in user",
+			args: args{
+				ctx: context.Background(),
+				input: &dto.ServiceRequestSLAInput{
+					RequestType:     enums.ServiceRequestTypeScreeningToolsRedFlag,
+					ResolutionHours: 12,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: unable to get user profile",
+			args: args{
+				ctx: context.Background(),
+				input: &dto.ServiceRequestSLAInput{
+					RequestType:     enums.ServiceRequestTypeScreeningToolsRedFlag,
+					ResolutionHours: 12,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: unable to list service request slas",
+			args: args{
+				ctx: context.Background(),
+				input: &dto.ServiceRequestSLAInput{
+					RequestType:     enums.ServiceRequestTypeScreeningToolsRedFlag,
+					ResolutionHours: 12,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: unable to set service request sla",
+			args: args{
+				ctx: context.Background(),
+				input: &dto.ServiceRequestSLAInput{
+					RequestType:     enums.ServiceRequestTypeScreeningToolsRedFlag,
+					ResolutionHours: 12,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeUser := userMock.NewUserUseCaseMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			fakeSMS := smsMock.NewSMSServiceMock()
+			fakeHealthCRM := healthCRMMock.NewHealthServiceMock()
+			fakeMail := mailMock.NewMailServiceMock()
+			u := servicerequest.NewUseCaseServiceRequestImpl(fakeDB, fakeDB, fakeDB, fakeExtension, fakeUser, fakeNotification, fakeSMS, fakeHealthCRM, fakeMail)
+
+			var auditLog *domain.AuditLog
+			fakeDB.MockCreateAuditLogFn = func(ctx context.Context, log *domain.AuditLog) error {
+				auditLog = log
+				return nil
+			}
+
+			if tt.name == "Sad case: unable to get logged in user" {
+				fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+					return "", fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get user profile" {
+				fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.User, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to list service request slas" {
+				fakeDB.MockListServiceRequestSLAsFn = func(ctx context.Context, programID *string) ([]*domain.ServiceRequestSLA, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to set service request sla" {
+				fakeDB.MockSetServiceRequestSLAFn = func(ctx context.Context, sla *domain.ServiceRequestSLA) (*domain.ServiceRequestSLA, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := u.SetServiceRequestSLA(tt.args.ctx, tt.args.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesServiceRequestImpl.SetServiceRequestSLA() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if got.ResolutionHours != tt.args.input.ResolutionHours || got.EscalationHours != tt.args.input.EscalationHours {
+				t.Errorf("expected the service request sla to be set, got %v", got)
+			}
+			if auditLog == nil || auditLog.RecordType != enums.AuditLogServiceRequestSLAChange || auditLog.Before["resolution_hours"] != 24 {
+				t.Errorf("expected the service request sla change to be audited with the previous sla, got %v", auditLog)
+			}
+		})
+	}
+}
+
+func TestUseCasesServiceRequestImpl_ListServiceRequestSLAs(t *testing.T) {
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "Happy case: list service request slas",
+			args: args{
+				ctx: context.Background(),
+			},
+			want:    3,
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to get logged in user",
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: unable to get user profile",
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: unable to list service request slas",
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeUser := userMock.NewUserUseCaseMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			fakeSMS := smsMock.NewSMSServiceMock()
+			fakeHealthCRM := healthCRMMock.NewHealthServiceMock()
+			fakeMail := mailMock.NewMailServiceMock()
+			u := servicerequest.NewUseCaseServiceRequestImpl(fakeDB, fakeDB, fakeDB, fakeExtension, fakeUser, fakeNotification, fakeSMS, fakeHealthCRM, fakeMail)
+
+			if tt.name == "Sad case: unable to get logged in user" {
+				fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+					return "", fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get user profile" {
+				fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.User, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to list service request slas" {
+				fakeDB.MockListServiceRequestSLAsFn = func(ctx context.Context, programID *string) ([]*domain.ServiceRequestSLA, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := u.ListServiceRequestSLAs(tt.args.ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesServiceRequestImpl.ListServiceRequestSLAs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if len(got) != tt.want {
+				t.Errorf("expected %d service request slas, got %d", tt.want, len(got))
+				return
+			}
+			for _, sla := range got {
+				if sla.RequestType == enums.ServiceRequestTypeScreeningToolsRedFlag && sla.ResolutionHours != 24 {
+					t.Errorf("expected the program's sla to replace the default, got %v", sla)
+				}
+			}
+		})
+	}
+}
+
+func TestUseCasesServiceRequestImpl_ListServiceRequestEscalations(t *testing.T) {
+	programID := uuid.NewString()
+
+	type args struct {
+		ctx              context.Context
+		serviceRequestID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: list service request escalations",
+			args: args{
+				ctx:              context.Background(),
+				serviceRequestID: uuid.NewString(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to get logged in user",
+			args: args{
+				ctx:              context.Background(),
+				serviceRequestID: uuid.NewString(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: unable to get user profile",
+			args: args{
+				ctx:              context.Background(),
+				serviceRequestID: uuid.NewString(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: unable to get service request",
+			args: args{
+				ctx:              context.Background(),
+				serviceRequestID: uuid.NewString(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: service request in another program",
+			args: args{
+				ctx:              context.Background(),
+				serviceRequestID: uuid.NewString(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: unable to list service request escalations",
+			args: args{
+				ctx:              context.Background(),
+				serviceRequestID: uuid.NewString(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeUser := userMock.NewUserUseCaseMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			fakeSMS := smsMock.NewSMSServiceMock()
+			fakeHealthCRM := healthCRMMock.NewHealthServiceMock()
+			fakeMail := mailMock.NewMailServiceMock()
+			u := servicerequest.NewUseCaseServiceRequestImpl(fakeDB, fakeDB, fakeDB, fakeExtension, fakeUser, fakeNotification, fakeSMS, fakeHealthCRM, fakeMail)
+
+			fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.User, error) {
+				return &domain.User{ID: &userID, CurrentProgramID: programID}, nil
+			}
+			fakeDB.MockGetClientServiceRequestByIDFn = func(ctx context.Context, id string) (*domain.ServiceRequest, error) {
+				return &domain.ServiceRequest{ID: id, ProgramID: programID}, nil
+			}
+
+			if tt.name == "Sad case: unable to get logged in user" {
+				fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+					return "", fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get user profile" {
+				fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.User, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get service request" {
+				fakeDB.MockGetClientServiceRequestByIDFn = func(ctx context.Context, id string) (*domain.ServiceRequest, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: service request in another program" {
+				fakeDB.MockGetClientServiceRequestByIDFn = func(ctx context.Context, id string) (*domain.ServiceRequest, error) {
+					return &domain.ServiceRequest{ID: id, ProgramID: uuid.NewString()}, nil
+				}
+			}
+			if tt.name == "Sad case: unable to list service request escalations" {
+				fakeDB.MockListServiceRequestEscalationsFn = func(ctx context.Context, serviceRequestID string) ([]*domain.ServiceRequestEscalation, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := u.ListServiceRequestEscalations(tt.args.ctx, tt.args.serviceRequestID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesServiceRequestImpl.ListServiceRequestEscalations() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got) != 1 {
+				t.Errorf("expected a service request escalation, got %v", got)
+			}
+		})
+	}
+}
+
+func TestUseCasesServiceRequestImpl_EscalateServiceRequests(t *testing.T) {
+	programID := uuid.NewString()
+	organisationID := uuid.NewString()
+	clientName := gofakeit.Name()
+	email := gofakeit.Email()
+	organisationAdminID := uuid.NewString()
+	facilityStaff := enums.ServiceRequestEscalationLevelFacilityStaff
+
+	pendingServiceRequest := func(requestType enums.ServiceRequestType, created time.Time) *domain.ServiceRequest {
+		return &domain.ServiceRequest{
+			ID:             uuid.NewString(),
+			RequestType:    requestType.String(),
+			Status:         enums.ServiceRequestStatusPending.String(),
+			Active:         true,
+			ClientID:       uuid.NewString(),
+			ClientName:     &clientName,
+			CreatedAt:      created,
+			FacilityID:     uuid.NewString(),
+			ProgramID:      programID,
+			OrganisationID: organisationID,
+		}
+	}
+
+	type args struct {
+		ctx context.Context
+	}
+	tests := []struct {
+		name               string
+		args               args
+		want               int
+		wantLevels         []enums.ServiceRequestEscalationLevel
+		wantSafetyIncident bool
+		wantErr            bool
+	}{
+		{
+			name: "Happy case: service request within its sla",
+			args: args{
+				ctx: context.Background(),
+			},
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name: "Happy case: escalate to facility staff",
+			args: args{
+				ctx: context.Background(),
+			},
+			want:       1,
+			wantLevels: []enums.ServiceRequestEscalationLevel{enums.ServiceRequestEscalationLevelFacilityStaff},
+			wantErr:    false,
+		},
+		{
+			name: "Happy case: escalate to facility staff with the default sla",
+			args: args{
+				ctx: context.Background(),
+			},
+			want:       1,
+			wantLevels: []enums.ServiceRequestEscalationLevel{enums.ServiceRequestEscalationLevelFacilityStaff},
+			wantErr:    false,
+		},
+		{
+			name: "Happy case: escalation period has not passed",
+			args: args{
+				ctx: context.Background(),
+			},
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name: "Happy case: escalate to admins",
+			args: args{
+				ctx: context.Background(),
+			},
+			want:       1,
+			wantLevels: []enums.ServiceRequestEscalationLevel{enums.ServiceRequestEscalationLevelAdmins},
+			wantErr:    false,
+		},
+		{
+			name: "Happy case: escalate to admins reached by email only",
+			args: args{
+				ctx: context.Background(),
+			},
+			want:       1,
+			wantLevels: []enums.ServiceRequestEscalationLevel{enums.ServiceRequestEscalationLevelAdmins},
+			wantErr:    false,
+		},
+		{
+			name: "Happy case: escalate to admins reached by SMS only",
+			args: args{
+				ctx: context.Background(),
+			},
+			want:       1,
+			wantLevels: []enums.ServiceRequestEscalationLevel{enums.ServiceRequestEscalationLevelAdmins},
+			wantErr:    false,
+		},
+		{
+			name: "Happy case: escalate to admins without a phone contact",
+			args: args{
+				ctx: context.Background(),
+			},
+			want:       1,
+			wantLevels: []enums.ServiceRequestEscalationLevel{enums.ServiceRequestEscalationLevelAdmins},
+			wantErr:    false,
+		},
+		{
+			name: "Happy case: escalate to organisation admins when no facility admin is reached",
+			args: args{
+				ctx: context.Background(),
+			},
+			want:       1,
+			wantLevels: []enums.ServiceRequestEscalationLevel{enums.ServiceRequestEscalationLevelAdmins},
+			wantErr:    false,
+		},
+		{
+			name: "Happy case: suicide risk red flag is a patient safety incident",
+			args: args{
+				ctx: context.Background(),
+			},
+			want: 2,
+			wantLevels: []enums.ServiceRequestEscalationLevel{
+				enums.ServiceRequestEscalationLevelFacilityStaff,
+				enums.ServiceRequestEscalationLevelAdmins,
+			},
+			wantSafetyIncident: true,
+			wantErr:            false,
+		},
+		{
+			name: "Happy case: suicide risk red flag sla is capped",
+			args: args{
+				ctx: context.Background(),
+			},
+			want: 2,
+			wantLevels: []enums.ServiceRequestEscalationLevel{
+				enums.ServiceRequestEscalationLevelFacilityStaff,
+				enums.ServiceRequestEscalationLevelAdmins,
+			},
+			wantSafetyIncident: true,
+			wantErr:            false,
+		},
+		{
+			name: "Sad case: unable to list service request slas",
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: unable to get service requests due for escalation",
+			args: args{
+				ctx: context.Background(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: unable to retrieve facility",
+			args: args{
+				ctx: context.Background(),
+			},
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to get facility staffs",
+			args: args{
+				ctx: context.Background(),
+			},
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to notify facility staffs",
+			args: args{
+				ctx: context.Background(),
+			},
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to record escalation",
+			args: args{
+				ctx: context.Background(),
+			},
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to list facility admins",
+			args: args{
+				ctx: context.Background(),
+			},
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to list organisation admins",
+			args: args{
+				ctx: context.Background(),
+			},
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name: "Sad case: unable to reach admins",
+			args: args{
+				ctx: context.Background(),
+			},
+			want:    0,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeUser := userMock.NewUserUseCaseMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			fakeSMS := smsMock.NewSMSServiceMock()
+			fakeHealthCRM := healthCRMMock.NewHealthServiceMock()
+			fakeMail := mailMock.NewMailServiceMock()
+			u := servicerequest.NewUseCaseServiceRequestImpl(fakeDB, fakeDB, fakeDB, fakeExtension, fakeUser, fakeNotification, fakeSMS, fakeHealthCRM, fakeMail)
+
+			escalations := []*domain.ServiceRequestEscalation{}
+			fakeDB.MockEscalateServiceRequestFn = func(ctx context.Context, escalation *domain.ServiceRequestEscalation) error {
+				escalations = append(escalations, escalation)
+				return nil
+			}
+			fakeDB.MockListServiceRequestSLAsFn = func(ctx context.Context, _ *string) ([]*domain.ServiceRequestSLA, error) {
+				return []*domain.ServiceRequestSLA{
+					{
+						ID:              uuid.NewString(),
+						ProgramID:       programID,
+						OrganisationID:  organisationID,
+						RequestType:     enums.ServiceRequestTypeScreeningToolsRedFlag,
+						ResolutionHours: 12,
+						EscalationHours: 6,
+					},
+				}, nil
+			}
+			fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.User, error) {
+				return &domain.User{
+					ID:    &userID,
+					Name:  gofakeit.Name(),
+					Email: &email,
+				}, nil
+			}
+
+			escalatedToFacilityStaff := pendingServiceRequest(enums.ServiceRequestTypeScreeningToolsRedFlag, time.Now().Add(-24*time.Hour))
+			breachedAt := time.Now().Add(-7 * time.Hour)
+			escalatedToFacilityStaff.EscalationLevel = &facilityStaff
+			escalatedToFacilityStaff.SLABreachedAt = &breachedAt
+
+			serviceRequest := pendingServiceRequest(enums.ServiceRequestTypeScreeningToolsRedFlag, time.Now().Add(-13*time.Hour))
+			switch tt.name {
+			case "Happy case: service request within its sla":
+				serviceRequest = pendingServiceRequest(enums.ServiceRequestTypeScreeningToolsRedFlag, time.Now().Add(-1*time.Hour))
+			case "Happy case: escalate to facility staff with the default sla":
+				serviceRequest = pendingServiceRequest(enums.ServiceRequestTypeSurveyRedFlag, time.Now().Add(-49*time.Hour))
+			case "Happy case: escalation period has not passed":
+				serviceRequest = escalatedToFacilityStaff
+				recentlyBreachedAt := time.Now().Add(-1 * time.Hour)
+				serviceRequest.SLABreachedAt = &recentlyBreachedAt
+			case "Happy case: escalate to admins",
+				"Happy case: escalate to admins reached by email only",
+				"Happy case: escalate to admins reached by SMS only",
+				"Happy case: escalate to admins without a phone contact",
+				"Happy case: escalate to organisation admins when no facility admin is reached",
+				"Sad case: unable to list facility admins",
+				"Sad case: unable to list organisation admins",
+				"Sad case: unable to reach admins":
+				serviceRequest = escalatedToFacilityStaff
+			case "Happy case: suicide risk red flag is a patient safety incident",
+				"Happy case: suicide risk red flag sla is capped":
+				serviceRequest = pendingServiceRequest(enums.ServiceRequestTypeRedFlag, time.Now().Add(-25*time.Hour))
+			}
+			fakeDB.MockGetServiceRequestsDueForEscalationFn = func(ctx context.Context, requestTypes []string) ([]*domain.ServiceRequest, error) {
+				return []*domain.ServiceRequest{serviceRequest}, nil
+			}
+
+			if tt.name == "Happy case: escalate to admins reached by email only" {
+				fakeSMS.MockSendSMSFn = func(ctx context.Context, message string, recipients []string) (*silcomms.BulkSMSResponse, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Happy case: suicide risk red flag sla is capped" {
+				fakeDB.MockListServiceRequestSLAsFn = func(ctx context.Context, programID *string) ([]*domain.ServiceRequestSLA, error) {
+					return []*domain.ServiceRequestSLA{
+						{
+							ID:              uuid.NewString(),
+							ProgramID:       serviceRequest.ProgramID,
+							OrganisationID:  organisationID,
+							RequestType:     enums.ServiceRequestTypeRedFlag,
+							ResolutionHours: 72,
+							EscalationHours: 24,
+						},
+					}, nil
+				}
+			}
+			if tt.name == "Sad case: unable to list service request slas" {
+				fakeDB.MockListServiceRequestSLAsFn = func(ctx context.Context, programID *string) ([]*domain.ServiceRequestSLA, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get service requests due for escalation" {
+				fakeDB.MockGetServiceRequestsDueForEscalationFn = func(ctx context.Context, requestTypes []string) ([]*domain.ServiceRequest, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to retrieve facility" {
+				fakeDB.MockRetrieveFacilityFn = func(ctx context.Context, id *string, isActive bool) (*domain.Facility, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get facility staffs" {
+				fakeDB.MockGetFacilityStaffsFn = func(ctx context.Context, facilityID string) ([]*domain.StaffProfile, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to notify facility staffs" {
+				fakeNotification.MockNotifyFacilityStaffsFn = func(ctx context.Context, facility *domain.Facility, notificationPayload *domain.Notification) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to record escalation" {
+				fakeDB.MockEscalateServiceRequestFn = func(ctx context.Context, escalation *domain.ServiceRequestEscalation) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to list organisation admins" {
+				fakeDB.MockListFacilityAdminsFn = func(ctx context.Context, facilityID, programID string) ([]*domain.StaffProfile, error) {
+					return []*domain.StaffProfile{}, nil
+				}
+				fakeDB.MockListOrganisationAdminsFn = func(ctx context.Context, organisationID string) ([]*domain.StaffProfile, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Happy case: escalate to admins reached by SMS only" {
+				fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.User, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Happy case: escalate to admins without a phone contact" {
+				fakeDB.MockGetContactByUserIDFn = func(ctx context.Context, userID *string, contactType string) (*domain.Contact, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Happy case: escalate to organisation admins when no facility admin is reached" {
+				fakeDB.MockListFacilityAdminsFn = func(ctx context.Context, facilityID, programID string) ([]*domain.StaffProfile, error) {
+					return []*domain.StaffProfile{}, nil
+				}
+				fakeDB.MockListOrganisationAdminsFn = func(ctx context.Context, organisationID string) ([]*domain.StaffProfile, error) {
+					return []*domain.StaffProfile{{UserID: organisationAdminID, OrganisationID: organisationID, IsOrganisationAdmin: true}}, nil
+				}
+			}
+			if tt.name == "Sad case: unable to list facility admins" {
+				fakeDB.MockListFacilityAdminsFn = func(ctx context.Context, facilityID, programID string) ([]*domain.StaffProfile, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to reach admins" {
+				fakeSMS.MockSendSMSFn = func(ctx context.Context, message string, recipients []string) (*silcomms.BulkSMSResponse, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+				fakeMail.MockSendEmailFn = func(ctx context.Context, subject, text string, to ...string) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := u.EscalateServiceRequests(tt.args.ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesServiceRequestImpl.EscalateServiceRequests() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UseCasesServiceRequestImpl.EscalateServiceRequests() = %v, want %v", got, tt.want)
+				return
+			}
+
+			if len(tt.wantLevels) != len(escalations) {
+				t.Errorf("expected escalations to %v, got %v", tt.wantLevels, escalations)
+				return
+			}
+			for i, escalation := range escalations {
+				if escalation.Level != tt.wantLevels[i] {
+					t.Errorf("expected an escalation to %s, got %s", tt.wantLevels[i], escalation.Level)
+				}
+				if escalation.PatientSafetyIncident != tt.wantSafetyIncident {
+					t.Errorf("expected patient safety incident to be %v, got %v", tt.wantSafetyIncident, escalation.PatientSafetyIncident)
+				}
+				if len(escalation.Recipients) == 0 {
+					t.Errorf("expected the recipients of the escalation to be recorded")
+				}
+				if tt.name == "Happy case: escalate to organisation admins when no facility admin is reached" && escalation.Recipients[0] != organisationAdminID {
+					t.Errorf("expected the organisation admin to be alerted, got %v", escalation.Recipients)
+				}
+			}
+		})
+	}
+}
@@ -25,6 +25,10 @@ type ServiceRequestUseCaseMock struct {
 	MockVerifyStaffPinResetServiceRequestFn  func(ctx context.Context, serviceRequestID string, status enums.PINResetVerificationStatus) (bool, error)
 	MockSearchServiceRequestsFn              func(ctx context.Context, searchTerm string, flavour feedlib.Flavour, requestType string, facilityID string) ([]*domain.ServiceRequest, error)
 	MockCompleteVisitFn                      func(ctx context.Context, staffID string, serviceRequestID string, bookingID string, notes string) (bool, error)
+	MockSetServiceRequestSLAFn               func(ctx context.Context, input *dto.ServiceRequestSLAInput) (*domain.ServiceRequestSLA, error)
+	MockListServiceRequestSLAsFn             func(ctx context.Context) ([]*domain.ServiceRequestSLA, error)
+	MockListServiceRequestEscalationsFn      func(ctx context.Context, serviceRequestID string) ([]*domain.ServiceRequestEscalation, error)
+	MockEscalateServiceRequestsFn            func(ctx context.Context) (int, error)
 }
 
 // NewServiceRequestUseCaseMock initializes a new service request instance mock
@@ -130,6 +134,44 @@ func NewServiceRequestUseCaseMock() *ServiceRequestUseCaseMock {
 		MockCompleteVisitFn: func(ctx context.Context, staffID, serviceRequestID, bookingID string, notes string) (bool, error) {
 			return true, nil
 		},
+		MockSetServiceRequestSLAFn: func(ctx context.Context, input *dto.ServiceRequestSLAInput) (*domain.ServiceRequestSLA, error) {
+			return &domain.ServiceRequestSLA{
+				ID:              uuid.NewString(),
+				ProgramID:       uuid.NewString(),
+				OrganisationID:  uuid.NewString(),
+				RequestType:     input.RequestType,
+				ResolutionHours: input.ResolutionHours,
+				EscalationHours: input.EscalationHours,
+			}, nil
+		},
+		MockListServiceRequestSLAsFn: func(ctx context.Context) ([]*domain.ServiceRequestSLA, error) {
+			return []*domain.ServiceRequestSLA{
+				{
+					ID:              uuid.NewString(),
+					ProgramID:       uuid.NewString(),
+					OrganisationID:  uuid.NewString(),
+					RequestType:     enums.ServiceRequestTypeSurveyRedFlag,
+					ResolutionHours: 48,
+					EscalationHours: 24,
+				},
+			}, nil
+		},
+		MockListServiceRequestEscalationsFn: func(ctx context.Context, serviceRequestID string) ([]*domain.ServiceRequestEscalation, error) {
+			return []*domain.ServiceRequestEscalation{
+				{
+					ID:               uuid.NewString(),
+					ServiceRequestID: serviceRequestID,
+					Level:            enums.ServiceRequestEscalationLevelFacilityStaff,
+					Recipients:       []string{},
+					EscalatedAt:      time.Now(),
+					ProgramID:        uuid.NewString(),
+					OrganisationID:   uuid.NewString(),
+				},
+			}, nil
+		},
+		MockEscalateServiceRequestsFn: func(ctx context.Context) (int, error) {
+			return 1, nil
+		},
 	}
 }
 
@@ -192,3 +234,23 @@ func (s *ServiceRequestUseCaseMock) SearchServiceRequests(ctx context.Context, s
 func (s *ServiceRequestUseCaseMock) CompleteVisit(ctx context.Context, staffID string, serviceRequestID string, bookingID string, notes string) (bool, error) {
 	return s.MockCompleteVisitFn(ctx, staffID, serviceRequestID, bookingID, notes)
 }
+
+// SetServiceRequestSLA mocks the implementation of setting a program's service request SLA
+func (s *ServiceRequestUseCaseMock) SetServiceRequestSLA(ctx context.Context, input *dto.ServiceRequestSLAInput) (*domain.ServiceRequestSLA, error) {
+	return s.MockSetServiceRequestSLAFn(ctx, input)
+}
+
+// ListServiceRequestSLAs mocks the implementation of listing the service request SLAs of a program
+func (s *ServiceRequestUseCaseMock) ListServiceRequestSLAs(ctx context.Context) ([]*domain.ServiceRequestSLA, error) {
+	return s.MockListServiceRequestSLAsFn(ctx)
+}
+
+// ListServiceRequestEscalations mocks the implementation of listing the escalation history of a service request
+func (s *ServiceRequestUseCaseMock) ListServiceRequestEscalations(ctx context.Context, serviceRequestID string) ([]*domain.ServiceRequestEscalation, error) {
+	return s.MockListServiceRequestEscalationsFn(ctx, serviceRequestID)
+}
+
+// EscalateServiceRequests mocks the implementation of escalating service requests that have breached their SLA
+func (s *ServiceRequestUseCaseMock) EscalateServiceRequests(ctx context.Context) (int, error) {
+	return s.MockEscalateServiceRequestsFn(ctx)
+}
@@ -3,6 +3,7 @@ package servicerequest
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -12,10 +13,13 @@ import (
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/extension"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/utils"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/healthcrm"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/mail"
 	serviceSMS "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/sms"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/common"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/notification"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/user"
 	"gorm.io/gorm"
@@ -70,6 +74,14 @@ type IUpdateServiceRequest interface {
 	UpdateServiceRequestsFromKenyaEMR(ctx context.Context, payload *dto.UpdateServiceRequestsPayload) (bool, error)
 }
 
+// IServiceRequestSLA is the interface holding the method signatures for tracking how long service requests wait and escalating those that breach their SLA
+type IServiceRequestSLA interface {
+	SetServiceRequestSLA(ctx context.Context, input *dto.ServiceRequestSLAInput) (*domain.ServiceRequestSLA, error)
+	ListServiceRequestSLAs(ctx context.Context) ([]*domain.ServiceRequestSLA, error)
+	ListServiceRequestEscalations(ctx context.Context, serviceRequestID string) ([]*domain.ServiceRequestEscalation, error)
+	EscalateServiceRequests(ctx context.Context) (int, error)
+}
+
 // UseCaseServiceRequest holds all the interfaces that represent the service request business logic
 type UseCaseServiceRequest interface {
 	ICreateServiceRequest
@@ -77,6 +89,7 @@ type UseCaseServiceRequest interface {
 	ISetInProgresssBy
 	IResolveServiceRequest
 	IUpdateServiceRequest
+	IServiceRequestSLA
 }
 
 // UseCasesServiceRequestImpl embeds the service request logic
@@ -89,6 +102,7 @@ type UseCasesServiceRequestImpl struct {
 	Notification notification.UseCaseNotification
 	SMS          serviceSMS.IServiceSMS
 	HealthCRM    healthcrm.IHealthCRMService
+	Mail         mail.IServiceMail
 }
 
 // NewUseCaseServiceRequestImpl creates a new service request instance
@@ -101,6 +115,7 @@ func NewUseCaseServiceRequestImpl(
 	notification notification.UseCaseNotification,
 	sms serviceSMS.IServiceSMS,
 	healthCRM healthcrm.IHealthCRMService,
+	mail mail.IServiceMail,
 ) *UseCasesServiceRequestImpl {
 	return &UseCasesServiceRequestImpl{
 		Create:       create,
@@ -111,6 +126,7 @@ func NewUseCaseServiceRequestImpl(
 		Notification: notification,
 		SMS:          sms,
 		HealthCRM:    healthCRM,
+		Mail:         mail,
 	}
 }
 
@@ -431,6 +447,22 @@ func (u *UseCasesServiceRequestImpl) ResolveServiceRequest(ctx context.Context,
 func (u *UseCasesServiceRequestImpl) UpdateServiceRequestsFromKenyaEMR(ctx context.Context, payload *dto.UpdateServiceRequestsPayload) (bool, error) {
 	var serviceRequests []domain.ServiceRequest
 
+	// a KenyaEMR client that is bound to a facility can only update the service requests of that facility
+	var facilityID string
+	mflCode, err := utils.GetValueFromContext(ctx, utils.FacilityMFLCodeContextKey)
+	if err == nil {
+		facility, err := u.Query.RetrieveFacilityByIdentifier(ctx, &dto.FacilityIdentifierInput{
+			Type:  enums.FacilityIdentifierTypeMFLCode,
+			Value: mflCode,
+		}, true)
+		if err != nil {
+			helpers.ReportErrorToSentry(err)
+			return false, fmt.Errorf("failed to retrieve facility with MFL code %s: %w", mflCode, err)
+		}
+
+		facilityID = *facility.ID
+	}
+
 	for _, request := range payload.ServiceRequests {
 		serviceRequest, err := u.Query.GetClientServiceRequestByID(ctx, request.ID)
 		if err != nil {
@@ -438,6 +470,12 @@ func (u *UseCasesServiceRequestImpl) UpdateServiceRequestsFromKenyaEMR(ctx conte
 			return false, err
 		}
 
+		if facilityID != "" && serviceRequest.FacilityID != facilityID {
+			err := fmt.Errorf("client is not allowed to update service request %s of another facility", request.ID)
+			helpers.ReportErrorToSentry(err)
+			return false, err
+		}
+
 		if request.RequestType == enums.ServiceRequestTypeAppointments.String() &&
 			request.Status == enums.ServiceRequestStatusResolved.String() {
 			client, err := u.Query.GetClientProfileByClientID(ctx, serviceRequest.ClientID)
@@ -644,8 +682,23 @@ func (u *UseCasesServiceRequestImpl) VerifyStaffPinResetServiceRequest(ctx conte
 
 	phoneNumber := staffProfile.User.Contacts.ContactValue
 
-	return u.VerifyServiceRequestResponse(ctx, status.String(), phoneNumber, serviceRequestID, staffProfile.User, loggedInStaffProfile, feedlib.FlavourPro)
+	ok, err := u.VerifyServiceRequestResponse(ctx, status.String(), phoneNumber, serviceRequestID, staffProfile.User, loggedInStaffProfile, feedlib.FlavourPro)
+	if err != nil {
+		return ok, err
+	}
+
+	common.RecordAuditLog(ctx, u.Create, &domain.AuditLog{
+		RecordType:     enums.AuditLogPINResetVerification,
+		Notes:          fmt.Sprintf("staff PIN reset request %s", strings.ToLower(status.String())),
+		ActorID:        loggedInUserID,
+		TargetID:       serviceRequestID,
+		TargetType:     enums.AuditLogTargetServiceRequest,
+		ProgramID:      loggedInStaffProfile.ProgramID,
+		OrganisationID: loggedInStaffProfile.OrganisationID,
+		After:          map[string]interface{}{"status": status.String(), "staff_id": serviceRequest.StaffID},
+	})
 
+	return ok, nil
 }
 
 // VerifyClientPinResetServiceRequest is used to approve/reject a pin reset service request. This is used by the
@@ -703,8 +756,27 @@ func (u *UseCasesServiceRequestImpl) VerifyClientPinResetServiceRequest(ctx cont
 
 	phoneNumber := clientProfile.User.Contacts.ContactValue
 
-	return u.VerifyServiceRequestResponse(ctx, status.String(), phoneNumber, serviceRequestID, clientProfile.User, loggedInStaffProfile, feedlib.FlavourConsumer)
+	ok, err := u.VerifyServiceRequestResponse(ctx, status.String(), phoneNumber, serviceRequestID, clientProfile.User, loggedInStaffProfile, feedlib.FlavourConsumer)
+	if err != nil {
+		return ok, err
+	}
+
+	common.RecordAuditLog(ctx, u.Create, &domain.AuditLog{
+		RecordType:     enums.AuditLogPINResetVerification,
+		Notes:          fmt.Sprintf("client PIN reset request %s", strings.ToLower(status.String())),
+		ActorID:        loggedInUserID,
+		TargetID:       serviceRequestID,
+		TargetType:     enums.AuditLogTargetServiceRequest,
+		ProgramID:      clientProfile.ProgramID,
+		OrganisationID: clientProfile.OrganisationID,
+		After: map[string]interface{}{
+			"status":                     status.String(),
+			"client_id":                  serviceRequest.ClientID,
+			"physical_identity_verified": physicalIdentityVerified,
+		},
+	})
 
+	return ok, nil
 }
 
 // VerifyServiceRequestResponse returns the boolean response indicating whether the processing of a service request is successful or not.
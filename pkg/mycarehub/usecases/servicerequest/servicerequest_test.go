@@ -12,9 +12,11 @@ import (
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	extensionMock "github.com/savannahghi/mycarehub/pkg/mycarehub/application/extension/mock"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/utils"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	pgMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/mock"
 	healthCRMMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/healthcrm/mock"
+	mailMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/mail/mock"
 	smsMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/sms/mock"
 	notificationMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/notification/mock"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/servicerequest"
@@ -204,7 +206,8 @@ func TestUseCasesServiceRequestImpl_CreateServiceRequest(t *testing.T) {
 			fakeNotification := notificationMock.NewServiceNotificationMock()
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeHealthCRM := healthCRMMock.NewHealthServiceMock()
-			u := servicerequest.NewUseCaseServiceRequestImpl(fakeDB, fakeDB, fakeDB, fakeExtension, fakeUser, fakeNotification, fakeSMS, fakeHealthCRM)
+			fakeMail := mailMock.NewMailServiceMock()
+			u := servicerequest.NewUseCaseServiceRequestImpl(fakeDB, fakeDB, fakeDB, fakeExtension, fakeUser, fakeNotification, fakeSMS, fakeHealthCRM, fakeMail)
 
 			if tt.name == "Sad Case - Fail to create a service request" {
 				fakeDB.MockCreateStaffServiceRequestFn = func(ctx context.Context, serviceRequestInput *dto.ServiceRequestInput) error {
@@ -336,7 +339,8 @@ func TestUseCasesServiceRequestImpl_InProgressBy(t *testing.T) {
 			fakeNotification := notificationMock.NewServiceNotificationMock()
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeHealthCRM := healthCRMMock.NewHealthServiceMock()
-			u := servicerequest.NewUseCaseServiceRequestImpl(fakeDB, fakeDB, fakeDB, fakeExtension, fakeUser, fakeNotification, fakeSMS, fakeHealthCRM)
+			fakeMail := mailMock.NewMailServiceMock()
+			u := servicerequest.NewUseCaseServiceRequestImpl(fakeDB, fakeDB, fakeDB, fakeExtension, fakeUser, fakeNotification, fakeSMS, fakeHealthCRM, fakeMail)
 
 			if tt.name == "Sad case" {
 				fakeDB.MockInProgressByFn = func(ctx context.Context, requestID, staffID string) (bool, error) {
@@ -517,7 +521,8 @@ func TestUseCasesServiceRequestImpl_GetServiceRequests(t *testing.T) {
 			fakeNotification := notificationMock.NewServiceNotificationMock()
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeHealthCRM := healthCRMMock.NewHealthServiceMock()
-			u := servicerequest.NewUseCaseServiceRequestImpl(fakeDB, fakeDB, fakeDB, fakeExtension, fakeUser, fakeNotification, fakeSMS, fakeHealthCRM)
+			fakeMail := mailMock.NewMailServiceMock()
+			u := servicerequest.NewUseCaseServiceRequestImpl(fakeDB, fakeDB, fakeDB, fakeExtension, fakeUser, fakeNotification, fakeSMS, fakeHealthCRM, fakeMail)
 
 			if tt.name == "Sad Case - Fail to get service requests" {
 				fakeDB.MockGetServiceRequestsFn = func(ctx context.Context, requestType, requestStatus *string, facilityID, programID string, flavour feedlib.Flavour, pagination *domain.Pagination) ([]*domain.ServiceRequest, *domain.Pagination, error) {
@@ -665,7 +670,8 @@ func TestUseCasesServiceRequestImpl_ResolveServiceRequest(t *testing.T) {
 			fakeNotification := notificationMock.NewServiceNotificationMock()
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeHealthCRM := healthCRMMock.NewHealthServiceMock()
-			u := servicerequest.NewUseCaseServiceRequestImpl(fakeDB, fakeDB, fakeDB, fakeExtension, fakeUser, fakeNotification, fakeSMS, fakeHealthCRM)
+			fakeMail := mailMock.NewMailServiceMock()
+			u := servicerequest.NewUseCaseServiceRequestImpl(fakeDB, fakeDB, fakeDB, fakeExtension, fakeUser, fakeNotification, fakeSMS, fakeHealthCRM, fakeMail)
 
 			if tt.name == "Sad Case - Fail to resolve service request" {
 				fakeDB.MockResolveServiceRequestFn = func(ctx context.Context, staffID, serviceRequestID *string, status string, action []string, comment *string) error {
@@ -806,7 +812,8 @@ func TestUseCasesServiceRequestImpl_GetPendingServiceRequestsCount(t *testing.T)
 			fakeNotification := notificationMock.NewServiceNotificationMock()
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeHealthCRM := healthCRMMock.NewHealthServiceMock()
-			u := servicerequest.NewUseCaseServiceRequestImpl(fakeDB, fakeDB, fakeDB, fakeExtension, fakeUser, fakeNotification, fakeSMS, fakeHealthCRM)
+			fakeMail := mailMock.NewMailServiceMock()
+			u := servicerequest.NewUseCaseServiceRequestImpl(fakeDB, fakeDB, fakeDB, fakeExtension, fakeUser, fakeNotification, fakeSMS, fakeHealthCRM, fakeMail)
 
 			if tt.name == "Sad case: unable to get service request count" {
 				fakeDB.MockGetPendingServiceRequestsCountFn = func(ctx context.Context, facilityID, programID string) (*domain.ServiceRequestsCountResponse, error) {
@@ -854,7 +861,8 @@ func TestUseCasesServiceRequestImpl_GetServiceRequestsForKenyaEMR(t *testing.T)
 	fakeNotification := notificationMock.NewServiceNotificationMock()
 	fakeSMS := smsMock.NewSMSServiceMock()
 	fakeHealthCRM := healthCRMMock.NewHealthServiceMock()
-	u := servicerequest.NewUseCaseServiceRequestImpl(fakeDB, fakeDB, fakeDB, fakeExtension, fakeUser, fakeNotification, fakeSMS, fakeHealthCRM)
+	fakeMail := mailMock.NewMailServiceMock()
+	u := servicerequest.NewUseCaseServiceRequestImpl(fakeDB, fakeDB, fakeDB, fakeExtension, fakeUser, fakeNotification, fakeSMS, fakeHealthCRM, fakeMail)
 
 	currentTime := time.Now()
 
@@ -1088,6 +1096,57 @@ func TestUseCasesServiceRequestImpl_UpdateServiceRequestsFromKenyaEMR(t *testing
 			want:    true,
 			wantErr: false,
 		},
+		{
+			name: "happy case: facility client updates its own service request",
+			args: args{
+				ctx: context.WithValue(context.Background(), utils.FacilityMFLCodeContextKey, "1234"),
+				payload: &dto.UpdateServiceRequestsPayload{
+					ServiceRequests: []dto.UpdateServiceRequestPayload{
+						{
+							ID:          uuid.New().String(),
+							RequestType: enums.ServiceRequestTypeScreeningToolsRedFlag.String(),
+							Status:      enums.ServiceRequestStatusResolved.String(),
+						},
+					},
+				},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "sad case: facility client updates another facility's service request",
+			args: args{
+				ctx: context.WithValue(context.Background(), utils.FacilityMFLCodeContextKey, "1234"),
+				payload: &dto.UpdateServiceRequestsPayload{
+					ServiceRequests: []dto.UpdateServiceRequestPayload{
+						{
+							ID:          uuid.New().String(),
+							RequestType: enums.ServiceRequestTypeScreeningToolsRedFlag.String(),
+							Status:      enums.ServiceRequestStatusResolved.String(),
+						},
+					},
+				},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "sad case: fail to get the facility of a facility client",
+			args: args{
+				ctx: context.WithValue(context.Background(), utils.FacilityMFLCodeContextKey, "1234"),
+				payload: &dto.UpdateServiceRequestsPayload{
+					ServiceRequests: []dto.UpdateServiceRequestPayload{
+						{
+							ID:          uuid.New().String(),
+							RequestType: enums.ServiceRequestTypeScreeningToolsRedFlag.String(),
+							Status:      enums.ServiceRequestStatusResolved.String(),
+						},
+					},
+				},
+			},
+			want:    false,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1097,7 +1156,39 @@ func TestUseCasesServiceRequestImpl_UpdateServiceRequestsFromKenyaEMR(t *testing
 			fakeNotification := notificationMock.NewServiceNotificationMock()
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeHealthCRM := healthCRMMock.NewHealthServiceMock()
-			u := servicerequest.NewUseCaseServiceRequestImpl(fakeDB, fakeDB, fakeDB, fakeExtension, fakeUser, fakeNotification, fakeSMS, fakeHealthCRM)
+			fakeMail := mailMock.NewMailServiceMock()
+			u := servicerequest.NewUseCaseServiceRequestImpl(fakeDB, fakeDB, fakeDB, fakeExtension, fakeUser, fakeNotification, fakeSMS, fakeHealthCRM, fakeMail)
+
+			facilityID := gofakeit.UUID()
+			fakeDB.MockRetrieveFacilityByIdentifierFn = func(ctx context.Context, identifier *dto.FacilityIdentifierInput, isActive bool) (*domain.Facility, error) {
+				return &domain.Facility{ID: &facilityID}, nil
+			}
+
+			if tt.name == "happy case: facility client updates its own service request" {
+				fakeDB.MockGetClientServiceRequestByIDFn = func(ctx context.Context, id string) (*domain.ServiceRequest, error) {
+					return &domain.ServiceRequest{
+						ID:         id,
+						ClientID:   gofakeit.UUID(),
+						FacilityID: facilityID,
+					}, nil
+				}
+			}
+
+			if tt.name == "sad case: facility client updates another facility's service request" {
+				fakeDB.MockGetClientServiceRequestByIDFn = func(ctx context.Context, id string) (*domain.ServiceRequest, error) {
+					return &domain.ServiceRequest{
+						ID:         id,
+						ClientID:   gofakeit.UUID(),
+						FacilityID: gofakeit.UUID(),
+					}, nil
+				}
+			}
+
+			if tt.name == "sad case: fail to get the facility of a facility client" {
+				fakeDB.MockRetrieveFacilityByIdentifierFn = func(ctx context.Context, identifier *dto.FacilityIdentifierInput, isActive bool) (*domain.Facility, error) {
+					return nil, fmt.Errorf("facility not found")
+				}
+			}
 
 			if tt.name == "happy case: appointment service request" {
 				fakeDB.MockGetClientServiceRequestByIDFn = func(ctx context.Context, id string) (*domain.ServiceRequest, error) {
@@ -1323,7 +1414,8 @@ func TestUseCasesServiceRequestImpl_CreatePinResetServiceRequest(t *testing.T) {
 			fakeNotification := notificationMock.NewServiceNotificationMock()
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeHealthCRM := healthCRMMock.NewHealthServiceMock()
-			u := servicerequest.NewUseCaseServiceRequestImpl(fakeDB, fakeDB, fakeDB, fakeExtension, fakeUser, fakeNotification, fakeSMS, fakeHealthCRM)
+			fakeMail := mailMock.NewMailServiceMock()
+			u := servicerequest.NewUseCaseServiceRequestImpl(fakeDB, fakeDB, fakeDB, fakeExtension, fakeUser, fakeNotification, fakeSMS, fakeHealthCRM, fakeMail)
 
 			if tt.name == "Sad Case - Fail to create service request" {
 				fakeDB.MockCreateServiceRequestFn = func(ctx context.Context, serviceRequestInput *dto.ServiceRequestInput) error {
@@ -1539,7 +1631,8 @@ func TestUseCasesServiceRequestImpl_VerifyClientPinResetServiceRequest(t *testin
 			fakeNotification := notificationMock.NewServiceNotificationMock()
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeHealthCRM := healthCRMMock.NewHealthServiceMock()
-			u := servicerequest.NewUseCaseServiceRequestImpl(fakeDB, fakeDB, fakeDB, fakeExtension, fakeUser, fakeNotification, fakeSMS, fakeHealthCRM)
+			fakeMail := mailMock.NewMailServiceMock()
+			u := servicerequest.NewUseCaseServiceRequestImpl(fakeDB, fakeDB, fakeDB, fakeExtension, fakeUser, fakeNotification, fakeSMS, fakeHealthCRM, fakeMail)
 
 			if tt.name == "Sad Case - Fail to get logged in user" {
 				fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
@@ -1632,7 +1725,8 @@ func TestUseCasesServiceRequestImpl_VerifyStaffPinResetServiceRequest(t *testing
 	fakeNotification := notificationMock.NewServiceNotificationMock()
 	fakeSMS := smsMock.NewSMSServiceMock()
 	fakeHealthCRM := healthCRMMock.NewHealthServiceMock()
-	u := servicerequest.NewUseCaseServiceRequestImpl(fakeDB, fakeDB, fakeDB, fakeExtension, fakeUser, fakeNotification, fakeSMS, fakeHealthCRM)
+	fakeMail := mailMock.NewMailServiceMock()
+	u := servicerequest.NewUseCaseServiceRequestImpl(fakeDB, fakeDB, fakeDB, fakeExtension, fakeUser, fakeNotification, fakeSMS, fakeHealthCRM, fakeMail)
 
 	type args struct {
 		ctx              context.Context
@@ -1913,7 +2007,8 @@ func TestUseCasesServiceRequestImpl_SearchServiceRequests(t *testing.T) {
 			fakeNotification := notificationMock.NewServiceNotificationMock()
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeHealthCRM := healthCRMMock.NewHealthServiceMock()
-			u := servicerequest.NewUseCaseServiceRequestImpl(fakeDB, fakeDB, fakeDB, fakeExtension, fakeUser, fakeNotification, fakeSMS, fakeHealthCRM)
+			fakeMail := mailMock.NewMailServiceMock()
+			u := servicerequest.NewUseCaseServiceRequestImpl(fakeDB, fakeDB, fakeDB, fakeExtension, fakeUser, fakeNotification, fakeSMS, fakeHealthCRM, fakeMail)
 
 			if tt.name == "Sad Case: Unable to search service requests" {
 				fakeDB.MockSearchClientServiceRequestsFn = func(ctx context.Context, searchParameter string, requestType string, facilityID string) ([]*domain.ServiceRequest, error) {
@@ -2030,7 +2125,8 @@ func TestUseCasesServiceRequestImpl_CompleteVisit(t *testing.T) {
 			fakeNotification := notificationMock.NewServiceNotificationMock()
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeHealthCRM := healthCRMMock.NewHealthServiceMock()
-			u := servicerequest.NewUseCaseServiceRequestImpl(fakeDB, fakeDB, fakeDB, fakeExtension, fakeUser, fakeNotification, fakeSMS, fakeHealthCRM)
+			fakeMail := mailMock.NewMailServiceMock()
+			u := servicerequest.NewUseCaseServiceRequestImpl(fakeDB, fakeDB, fakeDB, fakeExtension, fakeUser, fakeNotification, fakeSMS, fakeHealthCRM, fakeMail)
 
 			if tt.name == "Sad case: unable to get client service request by id" {
 				fakeDB.MockGetClientServiceRequestByIDFn = func(ctx context.Context, id string) (*domain.ServiceRequest, error) {
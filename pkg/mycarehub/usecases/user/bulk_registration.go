@@ -0,0 +1,300 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/savannahghi/converterandformatter"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/utils"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	"github.com/savannahghi/serverutils"
+	validator "gopkg.in/go-playground/validator.v9"
+)
+
+// bulkClientRegistrationBatchSize is the number of clients that are registered at the same time during a bulk registration.
+// It can be overridden by setting `BULK_CLIENT_REGISTRATION_BATCH_SIZE`
+var bulkClientRegistrationBatchSize = utils.IntFromEnv("BULK_CLIENT_REGISTRATION_BATCH_SIZE", 20)
+
+// BulkRegisterClients registers the clients in a csv, for example the patients exported from KenyaEMR when onboarding a facility.
+// Every row is validated first and the rows with errors are reported and skipped. In a dry run the report is returned without
+// registering any client. The valid rows are registered in batches and the clients are sent an invite when requested
+func (us *UseCasesUserImpl) BulkRegisterClients(ctx context.Context, input *dto.BulkClientRegistrationInput) (*dto.BulkClientRegistrationOutput, error) {
+	ctx, span := tracer.Start(ctx, "BulkRegisterClients")
+	defer span.End()
+
+	if err := input.Validate(); err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InputValidationErr(fmt.Errorf("failed to validate bulk client registration input: %w", err))
+	}
+
+	rows, err := utils.ParseClientsFromCSV(strings.NewReader(input.CSV))
+	if err != nil {
+		return nil, exceptions.InputValidationErr(err)
+	}
+
+	signUpPayload, err := us.bulkRegistrationSignUpPayload(ctx, input.ProgramID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &dto.BulkClientRegistrationOutput{
+		DryRun:    input.DryRun,
+		TotalRows: len(rows),
+		Errors:    []*dto.BulkClientRegistrationError{},
+	}
+
+	facilities := map[string]*domain.Facility{}
+	cccNumbers := map[string]int{}
+	usernames := map[string]int{}
+	validRows := []*dto.ClientCSVRow{}
+
+	for _, row := range rows {
+		rowErrors, err := us.validateClientCSVRow(ctx, row, facilities, cccNumbers, usernames)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(rowErrors) > 0 {
+			report.Errors = append(report.Errors, bulkClientRegistrationError(row, rowErrors...))
+			continue
+		}
+
+		validRows = append(validRows, row)
+	}
+	report.ValidRows = len(validRows)
+
+	if input.DryRun {
+		return report, nil
+	}
+
+	for start := 0; start < len(validRows); start += bulkClientRegistrationBatchSize {
+		end := start + bulkClientRegistrationBatchSize
+		if end > len(validRows) {
+			end = len(validRows)
+		}
+		batch := validRows[start:end]
+
+		registrationErrors := make([]error, len(batch))
+
+		var wg sync.WaitGroup
+		for i, row := range batch {
+			wg.Add(1)
+			go func(i int, row *dto.ClientCSVRow) {
+				defer wg.Done()
+
+				row.Input.InviteClient = input.InviteClients
+				row.Input.ProgramID = signUpPayload.UserProgram.ID
+
+				payload := *signUpPayload
+				payload.ClientInput = row.Input
+				payload.Facility = facilities[row.Input.Facility]
+
+				_, registrationErrors[i] = us.Register(ctx, &payload, false)
+			}(i, row)
+		}
+		wg.Wait()
+
+		for i, err := range registrationErrors {
+			if err != nil {
+				helpers.ReportErrorToSentry(err)
+				report.Errors = append(report.Errors, bulkClientRegistrationError(batch[i], err.Error()))
+				continue
+			}
+			report.Registered++
+		}
+	}
+
+	sort.Slice(report.Errors, func(i, j int) bool {
+		return report.Errors[i].Row < report.Errors[j].Row
+	})
+
+	return report, nil
+}
+
+// bulkRegistrationSignUpPayload returns the program that the clients are registered in and the matrix account that registers them.
+// Clients registered by a staff are added to the staff's current program. When a program is provided, for example from the command line,
+// the clients are registered using the service's matrix account
+func (us *UseCasesUserImpl) bulkRegistrationSignUpPayload(ctx context.Context, programID string) (*dto.SignUpPayload, error) {
+	if programID != "" {
+		program, err := us.Query.GetProgramByID(ctx, programID)
+		if err != nil {
+			helpers.ReportErrorToSentry(err)
+			return nil, err
+		}
+
+		return &dto.SignUpPayload{
+			UserProfile: &domain.User{
+				CurrentProgramID:      program.ID,
+				CurrentOrganizationID: program.Organisation.ID,
+			},
+			UserProgram: program,
+			Matrix: &domain.MatrixAuth{
+				Username: serverutils.MustGetEnvVar("MCH_MATRIX_USER"),
+				Password: serverutils.MustGetEnvVar("MCH_MATRIX_PASSWORD"),
+			},
+		}, nil
+	}
+
+	loggedInUserID, err := us.ExternalExt.GetLoggedInUserUID(ctx)
+	if err != nil {
+		return nil, exceptions.GetLoggedInUserUIDErr(err)
+	}
+
+	userProfile, err := us.Query.GetUserProfileByUserID(ctx, loggedInUserID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.UserNotFoundError(err)
+	}
+
+	program, err := us.Query.GetProgramByID(ctx, userProfile.CurrentProgramID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, err
+	}
+
+	matrixLoginPayload := &domain.MatrixAuth{
+		Username: userProfile.Username,
+		Password: loggedInUserID,
+	}
+
+	_, err = us.Matrix.CheckIfUserIsAdmin(ctx, matrixLoginPayload, userProfile.Username)
+	if err != nil {
+		return nil, fmt.Errorf("unable to register clients. Reason(Matrix): %w", err)
+	}
+
+	return &dto.SignUpPayload{
+		UserProfile: userProfile,
+		UserProgram: program,
+		Matrix:      matrixLoginPayload,
+	}, nil
+}
+
+// validateClientCSVRow returns the problems with a row of the client registration csv. It checks the row with the same validation
+// as a single client registration as well as for CCC numbers and usernames that are repeated in the file or are already in use.
+// The facilities that are found are cached so that they are only retrieved once. An error is only returned when the checks fail
+func (us *UseCasesUserImpl) validateClientCSVRow(
+	ctx context.Context,
+	row *dto.ClientCSVRow,
+	facilities map[string]*domain.Facility,
+	cccNumbers map[string]int,
+	usernames map[string]int,
+) ([]string, error) {
+	if row.Err != nil {
+		return []string{row.Err.Error()}, nil
+	}
+
+	rowErrors := []string{}
+	input := row.Input
+
+	if err := input.Validate(); err != nil {
+		var validationErrors validator.ValidationErrors
+		if !errors.As(err, &validationErrors) {
+			return nil, err
+		}
+		for _, fieldError := range validationErrors {
+			rowErrors = append(rowErrors, fmt.Sprintf("%s is required", fieldError.Field()))
+		}
+	}
+
+	if input.PhoneNumber != "" {
+		if _, err := converterandformatter.NormalizeMSISDN(input.PhoneNumber); err != nil {
+			rowErrors = append(rowErrors, fmt.Sprintf("invalid phone number %s", input.PhoneNumber))
+		}
+	}
+
+	if input.CCCNumber != "" {
+		if previousRow, ok := cccNumbers[input.CCCNumber]; ok {
+			rowErrors = append(rowErrors, fmt.Sprintf("CCC number %s is repeated from row %d", input.CCCNumber, previousRow))
+		} else {
+			cccNumbers[input.CCCNumber] = row.Row
+
+			exists, err := us.Query.CheckIdentifierExists(ctx, enums.UserIdentifierTypeCCC, input.CCCNumber)
+			if err != nil {
+				helpers.ReportErrorToSentry(err)
+				return nil, fmt.Errorf("failed to check if CCC number exists: %w", err)
+			}
+			if exists {
+				rowErrors = append(rowErrors, fmt.Sprintf("an identifier with this CCC number %s already exists", input.CCCNumber))
+			}
+		}
+	}
+
+	if input.Username != "" {
+		if previousRow, ok := usernames[input.Username]; ok {
+			rowErrors = append(rowErrors, fmt.Sprintf("username %s is repeated from row %d", input.Username, previousRow))
+		} else {
+			usernames[input.Username] = row.Row
+
+			exists, err := us.Query.CheckIfUsernameExists(ctx, input.Username)
+			if err != nil {
+				helpers.ReportErrorToSentry(err)
+				return nil, fmt.Errorf("unable to check if username exists: %w", err)
+			}
+			if exists {
+				rowErrors = append(rowErrors, fmt.Sprintf("username %s already exists", input.Username))
+			}
+		}
+	}
+
+	if input.Facility != "" {
+		if _, ok := facilities[input.Facility]; !ok {
+			facility, err := us.clientCSVFacility(ctx, input.Facility)
+			if err != nil {
+				return nil, err
+			}
+			if facility == nil {
+				rowErrors = append(rowErrors, fmt.Sprintf("facility with MFLCode %s does not exist", input.Facility))
+			} else {
+				facilities[input.Facility] = facility
+			}
+		}
+	}
+
+	return rowErrors, nil
+}
+
+// clientCSVFacility retrieves the facility with the provided MFL code. No facility is returned when it does not exist
+func (us *UseCasesUserImpl) clientCSVFacility(ctx context.Context, mflCode string) (*domain.Facility, error) {
+	identifier := &dto.FacilityIdentifierInput{
+		Type:  enums.FacilityIdentifierTypeMFLCode,
+		Value: mflCode,
+	}
+
+	exists, err := us.Query.CheckFacilityExistsByIdentifier(ctx, identifier)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, fmt.Errorf("failed to check if facility exists: %w", err)
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	facility, err := us.Query.RetrieveFacilityByIdentifier(ctx, identifier, true)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, fmt.Errorf("failed to retrieve facility: %w", err)
+	}
+
+	return facility, nil
+}
+
+// bulkClientRegistrationError reports the problems with a row of the client registration csv
+func bulkClientRegistrationError(row *dto.ClientCSVRow, rowErrors ...string) *dto.BulkClientRegistrationError {
+	registrationError := &dto.BulkClientRegistrationError{
+		Row:    row.Row,
+		Errors: rowErrors,
+	}
+	if row.Input != nil {
+		registrationError.CCCNumber = row.Input.CCCNumber
+	}
+
+	return registrationError
+}
@@ -0,0 +1,258 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	extensionMock "github.com/savannahghi/mycarehub/pkg/mycarehub/application/extension/mock"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	pgMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/mock"
+	clinicalMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/clinical/mock"
+	matrixMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/matrix/mock"
+	pubsubMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/pubsub/mock"
+	smsMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/sms/mock"
+	surveysMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/surveys/mock"
+	twilioMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/twilio/mock"
+	authorityMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/authority/mock"
+	notificationMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/notification/mock"
+	otpMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/otp/mock"
+)
+
+const clientCSVHeader = "cccNumber,clientName,gender,dateOfBirth,phoneNumber,clientTypes,facility,enrollmentDate,counselled\n"
+
+func TestUseCasesUserImpl_BulkRegisterClients(t *testing.T) {
+	validCSV := clientCSVHeader +
+		"1234567890,Jane Doe,female,1990-01-01,+254711223344,PMTCT;OTZ,12345,2023-01-01,true\n" +
+		"1234567891,John Doe,male,1985-06-15,0711223355,OVC,12345,2023-01-01,true\n"
+
+	invalidCSV := clientCSVHeader +
+		"1234567890,Jane Doe,female,1990-01-01,+254711223344,PMTCT,12345,2023-01-01,true\n" +
+		"1234567890,Mary Doe,female,1990-01-01,+254711223366,PMTCT,12345,2023-01-01,true\n" +
+		"1234567892,Peter Doe,male,1990-01-01,12,PMTCT,12345,2023-01-01,true\n" +
+		"1234567893,Paul Doe,male,1990-01-01,+254711223377,PMTCT,99999,2023-01-01,true\n" +
+		"1234567894,Ann Doe,x,1990-01-01,+254711223388,PMTCT,12345,2023-01-01,true\n" +
+		"1234567895,Ruth Doe,female,1990-01-01,+254711223399,PMTCT,12345,2023-01-01,false\n"
+
+	tests := []struct {
+		name           string
+		input          *dto.BulkClientRegistrationInput
+		wantValid      int
+		wantRegistered int
+		wantErrorRows  []int
+		wantErr        bool
+	}{
+		{
+			name:      "Happy case: dry run",
+			input:     &dto.BulkClientRegistrationInput{CSV: validCSV, DryRun: true},
+			wantValid: 2,
+		},
+		{
+			name:           "Happy case: register clients",
+			input:          &dto.BulkClientRegistrationInput{CSV: validCSV, InviteClients: true},
+			wantValid:      2,
+			wantRegistered: 2,
+		},
+		{
+			name:           "Happy case: register clients in a program",
+			input:          &dto.BulkClientRegistrationInput{CSV: validCSV, ProgramID: "program-id"},
+			wantValid:      2,
+			wantRegistered: 2,
+		},
+		{
+			name:          "Happy case: report invalid rows",
+			input:         &dto.BulkClientRegistrationInput{CSV: invalidCSV, DryRun: true},
+			wantValid:     1,
+			wantErrorRows: []int{3, 4, 5, 6, 7},
+		},
+		{
+			name:          "Happy case: report existing CCC numbers and usernames",
+			input:         &dto.BulkClientRegistrationInput{CSV: validCSV, DryRun: true},
+			wantValid:     0,
+			wantErrorRows: []int{2, 3},
+		},
+		{
+			name:          "Happy case: report failed registrations",
+			input:         &dto.BulkClientRegistrationInput{CSV: validCSV},
+			wantValid:     2,
+			wantErrorRows: []int{2, 3},
+		},
+		{
+			name:    "Sad case: empty csv",
+			input:   &dto.BulkClientRegistrationInput{},
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: invalid csv labels",
+			input:   &dto.BulkClientRegistrationInput{CSV: "cccNumber,name\n1234567890,Jane Doe\n"},
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to get logged in user",
+			input:   &dto.BulkClientRegistrationInput{CSV: validCSV},
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to get user profile",
+			input:   &dto.BulkClientRegistrationInput{CSV: validCSV},
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to get program",
+			input:   &dto.BulkClientRegistrationInput{CSV: validCSV},
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to get provided program",
+			input:   &dto.BulkClientRegistrationInput{CSV: validCSV, ProgramID: "program-id"},
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: staff is not a matrix admin",
+			input:   &dto.BulkClientRegistrationInput{CSV: validCSV},
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to check if CCC number exists",
+			input:   &dto.BulkClientRegistrationInput{CSV: validCSV},
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to check if username exists",
+			input:   &dto.BulkClientRegistrationInput{CSV: validCSV},
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to check if facility exists",
+			input:   &dto.BulkClientRegistrationInput{CSV: validCSV},
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to retrieve facility",
+			input:   &dto.BulkClientRegistrationInput{CSV: validCSV},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeOTP := otpMock.NewOTPUseCaseMock()
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			fakePubsub := pubsubMock.NewPubsubServiceMock()
+			fakeClinical := clinicalMock.NewClinicalServiceMock()
+			fakeSMS := smsMock.NewSMSServiceMock()
+			fakeTwilio := twilioMock.NewTwilioServiceMock()
+			fakeMatrix := matrixMock.NewMatrixMock()
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+
+			us := NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
+
+			fakeDB.MockCheckFacilityExistsByIdentifier = func(ctx context.Context, identifier *dto.FacilityIdentifierInput) (bool, error) {
+				return identifier.Value == "12345", nil
+			}
+
+			fakeDB.MockRegisterClientFn = func(ctx context.Context, payload *domain.ClientRegistrationPayload) (*domain.ClientProfile, error) {
+				id := payload.ClientIdentifier.Value
+				return &domain.ClientProfile{
+					ID:              &id,
+					UserID:          id,
+					User:            &domain.User{ID: &id, Name: payload.UserProfile.Name, DateOfBirth: payload.UserProfile.DateOfBirth},
+					Active:          true,
+					DefaultFacility: &domain.Facility{ID: payload.Client.DefaultFacility.ID},
+					ProgramID:       payload.Client.ProgramID,
+					OrganisationID:  payload.Client.OrganisationID,
+				}, nil
+			}
+
+			if tt.name == "Happy case: report existing CCC numbers and usernames" {
+				fakeDB.MockCheckIdentifierExists = func(ctx context.Context, identifierType enums.UserIdentifierType, identifierValue string) (bool, error) {
+					return identifierValue == "1234567890", nil
+				}
+				fakeDB.MockCheckIfUsernameExistsFn = func(ctx context.Context, username string) (bool, error) {
+					return username == "john7891", nil
+				}
+			}
+			if tt.name == "Happy case: report failed registrations" {
+				fakeDB.MockRegisterClientFn = func(ctx context.Context, payload *domain.ClientRegistrationPayload) (*domain.ClientProfile, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get logged in user" {
+				fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+					return "", fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get user profile" {
+				fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.User, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get program" || tt.name == "Sad case: unable to get provided program" {
+				fakeDB.MockGetProgramByIDFn = func(ctx context.Context, programID string) (*domain.Program, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: staff is not a matrix admin" {
+				fakeMatrix.MockCheckIfUserIsAdminFn = func(ctx context.Context, auth *domain.MatrixAuth, userID string) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to check if CCC number exists" {
+				fakeDB.MockCheckIdentifierExists = func(ctx context.Context, identifierType enums.UserIdentifierType, identifierValue string) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to check if username exists" {
+				fakeDB.MockCheckIfUsernameExistsFn = func(ctx context.Context, username string) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to check if facility exists" {
+				fakeDB.MockCheckFacilityExistsByIdentifier = func(ctx context.Context, identifier *dto.FacilityIdentifierInput) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to retrieve facility" {
+				fakeDB.MockRetrieveFacilityByIdentifierFn = func(ctx context.Context, identifier *dto.FacilityIdentifierInput, isActive bool) (*domain.Facility, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := us.BulkRegisterClients(context.Background(), tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesUserImpl.BulkRegisterClients() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if got.DryRun != tt.input.DryRun {
+				t.Errorf("UseCasesUserImpl.BulkRegisterClients() dry run = %v, want %v", got.DryRun, tt.input.DryRun)
+			}
+			if got.TotalRows != strings.Count(tt.input.CSV, "\n")-1 {
+				t.Errorf("UseCasesUserImpl.BulkRegisterClients() total rows = %v, want %v", got.TotalRows, strings.Count(tt.input.CSV, "\n")-1)
+			}
+			if got.ValidRows != tt.wantValid {
+				t.Errorf("UseCasesUserImpl.BulkRegisterClients() valid rows = %v, want %v: %v", got.ValidRows, tt.wantValid, got.Errors)
+			}
+			if got.Registered != tt.wantRegistered {
+				t.Errorf("UseCasesUserImpl.BulkRegisterClients() registered = %v, want %v", got.Registered, tt.wantRegistered)
+			}
+			if len(got.Errors) != len(tt.wantErrorRows) {
+				t.Errorf("UseCasesUserImpl.BulkRegisterClients() errors = %v, want errors in rows %v", got.Errors, tt.wantErrorRows)
+				return
+			}
+			for i, row := range tt.wantErrorRows {
+				if got.Errors[i].Row != row {
+					t.Errorf("UseCasesUserImpl.BulkRegisterClients() error %d is in row %v, want %v", i, got.Errors[i].Row, row)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/authorization"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/common"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/notification"
+	"github.com/savannahghi/scalarutils"
+)
+
+// caregiverDelegation works out the period that a caregiver is assigned to a client for and the abilities delegated to them.
+// The delegation starts immediately and does not end unless dates are provided, and all the abilities are delegated unless some are chosen
+func caregiverDelegation(ctx context.Context, input dto.ClientCaregiverInput, now time.Time) (*time.Time, *time.Time, []enums.CaregiverAbility, error) {
+	startsAt := now
+	if input.StartsAt != nil {
+		startsAt = input.StartsAt.AsTime()
+	}
+
+	var endsAt *time.Time
+	if input.EndsAt != nil {
+		end := input.EndsAt.AsTime()
+		if !end.After(startsAt) {
+			return nil, nil, nil, fmt.Errorf("the caregiver's access should end after it starts")
+		}
+		endsAt = &end
+	}
+
+	abilities := input.Abilities
+	if len(abilities) == 0 {
+		abilities = enums.AllCaregiverAbilities
+	}
+
+	if err := authorization.ValidateCaregiverAbilities(ctx, abilities); err != nil {
+		return nil, nil, nil, err
+	}
+
+	return &startsAt, endsAt, abilities, nil
+}
+
+// RevokeCaregiverDelegation is used by a client to stop a caregiver from acting on their behalf.
+// Both the client and the caregiver are notified
+func (us *UseCasesUserImpl) RevokeCaregiverDelegation(ctx context.Context, clientID string, caregiverID string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "RevokeCaregiverDelegation")
+	defer span.End()
+
+	loggedInUserID, err := us.ExternalExt.GetLoggedInUserUID(ctx)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.GetLoggedInUserUIDErr(err)
+	}
+
+	clientProfile, err := us.Query.GetClientProfileByClientID(ctx, clientID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.ClientProfileNotFoundErr(err)
+	}
+
+	if clientProfile.UserID != loggedInUserID {
+		err := fmt.Errorf("only client %s can revoke their caregiver's access", clientID)
+		helpers.ReportErrorToSentry(err)
+		return false, err
+	}
+
+	delegations, err := us.Query.GetCaregiversClient(ctx, domain.CaregiverClient{ClientID: clientID, CaregiverID: caregiverID})
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, fmt.Errorf("failed to get caregiver's delegation: %w", err)
+	}
+
+	if len(delegations) != 1 {
+		err := fmt.Errorf("client %s is not managed by caregiver %s", clientID, caregiverID)
+		helpers.ReportErrorToSentry(err)
+		return false, err
+	}
+
+	delegation := delegations[0]
+	if delegation.RevokedAt != nil || delegation.ExpiredAt != nil {
+		return false, fmt.Errorf("caregiver %s's access to client %s has already ended", caregiverID, clientID)
+	}
+
+	now := time.Now()
+	err = us.Update.UpdateCaregiverClient(ctx, &domain.CaregiverClient{ClientID: clientID, CaregiverID: caregiverID}, map[string]interface{}{
+		"active":     false,
+		"revoked_at": now,
+		"revoked_by": loggedInUserID,
+	})
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, fmt.Errorf("failed to revoke caregiver's delegation: %w", err)
+	}
+
+	delegation.RevokedAt = &now
+	delegation.RevokedBy = &loggedInUserID
+
+	common.RecordAuditLog(ctx, us.Create, &domain.AuditLog{
+		RecordType:     enums.AuditLogCaregiverDelegationChange,
+		Notes:          fmt.Sprintf("client revoked caregiver %s's access", caregiverID),
+		TargetID:       clientID,
+		TargetType:     enums.AuditLogTargetClient,
+		ProgramID:      clientProfile.ProgramID,
+		OrganisationID: clientProfile.OrganisationID,
+		After:          map[string]interface{}{"caregiver_id": caregiverID, "revoked_at": now},
+	})
+
+	us.notifyCaregiverAccessEnded(ctx, delegation)
+
+	return true, nil
+}
+
+// EndClientCaregiverAccess sets the date that all of a client's caregivers stop being able to act on their behalf
+// e.g when an adolescent OTZ client transitions to self-care. Caregivers whose access already ends earlier are left unchanged.
+// It returns the number of caregivers whose access will end on the date
+func (us *UseCasesUserImpl) EndClientCaregiverAccess(ctx context.Context, clientID string, endDate scalarutils.Date) (int, error) {
+	ctx, span := tracer.Start(ctx, "EndClientCaregiverAccess")
+	defer span.End()
+
+	endsAt := endDate.AsTime()
+	if !endsAt.After(time.Now()) {
+		return 0, exceptions.InputValidationErr(fmt.Errorf("the caregivers' access should end on a future date"))
+	}
+
+	clientProfile, err := us.organisationClientProfile(ctx, clientID)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := us.Update.EndClientCaregiverDelegations(ctx, clientID, endsAt)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return 0, fmt.Errorf("failed to end client's caregiver access: %w", err)
+	}
+
+	common.RecordAuditLog(ctx, us.Create, &domain.AuditLog{
+		RecordType:     enums.AuditLogCaregiverDelegationChange,
+		Notes:          "client's caregivers' access set to end",
+		TargetID:       clientID,
+		TargetType:     enums.AuditLogTargetClient,
+		ProgramID:      clientProfile.ProgramID,
+		OrganisationID: clientProfile.OrganisationID,
+		After:          map[string]interface{}{"ends_at": endsAt, "caregivers": count},
+	})
+
+	return count, nil
+}
+
+// ExpireCaregiverDelegations marks the caregiver delegations whose end date has passed as expired and notifies the clients and caregivers.
+// It is meant to be run on a schedule. Caregivers cannot act on behalf of a client once the end date passes even before it runs.
+// It returns the number of delegations that were expired
+func (us *UseCasesUserImpl) ExpireCaregiverDelegations(ctx context.Context) (int, error) {
+	ctx, span := tracer.Start(ctx, "ExpireCaregiverDelegations")
+	defer span.End()
+
+	now := time.Now()
+	delegations, err := us.Query.ListExpiredCaregiverDelegations(ctx, now)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return 0, exceptions.InternalErr(fmt.Errorf("failed to list expired caregiver delegations: %w", err))
+	}
+
+	expired := 0
+	failed := []string{}
+	for _, delegation := range delegations {
+		err := us.Update.UpdateCaregiverClient(ctx, &domain.CaregiverClient{ClientID: delegation.ClientID, CaregiverID: delegation.CaregiverID}, map[string]interface{}{
+			"active":     false,
+			"expired_at": now,
+		})
+		if err != nil {
+			helpers.ReportErrorToSentry(err)
+			failed = append(failed, fmt.Sprintf("%s/%s", delegation.CaregiverID, delegation.ClientID))
+			continue
+		}
+
+		delegation.ExpiredAt = &now
+		expired++
+
+		common.RecordAuditLog(ctx, us.Create, &domain.AuditLog{
+			RecordType:     enums.AuditLogCaregiverDelegationChange,
+			Notes:          fmt.Sprintf("caregiver %s's access expired", delegation.CaregiverID),
+			TargetID:       delegation.ClientID,
+			TargetType:     enums.AuditLogTargetClient,
+			ProgramID:      delegation.ProgramID,
+			OrganisationID: delegation.OrganisationID,
+			After:          map[string]interface{}{"caregiver_id": delegation.CaregiverID, "ends_at": delegation.EndsAt},
+		})
+
+		us.notifyCaregiverAccessEnded(ctx, delegation)
+	}
+
+	if len(failed) > 0 {
+		return expired, fmt.Errorf("failed to expire %d of %d caregiver delegations: %s", len(failed), len(delegations), strings.Join(failed, ", "))
+	}
+
+	return expired, nil
+}
+
+// notifyCaregiverAccessEnded lets both the client and the caregiver know that the caregiver can no longer act on the client's behalf
+func (us *UseCasesUserImpl) notifyCaregiverAccessEnded(ctx context.Context, delegation *domain.CaregiverClient) {
+	clientProfile, err := us.Query.GetClientProfileByClientID(ctx, delegation.ClientID)
+	if err != nil {
+		helpers.ReportErrorToSentry(fmt.Errorf("failed to get client %s to notify of ended caregiver access: %w", delegation.ClientID, err))
+		return
+	}
+
+	caregiverProfile, err := us.Query.GetCaregiverProfileByCaregiverID(ctx, delegation.CaregiverID)
+	if err != nil {
+		helpers.ReportErrorToSentry(fmt.Errorf("failed to get caregiver %s to notify of ended caregiver access: %w", delegation.CaregiverID, err))
+		return
+	}
+
+	for _, recipient := range []*domain.User{clientProfile.User, &caregiverProfile.User} {
+		accessNotification := notification.ComposeClientNotification(enums.NotificationTypeCaregiverAccessEnded, notification.ClientNotificationInput{
+			CaregiverDelegation: delegation,
+			Caregiver:           &caregiverProfile.User,
+			Client:              clientProfile.User,
+			ToCaregiver:         recipient == &caregiverProfile.User,
+		})
+
+		if err := us.Notification.NotifyUser(ctx, recipient, accessNotification); err != nil {
+			helpers.ReportErrorToSentry(err)
+		}
+	}
+}
@@ -0,0 +1,427 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	extensionMock "github.com/savannahghi/mycarehub/pkg/mycarehub/application/extension/mock"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	pgMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/mock"
+	clinicalMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/clinical/mock"
+	matrixMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/matrix/mock"
+	pubsubMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/pubsub/mock"
+	smsMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/sms/mock"
+	surveysMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/surveys/mock"
+	twilioMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/twilio/mock"
+	authorityMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/authority/mock"
+	notificationMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/notification/mock"
+	otpMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/otp/mock"
+	"github.com/savannahghi/scalarutils"
+)
+
+func Test_caregiverDelegation(t *testing.T) {
+	now := time.Now()
+	tomorrow := now.AddDate(0, 0, 1)
+	nextMonth := now.AddDate(0, 1, 0)
+
+	startDate := scalarutils.Date{Year: tomorrow.Year(), Month: int(tomorrow.Month()), Day: tomorrow.Day()}
+	endDate := scalarutils.Date{Year: nextMonth.Year(), Month: int(nextMonth.Month()), Day: nextMonth.Day()}
+
+	tests := []struct {
+		name          string
+		input         dto.ClientCaregiverInput
+		wantAbilities int
+		wantEnd       bool
+		wantErr       bool
+	}{
+		{
+			name:          "Happy case: delegate all abilities indefinitely when none are chosen",
+			input:         dto.ClientCaregiverInput{},
+			wantAbilities: len(enums.AllCaregiverAbilities),
+		},
+		{
+			name: "Happy case: delegate chosen abilities for a period",
+			input: dto.ClientCaregiverInput{
+				StartsAt:  &startDate,
+				EndsAt:    &endDate,
+				Abilities: []enums.CaregiverAbility{enums.CaregiverAbilityViewAppointments},
+			},
+			wantAbilities: 1,
+			wantEnd:       true,
+		},
+		{
+			name: "Sad case: delegation ends before it starts",
+			input: dto.ClientCaregiverInput{
+				StartsAt: &endDate,
+				EndsAt:   &startDate,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: invalid ability",
+			input: dto.ClientCaregiverInput{
+				Abilities: []enums.CaregiverAbility{"MANAGE_EVERYTHING"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			startsAt, endsAt, abilities, err := caregiverDelegation(context.Background(), tt.input, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("caregiverDelegation() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if startsAt == nil {
+				t.Errorf("expected the delegation to have a start date")
+			}
+			if (endsAt != nil) != tt.wantEnd {
+				t.Errorf("caregiverDelegation() endsAt = %v, wantEnd %v", endsAt, tt.wantEnd)
+			}
+			if len(abilities) != tt.wantAbilities {
+				t.Errorf("caregiverDelegation() abilities = %v, want %d abilities", abilities, tt.wantAbilities)
+			}
+		})
+	}
+}
+
+func TestUseCasesUserImpl_RevokeCaregiverDelegation(t *testing.T) {
+	clientID := uuid.New().String()
+	caregiverID := uuid.New().String()
+	clientUserID := uuid.New().String()
+
+	tests := []struct {
+		name    string
+		want    bool
+		wantErr bool
+	}{
+		{
+			name:    "Happy case: revoke caregiver delegation",
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name:    "Happy case: revoke caregiver delegation, unable to notify",
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name:    "Sad case: unable to get logged in user",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to get client profile",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: logged in user is not the client",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to get caregiver delegation",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: client not managed by caregiver",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: delegation already revoked",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to revoke delegation",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeOTP := otpMock.NewOTPUseCaseMock()
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			fakePubsub := pubsubMock.NewPubsubServiceMock()
+			fakeClinical := clinicalMock.NewClinicalServiceMock()
+			fakeSMS := smsMock.NewSMSServiceMock()
+			fakeTwilio := twilioMock.NewTwilioServiceMock()
+			fakeMatrix := matrixMock.NewMatrixMock()
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+
+			us := NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
+
+			fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+				return clientUserID, nil
+			}
+			fakeDB.MockGetClientProfileByClientIDFn = func(ctx context.Context, id string) (*domain.ClientProfile, error) {
+				return &domain.ClientProfile{ID: &id, UserID: clientUserID, User: &domain.User{ID: &clientUserID, Name: "client"}}, nil
+			}
+
+			var updateData map[string]interface{}
+			fakeDB.MockUpdateCaregiverClientFn = func(ctx context.Context, caregiverClient *domain.CaregiverClient, data map[string]interface{}) error {
+				updateData = data
+				return nil
+			}
+
+			notified := 0
+			fakeNotification.MockNotifyUserFn = func(ctx context.Context, userProfile *domain.User, notificationPayload *domain.Notification) error {
+				notified++
+				return nil
+			}
+
+			if tt.name == "Happy case: revoke caregiver delegation, unable to notify" {
+				fakeNotification.MockNotifyUserFn = func(ctx context.Context, userProfile *domain.User, notificationPayload *domain.Notification) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get logged in user" {
+				fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+					return "", fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get client profile" {
+				fakeDB.MockGetClientProfileByClientIDFn = func(ctx context.Context, id string) (*domain.ClientProfile, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: logged in user is not the client" {
+				fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+					return uuid.New().String(), nil
+				}
+			}
+			if tt.name == "Sad case: unable to get caregiver delegation" {
+				fakeDB.MockGetCaregiversClientFn = func(ctx context.Context, caregiverClient domain.CaregiverClient) ([]*domain.CaregiverClient, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: client not managed by caregiver" {
+				fakeDB.MockGetCaregiversClientFn = func(ctx context.Context, caregiverClient domain.CaregiverClient) ([]*domain.CaregiverClient, error) {
+					return []*domain.CaregiverClient{}, nil
+				}
+			}
+			if tt.name == "Sad case: delegation already revoked" {
+				fakeDB.MockGetCaregiversClientFn = func(ctx context.Context, caregiverClient domain.CaregiverClient) ([]*domain.CaregiverClient, error) {
+					revokedAt := time.Now().Add(-time.Hour)
+					return []*domain.CaregiverClient{{ClientID: clientID, CaregiverID: caregiverID, RevokedAt: &revokedAt}}, nil
+				}
+			}
+			if tt.name == "Sad case: unable to revoke delegation" {
+				fakeDB.MockUpdateCaregiverClientFn = func(ctx context.Context, caregiverClient *domain.CaregiverClient, data map[string]interface{}) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := us.RevokeCaregiverDelegation(context.Background(), clientID, caregiverID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesUserImpl.RevokeCaregiverDelegation() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UseCasesUserImpl.RevokeCaregiverDelegation() = %v, want %v", got, tt.want)
+			}
+
+			if tt.name == "Happy case: revoke caregiver delegation" {
+				if updateData["revoked_by"] != clientUserID {
+					t.Errorf("expected the delegation to be revoked by the client, got %v", updateData)
+				}
+				if notified != 2 {
+					t.Errorf("expected both the client and the caregiver to be notified, got %d notifications", notified)
+				}
+			}
+		})
+	}
+}
+
+func TestUseCasesUserImpl_EndClientCaregiverAccess(t *testing.T) {
+	organisationID := uuid.New().String()
+	staffUserID := uuid.New().String()
+
+	nextMonth := time.Now().AddDate(0, 1, 0)
+	futureDate := scalarutils.Date{Year: nextMonth.Year(), Month: int(nextMonth.Month()), Day: nextMonth.Day()}
+	lastMonth := time.Now().AddDate(0, -1, 0)
+	pastDate := scalarutils.Date{Year: lastMonth.Year(), Month: int(lastMonth.Month()), Day: lastMonth.Day()}
+
+	tests := []struct {
+		name    string
+		endDate scalarutils.Date
+		want    int
+		wantErr bool
+	}{
+		{
+			name:    "Happy case: end client's caregiver access",
+			endDate: futureDate,
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name:    "Sad case: end date has passed",
+			endDate: pastDate,
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: client belongs to another organisation",
+			endDate: futureDate,
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to end client's caregiver access",
+			endDate: futureDate,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeOTP := otpMock.NewOTPUseCaseMock()
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			fakePubsub := pubsubMock.NewPubsubServiceMock()
+			fakeClinical := clinicalMock.NewClinicalServiceMock()
+			fakeSMS := smsMock.NewSMSServiceMock()
+			fakeTwilio := twilioMock.NewTwilioServiceMock()
+			fakeMatrix := matrixMock.NewMatrixMock()
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+
+			us := NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
+
+			fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+				return staffUserID, nil
+			}
+			fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, id string) (*domain.User, error) {
+				return &domain.User{ID: &id, CurrentOrganizationID: organisationID}, nil
+			}
+			fakeDB.MockGetClientProfileByClientIDFn = func(ctx context.Context, id string) (*domain.ClientProfile, error) {
+				return &domain.ClientProfile{ID: &id, OrganisationID: organisationID}, nil
+			}
+
+			var auditLog *domain.AuditLog
+			fakeDB.MockCreateAuditLogFn = func(ctx context.Context, log *domain.AuditLog) error {
+				auditLog = log
+				return nil
+			}
+
+			if tt.name == "Sad case: client belongs to another organisation" {
+				fakeDB.MockGetClientProfileByClientIDFn = func(ctx context.Context, id string) (*domain.ClientProfile, error) {
+					return &domain.ClientProfile{ID: &id, OrganisationID: uuid.New().String()}, nil
+				}
+			}
+			if tt.name == "Sad case: unable to end client's caregiver access" {
+				fakeDB.MockEndClientCaregiverDelegationsFn = func(ctx context.Context, clientID string, endsAt time.Time) (int, error) {
+					return 0, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := us.EndClientCaregiverAccess(context.Background(), uuid.New().String(), tt.endDate)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesUserImpl.EndClientCaregiverAccess() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UseCasesUserImpl.EndClientCaregiverAccess() = %v, want %v", got, tt.want)
+			}
+
+			if !tt.wantErr && (auditLog == nil || auditLog.RecordType != enums.AuditLogCaregiverDelegationChange) {
+				t.Errorf("expected the change to the client's caregivers' access to be audited, got %v", auditLog)
+			}
+		})
+	}
+}
+
+func TestUseCasesUserImpl_ExpireCaregiverDelegations(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    int
+		wantErr bool
+	}{
+		{
+			name:    "Happy case: expire caregiver delegations",
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name:    "Happy case: no expired caregiver delegations",
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name:    "Happy case: unable to get the caregiver to notify",
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name:    "Sad case: unable to list expired caregiver delegations",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to expire caregiver delegation",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeOTP := otpMock.NewOTPUseCaseMock()
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			fakePubsub := pubsubMock.NewPubsubServiceMock()
+			fakeClinical := clinicalMock.NewClinicalServiceMock()
+			fakeSMS := smsMock.NewSMSServiceMock()
+			fakeTwilio := twilioMock.NewTwilioServiceMock()
+			fakeMatrix := matrixMock.NewMatrixMock()
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+
+			us := NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
+
+			var updateData map[string]interface{}
+			fakeDB.MockUpdateCaregiverClientFn = func(ctx context.Context, caregiverClient *domain.CaregiverClient, data map[string]interface{}) error {
+				updateData = data
+				return nil
+			}
+
+			if tt.name == "Happy case: no expired caregiver delegations" {
+				fakeDB.MockListExpiredCaregiverDelegationsFn = func(ctx context.Context, now time.Time) ([]*domain.CaregiverClient, error) {
+					return []*domain.CaregiverClient{}, nil
+				}
+			}
+			if tt.name == "Happy case: unable to get the caregiver to notify" {
+				fakeDB.MockGetCaregiverProfileByCaregiverIDFn = func(ctx context.Context, caregiverID string) (*domain.CaregiverProfile, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to list expired caregiver delegations" {
+				fakeDB.MockListExpiredCaregiverDelegationsFn = func(ctx context.Context, now time.Time) ([]*domain.CaregiverClient, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to expire caregiver delegation" {
+				fakeDB.MockUpdateCaregiverClientFn = func(ctx context.Context, caregiverClient *domain.CaregiverClient, data map[string]interface{}) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := us.ExpireCaregiverDelegations(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesUserImpl.ExpireCaregiverDelegations() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UseCasesUserImpl.ExpireCaregiverDelegations() = %v, want %v", got, tt.want)
+			}
+
+			if tt.name == "Happy case: expire caregiver delegations" && updateData["expired_at"] == nil {
+				t.Errorf("expected the delegation to be marked as expired, got %v", updateData)
+			}
+		})
+	}
+}
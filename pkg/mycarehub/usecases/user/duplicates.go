@@ -0,0 +1,154 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/common"
+)
+
+// duplicateClientMatchScores is how much each detail that two clients share counts towards them being the same person.
+// A CCC number is unique to a patient so it counts the most while a name is commonly shared
+var duplicateClientMatchScores = map[enums.DuplicateClientMatch]int{
+	enums.DuplicateClientMatchCCCNumber:   50,
+	enums.DuplicateClientMatchPhoneNumber: 25,
+	enums.DuplicateClientMatchName:        15,
+	enums.DuplicateClientMatchDateOfBirth: 10,
+}
+
+// minimumDuplicateClientScore is the score a client needs to be reported as a possible duplicate.
+// It leaves out clients that only share a name with the client
+const minimumDuplicateClientScore = 25
+
+// FindDuplicateClients returns the clients in the client's program that may be the same person as the client,
+// with the ones most likely to be duplicates first. The clients are compared by CCC number, phone number, name and date of birth.
+// Only clients in the same program are returned since clients in different programs cannot be merged
+func (us *UseCasesUserImpl) FindDuplicateClients(ctx context.Context, clientID string) ([]*domain.DuplicateClient, error) {
+	ctx, span := tracer.Start(ctx, "FindDuplicateClients")
+	defer span.End()
+
+	clientProfile, err := us.organisationClientProfile(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := us.Query.ListDuplicateClientCandidates(ctx, *clientProfile.ID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InternalErr(fmt.Errorf("failed to list duplicate client candidates: %w", err))
+	}
+
+	duplicates := []*domain.DuplicateClient{}
+	for _, candidate := range candidates {
+		for _, match := range candidate.Matches {
+			candidate.Score += duplicateClientMatchScores[match]
+		}
+
+		if candidate.Score < minimumDuplicateClientScore {
+			continue
+		}
+
+		candidate.Client, err = us.Query.GetClientProfileByClientID(ctx, *candidate.Client.ID)
+		if err != nil {
+			helpers.ReportErrorToSentry(err)
+			return nil, exceptions.ClientProfileNotFoundErr(err)
+		}
+
+		duplicates = append(duplicates, candidate)
+	}
+
+	sort.SliceStable(duplicates, func(i, j int) bool {
+		return duplicates[i].Score > duplicates[j].Score
+	})
+
+	return duplicates, nil
+}
+
+// MergeClients moves the health diary entries, service requests, appointments, screening tool responses, bookings, caregivers
+// and facilities of a duplicate client to the surviving client. The duplicate client is deactivated and keeps their identifiers
+// so that the merge can be traced back. Both clients must belong to the same program in the logged in staff's organisation and
+// the surviving client must be active
+func (us *UseCasesUserImpl) MergeClients(ctx context.Context, survivingClientID string, duplicateClientID string) (*domain.ClientMerge, error) {
+	ctx, span := tracer.Start(ctx, "MergeClients")
+	defer span.End()
+
+	if survivingClientID == duplicateClientID {
+		return nil, exceptions.InputValidationErr(fmt.Errorf("a client cannot be merged into themselves"))
+	}
+
+	survivingClient, err := us.organisationClientProfile(ctx, survivingClientID)
+	if err != nil {
+		return nil, err
+	}
+
+	duplicateClient, err := us.organisationClientProfile(ctx, duplicateClientID)
+	if err != nil {
+		return nil, err
+	}
+
+	if survivingClient.ProgramID != duplicateClient.ProgramID {
+		return nil, exceptions.InputValidationErr(fmt.Errorf("clients %s and %s belong to different programs", survivingClientID, duplicateClientID))
+	}
+
+	if !survivingClient.Active || survivingClient.ErasureRequestedAt != nil {
+		return nil, exceptions.InputValidationErr(fmt.Errorf("client %s is inactive or has been deleted and cannot be merged into", survivingClientID))
+	}
+
+	if !duplicateClient.Active {
+		return nil, exceptions.InputValidationErr(fmt.Errorf("client %s is inactive and may have already been merged", duplicateClientID))
+	}
+
+	merge, err := us.Update.MergeClients(ctx, *survivingClient.ID, *duplicateClient.ID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InternalErr(fmt.Errorf("failed to merge clients: %w", err))
+	}
+
+	common.RecordAuditLog(ctx, us.Create, &domain.AuditLog{
+		RecordType:     enums.AuditLogClientMerge,
+		Notes:          fmt.Sprintf("client %s merged into client %s", duplicateClientID, survivingClientID),
+		TargetID:       survivingClientID,
+		TargetType:     enums.AuditLogTargetClient,
+		ProgramID:      survivingClient.ProgramID,
+		OrganisationID: survivingClient.OrganisationID,
+		Before:         map[string]interface{}{"duplicate_client_id": duplicateClientID},
+		After: map[string]interface{}{
+			"health_diary_entries":     merge.HealthDiaryEntries,
+			"service_requests":         merge.ServiceRequests,
+			"appointments":             merge.Appointments,
+			"screening_tool_responses": merge.ScreeningToolResponses,
+			"service_bookings":         merge.ServiceBookings,
+			"caregiver_links":          merge.CaregiverLinks,
+			"facilities":               merge.Facilities,
+		},
+	})
+
+	return merge, nil
+}
+
+// organisationClientProfile retrieves a client's profile after checking that the client belongs to the logged in staff's organisation
+func (us *UseCasesUserImpl) organisationClientProfile(ctx context.Context, clientID string) (*domain.ClientProfile, error) {
+	loggedInUser, err := us.loggedInStaffUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	clientProfile, err := us.Query.GetClientProfileByClientID(ctx, clientID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.ClientProfileNotFoundErr(err)
+	}
+
+	if clientProfile.OrganisationID != loggedInUser.CurrentOrganizationID {
+		err := fmt.Errorf("client %s does not belong to the organisation of staff %s", clientID, *loggedInUser.ID)
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.UserNotAuthorizedErr(err)
+	}
+
+	return clientProfile, nil
+}
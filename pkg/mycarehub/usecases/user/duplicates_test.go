@@ -0,0 +1,325 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	extensionMock "github.com/savannahghi/mycarehub/pkg/mycarehub/application/extension/mock"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	pgMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/mock"
+	clinicalMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/clinical/mock"
+	matrixMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/matrix/mock"
+	pubsubMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/pubsub/mock"
+	smsMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/sms/mock"
+	surveysMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/surveys/mock"
+	twilioMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/twilio/mock"
+	authorityMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/authority/mock"
+	notificationMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/notification/mock"
+	otpMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/otp/mock"
+)
+
+func TestUseCasesUserImpl_FindDuplicateClients(t *testing.T) {
+	staffUserID := uuid.New().String()
+	organisationID := uuid.New().String()
+	clientID := uuid.New().String()
+	cccDuplicateID := uuid.New().String()
+	nameDuplicateID := uuid.New().String()
+	namesakeID := uuid.New().String()
+
+	tests := []struct {
+		name    string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "Happy case: find duplicate clients",
+			want:    []string{cccDuplicateID, nameDuplicateID},
+			wantErr: false,
+		},
+		{
+			name:    "Sad case: unable to get logged in user",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to get client profile",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: client belongs to a different organisation",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to list duplicate client candidates",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to get duplicate client profile",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeOTP := otpMock.NewOTPUseCaseMock()
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			fakePubsub := pubsubMock.NewPubsubServiceMock()
+			fakeClinical := clinicalMock.NewClinicalServiceMock()
+			fakeSMS := smsMock.NewSMSServiceMock()
+			fakeTwilio := twilioMock.NewTwilioServiceMock()
+			fakeMatrix := matrixMock.NewMatrixMock()
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+
+			us := NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
+
+			fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+				return staffUserID, nil
+			}
+			fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, id string) (*domain.User, error) {
+				return &domain.User{ID: &id, CurrentOrganizationID: organisationID}, nil
+			}
+			fakeDB.MockGetClientProfileByClientIDFn = func(ctx context.Context, id string) (*domain.ClientProfile, error) {
+				return &domain.ClientProfile{ID: &id, Active: true, OrganisationID: organisationID}, nil
+			}
+			fakeDB.MockListDuplicateClientCandidatesFn = func(ctx context.Context, id string) ([]*domain.DuplicateClient, error) {
+				return []*domain.DuplicateClient{
+					{
+						Client:  &domain.ClientProfile{ID: &namesakeID},
+						Matches: []enums.DuplicateClientMatch{enums.DuplicateClientMatchName},
+					},
+					{
+						Client:  &domain.ClientProfile{ID: &nameDuplicateID},
+						Matches: []enums.DuplicateClientMatch{enums.DuplicateClientMatchName, enums.DuplicateClientMatchDateOfBirth},
+					},
+					{
+						Client:  &domain.ClientProfile{ID: &cccDuplicateID},
+						Matches: []enums.DuplicateClientMatch{enums.DuplicateClientMatchCCCNumber, enums.DuplicateClientMatchPhoneNumber},
+					},
+				}, nil
+			}
+
+			if tt.name == "Sad case: unable to get logged in user" {
+				fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+					return "", fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get client profile" {
+				fakeDB.MockGetClientProfileByClientIDFn = func(ctx context.Context, id string) (*domain.ClientProfile, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: client belongs to a different organisation" {
+				fakeDB.MockGetClientProfileByClientIDFn = func(ctx context.Context, id string) (*domain.ClientProfile, error) {
+					return &domain.ClientProfile{ID: &id, Active: true, OrganisationID: uuid.New().String()}, nil
+				}
+			}
+			if tt.name == "Sad case: unable to list duplicate client candidates" {
+				fakeDB.MockListDuplicateClientCandidatesFn = func(ctx context.Context, id string) ([]*domain.DuplicateClient, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get duplicate client profile" {
+				fakeDB.MockGetClientProfileByClientIDFn = func(ctx context.Context, id string) (*domain.ClientProfile, error) {
+					if id == clientID {
+						return &domain.ClientProfile{ID: &id, Active: true, OrganisationID: organisationID}, nil
+					}
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := us.FindDuplicateClients(context.Background(), clientID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesUserImpl.FindDuplicateClients() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("UseCasesUserImpl.FindDuplicateClients() got %d duplicates, want %d", len(got), len(tt.want))
+			}
+			for i, duplicate := range got {
+				if *duplicate.Client.ID != tt.want[i] {
+					t.Errorf("UseCasesUserImpl.FindDuplicateClients() duplicate %d = %s, want %s", i, *duplicate.Client.ID, tt.want[i])
+				}
+			}
+			if got[0].Score != 75 {
+				t.Errorf("UseCasesUserImpl.FindDuplicateClients() score = %d, want 75", got[0].Score)
+			}
+		})
+	}
+}
+
+func TestUseCasesUserImpl_MergeClients(t *testing.T) {
+	staffUserID := uuid.New().String()
+	organisationID := uuid.New().String()
+	survivingClientID := uuid.New().String()
+	duplicateClientID := uuid.New().String()
+
+	tests := []struct {
+		name              string
+		duplicateClientID string
+		wantErr           bool
+	}{
+		{
+			name:              "Happy case: merge clients",
+			duplicateClientID: duplicateClientID,
+			wantErr:           false,
+		},
+		{
+			name:              "Sad case: merge a client into themselves",
+			duplicateClientID: survivingClientID,
+			wantErr:           true,
+		},
+		{
+			name:              "Sad case: unable to get surviving client profile",
+			duplicateClientID: duplicateClientID,
+			wantErr:           true,
+		},
+		{
+			name:              "Sad case: unable to get duplicate client profile",
+			duplicateClientID: duplicateClientID,
+			wantErr:           true,
+		},
+		{
+			name:              "Sad case: duplicate client belongs to a different organisation",
+			duplicateClientID: duplicateClientID,
+			wantErr:           true,
+		},
+		{
+			name:              "Sad case: duplicate client is inactive",
+			duplicateClientID: duplicateClientID,
+			wantErr:           true,
+		},
+		{
+			name:              "Sad case: clients belong to different programs",
+			duplicateClientID: duplicateClientID,
+			wantErr:           true,
+		},
+		{
+			name:              "Sad case: surviving client is inactive",
+			duplicateClientID: duplicateClientID,
+			wantErr:           true,
+		},
+		{
+			name:              "Sad case: surviving client has been deleted",
+			duplicateClientID: duplicateClientID,
+			wantErr:           true,
+		},
+		{
+			name:              "Sad case: unable to merge clients",
+			duplicateClientID: duplicateClientID,
+			wantErr:           true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeOTP := otpMock.NewOTPUseCaseMock()
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			fakePubsub := pubsubMock.NewPubsubServiceMock()
+			fakeClinical := clinicalMock.NewClinicalServiceMock()
+			fakeSMS := smsMock.NewSMSServiceMock()
+			fakeTwilio := twilioMock.NewTwilioServiceMock()
+			fakeMatrix := matrixMock.NewMatrixMock()
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+
+			us := NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
+
+			fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+				return staffUserID, nil
+			}
+			fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, id string) (*domain.User, error) {
+				return &domain.User{ID: &id, CurrentOrganizationID: organisationID}, nil
+			}
+			fakeDB.MockGetClientProfileByClientIDFn = func(ctx context.Context, id string) (*domain.ClientProfile, error) {
+				return &domain.ClientProfile{ID: &id, Active: true, OrganisationID: organisationID}, nil
+			}
+
+			var auditLog *domain.AuditLog
+			fakeDB.MockCreateAuditLogFn = func(ctx context.Context, log *domain.AuditLog) error {
+				auditLog = log
+				return nil
+			}
+
+			if tt.name == "Sad case: unable to get surviving client profile" {
+				fakeDB.MockGetClientProfileByClientIDFn = func(ctx context.Context, id string) (*domain.ClientProfile, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get duplicate client profile" {
+				fakeDB.MockGetClientProfileByClientIDFn = func(ctx context.Context, id string) (*domain.ClientProfile, error) {
+					if id == duplicateClientID {
+						return nil, fmt.Errorf("an error occurred")
+					}
+					return &domain.ClientProfile{ID: &id, Active: true, OrganisationID: organisationID}, nil
+				}
+			}
+			if tt.name == "Sad case: duplicate client belongs to a different organisation" {
+				fakeDB.MockGetClientProfileByClientIDFn = func(ctx context.Context, id string) (*domain.ClientProfile, error) {
+					if id == duplicateClientID {
+						return &domain.ClientProfile{ID: &id, Active: true, OrganisationID: uuid.New().String()}, nil
+					}
+					return &domain.ClientProfile{ID: &id, Active: true, OrganisationID: organisationID}, nil
+				}
+			}
+			if tt.name == "Sad case: duplicate client is inactive" {
+				fakeDB.MockGetClientProfileByClientIDFn = func(ctx context.Context, id string) (*domain.ClientProfile, error) {
+					return &domain.ClientProfile{ID: &id, Active: id != duplicateClientID, OrganisationID: organisationID}, nil
+				}
+			}
+			if tt.name == "Sad case: clients belong to different programs" {
+				fakeDB.MockGetClientProfileByClientIDFn = func(ctx context.Context, id string) (*domain.ClientProfile, error) {
+					return &domain.ClientProfile{ID: &id, Active: true, OrganisationID: organisationID, ProgramID: id}, nil
+				}
+			}
+			if tt.name == "Sad case: surviving client is inactive" {
+				fakeDB.MockGetClientProfileByClientIDFn = func(ctx context.Context, id string) (*domain.ClientProfile, error) {
+					return &domain.ClientProfile{ID: &id, Active: id != survivingClientID, OrganisationID: organisationID}, nil
+				}
+			}
+			if tt.name == "Sad case: surviving client has been deleted" {
+				fakeDB.MockGetClientProfileByClientIDFn = func(ctx context.Context, id string) (*domain.ClientProfile, error) {
+					profile := &domain.ClientProfile{ID: &id, Active: true, OrganisationID: organisationID}
+					if id == survivingClientID {
+						requestedAt := time.Now()
+						profile.ErasureRequestedAt = &requestedAt
+					}
+					return profile, nil
+				}
+			}
+			if tt.name == "Sad case: unable to merge clients" {
+				fakeDB.MockMergeClientsFn = func(ctx context.Context, survivingClientID string, duplicateClientID string) (*domain.ClientMerge, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := us.MergeClients(context.Background(), survivingClientID, tt.duplicateClientID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesUserImpl.MergeClients() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if auditLog != nil {
+					t.Errorf("UseCasesUserImpl.MergeClients() expected no audit log to be recorded")
+				}
+				return
+			}
+
+			if got.SurvivingClientID != survivingClientID || got.DuplicateClientID != duplicateClientID {
+				t.Errorf("UseCasesUserImpl.MergeClients() got = %v", got)
+			}
+			if auditLog == nil || auditLog.RecordType != enums.AuditLogClientMerge || auditLog.TargetID != survivingClientID {
+				t.Errorf("UseCasesUserImpl.MergeClients() expected the merge to be recorded in the audit log, got %v", auditLog)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/common"
+	"github.com/savannahghi/serverutils"
+)
+
+// PurgeClientsDueForErasure anonymizes the clients who asked for their data to be erased once their organisation's data retention period has passed.
+// It is meant to be run on a schedule. A client who cannot be erased is skipped and retried the next time the purge runs.
+// It returns the number of clients that were erased
+func (us *UseCasesUserImpl) PurgeClientsDueForErasure(ctx context.Context) (int, error) {
+	ctx, span := tracer.Start(ctx, "PurgeClientsDueForErasure")
+	defer span.End()
+
+	clients, err := us.Query.ListClientsDueForErasure(ctx, time.Now())
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return 0, exceptions.InternalErr(fmt.Errorf("failed to list clients due for erasure: %w", err))
+	}
+
+	erased := 0
+	failed := []string{}
+	for _, client := range clients {
+		if err := us.eraseClient(ctx, *client.ID); err != nil {
+			helpers.ReportErrorToSentry(err)
+			failed = append(failed, *client.ID)
+			continue
+		}
+		erased++
+	}
+
+	if len(failed) > 0 {
+		return erased, fmt.Errorf("failed to erase %d of %d clients: %s", len(failed), len(clients), strings.Join(failed, ", "))
+	}
+
+	return erased, nil
+}
+
+// eraseClient removes a client from the clinical service, the CMS and Matrix before anonymizing the personal information held about them.
+// The other services are updated first since the client's phone number and username are needed to find them.
+// The clinical service can only delete a FHIR patient so the patient and their clinical records are deleted rather than anonymized.
+// Anonymizing the FHIR patient in place is out of scope until the clinical service exposes an endpoint for it
+func (us *UseCasesUserImpl) eraseClient(ctx context.Context, clientID string) error {
+	clientProfile, err := us.Query.GetClientProfileByClientID(ctx, clientID)
+	if err != nil {
+		return fmt.Errorf("failed to get client %s: %w", clientID, err)
+	}
+
+	userID, err := us.erasableUserID(ctx, clientProfile)
+	if err != nil {
+		return fmt.Errorf("failed to check the other profiles of client %s: %w", clientID, err)
+	}
+
+	if clientProfile.FHIRPatientID != nil {
+		phone, err := us.Query.GetContactByUserID(ctx, &clientProfile.UserID, "PHONE")
+		if err != nil {
+			return fmt.Errorf("failed to get the phone number of client %s: %w", clientID, err)
+		}
+
+		if err := us.Clinical.DeleteFHIRPatientByPhone(ctx, phone.ContactValue); err != nil {
+			return fmt.Errorf("failed to delete the FHIR patient of client %s: %w", clientID, err)
+		}
+	}
+
+	err = us.Pubsub.NotifyDeleteCMSClient(ctx, &dto.DeleteCMSUserPayload{UserID: clientProfile.UserID})
+	if err != nil {
+		return fmt.Errorf("failed to delete client %s from the CMS: %w", clientID, err)
+	}
+
+	if userID != nil {
+		auth := &domain.MatrixAuth{
+			Username: serverutils.MustGetEnvVar("MCH_MATRIX_USER"),
+			Password: serverutils.MustGetEnvVar("MCH_MATRIX_PASSWORD"),
+		}
+
+		matrixUserID := fmt.Sprintf("@%s:%s", clientProfile.User.Username, serverutils.MustGetEnvVar("MATRIX_DOMAIN"))
+
+		if err := us.Matrix.DeactivateUser(ctx, matrixUserID, auth); err != nil {
+			return fmt.Errorf("failed to deactivate the matrix account of client %s: %w", clientID, err)
+		}
+	}
+
+	if err := us.Delete.AnonymizeClientProfile(ctx, clientID, userID); err != nil {
+		return fmt.Errorf("failed to anonymize client %s: %w", clientID, err)
+	}
+
+	common.RecordAuditLog(ctx, us.Create, &domain.AuditLog{
+		RecordType:     enums.AuditLogClientErasure,
+		Notes:          "client personal information anonymized",
+		TargetID:       clientID,
+		TargetType:     enums.AuditLogTargetClient,
+		ProgramID:      clientProfile.ProgramID,
+		OrganisationID: clientProfile.OrganisationID,
+		Before:         map[string]interface{}{"erasure_requested_at": clientProfile.ErasureRequestedAt},
+		After:          map[string]interface{}{"user_anonymized": userID != nil},
+	})
+
+	return nil
+}
+
+// erasableUserID returns the ID of the client's user when the client profile is their only profile.
+// A user who also has a staff or caregiver profile or another client profile keeps their user account
+func (us *UseCasesUserImpl) erasableUserID(ctx context.Context, clientProfile *domain.ClientProfile) (*string, error) {
+	clientProfiles, err := us.Query.GetUserClientProfiles(ctx, clientProfile.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	staffProfiles, err := us.Query.GetUserStaffProfiles(ctx, clientProfile.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	caregiverProfiles, err := us.Query.SearchCaregiverUser(ctx, clientProfile.User.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(clientProfiles) == 1 && len(staffProfiles) == 0 && len(caregiverProfiles) == 0 {
+		return &clientProfile.UserID, nil
+	}
+
+	return nil, nil
+}
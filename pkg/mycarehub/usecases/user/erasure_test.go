@@ -0,0 +1,237 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	extensionMock "github.com/savannahghi/mycarehub/pkg/mycarehub/application/extension/mock"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	pgMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/mock"
+	clinicalMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/clinical/mock"
+	matrixMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/matrix/mock"
+	pubsubMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/pubsub/mock"
+	smsMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/sms/mock"
+	surveysMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/surveys/mock"
+	twilioMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/twilio/mock"
+	authorityMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/authority/mock"
+	notificationMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/notification/mock"
+	otpMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/otp/mock"
+)
+
+func TestUseCasesUserImpl_PurgeClientsDueForErasure(t *testing.T) {
+	fhirPatientID := uuid.New().String()
+
+	tests := []struct {
+		name    string
+		want    int
+		wantErr bool
+	}{
+		{
+			name:    "Happy case: erase client",
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name:    "Happy case: erase client with a FHIR patient and no other profile",
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name:    "Happy case: no clients due for erasure",
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name:    "Sad case: unable to list clients due for erasure",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to get client profile",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to check the client's other profiles",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to get client's phone number",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to delete FHIR patient",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to delete client from the CMS",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to deactivate matrix user",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to anonymize client",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeOTP := otpMock.NewOTPUseCaseMock()
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			fakePubsub := pubsubMock.NewPubsubServiceMock()
+			fakeClinical := clinicalMock.NewClinicalServiceMock()
+			fakeSMS := smsMock.NewSMSServiceMock()
+			fakeTwilio := twilioMock.NewTwilioServiceMock()
+			fakeMatrix := matrixMock.NewMatrixMock()
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+
+			us := NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
+
+			fakeDB.MockGetClientProfileByClientIDFn = func(ctx context.Context, id string) (*domain.ClientProfile, error) {
+				userID := uuid.New().String()
+				return &domain.ClientProfile{ID: &id, UserID: userID, User: &domain.User{ID: &userID, Username: "client"}}, nil
+			}
+
+			var anonymizedUserID *string
+			anonymized := false
+			fakeDB.MockAnonymizeClientProfileFn = func(ctx context.Context, clientID string, userID *string) error {
+				anonymized = true
+				anonymizedUserID = userID
+				return nil
+			}
+
+			var auditLog *domain.AuditLog
+			fakeDB.MockCreateAuditLogFn = func(ctx context.Context, log *domain.AuditLog) error {
+				auditLog = log
+				return nil
+			}
+
+			onlyProfile := func() {
+				fakeDB.MockGetUserClientProfilesFn = func(ctx context.Context, userID string) ([]*domain.ClientProfile, error) {
+					return []*domain.ClientProfile{{UserID: userID}}, nil
+				}
+				fakeDB.MockGetUserStaffProfilesFn = func(ctx context.Context, userID string) ([]*domain.StaffProfile, error) {
+					return []*domain.StaffProfile{}, nil
+				}
+				fakeDB.MockSearchCaregiverUserFn = func(ctx context.Context, searchParameter string) ([]*domain.CaregiverProfile, error) {
+					return []*domain.CaregiverProfile{}, nil
+				}
+			}
+
+			withFHIRPatient := func() {
+				fakeDB.MockGetClientProfileByClientIDFn = func(ctx context.Context, id string) (*domain.ClientProfile, error) {
+					userID := uuid.New().String()
+					return &domain.ClientProfile{ID: &id, UserID: userID, FHIRPatientID: &fhirPatientID, User: &domain.User{ID: &userID, Username: "client"}}, nil
+				}
+			}
+
+			deletedFHIRPatient := false
+			fakeClinical.MockDeleteFHIRPatientByPhoneFn = func(ctx context.Context, phoneNumber string) error {
+				deletedFHIRPatient = true
+				return nil
+			}
+
+			if tt.name == "Happy case: erase client" {
+				fakeDB.MockGetUserStaffProfilesFn = func(ctx context.Context, userID string) ([]*domain.StaffProfile, error) {
+					return []*domain.StaffProfile{{UserID: userID}}, nil
+				}
+			}
+			if tt.name == "Happy case: erase client with a FHIR patient and no other profile" {
+				onlyProfile()
+				withFHIRPatient()
+			}
+			if tt.name == "Happy case: no clients due for erasure" {
+				fakeDB.MockListClientsDueForErasureFn = func(ctx context.Context, now time.Time) ([]*domain.ClientProfile, error) {
+					return []*domain.ClientProfile{}, nil
+				}
+			}
+			if tt.name == "Sad case: unable to list clients due for erasure" {
+				fakeDB.MockListClientsDueForErasureFn = func(ctx context.Context, now time.Time) ([]*domain.ClientProfile, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get client profile" {
+				fakeDB.MockGetClientProfileByClientIDFn = func(ctx context.Context, id string) (*domain.ClientProfile, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to check the client's other profiles" {
+				fakeDB.MockGetUserClientProfilesFn = func(ctx context.Context, userID string) ([]*domain.ClientProfile, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get client's phone number" {
+				withFHIRPatient()
+				fakeDB.MockGetContactByUserIDFn = func(ctx context.Context, userID *string, contactType string) (*domain.Contact, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to delete FHIR patient" {
+				withFHIRPatient()
+				fakeClinical.MockDeleteFHIRPatientByPhoneFn = func(ctx context.Context, phoneNumber string) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to delete client from the CMS" {
+				fakePubsub.MockNotifyDeleteCMSClientFn = func(ctx context.Context, user *dto.DeleteCMSUserPayload) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to deactivate matrix user" {
+				onlyProfile()
+				fakeMatrix.MockDeactivateUserFn = func(ctx context.Context, userID string, auth *domain.MatrixAuth) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to anonymize client" {
+				fakeDB.MockAnonymizeClientProfileFn = func(ctx context.Context, clientID string, userID *string) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := us.PurgeClientsDueForErasure(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesUserImpl.PurgeClientsDueForErasure() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UseCasesUserImpl.PurgeClientsDueForErasure() = %v, want %v", got, tt.want)
+			}
+
+			if tt.wantErr {
+				if anonymized && tt.name != "Sad case: unable to anonymize client" {
+					t.Errorf("expected a client whose erasure failed not to be anonymized")
+				}
+				return
+			}
+
+			switch tt.name {
+			case "Happy case: erase client":
+				if !anonymized || anonymizedUserID != nil {
+					t.Errorf("expected only the client profile of a user with other profiles to be anonymized")
+				}
+				if deletedFHIRPatient {
+					t.Errorf("expected a client without a FHIR patient not to be deleted from the clinical service")
+				}
+				if auditLog == nil || auditLog.RecordType != enums.AuditLogClientErasure {
+					t.Errorf("expected the erasure to be audited, got %v", auditLog)
+				}
+			case "Happy case: erase client with a FHIR patient and no other profile":
+				if !anonymized || anonymizedUserID == nil {
+					t.Errorf("expected the user of a client with no other profile to be anonymized")
+				}
+				if !deletedFHIRPatient {
+					t.Errorf("expected the client's FHIR patient to be deleted")
+				}
+			}
+		})
+	}
+}
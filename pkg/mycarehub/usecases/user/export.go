@@ -0,0 +1,391 @@
+package user
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/common"
+)
+
+// clientDataExportFile is a file in a client data export. Every category of data is exported as json and
+// the flat categories are also exported as csv so that they can be opened in a spreadsheet
+type clientDataExportFile struct {
+	name   string
+	data   interface{}
+	header []string
+	rows   [][]string
+}
+
+// clientSurveySubmission is a survey response in a client data export
+type clientSurveySubmission struct {
+	Title       string      `json:"title"`
+	Description string      `json:"description"`
+	ProjectID   int         `json:"projectID"`
+	FormID      string      `json:"formID"`
+	SubmittedAt time.Time   `json:"submittedAt"`
+	Answers     interface{} `json:"answers"`
+}
+
+// clientDataExportManifest describes a client data export
+type clientDataExportManifest struct {
+	ClientID    string    `json:"clientID"`
+	Reason      string    `json:"reason"`
+	RequestedBy string    `json:"requestedBy,omitempty"`
+	ExportedAt  time.Time `json:"exportedAt"`
+	Files       []string  `json:"files"`
+}
+
+// ExportClientData gathers everything held about a client into a zip archive of json and csv files, for example when a client
+// requests their data under the Data Protection Act. It covers the client's profile, identifiers, contacts, health diary entries,
+// service requests, appointments, screening tool and survey responses, notifications, metrics, feedback, consents and caregivers.
+// A staff can only export the data of the clients in their organisation and every export is recorded in the audit log
+func (us *UseCasesUserImpl) ExportClientData(ctx context.Context, input *dto.ClientDataExportInput) (*dto.ClientDataExport, error) {
+	ctx, span := tracer.Start(ctx, "ExportClientData")
+	defer span.End()
+
+	if err := input.Validate(); err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InputValidationErr(fmt.Errorf("failed to validate client data export input: %w", err))
+	}
+
+	var clientProfile *domain.ClientProfile
+	var err error
+
+	// exports from the command line are made by an operator who is identified by name since they are not logged in
+	if input.RequestedBy != "" {
+		clientProfile, err = us.Query.GetClientProfileByClientID(ctx, input.ClientID)
+		if err != nil {
+			helpers.ReportErrorToSentry(err)
+			return nil, exceptions.ClientProfileNotFoundErr(err)
+		}
+	} else {
+		clientProfile, err = us.organisationClientProfile(ctx, input.ClientID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	files, err := us.clientDataExportFiles(ctx, clientProfile)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InternalErr(fmt.Errorf("failed to gather client data: %w", err))
+	}
+
+	exportedAt := time.Now()
+
+	manifest := &clientDataExportManifest{
+		ClientID:    input.ClientID,
+		Reason:      input.Reason,
+		RequestedBy: input.RequestedBy,
+		ExportedAt:  exportedAt,
+	}
+	for _, file := range files {
+		manifest.Files = append(manifest.Files, file.name+".json")
+		if file.header != nil {
+			manifest.Files = append(manifest.Files, file.name+".csv")
+		}
+	}
+	files = append([]*clientDataExportFile{{name: "manifest", data: manifest}}, files...)
+
+	archive, err := writeClientDataExport(files)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InternalErr(fmt.Errorf("failed to write client data export: %w", err))
+	}
+
+	notes := fmt.Sprintf("client data exported: %s", input.Reason)
+	if input.RequestedBy != "" {
+		notes = fmt.Sprintf("client data exported from the command line by %s: %s", input.RequestedBy, input.Reason)
+	}
+
+	common.RecordAuditLog(ctx, us.Create, &domain.AuditLog{
+		RecordType:     enums.AuditLogClientDataExport,
+		Notes:          notes,
+		TargetID:       input.ClientID,
+		TargetType:     enums.AuditLogTargetClient,
+		ProgramID:      clientProfile.ProgramID,
+		OrganisationID: clientProfile.OrganisationID,
+		After:          map[string]interface{}{"files": manifest.Files},
+	})
+
+	return &dto.ClientDataExport{
+		FileName: fmt.Sprintf("client-%s-%s.zip", input.ClientID, exportedAt.Format("20060102150405")),
+		Content:  base64.StdEncoding.EncodeToString(archive),
+	}, nil
+}
+
+// clientDataExportFiles retrieves each category of data held about a client
+func (us *UseCasesUserImpl) clientDataExportFiles(ctx context.Context, clientProfile *domain.ClientProfile) ([]*clientDataExportFile, error) {
+	clientID := *clientProfile.ID
+	userID := clientProfile.UserID
+	if clientProfile.User != nil && clientProfile.User.ID != nil {
+		userID = *clientProfile.User.ID
+	}
+
+	identifiers, err := us.Query.GetClientIdentifiers(ctx, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get identifiers: %w", err)
+	}
+
+	contacts, err := us.Query.ListUserContacts(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list contacts: %w", err)
+	}
+
+	healthDiaryEntries, err := us.Query.GetClientHealthDiaryEntries(ctx, clientID, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get health diary entries: %w", err)
+	}
+
+	serviceRequests, err := us.Query.GetClientServiceRequests(ctx, "", "", clientID, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service requests: %w", err)
+	}
+
+	appointments, _, err := us.Query.ListAppointments(ctx, &domain.Appointment{ClientID: clientID}, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list appointments: %w", err)
+	}
+
+	screeningToolResponses, err := us.Query.ListClientScreeningToolResponses(ctx, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list screening tool responses: %w", err)
+	}
+	for i, screeningToolResponse := range screeningToolResponses {
+		screeningToolResponses[i], err = us.Query.GetScreeningToolResponseByID(ctx, screeningToolResponse.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get screening tool response: %w", err)
+		}
+	}
+
+	surveySubmissions, err := us.clientSurveySubmissions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	notifications, _, err := us.Query.ListNotifications(ctx, &domain.Notification{UserID: &userID}, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+
+	metrics, err := us.Query.ListUserMetrics(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list metrics: %w", err)
+	}
+
+	feedback, err := us.Query.ListUserFeedback(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feedback: %w", err)
+	}
+
+	caregivers, err := us.Query.GetCaregiversClient(ctx, domain.CaregiverClient{ClientID: clientID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get caregivers: %w", err)
+	}
+
+	identifiersFile := &clientDataExportFile{
+		name:   "identifiers",
+		data:   identifiers,
+		header: []string{"type", "value", "use", "primary", "valid_from", "valid_to"},
+	}
+	for _, identifier := range identifiers {
+		identifiersFile.rows = append(identifiersFile.rows, []string{
+			identifier.Type.String(), identifier.Value, identifier.Use, strconv.FormatBool(identifier.IsPrimaryIdentifier),
+			exportTime(&identifier.ValidFrom), exportTime(&identifier.ValidTo),
+		})
+	}
+
+	contactsFile := &clientDataExportFile{
+		name:   "contacts",
+		data:   contacts,
+		header: []string{"type", "value", "active", "opted_in"},
+	}
+	for _, contact := range contacts {
+		contactsFile.rows = append(contactsFile.rows, []string{
+			contact.ContactType, contact.ContactValue, strconv.FormatBool(contact.Active), strconv.FormatBool(contact.OptedIn),
+		})
+	}
+
+	healthDiaryFile := &clientDataExportFile{
+		name:   "health_diary_entries",
+		data:   healthDiaryEntries,
+		header: []string{"created", "mood", "entry_type", "note", "shared_with_health_worker", "shared_at"},
+	}
+	for _, entry := range healthDiaryEntries {
+		healthDiaryFile.rows = append(healthDiaryFile.rows, []string{
+			exportTime(&entry.CreatedAt), entry.Mood, entry.EntryType, entry.Note, strconv.FormatBool(entry.ShareWithHealthWorker), exportTime(entry.SharedAt),
+		})
+	}
+
+	serviceRequestsFile := &clientDataExportFile{
+		name:   "service_requests",
+		data:   serviceRequests,
+		header: []string{"created", "request_type", "request", "status", "in_progress_at", "resolved_at"},
+	}
+	for _, serviceRequest := range serviceRequests {
+		serviceRequestsFile.rows = append(serviceRequestsFile.rows, []string{
+			exportTime(&serviceRequest.CreatedAt), serviceRequest.RequestType, serviceRequest.Request, serviceRequest.Status,
+			exportTime(serviceRequest.InProgressAt), exportTime(serviceRequest.ResolvedAt),
+		})
+	}
+
+	appointmentsFile := &clientDataExportFile{
+		name:   "appointments",
+		data:   appointments,
+		header: []string{"date", "reason", "provider", "facility_id"},
+	}
+	for _, appointment := range appointments {
+		appointmentsFile.rows = append(appointmentsFile.rows, []string{
+			appointment.Date.String(), appointment.Reason, appointment.Provider, appointment.FacilityID,
+		})
+	}
+
+	notificationsFile := &clientDataExportFile{
+		name:   "notifications",
+		data:   notifications,
+		header: []string{"created", "type", "title", "body", "read"},
+	}
+	for _, notification := range notifications {
+		notificationsFile.rows = append(notificationsFile.rows, []string{
+			exportTime(&notification.CreatedAt), notification.Type.String(), notification.Title, notification.Body, strconv.FormatBool(notification.IsRead),
+		})
+	}
+
+	feedbackFile := &clientDataExportFile{
+		name:   "feedback",
+		data:   feedback,
+		header: []string{"feedback_type", "service_name", "satisfaction_level", "feedback", "requires_follow_up"},
+	}
+	for _, item := range feedback {
+		feedbackFile.rows = append(feedbackFile.rows, []string{
+			item.FeedbackType.String(), item.ServiceName, strconv.Itoa(item.SatisfactionLevel), item.Feedback, strconv.FormatBool(item.RequiresFollowUp),
+		})
+	}
+
+	// the caregivers a client is linked to are exported with the consent that each of them gave
+	caregiversFile := &clientDataExportFile{
+		name:   "caregivers",
+		data:   caregivers,
+		header: []string{"caregiver_id", "relationship", "client_consent", "client_consent_at", "caregiver_consent", "caregiver_consent_at"},
+	}
+	for _, caregiver := range caregivers {
+		caregiversFile.rows = append(caregiversFile.rows, []string{
+			caregiver.CaregiverID, caregiver.RelationshipType.String(), caregiver.ClientConsent.String(), exportTime(caregiver.ClientConsentAt),
+			caregiver.CaregiverConsent.String(), exportTime(caregiver.CaregiverConsentAt),
+		})
+	}
+
+	return []*clientDataExportFile{
+		{name: "profile", data: clientProfile},
+		identifiersFile,
+		contactsFile,
+		healthDiaryFile,
+		serviceRequestsFile,
+		appointmentsFile,
+		{name: "screening_tool_responses", data: screeningToolResponses},
+		{name: "survey_responses", data: surveySubmissions},
+		notificationsFile,
+		{name: "metrics", data: metrics},
+		feedbackFile,
+		caregiversFile,
+	}, nil
+}
+
+// clientSurveySubmissions retrieves the answers that a client submitted to the surveys sent to them.
+// The surveys that the client has not submitted are left out and so are their links and tokens since those can still be used to submit a response
+func (us *UseCasesUserImpl) clientSurveySubmissions(ctx context.Context, userID string) ([]*clientSurveySubmission, error) {
+	userSurveys, err := us.Query.GetUserSurveyForms(ctx, map[string]interface{}{"user_id": userID, "has_submitted": true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get survey responses: %w", err)
+	}
+
+	surveySubmissions := []*clientSurveySubmission{}
+	for _, userSurvey := range userSurveys {
+		submissions, err := us.Surveys.GetSubmissions(ctx, dto.VerifySurveySubmissionInput{ProjectID: userSurvey.ProjectID, FormID: userSurvey.FormID})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get survey submissions: %w", err)
+		}
+
+		// a survey is submitted through the public link that was generated for the client so the link identifies the submitter
+		for _, submission := range submissions {
+			if submission.SubmitterID != userSurvey.LinkID {
+				continue
+			}
+
+			submissionXML, err := us.Surveys.GetSubmissionXML(ctx, userSurvey.ProjectID, userSurvey.FormID, submission.InstanceID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get survey submission: %w", err)
+			}
+
+			surveySubmissions = append(surveySubmissions, &clientSurveySubmission{
+				Title:       userSurvey.Title,
+				Description: userSurvey.Description,
+				ProjectID:   userSurvey.ProjectID,
+				FormID:      userSurvey.FormID,
+				SubmittedAt: submission.CreatedAt,
+				Answers:     submissionXML["data"],
+			})
+		}
+	}
+
+	return surveySubmissions, nil
+}
+
+// writeClientDataExport writes the files of a client data export to a zip archive
+func writeClientDataExport(files []*clientDataExportFile) ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	archive := zip.NewWriter(buffer)
+
+	for _, file := range files {
+		jsonFile, err := archive.Create(file.name + ".json")
+		if err != nil {
+			return nil, err
+		}
+
+		encoder := json.NewEncoder(jsonFile)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(file.data); err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", file.name, err)
+		}
+
+		if file.header == nil {
+			continue
+		}
+
+		csvFile, err := archive.Create(file.name + ".csv")
+		if err != nil {
+			return nil, err
+		}
+
+		writer := csv.NewWriter(csvFile)
+		if err := writer.WriteAll(append([][]string{file.header}, file.rows...)); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", file.name, err)
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// exportTime formats a time in a client data export csv. Times that are not set are left empty
+func exportTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,387 @@
+package user
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/savannahghi/firebasetools"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	extensionMock "github.com/savannahghi/mycarehub/pkg/mycarehub/application/extension/mock"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	pgMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/mock"
+	clinicalMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/clinical/mock"
+	matrixMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/matrix/mock"
+	pubsubMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/pubsub/mock"
+	smsMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/sms/mock"
+	surveysMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/surveys/mock"
+	twilioMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/twilio/mock"
+	authorityMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/authority/mock"
+	notificationMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/notification/mock"
+	otpMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/otp/mock"
+)
+
+func TestUseCasesUserImpl_ExportClientData(t *testing.T) {
+	staffUserID := uuid.New().String()
+	organisationID := uuid.New().String()
+	clientID := uuid.New().String()
+
+	type args struct {
+		ctx   context.Context
+		input *dto.ClientDataExportInput
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Happy case: staff exports client data",
+			args: args{
+				ctx:   context.Background(),
+				input: &dto.ClientDataExportInput{ClientID: clientID, Reason: "Data subject access request"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case: client data exported from the command line",
+			args: args{
+				ctx:   context.Background(),
+				input: &dto.ClientDataExportInput{ClientID: clientID, Reason: "Data subject access request", RequestedBy: "Data Protection Officer"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: missing reason",
+			args: args{
+				ctx:   context.Background(),
+				input: &dto.ClientDataExportInput{ClientID: clientID},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: client belongs to a different organisation",
+			args: args{
+				ctx:   context.Background(),
+				input: &dto.ClientDataExportInput{ClientID: clientID, Reason: "Data subject access request"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: unable to get client profile from the command line",
+			args: args{
+				ctx:   context.Background(),
+				input: &dto.ClientDataExportInput{ClientID: clientID, Reason: "Data subject access request", RequestedBy: "Data Protection Officer"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: unable to get client identifiers",
+			args: args{
+				ctx:   context.Background(),
+				input: &dto.ClientDataExportInput{ClientID: clientID, Reason: "Data subject access request"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: unable to list contacts",
+			args: args{
+				ctx:   context.Background(),
+				input: &dto.ClientDataExportInput{ClientID: clientID, Reason: "Data subject access request"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: unable to get health diary entries",
+			args: args{
+				ctx:   context.Background(),
+				input: &dto.ClientDataExportInput{ClientID: clientID, Reason: "Data subject access request"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: unable to get service requests",
+			args: args{
+				ctx:   context.Background(),
+				input: &dto.ClientDataExportInput{ClientID: clientID, Reason: "Data subject access request"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: unable to list appointments",
+			args: args{
+				ctx:   context.Background(),
+				input: &dto.ClientDataExportInput{ClientID: clientID, Reason: "Data subject access request"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: unable to list screening tool responses",
+			args: args{
+				ctx:   context.Background(),
+				input: &dto.ClientDataExportInput{ClientID: clientID, Reason: "Data subject access request"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: unable to get screening tool response",
+			args: args{
+				ctx:   context.Background(),
+				input: &dto.ClientDataExportInput{ClientID: clientID, Reason: "Data subject access request"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: unable to get survey responses",
+			args: args{
+				ctx:   context.Background(),
+				input: &dto.ClientDataExportInput{ClientID: clientID, Reason: "Data subject access request"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: unable to get survey submissions",
+			args: args{
+				ctx:   context.Background(),
+				input: &dto.ClientDataExportInput{ClientID: clientID, Reason: "Data subject access request"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: unable to get survey submission answers",
+			args: args{
+				ctx:   context.Background(),
+				input: &dto.ClientDataExportInput{ClientID: clientID, Reason: "Data subject access request"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: unable to list notifications",
+			args: args{
+				ctx:   context.Background(),
+				input: &dto.ClientDataExportInput{ClientID: clientID, Reason: "Data subject access request"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: unable to list metrics",
+			args: args{
+				ctx:   context.Background(),
+				input: &dto.ClientDataExportInput{ClientID: clientID, Reason: "Data subject access request"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: unable to list feedback",
+			args: args{
+				ctx:   context.Background(),
+				input: &dto.ClientDataExportInput{ClientID: clientID, Reason: "Data subject access request"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: unable to get caregivers",
+			args: args{
+				ctx:   context.Background(),
+				input: &dto.ClientDataExportInput{ClientID: clientID, Reason: "Data subject access request"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeOTP := otpMock.NewOTPUseCaseMock()
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			fakePubsub := pubsubMock.NewPubsubServiceMock()
+			fakeClinical := clinicalMock.NewClinicalServiceMock()
+			fakeSMS := smsMock.NewSMSServiceMock()
+			fakeTwilio := twilioMock.NewTwilioServiceMock()
+			fakeMatrix := matrixMock.NewMatrixMock()
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+
+			us := NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
+
+			fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+				return staffUserID, nil
+			}
+			fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, id string) (*domain.User, error) {
+				return &domain.User{ID: &id, CurrentOrganizationID: organisationID}, nil
+			}
+			fakeDB.MockGetClientProfileByClientIDFn = func(ctx context.Context, id string) (*domain.ClientProfile, error) {
+				userID := uuid.New().String()
+				return &domain.ClientProfile{ID: &id, UserID: userID, Active: true, OrganisationID: organisationID}, nil
+			}
+
+			surveyToken := uuid.New().String()
+			fakeDB.MockGetUserSurveyFormsFn = func(ctx context.Context, params map[string]interface{}) ([]*domain.UserSurvey, error) {
+				return []*domain.UserSurvey{
+					{
+						ID:           uuid.New().String(),
+						Link:         "https://surveys.example.com/-/single/" + surveyToken,
+						Token:        surveyToken,
+						Title:        "SurveyTitle",
+						HasSubmitted: true,
+						ProjectID:    2,
+						FormID:       uuid.New().String(),
+						LinkID:       1096,
+					},
+				}, nil
+			}
+
+			var auditLog *domain.AuditLog
+			fakeDB.MockCreateAuditLogFn = func(ctx context.Context, log *domain.AuditLog) error {
+				auditLog = log
+				return nil
+			}
+
+			if tt.name == "Sad case: client belongs to a different organisation" {
+				fakeDB.MockGetClientProfileByClientIDFn = func(ctx context.Context, id string) (*domain.ClientProfile, error) {
+					return &domain.ClientProfile{ID: &id, Active: true, OrganisationID: uuid.New().String()}, nil
+				}
+			}
+			if tt.name == "Sad case: unable to get client profile from the command line" {
+				fakeDB.MockGetClientProfileByClientIDFn = func(ctx context.Context, id string) (*domain.ClientProfile, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get client identifiers" {
+				fakeDB.MockGetClientIdentifiers = func(ctx context.Context, clientID string) ([]*domain.Identifier, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to list contacts" {
+				fakeDB.MockListUserContactsFn = func(ctx context.Context, userID string) ([]*domain.Contact, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get health diary entries" {
+				fakeDB.MockGetClientHealthDiaryEntriesFn = func(ctx context.Context, clientID string, moodType *enums.Mood, shared *bool) ([]*domain.ClientHealthDiaryEntry, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get service requests" {
+				fakeDB.MockGetClientServiceRequestsFn = func(ctx context.Context, requestType, status, clientID, facilityID string) ([]*domain.ServiceRequest, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to list appointments" {
+				fakeDB.MockListAppointments = func(ctx context.Context, params *domain.Appointment, filters []*firebasetools.FilterParam, pagination *domain.Pagination) ([]*domain.Appointment, *domain.Pagination, error) {
+					return nil, nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to list screening tool responses" {
+				fakeDB.MockListClientScreeningToolResponsesFn = func(ctx context.Context, clientID string) ([]*domain.QuestionnaireScreeningToolResponse, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get screening tool response" {
+				fakeDB.MockGetScreeningToolResponseByIDFn = func(ctx context.Context, id string) (*domain.QuestionnaireScreeningToolResponse, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get survey responses" {
+				fakeDB.MockGetUserSurveyFormsFn = func(ctx context.Context, params map[string]interface{}) ([]*domain.UserSurvey, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get survey submissions" {
+				fakeSurveys.MockGetSubmissionsFn = func(ctx context.Context, input dto.VerifySurveySubmissionInput) ([]domain.Submission, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get survey submission answers" {
+				fakeSurveys.MockGetSubmissionXMLFn = func(ctx context.Context, projectID int, formID, instanceID string) (map[string]interface{}, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to list notifications" {
+				fakeDB.MockListNotificationsFn = func(ctx context.Context, params *domain.Notification, filters []*firebasetools.FilterParam, pagination *domain.Pagination) ([]*domain.Notification, *domain.Pagination, error) {
+					return nil, nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to list metrics" {
+				fakeDB.MockListUserMetricsFn = func(ctx context.Context, userID string) ([]*domain.Metric, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to list feedback" {
+				fakeDB.MockListUserFeedbackFn = func(ctx context.Context, userID string) ([]*domain.FeedbackResponse, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get caregivers" {
+				fakeDB.MockGetCaregiversClientFn = func(ctx context.Context, caregiverClient domain.CaregiverClient) ([]*domain.CaregiverClient, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := us.ExportClientData(tt.args.ctx, tt.args.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesUserImpl.ExportClientData() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if auditLog != nil {
+					t.Errorf("expected a failed export not to be audited")
+				}
+				return
+			}
+
+			content, err := base64.StdEncoding.DecodeString(got.Content)
+			if err != nil {
+				t.Errorf("expected the export to be base64 encoded: %v", err)
+				return
+			}
+			archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+			if err != nil {
+				t.Errorf("expected the export to be a zip archive: %v", err)
+				return
+			}
+
+			files := map[string]bool{}
+			for _, file := range archive.File {
+				files[file.Name] = true
+
+				if file.Name != "survey_responses.json" {
+					continue
+				}
+
+				reader, err := file.Open()
+				if err != nil {
+					t.Errorf("failed to open %s: %v", file.Name, err)
+					return
+				}
+				surveyResponses, err := io.ReadAll(reader)
+				if err != nil {
+					t.Errorf("failed to read %s: %v", file.Name, err)
+					return
+				}
+				if strings.Contains(string(surveyResponses), surveyToken) {
+					t.Errorf("expected the survey responses not to contain the survey link or token")
+				}
+				if !strings.Contains(string(surveyResponses), "not_difficult_at_all") {
+					t.Errorf("expected the survey responses to contain the submitted answers, got %s", surveyResponses)
+				}
+			}
+			for _, name := range []string{"manifest.json", "profile.json", "identifiers.csv", "caregivers.csv", "metrics.json"} {
+				if !files[name] {
+					t.Errorf("expected the export to contain %s", name)
+				}
+			}
+
+			if auditLog == nil || auditLog.RecordType != enums.AuditLogClientDataExport || auditLog.TargetID != clientID {
+				t.Errorf("expected the client data export to be audited, got %v", auditLog)
+			}
+		})
+	}
+}
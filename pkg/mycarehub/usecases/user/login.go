@@ -50,6 +50,7 @@ func (us *UseCasesUserImpl) userProfileCheck(ctx context.Context, credentials *d
 		PinUpdateRequired:      user.PinUpdateRequired,
 		HasSetNickname:         user.HasSetNickname,
 		CurrentProgramID:       user.CurrentProgramID,
+		CurrentOrganizationID:  user.CurrentOrganizationID,
 	}
 	response.SetUserProfile(profile)
 
@@ -296,6 +297,13 @@ func (us *UseCasesUserImpl) checkPIN(ctx context.Context, credentials *dto.Login
 		"last_failed_login":     nil,
 	}
 
+	// a PIN older than the organisation's maximum PIN age does not block the login but the user is asked to change it
+	pinPolicy, err := us.pinPolicy(ctx, user.CurrentOrganizationID)
+	if err == nil && pinPolicy.IsPINTooOld(userPIN.ValidFrom, currentTime) {
+		user.PinUpdateRequired = true
+		userUpdates["pin_update_required"] = true
+	}
+
 	err = us.Update.UpdateUser(ctx, &domain.User{ID: &user.ID}, userUpdates)
 	if err != nil {
 		helpers.ReportErrorToSentry(err)
@@ -312,6 +320,112 @@ func (us *UseCasesUserImpl) checkPIN(ctx context.Context, credentials *dto.Login
 	return true
 }
 
+// checkTOTP verifies the code from a staff's authenticator app, or one of their recovery codes, when they have enrolled one.
+// Staff without an authenticator app are flagged to enroll one when their organisation requires it and are only issued
+// a session that can be used to enroll the app.
+// Failed attempts are counted separately from failed PIN attempts so that a correct PIN does not reset the back-off.
+func (us *UseCasesUserImpl) checkTOTP(ctx context.Context, credentials *dto.LoginInput, response dto.ILoginResponse) bool {
+	ctx, span := tracer.Start(ctx, "checkTOTP")
+	defer span.End()
+	user := response.GetUserProfile()
+
+	switch credentials.Flavour {
+	case feedlib.FlavourPro:
+		enrolled, err := us.Query.CheckIfUserHasTOTP(ctx, user.ID)
+		if err != nil {
+			helpers.ReportErrorToSentry(err)
+
+			message := exceptions.InternalErr(err).Error()
+			code := exceptions.Internal.Code()
+			response.SetResponseCode(code, message)
+
+			return false
+		}
+
+		if !enrolled {
+			enforced, err := us.Query.CheckIfStaffTOTPIsEnforced(ctx, user.ID)
+			if err != nil {
+				helpers.ReportErrorToSentry(err)
+
+				message := exceptions.InternalErr(err).Error()
+				code := exceptions.Internal.Code()
+				response.SetResponseCode(code, message)
+
+				return false
+			}
+
+			user.TOTPEnrollmentRequired = enforced
+			response.SetUserProfile(user)
+
+			return true
+		}
+
+		if credentials.TOTPCode == "" {
+			message := exceptions.TOTPRequiredErr().Error()
+			code := exceptions.TOTPRequiredError.Code()
+			response.SetResponseCode(code, message)
+
+			return false
+		}
+
+		userTOTP, err := us.Query.GetUserTOTP(ctx, user.ID)
+		if err != nil {
+			helpers.ReportErrorToSentry(err)
+
+			message := exceptions.InternalErr(err).Error()
+			code := exceptions.Internal.Code()
+			response.SetResponseCode(code, message)
+
+			return false
+		}
+
+		verified, err := us.verifySecondFactor(ctx, userTOTP, credentials.TOTPCode)
+		if err != nil {
+			message := exceptions.InternalErr(err).Error()
+			code := exceptions.Internal.Code()
+			response.SetResponseCode(code, message)
+
+			return false
+		}
+
+		if !verified {
+			// increase the retry time exponentially
+			user.NextAllowedLogin = utils.NextAllowedLoginTime(userTOTP.FailedAttempts)
+
+			userUpdates := map[string]interface{}{
+				"next_allowed_login": user.NextAllowedLogin,
+				"last_failed_login":  time.Now().UTC(),
+			}
+
+			err := us.Update.UpdateUser(ctx, &domain.User{ID: &user.ID}, userUpdates)
+			if err != nil {
+				helpers.ReportErrorToSentry(err)
+				message := exceptions.InternalErr(err).Error()
+				code := exceptions.Internal.Code()
+				response.SetResponseCode(code, message)
+
+				return false
+			}
+
+			response.SetFailedLoginCount(userTOTP.FailedAttempts)
+
+			message := exceptions.TOTPMismatchErr().Error()
+			code := exceptions.TOTPMismatchError.Code()
+			response.SetResponseCode(code, message)
+
+			return false
+		}
+
+		user.TOTPVerified = true
+		response.SetUserProfile(user)
+
+		return true
+
+	default:
+		return true
+	}
+}
+
 func (us *UseCasesUserImpl) addRolesPermissions(ctx context.Context, credentials *dto.LoginInput, response dto.ILoginResponse) bool {
 
 	return true
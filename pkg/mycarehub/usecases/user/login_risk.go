@@ -0,0 +1,211 @@
+package user
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/savannahghi/feedlib"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/utils"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/notification"
+)
+
+var (
+	// sharedIPAddressAccountLimit is the number of different accounts that can log in from the same IP address within
+	// the window before the logins from it are flagged. Facilities where many users share a network may need a higher limit.
+	// They can be overridden by setting `LOGIN_RISK_SHARED_IP_ACCOUNT_LIMIT` and `LOGIN_RISK_SHARED_IP_WINDOW`. A limit of zero disables the check
+	sharedIPAddressAccountLimit = utils.IntFromEnv("LOGIN_RISK_SHARED_IP_ACCOUNT_LIMIT", 5)
+	sharedIPAddressWindow       = utils.DurationFromEnv("LOGIN_RISK_SHARED_IP_WINDOW", time.Hour)
+)
+
+// deviceFingerprint identifies the device that a user logs in from using the device ID reported by the app and its user agent
+func deviceFingerprint(deviceID string, userAgent string) string {
+	hash := sha256.Sum256([]byte(deviceID + "|" + userAgent))
+	return hex.EncodeToString(hash[:])
+}
+
+// evaluateLoginRisk compares a login with the user's previous logins and returns the anomalies detected.
+// The first device and country that a user logs in from are not flagged
+func (us *UseCasesUserImpl) evaluateLoginRisk(ctx context.Context, userID string, fingerprint string, ipAddress string, country string) ([]enums.LoginRiskFlag, error) {
+	flags := []enums.LoginRiskFlag{}
+
+	devices, err := us.Query.ListUserDevices(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user devices: %w", err)
+	}
+
+	knownDevice := false
+	for _, device := range devices {
+		if device.Fingerprint == fingerprint {
+			knownDevice = true
+			break
+		}
+	}
+	if len(devices) > 0 && !knownDevice {
+		flags = append(flags, enums.LoginRiskNewDevice)
+	}
+
+	// the country is only known when the load balancer reports it
+	if country != "" {
+		countries, err := us.Query.ListUserLoginCountries(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list user login countries: %w", err)
+		}
+
+		if len(countries) > 0 && !slices.Contains(countries, country) {
+			flags = append(flags, enums.LoginRiskUnusualCountry)
+		}
+	}
+
+	if ipAddress != "" && sharedIPAddressAccountLimit > 0 {
+		accounts, err := us.Query.CountUsersLoggedInFromIPAddress(ctx, ipAddress, time.Now().Add(-sharedIPAddressWindow))
+		if err != nil {
+			return nil, fmt.Errorf("failed to count users logged in from IP address: %w", err)
+		}
+
+		if accounts >= sharedIPAddressAccountLimit {
+			flags = append(flags, enums.LoginRiskSharedIPAddress)
+		}
+	}
+
+	return flags, nil
+}
+
+// checkLoginRisk records the device that a user logs in from and flags logins from a new device, an unusual country or an IP address
+// that many accounts have logged in from. The user is notified of a suspicious login and staff in organisations that require it
+// have to confirm it with an OTP sent to their phone, unless they have logged in with their authenticator app
+func (us *UseCasesUserImpl) checkLoginRisk(ctx context.Context, credentials *dto.LoginInput, response dto.ILoginResponse) bool {
+	ctx, span := tracer.Start(ctx, "checkLoginRisk")
+	defer span.End()
+	user := response.GetUserProfile()
+
+	// the IP address and country are only available for logins made through the HTTP server
+	ipAddress, _ := utils.GetValueFromContext(ctx, utils.ClientIPContextKey)
+	country, _ := utils.GetValueFromContext(ctx, utils.ClientCountryContextKey)
+	fingerprint := deviceFingerprint(credentials.DeviceID, credentials.UserAgent)
+
+	flags, err := us.evaluateLoginRisk(ctx, user.ID, fingerprint, ipAddress, country)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return true
+	}
+
+	now := time.Now()
+	event := &domain.LoginEvent{
+		UserID:      user.ID,
+		Fingerprint: fingerprint,
+		IPAddress:   ipAddress,
+		Country:     country,
+		RiskFlags:   flags,
+		Timestamp:   now,
+	}
+	device := &domain.UserDevice{
+		UserID:        user.ID,
+		Fingerprint:   fingerprint,
+		DeviceID:      credentials.DeviceID,
+		DeviceName:    credentials.DeviceName,
+		UserAgent:     credentials.UserAgent,
+		LastIPAddress: ipAddress,
+		LastCountry:   country,
+		LastLoginAt:   now,
+	}
+
+	if event.IsSuspicious() && credentials.Flavour == feedlib.FlavourPro && !user.TOTPVerified && us.staffLoginStepUpRequired(ctx, user.CurrentOrganizationID) {
+		if credentials.OTP == "" {
+			us.notifySuspiciousLogin(ctx, user.ID, credentials.Flavour, event, device)
+
+			_, err := us.OTP.VerifyPhoneNumber(ctx, user.Username, feedlib.FlavourPro)
+			if err != nil {
+				helpers.ReportErrorToSentry(err)
+
+				response.SetResponseCode(exceptions.GetErrorCode(err), err.Error())
+
+				return false
+			}
+
+			message := exceptions.LoginOTPRequiredErr().Error()
+			code := exceptions.LoginOTPRequiredError.Code()
+			response.SetResponseCode(code, message)
+
+			return false
+		}
+
+		verified, err := us.OTP.VerifyOTP(ctx, &dto.VerifyOTPInput{
+			Username: user.Username,
+			OTP:      credentials.OTP,
+			Flavour:  feedlib.FlavourPro,
+		})
+		if err != nil {
+			helpers.ReportErrorToSentry(err)
+
+			message := exceptions.InternalErr(err).Error()
+			code := exceptions.Internal.Code()
+			response.SetResponseCode(code, message)
+
+			return false
+		}
+
+		if !verified {
+			message := exceptions.LoginOTPMismatchErr().Error()
+			code := exceptions.LoginOTPMismatchError.Code()
+			response.SetResponseCode(code, message)
+
+			return false
+		}
+
+		event.SteppedUp = true
+	}
+
+	if err := us.Create.RecordLoginEvent(ctx, event, device); err != nil {
+		helpers.ReportErrorToSentry(err)
+	}
+
+	// staff who confirmed the login with an OTP were notified when it was requested
+	if event.IsSuspicious() && !event.SteppedUp {
+		us.notifySuspiciousLogin(ctx, user.ID, credentials.Flavour, event, device)
+	}
+
+	return true
+}
+
+// staffLoginStepUpRequired checks whether the staff's organisation requires suspicious logins to be confirmed with an OTP
+func (us *UseCasesUserImpl) staffLoginStepUpRequired(ctx context.Context, organisationID string) bool {
+	if organisationID == "" {
+		return false
+	}
+
+	organisation, err := us.Query.GetOrganisation(ctx, organisationID)
+	if err != nil {
+		helpers.ReportErrorToSentry(fmt.Errorf("failed to get organisation login step up policy: %w", err))
+		return false
+	}
+
+	return organisation.StaffLoginStepUp
+}
+
+// notifySuspiciousLogin lets the user know that their account was logged in to from a new device or location
+func (us *UseCasesUserImpl) notifySuspiciousLogin(ctx context.Context, userID string, flavour feedlib.Flavour, event *domain.LoginEvent, device *domain.UserDevice) {
+	userProfile, err := us.Query.GetUserProfileByUserID(ctx, userID)
+	if err != nil {
+		helpers.ReportErrorToSentry(fmt.Errorf("failed to get profile of user %s to notify of suspicious login: %w", userID, err))
+		return
+	}
+
+	loginNotification := notification.ComposeClientNotification(enums.NotificationTypeSuspiciousLogin, notification.ClientNotificationInput{
+		LoginEvent: event,
+		Device:     device,
+	})
+	loginNotification.Flavour = flavour
+
+	if err := us.Notification.NotifyUser(ctx, userProfile, loginNotification); err != nil {
+		helpers.ReportErrorToSentry(err)
+	}
+}
@@ -0,0 +1,367 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/savannahghi/feedlib"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
+	extensionMock "github.com/savannahghi/mycarehub/pkg/mycarehub/application/extension/mock"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/utils"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	pgMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/mock"
+	clinicalMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/clinical/mock"
+	matrixMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/matrix/mock"
+	pubsubMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/pubsub/mock"
+	smsMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/sms/mock"
+	surveysMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/surveys/mock"
+	twilioMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/twilio/mock"
+	authorityMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/authority/mock"
+	notificationMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/notification/mock"
+	otpMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/otp/mock"
+	"github.com/savannahghi/profileutils"
+)
+
+func TestUseCasesUserImpl_evaluateLoginRisk(t *testing.T) {
+	userID := uuid.New().String()
+	fingerprint := deviceFingerprint("device-id", "mycarehub/1.0")
+
+	tests := []struct {
+		name      string
+		country   string
+		ipAddress string
+		want      []enums.LoginRiskFlag
+		wantErr   bool
+	}{
+		{
+			name:      "Happy case: known device and country",
+			country:   "KE",
+			ipAddress: "197.248.0.1",
+			want:      []enums.LoginRiskFlag{},
+		},
+		{
+			name:      "Happy case: first login is not flagged",
+			country:   "KE",
+			ipAddress: "197.248.0.1",
+			want:      []enums.LoginRiskFlag{},
+		},
+		{
+			name:      "Happy case: new device",
+			country:   "KE",
+			ipAddress: "197.248.0.1",
+			want:      []enums.LoginRiskFlag{enums.LoginRiskNewDevice},
+		},
+		{
+			name:      "Happy case: unusual country",
+			country:   "US",
+			ipAddress: "197.248.0.1",
+			want:      []enums.LoginRiskFlag{enums.LoginRiskUnusualCountry},
+		},
+		{
+			name:      "Happy case: unknown country is not flagged",
+			ipAddress: "197.248.0.1",
+			want:      []enums.LoginRiskFlag{},
+		},
+		{
+			name:      "Happy case: shared IP address",
+			country:   "KE",
+			ipAddress: "197.248.0.1",
+			want:      []enums.LoginRiskFlag{enums.LoginRiskSharedIPAddress},
+		},
+		{
+			name:      "Sad case: unable to list user devices",
+			country:   "KE",
+			ipAddress: "197.248.0.1",
+			wantErr:   true,
+		},
+		{
+			name:      "Sad case: unable to list user login countries",
+			country:   "KE",
+			ipAddress: "197.248.0.1",
+			wantErr:   true,
+		},
+		{
+			name:      "Sad case: unable to count users logged in from IP address",
+			country:   "KE",
+			ipAddress: "197.248.0.1",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeOTP := otpMock.NewOTPUseCaseMock()
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			fakePubsub := pubsubMock.NewPubsubServiceMock()
+			fakeClinical := clinicalMock.NewClinicalServiceMock()
+			fakeSMS := smsMock.NewSMSServiceMock()
+			fakeTwilio := twilioMock.NewTwilioServiceMock()
+			fakeMatrix := matrixMock.NewMatrixMock()
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+
+			us := NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
+
+			fakeDB.MockListUserDevicesFn = func(ctx context.Context, userID string) ([]*domain.UserDevice, error) {
+				return []*domain.UserDevice{{UserID: userID, Fingerprint: fingerprint}}, nil
+			}
+
+			if tt.name == "Happy case: first login is not flagged" {
+				fakeDB.MockListUserDevicesFn = func(ctx context.Context, userID string) ([]*domain.UserDevice, error) {
+					return []*domain.UserDevice{}, nil
+				}
+				fakeDB.MockListUserLoginCountriesFn = func(ctx context.Context, userID string) ([]string, error) {
+					return []string{}, nil
+				}
+			}
+			if tt.name == "Happy case: new device" {
+				fakeDB.MockListUserDevicesFn = func(ctx context.Context, userID string) ([]*domain.UserDevice, error) {
+					return []*domain.UserDevice{{UserID: userID, Fingerprint: deviceFingerprint("other-device", "mycarehub/1.0")}}, nil
+				}
+			}
+			if tt.name == "Happy case: shared IP address" {
+				fakeDB.MockCountUsersLoggedInFromIPAddressFn = func(ctx context.Context, ipAddress string, since time.Time) (int, error) {
+					return sharedIPAddressAccountLimit, nil
+				}
+			}
+			if tt.name == "Sad case: unable to list user devices" {
+				fakeDB.MockListUserDevicesFn = func(ctx context.Context, userID string) ([]*domain.UserDevice, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to list user login countries" {
+				fakeDB.MockListUserLoginCountriesFn = func(ctx context.Context, userID string) ([]string, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to count users logged in from IP address" {
+				fakeDB.MockCountUsersLoggedInFromIPAddressFn = func(ctx context.Context, ipAddress string, since time.Time) (int, error) {
+					return 0, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := us.evaluateLoginRisk(context.Background(), userID, fingerprint, tt.ipAddress, tt.country)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesUserImpl.evaluateLoginRisk() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("UseCasesUserImpl.evaluateLoginRisk() = %v, want %v", got, tt.want)
+				return
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("UseCasesUserImpl.evaluateLoginRisk() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestUseCasesUserImpl_checkLoginRisk(t *testing.T) {
+	ctx := context.WithValue(context.Background(), utils.ClientIPContextKey, "197.248.0.1")
+
+	tests := []struct {
+		name         string
+		flavour      feedlib.Flavour
+		otp          string
+		totpVerified bool
+		want         bool
+		wantCode     int
+		wantNotified bool
+		wantRecorded bool
+	}{
+		{
+			name:         "Happy case: known device",
+			flavour:      feedlib.FlavourConsumer,
+			want:         true,
+			wantRecorded: true,
+		},
+		{
+			name:         "Happy case: client is notified of a new device",
+			flavour:      feedlib.FlavourConsumer,
+			want:         true,
+			wantNotified: true,
+			wantRecorded: true,
+		},
+		{
+			name:         "Happy case: staff is notified of a new device when step up is not required",
+			flavour:      feedlib.FlavourPro,
+			want:         true,
+			wantNotified: true,
+			wantRecorded: true,
+		},
+		{
+			name:         "Happy case: failing to evaluate the risk does not block the login",
+			flavour:      feedlib.FlavourConsumer,
+			want:         true,
+			wantRecorded: false,
+		},
+		{
+			name:         "Happy case: failing to record the login does not block the login",
+			flavour:      feedlib.FlavourConsumer,
+			want:         true,
+			wantNotified: true,
+		},
+		{
+			name:         "Happy case: staff that verified an authenticator code are not asked for an OTP",
+			flavour:      feedlib.FlavourPro,
+			totpVerified: true,
+			want:         true,
+			wantNotified: true,
+			wantRecorded: true,
+		},
+		{
+			name:         "Happy case: staff confirm a new device with an OTP",
+			flavour:      feedlib.FlavourPro,
+			otp:          "123456",
+			want:         true,
+			wantRecorded: true,
+		},
+		{
+			name:         "Sad case: staff are sent an OTP to confirm a new device",
+			flavour:      feedlib.FlavourPro,
+			want:         false,
+			wantCode:     int(exceptions.LoginOTPRequiredError),
+			wantNotified: true,
+		},
+		{
+			name:         "Sad case: unable to send OTP",
+			flavour:      feedlib.FlavourPro,
+			want:         false,
+			wantCode:     int(exceptions.Internal),
+			wantNotified: true,
+		},
+		{
+			name:     "Sad case: invalid OTP",
+			flavour:  feedlib.FlavourPro,
+			otp:      "000000",
+			want:     false,
+			wantCode: int(exceptions.LoginOTPMismatchError),
+		},
+		{
+			name:     "Sad case: unable to verify OTP",
+			flavour:  feedlib.FlavourPro,
+			otp:      "123456",
+			want:     false,
+			wantCode: int(exceptions.Internal),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeOTP := otpMock.NewOTPUseCaseMock()
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			fakePubsub := pubsubMock.NewPubsubServiceMock()
+			fakeClinical := clinicalMock.NewClinicalServiceMock()
+			fakeSMS := smsMock.NewSMSServiceMock()
+			fakeTwilio := twilioMock.NewTwilioServiceMock()
+			fakeMatrix := matrixMock.NewMatrixMock()
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+
+			us := NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
+
+			credentials := &dto.LoginInput{
+				Username:   "test",
+				Flavour:    tt.flavour,
+				DeviceID:   "device-id",
+				DeviceName: "Pixel 7",
+				UserAgent:  "mycarehub/1.0",
+				OTP:        tt.otp,
+			}
+			response := dto.NewLoginResponse()
+			response.SetUserProfile(&dto.User{
+				ID:                    uuid.New().String(),
+				Username:              "test",
+				CurrentOrganizationID: uuid.New().String(),
+				TOTPVerified:          tt.totpVerified,
+			})
+
+			notified := false
+			fakeNotification.MockNotifyUserFn = func(ctx context.Context, userProfile *domain.User, notificationPayload *domain.Notification) error {
+				notified = true
+				return nil
+			}
+			recorded := false
+			fakeDB.MockRecordLoginEventFn = func(ctx context.Context, event *domain.LoginEvent, device *domain.UserDevice) error {
+				recorded = true
+				return nil
+			}
+
+			if tt.name == "Happy case: known device" {
+				fakeDB.MockListUserDevicesFn = func(ctx context.Context, userID string) ([]*domain.UserDevice, error) {
+					return []*domain.UserDevice{{UserID: userID, Fingerprint: deviceFingerprint(credentials.DeviceID, credentials.UserAgent)}}, nil
+				}
+			}
+
+			// staff in an organisation that requires step up have to confirm logins from a new device
+			if tt.flavour == feedlib.FlavourPro && tt.name != "Happy case: staff is notified of a new device when step up is not required" {
+				fakeDB.MockGetOrganisationFn = func(ctx context.Context, id string) (*domain.Organisation, error) {
+					return &domain.Organisation{ID: id, StaffLoginStepUp: true}, nil
+				}
+			}
+
+			if tt.name == "Happy case: failing to evaluate the risk does not block the login" {
+				fakeDB.MockListUserDevicesFn = func(ctx context.Context, userID string) ([]*domain.UserDevice, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Happy case: failing to record the login does not block the login" {
+				fakeDB.MockRecordLoginEventFn = func(ctx context.Context, event *domain.LoginEvent, device *domain.UserDevice) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: staff are sent an OTP to confirm a new device" {
+				fakeOTP.MockVerifyPhoneNumberFn = func(ctx context.Context, username string, flavour feedlib.Flavour) (*profileutils.OtpResponse, error) {
+					return &profileutils.OtpResponse{OTP: "123456"}, nil
+				}
+			}
+			if tt.name == "Sad case: unable to send OTP" {
+				fakeOTP.MockVerifyPhoneNumberFn = func(ctx context.Context, username string, flavour feedlib.Flavour) (*profileutils.OtpResponse, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Happy case: staff confirm a new device with an OTP" {
+				fakeOTP.MockVerifyOTP = func(ctx context.Context, payload *dto.VerifyOTPInput) (bool, error) {
+					return true, nil
+				}
+			}
+			if tt.name == "Sad case: invalid OTP" {
+				fakeOTP.MockVerifyOTP = func(ctx context.Context, payload *dto.VerifyOTPInput) (bool, error) {
+					return false, nil
+				}
+			}
+			if tt.name == "Sad case: unable to verify OTP" {
+				fakeOTP.MockVerifyOTP = func(ctx context.Context, payload *dto.VerifyOTPInput) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got := us.checkLoginRisk(ctx, credentials, response)
+			if got != tt.want {
+				t.Errorf("UseCasesUserImpl.checkLoginRisk() = %v, want %v", got, tt.want)
+				return
+			}
+			if !tt.want && response.Code != tt.wantCode {
+				t.Errorf("UseCasesUserImpl.checkLoginRisk() code = %v, want %v", response.Code, tt.wantCode)
+			}
+			if notified != tt.wantNotified {
+				t.Errorf("UseCasesUserImpl.checkLoginRisk() notified = %v, want %v", notified, tt.wantNotified)
+			}
+			if recorded != tt.wantRecorded {
+				t.Errorf("UseCasesUserImpl.checkLoginRisk() recorded = %v, want %v", recorded, tt.wantRecorded)
+			}
+		})
+	}
+}
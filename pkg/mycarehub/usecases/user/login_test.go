@@ -4,19 +4,25 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/brianvoe/gofakeit"
+	"github.com/pquerna/otp/totp"
 	"github.com/savannahghi/feedlib"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
 	extensionMock "github.com/savannahghi/mycarehub/pkg/mycarehub/application/extension/mock"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/utils"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	pgMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/mock"
 	clinicalMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/clinical/mock"
 	matrixMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/matrix/mock"
 	pubsubMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/pubsub/mock"
 	smsMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/sms/mock"
+	surveysMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/surveys/mock"
 	twilioMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/twilio/mock"
 	authorityMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/authority/mock"
+	notificationMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/notification/mock"
 	otpMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/otp/mock"
 	"gorm.io/gorm"
 )
@@ -89,8 +95,10 @@ func TestUseCasesUserImpl_caregiverProfileCheck(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
 
-			us := NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			us := NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "sad case: fail to check caregiver profile" {
 				fakeDB.MockCheckCaregiverExistsFn = func(ctx context.Context, userID string) (bool, error) {
@@ -236,8 +244,10 @@ func TestUseCasesUserImpl_pinResetRequestCheck(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
 
-			us := NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			us := NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "Sad case: unable to get user profile by username" {
 				fakeDB.MockGetUserProfileByUsernameFn = func(ctx context.Context, username string) (*domain.User, error) {
@@ -263,3 +273,410 @@ func TestUseCasesUserImpl_pinResetRequestCheck(t *testing.T) {
 		})
 	}
 }
+
+func TestUseCasesUserImpl_checkTOTP(t *testing.T) {
+	secret, encryptedSecret := testTOTPSecret(t)
+
+	validCode, err := totp.GenerateCode(secret, time.Now())
+	if err != nil {
+		t.Fatalf("failed to generate code: %v", err)
+	}
+
+	recoveryCode := "abcde-23456"
+
+	type args struct {
+		ctx         context.Context
+		credentials *dto.LoginInput
+	}
+	tests := []struct {
+		name                       string
+		args                       args
+		want                       bool
+		wantCode                   int
+		wantTOTPEnrollmentRequired bool
+	}{
+		{
+			name: "Happy case: consumer login does not require TOTP",
+			args: args{
+				ctx: context.Background(),
+				credentials: &dto.LoginInput{
+					Username: gofakeit.Username(),
+					PIN:      "1234",
+					Flavour:  feedlib.FlavourConsumer,
+				},
+			},
+			want: true,
+		},
+		{
+			name: "Happy case: staff without TOTP",
+			args: args{
+				ctx: context.Background(),
+				credentials: &dto.LoginInput{
+					Username: gofakeit.Username(),
+					PIN:      "1234",
+					Flavour:  feedlib.FlavourPro,
+				},
+			},
+			want: true,
+		},
+		{
+			name: "Happy case: staff without TOTP in an organisation that enforces TOTP",
+			args: args{
+				ctx: context.Background(),
+				credentials: &dto.LoginInput{
+					Username: gofakeit.Username(),
+					PIN:      "1234",
+					Flavour:  feedlib.FlavourPro,
+				},
+			},
+			want:                       true,
+			wantTOTPEnrollmentRequired: true,
+		},
+		{
+			name: "Happy case: valid TOTP code",
+			args: args{
+				ctx: context.Background(),
+				credentials: &dto.LoginInput{
+					Username: gofakeit.Username(),
+					PIN:      "1234",
+					Flavour:  feedlib.FlavourPro,
+					TOTPCode: validCode,
+				},
+			},
+			want: true,
+		},
+		{
+			name: "Happy case: valid recovery code",
+			args: args{
+				ctx: context.Background(),
+				credentials: &dto.LoginInput{
+					Username: gofakeit.Username(),
+					PIN:      "1234",
+					Flavour:  feedlib.FlavourPro,
+					TOTPCode: recoveryCode,
+				},
+			},
+			want: true,
+		},
+		{
+			name: "Sad case: missing TOTP code",
+			args: args{
+				ctx: context.Background(),
+				credentials: &dto.LoginInput{
+					Username: gofakeit.Username(),
+					PIN:      "1234",
+					Flavour:  feedlib.FlavourPro,
+				},
+			},
+			want:     false,
+			wantCode: int(exceptions.TOTPRequiredError),
+		},
+		{
+			name: "Sad case: invalid TOTP code",
+			args: args{
+				ctx: context.Background(),
+				credentials: &dto.LoginInput{
+					Username: gofakeit.Username(),
+					PIN:      "1234",
+					Flavour:  feedlib.FlavourPro,
+					TOTPCode: "000000",
+				},
+			},
+			want:     false,
+			wantCode: int(exceptions.TOTPMismatchError),
+		},
+		{
+			name: "Sad case: unable to update user after an invalid TOTP code",
+			args: args{
+				ctx: context.Background(),
+				credentials: &dto.LoginInput{
+					Username: gofakeit.Username(),
+					PIN:      "1234",
+					Flavour:  feedlib.FlavourPro,
+					TOTPCode: "000000",
+				},
+			},
+			want:     false,
+			wantCode: int(exceptions.Internal),
+		},
+		{
+			name: "Sad case: unable to check if user has TOTP",
+			args: args{
+				ctx: context.Background(),
+				credentials: &dto.LoginInput{
+					Username: gofakeit.Username(),
+					PIN:      "1234",
+					Flavour:  feedlib.FlavourPro,
+					TOTPCode: validCode,
+				},
+			},
+			want:     false,
+			wantCode: int(exceptions.Internal),
+		},
+		{
+			name: "Sad case: unable to check if TOTP is enforced",
+			args: args{
+				ctx: context.Background(),
+				credentials: &dto.LoginInput{
+					Username: gofakeit.Username(),
+					PIN:      "1234",
+					Flavour:  feedlib.FlavourPro,
+				},
+			},
+			want:     false,
+			wantCode: int(exceptions.Internal),
+		},
+		{
+			name: "Sad case: unable to get user TOTP",
+			args: args{
+				ctx: context.Background(),
+				credentials: &dto.LoginInput{
+					Username: gofakeit.Username(),
+					PIN:      "1234",
+					Flavour:  feedlib.FlavourPro,
+					TOTPCode: validCode,
+				},
+			},
+			want:     false,
+			wantCode: int(exceptions.Internal),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeOTP := otpMock.NewOTPUseCaseMock()
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			fakePubsub := pubsubMock.NewPubsubServiceMock()
+			fakeClinical := clinicalMock.NewClinicalServiceMock()
+			fakeSMS := smsMock.NewSMSServiceMock()
+			fakeTwilio := twilioMock.NewTwilioServiceMock()
+			fakeMatrix := matrixMock.NewMatrixMock()
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+
+			us := NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
+
+			response := &dto.LoginResponse{
+				Response: &dto.Response{
+					User: &dto.User{
+						ID:       gofakeit.UUID(),
+						Username: tt.args.credentials.Username,
+					},
+				},
+			}
+
+			fakeDB.MockCheckIfUserHasTOTPFn = func(ctx context.Context, userID string) (bool, error) {
+				return true, nil
+			}
+			fakeDB.MockGetUserTOTPFn = func(ctx context.Context, userID string) (*domain.UserTOTP, error) {
+				return &domain.UserTOTP{
+					UserID:    userID,
+					Secret:    encryptedSecret,
+					Confirmed: true,
+				}, nil
+			}
+			fakeDB.MockListUserRecoveryCodesFn = func(ctx context.Context, userID string) ([]*domain.UserRecoveryCode, error) {
+				return []*domain.UserRecoveryCode{testRecoveryCode(recoveryCode)}, nil
+			}
+
+			if tt.name == "Happy case: staff without TOTP" || tt.name == "Sad case: unable to check if TOTP is enforced" {
+				fakeDB.MockCheckIfUserHasTOTPFn = func(ctx context.Context, userID string) (bool, error) {
+					return false, nil
+				}
+			}
+			if tt.name == "Happy case: staff without TOTP in an organisation that enforces TOTP" {
+				fakeDB.MockCheckIfUserHasTOTPFn = func(ctx context.Context, userID string) (bool, error) {
+					return false, nil
+				}
+				fakeDB.MockCheckIfStaffTOTPIsEnforcedFn = func(ctx context.Context, userID string) (bool, error) {
+					return true, nil
+				}
+			}
+			if tt.name == "Sad case: unable to update user after an invalid TOTP code" {
+				fakeDB.MockUpdateUserFn = func(ctx context.Context, user *domain.User, updateData map[string]interface{}) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to check if user has TOTP" {
+				fakeDB.MockCheckIfUserHasTOTPFn = func(ctx context.Context, userID string) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to check if TOTP is enforced" {
+				fakeDB.MockCheckIfStaffTOTPIsEnforcedFn = func(ctx context.Context, userID string) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get user TOTP" {
+				fakeDB.MockGetUserTOTPFn = func(ctx context.Context, userID string) (*domain.UserTOTP, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			if got := us.checkTOTP(tt.args.ctx, tt.args.credentials, response); got != tt.want {
+				t.Errorf("UseCasesUserImpl.checkTOTP() = %v, want %v", got, tt.want)
+			}
+			if response.Code != tt.wantCode {
+				t.Errorf("UseCasesUserImpl.checkTOTP() code = %v, want %v", response.Code, tt.wantCode)
+			}
+			if response.Response.User.TOTPEnrollmentRequired != tt.wantTOTPEnrollmentRequired {
+				t.Errorf("UseCasesUserImpl.checkTOTP() TOTPEnrollmentRequired = %v, want %v", response.Response.User.TOTPEnrollmentRequired, tt.wantTOTPEnrollmentRequired)
+			}
+		})
+	}
+}
+
+func TestUseCasesUserImpl_checkPIN(t *testing.T) {
+	pin := "4826"
+	salt, hashedPIN := utils.EncryptPIN(pin, nil)
+
+	type args struct {
+		ctx         context.Context
+		credentials *dto.LoginInput
+	}
+	tests := []struct {
+		name                  string
+		args                  args
+		organisationID        string
+		want                  bool
+		wantPinUpdateRequired bool
+	}{
+		{
+			name: "Happy case: pin within the organisation's maximum age",
+			args: args{
+				ctx: context.Background(),
+				credentials: &dto.LoginInput{
+					Username: gofakeit.Username(),
+					PIN:      pin,
+					Flavour:  feedlib.FlavourConsumer,
+				},
+			},
+			organisationID: gofakeit.UUID(),
+			want:           true,
+		},
+		{
+			name: "Happy case: pin older than the organisation's maximum age",
+			args: args{
+				ctx: context.Background(),
+				credentials: &dto.LoginInput{
+					Username: gofakeit.Username(),
+					PIN:      pin,
+					Flavour:  feedlib.FlavourConsumer,
+				},
+			},
+			organisationID:        gofakeit.UUID(),
+			want:                  true,
+			wantPinUpdateRequired: true,
+		},
+		{
+			name: "Happy case: user without an organisation",
+			args: args{
+				ctx: context.Background(),
+				credentials: &dto.LoginInput{
+					Username: gofakeit.Username(),
+					PIN:      pin,
+					Flavour:  feedlib.FlavourConsumer,
+				},
+			},
+			want: true,
+		},
+		{
+			name: "Happy case: unable to get the organisation's PIN policy",
+			args: args{
+				ctx: context.Background(),
+				credentials: &dto.LoginInput{
+					Username: gofakeit.Username(),
+					PIN:      pin,
+					Flavour:  feedlib.FlavourConsumer,
+				},
+			},
+			organisationID: gofakeit.UUID(),
+			want:           true,
+		},
+		{
+			name: "Sad case: wrong pin",
+			args: args{
+				ctx: context.Background(),
+				credentials: &dto.LoginInput{
+					Username: gofakeit.Username(),
+					PIN:      "5937",
+					Flavour:  feedlib.FlavourConsumer,
+				},
+			},
+			organisationID: gofakeit.UUID(),
+			want:           false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeOTP := otpMock.NewOTPUseCaseMock()
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			fakePubsub := pubsubMock.NewPubsubServiceMock()
+			fakeClinical := clinicalMock.NewClinicalServiceMock()
+			fakeSMS := smsMock.NewSMSServiceMock()
+			fakeTwilio := twilioMock.NewTwilioServiceMock()
+			fakeMatrix := matrixMock.NewMatrixMock()
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+
+			us := NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
+
+			response := &dto.LoginResponse{
+				Response: &dto.Response{
+					User: &dto.User{
+						ID:                    gofakeit.UUID(),
+						Username:              tt.args.credentials.Username,
+						CurrentOrganizationID: tt.organisationID,
+					},
+				},
+			}
+
+			pinAge := 30
+			if tt.name == "Happy case: pin older than the organisation's maximum age" || tt.name == "Happy case: user without an organisation" {
+				pinAge = 120
+			}
+
+			fakeDB.MockGetUserPINByUserIDFn = func(ctx context.Context, userID string) (*domain.UserPIN, error) {
+				return &domain.UserPIN{
+					UserID:    userID,
+					HashedPIN: hashedPIN,
+					Salt:      salt,
+					ValidFrom: time.Now().AddDate(0, 0, -pinAge),
+					ValidTo:   time.Now().AddDate(0, 0, 30),
+					IsValid:   true,
+				}, nil
+			}
+			fakeDB.MockGetOrganisationFn = func(ctx context.Context, id string) (*domain.Organisation, error) {
+				return &domain.Organisation{
+					ID:        id,
+					PINPolicy: domain.PINPolicy{MinLength: 4, MaxAgeDays: 90},
+				}, nil
+			}
+
+			var pinUpdateRequired interface{}
+			fakeDB.MockUpdateUserFn = func(ctx context.Context, user *domain.User, updateData map[string]interface{}) error {
+				pinUpdateRequired = updateData["pin_update_required"]
+				return nil
+			}
+
+			if tt.name == "Happy case: unable to get the organisation's PIN policy" {
+				fakeDB.MockGetOrganisationFn = func(ctx context.Context, id string) (*domain.Organisation, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			if got := us.checkPIN(tt.args.ctx, tt.args.credentials, response); got != tt.want {
+				t.Errorf("UseCasesUserImpl.checkPIN() = %v, want %v", got, tt.want)
+			}
+			if response.Response.User.PinUpdateRequired != tt.wantPinUpdateRequired {
+				t.Errorf("UseCasesUserImpl.checkPIN() PinUpdateRequired = %v, want %v", response.Response.User.PinUpdateRequired, tt.wantPinUpdateRequired)
+			}
+			if tt.wantPinUpdateRequired && pinUpdateRequired != true {
+				t.Errorf("expected the user to be flagged to update their PIN but got %v", pinUpdateRequired)
+			}
+		})
+	}
+}
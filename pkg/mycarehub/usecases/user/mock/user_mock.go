@@ -12,6 +12,7 @@ import (
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	"github.com/savannahghi/scalarutils"
 )
 
 // UserUseCaseMock mocks the implementation of usecase methods.
@@ -71,6 +72,30 @@ type UserUseCaseMock struct {
 	MockCheckIfPhoneExistsFn                func(ctx context.Context, phoneNumber string) (bool, error)
 	MockUpdateOrganisationAdminPermissionFn func(ctx context.Context, staffID string, isOrganisationAdmin bool) (bool, error)
 	MockClientSignUpFn                      func(ctx context.Context, input *dto.ClientSelfSignUp) (*dto.ClientRegistrationOutput, error)
+	MockEnrollTOTPFn                        func(ctx context.Context) (*domain.TOTPEnrollment, error)
+	MockConfirmTOTPEnrollmentFn             func(ctx context.Context, code string) ([]string, error)
+	MockRegenerateTOTPRecoveryCodesFn       func(ctx context.Context, code string) ([]string, error)
+	MockDisableTOTPFn                       func(ctx context.Context, code string) (bool, error)
+	MockGetTOTPStatusFn                     func(ctx context.Context) (*domain.TOTPStatus, error)
+	MockListMySessionsFn                    func(ctx context.Context) ([]*domain.UserSession, error)
+	MockRevokeSessionFn                     func(ctx context.Context, sessionID string) (bool, error)
+	MockRevokeAllOtherSessionsFn            func(ctx context.Context) (bool, error)
+	MockForceLogoutUserFn                   func(ctx context.Context, userID string) (bool, error)
+	MockBulkRegisterClientsFn               func(ctx context.Context, input *dto.BulkClientRegistrationInput) (*dto.BulkClientRegistrationOutput, error)
+	MockFindDuplicateClientsFn              func(ctx context.Context, clientID string) ([]*domain.DuplicateClient, error)
+	MockMergeClientsFn                      func(ctx context.Context, survivingClientID string, duplicateClientID string) (*domain.ClientMerge, error)
+	MockExportClientDataFn                  func(ctx context.Context, input *dto.ClientDataExportInput) (*dto.ClientDataExport, error)
+	MockPurgeClientsDueForErasureFn         func(ctx context.Context) (int, error)
+	MockRevokeCaregiverDelegationFn         func(ctx context.Context, clientID string, caregiverID string) (bool, error)
+	MockEndClientCaregiverAccessFn          func(ctx context.Context, clientID string, endDate scalarutils.Date) (int, error)
+	MockExpireCaregiverDelegationsFn        func(ctx context.Context) (int, error)
+	MockRequestPhoneChangeFn                func(ctx context.Context, phoneNumber string, flavour feedlib.Flavour) (bool, error)
+	MockConfirmPhoneChangeFn                func(ctx context.Context, phoneNumber string, otp string, flavour feedlib.Flavour) (bool, error)
+	MockRequestAssistedPhoneChangeFn        func(ctx context.Context, userID string, phoneNumber string, flavour feedlib.Flavour, reason string) (bool, error)
+	MockConfirmAssistedPhoneChangeFn        func(ctx context.Context, userID string, phoneNumber string, otp string, flavour feedlib.Flavour) (bool, error)
+	MockOffboardStaffFn                     func(ctx context.Context, input *dto.StaffOffboardingInput) (*domain.StaffOffboarding, error)
+	MockTransferClientFn                    func(ctx context.Context, input *dto.ClientTransferInput) (*domain.ClientTransfer, error)
+	MockListClientTransfersFn               func(ctx context.Context, clientID string) ([]*domain.ClientTransfer, error)
 }
 
 // NewUserUseCaseMock creates in initializes create type mocks
@@ -575,6 +600,159 @@ func NewUserUseCaseMock() *UserUseCaseMock {
 				UserID: gofakeit.UUID(),
 			}, nil
 		},
+		MockEnrollTOTPFn: func(ctx context.Context) (*domain.TOTPEnrollment, error) {
+			return &domain.TOTPEnrollment{
+				Secret: "JBSWY3DPEHPK3PXP",
+				URI:    "otpauth://totp/myCareHub:johndoe?issuer=myCareHub&secret=JBSWY3DPEHPK3PXP",
+			}, nil
+		},
+		MockConfirmTOTPEnrollmentFn: func(ctx context.Context, code string) ([]string, error) {
+			return []string{"abcde-23456"}, nil
+		},
+		MockRegenerateTOTPRecoveryCodesFn: func(ctx context.Context, code string) ([]string, error) {
+			return []string{"abcde-23456"}, nil
+		},
+		MockDisableTOTPFn: func(ctx context.Context, code string) (bool, error) {
+			return true, nil
+		},
+		MockGetTOTPStatusFn: func(ctx context.Context) (*domain.TOTPStatus, error) {
+			return &domain.TOTPStatus{
+				Enabled:                true,
+				Enforced:               false,
+				RemainingRecoveryCodes: 10,
+			}, nil
+		},
+		MockListMySessionsFn: func(ctx context.Context) ([]*domain.UserSession, error) {
+			now := time.Now()
+			return []*domain.UserSession{
+				{
+					ID:         UUID,
+					DeviceID:   UUID,
+					DeviceName: "Pixel 7",
+					UserAgent:  "okhttp/4.9.2",
+					IPAddress:  "127.0.0.1",
+					CreatedAt:  now,
+					LastSeenAt: &now,
+					Current:    true,
+				},
+			}, nil
+		},
+		MockRevokeSessionFn: func(ctx context.Context, sessionID string) (bool, error) {
+			return true, nil
+		},
+		MockRevokeAllOtherSessionsFn: func(ctx context.Context) (bool, error) {
+			return true, nil
+		},
+		MockForceLogoutUserFn: func(ctx context.Context, userID string) (bool, error) {
+			return true, nil
+		},
+		MockBulkRegisterClientsFn: func(ctx context.Context, input *dto.BulkClientRegistrationInput) (*dto.BulkClientRegistrationOutput, error) {
+			return &dto.BulkClientRegistrationOutput{
+				DryRun:     input.DryRun,
+				TotalRows:  1,
+				ValidRows:  1,
+				Registered: 1,
+				Errors:     []*dto.BulkClientRegistrationError{},
+			}, nil
+		},
+		MockFindDuplicateClientsFn: func(ctx context.Context, clientID string) ([]*domain.DuplicateClient, error) {
+			return []*domain.DuplicateClient{
+				{
+					Client:  clientProfile,
+					Score:   50,
+					Matches: []enums.DuplicateClientMatch{enums.DuplicateClientMatchCCCNumber},
+				},
+			}, nil
+		},
+		MockMergeClientsFn: func(ctx context.Context, survivingClientID string, duplicateClientID string) (*domain.ClientMerge, error) {
+			return &domain.ClientMerge{
+				SurvivingClientID:  survivingClientID,
+				DuplicateClientID:  duplicateClientID,
+				HealthDiaryEntries: 1,
+				ServiceRequests:    1,
+			}, nil
+		},
+		MockExportClientDataFn: func(ctx context.Context, input *dto.ClientDataExportInput) (*dto.ClientDataExport, error) {
+			return &dto.ClientDataExport{
+				FileName: "client-" + input.ClientID + ".zip",
+				Content:  "UEsFBgAAAAAAAAAAAAAAAAAAAAAAAA==",
+			}, nil
+		},
+		MockPurgeClientsDueForErasureFn: func(ctx context.Context) (int, error) {
+			return 1, nil
+		},
+		MockRevokeCaregiverDelegationFn: func(ctx context.Context, clientID string, caregiverID string) (bool, error) {
+			return true, nil
+		},
+		MockEndClientCaregiverAccessFn: func(ctx context.Context, clientID string, endDate scalarutils.Date) (int, error) {
+			return 1, nil
+		},
+		MockExpireCaregiverDelegationsFn: func(ctx context.Context) (int, error) {
+			return 1, nil
+		},
+		MockRequestPhoneChangeFn: func(ctx context.Context, phoneNumber string, flavour feedlib.Flavour) (bool, error) {
+			return true, nil
+		},
+		MockConfirmPhoneChangeFn: func(ctx context.Context, phoneNumber string, otp string, flavour feedlib.Flavour) (bool, error) {
+			return true, nil
+		},
+		MockRequestAssistedPhoneChangeFn: func(ctx context.Context, userID string, phoneNumber string, flavour feedlib.Flavour, reason string) (bool, error) {
+			return true, nil
+		},
+		MockConfirmAssistedPhoneChangeFn: func(ctx context.Context, userID string, phoneNumber string, otp string, flavour feedlib.Flavour) (bool, error) {
+			return true, nil
+		},
+		MockOffboardStaffFn: func(ctx context.Context, input *dto.StaffOffboardingInput) (*domain.StaffOffboarding, error) {
+			return &domain.StaffOffboarding{
+				StaffID:         input.StaffID,
+				UserID:          UUID,
+				Name:            name,
+				OrganisationID:  UUID,
+				Reason:          input.Reason,
+				DryRun:          input.DryRun,
+				StaffProfiles:   []string{input.StaffID},
+				UserDeactivated: true,
+				ServiceRequests: []*domain.StaffOffboardingServiceRequest{},
+			}, nil
+		},
+		MockTransferClientFn: func(ctx context.Context, input *dto.ClientTransferInput) (*domain.ClientTransfer, error) {
+			return &domain.ClientTransfer{
+				ID:                 UUID,
+				UserID:             UUID,
+				ClientID:           input.ClientID,
+				NewClientID:        input.ClientID,
+				FromFacilityID:     UUID,
+				ToFacilityID:       input.FacilityID,
+				FromProgramID:      UUID,
+				ToProgramID:        UUID,
+				FromOrganisationID: UUID,
+				ToOrganisationID:   UUID,
+				Reason:             input.Reason,
+				CarriedHistory:     []enums.ClientTransferHistory{},
+				TransferredBy:      UUID,
+				TransferredAt:      time.Now(),
+			}, nil
+		},
+		MockListClientTransfersFn: func(ctx context.Context, clientID string) ([]*domain.ClientTransfer, error) {
+			return []*domain.ClientTransfer{
+				{
+					ID:                 UUID,
+					UserID:             UUID,
+					ClientID:           clientID,
+					NewClientID:        clientID,
+					FromFacilityID:     UUID,
+					ToFacilityID:       UUID,
+					FromProgramID:      UUID,
+					ToProgramID:        UUID,
+					FromOrganisationID: UUID,
+					ToOrganisationID:   UUID,
+					Reason:             "Relocated",
+					CarriedHistory:     []enums.ClientTransferHistory{},
+					TransferredBy:      UUID,
+					TransferredAt:      time.Now(),
+				},
+			}, nil
+		},
 	}
 }
 
@@ -853,3 +1031,123 @@ func (f *UserUseCaseMock) UpdateOrganisationAdminPermission(ctx context.Context,
 func (f *UserUseCaseMock) ClientSignUp(ctx context.Context, input *dto.ClientSelfSignUp) (*dto.ClientRegistrationOutput, error) {
 	return f.MockClientSignUpFn(ctx, input)
 }
+
+// EnrollTOTP mocks the implementation of starting the enrollment of an authenticator app
+func (f *UserUseCaseMock) EnrollTOTP(ctx context.Context) (*domain.TOTPEnrollment, error) {
+	return f.MockEnrollTOTPFn(ctx)
+}
+
+// ConfirmTOTPEnrollment mocks the implementation of confirming the enrollment of an authenticator app
+func (f *UserUseCaseMock) ConfirmTOTPEnrollment(ctx context.Context, code string) ([]string, error) {
+	return f.MockConfirmTOTPEnrollmentFn(ctx, code)
+}
+
+// RegenerateTOTPRecoveryCodes mocks the implementation of regenerating recovery codes
+func (f *UserUseCaseMock) RegenerateTOTPRecoveryCodes(ctx context.Context, code string) ([]string, error) {
+	return f.MockRegenerateTOTPRecoveryCodesFn(ctx, code)
+}
+
+// DisableTOTP mocks the implementation of removing an authenticator app
+func (f *UserUseCaseMock) DisableTOTP(ctx context.Context, code string) (bool, error) {
+	return f.MockDisableTOTPFn(ctx, code)
+}
+
+// GetTOTPStatus mocks the implementation of retrieving a user's authenticator app status
+func (f *UserUseCaseMock) GetTOTPStatus(ctx context.Context) (*domain.TOTPStatus, error) {
+	return f.MockGetTOTPStatusFn(ctx)
+}
+
+// ListMySessions mocks the implementation of listing the logged in user's sessions
+func (f *UserUseCaseMock) ListMySessions(ctx context.Context) ([]*domain.UserSession, error) {
+	return f.MockListMySessionsFn(ctx)
+}
+
+// RevokeSession mocks the implementation of logging the user out of one of their sessions
+func (f *UserUseCaseMock) RevokeSession(ctx context.Context, sessionID string) (bool, error) {
+	return f.MockRevokeSessionFn(ctx, sessionID)
+}
+
+// RevokeAllOtherSessions mocks the implementation of logging the user out of their other sessions
+func (f *UserUseCaseMock) RevokeAllOtherSessions(ctx context.Context) (bool, error) {
+	return f.MockRevokeAllOtherSessionsFn(ctx)
+}
+
+// ForceLogoutUser mocks the implementation of a staff logging a user out of all their sessions
+func (f *UserUseCaseMock) ForceLogoutUser(ctx context.Context, userID string) (bool, error) {
+	return f.MockForceLogoutUserFn(ctx, userID)
+}
+
+// BulkRegisterClients mocks the implementation of registering the clients in a csv
+func (f *UserUseCaseMock) BulkRegisterClients(ctx context.Context, input *dto.BulkClientRegistrationInput) (*dto.BulkClientRegistrationOutput, error) {
+	return f.MockBulkRegisterClientsFn(ctx, input)
+}
+
+// FindDuplicateClients mocks the implementation of finding the clients that may be duplicates of a client
+func (f *UserUseCaseMock) FindDuplicateClients(ctx context.Context, clientID string) ([]*domain.DuplicateClient, error) {
+	return f.MockFindDuplicateClientsFn(ctx, clientID)
+}
+
+// MergeClients mocks the implementation of merging a duplicate client into a surviving client
+func (f *UserUseCaseMock) MergeClients(ctx context.Context, survivingClientID string, duplicateClientID string) (*domain.ClientMerge, error) {
+	return f.MockMergeClientsFn(ctx, survivingClientID, duplicateClientID)
+}
+
+// ExportClientData mocks the implementation of exporting all the data held about a client
+func (f *UserUseCaseMock) ExportClientData(ctx context.Context, input *dto.ClientDataExportInput) (*dto.ClientDataExport, error) {
+	return f.MockExportClientDataFn(ctx, input)
+}
+
+// PurgeClientsDueForErasure mocks the implementation of erasing the clients whose data retention period has passed
+func (f *UserUseCaseMock) PurgeClientsDueForErasure(ctx context.Context) (int, error) {
+	return f.MockPurgeClientsDueForErasureFn(ctx)
+}
+
+// RevokeCaregiverDelegation mocks the implementation of a client revoking a caregiver's access
+func (f *UserUseCaseMock) RevokeCaregiverDelegation(ctx context.Context, clientID string, caregiverID string) (bool, error) {
+	return f.MockRevokeCaregiverDelegationFn(ctx, clientID, caregiverID)
+}
+
+// EndClientCaregiverAccess mocks the implementation of setting the date that a client's caregivers' access ends
+func (f *UserUseCaseMock) EndClientCaregiverAccess(ctx context.Context, clientID string, endDate scalarutils.Date) (int, error) {
+	return f.MockEndClientCaregiverAccessFn(ctx, clientID, endDate)
+}
+
+// ExpireCaregiverDelegations mocks the implementation of expiring the caregiver delegations whose end date has passed
+func (f *UserUseCaseMock) ExpireCaregiverDelegations(ctx context.Context) (int, error) {
+	return f.MockExpireCaregiverDelegationsFn(ctx)
+}
+
+// RequestPhoneChange mocks the implementation of starting a change of the logged in user's phone number
+func (f *UserUseCaseMock) RequestPhoneChange(ctx context.Context, phoneNumber string, flavour feedlib.Flavour) (bool, error) {
+	return f.MockRequestPhoneChangeFn(ctx, phoneNumber, flavour)
+}
+
+// ConfirmPhoneChange mocks the implementation of confirming a change of the logged in user's phone number
+func (f *UserUseCaseMock) ConfirmPhoneChange(ctx context.Context, phoneNumber string, otp string, flavour feedlib.Flavour) (bool, error) {
+	return f.MockConfirmPhoneChangeFn(ctx, phoneNumber, otp, flavour)
+}
+
+// RequestAssistedPhoneChange mocks the implementation of a staff starting a change of a user's phone number
+func (f *UserUseCaseMock) RequestAssistedPhoneChange(ctx context.Context, userID string, phoneNumber string, flavour feedlib.Flavour, reason string) (bool, error) {
+	return f.MockRequestAssistedPhoneChangeFn(ctx, userID, phoneNumber, flavour, reason)
+}
+
+// ConfirmAssistedPhoneChange mocks the implementation of a staff confirming a change of a user's phone number
+func (f *UserUseCaseMock) ConfirmAssistedPhoneChange(ctx context.Context, userID string, phoneNumber string, otp string, flavour feedlib.Flavour) (bool, error) {
+	return f.MockConfirmAssistedPhoneChangeFn(ctx, userID, phoneNumber, otp, flavour)
+}
+
+// OffboardStaff mocks the implementation of removing a staff who has left an organisation
+func (f *UserUseCaseMock) OffboardStaff(ctx context.Context, input *dto.StaffOffboardingInput) (*domain.StaffOffboarding, error) {
+	return f.MockOffboardStaffFn(ctx, input)
+}
+
+// TransferClient mocks the implementation of transferring a client between facilities, programs and organisations
+func (f *UserUseCaseMock) TransferClient(ctx context.Context, input *dto.ClientTransferInput) (*domain.ClientTransfer, error) {
+	return f.MockTransferClientFn(ctx, input)
+}
+
+// ListClientTransfers mocks the implementation of listing a client's transfers
+func (f *UserUseCaseMock) ListClientTransfers(ctx context.Context, clientID string) ([]*domain.ClientTransfer, error) {
+	return f.MockListClientTransfersFn(ctx, clientID)
+}
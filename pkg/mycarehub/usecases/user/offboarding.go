@@ -0,0 +1,289 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/common"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/notification"
+	"github.com/savannahghi/serverutils"
+)
+
+// OffboardStaff removes a staff who has left the organisation. Their staff profiles in the organisation are deactivated, their
+// organisation admin rights are removed and they are logged out of all their sessions. Their in progress service requests are
+// reassigned or released back to the facility's queue. The user and their Matrix account are deactivated unless they have other profiles.
+// The organisation admins are sent a summary of the offboarding. A dry run returns the summary without making any changes
+func (us *UseCasesUserImpl) OffboardStaff(ctx context.Context, input *dto.StaffOffboardingInput) (*domain.StaffOffboarding, error) {
+	ctx, span := tracer.Start(ctx, "OffboardStaff")
+	defer span.End()
+
+	if err := input.Validate(); err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InputValidationErr(fmt.Errorf("failed to validate staff offboarding input: %w", err))
+	}
+
+	loggedInUser, err := us.loggedInStaffUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	loggedInStaff, err := us.Query.GetStaffProfile(ctx, *loggedInUser.ID, loggedInUser.CurrentProgramID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.StaffProfileNotFoundErr(err)
+	}
+
+	if !loggedInStaff.IsOrganisationAdmin {
+		err := fmt.Errorf("staff %s is not an organisation admin and cannot offboard staff", *loggedInUser.ID)
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.UserNotAuthorizedErr(err)
+	}
+
+	staffProfile, err := us.Query.GetStaffProfileByStaffID(ctx, input.StaffID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.StaffProfileNotFoundErr(err)
+	}
+
+	if staffProfile.OrganisationID != loggedInUser.CurrentOrganizationID {
+		err := fmt.Errorf("staff %s does not belong to the organisation of staff %s", input.StaffID, *loggedInUser.ID)
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.UserNotAuthorizedErr(err)
+	}
+
+	if staffProfile.UserID == *loggedInUser.ID {
+		return nil, exceptions.InputValidationErr(fmt.Errorf("a staff cannot offboard themselves"))
+	}
+
+	if !staffProfile.Active {
+		return nil, exceptions.InputValidationErr(fmt.Errorf("staff %s is inactive and may have already been offboarded", input.StaffID))
+	}
+
+	userProfile, err := us.Query.GetUserProfileByUserID(ctx, staffProfile.UserID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.UserNotFoundError(err)
+	}
+
+	offboarding, err := us.planStaffOffboarding(ctx, staffProfile, userProfile, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.DryRun {
+		return offboarding, nil
+	}
+
+	err = us.Update.OffboardStaff(ctx, offboarding, time.Now())
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InternalErr(fmt.Errorf("failed to offboard staff: %w", err))
+	}
+
+	if offboarding.MatrixAccountDeactivated {
+		auth := &domain.MatrixAuth{
+			Username: serverutils.MustGetEnvVar("MCH_MATRIX_USER"),
+			Password: serverutils.MustGetEnvVar("MCH_MATRIX_PASSWORD"),
+		}
+
+		matrixUserID := fmt.Sprintf("@%s:%s", userProfile.Username, serverutils.MustGetEnvVar("MATRIX_DOMAIN"))
+
+		if err := us.Matrix.DeactivateUser(ctx, matrixUserID, auth); err != nil {
+			helpers.ReportErrorToSentry(fmt.Errorf("failed to deactivate the matrix account of staff %s: %w", input.StaffID, err))
+			offboarding.MatrixAccountDeactivated = false
+		}
+	}
+
+	common.RecordAuditLog(ctx, us.Create, &domain.AuditLog{
+		RecordType:     enums.AuditLogStaffOffboarding,
+		Notes:          fmt.Sprintf("staff offboarded: %s", input.Reason),
+		ActorID:        *loggedInUser.ID,
+		TargetID:       input.StaffID,
+		TargetType:     enums.AuditLogTargetStaff,
+		ProgramID:      loggedInUser.CurrentProgramID,
+		OrganisationID: loggedInUser.CurrentOrganizationID,
+		Before: map[string]interface{}{
+			"staff_profiles":        offboarding.StaffProfiles,
+			"is_organisation_admin": offboarding.OrganisationAdminRemoved,
+		},
+		After: map[string]interface{}{
+			"user_deactivated":           offboarding.UserDeactivated,
+			"matrix_account_deactivated": offboarding.MatrixAccountDeactivated,
+			"revoked_sessions":           offboarding.RevokedSessions,
+			"service_requests":           offboarding.ServiceRequests,
+		},
+	})
+
+	us.notifyOrganisationAdmins(ctx, userProfile, offboarding)
+
+	return offboarding, nil
+}
+
+// planStaffOffboarding works out what is changed when a staff is offboarded from the organisation
+func (us *UseCasesUserImpl) planStaffOffboarding(ctx context.Context, staffProfile *domain.StaffProfile, userProfile *domain.User, input *dto.StaffOffboardingInput) (*domain.StaffOffboarding, error) {
+	offboarding := &domain.StaffOffboarding{
+		StaffID:         input.StaffID,
+		UserID:          staffProfile.UserID,
+		Name:            userProfile.Name,
+		OrganisationID:  staffProfile.OrganisationID,
+		Reason:          input.Reason,
+		DryRun:          input.DryRun,
+		StaffProfiles:   []string{},
+		ServiceRequests: []*domain.StaffOffboardingServiceRequest{},
+	}
+
+	staffProfiles, err := us.Query.GetUserStaffProfiles(ctx, staffProfile.UserID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InternalErr(fmt.Errorf("failed to get user staff profiles: %w", err))
+	}
+
+	hasOtherProfiles := false
+	for _, profile := range staffProfiles {
+		if !profile.Active {
+			continue
+		}
+
+		if profile.OrganisationID != staffProfile.OrganisationID {
+			hasOtherProfiles = true
+			continue
+		}
+
+		offboarding.StaffProfiles = append(offboarding.StaffProfiles, *profile.ID)
+		if profile.IsOrganisationAdmin {
+			offboarding.OrganisationAdminRemoved = true
+		}
+	}
+
+	if !hasOtherProfiles {
+		hasOtherProfiles, err = us.hasClientOrCaregiverProfile(ctx, userProfile)
+		if err != nil {
+			helpers.ReportErrorToSentry(err)
+			return nil, exceptions.InternalErr(fmt.Errorf("failed to check the user's other profiles: %w", err))
+		}
+	}
+
+	offboarding.UserDeactivated = !hasOtherProfiles
+	offboarding.MatrixAccountDeactivated = !hasOtherProfiles
+
+	sessions, err := us.listActiveSessions(ctx, staffProfile.UserID)
+	if err != nil {
+		return nil, err
+	}
+	offboarding.RevokedSessions = len(sessions)
+
+	var reassignTo *domain.StaffProfile
+	if input.ReassignTo != nil {
+		reassignTo, err = us.Query.GetStaffProfileByStaffID(ctx, *input.ReassignTo)
+		if err != nil {
+			helpers.ReportErrorToSentry(err)
+			return nil, exceptions.StaffProfileNotFoundErr(err)
+		}
+
+		if !reassignTo.Active || reassignTo.OrganisationID != staffProfile.OrganisationID || reassignTo.UserID == staffProfile.UserID {
+			err := fmt.Errorf("service requests can only be reassigned to another active staff in the organisation")
+			helpers.ReportErrorToSentry(err)
+			return nil, exceptions.InputValidationErr(err)
+		}
+	}
+
+	serviceRequests, err := us.Query.GetStaffInProgressServiceRequests(ctx, offboarding.StaffProfiles)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InternalErr(fmt.Errorf("failed to get staff in progress service requests: %w", err))
+	}
+
+	for _, serviceRequest := range serviceRequests {
+		handover := &domain.StaffOffboardingServiceRequest{
+			ID:          serviceRequest.ID,
+			RequestType: serviceRequest.RequestType,
+			FacilityID:  serviceRequest.FacilityID,
+			ProgramID:   serviceRequest.ProgramID,
+		}
+
+		// a request that the staff cannot work on at their facility is released back to the facility's queue instead
+		if reassignTo != nil && reassignTo.ProgramID == serviceRequest.ProgramID {
+			facilities, _, err := us.Query.GetStaffFacilities(ctx, dto.StaffFacilityInput{
+				StaffID:    reassignTo.ID,
+				FacilityID: &serviceRequest.FacilityID,
+			}, nil)
+			if err != nil {
+				helpers.ReportErrorToSentry(err)
+				return nil, exceptions.InternalErr(fmt.Errorf("failed to get staff facilities: %w", err))
+			}
+
+			if len(facilities) > 0 {
+				handover.ReassignedTo = reassignTo.ID
+			}
+		}
+
+		offboarding.ServiceRequests = append(offboarding.ServiceRequests, handover)
+	}
+
+	return offboarding, nil
+}
+
+// hasClientOrCaregiverProfile checks whether a user is also a client or a caregiver and should keep their user account
+func (us *UseCasesUserImpl) hasClientOrCaregiverProfile(ctx context.Context, user *domain.User) (bool, error) {
+	clientProfiles, err := us.Query.GetUserClientProfiles(ctx, *user.ID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, clientProfile := range clientProfiles {
+		if clientProfile.Active {
+			return true, nil
+		}
+	}
+
+	caregiverProfiles, err := us.Query.SearchCaregiverUser(ctx, user.Username)
+	if err != nil {
+		return false, err
+	}
+
+	for _, caregiverProfile := range caregiverProfiles {
+		if caregiverProfile.UserID == *user.ID {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// notifyOrganisationAdmins sends the organisation admins a summary of a staff's offboarding
+func (us *UseCasesUserImpl) notifyOrganisationAdmins(ctx context.Context, subject *domain.User, offboarding *domain.StaffOffboarding) {
+	admins, err := us.Query.ListOrganisationAdmins(ctx, offboarding.OrganisationID)
+	if err != nil {
+		helpers.ReportErrorToSentry(fmt.Errorf("failed to list organisation admins to notify of staff offboarding: %w", err))
+		return
+	}
+
+	notified := map[string]bool{offboarding.UserID: true}
+	for _, admin := range admins {
+		if notified[admin.UserID] {
+			continue
+		}
+		notified[admin.UserID] = true
+
+		adminProfile, err := us.Query.GetUserProfileByUserID(ctx, admin.UserID)
+		if err != nil {
+			helpers.ReportErrorToSentry(fmt.Errorf("failed to get profile of organisation admin %s: %w", admin.UserID, err))
+			continue
+		}
+
+		offboardingNotification := notification.ComposeStaffNotification(enums.NotificationTypeStaffOffboarded, notification.StaffNotificationArgs{
+			Subject:     subject,
+			Offboarding: offboarding,
+		})
+
+		if err := us.Notification.NotifyUser(ctx, adminProfile, offboardingNotification); err != nil {
+			helpers.ReportErrorToSentry(err)
+		}
+	}
+}
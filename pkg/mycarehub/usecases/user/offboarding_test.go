@@ -0,0 +1,390 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	extensionMock "github.com/savannahghi/mycarehub/pkg/mycarehub/application/extension/mock"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	pgMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/mock"
+	clinicalMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/clinical/mock"
+	matrixMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/matrix/mock"
+	pubsubMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/pubsub/mock"
+	smsMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/sms/mock"
+	surveysMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/surveys/mock"
+	twilioMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/twilio/mock"
+	authorityMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/authority/mock"
+	notificationMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/notification/mock"
+	otpMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/otp/mock"
+)
+
+func TestUseCasesUserImpl_OffboardStaff(t *testing.T) {
+	staffID := uuid.New().String()
+	reassignTo := uuid.New().String()
+
+	tests := []struct {
+		name    string
+		input   *dto.StaffOffboardingInput
+		wantErr bool
+	}{
+		{
+			name: "Happy case: offboard staff",
+			input: &dto.StaffOffboardingInput{
+				StaffID: staffID,
+				Reason:  "Left the organisation",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case: dry run",
+			input: &dto.StaffOffboardingInput{
+				StaffID: staffID,
+				Reason:  "Left the organisation",
+				DryRun:  true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case: reassign service requests",
+			input: &dto.StaffOffboardingInput{
+				StaffID:    staffID,
+				ReassignTo: &reassignTo,
+				Reason:     "Left the organisation",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case: release service requests the staff cannot work on",
+			input: &dto.StaffOffboardingInput{
+				StaffID:    staffID,
+				ReassignTo: &reassignTo,
+				Reason:     "Left the organisation",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case: keep the account of a staff who is also a client",
+			input: &dto.StaffOffboardingInput{
+				StaffID: staffID,
+				Reason:  "Left the organisation",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case: unable to deactivate matrix account",
+			input: &dto.StaffOffboardingInput{
+				StaffID: staffID,
+				Reason:  "Left the organisation",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: invalid input",
+			input: &dto.StaffOffboardingInput{
+				StaffID: staffID,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: unable to get logged in user",
+			input: &dto.StaffOffboardingInput{
+				StaffID: staffID,
+				Reason:  "Left the organisation",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: logged in staff is not an organisation admin",
+			input: &dto.StaffOffboardingInput{
+				StaffID: staffID,
+				Reason:  "Left the organisation",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: unable to get staff profile",
+			input: &dto.StaffOffboardingInput{
+				StaffID: staffID,
+				Reason:  "Left the organisation",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: staff belongs to another organisation",
+			input: &dto.StaffOffboardingInput{
+				StaffID: staffID,
+				Reason:  "Left the organisation",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: staff offboarding themselves",
+			input: &dto.StaffOffboardingInput{
+				StaffID: staffID,
+				Reason:  "Left the organisation",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: staff is inactive",
+			input: &dto.StaffOffboardingInput{
+				StaffID: staffID,
+				Reason:  "Left the organisation",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: reassign to an inactive staff",
+			input: &dto.StaffOffboardingInput{
+				StaffID:    staffID,
+				ReassignTo: &reassignTo,
+				Reason:     "Left the organisation",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: unable to get in progress service requests",
+			input: &dto.StaffOffboardingInput{
+				StaffID: staffID,
+				Reason:  "Left the organisation",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: unable to offboard staff",
+			input: &dto.StaffOffboardingInput{
+				StaffID: staffID,
+				Reason:  "Left the organisation",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeOTP := otpMock.NewOTPUseCaseMock()
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			fakePubsub := pubsubMock.NewPubsubServiceMock()
+			fakeClinical := clinicalMock.NewClinicalServiceMock()
+			fakeSMS := smsMock.NewSMSServiceMock()
+			fakeTwilio := twilioMock.NewTwilioServiceMock()
+			fakeMatrix := matrixMock.NewMatrixMock()
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+
+			us := NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
+
+			adminUserID := uuid.New().String()
+			adminStaffID := uuid.New().String()
+			staffUserID := uuid.New().String()
+			reassignUserID := uuid.New().String()
+			organisationID := uuid.New().String()
+			programID := uuid.New().String()
+			facilityID := uuid.New().String()
+
+			staffProfile := &domain.StaffProfile{
+				ID:                  &staffID,
+				UserID:              staffUserID,
+				Active:              true,
+				OrganisationID:      organisationID,
+				ProgramID:           programID,
+				IsOrganisationAdmin: true,
+			}
+			reassignProfile := &domain.StaffProfile{
+				ID:             &reassignTo,
+				UserID:         reassignUserID,
+				Active:         true,
+				OrganisationID: organisationID,
+				ProgramID:      programID,
+			}
+
+			fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+				return adminUserID, nil
+			}
+			fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.User, error) {
+				return &domain.User{
+					ID:                    &userID,
+					Username:              "user",
+					Name:                  "Test User",
+					CurrentOrganizationID: organisationID,
+					CurrentProgramID:      programID,
+				}, nil
+			}
+			fakeDB.MockGetStaffProfileFn = func(ctx context.Context, userID string, programID string) (*domain.StaffProfile, error) {
+				return &domain.StaffProfile{
+					ID:                  &adminStaffID,
+					UserID:              adminUserID,
+					Active:              true,
+					OrganisationID:      organisationID,
+					ProgramID:           programID,
+					IsOrganisationAdmin: true,
+				}, nil
+			}
+			fakeDB.MockGetStaffProfileByStaffIDFn = func(ctx context.Context, id string) (*domain.StaffProfile, error) {
+				if id == reassignTo {
+					return reassignProfile, nil
+				}
+				return staffProfile, nil
+			}
+			fakeDB.MockGetUserStaffProfilesFn = func(ctx context.Context, userID string) ([]*domain.StaffProfile, error) {
+				return []*domain.StaffProfile{staffProfile}, nil
+			}
+			fakeDB.MockGetUserClientProfilesFn = func(ctx context.Context, userID string) ([]*domain.ClientProfile, error) {
+				return []*domain.ClientProfile{}, nil
+			}
+			fakeDB.MockSearchCaregiverUserFn = func(ctx context.Context, searchParameter string) ([]*domain.CaregiverProfile, error) {
+				return []*domain.CaregiverProfile{}, nil
+			}
+			fakeDB.MockGetStaffInProgressServiceRequestsFn = func(ctx context.Context, staffIDs []string) ([]*domain.ServiceRequest, error) {
+				return []*domain.ServiceRequest{
+					{
+						ID:          uuid.New().String(),
+						RequestType: "RED_FLAG",
+						FacilityID:  facilityID,
+						ProgramID:   programID,
+					},
+				}, nil
+			}
+			fakeDB.MockGetStaffFacilitiesFn = func(ctx context.Context, input dto.StaffFacilityInput, pagination *domain.Pagination) ([]*domain.Facility, *domain.Pagination, error) {
+				return []*domain.Facility{{ID: &facilityID}}, nil, nil
+			}
+			fakeDB.MockListOrganisationAdminsFn = func(ctx context.Context, organisationID string) ([]*domain.StaffProfile, error) {
+				return []*domain.StaffProfile{
+					{ID: &adminStaffID, UserID: adminUserID, Active: true, IsOrganisationAdmin: true},
+					staffProfile,
+				}, nil
+			}
+
+			offboarded := false
+			fakeDB.MockOffboardStaffFn = func(ctx context.Context, offboarding *domain.StaffOffboarding, offboardedAt time.Time) error {
+				offboarded = true
+				return nil
+			}
+
+			var deactivated string
+			fakeMatrix.MockDeactivateUserFn = func(ctx context.Context, userID string, auth *domain.MatrixAuth) error {
+				deactivated = userID
+				return nil
+			}
+
+			var notified []string
+			fakeNotification.MockNotifyUserFn = func(ctx context.Context, userProfile *domain.User, notificationPayload *domain.Notification) error {
+				notified = append(notified, *userProfile.ID)
+				return nil
+			}
+
+			if tt.name == "Happy case: release service requests the staff cannot work on" {
+				fakeDB.MockGetStaffFacilitiesFn = func(ctx context.Context, input dto.StaffFacilityInput, pagination *domain.Pagination) ([]*domain.Facility, *domain.Pagination, error) {
+					return []*domain.Facility{}, nil, nil
+				}
+			}
+			if tt.name == "Happy case: keep the account of a staff who is also a client" {
+				fakeDB.MockGetUserClientProfilesFn = func(ctx context.Context, userID string) ([]*domain.ClientProfile, error) {
+					return []*domain.ClientProfile{{UserID: userID, Active: true}}, nil
+				}
+			}
+			if tt.name == "Happy case: unable to deactivate matrix account" {
+				fakeMatrix.MockDeactivateUserFn = func(ctx context.Context, userID string, auth *domain.MatrixAuth) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get logged in user" {
+				fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+					return "", fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: logged in staff is not an organisation admin" {
+				fakeDB.MockGetStaffProfileFn = func(ctx context.Context, userID string, programID string) (*domain.StaffProfile, error) {
+					return &domain.StaffProfile{ID: &adminStaffID, UserID: adminUserID, Active: true}, nil
+				}
+			}
+			if tt.name == "Sad case: unable to get staff profile" {
+				fakeDB.MockGetStaffProfileByStaffIDFn = func(ctx context.Context, id string) (*domain.StaffProfile, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: staff belongs to another organisation" {
+				staffProfile.OrganisationID = uuid.New().String()
+			}
+			if tt.name == "Sad case: staff offboarding themselves" {
+				staffProfile.UserID = adminUserID
+			}
+			if tt.name == "Sad case: staff is inactive" {
+				staffProfile.Active = false
+			}
+			if tt.name == "Sad case: reassign to an inactive staff" {
+				reassignProfile.Active = false
+			}
+			if tt.name == "Sad case: unable to get in progress service requests" {
+				fakeDB.MockGetStaffInProgressServiceRequestsFn = func(ctx context.Context, staffIDs []string) ([]*domain.ServiceRequest, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to offboard staff" {
+				fakeDB.MockOffboardStaffFn = func(ctx context.Context, offboarding *domain.StaffOffboarding, offboardedAt time.Time) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := us.OffboardStaff(context.Background(), tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesUserImpl.OffboardStaff() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if tt.name == "Happy case: offboard staff" {
+				if !offboarded {
+					t.Errorf("expected the staff to be offboarded")
+				}
+				if !got.UserDeactivated || !got.OrganisationAdminRemoved {
+					t.Errorf("expected the user to be deactivated and their admin rights removed, got %v", got)
+				}
+				if deactivated == "" {
+					t.Errorf("expected the matrix account to be deactivated")
+				}
+				if len(got.ServiceRequests) != 1 || got.ServiceRequests[0].ReassignedTo != nil {
+					t.Errorf("expected the service request to be released, got %v", got.ServiceRequests)
+				}
+				if len(notified) != 1 || notified[0] != adminUserID {
+					t.Errorf("expected only the organisation admin to be notified, got %v", notified)
+				}
+			}
+			if tt.name == "Happy case: dry run" {
+				if offboarded || deactivated != "" || len(notified) != 0 {
+					t.Errorf("expected a dry run not to make any changes")
+				}
+				if !got.DryRun || !got.UserDeactivated {
+					t.Errorf("expected a dry run to report the changes, got %v", got)
+				}
+			}
+			if tt.name == "Happy case: reassign service requests" {
+				if len(got.ServiceRequests) != 1 || got.ServiceRequests[0].ReassignedTo == nil || *got.ServiceRequests[0].ReassignedTo != reassignTo {
+					t.Errorf("expected the service request to be reassigned, got %v", got.ServiceRequests)
+				}
+			}
+			if tt.name == "Happy case: release service requests the staff cannot work on" {
+				if len(got.ServiceRequests) != 1 || got.ServiceRequests[0].ReassignedTo != nil {
+					t.Errorf("expected the service request to be released, got %v", got.ServiceRequests)
+				}
+			}
+			if tt.name == "Happy case: keep the account of a staff who is also a client" {
+				if got.UserDeactivated || got.MatrixAccountDeactivated || deactivated != "" {
+					t.Errorf("expected a staff who is also a client to keep their account, got %v", got)
+				}
+			}
+			if tt.name == "Happy case: unable to deactivate matrix account" {
+				if got.MatrixAccountDeactivated {
+					t.Errorf("expected the matrix account not to be reported as deactivated")
+				}
+			}
+		})
+	}
+}
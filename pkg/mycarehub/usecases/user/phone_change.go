@@ -0,0 +1,387 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/savannahghi/converterandformatter"
+	"github.com/savannahghi/feedlib"
+	"github.com/savannahghi/interserviceclient"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/common"
+	"github.com/savannahghi/serverutils"
+)
+
+// phoneChangeExpiry is how long a phone change can be confirmed for. It matches the validity of the OTP sent to the new phone number
+const phoneChangeExpiry = time.Minute * 10
+
+const (
+	// profilePhoneChangeReason is recorded when a staff changes a user's phone number while updating their profile
+	profilePhoneChangeReason = "phone number updated from the user's profile"
+
+	phoneChangeRequestedMessage = "A request has been made to change the phone number of your account to a number ending in %s. " +
+		"This phone number will be used until the change is confirmed. If you did not make this request, please contact your health facility."
+)
+
+// RequestPhoneChange starts a change of the logged in user's phone number. An OTP is sent to the new phone number
+// and the current phone number is notified of the change. The current phone number is used until the change is confirmed
+func (us *UseCasesUserImpl) RequestPhoneChange(ctx context.Context, phoneNumber string, flavour feedlib.Flavour) (bool, error) {
+	ctx, span := tracer.Start(ctx, "RequestPhoneChange")
+	defer span.End()
+
+	loggedInUserID, err := us.ExternalExt.GetLoggedInUserUID(ctx)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.GetLoggedInUserUIDErr(err)
+	}
+
+	userProfile, err := us.Query.GetUserProfileByUserID(ctx, loggedInUserID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.UserNotFoundError(err)
+	}
+
+	_, err = us.requestPhoneChange(ctx, userProfile, phoneNumber, flavour, loggedInUserID, "")
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ConfirmPhoneChange replaces the logged in user's phone number with the new phone number once the OTP sent to it is verified
+func (us *UseCasesUserImpl) ConfirmPhoneChange(ctx context.Context, phoneNumber string, otp string, flavour feedlib.Flavour) (bool, error) {
+	ctx, span := tracer.Start(ctx, "ConfirmPhoneChange")
+	defer span.End()
+
+	loggedInUserID, err := us.ExternalExt.GetLoggedInUserUID(ctx)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.GetLoggedInUserUIDErr(err)
+	}
+
+	userProfile, err := us.Query.GetUserProfileByUserID(ctx, loggedInUserID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.UserNotFoundError(err)
+	}
+
+	request, err := us.confirmPhoneChange(ctx, userProfile, phoneNumber, otp, flavour)
+	if err != nil {
+		return false, err
+	}
+
+	common.RecordAuditLog(ctx, us.Create, &domain.AuditLog{
+		RecordType:     enums.AuditLogPhoneNumberChange,
+		Notes:          "user changed their phone number",
+		ActorID:        loggedInUserID,
+		TargetID:       loggedInUserID,
+		TargetType:     enums.AuditLogTargetUser,
+		ProgramID:      userProfile.CurrentProgramID,
+		OrganisationID: userProfile.CurrentOrganizationID,
+		Before:         map[string]interface{}{"phoneNumber": request.OldPhoneNumber},
+		After:          map[string]interface{}{"phoneNumber": request.NewPhoneNumber},
+	})
+
+	return true, nil
+}
+
+// RequestAssistedPhoneChange is used by a staff to start a change of a user's phone number e.g when a client at the facility
+// has lost access to their phone. The OTP is sent to the new phone number for the user to read out to the staff
+func (us *UseCasesUserImpl) RequestAssistedPhoneChange(ctx context.Context, userID string, phoneNumber string, flavour feedlib.Flavour, reason string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "RequestAssistedPhoneChange")
+	defer span.End()
+
+	if strings.TrimSpace(reason) == "" {
+		err := fmt.Errorf("a reason for changing the user's phone number is required")
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.InputValidationErr(err)
+	}
+
+	loggedInUser, userProfile, err := us.phoneChangeStaffAndUser(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	err = us.requestAssistedPhoneChange(ctx, &domain.StaffProfile{
+		UserID:         *loggedInUser.ID,
+		ProgramID:      loggedInUser.CurrentProgramID,
+		OrganisationID: loggedInUser.CurrentOrganizationID,
+	}, userProfile, phoneNumber, flavour, reason)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ConfirmAssistedPhoneChange is used by a staff to confirm a user's phone number change with the OTP sent to the new phone number
+func (us *UseCasesUserImpl) ConfirmAssistedPhoneChange(ctx context.Context, userID string, phoneNumber string, otp string, flavour feedlib.Flavour) (bool, error) {
+	ctx, span := tracer.Start(ctx, "ConfirmAssistedPhoneChange")
+	defer span.End()
+
+	loggedInUser, userProfile, err := us.phoneChangeStaffAndUser(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	request, err := us.confirmPhoneChange(ctx, userProfile, phoneNumber, otp, flavour)
+	if err != nil {
+		return false, err
+	}
+
+	common.RecordAuditLog(ctx, us.Create, &domain.AuditLog{
+		RecordType:     enums.AuditLogPhoneNumberChange,
+		Notes:          "staff confirmed the change of the user's phone number",
+		ActorID:        *loggedInUser.ID,
+		TargetID:       userID,
+		TargetType:     enums.AuditLogTargetUser,
+		ProgramID:      loggedInUser.CurrentProgramID,
+		OrganisationID: loggedInUser.CurrentOrganizationID,
+		Before:         map[string]interface{}{"phoneNumber": request.OldPhoneNumber},
+		After:          map[string]interface{}{"phoneNumber": request.NewPhoneNumber},
+	})
+
+	return true, nil
+}
+
+// phoneChangeStaffAndUser returns the logged in staff and the user whose phone number they are changing.
+// The user should belong to the staff's organisation and only organisation admins can change another staff's phone number
+func (us *UseCasesUserImpl) phoneChangeStaffAndUser(ctx context.Context, userID string) (*domain.User, *domain.User, error) {
+	loggedInUser, err := us.loggedInStaffUser(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	userProfile, err := us.Query.GetUserProfileByUserID(ctx, userID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, nil, exceptions.UserNotFoundError(err)
+	}
+
+	if userProfile.CurrentOrganizationID != loggedInUser.CurrentOrganizationID {
+		err := fmt.Errorf("user %s does not belong to the organisation of staff %s", userID, *loggedInUser.ID)
+		helpers.ReportErrorToSentry(err)
+		return nil, nil, exceptions.UserNotAuthorizedErr(err)
+	}
+
+	isStaff, err := us.Query.CheckStaffExists(ctx, userID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, nil, exceptions.InternalErr(err)
+	}
+
+	if isStaff && userID != *loggedInUser.ID {
+		loggedInStaff, err := us.Query.GetStaffProfile(ctx, *loggedInUser.ID, loggedInUser.CurrentProgramID)
+		if err != nil {
+			helpers.ReportErrorToSentry(err)
+			return nil, nil, exceptions.StaffProfileNotFoundErr(err)
+		}
+
+		if !loggedInStaff.IsOrganisationAdmin {
+			err := fmt.Errorf("staff %s is not an organisation admin and cannot change the phone number of staff %s", *loggedInUser.ID, userID)
+			helpers.ReportErrorToSentry(err)
+			return nil, nil, exceptions.UserNotAuthorizedErr(err)
+		}
+	}
+
+	return loggedInUser, userProfile, nil
+}
+
+// requestAssistedPhoneChange starts a change of a user's phone number on behalf of the user and records it in the audit log
+func (us *UseCasesUserImpl) requestAssistedPhoneChange(ctx context.Context, staff *domain.StaffProfile, userProfile *domain.User, phoneNumber string, flavour feedlib.Flavour, reason string) error {
+	request, err := us.requestPhoneChange(ctx, userProfile, phoneNumber, flavour, staff.UserID, reason)
+	if err != nil {
+		return err
+	}
+
+	common.RecordAuditLog(ctx, us.Create, &domain.AuditLog{
+		RecordType:     enums.AuditLogPhoneNumberChange,
+		Notes:          fmt.Sprintf("staff requested a change of the user's phone number: %s", reason),
+		ActorID:        staff.UserID,
+		TargetID:       *userProfile.ID,
+		TargetType:     enums.AuditLogTargetUser,
+		ProgramID:      staff.ProgramID,
+		OrganisationID: staff.OrganisationID,
+		Before:         map[string]interface{}{"phoneNumber": request.OldPhoneNumber},
+		After:          map[string]interface{}{"phoneNumber": request.NewPhoneNumber},
+	})
+
+	return nil
+}
+
+// requestPhoneChange sends an OTP to the new phone number, saves the pending change and notifies the user's current phone number
+func (us *UseCasesUserImpl) requestPhoneChange(ctx context.Context, userProfile *domain.User, phoneNumber string, flavour feedlib.Flavour, requestedBy string, reason string) (*domain.PhoneChangeRequest, error) {
+	if !flavour.IsValid() {
+		return nil, exceptions.InvalidFlavourDefinedErr(fmt.Errorf("flavour is not valid"))
+	}
+
+	newPhone, err := converterandformatter.NormalizeMSISDN(phoneNumber)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.NormalizeMSISDNError(err)
+	}
+
+	currentPhone, err := us.Query.GetContactByUserID(ctx, userProfile.ID, "PHONE")
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.ContactNotFoundErr(err)
+	}
+
+	if currentPhone.ContactValue == *newPhone {
+		err := fmt.Errorf("the new phone number is the same as the user's current phone number")
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InputValidationErr(err)
+	}
+
+	exists, err := us.Query.CheckIfPhoneNumberExists(ctx, *newPhone, false, flavour)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InternalErr(err)
+	}
+
+	if exists {
+		err := fmt.Errorf("phone number %s belongs to another user", *newPhone)
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.PhoneNumberInUseErr(err)
+	}
+
+	_, err = us.OTP.SendPhoneChangeOTP(ctx, userProfile.Username, *newPhone, flavour)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, err
+	}
+
+	request, err := us.Create.CreatePhoneChangeRequest(ctx, &domain.PhoneChangeRequest{
+		UserID:         *userProfile.ID,
+		Flavour:        flavour,
+		OldPhoneNumber: currentPhone.ContactValue,
+		NewPhoneNumber: *newPhone,
+		RequestedBy:    requestedBy,
+		Assisted:       requestedBy != *userProfile.ID,
+		Reason:         reason,
+		ExpiresAt:      time.Now().Add(phoneChangeExpiry),
+	})
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InternalErr(fmt.Errorf("failed to save phone change request: %w", err))
+	}
+
+	// the current phone number is notified so that the user can report a change they did not make
+	message := fmt.Sprintf(phoneChangeRequestedMessage, (*newPhone)[len(*newPhone)-3:])
+	if err := us.sendPhoneChangeSMS(ctx, currentPhone.ContactValue, message); err != nil {
+		helpers.ReportErrorToSentry(fmt.Errorf("failed to notify current phone number of phone change: %w", err))
+	}
+
+	return request, nil
+}
+
+// confirmPhoneChange verifies the OTP sent to the new phone number and replaces the user's phone number with it.
+// The user's Matrix profile is updated with the new phone number. The CMS has no client update topic so it keeps the old phone number
+func (us *UseCasesUserImpl) confirmPhoneChange(ctx context.Context, userProfile *domain.User, phoneNumber string, otp string, flavour feedlib.Flavour) (*domain.PhoneChangeRequest, error) {
+	if !flavour.IsValid() {
+		return nil, exceptions.InvalidFlavourDefinedErr(fmt.Errorf("flavour is not valid"))
+	}
+
+	newPhone, err := converterandformatter.NormalizeMSISDN(phoneNumber)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.NormalizeMSISDNError(err)
+	}
+
+	request, err := us.Query.GetPendingPhoneChangeRequest(ctx, *userProfile.ID, flavour)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.PhoneChangeNotFoundErr(err)
+	}
+
+	now := time.Now()
+	if !request.IsPending(now) || request.NewPhoneNumber != *newPhone {
+		err := fmt.Errorf("user %s does not have a pending change to phone number %s", *userProfile.ID, *newPhone)
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.PhoneChangeNotFoundErr(err)
+	}
+
+	valid, err := us.Query.VerifyOTP(ctx, &dto.VerifyOTPInput{
+		PhoneNumber: *newPhone,
+		Username:    userProfile.Username,
+		OTP:         otp,
+		Flavour:     flavour,
+	})
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InternalErr(err)
+	}
+
+	if !valid {
+		return nil, exceptions.PhoneChangeOTPMismatchErr()
+	}
+
+	// another user may have taken the phone number since the change was requested
+	exists, err := us.Query.CheckIfPhoneNumberExists(ctx, *newPhone, false, flavour)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InternalErr(err)
+	}
+
+	if exists {
+		err := fmt.Errorf("phone number %s belongs to another user", *newPhone)
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.PhoneNumberInUseErr(err)
+	}
+
+	err = us.Update.ConfirmPhoneChange(ctx, request, now)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InternalErr(fmt.Errorf("failed to change user phone number: %w", err))
+	}
+
+	// the phone number has been changed so a failure to update Matrix is reported rather than returned
+	us.updateMatrixPhoneNumber(ctx, userProfile.Username, *newPhone)
+
+	return request, nil
+}
+
+// updateMatrixPhoneNumber replaces the phone number of a user's Matrix account. The account's admin status is read first so that it is kept as is
+func (us *UseCasesUserImpl) updateMatrixPhoneNumber(ctx context.Context, username string, phoneNumber string) {
+	matrixAuth := &domain.MatrixAuth{
+		Username: serverutils.MustGetEnvVar("MCH_MATRIX_USER"),
+		Password: serverutils.MustGetEnvVar("MCH_MATRIX_PASSWORD"),
+	}
+
+	isAdmin, err := us.Matrix.CheckIfUserIsAdmin(ctx, matrixAuth, username)
+	if err != nil {
+		helpers.ReportErrorToSentry(fmt.Errorf("failed to check if matrix user %s is an admin: %w", username, err))
+		return
+	}
+
+	err = us.Pubsub.NotifyRegisterMatrixUser(ctx, &dto.MatrixUserRegistrationPayload{
+		Auth: matrixAuth,
+		RegistrationData: &domain.MatrixUserRegistration{
+			Username: username,
+			Admin:    isAdmin,
+			Threepids: []*domain.MatrixThreepid{
+				{Medium: "msisdn", Address: strings.TrimPrefix(phoneNumber, "+")},
+			},
+		},
+	})
+	if err != nil {
+		helpers.ReportErrorToSentry(fmt.Errorf("failed to update matrix user phone number: %w", err))
+	}
+}
+
+// sendPhoneChangeSMS sends an SMS about a phone number change. Kenyan phone numbers are sent through SILComms and foreign ones through Twilio
+func (us *UseCasesUserImpl) sendPhoneChangeSMS(ctx context.Context, phoneNumber string, message string) error {
+	if interserviceclient.IsKenyanNumber(phoneNumber) {
+		_, err := us.SMS.SendSMS(ctx, message, []string{phoneNumber})
+		return err
+	}
+
+	return us.Twilio.SendSMSViaTwilio(ctx, phoneNumber, message)
+}
@@ -0,0 +1,711 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/savannahghi/feedlib"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	extensionMock "github.com/savannahghi/mycarehub/pkg/mycarehub/application/extension/mock"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	pgMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/mock"
+	clinicalMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/clinical/mock"
+	matrixMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/matrix/mock"
+	pubsubMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/pubsub/mock"
+	smsMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/sms/mock"
+	surveysMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/surveys/mock"
+	twilioMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/twilio/mock"
+	authorityMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/authority/mock"
+	notificationMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/notification/mock"
+	otpMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/otp/mock"
+	"github.com/savannahghi/silcomms"
+)
+
+func TestUseCasesUserImpl_RequestPhoneChange(t *testing.T) {
+	tests := []struct {
+		name        string
+		phoneNumber string
+		flavour     feedlib.Flavour
+		want        bool
+		wantErr     bool
+	}{
+		{
+			name:        "Happy case: request phone change",
+			phoneNumber: "+254711223344",
+			flavour:     feedlib.FlavourConsumer,
+			want:        true,
+			wantErr:     false,
+		},
+		{
+			name:        "Happy case: request phone change, unable to notify current phone number",
+			phoneNumber: "+254711223344",
+			flavour:     feedlib.FlavourConsumer,
+			want:        true,
+			wantErr:     false,
+		},
+		{
+			name:        "Sad case: unable to get logged in user",
+			phoneNumber: "+254711223344",
+			flavour:     feedlib.FlavourConsumer,
+			wantErr:     true,
+		},
+		{
+			name:        "Sad case: unable to get user profile",
+			phoneNumber: "+254711223344",
+			flavour:     feedlib.FlavourConsumer,
+			wantErr:     true,
+		},
+		{
+			name:        "Sad case: invalid flavour",
+			phoneNumber: "+254711223344",
+			flavour:     "invalid",
+			wantErr:     true,
+		},
+		{
+			name:        "Sad case: invalid phone number",
+			phoneNumber: "invalid",
+			flavour:     feedlib.FlavourConsumer,
+			wantErr:     true,
+		},
+		{
+			name:        "Sad case: unable to get current phone number",
+			phoneNumber: "+254711223344",
+			flavour:     feedlib.FlavourConsumer,
+			wantErr:     true,
+		},
+		{
+			name:        "Sad case: new phone number is the current phone number",
+			phoneNumber: "+254700000000",
+			flavour:     feedlib.FlavourConsumer,
+			wantErr:     true,
+		},
+		{
+			name:        "Sad case: unable to check if phone number exists",
+			phoneNumber: "+254711223344",
+			flavour:     feedlib.FlavourConsumer,
+			wantErr:     true,
+		},
+		{
+			name:        "Sad case: phone number belongs to another user",
+			phoneNumber: "+254711223344",
+			flavour:     feedlib.FlavourConsumer,
+			wantErr:     true,
+		},
+		{
+			name:        "Sad case: unable to send otp",
+			phoneNumber: "+254711223344",
+			flavour:     feedlib.FlavourConsumer,
+			wantErr:     true,
+		},
+		{
+			name:        "Sad case: unable to save phone change request",
+			phoneNumber: "+254711223344",
+			flavour:     feedlib.FlavourConsumer,
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeOTP := otpMock.NewOTPUseCaseMock()
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			fakePubsub := pubsubMock.NewPubsubServiceMock()
+			fakeClinical := clinicalMock.NewClinicalServiceMock()
+			fakeSMS := smsMock.NewSMSServiceMock()
+			fakeTwilio := twilioMock.NewTwilioServiceMock()
+			fakeMatrix := matrixMock.NewMatrixMock()
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+
+			us := NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
+
+			userID := uuid.New().String()
+			fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+				return userID, nil
+			}
+			fakeDB.MockCheckIfPhoneNumberExistsFn = func(ctx context.Context, phone string, isOptedIn bool, flavour feedlib.Flavour) (bool, error) {
+				return false, nil
+			}
+
+			var saved *domain.PhoneChangeRequest
+			fakeDB.MockCreatePhoneChangeRequestFn = func(ctx context.Context, request *domain.PhoneChangeRequest) (*domain.PhoneChangeRequest, error) {
+				saved = request
+				return request, nil
+			}
+
+			var notified []string
+			fakeSMS.MockSendSMSFn = func(ctx context.Context, message string, recipients []string) (*silcomms.BulkSMSResponse, error) {
+				notified = recipients
+				return &silcomms.BulkSMSResponse{}, nil
+			}
+
+			if tt.name == "Happy case: request phone change, unable to notify current phone number" {
+				fakeSMS.MockSendSMSFn = func(ctx context.Context, message string, recipients []string) (*silcomms.BulkSMSResponse, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get logged in user" {
+				fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+					return "", fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get user profile" {
+				fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.User, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get current phone number" {
+				fakeDB.MockGetContactByUserIDFn = func(ctx context.Context, userID *string, contactType string) (*domain.Contact, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to check if phone number exists" {
+				fakeDB.MockCheckIfPhoneNumberExistsFn = func(ctx context.Context, phone string, isOptedIn bool, flavour feedlib.Flavour) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: phone number belongs to another user" {
+				fakeDB.MockCheckIfPhoneNumberExistsFn = func(ctx context.Context, phone string, isOptedIn bool, flavour feedlib.Flavour) (bool, error) {
+					return true, nil
+				}
+			}
+			if tt.name == "Sad case: unable to send otp" {
+				fakeOTP.MockSendPhoneChangeOTPFn = func(ctx context.Context, username string, phoneNumber string, flavour feedlib.Flavour) (*domain.OTPResponse, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to save phone change request" {
+				fakeDB.MockCreatePhoneChangeRequestFn = func(ctx context.Context, request *domain.PhoneChangeRequest) (*domain.PhoneChangeRequest, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := us.RequestPhoneChange(context.Background(), tt.phoneNumber, tt.flavour)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesUserImpl.RequestPhoneChange() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UseCasesUserImpl.RequestPhoneChange() = %v, want %v", got, tt.want)
+			}
+
+			if tt.name == "Happy case: request phone change" {
+				if saved.OldPhoneNumber != "+254700000000" || saved.NewPhoneNumber != tt.phoneNumber {
+					t.Errorf("expected a change from the current phone number to the new one, got %v", saved)
+				}
+				if saved.Assisted {
+					t.Errorf("expected a change requested by the user not to be assisted")
+				}
+				if len(notified) != 1 || notified[0] != "+254700000000" {
+					t.Errorf("expected the current phone number to be notified, got %v", notified)
+				}
+			}
+		})
+	}
+}
+
+func TestUseCasesUserImpl_ConfirmPhoneChange(t *testing.T) {
+	tests := []struct {
+		name        string
+		phoneNumber string
+		flavour     feedlib.Flavour
+		want        bool
+		wantErr     bool
+	}{
+		{
+			name:        "Happy case: confirm phone change",
+			phoneNumber: "+254711223344",
+			flavour:     feedlib.FlavourConsumer,
+			want:        true,
+			wantErr:     false,
+		},
+		{
+			name:        "Happy case: confirm phone change, unable to update matrix",
+			phoneNumber: "+254711223344",
+			flavour:     feedlib.FlavourConsumer,
+			want:        true,
+			wantErr:     false,
+		},
+		{
+			name:        "Happy case: confirm phone change, matrix user is not an admin",
+			phoneNumber: "+254711223344",
+			flavour:     feedlib.FlavourConsumer,
+			want:        true,
+			wantErr:     false,
+		},
+		{
+			name:        "Happy case: confirm phone change, unable to check if matrix user is an admin",
+			phoneNumber: "+254711223344",
+			flavour:     feedlib.FlavourConsumer,
+			want:        true,
+			wantErr:     false,
+		},
+		{
+			name:        "Sad case: unable to get logged in user",
+			phoneNumber: "+254711223344",
+			flavour:     feedlib.FlavourConsumer,
+			wantErr:     true,
+		},
+		{
+			name:        "Sad case: unable to get user profile",
+			phoneNumber: "+254711223344",
+			flavour:     feedlib.FlavourConsumer,
+			wantErr:     true,
+		},
+		{
+			name:        "Sad case: invalid flavour",
+			phoneNumber: "+254711223344",
+			flavour:     "invalid",
+			wantErr:     true,
+		},
+		{
+			name:        "Sad case: invalid phone number",
+			phoneNumber: "invalid",
+			flavour:     feedlib.FlavourConsumer,
+			wantErr:     true,
+		},
+		{
+			name:        "Sad case: no pending phone change",
+			phoneNumber: "+254711223344",
+			flavour:     feedlib.FlavourConsumer,
+			wantErr:     true,
+		},
+		{
+			name:        "Sad case: phone change has expired",
+			phoneNumber: "+254711223344",
+			flavour:     feedlib.FlavourConsumer,
+			wantErr:     true,
+		},
+		{
+			name:        "Sad case: phone number does not match the pending change",
+			phoneNumber: "+254722000000",
+			flavour:     feedlib.FlavourConsumer,
+			wantErr:     true,
+		},
+		{
+			name:        "Sad case: unable to verify otp",
+			phoneNumber: "+254711223344",
+			flavour:     feedlib.FlavourConsumer,
+			wantErr:     true,
+		},
+		{
+			name:        "Sad case: invalid otp",
+			phoneNumber: "+254711223344",
+			flavour:     feedlib.FlavourConsumer,
+			wantErr:     true,
+		},
+		{
+			name:        "Sad case: phone number taken by another user",
+			phoneNumber: "+254711223344",
+			flavour:     feedlib.FlavourConsumer,
+			wantErr:     true,
+		},
+		{
+			name:        "Sad case: unable to confirm phone change",
+			phoneNumber: "+254711223344",
+			flavour:     feedlib.FlavourConsumer,
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeOTP := otpMock.NewOTPUseCaseMock()
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			fakePubsub := pubsubMock.NewPubsubServiceMock()
+			fakeClinical := clinicalMock.NewClinicalServiceMock()
+			fakeSMS := smsMock.NewSMSServiceMock()
+			fakeTwilio := twilioMock.NewTwilioServiceMock()
+			fakeMatrix := matrixMock.NewMatrixMock()
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+
+			us := NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
+
+			fakeDB.MockCheckIfPhoneNumberExistsFn = func(ctx context.Context, phone string, isOptedIn bool, flavour feedlib.Flavour) (bool, error) {
+				return false, nil
+			}
+
+			var matrixPayload *dto.MatrixUserRegistrationPayload
+			fakePubsub.MockNotifyRegisterMatrixUserFn = func(ctx context.Context, payload *dto.MatrixUserRegistrationPayload) error {
+				matrixPayload = payload
+				return nil
+			}
+
+			cmsClientPublished := false
+			fakePubsub.MockNotifyCreateCMSClientFn = func(ctx context.Context, payload *dto.PubsubCreateCMSClientPayload) error {
+				cmsClientPublished = true
+				return nil
+			}
+
+			if tt.name == "Happy case: confirm phone change, unable to update matrix" {
+				fakePubsub.MockNotifyRegisterMatrixUserFn = func(ctx context.Context, payload *dto.MatrixUserRegistrationPayload) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Happy case: confirm phone change, matrix user is not an admin" {
+				fakeMatrix.MockCheckIfUserIsAdminFn = func(ctx context.Context, auth *domain.MatrixAuth, userID string) (bool, error) {
+					return false, nil
+				}
+			}
+			if tt.name == "Happy case: confirm phone change, unable to check if matrix user is an admin" {
+				fakeMatrix.MockCheckIfUserIsAdminFn = func(ctx context.Context, auth *domain.MatrixAuth, userID string) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get logged in user" {
+				fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+					return "", fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get user profile" {
+				fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.User, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: no pending phone change" {
+				fakeDB.MockGetPendingPhoneChangeRequestFn = func(ctx context.Context, userID string, flavour feedlib.Flavour) (*domain.PhoneChangeRequest, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: phone change has expired" {
+				fakeDB.MockGetPendingPhoneChangeRequestFn = func(ctx context.Context, userID string, flavour feedlib.Flavour) (*domain.PhoneChangeRequest, error) {
+					return &domain.PhoneChangeRequest{
+						UserID:         userID,
+						Flavour:        flavour,
+						OldPhoneNumber: "+254700000000",
+						NewPhoneNumber: "+254711223344",
+						ExpiresAt:      time.Now().Add(-time.Minute),
+					}, nil
+				}
+			}
+			if tt.name == "Sad case: unable to verify otp" {
+				fakeDB.MockVerifyOTPFn = func(ctx context.Context, payload *dto.VerifyOTPInput) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: invalid otp" {
+				fakeDB.MockVerifyOTPFn = func(ctx context.Context, payload *dto.VerifyOTPInput) (bool, error) {
+					return false, nil
+				}
+			}
+			if tt.name == "Sad case: phone number taken by another user" {
+				fakeDB.MockCheckIfPhoneNumberExistsFn = func(ctx context.Context, phone string, isOptedIn bool, flavour feedlib.Flavour) (bool, error) {
+					return true, nil
+				}
+			}
+			if tt.name == "Sad case: unable to confirm phone change" {
+				fakeDB.MockConfirmPhoneChangeFn = func(ctx context.Context, request *domain.PhoneChangeRequest, confirmedAt time.Time) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := us.ConfirmPhoneChange(context.Background(), tt.phoneNumber, "123456", tt.flavour)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesUserImpl.ConfirmPhoneChange() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UseCasesUserImpl.ConfirmPhoneChange() = %v, want %v", got, tt.want)
+			}
+
+			if tt.name == "Happy case: confirm phone change" {
+				threepids := matrixPayload.RegistrationData.Threepids
+				if len(threepids) != 1 || threepids[0].Address != "254711223344" {
+					t.Errorf("expected the matrix user to be updated with the new phone number, got %v", matrixPayload.RegistrationData)
+				}
+				if !matrixPayload.RegistrationData.Admin {
+					t.Errorf("expected the matrix user to remain an admin")
+				}
+				if cmsClientPublished {
+					t.Errorf("expected the client not to be published to the cms as a new client")
+				}
+			}
+			if tt.name == "Happy case: confirm phone change, matrix user is not an admin" && matrixPayload.RegistrationData.Admin {
+				t.Errorf("expected the matrix user to remain a non admin")
+			}
+			if tt.name == "Happy case: confirm phone change, unable to check if matrix user is an admin" && matrixPayload != nil {
+				t.Errorf("expected the matrix user not to be updated when its admin status is unknown")
+			}
+		})
+	}
+}
+
+func TestUseCasesUserImpl_RequestAssistedPhoneChange(t *testing.T) {
+	staffUserID := uuid.New().String()
+	organisationID := uuid.New().String()
+
+	tests := []struct {
+		name    string
+		reason  string
+		want    bool
+		wantErr bool
+	}{
+		{
+			name:    "Happy case: request assisted phone change for a client",
+			reason:  "client lost their phone",
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name:    "Happy case: organisation admin requests a staff's phone change",
+			reason:  "staff lost their phone",
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name:    "Sad case: missing reason",
+			reason:  " ",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: logged in user is not a staff",
+			reason:  "client lost their phone",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to get user profile",
+			reason:  "client lost their phone",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: user belongs to another organisation",
+			reason:  "client lost their phone",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to check if user is a staff",
+			reason:  "client lost their phone",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to get logged in staff profile",
+			reason:  "staff lost their phone",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: staff is not an organisation admin",
+			reason:  "staff lost their phone",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: phone number belongs to another user",
+			reason:  "client lost their phone",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeOTP := otpMock.NewOTPUseCaseMock()
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			fakePubsub := pubsubMock.NewPubsubServiceMock()
+			fakeClinical := clinicalMock.NewClinicalServiceMock()
+			fakeSMS := smsMock.NewSMSServiceMock()
+			fakeTwilio := twilioMock.NewTwilioServiceMock()
+			fakeMatrix := matrixMock.NewMatrixMock()
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+
+			us := NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
+
+			userID := uuid.New().String()
+			fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+				return staffUserID, nil
+			}
+			fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.User, error) {
+				return &domain.User{ID: &userID, Username: "user", CurrentOrganizationID: organisationID}, nil
+			}
+			fakeDB.MockCheckStaffExistsFn = func(ctx context.Context, id string) (bool, error) {
+				return id == staffUserID, nil
+			}
+			fakeDB.MockCheckIfPhoneNumberExistsFn = func(ctx context.Context, phone string, isOptedIn bool, flavour feedlib.Flavour) (bool, error) {
+				return false, nil
+			}
+
+			var saved *domain.PhoneChangeRequest
+			fakeDB.MockCreatePhoneChangeRequestFn = func(ctx context.Context, request *domain.PhoneChangeRequest) (*domain.PhoneChangeRequest, error) {
+				saved = request
+				return request, nil
+			}
+
+			var auditLog *domain.AuditLog
+			fakeDB.MockCreateAuditLogFn = func(ctx context.Context, log *domain.AuditLog) error {
+				auditLog = log
+				return nil
+			}
+
+			staffTarget := tt.name == "Happy case: organisation admin requests a staff's phone change" ||
+				tt.name == "Sad case: unable to get logged in staff profile" ||
+				tt.name == "Sad case: staff is not an organisation admin"
+			if staffTarget {
+				fakeDB.MockCheckStaffExistsFn = func(ctx context.Context, id string) (bool, error) {
+					return true, nil
+				}
+				fakeDB.MockGetStaffProfileFn = func(ctx context.Context, id string, programID string) (*domain.StaffProfile, error) {
+					return &domain.StaffProfile{UserID: id, IsOrganisationAdmin: true}, nil
+				}
+			}
+
+			if tt.name == "Sad case: logged in user is not a staff" {
+				fakeDB.MockCheckStaffExistsFn = func(ctx context.Context, id string) (bool, error) {
+					return false, nil
+				}
+			}
+			if tt.name == "Sad case: unable to get user profile" {
+				fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, id string) (*domain.User, error) {
+					if id == staffUserID {
+						return &domain.User{ID: &id, CurrentOrganizationID: organisationID}, nil
+					}
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: user belongs to another organisation" {
+				fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, id string) (*domain.User, error) {
+					if id == staffUserID {
+						return &domain.User{ID: &id, CurrentOrganizationID: organisationID}, nil
+					}
+					return &domain.User{ID: &id, CurrentOrganizationID: uuid.New().String()}, nil
+				}
+			}
+			if tt.name == "Sad case: unable to check if user is a staff" {
+				fakeDB.MockCheckStaffExistsFn = func(ctx context.Context, id string) (bool, error) {
+					if id == staffUserID {
+						return true, nil
+					}
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get logged in staff profile" {
+				fakeDB.MockGetStaffProfileFn = func(ctx context.Context, id string, programID string) (*domain.StaffProfile, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: staff is not an organisation admin" {
+				fakeDB.MockGetStaffProfileFn = func(ctx context.Context, id string, programID string) (*domain.StaffProfile, error) {
+					return &domain.StaffProfile{UserID: id}, nil
+				}
+			}
+			if tt.name == "Sad case: phone number belongs to another user" {
+				fakeDB.MockCheckIfPhoneNumberExistsFn = func(ctx context.Context, phone string, isOptedIn bool, flavour feedlib.Flavour) (bool, error) {
+					return true, nil
+				}
+			}
+
+			got, err := us.RequestAssistedPhoneChange(context.Background(), userID, "+254711223344", feedlib.FlavourConsumer, tt.reason)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesUserImpl.RequestAssistedPhoneChange() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UseCasesUserImpl.RequestAssistedPhoneChange() = %v, want %v", got, tt.want)
+			}
+
+			if tt.name == "Happy case: request assisted phone change for a client" {
+				if !saved.Assisted || saved.RequestedBy != staffUserID || saved.Reason != tt.reason {
+					t.Errorf("expected an assisted phone change requested by the staff, got %v", saved)
+				}
+				if auditLog == nil || auditLog.ActorID != staffUserID || auditLog.TargetID != userID {
+					t.Errorf("expected the assisted phone change to be audited, got %v", auditLog)
+				}
+			}
+		})
+	}
+}
+
+func TestUseCasesUserImpl_ConfirmAssistedPhoneChange(t *testing.T) {
+	staffUserID := uuid.New().String()
+	organisationID := uuid.New().String()
+
+	tests := []struct {
+		name    string
+		want    bool
+		wantErr bool
+	}{
+		{
+			name:    "Happy case: confirm assisted phone change",
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name:    "Sad case: user belongs to another organisation",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: invalid otp",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeOTP := otpMock.NewOTPUseCaseMock()
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			fakePubsub := pubsubMock.NewPubsubServiceMock()
+			fakeClinical := clinicalMock.NewClinicalServiceMock()
+			fakeSMS := smsMock.NewSMSServiceMock()
+			fakeTwilio := twilioMock.NewTwilioServiceMock()
+			fakeMatrix := matrixMock.NewMatrixMock()
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+
+			us := NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
+
+			userID := uuid.New().String()
+			fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+				return staffUserID, nil
+			}
+			fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, id string) (*domain.User, error) {
+				return &domain.User{ID: &id, Username: "user", CurrentOrganizationID: organisationID}, nil
+			}
+			fakeDB.MockCheckStaffExistsFn = func(ctx context.Context, id string) (bool, error) {
+				return id == staffUserID, nil
+			}
+			fakeDB.MockCheckIfPhoneNumberExistsFn = func(ctx context.Context, phone string, isOptedIn bool, flavour feedlib.Flavour) (bool, error) {
+				return false, nil
+			}
+
+			var auditLog *domain.AuditLog
+			fakeDB.MockCreateAuditLogFn = func(ctx context.Context, log *domain.AuditLog) error {
+				auditLog = log
+				return nil
+			}
+
+			if tt.name == "Sad case: user belongs to another organisation" {
+				fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, id string) (*domain.User, error) {
+					if id == staffUserID {
+						return &domain.User{ID: &id, CurrentOrganizationID: organisationID}, nil
+					}
+					return &domain.User{ID: &id, CurrentOrganizationID: uuid.New().String()}, nil
+				}
+			}
+			if tt.name == "Sad case: invalid otp" {
+				fakeDB.MockVerifyOTPFn = func(ctx context.Context, payload *dto.VerifyOTPInput) (bool, error) {
+					return false, nil
+				}
+			}
+
+			got, err := us.ConfirmAssistedPhoneChange(context.Background(), userID, "+254711223344", "123456", feedlib.FlavourConsumer)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesUserImpl.ConfirmAssistedPhoneChange() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UseCasesUserImpl.ConfirmAssistedPhoneChange() = %v, want %v", got, tt.want)
+			}
+
+			if tt.name == "Happy case: confirm assisted phone change" {
+				if auditLog == nil || auditLog.ActorID != staffUserID || auditLog.TargetID != userID {
+					t.Errorf("expected the assisted phone change to be audited, got %v", auditLog)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/utils"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+)
+
+// pinPolicy returns the PIN policy of the user's organisation.
+// Users who do not belong to an organisation get the default policy
+func (us *UseCasesUserImpl) pinPolicy(ctx context.Context, organisationID string) (*domain.PINPolicy, error) {
+	if organisationID == "" {
+		policy := domain.DefaultPINPolicy
+		return &policy, nil
+	}
+
+	organisation, err := us.Query.GetOrganisation(ctx, organisationID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InternalErr(fmt.Errorf("failed to get organisation PIN policy: %w", err))
+	}
+
+	return &organisation.PINPolicy, nil
+}
+
+// validatePIN checks that a new PIN meets the length and complexity rules of the user's organisation's PIN policy
+func (us *UseCasesUserImpl) validatePIN(ctx context.Context, organisationID string, pin string) (*domain.PINPolicy, error) {
+	policy, err := us.pinPolicy(ctx, organisationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := utils.ValidatePINPolicy(pin, *policy); err != nil {
+		return nil, exceptions.WeakPINErr(err)
+	}
+
+	return policy, nil
+}
+
+// checkPINReuse ensures that a new PIN is not one of the user's recent PINs.
+// It should only be called once the user has been verified so that it cannot be used to guess their previous PINs
+func (us *UseCasesUserImpl) checkPINReuse(ctx context.Context, userID string, pin string, policy *domain.PINPolicy) error {
+	if policy.HistoryCount <= 0 {
+		return nil
+	}
+
+	history, err := us.Query.GetUserPINHistory(ctx, userID, policy.HistoryCount)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return exceptions.InternalErr(fmt.Errorf("failed to get user PIN history: %w", err))
+	}
+
+	for _, previousPIN := range history {
+		if utils.ComparePIN(pin, previousPIN.Salt, previousPIN.HashedPIN, nil) {
+			return exceptions.PINReusedErr(fmt.Errorf("the PIN is one of the user's last %d PINs", policy.HistoryCount))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,258 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/utils"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	usecasesCommon "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/common"
+)
+
+// ListMySessions returns the sessions that the logged in user has not been logged out of.
+// The session that the request was made with is marked as the current session
+func (us *UseCasesUserImpl) ListMySessions(ctx context.Context) ([]*domain.UserSession, error) {
+	ctx, span := tracer.Start(ctx, "ListMySessions")
+	defer span.End()
+
+	loggedInUserID, err := us.ExternalExt.GetLoggedInUserUID(ctx)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.GetLoggedInUserUIDErr(err)
+	}
+
+	sessions, err := us.listActiveSessions(ctx, loggedInUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	currentSessionID, _ := utils.GetValueFromContext(ctx, utils.SessionIDContextKey)
+
+	userSessions := []*domain.UserSession{}
+	for _, session := range sessions {
+		userSessions = append(userSessions, &domain.UserSession{
+			ID:         session.ID,
+			DeviceID:   session.DeviceID,
+			DeviceName: session.DeviceName,
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			CreatedAt:  session.CreatedAt,
+			LastSeenAt: session.LastSeenAt,
+			Current:    session.ID == currentSessionID,
+		})
+	}
+
+	return userSessions, nil
+}
+
+// RevokeSession logs the logged in user out of one of their sessions e.g on a phone that has been lost.
+// The session's tokens stop working immediately
+func (us *UseCasesUserImpl) RevokeSession(ctx context.Context, sessionID string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "RevokeSession")
+	defer span.End()
+
+	loggedInUserID, err := us.ExternalExt.GetLoggedInUserUID(ctx)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.GetLoggedInUserUIDErr(err)
+	}
+
+	sessions, err := us.listActiveSessions(ctx, loggedInUserID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, session := range sessions {
+		if session.ID != sessionID {
+			continue
+		}
+
+		if err := us.revokeSessions(ctx, loggedInUserID, []*domain.Session{session}); err != nil {
+			return false, err
+		}
+
+		return true, nil
+	}
+
+	err = fmt.Errorf("user %s does not have an active session %s", loggedInUserID, sessionID)
+	helpers.ReportErrorToSentry(err)
+	return false, exceptions.SessionNotFoundErr(err)
+}
+
+// RevokeAllOtherSessions logs the logged in user out of all their sessions except the one that they made the request with
+func (us *UseCasesUserImpl) RevokeAllOtherSessions(ctx context.Context) (bool, error) {
+	ctx, span := tracer.Start(ctx, "RevokeAllOtherSessions")
+	defer span.End()
+
+	loggedInUserID, err := us.ExternalExt.GetLoggedInUserUID(ctx)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.GetLoggedInUserUIDErr(err)
+	}
+
+	currentSessionID, err := utils.GetValueFromContext(ctx, utils.SessionIDContextKey)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.SessionNotFoundErr(fmt.Errorf("failed to get the current session: %w", err))
+	}
+
+	sessions, err := us.listActiveSessions(ctx, loggedInUserID)
+	if err != nil {
+		return false, err
+	}
+
+	otherSessions := []*domain.Session{}
+	for _, session := range sessions {
+		if session.ID != currentSessionID {
+			otherSessions = append(otherSessions, session)
+		}
+	}
+
+	if err := us.revokeSessions(ctx, loggedInUserID, otherSessions); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ForceLogoutUser is used by a staff to log a user out of all their sessions e.g when a client reports a lost phone
+// or a staff leaves the organisation. Only organisation admins can log out other staff.
+func (us *UseCasesUserImpl) ForceLogoutUser(ctx context.Context, userID string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "ForceLogoutUser")
+	defer span.End()
+
+	loggedInUser, err := us.loggedInStaffUser(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	loggedInStaff, err := us.Query.GetStaffProfile(ctx, *loggedInUser.ID, loggedInUser.CurrentProgramID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.StaffProfileNotFoundErr(err)
+	}
+
+	userProfile, err := us.Query.GetUserProfileByUserID(ctx, userID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.UserNotFoundError(err)
+	}
+
+	if userProfile.CurrentOrganizationID != loggedInUser.CurrentOrganizationID {
+		err := fmt.Errorf("user %s does not belong to the organisation of staff %s", userID, *loggedInUser.ID)
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.UserNotAuthorizedErr(err)
+	}
+
+	isStaff, err := us.Query.CheckStaffExists(ctx, userID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.InternalErr(err)
+	}
+
+	if isStaff && !loggedInStaff.IsOrganisationAdmin {
+		err := fmt.Errorf("staff %s is not an organisation admin and cannot log out staff %s", *loggedInUser.ID, userID)
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.UserNotAuthorizedErr(err)
+	}
+
+	sessions, err := us.listActiveSessions(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	if err := us.revokeSessions(ctx, userID, sessions); err != nil {
+		return false, err
+	}
+
+	// the push tokens of devices without a session are also removed so that the user stops receiving notifications
+	err = us.Update.UpdateUser(ctx, userProfile, map[string]interface{}{
+		"push_tokens": pq.StringArray{},
+	})
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.InternalErr(fmt.Errorf("failed to remove user push tokens: %w", err))
+	}
+
+	usecasesCommon.RecordAuditLog(ctx, us.Create, &domain.AuditLog{
+		RecordType:     enums.AuditLogSessionRevocation,
+		Notes:          fmt.Sprintf("user logged out of %d sessions", len(sessions)),
+		ActorID:        *loggedInUser.ID,
+		TargetID:       userID,
+		TargetType:     enums.AuditLogTargetUser,
+		ProgramID:      loggedInUser.CurrentProgramID,
+		OrganisationID: loggedInUser.CurrentOrganizationID,
+	})
+
+	return true, nil
+}
+
+// listActiveSessions returns the user's sessions that can still be used
+func (us *UseCasesUserImpl) listActiveSessions(ctx context.Context, userID string) ([]*domain.Session, error) {
+	sessions, err := us.Query.ListUserSessions(ctx, userID, time.Now().Add(-common.RefreshTokenLifespan))
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InternalErr(fmt.Errorf("failed to list user sessions: %w", err))
+	}
+
+	return sessions, nil
+}
+
+// revokeSessions ends the sessions and removes the push tokens that were registered on them
+// so that a lost device stops receiving the user's notifications
+func (us *UseCasesUserImpl) revokeSessions(ctx context.Context, userID string, sessions []*domain.Session) error {
+	if len(sessions) == 0 {
+		return nil
+	}
+
+	sessionIDs := []string{}
+	revokedPushTokens := map[string]bool{}
+	for _, session := range sessions {
+		sessionIDs = append(sessionIDs, session.ID)
+		if session.PushToken != "" {
+			revokedPushTokens[session.PushToken] = true
+		}
+	}
+
+	if err := us.Update.RevokeSessions(ctx, sessionIDs, time.Now()); err != nil {
+		helpers.ReportErrorToSentry(err)
+		return exceptions.InternalErr(fmt.Errorf("failed to revoke sessions: %w", err))
+	}
+
+	if len(revokedPushTokens) == 0 {
+		return nil
+	}
+
+	userProfile, err := us.Query.GetUserProfileByUserID(ctx, userID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return exceptions.UserNotFoundError(err)
+	}
+
+	pushTokens := pq.StringArray{}
+	for _, token := range userProfile.PushTokens {
+		if !revokedPushTokens[token] {
+			pushTokens = append(pushTokens, token)
+		}
+	}
+
+	if len(pushTokens) == len(userProfile.PushTokens) {
+		return nil
+	}
+
+	err = us.Update.UpdateUser(ctx, userProfile, map[string]interface{}{
+		"push_tokens": pushTokens,
+	})
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return exceptions.InternalErr(fmt.Errorf("failed to remove revoked push tokens: %w", err))
+	}
+
+	return nil
+}
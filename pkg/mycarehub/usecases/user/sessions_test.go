@@ -0,0 +1,553 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	extensionMock "github.com/savannahghi/mycarehub/pkg/mycarehub/application/extension/mock"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/utils"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	pgMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/mock"
+	clinicalMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/clinical/mock"
+	matrixMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/matrix/mock"
+	pubsubMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/pubsub/mock"
+	smsMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/sms/mock"
+	surveysMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/surveys/mock"
+	twilioMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/twilio/mock"
+	authorityMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/authority/mock"
+	notificationMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/notification/mock"
+	otpMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/otp/mock"
+)
+
+// testSessions returns the sessions of a user where the first session is the one the request is made with
+func testSessions(userID string) []*domain.Session {
+	now := time.Now()
+	return []*domain.Session{
+		{
+			ID:         uuid.New().String(),
+			UserID:     userID,
+			DeviceName: "Pixel 7",
+			PushToken:  "current-device-push-token",
+			CreatedAt:  now,
+			LastSeenAt: &now,
+		},
+		{
+			ID:         uuid.New().String(),
+			UserID:     userID,
+			DeviceName: "Galaxy A12",
+			PushToken:  "lost-device-push-token",
+			CreatedAt:  now.Add(-time.Hour),
+		},
+	}
+}
+
+func TestUseCasesUserImpl_ListMySessions(t *testing.T) {
+	userID := uuid.New().String()
+	sessions := testSessions(userID)
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		want    int
+		wantErr bool
+	}{
+		{
+			name:    "Happy case: list sessions",
+			ctx:     context.WithValue(context.Background(), utils.SessionIDContextKey, sessions[0].ID),
+			want:    2,
+			wantErr: false,
+		},
+		{
+			name:    "Happy case: list sessions without a current session",
+			ctx:     context.Background(),
+			want:    2,
+			wantErr: false,
+		},
+		{
+			name:    "Sad case: unable to get logged in user",
+			ctx:     context.Background(),
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to list sessions",
+			ctx:     context.Background(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeOTP := otpMock.NewOTPUseCaseMock()
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			fakePubsub := pubsubMock.NewPubsubServiceMock()
+			fakeClinical := clinicalMock.NewClinicalServiceMock()
+			fakeSMS := smsMock.NewSMSServiceMock()
+			fakeTwilio := twilioMock.NewTwilioServiceMock()
+			fakeMatrix := matrixMock.NewMatrixMock()
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+
+			us := NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
+
+			fakeDB.MockListUserSessionsFn = func(ctx context.Context, userID string, activeSince time.Time) ([]*domain.Session, error) {
+				return sessions, nil
+			}
+
+			if tt.name == "Sad case: unable to get logged in user" {
+				fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+					return "", fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to list sessions" {
+				fakeDB.MockListUserSessionsFn = func(ctx context.Context, userID string, activeSince time.Time) ([]*domain.Session, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := us.ListMySessions(tt.ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesUserImpl.ListMySessions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != tt.want {
+				t.Errorf("UseCasesUserImpl.ListMySessions() got %d sessions, want %d", len(got), tt.want)
+				return
+			}
+			if tt.name == "Happy case: list sessions" && (!got[0].Current || got[1].Current) {
+				t.Errorf("UseCasesUserImpl.ListMySessions() expected only the first session to be current")
+			}
+			if tt.name == "Happy case: list sessions without a current session" && (got[0].Current || got[1].Current) {
+				t.Errorf("UseCasesUserImpl.ListMySessions() expected no session to be current")
+			}
+		})
+	}
+}
+
+func TestUseCasesUserImpl_RevokeSession(t *testing.T) {
+	userID := uuid.New().String()
+	sessions := testSessions(userID)
+
+	tests := []struct {
+		name      string
+		sessionID string
+		want      bool
+		wantErr   bool
+	}{
+		{
+			name:      "Happy case: revoke session",
+			sessionID: sessions[1].ID,
+			want:      true,
+			wantErr:   false,
+		},
+		{
+			name:      "Sad case: unable to get logged in user",
+			sessionID: sessions[1].ID,
+			wantErr:   true,
+		},
+		{
+			name:      "Sad case: unable to list sessions",
+			sessionID: sessions[1].ID,
+			wantErr:   true,
+		},
+		{
+			name:      "Sad case: session does not belong to the user",
+			sessionID: uuid.New().String(),
+			wantErr:   true,
+		},
+		{
+			name:      "Sad case: unable to revoke session",
+			sessionID: sessions[1].ID,
+			wantErr:   true,
+		},
+		{
+			name:      "Sad case: unable to get user profile",
+			sessionID: sessions[1].ID,
+			wantErr:   true,
+		},
+		{
+			name:      "Sad case: unable to remove push token",
+			sessionID: sessions[1].ID,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeOTP := otpMock.NewOTPUseCaseMock()
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			fakePubsub := pubsubMock.NewPubsubServiceMock()
+			fakeClinical := clinicalMock.NewClinicalServiceMock()
+			fakeSMS := smsMock.NewSMSServiceMock()
+			fakeTwilio := twilioMock.NewTwilioServiceMock()
+			fakeMatrix := matrixMock.NewMatrixMock()
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+
+			us := NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
+
+			fakeDB.MockListUserSessionsFn = func(ctx context.Context, userID string, activeSince time.Time) ([]*domain.Session, error) {
+				return sessions, nil
+			}
+			fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, id string) (*domain.User, error) {
+				return &domain.User{
+					ID:         &userID,
+					PushTokens: []string{sessions[0].PushToken, sessions[1].PushToken},
+				}, nil
+			}
+
+			var revokedSessionIDs []string
+			fakeDB.MockRevokeSessionsFn = func(ctx context.Context, sessionIDs []string, revokedAt time.Time) error {
+				revokedSessionIDs = sessionIDs
+				return nil
+			}
+
+			var pushTokens interface{}
+			fakeDB.MockUpdateUserFn = func(ctx context.Context, user *domain.User, updateData map[string]interface{}) error {
+				pushTokens = updateData["push_tokens"]
+				return nil
+			}
+
+			if tt.name == "Sad case: unable to get logged in user" {
+				fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+					return "", fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to list sessions" {
+				fakeDB.MockListUserSessionsFn = func(ctx context.Context, userID string, activeSince time.Time) ([]*domain.Session, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to revoke session" {
+				fakeDB.MockRevokeSessionsFn = func(ctx context.Context, sessionIDs []string, revokedAt time.Time) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get user profile" {
+				fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.User, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to remove push token" {
+				fakeDB.MockUpdateUserFn = func(ctx context.Context, user *domain.User, updateData map[string]interface{}) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := us.RevokeSession(context.Background(), tt.sessionID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesUserImpl.RevokeSession() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UseCasesUserImpl.RevokeSession() = %v, want %v", got, tt.want)
+			}
+
+			if tt.name == "Happy case: revoke session" {
+				if len(revokedSessionIDs) != 1 || revokedSessionIDs[0] != sessions[1].ID {
+					t.Errorf("expected only session %s to be revoked, got %v", sessions[1].ID, revokedSessionIDs)
+				}
+				if fmt.Sprint(pushTokens) != fmt.Sprint([]string{sessions[0].PushToken}) {
+					t.Errorf("expected only the revoked session's push token to be removed, got %v", pushTokens)
+				}
+			}
+		})
+	}
+}
+
+func TestUseCasesUserImpl_RevokeAllOtherSessions(t *testing.T) {
+	userID := uuid.New().String()
+	sessions := testSessions(userID)
+	currentSessionCtx := context.WithValue(context.Background(), utils.SessionIDContextKey, sessions[0].ID)
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		want    bool
+		wantErr bool
+	}{
+		{
+			name:    "Happy case: revoke all other sessions",
+			ctx:     currentSessionCtx,
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name:    "Happy case: no other sessions",
+			ctx:     currentSessionCtx,
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name:    "Sad case: unable to get logged in user",
+			ctx:     currentSessionCtx,
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: current session is unknown",
+			ctx:     context.Background(),
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to list sessions",
+			ctx:     currentSessionCtx,
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to revoke sessions",
+			ctx:     currentSessionCtx,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeOTP := otpMock.NewOTPUseCaseMock()
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			fakePubsub := pubsubMock.NewPubsubServiceMock()
+			fakeClinical := clinicalMock.NewClinicalServiceMock()
+			fakeSMS := smsMock.NewSMSServiceMock()
+			fakeTwilio := twilioMock.NewTwilioServiceMock()
+			fakeMatrix := matrixMock.NewMatrixMock()
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+
+			us := NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
+
+			fakeDB.MockListUserSessionsFn = func(ctx context.Context, userID string, activeSince time.Time) ([]*domain.Session, error) {
+				return sessions, nil
+			}
+
+			revokeCalled := false
+			var revokedSessionIDs []string
+			fakeDB.MockRevokeSessionsFn = func(ctx context.Context, sessionIDs []string, revokedAt time.Time) error {
+				revokeCalled = true
+				revokedSessionIDs = sessionIDs
+				return nil
+			}
+
+			if tt.name == "Happy case: no other sessions" {
+				fakeDB.MockListUserSessionsFn = func(ctx context.Context, userID string, activeSince time.Time) ([]*domain.Session, error) {
+					return sessions[:1], nil
+				}
+			}
+			if tt.name == "Sad case: unable to get logged in user" {
+				fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+					return "", fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to list sessions" {
+				fakeDB.MockListUserSessionsFn = func(ctx context.Context, userID string, activeSince time.Time) ([]*domain.Session, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to revoke sessions" {
+				fakeDB.MockRevokeSessionsFn = func(ctx context.Context, sessionIDs []string, revokedAt time.Time) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := us.RevokeAllOtherSessions(tt.ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesUserImpl.RevokeAllOtherSessions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UseCasesUserImpl.RevokeAllOtherSessions() = %v, want %v", got, tt.want)
+			}
+
+			if tt.name == "Happy case: revoke all other sessions" && (len(revokedSessionIDs) != 1 || revokedSessionIDs[0] != sessions[1].ID) {
+				t.Errorf("expected only session %s to be revoked, got %v", sessions[1].ID, revokedSessionIDs)
+			}
+			if tt.name == "Happy case: no other sessions" && revokeCalled {
+				t.Errorf("expected no session to be revoked")
+			}
+		})
+	}
+}
+
+func TestUseCasesUserImpl_ForceLogoutUser(t *testing.T) {
+	staffUserID := uuid.New().String()
+	userID := uuid.New().String()
+	organisationID := uuid.New().String()
+
+	tests := []struct {
+		name    string
+		want    bool
+		wantErr bool
+	}{
+		{
+			name:    "Happy case: force logout a client",
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name:    "Happy case: organisation admin force logs out a staff",
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name:    "Sad case: unable to get logged in user",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to get staff profile",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to get user profile",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: user belongs to a different organisation",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to check if user is a staff",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: staff who is not an organisation admin logs out a staff",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to list sessions",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to revoke sessions",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to remove push tokens",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeOTP := otpMock.NewOTPUseCaseMock()
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			fakePubsub := pubsubMock.NewPubsubServiceMock()
+			fakeClinical := clinicalMock.NewClinicalServiceMock()
+			fakeSMS := smsMock.NewSMSServiceMock()
+			fakeTwilio := twilioMock.NewTwilioServiceMock()
+			fakeMatrix := matrixMock.NewMatrixMock()
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+
+			us := NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
+
+			fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+				return staffUserID, nil
+			}
+			fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, id string) (*domain.User, error) {
+				return &domain.User{
+					ID:                    &id,
+					CurrentOrganizationID: organisationID,
+					PushTokens:            []string{"push-token"},
+				}, nil
+			}
+			fakeDB.MockGetStaffProfileFn = func(ctx context.Context, userID string, programID string) (*domain.StaffProfile, error) {
+				return &domain.StaffProfile{UserID: userID, IsOrganisationAdmin: false}, nil
+			}
+			fakeDB.MockCheckStaffExistsFn = func(ctx context.Context, id string) (bool, error) {
+				return id == staffUserID, nil
+			}
+
+			var auditLog *domain.AuditLog
+			fakeDB.MockCreateAuditLogFn = func(ctx context.Context, log *domain.AuditLog) error {
+				auditLog = log
+				return nil
+			}
+
+			if tt.name == "Happy case: organisation admin force logs out a staff" {
+				fakeDB.MockGetStaffProfileFn = func(ctx context.Context, userID string, programID string) (*domain.StaffProfile, error) {
+					return &domain.StaffProfile{UserID: userID, IsOrganisationAdmin: true}, nil
+				}
+				fakeDB.MockCheckStaffExistsFn = func(ctx context.Context, id string) (bool, error) {
+					return true, nil
+				}
+			}
+			if tt.name == "Sad case: unable to get logged in user" {
+				fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+					return "", fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get staff profile" {
+				fakeDB.MockGetStaffProfileFn = func(ctx context.Context, userID string, programID string) (*domain.StaffProfile, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get user profile" {
+				fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, id string) (*domain.User, error) {
+					if id == userID {
+						return nil, fmt.Errorf("an error occurred")
+					}
+					return &domain.User{ID: &id, CurrentOrganizationID: organisationID}, nil
+				}
+			}
+			if tt.name == "Sad case: user belongs to a different organisation" {
+				fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, id string) (*domain.User, error) {
+					if id == userID {
+						return &domain.User{ID: &id, CurrentOrganizationID: uuid.New().String()}, nil
+					}
+					return &domain.User{ID: &id, CurrentOrganizationID: organisationID}, nil
+				}
+			}
+			if tt.name == "Sad case: unable to check if user is a staff" {
+				fakeDB.MockCheckStaffExistsFn = func(ctx context.Context, id string) (bool, error) {
+					if id == userID {
+						return false, fmt.Errorf("an error occurred")
+					}
+					return true, nil
+				}
+			}
+			if tt.name == "Sad case: staff who is not an organisation admin logs out a staff" {
+				fakeDB.MockCheckStaffExistsFn = func(ctx context.Context, id string) (bool, error) {
+					return true, nil
+				}
+			}
+			if tt.name == "Sad case: unable to list sessions" {
+				fakeDB.MockListUserSessionsFn = func(ctx context.Context, userID string, activeSince time.Time) ([]*domain.Session, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to revoke sessions" {
+				fakeDB.MockRevokeSessionsFn = func(ctx context.Context, sessionIDs []string, revokedAt time.Time) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to remove push tokens" {
+				fakeDB.MockUpdateUserFn = func(ctx context.Context, user *domain.User, updateData map[string]interface{}) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := us.ForceLogoutUser(context.Background(), userID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesUserImpl.ForceLogoutUser() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UseCasesUserImpl.ForceLogoutUser() = %v, want %v", got, tt.want)
+			}
+
+			if !tt.wantErr {
+				if auditLog == nil || auditLog.RecordType != enums.AuditLogSessionRevocation || auditLog.TargetID != userID {
+					t.Errorf("expected the forced logout to be recorded in the audit log, got %v", auditLog)
+				}
+			}
+		})
+	}
+}
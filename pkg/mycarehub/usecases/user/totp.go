@@ -0,0 +1,432 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/utils"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/common"
+	"github.com/savannahghi/serverutils"
+)
+
+// totpSecretPassphrase is used to encrypt the TOTP secrets before they are stored
+var totpSecretPassphrase = serverutils.MustGetEnvVar("SENSITIVE_CONTENT_SECRET_KEY")
+
+// EnrollTOTP starts the enrollment of an authenticator app for the logged in staff.
+// The returned secret and URI are added to the authenticator app and the enrollment is completed
+// once the staff confirms it with a code generated by the app.
+func (us *UseCasesUserImpl) EnrollTOTP(ctx context.Context) (*domain.TOTPEnrollment, error) {
+	ctx, span := tracer.Start(ctx, "EnrollTOTP")
+	defer span.End()
+
+	userProfile, err := us.loggedInStaffUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	enrolled, err := us.Query.CheckIfUserHasTOTP(ctx, *userProfile.ID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InternalErr(err)
+	}
+
+	if enrolled {
+		err := fmt.Errorf("user %s already has a confirmed authenticator app", *userProfile.ID)
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.TOTPAlreadyEnrolledErr(err)
+	}
+
+	key, err := utils.GenerateTOTPKey(userProfile.Username)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InternalErr(err)
+	}
+
+	secret, err := helpers.EncryptSensitiveData(key.Secret(), totpSecretPassphrase)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InternalErr(fmt.Errorf("failed to encrypt TOTP secret: %w", err))
+	}
+
+	err = us.Create.SaveUserTOTP(ctx, &domain.UserTOTP{
+		UserID: *userProfile.ID,
+		Secret: secret,
+	})
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InternalErr(err)
+	}
+
+	return &domain.TOTPEnrollment{
+		Secret: key.Secret(),
+		URI:    key.URL(),
+	}, nil
+}
+
+// ConfirmTOTPEnrollment completes the enrollment of the logged in staff's authenticator app using a code generated by the app.
+// It returns the recovery codes that the staff can log in with when they do not have access to the app.
+// The recovery codes are only shown once.
+func (us *UseCasesUserImpl) ConfirmTOTPEnrollment(ctx context.Context, code string) ([]string, error) {
+	ctx, span := tracer.Start(ctx, "ConfirmTOTPEnrollment")
+	defer span.End()
+
+	userProfile, err := us.loggedInStaffUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userTOTP, err := us.Query.GetUserTOTP(ctx, *userProfile.ID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.TOTPNotEnrolledErr(err)
+	}
+
+	if userTOTP.Confirmed {
+		err := fmt.Errorf("user %s already has a confirmed authenticator app", *userProfile.ID)
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.TOTPAlreadyEnrolledErr(err)
+	}
+
+	secret, err := helpers.DecryptSensitiveData(userTOTP.Secret, totpSecretPassphrase)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InternalErr(fmt.Errorf("failed to decrypt TOTP secret: %w", err))
+	}
+
+	step, valid := utils.ValidateTOTPCode(secret, code, time.Now())
+	if !valid {
+		return nil, exceptions.TOTPMismatchErr()
+	}
+
+	recoveryCodes, err := us.generateRecoveryCodes(ctx, *userProfile.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = us.Update.UpdateUserTOTP(ctx, userTOTP, map[string]interface{}{
+		"confirmed":       true,
+		"confirmed_at":    time.Now(),
+		"last_used_step":  step,
+		"failed_attempts": 0,
+	})
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InternalErr(err)
+	}
+
+	common.RecordAuditLog(ctx, us.Create, &domain.AuditLog{
+		RecordType:     enums.AuditLogTOTPChange,
+		Notes:          "authenticator app enrolled",
+		ActorID:        *userProfile.ID,
+		TargetID:       *userProfile.ID,
+		TargetType:     enums.AuditLogTargetUser,
+		ProgramID:      userProfile.CurrentProgramID,
+		OrganisationID: userProfile.CurrentOrganizationID,
+	})
+
+	return recoveryCodes, nil
+}
+
+// RegenerateTOTPRecoveryCodes replaces the logged in staff's recovery codes after verifying a code from their authenticator app
+func (us *UseCasesUserImpl) RegenerateTOTPRecoveryCodes(ctx context.Context, code string) ([]string, error) {
+	ctx, span := tracer.Start(ctx, "RegenerateTOTPRecoveryCodes")
+	defer span.End()
+
+	userProfile, err := us.loggedInStaffUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := us.verifyConfirmedTOTP(ctx, *userProfile.ID, code); err != nil {
+		return nil, err
+	}
+
+	recoveryCodes, err := us.generateRecoveryCodes(ctx, *userProfile.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	common.RecordAuditLog(ctx, us.Create, &domain.AuditLog{
+		RecordType:     enums.AuditLogTOTPChange,
+		Notes:          "recovery codes regenerated",
+		ActorID:        *userProfile.ID,
+		TargetID:       *userProfile.ID,
+		TargetType:     enums.AuditLogTargetUser,
+		ProgramID:      userProfile.CurrentProgramID,
+		OrganisationID: userProfile.CurrentOrganizationID,
+	})
+
+	return recoveryCodes, nil
+}
+
+// DisableTOTP removes the logged in staff's authenticator app after verifying a code from the app or a recovery code.
+// Staff in an organisation that requires an authenticator app are not allowed to remove it.
+func (us *UseCasesUserImpl) DisableTOTP(ctx context.Context, code string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "DisableTOTP")
+	defer span.End()
+
+	userProfile, err := us.loggedInStaffUser(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	enforced, err := us.Query.CheckIfStaffTOTPIsEnforced(ctx, *userProfile.ID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.InternalErr(err)
+	}
+
+	if enforced {
+		err := fmt.Errorf("user %s is required to use an authenticator app", *userProfile.ID)
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.TOTPEnforcedErr(err)
+	}
+
+	if err := us.verifyConfirmedTOTP(ctx, *userProfile.ID, code); err != nil {
+		return false, err
+	}
+
+	if err := us.Delete.DeleteUserTOTP(ctx, *userProfile.ID); err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, exceptions.InternalErr(err)
+	}
+
+	common.RecordAuditLog(ctx, us.Create, &domain.AuditLog{
+		RecordType:     enums.AuditLogTOTPChange,
+		Notes:          "authenticator app removed",
+		ActorID:        *userProfile.ID,
+		TargetID:       *userProfile.ID,
+		TargetType:     enums.AuditLogTargetUser,
+		ProgramID:      userProfile.CurrentProgramID,
+		OrganisationID: userProfile.CurrentOrganizationID,
+	})
+
+	return true, nil
+}
+
+// GetTOTPStatus returns whether the logged in staff has an authenticator app and whether their organisation requires one
+func (us *UseCasesUserImpl) GetTOTPStatus(ctx context.Context) (*domain.TOTPStatus, error) {
+	ctx, span := tracer.Start(ctx, "GetTOTPStatus")
+	defer span.End()
+
+	userProfile, err := us.loggedInStaffUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	enabled, err := us.Query.CheckIfUserHasTOTP(ctx, *userProfile.ID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InternalErr(err)
+	}
+
+	enforced, err := us.Query.CheckIfStaffTOTPIsEnforced(ctx, *userProfile.ID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InternalErr(err)
+	}
+
+	status := &domain.TOTPStatus{
+		Enabled:  enabled,
+		Enforced: enforced,
+	}
+
+	if enabled {
+		recoveryCodes, err := us.Query.ListUserRecoveryCodes(ctx, *userProfile.ID)
+		if err != nil {
+			helpers.ReportErrorToSentry(err)
+			return nil, exceptions.InternalErr(err)
+		}
+		status.RemainingRecoveryCodes = len(recoveryCodes)
+	}
+
+	return status, nil
+}
+
+// loggedInStaffUser returns the profile of the logged in user after ensuring that they are a staff.
+// Authenticator apps are only used as a second factor for staff logins
+func (us *UseCasesUserImpl) loggedInStaffUser(ctx context.Context) (*domain.User, error) {
+	loggedInUserID, err := us.ExternalExt.GetLoggedInUserUID(ctx)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.GetLoggedInUserUIDErr(err)
+	}
+
+	userProfile, err := us.Query.GetUserProfileByUserID(ctx, loggedInUserID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.UserNotFoundError(err)
+	}
+
+	exists, err := us.Query.CheckStaffExists(ctx, loggedInUserID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.StaffProfileNotFoundErr(err)
+	}
+
+	if !exists {
+		err := fmt.Errorf("user %s does not have a staff profile", loggedInUserID)
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.StaffProfileNotFoundErr(err)
+	}
+
+	return userProfile, nil
+}
+
+// verifyConfirmedTOTP checks the provided code against the user's confirmed authenticator app
+func (us *UseCasesUserImpl) verifyConfirmedTOTP(ctx context.Context, userID string, code string) error {
+	userTOTP, err := us.Query.GetUserTOTP(ctx, userID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return exceptions.TOTPNotEnrolledErr(err)
+	}
+
+	if !userTOTP.Confirmed {
+		err := fmt.Errorf("user %s has not confirmed their authenticator app", userID)
+		helpers.ReportErrorToSentry(err)
+		return exceptions.TOTPNotEnrolledErr(err)
+	}
+
+	verified, err := us.verifySecondFactor(ctx, userTOTP, code)
+	if err != nil {
+		return exceptions.InternalErr(err)
+	}
+
+	if !verified {
+		return exceptions.TOTPMismatchErr()
+	}
+
+	return nil
+}
+
+// verifySecondFactor checks the provided code against the user's authenticator app or their unused recovery codes.
+// A TOTP code is only accepted once and a recovery code is used up once it is accepted.
+// The returned error is only set when the code could not be verified e.g due to a database error.
+func (us *UseCasesUserImpl) verifySecondFactor(ctx context.Context, userTOTP *domain.UserTOTP, code string) (bool, error) {
+	verified, err := us.verifyTOTPCode(ctx, userTOTP, code)
+	if err != nil {
+		return false, err
+	}
+
+	if !verified {
+		verified, err = us.verifyRecoveryCode(ctx, userTOTP.UserID, code)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if !verified {
+		userTOTP.FailedAttempts++
+		err := us.Update.UpdateUserTOTP(ctx, userTOTP, map[string]interface{}{
+			"failed_attempts": userTOTP.FailedAttempts,
+		})
+		if err != nil {
+			helpers.ReportErrorToSentry(err)
+			return false, err
+		}
+
+		return false, nil
+	}
+
+	if userTOTP.FailedAttempts > 0 {
+		err := us.Update.UpdateUserTOTP(ctx, userTOTP, map[string]interface{}{
+			"failed_attempts": 0,
+		})
+		if err != nil {
+			helpers.ReportErrorToSentry(err)
+			return false, err
+		}
+		userTOTP.FailedAttempts = 0
+	}
+
+	return true, nil
+}
+
+func (us *UseCasesUserImpl) verifyTOTPCode(ctx context.Context, userTOTP *domain.UserTOTP, code string) (bool, error) {
+	secret, err := helpers.DecryptSensitiveData(userTOTP.Secret, totpSecretPassphrase)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, fmt.Errorf("failed to decrypt TOTP secret: %w", err)
+	}
+
+	step, valid := utils.ValidateTOTPCode(secret, code, time.Now())
+	if !valid {
+		return false, nil
+	}
+
+	// a code cannot be replayed within the period it is valid for
+	if userTOTP.LastUsedStep != nil && step <= *userTOTP.LastUsedStep {
+		return false, nil
+	}
+
+	err = us.Update.UpdateUserTOTP(ctx, userTOTP, map[string]interface{}{
+		"last_used_step": step,
+	})
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, err
+	}
+	userTOTP.LastUsedStep = &step
+
+	return true, nil
+}
+
+func (us *UseCasesUserImpl) verifyRecoveryCode(ctx context.Context, userID string, code string) (bool, error) {
+	normalizedCode := utils.NormalizeRecoveryCode(code)
+	if normalizedCode == "" {
+		return false, nil
+	}
+
+	recoveryCodes, err := us.Query.ListUserRecoveryCodes(ctx, userID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, err
+	}
+
+	for _, recoveryCode := range recoveryCodes {
+		if !utils.ComparePIN(normalizedCode, recoveryCode.Salt, recoveryCode.HashedCode, nil) {
+			continue
+		}
+
+		// the code may have been used by a concurrent login
+		if err := us.Update.UseUserRecoveryCode(ctx, recoveryCode); err != nil {
+			helpers.ReportErrorToSentry(err)
+			return false, nil
+		}
+
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// generateRecoveryCodes replaces a user's recovery codes and returns the new codes in plain text
+func (us *UseCasesUserImpl) generateRecoveryCodes(ctx context.Context, userID string) ([]string, error) {
+	codes, err := utils.GenerateRecoveryCodes(utils.RecoveryCodesCount)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InternalErr(err)
+	}
+
+	recoveryCodes := []*domain.UserRecoveryCode{}
+	for _, code := range codes {
+		salt, hashedCode := utils.EncryptPIN(utils.NormalizeRecoveryCode(code), nil)
+		recoveryCodes = append(recoveryCodes, &domain.UserRecoveryCode{
+			UserID:     userID,
+			HashedCode: hashedCode,
+			Salt:       salt,
+		})
+	}
+
+	if err := us.Create.SaveUserRecoveryCodes(ctx, userID, recoveryCodes); err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InternalErr(err)
+	}
+
+	return codes, nil
+}
@@ -0,0 +1,626 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pquerna/otp/totp"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
+	extensionMock "github.com/savannahghi/mycarehub/pkg/mycarehub/application/extension/mock"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/utils"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	pgMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/mock"
+	clinicalMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/clinical/mock"
+	matrixMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/matrix/mock"
+	pubsubMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/pubsub/mock"
+	smsMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/sms/mock"
+	surveysMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/surveys/mock"
+	twilioMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/twilio/mock"
+	authorityMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/authority/mock"
+	notificationMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/notification/mock"
+	otpMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/otp/mock"
+)
+
+// testTOTPSecret generates a TOTP secret and returns it together with its encrypted form as it is stored in the database
+func testTOTPSecret(t *testing.T) (string, string) {
+	key, err := utils.GenerateTOTPKey("johndoe")
+	if err != nil {
+		t.Fatalf("failed to generate TOTP key: %v", err)
+	}
+
+	encrypted, err := helpers.EncryptSensitiveData(key.Secret(), totpSecretPassphrase)
+	if err != nil {
+		t.Fatalf("failed to encrypt TOTP secret: %v", err)
+	}
+
+	return key.Secret(), encrypted
+}
+
+// testRecoveryCode returns a recovery code as it is stored in the database
+func testRecoveryCode(code string) *domain.UserRecoveryCode {
+	salt, hashedCode := utils.EncryptPIN(utils.NormalizeRecoveryCode(code), nil)
+	return &domain.UserRecoveryCode{
+		ID:         "c7b3f4e2-5b3a-4e0c-9a7f-7d3c2b1a0f9e",
+		HashedCode: hashedCode,
+		Salt:       salt,
+	}
+}
+
+func TestUseCasesUserImpl_EnrollTOTP(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{
+			name:    "Happy case: enroll TOTP",
+			wantErr: false,
+		},
+		{
+			name:    "Sad case: unable to get logged in user",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to get user profile",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to check if staff exists",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: user is not a staff",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to check if user has TOTP",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: user already has TOTP",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to save user TOTP",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeOTP := otpMock.NewOTPUseCaseMock()
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			fakePubsub := pubsubMock.NewPubsubServiceMock()
+			fakeClinical := clinicalMock.NewClinicalServiceMock()
+			fakeSMS := smsMock.NewSMSServiceMock()
+			fakeTwilio := twilioMock.NewTwilioServiceMock()
+			fakeMatrix := matrixMock.NewMatrixMock()
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+
+			us := NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
+
+			var savedTOTP *domain.UserTOTP
+			fakeDB.MockSaveUserTOTPFn = func(ctx context.Context, userTOTP *domain.UserTOTP) error {
+				savedTOTP = userTOTP
+				return nil
+			}
+
+			if tt.name == "Sad case: unable to get logged in user" {
+				fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+					return "", fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get user profile" {
+				fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.User, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to check if staff exists" {
+				fakeDB.MockCheckStaffExistsFn = func(ctx context.Context, userID string) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: user is not a staff" {
+				fakeDB.MockCheckStaffExistsFn = func(ctx context.Context, userID string) (bool, error) {
+					return false, nil
+				}
+			}
+			if tt.name == "Sad case: unable to check if user has TOTP" {
+				fakeDB.MockCheckIfUserHasTOTPFn = func(ctx context.Context, userID string) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: user already has TOTP" {
+				fakeDB.MockCheckIfUserHasTOTPFn = func(ctx context.Context, userID string) (bool, error) {
+					return true, nil
+				}
+			}
+			if tt.name == "Sad case: unable to save user TOTP" {
+				fakeDB.MockSaveUserTOTPFn = func(ctx context.Context, userTOTP *domain.UserTOTP) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := us.EnrollTOTP(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesUserImpl.EnrollTOTP() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if got.Secret == "" || got.URI == "" {
+				t.Errorf("UseCasesUserImpl.EnrollTOTP() expected a secret and URI")
+			}
+			if savedTOTP == nil || savedTOTP.Secret == got.Secret {
+				t.Errorf("UseCasesUserImpl.EnrollTOTP() expected the secret to be saved encrypted")
+			}
+		})
+	}
+}
+
+func TestUseCasesUserImpl_ConfirmTOTPEnrollment(t *testing.T) {
+	secret, encryptedSecret := testTOTPSecret(t)
+
+	validCode, err := totp.GenerateCode(secret, time.Now())
+	if err != nil {
+		t.Fatalf("failed to generate code: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		code    string
+		wantErr bool
+	}{
+		{
+			name:    "Happy case: confirm TOTP enrollment",
+			code:    validCode,
+			wantErr: false,
+		},
+		{
+			name:    "Sad case: unable to get logged in staff",
+			code:    validCode,
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: user has not started enrollment",
+			code:    validCode,
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: enrollment is already confirmed",
+			code:    validCode,
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: invalid code",
+			code:    "000000",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to save recovery codes",
+			code:    validCode,
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to update user TOTP",
+			code:    validCode,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeOTP := otpMock.NewOTPUseCaseMock()
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			fakePubsub := pubsubMock.NewPubsubServiceMock()
+			fakeClinical := clinicalMock.NewClinicalServiceMock()
+			fakeSMS := smsMock.NewSMSServiceMock()
+			fakeTwilio := twilioMock.NewTwilioServiceMock()
+			fakeMatrix := matrixMock.NewMatrixMock()
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+
+			us := NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
+
+			fakeDB.MockGetUserTOTPFn = func(ctx context.Context, userID string) (*domain.UserTOTP, error) {
+				return &domain.UserTOTP{
+					UserID: userID,
+					Secret: encryptedSecret,
+				}, nil
+			}
+
+			var savedRecoveryCodes []*domain.UserRecoveryCode
+			fakeDB.MockSaveUserRecoveryCodesFn = func(ctx context.Context, userID string, recoveryCodes []*domain.UserRecoveryCode) error {
+				savedRecoveryCodes = recoveryCodes
+				return nil
+			}
+
+			if tt.name == "Sad case: unable to get logged in staff" {
+				fakeDB.MockCheckStaffExistsFn = func(ctx context.Context, userID string) (bool, error) {
+					return false, nil
+				}
+			}
+			if tt.name == "Sad case: user has not started enrollment" {
+				fakeDB.MockGetUserTOTPFn = func(ctx context.Context, userID string) (*domain.UserTOTP, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: enrollment is already confirmed" {
+				fakeDB.MockGetUserTOTPFn = func(ctx context.Context, userID string) (*domain.UserTOTP, error) {
+					return &domain.UserTOTP{
+						UserID:    userID,
+						Secret:    encryptedSecret,
+						Confirmed: true,
+					}, nil
+				}
+			}
+			if tt.name == "Sad case: unable to save recovery codes" {
+				fakeDB.MockSaveUserRecoveryCodesFn = func(ctx context.Context, userID string, recoveryCodes []*domain.UserRecoveryCode) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to update user TOTP" {
+				fakeDB.MockUpdateUserTOTPFn = func(ctx context.Context, userTOTP *domain.UserTOTP, updateData map[string]interface{}) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := us.ConfirmTOTPEnrollment(context.Background(), tt.code)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesUserImpl.ConfirmTOTPEnrollment() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if len(got) != utils.RecoveryCodesCount || len(savedRecoveryCodes) != utils.RecoveryCodesCount {
+				t.Errorf("UseCasesUserImpl.ConfirmTOTPEnrollment() expected %v recovery codes to be issued", utils.RecoveryCodesCount)
+				return
+			}
+			for i, code := range got {
+				if !utils.ComparePIN(utils.NormalizeRecoveryCode(code), savedRecoveryCodes[i].Salt, savedRecoveryCodes[i].HashedCode, nil) {
+					t.Errorf("UseCasesUserImpl.ConfirmTOTPEnrollment() expected recovery code %v to be saved hashed", code)
+				}
+			}
+		})
+	}
+}
+
+func TestUseCasesUserImpl_RegenerateTOTPRecoveryCodes(t *testing.T) {
+	secret, encryptedSecret := testTOTPSecret(t)
+
+	validCode, err := totp.GenerateCode(secret, time.Now())
+	if err != nil {
+		t.Fatalf("failed to generate code: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		code    string
+		wantErr bool
+	}{
+		{
+			name:    "Happy case: regenerate recovery codes",
+			code:    validCode,
+			wantErr: false,
+		},
+		{
+			name:    "Sad case: unable to get logged in staff",
+			code:    validCode,
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: user has no TOTP",
+			code:    validCode,
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: enrollment is not confirmed",
+			code:    validCode,
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: invalid code",
+			code:    "000000",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to save recovery codes",
+			code:    validCode,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeOTP := otpMock.NewOTPUseCaseMock()
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			fakePubsub := pubsubMock.NewPubsubServiceMock()
+			fakeClinical := clinicalMock.NewClinicalServiceMock()
+			fakeSMS := smsMock.NewSMSServiceMock()
+			fakeTwilio := twilioMock.NewTwilioServiceMock()
+			fakeMatrix := matrixMock.NewMatrixMock()
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+
+			us := NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
+
+			fakeDB.MockGetUserTOTPFn = func(ctx context.Context, userID string) (*domain.UserTOTP, error) {
+				return &domain.UserTOTP{
+					UserID:    userID,
+					Secret:    encryptedSecret,
+					Confirmed: true,
+				}, nil
+			}
+
+			if tt.name == "Sad case: unable to get logged in staff" {
+				fakeDB.MockCheckStaffExistsFn = func(ctx context.Context, userID string) (bool, error) {
+					return false, nil
+				}
+			}
+			if tt.name == "Sad case: user has no TOTP" {
+				fakeDB.MockGetUserTOTPFn = func(ctx context.Context, userID string) (*domain.UserTOTP, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: enrollment is not confirmed" {
+				fakeDB.MockGetUserTOTPFn = func(ctx context.Context, userID string) (*domain.UserTOTP, error) {
+					return &domain.UserTOTP{
+						UserID: userID,
+						Secret: encryptedSecret,
+					}, nil
+				}
+			}
+			if tt.name == "Sad case: unable to save recovery codes" {
+				fakeDB.MockSaveUserRecoveryCodesFn = func(ctx context.Context, userID string, recoveryCodes []*domain.UserRecoveryCode) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := us.RegenerateTOTPRecoveryCodes(context.Background(), tt.code)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesUserImpl.RegenerateTOTPRecoveryCodes() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got) != utils.RecoveryCodesCount {
+				t.Errorf("UseCasesUserImpl.RegenerateTOTPRecoveryCodes() returned %v codes, want %v", len(got), utils.RecoveryCodesCount)
+			}
+		})
+	}
+}
+
+func TestUseCasesUserImpl_DisableTOTP(t *testing.T) {
+	secret, encryptedSecret := testTOTPSecret(t)
+
+	validCode, err := totp.GenerateCode(secret, time.Now())
+	if err != nil {
+		t.Fatalf("failed to generate code: %v", err)
+	}
+
+	recoveryCode := "abcde-23456"
+
+	tests := []struct {
+		name    string
+		code    string
+		want    bool
+		wantErr bool
+	}{
+		{
+			name:    "Happy case: disable TOTP",
+			code:    validCode,
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name:    "Happy case: disable TOTP using a recovery code",
+			code:    recoveryCode,
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name:    "Sad case: unable to get logged in staff",
+			code:    validCode,
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to check if TOTP is enforced",
+			code:    validCode,
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: organisation enforces TOTP",
+			code:    validCode,
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: invalid code",
+			code:    "000000",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to record failed attempt",
+			code:    "000000",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to delete user TOTP",
+			code:    validCode,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeOTP := otpMock.NewOTPUseCaseMock()
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			fakePubsub := pubsubMock.NewPubsubServiceMock()
+			fakeClinical := clinicalMock.NewClinicalServiceMock()
+			fakeSMS := smsMock.NewSMSServiceMock()
+			fakeTwilio := twilioMock.NewTwilioServiceMock()
+			fakeMatrix := matrixMock.NewMatrixMock()
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+
+			us := NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
+
+			fakeDB.MockGetUserTOTPFn = func(ctx context.Context, userID string) (*domain.UserTOTP, error) {
+				return &domain.UserTOTP{
+					UserID:    userID,
+					Secret:    encryptedSecret,
+					Confirmed: true,
+				}, nil
+			}
+			fakeDB.MockListUserRecoveryCodesFn = func(ctx context.Context, userID string) ([]*domain.UserRecoveryCode, error) {
+				return []*domain.UserRecoveryCode{testRecoveryCode(recoveryCode)}, nil
+			}
+
+			if tt.name == "Sad case: unable to get logged in staff" {
+				fakeDB.MockCheckStaffExistsFn = func(ctx context.Context, userID string) (bool, error) {
+					return false, nil
+				}
+			}
+			if tt.name == "Sad case: unable to check if TOTP is enforced" {
+				fakeDB.MockCheckIfStaffTOTPIsEnforcedFn = func(ctx context.Context, userID string) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: organisation enforces TOTP" {
+				fakeDB.MockCheckIfStaffTOTPIsEnforcedFn = func(ctx context.Context, userID string) (bool, error) {
+					return true, nil
+				}
+			}
+			if tt.name == "Sad case: unable to record failed attempt" {
+				fakeDB.MockUpdateUserTOTPFn = func(ctx context.Context, userTOTP *domain.UserTOTP, updateData map[string]interface{}) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to delete user TOTP" {
+				fakeDB.MockDeleteUserTOTPFn = func(ctx context.Context, userID string) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := us.DisableTOTP(context.Background(), tt.code)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesUserImpl.DisableTOTP() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("UseCasesUserImpl.DisableTOTP() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUseCasesUserImpl_GetTOTPStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    *domain.TOTPStatus
+		wantErr bool
+	}{
+		{
+			name: "Happy case: user has TOTP",
+			want: &domain.TOTPStatus{
+				Enabled:                true,
+				Enforced:               true,
+				RemainingRecoveryCodes: 1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case: user does not have TOTP",
+			want: &domain.TOTPStatus{
+				Enabled:  false,
+				Enforced: true,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Sad case: unable to get logged in staff",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to check if user has TOTP",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to check if TOTP is enforced",
+			wantErr: true,
+		},
+		{
+			name:    "Sad case: unable to list recovery codes",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeOTP := otpMock.NewOTPUseCaseMock()
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			fakePubsub := pubsubMock.NewPubsubServiceMock()
+			fakeClinical := clinicalMock.NewClinicalServiceMock()
+			fakeSMS := smsMock.NewSMSServiceMock()
+			fakeTwilio := twilioMock.NewTwilioServiceMock()
+			fakeMatrix := matrixMock.NewMatrixMock()
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+
+			us := NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
+
+			fakeDB.MockCheckIfUserHasTOTPFn = func(ctx context.Context, userID string) (bool, error) {
+				return true, nil
+			}
+			fakeDB.MockCheckIfStaffTOTPIsEnforcedFn = func(ctx context.Context, userID string) (bool, error) {
+				return true, nil
+			}
+
+			if tt.name == "Happy case: user does not have TOTP" {
+				fakeDB.MockCheckIfUserHasTOTPFn = func(ctx context.Context, userID string) (bool, error) {
+					return false, nil
+				}
+			}
+			if tt.name == "Sad case: unable to get logged in staff" {
+				fakeDB.MockCheckStaffExistsFn = func(ctx context.Context, userID string) (bool, error) {
+					return false, nil
+				}
+			}
+			if tt.name == "Sad case: unable to check if user has TOTP" {
+				fakeDB.MockCheckIfUserHasTOTPFn = func(ctx context.Context, userID string) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to check if TOTP is enforced" {
+				fakeDB.MockCheckIfStaffTOTPIsEnforcedFn = func(ctx context.Context, userID string) (bool, error) {
+					return false, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to list recovery codes" {
+				fakeDB.MockListUserRecoveryCodesFn = func(ctx context.Context, userID string) ([]*domain.UserRecoveryCode, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := us.GetTOTPStatus(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesUserImpl.GetTOTPStatus() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if *got != *tt.want {
+				t.Errorf("UseCasesUserImpl.GetTOTPStatus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,272 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/common/helpers"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/authorization"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/common"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/notification"
+	"github.com/savannahghi/scalarutils"
+)
+
+// TransferClient moves a client to another facility in their program or to a facility in another program of their organisation.
+// A client moving to another program gets a new client profile there and their old profile is deactivated. Only the history the client
+// consented to carry is moved to the new profile, the rest stays with the program that recorded it.
+// Transfers to another organisation are rejected since the client's FHIR patient cannot be moved to the receiving organisation yet.
+// The staff of the receiving facility are notified of the transfer
+func (us *UseCasesUserImpl) TransferClient(ctx context.Context, input *dto.ClientTransferInput) (*domain.ClientTransfer, error) {
+	ctx, span := tracer.Start(ctx, "TransferClient")
+	defer span.End()
+
+	if err := input.Validate(); err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InputValidationErr(fmt.Errorf("failed to validate client transfer input: %w", err))
+	}
+
+	loggedInUser, err := us.loggedInStaffUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	clientProfile, err := us.Query.GetClientProfileByClientID(ctx, input.ClientID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.ClientProfileNotFoundErr(err)
+	}
+
+	if !clientProfile.Active {
+		return nil, exceptions.InputValidationErr(fmt.Errorf("client %s is inactive and cannot be transferred", input.ClientID))
+	}
+
+	if clientProfile.ProgramID != loggedInUser.CurrentProgramID {
+		err := fmt.Errorf("client %s does not belong to the program of staff %s", input.ClientID, *loggedInUser.ID)
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.UserNotAuthorizedErr(err)
+	}
+
+	programID := clientProfile.ProgramID
+	if input.ProgramID != nil {
+		programID = *input.ProgramID
+	}
+
+	program, err := us.Query.GetProgramByID(ctx, programID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InputValidationErr(fmt.Errorf("failed to get program %s: %w", programID, err))
+	}
+
+	if program.Organisation.ID != clientProfile.OrganisationID {
+		return nil, exceptions.InputValidationErr(fmt.Errorf("client %s cannot be transferred to program %s in another organisation", input.ClientID, program.Name))
+	}
+
+	facility, err := us.Query.RetrieveFacility(ctx, &input.FacilityID, true)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InputValidationErr(fmt.Errorf("failed to get facility %s: %w", input.FacilityID, err))
+	}
+
+	exists, err := us.Query.CheckIfFacilityExistsInProgram(ctx, program.ID, input.FacilityID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InternalErr(fmt.Errorf("failed to check if facility is in program: %w", err))
+	}
+
+	if !exists {
+		return nil, exceptions.InputValidationErr(fmt.Errorf("facility %s is not in program %s", facility.Name, program.Name))
+	}
+
+	transfer := &domain.ClientTransfer{
+		UserID:             clientProfile.UserID,
+		ClientID:           input.ClientID,
+		FromFacilityID:     *clientProfile.DefaultFacility.ID,
+		ToFacilityID:       input.FacilityID,
+		FromProgramID:      clientProfile.ProgramID,
+		ToProgramID:        program.ID,
+		FromOrganisationID: clientProfile.OrganisationID,
+		ToOrganisationID:   program.Organisation.ID,
+		Reason:             input.Reason,
+		CarriedHistory:     []enums.ClientTransferHistory{},
+		ClientConsented:    input.ClientConsented,
+		TransferredBy:      *loggedInUser.ID,
+		TransferredAt:      time.Now(),
+	}
+
+	var payload *domain.ClientRegistrationPayload
+	if transfer.IsProgramTransfer() {
+		payload, err = us.clientTransferProfile(ctx, clientProfile, program, input.FacilityID)
+		if err != nil {
+			return nil, err
+		}
+
+		transfer.CarriedHistory = input.CarryHistory
+	} else if transfer.FromFacilityID == transfer.ToFacilityID {
+		return nil, exceptions.InputValidationErr(fmt.Errorf("client %s is already at facility %s", input.ClientID, facility.Name))
+	}
+
+	transfer, err = us.Create.TransferClient(ctx, transfer, payload)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InternalErr(fmt.Errorf("failed to transfer client: %w", err))
+	}
+
+	if payload != nil {
+		// a failure is reported by assignDefaultRole and the role is assigned again when the default roles are seeded
+		_ = us.assignDefaultRole(ctx, enums.ClientUser, transfer.NewClientID, transfer.ToProgramID, authorization.DefaultRoleClient)
+
+		cmsClientPayload := &dto.PubsubCreateCMSClientPayload{
+			ClientID:       transfer.NewClientID,
+			Name:           clientProfile.User.Name,
+			Gender:         clientProfile.User.Gender.String(),
+			OrganisationID: transfer.ToOrganisationID,
+			ProgramID:      transfer.ToProgramID,
+		}
+		if clientProfile.User.DateOfBirth != nil {
+			cmsClientPayload.DateOfBirth = scalarutils.Date{
+				Year:  clientProfile.User.DateOfBirth.Year(),
+				Month: int(clientProfile.User.DateOfBirth.Month()),
+				Day:   clientProfile.User.DateOfBirth.Day(),
+			}
+		}
+
+		if err := us.Pubsub.NotifyCreateCMSClient(ctx, cmsClientPayload); err != nil {
+			helpers.ReportErrorToSentry(fmt.Errorf("failed to publish transferred client profile to cms: %w", err))
+		}
+	}
+
+	common.RecordAuditLog(ctx, us.Create, &domain.AuditLog{
+		RecordType:     enums.AuditLogClientTransfer,
+		Notes:          fmt.Sprintf("client transferred: %s", input.Reason),
+		ActorID:        *loggedInUser.ID,
+		TargetID:       input.ClientID,
+		TargetType:     enums.AuditLogTargetClient,
+		ProgramID:      transfer.FromProgramID,
+		OrganisationID: transfer.FromOrganisationID,
+		Before: map[string]interface{}{
+			"client_id":       transfer.ClientID,
+			"facility_id":     transfer.FromFacilityID,
+			"program_id":      transfer.FromProgramID,
+			"organisation_id": transfer.FromOrganisationID,
+		},
+		After: map[string]interface{}{
+			"client_id":        transfer.NewClientID,
+			"facility_id":      transfer.ToFacilityID,
+			"program_id":       transfer.ToProgramID,
+			"organisation_id":  transfer.ToOrganisationID,
+			"carried_history":  transfer.CarriedHistory,
+			"client_consented": transfer.ClientConsented,
+		},
+	})
+
+	transferNotification := notification.ComposeStaffNotification(enums.NotificationTypeClientTransferred, notification.StaffNotificationArgs{
+		Subject:         clientProfile.User,
+		Transfer:        transfer,
+		TransferredFrom: clientProfile.DefaultFacility,
+	})
+	transferNotification.ProgramID = transfer.ToProgramID
+	transferNotification.OrganisationID = transfer.ToOrganisationID
+
+	if err := us.Notification.NotifyFacilityStaffs(ctx, facility, transferNotification); err != nil {
+		helpers.ReportErrorToSentry(err)
+	}
+
+	return transfer, nil
+}
+
+// clientTransferProfile prepares the client profile that a client moving to another program gets there.
+// The profile keeps the client's identifier, FHIR patient and health record since the client stays in their organisation
+func (us *UseCasesUserImpl) clientTransferProfile(ctx context.Context, clientProfile *domain.ClientProfile, program *domain.Program, facilityID string) (*domain.ClientRegistrationPayload, error) {
+	exists, err := us.Query.CheckIfClientExistsInProgram(ctx, clientProfile.UserID, program.ID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InternalErr(fmt.Errorf("failed to check if client is already registered in program: %w", err))
+	}
+
+	if exists {
+		return nil, exceptions.InputValidationErr(fmt.Errorf("client %s is already registered in program %s", *clientProfile.ID, program.Name))
+	}
+
+	var identifier *domain.Identifier
+	for _, clientIdentifier := range clientProfile.Identifiers {
+		if clientIdentifier.Type == enums.UserIdentifierTypeCCC {
+			identifier = clientIdentifier
+			break
+		}
+	}
+
+	if identifier == nil {
+		err := fmt.Errorf("client %s does not have a CCC number", *clientProfile.ID)
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.ClientCCCIdentifierNotFoundErr(err)
+	}
+
+	return &domain.ClientRegistrationPayload{
+		ClientIdentifier: domain.Identifier{
+			Type:                identifier.Type,
+			Value:               identifier.Value,
+			Use:                 identifier.Use,
+			Description:         identifier.Description,
+			IsPrimaryIdentifier: identifier.IsPrimaryIdentifier,
+			Active:              true,
+			ProgramID:           program.ID,
+			OrganisationID:      program.Organisation.ID,
+		},
+		Client: domain.ClientProfile{
+			UserID:                  clientProfile.UserID,
+			Active:                  true,
+			ClientTypes:             clientProfile.ClientTypes,
+			TreatmentEnrollmentDate: clientProfile.TreatmentEnrollmentDate,
+			FHIRPatientID:           clientProfile.FHIRPatientID,
+			HealthRecordID:          clientProfile.HealthRecordID,
+			ClientCounselled:        clientProfile.ClientCounselled,
+			DefaultFacility:         &domain.Facility{ID: &facilityID},
+			ProgramID:               program.ID,
+			OrganisationID:          program.Organisation.ID,
+		},
+	}, nil
+}
+
+// ListClientTransfers returns the transfers of a client across all their client profiles, the most recent first.
+// A staff only sees the transfers into or out of their organisation
+func (us *UseCasesUserImpl) ListClientTransfers(ctx context.Context, clientID string) ([]*domain.ClientTransfer, error) {
+	ctx, span := tracer.Start(ctx, "ListClientTransfers")
+	defer span.End()
+
+	loggedInUser, err := us.loggedInStaffUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	clientProfile, err := us.Query.GetClientProfileByClientID(ctx, clientID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.ClientProfileNotFoundErr(err)
+	}
+
+	if clientProfile.OrganisationID != loggedInUser.CurrentOrganizationID {
+		err := fmt.Errorf("client %s does not belong to the organisation of staff %s", clientID, *loggedInUser.ID)
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.UserNotAuthorizedErr(err)
+	}
+
+	transfers, err := us.Query.ListClientTransfers(ctx, clientProfile.UserID)
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return nil, exceptions.InternalErr(fmt.Errorf("failed to list client transfers: %w", err))
+	}
+
+	organisationTransfers := []*domain.ClientTransfer{}
+	for _, transfer := range transfers {
+		if transfer.FromOrganisationID == loggedInUser.CurrentOrganizationID || transfer.ToOrganisationID == loggedInUser.CurrentOrganizationID {
+			organisationTransfers = append(organisationTransfers, transfer)
+		}
+	}
+
+	return organisationTransfers, nil
+}
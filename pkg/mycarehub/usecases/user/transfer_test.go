@@ -0,0 +1,499 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/savannahghi/enumutils"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/dto"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
+	extensionMock "github.com/savannahghi/mycarehub/pkg/mycarehub/application/extension/mock"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
+	pgMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/mock"
+	clinicalMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/clinical/mock"
+	matrixMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/matrix/mock"
+	pubsubMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/pubsub/mock"
+	smsMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/sms/mock"
+	surveysMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/surveys/mock"
+	twilioMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/twilio/mock"
+	authorityMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/authority/mock"
+	notificationMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/notification/mock"
+	otpMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/otp/mock"
+)
+
+func TestUseCasesUserImpl_TransferClient(t *testing.T) {
+	clientID := uuid.New().String()
+	facilityID := uuid.New().String()
+	programID := uuid.New().String()
+
+	tests := []struct {
+		name    string
+		input   *dto.ClientTransferInput
+		wantErr bool
+	}{
+		{
+			name: "Happy case: transfer client to another facility in their program",
+			input: &dto.ClientTransferInput{
+				ClientID:   clientID,
+				FacilityID: facilityID,
+				Reason:     "Relocated",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case: transfer client to another program",
+			input: &dto.ClientTransferInput{
+				ClientID:        clientID,
+				FacilityID:      facilityID,
+				ProgramID:       &programID,
+				Reason:          "Relocated",
+				CarryHistory:    []enums.ClientTransferHistory{enums.ClientTransferHistoryHealthDiary},
+				ClientConsented: true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case: transfer client without a date of birth to another program",
+			input: &dto.ClientTransferInput{
+				ClientID:   clientID,
+				FacilityID: facilityID,
+				ProgramID:  &programID,
+				Reason:     "Relocated",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Happy case: unable to notify facility staffs",
+			input: &dto.ClientTransferInput{
+				ClientID:   clientID,
+				FacilityID: facilityID,
+				Reason:     "Relocated",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sad case: carry history without consent",
+			input: &dto.ClientTransferInput{
+				ClientID:     clientID,
+				FacilityID:   facilityID,
+				ProgramID:    &programID,
+				Reason:       "Relocated",
+				CarryHistory: []enums.ClientTransferHistory{enums.ClientTransferHistoryHealthDiary},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: unable to get logged in user",
+			input: &dto.ClientTransferInput{
+				ClientID:   clientID,
+				FacilityID: facilityID,
+				Reason:     "Relocated",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: unable to get client profile",
+			input: &dto.ClientTransferInput{
+				ClientID:   clientID,
+				FacilityID: facilityID,
+				Reason:     "Relocated",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: client is inactive",
+			input: &dto.ClientTransferInput{
+				ClientID:   clientID,
+				FacilityID: facilityID,
+				Reason:     "Relocated",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: client belongs to another program",
+			input: &dto.ClientTransferInput{
+				ClientID:   clientID,
+				FacilityID: facilityID,
+				Reason:     "Relocated",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: unable to get program",
+			input: &dto.ClientTransferInput{
+				ClientID:   clientID,
+				FacilityID: facilityID,
+				ProgramID:  &programID,
+				Reason:     "Relocated",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: program belongs to another organisation",
+			input: &dto.ClientTransferInput{
+				ClientID:   clientID,
+				FacilityID: facilityID,
+				ProgramID:  &programID,
+				Reason:     "Relocated",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: unable to get facility",
+			input: &dto.ClientTransferInput{
+				ClientID:   clientID,
+				FacilityID: facilityID,
+				Reason:     "Relocated",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: facility is not in program",
+			input: &dto.ClientTransferInput{
+				ClientID:   clientID,
+				FacilityID: facilityID,
+				ProgramID:  &programID,
+				Reason:     "Relocated",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: client is already at facility",
+			input: &dto.ClientTransferInput{
+				ClientID:   clientID,
+				FacilityID: facilityID,
+				Reason:     "Relocated",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: client is already registered in program",
+			input: &dto.ClientTransferInput{
+				ClientID:   clientID,
+				FacilityID: facilityID,
+				ProgramID:  &programID,
+				Reason:     "Relocated",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: client does not have a CCC number",
+			input: &dto.ClientTransferInput{
+				ClientID:   clientID,
+				FacilityID: facilityID,
+				ProgramID:  &programID,
+				Reason:     "Relocated",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: unable to transfer client",
+			input: &dto.ClientTransferInput{
+				ClientID:   clientID,
+				FacilityID: facilityID,
+				Reason:     "Relocated",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeOTP := otpMock.NewOTPUseCaseMock()
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			fakePubsub := pubsubMock.NewPubsubServiceMock()
+			fakeClinical := clinicalMock.NewClinicalServiceMock()
+			fakeSMS := smsMock.NewSMSServiceMock()
+			fakeTwilio := twilioMock.NewTwilioServiceMock()
+			fakeMatrix := matrixMock.NewMatrixMock()
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+
+			us := NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
+
+			staffUserID := uuid.New().String()
+			clientUserID := uuid.New().String()
+			currentProgramID := uuid.New().String()
+			currentFacilityID := uuid.New().String()
+			organisationID := uuid.New().String()
+			fhirPatientID := uuid.New().String()
+			dateOfBirth := time.Now().AddDate(-20, 0, 0)
+
+			clientProfile := &domain.ClientProfile{
+				ID:     &clientID,
+				UserID: clientUserID,
+				User: &domain.User{
+					ID:          &clientUserID,
+					Name:        "Test Client",
+					Gender:      enumutils.GenderFemale,
+					DateOfBirth: &dateOfBirth,
+				},
+				Active:         true,
+				FHIRPatientID:  &fhirPatientID,
+				ProgramID:      currentProgramID,
+				OrganisationID: organisationID,
+				DefaultFacility: &domain.Facility{
+					ID:   &currentFacilityID,
+					Name: "Current Facility",
+				},
+				Identifiers: []*domain.Identifier{
+					{
+						Type:                enums.UserIdentifierTypeCCC,
+						Value:               "123456",
+						Use:                 "OFFICIAL",
+						IsPrimaryIdentifier: true,
+						Active:              true,
+					},
+				},
+			}
+
+			fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+				return staffUserID, nil
+			}
+			fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.User, error) {
+				return &domain.User{
+					ID:                    &userID,
+					Name:                  "Test Staff",
+					CurrentOrganizationID: organisationID,
+					CurrentProgramID:      currentProgramID,
+				}, nil
+			}
+			fakeDB.MockGetClientProfileByClientIDFn = func(ctx context.Context, clientID string) (*domain.ClientProfile, error) {
+				return clientProfile, nil
+			}
+			fakeDB.MockGetProgramByIDFn = func(ctx context.Context, programID string) (*domain.Program, error) {
+				return &domain.Program{
+					ID:                 programID,
+					Name:               "Test Program",
+					FHIROrganisationID: uuid.New().String(),
+					Organisation:       domain.Organisation{ID: organisationID},
+				}, nil
+			}
+			fakeDB.MockRetrieveFacilityFn = func(ctx context.Context, id *string, isActive bool) (*domain.Facility, error) {
+				return &domain.Facility{ID: id, Name: "New Facility", FHIROrganisationID: uuid.New().String()}, nil
+			}
+
+			var transferPayload *domain.ClientRegistrationPayload
+			fakeDB.MockTransferClientFn = func(ctx context.Context, transfer *domain.ClientTransfer, payload *domain.ClientRegistrationPayload) (*domain.ClientTransfer, error) {
+				transferPayload = payload
+				transfer.ID = uuid.New().String()
+				transfer.NewClientID = transfer.ClientID
+				if payload != nil {
+					transfer.NewClientID = uuid.New().String()
+				}
+				return transfer, nil
+			}
+
+			var notifiedFacility string
+			fakeNotification.MockNotifyFacilityStaffsFn = func(ctx context.Context, facility *domain.Facility, notificationPayload *domain.Notification) error {
+				notifiedFacility = *facility.ID
+				return nil
+			}
+
+			if tt.name == "Happy case: transfer client without a date of birth to another program" {
+				clientProfile.User.DateOfBirth = nil
+			}
+			if tt.name == "Happy case: unable to notify facility staffs" {
+				fakeNotification.MockNotifyFacilityStaffsFn = func(ctx context.Context, facility *domain.Facility, notificationPayload *domain.Notification) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get logged in user" {
+				fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+					return "", fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get client profile" {
+				fakeDB.MockGetClientProfileByClientIDFn = func(ctx context.Context, clientID string) (*domain.ClientProfile, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: client is inactive" {
+				clientProfile.Active = false
+			}
+			if tt.name == "Sad case: client belongs to another program" {
+				clientProfile.ProgramID = uuid.New().String()
+			}
+			if tt.name == "Sad case: unable to get program" {
+				fakeDB.MockGetProgramByIDFn = func(ctx context.Context, programID string) (*domain.Program, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: program belongs to another organisation" {
+				fakeDB.MockGetProgramByIDFn = func(ctx context.Context, programID string) (*domain.Program, error) {
+					return &domain.Program{ID: programID, Name: "Test Program", Organisation: domain.Organisation{ID: uuid.New().String()}}, nil
+				}
+			}
+			if tt.name == "Sad case: unable to get facility" {
+				fakeDB.MockRetrieveFacilityFn = func(ctx context.Context, id *string, isActive bool) (*domain.Facility, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: facility is not in program" {
+				fakeDB.MockCheckIfFacilityExistsInProgramFn = func(ctx context.Context, programID, facilityID string) (bool, error) {
+					return false, nil
+				}
+			}
+			if tt.name == "Sad case: client is already at facility" {
+				clientProfile.DefaultFacility.ID = &facilityID
+			}
+			if tt.name == "Sad case: client is already registered in program" {
+				fakeDB.MockCheckIfClientExistsInProgramFn = func(ctx context.Context, userID, programID string) (bool, error) {
+					return true, nil
+				}
+			}
+			if tt.name == "Sad case: client does not have a CCC number" {
+				clientProfile.Identifiers = []*domain.Identifier{}
+			}
+			if tt.name == "Sad case: unable to transfer client" {
+				fakeDB.MockTransferClientFn = func(ctx context.Context, transfer *domain.ClientTransfer, payload *domain.ClientRegistrationPayload) (*domain.ClientTransfer, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := us.TransferClient(context.Background(), tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesUserImpl.TransferClient() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if tt.name == "Happy case: transfer client to another facility in their program" {
+				if transferPayload != nil {
+					t.Errorf("expected the client to keep their client profile")
+				}
+				if got.NewClientID != clientID || got.IsProgramTransfer() {
+					t.Errorf("expected a transfer within the program, got %v", got)
+				}
+				if notifiedFacility != facilityID {
+					t.Errorf("expected the staff of the receiving facility to be notified")
+				}
+			}
+			if tt.name == "Happy case: transfer client to another program" {
+				if transferPayload == nil || transferPayload.Client.ProgramID != programID || transferPayload.ClientIdentifier.Value != "123456" {
+					t.Errorf("expected a client profile in the new program, got %v", transferPayload)
+				}
+				if got.NewClientID == clientID || !got.IsProgramTransfer() {
+					t.Errorf("expected a transfer to another program, got %v", got)
+				}
+				if len(got.CarriedHistory) != 1 || got.CarriedHistory[0] != enums.ClientTransferHistoryHealthDiary {
+					t.Errorf("expected the health diary to be carried, got %v", got.CarriedHistory)
+				}
+				if !got.ClientConsented {
+					t.Errorf("expected the client's consent to be recorded on the transfer")
+				}
+			}
+		})
+	}
+}
+
+func TestUseCasesUserImpl_ListClientTransfers(t *testing.T) {
+	clientID := uuid.New().String()
+
+	tests := []struct {
+		name      string
+		clientID  string
+		wantCount int
+		wantErr   bool
+	}{
+		{
+			name:      "Happy case: list client transfers",
+			clientID:  clientID,
+			wantCount: 2,
+			wantErr:   false,
+		},
+		{
+			name:     "Sad case: unable to get logged in user",
+			clientID: clientID,
+			wantErr:  true,
+		},
+		{
+			name:     "Sad case: unable to get client profile",
+			clientID: clientID,
+			wantErr:  true,
+		},
+		{
+			name:     "Sad case: client belongs to another organisation",
+			clientID: clientID,
+			wantErr:  true,
+		},
+		{
+			name:     "Sad case: unable to list client transfers",
+			clientID: clientID,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeOTP := otpMock.NewOTPUseCaseMock()
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			fakePubsub := pubsubMock.NewPubsubServiceMock()
+			fakeClinical := clinicalMock.NewClinicalServiceMock()
+			fakeSMS := smsMock.NewSMSServiceMock()
+			fakeTwilio := twilioMock.NewTwilioServiceMock()
+			fakeMatrix := matrixMock.NewMatrixMock()
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+
+			us := NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
+
+			organisationID := uuid.New().String()
+			otherOrganisationID := uuid.New().String()
+			clientProfile := &domain.ClientProfile{
+				ID:             &clientID,
+				UserID:         uuid.New().String(),
+				OrganisationID: organisationID,
+			}
+
+			fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.User, error) {
+				return &domain.User{ID: &userID, CurrentOrganizationID: organisationID}, nil
+			}
+			fakeDB.MockGetClientProfileByClientIDFn = func(ctx context.Context, clientID string) (*domain.ClientProfile, error) {
+				return clientProfile, nil
+			}
+			fakeDB.MockListClientTransfersFn = func(ctx context.Context, userID string) ([]*domain.ClientTransfer, error) {
+				return []*domain.ClientTransfer{
+					{ID: uuid.New().String(), FromOrganisationID: otherOrganisationID, ToOrganisationID: organisationID},
+					{ID: uuid.New().String(), FromOrganisationID: organisationID, ToOrganisationID: organisationID},
+					{ID: uuid.New().String(), FromOrganisationID: otherOrganisationID, ToOrganisationID: otherOrganisationID},
+				}, nil
+			}
+
+			if tt.name == "Sad case: unable to get logged in user" {
+				fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
+					return "", fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to get client profile" {
+				fakeDB.MockGetClientProfileByClientIDFn = func(ctx context.Context, clientID string) (*domain.ClientProfile, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: client belongs to another organisation" {
+				clientProfile.OrganisationID = otherOrganisationID
+			}
+			if tt.name == "Sad case: unable to list client transfers" {
+				fakeDB.MockListClientTransfersFn = func(ctx context.Context, userID string) ([]*domain.ClientTransfer, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+
+			got, err := us.ListClientTransfers(context.Background(), tt.clientID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UseCasesUserImpl.ListClientTransfers() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got) != tt.wantCount {
+				t.Errorf("expected %d transfers, got %d", tt.wantCount, len(got))
+			}
+		})
+	}
+}
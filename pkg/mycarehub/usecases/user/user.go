@@ -8,7 +8,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/cenkalti/backoff/v4"
 	"github.com/hashicorp/go-multierror"
 	"github.com/lib/pq"
 	"github.com/savannahghi/converterandformatter"
@@ -21,6 +20,7 @@ import (
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/exceptions"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/extension"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/utils"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/authorization"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/gorm"
@@ -28,8 +28,11 @@ import (
 	serviceMatrix "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/matrix"
 	pubsubmessaging "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/pubsub"
 	serviceSMS "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/sms"
+	serviceSurveys "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/surveys"
 	serviceTwilio "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/twilio"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/authority"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/common"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/notification"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/otp"
 	"github.com/savannahghi/scalarutils"
 	"github.com/savannahghi/serverutils"
@@ -110,6 +113,7 @@ type IRegisterUser interface {
 	CreateSuperUser(ctx context.Context, input dto.StaffRegistrationInput) (*dto.StaffRegistrationOutput, error)
 	RegisterOrganisationAdmin(ctx context.Context, input dto.StaffRegistrationInput) (*dto.StaffRegistrationOutput, error)
 	ClientSignUp(ctx context.Context, input *dto.ClientSelfSignUp) (*dto.ClientRegistrationOutput, error)
+	BulkRegisterClients(ctx context.Context, input *dto.BulkClientRegistrationInput) (*dto.BulkClientRegistrationOutput, error)
 }
 
 // IClientMedicalHistory interface defines method signature for dealing with medical history
@@ -171,6 +175,65 @@ type UpdateUserProfile interface {
 	UpdateOrganisationAdminPermission(ctx context.Context, staffID string, isOrganisationAdmin bool) (bool, error)
 }
 
+// ITOTP contains the methods used to manage the authenticator app a staff uses as a second factor when logging in
+type ITOTP interface {
+	EnrollTOTP(ctx context.Context) (*domain.TOTPEnrollment, error)
+	ConfirmTOTPEnrollment(ctx context.Context, code string) ([]string, error)
+	RegenerateTOTPRecoveryCodes(ctx context.Context, code string) ([]string, error)
+	DisableTOTP(ctx context.Context, code string) (bool, error)
+	GetTOTPStatus(ctx context.Context) (*domain.TOTPStatus, error)
+}
+
+// ISessions contains the methods used to list and end the sessions that a user is logged in with
+type ISessions interface {
+	ListMySessions(ctx context.Context) ([]*domain.UserSession, error)
+	RevokeSession(ctx context.Context, sessionID string) (bool, error)
+	RevokeAllOtherSessions(ctx context.Context) (bool, error)
+	ForceLogoutUser(ctx context.Context, userID string) (bool, error)
+}
+
+// IClientDuplicates contains the methods used to find clients that were registered more than once and merge their records
+type IClientDuplicates interface {
+	FindDuplicateClients(ctx context.Context, clientID string) ([]*domain.DuplicateClient, error)
+	MergeClients(ctx context.Context, survivingClientID string, duplicateClientID string) (*domain.ClientMerge, error)
+}
+
+// IClientDataExport contains the method used to export all the data held about a client
+type IClientDataExport interface {
+	ExportClientData(ctx context.Context, input *dto.ClientDataExportInput) (*dto.ClientDataExport, error)
+}
+
+// IClientErasure contains the method used to erase the clients who asked for their data to be erased
+type IClientErasure interface {
+	PurgeClientsDueForErasure(ctx context.Context) (int, error)
+}
+
+// ICaregiverDelegation contains the methods used to end a caregiver's access to a client
+type ICaregiverDelegation interface {
+	RevokeCaregiverDelegation(ctx context.Context, clientID string, caregiverID string) (bool, error)
+	EndClientCaregiverAccess(ctx context.Context, clientID string, endDate scalarutils.Date) (int, error)
+	ExpireCaregiverDelegations(ctx context.Context) (int, error)
+}
+
+// IPhoneChange contains the methods used to change a user's phone number after verifying the new phone number
+type IPhoneChange interface {
+	RequestPhoneChange(ctx context.Context, phoneNumber string, flavour feedlib.Flavour) (bool, error)
+	ConfirmPhoneChange(ctx context.Context, phoneNumber string, otp string, flavour feedlib.Flavour) (bool, error)
+	RequestAssistedPhoneChange(ctx context.Context, userID string, phoneNumber string, flavour feedlib.Flavour, reason string) (bool, error)
+	ConfirmAssistedPhoneChange(ctx context.Context, userID string, phoneNumber string, otp string, flavour feedlib.Flavour) (bool, error)
+}
+
+// IStaffOffboarding contains the method used to remove a staff who has left an organisation
+type IStaffOffboarding interface {
+	OffboardStaff(ctx context.Context, input *dto.StaffOffboardingInput) (*domain.StaffOffboarding, error)
+}
+
+// IClientTransfer contains the methods used to transfer a client between facilities, programs and organisations
+type IClientTransfer interface {
+	TransferClient(ctx context.Context, input *dto.ClientTransferInput) (*domain.ClientTransfer, error)
+	ListClientTransfers(ctx context.Context, clientID string) ([]*domain.ClientTransfer, error)
+}
+
 // UseCasesUser group all business logic usecases related to user
 type UseCasesUser interface {
 	ILogin
@@ -193,22 +256,33 @@ type UseCasesUser interface {
 	ISearchCaregiverUser
 	ICaregiversClients
 	UpdateUserProfile
+	ITOTP
+	ISessions
+	IClientDuplicates
+	IClientDataExport
+	IClientErasure
+	ICaregiverDelegation
+	IPhoneChange
+	IStaffOffboarding
+	IClientTransfer
 }
 
 // UseCasesUserImpl represents user implementation object
 type UseCasesUserImpl struct {
-	Create      infrastructure.Create
-	Query       infrastructure.Query
-	Delete      infrastructure.Delete
-	Update      infrastructure.Update
-	ExternalExt extension.ExternalMethodsExtension
-	OTP         otp.UsecaseOTP
-	Authority   authority.UsecaseAuthority
-	Pubsub      pubsubmessaging.ServicePubsub
-	Clinical    clinical.IServiceClinical
-	SMS         serviceSMS.IServiceSMS
-	Twilio      serviceTwilio.ITwilioService
-	Matrix      serviceMatrix.Matrix
+	Create       infrastructure.Create
+	Query        infrastructure.Query
+	Delete       infrastructure.Delete
+	Update       infrastructure.Update
+	ExternalExt  extension.ExternalMethodsExtension
+	OTP          otp.UsecaseOTP
+	Authority    authority.UsecaseAuthority
+	Pubsub       pubsubmessaging.ServicePubsub
+	Clinical     clinical.IServiceClinical
+	SMS          serviceSMS.IServiceSMS
+	Twilio       serviceTwilio.ITwilioService
+	Matrix       serviceMatrix.Matrix
+	Notification notification.UseCaseNotification
+	Surveys      serviceSurveys.Surveys
 }
 
 // NewUseCasesUserImpl returns a new user service
@@ -225,20 +299,24 @@ func NewUseCasesUserImpl(
 	sms serviceSMS.IServiceSMS,
 	twilio serviceTwilio.ITwilioService,
 	matrix serviceMatrix.Matrix,
+	notification notification.UseCaseNotification,
+	surveys serviceSurveys.Surveys,
 ) *UseCasesUserImpl {
 	return &UseCasesUserImpl{
-		Create:      create,
-		Query:       query,
-		Delete:      delete,
-		Update:      update,
-		ExternalExt: externalExt,
-		OTP:         otp,
-		Authority:   authority,
-		Pubsub:      pubsub,
-		Clinical:    clinical,
-		SMS:         sms,
-		Twilio:      twilio,
-		Matrix:      matrix,
+		Create:       create,
+		Query:        query,
+		Delete:       delete,
+		Update:       update,
+		ExternalExt:  externalExt,
+		OTP:          otp,
+		Authority:    authority,
+		Pubsub:       pubsub,
+		Clinical:     clinical,
+		SMS:          sms,
+		Twilio:       twilio,
+		Matrix:       matrix,
+		Notification: notification,
+		Surveys:      surveys,
 	}
 }
 
@@ -275,6 +353,8 @@ func (us *UseCasesUserImpl) Login(ctx context.Context, input *dto.LoginInput) (*
 		us.pinResetRequestCheck,
 		us.loginTimeoutCheck,
 		us.checkPIN,
+		us.checkTOTP,
+		us.checkLoginRisk,
 		us.addRolesPermissions,
 	}
 
@@ -422,10 +502,10 @@ func (us *UseCasesUserImpl) SetUserPIN(ctx context.Context, input dto.PINInput)
 		return false, exceptions.UserNotFoundError(fmt.Errorf("failed to get a user profile by phonenumber: %v", err))
 	}
 
-	err = utils.ValidatePIN(*input.PIN)
+	pinPolicy, err := us.validatePIN(ctx, userProfile.CurrentOrganizationID, *input.PIN)
 	if err != nil {
 		helpers.ReportErrorToSentry(err)
-		return false, exceptions.ValidatePINDigitsErr(err)
+		return false, err
 	}
 
 	salt, encryptedPIN := utils.EncryptPIN(*input.PIN, nil)
@@ -435,6 +515,11 @@ func (us *UseCasesUserImpl) SetUserPIN(ctx context.Context, input dto.PINInput)
 		return false, exceptions.PinMismatchError()
 	}
 
+	if err := us.checkPINReuse(ctx, *userProfile.ID, *input.PIN, pinPolicy); err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, err
+	}
+
 	expiryDate, err := helpers.GetPinExpiryDate()
 	if err != nil {
 		helpers.ReportErrorToSentry(err)
@@ -599,14 +684,10 @@ func (us *UseCasesUserImpl) ResetPIN(ctx context.Context, input dto.UserResetPin
 		return false, exceptions.InvalidFlavourDefinedErr(fmt.Errorf("flavour is not valid"))
 	}
 
-	_, err = strconv.ParseInt(input.PIN, 10, 64)
+	pinPolicy, err := us.validatePIN(ctx, userProfile.CurrentOrganizationID, input.PIN)
 	if err != nil {
 		helpers.ReportErrorToSentry(err)
-		return false, exceptions.PINErr(fmt.Errorf("PIN should be a number: %v", err))
-	}
-
-	if len([]byte(input.PIN)) != 4 {
-		return false, exceptions.PINErr(fmt.Errorf("PIN length be 4 digits: %v", err))
+		return false, err
 	}
 
 	ok, err = us.Query.VerifyOTP(ctx, &dto.VerifyOTPInput{
@@ -623,6 +704,11 @@ func (us *UseCasesUserImpl) ResetPIN(ctx context.Context, input dto.UserResetPin
 		return false, exceptions.InternalErr(fmt.Errorf("failed to verify otp: %v", err))
 	}
 
+	if err := us.checkPINReuse(ctx, *userProfile.ID, input.PIN, pinPolicy); err != nil {
+		helpers.ReportErrorToSentry(err)
+		return false, err
+	}
+
 	salt, encryptedPin := utils.EncryptPIN(input.PIN, nil)
 	expiryDate, err := helpers.GetPinExpiryDate()
 	if err != nil {
@@ -657,9 +743,54 @@ func (us *UseCasesUserImpl) ResetPIN(ctx context.Context, input dto.UserResetPin
 		return false, exceptions.ResetPinErr(err)
 	}
 
+	// the user is not logged in so the organisation cannot be read from the context
+	programID, organisationID := us.userProgramOrganisation(ctx, userProfile, input.Flavour)
+	common.RecordAuditLog(ctx, us.Create, &domain.AuditLog{
+		RecordType:     enums.AuditLogPINReset,
+		Notes:          fmt.Sprintf("%s PIN reset", input.Flavour),
+		ActorID:        *userProfile.ID,
+		TargetID:       *userProfile.ID,
+		TargetType:     enums.AuditLogTargetUser,
+		ProgramID:      programID,
+		OrganisationID: organisationID,
+	})
+
 	return true, nil
 }
 
+// userProgramOrganisation resolves the program and organisation of a user from their profile. The user's client or staff profile
+// is used when they have not picked a current program, such as before they log in for the first time
+func (us *UseCasesUserImpl) userProgramOrganisation(ctx context.Context, userProfile *domain.User, flavour feedlib.Flavour) (string, string) {
+	if userProfile.CurrentProgramID != "" && userProfile.CurrentOrganizationID != "" {
+		return userProfile.CurrentProgramID, userProfile.CurrentOrganizationID
+	}
+
+	switch flavour {
+	case feedlib.FlavourConsumer:
+		clientProfiles, err := us.Query.GetUserClientProfiles(ctx, *userProfile.ID)
+		if err != nil {
+			helpers.ReportErrorToSentry(fmt.Errorf("failed to get client profiles of user %s: %w", *userProfile.ID, err))
+			return "", ""
+		}
+		if len(clientProfiles) > 0 {
+			return clientProfiles[0].ProgramID, clientProfiles[0].OrganisationID
+		}
+
+	case feedlib.FlavourPro:
+		staffProfiles, err := us.Query.GetUserStaffProfiles(ctx, *userProfile.ID)
+		if err != nil {
+			helpers.ReportErrorToSentry(fmt.Errorf("failed to get staff profiles of user %s: %w", *userProfile.ID, err))
+			return "", ""
+		}
+		if len(staffProfiles) > 0 {
+			return staffProfiles[0].ProgramID, staffProfiles[0].OrganisationID
+		}
+	}
+
+	helpers.ReportErrorToSentry(fmt.Errorf("unable to resolve the organisation of user %s", *userProfile.ID))
+	return "", ""
+}
+
 // RefreshToken takes a user ID and creates a custom Firebase refresh token. It then tries to fetch
 // an ID token and returns auth credentials if successful
 func (us *UseCasesUserImpl) RefreshToken(ctx context.Context, userID string) (*dto.AuthCredentials, error) {
@@ -913,6 +1044,11 @@ func (us *UseCasesUserImpl) Register(ctx context.Context, payload *dto.SignUpPay
 			return nil, err
 		}
 
+		err = us.assignDefaultRole(ctx, enums.ClientUser, *registeredClient.ID, defaultProgramID, authorization.DefaultRoleClient)
+		if err != nil {
+			return nil, err
+		}
+
 		payload := &dto.PatientCreationOutput{
 			UserID:         registeredClient.UserID,
 			ClientID:       *registeredClient.ID,
@@ -942,6 +1078,7 @@ func (us *UseCasesUserImpl) Register(ctx context.Context, payload *dto.SignUpPay
 			},
 			OrganisationID: registeredClient.OrganisationID,
 			ProgramID:      registeredClient.ProgramID,
+			PhoneNumber:    *normalized,
 		}
 
 		err = us.Pubsub.NotifyCreateCMSClient(ctx, cmsUserPayload)
@@ -1029,6 +1166,11 @@ func (us *UseCasesUserImpl) Register(ctx context.Context, payload *dto.SignUpPay
 		return nil, err
 	}
 
+	err = us.assignDefaultRole(ctx, enums.ClientUser, *registeredClient.ID, registeredClient.ProgramID, authorization.DefaultRoleClient)
+	if err != nil {
+		return nil, err
+	}
+
 	patient := &dto.PatientCreationOutput{
 		UserID:         registeredClient.UserID,
 		ClientID:       *registeredClient.ID,
@@ -1057,6 +1199,7 @@ func (us *UseCasesUserImpl) Register(ctx context.Context, payload *dto.SignUpPay
 		},
 		OrganisationID: registeredClient.OrganisationID,
 		ProgramID:      registeredClient.ProgramID,
+		PhoneNumber:    *normalized,
 	}
 
 	err = us.Pubsub.NotifyCreateCMSClient(ctx, cmsUserPayload)
@@ -1195,6 +1338,16 @@ func (us *UseCasesUserImpl) RegisterExistingUserAsClient(ctx context.Context, in
 		return nil, err
 	}
 
+	err = us.assignDefaultRole(ctx, enums.ClientUser, *registeredClient.ID, program.ID, authorization.DefaultRoleClient)
+	if err != nil {
+		return nil, err
+	}
+
+	normalized, err := converterandformatter.NormalizeMSISDN(registeredClient.User.Contacts.ContactValue)
+	if err != nil {
+		return nil, err
+	}
+
 	cmsUserPayload := &dto.PubsubCreateCMSClientPayload{
 		ClientID: *registeredClient.ID,
 		Name:     registeredClient.User.Name,
@@ -1206,6 +1359,7 @@ func (us *UseCasesUserImpl) RegisterExistingUserAsClient(ctx context.Context, in
 		},
 		OrganisationID: registeredClient.OrganisationID,
 		ProgramID:      registeredClient.ProgramID,
+		PhoneNumber:    *normalized,
 	}
 
 	err = us.Pubsub.NotifyCreateCMSClient(ctx, cmsUserPayload)
@@ -1214,11 +1368,6 @@ func (us *UseCasesUserImpl) RegisterExistingUserAsClient(ctx context.Context, in
 		log.Printf("failed to publish client profile to cms: %v", err)
 	}
 
-	normalized, err := converterandformatter.NormalizeMSISDN(registeredClient.User.Contacts.ContactValue)
-	if err != nil {
-		return nil, err
-	}
-
 	payload := &dto.PatientCreationOutput{
 		UserID:         registeredClient.UserID,
 		ClientID:       *registeredClient.ID,
@@ -1328,6 +1477,11 @@ func (us *UseCasesUserImpl) RegisterCaregiver(ctx context.Context, input dto.Car
 		return nil, err
 	}
 
+	err = us.assignDefaultRole(ctx, enums.CaregiverUser, profile.ID, loggedInUser.CurrentProgramID, authorization.DefaultRoleCaregiver)
+	if err != nil {
+		return nil, err
+	}
+
 	if input.SendInvite {
 		_, err := us.InviteUser(ctx, *profile.User.ID, input.PhoneNumber, feedlib.FlavourConsumer, false)
 		if err != nil {
@@ -1357,6 +1511,9 @@ func (us *UseCasesUserImpl) RegisterCaregiver(ctx context.Context, input dto.Car
 				CaregiverID:   profile.ID,
 				CaregiverType: client.CaregiverType,
 				Consent:       client.Consent,
+				StartsAt:      client.StartsAt,
+				EndsAt:        client.EndsAt,
+				Abilities:     client.Abilities,
 			})
 			if err != nil {
 				helpers.ReportErrorToSentry(err)
@@ -1401,6 +1558,11 @@ func (us *UseCasesUserImpl) RegisterExistingUserAsCaregiver(ctx context.Context,
 		return nil, err
 	}
 
+	err = us.assignDefaultRole(ctx, enums.CaregiverUser, caregiverProfile.ID, loggedInUserProfile.CurrentProgramID, authorization.DefaultRoleCaregiver)
+	if err != nil {
+		return nil, err
+	}
+
 	return caregiverProfile, nil
 
 }
@@ -1426,6 +1588,11 @@ func (us *UseCasesUserImpl) RegisterClientAsCaregiver(ctx context.Context, clien
 		return nil, fmt.Errorf("failed to create caregiver: %w", err)
 	}
 
+	err = us.assignDefaultRole(ctx, enums.CaregiverUser, caregiver.ID, client.ProgramID, authorization.DefaultRoleCaregiver)
+	if err != nil {
+		return nil, err
+	}
+
 	return &domain.CaregiverProfile{
 		ID:              caregiver.ID,
 		User:            *client.User,
@@ -1613,6 +1780,16 @@ func (us *UseCasesUserImpl) RegisterStaffProfile(ctx context.Context, input dto.
 		return nil, fmt.Errorf("unable to register staff: %w", err)
 	}
 
+	defaultRole := authorization.DefaultRoleStaff
+	if input.IsOrganisationAdmin {
+		defaultRole = authorization.DefaultRoleAdmin
+	}
+
+	err = us.assignDefaultRole(ctx, enums.StaffUser, *staff.ID, input.ProgramID, defaultRole)
+	if err != nil {
+		return nil, err
+	}
+
 	if input.InviteStaff {
 		_, err := us.InviteUser(ctx, staff.UserID, input.PhoneNumber, feedlib.FlavourPro, false)
 		if err != nil {
@@ -1630,6 +1807,23 @@ func (us *UseCasesUserImpl) RegisterStaffProfile(ctx context.Context, input dto.
 	}, nil
 }
 
+// assignDefaultRole assigns a program's default role to a newly created staff, client or caregiver profile
+func (us *UseCasesUserImpl) assignDefaultRole(ctx context.Context, userType enums.UsersType, profileID string, programID string, defaultRole authorization.DefaultRole) error {
+	role, err := us.Query.GetAuthorityRoleByName(ctx, programID, defaultRole.String())
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return fmt.Errorf("unable to get the %s role: %w", defaultRole, err)
+	}
+
+	err = us.Create.AssignRoles(ctx, userType, profileID, []string{role.AuthorityRoleID})
+	if err != nil {
+		helpers.ReportErrorToSentry(err)
+		return fmt.Errorf("unable to assign the %s role: %w", defaultRole, err)
+	}
+
+	return nil
+}
+
 // RegisterStaff is used to register a staff user in mycarehub
 func (us *UseCasesUserImpl) RegisterStaff(ctx context.Context, input dto.StaffRegistrationInput) (*dto.StaffRegistrationOutput, error) {
 	ctx, span := tracer.Start(ctx, "RegisterStaff")
@@ -1823,6 +2017,11 @@ func (us *UseCasesUserImpl) RegisterExistingUserAsStaff(ctx context.Context, inp
 		return nil, fmt.Errorf("unable to register staff: %w", err)
 	}
 
+	err = us.assignDefaultRole(ctx, enums.StaffUser, *staff.ID, program.ID, authorization.DefaultRoleStaff)
+	if err != nil {
+		return nil, err
+	}
+
 	return &dto.StaffRegistrationOutput{
 		ID:                  *staff.ID,
 		Active:              staff.Active,
@@ -1922,6 +2121,17 @@ func (us *UseCasesUserImpl) RegisterPushToken(ctx context.Context, token string)
 		return false, fmt.Errorf("failed to update user push token")
 	}
 
+	// the push token is removed from the user's profile when the session it was registered on is revoked
+	sessionID, err := utils.GetValueFromContext(ctx, utils.SessionIDContextKey)
+	if err == nil {
+		err = us.Update.UpdateSession(ctx, &domain.Session{ID: sessionID}, map[string]interface{}{
+			"push_token": token,
+		})
+		if err != nil {
+			helpers.ReportErrorToSentry(fmt.Errorf("failed to record session push token: %w", err))
+		}
+	}
+
 	return true, nil
 }
 
@@ -2004,6 +2214,18 @@ func (us *UseCasesUserImpl) TransferClientToFacility(ctx context.Context, client
 			return false, exceptions.InternalErr(err)
 		}
 	}
+
+	common.RecordAuditLog(ctx, us.Create, &domain.AuditLog{
+		RecordType:     enums.AuditLogClientFacilityTransfer,
+		Notes:          "client transferred to a new facility",
+		TargetID:       *clientID,
+		TargetType:     enums.AuditLogTargetClient,
+		ProgramID:      clientProfile.ProgramID,
+		OrganisationID: clientProfile.OrganisationID,
+		Before:         map[string]interface{}{"current_facility_id": currentClientFacilityID},
+		After:          map[string]interface{}{"current_facility_id": *facilityID},
+	})
+
 	return true, nil
 }
 
@@ -2312,6 +2534,11 @@ func (us *UseCasesUserImpl) AssignCaregiver(ctx context.Context, input dto.Clien
 
 	now := time.Now()
 
+	startsAt, endsAt, abilities, err := caregiverDelegation(ctx, input, now)
+	if err != nil {
+		return false, exceptions.InputValidationErr(err)
+	}
+
 	caregiver := &domain.CaregiverClient{
 		CaregiverID:        input.CaregiverID,
 		ClientID:           input.ClientID,
@@ -2323,6 +2550,9 @@ func (us *UseCasesUserImpl) AssignCaregiver(ctx context.Context, input dto.Clien
 		CaregiverConsentAt: &now,
 		ClientConsent:      input.Consent,
 		ClientConsentAt:    &now,
+		StartsAt:           startsAt,
+		EndsAt:             endsAt,
+		Abilities:          abilities,
 	}
 
 	err = us.Create.AddCaregiverToClient(ctx, caregiver)
@@ -2331,6 +2561,11 @@ func (us *UseCasesUserImpl) AssignCaregiver(ctx context.Context, input dto.Clien
 		return false, fmt.Errorf("failed to add caregiver to client: %w", err)
 	}
 
+	err = us.assignDefaultRole(ctx, enums.CaregiverUser, input.CaregiverID, userProfile.CurrentProgramID, authorization.DefaultRoleCaregiver)
+	if err != nil {
+		return false, err
+	}
+
 	return true, nil
 }
 
@@ -2454,6 +2689,14 @@ func (us *UseCasesUserImpl) ConsentToAClientCaregiver(ctx context.Context, clien
 		return false, fmt.Errorf("failed to update client consent: %w", err)
 	}
 
+	common.RecordAuditLog(ctx, us.Create, &domain.AuditLog{
+		RecordType: enums.AuditLogCaregiverConsentChange,
+		Notes:      fmt.Sprintf("client consent to caregiver %s", caregiverID),
+		TargetID:   clientID,
+		TargetType: enums.AuditLogTargetClient,
+		After:      map[string]interface{}{"client_consent": consent.String(), "caregiver_id": caregiverID},
+	})
+
 	return true, nil
 }
 
@@ -2477,6 +2720,14 @@ func (us *UseCasesUserImpl) ConsentToManagingClient(ctx context.Context, caregiv
 		return false, err
 	}
 
+	common.RecordAuditLog(ctx, us.Create, &domain.AuditLog{
+		RecordType: enums.AuditLogCaregiverConsentChange,
+		Notes:      fmt.Sprintf("caregiver consent to managing client %s", clientID),
+		TargetID:   caregiverID,
+		TargetType: enums.AuditLogTargetCaregiver,
+		After:      map[string]interface{}{"caregiver_consent": consent.String(), "client_id": clientID},
+	})
+
 	return true, nil
 }
 
@@ -2660,6 +2911,12 @@ func (us *UseCasesUserImpl) SetCaregiverCurrentClient(ctx context.Context, clien
 		return nil, err
 	}
 
+	if !caregiversClients[0].InEffect(time.Now()) {
+		err := fmt.Errorf("caregiver %v is not allowed to act on behalf of client %v at this time", caregiverProfile.ID, clientID)
+		helpers.ReportErrorToSentry(err)
+		return nil, err
+	}
+
 	caregiverProfile.Consent.ConsentStatus = caregiversClients[0].ClientConsent
 
 	if caregiverProfile.Consent.ConsentStatus != enums.ConsentStateAccepted {
@@ -2731,6 +2988,7 @@ func (us *UseCasesUserImpl) SetCaregiverCurrentFacility(ctx context.Context, cli
 }
 
 // UpdateUserProfile is used to update a user's informmation such as username, phone and CCC number(on need basis)
+// A new phone number only replaces the user's phone number once the OTP sent to it is confirmed with ConfirmAssistedPhoneChange
 func (us *UseCasesUserImpl) UpdateUserProfile(ctx context.Context, userID string, cccNumber *string, username *string, phoneNumber *string, programID string, flavour feedlib.Flavour, email *string) (bool, error) {
 	ctx, span := tracer.Start(ctx, "UpdateUserProfile")
 	defer span.End()
@@ -2761,12 +3019,6 @@ func (us *UseCasesUserImpl) UpdateUserProfile(ctx context.Context, userID string
 			return false, fmt.Errorf("failed to get client profile: %w", err)
 		}
 
-		phone, err := us.Query.GetContactByUserID(ctx, &userID, "PHONE")
-		if err != nil {
-			helpers.ReportErrorToSentry(fmt.Errorf("failed to get user contact: %w", err))
-			return false, fmt.Errorf("failed to get user contact: %w", err)
-		}
-
 		if cccNumber != nil {
 			err := us.Update.UpdateClientIdentifier(ctx, *clientProfile.ID, "CCC", *cccNumber, clientProfile.ProgramID)
 			if err != nil {
@@ -2784,78 +3036,20 @@ func (us *UseCasesUserImpl) UpdateUserProfile(ctx context.Context, userID string
 			}
 		}
 
+		// the phone number is only changed once the OTP sent to the new phone number is confirmed
 		if phoneNumber != nil {
-			currentClientPhoneNumber := phone.ContactValue
-			contact := &domain.Contact{
-				ContactType:  "PHONE",
-				ContactValue: currentClientPhoneNumber,
-				UserID:       &userID,
-			}
-
-			updateData := map[string]interface{}{
-				"contact_value": phoneNumber,
-			}
-			err := us.Update.UpdateUserContact(ctx, contact, updateData)
-			if err != nil {
-				helpers.ReportErrorToSentry(fmt.Errorf("failed to update user contact: %w", err))
-				return false, fmt.Errorf("failed to update user contact: %w", err)
-			}
-
-			_, err = us.OTP.VerifyPhoneNumber(ctx, userProfile.Username, feedlib.FlavourConsumer)
-			if err != nil {
-				helpers.ReportErrorToSentry(fmt.Errorf("failed to verify phone number: %w", err))
-				return false, fmt.Errorf("failed to verify phone number: %w", err)
-			}
-
-			user := &domain.User{ID: &userID}
-			err = us.Update.UpdateUser(ctx, user, map[string]interface{}{
-				"is_phone_verified": false,
-				"has_set_pin":       false,
-			})
+			err := us.requestAssistedPhoneChange(ctx, loggedInStaff, userProfile, *phoneNumber, feedlib.FlavourConsumer, profilePhoneChangeReason)
 			if err != nil {
-				helpers.ReportErrorToSentry(fmt.Errorf("failed to update client user profile: %w", err))
-				return false, fmt.Errorf("failed to update client user profile: %w", err)
+				return false, err
 			}
-
 		}
 
 	case feedlib.FlavourPro:
-		phone, err := us.Query.GetContactByUserID(ctx, userProfile.ID, "PHONE")
-		if err != nil {
-			helpers.ReportErrorToSentry(fmt.Errorf("failed to get user contact: %w", err))
-			return false, fmt.Errorf("failed to get user contact: %w", err)
-		}
-
+		// the phone number is only changed once the OTP sent to the new phone number is confirmed
 		if phoneNumber != nil {
-			contact := &domain.Contact{
-				ContactType:  "PHONE",
-				ContactValue: phone.ContactValue,
-				UserID:       &userID,
-			}
-
-			updateData := map[string]interface{}{
-				"contact_value": phoneNumber,
-			}
-			err := us.Update.UpdateUserContact(ctx, contact, updateData)
-			if err != nil {
-				helpers.ReportErrorToSentry(fmt.Errorf("failed to update user contact: %w", err))
-				return false, fmt.Errorf("failed to update user contact: %w", err)
-			}
-
-			_, err = us.OTP.VerifyPhoneNumber(ctx, userProfile.Username, feedlib.FlavourPro)
+			err := us.requestAssistedPhoneChange(ctx, loggedInStaff, userProfile, *phoneNumber, feedlib.FlavourPro, profilePhoneChangeReason)
 			if err != nil {
-				helpers.ReportErrorToSentry(fmt.Errorf("failed to verify phone: %w", err))
-				return false, fmt.Errorf("failed to verify phone: %w", err)
-			}
-
-			user := &domain.User{ID: &userID}
-			err = us.Update.UpdateUser(ctx, user, map[string]interface{}{
-				"is_phone_verified": false,
-				"has_set_pin":       false,
-			})
-			if err != nil {
-				helpers.ReportErrorToSentry(fmt.Errorf("failed to update staff user profile: %w", err))
-				return false, fmt.Errorf("failed to update staff user profile: %w", err)
+				return false, err
 			}
 		}
 
@@ -3010,15 +3204,10 @@ func (us *UseCasesUserImpl) CreateSuperUser(ctx context.Context, input dto.Staff
 		return nil, err
 	}
 
-	// UpdateRoles is used to update the roles the superuser to have the system admin role
-	// TODO: update after implementing RBAC
-	// var staffRoles []enums.UserRoleType
-	// staffRoles = append(staffRoles, enums.UserRoleTypeSystemAdministrator)
-	// _, err = us.Update.AssignRoles(ctx, staff.UserID, staffRoles)
-	// if err != nil {
-	// 	helpers.ReportErrorToSentry(fmt.Errorf("%w", err))
-	// 	return nil, fmt.Errorf("unable to assign roles: %w", err)
-	// }
+	err = us.assignDefaultRole(ctx, enums.StaffUser, *staff.ID, input.ProgramID, authorization.DefaultRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
 
 	if input.InviteStaff {
 		_, err := us.InviteUser(ctx, staff.UserID, input.PhoneNumber, feedlib.FlavourPro, false)
@@ -3095,17 +3284,31 @@ func (us *UseCasesUserImpl) UpdateOrganisationAdminPermission(ctx context.Contex
 		return false, fmt.Errorf("failed to get staff profile by staff id: %w", err)
 	}
 
+	wasOrganisationAdmin := staffProfile.IsOrganisationAdmin
+
 	err = us.Update.UpdateStaff(ctx, staffProfile, map[string]interface{}{"is_organisation_admin": isOrganisationAdmin})
 	if err != nil {
 		helpers.ReportErrorToSentry(fmt.Errorf("failed to update staff profile: %w", err))
 		return false, fmt.Errorf("failed to update staff profile: %w", err)
 	}
 
+	common.RecordAuditLog(ctx, us.Create, &domain.AuditLog{
+		RecordType:     enums.AuditLogOrganisationAdminChange,
+		Notes:          "organisation admin permission updated",
+		TargetID:       staffID,
+		TargetType:     enums.AuditLogTargetStaff,
+		ProgramID:      staffProfile.ProgramID,
+		OrganisationID: staffProfile.OrganisationID,
+		Before:         map[string]interface{}{"is_organisation_admin": wasOrganisationAdmin},
+		After:          map[string]interface{}{"is_organisation_admin": isOrganisationAdmin},
+	})
+
 	return true, nil
 }
 
 // DeleteClientProfile gives the client an option to choose to withdraw from the app by withdrawing their consent.
-// their client profile will be deleted
+// The client is deactivated and marked for erasure. Their personal information is anonymized and removed from the CMS, Matrix and
+// the clinical service by the purge job once their organisation's data retention period has passed
 func (us *UseCasesUserImpl) DeleteClientProfile(ctx context.Context, clientID string) (bool, error) {
 	ctx, span := tracer.Start(ctx, "DeleteClientProfile")
 	defer span.End()
@@ -3116,88 +3319,39 @@ func (us *UseCasesUserImpl) DeleteClientProfile(ctx context.Context, clientID st
 		return false, err
 	}
 
-	var userID *string
-
-	clientProfiles, err := us.Query.GetUserClientProfiles(ctx, *clientProfile.User.ID)
-	if err != nil {
-		helpers.ReportErrorToSentry(err)
-		return false, err
-	}
-
-	staffProfiles, err := us.Query.GetUserStaffProfiles(ctx, *clientProfile.User.ID)
-	if err != nil {
-		helpers.ReportErrorToSentry(err)
-		return false, err
+	if clientProfile.ErasureRequestedAt != nil {
+		return true, nil
 	}
 
-	caregiverProfiles, err := us.Query.SearchCaregiverUser(ctx, clientProfile.User.Username)
+	userID, err := us.erasableUserID(ctx, clientProfile)
 	if err != nil {
 		helpers.ReportErrorToSentry(err)
 		return false, err
 	}
 
-	if len(clientProfiles) == 1 && len(staffProfiles) == 0 && len(caregiverProfiles) == 0 {
-		userID = clientProfile.User.ID
-	}
+	requestedAt := time.Now()
 
-	phone, err := us.Query.GetContactByUserID(ctx, clientProfile.User.ID, "PHONE")
+	err = us.Update.MarkClientForErasure(ctx, *clientProfile.ID, userID, requestedAt)
 	if err != nil {
 		helpers.ReportErrorToSentry(err)
-		return false, exceptions.ContactNotFoundErr(err)
-	}
-
-	go func() {
-		timeoutContext, cancel := context.WithTimeout(context.Background(), time.Duration(time.Minute*10))
-		defer cancel()
-
-		backOff := backoff.WithContext(backoff.NewExponentialBackOff(), timeoutContext)
-		deletePatientProfile := func() error {
-			err = us.Clinical.DeleteFHIRPatientByPhone(ctx, phone.ContactValue)
-			if err != nil {
-				helpers.ReportErrorToSentry(err)
-				return fmt.Errorf("error deleting FHIR patient profile: %w", err)
-			}
-			return nil
-		}
-		if err := backoff.Retry(
-			deletePatientProfile,
-			backOff,
-		); err != nil {
-			helpers.ReportErrorToSentry(err)
-			return
-		}
-	}()
-
-	deleteCMSClientPayload := &dto.DeleteCMSUserPayload{
-		UserID: *clientProfile.User.ID,
+		return false, fmt.Errorf("failed to opt-out from the platform: %w", err)
 	}
 
-	err = us.Pubsub.NotifyDeleteCMSClient(ctx, deleteCMSClientPayload)
-	if err != nil {
-		helpers.ReportErrorToSentry(err)
-		log.Printf("error notifying delete cms client: %v", err)
+	dataRetentionDays := 0
+	if clientProfile.Organisation != nil {
+		dataRetentionDays = clientProfile.Organisation.DataRetentionDays
 	}
 
-	if userID != nil {
-		auth := &domain.MatrixAuth{
-			Username: serverutils.MustGetEnvVar("MCH_MATRIX_USER"),
-			Password: serverutils.MustGetEnvVar("MCH_MATRIX_PASSWORD"),
-		}
-
-		matrixUserID := fmt.Sprintf("@%s:%s", clientProfile.User.Username, serverutils.MustGetEnvVar("MATRIX_DOMAIN"))
-
-		err = us.Matrix.DeactivateUser(ctx, matrixUserID, auth)
-		if err != nil {
-			helpers.ReportErrorToSentry(err)
-			return false, err
-		}
-	}
-
-	err = us.Delete.DeleteClientProfile(ctx, *clientProfile.ID, userID)
-	if err != nil {
-		helpers.ReportErrorToSentry(err)
-		return false, fmt.Errorf("failed to opt-out from the platform: %w", err)
-	}
+	common.RecordAuditLog(ctx, us.Create, &domain.AuditLog{
+		RecordType:     enums.AuditLogClientProfileDeletion,
+		Notes:          "client profile marked for erasure",
+		TargetID:       clientID,
+		TargetType:     enums.AuditLogTargetClient,
+		ProgramID:      clientProfile.ProgramID,
+		OrganisationID: clientProfile.OrganisationID,
+		Before:         map[string]interface{}{"user_id": clientProfile.UserID, "user_deleted": userID != nil},
+		After:          map[string]interface{}{"erasure_due_at": requestedAt.AddDate(0, 0, dataRetentionDays)},
+	})
 
 	return true, nil
 }
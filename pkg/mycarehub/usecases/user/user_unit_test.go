@@ -1,14 +1,10 @@
 package user_test
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"math"
-	"net/http"
 	"os"
 	"reflect"
 	"strconv"
@@ -25,6 +21,7 @@ import (
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/enums"
 	extensionMock "github.com/savannahghi/mycarehub/pkg/mycarehub/application/extension/mock"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/application/utils"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/authorization"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/domain"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/gorm"
 	pgMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/database/postgres/mock"
@@ -32,12 +29,14 @@ import (
 	matrixMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/matrix/mock"
 	pubsubMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/pubsub/mock"
 	smsMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/sms/mock"
+	surveysMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/surveys/mock"
 	twilioMock "github.com/savannahghi/mycarehub/pkg/mycarehub/infrastructure/services/twilio/mock"
+	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/authority"
 	authorityMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/authority/mock"
+	notificationMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/notification/mock"
 	otpMock "github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/otp/mock"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/user"
 	"github.com/savannahghi/mycarehub/pkg/mycarehub/usecases/user/mock"
-	"github.com/savannahghi/profileutils"
 	"github.com/savannahghi/scalarutils"
 	"github.com/savannahghi/silcomms"
 	"github.com/segmentio/ksuid"
@@ -269,7 +268,9 @@ func TestUseCasesUserImpl_Login_Unittest(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
-			u := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			u := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "Happy case: consumer login" {
 				currentTime := time.Now()
@@ -669,8 +670,10 @@ func TestUnit_InviteUser(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
 
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "valid: valid phone number" {
 				fakeUserMock.MockInviteUserFn = func(ctx context.Context, userID string, phoneNumber string, flavour feedlib.Flavour, reinvite bool) (bool, error) {
@@ -791,7 +794,8 @@ func TestUnit_InviteUser(t *testing.T) {
 func TestUseCasesUserImpl_SetUserPIN(t *testing.T) {
 	ctx := context.Background()
 	UserID := ksuid.New().String()
-	PIN := "1234"
+	PIN := "4826"
+	simplePIN := "1234"
 	longPIN := "12345"
 	shortPIN := "123"
 	tooLongPIN := strconv.Itoa(int(math.Pow(10, 6)))
@@ -894,6 +898,62 @@ func TestUseCasesUserImpl_SetUserPIN(t *testing.T) {
 			want:    false,
 			wantErr: true,
 		},
+		{
+			name: "invalid: simple pin",
+			args: args{
+				ctx: ctx,
+				input: dto.PINInput{
+					UserID:     &UserID,
+					PIN:        &simplePIN,
+					ConfirmPIN: &simplePIN,
+					Flavour:    flavour,
+				},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "invalid: pin is one of the recent pins",
+			args: args{
+				ctx: ctx,
+				input: dto.PINInput{
+					UserID:     &UserID,
+					PIN:        &PIN,
+					ConfirmPIN: &PIN,
+					Flavour:    flavour,
+				},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad Case - Fail to get pin policy",
+			args: args{
+				ctx: ctx,
+				input: dto.PINInput{
+					UserID:     &UserID,
+					PIN:        &PIN,
+					ConfirmPIN: &PIN,
+					Flavour:    flavour,
+				},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Sad Case - Fail to get pin history",
+			args: args{
+				ctx: ctx,
+				input: dto.PINInput{
+					UserID:     &UserID,
+					PIN:        &PIN,
+					ConfirmPIN: &PIN,
+					Flavour:    flavour,
+				},
+			},
+			want:    false,
+			wantErr: true,
+		},
 		{
 			name: "Sad Case - Empty input",
 			args: args{
@@ -966,8 +1026,10 @@ func TestUseCasesUserImpl_SetUserPIN(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
 
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "invalid: user not found" {
 				fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.User, error) {
@@ -998,6 +1060,25 @@ func TestUseCasesUserImpl_SetUserPIN(t *testing.T) {
 				}
 			}
 
+			if tt.name == "invalid: pin is one of the recent pins" {
+				fakeDB.MockGetUserPINHistoryFn = func(ctx context.Context, userID string, limit int) ([]*domain.UserPIN, error) {
+					salt, hashedPIN := utils.EncryptPIN(PIN, nil)
+					return []*domain.UserPIN{{UserID: userID, HashedPIN: hashedPIN, Salt: salt}}, nil
+				}
+			}
+
+			if tt.name == "Sad Case - Fail to get pin policy" {
+				fakeDB.MockGetOrganisationFn = func(ctx context.Context, id string) (*domain.Organisation, error) {
+					return nil, fmt.Errorf("failed to get organisation")
+				}
+			}
+
+			if tt.name == "Sad Case - Fail to get pin history" {
+				fakeDB.MockGetUserPINHistoryFn = func(ctx context.Context, userID string, limit int) ([]*domain.UserPIN, error) {
+					return nil, fmt.Errorf("failed to get pin history")
+				}
+			}
+
 			if tt.name == "Sad Case - Empty input" {
 				fakeDB.MockSavePinFn = func(ctx context.Context, pin *domain.UserPIN) (bool, error) {
 					return false, fmt.Errorf("empty input")
@@ -1106,8 +1187,10 @@ func TestUseCasesUserImpl_SetNickName(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
 
-			u := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			u := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "Happy case: Successfully set nickname" {
 				fakeDB.MockCheckIfUsernameExistsFn = func(ctx context.Context, username string) (bool, error) {
@@ -1228,8 +1311,10 @@ func TestUseCasesUserImpl_RequestPINReset(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
 
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "Sad Case - Invalid username" {
 				fakeUser.MockRequestPINResetFn = func(ctx context.Context, username string, flavour feedlib.Flavour) (string, error) {
@@ -1305,7 +1390,63 @@ func TestUseCasesUserImpl_ResetPIN(t *testing.T) {
 					Username: gofakeit.Word(),
 					Flavour:  feedlib.FlavourConsumer,
 					OTP:      "111222",
-					PIN:      "1234",
+					PIN:      "4826",
+				},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Happy Case - reset pin of a client without a current organisation",
+			args: args{
+				ctx: context.Background(),
+				input: dto.UserResetPinInput{
+					Username: gofakeit.Word(),
+					Flavour:  feedlib.FlavourConsumer,
+					OTP:      "111222",
+					PIN:      "4826",
+				},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Happy Case - reset pin of a staff without a current organisation",
+			args: args{
+				ctx: context.Background(),
+				input: dto.UserResetPinInput{
+					Username: gofakeit.Word(),
+					Flavour:  feedlib.FlavourPro,
+					OTP:      "111222",
+					PIN:      "4826",
+				},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Happy Case - unable to resolve the organisation of a user without a current organisation",
+			args: args{
+				ctx: context.Background(),
+				input: dto.UserResetPinInput{
+					Username: gofakeit.Word(),
+					Flavour:  feedlib.FlavourConsumer,
+					OTP:      "111222",
+					PIN:      "4826",
+				},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Happy Case - unable to record audit log",
+			args: args{
+				ctx: context.Background(),
+				input: dto.UserResetPinInput{
+					Username: gofakeit.Word(),
+					Flavour:  feedlib.FlavourConsumer,
+					OTP:      "111222",
+					PIN:      "4826",
 				},
 			},
 			want:    true,
@@ -1319,7 +1460,7 @@ func TestUseCasesUserImpl_ResetPIN(t *testing.T) {
 					Username: gofakeit.Word(),
 					Flavour:  "invalid",
 					OTP:      "111222",
-					PIN:      "1234",
+					PIN:      "4826",
 				},
 			},
 			want:    false,
@@ -1339,6 +1480,34 @@ func TestUseCasesUserImpl_ResetPIN(t *testing.T) {
 			want:    false,
 			wantErr: true,
 		},
+		{
+			name: "invalid: simple pin",
+			args: args{
+				ctx: context.Background(),
+				input: dto.UserResetPinInput{
+					Username: gofakeit.Word(),
+					Flavour:  feedlib.FlavourConsumer,
+					OTP:      "111222",
+					PIN:      "1111",
+				},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "invalid: pin is one of the recent pins",
+			args: args{
+				ctx: context.Background(),
+				input: dto.UserResetPinInput{
+					Username: gofakeit.Word(),
+					Flavour:  feedlib.FlavourConsumer,
+					OTP:      "111222",
+					PIN:      "4826",
+				},
+			},
+			want:    false,
+			wantErr: true,
+		},
 		{
 			name: "invalid: invalid pin length",
 			args: args{
@@ -1361,7 +1530,7 @@ func TestUseCasesUserImpl_ResetPIN(t *testing.T) {
 					Username: gofakeit.Word(),
 					Flavour:  feedlib.FlavourConsumer,
 					OTP:      "111222",
-					PIN:      "1234",
+					PIN:      "4826",
 				},
 			},
 			want:    false,
@@ -1375,7 +1544,7 @@ func TestUseCasesUserImpl_ResetPIN(t *testing.T) {
 					Username: gofakeit.Word(),
 					Flavour:  feedlib.FlavourConsumer,
 					OTP:      "111222",
-					PIN:      "1234",
+					PIN:      "4826",
 				},
 			},
 			want:    false,
@@ -1389,7 +1558,7 @@ func TestUseCasesUserImpl_ResetPIN(t *testing.T) {
 					Username: gofakeit.Word(),
 					Flavour:  feedlib.FlavourConsumer,
 					OTP:      "111222",
-					PIN:      "1234",
+					PIN:      "4826",
 				},
 			},
 			want:    false,
@@ -1403,7 +1572,7 @@ func TestUseCasesUserImpl_ResetPIN(t *testing.T) {
 					Username: gofakeit.Word(),
 					Flavour:  feedlib.FlavourConsumer,
 					OTP:      "111222",
-					PIN:      "1234",
+					PIN:      "4826",
 				},
 			},
 			want:    false,
@@ -1436,8 +1605,46 @@ func TestUseCasesUserImpl_ResetPIN(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
+
+			organisationID := uuid.New().String()
+			userWithoutOrganisation := func(ctx context.Context, username string) (*domain.User, error) {
+				id := uuid.New().String()
+				return &domain.User{ID: &id, Username: username}, nil
+			}
+
+			var auditLog *domain.AuditLog
+			fakeDB.MockCreateAuditLogFn = func(ctx context.Context, log *domain.AuditLog) error {
+				auditLog = log
+				return nil
+			}
 
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			if tt.name == "Happy Case - reset pin of a client without a current organisation" {
+				fakeDB.MockGetUserProfileByUsernameFn = userWithoutOrganisation
+				fakeDB.MockGetUserClientProfilesFn = func(ctx context.Context, userID string) ([]*domain.ClientProfile, error) {
+					return []*domain.ClientProfile{{OrganisationID: organisationID}}, nil
+				}
+			}
+			if tt.name == "Happy Case - reset pin of a staff without a current organisation" {
+				fakeDB.MockGetUserProfileByUsernameFn = userWithoutOrganisation
+				fakeDB.MockGetUserStaffProfilesFn = func(ctx context.Context, userID string) ([]*domain.StaffProfile, error) {
+					return []*domain.StaffProfile{{OrganisationID: organisationID}}, nil
+				}
+			}
+			if tt.name == "Happy Case - unable to resolve the organisation of a user without a current organisation" {
+				fakeDB.MockGetUserProfileByUsernameFn = userWithoutOrganisation
+				fakeDB.MockGetUserClientProfilesFn = func(ctx context.Context, userID string) ([]*domain.ClientProfile, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Happy Case - unable to record audit log" {
+				fakeDB.MockCreateAuditLogFn = func(ctx context.Context, log *domain.AuditLog) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
 
 			if tt.name == "Happy Case - Successfully reset pin" {
 				fakeDB.MockGetUserSecurityQuestionsResponsesFn = func(ctx context.Context, userID, flavour string) ([]*domain.SecurityQuestionResponse, error) {
@@ -1486,6 +1693,13 @@ func TestUseCasesUserImpl_ResetPIN(t *testing.T) {
 				}
 			}
 
+			if tt.name == "invalid: pin is one of the recent pins" {
+				fakeDB.MockGetUserPINHistoryFn = func(ctx context.Context, userID string, limit int) ([]*domain.UserPIN, error) {
+					salt, hashedPIN := utils.EncryptPIN(tt.args.input.PIN, nil)
+					return []*domain.UserPIN{{UserID: userID, HashedPIN: hashedPIN, Salt: salt}}, nil
+				}
+			}
+
 			if tt.name == "invalid: failed to invalidate pin" {
 				fakeDB.MockGetUserSecurityQuestionsResponsesFn = func(ctx context.Context, userID, flavour string) ([]*domain.SecurityQuestionResponse, error) {
 					return []*domain.SecurityQuestionResponse{
@@ -1534,6 +1748,12 @@ func TestUseCasesUserImpl_ResetPIN(t *testing.T) {
 			if got != tt.want {
 				t.Errorf("UseCasesUserImpl.ResetPIN() = %v, want %v", got, tt.want)
 			}
+
+			if tt.name == "Happy Case - reset pin of a client without a current organisation" || tt.name == "Happy Case - reset pin of a staff without a current organisation" {
+				if auditLog == nil || auditLog.OrganisationID != organisationID {
+					t.Errorf("expected the PIN reset to be recorded in the user's organisation, got %v", auditLog)
+				}
+			}
 		})
 	}
 }
@@ -1585,7 +1805,9 @@ func TestUseCasesUserImpl_RefreshToken(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "Sad Case - Fail to create firebase custom token" {
 				fakeExtension.MockCreateFirebaseCustomTokenFn = func(ctx context.Context, uid string) (string, error) {
@@ -1701,7 +1923,9 @@ func TestUseCasesUserImpl_VerifyPIN(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "Happy Case - Successfully verify pin" {
 				fakeDB.MockGetUserPINByUserIDFn = func(ctx context.Context, userID string) (*domain.UserPIN, error) {
@@ -1790,7 +2014,9 @@ func TestUseCasesUserImpl_CompleteOnboardingTour(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "Sad case - no userID" {
 				fakeDB.MockCompleteOnboardingTourFn = func(ctx context.Context, userID string, flavour feedlib.Flavour) (bool, error) {
@@ -1997,7 +2223,9 @@ func TestUseCasesUserImpl_RegisterClient(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "Sad case: unable to register client" {
 				fakeDB.MockRegisterClientFn = func(ctx context.Context, payload *domain.ClientRegistrationPayload) (*domain.ClientProfile, error) {
@@ -2117,8 +2345,10 @@ func TestUseCasesUserImpl_GetUserProfile(t *testing.T) {
 	fakeSMS := smsMock.NewSMSServiceMock()
 	fakeTwilio := twilioMock.NewTwilioServiceMock()
 	fakeMatrix := matrixMock.NewMatrixMock()
+	fakeSurveys := surveysMock.NewSurveysMock()
+	fakeNotification := notificationMock.NewServiceNotificationMock()
 
-	us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+	us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 	type args struct {
 		ctx    context.Context
@@ -2203,8 +2433,10 @@ func TestUseCasesUserImpl_GetStaffProfile(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
 
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "sad case: failed to get staff profile" {
 				fakeDB.MockGetStaffProfileFn = func(ctx context.Context, userID, programID string) (*domain.StaffProfile, error) {
@@ -2231,7 +2463,9 @@ func TestUseCasesUserImpl_RegisteredFacilityPatients(t *testing.T) {
 	fakeSMS := smsMock.NewSMSServiceMock()
 	fakeTwilio := twilioMock.NewTwilioServiceMock()
 	fakeMatrix := matrixMock.NewMatrixMock()
-	us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+	fakeSurveys := surveysMock.NewSurveysMock()
+	fakeNotification := notificationMock.NewServiceNotificationMock()
+	us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 	ctx := context.Background()
 	syncTime := time.Now()
@@ -2501,7 +2735,9 @@ func TestUseCasesUserImpl_SearchStaffByStaffNumber(t *testing.T) {
 	fakeSMS := smsMock.NewSMSServiceMock()
 	fakeTwilio := twilioMock.NewTwilioServiceMock()
 	fakeMatrix := matrixMock.NewMatrixMock()
-	us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+	fakeSurveys := surveysMock.NewSurveysMock()
+	fakeNotification := notificationMock.NewServiceNotificationMock()
+	us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 	type args struct {
 		ctx         context.Context
@@ -2605,7 +2841,9 @@ func TestUseCasesUserImpl_SearchClientUser(t *testing.T) {
 	fakeSMS := smsMock.NewSMSServiceMock()
 	fakeTwilio := twilioMock.NewTwilioServiceMock()
 	fakeMatrix := matrixMock.NewMatrixMock()
-	us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+	fakeSurveys := surveysMock.NewSurveysMock()
+	fakeNotification := notificationMock.NewServiceNotificationMock()
+	us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 	type args struct {
 		ctx             context.Context
@@ -2683,6 +2921,24 @@ func TestUseCasesUserImpl_RegisterPushToken(t *testing.T) {
 			want:    true,
 			wantErr: false,
 		},
+		{
+			name: "Happy Case - Successfully register a push token on the current session",
+			args: args{
+				ctx:   context.WithValue(ctx, utils.SessionIDContextKey, uuid.New().String()),
+				token: "valid token",
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Happy Case - Register a push token when the session fails to update",
+			args: args{
+				ctx:   context.WithValue(ctx, utils.SessionIDContextKey, uuid.New().String()),
+				token: "valid token",
+			},
+			want:    true,
+			wantErr: false,
+		},
 		{
 			name: "Sad Case - invalid token length",
 			args: args{
@@ -2722,7 +2978,9 @@ func TestUseCasesUserImpl_RegisterPushToken(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "Sad Case - Fail to get logged in user" {
 				fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
@@ -2736,11 +2994,26 @@ func TestUseCasesUserImpl_RegisterPushToken(t *testing.T) {
 				}
 			}
 
+			sessionPushToken := ""
+			fakeDB.MockUpdateSessionFn = func(ctx context.Context, session *domain.Session, updateData map[string]interface{}) error {
+				sessionPushToken = updateData["push_token"].(string)
+				return nil
+			}
+
+			if tt.name == "Happy Case - Register a push token when the session fails to update" {
+				fakeDB.MockUpdateSessionFn = func(ctx context.Context, session *domain.Session, updateData map[string]interface{}) error {
+					return fmt.Errorf("failed to update session")
+				}
+			}
+
 			got, err := us.RegisterPushToken(tt.args.ctx, tt.args.token)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("UseCasesUserImpl.RegisterPushToken() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if tt.name == "Happy Case - Successfully register a push token on the current session" && sessionPushToken != tt.args.token {
+				t.Errorf("expected the push token to be recorded on the session, got %q", sessionPushToken)
+			}
 			if got != tt.want {
 				t.Errorf("UseCasesUserImpl.RegisterPushToken() = %v, want %v", got, tt.want)
 			}
@@ -2758,7 +3031,9 @@ func TestUseCasesUserImpl_GetProgramClientProfileByIdentifier(t *testing.T) {
 	fakeSMS := smsMock.NewSMSServiceMock()
 	fakeTwilio := twilioMock.NewTwilioServiceMock()
 	fakeMatrix := matrixMock.NewMatrixMock()
-	us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+	fakeSurveys := surveysMock.NewSurveysMock()
+	fakeNotification := notificationMock.NewServiceNotificationMock()
+	us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 	type args struct {
 		ctx       context.Context
@@ -2850,7 +3125,9 @@ func TestUseCasesUserImpl_TransferClientToFacility(t *testing.T) {
 	fakeSMS := smsMock.NewSMSServiceMock()
 	fakeTwilio := twilioMock.NewTwilioServiceMock()
 	fakeMatrix := matrixMock.NewMatrixMock()
-	us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+	fakeSurveys := surveysMock.NewSurveysMock()
+	fakeNotification := notificationMock.NewServiceNotificationMock()
+	us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 	type args struct {
 		ctx        context.Context
@@ -3144,6 +3421,8 @@ func TestUseCasesUserImpl_RegisterStaffProfile(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
 
 			if tt.name == "Sad Case - Unable to check identifier exists" {
 				fakeDB.MockCheckIdentifierExists = func(ctx context.Context, identifierType enums.UserIdentifierType, identifierValue string) (bool, error) {
@@ -3199,7 +3478,7 @@ func TestUseCasesUserImpl_RegisterStaffProfile(t *testing.T) {
 				}
 			}
 
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			_, err := us.RegisterStaffProfile(tt.args.ctx, tt.args.input)
 			if (err != nil) != tt.wantErr {
@@ -3279,6 +3558,22 @@ func TestUseCasesUserImpl_RegisterStaff(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Sad case: unable to get the default staff role",
+			args: args{
+				ctx:   context.Background(),
+				input: *payload,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Sad case: unable to assign the default staff role",
+			args: args{
+				ctx:   context.Background(),
+				input: *payload,
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -3292,6 +3587,8 @@ func TestUseCasesUserImpl_RegisterStaff(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
 
 			if tt.name == "Sad case: unable to get logged in user id" {
 				fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
@@ -3314,8 +3611,18 @@ func TestUseCasesUserImpl_RegisterStaff(t *testing.T) {
 					return false, fmt.Errorf("failed to check whether matrix user is an admin")
 				}
 			}
+			if tt.name == "Sad case: unable to get the default staff role" {
+				fakeDB.MockGetAuthorityRoleByNameFn = func(ctx context.Context, programID string, name string) (*domain.AuthorityRole, error) {
+					return nil, fmt.Errorf("an error occurred")
+				}
+			}
+			if tt.name == "Sad case: unable to assign the default staff role" {
+				fakeDB.MockAssignRolesFn = func(ctx context.Context, userType enums.UsersType, profileID string, roleIDs []string) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
 
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			_, err := us.RegisterStaff(tt.args.ctx, tt.args.input)
 			if (err != nil) != tt.wantErr {
@@ -3326,6 +3633,127 @@ func TestUseCasesUserImpl_RegisterStaff(t *testing.T) {
 	}
 }
 
+func TestUseCasesUserImpl_RegisterStaff_DefaultRole(t *testing.T) {
+	programID := gofakeit.UUID()
+
+	payload := dto.StaffRegistrationInput{
+		Facility:    "1234",
+		StaffName:   gofakeit.BeerName(),
+		Gender:      enumutils.GenderMale,
+		DateOfBirth: scalarutils.Date{Year: 2000, Month: 2, Day: 20},
+		PhoneNumber: interserviceclient.TestUserPhoneNumber,
+		IDNumber:    "123456789",
+		StaffNumber: "123456789",
+		ProgramID:   programID,
+	}
+
+	tests := []struct {
+		name             string
+		register         func(us *user.UseCasesUserImpl) (*dto.StaffRegistrationOutput, error)
+		grantedScopes    []string
+		notGrantedScopes []string
+	}{
+		{
+			name: "Happy case: registered staff is granted the default staff permissions",
+			register: func(us *user.UseCasesUserImpl) (*dto.StaffRegistrationOutput, error) {
+				return us.RegisterStaff(context.Background(), payload)
+			},
+			grantedScopes:    []string{"client.transfer", "client.servicerequest.update", "client.read"},
+			notGrantedScopes: []string{"staff.create", "role.assign", "organisation.delete"},
+		},
+		{
+			name: "Happy case: registered organisation admin is granted the default admin permissions",
+			register: func(us *user.UseCasesUserImpl) (*dto.StaffRegistrationOutput, error) {
+				return us.RegisterOrganisationAdmin(context.Background(), payload)
+			},
+			grantedScopes: []string{"staff.create", "client.transfer", "role.assign"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeDB := pgMock.NewPostgresMock()
+			fakeExtension := extensionMock.NewFakeExtension()
+			fakeOTP := otpMock.NewOTPUseCaseMock()
+			fakeAuthority := authorityMock.NewAuthorityUseCaseMock()
+			fakePubsub := pubsubMock.NewPubsubServiceMock()
+			fakeClinical := clinicalMock.NewClinicalServiceMock()
+			fakeSMS := smsMock.NewSMSServiceMock()
+			fakeTwilio := twilioMock.NewTwilioServiceMock()
+			fakeMatrix := matrixMock.NewMatrixMock()
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+
+			roles := map[string]domain.AuthorityRole{}
+			for _, role := range authorization.DefaultRoles() {
+				role.AuthorityRoleID = gofakeit.UUID()
+				role.ProgramID = programID
+				roles[role.AuthorityRoleID] = role
+			}
+
+			fakeDB.MockGetUserProfileByUserIDFn = func(ctx context.Context, userID string) (*domain.User, error) {
+				return &domain.User{ID: &userID, Username: gofakeit.Username(), CurrentProgramID: programID}, nil
+			}
+			fakeDB.MockGetAuthorityRoleByNameFn = func(ctx context.Context, programID string, name string) (*domain.AuthorityRole, error) {
+				for _, role := range roles {
+					if role.ProgramID == programID && role.Name == name {
+						return &role, nil
+					}
+				}
+				return nil, fmt.Errorf("role %s does not exist", name)
+			}
+
+			var assignedRoleIDs []string
+			fakeDB.MockAssignRolesFn = func(ctx context.Context, userType enums.UsersType, profileID string, roleIDs []string) error {
+				if userType != enums.StaffUser {
+					return fmt.Errorf("unexpected user type %s", userType)
+				}
+				assignedRoleIDs = append(assignedRoleIDs, roleIDs...)
+				return nil
+			}
+			fakeDB.MockGetUserPermissionsFn = func(ctx context.Context, userID string, programID string) ([]*domain.AuthorityPermission, error) {
+				permissions := []*domain.AuthorityPermission{}
+				for _, roleID := range assignedRoleIDs {
+					for _, permission := range roles[roleID].Permissions {
+						permission := permission
+						permission.Active = true
+						permissions = append(permissions, &permission)
+					}
+				}
+				return permissions, nil
+			}
+
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
+
+			if _, err := tt.register(us); err != nil {
+				t.Errorf("failed to register staff: %v", err)
+				return
+			}
+
+			checker := authority.NewUsecaseAuthority(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeNotification)
+			for _, scope := range tt.grantedScopes {
+				granted, err := checker.CheckUserPermission(context.Background(), scope)
+				if err != nil {
+					t.Errorf("UsecaseAuthorityImpl.CheckUserPermission() error = %v", err)
+					return
+				}
+				if !granted {
+					t.Errorf("expected the registered staff to be granted %s", scope)
+				}
+			}
+			for _, scope := range tt.notGrantedScopes {
+				granted, err := checker.CheckUserPermission(context.Background(), scope)
+				if err != nil {
+					t.Errorf("UsecaseAuthorityImpl.CheckUserPermission() error = %v", err)
+					return
+				}
+				if granted {
+					t.Errorf("expected the registered staff not to be granted %s", scope)
+				}
+			}
+		})
+	}
+}
+
 func TestUseCasesUserImpl_RegisterOrganisationAdmin(t *testing.T) {
 	ctx := context.Background()
 
@@ -3423,6 +3851,8 @@ func TestUseCasesUserImpl_RegisterOrganisationAdmin(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
 
 			if tt.name == "Sad case: unable to get staff profile by id" {
 				fakeDB.MockGetProgramByIDFn = func(ctx context.Context, programID string) (*domain.Program, error) {
@@ -3454,7 +3884,7 @@ func TestUseCasesUserImpl_RegisterOrganisationAdmin(t *testing.T) {
 				}
 			}
 
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			_, err := us.RegisterOrganisationAdmin(tt.args.ctx, tt.args.input)
 			if (err != nil) != tt.wantErr {
@@ -3542,7 +3972,9 @@ func TestUseCasesUserImpl_SetStaffDefaultFacility(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "Sad case: failed to get staff profile by staff id" {
 				fakeDB.MockGetStaffProfileByStaffIDFn = func(ctx context.Context, staffID string) (*domain.StaffProfile, error) {
@@ -3662,7 +4094,9 @@ func TestUseCasesUserImpl_SetClientDefaultFacility(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "Sad case: failed to get client profile by client" {
 				fakeDB.MockGetClientProfileByClientIDFn = func(ctx context.Context, clientID string) (*domain.ClientProfile, error) {
@@ -3807,7 +4241,9 @@ func TestUseCasesUserImpl_AddFacilitiesToStaffProfile(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "Sad case: unable to retrieve facility" {
 				fakeDB.MockRetrieveFacilityFn = func(ctx context.Context, id *string, isActive bool) (*domain.Facility, error) {
@@ -3934,7 +4370,9 @@ func TestUseCasesUserImpl_AddFacilitiesToClientProfile(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "Sad case: unable to retrieve facility" {
 				fakeDB.MockRetrieveFacilityFn = func(ctx context.Context, id *string, isActive bool) (*domain.Facility, error) {
@@ -4237,7 +4675,9 @@ func TestUseCasesUserImpl_RegisterCaregiver(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "sad case: username check error" {
 				fakeDB.MockCheckIfUsernameExistsFn = func(ctx context.Context, username string) (bool, error) {
@@ -4360,7 +4800,9 @@ func TestUseCasesUserImpl_RegisterClientAsCaregiver(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "sad case: get client error" {
 				fakeDB.MockGetClientProfileByClientIDFn = func(ctx context.Context, clientID string) (*domain.ClientProfile, error) {
@@ -4450,7 +4892,9 @@ func TestUseCasesUserImpl_SearchCaregiverUser(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "Sad case - failed to get logged in user id" {
 				fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
@@ -4559,7 +5003,9 @@ func TestUseCasesUserImpl_RemoveFacilitiesFromClientProfile(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "Sad Case: failed to get client profile by client id" {
 				fakeDB.MockGetClientProfileByClientIDFn = func(ctx context.Context, clientID string) (*domain.ClientProfile, error) {
@@ -4689,7 +5135,9 @@ func TestUseCasesUserImpl_AssignCaregiver(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "sad case: unable to add caregiver to client" {
 				fakeDB.MockAddCaregiverToClientFn = func(ctx context.Context, clientCaregiver *domain.CaregiverClient) error {
@@ -4799,7 +5247,9 @@ func TestUseCasesUserImpl_RemoveFacilitiesFromStaffProfile(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "Sad Case: failed to get staff profile by staff id" {
 				fakeDB.MockGetStaffProfileByStaffIDFn = func(ctx context.Context, staffID string) (*domain.StaffProfile, error) {
@@ -4896,7 +5346,9 @@ func TestUseCasesUserImpl_GetCaregiverManagedClients(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "Sad case: failed to get managed clients" {
 				fakeDB.MockGetCaregiverManagedClientsFn = func(ctx context.Context, userID string, pagination *domain.Pagination) ([]*domain.ManagedClient, *domain.Pagination, error) {
@@ -4970,7 +5422,9 @@ func TestUseCasesUserImpl_ListClientsCaregivers(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "Sad Case: unable to list clients caregivers" {
 				fakeDB.MockListClientsCaregiversFn = func(ctx context.Context, clientID string, pagination *domain.Pagination) (*domain.ClientCaregivers, *domain.Pagination, error) {
@@ -5037,7 +5491,9 @@ func TestUseCasesUserImpl_ConsentToAClientCaregiver(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "Sad Case: client unable to consent" {
 				fakeDB.MockUpdateCaregiverClientFn = func(ctx context.Context, caregiverClient *domain.CaregiverClient, updateData map[string]interface{}) error {
@@ -5103,7 +5559,9 @@ func TestUseCasesUserImpl_ConsentToManagingClient(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "Sad Case: unable to consent to managing client" {
 				fakeDB.MockUpdateCaregiverClientFn = func(ctx context.Context, caregiverClient *domain.CaregiverClient, updateData map[string]interface{}) error {
@@ -5200,7 +5658,9 @@ func TestUseCasesUserImpl_FetchContactOrganisations(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "sad case: fail to find contacts" {
 				fakeDB.MockFindContactsFn = func(ctx context.Context, contactType, contactValue string) ([]*domain.Contact, error) {
@@ -5344,7 +5804,9 @@ func TestUseCasesUserImpl_GetStaffFacilities(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "Sad case: unable to get staff facilities" {
 				fakeDB.MockGetStaffFacilitiesFn = func(ctx context.Context, input dto.StaffFacilityInput, pagination *domain.Pagination) ([]*domain.Facility, *domain.Pagination, error) {
@@ -5639,7 +6101,9 @@ func TestUseCasesUserImpl_RegisterExistingUserAsClient(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "sad case: failed to get client profiles" {
 				fakeDB.MockGetUserClientProfilesFn = func(ctx context.Context, userID string) ([]*domain.ClientProfile, error) {
@@ -5787,7 +6251,9 @@ func TestUseCasesUserImpl_GetClientFacilities(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "Sad case: unable to get client facilities" {
 				fakeDB.MockGetClientFacilitiesFn = func(ctx context.Context, input dto.ClientFacilityInput, pagination *domain.Pagination) ([]*domain.Facility, *domain.Pagination, error) {
@@ -5913,7 +6379,9 @@ func TestUseCasesUserImpl_SetCaregiverCurrentClient(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "sad case: failed to get logged in user" {
 				fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
@@ -6161,7 +6629,9 @@ func TestUseCasesUserImpl_RegisterExistingUserAsStaff(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "Sad case: unable to check if staff is already registered in program" {
 				fakeDB.MockCheckStaffExistsInProgramFn = func(ctx context.Context, userID string, programID string) (bool, error) {
@@ -6302,7 +6772,9 @@ func TestUseCasesUserImpl_SetCaregiverCurrentFacility(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "Sad case: unable to get logged in user id" {
 				fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
@@ -6406,7 +6878,9 @@ func TestUseCasesUserImpl_RegisterExistingUserAsCaregiver(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "sad case: unable to get logged in user id" {
 				fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
@@ -6539,7 +7013,7 @@ func TestUseCasesUserImpl_UpdateUserProfile(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "sad case: unable to update client phone number",
+			name: "sad case: client phone number belongs to another user",
 			args: args{
 				ctx:         context.Background(),
 				phoneNumber: &phoneNumber,
@@ -6550,7 +7024,7 @@ func TestUseCasesUserImpl_UpdateUserProfile(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "sad case: unable to verify phone number",
+			name: "sad case: unable to send otp to new client phone number",
 			args: args{
 				ctx:         context.Background(),
 				phoneNumber: &phoneNumber,
@@ -6561,7 +7035,7 @@ func TestUseCasesUserImpl_UpdateUserProfile(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "sad case: unable to update user",
+			name: "sad case: unable to save client phone change request",
 			args: args{
 				ctx:         context.Background(),
 				phoneNumber: &phoneNumber,
@@ -6583,7 +7057,7 @@ func TestUseCasesUserImpl_UpdateUserProfile(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "sad case: unable to update staff phone number",
+			name: "sad case: staff phone number belongs to another user",
 			args: args{
 				ctx:         context.Background(),
 				phoneNumber: &phoneNumber,
@@ -6594,7 +7068,7 @@ func TestUseCasesUserImpl_UpdateUserProfile(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "sad case: unable to verify staff phone number",
+			name: "sad case: unable to send otp to new staff phone number",
 			args: args{
 				ctx:         context.Background(),
 				phoneNumber: &phoneNumber,
@@ -6605,7 +7079,7 @@ func TestUseCasesUserImpl_UpdateUserProfile(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "sad case: unable to update user - pro",
+			name: "sad case: unable to save staff phone change request",
 			args: args{
 				ctx:         context.Background(),
 				phoneNumber: &phoneNumber,
@@ -6661,7 +7135,13 @@ func TestUseCasesUserImpl_UpdateUserProfile(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
+
+			fakeDB.MockCheckIfPhoneNumberExistsFn = func(ctx context.Context, phone string, isOptedIn bool, flavour feedlib.Flavour) (bool, error) {
+				return false, nil
+			}
 
 			if tt.name == "sad case: unable to get logged in user" {
 				fakeExtension.MockGetLoggedInUserUIDFn = func(ctx context.Context) (string, error) {
@@ -6704,19 +7184,19 @@ func TestUseCasesUserImpl_UpdateUserProfile(t *testing.T) {
 					return fmt.Errorf("an error occurred")
 				}
 			}
-			if tt.name == "sad case: unable to update client phone number" {
-				fakeDB.MockUpdateUserContactFn = func(ctx context.Context, contact *domain.Contact, updateData map[string]interface{}) error {
-					return fmt.Errorf("an error occurred")
+			if tt.name == "sad case: client phone number belongs to another user" {
+				fakeDB.MockCheckIfPhoneNumberExistsFn = func(ctx context.Context, phone string, isOptedIn bool, flavour feedlib.Flavour) (bool, error) {
+					return true, nil
 				}
 			}
-			if tt.name == "sad case: unable to verify phone number" {
-				fakeOTP.MockVerifyPhoneNumberFn = func(ctx context.Context, phone string, flavour feedlib.Flavour) (*profileutils.OtpResponse, error) {
+			if tt.name == "sad case: unable to send otp to new client phone number" {
+				fakeOTP.MockSendPhoneChangeOTPFn = func(ctx context.Context, username string, phoneNumber string, flavour feedlib.Flavour) (*domain.OTPResponse, error) {
 					return nil, fmt.Errorf("an error occurred")
 				}
 			}
-			if tt.name == "sad case: unable to update user" {
-				fakeDB.MockUpdateUserFn = func(ctx context.Context, user *domain.User, updateData map[string]interface{}) error {
-					return fmt.Errorf("an error occurred")
+			if tt.name == "sad case: unable to save client phone change request" {
+				fakeDB.MockCreatePhoneChangeRequestFn = func(ctx context.Context, request *domain.PhoneChangeRequest) (*domain.PhoneChangeRequest, error) {
+					return nil, fmt.Errorf("an error occurred")
 				}
 			}
 			if tt.name == "sad case: unable to get user profile - pro" {
@@ -6732,19 +7212,19 @@ func TestUseCasesUserImpl_UpdateUserProfile(t *testing.T) {
 					return nil, fmt.Errorf("an error occurred")
 				}
 			}
-			if tt.name == "sad case: unable to update staff phone number" {
-				fakeDB.MockUpdateUserContactFn = func(ctx context.Context, contact *domain.Contact, updateData map[string]interface{}) error {
-					return fmt.Errorf("an error occurred")
+			if tt.name == "sad case: staff phone number belongs to another user" {
+				fakeDB.MockCheckIfPhoneNumberExistsFn = func(ctx context.Context, phone string, isOptedIn bool, flavour feedlib.Flavour) (bool, error) {
+					return true, nil
 				}
 			}
-			if tt.name == "sad case: unable to verify staff phone number" {
-				fakeOTP.MockVerifyPhoneNumberFn = func(ctx context.Context, phone string, flavour feedlib.Flavour) (*profileutils.OtpResponse, error) {
+			if tt.name == "sad case: unable to send otp to new staff phone number" {
+				fakeOTP.MockSendPhoneChangeOTPFn = func(ctx context.Context, username string, phoneNumber string, flavour feedlib.Flavour) (*domain.OTPResponse, error) {
 					return nil, fmt.Errorf("an error occurred")
 				}
 			}
-			if tt.name == "sad case: unable to update user - pro" {
-				fakeDB.MockUpdateUserFn = func(ctx context.Context, user *domain.User, updateData map[string]interface{}) error {
-					return fmt.Errorf("an error occurred")
+			if tt.name == "sad case: unable to save staff phone change request" {
+				fakeDB.MockCreatePhoneChangeRequestFn = func(ctx context.Context, request *domain.PhoneChangeRequest) (*domain.PhoneChangeRequest, error) {
+					return nil, fmt.Errorf("an error occurred")
 				}
 			}
 			if tt.name == "sad case: unable to update username - pro" {
@@ -6904,8 +7384,10 @@ func TestUseCasesUserImpl_CreateSuperUser(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
 			fakeUser := mock.NewUserUseCaseMock()
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "Happy Case - Register Staff" {
 				fakeDB.MockCheckIfSuperUserExistsFn = func(ctx context.Context) (bool, error) {
@@ -7032,7 +7514,9 @@ func TestUseCasesUserImpl_CheckIdentifierExists(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "Sad case: unable to check identifier exists" {
 				fakeDB.MockCheckIdentifierExists = func(ctx context.Context, identifierType enums.UserIdentifierType, identifierValue string) (bool, error) {
@@ -7101,7 +7585,9 @@ func TestUseCasesUserImpl_CheckIfPhoneExists(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "Sad case: failed to check if phone exists" {
 				fakeDB.MockCheckPhoneExistsFn = func(ctx context.Context, phone string) (bool, error) {
@@ -7142,6 +7628,16 @@ func TestUseCasesUserImpl_UpdateOrganisationAdminPermission(t *testing.T) {
 			want:    true,
 			wantErr: false,
 		},
+		{
+			name: "Happy case: failure to record audit log does not fail the update",
+			args: args{
+				ctx:                 context.Background(),
+				staffID:             gofakeit.UUID(),
+				isOrganisationAdmin: true,
+			},
+			want:    true,
+			wantErr: false,
+		},
 		{
 			name: "Sad case: failed get staff profile",
 			args: args{
@@ -7174,7 +7670,9 @@ func TestUseCasesUserImpl_UpdateOrganisationAdminPermission(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "Sad case: failed get staff profile" {
 				fakeDB.MockGetStaffProfileByStaffIDFn = func(ctx context.Context, staffID string) (*domain.StaffProfile, error) {
@@ -7182,6 +7680,12 @@ func TestUseCasesUserImpl_UpdateOrganisationAdminPermission(t *testing.T) {
 				}
 			}
 
+			if tt.name == "Happy case: failure to record audit log does not fail the update" {
+				fakeDB.MockCreateAuditLogFn = func(ctx context.Context, auditLog *domain.AuditLog) error {
+					return fmt.Errorf("an error occurred")
+				}
+			}
+
 			if tt.name == "Sad case: failed update staff profile" {
 				fakeDB.MockUpdateStaffFn = func(ctx context.Context, staff *domain.StaffProfile, updates map[string]interface{}) error {
 					return fmt.Errorf("an error occurred")
@@ -7265,7 +7769,9 @@ func TestUseCasesUserImpl_NotifyNewFacilityAdded(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "Sad case: unable to send SMS" {
 				fakeSMS.MockSendSMSFn = func(ctx context.Context, message string, recipients []string) (*silcomms.BulkSMSResponse, error) {
@@ -7302,16 +7808,16 @@ func TestUseCasesUserImpl_DeleteClientProfile(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "Sad Case - unable to get client profile",
+			name: "Happy Case - client already marked for erasure",
 			args: args{
 				ctx:      ctx,
 				clientID: gofakeit.UUID(),
 			},
-			want:    false,
-			wantErr: true,
+			want:    true,
+			wantErr: false,
 		},
 		{
-			name: "Sad Case - unable to get client profiles",
+			name: "Sad Case - unable to get client profile",
 			args: args{
 				ctx:      ctx,
 				clientID: gofakeit.UUID(),
@@ -7320,7 +7826,7 @@ func TestUseCasesUserImpl_DeleteClientProfile(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "Sad Case - unable to get staff profiles",
+			name: "Sad Case - unable to get client profiles",
 			args: args{
 				ctx:      ctx,
 				clientID: gofakeit.UUID(),
@@ -7329,7 +7835,7 @@ func TestUseCasesUserImpl_DeleteClientProfile(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "Sad Case - unable to get contact by user ID",
+			name: "Sad Case - unable to get staff profiles",
 			args: args{
 				ctx:      ctx,
 				clientID: gofakeit.UUID(),
@@ -7338,25 +7844,16 @@ func TestUseCasesUserImpl_DeleteClientProfile(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "Sad Case - unable to delete FHIR patient profile",
-			args: args{
-				ctx:      ctx,
-				clientID: gofakeit.UUID(),
-			},
-			want:    true,
-			wantErr: false,
-		},
-		{
-			name: "Sad Case - unable to delete cms client via pub sub",
+			name: "Sad Case - unable to search caregiver profiles",
 			args: args{
 				ctx:      ctx,
 				clientID: gofakeit.UUID(),
 			},
-			want:    true,
-			wantErr: false,
+			want:    false,
+			wantErr: true,
 		},
 		{
-			name: "Sad Case - unable to deactivate matrix user",
+			name: "Sad Case - unable to mark client for erasure",
 			args: args{
 				ctx:      ctx,
 				clientID: gofakeit.UUID(),
@@ -7376,23 +7873,25 @@ func TestUseCasesUserImpl_DeleteClientProfile(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
-			if tt.name == "Happy Case - Successfully delete client" {
-				fakeExtension.MockMakeRequestFn = func(ctx context.Context, method string, path string, body interface{}) (*http.Response, error) {
-					input := dto.BasicUserInput{
-						Username: gofakeit.Word(),
-					}
-
-					payload, err := json.Marshal(input)
-					if err != nil {
-						t.Errorf("unable to marshal test item: %s", err)
-					}
+			var markedForErasure bool
+			fakeDB.MockMarkClientForErasureFn = func(ctx context.Context, clientID string, userID *string, requestedAt time.Time) error {
+				markedForErasure = true
+				return nil
+			}
 
-					return &http.Response{
-						StatusCode: http.StatusOK,
-						Status:     "OK",
-						Body:       io.NopCloser(bytes.NewBuffer(payload)),
+			if tt.name == "Happy Case - client already marked for erasure" {
+				fakeDB.MockGetClientProfileByClientIDFn = func(ctx context.Context, clientID string) (*domain.ClientProfile, error) {
+					userID := gofakeit.UUID()
+					erasureRequestedAt := time.Now().AddDate(0, 0, -1)
+					return &domain.ClientProfile{
+						ID:                 &clientID,
+						UserID:             userID,
+						User:               &domain.User{ID: &userID},
+						ErasureRequestedAt: &erasureRequestedAt,
 					}, nil
 				}
 			}
@@ -7415,42 +7914,15 @@ func TestUseCasesUserImpl_DeleteClientProfile(t *testing.T) {
 				}
 			}
 
-			if tt.name == "Sad Case - unable to get contact by user ID" {
-				fakeDB.MockGetContactByUserIDFn = func(ctx context.Context, userID *string, contactType string) (*domain.Contact, error) {
+			if tt.name == "Sad Case - unable to search caregiver profiles" {
+				fakeDB.MockSearchCaregiverUserFn = func(ctx context.Context, searchParameter string) ([]*domain.CaregiverProfile, error) {
 					return nil, errors.New("an error occurred")
 				}
 			}
 
-			if tt.name == "Sad Case - unable to delete FHIR patient profile" {
-				fakeClinical.MockDeleteFHIRPatientByPhoneFn = func(ctx context.Context, phoneNumber string) error {
-					return fmt.Errorf("failed to delete FHIR patient profile")
-				}
-			}
-			if tt.name == "Sad Case - unable to delete cms client via pub sub" {
-				fakePubsub.MockNotifyDeleteCMSClientFn = func(ctx context.Context, user *dto.DeleteCMSUserPayload) error {
-					return fmt.Errorf("failed to delete cms client")
-				}
-			}
-
-			if tt.name == "Sad Case - unable to deactivate matrix user" {
-				fakeDB.MockGetUserClientProfilesFn = func(ctx context.Context, userID string) ([]*domain.ClientProfile, error) {
-					id := gofakeit.UUID()
-					return []*domain.ClientProfile{{
-						ID:     &id,
-						UserID: id,
-					}}, nil
-				}
-
-				fakeDB.MockGetUserStaffProfilesFn = func(ctx context.Context, userID string) ([]*domain.StaffProfile, error) {
-					return []*domain.StaffProfile{}, nil
-				}
-
-				fakeDB.MockSearchCaregiverUserFn = func(ctx context.Context, searchParameter string) ([]*domain.CaregiverProfile, error) {
-					return []*domain.CaregiverProfile{}, nil
-				}
-
-				fakeMatrix.MockDeactivateUserFn = func(ctx context.Context, userID string, auth *domain.MatrixAuth) error {
-					return fmt.Errorf("failed to deactivate matrix user")
+			if tt.name == "Sad Case - unable to mark client for erasure" {
+				fakeDB.MockMarkClientForErasureFn = func(ctx context.Context, clientID string, userID *string, requestedAt time.Time) error {
+					return errors.New("an error occurred")
 				}
 			}
 
@@ -7462,6 +7934,13 @@ func TestUseCasesUserImpl_DeleteClientProfile(t *testing.T) {
 			if got != tt.want {
 				t.Errorf("UseCasesUserImpl.DeleteClientProfile() = %v, want %v", got, tt.want)
 			}
+
+			if tt.name == "Happy Case - Successfully delete client" && !markedForErasure {
+				t.Errorf("UseCasesUserImpl.DeleteClientProfile() expected the client to be marked for erasure")
+			}
+			if tt.name == "Happy Case - client already marked for erasure" && markedForErasure {
+				t.Errorf("UseCasesUserImpl.DeleteClientProfile() expected a client already marked for erasure not to be marked again")
+			}
 		})
 	}
 }
@@ -7514,7 +7993,9 @@ func TestUseCasesUserImpl_ClientSignUp(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "Sad case: client sign up unable to sign up" {
 				fakeDB.MockCheckIfUsernameExistsFn = func(ctx context.Context, username string) (bool, error) {
@@ -7988,7 +8469,9 @@ func TestUseCasesUserImpl_Register(t *testing.T) {
 			fakeSMS := smsMock.NewSMSServiceMock()
 			fakeTwilio := twilioMock.NewTwilioServiceMock()
 			fakeMatrix := matrixMock.NewMatrixMock()
-			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix)
+			fakeSurveys := surveysMock.NewSurveysMock()
+			fakeNotification := notificationMock.NewServiceNotificationMock()
+			us := user.NewUseCasesUserImpl(fakeDB, fakeDB, fakeDB, fakeDB, fakeExtension, fakeOTP, fakeAuthority, fakePubsub, fakeClinical, fakeSMS, fakeTwilio, fakeMatrix, fakeNotification, fakeSurveys)
 
 			if tt.name == "Sad case: self onboarded: unable to check facility exist by identifier" {
 				fakeDB.MockCheckFacilityExistsByIdentifier = func(ctx context.Context, identifier *dto.FacilityIdentifierInput) (bool, error) {
@@ -92,7 +92,7 @@ func ProviderUseCases() (*usecases.MyCareHub, error) {
 	twilioMessageObj := twilioClient.Messages
 	twilioService := serviceTwilio.NewServiceTwilio(twilioMessageObj)
 
-	otpUseCase := otp.NewOTPUseCase(db, db, externalExt, smsService, twilioService)
+	otpUseCase := otp.NewOTPUseCase(db, db, db, externalExt, smsService, twilioService)
 
 	matrixClient := matrix.ServiceImpl{
 		BaseURL: matrixBaseURL,
@@ -117,18 +117,23 @@ func ProviderUseCases() (*usecases.MyCareHub, error) {
 	// Initialize user usecase
 	notificationUseCase := notification.NewNotificationUseCaseImpl(fcmService, db, db, db, externalExt)
 
-	authorityUseCase := authority.NewUsecaseAuthority(db, db, externalExt, notificationUseCase)
+	authorityUseCase := authority.NewUsecaseAuthority(db, db, db, db, externalExt, notificationUseCase)
 
 	clinicalClient := externalExtension.NewInterServiceClient(clinicalDepsName, externalExt)
 	clinicalService := clinical.NewServiceClinical(clinicalClient)
 
 	oauthUsecase := oauth.NewUseCasesOauthImplementation(db, db, db, db)
 
-	userUsecase := user.NewUseCasesUserImpl(db, db, db, db, externalExt, otpUseCase, authorityUseCase, pubSub, clinicalService, smsService, twilioService, &matrixClient)
+	surveysClient := surveyInstance.ODKClient{
+		BaseURL:    surveysBaseURL,
+		HTTPClient: &http.Client{},
+	}
 
-	termsUsecase := terms.NewUseCasesTermsOfService(db, db, db)
+	survey := surveyInstance.NewSurveysImpl(surveysClient)
 
-	securityQuestionsUsecase := securityquestions.NewSecurityQuestionsUsecase(db, db, db, externalExt)
+	userUsecase := user.NewUseCasesUserImpl(db, db, db, db, externalExt, otpUseCase, authorityUseCase, pubSub, clinicalService, smsService, twilioService, &matrixClient, notificationUseCase, survey)
+
+	termsUsecase := terms.NewUseCasesTermsOfService(db, db, db)
 
 	contentUseCase := content.NewUseCasesContentImplementation(db, db, externalExt)
 
@@ -138,7 +143,9 @@ func ProviderUseCases() (*usecases.MyCareHub, error) {
 
 	feedbackUsecase := feedback.NewUsecaseFeedback(db, db, mailService)
 
-	serviceRequestUseCase := servicerequest.NewUseCaseServiceRequestImpl(db, db, db, externalExt, userUsecase, notificationUseCase, smsService, healthCRM)
+	serviceRequestUseCase := servicerequest.NewUseCaseServiceRequestImpl(db, db, db, externalExt, userUsecase, notificationUseCase, smsService, healthCRM, mailService)
+
+	securityQuestionsUsecase := securityquestions.NewSecurityQuestionsUsecase(db, db, db, externalExt, serviceRequestUseCase, notificationUseCase)
 
 	facilityUseCase := facility.NewFacilityUsecase(db, db, db, db, pubSub, externalExt, healthCRM, serviceRequestUseCase)
 
@@ -146,19 +153,13 @@ func ProviderUseCases() (*usecases.MyCareHub, error) {
 
 	healthDiaryUseCase := healthdiary.NewUseCaseHealthDiaryImpl(db, db, db, serviceRequestUseCase)
 
-	surveysClient := surveyInstance.ODKClient{
-		BaseURL:    surveysBaseURL,
-		HTTPClient: &http.Client{},
-	}
-
-	survey := surveyInstance.NewSurveysImpl(surveysClient)
 	surveysUsecase := surveys.NewUsecaseSurveys(survey, db, db, db, notificationUseCase, serviceRequestUseCase, externalExt)
 
 	metricsUsecase := metrics.NewUsecaseMetricsImpl(db)
 	questionnaireUsecase := questionnaires.NewUseCaseQuestionnaire(db, db, db, db, externalExt)
 	programsUsecase := programs.NewUsecasePrograms(db, db, db, externalExt, pubSub, matrixSvc)
 
-	organisationUsecase := organisation.NewUseCaseOrganisationImpl(db, db, db, externalExt, pubSub)
+	organisationUsecase := organisation.NewUseCaseOrganisationImpl(db, db, db, db, externalExt, pubSub)
 
 	communityUsecase := communities.NewUseCaseCommunitiesImpl(db, db, externalExt, matrixSvc, notificationUseCase)
 